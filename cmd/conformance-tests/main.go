@@ -104,6 +104,9 @@ type Opts struct {
 	kubermaticClient             *apiclient.KubermaticAPI
 	kubermaticAuthenticator      runtime.ClientAuthInfoWriter
 	scenarioOptions              string
+	scenarioSteps                sets.String
+	datacenter                   string
+	upgradeVersion               *kubermativsemver.Semver
 	pushgatewayEndpoint          string
 
 	secrets secrets
@@ -157,12 +160,47 @@ type secrets struct {
 	kubermaticAuthenticator runtime.ClientAuthInfoWriter
 }
 
+// fromEnvironment fills all credentials that were not given as flags from
+// environment variables, so that the same invocation works for every provider.
+func (s *secrets) fromEnvironment() {
+	credentials := map[string]*string{
+		"AWS_ACCESS_KEY_ID":         &s.AWS.AccessKeyID,
+		"AWS_SECRET_ACCESS_KEY":     &s.AWS.SecretAccessKey,
+		"AZURE_CLIENT_ID":           &s.Azure.ClientID,
+		"AZURE_CLIENT_SECRET":       &s.Azure.ClientSecret,
+		"AZURE_TENANT_ID":           &s.Azure.TenantID,
+		"AZURE_SUBSCRIPTION_ID":     &s.Azure.SubscriptionID,
+		"DO_TOKEN":                  &s.Digitalocean.Token,
+		"HZ_TOKEN":                  &s.Hetzner.Token,
+		"OS_DOMAIN":                 &s.OpenStack.Domain,
+		"OS_TENANT_NAME":            &s.OpenStack.Tenant,
+		"OS_USERNAME":               &s.OpenStack.Username,
+		"OS_PASSWORD":               &s.OpenStack.Password,
+		"VSPHERE_USERNAME":          &s.VSphere.Username,
+		"VSPHERE_PASSWORD":          &s.VSphere.Password,
+		"PACKET_API_KEY":            &s.Packet.APIKey,
+		"PACKET_PROJECT_ID":         &s.Packet.ProjectID,
+		"GOOGLE_SERVICE_ACCOUNT":    &s.GCP.ServiceAccount,
+		"KUBEVIRT_KUBECONFIG":       &s.Kubevirt.Kubeconfig,
+		"ALIBABA_ACCESS_KEY_ID":     &s.Alibaba.AccessKeyID,
+		"ALIBABA_ACCESS_KEY_SECRET": &s.Alibaba.AccessKeySecret,
+	}
+
+	for env, value := range credentials {
+		if *value == "" {
+			*value = os.Getenv(env)
+		}
+	}
+}
+
 var (
 	providers             string
 	pubKeyPath            string
 	sversions             string
 	sdistributions        string
 	sexcludeDistributions string
+	sscenarioSteps        string
+	supgradeVersion       string
 )
 
 //nolint:gocritic,exitAfterDefer
@@ -218,6 +256,9 @@ func main() {
 	flag.BoolVar(&opts.pspEnabled, "enable-psp", false, "When set, enables the Pod Security Policy plugin in the user cluster")
 	flag.StringVar(&opts.dexHelmValuesFile, "dex-helm-values-file", "", "Helm values.yaml of the OAuth (Dex) chart to read and configure a matching client for. Only needed if -create-oidc-token is enabled.")
 	flag.StringVar(&opts.scenarioOptions, "scenario-options", "", "Additional options to be passed to scenarios, e.g. to configure specific features to be tested.")
+	flag.StringVar(&sscenarioSteps, "scenario-steps", "", fmt.Sprintf("a comma-separated list of optional steps to run for every scenario after the cluster has been tested, possible values are %v", allScenarioSteps))
+	flag.StringVar(&supgradeVersion, "upgrade-version", "", "Kubernetes version to upgrade the control plane to, required for the upgrade step")
+	flag.StringVar(&opts.datacenter, "datacenter", "", "name of the datacenter to create all clusters in, overrides the default datacenter of each scenario and selects its provider")
 	flag.StringVar(&opts.pushgatewayEndpoint, "pushgateway-endpoint", "", "host:port of a Prometheus Pushgateway to send runtime metrics to")
 
 	// cloud provider credentials
@@ -247,6 +288,8 @@ func main() {
 
 	flag.Parse()
 
+	opts.secrets.fromEnvironment()
+
 	rawLog := kubermaticlog.New(logOpts.Debug, logOpts.Format)
 	if opts.workerName != "" {
 		rawLog = rawLog.Named(opts.workerName)
@@ -287,6 +330,21 @@ func main() {
 	}
 	log.Infow("Enabled versions", "versions", opts.versions)
 
+	opts.scenarioSteps, err = parseScenarioSteps(sscenarioSteps)
+	if err != nil {
+		log.Fatalw("Failed to parse scenario steps", zap.Error(err))
+	}
+	log.Infow("Enabled optional scenario steps", "steps", opts.scenarioSteps.List())
+
+	if supgradeVersion != "" {
+		opts.upgradeVersion, err = kubermativsemver.NewSemver(supgradeVersion)
+		if err != nil {
+			log.Fatalw("Failed to parse upgrade version", zap.Error(err))
+		}
+	} else if opts.scenarioSteps.Has(string(scenarioStepUpgrade)) {
+		log.Fatal("-upgrade-version must be set when the upgrade step is enabled")
+	}
+
 	kubermaticClient, err := utils.NewKubermaticClient(opts.kubermaticEndpoint)
 	if err != nil {
 		log.Fatalf("Failed to create Kubermatic API client: %v", err)
@@ -357,6 +415,11 @@ func main() {
 
 	log.Infow("Using project", "project", opts.kubermaticProjectID)
 
+	providersFlagSet := false
+	flag.Visit(func(f *flag.Flag) {
+		providersFlagSet = providersFlagSet || f.Name == "providers"
+	})
+
 	for _, s := range strings.Split(providers, ",") {
 		opts.providers.Insert(strings.ToLower(strings.TrimSpace(s)))
 	}
@@ -416,6 +479,27 @@ func main() {
 		log.Fatalw("Failed to get seed", zap.Error(err))
 	}
 
+	// the provider of a given datacenter is known, so that the scenarios can be
+	// run against any configured datacenter without selecting the provider
+	if opts.datacenter != "" {
+		datacenter, ok := opts.seed.Spec.Datacenters[opts.datacenter]
+		if !ok {
+			log.Fatalw("Datacenter does not exist in the seed", "datacenter", opts.datacenter, "seed", opts.seed.Name)
+		}
+
+		providerName, err := provider.DatacenterCloudProviderName(&datacenter.Spec)
+		if err != nil {
+			log.Fatalw("Failed to determine the provider of the datacenter", "datacenter", opts.datacenter, zap.Error(err))
+		}
+
+		if providersFlagSet && !opts.providers.Equal(sets.NewString(providerName)) {
+			log.Fatalw("-providers does not match the provider of the datacenter", "datacenter", opts.datacenter, "provider", providerName)
+		}
+
+		opts.providers = sets.NewString(providerName)
+		log.Infow("Testing the provider of the datacenter", "datacenter", opts.datacenter, "provider", providerName)
+	}
+
 	clusterClientProvider, err := clusterclient.NewExternal(seedClusterClient)
 	if err != nil {
 		log.Fatalw("Failed to get clusterClientProvider", zap.Error(err))
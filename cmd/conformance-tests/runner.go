@@ -45,6 +45,7 @@ import (
 	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1/helper"
 	"k8c.io/kubermatic/v2/pkg/provider"
 	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/semver"
 	"k8c.io/kubermatic/v2/pkg/test/e2e/utils"
 	apiclient "k8c.io/kubermatic/v2/pkg/test/e2e/utils/apiclient/client"
 	projectclient "k8c.io/kubermatic/v2/pkg/test/e2e/utils/apiclient/client/project"
@@ -58,6 +59,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	utilerror "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
@@ -112,6 +114,9 @@ func newRunner(scenarios []testScenario, opts *Opts, log *zap.SugaredLogger) *te
 		kubermaticProjectID:          opts.kubermaticProjectID,
 		kubermaticClient:             opts.kubermaticClient,
 		kubermaticAuthenticator:      opts.kubermaticAuthenticator,
+		scenarioSteps:                opts.scenarioSteps,
+		datacenter:                   opts.datacenter,
+		upgradeVersion:               opts.upgradeVersion,
 	}
 }
 
@@ -130,6 +135,12 @@ type testRunner struct {
 	onlyTestCreation   bool
 	pspEnabled         bool
 
+	// optional steps to run after the cluster has been tested
+	scenarioSteps  sets.String
+	upgradeVersion *semver.Semver
+	// if set, overrides the datacenter of all scenarios
+	datacenter string
+
 	controlPlaneReadyWaitTimeout time.Duration
 	nodeReadyTimeout             time.Duration
 	customTestTimeout            time.Duration
@@ -350,6 +361,10 @@ func (r *testRunner) executeScenario(ctx context.Context, log *zap.SugaredLogger
 		return report, err
 	}
 
+	if err := r.executeOptionalSteps(ctx, log, cluster, report); err != nil {
+		return report, err
+	}
+
 	if !r.deleteClusterAfterTests {
 		return report, nil
 	}
@@ -932,6 +947,9 @@ func (r *testRunner) createCluster(ctx context.Context, log *zap.SugaredLogger,
 	log.Info("Creating cluster via Kubermatic API")
 
 	cluster := scenario.Cluster(r.secrets)
+	if r.datacenter != "" {
+		cluster.Cluster.Spec.Cloud.DatacenterName = r.datacenter
+	}
 	// The cluster name must be unique per project.
 	// We build up a readable name with the various cli parameters & add a random string in the end to ensure
 	// we really have a unique name
@@ -0,0 +1,232 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/onsi/ginkgo/reporters"
+	"go.uber.org/zap"
+
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1/helper"
+	"k8c.io/kubermatic/v2/pkg/semver"
+	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// scenarioStep is an optional step that is executed for every scenario
+// after the cluster has been created and tested, but before it is deleted.
+// Steps are run in the order in which they are listed in allScenarioSteps.
+type scenarioStep string
+
+const (
+	// scenarioStepScale adds one more replica to every MachineDeployment
+	// and waits for the new nodes to become ready.
+	scenarioStepScale scenarioStep = "scale"
+	// scenarioStepUpgrade updates the control plane to the version given via
+	// -upgrade-version and waits for the cluster to be reconciled again.
+	scenarioStepUpgrade scenarioStep = "upgrade"
+)
+
+var allScenarioSteps = []scenarioStep{scenarioStepScale, scenarioStepUpgrade}
+
+// parseScenarioSteps parses a comma separated list of step tags.
+func parseScenarioSteps(s string) (sets.String, error) {
+	steps := sets.NewString()
+	if s == "" {
+		return steps, nil
+	}
+
+	known := sets.NewString()
+	for _, step := range allScenarioSteps {
+		known.Insert(string(step))
+	}
+
+	for _, step := range strings.Split(s, ",") {
+		step = strings.ToLower(strings.TrimSpace(step))
+		if !known.Has(step) {
+			return nil, fmt.Errorf("unknown scenario step %q, must be one of %v", step, known.List())
+		}
+		steps.Insert(step)
+	}
+
+	return steps, nil
+}
+
+func (r *testRunner) executeOptionalSteps(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster, report *reporters.JUnitTestSuite) error {
+	for _, step := range allScenarioSteps {
+		if !r.scenarioSteps.Has(string(step)) {
+			continue
+		}
+
+		var executor func() error
+		switch step {
+		case scenarioStepScale:
+			executor = func() error {
+				return r.scaleMachineDeployments(ctx, log, cluster)
+			}
+		case scenarioStepUpgrade:
+			executor = func() error {
+				return r.upgradeControlPlane(ctx, log, cluster.Name)
+			}
+		}
+
+		if err := junitReporterWrapper(fmt.Sprintf("[Kubermatic] Optional step %q", step), report, executor); err != nil {
+			return fmt.Errorf("optional step %q failed: %v", step, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *testRunner) scaleMachineDeployments(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster) error {
+	userClusterClient, err := r.clusterClientProvider.GetClient(ctx, cluster)
+	if err != nil {
+		return fmt.Errorf("failed to get the client for the cluster: %v", err)
+	}
+
+	mdList := &clusterv1alpha1.MachineDeploymentList{}
+	if err := userClusterClient.List(ctx, mdList); err != nil {
+		return fmt.Errorf("failed to list MachineDeployments: %v", err)
+	}
+
+	for _, md := range mdList.Items {
+		key := types.NamespacedName{Namespace: md.Namespace, Name: md.Name}
+
+		if err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+			current := &clusterv1alpha1.MachineDeployment{}
+			if err := userClusterClient.Get(ctx, key, current); err != nil {
+				return err
+			}
+
+			oldCurrent := current.DeepCopy()
+			replicas := int32(1)
+			if current.Spec.Replicas != nil {
+				replicas = *current.Spec.Replicas + 1
+			}
+			current.Spec.Replicas = &replicas
+
+			return userClusterClient.Patch(ctx, current, ctrlruntimeclient.MergeFrom(oldCurrent))
+		}); err != nil {
+			return fmt.Errorf("failed to scale MachineDeployment %s: %v", key, err)
+		}
+
+		log.Infow("Scaled up MachineDeployment", "machinedeployment", key.String())
+	}
+
+	timeoutRemaining, err := waitForMachinesToJoinCluster(ctx, log, userClusterClient, r.nodeReadyTimeout)
+	if err != nil {
+		return fmt.Errorf("failed to wait for machines to get a node: %v", err)
+	}
+
+	if _, err := waitForNodesToBeReady(ctx, log, userClusterClient, timeoutRemaining); err != nil {
+		return fmt.Errorf("failed to wait for all nodes to be ready: %v", err)
+	}
+
+	return nil
+}
+
+func (r *testRunner) upgradeControlPlane(ctx context.Context, log *zap.SugaredLogger, clusterName string) error {
+	if r.upgradeVersion == nil {
+		return fmt.Errorf("no -upgrade-version specified")
+	}
+
+	if err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		cluster := &kubermaticv1.Cluster{}
+		if err := r.seedClusterClient.Get(ctx, types.NamespacedName{Name: clusterName}, cluster); err != nil {
+			return err
+		}
+
+		oldCluster := cluster.DeepCopy()
+		cluster.Spec.Version = *r.upgradeVersion
+
+		return r.seedClusterClient.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster))
+	}); err != nil {
+		return fmt.Errorf("failed to update cluster version: %v", err)
+	}
+
+	log.Infow("Updated cluster version", "version", r.upgradeVersion.String())
+
+	// the control plane is only healthy again once the API server with
+	// the new version has been rolled out and serves requests
+	if err := wait.Poll(r.userClusterPollInterval, r.controlPlaneReadyWaitTimeout, func() (bool, error) {
+		return r.apiServerHasVersion(ctx, log, clusterName, r.upgradeVersion)
+	}); err != nil {
+		return fmt.Errorf("failed waiting for the API server to be upgraded: %v", err)
+	}
+
+	if _, err := r.waitForControlPlane(ctx, log, clusterName); err != nil {
+		return fmt.Errorf("failed waiting for control plane to become ready: %v", err)
+	}
+
+	return wait.Poll(5*time.Second, 5*time.Minute, func() (bool, error) {
+		cluster := &kubermaticv1.Cluster{}
+		if err := r.seedClusterClient.Get(ctx, types.NamespacedName{Name: clusterName}, cluster); err != nil {
+			log.Errorw("Failed to get cluster when waiting for successful reconciliation", zap.Error(err))
+			return false, nil
+		}
+
+		_, success := kubermaticv1helper.ClusterReconciliationSuccessful(cluster, kubermatic.NewDefaultVersions(), true)
+		return success, nil
+	})
+}
+
+// apiServerHasVersion returns whether the API server of the user cluster reports the
+// given version. Errors are only logged, as the API server is unavailable while it
+// is being replaced.
+func (r *testRunner) apiServerHasVersion(ctx context.Context, log *zap.SugaredLogger, clusterName string, version *semver.Semver) (bool, error) {
+	cluster := &kubermaticv1.Cluster{}
+	if err := r.seedClusterClient.Get(ctx, types.NamespacedName{Name: clusterName}, cluster); err != nil {
+		log.Errorw("Failed to get cluster when waiting for the upgrade", zap.Error(err))
+		return false, nil
+	}
+
+	config, err := r.clusterClientProvider.GetClientConfig(ctx, cluster)
+	if err != nil {
+		log.Debugw("Failed to get the client config for the cluster", zap.Error(err))
+		return false, nil
+	}
+
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return false, fmt.Errorf("failed to create client for the cluster: %v", err)
+	}
+
+	serverVersion, err := client.Discovery().ServerVersion()
+	if err != nil {
+		log.Debugw("API server is not available yet", zap.Error(err))
+		return false, nil
+	}
+
+	current, err := semver.NewSemver(serverVersion.GitVersion)
+	if err != nil {
+		return false, fmt.Errorf("API server reported invalid version %q: %v", serverVersion.GitVersion, err)
+	}
+
+	return current.Equal(version), nil
+}
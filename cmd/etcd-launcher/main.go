@@ -28,7 +28,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/minio/minio-go"
 	"github.com/pkg/errors"
 	"go.etcd.io/etcd/v3/clientv3"
 	"go.etcd.io/etcd/v3/clientv3/snapshot"
@@ -39,6 +38,7 @@ import (
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+	"k8c.io/kubermatic/v2/pkg/objectstorage"
 	"k8c.io/kubermatic/v2/pkg/resources"
 
 	corev1 "k8s.io/api/core/v1"
@@ -558,16 +558,16 @@ func (e *etcdCluster) restoreDatadirFromBackupIfNeeded(ctx context.Context, k8cC
 
 	log.Infow("restoring datadir from backup", "backup-name", activeRestore.Spec.BackupName)
 
-	s3Client, bucketName, err := resources.GetEtcdRestoreS3Client(ctx, activeRestore, false, client, k8cCluster)
+	store, err := resources.GetEtcdRestoreStore(ctx, activeRestore, false, client, k8cCluster)
 	if err != nil {
-		return fmt.Errorf("failed to get s3 client: %w", err)
+		return fmt.Errorf("failed to get backup storage client: %w", err)
 	}
 
 	objectName := fmt.Sprintf("%s-%s", k8cCluster.GetName(), activeRestore.Spec.BackupName)
 	downloadedSnapshotFile := fmt.Sprintf("/tmp/%s", objectName)
 
-	if err := s3Client.FGetObject(bucketName, objectName, downloadedSnapshotFile, minio.GetObjectOptions{}); err != nil {
-		return fmt.Errorf("failed to download backup (%s/%s): %w", bucketName, objectName, err)
+	if err := objectstorage.DownloadFile(ctx, store, objectName, downloadedSnapshotFile); err != nil {
+		return fmt.Errorf("failed to download backup %s: %w", objectName, err)
 	}
 
 	if err := os.RemoveAll(e.dataDir); err != nil {
@@ -160,7 +160,8 @@ func createExampleSeed() *kubermaticv1.Seed {
 							DNSPolicy: "",
 							DNSConfig: &corev1.PodDNSConfig{},
 						},
-						Alibaba: &kubermaticv1.DatacenterSpecAlibaba{},
+						Alibaba:        &kubermaticv1.DatacenterSpecAlibaba{},
+						CapacityLimits: &kubermaticv1.DatacenterCapacityLimits{},
 					},
 				},
 			},
@@ -26,6 +26,7 @@ import (
 	addonutil "k8c.io/kubermatic/v2/pkg/addon"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/registry"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer/json"
@@ -66,7 +67,7 @@ func getImagesFromAddon(log *zap.SugaredLogger, addonPath string, decoder runtim
 	log = log.With(zap.String("addon", path.Base(addonPath)))
 	log.Debug("Processing manifests...")
 
-	allManifests, err := addonutil.ParseFromFolder(log, "", addonPath, data)
+	allManifests, err := addonutil.ParseFromFolder(log, registry.GetOverwriteFunc(""), addonPath, data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse addon templates in %s: %v", addonPath, err)
 	}
@@ -35,6 +35,7 @@ import (
 	masteroperator "k8c.io/kubermatic/v2/pkg/controller/operator/master/resources/kubermatic"
 	seedoperatorkubermatic "k8c.io/kubermatic/v2/pkg/controller/operator/seed/resources/kubermatic"
 	seedoperatornodeportproxy "k8c.io/kubermatic/v2/pkg/controller/operator/seed/resources/nodeportproxy"
+	seedoperatorregistrycache "k8c.io/kubermatic/v2/pkg/controller/operator/seed/resources/registrycache"
 	kubernetescontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/kubernetes"
 	"k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/mla"
 	"k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/monitoring"
@@ -265,7 +266,12 @@ func getImagesForVersion(log *zap.SugaredLogger, clusterVersion *kubermaticversi
 }
 
 func getImagesFromCreators(log *zap.SugaredLogger, templateData *resources.TemplateData, config *operatorv1alpha1.KubermaticConfiguration, kubermaticVersions kubermatic.Versions) (images []string, err error) {
-	seed, err := common.DefaultSeed(&kubermaticv1.Seed{}, log)
+	// the registry cache is optional, but its image must be loaded as well
+	seed, err := common.DefaultSeed(&kubermaticv1.Seed{
+		Spec: kubermaticv1.SeedSpec{
+			RegistryCache: &kubermaticv1.RegistryCacheConfig{},
+		},
+	}, log)
 	if err != nil {
 		return nil, fmt.Errorf("failed to default Seed: %v", err)
 	}
@@ -281,6 +287,7 @@ func getImagesFromCreators(log *zap.SugaredLogger, templateData *resources.Templ
 	deploymentCreators = append(deploymentCreators, seedoperatorkubermatic.SeedControllerManagerDeploymentCreator("", kubermaticVersions, config, seed))
 	deploymentCreators = append(deploymentCreators, seedoperatornodeportproxy.EnvoyDeploymentCreator(config, seed, kubermaticVersions))
 	deploymentCreators = append(deploymentCreators, seedoperatornodeportproxy.UpdaterDeploymentCreator(config, seed, kubermaticVersions))
+	deploymentCreators = append(deploymentCreators, seedoperatorregistrycache.DeploymentCreator(seed, kubermaticVersions))
 	deploymentCreators = append(deploymentCreators, vpa.AdmissionControllerDeploymentCreator(config, kubermaticVersions))
 	deploymentCreators = append(deploymentCreators, vpa.RecommenderDeploymentCreator(config, kubermaticVersions))
 	deploymentCreators = append(deploymentCreators, vpa.UpdaterDeploymentCreator(config, kubermaticVersions))
@@ -39,6 +39,7 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"time"
@@ -48,6 +49,8 @@ import (
 	gatekeeperconfigv1alpha1 "github.com/open-policy-agent/gatekeeper/apis/config/v1alpha1"
 	prometheusapi "github.com/prometheus/client_golang/api"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
 	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
 	"k8c.io/kubermatic/v2/pkg/cluster/client"
@@ -58,6 +61,7 @@ import (
 	"k8c.io/kubermatic/v2/pkg/features"
 	"k8c.io/kubermatic/v2/pkg/handler"
 	"k8c.io/kubermatic/v2/pkg/handler/auth"
+	"k8c.io/kubermatic/v2/pkg/handler/rpc"
 	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
 	v2 "k8c.io/kubermatic/v2/pkg/handler/v2"
 	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
@@ -132,10 +136,11 @@ func main() {
 	if err != nil {
 		log.Fatalw("failed to create update manager", "error", err)
 	}
-	apiHandler, err := createAPIHandler(options, providers, oidcIssuerVerifier, tokenVerifiers, tokenExtractors, updateManager)
+	routingParams, err := createRoutingParams(options, providers, oidcIssuerVerifier, tokenVerifiers, tokenExtractors, updateManager)
 	if err != nil {
 		log.Fatalw("failed to create API Handler", "error", err)
 	}
+	apiHandler := createAPIHandler(options, routingParams)
 
 	go func() {
 		if err := pprofOpts.Start(ctx); err != nil {
@@ -144,6 +149,12 @@ func main() {
 	}()
 
 	go metricspkg.ServeForever(options.internalAddr, "/metrics")
+	if options.grpcAddress != "" {
+		go func() {
+			log.Infow("the gRPC API server listening", "grpcAddress", options.grpcAddress)
+			log.Fatalw("failed to start gRPC API server", "error", serveGRPC(options, routingParams))
+		}()
+	}
 	log.Infow("the API server listening", "listenAddress", options.listenAddress)
 	log.Fatalw("failed to start API server", "error", http.ListenAndServe(options.listenAddress, handlers.CombinedLoggingHandler(os.Stdout, apiHandler)))
 }
@@ -275,6 +286,9 @@ func createInitProviders(ctx context.Context, options serverRunOptions) (provide
 		return providers{}, fmt.Errorf("failed to create whitelisted registry provider due to %v", err)
 	}
 
+	privilegedAddonRolloutProvider := kubernetesprovider.NewAddonRolloutPrivilegedProvider(mgr.GetClient())
+	privilegedNamespaceTemplateProvider := kubernetesprovider.NewNamespaceTemplatePrivilegedProvider(mgr.GetClient())
+
 	constraintProviderGetter := kubernetesprovider.ConstraintProviderFactory(mgr.GetRESTMapper(), seedKubeconfigGetter)
 
 	kubeMasterInformerFactory.Start(wait.NeverStop)
@@ -341,6 +355,8 @@ func createInitProviders(ctx context.Context, options serverRunOptions) (provide
 		clusterTemplateInstanceProviderGetter: clusterTemplateInstanceProviderGetter,
 		privilegedWhitelistedRegistryProvider: privilegedWhitelistedRegistryProvider,
 		etcdBackupConfigProviderGetter:        etcdBackupConfigProviderGetter,
+		privilegedAddonRolloutProvider:        privilegedAddonRolloutProvider,
+		privilegedNamespaceTemplateProvider:   privilegedNamespaceTemplateProvider,
 	}, nil
 }
 
@@ -389,24 +405,24 @@ func createAuthClients(options serverRunOptions, prov providers) (auth.TokenVeri
 	return tokenVerifiers, tokenExtractors, nil
 }
 
-func createAPIHandler(options serverRunOptions, prov providers, oidcIssuerVerifier auth.OIDCIssuerVerifier, tokenVerifiers auth.TokenVerifier, tokenExtractors auth.TokenExtractor, updateManager common.UpdateManager) (http.HandlerFunc, error) {
+func createRoutingParams(options serverRunOptions, prov providers, oidcIssuerVerifier auth.OIDCIssuerVerifier, tokenVerifiers auth.TokenVerifier, tokenExtractors auth.TokenExtractor, updateManager common.UpdateManager) (handler.RoutingParams, error) {
 	var prometheusClient prometheusapi.Client
 	if options.featureGates.Enabled(features.PrometheusEndpoint) {
 		var err error
 		if prometheusClient, err = prometheusapi.NewClient(prometheusapi.Config{
 			Address: options.prometheusURL,
 		}); err != nil {
-			return nil, err
+			return handler.RoutingParams{}, err
 		}
 	}
 
 	serviceAccountTokenGenerator, err := serviceaccount.JWTTokenGenerator([]byte(options.serviceAccountSigningKey))
 	if err != nil {
-		return nil, fmt.Errorf("failed to create service account token generator due to %v", err)
+		return handler.RoutingParams{}, fmt.Errorf("failed to create service account token generator due to %v", err)
 	}
 	serviceAccountTokenAuth := serviceaccount.JWTTokenAuthenticator([]byte(options.serviceAccountSigningKey))
 
-	routingParams := handler.RoutingParams{
+	return handler.RoutingParams{
 		Log:                                   kubermaticlog.New(options.log.Debug, options.log.Format).Sugar(),
 		PresetsProvider:                       prov.presetProvider,
 		SeedsGetter:                           prov.seedsGetter,
@@ -453,10 +469,14 @@ func createAPIHandler(options serverRunOptions, prov providers, oidcIssuerVerifi
 		RuleGroupProviderGetter:               prov.ruleGroupProviderGetter,
 		PrivilegedWhitelistedRegistryProvider: prov.privilegedWhitelistedRegistryProvider,
 		EtcdBackupConfigProviderGetter:        prov.etcdBackupConfigProviderGetter,
+		PrivilegedAddonRolloutProvider:        prov.privilegedAddonRolloutProvider,
+		PrivilegedNamespaceTemplateProvider:   prov.privilegedNamespaceTemplateProvider,
 		Versions:                              options.versions,
 		CABundle:                              options.caBundle.CertPool(),
-	}
+	}, nil
+}
 
+func createAPIHandler(options serverRunOptions, routingParams handler.RoutingParams) http.HandlerFunc {
 	r := handler.NewRouting(routingParams)
 	rv2 := v2.NewV2Routing(routingParams)
 
@@ -509,7 +529,24 @@ func createAPIHandler(options serverRunOptions, prov providers, oidcIssuerVerifi
 		return name
 	}
 
-	return instrumentHandler(mainRouter, lookupRoute), nil
+	return instrumentHandler(mainRouter, lookupRoute)
+}
+
+func serveGRPC(options serverRunOptions, routingParams handler.RoutingParams) error {
+	creds, err := credentials.NewServerTLSFromFile(options.grpcCertFile, options.grpcKeyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load the serving certificate: %v", err)
+	}
+
+	listener, err := net.Listen("tcp", options.grpcAddress)
+	if err != nil {
+		return err
+	}
+
+	server := grpc.NewServer(grpc.Creds(creds))
+	rpc.NewServer(routingParams).Register(server)
+
+	return server.Serve(listener)
 }
 
 func setSecureHeaders(next http.Handler) http.Handler {
@@ -37,6 +37,9 @@ import (
 type serverRunOptions struct {
 	listenAddress    string
 	internalAddr     string
+	grpcAddress      string
+	grpcCertFile     string
+	grpcKeyFile      string
 	prometheusURL    string
 	masterResources  string
 	workerName       string
@@ -83,6 +86,9 @@ func newServerRunOptions() (serverRunOptions, error) {
 
 	flag.StringVar(&s.listenAddress, "address", ":8080", "The address to listen on")
 	flag.StringVar(&s.internalAddr, "internal-address", "127.0.0.1:8085", "The address on which the internal handler should be exposed")
+	flag.StringVar(&s.grpcAddress, "grpc-address", "", "The address on which the gRPC API should be exposed. The gRPC API is disabled if empty")
+	flag.StringVar(&s.grpcCertFile, "grpc-tls-cert-file", "", "The serving certificate of the gRPC API, usually the one of the API's ingress (kubermatic-tls). Required if grpc-address is set")
+	flag.StringVar(&s.grpcKeyFile, "grpc-tls-key-file", "", "The private key of the gRPC API's serving certificate. Required if grpc-address is set")
 	flag.StringVar(&s.prometheusURL, "prometheus-url", "http://prometheus.monitoring.svc.local:web", "The URL on which this API can talk to Prometheus")
 	flag.StringVar(&s.masterResources, "master-resources", "", "The path to the master resources (Required).")
 	flag.StringVar(&s.workerName, "worker-name", "", "Create clusters only processed by worker-name cluster controller")
@@ -139,6 +145,11 @@ func (o serverRunOptions) validate() error {
 		return fmt.Errorf("the service-account-signing-key is incorrect due to error: %v", err)
 	}
 
+	// the gRPC API authenticates with bearer tokens, which must not be sent in plain text
+	if o.grpcAddress != "" && (o.grpcCertFile == "" || o.grpcKeyFile == "") {
+		return fmt.Errorf("the grpc-tls-cert-file and grpc-tls-key-file are required if grpc-address is set")
+	}
+
 	return nil
 }
 
@@ -179,4 +190,6 @@ type providers struct {
 	ruleGroupProviderGetter               provider.RuleGroupProviderGetter
 	privilegedWhitelistedRegistryProvider provider.PrivilegedWhitelistedRegistryProvider
 	etcdBackupConfigProviderGetter        provider.EtcdBackupConfigProviderGetter
+	privilegedAddonRolloutProvider        provider.PrivilegedAddonRolloutProvider
+	privilegedNamespaceTemplateProvider   provider.PrivilegedNamespaceTemplateProvider
 }
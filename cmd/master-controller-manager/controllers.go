@@ -22,14 +22,17 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	addonrolloutcontroller "k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/addon-rollout-controller"
+	azureresourcegccontroller "k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/azure-resource-gc-controller"
 	clustertemplatesynchronizer "k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/cluster-template-synchronizer"
 	externalcluster "k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/external-cluster"
 	masterconstraintsynchronizer "k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/master-constraint-controller"
-	masterconstrainttemplatecontroller "k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/master-constraint-template-controller"
+	projectdeletion "k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/project-deletion"
 	projectlabelsynchronizer "k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/project-label-synchronizer"
 	projectsync "k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/project-sync"
 	"k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/rbac"
 	seedproxy "k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/seed-proxy"
+	seedresourcesynchronizer "k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/seed-resource-synchronizer"
 	seedsync "k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/seed-sync"
 	serviceaccount "k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/service-account"
 	userprojectbinding "k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/user-project-binding"
@@ -61,6 +64,24 @@ func createAllControllers(ctrlCtx *controllerContext) error {
 	masterconstraintSynchronizerFactory := masterconstraintSynchronizerFactoryCreator(ctrlCtx)
 	userSynchronizerFactory := userSynchronizerFactoryCreator(ctrlCtx)
 	clusterTemplateSynchronizerFactory := clusterTemplateSynchronizerFactoryCreator(ctrlCtx)
+	seedResourceSynchronizerFactory := seedResourceSynchronizerFactoryCreator(ctrlCtx)
+	addonRolloutControllerFactory := addonRolloutControllerFactoryCreator(ctrlCtx)
+	projectDeletionControllerFactory := projectDeletionControllerFactoryCreator(ctrlCtx)
+
+	factories := []seedcontrollerlifecycle.ControllerFactory{
+		rbacControllerFactory,
+		projectLabelSynchronizerFactory,
+		userSSHKeysSynchronizerFactory,
+		masterconstraintSynchronizerFactory,
+		userSynchronizerFactory,
+		clusterTemplateSynchronizerFactory,
+		seedResourceSynchronizerFactory,
+		addonRolloutControllerFactory,
+		projectDeletionControllerFactory,
+	}
+	if ctrlCtx.azureResourceGCInterval > 0 {
+		factories = append(factories, azureResourceGCControllerFactoryCreator(ctrlCtx))
+	}
 
 	if err := seedcontrollerlifecycle.Add(ctrlCtx.ctx,
 		kubermaticlog.Logger,
@@ -68,12 +89,7 @@ func createAllControllers(ctrlCtx *controllerContext) error {
 		ctrlCtx.namespace,
 		ctrlCtx.seedsGetter,
 		ctrlCtx.seedKubeconfigGetter,
-		rbacControllerFactory,
-		projectLabelSynchronizerFactory,
-		userSSHKeysSynchronizerFactory,
-		masterconstraintSynchronizerFactory,
-		userSynchronizerFactory,
-		clusterTemplateSynchronizerFactory); err != nil {
+		factories...); err != nil {
 		//TODO: Find a better name
 		return fmt.Errorf("failed to create seedcontrollerlifecycle: %v", err)
 	}
@@ -92,9 +108,6 @@ func createAllControllers(ctrlCtx *controllerContext) error {
 	if err := externalcluster.Add(ctrlCtx.ctx, ctrlCtx.mgr, ctrlCtx.log); err != nil {
 		return fmt.Errorf("failed to create external cluster controller: %v", err)
 	}
-	if err := masterconstrainttemplatecontroller.Add(ctrlCtx.ctx, ctrlCtx.mgr, ctrlCtx.log, 1, ctrlCtx.namespace, ctrlCtx.seedKubeconfigGetter); err != nil {
-		return fmt.Errorf("failed to create master constraint template controller: %v", err)
-	}
 	if err := projectsync.Add(ctrlCtx.mgr, ctrlCtx.log, 1, ctrlCtx.seedKubeconfigGetter); err != nil {
 		return fmt.Errorf("failed to create projectsync controller: %v", err)
 	}
@@ -186,3 +199,50 @@ func clusterTemplateSynchronizerFactoryCreator(ctrlCtx *controllerContext) seedc
 		)
 	}
 }
+
+func seedResourceSynchronizerFactoryCreator(ctrlCtx *controllerContext) seedcontrollerlifecycle.ControllerFactory {
+	return func(ctx context.Context, masterMgr manager.Manager, seedManagerMap map[string]manager.Manager) (string, error) {
+		return seedresourcesynchronizer.ControllerName, seedresourcesynchronizer.Add(
+			masterMgr,
+			seedManagerMap,
+			ctrlCtx.log,
+			ctrlCtx.namespace,
+			ctrlCtx.workerCount,
+		)
+	}
+}
+
+func addonRolloutControllerFactoryCreator(ctrlCtx *controllerContext) seedcontrollerlifecycle.ControllerFactory {
+	return func(ctx context.Context, masterMgr manager.Manager, seedManagerMap map[string]manager.Manager) (string, error) {
+		return addonrolloutcontroller.ControllerName, addonrolloutcontroller.Add(
+			masterMgr,
+			seedManagerMap,
+			ctrlCtx.log,
+			ctrlCtx.workerCount,
+		)
+	}
+}
+
+func projectDeletionControllerFactoryCreator(ctrlCtx *controllerContext) seedcontrollerlifecycle.ControllerFactory {
+	return func(ctx context.Context, masterMgr manager.Manager, seedManagerMap map[string]manager.Manager) (string, error) {
+		return projectdeletion.ControllerName, projectdeletion.Add(
+			masterMgr,
+			seedManagerMap,
+			ctrlCtx.log,
+			ctrlCtx.workerCount,
+		)
+	}
+}
+
+func azureResourceGCControllerFactoryCreator(ctrlCtx *controllerContext) seedcontrollerlifecycle.ControllerFactory {
+	return func(ctx context.Context, masterMgr manager.Manager, seedManagerMap map[string]manager.Manager) (string, error) {
+		return azureresourcegccontroller.ControllerName, azureresourcegccontroller.Add(
+			masterMgr,
+			seedManagerMap,
+			ctrlCtx.log,
+			ctrlCtx.azureResourceGCInterval,
+			ctrlCtx.azureResourceGCDelete,
+			ctrlCtx.azureInstallationID,
+		)
+	}
+}
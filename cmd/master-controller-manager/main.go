@@ -20,10 +20,12 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 
+	operatorv1alpha1 "k8c.io/kubermatic/v2/pkg/crd/operator/v1alpha1"
 	"k8c.io/kubermatic/v2/pkg/features"
 	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
 	"k8c.io/kubermatic/v2/pkg/metrics"
@@ -72,6 +74,10 @@ type controllerContext struct {
 	seedKubeconfigGetter    provider.SeedKubeconfigGetter
 	labelSelectorFunc       func(*metav1.ListOptions)
 	namespace               string
+
+	azureResourceGCInterval time.Duration
+	azureResourceGCDelete   bool
+	azureInstallationID     string
 }
 
 func main() {
@@ -91,6 +97,9 @@ func main() {
 		"Enabling this will ensure there is only one active controller manager.")
 	flag.StringVar(&runOpts.leaderElectionNamespace, "leader-election-namespace", "", "Leader election namespace. In-cluster discovery will be attempted in such case.")
 	flag.Var(&runOpts.featureGates, "feature-gates", "A set of key=value pairs that describe feature gates for various features.")
+	flag.DurationVar(&ctrlCtx.azureResourceGCInterval, "azure-resource-gc-interval", 0, "The interval in which Azure resources of clusters that do not exist anymore are looked for, 0 disables the garbage collection.")
+	flag.BoolVar(&ctrlCtx.azureResourceGCDelete, "azure-resource-gc-delete", false, "Delete orphaned Azure resources instead of only reporting them.")
+	flag.StringVar(&ctrlCtx.azureInstallationID, "azure-installation-id", "", "The ID of this installation in the tags of the Azure resources, has to match the flag of the seed controller managers. Required by the Azure resource garbage collection.")
 	addFlags(flag.CommandLine)
 	flag.Parse()
 
@@ -113,6 +122,10 @@ func main() {
 		log.Fatalw("invalid admission webhook configuration", zap.Error(err))
 	}
 
+	if ctrlCtx.azureResourceGCInterval > 0 && ctrlCtx.azureInstallationID == "" {
+		log.Fatal("-azure-resource-gc-interval requires -azure-installation-id to be set")
+	}
+
 	// TODO remove label selector when everything is migrated to controller-runtime
 	selector, err := workerlabel.LabelSelector(runOpts.workerName)
 	if err != nil {
@@ -148,6 +161,11 @@ func main() {
 	}
 	ctrlCtx.mgr = mgr
 
+	// the versions of the KubermaticConfigurations are synced into all seeds
+	if err := operatorv1alpha1.AddToScheme(mgr.GetScheme()); err != nil {
+		log.Fatalw("failed to register scheme", zap.Stringer("api", operatorv1alpha1.SchemeGroupVersion), zap.Error(err))
+	}
+
 	if err := mgr.Add(pprofOpts); err != nil {
 		log.Fatalw("failed to add pprof endpoint", zap.Error(err))
 	}
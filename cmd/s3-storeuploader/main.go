@@ -17,6 +17,7 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"crypto/x509"
 	"fmt"
 	"os"
@@ -25,6 +26,7 @@ import (
 	"go.uber.org/zap"
 
 	"k8c.io/kubermatic/v2/pkg/log"
+	"k8c.io/kubermatic/v2/pkg/objectstorage"
 	"k8c.io/kubermatic/v2/pkg/resources/certificates"
 	"k8c.io/kubermatic/v2/pkg/storeuploader"
 )
@@ -36,12 +38,24 @@ func main() {
 	app.Name = "S3 storer"
 	app.Usage = ""
 	app.Version = "v0.1.6"
-	app.Description = "Helper tool to backup files to S3 and maintain a given number of revisions"
+	app.Description = "Helper tool to backup files to an object storage and maintain a given number of revisions"
 
+	providerFlag := cli.StringFlag{
+		Name:   "provider",
+		Value:  string(objectstorage.ProviderS3),
+		EnvVar: "PROVIDER",
+		Usage:  fmt.Sprintf("Object storage provider, one of %v", objectstorage.SupportedProviders),
+	}
 	endpointFlag := cli.StringFlag{
 		Name:  "endpoint, e",
 		Value: "",
-		Usage: "S3 endpoint",
+		Usage: "S3 endpoint, Azure storage base URL or Keystone URL for Swift",
+	}
+	regionFlag := cli.StringFlag{
+		Name:   "region",
+		Value:  "",
+		EnvVar: "REGION",
+		Usage:  "S3 or Swift region",
 	}
 	accessKeyIDFlag := cli.StringFlag{
 		Name:   "access-key-id",
@@ -55,20 +69,62 @@ func main() {
 		EnvVar: "SECRET_ACCESS_KEY",
 		Usage:  "S3 SecretAccessKey",
 	}
+	serviceAccountFlag := cli.StringFlag{
+		Name:   "service-account",
+		Value:  "",
+		EnvVar: "SERVICE_ACCOUNT",
+		Usage:  "Base64 encoded Google Cloud service account",
+	}
+	accountNameFlag := cli.StringFlag{
+		Name:   "account-name",
+		Value:  "",
+		EnvVar: "ACCOUNT_NAME",
+		Usage:  "Azure storage account name",
+	}
+	accountKeyFlag := cli.StringFlag{
+		Name:   "account-key",
+		Value:  "",
+		EnvVar: "ACCOUNT_KEY",
+		Usage:  "Azure storage account key",
+	}
+	usernameFlag := cli.StringFlag{
+		Name:   "username",
+		Value:  "",
+		EnvVar: "USERNAME",
+		Usage:  "OpenStack username for Swift",
+	}
+	passwordFlag := cli.StringFlag{
+		Name:   "password",
+		Value:  "",
+		EnvVar: "PASSWORD",
+		Usage:  "OpenStack password for Swift",
+	}
+	domainFlag := cli.StringFlag{
+		Name:   "domain",
+		Value:  "",
+		EnvVar: "DOMAIN",
+		Usage:  "OpenStack domain for Swift",
+	}
+	tenantFlag := cli.StringFlag{
+		Name:   "tenant",
+		Value:  "",
+		EnvVar: "TENANT",
+		Usage:  "OpenStack tenant for Swift",
+	}
 	bucketFlag := cli.StringFlag{
 		Name:  "bucket, b",
 		Value: "kubermatic-backups",
-		Usage: "S3 bucket in which to store the snapshots",
+		Usage: "Bucket in which to store the snapshots",
 	}
 	prefixFlag := cli.StringFlag{
 		Name:  "prefix, p",
 		Value: "",
-		Usage: "Prefix to use for all objects stored in S3",
+		Usage: "Prefix to use for all objects stored in the bucket",
 	}
 	fileFlag := cli.StringFlag{
 		Name:  "file, f",
 		Value: "/backup/snapshot.db",
-		Usage: "Path to the file to store in S3",
+		Usage: "Path to the file to store in the bucket",
 	}
 	secureFlag := cli.BoolFlag{
 		Name:  "secure",
@@ -85,7 +141,7 @@ func main() {
 	maxRevisionsFlag := cli.IntFlag{
 		Name:  "max-revisions",
 		Value: 20,
-		Usage: "Maximum number of revisions of the file to keep in S3. Older ones will be deleted",
+		Usage: "Maximum number of revisions of the file to keep in the bucket. Older ones will be deleted",
 	}
 
 	logDebugFlag := cli.BoolFlag{
@@ -100,6 +156,25 @@ func main() {
 		Usage: fmt.Sprintf("Use one of [%v] to change the log output", log.AvailableFormats),
 	}
 
+	storageFlags := []cli.Flag{
+		providerFlag,
+		endpointFlag,
+		regionFlag,
+		secureFlag,
+		caBundleFlag,
+		accessKeyIDFlag,
+		secretAccessKeyFlag,
+		serviceAccountFlag,
+		accountNameFlag,
+		accountKeyFlag,
+		usernameFlag,
+		passwordFlag,
+		domainFlag,
+		tenantFlag,
+		bucketFlag,
+		prefixFlag,
+	}
+
 	app.Flags = []cli.Flag{
 		logDebugFlag,
 		logFormatFlag,
@@ -108,49 +183,24 @@ func main() {
 	app.Commands = []cli.Command{
 		{
 			Name:   "store",
-			Usage:  "Stores the given file on S3",
+			Usage:  "Stores the given file in the bucket",
 			Action: store,
-			Flags: []cli.Flag{
-				endpointFlag,
-				secureFlag,
-				caBundleFlag,
-				accessKeyIDFlag,
-				secretAccessKeyFlag,
-				bucketFlag,
-				prefixFlag,
-				fileFlag,
-				createBucketFlag,
-			},
+			Flags:  append(storageFlags, fileFlag, createBucketFlag),
 		},
 		{
 			Name:   "delete-old-revisions",
 			Usage:  "Deletes backups which are older than max-revisions",
 			Action: deleteOldRevisions,
-			Flags: []cli.Flag{
-				endpointFlag,
-				secureFlag,
-				caBundleFlag,
-				accessKeyIDFlag,
-				secretAccessKeyFlag,
-				bucketFlag,
-				prefixFlag,
+			Flags: append(storageFlags,
 				maxRevisionsFlag,
 				fileFlag, // unused but kept for BC compatibility with old cleanup scripts
-			},
+			),
 		},
 		{
 			Name:   "delete-all",
 			Usage:  "deletes all backups of the filename",
 			Action: deleteAll,
-			Flags: []cli.Flag{
-				endpointFlag,
-				secureFlag,
-				caBundleFlag,
-				accessKeyIDFlag,
-				secretAccessKeyFlag,
-				bucketFlag,
-				prefixFlag,
-			},
+			Flags:  storageFlags,
 		},
 	}
 
@@ -192,19 +242,26 @@ func getUploaderFromCtx(c *cli.Context) (*storeuploader.StoreUploader, error) {
 		rootCAs = bundle.CertPool()
 	}
 
-	uploader, err := storeuploader.New(
-		c.String("endpoint"),
-		c.Bool("secure"),
-		c.String("access-key-id"),
-		c.String("secret-access-key"),
-		logger,
-		rootCAs,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create store uploader: %v", err)
+	config := objectstorage.Config{
+		Provider: objectstorage.Provider(c.String("provider")),
+		Endpoint: c.String("endpoint"),
+		Region:   c.String("region"),
+		Insecure: !c.Bool("secure"),
+		RootCAs:  rootCAs,
+		Credentials: objectstorage.Credentials{
+			AccessKeyID:     c.String("access-key-id"),
+			SecretAccessKey: c.String("secret-access-key"),
+			ServiceAccount:  c.String("service-account"),
+			AccountName:     c.String("account-name"),
+			AccountKey:      c.String("account-key"),
+			Username:        c.String("username"),
+			Password:        c.String("password"),
+			Domain:          c.String("domain"),
+			Tenant:          c.String("tenant"),
+		},
 	}
 
-	return uploader, nil
+	return storeuploader.New(config, logger), nil
 }
 
 func store(c *cli.Context) error {
@@ -214,6 +271,7 @@ func store(c *cli.Context) error {
 	}
 
 	return uploader.Store(
+		context.Background(),
 		c.String("file"),
 		c.String("bucket"),
 		c.String("prefix"),
@@ -228,6 +286,7 @@ func deleteOldRevisions(c *cli.Context) error {
 	}
 
 	return uploader.DeleteOldBackups(
+		context.Background(),
 		c.String("bucket"),
 		c.String("prefix"),
 		c.Int("max-revisions"),
@@ -241,6 +300,7 @@ func deleteAll(c *cli.Context) error {
 	}
 
 	return uploader.DeleteAll(
+		context.Background(),
 		c.String("bucket"),
 		c.String("prefix"),
 	)
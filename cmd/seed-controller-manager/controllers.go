@@ -24,13 +24,18 @@ import (
 
 	"k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/addon"
 	"k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/addoninstaller"
+	"k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/apideprecation"
 	backupcontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/backup"
 	cloudcontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/cloud"
+	clusternamespacecontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/cluster-namespace-controller"
 	clustertemplatecontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/cluster-template-controller"
 	seedconstraintsynchronizer "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/constraint-controller"
 	constrainttemplatecontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/constraint-template-controller"
+	"k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/controlplanedensity"
+	"k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/credentialrotation"
 	etcdbackupcontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/etcdbackup"
 	etcdrestorecontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/etcdrestore"
+	eventbuscontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/eventbus"
 	"k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/initialmachinedeployment"
 	kubernetescontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/kubernetes"
 	"k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/mla"
@@ -40,6 +45,7 @@ import (
 	"k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/seedresourcesuptodatecondition"
 	updatecontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/update"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/eventbus"
 	"k8c.io/kubermatic/v2/pkg/features"
 	"k8c.io/kubermatic/v2/pkg/version"
 
@@ -69,6 +75,11 @@ var AllControllers = map[string]controllerCreator{
 	initialmachinedeployment.ControllerName:       createInitialMachineDeploymentController,
 	mla.ControllerName:                            createMLAController,
 	clustertemplatecontroller.ControllerName:      createClusterTemplateController,
+	apideprecation.ControllerName:                 createAPIDeprecationController,
+	credentialrotation.ControllerName:             createCredentialRotationController,
+	controlplanedensity.ControllerName:            createControlPlaneDensityController,
+	eventbuscontroller.ControllerName:             createEventBusController,
+	clusternamespacecontroller.ControllerName:     createClusterNamespaceController,
 }
 
 type controllerCreator func(*controllerContext) error
@@ -122,6 +133,7 @@ func createCloudController(ctrlCtx *controllerContext) error {
 		ctrlCtx.runOptions.workerName,
 		ctrlCtx.versions,
 		ctrlCtx.runOptions.caBundle.CertPool(),
+		ctrlCtx.runOptions.azureInstallationID,
 	); err != nil {
 		return fmt.Errorf("failed to add cloud controller to mgr: %v", err)
 	}
@@ -339,6 +351,7 @@ func createAddonController(ctrlCtx *controllerContext) error {
 		},
 		ctrlCtx.runOptions.kubernetesAddonsPath,
 		ctrlCtx.runOptions.overwriteRegistry,
+		ctrlCtx.seedGetter,
 		ctrlCtx.clientProvider,
 		ctrlCtx.versions,
 	)
@@ -444,3 +457,73 @@ func createClusterTemplateController(ctrlCtx *controllerContext) error {
 		ctrlCtx.runOptions.workerCount,
 	)
 }
+
+func createAPIDeprecationController(ctrlCtx *controllerContext) error {
+	if ctrlCtx.runOptions.apiDeprecationScanInterval == 0 {
+		return nil
+	}
+
+	return apideprecation.Add(
+		ctrlCtx.mgr,
+		ctrlCtx.runOptions.workerCount,
+		ctrlCtx.runOptions.workerName,
+		ctrlCtx.clientProvider,
+		ctrlCtx.log,
+		ctrlCtx.versions,
+		ctrlCtx.runOptions.apiDeprecationScanInterval,
+	)
+}
+
+func createCredentialRotationController(ctrlCtx *controllerContext) error {
+	if ctrlCtx.runOptions.credentialRotationInterval == 0 {
+		return nil
+	}
+
+	return credentialrotation.Add(
+		ctrlCtx.mgr,
+		ctrlCtx.runOptions.workerCount,
+		ctrlCtx.runOptions.workerName,
+		ctrlCtx.log,
+		ctrlCtx.versions,
+		ctrlCtx.runOptions.credentialRotationInterval,
+	)
+}
+
+func createControlPlaneDensityController(ctrlCtx *controllerContext) error {
+	return controlplanedensity.Add(
+		ctrlCtx.mgr,
+		ctrlCtx.log,
+		ctrlCtx.seedGetter,
+	)
+}
+
+func createEventBusController(ctrlCtx *controllerContext) error {
+	if ctrlCtx.runOptions.eventBusURL == "" {
+		return nil
+	}
+
+	publisher, err := eventbus.NewPublisher(ctrlCtx.runOptions.eventBusURL, ctrlCtx.runOptions.eventBusTopicPrefix)
+	if err != nil {
+		return fmt.Errorf("failed to create event bus publisher: %v", err)
+	}
+
+	return eventbuscontroller.Add(
+		ctrlCtx.mgr,
+		ctrlCtx.runOptions.workerCount,
+		ctrlCtx.runOptions.workerName,
+		ctrlCtx.log,
+		ctrlCtx.seedGetter,
+		ctrlCtx.clientProvider,
+		publisher,
+	)
+}
+
+func createClusterNamespaceController(ctrlCtx *controllerContext) error {
+	return clusternamespacecontroller.Add(
+		ctrlCtx.mgr,
+		ctrlCtx.runOptions.workerCount,
+		ctrlCtx.runOptions.workerName,
+		ctrlCtx.log,
+		ctrlCtx.clientProvider,
+	)
+}
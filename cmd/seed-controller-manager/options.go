@@ -26,12 +26,15 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"go.uber.org/zap"
 
 	"k8c.io/kubermatic/v2/pkg/cluster/client"
 	"k8c.io/kubermatic/v2/pkg/controller/operator/common"
+	"k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/apideprecation"
 	backupcontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/backup"
+	"k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/credentialrotation"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/features"
 	"k8c.io/kubermatic/v2/pkg/provider"
@@ -88,7 +91,12 @@ type controllerRunOptions struct {
 	admissionWebhook                                 webhook.Options
 	concurrentClusterUpdate                          int
 	addonEnforceInterval                             int
+	apiDeprecationScanInterval                       time.Duration
+	credentialRotationInterval                       time.Duration
+	eventBusURL                                      string
+	eventBusTopicPrefix                              string
 	caBundle                                         *certificates.CABundle
+	azureInstallationID                              string
 
 	// OIDC configuration
 	oidcIssuerURL          string
@@ -172,7 +180,12 @@ func newControllerRunOptions() (controllerRunOptions, error) {
 	flag.IntVar(&c.schedulerDefaultReplicas, "scheduler-default-replicas", 1, "The default number of replicas for usercluster schedulers")
 	flag.IntVar(&c.concurrentClusterUpdate, "max-parallel-reconcile", 10, "The default number of resources updates per cluster")
 	flag.IntVar(&c.addonEnforceInterval, "addon-enforce-interval", 5, "Check and ensure default usercluster addons are deployed every interval in minutes. Set to 0 to disable.")
+	flag.DurationVar(&c.apiDeprecationScanInterval, "api-deprecation-scan-interval", apideprecation.DefaultScanInterval, "Interval in which user clusters are scanned for APIs removed in the next Kubernetes version. Set to 0 to disable.")
+	flag.DurationVar(&c.credentialRotationInterval, "credential-rotation-interval", credentialrotation.DefaultRotationInterval, "Maximum age of the tokens and certificates generated for the control plane of user clusters, after which they are rotated. Rotating the admin token invalidates downloaded admin kubeconfigs. Set to 0 to disable.")
+	flag.StringVar(&c.eventBusURL, "event-bus-url", "", "URL of the NATS server (nats://host:port) or the Kafka brokers (kafka://host:port[,host:port...]) cluster lifecycle, health and backup events are published to. Leave empty to disable publishing.")
+	flag.StringVar(&c.eventBusTopicPrefix, "event-bus-topic-prefix", "kubermatic", "Prefix of the topics, respectively NATS subjects, events are published to, e.g. kubermatic.cluster.created.")
 	flag.StringVar(&caBundleFile, "ca-bundle", "", "File containing the PEM-encoded CA bundle for all userclusters")
+	flag.StringVar(&c.azureInstallationID, "azure-installation-id", "", "The ID of this installation, which is added as a tag to all Azure resources created for clusters. Has to match the flag of the master controller manager for the Azure resource garbage collection.")
 	flag.Var(&c.tunnelingAgentIP, "tunneling-agent-ip", "The address used by the tunneling agents.")
 	flag.BoolVar(&c.enableUserClusterMLA, "enable-user-cluster-mla", false, "Enables user cluster MLA (Monitoring, Logging & Alerting) stack in the seed.")
 	flag.StringVar(&c.mlaNamespace, "mla-namespace", "mla", "The namespace in which the user cluster MLA stack is running.")
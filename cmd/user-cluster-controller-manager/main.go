@@ -26,14 +26,20 @@ import (
 	"go.uber.org/zap"
 
 	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+	canaryrollout "k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/canary-rollout"
 	ccmcsimigrator "k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/ccm-csi-migrator"
+	cinderstorageclass "k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/cinder-storage-class"
 	clusterrolelabeler "k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/cluster-role-labeler"
 	constraintsyncer "k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/constraint-syncer"
 	"k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/flatcar"
+	instancetypefallback "k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/instance-type-fallback"
 	"k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/ipam"
 	nodelabeler "k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/node-labeler"
+	nodemaintenance "k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/node-maintenance"
+	nodeproblems "k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/node-problems"
 	ownerbindingcreator "k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/owner-binding-creator"
 	rbacusercluster "k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/rbac"
+	replicasfreeze "k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/replicas-freeze"
 	usercluster "k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/resources"
 	machinecontrolerresources "k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/resources/resources/machine-controller"
 	rolecloner "k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/role-cloner"
@@ -86,6 +92,10 @@ type controllerRunOptions struct {
 	userClusterLogging    bool
 	userClusterMonitoring bool
 	ccmMigration          bool
+	logShippingType       string
+	logShippingEndpoint   string
+	logShippingLabels     string
+	logShippingInsecure   bool
 }
 
 func main() {
@@ -123,6 +133,10 @@ func main() {
 	flag.BoolVar(&runOp.userClusterLogging, "user-cluster-logging", false, "Enable logging in user cluster.")
 	flag.BoolVar(&runOp.userClusterMonitoring, "user-cluster-monitoring", false, "Enable monitoring in user cluster.")
 	flag.BoolVar(&runOp.ccmMigration, "ccm-migration", false, "Enable ccm migration in user cluster.")
+	flag.StringVar(&runOp.logShippingType, "log-shipping-type", "", "The type of the destination the container logs are shipped to, one of loki, elasticsearch or syslog. If unset, log shipping is disabled.")
+	flag.StringVar(&runOp.logShippingEndpoint, "log-shipping-endpoint", "", "The URL of the destination the container logs are shipped to.")
+	flag.StringVar(&runOp.logShippingLabels, "log-shipping-labels", "", "A json-encoded map of labels added to all shipped log records.")
+	flag.BoolVar(&runOp.logShippingInsecure, "log-shipping-insecure-skip-verify", false, "Disable verifying the certificate of the log shipping destination.")
 
 	flag.Parse()
 
@@ -170,6 +184,23 @@ func main() {
 		}
 	}
 
+	var logShipping *kubermaticv1.LogShippingSettings
+	if runOp.logShippingType != "" {
+		if runOp.logShippingEndpoint == "" {
+			log.Fatal("-log-shipping-endpoint must be set when enabling log shipping")
+		}
+		logShipping = &kubermaticv1.LogShippingSettings{
+			Type:               kubermaticv1.LogShippingDestinationType(runOp.logShippingType),
+			Endpoint:           runOp.logShippingEndpoint,
+			InsecureSkipVerify: runOp.logShippingInsecure,
+		}
+		if runOp.logShippingLabels != "" {
+			if err := json.Unmarshal([]byte(runOp.logShippingLabels), &logShipping.Labels); err != nil {
+				log.Fatalw("Failed to unmarshal value of --log-shipping-labels arg", zap.Error(err))
+			}
+		}
+	}
+
 	cfg, err := config.GetConfig()
 	if err != nil {
 		log.Fatalw("Failed getting user cluster controller config", zap.Error(err))
@@ -256,6 +287,7 @@ func main() {
 			Monitoring:    runOp.userClusterMonitoring,
 			MLAGatewayURL: runOp.mlaGatewayURL,
 		},
+		logShipping,
 		log,
 	); err != nil {
 		log.Fatalw("Failed to register user cluster controller", zap.Error(err))
@@ -300,6 +332,29 @@ func main() {
 	}
 	log.Info("Registered nodelabel controller")
 
+	if err := instancetypefallback.Add(rootCtx, log, mgr); err != nil {
+		log.Fatalw("Failed to register instancetypefallback controller", zap.Error(err))
+	}
+	log.Info("Registered instancetypefallback controller")
+
+	if err := canaryrollout.Add(rootCtx, log, mgr); err != nil {
+		log.Fatalw("Failed to register canaryrollout controller", zap.Error(err))
+	}
+	log.Info("Registered canaryrollout controller")
+
+	if err := replicasfreeze.Add(rootCtx, log, mgr); err != nil {
+		log.Fatalw("Failed to register replicasfreeze controller", zap.Error(err))
+	}
+	log.Info("Registered replicasfreeze controller")
+
+	// Only clusters with an external cloud provider use the Cinder CSI driver.
+	if runOp.cloudProviderName == "external" {
+		if err := cinderstorageclass.Add(log, mgr); err != nil {
+			log.Fatalw("Failed to register cinderstorageclass controller", zap.Error(err))
+		}
+		log.Info("Registered cinderstorageclass controller")
+	}
+
 	if err := clusterrolelabeler.Add(rootCtx, log, mgr); err != nil {
 		log.Fatalw("Failed to register clusterrolelabeler controller", zap.Error(err))
 	}
@@ -315,6 +370,16 @@ func main() {
 	}
 	log.Info("Registered ownerbindingcreator controller")
 
+	if err := nodeproblems.Add(rootCtx, log, seedMgr, mgr, runOp.clusterName); err != nil {
+		log.Fatalw("Failed to register nodeproblems controller", zap.Error(err))
+	}
+	log.Info("Registered nodeproblems controller")
+
+	if err := nodemaintenance.Add(rootCtx, log, seedMgr, mgr, runOp.clusterName); err != nil {
+		log.Fatalw("Failed to register nodemaintenance controller", zap.Error(err))
+	}
+	log.Info("Registered nodemaintenance controller")
+
 	if runOp.ccmMigration {
 		if err := ccmcsimigrator.Add(rootCtx, log, seedMgr, mgr, versions, runOp.clusterName); err != nil {
 			log.Fatalw("failed to register ccm-csi-migrator controller", zap.Error(err))
@@ -60,6 +60,21 @@ func main() {
 				ImportAlias:  "corev1",
 				// Don't specify ResourceImportPath so this block does not create a new import line in the generated code
 			},
+			{
+				ResourceName: "ResourceQuota",
+				ImportAlias:  "corev1",
+				// Don't specify ResourceImportPath so this block does not create a new import line in the generated code
+			},
+			{
+				ResourceName: "LimitRange",
+				ImportAlias:  "corev1",
+				// Don't specify ResourceImportPath so this block does not create a new import line in the generated code
+			},
+			{
+				ResourceName: "PersistentVolumeClaim",
+				ImportAlias:  "corev1",
+				// Don't specify ResourceImportPath so this block does not create a new import line in the generated code
+			},
 			{
 				ResourceName:       "StatefulSet",
 				ImportAlias:        "appsv1",
@@ -141,6 +156,12 @@ func main() {
 				ImportAlias:        "networkingv1beta1",
 				ResourceImportPath: "k8s.io/api/networking/v1beta1",
 			},
+			{
+				ResourceName:       "NetworkPolicy",
+				ResourceNamePlural: "NetworkPolicies",
+				ImportAlias:        "networkingv1",
+				ResourceImportPath: "k8s.io/api/networking/v1",
+			},
 			{
 				ResourceName:       "Seed",
 				ImportAlias:        "kubermaticv1",
@@ -191,6 +212,16 @@ func main() {
 				ImportAlias:      "kubermaticv1",
 				APIVersionPrefix: "KubermaticV1",
 			},
+			{
+				ResourceName:     "Preset",
+				ImportAlias:      "kubermaticv1",
+				APIVersionPrefix: "KubermaticV1",
+			},
+			{
+				ResourceName:     "AddonConfig",
+				ImportAlias:      "kubermaticv1",
+				APIVersionPrefix: "KubermaticV1",
+			},
 		},
 	}
 
@@ -39,38 +39,32 @@ fi
 
 echodate "SSH public key will be $(head -c 25 ${E2E_SSH_PUBKEY})...$(tail -c 25 ${E2E_SSH_PUBKEY})"
 
-EXTRA_ARGS=""
-provider="${PROVIDER:-aws}"
-if [[ $provider == "aws" ]]; then
-  EXTRA_ARGS="-aws-access-key-id=${AWS_E2E_TESTS_KEY_ID}
-    -aws-secret-access-key=${AWS_E2E_TESTS_SECRET}"
-elif [[ $provider == "packet" ]]; then
-  EXTRA_ARGS="-packet-api-key=${PACKET_API_KEY}
-    -packet-project-id=${PACKET_PROJECT_ID}"
-elif [[ $provider == "gcp" ]]; then
-  EXTRA_ARGS="-gcp-service-account=${GOOGLE_SERVICE_ACCOUNT}"
-elif [[ $provider == "azure" ]]; then
-  EXTRA_ARGS="-azure-client-id=${AZURE_E2E_TESTS_CLIENT_ID}
-    -azure-client-secret=${AZURE_E2E_TESTS_CLIENT_SECRET}
-    -azure-tenant-id=${AZURE_E2E_TESTS_TENANT_ID}
-    -azure-subscription-id=${AZURE_E2E_TESTS_SUBSCRIPTION_ID}"
-elif [[ $provider == "digitalocean" ]]; then
-  EXTRA_ARGS="-digitalocean-token=${DO_E2E_TESTS_TOKEN}"
-elif [[ $provider == "hetzner" ]]; then
-  EXTRA_ARGS="-hetzner-token=${HZ_E2E_TOKEN}"
-elif [[ $provider == "openstack" ]]; then
-  EXTRA_ARGS="-openstack-domain=${OS_DOMAIN}
-    -openstack-tenant=${OS_TENANT_NAME}
-    -openstack-username=${OS_USERNAME}
-    -openstack-password=${OS_PASSWORD}"
-elif [[ $provider == "vsphere" ]]; then
-  EXTRA_ARGS="-vsphere-username=${VSPHERE_E2E_USERNAME}
-    -vsphere-password=${VSPHERE_E2E_PASSWORD}"
-elif [[ $provider == "kubevirt" ]]; then
-  EXTRA_ARGS="-kubevirt-kubeconfig=${KUBEVIRT_E2E_TESTS_KUBECONFIG}"
-elif [[ $provider == "alibaba" ]]; then
-  EXTRA_ARGS="-alibaba-access-key-id=${ALIBABA_E2E_TESTS_KEY_ID}
-    -alibaba-secret-access-key=${ALIBABA_E2E_TESTS_SECRET}"
+# The conformance tester reads the credentials of all providers from the
+# environment, so the CI secrets only need to be mapped to the expected names
+# where they differ.
+export AWS_ACCESS_KEY_ID="${AWS_E2E_TESTS_KEY_ID:-}"
+export AWS_SECRET_ACCESS_KEY="${AWS_E2E_TESTS_SECRET:-}"
+export AZURE_CLIENT_ID="${AZURE_E2E_TESTS_CLIENT_ID:-}"
+export AZURE_CLIENT_SECRET="${AZURE_E2E_TESTS_CLIENT_SECRET:-}"
+export AZURE_TENANT_ID="${AZURE_E2E_TESTS_TENANT_ID:-}"
+export AZURE_SUBSCRIPTION_ID="${AZURE_E2E_TESTS_SUBSCRIPTION_ID:-}"
+export DO_TOKEN="${DO_E2E_TESTS_TOKEN:-}"
+export HZ_TOKEN="${HZ_E2E_TOKEN:-}"
+export VSPHERE_USERNAME="${VSPHERE_E2E_USERNAME:-}"
+export VSPHERE_PASSWORD="${VSPHERE_E2E_PASSWORD:-}"
+export KUBEVIRT_KUBECONFIG="${KUBEVIRT_E2E_TESTS_KUBECONFIG:-}"
+export ALIBABA_ACCESS_KEY_ID="${ALIBABA_E2E_TESTS_KEY_ID:-}"
+export ALIBABA_ACCESS_KEY_SECRET="${ALIBABA_E2E_TESTS_SECRET:-}"
+
+# a datacenter implies its provider
+if [ -n "${DATACENTER:-}" ]; then
+  EXTRA_ARGS="-datacenter=$DATACENTER"
+else
+  EXTRA_ARGS="-providers=${PROVIDER:-aws}"
+fi
+
+if [ -n "${SCENARIO_STEPS:-}" ]; then
+  EXTRA_ARGS="$EXTRA_ARGS -scenario-steps=$SCENARIO_STEPS -upgrade-version=${UPGRADE_VERSION:-}"
 fi
 
 timeout -s 9 90m ./_build/conformance-tests $EXTRA_ARGS \
@@ -83,7 +77,6 @@ timeout -s 9 90m ./_build/conformance-tests $EXTRA_ARGS \
   -reports-root=/reports \
   -create-oidc-token=true \
   -versions="$VERSIONS_TO_TEST" \
-  -providers=$provider \
   -node-ssh-pub-key="$E2E_SSH_PUBKEY" \
   -distributions="${DISTRIBUTIONS:-}" \
   -exclude-distributions="${EXCLUDE_DISTRIBUTIONS:-}" \
@@ -19,8 +19,10 @@
 ### to be installed, with a `$KUBECONFIG` pointing to the KKP master cluster.
 ###
 ### The tests run against a single provider, specified via the `PROVIDER`
-### environment variable (default: `aws`). See this script for the
-### credential variables for each provider.
+### environment variable (default: `aws`), or against a single datacenter
+### specified via `DATACENTER`, which implies its provider. The credentials
+### are read from the environment by the conformance tests themselves, see
+### `CREDENTIAL_VARIABLES` below for their names.
 ###
 ### OIDC credentials need to be provided either by specifying
 ### `KUBERMATIC_OIDC_LOGIN` and `KUBERMATIC_OIDC_PASSWORD` environment
@@ -54,79 +56,19 @@ if [ -z "${KUBECONFIG:-}" ]; then
   vault kv get -field=kubeconfig dev/seed-clusters/dev.kubermatic.io > $KUBECONFIG
 fi
 
-extraArgs=""
-provider="${PROVIDER:-aws}"
-
-case "$provider" in
-alibaba)
-  extraArgs="-alibaba-access-key-id=$ALIBABA_KEY_ID
-      -alibaba-secret-access-key=$ALIBABA_SECRET"
-  ;;
-
-aws)
-  AWS_ACCESS_KEY_ID="${AWS_ACCESS_KEY_ID:-$(vault kv get -field=accessKeyID dev/e2e-aws)}"
-  AWS_SECRET_ACCESS_KEY="${AWS_SECRET_ACCESS_KEY:-$(vault kv get -field=secretAccessKey dev/e2e-aws)}"
-  extraArgs="-aws-access-key-id=$AWS_ACCESS_KEY_ID
-      -aws-secret-access-key=$AWS_SECRET_ACCESS_KEY"
-  ;;
-
-azure)
-  AZURE_CLIENT_ID="${AZURE_CLIENT_ID:-$(vault kv get -field=clientID dev/e2e-azure)}"
-  AZURE_CLIENT_SECRET="${AZURE_CLIENT_SECRET:-$(vault kv get -field=clientSecret dev/e2e-azure)}"
-  AZURE_TENANT_ID="${AZURE_TENANT_ID:-$(vault kv get -field=tenantID dev/e2e-azure)}"
-  AZURE_SUBSCRIPTION_ID="${AZURE_SUBSCRIPTION_ID:-$(vault kv get -field=subscriptionID dev/e2e-azure)}"
-  extraArgs="-azure-client-id=$AZURE_CLIENT_ID
-      -azure-client-secret=$AZURE_CLIENT_SECRET
-      -azure-tenant-id=$AZURE_TENANT_ID
-      -azure-subscription-id=$AZURE_SUBSCRIPTION_ID"
-  ;;
-
-digitalocean)
-  DO_TOKEN="${DO_TOKEN:-$(vault kv get -field=token dev/e2e-digitalocean)}"
-  extraArgs="-digitalocean-token=$DO_TOKEN"
-  ;;
-
-gcp)
-  extraArgs="-gcp-service-account=$GOOGLE_SERVICE_ACCOUNT"
-  ;;
-
-hetzner)
-  HZ_TOKEN="${HZ_TOKEN:-$(vault kv get -field=token dev/e2e-hetzner)}"
-  extraArgs="-hetzner-token=$HZ_TOKEN"
-  ;;
-
-kubevirt)
-  extraArgs="-kubevirt-kubeconfig=${KUBEVIRT_KUBECONFIG}"
-  ;;
-
-openstack)
-  OS_DOMAIN="${OS_DOMAIN:-$(vault kv get -field=OS_USER_DOMAIN_NAME dev/syseleven-openstack)}"
-  OS_TENANT_NAME="${OS_TENANT_NAME:-$(vault kv get -field=OS_TENANT_NAME dev/syseleven-openstack)}"
-  OS_USERNAME="${OS_USERNAME:-$(vault kv get -field=username dev/syseleven-openstack)}"
-  OS_PASSWORD="${OS_PASSWORD:-$(vault kv get -field=password dev/syseleven-openstack)}"
-  extraArgs="-openstack-domain=$OS_DOMAIN
-      -openstack-tenant=$OS_TENANT_NAME
-      -openstack-username=$OS_USERNAME
-      -openstack-password=$OS_PASSWORD"
-  ;;
-
-packet)
-  extraArgs="-packet-api-key=$PACKET_API_KEY
-      -packet-project-id=$PACKET_PROJECT_ID"
-  ;;
-
-vsphere)
-  VSPHERE_USERNAME="${VSPHERE_USERNAME:-$(vault kv get -field=username dev/vsphere)}"
-  VSPHERE_PASSWORD="${VSPHERE_PASSWORD:-$(vault kv get -field=password dev/vsphere)}"
-  extraArgs="-vsphere-username=$VSPHERE_USERNAME
-      -vsphere-password=$VSPHERE_PASSWORD"
-  ;;
-
-*)
-  echodate "Unknown provider $provider (\$PROVIDER) selected."
-  exit 1
-  ;;
-esac
+CREDENTIAL_VARIABLES="AWS_ACCESS_KEY_ID AWS_SECRET_ACCESS_KEY
+  AZURE_CLIENT_ID AZURE_CLIENT_SECRET AZURE_TENANT_ID AZURE_SUBSCRIPTION_ID
+  DO_TOKEN HZ_TOKEN GOOGLE_SERVICE_ACCOUNT KUBEVIRT_KUBECONFIG
+  OS_DOMAIN OS_TENANT_NAME OS_USERNAME OS_PASSWORD
+  VSPHERE_USERNAME VSPHERE_PASSWORD PACKET_API_KEY PACKET_PROJECT_ID
+  ALIBABA_ACCESS_KEY_ID ALIBABA_ACCESS_KEY_SECRET"
+
+# a datacenter implies its provider
+if [ -n "${DATACENTER:-}" ]; then
+  extraArgs="-datacenter=$DATACENTER"
+else
+  extraArgs="-providers=${PROVIDER:-aws}"
+fi
 
 if [ -n "${VERSIONS:-}" ]; then
   extraArgs="$extraArgs -versions=$VERSIONS"
@@ -168,7 +110,6 @@ if [ -n "${NO_DOCKER:-}" ]; then
     -kubermatic-endpoint="$endpoint" \
     -kubermatic-oidc-token="$oidcToken" \
     -kubermatic-delete-cluster=true \
-    -providers="$provider" \
     -distributions="flatcar" \
     $@
 else
@@ -177,8 +118,15 @@ else
   # run inside the container
   GOOS=linux GOARCH=amd64 make conformance-tests
 
+  # forward the credentials without revealing them as CLI flags
+  credentialEnv=""
+  for variable in $CREDENTIAL_VARIABLES; do
+    credentialEnv="$credentialEnv --env $variable"
+  done
+
   echodate "Starting conformance-tests in Docker..."
   docker run \
+    $credentialEnv \
     --rm \
     --interactive \
     --tty \
@@ -200,7 +148,6 @@ else
     -kubermatic-endpoint="$endpoint" \
     -kubermatic-oidc-token="$oidcToken" \
     -kubermatic-delete-cluster=true \
-    -providers="$provider" \
     -distributions="flatcar" \
     $@
 fi
@@ -19,6 +19,7 @@ package tools
 
 import (
 	_ "github.com/go-swagger/go-swagger/cmd/swagger"
+	_ "github.com/golang/protobuf/protoc-gen-go"
 
 	_ "k8s.io/code-generator"
 	_ "k8s.io/code-generator/cmd/client-gen"
@@ -0,0 +1,38 @@
+#!/usr/bin/env bash
+
+# Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+set -euo pipefail
+
+cd $(dirname $0)/..
+source hack/lib.sh
+
+if ! [ -x "$(command -v protoc)" ]; then
+  echodate "protoc is required to generate the gRPC API, see https://grpc.io/docs/protoc-installation/"
+  exit 1
+fi
+
+TMP_DIR="$(mktemp -d)"
+trap "rm -rf $TMP_DIR" EXIT
+
+echodate "Building protoc-gen-go"
+go build -o "$TMP_DIR/protoc-gen-go" github.com/golang/protobuf/protoc-gen-go
+
+echodate "Generating gRPC API"
+protoc \
+  --plugin=protoc-gen-go="$TMP_DIR/protoc-gen-go" \
+  --go_out=plugins=grpc,paths=source_relative:. \
+  pkg/api/grpc/v1/*.proto
+echodate "Completed."
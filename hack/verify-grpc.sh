@@ -0,0 +1,44 @@
+#!/usr/bin/env bash
+
+# Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+set -euo pipefail
+
+cd $(dirname $0)/..
+source hack/lib.sh
+
+DIFFROOT=pkg/api/grpc
+TMP_DIFFROOT="$(mktemp -d)"
+trap "rm -rf $TMP_DIFFROOT" EXIT
+
+cp -a "${DIFFROOT}"/* "${TMP_DIFFROOT}"
+
+./hack/update-grpc.sh
+
+echodate "Diffing ${DIFFROOT} against freshly generated gRPC API"
+ret=0
+diff -Naupr "${TMP_DIFFROOT}" "${DIFFROOT}" || ret=$?
+cp -a "${TMP_DIFFROOT}"/* "${DIFFROOT}"
+if [[ $ret -ne 0 ]]; then
+  echodate "${DIFFROOT} is out of date. Please run hack/update-grpc.sh"
+  exit 1
+fi
+
+# cluster.proto is a subset of the API models, make sure that it is still
+# in line with them
+echodate "Verifying that the gRPC API matches the API models"
+go test ./pkg/handler/rpc -run TestProtoMatchesAPIModels
+
+echodate "${DIFFROOT} up to date."
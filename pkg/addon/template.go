@@ -21,7 +21,10 @@ import (
 	"bytes"
 	"fmt"
 	"io/ioutil"
+	"os"
 	"path"
+	"regexp"
+	"strconv"
 	"strings"
 	"text/template"
 
@@ -32,6 +35,7 @@ import (
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/provider"
 	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/registry"
 	"k8c.io/kubermatic/v2/pkg/util/yaml"
 
 	"k8s.io/apimachinery/pkg/runtime"
@@ -42,14 +46,14 @@ const (
 	ClusterTypeKubernetes = "kubernetes"
 )
 
-func txtFuncMap(overwriteRegistry string) template.FuncMap {
+// versionedManifestPattern matches manifest files which are variants of another manifest
+// for clusters starting with a given Kubernetes minor version, for example "rbac.v1.21.yaml"
+// is used instead of "rbac.yaml" for clusters running Kubernetes 1.21 or newer.
+var versionedManifestPattern = regexp.MustCompile(`^(.+)\.v(\d+)\.(\d+)(\.[^.]+)$`)
+
+func txtFuncMap(registryWithOverwrite registry.WithOverwriteFunc) template.FuncMap {
 	funcs := sprig.TxtFuncMap()
-	funcs["Registry"] = func(registry string) string {
-		if overwriteRegistry != "" {
-			return overwriteRegistry
-		}
-		return registry
-	}
+	funcs["Registry"] = registryWithOverwrite
 
 	return funcs
 }
@@ -80,6 +84,15 @@ func NewTemplateData(
 		return nil, fmt.Errorf("failed to determine cloud provider name: %v", err)
 	}
 
+	var additionalProviderNames []string
+	for _, cloud := range cluster.Spec.AdditionalClouds {
+		name, err := provider.ClusterCloudProviderName(cloud)
+		if err != nil {
+			return nil, fmt.Errorf("failed to determine cloud provider name of additional cloud %q: %v", cloud.DatacenterName, err)
+		}
+		additionalProviderNames = append(additionalProviderNames, name)
+	}
+
 	if variables == nil {
 		variables = make(map[string]interface{})
 	}
@@ -104,22 +117,24 @@ func NewTemplateData(
 		Variables:      variables,
 		Credentials:    credentials,
 		Cluster: ClusterData{
-			Type:                 ClusterTypeKubernetes,
-			Name:                 cluster.Name,
-			HumanReadableName:    cluster.Spec.HumanReadableName,
-			Namespace:            cluster.Status.NamespaceName,
-			Labels:               cluster.Labels,
-			Annotations:          cluster.Annotations,
-			Kubeconfig:           kubeconfig,
-			OwnerName:            cluster.Status.UserName,
-			OwnerEmail:           cluster.Status.UserEmail,
-			ApiserverExternalURL: cluster.Address.URL,
-			ApiserverInternalURL: fmt.Sprintf("https://%s:%d", cluster.Address.InternalName, cluster.Address.Port),
-			AdminToken:           cluster.Address.AdminToken,
-			CloudProviderName:    providerName,
-			Version:              semver.MustParse(cluster.Spec.Version.String()),
-			MajorMinorVersion:    cluster.Spec.Version.MajorMinor(),
-			Features:             sets.StringKeySet(cluster.Spec.Features),
+			Type:                         ClusterTypeKubernetes,
+			Name:                         cluster.Name,
+			HumanReadableName:            cluster.Spec.HumanReadableName,
+			Namespace:                    cluster.Status.NamespaceName,
+			Labels:                       cluster.Labels,
+			Annotations:                  cluster.Annotations,
+			Kubeconfig:                   kubeconfig,
+			OwnerName:                    cluster.Status.UserName,
+			OwnerEmail:                   cluster.Status.UserEmail,
+			ApiserverExternalURL:         cluster.Address.URL,
+			ApiserverInternalURL:         fmt.Sprintf("https://%s:%d", cluster.Address.InternalName, cluster.Address.Port),
+			AdminToken:                   cluster.Address.AdminToken,
+			CloudProviderName:            providerName,
+			AdditionalCloudProviderNames: additionalProviderNames,
+			Version:                      semver.MustParse(cluster.Spec.Version.String()),
+			MajorMinorVersion:            cluster.Spec.Version.MajorMinor(),
+			Features:                     sets.StringKeySet(cluster.Spec.Features),
+			ContainerRuntime:             cluster.Spec.ContainerRuntime,
 			Network: ClusterNetwork{
 				DNSDomain:         cluster.Spec.ClusterNetwork.DNSDomain,
 				DNSClusterIP:      dnsClusterIP,
@@ -172,6 +187,9 @@ type ClusterData struct {
 	// "hetzner", "kubevirt", "openstack", "packet", "vsphere" depending on
 	// the configured datacenters.
 	CloudProviderName string
+	// AdditionalCloudProviderNames are the names of the cloud providers of the additional clouds
+	// of the cluster. Their nodes are labelled with "kubermatic.io/cloud-provider".
+	AdditionalCloudProviderNames []string
 	// Version is the exact cluster version.
 	Version *semver.Version
 	// MajorMinorVersion is a shortcut for common testing on "Major.Minor".
@@ -182,6 +200,11 @@ type ClusterData struct {
 	Features sets.String
 	// CNIPlugin contains the CNIPlugin settings
 	CNIPlugin CNIPlugin
+	// ContainerRuntime is the container runtime of the nodes, "docker" or "containerd".
+	ContainerRuntime string
+	// AzureLogAnalytics contains the Log Analytics workspace the nodes of Azure clusters
+	// send their logs and metrics to. It is empty if the cluster has no workspace.
+	AzureLogAnalytics AzureLogAnalytics
 }
 
 type ClusterNetwork struct {
@@ -198,7 +221,12 @@ type CNIPlugin struct {
 	Version string
 }
 
-func ParseFromFolder(log *zap.SugaredLogger, overwriteRegistry string, manifestPath string, data *TemplateData) ([]runtime.RawExtension, error) {
+type AzureLogAnalytics struct {
+	WorkspaceID  string
+	WorkspaceKey string
+}
+
+func ParseFromFolder(log *zap.SugaredLogger, registryWithOverwrite registry.WithOverwriteFunc, manifestPath string, data *TemplateData) ([]runtime.RawExtension, error) {
 	var allManifests []runtime.RawExtension
 
 	infos, err := ioutil.ReadDir(manifestPath)
@@ -206,13 +234,13 @@ func ParseFromFolder(log *zap.SugaredLogger, overwriteRegistry string, manifestP
 		return nil, err
 	}
 
-	for _, info := range infos {
+	for _, info := range selectManifestVariants(infos, data.Cluster.Version) {
 		filename := path.Join(manifestPath, info.Name())
 		infoLog := log.With("file", filename)
 
 		// recurse into subdirectory
 		if info.IsDir() {
-			subManifests, err := ParseFromFolder(log, overwriteRegistry, filename, data)
+			subManifests, err := ParseFromFolder(log, registryWithOverwrite, filename, data)
 			if err != nil {
 				return nil, err
 			}
@@ -227,7 +255,7 @@ func ParseFromFolder(log *zap.SugaredLogger, overwriteRegistry string, manifestP
 			return nil, fmt.Errorf("failed to read file %s: %v", filename, err)
 		}
 
-		tpl, err := template.New(info.Name()).Funcs(txtFuncMap(overwriteRegistry)).Parse(string(fbytes))
+		tpl, err := template.New(info.Name()).Funcs(txtFuncMap(registryWithOverwrite)).Parse(string(fbytes))
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse file %s: %v", filename, err)
 		}
@@ -252,3 +280,64 @@ func ParseFromFolder(log *zap.SugaredLogger, overwriteRegistry string, manifestP
 
 	return allManifests, nil
 }
+
+// selectManifestVariants filters the given directory entries so that of every manifest
+// only the variant best matching the cluster version remains. A manifest without
+// version suffix is the fallback for all versions; a versioned variant is used for
+// clusters running the same or a newer minor version, the highest applicable variant
+// wins. Directories and files are otherwise returned unchanged and in their original
+// order.
+func selectManifestVariants(infos []os.FileInfo, version *semver.Version) []os.FileInfo {
+	type variant struct {
+		index int
+		major uint64
+		minor uint64
+	}
+
+	selected := map[string]variant{}
+	for i, info := range infos {
+		if info.IsDir() {
+			continue
+		}
+
+		name := info.Name()
+		v := variant{index: i}
+
+		if match := versionedManifestPattern.FindStringSubmatch(name); match != nil {
+			major, majorErr := strconv.ParseUint(match[2], 10, 64)
+			minor, minorErr := strconv.ParseUint(match[3], 10, 64)
+			if majorErr != nil || minorErr != nil {
+				continue
+			}
+
+			// variants for newer versions and, when the cluster version is unknown,
+			// all variants are skipped
+			if version == nil || major > version.Major() || (major == version.Major() && minor > version.Minor()) {
+				continue
+			}
+
+			name = match[1] + match[4]
+			v.major = major
+			v.minor = minor
+		}
+
+		current, exists := selected[name]
+		if !exists || v.major > current.major || (v.major == current.major && v.minor > current.minor) {
+			selected[name] = v
+		}
+	}
+
+	indices := sets.NewInt()
+	for _, v := range selected {
+		indices.Insert(v.index)
+	}
+
+	var result []os.FileInfo
+	for i, info := range infos {
+		if info.IsDir() || indices.Has(i) {
+			result = append(result, info)
+		}
+	}
+
+	return result
+}
@@ -23,10 +23,12 @@ import (
 	"path/filepath"
 	"testing"
 
+	semverlib "github.com/Masterminds/semver/v3"
 	"go.uber.org/zap"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/registry"
 	"k8c.io/kubermatic/v2/pkg/semver"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -92,10 +94,13 @@ func testRenderAddonsForOrchestrator(t *testing.T, orchestrator string) {
 			if err != nil {
 				t.Fatalf("Rendering %s addon %s for cluster %s failed: %v", orchestrator, addon.Name, cluster.Name, err)
 			}
+			if cluster.Spec.Cloud.Azure != nil {
+				data.Cluster.AzureLogAnalytics = AzureLogAnalytics{WorkspaceID: "workspace-id", WorkspaceKey: "workspace-key"}
+			}
 
 			path := filepath.Join(addonBasePath, addon.Name)
 
-			_, err = ParseFromFolder(log, "", path, data)
+			_, err = ParseFromFolder(log, registry.GetOverwriteFunc(""), path, data)
 			if err != nil {
 				t.Fatalf("Rendering %s addon %s for cluster %s failed: %v", orchestrator, addon.Name, cluster.Name, err)
 			}
@@ -126,3 +131,57 @@ func TestNewTemplateData(t *testing.T) {
 		t.Fatalf("Expected cluster features to contain %q, but does not.", feature)
 	}
 }
+
+func TestSelectManifestVariants(t *testing.T) {
+	dir, err := ioutil.TempDir("", "addon")
+	if err != nil {
+		t.Fatalf("Failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := []string{"deployment.yaml", "rbac.yaml", "rbac.v1.19.yaml", "rbac.v1.21.yaml", "psp.v1.25.yaml", "crd.v1.16.yaml"}
+	for _, file := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, file), nil, 0644); err != nil {
+			t.Fatalf("Failed to create %s: %v", file, err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(dir, "subdir.v1.30.yaml"), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("Failed to read directory: %v", err)
+	}
+
+	testCases := []struct {
+		version  string
+		expected []string
+	}{
+		{
+			version:  "1.18.3",
+			expected: []string{"crd.v1.16.yaml", "deployment.yaml", "rbac.yaml", "subdir.v1.30.yaml"},
+		},
+		{
+			version:  "1.20.0",
+			expected: []string{"crd.v1.16.yaml", "deployment.yaml", "rbac.v1.19.yaml", "subdir.v1.30.yaml"},
+		},
+		{
+			version:  "1.25.1",
+			expected: []string{"crd.v1.16.yaml", "deployment.yaml", "psp.v1.25.yaml", "rbac.v1.21.yaml", "subdir.v1.30.yaml"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.version, func(t *testing.T) {
+			var names []string
+			for _, info := range selectManifestVariants(infos, semverlib.MustParse(tc.version)) {
+				names = append(names, info.Name())
+			}
+
+			if fmt.Sprint(names) != fmt.Sprint(tc.expected) {
+				t.Fatalf("Expected %v, got %v", tc.expected, names)
+			}
+		})
+	}
+}
@@ -97,6 +97,14 @@ type DatacenterSpec struct {
 	// EnforcePodSecurityPolicy enforces pod security policy plugin on every clusters within the DC,
 	// ignoring cluster-specific settings
 	EnforcePodSecurityPolicy bool `json:"enforcePodSecurityPolicy"`
+
+	// CapacityLimits are hard limits for the number of clusters and nodes in the DC,
+	// enforced when clusters and node deployments are created or scaled.
+	CapacityLimits *kubermaticv1.DatacenterCapacityLimits `json:"capacityLimits,omitempty"`
+
+	// MachineFlavorFilter restricts the machine flavors of the datacenter further than the filter
+	// of its cloud provider in the global settings.
+	MachineFlavorFilter *kubermaticv1.MachineFlavorFilter `json:"machineFlavorFilter,omitempty"`
 }
 
 // DatacenterList represents a list of datacenters
@@ -352,12 +360,47 @@ type AzureResourceGroupsList struct {
 	ResourceGroups []string `json:"resourceGroups"`
 }
 
+// AzureLocationList is the object representing the locations available to an Azure subscription
+// swagger:model AzureLocationList
+type AzureLocationList struct {
+	Locations []AzureLocation `json:"locations"`
+}
+
+// AzureLocation is a location of the Azure cloud provider
+// swagger:model AzureLocation
+type AzureLocation struct {
+	// Name is the name of the location to be used in datacenters, e.g. westeurope.
+	Name string `json:"name"`
+	// DisplayName is the human readable name of the location, e.g. West Europe.
+	DisplayName string `json:"displayName,omitempty"`
+}
+
 // AzureAvailabilityZonesList is the object representing the availability zones for vms in azure cloud provider
 // swagger:model AzureAvailabilityZonesList
 type AzureAvailabilityZonesList struct {
 	Zones []string `json:"zones"`
 }
 
+// AzureCostEstimate is the estimated cost of Azure nodes, based on the retail prices of
+// pay-as-you-go Linux VMs and managed disks.
+// swagger:model AzureCostEstimate
+type AzureCostEstimate struct {
+	Currency string `json:"currency"`
+	Replicas int32  `json:"replicas"`
+	// VMHourlyPrice is the price of a VM per hour.
+	VMHourlyPrice float64 `json:"vmHourlyPrice"`
+	// OSDiskTier is the tier the OS disk is billed as, e.g. "P10".
+	OSDiskTier         string  `json:"osDiskTier,omitempty"`
+	OSDiskMonthlyPrice float64 `json:"osDiskMonthlyPrice"`
+	// DataDiskTier is the tier the data disk is billed as, e.g. "P10".
+	DataDiskTier         string  `json:"dataDiskTier,omitempty"`
+	DataDiskMonthlyPrice float64 `json:"dataDiskMonthlyPrice"`
+	// NodeMonthlyPrice is the price of a single node per month, including its disks.
+	NodeMonthlyPrice float64 `json:"nodeMonthlyPrice"`
+	// MonthlyPrice is the price of all nodes per month.
+	MonthlyPrice float64 `json:"monthlyPrice"`
+}
+
 // AzureSizeList represents an array of Azure VM sizes.
 // swagger:model AzureSizeList
 type AzureSizeList []AzureSize
@@ -372,6 +415,15 @@ type AzureSize struct {
 	ResourceDiskSizeInMB int32  `json:"resourceDiskSizeInMB"`
 	MemoryInMB           int32  `json:"memoryInMB"`
 	MaxDataDiskCount     int32  `json:"maxDataDiskCount"`
+	// HyperVGenerations are the image generations supported by the size, "V1" and/or "V2"
+	HyperVGenerations []string `json:"hyperVGenerations,omitempty"`
+	// TrustedLaunchSupported is true if VMs of the size can use trusted launch
+	TrustedLaunchSupported bool `json:"trustedLaunchSupported,omitempty"`
+	// AcceleratedNetworkingSupported is true if VMs of the size can use accelerated networking
+	AcceleratedNetworkingSupported bool `json:"acceleratedNetworkingSupported,omitempty"`
+	// NvidiaGPU is true if VMs of the size have NVIDIA GPUs, the nvidia-gpu-driver addon installs
+	// the driver on their nodes
+	NvidiaGPU bool `json:"nvidiaGPU,omitempty"`
 }
 
 // HetznerSizeList represents an array of Hetzner sizes.
@@ -529,6 +581,10 @@ type PublicServiceAccountToken struct {
 	// Expiry is a timestamp representing the time when this token will expire.
 	// swagger:strfmt date-time
 	Expiry Time `json:"expiry,omitempty"`
+	// Scope restricts the token to a part of the API. Tokens with the "monitoring" scope
+	// can only read the health, metrics and events of the clusters in the project.
+	// An empty scope grants the permissions of the service account.
+	Scope string `json:"scope,omitempty"`
 }
 
 // ServiceAccountToken represent an API service account token
@@ -548,6 +604,36 @@ type Project struct {
 	// Owners an optional owners list for the given project
 	Owners         []User `json:"owners,omitempty"`
 	ClustersNumber int    `json:"clustersNumber,omitempty"`
+	// DefaultPresets maps cloud provider names to the preset used for clusters of the project
+	// which are created without explicit credentials
+	DefaultPresets map[string]string `json:"defaultPresets,omitempty"`
+}
+
+// ProjectDeletionReport lists the resources which are destroyed together with a project. Once the
+// project is deleted, it shows the progress of their deletion.
+// swagger:model ProjectDeletionReport
+type ProjectDeletionReport struct {
+	// Stage is the stage the deletion of the project is in, one of "Backups", "Clusters",
+	// "ServiceAccounts", "SSHKeys" or "Completed". It is empty if the project is not deleted.
+	Stage string `json:"stage,omitempty"`
+	// Resources are listed in the order they are deleted in.
+	Resources []ProjectDeletionReportResource `json:"resources"`
+}
+
+// ProjectDeletionReportResource is a resource which is destroyed together with its project.
+// swagger:model ProjectDeletionReportResource
+type ProjectDeletionReportResource struct {
+	// Kind is one of "EtcdBackupConfig", "Cluster", "ServiceAccount" or "SSHKey". The tokens of
+	// a service account are deleted together with it.
+	Kind string `json:"kind"`
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+	// Seed is the seed the resource is stored in, only set for clusters and their backups.
+	Seed string `json:"seed,omitempty"`
+	// Stage is the stage of the project deletion the resource is deleted in.
+	Stage string `json:"stage"`
+	// Phase is one of "Pending", "Deleting" or "Deleted".
+	Phase string `json:"phase"`
 }
 
 // Kubeconfig is a clusters kubeconfig
@@ -557,6 +643,27 @@ type Kubeconfig struct {
 	Config []byte
 }
 
+// KubeconfigShareLinkSpec defines the parameters of a kubeconfig sharing link
+// swagger:model KubeconfigShareLinkSpec
+type KubeconfigShareLinkSpec struct {
+	// ExpiresIn is the lifetime of the link in seconds.
+	// Defaults to one hour and can not exceed 24 hours.
+	ExpiresIn int64 `json:"expiresIn,omitempty"`
+}
+
+// KubeconfigShareLink is a signed, time-limited link that allows downloading
+// a viewer kubeconfig for a cluster without being a member of its project
+// swagger:model KubeconfigShareLink
+type KubeconfigShareLink struct {
+	// ID identifies the link, it is used to revoke it.
+	ID string `json:"id"`
+	// URL is the path of the link, relative to the API server address.
+	URL string `json:"url"`
+	// Expiry is a timestamp representing the time when this link will expire.
+	// swagger:strfmt date-time
+	Expiry Time `json:"expiry"`
+}
+
 // OpenstackSize is the object representing openstack's sizes.
 // swagger:model OpenstackSize
 type OpenstackSize struct {
@@ -736,8 +843,11 @@ type Cluster struct {
 	InheritedLabels map[string]string `json:"inheritedLabels,omitempty"`
 	Type            string            `json:"type"`
 	Credential      string            `json:"credential,omitempty"`
-	Spec            ClusterSpec       `json:"spec"`
-	Status          ClusterStatus     `json:"status"`
+	// InheritedPreset is the name of the project default preset whose credentials were bound to
+	// the cluster because it was created without explicit credentials.
+	InheritedPreset string        `json:"inheritedPreset,omitempty"`
+	Spec            ClusterSpec   `json:"spec"`
+	Status          ClusterStatus `json:"status"`
 }
 
 // ClusterSpec defines the cluster specification
@@ -748,6 +858,10 @@ type ClusterSpec struct {
 	// MachineNetworks optionally specifies the parameters for IPAM.
 	MachineNetworks []kubermaticv1.MachineNetworkingConfig `json:"machineNetworks,omitempty"`
 
+	// AdditionalClouds are clouds of other providers node deployments can be created in, in addition
+	// to the cloud of the cluster. Every provider can only be used once.
+	AdditionalClouds []kubermaticv1.CloudSpec `json:"additionalClouds,omitempty"`
+
 	// Version desired version of the kubernetes master components
 	Version ksemver.Semver `json:"version"`
 
@@ -797,6 +911,12 @@ type ClusterSpec struct {
 	// MLA contains monitoring, logging and alerting related settings for the user cluster.
 	MLA *kubermaticv1.MLASettings `json:"mla,omitempty"`
 
+	// LogShipping configures shipping of the container logs of the user cluster to an external destination.
+	LogShipping *kubermaticv1.LogShippingSettings `json:"logShipping,omitempty"`
+
+	// PatchUpgradePolicy enables automatic upgrades of the control plane to new patch releases of its minor version.
+	PatchUpgradePolicy *kubermaticv1.PatchUpgradePolicy `json:"patchUpgradePolicy,omitempty"`
+
 	// ClusterNetwork contains network settings.
 	ClusterNetwork *kubermaticv1.ClusterNetworkingConfig `json:"clusterNetwork,omitempty"`
 
@@ -810,6 +930,7 @@ func (cs *ClusterSpec) MarshalJSON() ([]byte, error) {
 	ret, err := json.Marshal(struct {
 		Cloud                                PublicCloudSpec                        `json:"cloud"`
 		MachineNetworks                      []kubermaticv1.MachineNetworkingConfig `json:"machineNetworks,omitempty"`
+		AdditionalClouds                     []PublicCloudSpec                      `json:"additionalClouds,omitempty"`
 		Version                              ksemver.Semver                         `json:"version"`
 		OIDC                                 kubermaticv1.OIDCSettings              `json:"oidc"`
 		UpdateWindow                         *kubermaticv1.UpdateWindow             `json:"updateWindow,omitempty"`
@@ -822,27 +943,15 @@ func (cs *ClusterSpec) MarshalJSON() ([]byte, error) {
 		ServiceAccount                       *kubermaticv1.ServiceAccountSettings   `json:"serviceAccount,omitempty"`
 		OPAIntegration                       *kubermaticv1.OPAIntegrationSettings   `json:"opaIntegration,omitempty"`
 		MLA                                  *kubermaticv1.MLASettings              `json:"mla,omitempty"`
+		LogShipping                          *kubermaticv1.LogShippingSettings      `json:"logShipping,omitempty"`
+		PatchUpgradePolicy                   *kubermaticv1.PatchUpgradePolicy       `json:"patchUpgradePolicy,omitempty"`
 		ContainerRuntime                     string                                 `json:"containerRuntime,omitempty"`
 		ClusterNetwork                       *kubermaticv1.ClusterNetworkingConfig  `json:"clusterNetwork,omitempty"`
 	}{
-		Cloud: PublicCloudSpec{
-			DatacenterName: cs.Cloud.DatacenterName,
-			Fake:           newPublicFakeCloudSpec(cs.Cloud.Fake),
-			Digitalocean:   newPublicDigitaloceanCloudSpec(cs.Cloud.Digitalocean),
-			BringYourOwn:   newPublicBringYourOwnCloudSpec(cs.Cloud.BringYourOwn),
-			AWS:            newPublicAWSCloudSpec(cs.Cloud.AWS),
-			Azure:          newPublicAzureCloudSpec(cs.Cloud.Azure),
-			Openstack:      newPublicOpenstackCloudSpec(cs.Cloud.Openstack),
-			Packet:         newPublicPacketCloudSpec(cs.Cloud.Packet),
-			Hetzner:        newPublicHetznerCloudSpec(cs.Cloud.Hetzner),
-			VSphere:        newPublicVSphereCloudSpec(cs.Cloud.VSphere),
-			GCP:            newPublicGCPCloudSpec(cs.Cloud.GCP),
-			Kubevirt:       newPublicKubevirtCloudSpec(cs.Cloud.Kubevirt),
-			Alibaba:        newPublicAlibabaCloudSpec(cs.Cloud.Alibaba),
-			Anexia:         newPublicAnexiaCloudSpec(cs.Cloud.Anexia),
-		},
+		Cloud:                                newPublicCloudSpec(cs.Cloud),
 		Version:                              cs.Version,
 		MachineNetworks:                      cs.MachineNetworks,
+		AdditionalClouds:                     newPublicCloudSpecs(cs.AdditionalClouds),
 		OIDC:                                 cs.OIDC,
 		UpdateWindow:                         cs.UpdateWindow,
 		UsePodSecurityPolicyAdmissionPlugin:  cs.UsePodSecurityPolicyAdmissionPlugin,
@@ -854,6 +963,8 @@ func (cs *ClusterSpec) MarshalJSON() ([]byte, error) {
 		ServiceAccount:                       cs.ServiceAccount,
 		OPAIntegration:                       cs.OPAIntegration,
 		MLA:                                  cs.MLA,
+		LogShipping:                          cs.LogShipping,
+		PatchUpgradePolicy:                   cs.PatchUpgradePolicy,
 		ContainerRuntime:                     cs.ContainerRuntime,
 		ClusterNetwork:                       cs.ClusterNetwork,
 	})
@@ -880,6 +991,37 @@ type PublicCloudSpec struct {
 	Anexia         *PublicAnexiaCloudSpec       `json:"anexia,omitempty"`
 }
 
+func newPublicCloudSpec(internal kubermaticv1.CloudSpec) PublicCloudSpec {
+	return PublicCloudSpec{
+		DatacenterName: internal.DatacenterName,
+		Fake:           newPublicFakeCloudSpec(internal.Fake),
+		Digitalocean:   newPublicDigitaloceanCloudSpec(internal.Digitalocean),
+		BringYourOwn:   newPublicBringYourOwnCloudSpec(internal.BringYourOwn),
+		AWS:            newPublicAWSCloudSpec(internal.AWS),
+		Azure:          newPublicAzureCloudSpec(internal.Azure),
+		Openstack:      newPublicOpenstackCloudSpec(internal.Openstack),
+		Packet:         newPublicPacketCloudSpec(internal.Packet),
+		Hetzner:        newPublicHetznerCloudSpec(internal.Hetzner),
+		VSphere:        newPublicVSphereCloudSpec(internal.VSphere),
+		GCP:            newPublicGCPCloudSpec(internal.GCP),
+		Kubevirt:       newPublicKubevirtCloudSpec(internal.Kubevirt),
+		Alibaba:        newPublicAlibabaCloudSpec(internal.Alibaba),
+		Anexia:         newPublicAnexiaCloudSpec(internal.Anexia),
+	}
+}
+
+func newPublicCloudSpecs(internal []kubermaticv1.CloudSpec) []PublicCloudSpec {
+	if internal == nil {
+		return nil
+	}
+
+	public := make([]PublicCloudSpec, len(internal))
+	for i := range internal {
+		public[i] = newPublicCloudSpec(internal[i])
+	}
+	return public
+}
+
 // PublicFakeCloudSpec is a public counterpart of apiv1.FakeCloudSpec.
 type PublicFakeCloudSpec struct{}
 
@@ -1049,6 +1191,10 @@ type ClusterStatus struct {
 	URL string `json:"url"`
 	// ExternalCCMMigration represents the migration status to the external CCM
 	ExternalCCMMigration ExternalCCMMigrationStatus `json:"externalCCMMigration"`
+	// CloudProviderResources are the provider-native identifiers of the cloud resources the cluster uses
+	CloudProviderResources []kubermaticv1.CloudProviderResource `json:"cloudProviderResources,omitempty"`
+	// NodeProblems are the problems currently reported by the nodes of the cluster
+	NodeProblems []kubermaticv1.NodeProblem `json:"nodeProblems,omitempty"`
 }
 
 type ExternalCCMMigrationStatus string
@@ -1076,6 +1222,8 @@ type ClusterHealth struct {
 	UserClusterControllerManager kubermaticv1.HealthStatus `json:"userClusterControllerManager"`
 	GatekeeperController         kubermaticv1.HealthStatus `json:"gatekeeperController,omitempty"`
 	GatekeeperAudit              kubermaticv1.HealthStatus `json:"gatekeeperAudit,omitempty"`
+	LogShipping                  kubermaticv1.HealthStatus `json:"logShipping,omitempty"`
+	Nodes                        kubermaticv1.HealthStatus `json:"nodes,omitempty"`
 }
 
 // AccessibleAddons represents an array of addons that can be configured in the user clusters.
@@ -1206,6 +1354,10 @@ type TaintSpec struct {
 type NodeSpec struct {
 	// required: true
 	Cloud NodeCloudSpec `json:"cloud"`
+	// Datacenter is the name of one of the additional clouds of the cluster the nodes are created
+	// in. Defaults to the datacenter of the cluster.
+	// required: false
+	Datacenter string `json:"datacenter,omitempty"`
 	// required: true
 	OperatingSystem OperatingSystemSpec `json:"operatingSystem"`
 	// required: false
@@ -1218,8 +1370,61 @@ type NodeSpec struct {
 	Labels map[string]string `json:"labels,omitempty"`
 	// List of taints to set on new nodes
 	Taints []TaintSpec `json:"taints,omitempty"`
+	// DiskLayout configures the OS disk and the data disks of the nodes independently of the
+	// cloud provider.
+	// required: false
+	DiskLayout *DiskLayout `json:"diskLayout,omitempty"`
+}
+
+// DiskLayout configures the disks of the nodes of a node deployment.
+// swagger:model DiskLayout
+type DiskLayout struct {
+	// OSDisk configures the disk of the operating system. It takes precedence over the disk
+	// settings of the cloud provider. Not supported on Digitalocean, Hetzner, Packet and KubeVirt.
+	OSDisk *OSDiskSpec `json:"osDisk,omitempty"`
+	// DataDisks are attached to the nodes in addition to the OS disk, formatted and mounted
+	// while the nodes are bootstrapped. Only supported on AWS, Azure, GCP, OpenStack and vSphere.
+	DataDisks []DataDiskSpec `json:"dataDisks,omitempty"`
+}
+
+// OSDiskSpec configures the OS disk of the nodes.
+// swagger:model OSDiskSpec
+type OSDiskSpec struct {
+	// Size of the disk in GB, defaults to the default of the cloud provider.
+	Size int32 `json:"size,omitempty"`
+	// Type of the disk, for example "gp3" on AWS, "Premium_LRS" on Azure or "pd-ssd" on GCP.
+	// Defaults to the default of the cloud provider. Not supported on vSphere and Anexia.
+	Type string `json:"type,omitempty"`
 }
 
+// DataDiskSpec is a data disk of the nodes, for example for the container runtime or local
+// persistent volumes.
+// swagger:model DataDiskSpec
+type DataDiskSpec struct {
+	// Size of the disk in GB
+	// required: true
+	Size int32 `json:"size"`
+	// Type of the disk, like the type of the OS disk. Not supported on vSphere.
+	Type string `json:"type,omitempty"`
+	// MountPoint is the absolute path the disk is mounted at, for example "/var/lib/containerd"
+	// for the container runtime or "/mnt/disks/data0" for local persistent volumes.
+	// required: true
+	MountPoint string `json:"mountPoint"`
+	// Filesystem the disk is formatted with, either "ext4" or "xfs". Defaults to "ext4".
+	Filesystem string `json:"filesystem,omitempty"`
+	// LocalVolumes dedicates the disk to local persistent volumes, which requires the disk to be
+	// mounted directly below "/mnt/disks". The local-volume-provisioner addon offers the disks of
+	// the nodes as persistent volumes of the "local-storage" storage class.
+	LocalVolumes bool `json:"localVolumes,omitempty"`
+}
+
+const (
+	// DataDiskFilesystemExt4 formats data disks with ext4.
+	DataDiskFilesystemExt4 = "ext4"
+	// DataDiskFilesystemXFS formats data disks with XFS.
+	DataDiskFilesystemXFS = "xfs"
+)
+
 // DigitaloceanNodeSpec digitalocean node settings
 // swagger:model DigitaloceanNodeSpec
 type DigitaloceanNodeSpec struct {
@@ -1319,11 +1524,92 @@ type AzureNodeSpec struct {
 	// Zones represents the availability zones for azure vms
 	// required: false
 	Zones []string `json:"zones"`
-	// ImageID represents the ID of the image that should be used to run the node
+	// ImageID represents the ID of the image that should be used to run the node, either a
+	// managed image or an image definition or version of a Shared Image Gallery. Image
+	// definitions resolve to their latest version.
 	// required: false
 	ImageID string `json:"imageID"`
+	// ImageReference is a marketplace image the nodes are created from instead of the default
+	// image of the operating system. It cannot be combined with an image ID.
+	// required: false
+	ImageReference *AzureImageReference `json:"imageReference,omitempty"`
+	// ImagePlan is the marketplace plan of the image, which is required for images of offers
+	// whose terms must be accepted, including gallery images created from such offers.
+	// required: false
+	ImagePlan *AzureImagePlan `json:"imagePlan,omitempty"`
+	// HyperVGeneration is the generation of the VM image, either "V1" or "V2". If no image ID
+	// is set, the Gen2 variant of the default image of the operating system is used for "V2".
+	// required: false
+	HyperVGeneration string `json:"hyperVGeneration,omitempty"`
+	// SecurityType is the security type of the VM. Only "TrustedLaunch" is supported, which
+	// requires a Gen2 image and a VM size supporting it.
+	// required: false
+	SecurityType string `json:"securityType,omitempty"`
+	// EnableSecureBoot enables Secure Boot for trusted launch VMs
+	// required: false
+	EnableSecureBoot bool `json:"enableSecureBoot,omitempty"`
+	// EnableVTPM enables the virtual TPM for trusted launch VMs
+	// required: false
+	EnableVTPM bool `json:"enableVTPM,omitempty"`
+	// EnableEncryptionAtHost encrypts the temporary disk and the caches of the OS and data disks
+	// on the VM host, which requires a VM size supporting it
+	// required: false
+	EnableEncryptionAtHost bool `json:"enableEncryptionAtHost,omitempty"`
+	// Subnet is the name of the subnet the nodes are placed in, either the subnet of the cluster
+	// or one of its additional subnets. Defaults to the subnet of the cluster.
+	// required: false
+	Subnet string `json:"subnet,omitempty"`
+	// BootDiagnostics writes the serial console output and screenshots of the VMs to the boot
+	// diagnostics storage account of the cluster, which must have boot diagnostics enabled.
+	// required: false
+	BootDiagnostics bool `json:"bootDiagnostics,omitempty"`
+	// EnableAcceleratedNetworking enables accelerated networking on the network interfaces of the
+	// VMs, which requires a VM size supporting it
+	// required: false
+	EnableAcceleratedNetworking bool `json:"enableAcceleratedNetworking,omitempty"`
 }
 
+// AzureImageReference references an image of the Azure marketplace.
+// swagger:model AzureImageReference
+type AzureImageReference struct {
+	// Publisher of the image, for example "Canonical"
+	// required: true
+	Publisher string `json:"publisher"`
+	// Offer of the image, for example "UbuntuServer"
+	// required: true
+	Offer string `json:"offer"`
+	// SKU of the image, for example "18_04-lts-gen2"
+	// required: true
+	SKU string `json:"sku"`
+	// Version of the image, defaults to "latest"
+	// required: false
+	Version string `json:"version,omitempty"`
+}
+
+// AzureImagePlan is the marketplace plan of an Azure image. The terms of the plan must be
+// accepted in the subscription of the cluster before VMs can be created from the image.
+// swagger:model AzureImagePlan
+type AzureImagePlan struct {
+	// Name of the plan, usually the SKU of the image
+	// required: true
+	Name string `json:"name"`
+	// Publisher of the plan
+	// required: true
+	Publisher string `json:"publisher"`
+	// Product of the plan, usually the offer of the image
+	// required: true
+	Product string `json:"product"`
+}
+
+const (
+	// AzureHyperVGenerationV1 is the generation of BIOS based VM images.
+	AzureHyperVGenerationV1 = "V1"
+	// AzureHyperVGenerationV2 is the generation of UEFI based VM images.
+	AzureHyperVGenerationV2 = "V2"
+	// AzureSecurityTypeTrustedLaunch protects VMs with Secure Boot and a virtual TPM.
+	AzureSecurityTypeTrustedLaunch = "TrustedLaunch"
+)
+
 func (spec *AzureNodeSpec) MarshalJSON() ([]byte, error) {
 	missing := make([]string, 0)
 
@@ -1336,21 +1622,41 @@ func (spec *AzureNodeSpec) MarshalJSON() ([]byte, error) {
 	}
 
 	res := struct {
-		Size           string            `json:"size"`
-		AssignPublicIP bool              `json:"assignPublicIP"`
-		Tags           map[string]string `json:"tags,omitempty"`
-		OSDiskSize     int32             `json:"osDiskSize"`
-		DataDiskSize   int32             `json:"dataDiskSize"`
-		Zones          []string          `json:"zones"`
-		ImageID        string            `json:"imageID"`
+		Size                        string               `json:"size"`
+		AssignPublicIP              bool                 `json:"assignPublicIP"`
+		Tags                        map[string]string    `json:"tags,omitempty"`
+		OSDiskSize                  int32                `json:"osDiskSize"`
+		DataDiskSize                int32                `json:"dataDiskSize"`
+		Zones                       []string             `json:"zones"`
+		ImageID                     string               `json:"imageID"`
+		ImageReference              *AzureImageReference `json:"imageReference,omitempty"`
+		ImagePlan                   *AzureImagePlan      `json:"imagePlan,omitempty"`
+		HyperVGeneration            string               `json:"hyperVGeneration,omitempty"`
+		SecurityType                string               `json:"securityType,omitempty"`
+		EnableSecureBoot            bool                 `json:"enableSecureBoot,omitempty"`
+		EnableVTPM                  bool                 `json:"enableVTPM,omitempty"`
+		EnableEncryptionAtHost      bool                 `json:"enableEncryptionAtHost,omitempty"`
+		Subnet                      string               `json:"subnet,omitempty"`
+		BootDiagnostics             bool                 `json:"bootDiagnostics,omitempty"`
+		EnableAcceleratedNetworking bool                 `json:"enableAcceleratedNetworking,omitempty"`
 	}{
-		Size:           spec.Size,
-		AssignPublicIP: spec.AssignPublicIP,
-		Tags:           spec.Tags,
-		OSDiskSize:     spec.OSDiskSize,
-		DataDiskSize:   spec.DataDiskSize,
-		Zones:          spec.Zones,
-		ImageID:        spec.ImageID,
+		Size:                        spec.Size,
+		AssignPublicIP:              spec.AssignPublicIP,
+		Tags:                        spec.Tags,
+		OSDiskSize:                  spec.OSDiskSize,
+		DataDiskSize:                spec.DataDiskSize,
+		Zones:                       spec.Zones,
+		ImageID:                     spec.ImageID,
+		ImageReference:              spec.ImageReference,
+		ImagePlan:                   spec.ImagePlan,
+		HyperVGeneration:            spec.HyperVGeneration,
+		SecurityType:                spec.SecurityType,
+		EnableSecureBoot:            spec.EnableSecureBoot,
+		EnableVTPM:                  spec.EnableVTPM,
+		EnableEncryptionAtHost:      spec.EnableEncryptionAtHost,
+		Subnet:                      spec.Subnet,
+		BootDiagnostics:             spec.BootDiagnostics,
+		EnableAcceleratedNetworking: spec.EnableAcceleratedNetworking,
 	}
 
 	return json.Marshal(&res)
@@ -1421,7 +1727,10 @@ type OpenstackNodeSpec struct {
 	// if set, the rootDisk will be a volume. If not, the rootDisk will be on ephemeral storage and its size will be derived from the flavor
 	// required: false
 	RootDiskSizeGB *int `json:"diskSize"`
-	// if not set, the default AZ from the Datacenter spec will be used
+	// if set, the root disk volume is created with this Cinder volume type, requires diskSize to be set
+	// required: false
+	RootDiskVolumeType string `json:"rootDiskVolumeType,omitempty"`
+	// if not set, the default AZ from the Datacenter spec will be used. The root disk volume is created in the same AZ.
 	// required: false
 	AvailabilityZone string `json:"availabilityZone"`
 	// Period of time to check for instance ready status, i.e. 10s/1m
@@ -1453,6 +1762,7 @@ func (spec *OpenstackNodeSpec) MarshalJSON() ([]byte, error) {
 		Tags                      map[string]string `json:"tags,omitempty"`
 		UseFloatingIP             bool              `json:"useFloatingIP,omitempty"`
 		RootDiskSizeGB            *int              `json:"diskSize"`
+		RootDiskVolumeType        string            `json:"rootDiskVolumeType,omitempty"`
 		AvailabilityZone          string            `json:"availabilityZone"`
 		InstanceReadyCheckPeriod  string            `json:"instanceReadyCheckPeriod"`
 		InstanceReadyCheckTimeout string            `json:"instanceReadyCheckTimeout"`
@@ -1462,6 +1772,7 @@ func (spec *OpenstackNodeSpec) MarshalJSON() ([]byte, error) {
 		Tags:                      spec.Tags,
 		UseFloatingIP:             spec.UseFloatingIP,
 		RootDiskSizeGB:            spec.RootDiskSizeGB,
+		RootDiskVolumeType:        spec.RootDiskVolumeType,
 		AvailabilityZone:          spec.AvailabilityZone,
 		InstanceReadyCheckPeriod:  spec.InstanceReadyCheckPeriod,
 		InstanceReadyCheckTimeout: spec.InstanceReadyCheckTimeout,
@@ -1871,12 +2182,28 @@ type NodeStatus struct {
 	// node versions and systems info
 	NodeInfo NodeSystemInfo `json:"nodeInfo,omitempty"`
 
+	// LocalVolumes is the capacity of the local persistent volumes of the node, if it has any.
+	LocalVolumes *NodeLocalVolumes `json:"localVolumes,omitempty"`
+
 	// in case of a error this will contain a short error message
 	ErrorReason string `json:"errorReason,omitempty"`
 	// in case of a error this will contain a detailed error explanation
 	ErrorMessage string `json:"errorMessage,omitempty"`
 }
 
+// NodeLocalVolumes is the capacity of the local persistent volumes of a node.
+// swagger:model NodeLocalVolumes
+type NodeLocalVolumes struct {
+	// Volumes is the number of local persistent volumes of the node
+	Volumes int `json:"volumes"`
+	// BoundVolumes is the number of local persistent volumes bound to a claim
+	BoundVolumes int `json:"boundVolumes"`
+	// Capacity is the total capacity of the local persistent volumes, for example "100Gi"
+	Capacity string `json:"capacity"`
+	// Available is the capacity of the local persistent volumes which are not bound to a claim
+	Available string `json:"available"`
+}
+
 // NodeAddress contains information for the node's address.
 // swagger:model NodeAddress
 type NodeAddress struct {
@@ -1897,6 +2224,29 @@ type NodeSystemInfo struct {
 	Architecture            string `json:"architecture"`
 }
 
+// FleetStatistics contains statistics about all clusters of all seeds
+// swagger:model FleetStatistics
+type FleetStatistics struct {
+	// Clusters is the total number of clusters
+	Clusters int `json:"clusters"`
+	// ClustersByProvider is the number of clusters per cloud provider
+	ClustersByProvider map[string]int `json:"clustersByProvider"`
+	// ClustersByVersion is the number of clusters per Kubernetes version
+	ClustersByVersion map[string]int `json:"clustersByVersion"`
+	// ClustersBySeed is the number of clusters per seed
+	ClustersBySeed map[string]int `json:"clustersBySeed"`
+	// Nodes is the total number of nodes of all clusters with a reachable API server
+	Nodes int `json:"nodes"`
+	// FailingClusters is the number of clusters with a reconciliation error or unhealthy components
+	FailingClusters int `json:"failingClusters"`
+	// PendingUpgrades is the number of clusters for which a newer Kubernetes version is available
+	PendingUpgrades int `json:"pendingUpgrades"`
+	// UnreachableSeeds contains the names of the seeds whose clusters could not be listed
+	UnreachableSeeds []string `json:"unreachableSeeds,omitempty"`
+	// GeneratedAt is the time the statistics have been computed at
+	GeneratedAt Time `json:"generatedAt"`
+}
+
 // ClusterMetrics defines a metric for the given cluster
 // swagger:model ClusterMetrics
 type ClusterMetrics struct {
@@ -1947,6 +2297,16 @@ type NodeMetric struct {
 	CPUUsedPercentage int64 `json:"cpuUsedPercentage,omitempty"`
 }
 
+// MachineDeploymentMetrics defines the metrics of the nodes of a machine deployment
+// swagger:model MachineDeploymentMetrics
+type MachineDeploymentMetrics struct {
+	Name string `json:"name"`
+	// Utilization is the aggregated utilization of all nodes of the machine deployment
+	Utilization NodesMetric `json:"utilization"`
+	// Nodes contains the metrics of the individual nodes
+	Nodes []NodeMetric `json:"nodes"`
+}
+
 // NodeDeployment represents a set of worker nodes that is part of a cluster
 // swagger:model NodeDeployment
 type NodeDeployment struct {
@@ -1954,6 +2314,12 @@ type NodeDeployment struct {
 
 	Spec   NodeDeploymentSpec               `json:"spec"`
 	Status v1alpha1.MachineDeploymentStatus `json:"status"`
+	// InstanceTypesInUse is the number of machines per instance type. It is only reported
+	// for node deployments with fallback instance types.
+	InstanceTypesInUse map[string]int32 `json:"instanceTypesInUse,omitempty"`
+	// CanaryRollout is the status of the latest canary rollout of an OS image. It is only
+	// reported for node deployments with canary rollouts.
+	CanaryRollout *CanaryRolloutStatus `json:"canaryRollout,omitempty"`
 }
 
 // NodeDeploymentSpec node deployment specification
@@ -1967,6 +2333,64 @@ type NodeDeploymentSpec struct {
 	Paused *bool `json:"paused,omitempty"`
 	// required: false
 	DynamicConfig *bool `json:"dynamicConfig,omitempty"`
+	// FallbackInstanceTypes are the instance types, in order of priority, new nodes fall back to
+	// when the cloud provider runs out of capacity for the instance type of the template. They are
+	// only used for the nodes out of capacity and removed again once the instance type of the
+	// template has capacity. Not supported on vSphere, KubeVirt and Anexia.
+	// required: false
+	FallbackInstanceTypes []string `json:"fallbackInstanceTypes,omitempty"`
+	// CanaryRollout enables canary rollouts of OS image changes: the nodes are only updated to a
+	// new image once canary nodes created with it passed the validation. Only supported on AWS,
+	// Azure, GCP, OpenStack, Hetzner and vSphere.
+	// required: false
+	CanaryRollout *CanaryRolloutSettings `json:"canaryRollout,omitempty"`
+	// ReplicasFrozen freezes the number of nodes, e.g. during an investigation. Neither the
+	// cluster autoscaler nor users can scale a frozen node deployment until it is unfrozen.
+	// required: false
+	ReplicasFrozen *bool `json:"replicasFrozen,omitempty"`
+}
+
+// CanaryRolloutSettings configures the canary rollouts of OS image changes of a node deployment.
+// swagger:model CanaryRolloutSettings
+type CanaryRolloutSettings struct {
+	// Replicas is the number of canary nodes created with the new image, defaults to 1.
+	Replicas int32 `json:"replicas,omitempty"`
+	// ValidationImage is the container image of the validation workload, which is run once on
+	// every canary node and has to exit successfully. If unset, the canary nodes only have to
+	// become ready.
+	ValidationImage string `json:"validationImage,omitempty"`
+	// ValidationCommand overrides the entrypoint of the validation image.
+	ValidationCommand []string `json:"validationCommand,omitempty"`
+	// TimeoutMinutes is the time the canary nodes have to become ready and pass the validation,
+	// defaults to 30.
+	TimeoutMinutes int32 `json:"timeoutMinutes,omitempty"`
+}
+
+// CanaryRolloutPhase is the phase of a canary rollout.
+type CanaryRolloutPhase string
+
+const (
+	// CanaryRolloutInProgress means the canary nodes are being created or validated.
+	CanaryRolloutInProgress CanaryRolloutPhase = "InProgress"
+	// CanaryRolloutSucceeded means the canary nodes passed the validation and the node
+	// deployment has been updated to the new image.
+	CanaryRolloutSucceeded CanaryRolloutPhase = "Succeeded"
+	// CanaryRolloutFailed means the canary nodes failed the validation, the node deployment
+	// keeps its previous image.
+	CanaryRolloutFailed CanaryRolloutPhase = "Failed"
+)
+
+// CanaryRolloutStatus is the status of the latest canary rollout of a node deployment.
+// swagger:model CanaryRolloutStatus
+type CanaryRolloutStatus struct {
+	Phase CanaryRolloutPhase `json:"phase"`
+	// Image is the OS image being rolled out.
+	Image string `json:"image"`
+	// Message explains why the canary rollout failed.
+	Message string `json:"message,omitempty"`
+	// StartedAt is the time the canary rollout was started.
+	// swagger:strfmt date-time
+	StartedAt Time `json:"startedAt"`
 }
 
 // Event is a report of an event somewhere in the cluster.
@@ -2184,6 +2608,8 @@ const (
 	InClusterPVCleanupFinalizer = "kubermatic.io/cleanup-in-cluster-pv"
 	// InClusterLBCleanupFinalizer indicates that the LBs still need cleanup
 	InClusterLBCleanupFinalizer = "kubermatic.io/cleanup-in-cluster-lb"
+	// PreDeleteHooksFinalizer indicates that the pre-delete hooks of the project still need to run
+	PreDeleteHooksFinalizer = "kubermatic.io/run-pre-delete-hooks"
 	// CredentialsSecretsCleanupFinalizer indicates that secrets for credentials still need cleanup
 	CredentialsSecretsCleanupFinalizer = "kubermatic.io/cleanup-credentials-secrets"
 	// UserClusterRoleCleanupFinalizer indicates that user cluster role still need cleanup
@@ -2218,6 +2644,18 @@ const (
 	WhitelistedRegistryCleanupFinalizer = "kubermatic.io/cleanup-whitelisted-registry"
 	// ClusterTemplateSeedCleanupFinalizer indicates that cluster template instance on seed clusters need cleanup
 	SeedClusterTemplateInstanceFinalizer = "kubermatic.io/cleanup-seed-cluster-template-instance"
+	// SeedPresetCleanupFinalizer indicates that synced Presets on seed clusters need cleanup
+	SeedPresetCleanupFinalizer = "kubermatic.io/cleanup-seed-presets"
+	// SeedAddonConfigCleanupFinalizer indicates that synced AddonConfigs on seed clusters need cleanup
+	SeedAddonConfigCleanupFinalizer = "kubermatic.io/cleanup-seed-addon-configs"
+	// SeedVersionsCleanupFinalizer indicates that the versions synced from a KubermaticConfiguration to seed clusters need cleanup
+	SeedVersionsCleanupFinalizer = "kubermatic.io/cleanup-seed-versions"
+	// AddonRolloutCleanupFinalizer indicates that the addons installed by an addon rollout need cleanup
+	AddonRolloutCleanupFinalizer = "kubermatic.io/cleanup-addon-rollout"
+	// ProjectStagedDeletionFinalizer indicates that the resources of the project still need to be deleted stage by stage
+	ProjectStagedDeletionFinalizer = "kubermatic.io/staged-project-deletion"
+	// ClusterNamespaceCleanupFinalizer indicates that a namespace created via the API needs to be removed from the user cluster
+	ClusterNamespaceCleanupFinalizer = "kubermatic.io/cleanup-cluster-namespace"
 )
 
 func ToInternalClusterType(externalClusterType string) kubermaticv1.ClusterType {
@@ -21,6 +21,8 @@ import (
 
 	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
 	crdapiv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // ConstraintTemplate represents a gatekeeper ConstraintTemplate
@@ -225,3 +227,170 @@ type EtcdBackupConfigSpec struct {
 	// If not set, defaults to DefaultKeptBackupsCount. Only used if Schedule is set.
 	Keep *int `json:"keep,omitempty"`
 }
+
+// ClusterSpecRevision represents a recorded change of a cluster's spec
+// swagger:model ClusterSpecRevision
+type ClusterSpecRevision struct {
+	// Revision is the sequence number of the change, starting at 1.
+	Revision int64 `json:"revision"`
+	// Author is the email of the user who made the change.
+	Author string `json:"author,omitempty"`
+	// Timestamp is the time when the change was made.
+	// swagger:strfmt date-time
+	Timestamp apiv1.Time `json:"timestamp"`
+	// Diff is the JSON merge patch which turns the spec of the previous revision
+	// into this one. It is empty for the first revision.
+	Diff string `json:"diff,omitempty"`
+}
+
+// ClusterUpgradePreflight represents the compatibility of a cluster with the next Kubernetes minor version
+// swagger:model ClusterUpgradePreflight
+type ClusterUpgradePreflight struct {
+	// TargetVersion is the next Kubernetes minor version of the cluster.
+	TargetVersion string `json:"targetVersion"`
+	// ScanTime is the time when the cluster was scanned for removed APIs. It is empty if
+	// the cluster has not been scanned since it was created or upgraded.
+	// swagger:strfmt date-time
+	ScanTime *apiv1.Time `json:"scanTime,omitempty"`
+	// RemovedAPIs are the APIs served by the cluster which are removed in the target version.
+	RemovedAPIs []crdapiv1.RemovedAPI `json:"removedAPIs"`
+	// Compatible is true if the cluster has been scanned and none of the removed APIs is
+	// requested anymore.
+	Compatible bool `json:"compatible"`
+}
+
+// ClusterCredentialRotation represents the age of a credential generated for the control plane of a cluster
+// swagger:model ClusterCredentialRotation
+type ClusterCredentialRotation struct {
+	// Name is the name of the secret in the cluster namespace containing the credential, or
+	// "admin-token" for the admin token of the cluster.
+	Name string `json:"name"`
+	// RotationTime is the time the credential was generated.
+	// swagger:strfmt date-time
+	RotationTime apiv1.Time `json:"rotationTime"`
+	// AgeSeconds is the time since the credential was generated, in seconds.
+	AgeSeconds int64 `json:"ageSeconds"`
+}
+
+// ClusterInfrastructureResource represents the live state of a cloud resource used by a cluster
+// swagger:model ClusterInfrastructureResource
+type ClusterInfrastructureResource struct {
+	// Type is the type of the resource, like "vnet" or "securityGroup".
+	Type string `json:"type"`
+	// Name is the name of the resource in the cluster spec.
+	Name string `json:"name"`
+	// ID is the provider-native identifier of the resource, if it exists.
+	ID string `json:"id,omitempty"`
+	// Managed is true if the resource has been created by Kubermatic.
+	Managed bool `json:"managed"`
+	// State is one of "Exists", "Provisioning", "Failed" or "Deleted".
+	State string            `json:"state"`
+	Tags  map[string]string `json:"tags,omitempty"`
+	// AddressPrefixes are the IP ranges of network resources.
+	AddressPrefixes []string `json:"addressPrefixes,omitempty"`
+	// Drift describes the differences between the resource and the cluster spec.
+	Drift []string `json:"drift,omitempty"`
+}
+
+// ClusterInfrastructurePlanResource represents a cloud resource which would be created or updated
+// when the cloud provider infrastructure of a cluster is initialized
+// swagger:model ClusterInfrastructurePlanResource
+type ClusterInfrastructurePlanResource struct {
+	// Type is the type of the resource, like "vnet" or "securityGroup".
+	Type string `json:"type"`
+	Name string `json:"name"`
+	// Action is one of "Create", "CreateIfMissing" or "Update".
+	Action string `json:"action"`
+	// Parent is the resource the resource is created in, like the resource group.
+	Parent string            `json:"parent,omitempty"`
+	Tags   map[string]string `json:"tags,omitempty"`
+	// AddressPrefixes are the IP ranges of network resources.
+	AddressPrefixes []string `json:"addressPrefixes,omitempty"`
+	// Rules are the firewall rules of security groups.
+	Rules []ClusterInfrastructurePlanSecurityRule `json:"rules,omitempty"`
+	// Properties are further provider-specific settings of the resource.
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+// ClusterInfrastructurePlanSecurityRule represents a firewall rule of a planned security group
+// swagger:model ClusterInfrastructurePlanSecurityRule
+type ClusterInfrastructurePlanSecurityRule struct {
+	Name                 string `json:"name"`
+	Direction            string `json:"direction"`
+	Access               string `json:"access"`
+	Protocol             string `json:"protocol"`
+	Priority             int32  `json:"priority"`
+	Source               string `json:"source"`
+	SourcePortRange      string `json:"sourcePortRange"`
+	Destination          string `json:"destination"`
+	DestinationPortRange string `json:"destinationPortRange"`
+}
+
+// MachineDeploymentBootstrapConfig represents the bootstrap configuration the machines of a machine deployment would be provisioned with
+// swagger:model MachineDeploymentBootstrapConfig
+type MachineDeploymentBootstrapConfig struct {
+	// OperatingSystem is the operating system of the machines.
+	OperatingSystem string `json:"operatingSystem"`
+	// Format is the format of the bootstrap configuration, either cloud-init or ignition.
+	Format string `json:"format"`
+	// Content is the rendered bootstrap configuration. Secrets like the bootstrap token
+	// and the cloud config are redacted.
+	Content string `json:"content"`
+}
+
+// AddonRollout represents an object installing an addon into many clusters in waves
+// swagger:model AddonRollout
+type AddonRollout struct {
+	Name string `json:"name"`
+
+	Spec   AddonRolloutSpec            `json:"spec"`
+	Status crdapiv1.AddonRolloutStatus `json:"status"`
+}
+
+// AddonRolloutSpec specifies which addon is rolled out to which clusters and how
+type AddonRolloutSpec struct {
+	// Addon is the name of the addon to install.
+	Addon string `json:"addon"`
+	// Version identifies the addon configuration being rolled out. Changing it
+	// restarts the rollout from the first wave.
+	Version string `json:"version"`
+	// Variables is free form data to use for parsing the manifest templates of the addon.
+	Variables map[string]interface{} `json:"variables,omitempty"`
+	// ClusterSelector selects the clusters across all seeds the addon is installed into.
+	ClusterSelector metav1.LabelSelector `json:"clusterSelector"`
+	// Waves define the cumulative percentage of the selected clusters which the addon
+	// is installed into per step.
+	Waves []crdapiv1.AddonRolloutWave `json:"waves,omitempty"`
+	// HealthTimeout is the time a cluster has to become healthy after the addon was
+	// applied, e.g. "15m".
+	HealthTimeout string `json:"healthTimeout,omitempty"`
+	// MaxFailures is the number of failed clusters which is tolerated before the
+	// rollout is halted.
+	MaxFailures int `json:"maxFailures,omitempty"`
+	// Paused prevents the next wave from being started.
+	Paused bool `json:"paused,omitempty"`
+}
+
+// NamespaceTemplate represents the objects created together with a namespace in a user cluster
+// swagger:model NamespaceTemplate
+type NamespaceTemplate struct {
+	Name string `json:"name"`
+
+	Spec crdapiv1.NamespaceTemplateSpec `json:"spec"`
+}
+
+// ClusterNamespace represents a namespace created in a user cluster via the API
+// swagger:model ClusterNamespace
+type ClusterNamespace struct {
+	// Name is the name of the namespace in the user cluster.
+	Name string `json:"name"`
+	// Template is the name of the namespace template which was applied.
+	Template string `json:"template,omitempty"`
+	// CreatedBy is the email of the user who created the namespace.
+	CreatedBy string `json:"createdBy,omitempty"`
+	// CreationTimestamp is the time when the namespace was requested.
+	// swagger:strfmt date-time
+	CreationTimestamp apiv1.Time `json:"creationTimestamp,omitempty"`
+
+	Status crdapiv1.ClusterNamespaceStatus `json:"status"`
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterdeletion
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	kubermaticapiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1/helper"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	preDeleteHookJobPrefix    = "pre-delete-"
+	preDeleteHookJobNamespace = metav1.NamespaceSystem
+)
+
+// preDeleteHookJobName returns the name of the Job that is created inside the user cluster for the given hook.
+func preDeleteHookJobName(hook kubermaticv1.ClusterPreDeleteHook) string {
+	return preDeleteHookJobPrefix + hook.Name
+}
+
+// runPreDeleteHooks creates the Jobs of the pre-delete hooks of the cluster's project inside the user cluster
+// and waits for them to finish or time out. The PreDeleteHooksFinalizer is removed once all hooks are done.
+func (d *Deletion) runPreDeleteHooks(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster) error {
+	if !kuberneteshelper.HasFinalizer(cluster, kubermaticapiv1.PreDeleteHooksFinalizer) {
+		return nil
+	}
+
+	log = log.Named("pre-delete-hooks")
+
+	done, err := d.preDeleteHooksDone(ctx, log, cluster)
+	if err != nil {
+		return err
+	}
+	if !done {
+		return nil
+	}
+
+	return kubermaticv1helper.UpdateCluster(ctx, d.seedClient, cluster, func(c *kubermaticv1.Cluster) {
+		kuberneteshelper.RemoveFinalizer(c, kubermaticapiv1.PreDeleteHooksFinalizer)
+	})
+}
+
+func (d *Deletion) preDeleteHooksDone(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster) (bool, error) {
+	if cluster.Annotations[kubermaticv1.SkipPreDeleteHooksAnnotation] == "true" {
+		log.Infow("Skipping pre-delete hooks", "annotation", kubermaticv1.SkipPreDeleteHooksAnnotation)
+		return true, nil
+	}
+
+	projectID := cluster.Labels[kubermaticv1.ProjectIDLabelKey]
+	if projectID == "" {
+		return true, nil
+	}
+
+	project := &kubermaticv1.Project{}
+	if err := d.seedClient.Get(ctx, types.NamespacedName{Name: projectID}, project); err != nil {
+		if kerrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, fmt.Errorf("failed to get project %q: %v", projectID, err)
+	}
+
+	var userClusterClient ctrlruntimeclient.Client
+	allDone := true
+
+	for _, hook := range project.Spec.PreDeleteHooks {
+		hookLog := log.With("hook", hook.Name)
+
+		if preDeleteHookTimedOut(cluster, hook) {
+			hookLog.Info("Pre-delete hook timed out, continuing with the cluster deletion")
+			continue
+		}
+
+		if userClusterClient == nil {
+			var err error
+			userClusterClient, err = d.userClusterClientGetter()
+			if err != nil {
+				return false, fmt.Errorf("failed to get user cluster client: %v", err)
+			}
+		}
+
+		job, err := ensurePreDeleteHookJob(ctx, userClusterClient, hook)
+		if err != nil {
+			return false, err
+		}
+
+		switch {
+		case jobHasCondition(job, batchv1.JobComplete):
+			hookLog.Debug("Pre-delete hook completed")
+		case jobHasCondition(job, batchv1.JobFailed):
+			hookLog.Info("Pre-delete hook failed, continuing with the cluster deletion")
+		default:
+			allDone = false
+		}
+	}
+
+	return allDone, nil
+}
+
+// preDeleteHookTimedOut returns whether the timeout of the hook, measured from the
+// deletion timestamp of the cluster, has been exceeded.
+func preDeleteHookTimedOut(cluster *kubermaticv1.Cluster, hook kubermaticv1.ClusterPreDeleteHook) bool {
+	if cluster.DeletionTimestamp == nil {
+		return false
+	}
+
+	timeout := kubermaticv1.DefaultPreDeleteHookTimeout
+	if hook.Timeout != nil {
+		timeout = hook.Timeout.Duration
+	}
+
+	return time.Since(cluster.DeletionTimestamp.Time) > timeout
+}
+
+func ensurePreDeleteHookJob(ctx context.Context, client ctrlruntimeclient.Client, hook kubermaticv1.ClusterPreDeleteHook) (*batchv1.Job, error) {
+	job := &batchv1.Job{}
+	name := types.NamespacedName{Namespace: preDeleteHookJobNamespace, Name: preDeleteHookJobName(hook)}
+
+	err := client.Get(ctx, name, job)
+	if err == nil {
+		return job, nil
+	}
+	if !kerrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get pre-delete hook Job %q: %v", name.Name, err)
+	}
+
+	job = &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: name.Namespace,
+			Name:      name.Name,
+		},
+		Spec: *hook.Job.DeepCopy(),
+	}
+	if job.Spec.Template.Spec.RestartPolicy == "" {
+		job.Spec.Template.Spec.RestartPolicy = corev1.RestartPolicyNever
+	}
+
+	if err := client.Create(ctx, job); err != nil {
+		return nil, fmt.Errorf("failed to create pre-delete hook Job %q: %v", name.Name, err)
+	}
+
+	return job, nil
+}
+
+func jobHasCondition(job *batchv1.Job, conditionType batchv1.JobConditionType) bool {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == conditionType && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
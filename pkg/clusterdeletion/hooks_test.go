@@ -0,0 +1,157 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterdeletion
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kubermaticapiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRunPreDeleteHooks(t *testing.T) {
+	const (
+		clusterName = "cluster"
+		projectID   = "my-project"
+		hookName    = "deprovision"
+	)
+
+	hook := kubermaticv1.ClusterPreDeleteHook{Name: hookName}
+	project := &kubermaticv1.Project{
+		ObjectMeta: metav1.ObjectMeta{Name: projectID},
+		Spec: kubermaticv1.ProjectSpec{
+			Name:           "my project",
+			PreDeleteHooks: []kubermaticv1.ClusterPreDeleteHook{hook},
+		},
+	}
+
+	jobWithCondition := func(conditionType batchv1.JobConditionType) *batchv1.Job {
+		return &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: metav1.NamespaceSystem,
+				Name:      preDeleteHookJobName(hook),
+			},
+			Status: batchv1.JobStatus{
+				Conditions: []batchv1.JobCondition{{Type: conditionType, Status: corev1.ConditionTrue}},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name              string
+		deletedSince      time.Duration
+		annotations       map[string]string
+		userObjects       []ctrlruntimeclient.Object
+		expectJob         bool
+		expectFinalizer   bool
+		expectPVRemaining bool
+	}{
+		{
+			name:              "Job is created and in-cluster resources remain while the hook is running",
+			deletedSince:      time.Minute,
+			expectJob:         true,
+			expectFinalizer:   true,
+			expectPVRemaining: true,
+		},
+		{
+			name:         "Finalizer is removed once the Job completed",
+			deletedSince: time.Minute,
+			userObjects:  []ctrlruntimeclient.Object{jobWithCondition(batchv1.JobComplete)},
+			expectJob:    true,
+		},
+		{
+			name:         "Finalizer is removed once the Job failed",
+			deletedSince: time.Minute,
+			userObjects:  []ctrlruntimeclient.Object{jobWithCondition(batchv1.JobFailed)},
+			expectJob:    true,
+		},
+		{
+			name:         "Hook is not run after the timeout",
+			deletedSince: kubermaticv1.DefaultPreDeleteHookTimeout + time.Minute,
+		},
+		{
+			name:         "Hooks are skipped via annotation",
+			deletedSince: time.Minute,
+			annotations:  map[string]string{kubermaticv1.SkipPreDeleteHooksAnnotation: "true"},
+		},
+	}
+
+	for idx := range testCases {
+		tc := testCases[idx]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			deletionTimestamp := metav1.NewTime(time.Now().Add(-tc.deletedSince))
+			cluster := getClusterWithFinalizer(clusterName, kubermaticapiv1.PreDeleteHooksFinalizer, kubermaticapiv1.InClusterPVCleanupFinalizer)
+			cluster.Labels = map[string]string{kubermaticv1.ProjectIDLabelKey: projectID}
+			cluster.Annotations = tc.annotations
+			cluster.DeletionTimestamp = &deletionTimestamp
+
+			userClusterClient := fake.
+				NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(append(tc.userObjects, &corev1.PersistentVolume{ObjectMeta: metav1.ObjectMeta{Name: "my-pv"}})...).
+				Build()
+			seedClient := fake.NewClientBuilder().WithObjects(cluster, project.DeepCopy()).Build()
+
+			ctx := context.Background()
+			deletion := &Deletion{
+				seedClient: seedClient,
+				userClusterClientGetter: func() (ctrlruntimeclient.Client, error) {
+					return userClusterClient, nil
+				},
+			}
+
+			if err := deletion.CleanupCluster(ctx, kubermaticlog.Logger, cluster); err != nil {
+				t.Fatalf("Deletion failed: %v", err)
+			}
+
+			err := userClusterClient.Get(ctx, types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: preDeleteHookJobName(hook)}, &batchv1.Job{})
+			if err != nil && !kerrors.IsNotFound(err) {
+				t.Fatalf("failed to get Job: %v", err)
+			}
+			if hasJob := err == nil; hasJob != tc.expectJob {
+				t.Errorf("expected Job to exist: %v, but it does: %v", tc.expectJob, hasJob)
+			}
+
+			if hasFinalizer := kuberneteshelper.HasFinalizer(cluster, kubermaticapiv1.PreDeleteHooksFinalizer); hasFinalizer != tc.expectFinalizer {
+				t.Errorf("expected finalizer to be present: %v, but it is: %v", tc.expectFinalizer, hasFinalizer)
+			}
+
+			err = userClusterClient.Get(ctx, types.NamespacedName{Name: "my-pv"}, &corev1.PersistentVolume{})
+			if err != nil && !kerrors.IsNotFound(err) {
+				t.Fatalf("failed to get PV: %v", err)
+			}
+			if hasPV := err == nil; tc.expectPVRemaining && !hasPV {
+				t.Error("expected PV to remain while the pre-delete hooks are running")
+			}
+		})
+	}
+}
@@ -0,0 +1,563 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addonrolloutcontroller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+
+	kubermaticapiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	controllerutil "k8c.io/kubermatic/v2/pkg/controller/util"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	// This controller installs addons into the clusters selected by AddonRollouts.
+	ControllerName = "addon_rollout_controller"
+
+	// defaultHealthTimeout is the time a cluster has to become healthy if the rollout does not specify it.
+	defaultHealthTimeout = 10 * time.Minute
+
+	// progressInterval is the interval in which the health of the clusters is checked while a rollout progresses.
+	progressInterval = 30 * time.Second
+)
+
+type reconciler struct {
+	log          *zap.SugaredLogger
+	masterClient ctrlruntimeclient.Client
+	seedClients  map[string]ctrlruntimeclient.Client
+	recorder     record.EventRecorder
+	now          func() time.Time
+}
+
+func Add(
+	masterMgr manager.Manager,
+	seedManagers map[string]manager.Manager,
+	log *zap.SugaredLogger,
+	numWorkers int,
+) error {
+	r := &reconciler{
+		log:          log.Named(ControllerName),
+		masterClient: masterMgr.GetClient(),
+		seedClients:  map[string]ctrlruntimeclient.Client{},
+		recorder:     masterMgr.GetEventRecorderFor(ControllerName),
+		now:          time.Now,
+	}
+
+	c, err := controller.New(ControllerName, masterMgr, controller.Options{Reconciler: r, MaxConcurrentReconciles: numWorkers})
+	if err != nil {
+		return fmt.Errorf("failed to construct controller: %w", err)
+	}
+
+	for seedName, seedManager := range seedManagers {
+		r.seedClients[seedName] = seedManager.GetClient()
+
+		// Newly created or relabeled clusters might have to be added to a rollout.
+		clusterWatch := &source.Kind{Type: &kubermaticv1.Cluster{}}
+		if err := clusterWatch.InjectCache(seedManager.GetCache()); err != nil {
+			return fmt.Errorf("failed to inject cache for seed %q into watch: %w", seedName, err)
+		}
+		if err := c.Watch(clusterWatch, r.enqueueAllRollouts()); err != nil {
+			return fmt.Errorf("failed to watch clusters in seed %q: %w", seedName, err)
+		}
+
+		addonWatch := &source.Kind{Type: &kubermaticv1.Addon{}}
+		if err := addonWatch.InjectCache(seedManager.GetCache()); err != nil {
+			return fmt.Errorf("failed to inject cache for seed %q into watch: %w", seedName, err)
+		}
+		if err := c.Watch(addonWatch, enqueueOwningRollout()); err != nil {
+			return fmt.Errorf("failed to watch addons in seed %q: %w", seedName, err)
+		}
+	}
+
+	if err := c.Watch(&source.Kind{Type: &kubermaticv1.AddonRollout{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("failed to watch addon rollouts: %w", err)
+	}
+
+	return nil
+}
+
+func (r *reconciler) enqueueAllRollouts() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(a ctrlruntimeclient.Object) []reconcile.Request {
+		rollouts := &kubermaticv1.AddonRolloutList{}
+		if err := r.masterClient.List(context.Background(), rollouts); err != nil {
+			r.log.Errorw("Failed to list addon rollouts", zap.Error(err))
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for _, rollout := range rollouts.Items {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: rollout.Name}})
+		}
+		return requests
+	})
+}
+
+func enqueueOwningRollout() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(a ctrlruntimeclient.Object) []reconcile.Request {
+		name := a.GetLabels()[kubermaticv1.AddonRolloutLabelKey]
+		if name == "" {
+			return nil
+		}
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: name}}}
+	})
+}
+
+func (r *reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.With("rollout", request.Name)
+	log.Debug("Processing")
+
+	rollout := &kubermaticv1.AddonRollout{}
+	if err := r.masterClient.Get(ctx, request.NamespacedName, rollout); err != nil {
+		return reconcile.Result{}, ctrlruntimeclient.IgnoreNotFound(err)
+	}
+
+	result, err := r.reconcile(ctx, log, rollout)
+	if controllerutil.IsCacheNotStarted(err) {
+		return reconcile.Result{RequeueAfter: 5 * time.Second}, nil
+	}
+	if err != nil {
+		log.Errorw("ReconcilingError", zap.Error(err))
+		r.recorder.Event(rollout, corev1.EventTypeWarning, "ReconcilingError", err.Error())
+	}
+
+	return result, err
+}
+
+// selectedCluster is a cluster matching the selector of a rollout.
+type selectedCluster struct {
+	seed    string
+	cluster *kubermaticv1.Cluster
+}
+
+func (r *reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, rollout *kubermaticv1.AddonRollout) (reconcile.Result, error) {
+	if !rollout.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, r.handleDeletion(ctx, rollout)
+	}
+
+	if !kuberneteshelper.HasFinalizer(rollout, kubermaticapiv1.AddonRolloutCleanupFinalizer) {
+		kuberneteshelper.AddFinalizer(rollout, kubermaticapiv1.AddonRolloutCleanupFinalizer)
+		if err := r.masterClient.Update(ctx, rollout); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to add finalizer: %w", err)
+		}
+	}
+
+	oldRollout := rollout.DeepCopy()
+
+	// a new version restarts the rollout from the first wave
+	if rollout.Status.Version != rollout.Spec.Version {
+		rollout.Status = kubermaticv1.AddonRolloutStatus{
+			Version: rollout.Spec.Version,
+			Phase:   kubermaticv1.AddonRolloutProgressing,
+		}
+	}
+
+	clusters, err := r.selectClusters(ctx, rollout)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if err := r.removeDeselectedAddons(ctx, rollout.Name, clusters); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	updateClusterStatuses(rollout, clusters)
+
+	requeue := false
+	if rollout.Status.Phase != kubermaticv1.AddonRolloutHalted {
+		requeue, err = r.progress(ctx, log, rollout, clusters)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	if !apiequality.Semantic.DeepEqual(oldRollout.Status, rollout.Status) {
+		if err := r.masterClient.Patch(ctx, rollout, ctrlruntimeclient.MergeFrom(oldRollout)); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to update status: %w", err)
+		}
+	}
+
+	switch {
+	case requeue:
+		return reconcile.Result{Requeue: true}, nil
+	case rollout.Status.Phase == kubermaticv1.AddonRolloutProgressing:
+		return reconcile.Result{RequeueAfter: progressInterval}, nil
+	default:
+		return reconcile.Result{}, nil
+	}
+}
+
+// selectClusters returns all clusters across all seeds which match the selector of
+// the rollout, sorted by name.
+func (r *reconciler) selectClusters(ctx context.Context, rollout *kubermaticv1.AddonRollout) ([]selectedCluster, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&rollout.Spec.ClusterSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cluster selector: %w", err)
+	}
+
+	var clusters []selectedCluster
+	for seedName, seedClient := range r.seedClients {
+		clusterList := &kubermaticv1.ClusterList{}
+		if err := seedClient.List(ctx, clusterList, &ctrlruntimeclient.ListOptions{LabelSelector: selector}); err != nil {
+			return nil, fmt.Errorf("failed to list clusters in seed %q: %w", seedName, err)
+		}
+
+		for i := range clusterList.Items {
+			cluster := &clusterList.Items[i]
+			if !cluster.DeletionTimestamp.IsZero() {
+				continue
+			}
+			clusters = append(clusters, selectedCluster{seed: seedName, cluster: cluster})
+		}
+	}
+
+	sort.Slice(clusters, func(i, j int) bool {
+		return clusters[i].cluster.Name < clusters[j].cluster.Name
+	})
+
+	return clusters, nil
+}
+
+// updateClusterStatuses makes the cluster list in the status match the selected clusters. Clusters
+// which were not selected before are assigned to a wave based on their position.
+func updateClusterStatuses(rollout *kubermaticv1.AddonRollout, clusters []selectedCluster) {
+	existing := map[string]kubermaticv1.AddonRolloutClusterStatus{}
+	for _, status := range rollout.Status.Clusters {
+		existing[status.Name] = status
+	}
+
+	boundaries := waveBoundaries(rollout.Spec.Waves, len(clusters))
+
+	statuses := make([]kubermaticv1.AddonRolloutClusterStatus, 0, len(clusters))
+	for i, selected := range clusters {
+		status, ok := existing[selected.cluster.Name]
+		if !ok {
+			status = kubermaticv1.AddonRolloutClusterStatus{
+				Name:  selected.cluster.Name,
+				Seed:  selected.seed,
+				Phase: kubermaticv1.AddonRolloutClusterPending,
+			}
+		}
+		if status.Phase == kubermaticv1.AddonRolloutClusterPending {
+			status.Wave = waveOf(i, boundaries)
+		}
+		statuses = append(statuses, status)
+	}
+
+	rollout.Status.Clusters = statuses
+}
+
+// waveBoundaries returns for each wave the number of clusters which are part of it or any
+// of the waves before.
+func waveBoundaries(waves []kubermaticv1.AddonRolloutWave, clusterCount int) []int {
+	if len(waves) == 0 {
+		return []int{clusterCount}
+	}
+
+	boundaries := make([]int, len(waves))
+	previous := 0
+	for i, wave := range waves {
+		boundary := (wave.Percentage*clusterCount + 99) / 100
+		if boundary < previous {
+			boundary = previous
+		}
+		if boundary > clusterCount || i == len(waves)-1 {
+			boundary = clusterCount
+		}
+		boundaries[i] = boundary
+		previous = boundary
+	}
+
+	return boundaries
+}
+
+func waveOf(index int, boundaries []int) int {
+	for wave, boundary := range boundaries {
+		if index < boundary {
+			return wave
+		}
+	}
+	return len(boundaries) - 1
+}
+
+// progress installs the addon into the clusters of all started waves, checks their health
+// and starts the next wave once the current one is done. It returns true if the next
+// wave was started and the rollout should be processed again right away.
+func (r *reconciler) progress(ctx context.Context, log *zap.SugaredLogger, rollout *kubermaticv1.AddonRollout, clusters []selectedCluster) (bool, error) {
+	healthTimeout := defaultHealthTimeout
+	if rollout.Spec.HealthTimeout != nil {
+		healthTimeout = rollout.Spec.HealthTimeout.Duration
+	}
+
+	failed := 0
+	waveDone := true
+
+	for i := range rollout.Status.Clusters {
+		status := &rollout.Status.Clusters[i]
+		cluster := clusters[i].cluster
+
+		if status.Wave <= rollout.Status.CurrentWave {
+			if err := r.progressCluster(ctx, log, rollout, status, cluster, healthTimeout); err != nil {
+				return false, fmt.Errorf("failed to process cluster %s: %w", cluster.Name, err)
+			}
+		}
+
+		switch status.Phase {
+		case kubermaticv1.AddonRolloutClusterFailed:
+			failed++
+		case kubermaticv1.AddonRolloutClusterPending, kubermaticv1.AddonRolloutClusterInstalling:
+			if status.Wave <= rollout.Status.CurrentWave {
+				waveDone = false
+			}
+		}
+	}
+
+	if failed > rollout.Spec.MaxFailures {
+		rollout.Status.Phase = kubermaticv1.AddonRolloutHalted
+		rollout.Status.Message = fmt.Sprintf("%d clusters failed, at most %d failures are tolerated", failed, rollout.Spec.MaxFailures)
+		r.recorder.Event(rollout, corev1.EventTypeWarning, "RolloutHalted", rollout.Status.Message)
+		return false, nil
+	}
+
+	lastWave := len(rollout.Spec.Waves) - 1
+	if lastWave < 0 {
+		lastWave = 0
+	}
+
+	switch {
+	case !waveDone:
+		rollout.Status.Phase = kubermaticv1.AddonRolloutProgressing
+		rollout.Status.Message = ""
+	case rollout.Status.CurrentWave >= lastWave:
+		rollout.Status.Phase = kubermaticv1.AddonRolloutCompleted
+		rollout.Status.Message = ""
+	case rollout.Spec.Paused:
+		rollout.Status.Phase = kubermaticv1.AddonRolloutProgressing
+		rollout.Status.Message = fmt.Sprintf("paused before wave %d", rollout.Status.CurrentWave+1)
+	default:
+		rollout.Status.CurrentWave++
+		rollout.Status.Phase = kubermaticv1.AddonRolloutProgressing
+		rollout.Status.Message = ""
+		log.Infow("Starting next wave", "wave", rollout.Status.CurrentWave)
+		return true, nil
+	}
+
+	return false, nil
+}
+
+func (r *reconciler) progressCluster(
+	ctx context.Context,
+	log *zap.SugaredLogger,
+	rollout *kubermaticv1.AddonRollout,
+	status *kubermaticv1.AddonRolloutClusterStatus,
+	cluster *kubermaticv1.Cluster,
+	healthTimeout time.Duration,
+) error {
+	seedClient, ok := r.seedClients[status.Seed]
+	if !ok {
+		return fmt.Errorf("no client for seed %q", status.Seed)
+	}
+
+	switch status.Phase {
+	case kubermaticv1.AddonRolloutClusterPending:
+		// the cluster namespace does not exist yet
+		if cluster.Status.NamespaceName == "" {
+			return nil
+		}
+
+		installed, err := r.ensureAddon(ctx, seedClient, rollout, cluster)
+		if err != nil {
+			return err
+		}
+		if !installed {
+			setClusterPhase(status, kubermaticv1.AddonRolloutClusterSkipped, "an addon of the same name not managed by this rollout exists", r.now())
+			return nil
+		}
+		log.Debugw("Installed addon", "cluster", cluster.Name)
+		setClusterPhase(status, kubermaticv1.AddonRolloutClusterInstalling, "", r.now())
+
+	case kubermaticv1.AddonRolloutClusterInstalling:
+		addon := &kubermaticv1.Addon{}
+		key := types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: rollout.Spec.Addon}
+		if err := seedClient.Get(ctx, key, addon); err != nil {
+			if !kerrors.IsNotFound(err) {
+				return err
+			}
+			// someone removed the addon, start over
+			setClusterPhase(status, kubermaticv1.AddonRolloutClusterPending, "", r.now())
+			return nil
+		}
+
+		if isHealthy(cluster, addon) {
+			setClusterPhase(status, kubermaticv1.AddonRolloutClusterHealthy, "", r.now())
+			return nil
+		}
+
+		if r.now().Sub(status.LastTransitionTime.Time) > healthTimeout {
+			setClusterPhase(status, kubermaticv1.AddonRolloutClusterFailed, fmt.Sprintf("cluster did not become healthy within %v", healthTimeout), r.now())
+		}
+	}
+
+	return nil
+}
+
+// ensureAddon creates or updates the addon in the cluster. It returns false if the
+// cluster already has an addon of the same name which is not managed by the rollout.
+func (r *reconciler) ensureAddon(ctx context.Context, seedClient ctrlruntimeclient.Client, rollout *kubermaticv1.AddonRollout, cluster *kubermaticv1.Cluster) (bool, error) {
+	addon := &kubermaticv1.Addon{}
+	key := types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: rollout.Spec.Addon}
+	if err := seedClient.Get(ctx, key, addon); err != nil {
+		if !kerrors.IsNotFound(err) {
+			return false, err
+		}
+
+		addon = &kubermaticv1.Addon{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      rollout.Spec.Addon,
+				Namespace: cluster.Status.NamespaceName,
+			},
+		}
+		setAddonSpec(addon, rollout, cluster)
+		return true, seedClient.Create(ctx, addon)
+	}
+
+	if addon.Labels[kubermaticv1.AddonRolloutLabelKey] != rollout.Name {
+		return false, nil
+	}
+
+	oldAddon := addon.DeepCopy()
+	setAddonSpec(addon, rollout, cluster)
+	if oldAddon.Annotations[kubermaticv1.AddonRolloutVersionAnnotation] != rollout.Spec.Version {
+		// the addon controller only sets the condition once, so it has to be reset
+		// for the new version to be confirmed
+		addon.Status.Conditions = nil
+	}
+
+	return true, seedClient.Patch(ctx, addon, ctrlruntimeclient.MergeFrom(oldAddon))
+}
+
+func setAddonSpec(addon *kubermaticv1.Addon, rollout *kubermaticv1.AddonRollout, cluster *kubermaticv1.Cluster) {
+	if addon.Labels == nil {
+		addon.Labels = map[string]string{}
+	}
+	addon.Labels[kubermaticv1.AddonRolloutLabelKey] = rollout.Name
+
+	if addon.Annotations == nil {
+		addon.Annotations = map[string]string{}
+	}
+	addon.Annotations[kubermaticv1.AddonRolloutVersionAnnotation] = rollout.Spec.Version
+
+	addon.Spec.Name = rollout.Spec.Addon
+	addon.Spec.Variables = rollout.Spec.Variables
+	addon.Spec.Cluster = corev1.ObjectReference{
+		Name:       cluster.Name,
+		Namespace:  "",
+		UID:        cluster.UID,
+		APIVersion: cluster.APIVersion,
+		Kind:       "Cluster",
+	}
+}
+
+func isHealthy(cluster *kubermaticv1.Cluster, addon *kubermaticv1.Addon) bool {
+	if !cluster.Status.ExtendedHealth.AllHealthy() {
+		return false
+	}
+
+	for _, condition := range addon.Status.Conditions {
+		if condition.Type == kubermaticv1.AddonResourcesCreated {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
+
+func setClusterPhase(status *kubermaticv1.AddonRolloutClusterStatus, phase kubermaticv1.AddonRolloutClusterPhase, message string, now time.Time) {
+	status.Phase = phase
+	status.Message = message
+	status.LastTransitionTime = metav1.NewTime(now)
+}
+
+// removeDeselectedAddons deletes the addons of the rollout from all clusters which are not
+// selected anymore.
+func (r *reconciler) removeDeselectedAddons(ctx context.Context, rolloutName string, clusters []selectedCluster) error {
+	selectedNamespaces := map[string]sets.String{}
+	for _, selected := range clusters {
+		if selectedNamespaces[selected.seed] == nil {
+			selectedNamespaces[selected.seed] = sets.NewString()
+		}
+		selectedNamespaces[selected.seed].Insert(selected.cluster.Status.NamespaceName)
+	}
+
+	for seedName, seedClient := range r.seedClients {
+		addons := &kubermaticv1.AddonList{}
+		if err := seedClient.List(ctx, addons, ctrlruntimeclient.MatchingLabelsSelector{
+			Selector: labels.SelectorFromSet(labels.Set{kubermaticv1.AddonRolloutLabelKey: rolloutName}),
+		}); err != nil {
+			return fmt.Errorf("failed to list addons in seed %q: %w", seedName, err)
+		}
+
+		for i := range addons.Items {
+			addon := &addons.Items[i]
+			if selectedNamespaces[seedName].Has(addon.Namespace) {
+				continue
+			}
+			if err := seedClient.Delete(ctx, addon); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+				return fmt.Errorf("failed to delete addon %s/%s in seed %q: %w", addon.Namespace, addon.Name, seedName, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *reconciler) handleDeletion(ctx context.Context, rollout *kubermaticv1.AddonRollout) error {
+	if !kuberneteshelper.HasFinalizer(rollout, kubermaticapiv1.AddonRolloutCleanupFinalizer) {
+		return nil
+	}
+
+	if err := r.removeDeselectedAddons(ctx, rollout.Name, nil); err != nil {
+		return err
+	}
+
+	kuberneteshelper.RemoveFinalizer(rollout, kubermaticapiv1.AddonRolloutCleanupFinalizer)
+	if err := r.masterClient.Update(ctx, rollout); err != nil {
+		return fmt.Errorf("failed to remove finalizer: %w", err)
+	}
+
+	return nil
+}
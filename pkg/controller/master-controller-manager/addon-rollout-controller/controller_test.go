@@ -0,0 +1,381 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addonrolloutcontroller
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	kubermaticapiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	"k8c.io/kubermatic/v2/pkg/crd/client/clientset/versioned/scheme"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const rolloutName = "monitoring"
+
+var now = time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func TestReconcile(t *testing.T) {
+	testCases := []struct {
+		name             string
+		rollout          *kubermaticv1.AddonRollout
+		seedObjects      []ctrlruntimeclient.Object
+		expectedPhase    kubermaticv1.AddonRolloutPhase
+		expectedWave     int
+		expectedClusters map[string]kubermaticv1.AddonRolloutClusterPhase
+		expectedAddons   []string
+	}{
+		{
+			name:    "scenario 1: install the addon into the clusters of the first wave",
+			rollout: generateRollout(nil),
+			seedObjects: []ctrlruntimeclient.Object{
+				generateCluster("a", true), generateCluster("b", true), generateCluster("c", true), generateCluster("d", true),
+			},
+			expectedPhase: kubermaticv1.AddonRolloutProgressing,
+			expectedWave:  0,
+			expectedClusters: map[string]kubermaticv1.AddonRolloutClusterPhase{
+				"a": kubermaticv1.AddonRolloutClusterInstalling,
+				"b": kubermaticv1.AddonRolloutClusterInstalling,
+				"c": kubermaticv1.AddonRolloutClusterPending,
+				"d": kubermaticv1.AddonRolloutClusterPending,
+			},
+			expectedAddons: []string{"a", "b"},
+		},
+		{
+			name: "scenario 2: start the next wave once the first one is healthy",
+			rollout: generateRollout([]kubermaticv1.AddonRolloutClusterStatus{
+				generateClusterStatus("a", 0, kubermaticv1.AddonRolloutClusterInstalling, now),
+				generateClusterStatus("b", 0, kubermaticv1.AddonRolloutClusterHealthy, now),
+				generateClusterStatus("c", 1, kubermaticv1.AddonRolloutClusterPending, now),
+			}),
+			seedObjects: []ctrlruntimeclient.Object{
+				generateCluster("a", true), generateCluster("b", true), generateCluster("c", true),
+				generateAddon("a", rolloutName, true), generateAddon("b", rolloutName, true),
+			},
+			expectedPhase: kubermaticv1.AddonRolloutCompleted,
+			expectedWave:  1,
+			expectedClusters: map[string]kubermaticv1.AddonRolloutClusterPhase{
+				"a": kubermaticv1.AddonRolloutClusterHealthy,
+				"b": kubermaticv1.AddonRolloutClusterHealthy,
+				"c": kubermaticv1.AddonRolloutClusterHealthy,
+			},
+			expectedAddons: []string{"a", "b", "c"},
+		},
+		{
+			name: "scenario 3: halt the rollout when a cluster does not become healthy in time",
+			rollout: generateRollout([]kubermaticv1.AddonRolloutClusterStatus{
+				generateClusterStatus("a", 0, kubermaticv1.AddonRolloutClusterInstalling, now.Add(-time.Hour)),
+				generateClusterStatus("b", 1, kubermaticv1.AddonRolloutClusterPending, now),
+			}),
+			seedObjects: []ctrlruntimeclient.Object{
+				generateCluster("a", false), generateCluster("b", true),
+				generateAddon("a", rolloutName, true),
+			},
+			expectedPhase: kubermaticv1.AddonRolloutHalted,
+			expectedWave:  0,
+			expectedClusters: map[string]kubermaticv1.AddonRolloutClusterPhase{
+				"a": kubermaticv1.AddonRolloutClusterFailed,
+				"b": kubermaticv1.AddonRolloutClusterPending,
+			},
+			expectedAddons: []string{"a"},
+		},
+		{
+			name:    "scenario 4: skip clusters which already have an unmanaged addon of the same name",
+			rollout: generateRollout(nil),
+			seedObjects: []ctrlruntimeclient.Object{
+				generateCluster("a", true), generateAddon("a", "", true),
+			},
+			expectedPhase: kubermaticv1.AddonRolloutCompleted,
+			expectedWave:  1,
+			expectedClusters: map[string]kubermaticv1.AddonRolloutClusterPhase{
+				"a": kubermaticv1.AddonRolloutClusterSkipped,
+			},
+			expectedAddons: []string{"a"},
+		},
+		{
+			name:    "scenario 5: remove the addon from clusters which are not selected anymore",
+			rollout: generateRollout(nil),
+			seedObjects: []ctrlruntimeclient.Object{
+				generateCluster("a", true), generateAddon("a", rolloutName, true), generateAddon("gone", rolloutName, true),
+			},
+			expectedPhase: kubermaticv1.AddonRolloutCompleted,
+			expectedWave:  1,
+			expectedClusters: map[string]kubermaticv1.AddonRolloutClusterPhase{
+				"a": kubermaticv1.AddonRolloutClusterHealthy,
+			},
+			expectedAddons: []string{"a"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			masterClient := fakectrlruntimeclient.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(tc.rollout).Build()
+			seedClient := fakectrlruntimeclient.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(tc.seedObjects...).Build()
+
+			r := &reconciler{
+				log:          kubermaticlog.Logger,
+				recorder:     &record.FakeRecorder{},
+				masterClient: masterClient,
+				seedClients:  map[string]ctrlruntimeclient.Client{"europe": seedClient},
+				now:          func() time.Time { return now },
+			}
+
+			request := reconcile.Request{NamespacedName: types.NamespacedName{Name: rolloutName}}
+			reconcileUntilSettled(ctx, t, r, request)
+
+			// the health of freshly installed addons is only checked on the next reconciliation
+			if tc.expectedPhase == kubermaticv1.AddonRolloutCompleted {
+				markAddonsCreated(ctx, t, seedClient)
+				reconcileUntilSettled(ctx, t, r, request)
+			}
+
+			rollout := &kubermaticv1.AddonRollout{}
+			if err := masterClient.Get(ctx, request.NamespacedName, rollout); err != nil {
+				t.Fatalf("failed to get rollout: %v", err)
+			}
+
+			if rollout.Status.Phase != tc.expectedPhase {
+				t.Errorf("expected phase %q, got %q (%s)", tc.expectedPhase, rollout.Status.Phase, rollout.Status.Message)
+			}
+			if rollout.Status.CurrentWave != tc.expectedWave {
+				t.Errorf("expected wave %d, got %d", tc.expectedWave, rollout.Status.CurrentWave)
+			}
+
+			clusters := map[string]kubermaticv1.AddonRolloutClusterPhase{}
+			for _, status := range rollout.Status.Clusters {
+				clusters[status.Name] = status.Phase
+			}
+			if !reflect.DeepEqual(clusters, tc.expectedClusters) {
+				t.Errorf("expected clusters %v, got %v", tc.expectedClusters, clusters)
+			}
+
+			addons := &kubermaticv1.AddonList{}
+			if err := seedClient.List(ctx, addons); err != nil {
+				t.Fatalf("failed to list addons: %v", err)
+			}
+			var addonClusters []string
+			for _, addon := range addons.Items {
+				addonClusters = append(addonClusters, addon.Spec.Cluster.Name)
+			}
+			if !reflect.DeepEqual(addonClusters, tc.expectedAddons) {
+				t.Errorf("expected addons in clusters %v, got %v", tc.expectedAddons, addonClusters)
+			}
+		})
+	}
+}
+
+func TestReconcileDeletion(t *testing.T) {
+	ctx := context.Background()
+
+	rollout := generateRollout(nil)
+	deletionTime := metav1.NewTime(now)
+	rollout.DeletionTimestamp = &deletionTime
+	rollout.Finalizers = []string{kubermaticapiv1.AddonRolloutCleanupFinalizer}
+
+	masterClient := fakectrlruntimeclient.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(rollout).Build()
+	seedClient := fakectrlruntimeclient.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		generateCluster("a", true), generateAddon("a", rolloutName, true), generateAddon("b", "", true),
+	).Build()
+
+	r := &reconciler{
+		log:          kubermaticlog.Logger,
+		recorder:     &record.FakeRecorder{},
+		masterClient: masterClient,
+		seedClients:  map[string]ctrlruntimeclient.Client{"europe": seedClient},
+		now:          func() time.Time { return now },
+	}
+
+	if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: rolloutName}}); err != nil {
+		t.Fatalf("reconciling failed: %v", err)
+	}
+
+	addons := &kubermaticv1.AddonList{}
+	if err := seedClient.List(ctx, addons); err != nil {
+		t.Fatalf("failed to list addons: %v", err)
+	}
+	if len(addons.Items) != 1 || addons.Items[0].Spec.Cluster.Name != "b" {
+		t.Fatalf("expected only the unmanaged addon to remain, got %v", addons.Items)
+	}
+}
+
+func TestWaveBoundaries(t *testing.T) {
+	testCases := []struct {
+		name     string
+		waves    []kubermaticv1.AddonRolloutWave
+		clusters int
+		expected []int
+	}{
+		{
+			name:     "no waves",
+			clusters: 5,
+			expected: []int{5},
+		},
+		{
+			name:     "percentages are rounded up",
+			waves:    []kubermaticv1.AddonRolloutWave{{Percentage: 10}, {Percentage: 50}, {Percentage: 100}},
+			clusters: 5,
+			expected: []int{1, 3, 5},
+		},
+		{
+			name:     "last wave always covers all clusters",
+			waves:    []kubermaticv1.AddonRolloutWave{{Percentage: 25}, {Percentage: 50}},
+			clusters: 8,
+			expected: []int{2, 8},
+		},
+		{
+			name:     "decreasing percentages do not shrink waves",
+			waves:    []kubermaticv1.AddonRolloutWave{{Percentage: 50}, {Percentage: 20}, {Percentage: 100}},
+			clusters: 10,
+			expected: []int{5, 5, 10},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if boundaries := waveBoundaries(tc.waves, tc.clusters); !reflect.DeepEqual(boundaries, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, boundaries)
+			}
+		})
+	}
+}
+
+func generateRollout(clusters []kubermaticv1.AddonRolloutClusterStatus) *kubermaticv1.AddonRollout {
+	rollout := &kubermaticv1.AddonRollout{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: rolloutName,
+		},
+		Spec: kubermaticv1.AddonRolloutSpec{
+			Addon:   "node-exporter",
+			Version: "v1",
+			ClusterSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{"monitoring": "true"},
+			},
+			Waves: []kubermaticv1.AddonRolloutWave{{Percentage: 50}, {Percentage: 100}},
+		},
+	}
+	if clusters != nil {
+		rollout.Status = kubermaticv1.AddonRolloutStatus{
+			Phase:    kubermaticv1.AddonRolloutProgressing,
+			Version:  "v1",
+			Clusters: clusters,
+		}
+	}
+	return rollout
+}
+
+func generateClusterStatus(name string, wave int, phase kubermaticv1.AddonRolloutClusterPhase, transition time.Time) kubermaticv1.AddonRolloutClusterStatus {
+	return kubermaticv1.AddonRolloutClusterStatus{
+		Name:               name,
+		Seed:               "europe",
+		Wave:               wave,
+		Phase:              phase,
+		LastTransitionTime: metav1.NewTime(transition),
+	}
+}
+
+func generateCluster(name string, healthy bool) *kubermaticv1.Cluster {
+	health := kubermaticv1.HealthStatusUp
+	if !healthy {
+		health = kubermaticv1.HealthStatusDown
+	}
+
+	return &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{"monitoring": "true"},
+		},
+		Status: kubermaticv1.ClusterStatus{
+			NamespaceName: "cluster-" + name,
+			ExtendedHealth: kubermaticv1.ExtendedClusterHealth{
+				Apiserver:                    kubermaticv1.HealthStatusUp,
+				Scheduler:                    kubermaticv1.HealthStatusUp,
+				Controller:                   kubermaticv1.HealthStatusUp,
+				MachineController:            health,
+				Etcd:                         kubermaticv1.HealthStatusUp,
+				CloudProviderInfrastructure:  kubermaticv1.HealthStatusUp,
+				UserClusterControllerManager: kubermaticv1.HealthStatusUp,
+			},
+		},
+	}
+}
+
+func generateAddon(clusterName, rollout string, created bool) *kubermaticv1.Addon {
+	addon := &kubermaticv1.Addon{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "node-exporter",
+			Namespace: "cluster-" + clusterName,
+		},
+		Spec: kubermaticv1.AddonSpec{
+			Name:    "node-exporter",
+			Cluster: corev1.ObjectReference{Kind: "Cluster", Name: clusterName},
+		},
+	}
+	if rollout != "" {
+		addon.Labels = map[string]string{kubermaticv1.AddonRolloutLabelKey: rollout}
+		addon.Annotations = map[string]string{kubermaticv1.AddonRolloutVersionAnnotation: "v1"}
+	}
+	if created {
+		addon.Status.Conditions = []kubermaticv1.AddonCondition{{
+			Type:   kubermaticv1.AddonResourcesCreated,
+			Status: corev1.ConditionTrue,
+		}}
+	}
+	return addon
+}
+
+// reconcileUntilSettled reconciles the rollout until no further wave is started right away.
+func reconcileUntilSettled(ctx context.Context, t *testing.T, r *reconciler, request reconcile.Request) {
+	for {
+		result, err := r.Reconcile(ctx, request)
+		if err != nil {
+			t.Fatalf("reconciling failed: %v", err)
+		}
+		if !result.Requeue {
+			return
+		}
+	}
+}
+
+// markAddonsCreated does what the addon controller in the seed would do.
+func markAddonsCreated(ctx context.Context, t *testing.T, seedClient ctrlruntimeclient.Client) {
+	addons := &kubermaticv1.AddonList{}
+	if err := seedClient.List(ctx, addons); err != nil {
+		t.Fatalf("failed to list addons: %v", err)
+	}
+	for i := range addons.Items {
+		addon := &addons.Items[i]
+		addon.Status.Conditions = []kubermaticv1.AddonCondition{{
+			Type:   kubermaticv1.AddonResourcesCreated,
+			Status: corev1.ConditionTrue,
+		}}
+		if err := seedClient.Update(ctx, addon); err != nil {
+			t.Fatalf("failed to update addon: %v", err)
+		}
+	}
+}
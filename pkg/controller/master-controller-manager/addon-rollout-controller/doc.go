@@ -0,0 +1,27 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package addonrolloutcontroller contains a controller that rolls out AddonRollouts, i.e. installs an
+addon into all user clusters across all seeds which match a label selector.
+
+The selected clusters are sorted by name and split into waves. The addon is only installed into the
+clusters of the next wave once every cluster of the previous waves is either healthy, failed or
+skipped. A cluster is healthy when the addon controller reports the addon resources as created and
+all control plane components of the cluster are up. If more clusters fail than the rollout
+tolerates, the rollout is halted until its version is changed.
+*/
+package addonrolloutcontroller
@@ -0,0 +1,220 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azureresourcegccontroller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/provider/cloud/azure"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	// This controller finds and optionally deletes Azure resources of clusters which do not exist anymore.
+	ControllerName = "azure_resource_gc_controller"
+)
+
+type collector struct {
+	log           *zap.SugaredLogger
+	masterClient  ctrlruntimeclient.Client
+	seedClients   map[string]ctrlruntimeclient.Client
+	deleteOrphans bool
+	// installationID is the value of the installation tag of the resources this installation creates,
+	// resources of other installations are never considered orphaned.
+	installationID string
+
+	// listResources and deleteResource are overridden in tests
+	listResources  func(context.Context, azure.Credentials) ([]azure.TaggedResource, error)
+	deleteResource func(context.Context, azure.Credentials, azure.TaggedResource) error
+}
+
+// Add creates a new Azure resource garbage collector which looks for orphaned resources of the given
+// installation in the given interval. Orphaned resources are only deleted if deleteOrphans is set.
+func Add(
+	masterMgr manager.Manager,
+	seedManagers map[string]manager.Manager,
+	log *zap.SugaredLogger,
+	interval time.Duration,
+	deleteOrphans bool,
+	installationID string,
+) error {
+	c := &collector{
+		log:            log.Named(ControllerName),
+		masterClient:   masterMgr.GetClient(),
+		seedClients:    map[string]ctrlruntimeclient.Client{},
+		deleteOrphans:  deleteOrphans,
+		installationID: installationID,
+		listResources:  azure.ListClusterResources,
+		deleteResource: azure.DeleteResource,
+	}
+
+	for seedName, seedManager := range seedManagers {
+		c.seedClients[seedName] = seedManager.GetClient()
+	}
+
+	if err := masterMgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		wait.UntilWithContext(ctx, c.collect, interval)
+		return nil
+	})); err != nil {
+		return fmt.Errorf("failed to add runnable to manager: %w", err)
+	}
+
+	return nil
+}
+
+func (c *collector) collect(ctx context.Context) {
+	clusters, subscriptions, err := c.clustersAndSubscriptions(ctx)
+	if err != nil {
+		// Without knowing every cluster, resources of existing clusters would be considered orphaned.
+		c.log.Errorw("Failed to determine clusters, skipping garbage collection", zap.Error(err))
+		return
+	}
+
+	orphanedResourcesMetric.Reset()
+
+	subscriptionIDs := make([]string, 0, len(subscriptions))
+	for subscriptionID := range subscriptions {
+		subscriptionIDs = append(subscriptionIDs, subscriptionID)
+	}
+	sort.Strings(subscriptionIDs)
+
+	for _, subscriptionID := range subscriptionIDs {
+		if err := c.collectSubscription(ctx, subscriptions[subscriptionID], clusters); err != nil {
+			c.log.Errorw("Failed to collect orphaned resources", "subscription", subscriptionID, zap.Error(err))
+		}
+	}
+}
+
+// clustersAndSubscriptions returns the names of all clusters across all seeds and the credentials of
+// every subscription used by an Azure cluster or preset, keyed by the subscription ID.
+func (c *collector) clustersAndSubscriptions(ctx context.Context) (sets.String, map[string]azure.Credentials, error) {
+	clusters := sets.NewString()
+	subscriptions := map[string]azure.Credentials{}
+
+	for seedName, seedClient := range c.seedClients {
+		clusterList := &kubermaticv1.ClusterList{}
+		if err := seedClient.List(ctx, clusterList); err != nil {
+			return nil, nil, fmt.Errorf("failed to list clusters in seed %q: %w", seedName, err)
+		}
+
+		secretKeySelector := provider.SecretKeySelectorValueFuncFactory(ctx, seedClient)
+		for _, cluster := range clusterList.Items {
+			clusters.Insert(cluster.Name)
+
+			if cluster.Spec.Cloud.Azure == nil {
+				continue
+			}
+			credentials, err := azure.GetCredentialsForCluster(cluster.Spec.Cloud, secretKeySelector)
+			if err != nil {
+				c.log.Debugw("Failed to get credentials of cluster", "seed", seedName, "cluster", cluster.Name, zap.Error(err))
+				continue
+			}
+			addSubscription(subscriptions, credentials)
+		}
+	}
+
+	presets := &kubermaticv1.PresetList{}
+	if err := c.masterClient.List(ctx, presets); err != nil {
+		return nil, nil, fmt.Errorf("failed to list presets: %w", err)
+	}
+	for _, preset := range presets.Items {
+		if preset.Spec.Azure == nil || !preset.Spec.Azure.IsValid() {
+			continue
+		}
+		addSubscription(subscriptions, azure.Credentials{
+			TenantID:       preset.Spec.Azure.TenantID,
+			SubscriptionID: preset.Spec.Azure.SubscriptionID,
+			ClientID:       preset.Spec.Azure.ClientID,
+			ClientSecret:   preset.Spec.Azure.ClientSecret,
+		})
+	}
+
+	return clusters, subscriptions, nil
+}
+
+func addSubscription(subscriptions map[string]azure.Credentials, credentials azure.Credentials) {
+	if _, ok := subscriptions[credentials.SubscriptionID]; !ok {
+		subscriptions[credentials.SubscriptionID] = credentials
+	}
+}
+
+func (c *collector) collectSubscription(ctx context.Context, credentials azure.Credentials, clusters sets.String) error {
+	log := c.log.With("subscription", credentials.SubscriptionID)
+
+	tagged, err := c.listResources(ctx, credentials)
+	if err != nil {
+		return err
+	}
+
+	orphaned := azure.FindOrphanedResources(tagged, clusters, c.installationID, time.Now())
+	for _, resource := range orphaned {
+		orphanedResourcesMetric.WithLabelValues(credentials.SubscriptionID, resource.Type).Inc()
+
+		if !c.deleteOrphans || !resource.Deletable() {
+			log.Infow("Found orphaned resource", "resource", resource.ID, "cluster", resource.Cluster)
+			continue
+		}
+
+		// The cluster may have been created since the clusters were listed.
+		exists, err := c.clusterExists(ctx, resource.Cluster)
+		if err != nil {
+			log.Errorw("Failed to check whether the cluster of the orphaned resource exists", "resource", resource.ID, "cluster", resource.Cluster, zap.Error(err))
+			continue
+		}
+		if exists {
+			continue
+		}
+
+		if err := c.deleteResource(ctx, credentials, resource); err != nil {
+			// Resources which still depend on each other are deleted in one of the next runs.
+			log.Errorw("Failed to delete orphaned resource", "resource", resource.ID, "cluster", resource.Cluster, zap.Error(err))
+			continue
+		}
+		deletedResourcesMetric.WithLabelValues(credentials.SubscriptionID, resource.Type).Inc()
+		log.Infow("Deleted orphaned resource", "resource", resource.ID, "cluster", resource.Cluster)
+	}
+
+	return nil
+}
+
+// clusterExists returns whether a cluster with the given name exists in any seed.
+func (c *collector) clusterExists(ctx context.Context, name string) (bool, error) {
+	for seedName, seedClient := range c.seedClients {
+		err := seedClient.Get(ctx, types.NamespacedName{Name: name}, &kubermaticv1.Cluster{})
+		if err == nil {
+			return true, nil
+		}
+		if !kerrors.IsNotFound(err) {
+			return false, fmt.Errorf("failed to get cluster %q in seed %q: %w", name, seedName, err)
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azureresourcegccontroller
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"k8c.io/kubermatic/v2/pkg/crd/client/clientset/versioned/scheme"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+	"k8c.io/kubermatic/v2/pkg/provider/cloud/azure"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func azureCluster(name, subscriptionID string) *kubermaticv1.Cluster {
+	return &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: kubermaticv1.ClusterSpec{
+			Cloud: kubermaticv1.CloudSpec{
+				Azure: &kubermaticv1.AzureCloudSpec{
+					TenantID:       "tenant",
+					SubscriptionID: subscriptionID,
+					ClientID:       "client",
+					ClientSecret:   "secret",
+				},
+			},
+		},
+	}
+}
+
+// listHidingClient hides the clusters from lists, like a cluster which is created after the
+// clusters have been listed.
+type listHidingClient struct {
+	ctrlruntimeclient.Client
+}
+
+func (c listHidingClient) List(_ context.Context, _ ctrlruntimeclient.ObjectList, _ ...ctrlruntimeclient.ListOption) error {
+	return nil
+}
+
+func managed(resource azure.TaggedResource, installationID string) azure.TaggedResource {
+	resource.Installation = installationID
+	resource.Managed = true
+	return resource
+}
+
+func TestCollect(t *testing.T) {
+	preset := &kubermaticv1.Preset{
+		ObjectMeta: metav1.ObjectMeta{Name: "azure"},
+		Spec: kubermaticv1.PresetSpec{
+			Azure: &kubermaticv1.Azure{
+				TenantID:       "tenant",
+				SubscriptionID: "sub-preset",
+				ClientID:       "client",
+				ClientSecret:   "secret",
+			},
+		},
+	}
+
+	resources := map[string][]azure.TaggedResource{
+		"sub-cluster": {
+			managed(azure.TaggedResource{ID: "nic-a", Type: "Microsoft.Network/networkInterfaces", Cluster: "cluster-a"}, "kkp"),
+			managed(azure.TaggedResource{ID: "nic-gone", Type: "Microsoft.Network/networkInterfaces", Cluster: "gone"}, "kkp"),
+			managed(azure.TaggedResource{ID: "storage-gone", Type: "Microsoft.Storage/storageAccounts", Cluster: "gone"}, "kkp"),
+			managed(azure.TaggedResource{ID: "vm-gone", Type: "Microsoft.Compute/virtualMachines", Cluster: "gone"}, "kkp"),
+			managed(azure.TaggedResource{ID: "rg-gone", Type: "Microsoft.Resources/resourceGroups", Cluster: "gone"}, "kkp"),
+			// the cluster was created after the clusters have been listed
+			managed(azure.TaggedResource{ID: "nic-new", Type: "Microsoft.Network/networkInterfaces", Cluster: "cluster-new"}, "kkp"),
+			// resources of other installations and tools also using the cluster tag are left alone
+			managed(azure.TaggedResource{ID: "nic-other-installation", Type: "Microsoft.Network/networkInterfaces", Cluster: "gone"}, "other-kkp"),
+			{ID: "nic-unmanaged", Type: "Microsoft.Network/networkInterfaces", Cluster: "gone"},
+		},
+		"sub-preset": {
+			// cluster-b lives in another seed, but shares the subscription
+			managed(azure.TaggedResource{ID: "disk-b", Type: "Microsoft.Compute/disks", Cluster: "cluster-b"}, "kkp"),
+			managed(azure.TaggedResource{ID: "pip-gone", Type: "Microsoft.Network/publicIPAddresses", Cluster: "other-gone"}, "kkp"),
+		},
+	}
+
+	testCases := []struct {
+		name            string
+		deleteOrphans   bool
+		seedListFails   bool
+		expectedListed  []string
+		expectedDeleted []string
+	}{
+		{
+			name:           "orphans are only reported by default",
+			expectedListed: []string{"sub-cluster", "sub-preset"},
+		},
+		{
+			name:            "deletable orphans are deleted in order if enabled",
+			deleteOrphans:   true,
+			expectedListed:  []string{"sub-cluster", "sub-preset"},
+			expectedDeleted: []string{"vm-gone", "nic-gone", "pip-gone"},
+		},
+		{
+			name:          "nothing is collected if a seed cannot be listed",
+			deleteOrphans: true,
+			seedListFails: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var listed, deleted []string
+
+			seedB := ctrlruntimeclient.Client(fakectrlruntimeclient.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(azureCluster("cluster-b", "sub-preset")).Build())
+			if tc.seedListFails {
+				// Clusters are unknown to an empty scheme, so listing them fails.
+				seedB = fakectrlruntimeclient.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
+			}
+
+			c := &collector{
+				log:          kubermaticlog.Logger,
+				masterClient: fakectrlruntimeclient.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(preset).Build(),
+				seedClients: map[string]ctrlruntimeclient.Client{
+					"seed-a": fakectrlruntimeclient.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(azureCluster("cluster-a", "sub-cluster")).Build(),
+					"seed-b": seedB,
+					"seed-c": listHidingClient{fakectrlruntimeclient.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(azureCluster("cluster-new", "sub-cluster")).Build()},
+				},
+				deleteOrphans:  tc.deleteOrphans,
+				installationID: "kkp",
+				listResources: func(_ context.Context, credentials azure.Credentials) ([]azure.TaggedResource, error) {
+					listed = append(listed, credentials.SubscriptionID)
+					return resources[credentials.SubscriptionID], nil
+				},
+				deleteResource: func(_ context.Context, _ azure.Credentials, resource azure.TaggedResource) error {
+					if !resource.Deletable() {
+						return errors.New("not deletable")
+					}
+					deleted = append(deleted, resource.ID)
+					return nil
+				},
+			}
+
+			c.collect(context.Background())
+
+			if !reflect.DeepEqual(listed, tc.expectedListed) {
+				t.Errorf("expected subscriptions %v to be listed, got %v", tc.expectedListed, listed)
+			}
+			if !reflect.DeepEqual(deleted, tc.expectedDeleted) {
+				t.Errorf("expected resources %v to be deleted, got %v", tc.expectedDeleted, deleted)
+			}
+		})
+	}
+}
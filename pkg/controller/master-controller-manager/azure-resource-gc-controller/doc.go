@@ -0,0 +1,28 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package azureresourcegccontroller contains a controller that periodically looks for Azure resources
+which are tagged as belonging to a cluster that does not exist anymore. Such resources are left
+behind when the cleanup of a cluster or of its machines failed, e.g. network interfaces, disks or
+public IPs, and would otherwise leak silently.
+
+The subscriptions are taken from the credentials of all Azure clusters across all seeds and from
+the Azure presets. As clusters from every seed are known, resources of clusters in other seeds
+sharing a subscription are never considered orphaned. The orphaned resources are reported as a
+metric and, if enabled, deleted.
+*/
+package azureresourcegccontroller
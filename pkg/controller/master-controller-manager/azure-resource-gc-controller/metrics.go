@@ -0,0 +1,42 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azureresourcegccontroller
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	orphanedResourcesMetric = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "kubermatic",
+		Subsystem: "azure",
+		Name:      "orphaned_resources",
+		Help:      "The number of Azure resources found during the last run which belong to a cluster that does not exist anymore",
+	}, []string{"subscription", "type"})
+
+	deletedResourcesMetric = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "kubermatic",
+		Subsystem: "azure",
+		Name:      "orphaned_resources_deleted_total",
+		Help:      "The number of orphaned Azure resources which were deleted",
+	}, []string{"subscription", "type"})
+)
+
+func init() {
+	prometheus.MustRegister(orphanedResourcesMetric)
+	prometheus.MustRegister(deletedResourcesMetric)
+}
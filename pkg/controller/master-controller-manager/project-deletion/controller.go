@@ -0,0 +1,379 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package projectdeletion
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+
+	kubermaticapiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	controllerutil "k8c.io/kubermatic/v2/pkg/controller/util"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/provider/kubernetes"
+
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	// This controller deletes the resources of deleted projects stage by stage.
+	ControllerName = "project_deletion_controller"
+
+	// progressInterval is the interval in which the teardown of a project is checked while its
+	// resources are deleted.
+	progressInterval = 10 * time.Second
+)
+
+type reconciler struct {
+	log          *zap.SugaredLogger
+	masterClient ctrlruntimeclient.Client
+	seedClients  map[string]ctrlruntimeclient.Client
+	recorder     record.EventRecorder
+}
+
+func Add(
+	masterMgr manager.Manager,
+	seedManagers map[string]manager.Manager,
+	log *zap.SugaredLogger,
+	numWorkers int,
+) error {
+	r := &reconciler{
+		log:          log.Named(ControllerName),
+		masterClient: masterMgr.GetClient(),
+		seedClients:  map[string]ctrlruntimeclient.Client{},
+		recorder:     masterMgr.GetEventRecorderFor(ControllerName),
+	}
+
+	c, err := controller.New(ControllerName, masterMgr, controller.Options{Reconciler: r, MaxConcurrentReconciles: numWorkers})
+	if err != nil {
+		return fmt.Errorf("failed to construct controller: %w", err)
+	}
+
+	for seedName, seedManager := range seedManagers {
+		r.seedClients[seedName] = seedManager.GetClient()
+
+		// The next stage starts once the last cluster of a project is gone.
+		clusterWatch := &source.Kind{Type: &kubermaticv1.Cluster{}}
+		if err := clusterWatch.InjectCache(seedManager.GetCache()); err != nil {
+			return fmt.Errorf("failed to inject cache for seed %q into watch: %w", seedName, err)
+		}
+		if err := c.Watch(clusterWatch, enqueueOwningProject()); err != nil {
+			return fmt.Errorf("failed to watch clusters in seed %q: %w", seedName, err)
+		}
+	}
+
+	if err := c.Watch(&source.Kind{Type: &kubermaticv1.Project{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("failed to watch projects: %w", err)
+	}
+
+	return nil
+}
+
+func enqueueOwningProject() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(a ctrlruntimeclient.Object) []reconcile.Request {
+		name := a.GetLabels()[kubermaticv1.ProjectIDLabelKey]
+		if name == "" {
+			return nil
+		}
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: name}}}
+	})
+}
+
+func (r *reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.With("project", request.Name)
+	log.Debug("Processing")
+
+	project := &kubermaticv1.Project{}
+	if err := r.masterClient.Get(ctx, request.NamespacedName, project); err != nil {
+		return reconcile.Result{}, ctrlruntimeclient.IgnoreNotFound(err)
+	}
+
+	result, err := r.reconcile(ctx, log, project)
+	if controllerutil.IsCacheNotStarted(err) {
+		return reconcile.Result{RequeueAfter: 5 * time.Second}, nil
+	}
+	if err != nil {
+		log.Errorw("ReconcilingError", zap.Error(err))
+		r.recorder.Event(project, corev1.EventTypeWarning, "ReconcilingError", err.Error())
+	}
+
+	return result, err
+}
+
+func (r *reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, project *kubermaticv1.Project) (reconcile.Result, error) {
+	if project.DeletionTimestamp.IsZero() {
+		if !kuberneteshelper.HasFinalizer(project, kubermaticapiv1.ProjectStagedDeletionFinalizer) {
+			kuberneteshelper.AddFinalizer(project, kubermaticapiv1.ProjectStagedDeletionFinalizer)
+			if err := r.masterClient.Update(ctx, project); err != nil {
+				return reconcile.Result{}, fmt.Errorf("failed to add finalizer: %w", err)
+			}
+		}
+		return reconcile.Result{}, nil
+	}
+
+	if !kuberneteshelper.HasFinalizer(project, kubermaticapiv1.ProjectStagedDeletionFinalizer) {
+		return reconcile.Result{}, nil
+	}
+
+	resources, err := r.listResources(ctx, project)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	oldProject := project.DeepCopy()
+	updateDeletionStatus(project, resources)
+
+	status := project.Status.Deletion
+	for _, res := range resources {
+		if res.status.Stage != status.Stage || res.object.GetDeletionTimestamp() != nil {
+			continue
+		}
+		if err := res.client.Delete(ctx, res.object); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to delete %s %s: %w", res.status.Kind, res.status.Name, err)
+		}
+		log.Debugw("Deleted resource", "kind", res.status.Kind, "name", res.status.Name, "seed", res.status.Seed)
+		setResourcePhase(status, res.status, kubermaticv1.ProjectDeletionResourceDeleting)
+	}
+
+	if oldProject.Status.Deletion == nil || oldProject.Status.Deletion.Stage != status.Stage {
+		log.Infow("Deletion stage reached", "stage", status.Stage)
+	}
+
+	if !apiequality.Semantic.DeepEqual(oldProject.Status, project.Status) {
+		if err := r.masterClient.Patch(ctx, project, ctrlruntimeclient.MergeFrom(oldProject)); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to update status: %w", err)
+		}
+	}
+
+	if status.Stage != kubermaticv1.ProjectDeletionStageCompleted {
+		return reconcile.Result{RequeueAfter: progressInterval}, nil
+	}
+
+	kuberneteshelper.RemoveFinalizer(project, kubermaticapiv1.ProjectStagedDeletionFinalizer)
+	if err := r.masterClient.Update(ctx, project); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to remove finalizer: %w", err)
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// resource is a resource of a project which still exists.
+type resource struct {
+	status kubermaticv1.ProjectDeletionResource
+	object ctrlruntimeclient.Object
+	client ctrlruntimeclient.Client
+}
+
+// listResources returns all resources of the project across the master and all seeds.
+func (r *reconciler) listResources(ctx context.Context, project *kubermaticv1.Project) ([]resource, error) {
+	var resources []resource
+
+	seedNames := make([]string, 0, len(r.seedClients))
+	for seedName := range r.seedClients {
+		seedNames = append(seedNames, seedName)
+	}
+	sort.Strings(seedNames)
+
+	for _, seedName := range seedNames {
+		seedClient := r.seedClients[seedName]
+
+		clusters := &kubermaticv1.ClusterList{}
+		if err := seedClient.List(ctx, clusters, ctrlruntimeclient.MatchingLabels{kubermaticv1.ProjectIDLabelKey: project.Name}); err != nil {
+			return nil, fmt.Errorf("failed to list clusters in seed %q: %w", seedName, err)
+		}
+
+		for i := range clusters.Items {
+			cluster := &clusters.Items[i]
+			resources = append(resources, resource{
+				status: kubermaticv1.ProjectDeletionResource{
+					Kind:        kubermaticv1.ProjectDeletionKindCluster,
+					Name:        cluster.Name,
+					DisplayName: cluster.Spec.HumanReadableName,
+					Seed:        seedName,
+					Stage:       kubermaticv1.ProjectDeletionStageClusters,
+				},
+				object: cluster,
+				client: seedClient,
+			})
+
+			if cluster.Status.NamespaceName == "" {
+				continue
+			}
+
+			backupConfigs := &kubermaticv1.EtcdBackupConfigList{}
+			if err := seedClient.List(ctx, backupConfigs, ctrlruntimeclient.InNamespace(cluster.Status.NamespaceName)); err != nil {
+				return nil, fmt.Errorf("failed to list etcd backup configs of cluster %s: %w", cluster.Name, err)
+			}
+
+			for j := range backupConfigs.Items {
+				backupConfig := &backupConfigs.Items[j]
+				resources = append(resources, resource{
+					status: kubermaticv1.ProjectDeletionResource{
+						Kind:        kubermaticv1.ProjectDeletionKindEtcdBackupConfig,
+						Name:        backupConfig.Name,
+						DisplayName: backupConfig.Spec.Name,
+						Namespace:   backupConfig.Namespace,
+						Seed:        seedName,
+						Stage:       kubermaticv1.ProjectDeletionStageBackups,
+					},
+					object: backupConfig,
+					client: seedClient,
+				})
+			}
+		}
+	}
+
+	users := &kubermaticv1.UserList{}
+	if err := r.masterClient.List(ctx, users); err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	for i := range users.Items {
+		user := &users.Items[i]
+		if !kubernetes.IsProjectServiceAccount(user.Spec.Email) || !isOwnedBy(user, project) {
+			continue
+		}
+		resources = append(resources, resource{
+			status: kubermaticv1.ProjectDeletionResource{
+				Kind:        kubermaticv1.ProjectDeletionKindServiceAccount,
+				Name:        user.Spec.ID,
+				DisplayName: user.Spec.Name,
+				Stage:       kubermaticv1.ProjectDeletionStageServiceAccounts,
+			},
+			object: user,
+			client: r.masterClient,
+		})
+	}
+
+	keys := &kubermaticv1.UserSSHKeyList{}
+	if err := r.masterClient.List(ctx, keys); err != nil {
+		return nil, fmt.Errorf("failed to list SSH keys: %w", err)
+	}
+
+	for i := range keys.Items {
+		key := &keys.Items[i]
+		if !isOwnedBy(key, project) {
+			continue
+		}
+		resources = append(resources, resource{
+			status: kubermaticv1.ProjectDeletionResource{
+				Kind:        kubermaticv1.ProjectDeletionKindSSHKey,
+				Name:        key.Name,
+				DisplayName: key.Spec.Name,
+				Stage:       kubermaticv1.ProjectDeletionStageSSHKeys,
+			},
+			object: key,
+			client: r.masterClient,
+		})
+	}
+
+	return resources, nil
+}
+
+func isOwnedBy(obj ctrlruntimeclient.Object, project *kubermaticv1.Project) bool {
+	for _, owner := range obj.GetOwnerReferences() {
+		if owner.APIVersion == kubermaticv1.SchemeGroupVersion.String() && owner.Kind == kubermaticv1.ProjectKindName && owner.Name == project.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// updateDeletionStatus merges the resources which still exist into the deletion status of the
+// project and moves the teardown to the first stage with resources left.
+func updateDeletionStatus(project *kubermaticv1.Project, resources []resource) {
+	status := project.Status.Deletion
+	if status == nil {
+		status = &kubermaticv1.ProjectDeletionStatus{}
+		project.Status.Deletion = status
+	}
+
+	existing := map[string]resource{}
+	for _, res := range resources {
+		existing[resourceKey(res.status)] = res
+	}
+
+	known := map[string]bool{}
+	for i := range status.Resources {
+		entry := &status.Resources[i]
+		key := resourceKey(*entry)
+		known[key] = true
+
+		res, ok := existing[key]
+		switch {
+		case !ok:
+			entry.Phase = kubermaticv1.ProjectDeletionResourceDeleted
+		case res.object.GetDeletionTimestamp() != nil:
+			entry.Phase = kubermaticv1.ProjectDeletionResourceDeleting
+		case entry.Phase == kubermaticv1.ProjectDeletionResourceDeleted:
+			// the resource has been created again
+			entry.Phase = kubermaticv1.ProjectDeletionResourcePending
+		}
+	}
+
+	for _, res := range resources {
+		if known[resourceKey(res.status)] {
+			continue
+		}
+		entry := res.status
+		entry.Phase = kubermaticv1.ProjectDeletionResourcePending
+		if res.object.GetDeletionTimestamp() != nil {
+			entry.Phase = kubermaticv1.ProjectDeletionResourceDeleting
+		}
+		status.Resources = append(status.Resources, entry)
+	}
+
+	status.Stage = currentStage(status.Resources)
+}
+
+// currentStage returns the first stage which has resources that are not deleted yet.
+func currentStage(resources []kubermaticv1.ProjectDeletionResource) kubermaticv1.ProjectDeletionStage {
+	for _, stage := range kubermaticv1.ProjectDeletionStages {
+		for _, res := range resources {
+			if res.Stage == stage && res.Phase != kubermaticv1.ProjectDeletionResourceDeleted {
+				return stage
+			}
+		}
+	}
+	return kubermaticv1.ProjectDeletionStageCompleted
+}
+
+func setResourcePhase(status *kubermaticv1.ProjectDeletionStatus, res kubermaticv1.ProjectDeletionResource, phase kubermaticv1.ProjectDeletionResourcePhase) {
+	key := resourceKey(res)
+	for i := range status.Resources {
+		if resourceKey(status.Resources[i]) == key {
+			status.Resources[i].Phase = phase
+		}
+	}
+}
+
+func resourceKey(res kubermaticv1.ProjectDeletionResource) string {
+	return fmt.Sprintf("%s/%s/%s/%s", res.Kind, res.Seed, res.Namespace, res.Name)
+}
@@ -0,0 +1,269 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package projectdeletion
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	kubermaticapiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	"k8c.io/kubermatic/v2/pkg/crd/client/clientset/versioned/scheme"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	projectName = "my-project"
+	seedName    = "europe"
+)
+
+func TestReconcile(t *testing.T) {
+	testCases := []struct {
+		name              string
+		project           *kubermaticv1.Project
+		masterObjects     []ctrlruntimeclient.Object
+		seedObjects       []ctrlruntimeclient.Object
+		expectedStage     kubermaticv1.ProjectDeletionStage
+		expectedResources map[string]kubermaticv1.ProjectDeletionResourcePhase
+		expectedFinalizer bool
+	}{
+		{
+			name:              "scenario 1: add the finalizer to projects which are not deleted",
+			project:           generateProject(false, nil),
+			seedObjects:       []ctrlruntimeclient.Object{generateCluster("a")},
+			expectedFinalizer: true,
+		},
+		{
+			name:          "scenario 2: delete the backups before the clusters",
+			project:       generateProject(true, nil),
+			masterObjects: []ctrlruntimeclient.Object{generateServiceAccount("sa"), generateSSHKey("key")},
+			seedObjects:   []ctrlruntimeclient.Object{generateCluster("a"), generateBackupConfig("a", "daily")},
+			expectedStage: kubermaticv1.ProjectDeletionStageBackups,
+			expectedResources: map[string]kubermaticv1.ProjectDeletionResourcePhase{
+				"EtcdBackupConfig/daily": kubermaticv1.ProjectDeletionResourceDeleting,
+				"Cluster/a":              kubermaticv1.ProjectDeletionResourcePending,
+				"ServiceAccount/sa":      kubermaticv1.ProjectDeletionResourcePending,
+				"SSHKey/key":             kubermaticv1.ProjectDeletionResourcePending,
+			},
+			expectedFinalizer: true,
+		},
+		{
+			name: "scenario 3: continue with the clusters once the backups are gone",
+			project: generateProject(true, []kubermaticv1.ProjectDeletionResource{
+				generateStatusResource(kubermaticv1.ProjectDeletionKindEtcdBackupConfig, "daily", kubermaticv1.ProjectDeletionStageBackups, kubermaticv1.ProjectDeletionResourceDeleting),
+				generateStatusResource(kubermaticv1.ProjectDeletionKindCluster, "a", kubermaticv1.ProjectDeletionStageClusters, kubermaticv1.ProjectDeletionResourcePending),
+				generateStatusResource(kubermaticv1.ProjectDeletionKindSSHKey, "key", kubermaticv1.ProjectDeletionStageSSHKeys, kubermaticv1.ProjectDeletionResourcePending),
+			}),
+			masterObjects: []ctrlruntimeclient.Object{generateSSHKey("key")},
+			seedObjects:   []ctrlruntimeclient.Object{generateCluster("a"), generateCluster("b")},
+			expectedStage: kubermaticv1.ProjectDeletionStageClusters,
+			expectedResources: map[string]kubermaticv1.ProjectDeletionResourcePhase{
+				"EtcdBackupConfig/daily": kubermaticv1.ProjectDeletionResourceDeleted,
+				"Cluster/a":              kubermaticv1.ProjectDeletionResourceDeleting,
+				"Cluster/b":              kubermaticv1.ProjectDeletionResourceDeleting,
+				"SSHKey/key":             kubermaticv1.ProjectDeletionResourcePending,
+			},
+			expectedFinalizer: true,
+		},
+		{
+			name: "scenario 4: remove the finalizer once all resources are gone",
+			project: generateProject(true, []kubermaticv1.ProjectDeletionResource{
+				generateStatusResource(kubermaticv1.ProjectDeletionKindCluster, "a", kubermaticv1.ProjectDeletionStageClusters, kubermaticv1.ProjectDeletionResourceDeleted),
+				generateStatusResource(kubermaticv1.ProjectDeletionKindSSHKey, "key", kubermaticv1.ProjectDeletionStageSSHKeys, kubermaticv1.ProjectDeletionResourceDeleting),
+			}),
+			expectedStage: kubermaticv1.ProjectDeletionStageCompleted,
+			expectedResources: map[string]kubermaticv1.ProjectDeletionResourcePhase{
+				"Cluster/a":  kubermaticv1.ProjectDeletionResourceDeleted,
+				"SSHKey/key": kubermaticv1.ProjectDeletionResourceDeleted,
+			},
+			expectedFinalizer: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			masterClient := fakectrlruntimeclient.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(append(tc.masterObjects, tc.project)...).Build()
+			seedClient := fakectrlruntimeclient.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(tc.seedObjects...).Build()
+
+			r := &reconciler{
+				log:          kubermaticlog.Logger,
+				masterClient: masterClient,
+				seedClients:  map[string]ctrlruntimeclient.Client{seedName: seedClient},
+				recorder:     &record.FakeRecorder{},
+			}
+
+			request := reconcile.Request{NamespacedName: types.NamespacedName{Name: projectName}}
+			if _, err := r.Reconcile(ctx, request); err != nil {
+				t.Fatalf("reconciling failed: %v", err)
+			}
+
+			project := &kubermaticv1.Project{}
+			if err := masterClient.Get(ctx, request.NamespacedName, project); err != nil {
+				t.Fatalf("failed to get project: %v", err)
+			}
+
+			if hasFinalizer := kuberneteshelper.HasFinalizer(project, kubermaticapiv1.ProjectStagedDeletionFinalizer); hasFinalizer != tc.expectedFinalizer {
+				t.Errorf("expected the finalizer to exist to be %v, got %v", tc.expectedFinalizer, hasFinalizer)
+			}
+
+			if tc.expectedResources == nil {
+				if project.Status.Deletion != nil {
+					t.Errorf("expected no deletion status, got %+v", project.Status.Deletion)
+				}
+				return
+			}
+
+			if project.Status.Deletion == nil {
+				t.Fatal("expected a deletion status")
+			}
+			if project.Status.Deletion.Stage != tc.expectedStage {
+				t.Errorf("expected stage %q, got %q", tc.expectedStage, project.Status.Deletion.Stage)
+			}
+
+			resources := map[string]kubermaticv1.ProjectDeletionResourcePhase{}
+			for _, res := range project.Status.Deletion.Resources {
+				resources[res.Kind+"/"+res.Name] = res.Phase
+			}
+			if len(resources) != len(tc.expectedResources) {
+				t.Errorf("expected resources %v, got %v", tc.expectedResources, resources)
+			}
+			for name, phase := range tc.expectedResources {
+				if resources[name] != phase {
+					t.Errorf("expected %s to be %q, got %q", name, phase, resources[name])
+				}
+			}
+
+			// the clusters of later stages must not be touched yet
+			for name, phase := range tc.expectedResources {
+				if phase != kubermaticv1.ProjectDeletionResourcePending || !strings.HasPrefix(name, "Cluster/") {
+					continue
+				}
+				clusterName := strings.TrimPrefix(name, "Cluster/")
+				if err := seedClient.Get(ctx, types.NamespacedName{Name: clusterName}, &kubermaticv1.Cluster{}); err != nil {
+					t.Errorf("expected cluster %s to still exist: %v", clusterName, err)
+				}
+			}
+		})
+	}
+}
+
+func generateProject(deleted bool, resources []kubermaticv1.ProjectDeletionResource) *kubermaticv1.Project {
+	project := &kubermaticv1.Project{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: projectName,
+			UID:  types.UID(projectName),
+		},
+		Spec: kubermaticv1.ProjectSpec{Name: "My Project"},
+		Status: kubermaticv1.ProjectStatus{
+			Phase: kubermaticv1.ProjectActive,
+		},
+	}
+	if deleted {
+		deleteTime := metav1.NewTime(time.Now())
+		project.DeletionTimestamp = &deleteTime
+		project.Status.Phase = kubermaticv1.ProjectTerminating
+		project.Finalizers = []string{kubermaticapiv1.ProjectStagedDeletionFinalizer, kubermaticapiv1.SeedProjectCleanupFinalizer}
+	}
+	if resources != nil {
+		project.Status.Deletion = &kubermaticv1.ProjectDeletionStatus{Resources: resources}
+	}
+	return project
+}
+
+func projectOwnerReference() []metav1.OwnerReference {
+	return []metav1.OwnerReference{{
+		APIVersion: kubermaticv1.SchemeGroupVersion.String(),
+		Kind:       kubermaticv1.ProjectKindName,
+		Name:       projectName,
+		UID:        types.UID(projectName),
+	}}
+}
+
+func generateCluster(name string) *kubermaticv1.Cluster {
+	return &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{kubermaticv1.ProjectIDLabelKey: projectName},
+		},
+		Spec: kubermaticv1.ClusterSpec{HumanReadableName: "cluster-" + name},
+		Status: kubermaticv1.ClusterStatus{
+			NamespaceName: "cluster-" + name,
+		},
+	}
+}
+
+func generateBackupConfig(cluster, name string) *kubermaticv1.EtcdBackupConfig {
+	return &kubermaticv1.EtcdBackupConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "cluster-" + cluster,
+		},
+		Spec: kubermaticv1.EtcdBackupConfigSpec{Name: name},
+	}
+}
+
+func generateServiceAccount(id string) *kubermaticv1.User {
+	return &kubermaticv1.User{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "serviceaccount-" + id,
+			OwnerReferences: projectOwnerReference(),
+		},
+		Spec: kubermaticv1.UserSpec{
+			ID:    id,
+			Name:  "ci",
+			Email: "serviceaccount-" + id + "@sa.kubermatic.io",
+		},
+	}
+}
+
+func generateSSHKey(name string) *kubermaticv1.UserSSHKey {
+	return &kubermaticv1.UserSSHKey{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			OwnerReferences: projectOwnerReference(),
+		},
+		Spec: kubermaticv1.SSHKeySpec{Name: "laptop"},
+	}
+}
+
+func generateStatusResource(kind, name string, stage kubermaticv1.ProjectDeletionStage, phase kubermaticv1.ProjectDeletionResourcePhase) kubermaticv1.ProjectDeletionResource {
+	res := kubermaticv1.ProjectDeletionResource{
+		Kind:  kind,
+		Name:  name,
+		Stage: stage,
+		Phase: phase,
+	}
+	switch kind {
+	case kubermaticv1.ProjectDeletionKindCluster:
+		res.Seed = seedName
+	case kubermaticv1.ProjectDeletionKindEtcdBackupConfig:
+		res.Seed = seedName
+		res.Namespace = "cluster-a"
+	}
+	return res
+}
@@ -0,0 +1,28 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package projectdeletion contains a controller that tears down the resources of deleted projects
+stage by stage instead of relying on a single cascading deletion.
+
+The etcd backup configs of the clusters of the project are deleted first, then the clusters
+themselves, then the service accounts together with their tokens and finally the SSH keys. A stage
+only starts once every resource of the previous stages is gone. The progress of every resource is
+recorded in the status of the project, so that the teardown continues where it stopped after a
+restart and can be followed via the API. The project itself is only removed once all stages have
+completed.
+*/
+package projectdeletion
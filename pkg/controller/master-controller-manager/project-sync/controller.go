@@ -124,6 +124,12 @@ func (r *reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 }
 
 func (r *reconciler) handleDeletion(ctx context.Context, log *zap.SugaredLogger, project *kubermaticv1.Project) error {
+	// the clusters in the seeds still need the project until the staged deletion has removed them
+	if kuberneteshelper.HasFinalizer(project, kubermaticapiv1.ProjectStagedDeletionFinalizer) {
+		log.Debug("Waiting for the staged deletion of the project")
+		return nil
+	}
+
 	err := r.syncAllSeeds(ctx, log, project, func(seedClusterClient ctrlruntimeclient.Client, project *kubermaticv1.Project) error {
 		if err := seedClusterClient.Delete(ctx, project); err != nil {
 			return ctrlruntimeclient.IgnoreNotFound(err)
@@ -79,6 +79,21 @@ func TestReconcile(t *testing.T) {
 				WithObjects(generateProject(projectName, false), test.GenTestSeed()).
 				Build(),
 		},
+		{
+			name:            "scenario 3: keep the project on the seed cluster until the staged deletion is done",
+			requestName:     projectName,
+			expectedProject: generateProject(projectName, false),
+			masterClient: fakectrlruntimeclient.
+				NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(generateStagedDeletionProject(projectName), test.GenTestSeed()).
+				Build(),
+			seedClient: fakectrlruntimeclient.
+				NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(generateProject(projectName, false), test.GenTestSeed()).
+				Build(),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -140,3 +155,9 @@ func generateProject(name string, deleted bool) *kubermaticv1.Project {
 	}
 	return project
 }
+
+func generateStagedDeletionProject(name string) *kubermaticv1.Project {
+	project := generateProject(name, true)
+	project.Finalizers = append(project.Finalizers, v1.ProjectStagedDeletionFinalizer)
+	return project
+}
@@ -0,0 +1,448 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package seedresourcesynchronizer
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	kubermaticapiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	"k8c.io/kubermatic/v2/pkg/controller/operator/common"
+	controllerutil "k8c.io/kubermatic/v2/pkg/controller/util"
+	predicateutil "k8c.io/kubermatic/v2/pkg/controller/util/predicate"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1/helper"
+	operatorv1alpha1 "k8c.io/kubermatic/v2/pkg/crd/operator/v1alpha1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/resources/reconciling"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	// This controller syncs global resources on the master cluster to the seed clusters.
+	ControllerName = "seed_resource_synchronizer"
+
+	// VersionsConfigMapName is the name of the ConfigMap in the KKP namespace of every seed
+	// that holds the Kubernetes versions and updates of the KubermaticConfiguration.
+	VersionsConfigMapName = "kubermatic-versions"
+	// ConfigurationLabel is set on the versions ConfigMap to point to the KubermaticConfiguration
+	// it was synced from.
+	ConfigurationLabel = "kubermatic.io/configuration"
+)
+
+// syncedResource describes a resource kind that is synced from the master into all seeds.
+type syncedResource struct {
+	kind      string
+	finalizer string
+	// condition is set on every Seed to report whether all objects of this kind are synced.
+	condition kubermaticv1.SeedConditionType
+	newObject func() ctrlruntimeclient.Object
+	// seedObject returns an empty copy of the object that obj is synced into on the seeds.
+	seedObject func(obj ctrlruntimeclient.Object) ctrlruntimeclient.Object
+	// seedHandler maps events of the objects on the seeds to the objects on the master.
+	// Defaults to handler.EnqueueRequestForObject.
+	seedHandler    handler.EventHandler
+	seedPredicates []predicate.Predicate
+	reconcile      func(ctx context.Context, obj ctrlruntimeclient.Object, seedClient ctrlruntimeclient.Client) error
+}
+
+var syncedResources = []syncedResource{
+	{
+		kind:       "Preset",
+		finalizer:  kubermaticapiv1.SeedPresetCleanupFinalizer,
+		condition:  kubermaticv1.SeedConditionPresetsSynced,
+		newObject:  func() ctrlruntimeclient.Object { return &kubermaticv1.Preset{} },
+		seedObject: sameObject(func() ctrlruntimeclient.Object { return &kubermaticv1.Preset{} }),
+		reconcile: func(ctx context.Context, obj ctrlruntimeclient.Object, seedClient ctrlruntimeclient.Client) error {
+			return reconciling.ReconcileKubermaticV1Presets(ctx, []reconciling.NamedKubermaticV1PresetCreatorGetter{
+				presetCreatorGetter(obj.(*kubermaticv1.Preset)),
+			}, "", seedClient)
+		},
+	},
+	{
+		kind:       "AddonConfig",
+		finalizer:  kubermaticapiv1.SeedAddonConfigCleanupFinalizer,
+		condition:  kubermaticv1.SeedConditionAddonConfigsSynced,
+		newObject:  func() ctrlruntimeclient.Object { return &kubermaticv1.AddonConfig{} },
+		seedObject: sameObject(func() ctrlruntimeclient.Object { return &kubermaticv1.AddonConfig{} }),
+		reconcile: func(ctx context.Context, obj ctrlruntimeclient.Object, seedClient ctrlruntimeclient.Client) error {
+			return reconciling.ReconcileKubermaticV1AddonConfigs(ctx, []reconciling.NamedKubermaticV1AddonConfigCreatorGetter{
+				addonConfigCreatorGetter(obj.(*kubermaticv1.AddonConfig)),
+			}, "", seedClient)
+		},
+	},
+	{
+		kind:       "ConstraintTemplate",
+		finalizer:  kubermaticapiv1.GatekeeperSeedConstraintTemplateCleanupFinalizer,
+		condition:  kubermaticv1.SeedConditionConstraintTemplatesSynced,
+		newObject:  func() ctrlruntimeclient.Object { return &kubermaticv1.ConstraintTemplate{} },
+		seedObject: sameObject(func() ctrlruntimeclient.Object { return &kubermaticv1.ConstraintTemplate{} }),
+		reconcile: func(ctx context.Context, obj ctrlruntimeclient.Object, seedClient ctrlruntimeclient.Client) error {
+			return reconciling.ReconcileKubermaticV1ConstraintTemplates(ctx, []reconciling.NamedKubermaticV1ConstraintTemplateCreatorGetter{
+				constraintTemplateCreatorGetter(obj.(*kubermaticv1.ConstraintTemplate)),
+			}, "", seedClient)
+		},
+	},
+	{
+		// The versions are synced into a ConfigMap instead of copying the whole
+		// KubermaticConfiguration, which also contains credentials.
+		kind:      "KubermaticConfiguration",
+		finalizer: kubermaticapiv1.SeedVersionsCleanupFinalizer,
+		condition: kubermaticv1.SeedConditionVersionsSynced,
+		newObject: func() ctrlruntimeclient.Object { return &operatorv1alpha1.KubermaticConfiguration{} },
+		seedObject: func(obj ctrlruntimeclient.Object) ctrlruntimeclient.Object {
+			cm := &corev1.ConfigMap{}
+			cm.Name = VersionsConfigMapName
+			cm.Namespace = obj.GetNamespace()
+			return cm
+		},
+		seedHandler: handler.EnqueueRequestsFromMapFunc(func(o ctrlruntimeclient.Object) []reconcile.Request {
+			name := o.GetLabels()[ConfigurationLabel]
+			if name == "" {
+				return nil
+			}
+			return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: o.GetNamespace(), Name: name}}}
+		}),
+		seedPredicates: []predicate.Predicate{predicateutil.ByName(VersionsConfigMapName)},
+		reconcile: func(ctx context.Context, obj ctrlruntimeclient.Object, seedClient ctrlruntimeclient.Client) error {
+			config := obj.(*operatorv1alpha1.KubermaticConfiguration)
+			return reconciling.ReconcileConfigMaps(ctx, []reconciling.NamedConfigMapCreatorGetter{
+				versionsConfigMapCreatorGetter(config),
+			}, config.Namespace, seedClient)
+		},
+	},
+}
+
+// sameObject returns a seedObject func for resources that are synced into
+// objects of the same kind and name.
+func sameObject(newObject func() ctrlruntimeclient.Object) func(obj ctrlruntimeclient.Object) ctrlruntimeclient.Object {
+	return func(obj ctrlruntimeclient.Object) ctrlruntimeclient.Object {
+		seedObj := newObject()
+		seedObj.SetName(obj.GetName())
+		seedObj.SetNamespace(obj.GetNamespace())
+		return seedObj
+	}
+}
+
+type reconciler struct {
+	log          *zap.SugaredLogger
+	masterClient ctrlruntimeclient.Client
+	seedClients  map[string]ctrlruntimeclient.Client
+	recorder     record.EventRecorder
+	namespace    string
+	resource     syncedResource
+
+	// failures contains the keys of the objects that failed to sync, per seed.
+	failures     map[string]sets.String
+	failuresLock sync.Mutex
+}
+
+// Add creates a controller per synced resource kind that copies all objects of that
+// kind from the master into all given seeds.
+func Add(
+	masterMgr manager.Manager,
+	seedManagers map[string]manager.Manager,
+	log *zap.SugaredLogger,
+	namespace string,
+	numWorkers int,
+) error {
+	for _, resource := range syncedResources {
+		if err := add(masterMgr, seedManagers, log, namespace, numWorkers, resource); err != nil {
+			return fmt.Errorf("failed to create controller for %s: %w", resource.kind, err)
+		}
+	}
+
+	return nil
+}
+
+func add(
+	masterMgr manager.Manager,
+	seedManagers map[string]manager.Manager,
+	log *zap.SugaredLogger,
+	namespace string,
+	numWorkers int,
+	resource syncedResource,
+) error {
+	name := fmt.Sprintf("%s_%s", ControllerName, resource.kind)
+
+	r := &reconciler{
+		log:          log.Named(name),
+		masterClient: masterMgr.GetClient(),
+		seedClients:  map[string]ctrlruntimeclient.Client{},
+		recorder:     masterMgr.GetEventRecorderFor(name),
+		namespace:    namespace,
+		resource:     resource,
+		failures:     map[string]sets.String{},
+	}
+
+	c, err := controller.New(name, masterMgr, controller.Options{Reconciler: r, MaxConcurrentReconciles: numWorkers})
+	if err != nil {
+		return fmt.Errorf("failed to construct controller: %w", err)
+	}
+
+	seedHandler := resource.seedHandler
+	if seedHandler == nil {
+		seedHandler = &handler.EnqueueRequestForObject{}
+	}
+
+	for seedName, seedManager := range seedManagers {
+		r.seedClients[seedName] = seedManager.GetClient()
+
+		// Watch the copies on the seed as well, so that manual changes or
+		// deletions are reverted right away.
+		seedWatch := &source.Kind{Type: resource.seedObject(resource.newObject())}
+		if err := seedWatch.InjectCache(seedManager.GetCache()); err != nil {
+			return fmt.Errorf("failed to inject cache for seed %q into watch: %w", seedName, err)
+		}
+		if err := c.Watch(seedWatch, seedHandler, resource.seedPredicates...); err != nil {
+			return fmt.Errorf("failed to watch %s objects in seed %q: %w", resource.kind, seedName, err)
+		}
+	}
+
+	if err := c.Watch(&source.Kind{Type: resource.newObject()}, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("failed to watch %s objects: %w", resource.kind, err)
+	}
+
+	return nil
+}
+
+func (r *reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.With("resource", request.NamespacedName.String())
+	log.Debug("Processing")
+
+	err := r.reconcile(ctx, log, request)
+	if controllerutil.IsCacheNotStarted(err) {
+		return reconcile.Result{RequeueAfter: 5 * time.Second}, nil
+	}
+	if err != nil {
+		log.Errorw("ReconcilingError", zap.Error(err))
+	}
+
+	return reconcile.Result{}, err
+}
+
+func (r *reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, request reconcile.Request) error {
+	obj := r.resource.newObject()
+	if err := r.masterClient.Get(ctx, request.NamespacedName, obj); err != nil {
+		return ctrlruntimeclient.IgnoreNotFound(err)
+	}
+
+	if !obj.GetDeletionTimestamp().IsZero() {
+		if err := r.handleDeletion(ctx, log, obj); err != nil {
+			return fmt.Errorf("handling deletion: %w", err)
+		}
+		return nil
+	}
+
+	if !kuberneteshelper.HasFinalizer(obj, r.resource.finalizer) {
+		kuberneteshelper.AddFinalizer(obj, r.resource.finalizer)
+		if err := r.masterClient.Update(ctx, obj); err != nil {
+			return fmt.Errorf("failed to add finalizer: %w", err)
+		}
+	}
+
+	err := r.syncAllSeeds(ctx, log, obj, func(seedClient ctrlruntimeclient.Client, obj ctrlruntimeclient.Object) error {
+		return r.resource.reconcile(ctx, obj, seedClient)
+	})
+	if err != nil {
+		r.recorder.Eventf(obj, corev1.EventTypeWarning, "ReconcilingError", err.Error())
+		return fmt.Errorf("reconciled %s %s: %w", r.resource.kind, obj.GetName(), err)
+	}
+
+	return nil
+}
+
+func (r *reconciler) handleDeletion(ctx context.Context, log *zap.SugaredLogger, obj ctrlruntimeclient.Object) error {
+	if !kuberneteshelper.HasFinalizer(obj, r.resource.finalizer) {
+		return nil
+	}
+
+	err := r.syncAllSeeds(ctx, log, obj, func(seedClient ctrlruntimeclient.Client, obj ctrlruntimeclient.Object) error {
+		return ctrlruntimeclient.IgnoreNotFound(seedClient.Delete(ctx, r.resource.seedObject(obj)))
+	})
+	if err != nil {
+		return err
+	}
+
+	kuberneteshelper.RemoveFinalizer(obj, r.resource.finalizer)
+	if err := r.masterClient.Update(ctx, obj); err != nil {
+		return fmt.Errorf("failed to remove finalizer from %s %s: %w", r.resource.kind, obj.GetName(), err)
+	}
+
+	return nil
+}
+
+func (r *reconciler) syncAllSeeds(
+	ctx context.Context,
+	log *zap.SugaredLogger,
+	obj ctrlruntimeclient.Object,
+	action func(seedClient ctrlruntimeclient.Client, obj ctrlruntimeclient.Object) error,
+) error {
+	var failedSeeds []string
+
+	for seedName, seedClient := range r.seedClients {
+		log := log.With("seed", seedName)
+
+		syncErr := action(seedClient, obj)
+		if syncErr != nil {
+			log.Errorw("Failed to sync with seed", zap.Error(syncErr))
+			syncErrorsMetric.WithLabelValues(seedName, r.resource.kind).Inc()
+			failedSeeds = append(failedSeeds, seedName)
+		} else {
+			lastSuccessfulSyncMetric.WithLabelValues(seedName, r.resource.kind).SetToCurrentTime()
+			log.Debugw("Reconciled with seed")
+		}
+
+		if err := r.updateSeedCondition(ctx, seedName, ctrlruntimeclient.ObjectKeyFromObject(obj).String(), syncErr == nil); err != nil {
+			log.Errorw("Failed to update sync status of seed", zap.Error(err))
+		}
+	}
+
+	if len(failedSeeds) > 0 {
+		return fmt.Errorf("failed syncing %s %s for seeds %v", r.resource.kind, obj.GetName(), failedSeeds)
+	}
+
+	return nil
+}
+
+// updateSeedCondition records whether the given object was synced into the seed and
+// reflects the result for all objects of the resource kind in the condition of the Seed.
+func (r *reconciler) updateSeedCondition(ctx context.Context, seedName string, key string, synced bool) error {
+	r.failuresLock.Lock()
+	failed, ok := r.failures[seedName]
+	if !ok {
+		failed = sets.NewString()
+		r.failures[seedName] = failed
+	}
+	if synced {
+		failed.Delete(key)
+	} else {
+		failed.Insert(key)
+	}
+	failedKeys := failed.List()
+	r.failuresLock.Unlock()
+
+	status := corev1.ConditionTrue
+	reason := ""
+	message := ""
+	if len(failedKeys) > 0 {
+		status = corev1.ConditionFalse
+		reason = kubermaticv1.ReasonSeedResourcesSyncFailed
+		message = fmt.Sprintf("Failed to sync the %s objects %s.", r.resource.kind, strings.Join(failedKeys, ", "))
+	}
+
+	// Every synced resource kind updates its own condition, so conflicting
+	// updates of the conditions list have to be retried instead of overwritten.
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		seed := &kubermaticv1.Seed{}
+		if err := r.masterClient.Get(ctx, types.NamespacedName{Namespace: r.namespace, Name: seedName}, seed); err != nil {
+			return ctrlruntimeclient.IgnoreNotFound(err)
+		}
+
+		oldSeed := seed.DeepCopy()
+		kubermaticv1helper.SetSeedCondition(seed, r.resource.condition, status, reason, message)
+		if reflect.DeepEqual(oldSeed, seed) {
+			return nil
+		}
+
+		return r.masterClient.Patch(ctx, seed, ctrlruntimeclient.MergeFromWithOptions(oldSeed, ctrlruntimeclient.MergeFromWithOptimisticLock{}))
+	})
+}
+
+func presetCreatorGetter(preset *kubermaticv1.Preset) reconciling.NamedKubermaticV1PresetCreatorGetter {
+	return func() (string, reconciling.KubermaticV1PresetCreator) {
+		return preset.Name, func(p *kubermaticv1.Preset) (*kubermaticv1.Preset, error) {
+			p.Name = preset.Name
+			p.Labels = preset.Labels
+			p.Spec = preset.Spec
+			return p, nil
+		}
+	}
+}
+
+func addonConfigCreatorGetter(config *kubermaticv1.AddonConfig) reconciling.NamedKubermaticV1AddonConfigCreatorGetter {
+	return func() (string, reconciling.KubermaticV1AddonConfigCreator) {
+		return config.Name, func(c *kubermaticv1.AddonConfig) (*kubermaticv1.AddonConfig, error) {
+			c.Name = config.Name
+			c.Labels = config.Labels
+			c.Spec = config.Spec
+			return c, nil
+		}
+	}
+}
+
+func constraintTemplateCreatorGetter(template *kubermaticv1.ConstraintTemplate) reconciling.NamedKubermaticV1ConstraintTemplateCreatorGetter {
+	return func() (string, reconciling.KubermaticV1ConstraintTemplateCreator) {
+		return template.Name, func(ct *kubermaticv1.ConstraintTemplate) (*kubermaticv1.ConstraintTemplate, error) {
+			ct.Name = template.Name
+			ct.Spec = template.Spec
+			return ct, nil
+		}
+	}
+}
+
+func versionsConfigMapCreatorGetter(config *operatorv1alpha1.KubermaticConfiguration) reconciling.NamedConfigMapCreatorGetter {
+	return func() (string, reconciling.ConfigMapCreator) {
+		return VersionsConfigMapName, func(cm *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+			// the versions are synced including the defaults, just like they are
+			// passed to the controller managers
+			defaulted, err := common.DefaultConfiguration(config, zap.NewNop().Sugar())
+			if err != nil {
+				return nil, fmt.Errorf("failed to apply defaults: %w", err)
+			}
+
+			versions, err := common.CreateVersionsYAML(&defaulted.Spec.Versions)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode versions as YAML: %w", err)
+			}
+
+			updates, err := common.CreateUpdatesYAML(&defaulted.Spec.Versions)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode updates as YAML: %w", err)
+			}
+
+			if cm.Labels == nil {
+				cm.Labels = map[string]string{}
+			}
+			cm.Labels[ConfigurationLabel] = config.Name
+			cm.Data = map[string]string{
+				common.VersionsFileName: versions,
+				common.UpdatesFileName:  updates,
+			}
+
+			return cm, nil
+		}
+	}
+}
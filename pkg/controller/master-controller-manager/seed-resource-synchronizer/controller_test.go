@@ -0,0 +1,319 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package seedresourcesynchronizer
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/open-policy-agent/frameworks/constraint/pkg/apis/templates/v1beta1"
+
+	v1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	"k8c.io/kubermatic/v2/pkg/controller/operator/common"
+	"k8c.io/kubermatic/v2/pkg/crd/client/clientset/versioned/scheme"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	operatorv1alpha1 "k8c.io/kubermatic/v2/pkg/crd/operator/v1alpha1"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/diff"
+	"k8s.io/apimachinery/pkg/util/sets"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const presetName = "preset-test"
+
+func TestReconcilePresets(t *testing.T) {
+	testCases := []struct {
+		name           string
+		expectedPreset *kubermaticv1.Preset
+		masterClient   ctrlruntimeclient.Client
+		seedClient     ctrlruntimeclient.Client
+	}{
+		{
+			name:           "scenario 1: sync preset from master cluster to seed cluster",
+			expectedPreset: generatePreset(presetName, "master-token", false),
+			masterClient: fakectrlruntimeclient.
+				NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(generatePreset(presetName, "master-token", false)).
+				Build(),
+			seedClient: fakectrlruntimeclient.
+				NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				Build(),
+		},
+		{
+			name:           "scenario 2: revert changes made to the preset on the seed cluster",
+			expectedPreset: generatePreset(presetName, "master-token", false),
+			masterClient: fakectrlruntimeclient.
+				NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(generatePreset(presetName, "master-token", false)).
+				Build(),
+			seedClient: fakectrlruntimeclient.
+				NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(generatePreset(presetName, "seed-token", false)).
+				Build(),
+		},
+		{
+			name:           "scenario 3: cleanup preset on the seed cluster when master preset is being terminated",
+			expectedPreset: nil,
+			masterClient: fakectrlruntimeclient.
+				NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(generatePreset(presetName, "master-token", true)).
+				Build(),
+			seedClient: fakectrlruntimeclient.
+				NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(generatePreset(presetName, "master-token", false)).
+				Build(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			r := &reconciler{
+				log:          kubermaticlog.Logger,
+				recorder:     &record.FakeRecorder{},
+				masterClient: tc.masterClient,
+				seedClients:  map[string]ctrlruntimeclient.Client{"first": tc.seedClient},
+				namespace:    "kubermatic",
+				resource:     syncedResources[0],
+				failures:     map[string]sets.String{},
+			}
+
+			request := reconcile.Request{NamespacedName: types.NamespacedName{Name: presetName}}
+			if _, err := r.Reconcile(ctx, request); err != nil {
+				t.Fatalf("reconciling failed: %v", err)
+			}
+
+			seedPreset := &kubermaticv1.Preset{}
+			err := tc.seedClient.Get(ctx, request.NamespacedName, seedPreset)
+			if tc.expectedPreset == nil {
+				if err == nil {
+					t.Fatal("failed to clean up preset on the seed cluster")
+				} else if !errors.IsNotFound(err) {
+					t.Fatalf("failed to get preset: %v", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("failed to get preset: %v", err)
+			}
+			if !reflect.DeepEqual(seedPreset.Spec, tc.expectedPreset.Spec) {
+				t.Fatalf("diff: %s", diff.ObjectGoPrintSideBySide(seedPreset.Spec, tc.expectedPreset.Spec))
+			}
+		})
+	}
+}
+
+func generatePreset(name, token string, deleted bool) *kubermaticv1.Preset {
+	preset := &kubermaticv1.Preset{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: kubermaticv1.PresetSpec{
+			Digitalocean: &kubermaticv1.Digitalocean{
+				Token: token,
+			},
+		},
+	}
+	if deleted {
+		deleteTime := metav1.NewTime(time.Now())
+		preset.DeletionTimestamp = &deleteTime
+		preset.Finalizers = append(preset.Finalizers, v1.SeedPresetCleanupFinalizer)
+	}
+	return preset
+}
+
+func newReconciler(resource syncedResource, masterClient ctrlruntimeclient.Client, seedClients map[string]ctrlruntimeclient.Client) *reconciler {
+	return &reconciler{
+		log:          kubermaticlog.Logger,
+		recorder:     &record.FakeRecorder{},
+		masterClient: masterClient,
+		seedClients:  seedClients,
+		namespace:    "kubermatic",
+		resource:     resource,
+		failures:     map[string]sets.String{},
+	}
+}
+
+func syncedResourceOfKind(t *testing.T, kind string) syncedResource {
+	for _, resource := range syncedResources {
+		if resource.kind == kind {
+			return resource
+		}
+	}
+	t.Fatalf("no synced resource of kind %s", kind)
+	return syncedResource{}
+}
+
+func TestReconcileConstraintTemplates(t *testing.T) {
+	ctx := context.Background()
+
+	template := &kubermaticv1.ConstraintTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "requiredlabels"},
+		Spec: kubermaticv1.ConstraintTemplateSpec{
+			Targets: []v1beta1.Target{{Target: "admission.k8s.gatekeeper.sh", Rego: "package requiredlabels"}},
+		},
+	}
+
+	masterClient := fakectrlruntimeclient.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(template).Build()
+	seedClient := fakectrlruntimeclient.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	r := newReconciler(syncedResourceOfKind(t, "ConstraintTemplate"), masterClient, map[string]ctrlruntimeclient.Client{"first": seedClient})
+
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: template.Name}}
+	if _, err := r.Reconcile(ctx, request); err != nil {
+		t.Fatalf("reconciling failed: %v", err)
+	}
+
+	seedTemplate := &kubermaticv1.ConstraintTemplate{}
+	if err := seedClient.Get(ctx, request.NamespacedName, seedTemplate); err != nil {
+		t.Fatalf("failed to get constraint template: %v", err)
+	}
+	if !reflect.DeepEqual(seedTemplate.Spec, template.Spec) {
+		t.Fatalf("diff: %s", diff.ObjectGoPrintSideBySide(seedTemplate.Spec, template.Spec))
+	}
+
+	// terminate the template on the master
+	deleteTime := metav1.NewTime(time.Now())
+	template.DeletionTimestamp = &deleteTime
+	template.Finalizers = []string{v1.GatekeeperSeedConstraintTemplateCleanupFinalizer}
+	r.masterClient = fakectrlruntimeclient.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(template).Build()
+	if _, err := r.Reconcile(ctx, request); err != nil {
+		t.Fatalf("reconciling failed: %v", err)
+	}
+
+	if err := seedClient.Get(ctx, request.NamespacedName, seedTemplate); !errors.IsNotFound(err) {
+		t.Fatalf("expected constraint template on the seed cluster to be deleted, got %v", err)
+	}
+}
+
+func TestReconcileVersions(t *testing.T) {
+	ctx := context.Background()
+
+	operatorScheme := runtime.NewScheme()
+	for _, addToScheme := range []func(*runtime.Scheme) error{clientgoscheme.AddToScheme, kubermaticv1.AddToScheme, operatorv1alpha1.AddToScheme} {
+		if err := addToScheme(operatorScheme); err != nil {
+			t.Fatalf("failed to build scheme: %v", err)
+		}
+	}
+
+	config := &operatorv1alpha1.KubermaticConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "kubermatic", Namespace: "kubermatic"},
+		Spec: operatorv1alpha1.KubermaticConfigurationSpec{
+			Versions: operatorv1alpha1.KubermaticVersionsConfiguration{
+				Kubernetes: operatorv1alpha1.KubermaticVersioningConfiguration{
+					Versions: []*semver.Version{semver.MustParse("v1.20.2")},
+					Default:  semver.MustParse("v1.20.2"),
+				},
+			},
+		},
+	}
+
+	masterClient := fakectrlruntimeclient.NewClientBuilder().WithScheme(operatorScheme).WithObjects(config).Build()
+	seedClient := fakectrlruntimeclient.NewClientBuilder().WithScheme(operatorScheme).Build()
+	r := newReconciler(syncedResourceOfKind(t, "KubermaticConfiguration"), masterClient, map[string]ctrlruntimeclient.Client{"first": seedClient})
+
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: config.Namespace, Name: config.Name}}
+	if _, err := r.Reconcile(ctx, request); err != nil {
+		t.Fatalf("reconciling failed: %v", err)
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err := seedClient.Get(ctx, types.NamespacedName{Namespace: config.Namespace, Name: VersionsConfigMapName}, cm); err != nil {
+		t.Fatalf("failed to get versions ConfigMap: %v", err)
+	}
+	if cm.Labels[ConfigurationLabel] != config.Name {
+		t.Fatalf("expected ConfigMap to point to KubermaticConfiguration %q, got %q", config.Name, cm.Labels[ConfigurationLabel])
+	}
+	if versions := cm.Data[common.VersionsFileName]; !strings.Contains(versions, "1.20.2") {
+		t.Fatalf("expected versions to contain 1.20.2, got %q", versions)
+	}
+	if _, ok := cm.Data[common.UpdatesFileName]; !ok {
+		t.Fatal("expected ConfigMap to contain the updates")
+	}
+}
+
+func TestSeedConditionReportsSyncStatus(t *testing.T) {
+	ctx := context.Background()
+
+	seeds := []ctrlruntimeclient.Object{
+		&kubermaticv1.Seed{ObjectMeta: metav1.ObjectMeta{Name: "healthy", Namespace: "kubermatic"}},
+		&kubermaticv1.Seed{ObjectMeta: metav1.ObjectMeta{Name: "broken", Namespace: "kubermatic"}},
+	}
+	masterClient := fakectrlruntimeclient.
+		NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(append(seeds, generatePreset(presetName, "master-token", false))...).
+		Build()
+
+	r := newReconciler(syncedResources[0], masterClient, map[string]ctrlruntimeclient.Client{
+		"healthy": fakectrlruntimeclient.NewClientBuilder().WithScheme(scheme.Scheme).Build(),
+		// the Preset kind is unknown to this client, so syncing into it fails
+		"broken": fakectrlruntimeclient.NewClientBuilder().WithScheme(runtime.NewScheme()).Build(),
+	})
+
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: presetName}}
+	if _, err := r.Reconcile(ctx, request); err == nil {
+		t.Fatal("expected reconciling to fail for the broken seed")
+	}
+
+	expected := map[string]corev1.ConditionStatus{
+		"healthy": corev1.ConditionTrue,
+		"broken":  corev1.ConditionFalse,
+	}
+	for seedName, status := range expected {
+		seed := &kubermaticv1.Seed{}
+		if err := masterClient.Get(ctx, types.NamespacedName{Namespace: "kubermatic", Name: seedName}, seed); err != nil {
+			t.Fatalf("failed to get seed: %v", err)
+		}
+
+		var condition *kubermaticv1.SeedCondition
+		for i := range seed.Status.Conditions {
+			if seed.Status.Conditions[i].Type == kubermaticv1.SeedConditionPresetsSynced {
+				condition = &seed.Status.Conditions[i]
+			}
+		}
+		if condition == nil {
+			t.Fatalf("seed %s has no %s condition", seedName, kubermaticv1.SeedConditionPresetsSynced)
+		}
+		if condition.Status != status {
+			t.Errorf("expected condition of seed %s to be %s, got %s", seedName, status, condition.Status)
+		}
+		if status == corev1.ConditionFalse && !strings.Contains(condition.Message, presetName) {
+			t.Errorf("expected condition message of seed %s to name the preset, got %q", seedName, condition.Message)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package seedresourcesynchronizer contains a controller that is responsible for ensuring that
+global resources like Presets, AddonConfigs and ConstraintTemplates are synced from the master
+to all seed clusters. The Kubernetes versions and updates of the KubermaticConfiguration are
+synced into the kubermatic-versions ConfigMap in the KKP namespace of every seed.
+
+As the controller is (re)created by the seed controller lifecycle whenever the set of seeds
+changes, newly registered seeds receive all resources as soon as they become available. Every
+Seed has a condition per resource kind that names the objects which failed to sync into it,
+and the time of the last successful sync per seed and resource kind is exposed as a metric,
+so that the sync lag of every seed can be monitored.
+*/
+package seedresourcesynchronizer
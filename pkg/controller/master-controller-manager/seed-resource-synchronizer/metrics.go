@@ -0,0 +1,42 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package seedresourcesynchronizer
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	lastSuccessfulSyncMetric = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "kubermatic",
+		Subsystem: "master_controller_manager",
+		Name:      "seed_resource_last_successful_sync_timestamp_seconds",
+		Help:      "The Unix timestamp of the last successful sync of a global resource kind into the given seed",
+	}, []string{"seed", "kind"})
+
+	syncErrorsMetric = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "kubermatic",
+		Subsystem: "master_controller_manager",
+		Name:      "seed_resource_sync_errors_total",
+		Help:      "The number of failed syncs of a global resource kind into the given seed",
+	}, []string{"seed", "kind"})
+)
+
+func init() {
+	prometheus.MustRegister(lastSuccessfulSyncMetric)
+	prometheus.MustRegister(syncErrorsMetric)
+}
@@ -30,6 +30,7 @@ import (
 	kubermaticapiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	operatorv1alpha1 "k8c.io/kubermatic/v2/pkg/crd/operator/v1alpha1"
+	"k8c.io/kubermatic/v2/pkg/objectstorage"
 	"k8c.io/kubermatic/v2/pkg/resources"
 	"k8c.io/kubermatic/v2/pkg/version"
 
@@ -55,6 +56,7 @@ const (
 	DefaultVPAUpdaterDockerRepository             = "gcr.io/google_containers/vpa-updater"
 	DefaultVPAAdmissionControllerDockerRepository = "gcr.io/google_containers/vpa-admission-controller"
 	DefaultEnvoyDockerRepository                  = "docker.io/envoyproxy/envoy-alpine"
+	DefaultRegistryCacheDockerRepository          = "docker.io/library/registry"
 	DefaultMaximumParallelReconciles              = 10
 	DefaultS3Endpoint                             = "s3.amazonaws.com"
 
@@ -68,6 +70,8 @@ var (
 	DefaultAccessibleAddons = []string{
 		"cluster-autoscaler",
 		"node-exporter",
+		"node-problem-detector",
+		"local-volume-provisioner",
 		"multus",
 		"gatekeeper",
 	}
@@ -182,6 +186,19 @@ var (
 		},
 	}
 
+	DefaultRegistryCacheResources = corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("50m"),
+			corev1.ResourceMemory: resource.MustParse("64Mi"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("500m"),
+			corev1.ResourceMemory: resource.MustParse("256Mi"),
+		},
+	}
+
+	DefaultRegistryCacheStorageSize = resource.MustParse("20Gi")
+
 	DefaultNodeportProxyServiceAnnotations = map[string]string{
 		// If we're running on AWS, use an NLB. It has a fixed IP & we can use VPC endpoints
 		// https://docs.aws.amazon.com/de_de/eks/latest/userguide/load-balancing.html
@@ -309,11 +326,13 @@ func DefaultConfiguration(config *operatorv1alpha1.KubermaticConfiguration, logg
 	}
 
 	if copy.Spec.SeedController.BackupRestore.Enabled {
-		if copy.Spec.SeedController.BackupRestore.S3Endpoint == "" {
-			copy.Spec.SeedController.BackupRestore.S3Endpoint = DefaultS3Endpoint
-		}
-		if copy.Spec.SeedController.BackupRestore.S3BucketName == "" {
-			return nil, fmt.Errorf("backupRestore.enabled is set, but s3BucketName is unset")
+		if copy.Spec.ObjectStorage.Backups == nil {
+			if copy.Spec.SeedController.BackupRestore.S3Endpoint == "" {
+				copy.Spec.SeedController.BackupRestore.S3Endpoint = DefaultS3Endpoint
+			}
+			if copy.Spec.SeedController.BackupRestore.S3BucketName == "" {
+				return nil, fmt.Errorf("backupRestore.enabled is set, but neither objectStorage.backups nor s3BucketName is set")
+			}
 		}
 		if copy.Spec.SeedController.BackupDeleteContainer == "" {
 			copy.Spec.SeedController.BackupDeleteContainer = strings.TrimSpace(DefaultNewBackupDeleteContainer)
@@ -403,6 +422,16 @@ func DefaultConfiguration(config *operatorv1alpha1.KubermaticConfiguration, logg
 		return copy, err
 	}
 
+	if err := defaultObjectStorageLocation(copy.Spec.ObjectStorage.Backups, "objectStorage.backups", logger); err != nil {
+		return copy, err
+	}
+	if err := defaultObjectStorageLocation(copy.Spec.ObjectStorage.MeteringReports, "objectStorage.meteringReports", logger); err != nil {
+		return copy, err
+	}
+	if err := defaultObjectStorageLocation(copy.Spec.ObjectStorage.SupportBundles, "objectStorage.supportBundles", logger); err != nil {
+		return copy, err
+	}
+
 	auth := copy.Spec.Auth
 
 	if auth.ClientID == "" {
@@ -537,6 +566,22 @@ func DefaultSeed(seed *kubermaticv1.Seed, logger *zap.SugaredLogger) (*kubermati
 		logger.Debugw("Defaulting field", "field", "nodeportProxy.annotations", "value", copy.Spec.NodeportProxy.Annotations)
 	}
 
+	if cache := copy.Spec.RegistryCache; cache != nil {
+		if err := defaultDockerRepo(&cache.DockerRepository, DefaultRegistryCacheDockerRepository, "registryCache.dockerRepository", logger); err != nil {
+			return copy, err
+		}
+
+		if err := defaultResources(&cache.Resources, DefaultRegistryCacheResources, "registryCache.resources", logger); err != nil {
+			return copy, err
+		}
+
+		if cache.StorageSize == nil {
+			storageSize := DefaultRegistryCacheStorageSize.DeepCopy()
+			cache.StorageSize = &storageSize
+			logger.Debugw("Defaulting field", "field", "registryCache.storageSize", "value", cache.StorageSize.String())
+		}
+	}
+
 	return copy, nil
 }
 
@@ -597,6 +642,42 @@ func defaultResourceList(list *corev1.ResourceList, defaults corev1.ResourceList
 	return nil
 }
 
+func defaultObjectStorageLocation(location *operatorv1alpha1.ObjectStorageLocation, key string, logger *zap.SugaredLogger) error {
+	if location == nil {
+		return nil
+	}
+
+	if location.Bucket == "" {
+		return fmt.Errorf("%s.bucket must be set", key)
+	}
+
+	if location.Provider == "" {
+		location.Provider = string(objectstorage.ProviderS3)
+		logger.Debugw("Defaulting field", "field", key+".provider", "value", location.Provider)
+	}
+
+	supported := false
+	for _, provider := range objectstorage.SupportedProviders {
+		if location.Provider == string(provider) {
+			supported = true
+		}
+	}
+	if !supported {
+		return fmt.Errorf("%s.provider %q is not supported, must be one of %v", key, location.Provider, objectstorage.SupportedProviders)
+	}
+
+	if location.Provider == string(objectstorage.ProviderS3) && location.Endpoint == "" {
+		location.Endpoint = DefaultS3Endpoint
+		logger.Debugw("Defaulting field", "field", key+".endpoint", "value", location.Endpoint)
+	}
+
+	if location.Provider == string(objectstorage.ProviderSwift) && location.Endpoint == "" {
+		return fmt.Errorf("%s.endpoint must be set to the Keystone URL for Swift", key)
+	}
+
+	return nil
+}
+
 func defaultVersioning(settings *operatorv1alpha1.KubermaticVersioningConfiguration, defaults operatorv1alpha1.KubermaticVersioningConfiguration, key string, logger *zap.SugaredLogger) error {
 	// this should never happen as the resources are not pointers in a KubermaticConfiguration
 	if settings == nil {
@@ -849,6 +930,18 @@ items:
     name: aws-node-termination-handler
     labels:
       addons.kubermatic.io/ensure: true
+- apiVersion: kubermatic.k8s.io/v1
+  kind: Addon
+  metadata:
+    name: nvidia-gpu-driver
+    labels:
+      addons.kubermatic.io/ensure: true
+- apiVersion: kubermatic.k8s.io/v1
+  kind: Addon
+  metadata:
+    name: azure-monitor-agent
+    labels:
+      addons.kubermatic.io/ensure: true
 `
 
 type versionsYAML struct {
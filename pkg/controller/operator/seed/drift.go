@@ -0,0 +1,230 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package seed
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1/helper"
+	operatorv1alpha1 "k8c.io/kubermatic/v2/pkg/crd/operator/v1alpha1"
+	"k8c.io/kubermatic/v2/pkg/resources/reconciling"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ComponentsHashAnnotation is the hash of the images, flags and replicas of a Deployment
+	// as last applied by the operator. It is used to detect manual changes to the Deployment.
+	ComponentsHashAnnotation = "operator.kubermatic.io/components-hash"
+)
+
+// deploymentComponents are the parts of a Deployment which are checked for drift.
+type deploymentComponents struct {
+	Replicas   int32                 `json:"replicas"`
+	Containers []containerComponents `json:"containers"`
+}
+
+type containerComponents struct {
+	Name    string   `json:"name"`
+	Image   string   `json:"image"`
+	Command []string `json:"command,omitempty"`
+	Args    []string `json:"args,omitempty"`
+}
+
+// componentsHash returns the hash of the images, flags and replicas of the Deployment.
+func componentsHash(deployment *appsv1.Deployment) (string, error) {
+	// Kubernetes defaults unset replicas to 1
+	components := deploymentComponents{Replicas: 1}
+	if deployment.Spec.Replicas != nil {
+		components.Replicas = *deployment.Spec.Replicas
+	}
+
+	podSpec := deployment.Spec.Template.Spec
+	for _, containers := range [][]corev1.Container{podSpec.InitContainers, podSpec.Containers} {
+		for _, container := range containers {
+			components.Containers = append(components.Containers, containerComponents{
+				Name:    container.Name,
+				Image:   container.Image,
+				Command: container.Command,
+				Args:    container.Args,
+			})
+		}
+	}
+
+	encoded, err := json.Marshal(components)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(encoded)), nil
+}
+
+// componentsHashModifier records the hash of the components of the Deployment as applied by the
+// operator. It must be the last modifier, so it sees the final Deployment.
+func componentsHashModifier(create reconciling.ObjectCreator) reconciling.ObjectCreator {
+	return func(existing ctrlruntimeclient.Object) (ctrlruntimeclient.Object, error) {
+		obj, err := create(existing)
+		if err != nil {
+			return obj, err
+		}
+
+		deployment, ok := obj.(*appsv1.Deployment)
+		if !ok {
+			return obj, nil
+		}
+
+		hash, err := componentsHash(deployment)
+		if err != nil {
+			return obj, fmt.Errorf("failed to hash Deployment components: %v", err)
+		}
+
+		if deployment.Annotations == nil {
+			deployment.Annotations = map[string]string{}
+		}
+		deployment.Annotations[ComponentsHashAnnotation] = hash
+
+		return deployment, nil
+	}
+}
+
+// driftedDeployments returns the names of the Deployments whose images, flags or replicas were
+// changed since the operator applied them. Deployments that were never applied with a hash are
+// not considered to be drifted.
+func driftedDeployments(ctx context.Context, client ctrlruntimeclient.Client, namespace string, creators []reconciling.NamedDeploymentCreatorGetter) ([]string, error) {
+	var drifted []string
+
+	for _, get := range creators {
+		name, _ := get()
+
+		deployment := &appsv1.Deployment{}
+		if err := client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, deployment); err != nil {
+			if kerrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get Deployment %s/%s: %v", namespace, name, err)
+		}
+
+		applied := deployment.Annotations[ComponentsHashAnnotation]
+		if applied == "" {
+			continue
+		}
+
+		hash, err := componentsHash(deployment)
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash components of Deployment %s/%s: %v", namespace, name, err)
+		}
+		if hash != applied {
+			drifted = append(drifted, name)
+		}
+	}
+
+	return drifted, nil
+}
+
+// withoutDrifted returns the creators of the Deployments which are not drifted.
+func withoutDrifted(creators []reconciling.NamedDeploymentCreatorGetter, drifted []string) []reconciling.NamedDeploymentCreatorGetter {
+	driftedSet := map[string]struct{}{}
+	for _, name := range drifted {
+		driftedSet[name] = struct{}{}
+	}
+
+	var filtered []reconciling.NamedDeploymentCreatorGetter
+	for _, get := range creators {
+		name, _ := get()
+		if _, ok := driftedSet[name]; !ok {
+			filtered = append(filtered, get)
+		}
+	}
+	return filtered
+}
+
+// filterDriftedDeployments detects drifted Deployments in the namespace and, unless the drift is
+// reverted automatically, removes them from the creators. The drifted Deployments are returned
+// as "namespace/name".
+func filterDriftedDeployments(ctx context.Context, cfg *operatorv1alpha1.KubermaticConfiguration, client ctrlruntimeclient.Client, namespace string, creators []reconciling.NamedDeploymentCreatorGetter) ([]reconciling.NamedDeploymentCreatorGetter, []string, error) {
+	drifted, err := driftedDeployments(ctx, client, namespace, creators)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var report []string
+	for _, name := range drifted {
+		report = append(report, fmt.Sprintf("%s/%s", namespace, name))
+	}
+
+	if cfg.Spec.DriftDetection.AutoRevert {
+		return creators, report, nil
+	}
+	return withoutDrifted(creators, drifted), report, nil
+}
+
+// updateComponentsCondition reports the drifted Deployments in the ComponentsInSync condition of
+// the Seed in the master cluster.
+func (r *Reconciler) updateComponentsCondition(ctx context.Context, cfg *operatorv1alpha1.KubermaticConfiguration, seedName string, drifted []string, log *zap.SugaredLogger) error {
+	seed := &kubermaticv1.Seed{}
+	if err := r.masterClient.Get(ctx, types.NamespacedName{Namespace: r.namespace, Name: seedName}, seed); err != nil {
+		return fmt.Errorf("failed to get Seed: %v", err)
+	}
+
+	status := corev1.ConditionTrue
+	reason := ""
+	message := ""
+
+	if len(drifted) > 0 {
+		sort.Strings(drifted)
+		list := strings.Join(drifted, ", ")
+
+		if cfg.Spec.DriftDetection.AutoRevert {
+			log.Infow("reverted manual changes to Deployments", "deployments", drifted)
+			reason = kubermaticv1.ReasonSeedComponentsDriftReverted
+			message = fmt.Sprintf("Reverted manual changes to the Deployments %s.", list)
+			r.masterRecorder.Event(seed, corev1.EventTypeNormal, kubermaticv1.ReasonSeedComponentsDriftReverted, message)
+		} else {
+			log.Infow("Deployments were changed manually, not reconciling them", "deployments", drifted)
+			status = corev1.ConditionFalse
+			reason = kubermaticv1.ReasonSeedComponentsDrifted
+			message = fmt.Sprintf("The Deployments %s were changed manually and are not reconciled until the changes are reverted.", list)
+		}
+	}
+
+	oldSeed := seed.DeepCopy()
+	kubermaticv1helper.SetSeedCondition(seed, kubermaticv1.SeedConditionComponentsInSync, status, reason, message)
+	if reflect.DeepEqual(oldSeed, seed) {
+		return nil
+	}
+
+	if status == corev1.ConditionFalse {
+		r.masterRecorder.Event(seed, corev1.EventTypeWarning, reason, message)
+	}
+
+	if err := r.masterClient.Patch(ctx, seed, ctrlruntimeclient.MergeFrom(oldSeed)); err != nil {
+		return fmt.Errorf("failed to update Seed status: %v", err)
+	}
+	return nil
+}
@@ -27,6 +27,7 @@ import (
 	"k8c.io/kubermatic/v2/pkg/controller/operator/common/vpa"
 	kubermaticseed "k8c.io/kubermatic/v2/pkg/controller/operator/seed/resources/kubermatic"
 	"k8c.io/kubermatic/v2/pkg/controller/operator/seed/resources/nodeportproxy"
+	"k8c.io/kubermatic/v2/pkg/controller/operator/seed/resources/registrycache"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	operatorv1alpha1 "k8c.io/kubermatic/v2/pkg/crd/operator/v1alpha1"
 	"k8c.io/kubermatic/v2/pkg/features"
@@ -37,12 +38,15 @@ import (
 	kubermaticversion "k8c.io/kubermatic/v2/pkg/version/kubermatic"
 
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -261,7 +265,16 @@ func (r *Reconciler) reconcileResources(ctx context.Context, cfg *operatorv1alph
 		return err
 	}
 
-	if err := r.reconcileDeployments(ctx, cfg, seed, client, log, caBundle); err != nil {
+	if err := r.reconcilePersistentVolumeClaims(ctx, cfg, seed, client, log); err != nil {
+		return err
+	}
+
+	drifted, err := r.reconcileDeployments(ctx, cfg, seed, client, log, caBundle)
+	if err != nil {
+		return err
+	}
+
+	if err := r.updateComponentsCondition(ctx, cfg, seed.Name, drifted, log); err != nil {
 		return err
 	}
 
@@ -277,6 +290,12 @@ func (r *Reconciler) reconcileResources(ctx context.Context, cfg *operatorv1alph
 		return err
 	}
 
+	if seed.Spec.RegistryCache == nil {
+		if err := r.cleanupRegistryCache(ctx, cfg, client, log); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -423,11 +442,7 @@ func (r *Reconciler) reconcileConfigMaps(ctx context.Context, cfg *operatorv1alp
 		return fmt.Errorf("failed to reconcile ConfigMaps: %v", err)
 	}
 
-	var kubeSystemCreators []reconciling.NamedConfigMapCreatorGetter
-
-	if creator := kubermaticseed.RestoreS3SettingsConfigMapCreator(cfg); creator != nil {
-		kubeSystemCreators = append(kubeSystemCreators, creator)
-	}
+	kubeSystemCreators := kubermaticseed.ObjectStorageSettingsConfigMapCreators(cfg)
 
 	if err := reconciling.ReconcileConfigMaps(ctx, kubeSystemCreators, metav1.NamespaceSystem, client); err != nil {
 		return fmt.Errorf("failed to reconcile kube-system ConfigMaps: %v", err)
@@ -467,7 +482,8 @@ func (r *Reconciler) reconcileSecrets(ctx context.Context, cfg *operatorv1alpha1
 	return nil
 }
 
-func (r *Reconciler) reconcileDeployments(ctx context.Context, cfg *operatorv1alpha1.KubermaticConfiguration, seed *kubermaticv1.Seed, client ctrlruntimeclient.Client, log *zap.SugaredLogger, caBundle *corev1.ConfigMap) error {
+// reconcileDeployments returns the Deployments whose images, flags or replicas were changed manually.
+func (r *Reconciler) reconcileDeployments(ctx context.Context, cfg *operatorv1alpha1.KubermaticConfiguration, seed *kubermaticv1.Seed, client ctrlruntimeclient.Client, log *zap.SugaredLogger, caBundle *corev1.ConfigMap) ([]string, error) {
 	log.Debug("reconciling Deployments")
 
 	creators := []reconciling.NamedDeploymentCreatorGetter{
@@ -482,6 +498,15 @@ func (r *Reconciler) reconcileDeployments(ctx context.Context, cfg *operatorv1al
 		)
 	}
 
+	if seed.Spec.RegistryCache != nil {
+		creators = append(creators, registrycache.DeploymentCreator(seed, r.versions))
+	}
+
+	creators, drifted, err := filterDriftedDeployments(ctx, cfg, client, r.namespace, creators)
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect drift of Kubermatic Deployments: %v", err)
+	}
+
 	volumeLabelModifier := common.VolumeRevisionLabelsModifierFactory(ctx, client)
 	modifiers := []reconciling.ObjectModifier{
 		common.OwnershipModifierFactory(seed, r.scheme),
@@ -492,9 +517,10 @@ func (r *Reconciler) reconcileDeployments(ctx context.Context, cfg *operatorv1al
 	if cfg.Spec.ImagePullSecret != "" {
 		modifiers = append(modifiers, reconciling.ImagePullSecretsWrapper(common.DockercfgSecretName))
 	}
+	modifiers = append(modifiers, componentsHashModifier)
 
 	if err := reconciling.ReconcileDeployments(ctx, creators, r.namespace, client, modifiers...); err != nil {
-		return fmt.Errorf("failed to reconcile Kubermatic Deployments: %v", err)
+		return nil, fmt.Errorf("failed to reconcile Kubermatic Deployments: %v", err)
 	}
 
 	if cfg.Spec.FeatureGates.Has(features.VerticalPodAutoscaler) {
@@ -504,13 +530,19 @@ func (r *Reconciler) reconcileDeployments(ctx context.Context, cfg *operatorv1al
 			vpa.AdmissionControllerDeploymentCreator(cfg, r.versions),
 		}
 
+		creators, vpaDrifted, err := filterDriftedDeployments(ctx, cfg, client, metav1.NamespaceSystem, creators)
+		if err != nil {
+			return nil, fmt.Errorf("failed to detect drift of VPA Deployments: %v", err)
+		}
+		drifted = append(drifted, vpaDrifted...)
+
 		// no ownership because these resources are most likely in a different namespace than Kubermatic
-		if err := reconciling.ReconcileDeployments(ctx, creators, metav1.NamespaceSystem, client, volumeLabelModifier); err != nil {
-			return fmt.Errorf("failed to reconcile VPA Deployments: %v", err)
+		if err := reconciling.ReconcileDeployments(ctx, creators, metav1.NamespaceSystem, client, volumeLabelModifier, componentsHashModifier); err != nil {
+			return nil, fmt.Errorf("failed to reconcile VPA Deployments: %v", err)
 		}
 	}
 
-	return nil
+	return drifted, nil
 }
 
 func (r *Reconciler) reconcilePodDisruptionBudgets(ctx context.Context, cfg *operatorv1alpha1.KubermaticConfiguration, seed *kubermaticv1.Seed, client ctrlruntimeclient.Client, log *zap.SugaredLogger) error {
@@ -524,6 +556,10 @@ func (r *Reconciler) reconcilePodDisruptionBudgets(ctx context.Context, cfg *ope
 		creators = append(creators, nodeportproxy.EnvoyPDBCreator())
 	}
 
+	if seed.Spec.RegistryCache != nil {
+		creators = append(creators, registrycache.PDBCreator())
+	}
+
 	if err := reconciling.ReconcilePodDisruptionBudgets(ctx, creators, cfg.Namespace, client, common.OwnershipModifierFactory(seed, r.scheme)); err != nil {
 		return fmt.Errorf("failed to reconcile PodDisruptionBudgets: %v", err)
 	}
@@ -556,6 +592,18 @@ func (r *Reconciler) reconcileServices(ctx context.Context, cfg *operatorv1alpha
 		}
 	}
 
+	// Same as for the nodeport-proxy, the registry cache's endpoint is usually a DNS
+	// record pointing to the LoadBalancer, so the Service is not given an owner reference.
+	if seed.Spec.RegistryCache != nil {
+		creators = []reconciling.NamedServiceCreatorGetter{
+			registrycache.ServiceCreator(seed),
+		}
+
+		if err := reconciling.ReconcileServices(ctx, creators, cfg.Namespace, client); err != nil {
+			return fmt.Errorf("failed to reconcile registry cache Services: %v", err)
+		}
+	}
+
 	if cfg.Spec.FeatureGates.Has(features.VerticalPodAutoscaler) {
 		creators := []reconciling.NamedServiceCreatorGetter{
 			vpa.AdmissionControllerServiceCreator(),
@@ -570,6 +618,64 @@ func (r *Reconciler) reconcileServices(ctx context.Context, cfg *operatorv1alpha
 	return nil
 }
 
+// cleanupRegistryCache removes the registry cache once it has been disabled in the Seed.
+func (r *Reconciler) cleanupRegistryCache(ctx context.Context, cfg *operatorv1alpha1.KubermaticConfiguration, client ctrlruntimeclient.Client, log *zap.SugaredLogger) error {
+	objects := []ctrlruntimeclient.Object{
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: registrycache.DeploymentName, Namespace: cfg.Namespace}},
+		&policyv1beta1.PodDisruptionBudget{ObjectMeta: metav1.ObjectMeta{Name: registrycache.DeploymentName, Namespace: cfg.Namespace}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: registrycache.ServiceName, Namespace: cfg.Namespace}},
+	}
+
+	for _, obj := range objects {
+		if err := client.Delete(ctx, obj); err != nil && !kerrors.IsNotFound(err) {
+			return fmt.Errorf("failed to clean up registry cache %T: %v", obj, err)
+		}
+	}
+
+	return r.cleanupRegistryCacheVolumeClaims(ctx, cfg, client, sets.NewString())
+}
+
+func (r *Reconciler) reconcilePersistentVolumeClaims(ctx context.Context, cfg *operatorv1alpha1.KubermaticConfiguration, seed *kubermaticv1.Seed, client ctrlruntimeclient.Client, log *zap.SugaredLogger) error {
+	log.Debug("reconciling PersistentVolumeClaims")
+
+	if seed.Spec.RegistryCache == nil {
+		return nil
+	}
+
+	creators := registrycache.VolumeClaimCreators(seed)
+	if err := reconciling.ReconcilePersistentVolumeClaims(ctx, creators, cfg.Namespace, client, common.OwnershipModifierFactory(seed, r.scheme)); err != nil {
+		return fmt.Errorf("failed to reconcile registry cache PersistentVolumeClaims: %v", err)
+	}
+
+	// remove the cached images of registries that are no longer cached
+	keep := sets.NewString()
+	for _, upstream := range seed.Spec.RegistryCache.UpstreamRegistries() {
+		keep.Insert(registrycache.VolumeClaimName(upstream.Upstream))
+	}
+
+	return r.cleanupRegistryCacheVolumeClaims(ctx, cfg, client, keep)
+}
+
+// cleanupRegistryCacheVolumeClaims removes all PersistentVolumeClaims of the registry cache
+// except the ones to keep.
+func (r *Reconciler) cleanupRegistryCacheVolumeClaims(ctx context.Context, cfg *operatorv1alpha1.KubermaticConfiguration, client ctrlruntimeclient.Client, keep sets.String) error {
+	claims := &corev1.PersistentVolumeClaimList{}
+	if err := client.List(ctx, claims, ctrlruntimeclient.InNamespace(cfg.Namespace), ctrlruntimeclient.MatchingLabels(registrycache.VolumeClaimLabels())); err != nil {
+		return fmt.Errorf("failed to list registry cache PersistentVolumeClaims: %v", err)
+	}
+
+	for i := range claims.Items {
+		if keep.Has(claims.Items[i].Name) {
+			continue
+		}
+		if err := client.Delete(ctx, &claims.Items[i]); err != nil && !kerrors.IsNotFound(err) {
+			return fmt.Errorf("failed to clean up registry cache PersistentVolumeClaim %s: %v", claims.Items[i].Name, err)
+		}
+	}
+
+	return nil
+}
+
 func (r *Reconciler) reconcileAdmissionWebhooks(ctx context.Context, cfg *operatorv1alpha1.KubermaticConfiguration, seed *kubermaticv1.Seed, client ctrlruntimeclient.Client, log *zap.SugaredLogger) error {
 	log.Debug("reconciling AdmissionWebhooks")
 
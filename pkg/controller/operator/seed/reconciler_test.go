@@ -106,6 +106,22 @@ func TestBasicReconciling(t *testing.T) {
 				},
 			},
 		},
+		"seed-with-registry-cache": {
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "seed-with-registry-cache",
+				Namespace: "kubermatic",
+			},
+			Spec: kubermaticv1.SeedSpec{
+				RegistryCache: &kubermaticv1.RegistryCacheConfig{
+					Endpoint:      "registry-cache.example.com",
+					TLSSecretName: "registry-cache-tls",
+					Registries: []kubermaticv1.RegistryCacheRegistry{
+						{Upstream: "quay.io", Port: 5010},
+						{Upstream: "docker.io", Port: 5000},
+					},
+				},
+			},
+		},
 	}
 
 	type testcase struct {
@@ -355,6 +371,73 @@ func TestBasicReconciling(t *testing.T) {
 			},
 		},
 
+		{
+			name:            "registry cache is deployed with one container, port and volume claim per registry",
+			seedToReconcile: "seed-with-registry-cache",
+			configuration: &operatorv1alpha1.KubermaticConfiguration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: "kubermatic",
+				},
+				Spec: operatorv1alpha1.KubermaticConfigurationSpec{
+					Ingress: operatorv1alpha1.KubermaticIngressConfiguration{
+						Domain: "example.com",
+					},
+				},
+			},
+			seedsOnMaster: []string{"seed-with-registry-cache"},
+			syncedSeeds:   sets.NewString("seed-with-registry-cache"),
+			assertion: func(test *testcase, reconciler *Reconciler) error {
+				ctx := context.Background()
+
+				if err := reconciler.reconcile(ctx, reconciler.log, test.seedToReconcile); err != nil {
+					return fmt.Errorf("reconciliation failed: %v", err)
+				}
+
+				seedClient := reconciler.seedClients["seed-with-registry-cache"]
+
+				deployment := appsv1.Deployment{}
+				if err := seedClient.Get(ctx, types.NamespacedName{
+					Namespace: "kubermatic",
+					Name:      "registry-cache",
+				}, &deployment); err != nil {
+					return fmt.Errorf("failed to retrieve registry cache Deployment: %v", err)
+				}
+
+				if containers := len(deployment.Spec.Template.Spec.Containers); containers != 2 {
+					return fmt.Errorf("expected 2 registry cache containers, got %d", containers)
+				}
+
+				svc := corev1.Service{}
+				if err := seedClient.Get(ctx, types.NamespacedName{
+					Namespace: "kubermatic",
+					Name:      "registry-cache",
+				}, &svc); err != nil {
+					return fmt.Errorf("failed to retrieve registry cache Service: %v", err)
+				}
+
+				expectedPorts := map[string]int32{"quay-io": 5010, "docker-io": 5000}
+				if len(svc.Spec.Ports) != len(expectedPorts) {
+					return fmt.Errorf("expected %d service ports, got %d", len(expectedPorts), len(svc.Spec.Ports))
+				}
+				for _, port := range svc.Spec.Ports {
+					if expectedPorts[port.Name] != port.Port {
+						return fmt.Errorf("expected port %q to be %d, got %d", port.Name, expectedPorts[port.Name], port.Port)
+					}
+				}
+
+				claims := corev1.PersistentVolumeClaimList{}
+				if err := seedClient.List(ctx, &claims, ctrlruntimeclient.InNamespace("kubermatic")); err != nil {
+					return fmt.Errorf("failed to list registry cache PersistentVolumeClaims: %v", err)
+				}
+				if len(claims.Items) != len(expectedPorts) {
+					return fmt.Errorf("expected %d registry cache PersistentVolumeClaims, got %d", len(expectedPorts), len(claims.Items))
+				}
+
+				return nil
+			},
+		},
+
 		{
 			name:            "when imagePullSecret is given secret should be provisioned",
 			seedToReconcile: "europe",
@@ -416,6 +499,51 @@ func TestBasicReconciling(t *testing.T) {
 
 			},
 		},
+
+		{
+			name:            "manual changes to Deployments are reported and kept",
+			seedToReconcile: "europe",
+			configuration: &operatorv1alpha1.KubermaticConfiguration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: "kubermatic",
+				},
+				Spec: operatorv1alpha1.KubermaticConfigurationSpec{
+					Ingress: operatorv1alpha1.KubermaticIngressConfiguration{
+						Domain: "example.com",
+					},
+				},
+			},
+			seedsOnMaster: []string{"europe"},
+			syncedSeeds:   sets.NewString("europe"),
+			assertion: func(test *testcase, reconciler *Reconciler) error {
+				return assertDrift(reconciler, test.seedToReconcile, false)
+			},
+		},
+
+		{
+			name:            "manual changes to Deployments are reverted",
+			seedToReconcile: "europe",
+			configuration: &operatorv1alpha1.KubermaticConfiguration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: "kubermatic",
+				},
+				Spec: operatorv1alpha1.KubermaticConfigurationSpec{
+					Ingress: operatorv1alpha1.KubermaticIngressConfiguration{
+						Domain: "example.com",
+					},
+					DriftDetection: operatorv1alpha1.KubermaticDriftDetectionConfiguration{
+						AutoRevert: true,
+					},
+				},
+			},
+			seedsOnMaster: []string{"europe"},
+			syncedSeeds:   sets.NewString("europe"),
+			assertion: func(test *testcase, reconciler *Reconciler) error {
+				return assertDrift(reconciler, test.seedToReconcile, true)
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -429,6 +557,68 @@ func TestBasicReconciling(t *testing.T) {
 	}
 }
 
+// assertDrift changes the image of the seed-controller-manager and checks that the change is
+// reported in the Seed and reverted only if autoRevert is enabled.
+func assertDrift(reconciler *Reconciler, seedName string, autoRevert bool) error {
+	ctx := context.Background()
+
+	if err := reconciler.reconcile(ctx, reconciler.log, seedName); err != nil {
+		return fmt.Errorf("reconciliation failed: %v", err)
+	}
+
+	seedClient := reconciler.seedClients[seedName]
+	deploymentName := types.NamespacedName{Namespace: "kubermatic", Name: common.SeedControllerManagerDeploymentName}
+
+	deployment := &appsv1.Deployment{}
+	if err := seedClient.Get(ctx, deploymentName, deployment); err != nil {
+		return fmt.Errorf("failed to retrieve seed-controller-manager Deployment: %v", err)
+	}
+	image := deployment.Spec.Template.Spec.Containers[0].Image
+
+	// nothing was changed yet
+	seed := &kubermaticv1.Seed{}
+	if err := reconciler.masterClient.Get(ctx, types.NamespacedName{Namespace: "kubermatic", Name: seedName}, seed); err != nil {
+		return fmt.Errorf("failed to retrieve Seed: %v", err)
+	}
+	if len(seed.Status.Conditions) != 1 || seed.Status.Conditions[0].Status != corev1.ConditionTrue {
+		return fmt.Errorf("expected the components to be in sync, got conditions %+v", seed.Status.Conditions)
+	}
+
+	deployment.Spec.Template.Spec.Containers[0].Image = "registry.example.com/custom:latest"
+	if err := seedClient.Update(ctx, deployment); err != nil {
+		return fmt.Errorf("failed to update Deployment: %v", err)
+	}
+
+	if err := reconciler.reconcile(ctx, reconciler.log, seedName); err != nil {
+		return fmt.Errorf("reconciliation failed: %v", err)
+	}
+
+	if err := seedClient.Get(ctx, deploymentName, deployment); err != nil {
+		return fmt.Errorf("failed to retrieve seed-controller-manager Deployment: %v", err)
+	}
+	expectedImage := "registry.example.com/custom:latest"
+	expectedStatus := corev1.ConditionFalse
+	expectedReason := kubermaticv1.ReasonSeedComponentsDrifted
+	if autoRevert {
+		expectedImage = image
+		expectedStatus = corev1.ConditionTrue
+		expectedReason = kubermaticv1.ReasonSeedComponentsDriftReverted
+	}
+	if actual := deployment.Spec.Template.Spec.Containers[0].Image; actual != expectedImage {
+		return fmt.Errorf("expected image %q, got %q", expectedImage, actual)
+	}
+
+	if err := reconciler.masterClient.Get(ctx, types.NamespacedName{Namespace: "kubermatic", Name: seedName}, seed); err != nil {
+		return fmt.Errorf("failed to retrieve Seed: %v", err)
+	}
+	condition := seed.Status.Conditions[0]
+	if condition.Type != kubermaticv1.SeedConditionComponentsInSync || condition.Status != expectedStatus || condition.Reason != expectedReason {
+		return fmt.Errorf("expected condition %s with status %s and reason %s, got %+v", kubermaticv1.SeedConditionComponentsInSync, expectedStatus, expectedReason, condition)
+	}
+
+	return nil
+}
+
 func createTestReconciler(allSeeds map[string]*kubermaticv1.Seed, cfg *operatorv1alpha1.KubermaticConfiguration, seeds []string, syncedSeeds sets.String) *Reconciler {
 	masterObjects := []ctrlruntimeclient.Object{}
 	if cfg != nil {
@@ -473,6 +663,17 @@ func createTestReconciler(allSeeds map[string]*kubermaticv1.Seed, cfg *operatorv
 			seedObjects[seedName] = append(seedObjects[seedName], masterSeed.DeepCopy())
 		}
 
+		if cache := masterSeed.Spec.RegistryCache; cache != nil {
+			// the registry cache TLS secret is provided by the administrator
+			seedObjects[seedName] = append(seedObjects[seedName], &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      cache.TLSSecretName,
+					Namespace: masterSeed.Namespace,
+				},
+				Type: corev1.SecretTypeTLS,
+			})
+		}
+
 		seedClients[seedName] = ctrlruntimefakeclient.
 			NewClientBuilder().
 			WithScheme(scheme.Scheme).
@@ -21,6 +21,7 @@ import (
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	operatorv1alpha1 "k8c.io/kubermatic/v2/pkg/crd/operator/v1alpha1"
+	"k8c.io/kubermatic/v2/pkg/objectstorage"
 	"k8c.io/kubermatic/v2/pkg/resources/reconciling"
 
 	corev1 "k8s.io/api/core/v1"
@@ -28,15 +29,12 @@ import (
 )
 
 const (
-	serviceAccountName             = "kubermatic-seed"
-	backupContainersConfigMapName  = "backup-containers"
-	restoreS3SettingsConfigMapName = "s3-settings"
-	storeContainerKey              = "store-container.yaml"
-	cleanupContainerKey            = "cleanup-container.yaml"
-	deleteContainerKey             = "delete-container.yaml"
-	s3EndpointKey                  = "ENDPOINT"
-	s3BucketNameKey                = "BUCKET_NAME"
-	caBundleConfigMapName          = "ca-bundle"
+	serviceAccountName            = "kubermatic-seed"
+	backupContainersConfigMapName = "backup-containers"
+	storeContainerKey             = "store-container.yaml"
+	cleanupContainerKey           = "cleanup-container.yaml"
+	deleteContainerKey            = "delete-container.yaml"
+	caBundleConfigMapName         = "ca-bundle"
 )
 
 func ClusterRoleBindingName(cfg *operatorv1alpha1.KubermaticConfiguration) string {
@@ -93,19 +91,46 @@ func BackupContainersConfigMapCreator(cfg *operatorv1alpha1.KubermaticConfigurat
 	}
 }
 
-func RestoreS3SettingsConfigMapCreator(cfg *operatorv1alpha1.KubermaticConfiguration) reconciling.NamedConfigMapCreatorGetter {
-	if !cfg.Spec.SeedController.BackupRestore.Enabled {
-		return nil
+// ObjectStorageSettingsConfigMapCreators returns the creators for the ConfigMaps in kube-system
+// which contain the bucket settings of each configured kind of artifact. Backups fall back to the
+// S3 settings of the backup and restore controllers.
+func ObjectStorageSettingsConfigMapCreators(cfg *operatorv1alpha1.KubermaticConfiguration) []reconciling.NamedConfigMapCreatorGetter {
+	backups := cfg.Spec.ObjectStorage.Backups
+	if backups == nil && cfg.Spec.SeedController.BackupRestore.Enabled {
+		backups = &operatorv1alpha1.ObjectStorageLocation{
+			Provider: string(objectstorage.ProviderS3),
+			Bucket:   cfg.Spec.SeedController.BackupRestore.S3BucketName,
+			Endpoint: cfg.Spec.SeedController.BackupRestore.S3Endpoint,
+		}
+	}
+
+	locations := map[objectstorage.Purpose]*operatorv1alpha1.ObjectStorageLocation{
+		objectstorage.PurposeBackups:         backups,
+		objectstorage.PurposeMeteringReports: cfg.Spec.ObjectStorage.MeteringReports,
+		objectstorage.PurposeSupportBundles:  cfg.Spec.ObjectStorage.SupportBundles,
+	}
+
+	var creators []reconciling.NamedConfigMapCreatorGetter
+	for _, purpose := range objectstorage.Purposes {
+		if location := locations[purpose]; location != nil {
+			creators = append(creators, objectStorageSettingsConfigMapCreator(purpose, *location))
+		}
 	}
 
+	return creators
+}
+
+func objectStorageSettingsConfigMapCreator(purpose objectstorage.Purpose, location operatorv1alpha1.ObjectStorageLocation) reconciling.NamedConfigMapCreatorGetter {
 	return func() (string, reconciling.ConfigMapCreator) {
-		return restoreS3SettingsConfigMapName, func(c *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+		return objectstorage.SettingsConfigMapName(purpose), func(c *corev1.ConfigMap) (*corev1.ConfigMap, error) {
 			if c.Data == nil {
 				c.Data = make(map[string]string)
 			}
 
-			c.Data[s3EndpointKey] = cfg.Spec.SeedController.BackupRestore.S3Endpoint
-			c.Data[s3BucketNameKey] = cfg.Spec.SeedController.BackupRestore.S3BucketName
+			c.Data[objectstorage.ProviderKey] = location.Provider
+			c.Data[objectstorage.BucketNameKey] = location.Bucket
+			c.Data[objectstorage.EndpointKey] = location.Endpoint
+			c.Data[objectstorage.RegionKey] = location.Region
 
 			return c, nil
 		}
@@ -0,0 +1,181 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registrycache
+
+import (
+	"fmt"
+	"strings"
+
+	"k8c.io/kubermatic/v2/pkg/controller/operator/common"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources/reconciling"
+	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+)
+
+const (
+	// DeploymentName is the name of the registry cache Deployment and PodDisruptionBudget.
+	DeploymentName = "registry-cache"
+
+	tlsVolumeName = "tls"
+	tlsMountPath  = "/etc/registry/tls"
+)
+
+// DeploymentCreator returns the registry cache Deployment. The Deployment
+// contains one container per upstream registry.
+func DeploymentCreator(seed *kubermaticv1.Seed, versions kubermatic.Versions) reconciling.NamedDeploymentCreatorGetter {
+	return func() (string, reconciling.DeploymentCreator) {
+		return DeploymentName, func(d *appsv1.Deployment) (*appsv1.Deployment, error) {
+			cache := seed.Spec.RegistryCache
+
+			d.Spec.Replicas = pointer.Int32Ptr(2)
+			d.Spec.Selector = &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					common.NameLabel: DeploymentName,
+				},
+			}
+
+			d.Spec.Template.Labels = d.Spec.Selector.MatchLabels
+			// No scrape port is given, so Prometheus scrapes all declared container
+			// ports, which are only the metrics ports.
+			d.Spec.Template.Annotations = map[string]string{
+				"prometheus.io/scrape": "true",
+			}
+
+			var (
+				containers []corev1.Container
+				volumes    []corev1.Volume
+			)
+
+			for _, upstream := range cache.UpstreamRegistries() {
+				name := containerName(upstream.Upstream)
+				port := int(upstream.Port)
+				metricsPort := port + kubermaticv1.RegistryCacheMetricsPortOffset
+
+				containers = append(containers, corev1.Container{
+					Name:  name,
+					Image: cache.DockerRepository + ":" + versions.RegistryCache,
+					Env: []corev1.EnvVar{
+						{Name: "REGISTRY_HTTP_ADDR", Value: fmt.Sprintf(":%d", port)},
+						{Name: "REGISTRY_HTTP_DEBUG_ADDR", Value: fmt.Sprintf(":%d", metricsPort)},
+						{Name: "REGISTRY_HTTP_DEBUG_PROMETHEUS_ENABLED", Value: "true"},
+						{Name: "REGISTRY_HTTP_DEBUG_PROMETHEUS_PATH", Value: "/metrics"},
+						{Name: "REGISTRY_HTTP_TLS_CERTIFICATE", Value: tlsMountPath + "/" + corev1.TLSCertKey},
+						{Name: "REGISTRY_HTTP_TLS_KEY", Value: tlsMountPath + "/" + corev1.TLSPrivateKeyKey},
+						{Name: "REGISTRY_PROXY_REMOTEURL", Value: remoteURL(upstream.Upstream)},
+						{Name: "REGISTRY_STORAGE_DELETE_ENABLED", Value: "true"},
+						{Name: "REGISTRY_STORAGE_FILESYSTEM_ROOTDIRECTORY", Value: "/var/lib/registry"},
+					},
+					// The registry port is deliberately not declared, see the scrape annotation above.
+					Ports: []corev1.ContainerPort{
+						{
+							Name:          fmt.Sprintf("metrics-%d", upstream.Port),
+							Protocol:      corev1.ProtocolTCP,
+							ContainerPort: int32(metricsPort),
+						},
+					},
+					ReadinessProbe: &corev1.Probe{
+						FailureThreshold: 3,
+						SuccessThreshold: 1,
+						TimeoutSeconds:   1,
+						PeriodSeconds:    10,
+						Handler: corev1.Handler{
+							HTTPGet: &corev1.HTTPGetAction{
+								Port:   intstr.FromInt(port),
+								Scheme: corev1.URISchemeHTTPS,
+								Path:   "/",
+							},
+						},
+					},
+					Resources: cache.Resources,
+					VolumeMounts: []corev1.VolumeMount{
+						{
+							Name:      name,
+							MountPath: "/var/lib/registry",
+						},
+						{
+							Name:      tlsVolumeName,
+							MountPath: tlsMountPath,
+							ReadOnly:  true,
+						},
+					},
+				})
+
+				volumes = append(volumes, corev1.Volume{
+					Name: name,
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+							ClaimName: VolumeClaimName(upstream.Upstream),
+						},
+					},
+				})
+			}
+
+			volumes = append(volumes, corev1.Volume{
+				Name: tlsVolumeName,
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName: cache.TLSSecretName,
+					},
+				},
+			})
+
+			d.Spec.Template.Spec.Containers = containers
+			d.Spec.Template.Spec.Volumes = volumes
+
+			return d, nil
+		}
+	}
+}
+
+// PDBCreator returns the PodDisruptionBudget for the registry cache.
+func PDBCreator() reconciling.NamedPodDisruptionBudgetCreatorGetter {
+	maxUnavailable := intstr.FromInt(1)
+	return func() (string, reconciling.PodDisruptionBudgetCreator) {
+		return DeploymentName, func(pdb *policyv1beta1.PodDisruptionBudget) (*policyv1beta1.PodDisruptionBudget, error) {
+			pdb.Spec.MaxUnavailable = &maxUnavailable
+			pdb.Spec.Selector = &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					common.NameLabel: DeploymentName,
+				},
+			}
+			return pdb, nil
+		}
+	}
+}
+
+// containerName turns a registry domain like "k8s.gcr.io" into a valid
+// container name like "k8s-gcr-io".
+func containerName(registry string) string {
+	return strings.NewReplacer(".", "-", ":", "-").Replace(strings.ToLower(registry))
+}
+
+// remoteURL returns the URL the registry cache pulls from. Docker Hub is
+// special, as its images are referenced as docker.io, but served by a
+// different host.
+func remoteURL(registry string) string {
+	if registry == "docker.io" {
+		return "https://registry-1.docker.io"
+	}
+	return "https://" + registry
+}
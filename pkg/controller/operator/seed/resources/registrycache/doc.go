@@ -0,0 +1,23 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package registrycache is responsible for reconciling an optional,
+// seed-local pull-through cache for container images. Every cached upstream
+// registry is served over TLS by its own Docker registry container, backed by
+// a ReadWriteMany volume shared by all replicas, and exposed on its own fixed
+// port of a shared LoadBalancer service, so that image references can be
+// rewritten by simply replacing the registry domain.
+package registrycache
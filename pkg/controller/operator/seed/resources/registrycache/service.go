@@ -0,0 +1,72 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registrycache
+
+import (
+	"k8c.io/kubermatic/v2/pkg/controller/operator/common"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources/reconciling"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// ServiceName is the name of the LoadBalancer service exposing the registry cache.
+	ServiceName = "registry-cache"
+)
+
+// ServiceCreator returns the LoadBalancer service for the registry cache, with one
+// port per cached upstream registry.
+func ServiceCreator(seed *kubermaticv1.Seed) reconciling.NamedServiceCreatorGetter {
+	return func() (string, reconciling.ServiceCreator) {
+		return ServiceName, func(s *corev1.Service) (*corev1.Service, error) {
+			cache := seed.Spec.RegistryCache
+
+			s.Spec.Type = corev1.ServiceTypeLoadBalancer
+			s.Spec.Selector = map[string]string{
+				common.NameLabel: DeploymentName,
+			}
+
+			if cache.Annotations != nil {
+				s.Annotations = cache.Annotations
+			}
+
+			// Keep the NodePorts that have already been allocated, so that
+			// the LoadBalancer is not reconfigured on every reconciliation.
+			nodePorts := map[int32]int32{}
+			for _, port := range s.Spec.Ports {
+				nodePorts[port.Port] = port.NodePort
+			}
+
+			var ports []corev1.ServicePort
+			for _, upstream := range cache.UpstreamRegistries() {
+				port := upstream.Port
+				ports = append(ports, corev1.ServicePort{
+					Name:       containerName(upstream.Upstream),
+					Port:       port,
+					TargetPort: intstr.FromInt(int(port)),
+					NodePort:   nodePorts[port],
+					Protocol:   corev1.ProtocolTCP,
+				})
+			}
+			s.Spec.Ports = ports
+
+			return s, nil
+		}
+	}
+}
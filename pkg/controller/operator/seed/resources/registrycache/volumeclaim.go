@@ -0,0 +1,73 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registrycache
+
+import (
+	"k8c.io/kubermatic/v2/pkg/controller/operator/common"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources/reconciling"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// VolumeClaimName returns the name of the PersistentVolumeClaim holding the cached
+// images of the given upstream registry.
+func VolumeClaimName(upstream string) string {
+	return DeploymentName + "-" + containerName(upstream)
+}
+
+// VolumeClaimLabels are the labels of all PersistentVolumeClaims of the registry cache.
+func VolumeClaimLabels() map[string]string {
+	return map[string]string{
+		common.NameLabel: DeploymentName,
+	}
+}
+
+// VolumeClaimCreators returns one PersistentVolumeClaim per upstream registry. Each claim
+// is shared by all replicas of the cache, so that images are only pulled once.
+func VolumeClaimCreators(seed *kubermaticv1.Seed) []reconciling.NamedPersistentVolumeClaimCreatorGetter {
+	cache := seed.Spec.RegistryCache
+
+	var creators []reconciling.NamedPersistentVolumeClaimCreatorGetter
+	for _, upstream := range cache.UpstreamRegistries() {
+		name := VolumeClaimName(upstream.Upstream)
+
+		creators = append(creators, func() (string, reconciling.PersistentVolumeClaimCreator) {
+			return name, func(pvc *corev1.PersistentVolumeClaim) (*corev1.PersistentVolumeClaim, error) {
+				pvc.Labels = VolumeClaimLabels()
+
+				// the access modes and storage class cannot be changed once the claim exists
+				if len(pvc.Spec.AccessModes) == 0 {
+					pvc.Spec.AccessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany}
+					pvc.Spec.StorageClassName = cache.StorageClassName
+				}
+
+				// volumes can only be expanded, never shrunk
+				current, ok := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+				if !ok || current.Cmp(*cache.StorageSize) < 0 {
+					pvc.Spec.Resources.Requests = corev1.ResourceList{
+						corev1.ResourceStorage: *cache.StorageSize,
+					}
+				}
+
+				return pvc, nil
+			}
+		})
+	}
+
+	return creators
+}
@@ -36,8 +36,10 @@ import (
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1/helper"
 	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/provider"
 	"k8c.io/kubermatic/v2/pkg/resources"
 	"k8c.io/kubermatic/v2/pkg/resources/machinecontroller"
+	"k8c.io/kubermatic/v2/pkg/resources/registry"
 	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
 
 	corev1 "k8s.io/api/core/v1"
@@ -84,6 +86,7 @@ type Reconciler struct {
 	addonVariables       map[string]interface{}
 	kubernetesAddonDir   string
 	overwriteRegistry    string
+	seedGetter           provider.SeedGetter
 	recorder             record.EventRecorder
 	KubeconfigProvider   KubeconfigProvider
 	versions             kubermatic.Versions
@@ -100,6 +103,7 @@ func Add(
 	addonCtxVariables map[string]interface{},
 	kubernetesAddonDir,
 	overwriteRegistey string,
+	seedGetter provider.SeedGetter,
 	kubeconfigProvider KubeconfigProvider,
 	versions kubermatic.Versions,
 ) error {
@@ -117,6 +121,7 @@ func Add(
 		workerName:           workerName,
 		recorder:             mgr.GetEventRecorderFor(ControllerName),
 		overwriteRegistry:    overwriteRegistey,
+		seedGetter:           seedGetter,
 		versions:             versions,
 	}
 
@@ -328,15 +333,63 @@ func (r *Reconciler) getAddonManifests(ctx context.Context, log *zap.SugaredLogg
 		return nil, fmt.Errorf("failed to create template data for addon manifests: %v", err)
 	}
 
+	// The cloud controller stores the Log Analytics workspace of Azure clusters in the
+	// cluster namespace, the monitoring agent addon needs it to ship the node logs.
+	if cluster.Spec.Cloud.Azure != nil {
+		logAnalyticsSecret := &corev1.Secret{}
+		key := types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: resources.AzureLogAnalyticsSecretName}
+		if err := r.Get(ctx, key, logAnalyticsSecret); err != nil {
+			if !kerrors.IsNotFound(err) {
+				return nil, fmt.Errorf("failed to get Log Analytics secret: %v", err)
+			}
+		} else {
+			data.Cluster.AzureLogAnalytics = addonutils.AzureLogAnalytics{
+				WorkspaceID:  string(logAnalyticsSecret.Data[resources.AzureLogAnalyticsWorkspaceID]),
+				WorkspaceKey: string(logAnalyticsSecret.Data[resources.AzureLogAnalyticsWorkspaceKey]),
+			}
+		}
+	}
+
+	seed, err := r.seedGetter()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get seed: %v", err)
+	}
+
 	manifestPath := path.Join(addonDir, addon.Spec.Name)
-	allManifests, err := addonutils.ParseFromFolder(log, r.overwriteRegistry, manifestPath, data)
+	allManifests, err := addonutils.ParseFromFolder(log, registry.GetOverwriteFunc(r.overwriteRegistry), manifestPath, data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse addon templates in %s: %v", manifestPath, err)
 	}
 
+	if seed.Spec.RegistryCache != nil {
+		if err := rewriteImages(allManifests, registry.GetCacheRewriteFunc(seed.Spec.RegistryCache)); err != nil {
+			return nil, fmt.Errorf("failed to rewrite images of addon manifests in %s: %v", manifestPath, err)
+		}
+	}
+
 	return allManifests, nil
 }
 
+// rewriteImages rewrites the images of all containers in the manifests.
+func rewriteImages(manifests []runtime.RawExtension, rewrite registry.ImageRewriteFunc) error {
+	for i := range manifests {
+		obj := &metav1unstructured.Unstructured{}
+		if _, _, err := metav1unstructured.UnstructuredJSONScheme.Decode(manifests[i].Raw, nil, obj); err != nil {
+			return fmt.Errorf("parsing unstructured failed: %v", err)
+		}
+
+		registry.RewriteUnstructuredImages(obj.Object, rewrite)
+
+		raw, err := obj.MarshalJSON()
+		if err != nil {
+			return fmt.Errorf("encoding json failed: %v", err)
+		}
+		manifests[i].Raw = raw
+	}
+
+	return nil
+}
+
 // combineManifests returns all manifests combined into a multi document yaml
 func (r *Reconciler) combineManifests(manifests []*bytes.Buffer) *bytes.Buffer {
 	parts := make([]string, len(manifests))
@@ -131,6 +131,10 @@ func (f *fakeKubeconfigProvider) GetClient(_ context.Context, c *kubermaticv1.Cl
 	return nil, errors.New("not implemented")
 }
 
+func fakeSeedGetter() (*kubermaticv1.Seed, error) {
+	return &kubermaticv1.Seed{}, nil
+}
+
 func TestController_combineManifests(t *testing.T) {
 	controller := &Reconciler{}
 
@@ -210,6 +214,7 @@ func TestController_getAddonKubeDNStManifests(t *testing.T) {
 
 	controller := &Reconciler{
 		kubernetesAddonDir: addonDir,
+		seedGetter:         fakeSeedGetter,
 		KubeconfigProvider: &fakeKubeconfigProvider{},
 	}
 	manifests, err := controller.getAddonManifests(ctx, log, addon, cluster)
@@ -265,6 +270,7 @@ func TestController_getAddonDeploymentManifests(t *testing.T) {
 	controller := &Reconciler{
 		kubernetesAddonDir: addonDir,
 		overwriteRegistry:  "bar.io",
+		seedGetter:         fakeSeedGetter,
 		KubeconfigProvider: &fakeKubeconfigProvider{},
 	}
 	manifests, err := controller.getAddonManifests(context.Background(), log, addon, cluster)
@@ -303,6 +309,7 @@ func TestController_getAddonDeploymentManifestsDefault(t *testing.T) {
 
 	controller := &Reconciler{
 		kubernetesAddonDir: addonDir,
+		seedGetter:         fakeSeedGetter,
 		KubeconfigProvider: &fakeKubeconfigProvider{},
 	}
 	manifests, err := controller.getAddonManifests(context.Background(), log, addon, cluster)
@@ -320,6 +327,58 @@ func TestController_getAddonDeploymentManifestsDefault(t *testing.T) {
 	}
 }
 
+func TestController_getAddonDeploymentManifestsRegistryCache(t *testing.T) {
+	cluster := setupTestCluster("10.240.16.0/20")
+	addon := setupTestAddon("test")
+
+	addonDir, err := ioutil.TempDir("/tmp", "kubermatic-tests-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(addonDir)
+
+	if err := os.Mkdir(path.Join(addonDir, addon.Spec.Name), 0777); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path.Join(addonDir, addon.Spec.Name, "testManifest.yaml"), []byte(testManifest1WithDeployment), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	log := kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar()
+
+	controller := &Reconciler{
+		kubernetesAddonDir: addonDir,
+		seedGetter: func() (*kubermaticv1.Seed, error) {
+			return &kubermaticv1.Seed{
+				Spec: kubermaticv1.SeedSpec{
+					RegistryCache: &kubermaticv1.RegistryCacheConfig{
+						Endpoint:      "cache.example.com",
+						TLSSecretName: "registry-cache-tls",
+						Registries: []kubermaticv1.RegistryCacheRegistry{
+							{Upstream: "quay.io", Port: 5000},
+							{Upstream: "foo.io", Port: 5001},
+						},
+					},
+				},
+			}, nil
+		},
+		KubeconfigProvider: &fakeKubeconfigProvider{},
+	}
+	manifests, err := controller.getAddonManifests(context.Background(), log, addon, cluster)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(manifests) != 1 {
+		t.Fatalf("invalid number of manifests returned. Expected 1, Got %d", len(manifests))
+	}
+
+	expectedRegURL := "cache.example.com:5001/test:1.2.3"
+	if !strings.Contains(string(manifests[0].Raw), expectedRegURL) {
+		t.Fatalf("invalid registryURI returned. Expected \n%s, Got \n%s", expectedRegURL, manifests[0].String())
+	}
+}
+
 func TestController_getAddonManifests(t *testing.T) {
 	cluster := setupTestCluster("10.240.16.0/20")
 	addon := setupTestAddon("test")
@@ -347,6 +406,7 @@ func TestController_getAddonManifests(t *testing.T) {
 
 	controller := &Reconciler{
 		kubernetesAddonDir: addonDir,
+		seedGetter:         fakeSeedGetter,
 		KubeconfigProvider: &fakeKubeconfigProvider{},
 	}
 	manifests, err := controller.getAddonManifests(context.Background(), log, addon, cluster)
@@ -416,6 +476,7 @@ func TestHugeManifest(t *testing.T) {
 	addon := setupTestAddon("istio")
 	r := &Reconciler{
 		kubernetesAddonDir: "./testdata",
+		seedGetter:         fakeSeedGetter,
 		KubeconfigProvider: &fakeKubeconfigProvider{},
 	}
 	if _, _, _, err := r.setupManifestInteraction(context.Background(), log, addon, cluster); err != nil {
@@ -0,0 +1,220 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apideprecation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	clusterclient "k8c.io/kubermatic/v2/pkg/cluster/client"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1/helper"
+	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/discovery"
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	ControllerName = "kubermatic_api_deprecation_controller"
+
+	// DefaultScanInterval is the default interval in which clusters are scanned.
+	DefaultScanInterval = 6 * time.Hour
+)
+
+// UserClusterClientProvider provides functionality to get a user cluster client config
+type UserClusterClientProvider interface {
+	GetClientConfig(ctx context.Context, c *kubermaticv1.Cluster, options ...clusterclient.ConfigOption) (*restclient.Config, error)
+}
+
+// apiUsage contains the resources served by a cluster and the deprecated resources
+// requested from it.
+type apiUsage struct {
+	served    sets.String
+	requested sets.String
+}
+
+type Reconciler struct {
+	ctrlruntimeclient.Client
+
+	workerName                    string
+	recorder                      record.EventRecorder
+	userClusterConnectionProvider UserClusterClientProvider
+	log                           *zap.SugaredLogger
+	versions                      kubermatic.Versions
+	scanInterval                  time.Duration
+
+	// getAPIUsage is overridden in tests
+	getAPIUsage func(ctx context.Context, cluster *kubermaticv1.Cluster) (*apiUsage, error)
+	now         func() time.Time
+}
+
+// Add creates a new API deprecation controller
+func Add(mgr manager.Manager, numWorkers int, workerName string, userClusterConnectionProvider UserClusterClientProvider, log *zap.SugaredLogger, versions kubermatic.Versions, scanInterval time.Duration) error {
+	reconciler := &Reconciler{
+		Client: mgr.GetClient(),
+
+		workerName:                    workerName,
+		recorder:                      mgr.GetEventRecorderFor(ControllerName),
+		userClusterConnectionProvider: userClusterConnectionProvider,
+		log:                           log.Named(ControllerName),
+		versions:                      versions,
+		scanInterval:                  scanInterval,
+		now:                           time.Now,
+	}
+	reconciler.getAPIUsage = reconciler.scanCluster
+
+	c, err := controller.New(ControllerName, mgr, controller.Options{
+		Reconciler:              reconciler,
+		MaxConcurrentReconciles: numWorkers,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create controller: %v", err)
+	}
+
+	if err := c.Watch(&source.Kind{Type: &kubermaticv1.Cluster{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("failed to create watch: %v", err)
+	}
+
+	return nil
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	cluster := &kubermaticv1.Cluster{}
+	if err := r.Get(ctx, request.NamespacedName, cluster); err != nil {
+		if kerrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	// The scan is periodic, so there is no condition which could ever become true.
+	result, err := kubermaticv1helper.ClusterReconcileWrapper(
+		ctx,
+		r.Client,
+		r.workerName,
+		cluster,
+		r.versions,
+		kubermaticv1.ClusterConditionNone,
+		func() (*reconcile.Result, error) {
+			return r.reconcile(ctx, cluster)
+		},
+	)
+	if err != nil {
+		r.log.Errorw("Failed to reconcile cluster", "cluster", cluster.Name, zap.Error(err))
+		r.recorder.Event(cluster, corev1.EventTypeWarning, "ReconcilingError", err.Error())
+	}
+	if result == nil {
+		result = &reconcile.Result{}
+	}
+	return *result, err
+}
+
+func (r *Reconciler) reconcile(ctx context.Context, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
+	if cluster.DeletionTimestamp != nil {
+		return nil, nil
+	}
+
+	// We get notified once the API server becomes healthy, no need to requeue.
+	if cluster.Status.ExtendedHealth.Apiserver != kubermaticv1.HealthStatusUp {
+		return nil, nil
+	}
+
+	version := cluster.Spec.Version.Semver()
+
+	// Clusters are rescanned after an upgrade, as the target version changes.
+	if report := cluster.Status.APIDeprecations; report != nil && report.KubernetesVersion == version.String() {
+		if next := report.ScanTime.Add(r.scanInterval); next.After(r.now()) {
+			return &reconcile.Result{RequeueAfter: next.Sub(r.now())}, nil
+		}
+	}
+
+	usage, err := r.getAPIUsage(ctx, cluster)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan cluster: %v", err)
+	}
+
+	oldCluster := cluster.DeepCopy()
+	cluster.Status.APIDeprecations = buildReport(version, usage.served, usage.requested, r.now())
+	if err := r.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+		return nil, fmt.Errorf("failed to update API deprecation report: %v", err)
+	}
+
+	return &reconcile.Result{RequeueAfter: r.scanInterval}, nil
+}
+
+// scanCluster determines the served resources via discovery and the requested deprecated
+// resources via the metrics of the cluster's API server.
+func (r *Reconciler) scanCluster(ctx context.Context, cluster *kubermaticv1.Cluster) (*apiUsage, error) {
+	config, err := r.userClusterConnectionProvider.GetClientConfig(ctx, cluster)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user cluster client config: %v", err)
+	}
+
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create discovery client: %v", err)
+	}
+
+	// Unavailable aggregated APIs must not prevent the scan.
+	_, resourceLists, err := discoveryClient.ServerGroupsAndResources()
+	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
+		return nil, fmt.Errorf("failed to discover API resources: %v", err)
+	}
+
+	usage := &apiUsage{served: sets.NewString()}
+	for _, resourceList := range resourceLists {
+		gv, err := schema.ParseGroupVersion(resourceList.GroupVersion)
+		if err != nil {
+			continue
+		}
+		for _, resource := range resourceList.APIResources {
+			// skip subresources
+			if strings.Contains(resource.Name, "/") {
+				continue
+			}
+			usage.served.Insert(gv.WithResource(resource.Name).String())
+		}
+	}
+
+	metrics, err := discoveryClient.RESTClient().Get().AbsPath("/metrics").DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API server metrics: %v", err)
+	}
+
+	usage.requested, err = parseRequestedDeprecatedAPIs(metrics)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse API server metrics: %v", err)
+	}
+
+	return usage, nil
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apideprecation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/semver"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReconcile(t *testing.T) {
+	now := time.Date(2021, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name            string
+		health          kubermaticv1.HealthStatus
+		report          *kubermaticv1.APIDeprecationReport
+		expectScan      bool
+		expectedRequeue time.Duration
+	}{
+		{
+			name:       "cluster without report gets scanned",
+			health:     kubermaticv1.HealthStatusUp,
+			expectScan: true,
+		},
+		{
+			name:   "cluster with unhealthy API server is skipped",
+			health: kubermaticv1.HealthStatusDown,
+		},
+		{
+			name:   "recent report is kept",
+			health: kubermaticv1.HealthStatusUp,
+			report: &kubermaticv1.APIDeprecationReport{
+				ScanTime:          metav1.NewTime(now.Add(-time.Hour)),
+				KubernetesVersion: "1.21.3",
+			},
+			expectedRequeue: time.Hour,
+		},
+		{
+			name:   "outdated report gets replaced",
+			health: kubermaticv1.HealthStatusUp,
+			report: &kubermaticv1.APIDeprecationReport{
+				ScanTime:          metav1.NewTime(now.Add(-3 * time.Hour)),
+				KubernetesVersion: "1.21.3",
+			},
+			expectScan: true,
+		},
+		{
+			name:   "report of a previous version gets replaced",
+			health: kubermaticv1.HealthStatusUp,
+			report: &kubermaticv1.APIDeprecationReport{
+				ScanTime:          metav1.NewTime(now.Add(-time.Hour)),
+				KubernetesVersion: "1.20.9",
+			},
+			expectScan: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := &kubermaticv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: kubermaticv1.ClusterSpec{
+					Version: *semver.NewSemverOrDie("1.21.3"),
+				},
+				Status: kubermaticv1.ClusterStatus{
+					ExtendedHealth:  kubermaticv1.ExtendedClusterHealth{Apiserver: tc.health},
+					APIDeprecations: tc.report,
+				},
+			}
+
+			scanned := false
+			r := &Reconciler{
+				Client: fakectrlruntimeclient.
+					NewClientBuilder().
+					WithScheme(scheme.Scheme).
+					WithObjects(cluster).
+					Build(),
+				recorder:     &record.FakeRecorder{},
+				log:          zap.NewNop().Sugar(),
+				scanInterval: 2 * time.Hour,
+				now:          func() time.Time { return now },
+				getAPIUsage: func(_ context.Context, _ *kubermaticv1.Cluster) (*apiUsage, error) {
+					scanned = true
+					return &apiUsage{
+						served:    sets.NewString(gvr("extensions", "v1beta1", "ingresses")),
+						requested: sets.NewString(),
+					}, nil
+				},
+			}
+
+			ctx := context.Background()
+			result, err := r.reconcile(ctx, cluster)
+			if err != nil {
+				t.Fatalf("reconciling failed: %v", err)
+			}
+
+			if scanned != tc.expectScan {
+				t.Fatalf("expected scan to be %v, got %v", tc.expectScan, scanned)
+			}
+
+			if !tc.expectScan {
+				if tc.expectedRequeue == 0 && result != nil {
+					t.Errorf("expected no requeue, got %v", result)
+				}
+				if tc.expectedRequeue != 0 && (result == nil || result.RequeueAfter != tc.expectedRequeue) {
+					t.Errorf("expected requeue after %v, got %v", tc.expectedRequeue, result)
+				}
+				return
+			}
+
+			if result == nil || result.RequeueAfter != r.scanInterval {
+				t.Errorf("expected requeue after the scan interval, got %v", result)
+			}
+
+			updated := &kubermaticv1.Cluster{}
+			if err := r.Get(ctx, types.NamespacedName{Name: cluster.Name}, updated); err != nil {
+				t.Fatalf("failed to get cluster: %v", err)
+			}
+			report := updated.Status.APIDeprecations
+			if report == nil || report.KubernetesVersion != "1.21.3" || len(report.RemovedAPIs) != 1 {
+				t.Errorf("expected a report with one removed API, got %+v", report)
+			}
+		})
+	}
+}
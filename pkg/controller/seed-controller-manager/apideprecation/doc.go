@@ -0,0 +1,26 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package apideprecation contains a controller that periodically scans user
+clusters for APIs which are removed in the next Kubernetes minor version.
+
+Served APIs are determined via discovery, actual usage via the
+apiserver_requested_deprecated_apis metric of the cluster's API server.
+The result is stored in the cluster status and shown by the upgrade
+pre-flight endpoint of the REST API.
+*/
+package apideprecation
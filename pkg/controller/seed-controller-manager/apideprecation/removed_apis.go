@@ -0,0 +1,163 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apideprecation
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	semverlib "github.com/Masterminds/semver/v3"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// requestedDeprecatedAPIsMetric is exposed by API servers since Kubernetes 1.19.
+const requestedDeprecatedAPIsMetric = "apiserver_requested_deprecated_apis"
+
+type removedAPI struct {
+	schema.GroupVersionResource
+	removedIn   *semverlib.Version
+	replacement string
+}
+
+func removal(group, version, resource, removedIn, replacement string) removedAPI {
+	return removedAPI{
+		GroupVersionResource: schema.GroupVersionResource{Group: group, Version: version, Resource: resource},
+		removedIn:            semverlib.MustParse(removedIn),
+		replacement:          replacement,
+	}
+}
+
+// removedAPIs are the APIs removed in the Kubernetes versions supported by Kubermatic.
+var removedAPIs = []removedAPI{
+	removal("extensions", "v1beta1", "ingresses", "1.22", "networking.k8s.io/v1"),
+	removal("networking.k8s.io", "v1beta1", "ingresses", "1.22", "networking.k8s.io/v1"),
+	removal("networking.k8s.io", "v1beta1", "ingressclasses", "1.22", "networking.k8s.io/v1"),
+	removal("admissionregistration.k8s.io", "v1beta1", "mutatingwebhookconfigurations", "1.22", "admissionregistration.k8s.io/v1"),
+	removal("admissionregistration.k8s.io", "v1beta1", "validatingwebhookconfigurations", "1.22", "admissionregistration.k8s.io/v1"),
+	removal("apiextensions.k8s.io", "v1beta1", "customresourcedefinitions", "1.22", "apiextensions.k8s.io/v1"),
+	removal("apiregistration.k8s.io", "v1beta1", "apiservices", "1.22", "apiregistration.k8s.io/v1"),
+	removal("authentication.k8s.io", "v1beta1", "tokenreviews", "1.22", "authentication.k8s.io/v1"),
+	removal("authorization.k8s.io", "v1beta1", "localsubjectaccessreviews", "1.22", "authorization.k8s.io/v1"),
+	removal("authorization.k8s.io", "v1beta1", "selfsubjectaccessreviews", "1.22", "authorization.k8s.io/v1"),
+	removal("authorization.k8s.io", "v1beta1", "selfsubjectrulesreviews", "1.22", "authorization.k8s.io/v1"),
+	removal("authorization.k8s.io", "v1beta1", "subjectaccessreviews", "1.22", "authorization.k8s.io/v1"),
+	removal("certificates.k8s.io", "v1beta1", "certificatesigningrequests", "1.22", "certificates.k8s.io/v1"),
+	removal("coordination.k8s.io", "v1beta1", "leases", "1.22", "coordination.k8s.io/v1"),
+	removal("rbac.authorization.k8s.io", "v1beta1", "clusterroles", "1.22", "rbac.authorization.k8s.io/v1"),
+	removal("rbac.authorization.k8s.io", "v1beta1", "clusterrolebindings", "1.22", "rbac.authorization.k8s.io/v1"),
+	removal("rbac.authorization.k8s.io", "v1beta1", "roles", "1.22", "rbac.authorization.k8s.io/v1"),
+	removal("rbac.authorization.k8s.io", "v1beta1", "rolebindings", "1.22", "rbac.authorization.k8s.io/v1"),
+	removal("scheduling.k8s.io", "v1beta1", "priorityclasses", "1.22", "scheduling.k8s.io/v1"),
+	removal("storage.k8s.io", "v1beta1", "csidrivers", "1.22", "storage.k8s.io/v1"),
+	removal("storage.k8s.io", "v1beta1", "csinodes", "1.22", "storage.k8s.io/v1"),
+	removal("storage.k8s.io", "v1beta1", "storageclasses", "1.22", "storage.k8s.io/v1"),
+	removal("storage.k8s.io", "v1beta1", "volumeattachments", "1.22", "storage.k8s.io/v1"),
+	removal("batch", "v1beta1", "cronjobs", "1.25", "batch/v1"),
+	removal("discovery.k8s.io", "v1beta1", "endpointslices", "1.25", "discovery.k8s.io/v1"),
+	removal("events.k8s.io", "v1beta1", "events", "1.25", "events.k8s.io/v1"),
+	removal("autoscaling", "v2beta1", "horizontalpodautoscalers", "1.25", "autoscaling/v2"),
+	removal("policy", "v1beta1", "poddisruptionbudgets", "1.25", "policy/v1"),
+	removal("policy", "v1beta1", "podsecuritypolicies", "1.25", ""),
+	removal("node.k8s.io", "v1beta1", "runtimeclasses", "1.25", "node.k8s.io/v1"),
+	removal("autoscaling", "v2beta2", "horizontalpodautoscalers", "1.26", "autoscaling/v2"),
+	removal("flowcontrol.apiserver.k8s.io", "v1beta1", "flowschemas", "1.26", "flowcontrol.apiserver.k8s.io/v1beta3"),
+	removal("flowcontrol.apiserver.k8s.io", "v1beta1", "prioritylevelconfigurations", "1.26", "flowcontrol.apiserver.k8s.io/v1beta3"),
+}
+
+// targetVersion returns the next minor version after the given one.
+func targetVersion(version *semverlib.Version) *semverlib.Version {
+	return semverlib.MustParse(fmt.Sprintf("%d.%d", version.Major(), version.Minor()+1))
+}
+
+// buildReport returns the report for a cluster of the given version, which serves the given
+// resources and received requests for the given deprecated resources.
+func buildReport(version *semverlib.Version, served, requested sets.String, now time.Time) *kubermaticv1.APIDeprecationReport {
+	target := targetVersion(version)
+
+	report := &kubermaticv1.APIDeprecationReport{
+		ScanTime:          metav1.NewTime(now),
+		KubernetesVersion: version.String(),
+		TargetVersion:     fmt.Sprintf("%d.%d", target.Major(), target.Minor()),
+	}
+
+	for _, api := range removedAPIs {
+		if api.removedIn.GreaterThan(target) || !served.Has(api.String()) {
+			continue
+		}
+
+		report.RemovedAPIs = append(report.RemovedAPIs, kubermaticv1.RemovedAPI{
+			Group:       api.Group,
+			Version:     api.Version,
+			Resource:    api.Resource,
+			RemovedIn:   fmt.Sprintf("%d.%d", api.removedIn.Major(), api.removedIn.Minor()),
+			Replacement: api.replacement,
+			Requested:   requested.Has(api.String()),
+		})
+	}
+
+	// requested APIs first, as these most likely break after the upgrade
+	sort.SliceStable(report.RemovedAPIs, func(i, j int) bool {
+		return report.RemovedAPIs[i].Requested && !report.RemovedAPIs[j].Requested
+	})
+
+	return report
+}
+
+var metricLabelRegexp = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// parseRequestedDeprecatedAPIs returns the resources from the apiserver_requested_deprecated_apis
+// metric in the given Prometheus text exposition.
+func parseRequestedDeprecatedAPIs(metrics []byte) (sets.String, error) {
+	requested := sets.NewString()
+
+	scanner := bufio.NewScanner(bytes.NewReader(metrics))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, requestedDeprecatedAPIsMetric+"{") {
+			continue
+		}
+
+		end := strings.LastIndex(line, "}")
+		if end == -1 {
+			continue
+		}
+		if value := strings.TrimSpace(line[end+1:]); value == "0" {
+			continue
+		}
+
+		labels := map[string]string{}
+		for _, match := range metricLabelRegexp.FindAllStringSubmatch(line[:end], -1) {
+			labels[match[1]] = match[2]
+		}
+
+		gvr := schema.GroupVersionResource{Group: labels["group"], Version: labels["version"], Resource: labels["resource"]}
+		requested.Insert(gvr.String())
+	}
+
+	return requested, scanner.Err()
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apideprecation
+
+import (
+	"testing"
+	"time"
+
+	semverlib "github.com/Masterminds/semver/v3"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func gvr(group, version, resource string) string {
+	return schema.GroupVersionResource{Group: group, Version: version, Resource: resource}.String()
+}
+
+func TestBuildReport(t *testing.T) {
+	served := sets.NewString(
+		gvr("extensions", "v1beta1", "ingresses"),
+		gvr("networking.k8s.io", "v1beta1", "ingresses"),
+		gvr("batch", "v1beta1", "cronjobs"),
+		gvr("apps", "v1", "deployments"),
+	)
+	requested := sets.NewString(gvr("networking.k8s.io", "v1beta1", "ingresses"))
+
+	testCases := []struct {
+		name              string
+		version           string
+		expectedTarget    string
+		expectedResources []string
+	}{
+		{
+			name:              "APIs removed in the next minor version are reported",
+			version:           "1.21.3",
+			expectedTarget:    "1.22",
+			expectedResources: []string{"ingresses", "ingresses"},
+		},
+		{
+			name:           "APIs removed in later versions are not reported",
+			version:        "1.20.9",
+			expectedTarget: "1.21",
+		},
+		{
+			name:              "APIs removed in earlier versions are reported if still served",
+			version:           "1.24.0",
+			expectedTarget:    "1.25",
+			expectedResources: []string{"ingresses", "ingresses", "cronjobs"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			report := buildReport(semverlib.MustParse(tc.version), served, requested, time.Now())
+
+			if report.TargetVersion != tc.expectedTarget {
+				t.Errorf("expected target version %q, got %q", tc.expectedTarget, report.TargetVersion)
+			}
+
+			var resources []string
+			for _, api := range report.RemovedAPIs {
+				resources = append(resources, api.Resource)
+			}
+			if len(resources) != len(tc.expectedResources) {
+				t.Fatalf("expected removed resources %v, got %v", tc.expectedResources, resources)
+			}
+			for i := range resources {
+				if resources[i] != tc.expectedResources[i] {
+					t.Fatalf("expected removed resources %v, got %v", tc.expectedResources, resources)
+				}
+			}
+
+			if len(report.RemovedAPIs) > 0 {
+				first := report.RemovedAPIs[0]
+				if first.Group != "networking.k8s.io" || !first.Requested {
+					t.Errorf("expected the requested API to be listed first, got %+v", first)
+				}
+			}
+		})
+	}
+}
+
+func TestParseRequestedDeprecatedAPIs(t *testing.T) {
+	metrics := []byte(`# HELP apiserver_requested_deprecated_apis [ALPHA] Gauge of deprecated APIs that have been requested, broken out by API group, version, resource, subresource, and removed_release.
+# TYPE apiserver_requested_deprecated_apis gauge
+apiserver_requested_deprecated_apis{group="extensions",removed_release="1.22",resource="ingresses",subresource="",version="v1beta1"} 1
+apiserver_requested_deprecated_apis{group="batch",removed_release="1.25",resource="cronjobs",subresource="",version="v1beta1"} 0
+apiserver_request_total{code="200",resource="ingresses",verb="LIST"} 12
+`)
+
+	requested, err := parseRequestedDeprecatedAPIs(metrics)
+	if err != nil {
+		t.Fatalf("failed to parse metrics: %v", err)
+	}
+
+	expected := sets.NewString(gvr("extensions", "v1beta1", "ingresses"))
+	if !requested.Equal(expected) {
+		t.Errorf("expected requested APIs %v, got %v", expected.List(), requested.List())
+	}
+}
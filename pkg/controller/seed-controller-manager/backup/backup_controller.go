@@ -271,9 +271,9 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, clus
 				}
 			}
 
-			oldCluster := cluster.DeepCopy()
-			kuberneteshelper.RemoveFinalizer(cluster, cleanupFinalizer)
-			if err := r.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+			if err := kubermaticv1helper.UpdateCluster(ctx, r, cluster, func(c *kubermaticv1.Cluster) {
+				kuberneteshelper.RemoveFinalizer(c, cleanupFinalizer)
+			}); err != nil {
 				return fmt.Errorf("failed to update cluster after removing cleanup finalizer: %v", err)
 			}
 		}
@@ -287,9 +287,9 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, clus
 
 	// Always add the finalizer first
 	if !kuberneteshelper.HasFinalizer(cluster, cleanupFinalizer) && !r.disabled {
-		oldCluster := cluster.DeepCopy()
-		kuberneteshelper.AddFinalizer(cluster, cleanupFinalizer)
-		if err := r.Patch(ctx, cluster, ctrlruntimeclient.MergeFromWithOptions(oldCluster, ctrlruntimeclient.MergeFromWithOptimisticLock{})); err != nil {
+		if err := kubermaticv1helper.UpdateCluster(ctx, r, cluster, func(c *kubermaticv1.Cluster) {
+			kuberneteshelper.AddFinalizer(c, cleanupFinalizer)
+		}); err != nil {
 			return fmt.Errorf("failed to update cluster after adding cleanup finalizer: %w", err)
 		}
 	}
@@ -19,14 +19,16 @@ package cloud
 import (
 	"context"
 	"crypto/x509"
+	"errors"
 	"fmt"
-	"reflect"
+	"sync"
 	"time"
 
 	"go.uber.org/zap"
 
 	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
 	kubermaticapiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	controllerutil "k8c.io/kubermatic/v2/pkg/controller/util"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1/helper"
 	"k8c.io/kubermatic/v2/pkg/provider"
@@ -34,16 +36,17 @@ import (
 	"k8c.io/kubermatic/v2/pkg/provider/cloud/aws"
 	"k8c.io/kubermatic/v2/pkg/provider/cloud/azure"
 	"k8c.io/kubermatic/v2/pkg/provider/cloud/openstack"
+	"k8c.io/kubermatic/v2/pkg/resources"
 	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller"
-	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
@@ -60,6 +63,21 @@ const (
 	// currentMigrationRevision describes the current migration revision. If this is set on the
 	// cluster, certain migrations won't get executed. This must never be decremented.
 	CurrentMigrationRevision = awsHarcodedAZMigrationRevision
+	// azureApplicationSecurityGroupResyncPeriod is the interval in which Azure clusters with an
+	// application security group are reconciled, to add the NICs of new nodes to the group.
+	azureApplicationSecurityGroupResyncPeriod = 2 * time.Minute
+	// azureResyncPeriod is the interval in which all Azure clusters are reconciled, to restore
+	// the associations of their subnet which were changed outside of Kubermatic.
+	azureResyncPeriod = 10 * time.Minute
+	// azureConflictRetryPeriod is the delay after which a request that conflicted with another
+	// operation on an Azure resource is retried.
+	azureConflictRetryPeriod = 15 * time.Second
+	// azureOperationPollPeriod is the interval in which long-running Azure operations are polled
+	// until they finished.
+	azureOperationPollPeriod = 10 * time.Second
+	// azureTerminalErrorRetryPeriod is the interval in which clusters are retried after Azure
+	// rejected a request because of invalid credentials or exceeded quotas.
+	azureTerminalErrorRetryPeriod = 10 * time.Minute
 )
 
 // Check if the Reconciler fulfills the interface
@@ -75,6 +93,19 @@ type Reconciler struct {
 	workerName string
 	versions   kubermatic.Versions
 	caBundle   *x509.CertPool
+
+	azureInstallationID string
+	azureAPI            azureAPISettings
+}
+
+// azureAPISettings holds the settings of the seed which were last applied to the Azure clients
+// of the process. They are shared by all workers and only applied again if the seed changed.
+type azureAPISettings struct {
+	lock              sync.Mutex
+	rateLimitsApplied bool
+	rateLimits        *kubermaticv1.AzureRateLimitSettings
+	proxyApplied      bool
+	proxy             *kubermaticv1.AzureProxySettings
 }
 
 func Add(
@@ -85,22 +116,24 @@ func Add(
 	workerName string,
 	versions kubermatic.Versions,
 	caBundle *x509.CertPool,
+	azureInstallationID string,
 ) error {
 	reconciler := &Reconciler{
-		Client:     mgr.GetClient(),
-		log:        log.Named(ControllerName),
-		recorder:   mgr.GetEventRecorderFor(ControllerName),
-		seedGetter: seedGetter,
-		workerName: workerName,
-		versions:   versions,
-		caBundle:   caBundle,
+		Client:              mgr.GetClient(),
+		log:                 log.Named(ControllerName),
+		recorder:            mgr.GetEventRecorderFor(ControllerName),
+		seedGetter:          seedGetter,
+		workerName:          workerName,
+		versions:            versions,
+		caBundle:            caBundle,
+		azureInstallationID: azureInstallationID,
 	}
 
-	c, err := controller.New(ControllerName, mgr, controller.Options{Reconciler: reconciler, MaxConcurrentReconciles: numWorkers})
+	c, err := controllerutil.NewPrioritizedController(ControllerName, mgr, reconciler.log, reconciler, numWorkers)
 	if err != nil {
 		return err
 	}
-	return c.Watch(&source.Kind{Type: &kubermaticv1.Cluster{}}, &handler.EnqueueRequestForObject{})
+	return c.Watch(&source.Kind{Type: &kubermaticv1.Cluster{}}, c.EnqueueRequestForObject())
 }
 
 func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
@@ -138,6 +171,29 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 	return *result, err
 }
 
+// configureAzureAPI applies the rate limits and the proxy of the seed to the Azure clients if they
+// changed since they were last applied.
+func (r *Reconciler) configureAzureAPI(seed *kubermaticv1.Seed) error {
+	r.azureAPI.lock.Lock()
+	defer r.azureAPI.lock.Unlock()
+
+	if !r.azureAPI.rateLimitsApplied || !equality.Semantic.DeepEqual(r.azureAPI.rateLimits, seed.Spec.AzureRateLimits) {
+		azure.SetRateLimits(seed.Spec.AzureRateLimits)
+		r.azureAPI.rateLimits = seed.Spec.AzureRateLimits.DeepCopy()
+		r.azureAPI.rateLimitsApplied = true
+	}
+
+	if !r.azureAPI.proxyApplied || !equality.Semantic.DeepEqual(r.azureAPI.proxy, seed.Spec.AzureProxy) {
+		if err := azure.SetProxy(seed.Spec.AzureProxy, r.getGlobalSecretKeySelectorValue); err != nil {
+			return fmt.Errorf("failed to configure the proxy for the Azure API: %v", err)
+		}
+		r.azureAPI.proxy = seed.Spec.AzureProxy.DeepCopy()
+		r.azureAPI.proxyApplied = true
+	}
+
+	return nil
+}
+
 func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
 	seed, err := r.seedGetter()
 	if err != nil {
@@ -151,17 +207,40 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, clus
 	if err != nil {
 		return nil, fmt.Errorf("failed to create cloud provider: %v", err)
 	}
+	if azureProvider, ok := prov.(*azure.Azure); ok {
+		azureProvider.SetEventRecorder(r.recorder)
+		azureProvider.SetInstallationID(r.azureInstallationID)
+		if err := r.configureAzureAPI(seed); err != nil {
+			return nil, err
+		}
+	}
 
 	if cluster.DeletionTimestamp != nil {
 		log.Debug("Cleaning up cloud provider")
+		// The resource group lock would block the deletion of the nodes and load balancers,
+		// which has to finish before the cloud provider resources are cleaned up.
+		if azureProvider, ok := prov.(*azure.Azure); ok {
+			unlockedCluster, err := azureProvider.UnlockResourceGroup(cluster, r.updateCluster)
+			if err != nil {
+				if result := r.handleAzureError(log, cluster, err); result != nil {
+					return result, nil
+				}
+				return nil, fmt.Errorf("failed to unlock resource group: %w", err)
+			}
+			cluster = unlockedCluster
+		}
 		finalizers := sets.NewString(cluster.Finalizers...)
 		if finalizers.Has(kubermaticapiv1.InClusterLBCleanupFinalizer) ||
 			finalizers.Has(kubermaticapiv1.InClusterPVCleanupFinalizer) ||
-			finalizers.Has(kubermaticapiv1.NodeDeletionFinalizer) {
+			finalizers.Has(kubermaticapiv1.NodeDeletionFinalizer) ||
+			finalizers.Has(kubermaticapiv1.PreDeleteHooksFinalizer) {
 			return &reconcile.Result{RequeueAfter: 5 * time.Second}, nil
 		}
 		if _, err := prov.CleanUpCloudProvider(cluster, r.updateCluster); err != nil {
-			return nil, fmt.Errorf("failed cloud provider cleanup: %v", err)
+			if result := r.handleAzureError(log, cluster, err); result != nil {
+				return result, nil
+			}
+			return nil, fmt.Errorf("failed cloud provider cleanup: %w", err)
 		}
 		return nil, nil
 
@@ -182,14 +261,40 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, clus
 		}
 	}
 
-	if _, err := prov.InitializeCloudProvider(cluster, r.updateCluster); err != nil {
+	// the health is probed before the resources are reconciled, so that it is also reported
+	// if reconciling them fails because of the same problem
+	if azureProvider, ok := prov.(*azure.Azure); ok {
+		probedCluster, err := azureProvider.ReconcileHealth(cluster, r.updateCluster)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update cloud provider health of cluster: %w", err)
+		}
+		cluster = probedCluster
+	}
+
+	initializedCluster, err := prov.InitializeCloudProvider(cluster, r.updateCluster)
+	if err != nil {
 		if kerrors.IsConflict(err) {
 			// In case of conflict we just re-enqueue the item for later
 			// processing without returning an error.
 			r.log.Infow("failed to add finalizer to cluster", "error", err)
 			return &reconcile.Result{Requeue: true}, nil
 		}
-		return nil, fmt.Errorf("failed cloud provider init: %v", err)
+		if result := r.handleAzureError(log, cluster, err); result != nil {
+			return result, nil
+		}
+		return nil, fmt.Errorf("failed cloud provider init: %w", err)
+	}
+
+	if azureProvider, ok := prov.(*azure.Azure); ok && initializedCluster.Spec.Cloud.Azure.ClusterServicePrincipal {
+		if err := r.reconcileAzureClusterServicePrincipal(ctx, log, initializedCluster, azureProvider); err != nil {
+			return nil, fmt.Errorf("failed to reconcile service principal of cluster: %v", err)
+		}
+	}
+
+	if azureProvider, ok := prov.(*azure.Azure); ok {
+		if err := r.reconcileAzureLogAnalyticsSecret(ctx, initializedCluster, azureProvider); err != nil {
+			return nil, fmt.Errorf("failed to reconcile Log Analytics workspace secret of cluster: %v", err)
+		}
 	}
 
 	if _, err := r.updateCluster(cluster.Name, func(c *kubermaticv1.Cluster) {
@@ -198,9 +303,161 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, clus
 		return nil, fmt.Errorf("failed to set cluster health: %v", err)
 	}
 
+	if cluster.Spec.Cloud.Azure != nil && cluster.Spec.Cloud.Azure.ApplicationSecurityGroup != "" {
+		return &reconcile.Result{RequeueAfter: azureApplicationSecurityGroupResyncPeriod}, nil
+	}
+	if cluster.Spec.Cloud.Azure != nil {
+		return &reconcile.Result{RequeueAfter: azureResyncPeriod}, nil
+	}
+
 	return nil, nil
 }
 
+// handleAzureError decides how to continue after the Azure provider returned an error. Running
+// operations are polled again, throttled and conflicting requests are retried after a delay, errors which require the user to fix the
+// credentials or quotas are reported and retried in a longer interval. For all other errors nil
+// is returned and the error should be returned to be retried with the default backoff.
+func (r *Reconciler) handleAzureError(log *zap.SugaredLogger, cluster *kubermaticv1.Cluster, err error) *reconcile.Result {
+	if cluster.Spec.Cloud.Azure == nil {
+		return nil
+	}
+
+	err = azure.ClassifyError(err)
+	if errors.Is(err, azure.ErrOperationInProgress) {
+		log.Debugw("Azure operation is still running, polling it later", "error", err)
+		return &reconcile.Result{RequeueAfter: azureOperationPollPeriod}
+	}
+
+	if retryAfter, throttled := azure.RetryAfter(err); throttled {
+		log.Infow("Azure requests are throttled, retrying later", "retryAfter", retryAfter)
+		return &reconcile.Result{RequeueAfter: retryAfter}
+	}
+
+	if errors.Is(err, azure.ErrConflict) {
+		log.Infow("Azure resource is in a conflicting state, retrying later", "error", err)
+		return &reconcile.Result{RequeueAfter: azureConflictRetryPeriod}
+	}
+
+	if azure.IsTerminalError(err) {
+		r.recorder.Event(cluster, corev1.EventTypeWarning, "CloudProviderError", err.Error())
+		log.Errorw("Azure rejected the request, the credentials or quotas of the subscription need to be fixed", zap.Error(err))
+		return &reconcile.Result{RequeueAfter: azureTerminalErrorRetryPeriod}
+	}
+
+	return nil
+}
+
+// reconcileAzureClusterServicePrincipal creates the service principal of an Azure cluster and stores
+// its credentials in the cluster namespace. If the secret is lost, the service principal is replaced,
+// as its client secret cannot be retrieved again.
+func (r *Reconciler) reconcileAzureClusterServicePrincipal(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster, prov *azure.Azure) error {
+	if cluster.Status.NamespaceName == "" {
+		return errors.New("the cluster namespace does not exist yet")
+	}
+
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: resources.AzureClusterServicePrincipalSecretName}
+	if err := r.Get(ctx, key, secret); err != nil {
+		if !kerrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get secret %q: %v", key.String(), err)
+		}
+
+		var credentials azure.Credentials
+		cluster, credentials, err = prov.CreateClusterServicePrincipal(cluster, r.updateCluster)
+		if err != nil {
+			return err
+		}
+
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+			Data: map[string][]byte{
+				resources.AzureTenantID:       []byte(credentials.TenantID),
+				resources.AzureSubscriptionID: []byte(credentials.SubscriptionID),
+				resources.AzureClientID:       []byte(credentials.ClientID),
+				resources.AzureClientSecret:   []byte(credentials.ClientSecret),
+			},
+		}
+		if err := r.Create(ctx, secret); err != nil {
+			return fmt.Errorf("failed to create secret %q: %v", key.String(), err)
+		}
+		log.Infow("Created service principal for cluster", "clientID", credentials.ClientID)
+	}
+
+	_, err := prov.ReconcileClusterServicePrincipal(cluster, r.updateCluster)
+	return err
+}
+
+// reconcileAzureLogAnalyticsSecret stores the ID and the shared key of the Log Analytics workspace of
+// an Azure cluster in the cluster namespace, from where they are passed to the monitoring agent addon.
+// The secret is removed once the integration is disabled.
+func (r *Reconciler) reconcileAzureLogAnalyticsSecret(ctx context.Context, cluster *kubermaticv1.Cluster, prov *azure.Azure) error {
+	enabled := cluster.Spec.Cloud.Azure.LogAnalytics != nil
+	if cluster.Status.NamespaceName == "" {
+		// without a namespace there is no secret to remove
+		if !enabled {
+			return nil
+		}
+		return errors.New("the cluster namespace does not exist yet")
+	}
+
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: resources.AzureLogAnalyticsSecretName}
+	if err := r.Get(ctx, key, secret); err != nil {
+		if !kerrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get secret %q: %v", key.String(), err)
+		}
+		secret = nil
+	}
+
+	if !enabled {
+		if secret == nil {
+			return nil
+		}
+		if err := r.Delete(ctx, secret); err != nil && !kerrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete secret %q: %v", key.String(), err)
+		}
+		return nil
+	}
+
+	workspace, err := prov.GetLogAnalyticsWorkspace(cluster)
+	if err != nil || workspace == nil {
+		return err
+	}
+
+	data := map[string][]byte{
+		resources.AzureLogAnalyticsWorkspaceID:  []byte(workspace.CustomerID),
+		resources.AzureLogAnalyticsWorkspaceKey: []byte(workspace.SharedKey),
+	}
+
+	if secret == nil {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+			Data: data,
+		}
+		if err := r.Create(ctx, secret); err != nil {
+			return fmt.Errorf("failed to create secret %q: %v", key.String(), err)
+		}
+		return nil
+	}
+
+	if string(secret.Data[resources.AzureLogAnalyticsWorkspaceID]) == workspace.CustomerID && string(secret.Data[resources.AzureLogAnalyticsWorkspaceKey]) == workspace.SharedKey {
+		return nil
+	}
+	oldSecret := secret.DeepCopy()
+	secret.Data = data
+	if err := r.Patch(ctx, secret, ctrlruntimeclient.MergeFrom(oldSecret)); err != nil {
+		return fmt.Errorf("failed to update secret %q: %v", key.String(), err)
+	}
+
+	return nil
+}
+
 func (r *Reconciler) migrateICMP(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster, cloudProvider provider.CloudProvider) error {
 	switch prov := cloudProvider.(type) {
 	case *aws.AmazonEC2:
@@ -250,23 +507,7 @@ func (r *Reconciler) migrateAWSMultiAZ(ctx context.Context, cluster *kubermaticv
 }
 
 func (r *Reconciler) updateCluster(name string, modify func(*kubermaticv1.Cluster), options ...provider.UpdaterOption) (*kubermaticv1.Cluster, error) {
-	cluster := &kubermaticv1.Cluster{}
-	if err := r.Get(context.Background(), types.NamespacedName{Name: name}, cluster); err != nil {
-		return nil, err
-	}
-	oldCluster := cluster.DeepCopy()
-	modify(cluster)
-	if reflect.DeepEqual(oldCluster, cluster) {
-		return cluster, nil
-	}
-	opts := (&provider.UpdaterOptions{}).Apply(options...)
-	var patch ctrlruntimeclient.Patch
-	if opts.OptimisticLock {
-		patch = ctrlruntimeclient.MergeFromWithOptions(oldCluster, ctrlruntimeclient.MergeFromWithOptimisticLock{})
-	} else {
-		patch = ctrlruntimeclient.MergeFrom(oldCluster)
-	}
-	return cluster, r.Patch(context.Background(), cluster, patch)
+	return provider.ClusterUpdaterFactory(context.Background(), r)(name, modify, options...)
 }
 
 func (r *Reconciler) getGlobalSecretKeySelectorValue(configVar *providerconfig.GlobalSecretKeySelector, key string) (string, error) {
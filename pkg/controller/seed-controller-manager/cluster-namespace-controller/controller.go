@@ -0,0 +1,384 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusternamespacecontroller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	kubermaticapiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	clusterclient "k8c.io/kubermatic/v2/pkg/cluster/client"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/resources/reconciling"
+	"k8c.io/kubermatic/v2/pkg/util/workerlabel"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	ControllerName = "kubermatic_cluster_namespace_controller"
+
+	// templateObjectName is the name of the resource quota, limit range and network policy
+	// created from the template of a namespace.
+	templateObjectName = "kubermatic"
+)
+
+// UserClusterClientProvider provides functionality to get a user cluster client
+type UserClusterClientProvider interface {
+	GetClient(ctx context.Context, c *kubermaticv1.Cluster, options ...clusterclient.ConfigOption) (ctrlruntimeclient.Client, error)
+}
+
+type Reconciler struct {
+	ctrlruntimeclient.Client
+
+	log                       *zap.SugaredLogger
+	workerName                string
+	recorder                  record.EventRecorder
+	userClusterClientProvider UserClusterClientProvider
+}
+
+// Add creates a new cluster namespace controller.
+func Add(mgr manager.Manager, numWorkers int, workerName string, log *zap.SugaredLogger, userClusterClientProvider UserClusterClientProvider) error {
+	reconciler := &Reconciler{
+		Client:                    mgr.GetClient(),
+		log:                       log.Named(ControllerName),
+		workerName:                workerName,
+		recorder:                  mgr.GetEventRecorderFor(ControllerName),
+		userClusterClientProvider: userClusterClientProvider,
+	}
+
+	c, err := controller.New(ControllerName, mgr, controller.Options{Reconciler: reconciler, MaxConcurrentReconciles: numWorkers})
+	if err != nil {
+		return fmt.Errorf("failed to create controller: %v", err)
+	}
+
+	if err := c.Watch(&source.Kind{Type: &kubermaticv1.ClusterNamespace{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("failed to create cluster namespace watch: %v", err)
+	}
+
+	// Namespaces are only created once the control plane of their cluster is up.
+	if err := c.Watch(&source.Kind{Type: &kubermaticv1.Cluster{}}, enqueueClusterNamespaces(reconciler), workerlabel.Predicates(workerName)); err != nil {
+		return fmt.Errorf("failed to create cluster watch: %v", err)
+	}
+
+	return nil
+}
+
+func enqueueClusterNamespaces(client ctrlruntimeclient.Client) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(o ctrlruntimeclient.Object) []reconcile.Request {
+		namespaces := &kubermaticv1.ClusterNamespaceList{}
+		if err := client.List(context.Background(), namespaces, ctrlruntimeclient.MatchingLabels{kubermaticv1.ClusterNamespaceClusterLabelKey: o.GetName()}); err != nil {
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for _, namespace := range namespaces.Items {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: namespace.Name}})
+		}
+		return requests
+	})
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.With("clusternamespace", request.Name)
+	log.Debug("Reconciling")
+
+	clusterNamespace := &kubermaticv1.ClusterNamespace{}
+	if err := r.Get(ctx, request.NamespacedName, clusterNamespace); err != nil {
+		return reconcile.Result{}, ctrlruntimeclient.IgnoreNotFound(err)
+	}
+
+	cluster := &kubermaticv1.Cluster{}
+	if err := r.Get(ctx, types.NamespacedName{Name: clusterNamespace.Spec.ClusterName}, cluster); err != nil {
+		if kerrors.IsNotFound(err) {
+			// The namespace is gone together with the cluster.
+			return reconcile.Result{}, r.removeFinalizer(ctx, clusterNamespace)
+		}
+		return reconcile.Result{}, fmt.Errorf("failed to get cluster: %v", err)
+	}
+
+	if cluster.Labels[kubermaticv1.WorkerNameLabelKey] != r.workerName {
+		return reconcile.Result{}, nil
+	}
+	if cluster.Spec.Pause {
+		log.Debug("Skipping because the cluster is paused")
+		return reconcile.Result{}, nil
+	}
+
+	result, err := r.reconcile(ctx, log, cluster, clusterNamespace)
+	if err != nil {
+		log.Errorw("Reconciling failed", zap.Error(err))
+		r.recorder.Event(clusterNamespace, corev1.EventTypeWarning, "ReconcilingError", err.Error())
+		if statusErr := r.setStatus(ctx, clusterNamespace, kubermaticv1.ClusterNamespaceFailed, err.Error()); statusErr != nil {
+			log.Errorw("Failed to update the status", zap.Error(statusErr))
+		}
+	}
+	if result == nil {
+		result = &reconcile.Result{}
+	}
+	return *result, err
+}
+
+func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster, clusterNamespace *kubermaticv1.ClusterNamespace) (*reconcile.Result, error) {
+	if clusterNamespace.DeletionTimestamp != nil {
+		if !kuberneteshelper.HasFinalizer(clusterNamespace, kubermaticapiv1.ClusterNamespaceCleanupFinalizer) {
+			return nil, nil
+		}
+		// Namespaces of clusters which are being deleted go away with the cluster.
+		if cluster.DeletionTimestamp == nil {
+			if err := r.deleteNamespace(ctx, cluster, clusterNamespace); err != nil {
+				return nil, err
+			}
+		}
+		return nil, r.removeFinalizer(ctx, clusterNamespace)
+	}
+
+	if cluster.DeletionTimestamp != nil {
+		return nil, nil
+	}
+
+	if !kuberneteshelper.HasFinalizer(clusterNamespace, kubermaticapiv1.ClusterNamespaceCleanupFinalizer) {
+		oldClusterNamespace := clusterNamespace.DeepCopy()
+		kuberneteshelper.AddFinalizer(clusterNamespace, kubermaticapiv1.ClusterNamespaceCleanupFinalizer)
+		if err := r.Patch(ctx, clusterNamespace, ctrlruntimeclient.MergeFrom(oldClusterNamespace)); err != nil {
+			return nil, fmt.Errorf("failed to add finalizer: %v", err)
+		}
+	}
+
+	if cluster.Status.ExtendedHealth.Apiserver != kubermaticv1.HealthStatusUp {
+		log.Debug("Waiting for the apiserver of the cluster to become healthy")
+		if err := r.setStatus(ctx, clusterNamespace, kubermaticv1.ClusterNamespacePending, "waiting for the cluster to become healthy"); err != nil {
+			return nil, err
+		}
+		return &reconcile.Result{RequeueAfter: 10 * time.Second}, nil
+	}
+
+	userClusterClient, err := r.userClusterClientProvider.GetClient(ctx, cluster)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user cluster client: %v", err)
+	}
+
+	// Namespaces which were not created via the API are never taken over, they would be
+	// deleted together with the ClusterNamespace.
+	namespace := &corev1.Namespace{}
+	if err := userClusterClient.Get(ctx, types.NamespacedName{Name: clusterNamespace.Spec.Namespace}, namespace); err == nil {
+		if namespace.Labels[kubermaticv1.ClusterNamespaceLabelKey] != clusterNamespace.Name {
+			return nil, r.setStatus(ctx, clusterNamespace, kubermaticv1.ClusterNamespaceFailed, "the namespace already exists and was not created via the API")
+		}
+	} else if !kerrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get namespace: %v", err)
+	}
+
+	if err := reconcileTemplateObjects(ctx, userClusterClient, clusterNamespace); err != nil {
+		return nil, err
+	}
+
+	return nil, r.setStatus(ctx, clusterNamespace, kubermaticv1.ClusterNamespaceReady, "")
+}
+
+// reconcileTemplateObjects creates the namespace and the objects of its template in the user cluster.
+func reconcileTemplateObjects(ctx context.Context, client ctrlruntimeclient.Client, clusterNamespace *kubermaticv1.ClusterNamespace) error {
+	spec := clusterNamespace.Spec.TemplateSpec
+	namespace := clusterNamespace.Spec.Namespace
+	modifier := labelsWrapper(clusterNamespace.Name)
+
+	if err := reconciling.ReconcileNamespaces(ctx, []reconciling.NamedNamespaceCreatorGetter{
+		namespaceCreatorGetter(namespace, spec.Labels),
+	}, "", client, modifier); err != nil {
+		return fmt.Errorf("failed to reconcile namespace: %v", err)
+	}
+
+	if spec.ResourceQuota != nil {
+		if err := reconciling.ReconcileResourceQuotas(ctx, []reconciling.NamedResourceQuotaCreatorGetter{
+			resourceQuotaCreatorGetter(*spec.ResourceQuota),
+		}, namespace, client, modifier); err != nil {
+			return fmt.Errorf("failed to reconcile resource quota: %v", err)
+		}
+	}
+
+	if spec.LimitRange != nil {
+		if err := reconciling.ReconcileLimitRanges(ctx, []reconciling.NamedLimitRangeCreatorGetter{
+			limitRangeCreatorGetter(*spec.LimitRange),
+		}, namespace, client, modifier); err != nil {
+			return fmt.Errorf("failed to reconcile limit range: %v", err)
+		}
+	}
+
+	if spec.NetworkPolicy != nil {
+		if err := reconciling.ReconcileNetworkPolicies(ctx, []reconciling.NamedNetworkPolicyCreatorGetter{
+			networkPolicyCreatorGetter(*spec.NetworkPolicy),
+		}, namespace, client, modifier); err != nil {
+			return fmt.Errorf("failed to reconcile network policy: %v", err)
+		}
+	}
+
+	var roleBindingCreators []reconciling.NamedRoleBindingCreatorGetter
+	for _, binding := range spec.RoleBindings {
+		roleBindingCreators = append(roleBindingCreators, roleBindingCreatorGetter(binding))
+	}
+	if err := reconciling.ReconcileRoleBindings(ctx, roleBindingCreators, namespace, client, modifier); err != nil {
+		return fmt.Errorf("failed to reconcile role bindings: %v", err)
+	}
+
+	return nil
+}
+
+// deleteNamespace deletes the namespace from the user cluster, if it was created for the ClusterNamespace.
+func (r *Reconciler) deleteNamespace(ctx context.Context, cluster *kubermaticv1.Cluster, clusterNamespace *kubermaticv1.ClusterNamespace) error {
+	userClusterClient, err := r.userClusterClientProvider.GetClient(ctx, cluster)
+	if err != nil {
+		return fmt.Errorf("failed to get user cluster client: %v", err)
+	}
+
+	namespace := &corev1.Namespace{}
+	if err := userClusterClient.Get(ctx, types.NamespacedName{Name: clusterNamespace.Spec.Namespace}, namespace); err != nil {
+		return ctrlruntimeclient.IgnoreNotFound(err)
+	}
+	if namespace.Labels[kubermaticv1.ClusterNamespaceLabelKey] != clusterNamespace.Name {
+		return nil
+	}
+
+	if err := userClusterClient.Delete(ctx, namespace); err != nil && !kerrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete namespace: %v", err)
+	}
+	return nil
+}
+
+func (r *Reconciler) removeFinalizer(ctx context.Context, clusterNamespace *kubermaticv1.ClusterNamespace) error {
+	if !kuberneteshelper.HasFinalizer(clusterNamespace, kubermaticapiv1.ClusterNamespaceCleanupFinalizer) {
+		return nil
+	}
+
+	oldClusterNamespace := clusterNamespace.DeepCopy()
+	kuberneteshelper.RemoveFinalizer(clusterNamespace, kubermaticapiv1.ClusterNamespaceCleanupFinalizer)
+	if err := r.Patch(ctx, clusterNamespace, ctrlruntimeclient.MergeFrom(oldClusterNamespace)); err != nil {
+		return fmt.Errorf("failed to remove finalizer: %v", err)
+	}
+	return nil
+}
+
+func (r *Reconciler) setStatus(ctx context.Context, clusterNamespace *kubermaticv1.ClusterNamespace, phase kubermaticv1.ClusterNamespacePhase, message string) error {
+	if clusterNamespace.Status.Phase == phase && clusterNamespace.Status.Message == message {
+		return nil
+	}
+
+	oldClusterNamespace := clusterNamespace.DeepCopy()
+	clusterNamespace.Status.Phase = phase
+	clusterNamespace.Status.Message = message
+	if err := r.Status().Patch(ctx, clusterNamespace, ctrlruntimeclient.MergeFrom(oldClusterNamespace)); err != nil {
+		return fmt.Errorf("failed to update status: %v", err)
+	}
+	return nil
+}
+
+// labelsWrapper marks all objects in the user cluster with the ClusterNamespace they were created for.
+func labelsWrapper(clusterNamespaceName string) reconciling.ObjectModifier {
+	return func(create reconciling.ObjectCreator) reconciling.ObjectCreator {
+		return func(existing ctrlruntimeclient.Object) (ctrlruntimeclient.Object, error) {
+			obj, err := create(existing)
+			if err != nil {
+				return nil, err
+			}
+
+			labels := obj.GetLabels()
+			if labels == nil {
+				labels = map[string]string{}
+			}
+			labels[kubermaticv1.ClusterNamespaceLabelKey] = clusterNamespaceName
+			obj.SetLabels(labels)
+
+			return obj, nil
+		}
+	}
+}
+
+func namespaceCreatorGetter(name string, labels map[string]string) reconciling.NamedNamespaceCreatorGetter {
+	return func() (string, reconciling.NamespaceCreator) {
+		return name, func(ns *corev1.Namespace) (*corev1.Namespace, error) {
+			if ns.Labels == nil {
+				ns.Labels = map[string]string{}
+			}
+			for key, value := range labels {
+				ns.Labels[key] = value
+			}
+			return ns, nil
+		}
+	}
+}
+
+func resourceQuotaCreatorGetter(spec corev1.ResourceQuotaSpec) reconciling.NamedResourceQuotaCreatorGetter {
+	return func() (string, reconciling.ResourceQuotaCreator) {
+		return templateObjectName, func(quota *corev1.ResourceQuota) (*corev1.ResourceQuota, error) {
+			quota.Spec = *spec.DeepCopy()
+			return quota, nil
+		}
+	}
+}
+
+func limitRangeCreatorGetter(spec corev1.LimitRangeSpec) reconciling.NamedLimitRangeCreatorGetter {
+	return func() (string, reconciling.LimitRangeCreator) {
+		return templateObjectName, func(limitRange *corev1.LimitRange) (*corev1.LimitRange, error) {
+			limitRange.Spec = *spec.DeepCopy()
+			return limitRange, nil
+		}
+	}
+}
+
+func networkPolicyCreatorGetter(spec networkingv1.NetworkPolicySpec) reconciling.NamedNetworkPolicyCreatorGetter {
+	return func() (string, reconciling.NetworkPolicyCreator) {
+		return templateObjectName, func(policy *networkingv1.NetworkPolicy) (*networkingv1.NetworkPolicy, error) {
+			policy.Spec = *spec.DeepCopy()
+			return policy, nil
+		}
+	}
+}
+
+func roleBindingCreatorGetter(binding kubermaticv1.NamespaceRoleBinding) reconciling.NamedRoleBindingCreatorGetter {
+	return func() (string, reconciling.RoleBindingCreator) {
+		return binding.Name, func(rb *rbacv1.RoleBinding) (*rbacv1.RoleBinding, error) {
+			rb.RoleRef = rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "ClusterRole",
+				Name:     binding.ClusterRole,
+			}
+			rb.Subjects = nil
+			for _, group := range binding.Groups {
+				rb.Subjects = append(rb.Subjects, rbacv1.Subject{
+					APIGroup: rbacv1.GroupName,
+					Kind:     rbacv1.GroupKind,
+					Name:     group,
+				})
+			}
+			return rb, nil
+		}
+	}
+}
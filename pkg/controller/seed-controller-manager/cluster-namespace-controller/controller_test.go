@@ -0,0 +1,211 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusternamespacecontroller
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/zap"
+
+	kubermaticapiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	clusterclient "k8c.io/kubermatic/v2/pkg/cluster/client"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+type fakeClientProvider struct {
+	client ctrlruntimeclient.Client
+}
+
+func (p *fakeClientProvider) GetClient(_ context.Context, _ *kubermaticv1.Cluster, _ ...clusterclient.ConfigOption) (ctrlruntimeclient.Client, error) {
+	return p.client, nil
+}
+
+func newTestReconciler(userClient ctrlruntimeclient.Client, objects ...ctrlruntimeclient.Object) *Reconciler {
+	return &Reconciler{
+		Client: fakectrlruntimeclient.
+			NewClientBuilder().
+			WithScheme(scheme.Scheme).
+			WithObjects(objects...).
+			Build(),
+		log:                       zap.NewNop().Sugar(),
+		recorder:                  record.NewFakeRecorder(10),
+		userClusterClientProvider: &fakeClientProvider{client: userClient},
+	}
+}
+
+func genCluster() *kubermaticv1.Cluster {
+	return &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "w225mx4z66"},
+		Status: kubermaticv1.ClusterStatus{
+			ExtendedHealth: kubermaticv1.ExtendedClusterHealth{Apiserver: kubermaticv1.HealthStatusUp},
+		},
+	}
+}
+
+func genClusterNamespace() *kubermaticv1.ClusterNamespace {
+	return &kubermaticv1.ClusterNamespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "w225mx4z66-team-a"},
+		Spec: kubermaticv1.ClusterNamespaceSpec{
+			ClusterName: "w225mx4z66",
+			Namespace:   "team-a",
+			Template:    "small",
+			TemplateSpec: kubermaticv1.NamespaceTemplateSpec{
+				Labels: map[string]string{"team": "a"},
+				ResourceQuota: &corev1.ResourceQuotaSpec{
+					Hard: corev1.ResourceList{corev1.ResourceLimitsCPU: resource.MustParse("4")},
+				},
+				RoleBindings: []kubermaticv1.NamespaceRoleBinding{
+					{Name: "editors", ClusterRole: "edit", Groups: []string{"owners", "editors"}},
+				},
+			},
+		},
+	}
+}
+
+func TestReconcileClusterNamespace(t *testing.T) {
+	ctx := context.Background()
+	userClient := fakectrlruntimeclient.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	r := newTestReconciler(userClient, genCluster(), genClusterNamespace())
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: "w225mx4z66-team-a"}}
+
+	if _, err := r.Reconcile(ctx, request); err != nil {
+		t.Fatalf("failed to reconcile: %v", err)
+	}
+
+	namespace := &corev1.Namespace{}
+	if err := userClient.Get(ctx, types.NamespacedName{Name: "team-a"}, namespace); err != nil {
+		t.Fatalf("failed to get namespace: %v", err)
+	}
+	if namespace.Labels["team"] != "a" || namespace.Labels[kubermaticv1.ClusterNamespaceLabelKey] != "w225mx4z66-team-a" {
+		t.Fatalf("unexpected namespace labels: %v", namespace.Labels)
+	}
+
+	quota := &corev1.ResourceQuota{}
+	if err := userClient.Get(ctx, types.NamespacedName{Namespace: "team-a", Name: templateObjectName}, quota); err != nil {
+		t.Fatalf("failed to get resource quota: %v", err)
+	}
+	if limit := quota.Spec.Hard[corev1.ResourceLimitsCPU]; limit.String() != "4" {
+		t.Fatalf("expected a CPU limit of 4, got %s", limit.String())
+	}
+
+	binding := &rbacv1.RoleBinding{}
+	if err := userClient.Get(ctx, types.NamespacedName{Namespace: "team-a", Name: "editors"}, binding); err != nil {
+		t.Fatalf("failed to get role binding: %v", err)
+	}
+	if binding.RoleRef.Name != "edit" || len(binding.Subjects) != 2 || binding.Subjects[1].Name != "editors" {
+		t.Fatalf("unexpected role binding: %+v", binding)
+	}
+
+	clusterNamespace := &kubermaticv1.ClusterNamespace{}
+	if err := r.Get(ctx, request.NamespacedName, clusterNamespace); err != nil {
+		t.Fatalf("failed to get cluster namespace: %v", err)
+	}
+	if clusterNamespace.Status.Phase != kubermaticv1.ClusterNamespaceReady {
+		t.Fatalf("expected the namespace to be ready, got %q: %s", clusterNamespace.Status.Phase, clusterNamespace.Status.Message)
+	}
+	if !kuberneteshelper.HasFinalizer(clusterNamespace, kubermaticapiv1.ClusterNamespaceCleanupFinalizer) {
+		t.Fatal("expected the cleanup finalizer to be set")
+	}
+}
+
+func TestExistingNamespaceIsNotTakenOver(t *testing.T) {
+	ctx := context.Background()
+	existing := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}}
+	userClient := fakectrlruntimeclient.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(existing).Build()
+	r := newTestReconciler(userClient, genCluster(), genClusterNamespace())
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: "w225mx4z66-team-a"}}
+
+	if _, err := r.Reconcile(ctx, request); err != nil {
+		t.Fatalf("failed to reconcile: %v", err)
+	}
+
+	clusterNamespace := &kubermaticv1.ClusterNamespace{}
+	if err := r.Get(ctx, request.NamespacedName, clusterNamespace); err != nil {
+		t.Fatalf("failed to get cluster namespace: %v", err)
+	}
+	if clusterNamespace.Status.Phase != kubermaticv1.ClusterNamespaceFailed {
+		t.Fatalf("expected the namespace to fail, got %q", clusterNamespace.Status.Phase)
+	}
+}
+
+func TestDeleteClusterNamespace(t *testing.T) {
+	testCases := []struct {
+		name              string
+		namespaceLabels   map[string]string
+		expectedNamespace bool
+	}{
+		{
+			name:              "namespace created for the ClusterNamespace is deleted",
+			namespaceLabels:   map[string]string{kubermaticv1.ClusterNamespaceLabelKey: "w225mx4z66-team-a"},
+			expectedNamespace: false,
+		},
+		{
+			name:              "namespace which was not created via the API is kept",
+			expectedNamespace: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			existing := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: tc.namespaceLabels}}
+			userClient := fakectrlruntimeclient.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(existing).Build()
+
+			deletionTimestamp := metav1.Now()
+			clusterNamespace := genClusterNamespace()
+			clusterNamespace.DeletionTimestamp = &deletionTimestamp
+			clusterNamespace.Finalizers = []string{kubermaticapiv1.ClusterNamespaceCleanupFinalizer}
+
+			r := newTestReconciler(userClient, genCluster(), clusterNamespace)
+			request := reconcile.Request{NamespacedName: types.NamespacedName{Name: "w225mx4z66-team-a"}}
+
+			if _, err := r.Reconcile(ctx, request); err != nil {
+				t.Fatalf("failed to reconcile: %v", err)
+			}
+
+			err := userClient.Get(ctx, types.NamespacedName{Name: "team-a"}, &corev1.Namespace{})
+			if tc.expectedNamespace && err != nil {
+				t.Fatalf("expected the namespace to be kept, got %v", err)
+			}
+			if !tc.expectedNamespace && !kerrors.IsNotFound(err) {
+				t.Fatalf("expected the namespace to be deleted, got %v", err)
+			}
+
+			updated := &kubermaticv1.ClusterNamespace{}
+			if err := r.Get(ctx, request.NamespacedName, updated); err != nil {
+				t.Fatalf("failed to get cluster namespace: %v", err)
+			}
+			if kuberneteshelper.HasFinalizer(updated, kubermaticapiv1.ClusterNamespaceCleanupFinalizer) {
+				t.Fatal("expected the cleanup finalizer to be removed")
+			}
+		})
+	}
+}
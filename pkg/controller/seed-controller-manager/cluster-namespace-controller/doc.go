@@ -0,0 +1,27 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package clusternamespacecontroller contains a controller that creates the namespaces
+which project members requested via the API in their user clusters.
+
+Every namespace is recorded as a ClusterNamespace in the seed. The controller creates
+the namespace together with the resource quota, limit range, network policy and role
+bindings of the template the ClusterNamespace was created with. When the
+ClusterNamespace is deleted, the namespace is deleted from the user cluster. Existing
+namespaces which were not created via the API are never taken over.
+*/
+package clusternamespacecontroller
@@ -0,0 +1,335 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controlplanedensity
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/provider"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	ControllerName = "kubermatic_control_plane_density_controller"
+
+	// resyncPeriod is the interval in which the density is computed again. When rebalancing, at
+	// most one pod is evicted per interval.
+	resyncPeriod = time.Minute
+)
+
+type Reconciler struct {
+	ctrlruntimeclient.Client
+
+	log        *zap.SugaredLogger
+	recorder   record.EventRecorder
+	seedGetter provider.SeedGetter
+
+	// evict is overridden in tests
+	evict func(ctx context.Context, pod *corev1.Pod) error
+}
+
+// nodeDensity contains the control planes with pods on a seed node.
+type nodeDensity struct {
+	node *corev1.Node
+	// pods are the control plane pods on the node by cluster namespace, sorted by name.
+	pods map[string][]corev1.Pod
+}
+
+func (d *nodeDensity) podCount() int {
+	count := 0
+	for _, pods := range d.pods {
+		count += len(pods)
+	}
+	return count
+}
+
+// Add creates a new control plane density controller
+func Add(mgr manager.Manager, log *zap.SugaredLogger, seedGetter provider.SeedGetter) error {
+	clientset, err := kubernetes.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		return fmt.Errorf("failed to create clientset: %v", err)
+	}
+
+	reconciler := &Reconciler{
+		Client:     mgr.GetClient(),
+		log:        log.Named(ControllerName),
+		recorder:   mgr.GetEventRecorderFor(ControllerName),
+		seedGetter: seedGetter,
+		evict: func(ctx context.Context, pod *corev1.Pod) error {
+			return clientset.PolicyV1beta1().Evictions(pod.Namespace).Evict(ctx, &policyv1beta1.Eviction{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      pod.Name,
+					Namespace: pod.Namespace,
+				},
+			})
+		},
+	}
+
+	c, err := controller.New(ControllerName, mgr, controller.Options{Reconciler: reconciler, MaxConcurrentReconciles: 1})
+	if err != nil {
+		return fmt.Errorf("failed to create controller: %v", err)
+	}
+
+	// The density of all nodes is computed at once, so every node enqueues the same request.
+	enqueueSeed := handler.EnqueueRequestsFromMapFunc(func(_ ctrlruntimeclient.Object) []reconcile.Request {
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: ControllerName}}}
+	})
+	if err := c.Watch(&source.Kind{Type: &corev1.Node{}}, enqueueSeed); err != nil {
+		return fmt.Errorf("failed to create watch: %v", err)
+	}
+
+	return nil
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	if err := r.reconcile(ctx); err != nil {
+		r.log.Errorw("Failed to reconcile control plane density", zap.Error(err))
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{RequeueAfter: resyncPeriod}, nil
+}
+
+func (r *Reconciler) reconcile(ctx context.Context) error {
+	seed, err := r.seedGetter()
+	if err != nil {
+		return fmt.Errorf("failed to get seed: %v", err)
+	}
+
+	densities, err := r.nodeDensities(ctx)
+	if err != nil {
+		return err
+	}
+
+	settings := seed.Spec.ControlPlaneDensity
+	updateMetrics(densities, settings)
+
+	if settings == nil || settings.MaxControlPlanesPerNode <= 0 {
+		return nil
+	}
+
+	overloaded := overloadedNodes(densities, settings.MaxControlPlanesPerNode)
+	for _, density := range overloaded {
+		r.recorder.Eventf(density.node, corev1.EventTypeWarning, "ControlPlaneDensityExceeded",
+			"Node hosts %d user cluster control planes, more than the maximum of %d. Add nodes to the seed or rebalance the control planes.",
+			len(density.pods), settings.MaxControlPlanesPerNode)
+	}
+
+	if !settings.Rebalance || len(overloaded) == 0 || !hasCapacity(densities, settings.MaxControlPlanesPerNode) {
+		return nil
+	}
+
+	return r.rebalance(ctx, overloaded[0])
+}
+
+// nodeDensities returns the control plane pods on every node of the seed.
+func (r *Reconciler) nodeDensities(ctx context.Context) ([]nodeDensity, error) {
+	nodes := &corev1.NodeList{}
+	if err := r.List(ctx, nodes); err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %v", err)
+	}
+
+	densities := make([]nodeDensity, len(nodes.Items))
+	byNode := map[string]*nodeDensity{}
+	for i := range nodes.Items {
+		densities[i] = nodeDensity{node: &nodes.Items[i], pods: map[string][]corev1.Pod{}}
+		byNode[nodes.Items[i].Name] = &densities[i]
+	}
+
+	clusters := &kubermaticv1.ClusterList{}
+	if err := r.List(ctx, clusters); err != nil {
+		return nil, fmt.Errorf("failed to list clusters: %v", err)
+	}
+
+	for _, cluster := range clusters.Items {
+		if cluster.Status.NamespaceName == "" {
+			continue
+		}
+
+		pods := &corev1.PodList{}
+		if err := r.List(ctx, pods, ctrlruntimeclient.InNamespace(cluster.Status.NamespaceName)); err != nil {
+			return nil, fmt.Errorf("failed to list pods of cluster %q: %v", cluster.Name, err)
+		}
+
+		for _, pod := range pods.Items {
+			if pod.DeletionTimestamp != nil || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+				continue
+			}
+			if density, ok := byNode[pod.Spec.NodeName]; ok {
+				density.pods[pod.Namespace] = append(density.pods[pod.Namespace], pod)
+			}
+		}
+	}
+
+	for _, density := range densities {
+		for _, pods := range density.pods {
+			sort.Slice(pods, func(i, j int) bool { return pods[i].Name < pods[j].Name })
+		}
+	}
+
+	return densities, nil
+}
+
+func updateMetrics(densities []nodeDensity, settings *kubermaticv1.ControlPlaneDensitySettings) {
+	nodeControlPlanesMetric.Reset()
+	nodeControlPlanePodsMetric.Reset()
+	for _, density := range densities {
+		nodeControlPlanesMetric.WithLabelValues(density.node.Name).Set(float64(len(density.pods)))
+		nodeControlPlanePodsMetric.WithLabelValues(density.node.Name).Set(float64(density.podCount()))
+	}
+
+	if settings == nil || settings.MaxControlPlanesPerNode <= 0 {
+		maxControlPlanesPerNodeMetric.Set(0)
+		recommendedNodesMetric.Set(0)
+		return
+	}
+	maxControlPlanesPerNodeMetric.Set(float64(settings.MaxControlPlanesPerNode))
+	recommendedNodesMetric.Set(float64(recommendedNodes(densities, settings.MaxControlPlanesPerNode)))
+}
+
+// recommendedNodes returns the number of nodes needed to host the control planes without any node
+// exceeding the maximum, assuming the control planes stay spread across as many nodes as now.
+func recommendedNodes(densities []nodeDensity, maxControlPlanes int) int {
+	total := 0
+	for _, density := range densities {
+		total += len(density.pods)
+	}
+	return (total + maxControlPlanes - 1) / maxControlPlanes
+}
+
+// overloadedNodes returns the nodes with more control planes than the maximum, the most
+// overloaded first.
+func overloadedNodes(densities []nodeDensity, maxControlPlanes int) []nodeDensity {
+	var overloaded []nodeDensity
+	for _, density := range densities {
+		if len(density.pods) > maxControlPlanes {
+			overloaded = append(overloaded, density)
+		}
+	}
+	sort.SliceStable(overloaded, func(i, j int) bool {
+		if len(overloaded[i].pods) != len(overloaded[j].pods) {
+			return len(overloaded[i].pods) > len(overloaded[j].pods)
+		}
+		return overloaded[i].node.Name < overloaded[j].node.Name
+	})
+	return overloaded
+}
+
+// hasCapacity returns true if a schedulable node hosts less control planes than the maximum, so
+// that evicted pods can be placed on it.
+func hasCapacity(densities []nodeDensity, maxControlPlanes int) bool {
+	for _, density := range densities {
+		if len(density.pods) < maxControlPlanes && isSchedulable(density.node) {
+			return true
+		}
+	}
+	return false
+}
+
+func isSchedulable(node *corev1.Node) bool {
+	if node.Spec.Unschedulable {
+		return false
+	}
+	for _, taint := range node.Spec.Taints {
+		if taint.Effect == corev1.TaintEffectNoSchedule || taint.Effect == corev1.TaintEffectNoExecute {
+			return false
+		}
+	}
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// rebalance evicts a pod of the control plane on the node which is the cheapest to move.
+func (r *Reconciler) rebalance(ctx context.Context, density nodeDensity) error {
+	pods := rebalanceCandidate(density)
+	if len(pods) == 0 {
+		r.log.Debugw("No control plane on the overloaded node can be moved", "node", density.node.Name)
+		return nil
+	}
+
+	for i := range pods {
+		pod := &pods[i]
+		if err := r.evict(ctx, pod); err != nil {
+			// Evictions violating a PodDisruptionBudget are rejected, the pod is retried later on.
+			if kerrors.IsTooManyRequests(err) || kerrors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("failed to evict pod %s/%s: %v", pod.Namespace, pod.Name, err)
+		}
+
+		r.log.Infow("Evicted control plane pod to rebalance the seed", "node", density.node.Name, "pod", pod.Namespace+"/"+pod.Name)
+		r.recorder.Eventf(density.node, corev1.EventTypeNormal, "ControlPlaneEvicted",
+			"Evicted pod %s/%s to rebalance the user cluster control planes of the seed", pod.Namespace, pod.Name)
+		return nil
+	}
+
+	return nil
+}
+
+// rebalanceCandidate returns the pods of the control plane with the fewest pods on the node, which
+// must all be managed by Deployments, as the pods of StatefulSets like etcd are bound to their volumes.
+func rebalanceCandidate(density nodeDensity) []corev1.Pod {
+	namespaces := make([]string, 0, len(density.pods))
+	for namespace := range density.pods {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+
+	var candidate []corev1.Pod
+	for _, namespace := range namespaces {
+		pods := density.pods[namespace]
+		if !ownedByReplicaSets(pods) {
+			continue
+		}
+		if candidate == nil || len(pods) < len(candidate) {
+			candidate = pods
+		}
+	}
+	return candidate
+}
+
+func ownedByReplicaSets(pods []corev1.Pod) bool {
+	for _, pod := range pods {
+		owner := metav1.GetControllerOf(&pod)
+		if owner == nil || owner.Kind != "ReplicaSet" {
+			return false
+		}
+	}
+	return true
+}
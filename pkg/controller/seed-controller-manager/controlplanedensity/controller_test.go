@@ -0,0 +1,247 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controlplanedensity
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func node(name string, ready bool) *corev1.Node {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: status}},
+		},
+	}
+}
+
+func cluster(name string) *kubermaticv1.Cluster {
+	return &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status:     kubermaticv1.ClusterStatus{NamespaceName: "cluster-" + name},
+	}
+}
+
+func pod(clusterName, name, nodeName, ownerKind string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "cluster-" + clusterName,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "apps/v1",
+				Kind:       ownerKind,
+				Name:       name,
+				Controller: func() *bool { b := true; return &b }(),
+			}},
+		},
+		Spec:   corev1.PodSpec{NodeName: nodeName},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	testCases := []struct {
+		name              string
+		settings          *kubermaticv1.ControlPlaneDensitySettings
+		objects           []ctrlruntimeclient.Object
+		evictErrors       map[string]error
+		expectedEvicted   []string
+		expectedEvents    int
+		expectedDensities map[string]float64
+		expectedPods      map[string]float64
+		expectedNodes     float64
+	}{
+		{
+			name: "density is exposed without settings",
+			objects: []ctrlruntimeclient.Object{
+				node("node-a", true),
+				node("node-b", true),
+				cluster("a"), cluster("b"),
+				pod("a", "apiserver-1", "node-a", "ReplicaSet"),
+				pod("a", "etcd-0", "node-a", "StatefulSet"),
+				pod("b", "apiserver-1", "node-a", "ReplicaSet"),
+				pod("b", "etcd-0", "node-b", "StatefulSet"),
+			},
+			expectedDensities: map[string]float64{"node-a": 2, "node-b": 1},
+			expectedPods:      map[string]float64{"node-a": 3, "node-b": 1},
+		},
+		{
+			name:     "overloaded node gets an event without rebalancing",
+			settings: &kubermaticv1.ControlPlaneDensitySettings{MaxControlPlanesPerNode: 1},
+			objects: []ctrlruntimeclient.Object{
+				node("node-a", true),
+				node("node-b", true),
+				cluster("a"), cluster("b"),
+				pod("a", "apiserver-1", "node-a", "ReplicaSet"),
+				pod("b", "apiserver-1", "node-a", "ReplicaSet"),
+			},
+			expectedEvents:    1,
+			expectedDensities: map[string]float64{"node-a": 2, "node-b": 0},
+			expectedPods:      map[string]float64{"node-a": 2, "node-b": 0},
+			expectedNodes:     2,
+		},
+		{
+			name:     "control plane with the fewest pods is evicted",
+			settings: &kubermaticv1.ControlPlaneDensitySettings{MaxControlPlanesPerNode: 2, Rebalance: true},
+			objects: []ctrlruntimeclient.Object{
+				node("node-a", true),
+				node("node-b", true),
+				cluster("a"), cluster("b"), cluster("c"),
+				pod("a", "apiserver-1", "node-a", "ReplicaSet"),
+				pod("a", "controller-manager-1", "node-a", "ReplicaSet"),
+				pod("b", "apiserver-1", "node-a", "ReplicaSet"),
+				pod("c", "etcd-0", "node-a", "StatefulSet"),
+			},
+			expectedEvicted:   []string{"cluster-b/apiserver-1"},
+			expectedEvents:    2,
+			expectedDensities: map[string]float64{"node-a": 3, "node-b": 0},
+			expectedPods:      map[string]float64{"node-a": 4, "node-b": 0},
+			expectedNodes:     2,
+		},
+		{
+			name:     "pods protected by a disruption budget are skipped",
+			settings: &kubermaticv1.ControlPlaneDensitySettings{MaxControlPlanesPerNode: 1, Rebalance: true},
+			objects: []ctrlruntimeclient.Object{
+				node("node-a", true),
+				node("node-b", true),
+				cluster("a"), cluster("b"),
+				pod("a", "apiserver-1", "node-a", "ReplicaSet"),
+				pod("b", "apiserver-1", "node-a", "ReplicaSet"),
+				pod("b", "controller-manager-1", "node-a", "ReplicaSet"),
+			},
+			evictErrors: map[string]error{
+				"cluster-a/apiserver-1": kerrors.NewTooManyRequests("disruption budget", 10),
+			},
+			expectedEvicted:   []string{"cluster-a/apiserver-1"},
+			expectedEvents:    1,
+			expectedDensities: map[string]float64{"node-a": 2, "node-b": 0},
+			expectedPods:      map[string]float64{"node-a": 3, "node-b": 0},
+			expectedNodes:     2,
+		},
+		{
+			name:     "no rebalancing without a node below the threshold",
+			settings: &kubermaticv1.ControlPlaneDensitySettings{MaxControlPlanesPerNode: 1, Rebalance: true},
+			objects: []ctrlruntimeclient.Object{
+				node("node-a", true),
+				node("node-b", false),
+				cluster("a"), cluster("b"),
+				pod("a", "apiserver-1", "node-a", "ReplicaSet"),
+				pod("b", "apiserver-1", "node-a", "ReplicaSet"),
+			},
+			expectedEvents:    1,
+			expectedDensities: map[string]float64{"node-a": 2, "node-b": 0},
+			expectedPods:      map[string]float64{"node-a": 2, "node-b": 0},
+			expectedNodes:     2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			seed := &kubermaticv1.Seed{Spec: kubermaticv1.SeedSpec{ControlPlaneDensity: tc.settings}}
+			recorder := record.NewFakeRecorder(10)
+
+			var evicted []string
+			r := &Reconciler{
+				Client:     fakectrlruntimeclient.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(tc.objects...).Build(),
+				log:        zap.NewNop().Sugar(),
+				recorder:   recorder,
+				seedGetter: func() (*kubermaticv1.Seed, error) { return seed, nil },
+				evict: func(_ context.Context, pod *corev1.Pod) error {
+					key := pod.Namespace + "/" + pod.Name
+					evicted = append(evicted, key)
+					return tc.evictErrors[key]
+				},
+			}
+
+			if err := r.reconcile(context.Background()); err != nil {
+				t.Fatalf("reconciling failed: %v", err)
+			}
+
+			if len(evicted) != len(tc.expectedEvicted) {
+				t.Fatalf("expected evictions %v, got %v", tc.expectedEvicted, evicted)
+			}
+			for i := range evicted {
+				if evicted[i] != tc.expectedEvicted[i] {
+					t.Errorf("expected evictions %v, got %v", tc.expectedEvicted, evicted)
+				}
+			}
+
+			if events := len(recorder.Events); events != tc.expectedEvents {
+				t.Errorf("expected %d events, got %d", tc.expectedEvents, events)
+			}
+
+			for node, expected := range tc.expectedDensities {
+				if density := testutil.ToFloat64(nodeControlPlanesMetric.WithLabelValues(node)); density != expected {
+					t.Errorf("expected %v control planes on node %q, got %v", expected, node, density)
+				}
+			}
+			for node, expected := range tc.expectedPods {
+				if pods := testutil.ToFloat64(nodeControlPlanePodsMetric.WithLabelValues(node)); pods != expected {
+					t.Errorf("expected %v control plane pods on node %q, got %v", expected, node, pods)
+				}
+			}
+			if nodes := testutil.ToFloat64(recommendedNodesMetric); nodes != tc.expectedNodes {
+				t.Errorf("expected %v recommended nodes, got %v", tc.expectedNodes, nodes)
+			}
+		})
+	}
+}
+
+func TestReconcileEvictionError(t *testing.T) {
+	seed := &kubermaticv1.Seed{Spec: kubermaticv1.SeedSpec{
+		ControlPlaneDensity: &kubermaticv1.ControlPlaneDensitySettings{MaxControlPlanesPerNode: 1, Rebalance: true},
+	}}
+	r := &Reconciler{
+		Client: fakectrlruntimeclient.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+			node("node-a", true),
+			node("node-b", true),
+			cluster("a"), cluster("b"),
+			pod("a", "apiserver-1", "node-a", "ReplicaSet"),
+			pod("b", "apiserver-1", "node-a", "ReplicaSet"),
+		).Build(),
+		log:        zap.NewNop().Sugar(),
+		recorder:   record.NewFakeRecorder(10),
+		seedGetter: func() (*kubermaticv1.Seed, error) { return seed, nil },
+		evict: func(_ context.Context, pod *corev1.Pod) error {
+			return kerrors.NewForbidden(schema.GroupResource{Resource: "pods"}, pod.Name, errors.New("forbidden"))
+		},
+	}
+
+	if err := r.reconcile(context.Background()); err == nil {
+		t.Fatal("expected reconciling to fail if a pod cannot be evicted")
+	}
+}
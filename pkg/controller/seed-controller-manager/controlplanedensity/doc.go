@@ -0,0 +1,29 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package controlplanedensity contains a controller that computes how many user
+cluster control planes each node of the seed cluster hosts and exposes it as
+metrics.
+
+If the seed configures a maximum number of control planes per node, nodes above
+it get a warning event and the number of nodes needed to stay below it is
+exposed as well, to guide scaling the seed. Optionally, the control planes are
+rebalanced by evicting the pods of one control plane at a time from the most
+overloaded node, similar to the descheduler, so that the scheduler places them
+on nodes with fewer control planes.
+*/
+package controlplanedensity
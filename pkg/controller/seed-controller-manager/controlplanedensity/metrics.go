@@ -0,0 +1,58 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controlplanedensity
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	nodeControlPlanesMetric = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "kubermatic",
+		Subsystem: "seed",
+		Name:      "node_control_planes",
+		Help:      "The number of user cluster control planes with pods on the given seed node",
+	}, []string{"node"})
+
+	nodeControlPlanePodsMetric = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "kubermatic",
+		Subsystem: "seed",
+		Name:      "node_control_plane_pods",
+		Help:      "The number of user cluster control plane pods on the given seed node",
+	}, []string{"node"})
+
+	maxControlPlanesPerNodeMetric = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "kubermatic",
+		Subsystem: "seed",
+		Name:      "max_control_planes_per_node",
+		Help:      "The maximum number of user cluster control planes per seed node configured for the seed, 0 if unset",
+	})
+
+	recommendedNodesMetric = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "kubermatic",
+		Subsystem: "seed",
+		Name:      "recommended_nodes",
+		Help:      "The number of schedulable seed nodes needed to host the user cluster control planes without exceeding the configured maximum per node, 0 if unset",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(nodeControlPlanesMetric)
+	prometheus.MustRegister(nodeControlPlanePodsMetric)
+	prometheus.MustRegister(maxControlPlanesPerNodeMetric)
+	prometheus.MustRegister(recommendedNodesMetric)
+}
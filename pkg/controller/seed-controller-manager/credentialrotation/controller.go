@@ -0,0 +1,206 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentialrotation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1/helper"
+	"k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	metricsserver "k8c.io/kubermatic/v2/pkg/resources/metrics-server"
+	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	ControllerName = "kubermatic_credential_rotation_controller"
+
+	// DefaultRotationInterval is the default maximum age of the credentials of a cluster.
+	DefaultRotationInterval = 90 * 24 * time.Hour
+)
+
+// rotatedSecrets are the secrets in the cluster namespace which are rotated. The CAs are not
+// rotated, as the certificates in the user cluster are signed by them.
+var rotatedSecrets = []string{
+	resources.ViewerTokenSecretName,
+	resources.OpenVPNServerCertificatesSecretName,
+	resources.OpenVPNClientCertificatesSecretName,
+	resources.MetricsServerKubeconfigSecretName,
+	metricsserver.ServingCertSecretName,
+	resources.KubeStateMetricsKubeconfigSecretName,
+}
+
+type Reconciler struct {
+	ctrlruntimeclient.Client
+
+	workerName       string
+	recorder         record.EventRecorder
+	log              *zap.SugaredLogger
+	versions         kubermatic.Versions
+	rotationInterval time.Duration
+
+	// now is overridden in tests
+	now func() time.Time
+}
+
+// Add creates a new credential rotation controller
+func Add(mgr manager.Manager, numWorkers int, workerName string, log *zap.SugaredLogger, versions kubermatic.Versions, rotationInterval time.Duration) error {
+	reconciler := &Reconciler{
+		Client: mgr.GetClient(),
+
+		workerName:       workerName,
+		recorder:         mgr.GetEventRecorderFor(ControllerName),
+		log:              log.Named(ControllerName),
+		versions:         versions,
+		rotationInterval: rotationInterval,
+		now:              time.Now,
+	}
+
+	c, err := controller.New(ControllerName, mgr, controller.Options{
+		Reconciler:              reconciler,
+		MaxConcurrentReconciles: numWorkers,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create controller: %v", err)
+	}
+
+	if err := c.Watch(&source.Kind{Type: &kubermaticv1.Cluster{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("failed to create watch: %v", err)
+	}
+
+	return nil
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	cluster := &kubermaticv1.Cluster{}
+	if err := r.Get(ctx, request.NamespacedName, cluster); err != nil {
+		if kerrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	// The rotation is periodic, so there is no condition which could ever become true.
+	result, err := kubermaticv1helper.ClusterReconcileWrapper(
+		ctx,
+		r.Client,
+		r.workerName,
+		cluster,
+		r.versions,
+		kubermaticv1.ClusterConditionNone,
+		func() (*reconcile.Result, error) {
+			return r.reconcile(ctx, cluster)
+		},
+	)
+	if err != nil {
+		r.log.Errorw("Failed to reconcile cluster", "cluster", cluster.Name, zap.Error(err))
+		r.recorder.Event(cluster, corev1.EventTypeWarning, "ReconcilingError", err.Error())
+	}
+	if result == nil {
+		result = &reconcile.Result{}
+	}
+	return *result, err
+}
+
+func (r *Reconciler) reconcile(ctx context.Context, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
+	if cluster.DeletionTimestamp != nil || cluster.Status.NamespaceName == "" {
+		return nil, nil
+	}
+
+	// Rotating credentials restarts the control plane, which is only done for running clusters.
+	// We get notified once the API server becomes healthy, no need to requeue.
+	if cluster.Status.ExtendedHealth.Apiserver != kubermaticv1.HealthStatusUp {
+		return nil, nil
+	}
+
+	now := r.now()
+	nextRotation := now.Add(r.rotationInterval)
+	oldCluster := cluster.DeepCopy()
+
+	if cluster.Address.AdminToken != "" {
+		rotationTime, ok := cluster.Status.GetCredentialRotationTime(kubermaticv1.AdminTokenCredentialName)
+		if !ok {
+			rotationTime = cluster.CreationTimestamp
+		}
+		if r.isDue(rotationTime, now) {
+			// The token users and the admin kubeconfig are updated by the kubernetes controller.
+			cluster.Address.AdminToken = kubernetes.GenerateToken()
+			rotationTime = metav1.NewTime(now)
+			r.log.Infow("Rotated admin token", "cluster", cluster.Name)
+			r.recorder.Event(cluster, corev1.EventTypeNormal, "CredentialRotated", "Rotated admin token")
+		}
+		cluster.Status.SetCredentialRotationTime(kubermaticv1.AdminTokenCredentialName, rotationTime)
+		nextRotation = earliest(nextRotation, rotationTime.Add(r.rotationInterval))
+	}
+
+	for _, name := range rotatedSecrets {
+		secret := &corev1.Secret{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: name}, secret); err != nil {
+			// The secret is either not used by the cluster or being recreated.
+			if kerrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get secret %q: %v", name, err)
+		}
+
+		rotationTime := secret.CreationTimestamp
+		if r.isDue(rotationTime, now) {
+			if err := r.Delete(ctx, secret); err != nil && !kerrors.IsNotFound(err) {
+				return nil, fmt.Errorf("failed to delete secret %q: %v", name, err)
+			}
+			rotationTime = metav1.NewTime(now)
+			r.log.Infow("Rotated secret", "cluster", cluster.Name, "secret", name)
+			r.recorder.Eventf(cluster, corev1.EventTypeNormal, "CredentialRotated", "Rotated secret %s", name)
+		}
+		cluster.Status.SetCredentialRotationTime(name, rotationTime)
+		nextRotation = earliest(nextRotation, rotationTime.Add(r.rotationInterval))
+	}
+
+	if err := r.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+		return nil, fmt.Errorf("failed to update credential rotations: %v", err)
+	}
+
+	return &reconcile.Result{RequeueAfter: nextRotation.Sub(now)}, nil
+}
+
+func (r *Reconciler) isDue(rotationTime metav1.Time, now time.Time) bool {
+	return !rotationTime.Add(r.rotationInterval).After(now)
+}
+
+func earliest(a, b time.Time) time.Time {
+	if b.Before(a) {
+		return b
+	}
+	return a
+}
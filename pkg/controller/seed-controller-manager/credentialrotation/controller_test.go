@@ -0,0 +1,170 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentialrotation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReconcile(t *testing.T) {
+	now := time.Date(2021, 7, 1, 12, 0, 0, 0, time.UTC)
+	interval := 30 * 24 * time.Hour
+	created := metav1.NewTime(now.Add(-10 * 24 * time.Hour))
+
+	secret := func(name string, age time.Duration) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "cluster-test",
+				CreationTimestamp: metav1.NewTime(now.Add(-age)),
+			},
+		}
+	}
+
+	testCases := []struct {
+		name                string
+		health              kubermaticv1.HealthStatus
+		adminTokenRotation  *metav1.Time
+		secrets             []ctrlruntimeclient.Object
+		expectedRotated     []string
+		expectedKept        []string
+		expectedTokenChange bool
+		expectedRequeue     time.Duration
+	}{
+		{
+			name:   "cluster with unhealthy API server is skipped",
+			health: kubermaticv1.HealthStatusDown,
+			secrets: []ctrlruntimeclient.Object{
+				secret(resources.OpenVPNServerCertificatesSecretName, 40*24*time.Hour),
+			},
+			expectedKept: []string{resources.OpenVPNServerCertificatesSecretName},
+		},
+		{
+			name:   "recent credentials are kept",
+			health: kubermaticv1.HealthStatusUp,
+			secrets: []ctrlruntimeclient.Object{
+				secret(resources.OpenVPNServerCertificatesSecretName, 5*24*time.Hour),
+				secret(resources.ViewerTokenSecretName, 15*24*time.Hour),
+			},
+			expectedKept:    []string{resources.OpenVPNServerCertificatesSecretName, resources.ViewerTokenSecretName},
+			expectedRequeue: 15 * 24 * time.Hour,
+		},
+		{
+			name:   "outdated credentials are rotated",
+			health: kubermaticv1.HealthStatusUp,
+			secrets: []ctrlruntimeclient.Object{
+				secret(resources.OpenVPNServerCertificatesSecretName, 40*24*time.Hour),
+				secret(resources.MetricsServerKubeconfigSecretName, 5*24*time.Hour),
+			},
+			adminTokenRotation:  &metav1.Time{Time: now.Add(-31 * 24 * time.Hour)},
+			expectedRotated:     []string{resources.OpenVPNServerCertificatesSecretName},
+			expectedKept:        []string{resources.MetricsServerKubeconfigSecretName},
+			expectedTokenChange: true,
+			expectedRequeue:     25 * 24 * time.Hour,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := &kubermaticv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", CreationTimestamp: created},
+				Address:    kubermaticv1.ClusterAddress{AdminToken: "abcdef.0123456789abcdef"},
+				Status: kubermaticv1.ClusterStatus{
+					NamespaceName:  "cluster-test",
+					ExtendedHealth: kubermaticv1.ExtendedClusterHealth{Apiserver: tc.health},
+				},
+			}
+			if tc.adminTokenRotation != nil {
+				cluster.Status.SetCredentialRotationTime(kubermaticv1.AdminTokenCredentialName, *tc.adminTokenRotation)
+			}
+
+			r := &Reconciler{
+				Client: fakectrlruntimeclient.
+					NewClientBuilder().
+					WithScheme(scheme.Scheme).
+					WithObjects(append(tc.secrets, cluster)...).
+					Build(),
+				recorder:         &record.FakeRecorder{},
+				log:              zap.NewNop().Sugar(),
+				rotationInterval: interval,
+				now:              func() time.Time { return now },
+			}
+
+			ctx := context.Background()
+			result, err := r.reconcile(ctx, cluster)
+			if err != nil {
+				t.Fatalf("reconciling failed: %v", err)
+			}
+
+			if tc.expectedRequeue == 0 && result != nil {
+				t.Errorf("expected no requeue, got %v", result)
+			}
+			if tc.expectedRequeue != 0 && (result == nil || result.RequeueAfter != tc.expectedRequeue) {
+				t.Errorf("expected requeue after %v, got %v", tc.expectedRequeue, result)
+			}
+
+			for _, name := range tc.expectedRotated {
+				err := r.Get(ctx, types.NamespacedName{Namespace: "cluster-test", Name: name}, &corev1.Secret{})
+				if !kerrors.IsNotFound(err) {
+					t.Errorf("expected secret %q to be deleted, got %v", name, err)
+				}
+			}
+			for _, name := range tc.expectedKept {
+				if err := r.Get(ctx, types.NamespacedName{Namespace: "cluster-test", Name: name}, &corev1.Secret{}); err != nil {
+					t.Errorf("expected secret %q to be kept, got %v", name, err)
+				}
+			}
+
+			updated := &kubermaticv1.Cluster{}
+			if err := r.Get(ctx, types.NamespacedName{Name: cluster.Name}, updated); err != nil {
+				t.Fatalf("failed to get cluster: %v", err)
+			}
+			if tokenChanged := updated.Address.AdminToken != "abcdef.0123456789abcdef"; tokenChanged != tc.expectedTokenChange {
+				t.Errorf("expected admin token change to be %v, got %v", tc.expectedTokenChange, tokenChanged)
+			}
+			if tc.health != kubermaticv1.HealthStatusUp {
+				return
+			}
+			for _, name := range append(tc.expectedRotated, tc.expectedKept...) {
+				if _, ok := updated.Status.GetCredentialRotationTime(name); !ok {
+					t.Errorf("expected rotation time of secret %q to be recorded", name)
+				}
+			}
+			for _, name := range tc.expectedRotated {
+				if rotationTime, _ := updated.Status.GetCredentialRotationTime(name); !rotationTime.Time.Equal(now) {
+					t.Errorf("expected secret %q to be rotated at %v, got %v", name, now, rotationTime)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package credentialrotation contains a controller that periodically rotates
+the credentials Kubermatic generates for the control plane of user clusters:
+the admin and viewer tokens, the OpenVPN certificates and the credentials
+used to collect metrics.
+
+Secrets are rotated by deleting them, so that the kubernetes controller
+generates them again. The control plane components using them are restarted
+as the secret revisions are part of their pod template labels. The rotation
+times are stored in the cluster status and shown by the REST API.
+*/
+package credentialrotation
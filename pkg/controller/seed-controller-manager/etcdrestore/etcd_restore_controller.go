@@ -22,7 +22,6 @@ import (
 	"reflect"
 	"time"
 
-	"github.com/minio/minio-go"
 	"go.uber.org/zap"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
@@ -190,13 +189,13 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, rest
 	}
 
 	// check that the backup to restore from exists and is accessible
-	s3Client, bucketName, err := resources.GetEtcdRestoreS3Client(ctx, restore, true, r.Client, cluster)
+	store, err := resources.GetEtcdRestoreStore(ctx, restore, true, r.Client, cluster)
 	if err != nil {
-		return nil, fmt.Errorf("failed to obtain S3 client: %w", err)
+		return nil, fmt.Errorf("failed to obtain backup storage client: %w", err)
 	}
 
 	objectName := fmt.Sprintf("%s-%s", cluster.GetName(), restore.Spec.BackupName)
-	if _, err := s3Client.StatObject(bucketName, objectName, minio.StatObjectOptions{}); err != nil {
+	if _, err := store.Stat(ctx, objectName); err != nil {
 		return nil, fmt.Errorf("could not access backup object %s: %w", objectName, err)
 	}
 
@@ -300,12 +299,7 @@ func (r *Reconciler) rebuildEtcdStatefulset(ctx context.Context, log *zap.Sugare
 }
 
 func (r *Reconciler) updateCluster(ctx context.Context, cluster *kubermaticv1.Cluster, modify func(*kubermaticv1.Cluster)) error {
-	oldCluster := cluster.DeepCopy()
-	modify(cluster)
-	if reflect.DeepEqual(oldCluster, cluster) {
-		return nil
-	}
-	return r.Client.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster))
+	return kubermaticv1helper.UpdateCluster(ctx, r.Client, cluster, modify)
 }
 
 func (r *Reconciler) updateRestore(ctx context.Context, restore *kubermaticv1.EtcdRestore, modify func(*kubermaticv1.EtcdRestore)) error {
@@ -0,0 +1,337 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+	clusterclient "k8c.io/kubermatic/v2/pkg/cluster/client"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/eventbus"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/util/workerlabel"
+	"k8c.io/kubermatic/v2/pkg/uuid"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	ControllerName = "kubermatic_event_bus_controller"
+
+	// resyncPeriod is the interval in which the MachineDeployments of user clusters are checked
+	// for scaling, as they are not watched.
+	resyncPeriod = time.Minute
+)
+
+// UserClusterClientProvider provides functionality to get a user cluster client
+type UserClusterClientProvider interface {
+	GetClient(ctx context.Context, c *kubermaticv1.Cluster, options ...clusterclient.ConfigOption) (ctrlruntimeclient.Client, error)
+}
+
+// clusterState is the last published state of a cluster, events are published when the
+// current state differs from it.
+type clusterState struct {
+	project  string
+	deleting bool
+	version  string
+	health   map[string]kubermaticv1.HealthStatus
+	// replicas are the replicas by MachineDeployment, nil until they could be listed.
+	replicas map[string]int32
+	// backups are the finished backups by backup config and name, nil until the backup
+	// configs could be listed.
+	backups map[string]finishedBackup
+}
+
+type Reconciler struct {
+	ctrlruntimeclient.Client
+
+	log        *zap.SugaredLogger
+	seedGetter provider.SeedGetter
+	publisher  eventbus.Publisher
+	// startTime is used to tell clusters created while the controller was not running apart
+	// from new ones, only the latter are announced.
+	startTime time.Time
+
+	lock   sync.Mutex
+	states map[string]*clusterState
+
+	// listReplicas is overridden in tests
+	listReplicas func(ctx context.Context, cluster *kubermaticv1.Cluster) (map[string]int32, error)
+	now          func() time.Time
+	newID        func() (string, error)
+}
+
+// Add creates a new event bus controller, which publishes the lifecycle events of the clusters
+// of the seed.
+func Add(mgr manager.Manager, numWorkers int, workerName string, log *zap.SugaredLogger, seedGetter provider.SeedGetter,
+	userClusterConnectionProvider UserClusterClientProvider, publisher eventbus.Publisher) error {
+	reconciler := &Reconciler{
+		Client:     mgr.GetClient(),
+		log:        log.Named(ControllerName),
+		seedGetter: seedGetter,
+		publisher:  publisher,
+		startTime:  time.Now(),
+		states:     map[string]*clusterState{},
+		now:        time.Now,
+		newID:      uuid.UUID,
+	}
+	reconciler.listReplicas = func(ctx context.Context, cluster *kubermaticv1.Cluster) (map[string]int32, error) {
+		return listReplicas(ctx, userClusterConnectionProvider, cluster)
+	}
+
+	c, err := controller.New(ControllerName, mgr, controller.Options{Reconciler: reconciler, MaxConcurrentReconciles: numWorkers})
+	if err != nil {
+		return fmt.Errorf("failed to create controller: %v", err)
+	}
+
+	if err := c.Watch(&source.Kind{Type: &kubermaticv1.Cluster{}}, &handler.EnqueueRequestForObject{}, workerlabel.Predicates(workerName)); err != nil {
+		return fmt.Errorf("failed to create cluster watch: %v", err)
+	}
+
+	enqueueCluster := handler.EnqueueRequestsFromMapFunc(func(o ctrlruntimeclient.Object) []reconcile.Request {
+		config, ok := o.(*kubermaticv1.EtcdBackupConfig)
+		if !ok {
+			return nil
+		}
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: config.Spec.Cluster.Name}}}
+	})
+	if err := c.Watch(&source.Kind{Type: &kubermaticv1.EtcdBackupConfig{}}, enqueueCluster); err != nil {
+		return fmt.Errorf("failed to create backup config watch: %v", err)
+	}
+
+	// Pending events must be flushed before shutting down.
+	return mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		return publisher.Close()
+	}))
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	if err := r.reconcile(ctx, request.Name); err != nil {
+		r.log.Errorw("Failed to publish cluster events", "cluster", request.Name, zap.Error(err))
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{RequeueAfter: resyncPeriod}, nil
+}
+
+func (r *Reconciler) reconcile(ctx context.Context, clusterName string) error {
+	seed, err := r.seedGetter()
+	if err != nil {
+		return fmt.Errorf("failed to get seed: %v", err)
+	}
+
+	r.lock.Lock()
+	previous := r.states[clusterName]
+	r.lock.Unlock()
+
+	cluster := &kubermaticv1.Cluster{}
+	if err := r.Get(ctx, types.NamespacedName{Name: clusterName}, cluster); err != nil {
+		if !kerrors.IsNotFound(err) {
+			return err
+		}
+		if previous == nil {
+			return nil
+		}
+
+		if err := r.publish(ctx, seed.Name, previous.project, clusterName, eventbus.ClusterDeleted, nil); err != nil {
+			return err
+		}
+		r.lock.Lock()
+		delete(r.states, clusterName)
+		r.lock.Unlock()
+		return nil
+	}
+
+	current := r.currentState(ctx, cluster, previous)
+	events := r.events(cluster, previous, current)
+	for _, event := range events {
+		if err := r.publish(ctx, seed.Name, current.project, clusterName, event.eventType, event.data); err != nil {
+			// The state is kept, so all events get published again when retrying.
+			return err
+		}
+	}
+
+	r.lock.Lock()
+	r.states[clusterName] = current
+	r.lock.Unlock()
+	return nil
+}
+
+// currentState builds the state of the cluster. Replicas and backups which cannot be listed are
+// taken from the previous state, so they do not cause events.
+func (r *Reconciler) currentState(ctx context.Context, cluster *kubermaticv1.Cluster, previous *clusterState) *clusterState {
+	current := &clusterState{
+		project:  cluster.Labels[kubermaticv1.ProjectIDLabelKey],
+		deleting: cluster.DeletionTimestamp != nil,
+		version:  cluster.Spec.Version.String(),
+		health:   healthComponents(cluster.Status.ExtendedHealth),
+	}
+	if previous != nil {
+		current.replicas = previous.replicas
+		current.backups = previous.backups
+	}
+
+	if !current.deleting && cluster.Status.ExtendedHealth.Apiserver == kubermaticv1.HealthStatusUp {
+		replicas, err := r.listReplicas(ctx, cluster)
+		if err != nil {
+			r.log.Debugw("Failed to list MachineDeployments", "cluster", cluster.Name, zap.Error(err))
+		} else {
+			current.replicas = replicas
+		}
+	}
+
+	if cluster.Status.NamespaceName != "" {
+		backups, err := r.finishedBackups(ctx, cluster.Status.NamespaceName)
+		if err != nil {
+			r.log.Debugw("Failed to list backup configs", "cluster", cluster.Name, zap.Error(err))
+		} else {
+			current.backups = backups
+		}
+	}
+
+	return current
+}
+
+type event struct {
+	eventType eventbus.EventType
+	data      interface{}
+}
+
+// events returns the events for the changes from the previous to the current state of a
+// cluster. Without a previous state, only clusters created after the controller has been
+// started are announced, for all others the current state is taken as given.
+func (r *Reconciler) events(cluster *kubermaticv1.Cluster, previous, current *clusterState) []event {
+	var events []event
+
+	if previous == nil {
+		if cluster.CreationTimestamp.Time.After(r.startTime) {
+			events = append(events, event{eventbus.ClusterCreated, clusterData(cluster, "")})
+		}
+		previous = &clusterState{version: current.version, health: current.health}
+	}
+
+	if current.deleting && !previous.deleting {
+		events = append(events, event{eventbus.ClusterDeleting, clusterData(cluster, "")})
+	}
+
+	if current.version != previous.version {
+		events = append(events, event{eventbus.ClusterVersionChanged, clusterData(cluster, previous.version)})
+	}
+
+	for _, component := range healthComponentNames {
+		if prev, cur := previous.health[component], current.health[component]; prev != cur {
+			events = append(events, event{eventbus.ClusterHealthChanged, eventbus.HealthData{
+				Component: component,
+				Previous:  healthStatusName(prev),
+				Current:   healthStatusName(cur),
+			}})
+		}
+	}
+
+	if previous.replicas != nil {
+		for _, name := range sets.StringKeySet(current.replicas).List() {
+			if prev, ok := previous.replicas[name]; ok && prev != current.replicas[name] {
+				events = append(events, event{eventbus.NodesScaled, eventbus.NodesScaledData{
+					MachineDeployment: name,
+					Previous:          prev,
+					Current:           current.replicas[name],
+				}})
+			}
+		}
+	}
+
+	if previous.backups != nil {
+		for _, key := range sets.StringKeySet(current.backups).List() {
+			if backup := current.backups[key]; previous.backups[key].phase != backup.phase {
+				events = append(events, backupEvent(backup))
+			}
+		}
+	}
+
+	return events
+}
+
+func (r *Reconciler) publish(ctx context.Context, seed, project, cluster string, eventType eventbus.EventType, data interface{}) error {
+	id, err := r.newID()
+	if err != nil {
+		return fmt.Errorf("failed to generate event ID: %v", err)
+	}
+
+	event := eventbus.Event{
+		SchemaVersion: eventbus.SchemaVersion,
+		ID:            id,
+		Type:          eventType,
+		Time:          r.now().UTC(),
+		Seed:          seed,
+		Project:       project,
+		Cluster:       cluster,
+		Data:          data,
+	}
+	if err := r.publisher.Publish(ctx, event); err != nil {
+		return fmt.Errorf("failed to publish %s event: %v", eventType, err)
+	}
+	return nil
+}
+
+func clusterData(cluster *kubermaticv1.Cluster, previousVersion string) eventbus.ClusterData {
+	// The provider name is informational, clusters without a valid cloud spec are
+	// announced nevertheless.
+	providerName, _ := provider.ClusterCloudProviderName(cluster.Spec.Cloud)
+
+	return eventbus.ClusterData{
+		HumanReadableName: cluster.Spec.HumanReadableName,
+		Provider:          providerName,
+		Datacenter:        cluster.Spec.Cloud.DatacenterName,
+		Version:           cluster.Spec.Version.String(),
+		PreviousVersion:   previousVersion,
+	}
+}
+
+func listReplicas(ctx context.Context, userClusterConnectionProvider UserClusterClientProvider, cluster *kubermaticv1.Cluster) (map[string]int32, error) {
+	client, err := userClusterConnectionProvider.GetClient(ctx, cluster)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get usercluster client: %v", err)
+	}
+
+	machineDeployments := &clusterv1alpha1.MachineDeploymentList{}
+	// Kubermatic only creates MachineDeployments in the kube-system namespace, everything else is essentially unsupported
+	if err := client.List(ctx, machineDeployments, ctrlruntimeclient.InNamespace("kube-system")); err != nil {
+		return nil, fmt.Errorf("failed to list MachineDeployments: %v", err)
+	}
+
+	replicas := map[string]int32{}
+	for _, md := range machineDeployments.Items {
+		if md.Spec.Replicas != nil {
+			replicas[md.Name] = *md.Spec.Replicas
+		}
+	}
+	return replicas, nil
+}
@@ -0,0 +1,250 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/eventbus"
+	"k8c.io/kubermatic/v2/pkg/semver"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type fakePublisher struct {
+	events []eventbus.Event
+	err    error
+}
+
+func (p *fakePublisher) Publish(_ context.Context, event eventbus.Event) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.events = append(p.events, event)
+	return nil
+}
+
+func (p *fakePublisher) Close() error {
+	return nil
+}
+
+func (p *fakePublisher) types() []eventbus.EventType {
+	var types []eventbus.EventType
+	for _, event := range p.events {
+		types = append(types, event.Type)
+	}
+	p.events = nil
+	return types
+}
+
+func newTestReconciler(startTime time.Time, publisher *fakePublisher, replicas map[string]int32, objects ...ctrlruntimeclient.Object) *Reconciler {
+	return &Reconciler{
+		Client: fakectrlruntimeclient.
+			NewClientBuilder().
+			WithScheme(scheme.Scheme).
+			WithObjects(objects...).
+			Build(),
+		log: zap.NewNop().Sugar(),
+		seedGetter: func() (*kubermaticv1.Seed, error) {
+			return &kubermaticv1.Seed{ObjectMeta: metav1.ObjectMeta{Name: "europe-west3"}}, nil
+		},
+		publisher: publisher,
+		startTime: startTime,
+		states:    map[string]*clusterState{},
+		listReplicas: func(_ context.Context, _ *kubermaticv1.Cluster) (map[string]int32, error) {
+			// every listing returns new replicas, like listing the MachineDeployments
+			listed := map[string]int32{}
+			for name, count := range replicas {
+				listed[name] = count
+			}
+			return listed, nil
+		},
+		now:   time.Now,
+		newID: func() (string, error) { return "id", nil },
+	}
+}
+
+func newTestCluster(created time.Time) *kubermaticv1.Cluster {
+	return &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "w225mx4z66",
+			Labels:            map[string]string{kubermaticv1.ProjectIDLabelKey: "zxd9b5gm"},
+			CreationTimestamp: metav1.NewTime(created),
+			Finalizers:        []string{"kubermatic.io/cleanup"},
+		},
+		Spec: kubermaticv1.ClusterSpec{
+			Version: *semver.NewSemverOrDie("1.21.3"),
+		},
+		Status: kubermaticv1.ClusterStatus{
+			NamespaceName: "cluster-w225mx4z66",
+			ExtendedHealth: kubermaticv1.ExtendedClusterHealth{
+				Apiserver: kubermaticv1.HealthStatusUp,
+				Etcd:      kubermaticv1.HealthStatusUp,
+			},
+		},
+	}
+}
+
+func TestPublishClusterChanges(t *testing.T) {
+	ctx := context.Background()
+	startTime := time.Date(2021, 8, 1, 12, 0, 0, 0, time.UTC)
+
+	cluster := newTestCluster(startTime.Add(-time.Hour))
+	backupConfig := &kubermaticv1.EtcdBackupConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "daily", Namespace: "cluster-w225mx4z66"},
+		Status: kubermaticv1.EtcdBackupConfigStatus{
+			CurrentBackups: []kubermaticv1.BackupStatus{
+				{BackupName: "daily-2021-08-01", BackupPhase: kubermaticv1.BackupStatusPhaseCompleted},
+				{BackupName: "daily-2021-08-02", BackupPhase: kubermaticv1.BackupStatusPhaseRunning},
+			},
+		},
+	}
+	publisher := &fakePublisher{}
+	replicas := map[string]int32{"workers": 3}
+	r := newTestReconciler(startTime, publisher, replicas, cluster, backupConfig)
+
+	// The state of clusters which existed before the controller has been started is taken as given.
+	if err := r.reconcile(ctx, cluster.Name); err != nil {
+		t.Fatalf("failed to reconcile: %v", err)
+	}
+	if types := publisher.types(); len(types) != 0 {
+		t.Fatalf("expected no events for the initial state, got %v", types)
+	}
+
+	if err := r.Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(cluster), cluster); err != nil {
+		t.Fatalf("failed to get cluster: %v", err)
+	}
+	cluster.Spec.Version = *semver.NewSemverOrDie("1.22.1")
+	cluster.Status.ExtendedHealth.Etcd = kubermaticv1.HealthStatusDown
+	if err := r.Update(ctx, cluster); err != nil {
+		t.Fatalf("failed to update cluster: %v", err)
+	}
+	if err := r.Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(backupConfig), backupConfig); err != nil {
+		t.Fatalf("failed to get backup config: %v", err)
+	}
+	backupConfig.Status.CurrentBackups[1].BackupPhase = kubermaticv1.BackupStatusPhaseFailed
+	backupConfig.Status.CurrentBackups[1].BackupMessage = "upload failed"
+	if err := r.Update(ctx, backupConfig); err != nil {
+		t.Fatalf("failed to update backup config: %v", err)
+	}
+	replicas["workers"] = 5
+
+	if err := r.reconcile(ctx, cluster.Name); err != nil {
+		t.Fatalf("failed to reconcile: %v", err)
+	}
+
+	expected := []eventbus.Event{
+		{
+			SchemaVersion: eventbus.SchemaVersion,
+			ID:            "id",
+			Type:          eventbus.ClusterVersionChanged,
+			Seed:          "europe-west3",
+			Project:       "zxd9b5gm",
+			Cluster:       "w225mx4z66",
+			Data:          eventbus.ClusterData{Version: "1.22.1", PreviousVersion: "1.21.3"},
+		},
+		{
+			SchemaVersion: eventbus.SchemaVersion,
+			ID:            "id",
+			Type:          eventbus.ClusterHealthChanged,
+			Seed:          "europe-west3",
+			Project:       "zxd9b5gm",
+			Cluster:       "w225mx4z66",
+			Data:          eventbus.HealthData{Component: "etcd", Previous: "Up", Current: "Down"},
+		},
+		{
+			SchemaVersion: eventbus.SchemaVersion,
+			ID:            "id",
+			Type:          eventbus.NodesScaled,
+			Seed:          "europe-west3",
+			Project:       "zxd9b5gm",
+			Cluster:       "w225mx4z66",
+			Data:          eventbus.NodesScaledData{MachineDeployment: "workers", Previous: 3, Current: 5},
+		},
+		{
+			SchemaVersion: eventbus.SchemaVersion,
+			ID:            "id",
+			Type:          eventbus.BackupFailed,
+			Seed:          "europe-west3",
+			Project:       "zxd9b5gm",
+			Cluster:       "w225mx4z66",
+			Data:          eventbus.BackupData{BackupConfig: "daily", Backup: "daily-2021-08-02", Message: "upload failed"},
+		},
+	}
+	for i := range publisher.events {
+		publisher.events[i].Time = time.Time{}
+	}
+	if diff := deep.Equal(publisher.events, expected); diff != nil {
+		t.Fatalf("unexpected events: %v", diff)
+	}
+	publisher.events = nil
+
+	// Nothing changed, nothing gets published.
+	if err := r.reconcile(ctx, cluster.Name); err != nil {
+		t.Fatalf("failed to reconcile: %v", err)
+	}
+	if types := publisher.types(); len(types) != 0 {
+		t.Fatalf("expected no events without changes, got %v", types)
+	}
+
+	cluster.Finalizers = nil
+	if err := r.Update(ctx, cluster); err != nil {
+		t.Fatalf("failed to update cluster: %v", err)
+	}
+	if err := r.Delete(ctx, cluster); err != nil {
+		t.Fatalf("failed to delete cluster: %v", err)
+	}
+	if err := r.reconcile(ctx, cluster.Name); err != nil {
+		t.Fatalf("failed to reconcile: %v", err)
+	}
+	if diff := deep.Equal(publisher.types(), []eventbus.EventType{eventbus.ClusterDeleted}); diff != nil {
+		t.Errorf("unexpected events: %v", diff)
+	}
+}
+
+func TestPublishNewCluster(t *testing.T) {
+	ctx := context.Background()
+	startTime := time.Date(2021, 8, 1, 12, 0, 0, 0, time.UTC)
+
+	cluster := newTestCluster(startTime.Add(time.Minute))
+	cluster.DeletionTimestamp = &metav1.Time{Time: startTime.Add(time.Hour)}
+	publisher := &fakePublisher{err: errors.New("bus is unavailable")}
+	r := newTestReconciler(startTime, publisher, nil, cluster)
+
+	// Events must not get lost if they cannot be published.
+	if err := r.reconcile(ctx, cluster.Name); err == nil {
+		t.Fatal("expected the reconciliation to fail")
+	}
+
+	publisher.err = nil
+	if err := r.reconcile(ctx, cluster.Name); err != nil {
+		t.Fatalf("failed to reconcile: %v", err)
+	}
+	if diff := deep.Equal(publisher.types(), []eventbus.EventType{eventbus.ClusterCreated, eventbus.ClusterDeleting}); diff != nil {
+		t.Errorf("unexpected events: %v", diff)
+	}
+}
@@ -0,0 +1,27 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package eventbus contains a controller that publishes the lifecycle events of
+the user clusters of a seed to NATS or Kafka, see pkg/eventbus for the schema.
+
+The controller compares the clusters, their MachineDeployments and etcd backups
+with the state it last published and publishes an event for every change. The
+state is kept in memory, so changes that happen while the controller is not
+running are not published. Events are delivered at least once, consumers can
+use the event ID to drop duplicates.
+*/
+package eventbus
@@ -0,0 +1,124 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/eventbus"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// healthComponentNames are the names of the components in the extended health of a cluster,
+// in the order their events are published.
+var healthComponentNames = []string{
+	"apiserver",
+	"scheduler",
+	"controller",
+	"machineController",
+	"etcd",
+	"openvpn",
+	"cloudProviderInfrastructure",
+	"userClusterControllerManager",
+	"gatekeeperController",
+	"gatekeeperAudit",
+	"logShipping",
+	"nodes",
+}
+
+func healthComponents(health kubermaticv1.ExtendedClusterHealth) map[string]kubermaticv1.HealthStatus {
+	return map[string]kubermaticv1.HealthStatus{
+		"apiserver":                    health.Apiserver,
+		"scheduler":                    health.Scheduler,
+		"controller":                   health.Controller,
+		"machineController":            health.MachineController,
+		"etcd":                         health.Etcd,
+		"openvpn":                      health.OpenVPN,
+		"cloudProviderInfrastructure":  health.CloudProviderInfrastructure,
+		"userClusterControllerManager": health.UserClusterControllerManager,
+		"gatekeeperController":         health.GatekeeperController,
+		"gatekeeperAudit":              health.GatekeeperAudit,
+		"logShipping":                  health.LogShipping,
+		"nodes":                        health.Nodes,
+	}
+}
+
+func healthStatusName(status kubermaticv1.HealthStatus) string {
+	switch status {
+	case kubermaticv1.HealthStatusUp:
+		return "Up"
+	case kubermaticv1.HealthStatusProvisioning:
+		return "Provisioning"
+	default:
+		return "Down"
+	}
+}
+
+// finishedBackup is a backup which has either been completed or has failed.
+type finishedBackup struct {
+	phase kubermaticv1.BackupStatusPhase
+	data  eventbus.BackupData
+}
+
+// finishedBackups returns the finished backups of the backup configs in the cluster namespace,
+// by backup config and backup name.
+func (r *Reconciler) finishedBackups(ctx context.Context, namespace string) (map[string]finishedBackup, error) {
+	configs := &kubermaticv1.EtcdBackupConfigList{}
+	if err := r.List(ctx, configs, ctrlruntimeclient.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list backup configs: %v", err)
+	}
+
+	backups := map[string]finishedBackup{}
+	for _, config := range configs.Items {
+		for _, backup := range config.Status.CurrentBackups {
+			if backup.BackupPhase != kubermaticv1.BackupStatusPhaseCompleted && backup.BackupPhase != kubermaticv1.BackupStatusPhaseFailed {
+				continue
+			}
+			backups[config.Name+"/"+backup.BackupName] = finishedBackup{
+				phase: backup.BackupPhase,
+				data: eventbus.BackupData{
+					BackupConfig: config.Name,
+					Backup:       backup.BackupName,
+					Message:      backup.BackupMessage,
+					StartTime:    timePtr(backup.BackupStartTime),
+					FinishedTime: timePtr(backup.BackupFinishedTime),
+				},
+			}
+		}
+	}
+	return backups, nil
+}
+
+func backupEvent(backup finishedBackup) event {
+	if backup.phase == kubermaticv1.BackupStatusPhaseFailed {
+		return event{eventbus.BackupFailed, backup.data}
+	}
+	return event{eventbus.BackupCompleted, backup.data}
+}
+
+func timePtr(t *metav1.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.Time.UTC()
+	return &utc
+}
@@ -29,6 +29,7 @@ import (
 	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1/helper"
 	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
 	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/provider/cloud/azure"
 	"k8c.io/kubermatic/v2/pkg/provider/kubernetes"
 	machineresource "k8c.io/kubermatic/v2/pkg/resources/machine"
 	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
@@ -190,6 +191,11 @@ func (r *Reconciler) createInitialMachineDeployment(ctx context.Context, nodeDep
 		return fmt.Errorf("failed to get SSH keys: %v", err)
 	}
 
+	cluster, err = azure.AddMachineDeploymentAvailabilitySet(ctx, r, cluster, nodeDeployment)
+	if err != nil {
+		return err
+	}
+
 	data := common.CredentialsData{
 		Ctx:               ctx,
 		KubermaticCluster: cluster,
@@ -19,7 +19,6 @@ package kubernetes
 import (
 	"context"
 	"fmt"
-	"reflect"
 	"time"
 
 	"go.uber.org/zap"
@@ -45,8 +44,6 @@ import (
 	autoscalingv1beta2 "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1beta2"
 	"k8s.io/client-go/tools/record"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller"
-	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
@@ -189,7 +186,7 @@ func Add(
 		versions: versions,
 	}
 
-	c, err := controller.New(ControllerName, mgr, controller.Options{Reconciler: reconciler, MaxConcurrentReconciles: numWorkers})
+	c, err := controllerutil.NewPrioritizedController(ControllerName, mgr, reconciler.log, reconciler, numWorkers)
 	if err != nil {
 		return err
 	}
@@ -210,12 +207,18 @@ func Add(
 	}
 
 	for _, t := range typesToWatch {
-		if err := c.Watch(&source.Kind{Type: t}, controllerutil.EnqueueClusterForNamespacedObject(mgr.GetClient())); err != nil {
+		if err := c.Watch(&source.Kind{Type: t}, c.Background(controllerutil.EnqueueClusterForNamespacedObject(mgr.GetClient()))); err != nil {
 			return fmt.Errorf("failed to create watcher for %T: %v", t, err)
 		}
 	}
 
-	return c.Watch(&source.Kind{Type: &kubermaticv1.Cluster{}}, &handler.EnqueueRequestForObject{})
+	// rotated cloud credentials have to be written into the cloud-config and the machine-controller
+	// without waiting for the next change to the cluster
+	if err := c.Watch(&source.Kind{Type: &corev1.Secret{}}, controllerutil.EnqueueClustersForAzureCredentialsSecret(mgr.GetClient())); err != nil {
+		return fmt.Errorf("failed to create watcher for credential secrets: %v", err)
+	}
+
+	return c.Watch(&source.Kind{Type: &kubermaticv1.Cluster{}}, c.EnqueueRequestForObject())
 }
 
 func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
@@ -301,25 +304,19 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, clus
 	return res, nil
 }
 
-func (r *Reconciler) updateCluster(ctx context.Context, cluster *kubermaticv1.Cluster, modify func(*kubermaticv1.Cluster), opts ...ctrlruntimeclient.MergeFromOption) error {
-	oldCluster := cluster.DeepCopy()
-	modify(cluster)
-	if reflect.DeepEqual(oldCluster, cluster) {
-		return nil
-	}
-
-	return r.Patch(ctx, cluster, ctrlruntimeclient.MergeFromWithOptions(oldCluster, opts...))
+func (r *Reconciler) updateCluster(ctx context.Context, cluster *kubermaticv1.Cluster, modify func(*kubermaticv1.Cluster)) error {
+	return kubermaticv1helper.UpdateCluster(ctx, r, cluster, modify)
 }
 
 func (r *Reconciler) AddFinalizers(ctx context.Context, cluster *kubermaticv1.Cluster, finalizers ...string) (*reconcile.Result, error) {
 	if err := r.updateCluster(ctx, cluster, func(c *kubermaticv1.Cluster) {
 		kuberneteshelper.AddFinalizer(c, finalizers...)
-	}, ctrlruntimeclient.MergeFromWithOptimisticLock{}); err != nil {
+	}); err != nil {
 		if !kerrors.IsConflict(err) {
 			return nil, fmt.Errorf("failed to add finalizers %v: %w", finalizers, err)
 		}
-		// In case of conflict we just re-enqueue the item for later
-		// processing without returning an error.
+		// In case the conflict persists after retrying we just re-enqueue
+		// the item for later processing without returning an error.
 		r.log.Infow("failed to add finalizers", "error", err, "finalizers", finalizers)
 		return &reconcile.Result{Requeue: true}, nil
 	}
@@ -18,12 +18,15 @@ package kubernetes
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	kubermaticapiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
 
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -83,6 +86,15 @@ func (r *Reconciler) reconcileCluster(ctx context.Context, cluster *kubermaticv1
 	if !kuberneteshelper.HasFinalizer(cluster, kubermaticapiv1.ClusterRoleBindingsCleanupFinalizer) {
 		finalizers = append(finalizers, kubermaticapiv1.ClusterRoleBindingsCleanupFinalizer)
 	}
+	if !kuberneteshelper.HasFinalizer(cluster, kubermaticapiv1.PreDeleteHooksFinalizer) {
+		hasHooks, err := r.projectHasPreDeleteHooks(ctx, cluster)
+		if err != nil {
+			return nil, err
+		}
+		if hasHooks {
+			finalizers = append(finalizers, kubermaticapiv1.PreDeleteHooksFinalizer)
+		}
+	}
 
 	if len(finalizers) > 0 {
 		return r.AddFinalizers(ctx, cluster, finalizers...)
@@ -91,6 +103,24 @@ func (r *Reconciler) reconcileCluster(ctx context.Context, cluster *kubermaticv1
 	return &reconcile.Result{}, nil
 }
 
+// projectHasPreDeleteHooks returns whether the project of the cluster defines pre-delete hooks
+func (r *Reconciler) projectHasPreDeleteHooks(ctx context.Context, cluster *kubermaticv1.Cluster) (bool, error) {
+	projectID := cluster.Labels[kubermaticv1.ProjectIDLabelKey]
+	if projectID == "" {
+		return false, nil
+	}
+
+	project := &kubermaticv1.Project{}
+	if err := r.Get(ctx, types.NamespacedName{Name: projectID}, project); err != nil {
+		if kerrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get project %q: %v", projectID, err)
+	}
+
+	return len(project.Spec.PreDeleteHooks) > 0, nil
+}
+
 // ensureEtcdLauncherFeatureFlag will apply seed controller etcdLauncher setting on the cluster level
 func (r *Reconciler) ensureEtcdLauncherFeatureFlag(ctx context.Context, cluster *kubermaticv1.Cluster) error {
 	return r.updateCluster(ctx, cluster, func(c *kubermaticv1.Cluster) {
@@ -18,6 +18,10 @@ limitations under the License.
 Package update contains a controller that auto applies updates to both the cluster version
 and the machine version based on a configuration file.
 
+Clusters with a patch upgrade policy are additionally upgraded to the latest patch release of their
+minor version during their update window. The control plane is rolled back if it does not become
+healthy in time after such an upgrade.
+
 TODO: Make this controller wait for successfully convergation after an update was applied. Currently,
 it may apply an update and then instantly apply another one, which is not supported, only n+1 minor
 version updates are supported.
@@ -0,0 +1,181 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package update
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/coreos/locksmith/pkg/timeutil"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/semver"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	defaultPatchUpgradeHealthCheckTimeout = 15 * time.Minute
+	// patchUpgradeVerificationInterval is the interval the health of an upgraded control
+	// plane is checked in.
+	patchUpgradeVerificationInterval = 30 * time.Second
+)
+
+// patchUpgrade upgrades the control plane to the latest patch release of its minor version, if
+// the cluster opted in and is inside its update window.
+func (r *Reconciler) patchUpgrade(ctx context.Context, cluster *kubermaticv1.Cluster, clusterType string) (*reconcile.Result, error) {
+	policy := cluster.Spec.PatchUpgradePolicy
+	if policy == nil || !policy.Enabled {
+		return nil, nil
+	}
+
+	if window := cluster.Spec.UpdateWindow; window != nil && window.Start != "" && window.Length != "" {
+		periodic, err := timeutil.ParsePeriodic(window.Start, window.Length)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse update window: %v", err)
+		}
+		// outside of the window, come back when it opens
+		if untilStart := periodic.DurationToStart(r.now()); untilStart > 0 {
+			return &reconcile.Result{RequeueAfter: untilStart}, nil
+		}
+	}
+
+	target, err := r.latestPatchRelease(cluster, clusterType)
+	if err != nil {
+		return nil, err
+	}
+	if target == nil {
+		return nil, nil
+	}
+
+	// a release which was rolled back is not tried again
+	if status := cluster.Status.PatchUpgrade; status != nil && status.Phase == kubermaticv1.PatchUpgradePhaseRolledBack && status.ToVersion == target.String() {
+		return nil, nil
+	}
+
+	oldCluster := cluster.DeepCopy()
+	fromVersion := cluster.Spec.Version.String()
+
+	cluster.Spec.Version = *target
+	cluster.Status.PatchUpgrade = &kubermaticv1.PatchUpgradeStatus{
+		Phase:       kubermaticv1.PatchUpgradePhaseInProgress,
+		FromVersion: fromVersion,
+		ToVersion:   target.String(),
+		StartedAt:   metav1.NewTime(r.now()),
+	}
+	invalidateControlPlaneHealth(cluster)
+	if err := r.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+		return nil, fmt.Errorf("failed to update cluster: %v", err)
+	}
+	r.recorder.Eventf(cluster, corev1.EventTypeNormal, "PatchUpgradeStarted", "Upgrading the control plane from %s to patch release %s", fromVersion, target.String())
+
+	return &reconcile.Result{RequeueAfter: patchUpgradeVerificationInterval}, nil
+}
+
+// verifyPatchUpgrade waits for the upgraded control plane to become healthy and rolls it back
+// to the previous version if it does not in time.
+func (r *Reconciler) verifyPatchUpgrade(ctx context.Context, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
+	status := cluster.Status.PatchUpgrade
+	oldCluster := cluster.DeepCopy()
+
+	switch {
+	case cluster.Spec.Version.String() != status.ToVersion:
+		// the version was changed in the meantime, there is nothing left to verify
+		cluster.Status.PatchUpgrade = nil
+
+	case controlPlaneHealthy(cluster.Status.ExtendedHealth):
+		now := metav1.NewTime(r.now())
+		status.Phase = kubermaticv1.PatchUpgradePhaseSucceeded
+		status.FinishedAt = &now
+		r.recorder.Eventf(cluster, corev1.EventTypeNormal, "PatchUpgradeSucceeded", "The control plane is healthy after the upgrade to patch release %s", status.ToVersion)
+
+	default:
+		timeout := defaultPatchUpgradeHealthCheckTimeout
+		if policy := cluster.Spec.PatchUpgradePolicy; policy != nil && policy.HealthCheckTimeout != nil {
+			timeout = policy.HealthCheckTimeout.Duration
+		}
+		if remaining := status.StartedAt.Add(timeout).Sub(r.now()); remaining > 0 {
+			if remaining > patchUpgradeVerificationInterval {
+				remaining = patchUpgradeVerificationInterval
+			}
+			return &reconcile.Result{RequeueAfter: remaining}, nil
+		}
+
+		fromVersion, err := semver.NewSemver(status.FromVersion)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the version before the upgrade: %v", err)
+		}
+
+		now := metav1.NewTime(r.now())
+		cluster.Spec.Version = *fromVersion
+		status.Phase = kubermaticv1.PatchUpgradePhaseRolledBack
+		status.FinishedAt = &now
+		invalidateControlPlaneHealth(cluster)
+		r.recorder.Eventf(cluster, corev1.EventTypeWarning, "PatchUpgradeRolledBack", "The control plane did not become healthy within %v after the upgrade to patch release %s, rolled back to %s", timeout, status.ToVersion, status.FromVersion)
+	}
+
+	if err := r.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+		return nil, fmt.Errorf("failed to update cluster: %v", err)
+	}
+
+	return nil, nil
+}
+
+// latestPatchRelease returns the latest patch release of the minor version of the cluster the
+// control plane can be updated to, or nil if it already runs it.
+func (r *Reconciler) latestPatchRelease(cluster *kubermaticv1.Cluster, clusterType string) (*semver.Semver, error) {
+	current := cluster.Spec.Version.Semver()
+
+	updates, err := r.updateManager.GetPossibleUpdates(current.String(), clusterType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get possible updates for version %s: %v", current.String(), err)
+	}
+
+	var latest *semver.Semver
+	for _, update := range updates {
+		v := update.Version
+		if v.Major() != current.Major() || v.Minor() != current.Minor() || !v.GreaterThan(current) || v.Prerelease() != "" {
+			continue
+		}
+		if latest == nil || v.GreaterThan(latest.Semver()) {
+			latest = semver.NewSemverOrDie(v.String())
+		}
+	}
+
+	return latest, nil
+}
+
+// controlPlaneHealthy returns whether the components updated with the control plane version are
+// healthy, problems of other components must not cause a rollback.
+func controlPlaneHealthy(health kubermaticv1.ExtendedClusterHealth) bool {
+	return health.Apiserver == kubermaticv1.HealthStatusUp &&
+		health.Controller == kubermaticv1.HealthStatusUp &&
+		health.Scheduler == kubermaticv1.HealthStatusUp &&
+		health.Etcd == kubermaticv1.HealthStatusUp
+}
+
+// invalidateControlPlaneHealth marks the control plane as down, to prevent acting on the
+// health reported for the previous version.
+func invalidateControlPlaneHealth(cluster *kubermaticv1.Cluster) {
+	cluster.Status.ExtendedHealth.Apiserver = kubermaticv1.HealthStatusDown
+	cluster.Status.ExtendedHealth.Controller = kubermaticv1.HealthStatusDown
+	cluster.Status.ExtendedHealth.Scheduler = kubermaticv1.HealthStatusDown
+}
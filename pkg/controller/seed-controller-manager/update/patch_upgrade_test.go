@@ -0,0 +1,255 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package update
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	semverlib "github.com/Masterminds/semver/v3"
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+
+	v1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	clusterclient "k8c.io/kubermatic/v2/pkg/cluster/client"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+	"k8c.io/kubermatic/v2/pkg/semver"
+	"k8c.io/kubermatic/v2/pkg/version"
+	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func init() {
+	if err := clusterv1alpha1.SchemeBuilder.AddToScheme(scheme.Scheme); err != nil {
+		panic(fmt.Sprintf("failed to add clusterv1alpha1 to scheme: %v", err))
+	}
+}
+
+func TestPatchUpgrade(t *testing.T) {
+	// a Saturday
+	now := time.Date(2021, 7, 3, 12, 0, 0, 0, time.UTC)
+
+	healthy := kubermaticv1.ExtendedClusterHealth{
+		Apiserver:                    kubermaticv1.HealthStatusUp,
+		Scheduler:                    kubermaticv1.HealthStatusUp,
+		Controller:                   kubermaticv1.HealthStatusUp,
+		MachineController:            kubermaticv1.HealthStatusUp,
+		Etcd:                         kubermaticv1.HealthStatusUp,
+		CloudProviderInfrastructure:  kubermaticv1.HealthStatusUp,
+		UserClusterControllerManager: kubermaticv1.HealthStatusUp,
+	}
+	unhealthy := healthy
+	unhealthy.Apiserver = kubermaticv1.HealthStatusDown
+
+	testCases := []struct {
+		name            string
+		version         string
+		policy          *kubermaticv1.PatchUpgradePolicy
+		updateWindow    *kubermaticv1.UpdateWindow
+		health          kubermaticv1.ExtendedClusterHealth
+		status          *kubermaticv1.PatchUpgradeStatus
+		expectedVersion string
+		expectedPhase   kubermaticv1.PatchUpgradePhase
+		expectedRequeue time.Duration
+	}{
+		{
+			name:            "cluster without policy is not upgraded",
+			version:         "1.20.2",
+			health:          healthy,
+			expectedVersion: "1.20.2",
+		},
+		{
+			name:            "cluster is upgraded to the latest patch release",
+			version:         "1.20.2",
+			policy:          &kubermaticv1.PatchUpgradePolicy{Enabled: true},
+			health:          healthy,
+			expectedVersion: "1.20.5",
+			expectedPhase:   kubermaticv1.PatchUpgradePhaseInProgress,
+			expectedRequeue: patchUpgradeVerificationInterval,
+		},
+		{
+			name:            "cluster is upgraded inside its update window",
+			version:         "1.20.2",
+			policy:          &kubermaticv1.PatchUpgradePolicy{Enabled: true},
+			updateWindow:    &kubermaticv1.UpdateWindow{Start: "Sat 11:00", Length: "2h"},
+			health:          healthy,
+			expectedVersion: "1.20.5",
+			expectedPhase:   kubermaticv1.PatchUpgradePhaseInProgress,
+			expectedRequeue: patchUpgradeVerificationInterval,
+		},
+		{
+			name:            "cluster is not upgraded outside of its update window",
+			version:         "1.20.2",
+			policy:          &kubermaticv1.PatchUpgradePolicy{Enabled: true},
+			updateWindow:    &kubermaticv1.UpdateWindow{Start: "Sat 14:00", Length: "2h"},
+			health:          healthy,
+			expectedVersion: "1.20.2",
+			expectedRequeue: 2 * time.Hour,
+		},
+		{
+			name:            "unhealthy cluster is not upgraded",
+			version:         "1.20.2",
+			policy:          &kubermaticv1.PatchUpgradePolicy{Enabled: true},
+			health:          unhealthy,
+			expectedVersion: "1.20.2",
+		},
+		{
+			name:    "rolled back patch release is not applied again",
+			version: "1.20.2",
+			policy:  &kubermaticv1.PatchUpgradePolicy{Enabled: true},
+			health:  healthy,
+			status: &kubermaticv1.PatchUpgradeStatus{
+				Phase:       kubermaticv1.PatchUpgradePhaseRolledBack,
+				FromVersion: "1.20.2",
+				ToVersion:   "1.20.5",
+			},
+			expectedVersion: "1.20.2",
+			expectedPhase:   kubermaticv1.PatchUpgradePhaseRolledBack,
+		},
+		{
+			name:    "healthy control plane completes the upgrade",
+			version: "1.20.5",
+			policy:  &kubermaticv1.PatchUpgradePolicy{Enabled: true},
+			health:  healthy,
+			status: &kubermaticv1.PatchUpgradeStatus{
+				Phase:       kubermaticv1.PatchUpgradePhaseInProgress,
+				FromVersion: "1.20.2",
+				ToVersion:   "1.20.5",
+				StartedAt:   metav1.NewTime(now.Add(-5 * time.Minute)),
+			},
+			expectedVersion: "1.20.5",
+			expectedPhase:   kubermaticv1.PatchUpgradePhaseSucceeded,
+		},
+		{
+			name:    "unhealthy control plane is given time",
+			version: "1.20.5",
+			policy:  &kubermaticv1.PatchUpgradePolicy{Enabled: true},
+			health:  unhealthy,
+			status: &kubermaticv1.PatchUpgradeStatus{
+				Phase:       kubermaticv1.PatchUpgradePhaseInProgress,
+				FromVersion: "1.20.2",
+				ToVersion:   "1.20.5",
+				StartedAt:   metav1.NewTime(now.Add(-5 * time.Minute)),
+			},
+			expectedVersion: "1.20.5",
+			expectedPhase:   kubermaticv1.PatchUpgradePhaseInProgress,
+			expectedRequeue: patchUpgradeVerificationInterval,
+		},
+		{
+			name:    "unhealthy control plane is rolled back after the timeout",
+			version: "1.20.5",
+			policy:  &kubermaticv1.PatchUpgradePolicy{Enabled: true, HealthCheckTimeout: &metav1.Duration{Duration: 10 * time.Minute}},
+			health:  unhealthy,
+			status: &kubermaticv1.PatchUpgradeStatus{
+				Phase:       kubermaticv1.PatchUpgradePhaseInProgress,
+				FromVersion: "1.20.2",
+				ToVersion:   "1.20.5",
+				StartedAt:   metav1.NewTime(now.Add(-11 * time.Minute)),
+			},
+			expectedVersion: "1.20.2",
+			expectedPhase:   kubermaticv1.PatchUpgradePhaseRolledBack,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := &kubermaticv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: kubermaticv1.ClusterSpec{
+					Version:            *semver.NewSemverOrDie(tc.version),
+					PatchUpgradePolicy: tc.policy,
+					UpdateWindow:       tc.updateWindow,
+				},
+				Status: kubermaticv1.ClusterStatus{
+					ExtendedHealth: tc.health,
+					PatchUpgrade:   tc.status,
+				},
+			}
+
+			client := fakectrlruntimeclient.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(cluster).Build()
+			r := &Reconciler{
+				Client:                        client,
+				updateManager:                 newTestUpdateManager(),
+				userClusterConnectionProvider: &fakeClientProvider{client: client},
+				recorder:                      record.NewFakeRecorder(10),
+				log:                           kubermaticlog.Logger,
+				versions:                      kubermatic.NewDefaultVersions(),
+				now:                           func() time.Time { return now },
+			}
+
+			result, err := r.reconcile(context.Background(), cluster)
+			if err != nil {
+				t.Fatalf("reconciling failed: %v", err)
+			}
+
+			var requeue time.Duration
+			if result != nil {
+				requeue = result.RequeueAfter
+			}
+			if requeue != tc.expectedRequeue {
+				t.Errorf("expected requeue after %v, got %v", tc.expectedRequeue, requeue)
+			}
+
+			updated := &kubermaticv1.Cluster{}
+			if err := client.Get(context.Background(), types.NamespacedName{Name: "test"}, updated); err != nil {
+				t.Fatalf("failed to get cluster: %v", err)
+			}
+
+			if v := updated.Spec.Version.String(); v != tc.expectedVersion {
+				t.Errorf("expected version %s, got %s", tc.expectedVersion, v)
+			}
+
+			var phase kubermaticv1.PatchUpgradePhase
+			if updated.Status.PatchUpgrade != nil {
+				phase = updated.Status.PatchUpgrade.Phase
+			}
+			if phase != tc.expectedPhase {
+				t.Errorf("expected patch upgrade phase %q, got %q", tc.expectedPhase, phase)
+			}
+		})
+	}
+}
+
+func newTestUpdateManager() *version.Manager {
+	var versions []*version.Version
+	for _, v := range []string{"1.20.2", "1.20.4", "1.20.5", "1.21.0"} {
+		versions = append(versions, &version.Version{Version: semverlib.MustParse(v), Type: v1.KubernetesClusterType})
+	}
+
+	updates := []*version.Update{
+		{From: "1.20.*", To: "1.20.*", Type: v1.KubernetesClusterType},
+		{From: "1.20.*", To: "1.21.*", Type: v1.KubernetesClusterType},
+	}
+
+	return version.New(versions, updates)
+}
+
+type fakeClientProvider struct {
+	client ctrlruntimeclient.Client
+}
+
+func (f *fakeClientProvider) GetClient(ctx context.Context, c *kubermaticv1.Cluster, options ...clusterclient.ConfigOption) (ctrlruntimeclient.Client, error) {
+	return f.client, nil
+}
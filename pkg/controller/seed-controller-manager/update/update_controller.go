@@ -47,20 +47,26 @@ const (
 	ControllerName = "kubermatic_update_controller"
 )
 
+// UserClusterClientProvider provides functionality to get a user cluster client
+type UserClusterClientProvider interface {
+	GetClient(ctx context.Context, c *kubermaticv1.Cluster, options ...client.ConfigOption) (ctrlruntimeclient.Client, error)
+}
+
 type Reconciler struct {
 	ctrlruntimeclient.Client
 
 	workerName                    string
 	updateManager                 *version.Manager
 	recorder                      record.EventRecorder
-	userClusterConnectionProvider *client.Provider
+	userClusterConnectionProvider UserClusterClientProvider
 	log                           *zap.SugaredLogger
 	versions                      kubermatic.Versions
+	now                           func() time.Time
 }
 
 // Add creates a new update controller
 func Add(mgr manager.Manager, numWorkers int, workerName string, updateManager *version.Manager,
-	userClusterConnectionProvider *client.Provider, log *zap.SugaredLogger, versions kubermatic.Versions) error {
+	userClusterConnectionProvider UserClusterClientProvider, log *zap.SugaredLogger, versions kubermatic.Versions) error {
 	reconciler := &Reconciler{
 		Client: mgr.GetClient(),
 
@@ -70,6 +76,7 @@ func Add(mgr manager.Manager, numWorkers int, workerName string, updateManager *
 		userClusterConnectionProvider: userClusterConnectionProvider,
 		log:                           log,
 		versions:                      versions,
+		now:                           time.Now,
 	}
 
 	c, err := controller.New(ControllerName, mgr, controller.Options{
@@ -119,6 +126,12 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 }
 
 func (r *Reconciler) reconcile(ctx context.Context, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
+	// An automatic patch upgrade must be verified even if the control plane is not healthy,
+	// so it can be rolled back
+	if status := cluster.Status.PatchUpgrade; status != nil && status.Phase == kubermaticv1.PatchUpgradePhaseInProgress {
+		return r.verifyPatchUpgrade(ctx, cluster)
+	}
+
 	if !cluster.Status.ExtendedHealth.AllHealthy() {
 		// Cluster not healthy yet. Nothing to do.
 		// If it gets healthy we'll get notified by the event. No need to requeue
@@ -138,11 +151,19 @@ func (r *Reconciler) reconcile(ctx context.Context, cluster *kubermaticv1.Cluste
 		return &reconcile.Result{RequeueAfter: time.Minute}, nil
 	}
 
+	result, err := r.patchUpgrade(ctx, cluster, v1.KubernetesClusterType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upgrade the controlplane to the latest patch release: %v", err)
+	}
+	if cluster.Status.PatchUpgrade != nil && cluster.Status.PatchUpgrade.Phase == kubermaticv1.PatchUpgradePhaseInProgress {
+		return result, nil
+	}
+
 	if err := r.nodeUpdate(ctx, cluster, v1.KubernetesClusterType); err != nil {
 		return nil, fmt.Errorf("failed to update machineDeployments: %v", err)
 	}
 
-	return nil, nil
+	return result, nil
 }
 
 func (r *Reconciler) nodeUpdate(ctx context.Context, cluster *kubermaticv1.Cluster, clusterType string) error {
@@ -188,9 +209,7 @@ func (r *Reconciler) controlPlaneUpgrade(ctx context.Context, cluster *kubermati
 
 	cluster.Spec.Version = *semver.NewSemverOrDie(update.Version.String())
 	// Invalidating the health to prevent automatic updates directly on the next processing.
-	cluster.Status.ExtendedHealth.Apiserver = kubermaticv1.HealthStatusDown
-	cluster.Status.ExtendedHealth.Controller = kubermaticv1.HealthStatusDown
-	cluster.Status.ExtendedHealth.Scheduler = kubermaticv1.HealthStatusDown
+	invalidateControlPlaneHealth(cluster)
 	if err := r.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
 		return false, fmt.Errorf("failed to update cluster: %v", err)
 	}
@@ -0,0 +1,434 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package canaryrollout
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"go.uber.org/zap"
+
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	machineconversions "k8c.io/kubermatic/v2/pkg/machine"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	// This controller creates events on the machine deployments, so do not put the word Kubermatic in it
+	controllerName = "canary_rollout_controller"
+
+	// canarySuffix is appended to the name of a machine deployment to get the name of its canary.
+	canarySuffix = "-canary"
+	// defaultReplicas is the default number of canary machines.
+	defaultReplicas = 1
+	// defaultTimeout is the default time the canary nodes have to pass the validation.
+	defaultTimeout = 30 * time.Minute
+	// requeueInterval is the interval in which the canary nodes are checked while a rollout is in progress.
+	requeueInterval = 30 * time.Second
+)
+
+type reconciler struct {
+	log      *zap.SugaredLogger
+	client   ctrlruntimeclient.Client
+	recorder record.EventRecorder
+	now      func() time.Time
+}
+
+func Add(ctx context.Context, log *zap.SugaredLogger, mgr manager.Manager) error {
+	log = log.Named(controllerName)
+
+	r := &reconciler{
+		log:      log,
+		client:   mgr.GetClient(),
+		recorder: mgr.GetEventRecorderFor(controllerName),
+		now:      time.Now,
+	}
+	c, err := controller.New(controllerName, mgr, controller.Options{
+		Reconciler: r,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create controller: %v", err)
+	}
+
+	if err := c.Watch(&source.Kind{Type: &clusterv1alpha1.MachineDeployment{}}, enqueueRolledOutMachineDeployment()); err != nil {
+		return fmt.Errorf("failed to establish watch for machine deployments: %v", err)
+	}
+
+	if err := c.Watch(&source.Kind{Type: &batchv1.Job{}}, enqueueRolledOutMachineDeployment()); err != nil {
+		return fmt.Errorf("failed to establish watch for jobs: %v", err)
+	}
+
+	return nil
+}
+
+// enqueueRolledOutMachineDeployment enqueues the machine deployment an object belongs to. Canary
+// machine deployments and validation jobs are mapped to the machine deployment being rolled out.
+func enqueueRolledOutMachineDeployment() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(a ctrlruntimeclient.Object) []reconcile.Request {
+		name := a.GetLabels()[machineconversions.CanaryOfLabel]
+		if name == "" {
+			if _, ok := a.(*clusterv1alpha1.MachineDeployment); !ok {
+				return nil
+			}
+			name = a.GetName()
+		}
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: name, Namespace: a.GetNamespace()}}}
+	})
+}
+
+func (r *reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.With("MachineDeployment", request.NamespacedName)
+	log.Debug("Reconciling")
+
+	md := &clusterv1alpha1.MachineDeployment{}
+	if err := r.client.Get(ctx, request.NamespacedName, md); err != nil {
+		if kerrors.IsNotFound(err) {
+			log.Debug("MachineDeployment not found, returning")
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, fmt.Errorf("failed to get machine deployment: %v", err)
+	}
+
+	result, err := r.reconcile(ctx, log, md)
+	if err != nil {
+		log.Errorw("Reconciling failed", zap.Error(err))
+		r.recorder.Event(md, corev1.EventTypeWarning, "CanaryRolloutReconcilingFailed", err.Error())
+	}
+	return result, err
+}
+
+func (r *reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, md *clusterv1alpha1.MachineDeployment) (reconcile.Result, error) {
+	if md.DeletionTimestamp != nil || md.Labels[machineconversions.CanaryOfLabel] != "" {
+		return reconcile.Result{}, nil
+	}
+
+	settings, err := machineconversions.GetCanaryRollout(md)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	status, err := machineconversions.GetCanaryRolloutStatus(md)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	image, pending := md.Annotations[machineconversions.CanaryImageAnnotation]
+	if settings == nil || !pending {
+		if pending {
+			// canary rollouts have been disabled while an image was waiting to be rolled out
+			oldMD := md.DeepCopy()
+			delete(md.Annotations, machineconversions.CanaryImageAnnotation)
+			if err := r.client.Patch(ctx, md, ctrlruntimeclient.MergeFrom(oldMD)); err != nil {
+				return reconcile.Result{}, fmt.Errorf("failed to update machine deployment: %v", err)
+			}
+		}
+		return reconcile.Result{}, r.cleanup(ctx, md)
+	}
+
+	if status != nil && status.Image == image && status.Phase == apiv1.CanaryRolloutFailed {
+		// a failed image is only retried once it has been changed
+		return reconcile.Result{}, r.cleanup(ctx, md)
+	}
+
+	if status == nil || status.Image != image || status.Phase != apiv1.CanaryRolloutInProgress {
+		// the canary of a previous image is replaced
+		if err := r.cleanup(ctx, md); err != nil {
+			return reconcile.Result{}, err
+		}
+
+		log.Infow("Starting canary rollout", "image", image)
+		status = &apiv1.CanaryRolloutStatus{
+			Phase:     apiv1.CanaryRolloutInProgress,
+			Image:     image,
+			StartedAt: apiv1.NewTime(r.now()),
+		}
+		if err := r.updateStatus(ctx, md, status, nil); err != nil {
+			return reconcile.Result{}, err
+		}
+		r.recorder.Eventf(md, corev1.EventTypeNormal, "CanaryRolloutStarted", "Rolling out image %q to canary nodes", image)
+	}
+
+	canary, err := r.ensureCanaryMachineDeployment(ctx, md, settings, image)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	timeout := defaultTimeout
+	if settings.TimeoutMinutes > 0 {
+		timeout = time.Duration(settings.TimeoutMinutes) * time.Minute
+	}
+	if r.now().After(status.StartedAt.Add(timeout)) {
+		return reconcile.Result{}, r.fail(ctx, log, md, status, fmt.Sprintf("the canary nodes did not pass the validation within %v", timeout))
+	}
+
+	nodes, err := r.readyCanaryNodes(ctx, canary)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if int32(len(nodes)) < *canary.Spec.Replicas {
+		log.Debugw("Waiting for canary nodes", "ready", len(nodes))
+		return reconcile.Result{RequeueAfter: requeueInterval}, nil
+	}
+
+	if settings.ValidationImage != "" {
+		passed, message, err := r.validate(ctx, md, settings, nodes)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if message != "" {
+			return reconcile.Result{}, r.fail(ctx, log, md, status, message)
+		}
+		if !passed {
+			log.Debug("Waiting for the validation of the canary nodes")
+			return reconcile.Result{RequeueAfter: requeueInterval}, nil
+		}
+	}
+
+	log.Infow("Canary nodes passed the validation, rolling out image", "image", image)
+	status.Phase = apiv1.CanaryRolloutSucceeded
+	if err := r.updateStatus(ctx, md, status, func(md *clusterv1alpha1.MachineDeployment) error {
+		return machineconversions.SetImage(&md.Spec.Template.Spec, image)
+	}); err != nil {
+		return reconcile.Result{}, err
+	}
+	r.recorder.Eventf(md, corev1.EventTypeNormal, "CanaryRolloutSucceeded", "Canary nodes passed the validation, rolling out image %q", image)
+
+	return reconcile.Result{}, r.cleanup(ctx, md)
+}
+
+// fail ends the canary rollout, keeping the previous image of the machine deployment.
+func (r *reconciler) fail(ctx context.Context, log *zap.SugaredLogger, md *clusterv1alpha1.MachineDeployment, status *apiv1.CanaryRolloutStatus, message string) error {
+	log.Infow("Canary rollout failed", "image", status.Image, "reason", message)
+	status.Phase = apiv1.CanaryRolloutFailed
+	status.Message = message
+	if err := r.updateStatus(ctx, md, status, nil); err != nil {
+		return err
+	}
+	r.recorder.Eventf(md, corev1.EventTypeWarning, "CanaryRolloutFailed", "Image %q is not rolled out: %s", status.Image, message)
+
+	return r.cleanup(ctx, md)
+}
+
+// updateStatus records the status of the canary rollout on the machine deployment and applies the
+// given mutation. The pending image is removed once it has been rolled out successfully.
+func (r *reconciler) updateStatus(ctx context.Context, md *clusterv1alpha1.MachineDeployment, status *apiv1.CanaryRolloutStatus, mutate func(*clusterv1alpha1.MachineDeployment) error) error {
+	oldMD := md.DeepCopy()
+	if err := machineconversions.SetCanaryRolloutStatus(md, status); err != nil {
+		return err
+	}
+	if status.Phase == apiv1.CanaryRolloutSucceeded {
+		delete(md.Annotations, machineconversions.CanaryImageAnnotation)
+	}
+	if mutate != nil {
+		if err := mutate(md); err != nil {
+			return err
+		}
+	}
+
+	if err := r.client.Patch(ctx, md, ctrlruntimeclient.MergeFrom(oldMD)); err != nil {
+		return fmt.Errorf("failed to update machine deployment: %v", err)
+	}
+	return nil
+}
+
+// ensureCanaryMachineDeployment creates the canary machine deployment, a copy of the machine
+// deployment with the new image and its own selector.
+func (r *reconciler) ensureCanaryMachineDeployment(ctx context.Context, md *clusterv1alpha1.MachineDeployment, settings *apiv1.CanaryRolloutSettings, image string) (*clusterv1alpha1.MachineDeployment, error) {
+	canary := &clusterv1alpha1.MachineDeployment{}
+	err := r.client.Get(ctx, types.NamespacedName{Namespace: md.Namespace, Name: md.Name + canarySuffix}, canary)
+	if err == nil {
+		return canary, nil
+	}
+	if !kerrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get canary machine deployment: %v", err)
+	}
+
+	canary, err = canaryMachineDeployment(md, settings, image)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.client.Create(ctx, canary); err != nil {
+		return nil, fmt.Errorf("failed to create canary machine deployment: %v", err)
+	}
+
+	return canary, nil
+}
+
+func canaryMachineDeployment(md *clusterv1alpha1.MachineDeployment, settings *apiv1.CanaryRolloutSettings, image string) (*clusterv1alpha1.MachineDeployment, error) {
+	replicas := settings.Replicas
+	if replicas == 0 {
+		replicas = defaultReplicas
+	}
+	// the labels must differ from the ones of the machine deployment, otherwise its selector
+	// would match the canary machines
+	labels := map[string]string{machineconversions.CanaryOfLabel: md.Name}
+
+	template := md.Spec.Template.DeepCopy()
+	template.Labels = labels
+	if err := machineconversions.SetImage(&template.Spec, image); err != nil {
+		return nil, err
+	}
+
+	return &clusterv1alpha1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            md.Name + canarySuffix,
+			Namespace:       md.Namespace,
+			Labels:          labels,
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(md, clusterv1alpha1.SchemeGroupVersion.WithKind("MachineDeployment"))},
+		},
+		Spec: clusterv1alpha1.MachineDeploymentSpec{
+			Replicas: &replicas,
+			Selector: metav1.LabelSelector{MatchLabels: labels},
+			Template: *template,
+		},
+	}, nil
+}
+
+// readyCanaryNodes returns the names of the ready nodes of the canary machine deployment.
+func (r *reconciler) readyCanaryNodes(ctx context.Context, canary *clusterv1alpha1.MachineDeployment) ([]string, error) {
+	machines := &clusterv1alpha1.MachineList{}
+	if err := r.client.List(ctx, machines, ctrlruntimeclient.InNamespace(canary.Namespace), ctrlruntimeclient.MatchingLabels(canary.Spec.Selector.MatchLabels)); err != nil {
+		return nil, fmt.Errorf("failed to list canary machines: %v", err)
+	}
+
+	var nodes []string
+	for _, machine := range machines.Items {
+		if machine.DeletionTimestamp != nil || machine.Status.NodeRef == nil {
+			continue
+		}
+
+		node := &corev1.Node{}
+		if err := r.client.Get(ctx, types.NamespacedName{Name: machine.Status.NodeRef.Name}, node); err != nil {
+			if kerrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get node %s: %v", machine.Status.NodeRef.Name, err)
+		}
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == corev1.NodeReady && condition.Status == corev1.ConditionTrue {
+				nodes = append(nodes, node.Name)
+			}
+		}
+	}
+
+	return nodes, nil
+}
+
+// validate runs the validation workload on every canary node. It returns whether all nodes
+// passed the validation, or a message if it failed on any of them.
+func (r *reconciler) validate(ctx context.Context, md *clusterv1alpha1.MachineDeployment, settings *apiv1.CanaryRolloutSettings, nodes []string) (bool, string, error) {
+	passed := true
+
+	for _, node := range nodes {
+		job := &batchv1.Job{}
+		err := r.client.Get(ctx, types.NamespacedName{Namespace: md.Namespace, Name: validationJobName(md.Name, node)}, job)
+		if kerrors.IsNotFound(err) {
+			if err := r.client.Create(ctx, validationJob(md, settings, node)); err != nil {
+				return false, "", fmt.Errorf("failed to create validation job: %v", err)
+			}
+			passed = false
+			continue
+		}
+		if err != nil {
+			return false, "", fmt.Errorf("failed to get validation job: %v", err)
+		}
+
+		for _, condition := range job.Status.Conditions {
+			if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+				return false, fmt.Sprintf("the validation failed on node %s: %s", node, condition.Message), nil
+			}
+		}
+		if job.Status.Succeeded == 0 {
+			passed = false
+		}
+	}
+
+	return passed, "", nil
+}
+
+func validationJobName(mdName, node string) string {
+	h := fnv.New32a()
+	// the hash only fails if the writer fails, which it never does for fnv
+	_, _ = h.Write([]byte(mdName + "/" + node))
+	return fmt.Sprintf("canary-validation-%x", h.Sum32())
+}
+
+func validationJob(md *clusterv1alpha1.MachineDeployment, settings *apiv1.CanaryRolloutSettings, node string) *batchv1.Job {
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      validationJobName(md.Name, node),
+			Namespace: md.Namespace,
+			Labels:    map[string]string{machineconversions.CanaryOfLabel: md.Name},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: pointer.Int32Ptr(2),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{machineconversions.CanaryOfLabel: md.Name},
+				},
+				Spec: corev1.PodSpec{
+					NodeName:      node,
+					RestartPolicy: corev1.RestartPolicyNever,
+					// the validation must run on the canary node regardless of its taints
+					Tolerations: []corev1.Toleration{{Operator: corev1.TolerationOpExists}},
+					Containers: []corev1.Container{{
+						Name:    "validation",
+						Image:   settings.ValidationImage,
+						Command: settings.ValidationCommand,
+					}},
+				},
+			},
+		},
+	}
+}
+
+// cleanup deletes the canary machine deployment and the validation jobs of the machine deployment.
+func (r *reconciler) cleanup(ctx context.Context, md *clusterv1alpha1.MachineDeployment) error {
+	canary := &clusterv1alpha1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: md.Namespace, Name: md.Name + canarySuffix},
+	}
+	if err := r.client.Delete(ctx, canary); err != nil && !kerrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete canary machine deployment: %v", err)
+	}
+
+	jobs := &batchv1.JobList{}
+	if err := r.client.List(ctx, jobs, ctrlruntimeclient.InNamespace(md.Namespace), ctrlruntimeclient.MatchingLabels{machineconversions.CanaryOfLabel: md.Name}); err != nil {
+		return fmt.Errorf("failed to list validation jobs: %v", err)
+	}
+	for i := range jobs.Items {
+		if err := r.client.Delete(ctx, &jobs.Items[i], ctrlruntimeclient.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !kerrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete validation job %s: %v", jobs.Items[i].Name, err)
+		}
+	}
+
+	return nil
+}
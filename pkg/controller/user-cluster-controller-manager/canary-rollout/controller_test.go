@@ -0,0 +1,363 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package canaryrollout
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+	machineconversions "k8c.io/kubermatic/v2/pkg/machine"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func init() {
+	if err := clusterv1alpha1.SchemeBuilder.AddToScheme(scheme.Scheme); err != nil {
+		kubermaticlog.Logger.Fatalw("failed to add clusterv1alpha1 scheme to scheme.Scheme", zap.Error(err))
+	}
+}
+
+const (
+	mdName      = "worker"
+	mdNamespace = "kube-system"
+	oldImage    = "ubuntu-2004-v1"
+	newImage    = "ubuntu-2004-v2"
+)
+
+var startedAt = time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func machineSpec(image string) clusterv1alpha1.MachineSpec {
+	return clusterv1alpha1.MachineSpec{
+		ProviderSpec: clusterv1alpha1.ProviderSpec{
+			Value: &runtime.RawExtension{Raw: []byte(fmt.Sprintf(`{"cloudProvider":"azure","cloudProviderSpec":{"imageID":%q,"vmSize":"Standard_D2s_v3"}}`, image))},
+		},
+	}
+}
+
+func genMachineDeployment(t *testing.T, settings *apiv1.CanaryRolloutSettings, pendingImage string, status *apiv1.CanaryRolloutStatus) *clusterv1alpha1.MachineDeployment {
+	replicas := int32(3)
+	md := &clusterv1alpha1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        mdName,
+			Namespace:   mdNamespace,
+			Annotations: map[string]string{},
+		},
+		Spec: clusterv1alpha1.MachineDeploymentSpec{
+			Replicas: &replicas,
+			Selector: metav1.LabelSelector{MatchLabels: map[string]string{"machine": "md-worker"}},
+			Template: clusterv1alpha1.MachineTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"machine": "md-worker"}},
+				Spec:       machineSpec(oldImage),
+			},
+		},
+	}
+	if err := machineconversions.SetCanaryRollout(md, settings); err != nil {
+		t.Fatal(err)
+	}
+	if pendingImage != "" {
+		md.Annotations[machineconversions.CanaryImageAnnotation] = pendingImage
+	}
+	if status != nil {
+		if err := machineconversions.SetCanaryRolloutStatus(md, status); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return md
+}
+
+func inProgress() *apiv1.CanaryRolloutStatus {
+	return &apiv1.CanaryRolloutStatus{
+		Phase:     apiv1.CanaryRolloutInProgress,
+		Image:     newImage,
+		StartedAt: apiv1.NewTime(startedAt),
+	}
+}
+
+func genCanary(t *testing.T) *clusterv1alpha1.MachineDeployment {
+	canary, err := canaryMachineDeployment(genMachineDeployment(t, nil, "", nil), &apiv1.CanaryRolloutSettings{}, newImage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return canary
+}
+
+func genNode(name string, ready bool) []ctrlruntimeclient.Object {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	return []ctrlruntimeclient.Object{
+		&clusterv1alpha1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: mdNamespace,
+				Labels:    map[string]string{machineconversions.CanaryOfLabel: mdName},
+			},
+			Spec:   machineSpec(newImage),
+			Status: clusterv1alpha1.MachineStatus{NodeRef: &corev1.ObjectReference{Name: name}},
+		},
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: status}},
+			},
+		},
+	}
+}
+
+func genJob(t *testing.T, node string, succeeded bool) *batchv1.Job {
+	job := validationJob(genMachineDeployment(t, nil, "", nil), &apiv1.CanaryRolloutSettings{ValidationImage: "validation"}, node)
+	if succeeded {
+		job.Status.Succeeded = 1
+	} else {
+		job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Message: "BackoffLimitExceeded"}}
+	}
+	return job
+}
+
+func TestReconcile(t *testing.T) {
+	validation := &apiv1.CanaryRolloutSettings{ValidationImage: "validation", TimeoutMinutes: 20}
+
+	testCases := []struct {
+		name            string
+		md              func(t *testing.T) *clusterv1alpha1.MachineDeployment
+		objects         func(t *testing.T) []ctrlruntimeclient.Object
+		now             time.Time
+		expectedImage   string
+		expectedPending bool
+		expectedPhase   apiv1.CanaryRolloutPhase
+		expectedCanary  bool
+		expectedJobs    int
+	}{
+		{
+			name: "no image pending",
+			md: func(t *testing.T) *clusterv1alpha1.MachineDeployment {
+				return genMachineDeployment(t, validation, "", nil)
+			},
+			now:           startedAt,
+			expectedImage: oldImage,
+		},
+		{
+			name: "new image starts canary rollout",
+			md: func(t *testing.T) *clusterv1alpha1.MachineDeployment {
+				return genMachineDeployment(t, validation, newImage, nil)
+			},
+			now:             startedAt,
+			expectedImage:   oldImage,
+			expectedPending: true,
+			expectedPhase:   apiv1.CanaryRolloutInProgress,
+			expectedCanary:  true,
+		},
+		{
+			name: "validation is started on ready canary nodes",
+			md: func(t *testing.T) *clusterv1alpha1.MachineDeployment {
+				return genMachineDeployment(t, validation, newImage, inProgress())
+			},
+			objects: func(t *testing.T) []ctrlruntimeclient.Object {
+				return append(genNode("canary-a", true), genCanary(t))
+			},
+			now:             startedAt.Add(5 * time.Minute),
+			expectedImage:   oldImage,
+			expectedPending: true,
+			expectedPhase:   apiv1.CanaryRolloutInProgress,
+			expectedCanary:  true,
+			expectedJobs:    1,
+		},
+		{
+			name: "image is rolled out after successful validation",
+			md: func(t *testing.T) *clusterv1alpha1.MachineDeployment {
+				return genMachineDeployment(t, validation, newImage, inProgress())
+			},
+			objects: func(t *testing.T) []ctrlruntimeclient.Object {
+				return append(genNode("canary-a", true), genCanary(t), genJob(t, "canary-a", true))
+			},
+			now:           startedAt.Add(10 * time.Minute),
+			expectedImage: newImage,
+			expectedPhase: apiv1.CanaryRolloutSucceeded,
+		},
+		{
+			name: "image is not rolled out after failed validation",
+			md: func(t *testing.T) *clusterv1alpha1.MachineDeployment {
+				return genMachineDeployment(t, validation, newImage, inProgress())
+			},
+			objects: func(t *testing.T) []ctrlruntimeclient.Object {
+				return append(genNode("canary-a", true), genCanary(t), genJob(t, "canary-a", false))
+			},
+			now:             startedAt.Add(10 * time.Minute),
+			expectedImage:   oldImage,
+			expectedPending: true,
+			expectedPhase:   apiv1.CanaryRolloutFailed,
+		},
+		{
+			name: "image is not rolled out if canary nodes do not become ready",
+			md: func(t *testing.T) *clusterv1alpha1.MachineDeployment {
+				return genMachineDeployment(t, validation, newImage, inProgress())
+			},
+			objects: func(t *testing.T) []ctrlruntimeclient.Object {
+				return append(genNode("canary-a", false), genCanary(t))
+			},
+			now:             startedAt.Add(30 * time.Minute),
+			expectedImage:   oldImage,
+			expectedPending: true,
+			expectedPhase:   apiv1.CanaryRolloutFailed,
+		},
+		{
+			name: "image is rolled out once canary nodes are ready without validation",
+			md: func(t *testing.T) *clusterv1alpha1.MachineDeployment {
+				return genMachineDeployment(t, &apiv1.CanaryRolloutSettings{}, newImage, inProgress())
+			},
+			objects: func(t *testing.T) []ctrlruntimeclient.Object {
+				return append(genNode("canary-a", true), genCanary(t))
+			},
+			now:           startedAt.Add(5 * time.Minute),
+			expectedImage: newImage,
+			expectedPhase: apiv1.CanaryRolloutSucceeded,
+		},
+		{
+			name: "failed image is not retried",
+			md: func(t *testing.T) *clusterv1alpha1.MachineDeployment {
+				status := inProgress()
+				status.Phase = apiv1.CanaryRolloutFailed
+				return genMachineDeployment(t, validation, newImage, status)
+			},
+			now:             startedAt.Add(time.Hour),
+			expectedImage:   oldImage,
+			expectedPending: true,
+			expectedPhase:   apiv1.CanaryRolloutFailed,
+		},
+		{
+			name: "canary is deleted when canary rollouts are disabled",
+			md: func(t *testing.T) *clusterv1alpha1.MachineDeployment {
+				return genMachineDeployment(t, nil, newImage, inProgress())
+			},
+			objects: func(t *testing.T) []ctrlruntimeclient.Object {
+				return append(genNode("canary-a", true), genCanary(t))
+			},
+			now:           startedAt.Add(5 * time.Minute),
+			expectedImage: oldImage,
+			expectedPhase: apiv1.CanaryRolloutInProgress,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			objects := []ctrlruntimeclient.Object{tc.md(t)}
+			if tc.objects != nil {
+				objects = append(objects, tc.objects(t)...)
+			}
+
+			client := fakectrlruntimeclient.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(objects...).Build()
+			r := &reconciler{
+				log:      kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar(),
+				client:   client,
+				recorder: record.NewFakeRecorder(10),
+				now:      func() time.Time { return tc.now },
+			}
+
+			md := &clusterv1alpha1.MachineDeployment{}
+			if err := client.Get(ctx, types.NamespacedName{Namespace: mdNamespace, Name: mdName}, md); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := r.reconcile(ctx, r.log, md); err != nil {
+				t.Fatalf("reconciling failed: %v", err)
+			}
+
+			if err := client.Get(ctx, types.NamespacedName{Namespace: mdNamespace, Name: mdName}, md); err != nil {
+				t.Fatal(err)
+			}
+			image, err := machineconversions.GetImage(md.Spec.Template.Spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if image != tc.expectedImage {
+				t.Errorf("expected image %q, got %q", tc.expectedImage, image)
+			}
+			if _, pending := md.Annotations[machineconversions.CanaryImageAnnotation]; pending != tc.expectedPending {
+				t.Errorf("expected pending image: %v, got %v", tc.expectedPending, pending)
+			}
+
+			status, err := machineconversions.GetCanaryRolloutStatus(md)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var phase apiv1.CanaryRolloutPhase
+			if status != nil {
+				phase = status.Phase
+			}
+			if phase != tc.expectedPhase {
+				t.Errorf("expected phase %q, got %q", tc.expectedPhase, phase)
+			}
+
+			err = client.Get(ctx, types.NamespacedName{Namespace: mdNamespace, Name: mdName + canarySuffix}, &clusterv1alpha1.MachineDeployment{})
+			if canaryExists := !kerrors.IsNotFound(err); canaryExists != tc.expectedCanary {
+				t.Errorf("expected canary machine deployment: %v, got %v", tc.expectedCanary, canaryExists)
+			}
+
+			jobs := &batchv1.JobList{}
+			if err := client.List(ctx, jobs); err != nil {
+				t.Fatal(err)
+			}
+			if len(jobs.Items) != tc.expectedJobs {
+				t.Errorf("expected %d validation jobs, got %d", tc.expectedJobs, len(jobs.Items))
+			}
+		})
+	}
+}
+
+func TestCanaryMachineDeployment(t *testing.T) {
+	md := genMachineDeployment(t, nil, "", nil)
+	canary, err := canaryMachineDeployment(md, &apiv1.CanaryRolloutSettings{Replicas: 2}, newImage)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if *canary.Spec.Replicas != 2 {
+		t.Errorf("expected 2 canary replicas, got %d", *canary.Spec.Replicas)
+	}
+	if image, _ := machineconversions.GetImage(canary.Spec.Template.Spec); image != newImage {
+		t.Errorf("expected canary image %q, got %q", newImage, image)
+	}
+	if selects(md, canary.Spec.Template.Labels) {
+		t.Error("expected the machine deployment not to select the canary machines")
+	}
+}
+
+func selects(md *clusterv1alpha1.MachineDeployment, labels map[string]string) bool {
+	for k, v := range md.Spec.Selector.MatchLabels {
+		if labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,25 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package canaryrollout contains a controller that rolls out OS image changes of machine deployments
+with canary rollouts enabled. The new image is first used for a small canary machine deployment,
+whose nodes have to become ready and pass an optional validation workload within a timeout. Only
+then the machine deployment is updated to the new image, otherwise it keeps its previous image and
+the failed image is not retried. The canary machine deployment is deleted in both cases, the status
+of the latest rollout is reported in an annotation.
+*/
+package canaryrollout
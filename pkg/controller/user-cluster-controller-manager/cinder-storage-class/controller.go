@@ -0,0 +1,229 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cinderstorageclass
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go.uber.org/zap"
+
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+	openstack "github.com/kubermatic/machine-controller/pkg/cloudprovider/provider/openstack/types"
+	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	controllerName = "cinder_storage_class_controller"
+
+	// ManagedLabelKey is set on the StorageClasses created by this controller.
+	ManagedLabelKey = "kubermatic.io/cinder-storage-class"
+
+	provisioner = "cinder.csi.openstack.org"
+	zoneKey     = "topology.cinder.csi.openstack.org/zone"
+)
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// All machine deployments are reconciled at once, as StorageClasses can be shared between them.
+var reconcileRequest = reconcile.Request{NamespacedName: types.NamespacedName{Name: "cinder-storage-classes"}}
+
+type reconciler struct {
+	log    *zap.SugaredLogger
+	client ctrlruntimeclient.Client
+}
+
+func Add(log *zap.SugaredLogger, mgr manager.Manager) error {
+	r := &reconciler{
+		log:    log.Named(controllerName),
+		client: mgr.GetClient(),
+	}
+	c, err := controller.New(controllerName, mgr, controller.Options{
+		Reconciler: r,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create controller: %v", err)
+	}
+
+	enqueue := handler.EnqueueRequestsFromMapFunc(func(a ctrlruntimeclient.Object) []reconcile.Request {
+		return []reconcile.Request{reconcileRequest}
+	})
+
+	if err := c.Watch(&source.Kind{Type: &clusterv1alpha1.MachineDeployment{}}, enqueue); err != nil {
+		return fmt.Errorf("failed to establish watch for machine deployments: %v", err)
+	}
+
+	if err := c.Watch(&source.Kind{Type: &storagev1.StorageClass{}}, enqueue); err != nil {
+		return fmt.Errorf("failed to establish watch for storage classes: %v", err)
+	}
+
+	return nil
+}
+
+func (r *reconciler) Reconcile(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+	err := r.reconcile(ctx)
+	if err != nil {
+		r.log.Errorw("Reconciling failed", zap.Error(err))
+	}
+	return reconcile.Result{}, err
+}
+
+func (r *reconciler) reconcile(ctx context.Context) error {
+	machineDeployments := &clusterv1alpha1.MachineDeploymentList{}
+	if err := r.client.List(ctx, machineDeployments); err != nil {
+		return fmt.Errorf("failed to list machine deployments: %v", err)
+	}
+
+	zones, err := volumeTypeZones(machineDeployments.Items)
+	if err != nil {
+		return err
+	}
+
+	desired := map[string]*storagev1.StorageClass{}
+	for volumeType, volumeTypeZones := range zones {
+		storageClass := storageClass(volumeType, volumeTypeZones)
+		desired[storageClass.Name] = storageClass
+	}
+
+	storageClasses := &storagev1.StorageClassList{}
+	if err := r.client.List(ctx, storageClasses, ctrlruntimeclient.HasLabels{ManagedLabelKey}); err != nil {
+		return fmt.Errorf("failed to list storage classes: %v", err)
+	}
+
+	existing := map[string]*storagev1.StorageClass{}
+	for i, storageClass := range storageClasses.Items {
+		existing[storageClass.Name] = &storageClasses.Items[i]
+
+		if _, ok := desired[storageClass.Name]; ok {
+			continue
+		}
+		r.log.Infow("Deleting unused storage class", "storageclass", storageClass.Name)
+		if err := r.client.Delete(ctx, &storageClasses.Items[i]); err != nil && !kerrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete storage class %s: %v", storageClass.Name, err)
+		}
+	}
+
+	for name, storageClass := range desired {
+		current, ok := existing[name]
+		if ok && storageClassEqual(current, storageClass) {
+			continue
+		}
+
+		// The parameters and topologies of a StorageClass are immutable, so it has to be recreated.
+		if ok {
+			if err := r.client.Delete(ctx, current); err != nil && !kerrors.IsNotFound(err) {
+				return fmt.Errorf("failed to delete storage class %s: %v", name, err)
+			}
+		}
+		r.log.Infow("Creating storage class", "storageclass", name)
+		if err := r.client.Create(ctx, storageClass); err != nil {
+			return fmt.Errorf("failed to create storage class %s: %v", name, err)
+		}
+	}
+
+	return nil
+}
+
+// volumeTypeZones returns the availability zones of the machine deployments per root disk volume type.
+func volumeTypeZones(machineDeployments []clusterv1alpha1.MachineDeployment) (map[string]sets.String, error) {
+	zones := map[string]sets.String{}
+	for _, md := range machineDeployments {
+		if md.DeletionTimestamp != nil || md.Spec.Template.Spec.ProviderSpec.Value == nil {
+			continue
+		}
+
+		config := providerconfig.Config{}
+		if err := json.Unmarshal(md.Spec.Template.Spec.ProviderSpec.Value.Raw, &config); err != nil {
+			return nil, fmt.Errorf("failed to parse provider spec of machine deployment %s: %v", md.Name, err)
+		}
+		if config.CloudProvider != providerconfig.CloudProviderOpenstack {
+			continue
+		}
+
+		cloudConfig := openstack.RawConfig{}
+		if err := json.Unmarshal(config.CloudProviderSpec.Raw, &cloudConfig); err != nil {
+			return nil, fmt.Errorf("failed to parse openstack config of machine deployment %s: %v", md.Name, err)
+		}
+		volumeType := cloudConfig.RootDiskVolumeType.Value
+		if volumeType == "" {
+			continue
+		}
+
+		if _, ok := zones[volumeType]; !ok {
+			zones[volumeType] = sets.NewString()
+		}
+		if zone := cloudConfig.AvailabilityZone.Value; zone != "" {
+			zones[volumeType].Insert(zone)
+		}
+	}
+
+	return zones, nil
+}
+
+// StorageClassName returns the name of the StorageClass for the given volume type.
+func StorageClassName(volumeType string) string {
+	return "cinder-csi-" + strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(volumeType), "-"), "-")
+}
+
+func storageClass(volumeType string, zones sets.String) *storagev1.StorageClass {
+	bindingMode := storagev1.VolumeBindingWaitForFirstConsumer
+	storageClass := &storagev1.StorageClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: StorageClassName(volumeType),
+			Labels: map[string]string{
+				ManagedLabelKey: "true",
+			},
+		},
+		Provisioner:       provisioner,
+		Parameters:        map[string]string{"type": volumeType},
+		VolumeBindingMode: &bindingMode,
+	}
+
+	if zones.Len() > 0 {
+		storageClass.AllowedTopologies = []corev1.TopologySelectorTerm{{
+			MatchLabelExpressions: []corev1.TopologySelectorLabelRequirement{{
+				Key:    zoneKey,
+				Values: zones.List(),
+			}},
+		}}
+	}
+
+	return storageClass
+}
+
+func storageClassEqual(current, desired *storagev1.StorageClass) bool {
+	return current.Provisioner == desired.Provisioner &&
+		apiequality.Semantic.DeepEqual(current.Parameters, desired.Parameters) &&
+		apiequality.Semantic.DeepEqual(current.AllowedTopologies, desired.AllowedTopologies)
+}
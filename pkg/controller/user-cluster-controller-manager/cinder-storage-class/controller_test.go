@@ -0,0 +1,142 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cinderstorageclass
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-test/deep"
+	"go.uber.org/zap"
+
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes/scheme"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func init() {
+	if err := clusterv1alpha1.SchemeBuilder.AddToScheme(scheme.Scheme); err != nil {
+		kubermaticlog.Logger.Fatalw("failed to add clusterv1alpha1 scheme to scheme.Scheme", zap.Error(err))
+	}
+}
+
+func genMachineDeployment(name, providerSpec string) *clusterv1alpha1.MachineDeployment {
+	return &clusterv1alpha1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: metav1.NamespaceSystem,
+		},
+		Spec: clusterv1alpha1.MachineDeploymentSpec{
+			Template: clusterv1alpha1.MachineTemplateSpec{
+				Spec: clusterv1alpha1.MachineSpec{
+					ProviderSpec: clusterv1alpha1.ProviderSpec{
+						Value: &runtime.RawExtension{Raw: []byte(providerSpec)},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	staleStorageClass := storageClass("unused", sets.NewString("zone-a"))
+	outdatedStorageClass := storageClass("SSD", sets.NewString("zone-a"))
+	unmanagedStorageClass := &storagev1.StorageClass{
+		ObjectMeta:  metav1.ObjectMeta{Name: "cinder-csi"},
+		Provisioner: provisioner,
+	}
+
+	client := fakectrlruntimeclient.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		genMachineDeployment("ssd-a", `{"cloudProvider":"openstack","cloudProviderSpec":{"availabilityZone":"zone-a","rootDiskSizeGB":50,"rootDiskVolumeType":"SSD"}}`),
+		genMachineDeployment("ssd-b", `{"cloudProvider":"openstack","cloudProviderSpec":{"availabilityZone":"zone-b","rootDiskSizeGB":50,"rootDiskVolumeType":"SSD"}}`),
+		genMachineDeployment("hdd", `{"cloudProvider":"openstack","cloudProviderSpec":{"availabilityZone":"zone-a","rootDiskSizeGB":50,"rootDiskVolumeType":"hdd_slow"}}`),
+		genMachineDeployment("ephemeral", `{"cloudProvider":"openstack","cloudProviderSpec":{"availabilityZone":"zone-c"}}`),
+		genMachineDeployment("aws", `{"cloudProvider":"aws","cloudProviderSpec":{"instanceType":"t3.small"}}`),
+		staleStorageClass,
+		outdatedStorageClass,
+		unmanagedStorageClass,
+	).Build()
+
+	r := &reconciler{log: kubermaticlog.Logger, client: client}
+	if err := r.reconcile(context.Background()); err != nil {
+		t.Fatalf("reconciling failed: %v", err)
+	}
+
+	storageClasses := &storagev1.StorageClassList{}
+	if err := client.List(context.Background(), storageClasses); err != nil {
+		t.Fatalf("failed to list storage classes: %v", err)
+	}
+
+	expected := map[string]storagev1.StorageClass{
+		"cinder-csi": *unmanagedStorageClass,
+		"cinder-csi-ssd": {
+			Parameters:        map[string]string{"type": "SSD"},
+			AllowedTopologies: zoneTopology("zone-a", "zone-b"),
+		},
+		"cinder-csi-hdd-slow": {
+			Parameters:        map[string]string{"type": "hdd_slow"},
+			AllowedTopologies: zoneTopology("zone-a"),
+		},
+	}
+
+	if len(storageClasses.Items) != len(expected) {
+		t.Fatalf("expected %d storage classes, got %d", len(expected), len(storageClasses.Items))
+	}
+	for _, storageClass := range storageClasses.Items {
+		expectedStorageClass, ok := expected[storageClass.Name]
+		if !ok {
+			t.Errorf("unexpected storage class %s", storageClass.Name)
+			continue
+		}
+		if diff := deep.Equal(storageClass.Parameters, expectedStorageClass.Parameters); diff != nil {
+			t.Errorf("unexpected parameters of storage class %s: %v", storageClass.Name, diff)
+		}
+		if diff := deep.Equal(storageClass.AllowedTopologies, expectedStorageClass.AllowedTopologies); diff != nil {
+			t.Errorf("unexpected topologies of storage class %s: %v", storageClass.Name, diff)
+		}
+	}
+}
+
+func zoneTopology(zones ...string) []corev1.TopologySelectorTerm {
+	return []corev1.TopologySelectorTerm{{
+		MatchLabelExpressions: []corev1.TopologySelectorLabelRequirement{{
+			Key:    zoneKey,
+			Values: zones,
+		}},
+	}}
+}
+
+func TestStorageClassName(t *testing.T) {
+	testCases := map[string]string{
+		"SSD":          "cinder-csi-ssd",
+		"hdd_slow":     "cinder-csi-hdd-slow",
+		"__fast.nvme ": "cinder-csi-fast-nvme",
+	}
+
+	for volumeType, expected := range testCases {
+		if name := StorageClassName(volumeType); name != expected {
+			t.Errorf("expected name of volume type %q to be %q, got %q", volumeType, expected, name)
+		}
+	}
+}
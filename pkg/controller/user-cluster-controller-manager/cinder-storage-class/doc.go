@@ -0,0 +1,23 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package cinderstorageclass contains a controller that creates a StorageClass for the Cinder CSI
+driver per volume type used for the root disks of OpenStack machine deployments. Volumes of a
+StorageClass are only provisioned in the availability zones of the machine deployments using its
+volume type. StorageClasses whose volume type is not used anymore are deleted.
+*/
+package cinderstorageclass
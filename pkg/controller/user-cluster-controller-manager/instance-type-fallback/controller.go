@@ -0,0 +1,369 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancetypefallback
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"go.uber.org/zap"
+
+	"github.com/kubermatic/machine-controller/pkg/apis/cluster/common"
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+	machineconversions "k8c.io/kubermatic/v2/pkg/machine"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	// This controller creates events on the machine deployments, so do not put the word Kubermatic in it
+	controllerName = "instance_type_fallback_controller"
+	// fallbackLevelLabel is set on fallback machine deployments and their machines, its value is
+	// the position of their instance type in the fallback instance types, starting at 1.
+	fallbackLevelLabel = "kubermatic.io/fallback-level"
+)
+
+type reconciler struct {
+	log      *zap.SugaredLogger
+	client   ctrlruntimeclient.Client
+	recorder record.EventRecorder
+}
+
+func Add(ctx context.Context, log *zap.SugaredLogger, mgr manager.Manager) error {
+	log = log.Named(controllerName)
+
+	r := &reconciler{
+		log:      log,
+		client:   mgr.GetClient(),
+		recorder: mgr.GetEventRecorderFor(controllerName),
+	}
+	c, err := controller.New(controllerName, mgr, controller.Options{
+		Reconciler: r,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create controller: %v", err)
+	}
+
+	if err := c.Watch(&source.Kind{Type: &clusterv1alpha1.MachineDeployment{}}, enqueueFallenBackMachineDeployment()); err != nil {
+		return fmt.Errorf("failed to establish watch for machine deployments: %v", err)
+	}
+
+	if err := c.Watch(&source.Kind{Type: &clusterv1alpha1.Machine{}}, enqueueMachineDeployments(ctx, r.client)); err != nil {
+		return fmt.Errorf("failed to establish watch for machines: %v", err)
+	}
+
+	return nil
+}
+
+// enqueueFallenBackMachineDeployment enqueues the machine deployment. Fallback machine deployments
+// are mapped to the machine deployment whose machines ran out of capacity.
+func enqueueFallenBackMachineDeployment() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(a ctrlruntimeclient.Object) []reconcile.Request {
+		name := a.GetLabels()[machineconversions.FallbackOfLabel]
+		if name == "" {
+			name = a.GetName()
+		}
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: name, Namespace: a.GetNamespace()}}}
+	})
+}
+
+// enqueueMachineDeployments enqueues the machine deployments selecting the machine. Machines of
+// fallback machine deployments are mapped to the machine deployment whose machines ran out of capacity.
+func enqueueMachineDeployments(ctx context.Context, client ctrlruntimeclient.Client) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(a ctrlruntimeclient.Object) []reconcile.Request {
+		if name := a.GetLabels()[machineconversions.FallbackOfLabel]; name != "" {
+			return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: name, Namespace: a.GetNamespace()}}}
+		}
+
+		machineDeployments := &clusterv1alpha1.MachineDeploymentList{}
+		if err := client.List(ctx, machineDeployments, ctrlruntimeclient.InNamespace(a.GetNamespace())); err != nil {
+			utilruntime.HandleError(fmt.Errorf("failed to list MachineDeployments: %v", err))
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for _, md := range machineDeployments.Items {
+			if selects(&md, a.GetLabels()) {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: md.Name, Namespace: md.Namespace}})
+			}
+		}
+		return requests
+	})
+}
+
+// selects returns true if the machine deployment selects an object with the given labels.
+func selects(md *clusterv1alpha1.MachineDeployment, objectLabels map[string]string) bool {
+	selector, err := metav1.LabelSelectorAsSelector(&md.Spec.Selector)
+	if err != nil || selector.Empty() {
+		return false
+	}
+	return selector.Matches(labels.Set(objectLabels))
+}
+
+func (r *reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.With("MachineDeployment", request.NamespacedName)
+	log.Debug("Reconciling")
+
+	md := &clusterv1alpha1.MachineDeployment{}
+	if err := r.client.Get(ctx, request.NamespacedName, md); err != nil {
+		if kerrors.IsNotFound(err) {
+			log.Debug("MachineDeployment not found, returning")
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, fmt.Errorf("failed to get machine deployment: %v", err)
+	}
+
+	err := r.reconcile(ctx, log, md)
+	if err != nil {
+		log.Errorw("Reconciling failed", zap.Error(err))
+		r.recorder.Event(md, corev1.EventTypeWarning, "InstanceTypeFallbackFailed", err.Error())
+	}
+	return reconcile.Result{}, err
+}
+
+func (r *reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, md *clusterv1alpha1.MachineDeployment) error {
+	// fallback and canary machine deployments are managed by the controllers creating them,
+	// their machines are removed together with the machine deployment they belong to
+	if md.DeletionTimestamp != nil || md.Labels[machineconversions.FallbackOfLabel] != "" || md.Labels[machineconversions.CanaryOfLabel] != "" {
+		return nil
+	}
+
+	oldMD := md.DeepCopy()
+	fallbacks := machineconversions.GetFallbackInstanceTypes(md)
+
+	if len(fallbacks) == 0 {
+		if err := r.deleteFallbackMachineDeployments(ctx, md, 1); err != nil {
+			return err
+		}
+		if _, ok := md.Annotations[machineconversions.InstanceTypesInUseAnnotation]; !ok {
+			return nil
+		}
+		delete(md.Annotations, machineconversions.InstanceTypesInUseAnnotation)
+		return r.client.Patch(ctx, md, ctrlruntimeclient.MergeFrom(oldMD))
+	}
+
+	current, err := machineconversions.GetInstanceType(md.Spec.Template.Spec)
+	if err != nil {
+		return err
+	}
+	instanceTypes := instanceTypeLevels(current, fallbacks)
+
+	machines := &clusterv1alpha1.MachineList{}
+	if err := r.client.List(ctx, machines, ctrlruntimeclient.InNamespace(md.Namespace)); err != nil {
+		return fmt.Errorf("failed to list machines: %v", err)
+	}
+
+	inUse := map[string]int32{}
+	outOfCapacity := make([]int32, len(instanceTypes))
+	for _, machine := range machines.Items {
+		level, ok := machineLevel(md, &machine)
+		if !ok {
+			continue
+		}
+
+		instanceType, err := machineconversions.GetInstanceType(machine.Spec)
+		if err != nil {
+			return fmt.Errorf("failed to get instance type of machine %s: %v", machine.Name, err)
+		}
+		inUse[instanceType]++
+
+		if level < len(instanceTypes) && machine.DeletionTimestamp == nil && machine.Status.ErrorReason != nil && *machine.Status.ErrorReason == common.InsufficientResourcesMachineError {
+			outOfCapacity[level]++
+		}
+	}
+
+	// the machines of every fallback level replace the machines of the previous level that are out
+	// of capacity, so running machines are never replaced and the fallback machines are removed again
+	// once the previous instance type has capacity
+	for level := 1; level < len(instanceTypes); level++ {
+		if err := r.reconcileFallbackMachineDeployment(ctx, log, md, level, instanceTypes, outOfCapacity[level-1]); err != nil {
+			return err
+		}
+	}
+	if err := r.deleteFallbackMachineDeployments(ctx, md, len(instanceTypes)); err != nil {
+		return err
+	}
+	if last := len(instanceTypes) - 1; outOfCapacity[last] > 0 {
+		r.recorder.Eventf(md, corev1.EventTypeWarning, "NoFallbackInstanceType", "Instance type %s is out of capacity and no fallback instance type is left", instanceTypes[last])
+	}
+
+	data, err := json.Marshal(inUse)
+	if err != nil {
+		return err
+	}
+	if md.Annotations[machineconversions.InstanceTypesInUseAnnotation] == string(data) {
+		return nil
+	}
+	md.Annotations[machineconversions.InstanceTypesInUseAnnotation] = string(data)
+
+	if err := r.client.Patch(ctx, md, ctrlruntimeclient.MergeFrom(oldMD)); err != nil {
+		return fmt.Errorf("failed to update machine deployment: %v", err)
+	}
+
+	return nil
+}
+
+// instanceTypeLevels returns the instance type of the template followed by the instance types
+// its machines fall back to, in order.
+func instanceTypeLevels(current string, fallbacks []string) []string {
+	instanceTypes := []string{current}
+	seen := sets.NewString(current)
+	for next := machineconversions.NextInstanceType(current, fallbacks); next != "" && !seen.Has(next); next = machineconversions.NextInstanceType(next, fallbacks) {
+		instanceTypes = append(instanceTypes, next)
+		seen.Insert(next)
+	}
+	return instanceTypes
+}
+
+// machineLevel returns the fallback level of a machine of the machine deployment, 0 for machines
+// created from its template. False is returned for machines of other machine deployments.
+func machineLevel(md *clusterv1alpha1.MachineDeployment, machine *clusterv1alpha1.Machine) (int, bool) {
+	fallbackOf := machine.Labels[machineconversions.FallbackOfLabel]
+	if fallbackOf == "" {
+		return 0, selects(md, machine.Labels)
+	}
+	if fallbackOf != md.Name {
+		return 0, false
+	}
+	level, err := strconv.Atoi(machine.Labels[fallbackLevelLabel])
+	return level, err == nil && level > 0
+}
+
+func fallbackMachineDeploymentName(mdName string, level int) string {
+	return fmt.Sprintf("%s-fallback-%d", mdName, level)
+}
+
+// reconcileFallbackMachineDeployment scales the fallback machine deployment of the given level
+// to the number of machines of the previous level that are out of capacity. It is deleted when
+// no machine is out of capacity.
+func (r *reconciler) reconcileFallbackMachineDeployment(ctx context.Context, log *zap.SugaredLogger, md *clusterv1alpha1.MachineDeployment, level int, instanceTypes []string, replicas int32) error {
+	name := fallbackMachineDeploymentName(md.Name, level)
+	existing := &clusterv1alpha1.MachineDeployment{}
+	err := r.client.Get(ctx, types.NamespacedName{Namespace: md.Namespace, Name: name}, existing)
+	if err != nil && !kerrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get fallback machine deployment %s: %v", name, err)
+	}
+	exists := err == nil
+
+	if replicas == 0 {
+		if !exists {
+			return nil
+		}
+		log.Infow("Instance type has capacity again, removing fallback machines", "instanceType", instanceTypes[level-1], "fallback", instanceTypes[level])
+		if err := r.client.Delete(ctx, existing); err != nil && !kerrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete fallback machine deployment %s: %v", name, err)
+		}
+		r.recorder.Eventf(md, corev1.EventTypeNormal, "InstanceTypeRecovered", "Instance type %s has capacity again, removing the machines of instance type %s", instanceTypes[level-1], instanceTypes[level])
+		return nil
+	}
+
+	desired, err := fallbackMachineDeployment(md, level, instanceTypes[level], replicas)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		log.Infow("Falling back to next instance type", "current", instanceTypes[level-1], "next", instanceTypes[level], "replicas", replicas)
+		if err := r.client.Create(ctx, desired); err != nil {
+			return fmt.Errorf("failed to create fallback machine deployment %s: %v", name, err)
+		}
+		r.recorder.Eventf(md, corev1.EventTypeNormal, "InstanceTypeFallback", "Instance type %s is out of capacity, falling back to %s for %d machines", instanceTypes[level-1], instanceTypes[level], replicas)
+		return nil
+	}
+
+	// the template follows the one of the machine deployment, so updates are rolled out to the
+	// fallback machines as well
+	oldFallback := existing.DeepCopy()
+	existing.Spec.Replicas = desired.Spec.Replicas
+	existing.Spec.Template = desired.Spec.Template
+	if equality.Semantic.DeepEqual(oldFallback.Spec, existing.Spec) {
+		return nil
+	}
+	if err := r.client.Patch(ctx, existing, ctrlruntimeclient.MergeFrom(oldFallback)); err != nil {
+		return fmt.Errorf("failed to update fallback machine deployment %s: %v", name, err)
+	}
+
+	return nil
+}
+
+// fallbackMachineDeployment returns the fallback machine deployment of the given level, a copy of
+// the machine deployment with the fallback instance type and its own selector.
+func fallbackMachineDeployment(md *clusterv1alpha1.MachineDeployment, level int, instanceType string, replicas int32) (*clusterv1alpha1.MachineDeployment, error) {
+	// the labels must differ from the ones of the machine deployment, otherwise its selector
+	// would match the fallback machines
+	labels := map[string]string{
+		machineconversions.FallbackOfLabel: md.Name,
+		fallbackLevelLabel:                 strconv.Itoa(level),
+	}
+
+	template := md.Spec.Template.DeepCopy()
+	template.Labels = labels
+	if err := machineconversions.SetInstanceType(&template.Spec, instanceType); err != nil {
+		return nil, fmt.Errorf("failed to set instance type: %v", err)
+	}
+
+	return &clusterv1alpha1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            fallbackMachineDeploymentName(md.Name, level),
+			Namespace:       md.Namespace,
+			Labels:          labels,
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(md, clusterv1alpha1.SchemeGroupVersion.WithKind("MachineDeployment"))},
+		},
+		Spec: clusterv1alpha1.MachineDeploymentSpec{
+			Replicas: &replicas,
+			Selector: metav1.LabelSelector{MatchLabels: labels},
+			Template: *template,
+		},
+	}, nil
+}
+
+// deleteFallbackMachineDeployments deletes the fallback machine deployments of the machine
+// deployment starting at the given level.
+func (r *reconciler) deleteFallbackMachineDeployments(ctx context.Context, md *clusterv1alpha1.MachineDeployment, from int) error {
+	fallbackMDs := &clusterv1alpha1.MachineDeploymentList{}
+	if err := r.client.List(ctx, fallbackMDs, ctrlruntimeclient.InNamespace(md.Namespace), ctrlruntimeclient.MatchingLabels{machineconversions.FallbackOfLabel: md.Name}); err != nil {
+		return fmt.Errorf("failed to list fallback machine deployments: %v", err)
+	}
+
+	for i := range fallbackMDs.Items {
+		fallbackMD := &fallbackMDs.Items[i]
+		if level, err := strconv.Atoi(fallbackMD.Labels[fallbackLevelLabel]); err == nil && level < from {
+			continue
+		}
+		if err := r.client.Delete(ctx, fallbackMD); err != nil && !kerrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete fallback machine deployment %s: %v", fallbackMD.Name, err)
+		}
+	}
+
+	return nil
+}
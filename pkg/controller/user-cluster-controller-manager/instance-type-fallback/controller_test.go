@@ -0,0 +1,272 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancetypefallback
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/go-test/deep"
+	"go.uber.org/zap"
+
+	"github.com/kubermatic/machine-controller/pkg/apis/cluster/common"
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+	machineconversions "k8c.io/kubermatic/v2/pkg/machine"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func init() {
+	if err := clusterv1alpha1.SchemeBuilder.AddToScheme(scheme.Scheme); err != nil {
+		kubermaticlog.Logger.Fatalw("failed to add clusterv1alpha1 scheme to scheme.Scheme", zap.Error(err))
+	}
+}
+
+const (
+	mdName      = "worker"
+	mdNamespace = "kube-system"
+)
+
+func machineSpec(instanceType string) clusterv1alpha1.MachineSpec {
+	return clusterv1alpha1.MachineSpec{
+		ProviderSpec: clusterv1alpha1.ProviderSpec{
+			Value: &runtime.RawExtension{Raw: []byte(fmt.Sprintf(`{"cloudProvider":"aws","cloudProviderSpec":{"instanceType":%q,"diskSize":25}}`, instanceType))},
+		},
+	}
+}
+
+func genMachineDeployment(instanceType string, fallbacks ...string) *clusterv1alpha1.MachineDeployment {
+	md := &clusterv1alpha1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      mdName,
+			Namespace: mdNamespace,
+		},
+		Spec: clusterv1alpha1.MachineDeploymentSpec{
+			Selector: metav1.LabelSelector{MatchLabels: map[string]string{"machine": "md-worker"}},
+			Template: clusterv1alpha1.MachineTemplateSpec{
+				Spec: machineSpec(instanceType),
+			},
+		},
+	}
+	machineconversions.SetFallbackInstanceTypes(md, fallbacks)
+	return md
+}
+
+func genMachine(name, instanceType string, outOfCapacity bool) *clusterv1alpha1.Machine {
+	machine := &clusterv1alpha1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: mdNamespace,
+			Labels:    map[string]string{"machine": "md-worker"},
+		},
+		Spec: machineSpec(instanceType),
+	}
+	if outOfCapacity {
+		reason := common.InsufficientResourcesMachineError
+		machine.Status.ErrorReason = &reason
+	}
+	return machine
+}
+
+func genFallbackMachine(name, instanceType string, level int, outOfCapacity bool) *clusterv1alpha1.Machine {
+	machine := genMachine(name, instanceType, outOfCapacity)
+	machine.Labels = map[string]string{
+		machineconversions.FallbackOfLabel: mdName,
+		fallbackLevelLabel:                 strconv.Itoa(level),
+	}
+	return machine
+}
+
+func genFallbackMachineDeployment(instanceType string, level int, replicas int32) *clusterv1alpha1.MachineDeployment {
+	md, err := fallbackMachineDeployment(genMachineDeployment("t3.large"), level, instanceType, replicas)
+	if err != nil {
+		panic(err)
+	}
+	return md
+}
+
+type fallback struct {
+	InstanceType string
+	Replicas     int32
+}
+
+func TestReconcile(t *testing.T) {
+	testCases := []struct {
+		name              string
+		md                *clusterv1alpha1.MachineDeployment
+		objects           []ctrlruntimeclient.Object
+		expectedFallbacks map[string]fallback
+		expectedInUse     map[string]int32
+	}{
+		{
+			name: "machines are running",
+			md:   genMachineDeployment("t3.large", "m5.large", "m4.large"),
+			objects: []ctrlruntimeclient.Object{
+				genMachine("worker-a", "t3.large", false),
+				genMachine("worker-b", "t3.large", false),
+			},
+			expectedFallbacks: map[string]fallback{},
+			expectedInUse:     map[string]int32{"t3.large": 2},
+		},
+		{
+			name: "instance type is out of capacity",
+			md:   genMachineDeployment("t3.large", "m5.large", "m4.large"),
+			objects: []ctrlruntimeclient.Object{
+				genMachine("worker-a", "t3.large", false),
+				genMachine("worker-b", "t3.large", true),
+			},
+			expectedFallbacks: map[string]fallback{"worker-fallback-1": {"m5.large", 1}},
+			expectedInUse:     map[string]int32{"t3.large": 2},
+		},
+		{
+			name: "fallback instance type is out of capacity",
+			md:   genMachineDeployment("t3.large", "m5.large", "m4.large"),
+			objects: []ctrlruntimeclient.Object{
+				genMachine("worker-a", "t3.large", true),
+				genMachine("worker-b", "t3.large", false),
+				genFallbackMachineDeployment("m5.large", 1, 1),
+				genFallbackMachine("worker-fallback-1-a", "m5.large", 1, true),
+			},
+			expectedFallbacks: map[string]fallback{
+				"worker-fallback-1": {"m5.large", 1},
+				"worker-fallback-2": {"m4.large", 1},
+			},
+			expectedInUse: map[string]int32{"t3.large": 2, "m5.large": 1},
+		},
+		{
+			name: "instance type has capacity again",
+			md:   genMachineDeployment("t3.large", "m5.large", "m4.large"),
+			objects: []ctrlruntimeclient.Object{
+				genMachine("worker-a", "t3.large", false),
+				genFallbackMachineDeployment("m5.large", 1, 1),
+				genFallbackMachine("worker-fallback-1-a", "m5.large", 1, false),
+			},
+			expectedFallbacks: map[string]fallback{},
+			expectedInUse:     map[string]int32{"t3.large": 1, "m5.large": 1},
+		},
+		{
+			name: "fallback machine deployment follows the number of machines out of capacity",
+			md:   genMachineDeployment("t3.large", "m5.large"),
+			objects: []ctrlruntimeclient.Object{
+				genMachine("worker-a", "t3.large", true),
+				genMachine("worker-b", "t3.large", false),
+				genFallbackMachineDeployment("m5.large", 1, 2),
+			},
+			expectedFallbacks: map[string]fallback{"worker-fallback-1": {"m5.large", 1}},
+			expectedInUse:     map[string]int32{"t3.large": 2},
+		},
+		{
+			name: "no fallback instance type left",
+			md:   genMachineDeployment("m4.large", "m5.large", "m4.large"),
+			objects: []ctrlruntimeclient.Object{
+				genMachine("worker-a", "m4.large", true),
+			},
+			expectedFallbacks: map[string]fallback{},
+			expectedInUse:     map[string]int32{"m4.large": 1},
+		},
+		{
+			name: "machines of other machine deployments are ignored",
+			md:   genMachineDeployment("t3.large", "m5.large"),
+			objects: []ctrlruntimeclient.Object{
+				genMachine("worker-a", "t3.large", false),
+				&clusterv1alpha1.Machine{
+					ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: mdNamespace, Labels: map[string]string{"machine": "md-other"}},
+					Spec:       machineSpec("t3.large"),
+				},
+			},
+			expectedFallbacks: map[string]fallback{},
+			expectedInUse:     map[string]int32{"t3.large": 1},
+		},
+		{
+			name: "no fallback instance types",
+			md:   genMachineDeployment("t3.large"),
+			objects: []ctrlruntimeclient.Object{
+				genMachine("worker-a", "t3.large", true),
+				genFallbackMachineDeployment("m5.large", 1, 1),
+			},
+			expectedFallbacks: map[string]fallback{},
+		},
+	}
+
+	for idx := range testCases {
+		tc := testCases[idx]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := fakectrlruntimeclient.NewClientBuilder().WithObjects(append(tc.objects, tc.md)...).Build()
+			r := &reconciler{
+				log:      kubermaticlog.Logger,
+				client:   client,
+				recorder: record.NewFakeRecorder(10),
+			}
+
+			ctx := context.Background()
+			request := reconcile.Request{NamespacedName: types.NamespacedName{Name: mdName, Namespace: mdNamespace}}
+			if _, err := r.Reconcile(ctx, request); err != nil {
+				t.Fatalf("failed to reconcile: %v", err)
+			}
+
+			md := &clusterv1alpha1.MachineDeployment{}
+			if err := client.Get(ctx, request.NamespacedName, md); err != nil {
+				t.Fatalf("failed to get machine deployment: %v", err)
+			}
+
+			// the machines which are running are never replaced
+			instanceType, err := machineconversions.GetInstanceType(md.Spec.Template.Spec)
+			if err != nil {
+				t.Fatalf("failed to get instance type: %v", err)
+			}
+			if expected, _ := machineconversions.GetInstanceType(tc.md.Spec.Template.Spec); instanceType != expected {
+				t.Errorf("expected instance type %q, got %q", expected, instanceType)
+			}
+
+			fallbackMDs := &clusterv1alpha1.MachineDeploymentList{}
+			if err := client.List(ctx, fallbackMDs, ctrlruntimeclient.MatchingLabels{machineconversions.FallbackOfLabel: mdName}); err != nil {
+				t.Fatalf("failed to list fallback machine deployments: %v", err)
+			}
+			fallbacks := map[string]fallback{}
+			for _, fallbackMD := range fallbackMDs.Items {
+				instanceType, err := machineconversions.GetInstanceType(fallbackMD.Spec.Template.Spec)
+				if err != nil {
+					t.Fatalf("failed to get instance type of %s: %v", fallbackMD.Name, err)
+				}
+				fallbacks[fallbackMD.Name] = fallback{InstanceType: instanceType, Replicas: *fallbackMD.Spec.Replicas}
+			}
+			if diff := deep.Equal(fallbacks, tc.expectedFallbacks); diff != nil {
+				t.Errorf("unexpected fallback machine deployments, diff: %v", diff)
+			}
+
+			inUse, err := machineconversions.GetInstanceTypesInUse(md)
+			if err != nil {
+				t.Fatalf("failed to get instance types in use: %v", err)
+			}
+			if diff := deep.Equal(inUse, tc.expectedInUse); diff != nil {
+				t.Errorf("unexpected instance types in use, diff: %v", diff)
+			}
+		})
+	}
+}
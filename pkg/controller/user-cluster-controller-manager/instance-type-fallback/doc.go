@@ -0,0 +1,26 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package instancetypefallback contains a controller that creates machines of the fallback instance
+types of machine deployments once the cloud provider reports that it ran out of capacity for their
+instance type. The machines out of capacity are compensated by a fallback machine deployment per
+fallback instance type, the machine deployment itself and its running machines are left untouched.
+The fallback machine deployments are scaled down and removed again once the machines of the
+previous instance type could be created. The number of machines per instance type is reported in
+an annotation.
+*/
+package instancetypefallback
@@ -0,0 +1,260 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodemaintenance
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	controllerName = "node-maintenance"
+
+	// MaintenanceScheduledCondition is the node condition set by the maintenance monitor of the
+	// node-problem-detector addon while a maintenance or interruption of the node is announced.
+	MaintenanceScheduledCondition corev1.NodeConditionType = "MaintenanceScheduled"
+
+	// cordonedAnnotation marks the nodes cordoned by this controller, only those are uncordoned
+	// once the maintenance is over.
+	cordonedAnnotation = "kubermatic.io/cordoned-for-maintenance"
+	// drainedAnnotation marks the nodes which have been drained completely.
+	drainedAnnotation = "kubermatic.io/drained-for-maintenance"
+
+	// mirrorPodAnnotation marks the static pods, which cannot be evicted.
+	mirrorPodAnnotation = "kubernetes.io/config.mirror"
+
+	// drainRetryPeriod is the interval in which the remaining pods of a node are evicted again,
+	// for example because a PodDisruptionBudget did not allow the eviction.
+	drainRetryPeriod = 10 * time.Second
+)
+
+type reconciler struct {
+	log          *zap.SugaredLogger
+	seedClient   ctrlruntimeclient.Client
+	userClient   ctrlruntimeclient.Client
+	seedRecorder record.EventRecorder
+	clusterName  string
+
+	// evict is overridden in tests
+	evict func(ctx context.Context, pod *corev1.Pod) error
+}
+
+func Add(ctx context.Context, log *zap.SugaredLogger, seedMgr, userMgr manager.Manager, clusterName string) error {
+	log = log.Named(controllerName)
+
+	clientset, err := kubernetes.NewForConfig(userMgr.GetConfig())
+	if err != nil {
+		return fmt.Errorf("failed to create clientset: %v", err)
+	}
+
+	r := &reconciler{
+		log:          log,
+		seedClient:   seedMgr.GetClient(),
+		userClient:   userMgr.GetClient(),
+		seedRecorder: seedMgr.GetEventRecorderFor(controllerName),
+		clusterName:  clusterName,
+		evict: func(ctx context.Context, pod *corev1.Pod) error {
+			return clientset.PolicyV1beta1().Evictions(pod.Namespace).Evict(ctx, &policyv1beta1.Eviction{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      pod.Name,
+					Namespace: pod.Namespace,
+				},
+			})
+		},
+	}
+	c, err := controller.New(controllerName, userMgr, controller.Options{
+		Reconciler: r,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create controller %s: %v", controllerName, err)
+	}
+
+	if err := c.Watch(&source.Kind{Type: &corev1.Node{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("failed to establish watch for the Nodes %v", err)
+	}
+
+	return nil
+}
+
+func (r *reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.With("node", request.Name)
+	log.Debug("Reconciling")
+
+	node := &corev1.Node{}
+	if err := r.userClient.Get(ctx, request.NamespacedName, node); err != nil {
+		return reconcile.Result{}, ctrlruntimeclient.IgnoreNotFound(err)
+	}
+
+	cluster := &kubermaticv1.Cluster{}
+	if err := r.seedClient.Get(ctx, types.NamespacedName{Name: r.clusterName}, cluster); err != nil {
+		if kerrors.IsNotFound(err) {
+			log.Debug("cluster not found, returning")
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, fmt.Errorf("failed to get cluster: %v", err)
+	}
+
+	result, err := r.reconcile(ctx, log, cluster, node)
+	if err != nil {
+		log.Errorw("Reconciling failed", zap.Error(err))
+		r.seedRecorder.Event(cluster, corev1.EventTypeWarning, "ReconcilingError", err.Error())
+	}
+
+	return result, err
+}
+
+func (r *reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster, node *corev1.Node) (reconcile.Result, error) {
+	if condition := maintenanceCondition(node); condition != nil {
+		return r.drain(ctx, log, cluster, node, condition)
+	}
+
+	_, cordoned := node.Annotations[cordonedAnnotation]
+	_, drained := node.Annotations[drainedAnnotation]
+	if !cordoned && !drained {
+		return reconcile.Result{}, nil
+	}
+
+	oldNode := node.DeepCopy()
+	if cordoned {
+		node.Spec.Unschedulable = false
+	}
+	delete(node.Annotations, cordonedAnnotation)
+	delete(node.Annotations, drainedAnnotation)
+	if err := r.userClient.Patch(ctx, node, ctrlruntimeclient.MergeFrom(oldNode)); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to uncordon node: %v", err)
+	}
+
+	log.Info("Maintenance of node is over")
+	r.seedRecorder.Eventf(cluster, corev1.EventTypeNormal, "NodeMaintenanceCompleted", "No maintenance is announced for node %s anymore", node.Name)
+
+	return reconcile.Result{}, nil
+}
+
+// drain cordons the node and evicts its pods. Pods whose eviction is not allowed yet are retried
+// until the node is empty.
+func (r *reconciler) drain(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster, node *corev1.Node, condition *corev1.NodeCondition) (reconcile.Result, error) {
+	if node.Annotations[drainedAnnotation] == "true" {
+		return reconcile.Result{}, nil
+	}
+
+	if !node.Spec.Unschedulable {
+		oldNode := node.DeepCopy()
+		node.Spec.Unschedulable = true
+		if node.Annotations == nil {
+			node.Annotations = map[string]string{}
+		}
+		node.Annotations[cordonedAnnotation] = "true"
+		if err := r.userClient.Patch(ctx, node, ctrlruntimeclient.MergeFrom(oldNode)); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to cordon node: %v", err)
+		}
+
+		log.Infow("Cordoned node because of announced maintenance", "reason", condition.Message)
+		r.seedRecorder.Eventf(cluster, corev1.EventTypeWarning, "NodeMaintenanceScheduled", "Cordoning and draining node %s: %s", node.Name, condition.Message)
+	}
+
+	pods := &corev1.PodList{}
+	if err := r.userClient.List(ctx, pods); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to list pods: %v", err)
+	}
+
+	remaining := 0
+	for i, pod := range pods.Items {
+		if pod.Spec.NodeName != node.Name || !evictable(pod) {
+			continue
+		}
+		remaining++
+
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		if err := r.evict(ctx, &pods.Items[i]); err != nil {
+			switch {
+			case kerrors.IsNotFound(err):
+				remaining--
+			case kerrors.IsTooManyRequests(err):
+				log.Debugw("Eviction of pod is not allowed yet", "pod", ctrlruntimeclient.ObjectKeyFromObject(&pod).String())
+			default:
+				return reconcile.Result{}, fmt.Errorf("failed to evict pod %s/%s: %v", pod.Namespace, pod.Name, err)
+			}
+		}
+	}
+
+	if remaining > 0 {
+		return reconcile.Result{RequeueAfter: drainRetryPeriod}, nil
+	}
+
+	oldNode := node.DeepCopy()
+	if node.Annotations == nil {
+		node.Annotations = map[string]string{}
+	}
+	node.Annotations[drainedAnnotation] = "true"
+	if err := r.userClient.Patch(ctx, node, ctrlruntimeclient.MergeFrom(oldNode)); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to mark node as drained: %v", err)
+	}
+
+	log.Info("Drained node")
+	r.seedRecorder.Eventf(cluster, corev1.EventTypeNormal, "NodeDrained", "Drained node %s for the announced maintenance", node.Name)
+
+	return reconcile.Result{}, nil
+}
+
+// maintenanceCondition returns the maintenance condition of the node if a maintenance is
+// announced for it.
+func maintenanceCondition(node *corev1.Node) *corev1.NodeCondition {
+	for i, condition := range node.Status.Conditions {
+		if condition.Type == MaintenanceScheduledCondition && condition.Status == corev1.ConditionTrue {
+			return &node.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// evictable returns false for the pods which are not moved by evicting them, like the pods of
+// DaemonSets, static pods and pods which have already terminated.
+func evictable(pod corev1.Pod) bool {
+	if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		return false
+	}
+	if _, ok := pod.Annotations[mirrorPodAnnotation]; ok {
+		return false
+	}
+	for _, owner := range pod.OwnerReferences {
+		if owner.Kind == "DaemonSet" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodemaintenance
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func genNode(maintenance bool, annotations map[string]string) *corev1.Node {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1", Annotations: annotations},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+			},
+		},
+	}
+	if maintenance {
+		node.Status.Conditions = append(node.Status.Conditions, corev1.NodeCondition{
+			Type:    MaintenanceScheduledCondition,
+			Status:  corev1.ConditionTrue,
+			Reason:  "MaintenanceScheduled",
+			Message: "Azure scheduled a Reboot of the VM",
+		})
+	}
+	return node
+}
+
+func genPod(name, node string, ownerKind string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceDefault},
+		Spec:       corev1.PodSpec{NodeName: node},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	if ownerKind != "" {
+		pod.OwnerReferences = []metav1.OwnerReference{{Kind: ownerKind, Name: "owner", APIVersion: "apps/v1"}}
+	}
+	return pod
+}
+
+func TestReconcile(t *testing.T) {
+	testCases := []struct {
+		name                string
+		node                *corev1.Node
+		unschedulable       bool
+		pods                []ctrlruntimeclient.Object
+		blockedPods         sets.String
+		expectedEvicted     sets.String
+		expectUnschedulable bool
+		expectAnnotations   sets.String
+		expectRequeue       bool
+		expectEvent         string
+	}{
+		{
+			name:              "no maintenance",
+			node:              genNode(false, nil),
+			pods:              []ctrlruntimeclient.Object{genPod("app", "node-1", "ReplicaSet")},
+			expectedEvicted:   sets.NewString(),
+			expectAnnotations: sets.NewString(),
+		},
+		{
+			name: "node is cordoned and drained",
+			node: genNode(true, nil),
+			pods: []ctrlruntimeclient.Object{
+				genPod("app", "node-1", "ReplicaSet"),
+				genPod("other-node", "node-2", "ReplicaSet"),
+				genPod("agent", "node-1", "DaemonSet"),
+			},
+			expectedEvicted:     sets.NewString("app"),
+			expectUnschedulable: true,
+			expectAnnotations:   sets.NewString(cordonedAnnotation),
+			expectRequeue:       true,
+			expectEvent:         "NodeMaintenanceScheduled",
+		},
+		{
+			name:                "blocked eviction is retried",
+			node:                genNode(true, map[string]string{cordonedAnnotation: "true"}),
+			unschedulable:       true,
+			pods:                []ctrlruntimeclient.Object{genPod("app", "node-1", "ReplicaSet")},
+			blockedPods:         sets.NewString("app"),
+			expectedEvicted:     sets.NewString(),
+			expectUnschedulable: true,
+			expectAnnotations:   sets.NewString(cordonedAnnotation),
+			expectRequeue:       true,
+		},
+		{
+			name:                "empty node is marked as drained",
+			node:                genNode(true, map[string]string{cordonedAnnotation: "true"}),
+			unschedulable:       true,
+			pods:                []ctrlruntimeclient.Object{genPod("agent", "node-1", "DaemonSet")},
+			expectedEvicted:     sets.NewString(),
+			expectUnschedulable: true,
+			expectAnnotations:   sets.NewString(cordonedAnnotation, drainedAnnotation),
+			expectEvent:         "NodeDrained",
+		},
+		{
+			name:                "node cordoned by somebody else is not uncordoned",
+			node:                genNode(false, map[string]string{drainedAnnotation: "true"}),
+			unschedulable:       true,
+			expectedEvicted:     sets.NewString(),
+			expectUnschedulable: true,
+			expectAnnotations:   sets.NewString(),
+			expectEvent:         "NodeMaintenanceCompleted",
+		},
+		{
+			name:              "node is uncordoned after the maintenance",
+			node:              genNode(false, map[string]string{cordonedAnnotation: "true", drainedAnnotation: "true"}),
+			unschedulable:     true,
+			expectedEvicted:   sets.NewString(),
+			expectAnnotations: sets.NewString(),
+			expectEvent:       "NodeMaintenanceCompleted",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			tc.node.Spec.Unschedulable = tc.unschedulable
+
+			cluster := &kubermaticv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"}}
+			recorder := record.NewFakeRecorder(10)
+			evicted := sets.NewString()
+
+			r := &reconciler{
+				log:          kubermaticlog.Logger,
+				seedClient:   fakectrlruntimeclient.NewClientBuilder().WithObjects(cluster).Build(),
+				userClient:   fakectrlruntimeclient.NewClientBuilder().WithObjects(append(tc.pods, tc.node)...).Build(),
+				seedRecorder: recorder,
+				clusterName:  cluster.Name,
+				evict: func(_ context.Context, pod *corev1.Pod) error {
+					if tc.blockedPods.Has(pod.Name) {
+						return kerrors.NewTooManyRequests("disruption budget", 10)
+					}
+					evicted.Insert(pod.Name)
+					return nil
+				},
+			}
+
+			result, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: tc.node.Name}})
+			if err != nil {
+				t.Fatalf("reconciling failed: %v", err)
+			}
+
+			if !evicted.Equal(tc.expectedEvicted) {
+				t.Errorf("expected evicted pods %v, got %v", tc.expectedEvicted.List(), evicted.List())
+			}
+			if (result.RequeueAfter > 0) != tc.expectRequeue {
+				t.Errorf("expected requeue to be %v, got %v", tc.expectRequeue, result)
+			}
+
+			node := &corev1.Node{}
+			if err := r.userClient.Get(ctx, types.NamespacedName{Name: tc.node.Name}, node); err != nil {
+				t.Fatalf("failed to get node: %v", err)
+			}
+			if node.Spec.Unschedulable != tc.expectUnschedulable {
+				t.Errorf("expected node to be unschedulable=%v", tc.expectUnschedulable)
+			}
+			annotations := sets.NewString()
+			for key := range node.Annotations {
+				annotations.Insert(key)
+			}
+			if !annotations.Equal(tc.expectAnnotations) {
+				t.Errorf("expected annotations %v, got %v", tc.expectAnnotations.List(), annotations.List())
+			}
+
+			select {
+			case event := <-recorder.Events:
+				if tc.expectEvent == "" {
+					t.Errorf("unexpected event %q", event)
+				} else if !containsReason(event, tc.expectEvent) {
+					t.Errorf("expected event %q, got %q", tc.expectEvent, event)
+				}
+			default:
+				if tc.expectEvent != "" {
+					t.Errorf("expected event %q, got none", tc.expectEvent)
+				}
+			}
+		})
+	}
+}
+
+func containsReason(event, reason string) bool {
+	for _, eventType := range []string{corev1.EventTypeNormal, corev1.EventTypeWarning} {
+		if strings.HasPrefix(event, eventType+" "+reason+" ") {
+			return true
+		}
+	}
+	return false
+}
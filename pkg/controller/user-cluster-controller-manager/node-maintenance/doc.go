@@ -0,0 +1,24 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package nodemaintenance contains a controller that cordons and drains the nodes for which the
+cloud provider announced a maintenance or an interruption, as reported by the maintenance
+monitor of the node-problem-detector addon, so that the workloads are moved before the node
+goes away. Nodes are uncordoned once the announcement is gone. Every step is recorded as an
+event on the cluster.
+*/
+package nodemaintenance
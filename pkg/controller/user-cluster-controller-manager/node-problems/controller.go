@@ -0,0 +1,235 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeproblems
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	controllerName = "node-problems"
+
+	// remediationDelay is the time a node has to report a permanent problem before its machine is
+	// replaced, to not replace machines because of short glitches.
+	remediationDelay = 10 * time.Minute
+)
+
+// permanentProblems are the problems reported by the node-problem-detector which do not recover
+// without a reboot of the node.
+var permanentProblems = map[corev1.NodeConditionType]bool{
+	"KernelDeadlock":        true,
+	"ReadonlyFilesystem":    true,
+	"CorruptDockerOverlay2": true,
+}
+
+type reconciler struct {
+	log          *zap.SugaredLogger
+	seedClient   ctrlruntimeclient.Client
+	userClient   ctrlruntimeclient.Client
+	seedRecorder record.EventRecorder
+	clusterName  string
+}
+
+func Add(ctx context.Context, log *zap.SugaredLogger, seedMgr, userMgr manager.Manager, clusterName string) error {
+	log = log.Named(controllerName)
+
+	r := &reconciler{
+		log:          log,
+		seedClient:   seedMgr.GetClient(),
+		userClient:   userMgr.GetClient(),
+		seedRecorder: seedMgr.GetEventRecorderFor(controllerName),
+		clusterName:  clusterName,
+	}
+	c, err := controller.New(controllerName, userMgr, controller.Options{
+		Reconciler: r,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create controller %s: %v", controllerName, err)
+	}
+
+	enqueueCluster := handler.EnqueueRequestsFromMapFunc(func(o ctrlruntimeclient.Object) []reconcile.Request {
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: clusterName}}}
+	})
+
+	if err := c.Watch(&source.Kind{Type: &corev1.Node{}}, enqueueCluster); err != nil {
+		return fmt.Errorf("failed to establish watch for the Nodes %v", err)
+	}
+
+	return nil
+}
+
+func (r *reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.With("Request", request.NamespacedName.String())
+	log.Debug("Reconciling")
+
+	cluster := &kubermaticv1.Cluster{}
+	if err := r.seedClient.Get(ctx, request.NamespacedName, cluster); err != nil {
+		if kerrors.IsNotFound(err) {
+			log.Debug("cluster not found, returning")
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, fmt.Errorf("failed to get cluster: %v", err)
+	}
+
+	result, err := r.reconcile(ctx, log, cluster)
+	if err != nil {
+		log.Errorw("Reconciling failed", zap.Error(err))
+		r.seedRecorder.Event(cluster, corev1.EventTypeWarning, "ReconcilingError", err.Error())
+	}
+
+	return result, err
+}
+
+func (r *reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster) (reconcile.Result, error) {
+	nodes := &corev1.NodeList{}
+	if err := r.userClient.List(ctx, nodes); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to list nodes: %v", err)
+	}
+
+	problems := nodeProblems(nodes.Items)
+
+	health := kubermaticv1.HealthStatusUp
+	if len(problems) > 0 {
+		health = kubermaticv1.HealthStatusDown
+	}
+
+	if !reflect.DeepEqual(cluster.Status.NodeProblems, problems) || cluster.Status.ExtendedHealth.Nodes != health {
+		oldCluster := cluster.DeepCopy()
+		cluster.Status.NodeProblems = problems
+		cluster.Status.ExtendedHealth.Nodes = health
+		if err := r.seedClient.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to update node problems of the cluster: %v", err)
+		}
+	}
+
+	if !cluster.Spec.Features[kubermaticv1.ClusterFeatureNodeProblemRemediation] {
+		return reconcile.Result{}, nil
+	}
+
+	return r.remediate(ctx, log, cluster, problems)
+}
+
+// nodeProblems returns the conditions of the nodes which indicate a problem. All node conditions
+// except Ready are true if the node has a problem, which also holds for the conditions reported by
+// custom node-problem-detector plugins.
+func nodeProblems(nodes []corev1.Node) []kubermaticv1.NodeProblem {
+	var problems []kubermaticv1.NodeProblem
+	for _, node := range nodes {
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == corev1.NodeReady || condition.Status != corev1.ConditionTrue {
+				continue
+			}
+			problems = append(problems, kubermaticv1.NodeProblem{
+				Node:    node.Name,
+				Type:    condition.Type,
+				Reason:  condition.Reason,
+				Message: condition.Message,
+				Since:   condition.LastTransitionTime,
+			})
+		}
+	}
+
+	sort.Slice(problems, func(i, j int) bool {
+		if problems[i].Node != problems[j].Node {
+			return problems[i].Node < problems[j].Node
+		}
+		return problems[i].Type < problems[j].Type
+	})
+
+	return problems
+}
+
+// remediate deletes the machine of a node which reports a permanent problem for longer than the
+// remediation delay, so that its MachineSet replaces it. Only one machine is replaced at a time.
+func (r *reconciler) remediate(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster, problems []kubermaticv1.NodeProblem) (reconcile.Result, error) {
+	machines := &clusterv1alpha1.MachineList{}
+	if err := r.userClient.List(ctx, machines); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to list machines: %v", err)
+	}
+
+	for _, machine := range machines.Items {
+		if machine.DeletionTimestamp != nil {
+			log.Debugw("Machine is being deleted, not remediating other nodes", "machine", machine.Name)
+			return reconcile.Result{RequeueAfter: time.Minute}, nil
+		}
+	}
+
+	var requeueAfter time.Duration
+	for _, problem := range problems {
+		if !permanentProblems[problem.Type] {
+			continue
+		}
+
+		if wait := remediationDelay - time.Since(problem.Since.Time); wait > 0 {
+			if requeueAfter == 0 || wait < requeueAfter {
+				requeueAfter = wait
+			}
+			continue
+		}
+
+		machine := machineForNode(machines.Items, problem.Node)
+		if machine == nil {
+			continue
+		}
+
+		log.Infow("Replacing machine of node with a permanent problem", "machine", machine.Name, "node", problem.Node, "problem", problem.Type)
+		if err := r.userClient.Delete(ctx, machine); err != nil && !kerrors.IsNotFound(err) {
+			return reconcile.Result{}, fmt.Errorf("failed to delete machine %s: %v", machine.Name, err)
+		}
+		r.seedRecorder.Eventf(cluster, corev1.EventTypeNormal, "NodeRemediated", "Replacing machine %s, because node %s reports %s since %s", machine.Name, problem.Node, problem.Type, problem.Since.Format(time.RFC3339))
+
+		return reconcile.Result{RequeueAfter: time.Minute}, nil
+	}
+
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// machineForNode returns the machine of the node if it is owned by a MachineSet, which replaces it
+// once it is deleted.
+func machineForNode(machines []clusterv1alpha1.Machine, node string) *clusterv1alpha1.Machine {
+	for i, machine := range machines {
+		if machine.Status.NodeRef == nil || machine.Status.NodeRef.Name != node {
+			continue
+		}
+		for _, owner := range machine.OwnerReferences {
+			if owner.Kind == "MachineSet" {
+				return &machines[i]
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeproblems
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func genNode(name string, since time.Time, conditions ...corev1.NodeConditionType) *corev1.Node {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+				{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionFalse},
+			},
+		},
+	}
+	for _, condition := range conditions {
+		node.Status.Conditions = append(node.Status.Conditions, corev1.NodeCondition{
+			Type:               condition,
+			Status:             corev1.ConditionTrue,
+			Reason:             "Problem",
+			LastTransitionTime: metav1.NewTime(since),
+		})
+	}
+	return node
+}
+
+func genMachine(name, node string) *clusterv1alpha1.Machine {
+	return &clusterv1alpha1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       metav1.NamespaceSystem,
+			OwnerReferences: []metav1.OwnerReference{{Kind: "MachineSet", Name: "workers", APIVersion: "cluster.k8s.io/v1alpha1"}},
+		},
+		Status: clusterv1alpha1.MachineStatus{
+			NodeRef: &corev1.ObjectReference{Name: node},
+		},
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	longAgo := time.Now().Add(-time.Hour).Truncate(time.Second)
+	recently := time.Now().Add(-time.Minute).Truncate(time.Second)
+
+	testCases := []struct {
+		name             string
+		remediation      bool
+		nodes            []*corev1.Node
+		expectedProblems []string
+		expectedHealth   kubermaticv1.HealthStatus
+		expectedDeleted  []string
+	}{
+		{
+			name:           "healthy nodes",
+			remediation:    true,
+			nodes:          []*corev1.Node{genNode("node-a", longAgo), genNode("node-b", longAgo)},
+			expectedHealth: kubermaticv1.HealthStatusUp,
+		},
+		{
+			name:             "problems are aggregated without remediation",
+			nodes:            []*corev1.Node{genNode("node-b", longAgo, "KernelDeadlock"), genNode("node-a", longAgo, corev1.NodeDiskPressure)},
+			expectedProblems: []string{"node-a/DiskPressure", "node-b/KernelDeadlock"},
+			expectedHealth:   kubermaticv1.HealthStatusDown,
+		},
+		{
+			name:             "machine with a permanent problem is replaced",
+			remediation:      true,
+			nodes:            []*corev1.Node{genNode("node-a", longAgo, "KernelDeadlock"), genNode("node-b", longAgo, corev1.NodeDiskPressure)},
+			expectedProblems: []string{"node-a/KernelDeadlock", "node-b/DiskPressure"},
+			expectedHealth:   kubermaticv1.HealthStatusDown,
+			expectedDeleted:  []string{"machine-a"},
+		},
+		{
+			name:             "recent problems are not remediated",
+			remediation:      true,
+			nodes:            []*corev1.Node{genNode("node-a", recently, "ReadonlyFilesystem"), genNode("node-b", longAgo)},
+			expectedProblems: []string{"node-a/ReadonlyFilesystem"},
+			expectedHealth:   kubermaticv1.HealthStatusDown,
+		},
+	}
+
+	for idx := range testCases {
+		tc := testCases[idx]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			scheme := runtime.NewScheme()
+			_ = kubermaticv1.AddToScheme(scheme)
+			_ = clusterv1alpha1.AddToScheme(scheme)
+			_ = corev1.AddToScheme(scheme)
+
+			cluster := &kubermaticv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: kubermaticv1.ClusterSpec{
+					Features: map[string]bool{kubermaticv1.ClusterFeatureNodeProblemRemediation: tc.remediation},
+				},
+			}
+			seedClient := fakectrlruntimeclient.NewClientBuilder().WithScheme(scheme).WithObjects(cluster).Build()
+
+			userClientBuilder := fakectrlruntimeclient.NewClientBuilder().WithScheme(scheme).
+				WithObjects(genMachine("machine-a", "node-a"), genMachine("machine-b", "node-b"))
+			for _, node := range tc.nodes {
+				userClientBuilder.WithObjects(node)
+			}
+			userClient := userClientBuilder.Build()
+
+			ctx := context.Background()
+			r := &reconciler{
+				log:          kubermaticlog.Logger,
+				seedClient:   seedClient,
+				userClient:   userClient,
+				seedRecorder: record.NewFakeRecorder(10),
+				clusterName:  cluster.Name,
+			}
+
+			if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: cluster.Name}}); err != nil {
+				t.Fatalf("reconciling failed: %v", err)
+			}
+
+			if err := seedClient.Get(ctx, types.NamespacedName{Name: cluster.Name}, cluster); err != nil {
+				t.Fatalf("failed to get cluster: %v", err)
+			}
+
+			var problems []string
+			for _, problem := range cluster.Status.NodeProblems {
+				problems = append(problems, problem.Node+"/"+string(problem.Type))
+			}
+			if len(problems) != len(tc.expectedProblems) {
+				t.Fatalf("expected problems %v, got %v", tc.expectedProblems, problems)
+			}
+			for i := range problems {
+				if problems[i] != tc.expectedProblems[i] {
+					t.Fatalf("expected problems %v, got %v", tc.expectedProblems, problems)
+				}
+			}
+			if cluster.Status.ExtendedHealth.Nodes != tc.expectedHealth {
+				t.Errorf("expected node health %q, got %q", tc.expectedHealth, cluster.Status.ExtendedHealth.Nodes)
+			}
+
+			deleted := map[string]bool{}
+			for _, name := range tc.expectedDeleted {
+				deleted[name] = true
+			}
+			for _, name := range []string{"machine-a", "machine-b"} {
+				err := userClient.Get(ctx, types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: name}, &clusterv1alpha1.Machine{})
+				if deleted[name] && !kerrors.IsNotFound(err) {
+					t.Errorf("expected machine %s to be deleted, got %v", name, err)
+				}
+				if !deleted[name] && err != nil {
+					t.Errorf("expected machine %s to exist, got %v", name, err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package nodeproblems contains a controller that aggregates the problems reported as node conditions,
+for example by the node-problem-detector addon or by the kubelet, into the status and health of the
+cluster. If the nodeProblemRemediation feature is enabled for the cluster, machines whose nodes
+report a problem that does not recover without a reboot are replaced, one at a time.
+*/
+package nodeproblems
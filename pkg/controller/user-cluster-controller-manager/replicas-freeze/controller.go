@@ -0,0 +1,122 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replicasfreeze
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+	machineconversions "k8c.io/kubermatic/v2/pkg/machine"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	// This controller creates events on the machine deployments, so do not put the word Kubermatic in it
+	controllerName = "replicas_freeze_controller"
+)
+
+type reconciler struct {
+	log      *zap.SugaredLogger
+	client   ctrlruntimeclient.Client
+	recorder record.EventRecorder
+}
+
+func Add(ctx context.Context, log *zap.SugaredLogger, mgr manager.Manager) error {
+	log = log.Named(controllerName)
+
+	r := &reconciler{
+		log:      log,
+		client:   mgr.GetClient(),
+		recorder: mgr.GetEventRecorderFor(controllerName),
+	}
+	c, err := controller.New(controllerName, mgr, controller.Options{
+		Reconciler: r,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create controller: %v", err)
+	}
+
+	if err := c.Watch(&source.Kind{Type: &clusterv1alpha1.MachineDeployment{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("failed to establish watch for machine deployments: %v", err)
+	}
+
+	return nil
+}
+
+func (r *reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.With("MachineDeployment", request.NamespacedName)
+	log.Debug("Reconciling")
+
+	md := &clusterv1alpha1.MachineDeployment{}
+	if err := r.client.Get(ctx, request.NamespacedName, md); err != nil {
+		if kerrors.IsNotFound(err) {
+			log.Debug("MachineDeployment not found, returning")
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, fmt.Errorf("failed to get machine deployment: %v", err)
+	}
+
+	err := r.reconcile(ctx, log, md)
+	if err != nil {
+		log.Errorw("Reconciling failed", zap.Error(err))
+		r.recorder.Event(md, corev1.EventTypeWarning, "ReplicasFreezeFailed", err.Error())
+	}
+	return reconcile.Result{}, err
+}
+
+func (r *reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, md *clusterv1alpha1.MachineDeployment) error {
+	if md.DeletionTimestamp != nil {
+		return nil
+	}
+
+	frozen, err := machineconversions.GetFrozenReplicas(md)
+	if err != nil || frozen == nil {
+		return err
+	}
+
+	oldMD := md.DeepCopy()
+	if md.Spec.Replicas == nil || *md.Spec.Replicas != *frozen {
+		log.Infow("Reverting replicas of frozen machine deployment", "replicas", md.Spec.Replicas, "frozen", *frozen)
+		r.recorder.Eventf(md, corev1.EventTypeWarning, "ReplicasFrozen", "The replicas are frozen at %d, unfreeze the machine deployment to scale it", *frozen)
+		md.Spec.Replicas = frozen
+	}
+	// moves autoscaler annotations that were added while frozen aside
+	machineconversions.FreezeReplicas(md)
+
+	if equality.Semantic.DeepEqual(oldMD, md) {
+		return nil
+	}
+
+	if err := r.client.Patch(ctx, md, ctrlruntimeclient.MergeFrom(oldMD)); err != nil {
+		return fmt.Errorf("failed to update machine deployment: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replicasfreeze
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/zap"
+
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+	machineconversions "k8c.io/kubermatic/v2/pkg/machine"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func init() {
+	if err := clusterv1alpha1.SchemeBuilder.AddToScheme(scheme.Scheme); err != nil {
+		kubermaticlog.Logger.Fatalw("failed to add clusterv1alpha1 scheme to scheme.Scheme", zap.Error(err))
+	}
+}
+
+const (
+	mdName      = "worker"
+	mdNamespace = "kube-system"
+)
+
+func genMachineDeployment(replicas int32, annotations map[string]string) *clusterv1alpha1.MachineDeployment {
+	return &clusterv1alpha1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        mdName,
+			Namespace:   mdNamespace,
+			Annotations: annotations,
+		},
+		Spec: clusterv1alpha1.MachineDeploymentSpec{Replicas: pointer.Int32Ptr(replicas)},
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	testCases := []struct {
+		name                string
+		md                  *clusterv1alpha1.MachineDeployment
+		expectedReplicas    int32
+		expectedAnnotations map[string]string
+	}{
+		{
+			name:             "machine deployment which is not frozen can be scaled",
+			md:               genMachineDeployment(5, map[string]string{machineconversions.AutoscalerMaxSizeAnnotation: "5"}),
+			expectedReplicas: 5,
+			expectedAnnotations: map[string]string{
+				machineconversions.AutoscalerMaxSizeAnnotation: "5",
+			},
+		},
+		{
+			name:             "scaling a frozen machine deployment is reverted",
+			md:               genMachineDeployment(5, map[string]string{machineconversions.ReplicasFrozenAnnotation: "3"}),
+			expectedReplicas: 3,
+			expectedAnnotations: map[string]string{
+				machineconversions.ReplicasFrozenAnnotation: "3",
+			},
+		},
+		{
+			name: "autoscaler annotations added to a frozen machine deployment are moved aside",
+			md: genMachineDeployment(3, map[string]string{
+				machineconversions.ReplicasFrozenAnnotation:    "3",
+				machineconversions.AutoscalerMinSizeAnnotation: "1",
+			}),
+			expectedReplicas: 3,
+			expectedAnnotations: map[string]string{
+				machineconversions.ReplicasFrozenAnnotation:                              "3",
+				"frozen.kubermatic.io/" + machineconversions.AutoscalerMinSizeAnnotation: "1",
+			},
+		},
+	}
+
+	for idx := range testCases {
+		tc := testCases[idx]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := fakectrlruntimeclient.NewClientBuilder().WithObjects(tc.md).Build()
+			r := &reconciler{
+				log:      kubermaticlog.Logger,
+				client:   client,
+				recorder: record.NewFakeRecorder(10),
+			}
+
+			ctx := context.Background()
+			request := reconcile.Request{NamespacedName: types.NamespacedName{Name: mdName, Namespace: mdNamespace}}
+			if _, err := r.Reconcile(ctx, request); err != nil {
+				t.Fatalf("failed to reconcile: %v", err)
+			}
+
+			md := &clusterv1alpha1.MachineDeployment{}
+			if err := client.Get(ctx, request.NamespacedName, md); err != nil {
+				t.Fatalf("failed to get machine deployment: %v", err)
+			}
+
+			if *md.Spec.Replicas != tc.expectedReplicas {
+				t.Errorf("expected %d replicas, got %d", tc.expectedReplicas, *md.Spec.Replicas)
+			}
+			if len(md.Annotations) != len(tc.expectedAnnotations) {
+				t.Fatalf("expected annotations %v, got %v", tc.expectedAnnotations, md.Annotations)
+			}
+			for key, value := range tc.expectedAnnotations {
+				if md.Annotations[key] != value {
+					t.Errorf("expected annotations %v, got %v", tc.expectedAnnotations, md.Annotations)
+				}
+			}
+		})
+	}
+}
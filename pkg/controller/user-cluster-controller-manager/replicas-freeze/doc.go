@@ -1,5 +1,5 @@
 /*
-Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -15,8 +15,9 @@ limitations under the License.
 */
 
 /*
-Package masterconstrainttemplatecontroller contains a controller that is responsible for ensuring that the
-kubermatic constraint templates are synced from master to the seed clusters.
-
+Package replicasfreeze contains a controller that keeps frozen machine deployments at their frozen
+number of replicas. Changes made directly in the user cluster, e.g. by scaling the machine deployment
+with kubectl, are reverted, and the cluster autoscaler annotations are kept aside so that the
+autoscaler ignores the machine deployment until it is unfrozen.
 */
-package masterconstrainttemplatecontroller
+package replicasfreeze
@@ -28,6 +28,7 @@ import (
 	"github.com/Masterminds/semver/v3"
 	"go.uber.org/zap"
 
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/resources"
 	"k8c.io/kubermatic/v2/pkg/resources/certificates/triple"
 	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
@@ -84,6 +85,7 @@ func Add(
 	opaWebhookTimeout int,
 	caBundle resources.CABundle,
 	userClusterMLA UserClusterMLA,
+	logShipping *kubermaticv1.LogShippingSettings,
 	log *zap.SugaredLogger) error {
 	r := &reconciler{
 		version:           version,
@@ -103,6 +105,7 @@ func Add(
 		versions:          versions,
 		caBundle:          caBundle,
 		userClusterMLA:    userClusterMLA,
+		logShipping:       logShipping,
 	}
 
 	var err error
@@ -148,6 +151,7 @@ func Add(
 		&admissionregistrationv1.ValidatingWebhookConfiguration{},
 		&apiextensionsv1beta1.CustomResourceDefinition{},
 		&appsv1.Deployment{},
+		&appsv1.DaemonSet{},
 		&v1beta1.PodDisruptionBudget{},
 	}
 
@@ -218,6 +222,7 @@ type reconciler struct {
 	versions          kubermatic.Versions
 	caBundle          resources.CABundle
 	userClusterMLA    UserClusterMLA
+	logShipping       *kubermaticv1.LogShippingSettings
 
 	rLock                      *sync.Mutex
 	reconciledSuccessfullyOnce bool
@@ -33,6 +33,7 @@ import (
 	"k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/resources/resources/gatekeeper"
 	kubestatemetrics "k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/resources/resources/kube-state-metrics"
 	kubernetesdashboard "k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/resources/resources/kubernetes-dashboard"
+	"k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/resources/resources/logshipping"
 	machinecontroller "k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/resources/resources/machine-controller"
 	metricsserver "k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/resources/resources/metrics-server"
 	"k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/resources/resources/mla"
@@ -161,7 +162,15 @@ func (r *reconciler) reconcile(ctx context.Context) error {
 		if err := r.ensureOPAIntegrationIsRemoved(ctx); err != nil {
 			return err
 		}
-	} else {
+	}
+
+	if r.logShipping == nil {
+		if err := r.ensureLogShippingIsRemoved(ctx); err != nil {
+			return err
+		}
+	}
+
+	if r.opaIntegration || r.logShipping != nil {
 		if err := r.healthCheck(ctx); err != nil {
 			return err
 		}
@@ -214,6 +223,10 @@ func (r *reconciler) reconcileServiceAcconts(ctx context.Context) error {
 		creators = append(creators, usersshkeys.ServiceAccountCreator())
 	}
 
+	if r.logShipping != nil {
+		creators = append(creators, logshipping.ServiceAccountCreator())
+	}
+
 	if err := reconciling.ReconcileServiceAccounts(ctx, creators, metav1.NamespaceSystem, r.Client); err != nil {
 		return fmt.Errorf("failed to reconcile ServiceAccounts in the namespace %s: %v", metav1.NamespaceSystem, err)
 	}
@@ -408,6 +421,10 @@ func (r *reconciler) reconcileClusterRoles(ctx context.Context) error {
 	if r.userClusterMLA.Logging {
 		creators = append(creators, promtail.ClusterRoleCreator())
 	}
+
+	if r.logShipping != nil {
+		creators = append(creators, logshipping.ClusterRoleCreator())
+	}
 	if r.userClusterMLA.Monitoring {
 		creators = append(creators, userclusterprometheus.ClusterRoleCreator())
 	}
@@ -444,6 +461,10 @@ func (r *reconciler) reconcileClusterRoleBindings(ctx context.Context) error {
 	if r.userClusterMLA.Logging {
 		creators = append(creators, promtail.ClusterRoleBindingCreator())
 	}
+
+	if r.logShipping != nil {
+		creators = append(creators, logshipping.ClusterRoleBindingCreator())
+	}
 	if r.userClusterMLA.Monitoring {
 		creators = append(creators, userclusterprometheus.ClusterRoleBindingCreator())
 	}
@@ -604,6 +625,10 @@ func (r *reconciler) reconcileSecrets(ctx context.Context, data reconcileData) e
 		creators = append(creators, usersshkeys.SecretCreator(data.userSSHKeys))
 	}
 
+	if r.logShipping != nil {
+		creators = append(creators, logshipping.SecretCreator(r.logShipping))
+	}
+
 	if err := reconciling.ReconcileSecrets(ctx, creators, metav1.NamespaceSystem, r.Client); err != nil {
 		return fmt.Errorf("failed to reconcile Secrets in kube-system Namespace: %v", err)
 	}
@@ -669,6 +694,10 @@ func (r *reconciler) reconcileDaemonSet(ctx context.Context) error {
 		dsCreators = append(dsCreators, envoyagent.DaemonSetCreator(r.tunnelingAgentIP, r.versions))
 	}
 
+	if r.logShipping != nil {
+		dsCreators = append(dsCreators, logshipping.DaemonSetCreator(r.logShipping))
+	}
+
 	if err := reconciling.ReconcileDaemonSets(ctx, dsCreators, metav1.NamespaceSystem, r.Client); err != nil {
 		return fmt.Errorf("failed to reconcile the DaemonSet: %v", err)
 	}
@@ -779,13 +808,26 @@ func (r *reconciler) healthCheck(ctx context.Context) error {
 	}
 	oldCluster := cluster.DeepCopy()
 
-	ctrlHealth, auditHealth, err := r.getGatekeeperHealth(ctx)
-	if err != nil {
-		return err
+	if r.opaIntegration {
+		ctrlHealth, auditHealth, err := r.getGatekeeperHealth(ctx)
+		if err != nil {
+			return err
+		}
+
+		cluster.Status.ExtendedHealth.GatekeeperController = ctrlHealth
+		cluster.Status.ExtendedHealth.GatekeeperAudit = auditHealth
 	}
 
-	cluster.Status.ExtendedHealth.GatekeeperController = ctrlHealth
-	cluster.Status.ExtendedHealth.GatekeeperAudit = auditHealth
+	if r.logShipping != nil {
+		logShippingHealth, err := resources.HealthyDaemonSet(ctx,
+			r.Client,
+			types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: resources.LogShippingDaemonSetName})
+		if err != nil {
+			return fmt.Errorf("failed to get daemonset health %q: %v", resources.LogShippingDaemonSetName, err)
+		}
+
+		cluster.Status.ExtendedHealth.LogShipping = logShippingHealth
+	}
 
 	if oldCluster.Status.ExtendedHealth != cluster.Status.ExtendedHealth {
 		if err := r.seedClient.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
@@ -827,6 +869,15 @@ func (r *reconciler) ensurePromtailIsRemoved(ctx context.Context) error {
 	return nil
 }
 
+func (r *reconciler) ensureLogShippingIsRemoved(ctx context.Context) error {
+	for _, resource := range logshipping.ResourcesOnDeletion() {
+		if err := r.Client.Delete(ctx, resource); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to ensure log shipping is removed/not present: %v", err)
+		}
+	}
+	return nil
+}
+
 func (r *reconciler) ensureUserClusterPrometheusIsRemoved(ctx context.Context) error {
 	for _, resource := range userclusterprometheus.ResourcesOnDeletion() {
 		if err := r.Client.Delete(ctx, resource); err != nil && !errors.IsNotFound(err) {
@@ -0,0 +1,205 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logshipping
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/reconciling"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+)
+
+const (
+	imageName = "fluent/fluent-bit"
+	tag       = "1.8.3"
+	appName   = "log-shipping"
+
+	configChecksumAnnotation = "checksum/config"
+
+	healthPort     = 2020
+	healthPortName = "http"
+
+	configVolumeName         = "config"
+	configVolumeMountPath    = "/fluent-bit/etc"
+	caBundleVolumeName       = "ca-bundle"
+	caBundleVolumeMountPath  = "/etc/ssl/ca-bundle"
+	stateVolumeName          = "state"
+	stateVolumeMountPath     = "/var/run/fluent-bit"
+	logVolumeName            = "logs"
+	logVolumeMountPath       = "/var/log"
+	containerVolumeName      = "containers"
+	containerVolumeMountPath = "/var/lib/docker/containers"
+
+	nameKey     = "app.kubernetes.io/name"
+	instanceKey = "app.kubernetes.io/instance"
+)
+
+var (
+	controllerLabels = map[string]string{
+		nameKey:     resources.LogShippingDaemonSetName,
+		instanceKey: resources.LogShippingDaemonSetName,
+	}
+)
+
+func DaemonSetCreator(settings *kubermaticv1.LogShippingSettings) reconciling.NamedDaemonSetCreatorGetter {
+	return func() (string, reconciling.DaemonSetCreator) {
+		return resources.LogShippingDaemonSetName, func(ds *appsv1.DaemonSet) (*appsv1.DaemonSet, error) {
+			ds.Labels = resources.BaseAppLabels(appName, nil)
+
+			// fluent-bit does not reload its configuration, so the pods are restarted when it changes
+			config, err := renderConfig(settings)
+			if err != nil {
+				return nil, err
+			}
+			ds.Spec.Template.Annotations = map[string]string{
+				configChecksumAnnotation: fmt.Sprintf("%x", sha256.Sum256(config)),
+			}
+
+			ds.Spec.Selector = &metav1.LabelSelector{
+				MatchLabels: controllerLabels,
+			}
+			ds.Spec.Template.ObjectMeta.Labels = controllerLabels
+			ds.Spec.Template.Spec.ServiceAccountName = resources.LogShippingServiceAccountName
+
+			ds.Spec.Template.Spec.Containers = []corev1.Container{
+				{
+					Name:            "fluent-bit",
+					Image:           fmt.Sprintf("%s/%s:%s", resources.RegistryDocker, imageName, tag),
+					ImagePullPolicy: corev1.PullIfNotPresent,
+					VolumeMounts: []corev1.VolumeMount{
+						{
+							Name:      configVolumeName,
+							MountPath: configVolumeMountPath,
+							ReadOnly:  true,
+						},
+						{
+							Name:      caBundleVolumeName,
+							MountPath: caBundleVolumeMountPath,
+							ReadOnly:  true,
+						},
+						{
+							Name:      stateVolumeName,
+							MountPath: stateVolumeMountPath,
+						},
+						{
+							Name:      logVolumeName,
+							MountPath: logVolumeMountPath,
+							ReadOnly:  true,
+						},
+						{
+							Name:      containerVolumeName,
+							MountPath: containerVolumeMountPath,
+							ReadOnly:  true,
+						},
+					},
+					Ports: []corev1.ContainerPort{
+						{
+							Name:          healthPortName,
+							ContainerPort: healthPort,
+							Protocol:      corev1.ProtocolTCP,
+						},
+					},
+					SecurityContext: &corev1.SecurityContext{
+						AllowPrivilegeEscalation: pointer.BoolPtr(false),
+						ReadOnlyRootFilesystem:   pointer.BoolPtr(true),
+					},
+					ReadinessProbe: &corev1.Probe{
+						Handler: corev1.Handler{
+							HTTPGet: &corev1.HTTPGetAction{
+								Path:   "/api/v1/health",
+								Port:   intstr.FromString(healthPortName),
+								Scheme: corev1.URISchemeHTTP,
+							},
+						},
+						FailureThreshold:    3,
+						InitialDelaySeconds: 10,
+						PeriodSeconds:       10,
+						SuccessThreshold:    1,
+						TimeoutSeconds:      1,
+					},
+				},
+			}
+
+			ds.Spec.Template.Spec.Tolerations = []corev1.Toleration{
+				{
+					Effect:   corev1.TaintEffectNoSchedule,
+					Key:      "node-role.kubernetes.io/master",
+					Operator: corev1.TolerationOpExists,
+				},
+			}
+
+			hostPathUnset := corev1.HostPathUnset
+			ds.Spec.Template.Spec.Volumes = []corev1.Volume{
+				{
+					Name: configVolumeName,
+					VolumeSource: corev1.VolumeSource{
+						Secret: &corev1.SecretVolumeSource{
+							SecretName: resources.LogShippingSecretName,
+						},
+					},
+				},
+				{
+					Name: caBundleVolumeName,
+					VolumeSource: corev1.VolumeSource{
+						ConfigMap: &corev1.ConfigMapVolumeSource{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: resources.CABundleConfigMapName,
+							},
+						},
+					},
+				},
+				{
+					Name: stateVolumeName,
+					VolumeSource: corev1.VolumeSource{
+						HostPath: &corev1.HostPathVolumeSource{
+							Type: &hostPathUnset,
+							Path: stateVolumeMountPath,
+						},
+					},
+				},
+				{
+					Name: logVolumeName,
+					VolumeSource: corev1.VolumeSource{
+						HostPath: &corev1.HostPathVolumeSource{
+							Type: &hostPathUnset,
+							Path: logVolumeMountPath,
+						},
+					},
+				},
+				{
+					Name: containerVolumeName,
+					VolumeSource: corev1.VolumeSource{
+						HostPath: &corev1.HostPathVolumeSource{
+							Type: &hostPathUnset,
+							Path: containerVolumeMountPath,
+						},
+					},
+				},
+			}
+
+			return ds, nil
+		}
+	}
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logshipping
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"sort"
+	"text/template"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/reconciling"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const configFileName = "fluent-bit.conf"
+
+type label struct {
+	Key   string
+	Value string
+}
+
+type configData struct {
+	Type       kubermaticv1.LogShippingDestinationType
+	Host       string
+	Port       string
+	Path       string
+	SyslogMode string
+	TLS        bool
+	TLSVerify  bool
+	CAFile     string
+	Labels     []label
+	HealthPort int
+	DBPath     string
+}
+
+func SecretCreator(settings *kubermaticv1.LogShippingSettings) reconciling.NamedSecretCreatorGetter {
+	return func() (string, reconciling.SecretCreator) {
+		return resources.LogShippingSecretName, func(secret *corev1.Secret) (*corev1.Secret, error) {
+			if secret.Data == nil {
+				secret.Data = map[string][]byte{}
+			}
+			config, err := renderConfig(settings)
+			if err != nil {
+				return nil, err
+			}
+			secret.Data[configFileName] = config
+			secret.Labels = resources.BaseAppLabels(appName, nil)
+			return secret, nil
+		}
+	}
+}
+
+func renderConfig(settings *kubermaticv1.LogShippingSettings) ([]byte, error) {
+	endpoint, err := url.Parse(settings.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse endpoint: %v", err)
+	}
+
+	data := configData{
+		Type:       settings.Type,
+		Host:       endpoint.Hostname(),
+		Port:       endpoint.Port(),
+		Path:       endpoint.Path,
+		TLS:        endpoint.Scheme == "https" || endpoint.Scheme == "tls",
+		TLSVerify:  !settings.InsecureSkipVerify,
+		CAFile:     fmt.Sprintf("%s/%s", caBundleVolumeMountPath, resources.CABundleConfigMapKey),
+		HealthPort: healthPort,
+		DBPath:     fmt.Sprintf("%s/flb_kube.db", stateVolumeMountPath),
+	}
+	if data.Port == "" {
+		data.Port = "80"
+		if data.TLS {
+			data.Port = "443"
+		}
+	}
+	if settings.Type == kubermaticv1.LogShippingDestinationLoki && data.Path == "" {
+		data.Path = "/loki/api/v1/push"
+	}
+	if settings.Type == kubermaticv1.LogShippingDestinationSyslog {
+		data.SyslogMode = endpoint.Scheme
+	}
+
+	for key, value := range settings.Labels {
+		data.Labels = append(data.Labels, label{Key: key, Value: value})
+	}
+	sort.Slice(data.Labels, func(i, j int) bool {
+		return data.Labels[i].Key < data.Labels[j].Key
+	})
+
+	t, err := template.New("fluent-bit").Parse(configTemplate)
+	if err != nil {
+		return nil, err
+	}
+	configBuf := bytes.Buffer{}
+	if err := t.Execute(&configBuf, data); err != nil {
+		return nil, err
+	}
+
+	return configBuf.Bytes(), nil
+}
+
+const (
+	configTemplate = `
+[SERVICE]
+    Flush                  5
+    Log_Level              info
+    HTTP_Server            On
+    HTTP_Listen            0.0.0.0
+    HTTP_Port              {{ .HealthPort }}
+    # the health check fails if the logs cannot be shipped to the destination,
+    # which makes the pods of the agent not ready
+    Health_Check           On
+    HC_Errors_Count        5
+    HC_Retry_Failure_Count 5
+    HC_Period              60
+
+[INPUT]
+    Name                   tail
+    Tag                    kube.*
+    Path                   /var/log/containers/*.log
+    multiline.parser       docker, cri
+    DB                     {{ .DBPath }}
+    Mem_Buf_Limit          5MB
+    Skip_Long_Lines        On
+    Refresh_Interval       10
+
+[FILTER]
+    Name                   kubernetes
+    Match                  kube.*
+    Merge_Log              On
+    Keep_Log               Off
+    K8S-Logging.Parser     On
+    K8S-Logging.Exclude    On
+{{- if and (ne .Type "loki") .Labels }}
+
+[FILTER]
+    Name                   record_modifier
+    Match                  kube.*
+{{- range .Labels }}
+    Record                 {{ .Key }} {{ .Value }}
+{{- end }}
+{{- end }}
+
+[OUTPUT]
+    Match                  kube.*
+    Host                   {{ .Host }}
+    Port                   {{ .Port }}
+{{- if eq .Type "loki" }}
+    Name                   loki
+    Uri                    {{ .Path }}
+    Labels                 job=fluent-bit{{ range .Labels }}, {{ .Key }}={{ .Value }}{{ end }}
+    Auto_Kubernetes_Labels On
+{{- else if eq .Type "elasticsearch" }}
+    Name                   es
+{{- if .Path }}
+    Path                   {{ .Path }}
+{{- end }}
+    Logstash_Format        On
+    Replace_Dots           On
+    Suppress_Type_Name     On
+    Retry_Limit            False
+{{- else if eq .Type "syslog" }}
+    Name                   syslog
+    Mode                   {{ .SyslogMode }}
+    Syslog_Format          rfc5424
+    Syslog_Message_Key     log
+{{- end }}
+{{- if .TLS }}
+    tls                    On
+    tls.verify             {{ if .TLSVerify }}On{{ else }}Off{{ end }}
+    tls.ca_file            {{ .CAFile }}
+{{- end }}
+`
+)
@@ -0,0 +1,100 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logshipping
+
+import (
+	"strings"
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+)
+
+func TestRenderConfig(t *testing.T) {
+	testCases := []struct {
+		name        string
+		settings    *kubermaticv1.LogShippingSettings
+		contains    []string
+		notContains []string
+	}{
+		{
+			name: "loki with tls",
+			settings: &kubermaticv1.LogShippingSettings{
+				Type:     kubermaticv1.LogShippingDestinationLoki,
+				Endpoint: "https://loki.example.com",
+				Labels:   map[string]string{"project": "my-project", "cluster": "my-cluster"},
+			},
+			contains: []string{
+				"Name                   loki",
+				"Host                   loki.example.com",
+				"Port                   443",
+				"Uri                    /loki/api/v1/push",
+				"Labels                 job=fluent-bit, cluster=my-cluster, project=my-project",
+				"tls.verify             On",
+				"tls.ca_file            /etc/ssl/ca-bundle/ca-bundle.pem",
+			},
+			notContains: []string{"record_modifier"},
+		},
+		{
+			name: "elasticsearch without tls",
+			settings: &kubermaticv1.LogShippingSettings{
+				Type:     kubermaticv1.LogShippingDestinationElasticsearch,
+				Endpoint: "http://elasticsearch.example.com:9200",
+				Labels:   map[string]string{"cluster": "my-cluster"},
+			},
+			contains: []string{
+				"Name                   es",
+				"Port                   9200",
+				"Record                 cluster my-cluster",
+			},
+			notContains: []string{"tls"},
+		},
+		{
+			name: "syslog over tls without verification",
+			settings: &kubermaticv1.LogShippingSettings{
+				Type:               kubermaticv1.LogShippingDestinationSyslog,
+				Endpoint:           "tls://syslog.example.com:6514",
+				InsecureSkipVerify: true,
+			},
+			contains: []string{
+				"Name                   syslog",
+				"Mode                   tls",
+				"Port                   6514",
+				"tls.verify             Off",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config, err := renderConfig(tc.settings)
+			if err != nil {
+				t.Fatalf("failed to render config: %v", err)
+			}
+
+			for _, s := range tc.contains {
+				if !strings.Contains(string(config), s) {
+					t.Errorf("expected config to contain %q, got:\n%s", s, config)
+				}
+			}
+			for _, s := range tc.notContains {
+				if strings.Contains(string(config), s) {
+					t.Errorf("expected config not to contain %q, got:\n%s", s, config)
+				}
+			}
+		})
+	}
+}
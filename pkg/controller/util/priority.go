@@ -0,0 +1,314 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// cacheSyncTimeout is the time the controller waits for the caches of its sources to sync.
+const cacheSyncTimeout = 2 * time.Minute
+
+var queueWaitMetric = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: "kubermatic",
+	Subsystem: "controller",
+	Name:      "queue_wait_seconds",
+	Help:      "Time a request spent waiting in the controller queue before being reconciled, by priority",
+	Buckets:   prometheus.ExponentialBuckets(0.01, 2, 14),
+}, []string{"controller", "priority"})
+
+func init() {
+	prometheus.MustRegister(queueWaitMetric)
+}
+
+// PrioritizedController is a controller that differentiates between user-triggered
+// reconciliations (spec changes, creations and deletions) and background reconciliations
+// (status updates, periodic resyncs, changes to owned objects, requeues). Both are queued
+// immediately, but whenever a worker becomes available, it picks the oldest user-triggered
+// request before any background request, so that on busy seeds user actions are not stuck
+// behind resyncs. The controller-runtime controllers do not allow to replace their queue,
+// which is why this is a controller of its own.
+type PrioritizedController struct {
+	name       string
+	log        *zap.SugaredLogger
+	reconciler reconcile.Reconciler
+	workers    int
+	setFields  func(interface{}) error
+	queue      *priorityQueue
+	// created is used to tell the creations of objects from the initial list of the caches
+	created time.Time
+
+	lock    sync.Mutex
+	ctx     context.Context
+	started bool
+	watches []watchDescription
+}
+
+type watchDescription struct {
+	src        source.Source
+	handler    handler.EventHandler
+	predicates []predicate.Predicate
+}
+
+// NewPrioritizedController creates a new PrioritizedController and adds it to the manager.
+func NewPrioritizedController(name string, mgr manager.Manager, log *zap.SugaredLogger, reconciler reconcile.Reconciler, workers int) (*PrioritizedController, error) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	c := &PrioritizedController{
+		name:       name,
+		log:        log,
+		reconciler: reconciler,
+		workers:    workers,
+		setFields:  mgr.SetFields,
+		queue:      newPriorityQueue(name, workqueue.DefaultControllerRateLimiter()),
+		created:    time.Now(),
+	}
+
+	return c, mgr.Add(c)
+}
+
+// Watch starts the source once the controller is started, using the event handler to queue
+// requests. Event handlers that are not wrapped by EnqueueRequestForObject or Background
+// queue user-triggered requests.
+func (c *PrioritizedController) Watch(src source.Source, h handler.EventHandler, predicates ...predicate.Predicate) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for _, i := range []interface{}{src, h} {
+		if err := c.setFields(i); err != nil {
+			return err
+		}
+	}
+	for _, p := range predicates {
+		if err := c.setFields(p); err != nil {
+			return err
+		}
+	}
+
+	if !c.started {
+		c.watches = append(c.watches, watchDescription{src: src, handler: h, predicates: predicates})
+		return nil
+	}
+	return src.Start(c.ctx, h, c.queue, predicates...)
+}
+
+// Start starts the sources and the workers and blocks until the context is done.
+func (c *PrioritizedController) Start(ctx context.Context) error {
+	c.lock.Lock()
+	if c.started {
+		c.lock.Unlock()
+		return errors.New("controller was started more than once")
+	}
+	defer c.queue.ShutDown()
+
+	err := func() error {
+		defer c.lock.Unlock()
+
+		c.ctx = ctx
+		for _, w := range c.watches {
+			if err := w.src.Start(ctx, w.handler, c.queue, w.predicates...); err != nil {
+				return err
+			}
+		}
+		for _, w := range c.watches {
+			syncing, ok := w.src.(source.SyncingSource)
+			if !ok {
+				continue
+			}
+
+			syncCtx, cancel := context.WithTimeout(ctx, cacheSyncTimeout)
+			err := syncing.WaitForSync(syncCtx)
+			cancel()
+			if err != nil {
+				return fmt.Errorf("failed to wait for %s caches to sync: %w", c.name, err)
+			}
+		}
+		c.watches = nil
+
+		for i := 0; i < c.workers; i++ {
+			go wait.UntilWithContext(ctx, func(ctx context.Context) {
+				for c.processNextRequest(ctx) {
+				}
+			}, time.Second)
+		}
+
+		c.started = true
+		return nil
+	}()
+	if err != nil {
+		return err
+	}
+
+	<-ctx.Done()
+	return nil
+}
+
+// processNextRequest reconciles the next request and requeues it the same way the
+// controller-runtime controllers do.
+func (c *PrioritizedController) processNextRequest(ctx context.Context) bool {
+	item, shutdown := c.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer c.queue.Done(item)
+
+	request, ok := item.(reconcile.Request)
+	if !ok {
+		c.queue.Forget(item)
+		c.log.Errorw("Queue item was not a request", "item", item)
+		return true
+	}
+
+	result, err := c.reconciler.Reconcile(ctx, request)
+	switch {
+	case err != nil:
+		c.queue.AddRateLimited(request)
+		c.log.Debugw("Reconciling failed", "request", request, zap.Error(err))
+	case result.RequeueAfter > 0:
+		c.queue.Forget(request)
+		c.queue.AddAfter(request, result.RequeueAfter)
+	case result.Requeue:
+		c.queue.AddRateLimited(request)
+	default:
+		c.queue.Forget(request)
+	}
+	return true
+}
+
+// EnqueueRequestForObject behaves like handler.EnqueueRequestForObject, but
+// treats updates that do not change the object's spec as background work, as
+// well as the creations of objects that existed before the controller was
+// created, which are sent when the caches are filled.
+func (c *PrioritizedController) EnqueueRequestForObject() handler.EventHandler {
+	inner := &handler.EnqueueRequestForObject{}
+
+	return handler.Funcs{
+		CreateFunc: func(e event.CreateEvent, q workqueue.RateLimitingInterface) {
+			if e.Object != nil && e.Object.GetCreationTimestamp().Time.Before(c.created) {
+				inner.Create(e, withPriority(q, priorityBackground))
+			} else {
+				inner.Create(e, withPriority(q, priorityUser))
+			}
+		},
+		UpdateFunc: func(e event.UpdateEvent, q workqueue.RateLimitingInterface) {
+			if isUserTriggeredUpdate(e) {
+				inner.Update(e, withPriority(q, priorityUser))
+			} else {
+				inner.Update(e, withPriority(q, priorityBackground))
+			}
+		},
+		DeleteFunc: func(e event.DeleteEvent, q workqueue.RateLimitingInterface) {
+			inner.Delete(e, withPriority(q, priorityUser))
+		},
+		GenericFunc: func(e event.GenericEvent, q workqueue.RateLimitingInterface) {
+			inner.Generic(e, withPriority(q, priorityBackground))
+		},
+	}
+}
+
+// Background wraps an event handler, so that all requests it enqueues are
+// treated as background work. It is meant for watches on owned objects.
+func (c *PrioritizedController) Background(h handler.EventHandler) handler.EventHandler {
+	return handler.Funcs{
+		CreateFunc: func(e event.CreateEvent, q workqueue.RateLimitingInterface) {
+			h.Create(e, withPriority(q, priorityBackground))
+		},
+		UpdateFunc: func(e event.UpdateEvent, q workqueue.RateLimitingInterface) {
+			h.Update(e, withPriority(q, priorityBackground))
+		},
+		DeleteFunc: func(e event.DeleteEvent, q workqueue.RateLimitingInterface) {
+			h.Delete(e, withPriority(q, priorityBackground))
+		},
+		GenericFunc: func(e event.GenericEvent, q workqueue.RateLimitingInterface) {
+			h.Generic(e, withPriority(q, priorityBackground))
+		},
+	}
+}
+
+// withPriority returns a queue that adds requests with the given priority to the priority
+// queue of the controller.
+func withPriority(q workqueue.RateLimitingInterface, prio priority) workqueue.RateLimitingInterface {
+	pq, ok := q.(*priorityQueue)
+	if !ok {
+		return q
+	}
+	return &prioritizedAdder{priorityQueue: pq, priority: prio}
+}
+
+// prioritizedAdder intercepts Add calls by event handlers and adds the request with its
+// priority.
+type prioritizedAdder struct {
+	*priorityQueue
+
+	priority priority
+}
+
+func (q *prioritizedAdder) Add(item interface{}) {
+	q.priorityQueue.add(item, q.priority)
+}
+
+// isUserTriggeredUpdate returns true if the update changed the object's spec
+// or marked it for deletion. The spec is compared directly instead of relying on
+// the generation, as not all CRDs use the status subresource.
+func isUserTriggeredUpdate(e event.UpdateEvent) bool {
+	if e.ObjectOld == nil || e.ObjectNew == nil {
+		return false
+	}
+
+	if e.ObjectOld.GetDeletionTimestamp() == nil && e.ObjectNew.GetDeletionTimestamp() != nil {
+		return true
+	}
+
+	oldSpec, err := specOf(e.ObjectOld)
+	if err != nil {
+		return true
+	}
+	newSpec, err := specOf(e.ObjectNew)
+	if err != nil {
+		return true
+	}
+
+	return !equality.Semantic.DeepEqual(oldSpec, newSpec)
+}
+
+func specOf(obj runtime.Object) (interface{}, error) {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	return u["spec"], nil
+}
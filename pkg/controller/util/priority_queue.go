@@ -0,0 +1,254 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+)
+
+type priority string
+
+const (
+	priorityUser       priority = "user"
+	priorityBackground priority = "background"
+)
+
+// priorityQueue is a work queue that hands out user-triggered requests before background
+// requests. Like the queues of client-go, it deduplicates requests and never hands out a request
+// that is currently being processed. A background request that is waiting is promoted when the
+// same request is added by a user-triggered event.
+type priorityQueue struct {
+	controllerName string
+	rateLimiter    workqueue.RateLimiter
+
+	cond *sync.Cond
+	// queues holds the waiting requests in the order they are handed out, per priority
+	queues map[priority][]interface{}
+	// dirty holds the requests that need to be processed, including the ones that are added
+	// again while they are being processed
+	dirty      map[interface{}]queuedRequest
+	processing map[interface{}]struct{}
+	// waiting holds the requests that are added after a delay, only the earliest one is kept
+	// per request
+	waiting  map[interface{}]*waitingRequest
+	shutdown bool
+}
+
+type queuedRequest struct {
+	since    time.Time
+	priority priority
+}
+
+type waitingRequest struct {
+	readyAt time.Time
+	timer   *time.Timer
+}
+
+var _ workqueue.RateLimitingInterface = &priorityQueue{}
+
+func newPriorityQueue(controllerName string, rateLimiter workqueue.RateLimiter) *priorityQueue {
+	return &priorityQueue{
+		controllerName: controllerName,
+		rateLimiter:    rateLimiter,
+		cond:           sync.NewCond(&sync.Mutex{}),
+		queues:         map[priority][]interface{}{},
+		dirty:          map[interface{}]queuedRequest{},
+		processing:     map[interface{}]struct{}{},
+		waiting:        map[interface{}]*waitingRequest{},
+	}
+}
+
+// Add adds a user-triggered request. Requests from event handlers that are not wrapped by
+// the controller are not classified and thus not held back.
+func (q *priorityQueue) Add(item interface{}) {
+	q.add(item, priorityUser)
+}
+
+func (q *priorityQueue) add(item interface{}, prio priority) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	q.addLocked(item, prio)
+}
+
+func (q *priorityQueue) addLocked(item interface{}, prio priority) {
+	if q.shutdown {
+		return
+	}
+
+	if existing, ok := q.dirty[item]; ok {
+		if prio == priorityUser && existing.priority == priorityBackground {
+			existing.priority = priorityUser
+			q.dirty[item] = existing
+			if _, processing := q.processing[item]; !processing {
+				q.remove(item, priorityBackground)
+				q.push(item, priorityUser)
+			}
+		}
+		return
+	}
+
+	q.dirty[item] = queuedRequest{since: time.Now(), priority: prio}
+	if _, processing := q.processing[item]; processing {
+		return
+	}
+	q.push(item, prio)
+}
+
+func (q *priorityQueue) push(item interface{}, prio priority) {
+	q.queues[prio] = append(q.queues[prio], item)
+	q.cond.Signal()
+}
+
+func (q *priorityQueue) remove(item interface{}, prio priority) {
+	queue := q.queues[prio]
+	for i := range queue {
+		if queue[i] == item {
+			q.queues[prio] = append(queue[:i], queue[i+1:]...)
+			return
+		}
+	}
+}
+
+// Get blocks until a request is available and returns the oldest user-triggered request, or
+// the oldest background request if there is none.
+func (q *priorityQueue) Get() (interface{}, bool) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	for q.len() == 0 && !q.shutdown {
+		q.cond.Wait()
+	}
+	if q.len() == 0 {
+		return nil, true
+	}
+
+	prio := priorityUser
+	if len(q.queues[priorityUser]) == 0 {
+		prio = priorityBackground
+	}
+	item := q.queues[prio][0]
+	q.queues[prio] = q.queues[prio][1:]
+
+	queued := q.dirty[item]
+	delete(q.dirty, item)
+	q.processing[item] = struct{}{}
+
+	wait := time.Since(queued.since)
+	if wait < 0 {
+		wait = 0
+	}
+	queueWaitMetric.WithLabelValues(q.controllerName, string(queued.priority)).Observe(wait.Seconds())
+
+	return item, false
+}
+
+// Done marks the request as processed. If it was added again in the meantime, it is queued
+// with the priority it was added with.
+func (q *priorityQueue) Done(item interface{}) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	delete(q.processing, item)
+	if queued, ok := q.dirty[item]; ok {
+		q.push(item, queued.priority)
+	}
+}
+
+func (q *priorityQueue) Len() int {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	return q.len()
+}
+
+func (q *priorityQueue) len() int {
+	return len(q.queues[priorityUser]) + len(q.queues[priorityBackground])
+}
+
+func (q *priorityQueue) ShutDown() {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	q.shutdown = true
+	for item, waiting := range q.waiting {
+		waiting.timer.Stop()
+		delete(q.waiting, item)
+	}
+	q.cond.Broadcast()
+}
+
+func (q *priorityQueue) ShuttingDown() bool {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	return q.shutdown
+}
+
+// AddAfter adds a background request after the given duration. It is used for requeues of
+// the controller. Like the delaying queue of client-go, only the earliest of the delayed
+// additions of a request is kept.
+func (q *priorityQueue) AddAfter(item interface{}, duration time.Duration) {
+	if duration <= 0 {
+		q.add(item, priorityBackground)
+		return
+	}
+
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	if q.shutdown {
+		return
+	}
+
+	readyAt := time.Now().Add(duration)
+	if existing, ok := q.waiting[item]; ok {
+		if !readyAt.Before(existing.readyAt) {
+			return
+		}
+		existing.timer.Stop()
+	}
+
+	waiting := &waitingRequest{readyAt: readyAt}
+	waiting.timer = time.AfterFunc(duration, func() {
+		q.cond.L.Lock()
+		defer q.cond.L.Unlock()
+
+		// the request has been replaced by an earlier one or the queue has been shut down
+		if q.waiting[item] != waiting {
+			return
+		}
+		delete(q.waiting, item)
+		q.addLocked(item, priorityBackground)
+	})
+	q.waiting[item] = waiting
+}
+
+func (q *priorityQueue) AddRateLimited(item interface{}) {
+	q.AddAfter(item, q.rateLimiter.When(item))
+}
+
+func (q *priorityQueue) Forget(item interface{}) {
+	q.rateLimiter.Forget(item)
+}
+
+func (q *priorityQueue) NumRequeues(item interface{}) int {
+	return q.rateLimiter.NumRequeues(item)
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+	"time"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestIsUserTriggeredUpdate(t *testing.T) {
+	now := metav1.Now()
+
+	testCases := []struct {
+		name     string
+		modify   func(*kubermaticv1.Cluster)
+		expected bool
+	}{
+		{
+			name:     "resync without changes",
+			modify:   func(c *kubermaticv1.Cluster) {},
+			expected: false,
+		},
+		{
+			name: "status change",
+			modify: func(c *kubermaticv1.Cluster) {
+				c.Status.NamespaceName = "cluster-test"
+			},
+			expected: false,
+		},
+		{
+			name: "spec change",
+			modify: func(c *kubermaticv1.Cluster) {
+				c.Spec.HumanReadableName = "renamed"
+			},
+			expected: true,
+		},
+		{
+			name: "deletion",
+			modify: func(c *kubermaticv1.Cluster) {
+				c.DeletionTimestamp = &now
+			},
+			expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			oldCluster := &kubermaticv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec:       kubermaticv1.ClusterSpec{HumanReadableName: "test"},
+			}
+			newCluster := oldCluster.DeepCopy()
+			tc.modify(newCluster)
+
+			if result := isUserTriggeredUpdate(event.UpdateEvent{ObjectOld: oldCluster, ObjectNew: newCluster}); result != tc.expected {
+				t.Fatalf("expected %v, got %v", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestPriorityQueue(t *testing.T) {
+	q := newPriorityQueue("test", workqueue.DefaultControllerRateLimiter())
+	defer q.ShutDown()
+
+	q.add("background-1", priorityBackground)
+	q.add("background-2", priorityBackground)
+	q.add("user-1", priorityUser)
+	// promoted to a user-triggered request, while keeping its position among them
+	q.add("background-2", priorityUser)
+	// deduplicated
+	q.add("user-1", priorityUser)
+	q.add("user-1", priorityBackground)
+
+	if q.Len() != 3 {
+		t.Fatalf("expected 3 requests, got %d", q.Len())
+	}
+
+	for _, expected := range []string{"user-1", "background-2", "background-1"} {
+		item, shutdown := q.Get()
+		if shutdown {
+			t.Fatal("queue was shut down")
+		}
+		if item != expected {
+			t.Fatalf("expected %q, got %q", expected, item)
+		}
+
+		// requests that are added while being processed are queued once they are done
+		queued := q.Len()
+		q.add(item, priorityBackground)
+		if q.Len() != queued {
+			t.Fatalf("expected request %q not to be queued while it is processed", item)
+		}
+		q.Done(item)
+	}
+
+	if q.Len() != 3 {
+		t.Fatalf("expected the 3 requests to be queued again, got %d", q.Len())
+	}
+}
+
+func TestPriorityQueueAddAfterKeepsEarliest(t *testing.T) {
+	q := newPriorityQueue("test", workqueue.DefaultControllerRateLimiter())
+	defer q.ShutDown()
+
+	q.AddAfter("requeue", 100*time.Millisecond)
+	q.AddAfter("requeue", 10*time.Millisecond)
+	q.AddAfter("requeue", time.Hour)
+
+	item, shutdown := q.Get()
+	if shutdown {
+		t.Fatal("queue was shut down")
+	}
+	if item != "requeue" {
+		t.Fatalf("expected %q, got %q", "requeue", item)
+	}
+
+	// the replaced delays must not add the request again while or after it is processed
+	time.Sleep(200 * time.Millisecond)
+	q.Done(item)
+
+	if q.Len() != 0 {
+		t.Fatalf("expected the request to be handed out once, got %d queued requests", q.Len())
+	}
+}
+
+func TestPriorityQueueShutDownDropsWaitingRequests(t *testing.T) {
+	q := newPriorityQueue("test", workqueue.DefaultControllerRateLimiter())
+
+	q.AddAfter("requeue", time.Hour)
+	q.ShutDown()
+
+	if len(q.waiting) != 0 {
+		t.Fatalf("expected no waiting requests after shutdown, got %d", len(q.waiting))
+	}
+}
+
+func TestPrioritizedControllerClassifiesEvents(t *testing.T) {
+	c := &PrioritizedController{
+		queue:   newPriorityQueue("test", workqueue.DefaultControllerRateLimiter()),
+		created: time.Now(),
+	}
+	defer c.queue.ShutDown()
+	h := c.EnqueueRequestForObject()
+
+	oldCluster := &kubermaticv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "status-update"}}
+	newCluster := oldCluster.DeepCopy()
+	newCluster.Status.NamespaceName = "cluster-status-update"
+	h.Update(event.UpdateEvent{ObjectOld: oldCluster, ObjectNew: newCluster}, c.queue)
+
+	existing := &kubermaticv1.Cluster{ObjectMeta: metav1.ObjectMeta{
+		Name:              "existing",
+		CreationTimestamp: metav1.NewTime(c.created.Add(-time.Hour)),
+	}}
+	h.Create(event.CreateEvent{Object: existing}, c.queue)
+
+	created := &kubermaticv1.Cluster{ObjectMeta: metav1.ObjectMeta{
+		Name:              "created",
+		CreationTimestamp: metav1.NewTime(c.created.Add(time.Hour)),
+	}}
+	h.Create(event.CreateEvent{Object: created}, c.queue)
+
+	// background requests are not delayed, they are only reconciled after the user-triggered ones
+	for _, expected := range []string{"created", "status-update", "existing"} {
+		item, _ := c.queue.Get()
+		if name := item.(reconcile.Request).Name; name != expected {
+			t.Fatalf("expected %q, got %q", expected, name)
+		}
+		c.queue.Done(item)
+	}
+}
@@ -77,6 +77,31 @@ func EnqueueClusterForNamespacedObjectWithSeedName(client ctrlruntimeclient.Clie
 	})
 }
 
+// EnqueueClustersForAzureCredentialsSecret enqueues all clusters whose Azure credentials are read from
+// the secret. The secrets are not stored in the cluster namespaces, so controllers which propagate the
+// credentials into the control plane need this to pick up rotated credentials.
+func EnqueueClustersForAzureCredentialsSecret(client ctrlruntimeclient.Client) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(a ctrlruntimeclient.Object) []reconcile.Request {
+		clusterList := &kubermaticv1.ClusterList{}
+		if err := client.List(context.Background(), clusterList); err != nil {
+			utilruntime.HandleError(fmt.Errorf("failed to list Clusters: %v", err))
+			return []reconcile.Request{}
+		}
+
+		requests := []reconcile.Request{}
+		for _, cluster := range clusterList.Items {
+			if cluster.Spec.Cloud.Azure == nil || cluster.Spec.Cloud.Azure.CredentialsReference == nil {
+				continue
+			}
+			ref := cluster.Spec.Cloud.Azure.CredentialsReference
+			if ref.Name == a.GetName() && ref.Namespace == a.GetNamespace() {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: cluster.Name}})
+			}
+		}
+		return requests
+	})
+}
+
 // EnqueueClusterScopedObjectWithSeedName enqueues a cluster-scoped object with the seedName
 // as namespace. If it gets an object with a non-empty name, it will log an error and not enqueue
 // anything.
@@ -20,9 +20,15 @@ import (
 	"context"
 	"testing"
 
+	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
+
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
 	ctrlruntimefakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 )
 
 func TestConcurrencyLimitReached(t *testing.T) {
@@ -57,3 +63,37 @@ func TestConcurrencyLimitReached(t *testing.T) {
 		})
 	}
 }
+
+func TestEnqueueClustersForAzureCredentialsSecret(t *testing.T) {
+	azureCluster := func(name, secretName string) *kubermaticv1.Cluster {
+		return &kubermaticv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: kubermaticv1.ClusterSpec{
+				Cloud: kubermaticv1.CloudSpec{
+					Azure: &kubermaticv1.AzureCloudSpec{
+						CredentialsReference: &providerconfig.GlobalSecretKeySelector{
+							ObjectReference: corev1.ObjectReference{Name: secretName, Namespace: "kubermatic"},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	client := ctrlruntimefakeclient.NewClientBuilder().WithObjects(
+		azureCluster("a", "credential-azure-a"),
+		azureCluster("b", "shared-credentials"),
+		azureCluster("c", "shared-credentials"),
+		&kubermaticv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "d"}},
+	).Build()
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "shared-credentials", Namespace: "kubermatic"}}
+	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer queue.ShutDown()
+
+	EnqueueClustersForAzureCredentialsSecret(client).Update(event.UpdateEvent{ObjectOld: secret, ObjectNew: secret}, queue)
+
+	if queue.Len() != 2 {
+		t.Fatalf("expected the two clusters using the secret to be enqueued, got %d requests", queue.Len())
+	}
+}
@@ -0,0 +1,168 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+	"time"
+
+	scheme "k8c.io/kubermatic/v2/pkg/crd/client/clientset/versioned/scheme"
+	v1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// AddonRolloutsGetter has a method to return a AddonRolloutInterface.
+// A group's client should implement this interface.
+type AddonRolloutsGetter interface {
+	AddonRollouts() AddonRolloutInterface
+}
+
+// AddonRolloutInterface has methods to work with AddonRollout resources.
+type AddonRolloutInterface interface {
+	Create(ctx context.Context, addonRollout *v1.AddonRollout, opts metav1.CreateOptions) (*v1.AddonRollout, error)
+	Update(ctx context.Context, addonRollout *v1.AddonRollout, opts metav1.UpdateOptions) (*v1.AddonRollout, error)
+	UpdateStatus(ctx context.Context, addonRollout *v1.AddonRollout, opts metav1.UpdateOptions) (*v1.AddonRollout, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.AddonRollout, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*v1.AddonRolloutList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.AddonRollout, err error)
+	AddonRolloutExpansion
+}
+
+// addonRollouts implements AddonRolloutInterface
+type addonRollouts struct {
+	client rest.Interface
+}
+
+// newAddonRollouts returns a AddonRollouts
+func newAddonRollouts(c *KubermaticV1Client) *addonRollouts {
+	return &addonRollouts{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the addonRollout, and returns the corresponding addonRollout object, and an error if there is any.
+func (c *addonRollouts) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.AddonRollout, err error) {
+	result = &v1.AddonRollout{}
+	err = c.client.Get().
+		Resource("addonrollouts").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of AddonRollouts that match those selectors.
+func (c *addonRollouts) List(ctx context.Context, opts metav1.ListOptions) (result *v1.AddonRolloutList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1.AddonRolloutList{}
+	err = c.client.Get().
+		Resource("addonrollouts").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested addonRollouts.
+func (c *addonRollouts) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Resource("addonrollouts").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a addonRollout and creates it.  Returns the server's representation of the addonRollout, and an error, if there is any.
+func (c *addonRollouts) Create(ctx context.Context, addonRollout *v1.AddonRollout, opts metav1.CreateOptions) (result *v1.AddonRollout, err error) {
+	result = &v1.AddonRollout{}
+	err = c.client.Post().
+		Resource("addonrollouts").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(addonRollout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a addonRollout and updates it. Returns the server's representation of the addonRollout, and an error, if there is any.
+func (c *addonRollouts) Update(ctx context.Context, addonRollout *v1.AddonRollout, opts metav1.UpdateOptions) (result *v1.AddonRollout, err error) {
+	result = &v1.AddonRollout{}
+	err = c.client.Put().
+		Resource("addonrollouts").
+		Name(addonRollout.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(addonRollout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *addonRollouts) UpdateStatus(ctx context.Context, addonRollout *v1.AddonRollout, opts metav1.UpdateOptions) (result *v1.AddonRollout, err error) {
+	result = &v1.AddonRollout{}
+	err = c.client.Put().
+		Resource("addonrollouts").
+		Name(addonRollout.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(addonRollout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the addonRollout and deletes it. Returns an error if one occurs.
+func (c *addonRollouts) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("addonrollouts").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *addonRollouts) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Resource("addonrollouts").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched addonRollout.
+func (c *addonRollouts) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.AddonRollout, err error) {
+	result = &v1.AddonRollout{}
+	err = c.client.Patch(pt).
+		Resource("addonrollouts").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -0,0 +1,168 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+	"time"
+
+	scheme "k8c.io/kubermatic/v2/pkg/crd/client/clientset/versioned/scheme"
+	v1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// ClusterNamespacesGetter has a method to return a ClusterNamespaceInterface.
+// A group's client should implement this interface.
+type ClusterNamespacesGetter interface {
+	ClusterNamespaces() ClusterNamespaceInterface
+}
+
+// ClusterNamespaceInterface has methods to work with ClusterNamespace resources.
+type ClusterNamespaceInterface interface {
+	Create(ctx context.Context, clusterNamespace *v1.ClusterNamespace, opts metav1.CreateOptions) (*v1.ClusterNamespace, error)
+	Update(ctx context.Context, clusterNamespace *v1.ClusterNamespace, opts metav1.UpdateOptions) (*v1.ClusterNamespace, error)
+	UpdateStatus(ctx context.Context, clusterNamespace *v1.ClusterNamespace, opts metav1.UpdateOptions) (*v1.ClusterNamespace, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.ClusterNamespace, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*v1.ClusterNamespaceList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.ClusterNamespace, err error)
+	ClusterNamespaceExpansion
+}
+
+// clusterNamespaces implements ClusterNamespaceInterface
+type clusterNamespaces struct {
+	client rest.Interface
+}
+
+// newClusterNamespaces returns a ClusterNamespaces
+func newClusterNamespaces(c *KubermaticV1Client) *clusterNamespaces {
+	return &clusterNamespaces{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the clusterNamespace, and returns the corresponding clusterNamespace object, and an error if there is any.
+func (c *clusterNamespaces) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.ClusterNamespace, err error) {
+	result = &v1.ClusterNamespace{}
+	err = c.client.Get().
+		Resource("clusternamespaces").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of ClusterNamespaces that match those selectors.
+func (c *clusterNamespaces) List(ctx context.Context, opts metav1.ListOptions) (result *v1.ClusterNamespaceList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1.ClusterNamespaceList{}
+	err = c.client.Get().
+		Resource("clusternamespaces").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested clusterNamespaces.
+func (c *clusterNamespaces) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Resource("clusternamespaces").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a clusterNamespace and creates it.  Returns the server's representation of the clusterNamespace, and an error, if there is any.
+func (c *clusterNamespaces) Create(ctx context.Context, clusterNamespace *v1.ClusterNamespace, opts metav1.CreateOptions) (result *v1.ClusterNamespace, err error) {
+	result = &v1.ClusterNamespace{}
+	err = c.client.Post().
+		Resource("clusternamespaces").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(clusterNamespace).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a clusterNamespace and updates it. Returns the server's representation of the clusterNamespace, and an error, if there is any.
+func (c *clusterNamespaces) Update(ctx context.Context, clusterNamespace *v1.ClusterNamespace, opts metav1.UpdateOptions) (result *v1.ClusterNamespace, err error) {
+	result = &v1.ClusterNamespace{}
+	err = c.client.Put().
+		Resource("clusternamespaces").
+		Name(clusterNamespace.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(clusterNamespace).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *clusterNamespaces) UpdateStatus(ctx context.Context, clusterNamespace *v1.ClusterNamespace, opts metav1.UpdateOptions) (result *v1.ClusterNamespace, err error) {
+	result = &v1.ClusterNamespace{}
+	err = c.client.Put().
+		Resource("clusternamespaces").
+		Name(clusterNamespace.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(clusterNamespace).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the clusterNamespace and deletes it. Returns an error if one occurs.
+func (c *clusterNamespaces) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("clusternamespaces").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *clusterNamespaces) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Resource("clusternamespaces").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched clusterNamespace.
+func (c *clusterNamespaces) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.ClusterNamespace, err error) {
+	result = &v1.ClusterNamespace{}
+	err = c.client.Patch(pt).
+		Resource("clusternamespaces").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
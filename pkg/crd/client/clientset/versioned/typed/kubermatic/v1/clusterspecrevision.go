@@ -0,0 +1,152 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+	"time"
+
+	scheme "k8c.io/kubermatic/v2/pkg/crd/client/clientset/versioned/scheme"
+	v1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// ClusterSpecRevisionsGetter has a method to return a ClusterSpecRevisionInterface.
+// A group's client should implement this interface.
+type ClusterSpecRevisionsGetter interface {
+	ClusterSpecRevisions() ClusterSpecRevisionInterface
+}
+
+// ClusterSpecRevisionInterface has methods to work with ClusterSpecRevision resources.
+type ClusterSpecRevisionInterface interface {
+	Create(ctx context.Context, clusterSpecRevision *v1.ClusterSpecRevision, opts metav1.CreateOptions) (*v1.ClusterSpecRevision, error)
+	Update(ctx context.Context, clusterSpecRevision *v1.ClusterSpecRevision, opts metav1.UpdateOptions) (*v1.ClusterSpecRevision, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.ClusterSpecRevision, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*v1.ClusterSpecRevisionList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.ClusterSpecRevision, err error)
+	ClusterSpecRevisionExpansion
+}
+
+// clusterSpecRevisions implements ClusterSpecRevisionInterface
+type clusterSpecRevisions struct {
+	client rest.Interface
+}
+
+// newClusterSpecRevisions returns a ClusterSpecRevisions
+func newClusterSpecRevisions(c *KubermaticV1Client) *clusterSpecRevisions {
+	return &clusterSpecRevisions{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the clusterSpecRevision, and returns the corresponding clusterSpecRevision object, and an error if there is any.
+func (c *clusterSpecRevisions) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.ClusterSpecRevision, err error) {
+	result = &v1.ClusterSpecRevision{}
+	err = c.client.Get().
+		Resource("clusterspecrevisions").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of ClusterSpecRevisions that match those selectors.
+func (c *clusterSpecRevisions) List(ctx context.Context, opts metav1.ListOptions) (result *v1.ClusterSpecRevisionList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1.ClusterSpecRevisionList{}
+	err = c.client.Get().
+		Resource("clusterspecrevisions").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested clusterSpecRevisions.
+func (c *clusterSpecRevisions) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Resource("clusterspecrevisions").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a clusterSpecRevision and creates it.  Returns the server's representation of the clusterSpecRevision, and an error, if there is any.
+func (c *clusterSpecRevisions) Create(ctx context.Context, clusterSpecRevision *v1.ClusterSpecRevision, opts metav1.CreateOptions) (result *v1.ClusterSpecRevision, err error) {
+	result = &v1.ClusterSpecRevision{}
+	err = c.client.Post().
+		Resource("clusterspecrevisions").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(clusterSpecRevision).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a clusterSpecRevision and updates it. Returns the server's representation of the clusterSpecRevision, and an error, if there is any.
+func (c *clusterSpecRevisions) Update(ctx context.Context, clusterSpecRevision *v1.ClusterSpecRevision, opts metav1.UpdateOptions) (result *v1.ClusterSpecRevision, err error) {
+	result = &v1.ClusterSpecRevision{}
+	err = c.client.Put().
+		Resource("clusterspecrevisions").
+		Name(clusterSpecRevision.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(clusterSpecRevision).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the clusterSpecRevision and deletes it. Returns an error if one occurs.
+func (c *clusterSpecRevisions) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("clusterspecrevisions").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *clusterSpecRevisions) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Resource("clusterspecrevisions").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched clusterSpecRevision.
+func (c *clusterSpecRevisions) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.ClusterSpecRevision, err error) {
+	result = &v1.ClusterSpecRevision{}
+	err = c.client.Patch(pt).
+		Resource("clusterspecrevisions").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
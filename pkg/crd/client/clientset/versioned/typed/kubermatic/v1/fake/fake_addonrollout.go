@@ -0,0 +1,117 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeAddonRollouts implements AddonRolloutInterface
+type FakeAddonRollouts struct {
+	Fake *FakeKubermaticV1
+}
+
+var addonrolloutsResource = schema.GroupVersionResource{Group: "kubermatic.k8s.io", Version: "v1", Resource: "addonrollouts"}
+
+var addonrolloutsKind = schema.GroupVersionKind{Group: "kubermatic.k8s.io", Version: "v1", Kind: "AddonRollout"}
+
+// Get takes name of the addonRollout, and returns the corresponding addonRollout object, and an error if there is any.
+func (c *FakeAddonRollouts) Get(ctx context.Context, name string, options v1.GetOptions) (result *kubermaticv1.AddonRollout, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(addonrolloutsResource, name), &kubermaticv1.AddonRollout{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*kubermaticv1.AddonRollout), err
+}
+
+// List takes label and field selectors, and returns the list of AddonRollouts that match those selectors.
+func (c *FakeAddonRollouts) List(ctx context.Context, opts v1.ListOptions) (result *kubermaticv1.AddonRolloutList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(addonrolloutsResource, addonrolloutsKind, opts), &kubermaticv1.AddonRolloutList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &kubermaticv1.AddonRolloutList{ListMeta: obj.(*kubermaticv1.AddonRolloutList).ListMeta}
+	for _, item := range obj.(*kubermaticv1.AddonRolloutList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested addonRollouts.
+func (c *FakeAddonRollouts) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(addonrolloutsResource, opts))
+}
+
+// Create takes the representation of a addonRollout and creates it.  Returns the server's representation of the addonRollout, and an error, if there is any.
+func (c *FakeAddonRollouts) Create(ctx context.Context, addonRollout *kubermaticv1.AddonRollout, opts v1.CreateOptions) (result *kubermaticv1.AddonRollout, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(addonrolloutsResource, addonRollout), &kubermaticv1.AddonRollout{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*kubermaticv1.AddonRollout), err
+}
+
+// Update takes the representation of a addonRollout and updates it. Returns the server's representation of the addonRollout, and an error, if there is any.
+func (c *FakeAddonRollouts) Update(ctx context.Context, addonRollout *kubermaticv1.AddonRollout, opts v1.UpdateOptions) (result *kubermaticv1.AddonRollout, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(addonrolloutsResource, addonRollout), &kubermaticv1.AddonRollout{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*kubermaticv1.AddonRollout), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeAddonRollouts) UpdateStatus(ctx context.Context, addonRollout *kubermaticv1.AddonRollout, opts v1.UpdateOptions) (*kubermaticv1.AddonRollout, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateSubresourceAction(addonrolloutsResource, "status", addonRollout), &kubermaticv1.AddonRollout{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*kubermaticv1.AddonRollout), err
+}
+
+// Delete takes name of the addonRollout and deletes it. Returns an error if one occurs.
+func (c *FakeAddonRollouts) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteAction(addonrolloutsResource, name), &kubermaticv1.AddonRollout{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeAddonRollouts) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(addonrolloutsResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &kubermaticv1.AddonRolloutList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched addonRollout.
+func (c *FakeAddonRollouts) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *kubermaticv1.AddonRollout, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(addonrolloutsResource, name, pt, data, subresources...), &kubermaticv1.AddonRollout{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*kubermaticv1.AddonRollout), err
+}
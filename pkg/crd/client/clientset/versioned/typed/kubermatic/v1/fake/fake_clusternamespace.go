@@ -0,0 +1,117 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeClusterNamespaces implements ClusterNamespaceInterface
+type FakeClusterNamespaces struct {
+	Fake *FakeKubermaticV1
+}
+
+var clusternamespacesResource = schema.GroupVersionResource{Group: "kubermatic.k8s.io", Version: "v1", Resource: "clusternamespaces"}
+
+var clusternamespacesKind = schema.GroupVersionKind{Group: "kubermatic.k8s.io", Version: "v1", Kind: "ClusterNamespace"}
+
+// Get takes name of the clusterNamespace, and returns the corresponding clusterNamespace object, and an error if there is any.
+func (c *FakeClusterNamespaces) Get(ctx context.Context, name string, options v1.GetOptions) (result *kubermaticv1.ClusterNamespace, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(clusternamespacesResource, name), &kubermaticv1.ClusterNamespace{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*kubermaticv1.ClusterNamespace), err
+}
+
+// List takes label and field selectors, and returns the list of ClusterNamespaces that match those selectors.
+func (c *FakeClusterNamespaces) List(ctx context.Context, opts v1.ListOptions) (result *kubermaticv1.ClusterNamespaceList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(clusternamespacesResource, clusternamespacesKind, opts), &kubermaticv1.ClusterNamespaceList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &kubermaticv1.ClusterNamespaceList{ListMeta: obj.(*kubermaticv1.ClusterNamespaceList).ListMeta}
+	for _, item := range obj.(*kubermaticv1.ClusterNamespaceList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested clusterNamespaces.
+func (c *FakeClusterNamespaces) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(clusternamespacesResource, opts))
+}
+
+// Create takes the representation of a clusterNamespace and creates it.  Returns the server's representation of the clusterNamespace, and an error, if there is any.
+func (c *FakeClusterNamespaces) Create(ctx context.Context, clusterNamespace *kubermaticv1.ClusterNamespace, opts v1.CreateOptions) (result *kubermaticv1.ClusterNamespace, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(clusternamespacesResource, clusterNamespace), &kubermaticv1.ClusterNamespace{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*kubermaticv1.ClusterNamespace), err
+}
+
+// Update takes the representation of a clusterNamespace and updates it. Returns the server's representation of the clusterNamespace, and an error, if there is any.
+func (c *FakeClusterNamespaces) Update(ctx context.Context, clusterNamespace *kubermaticv1.ClusterNamespace, opts v1.UpdateOptions) (result *kubermaticv1.ClusterNamespace, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(clusternamespacesResource, clusterNamespace), &kubermaticv1.ClusterNamespace{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*kubermaticv1.ClusterNamespace), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeClusterNamespaces) UpdateStatus(ctx context.Context, clusterNamespace *kubermaticv1.ClusterNamespace, opts v1.UpdateOptions) (*kubermaticv1.ClusterNamespace, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateSubresourceAction(clusternamespacesResource, "status", clusterNamespace), &kubermaticv1.ClusterNamespace{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*kubermaticv1.ClusterNamespace), err
+}
+
+// Delete takes name of the clusterNamespace and deletes it. Returns an error if one occurs.
+func (c *FakeClusterNamespaces) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteAction(clusternamespacesResource, name), &kubermaticv1.ClusterNamespace{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeClusterNamespaces) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(clusternamespacesResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &kubermaticv1.ClusterNamespaceList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched clusterNamespace.
+func (c *FakeClusterNamespaces) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *kubermaticv1.ClusterNamespace, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(clusternamespacesResource, name, pt, data, subresources...), &kubermaticv1.ClusterNamespace{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*kubermaticv1.ClusterNamespace), err
+}
@@ -0,0 +1,106 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeClusterSpecRevisions implements ClusterSpecRevisionInterface
+type FakeClusterSpecRevisions struct {
+	Fake *FakeKubermaticV1
+}
+
+var clusterspecrevisionsResource = schema.GroupVersionResource{Group: "kubermatic.k8s.io", Version: "v1", Resource: "clusterspecrevisions"}
+
+var clusterspecrevisionsKind = schema.GroupVersionKind{Group: "kubermatic.k8s.io", Version: "v1", Kind: "ClusterSpecRevision"}
+
+// Get takes name of the clusterSpecRevision, and returns the corresponding clusterSpecRevision object, and an error if there is any.
+func (c *FakeClusterSpecRevisions) Get(ctx context.Context, name string, options v1.GetOptions) (result *kubermaticv1.ClusterSpecRevision, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(clusterspecrevisionsResource, name), &kubermaticv1.ClusterSpecRevision{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*kubermaticv1.ClusterSpecRevision), err
+}
+
+// List takes label and field selectors, and returns the list of ClusterSpecRevisions that match those selectors.
+func (c *FakeClusterSpecRevisions) List(ctx context.Context, opts v1.ListOptions) (result *kubermaticv1.ClusterSpecRevisionList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(clusterspecrevisionsResource, clusterspecrevisionsKind, opts), &kubermaticv1.ClusterSpecRevisionList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &kubermaticv1.ClusterSpecRevisionList{ListMeta: obj.(*kubermaticv1.ClusterSpecRevisionList).ListMeta}
+	for _, item := range obj.(*kubermaticv1.ClusterSpecRevisionList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested clusterSpecRevisions.
+func (c *FakeClusterSpecRevisions) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(clusterspecrevisionsResource, opts))
+}
+
+// Create takes the representation of a clusterSpecRevision and creates it.  Returns the server's representation of the clusterSpecRevision, and an error, if there is any.
+func (c *FakeClusterSpecRevisions) Create(ctx context.Context, clusterSpecRevision *kubermaticv1.ClusterSpecRevision, opts v1.CreateOptions) (result *kubermaticv1.ClusterSpecRevision, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(clusterspecrevisionsResource, clusterSpecRevision), &kubermaticv1.ClusterSpecRevision{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*kubermaticv1.ClusterSpecRevision), err
+}
+
+// Update takes the representation of a clusterSpecRevision and updates it. Returns the server's representation of the clusterSpecRevision, and an error, if there is any.
+func (c *FakeClusterSpecRevisions) Update(ctx context.Context, clusterSpecRevision *kubermaticv1.ClusterSpecRevision, opts v1.UpdateOptions) (result *kubermaticv1.ClusterSpecRevision, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(clusterspecrevisionsResource, clusterSpecRevision), &kubermaticv1.ClusterSpecRevision{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*kubermaticv1.ClusterSpecRevision), err
+}
+
+// Delete takes name of the clusterSpecRevision and deletes it. Returns an error if one occurs.
+func (c *FakeClusterSpecRevisions) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteAction(clusterspecrevisionsResource, name), &kubermaticv1.ClusterSpecRevision{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeClusterSpecRevisions) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(clusterspecrevisionsResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &kubermaticv1.ClusterSpecRevisionList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched clusterSpecRevision.
+func (c *FakeClusterSpecRevisions) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *kubermaticv1.ClusterSpecRevision, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(clusterspecrevisionsResource, name, pt, data, subresources...), &kubermaticv1.ClusterSpecRevision{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*kubermaticv1.ClusterSpecRevision), err
+}
@@ -20,6 +20,10 @@ func (c *FakeKubermaticV1) AddonConfigs() v1.AddonConfigInterface {
 	return &FakeAddonConfigs{c}
 }
 
+func (c *FakeKubermaticV1) AddonRollouts() v1.AddonRolloutInterface {
+	return &FakeAddonRollouts{c}
+}
+
 func (c *FakeKubermaticV1) Alertmanagers(namespace string) v1.AlertmanagerInterface {
 	return &FakeAlertmanagers{c, namespace}
 }
@@ -28,6 +32,14 @@ func (c *FakeKubermaticV1) Clusters() v1.ClusterInterface {
 	return &FakeClusters{c}
 }
 
+func (c *FakeKubermaticV1) ClusterNamespaces() v1.ClusterNamespaceInterface {
+	return &FakeClusterNamespaces{c}
+}
+
+func (c *FakeKubermaticV1) ClusterSpecRevisions() v1.ClusterSpecRevisionInterface {
+	return &FakeClusterSpecRevisions{c}
+}
+
 func (c *FakeKubermaticV1) ClusterTemplates() v1.ClusterTemplateInterface {
 	return &FakeClusterTemplates{c}
 }
@@ -60,6 +72,10 @@ func (c *FakeKubermaticV1) KubermaticSettings() v1.KubermaticSettingInterface {
 	return &FakeKubermaticSettings{c}
 }
 
+func (c *FakeKubermaticV1) NamespaceTemplates() v1.NamespaceTemplateInterface {
+	return &FakeNamespaceTemplates{c}
+}
+
 func (c *FakeKubermaticV1) Projects() v1.ProjectInterface {
 	return &FakeProjects{c}
 }
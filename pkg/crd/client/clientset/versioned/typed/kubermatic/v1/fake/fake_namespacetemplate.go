@@ -0,0 +1,106 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeNamespaceTemplates implements NamespaceTemplateInterface
+type FakeNamespaceTemplates struct {
+	Fake *FakeKubermaticV1
+}
+
+var namespacetemplatesResource = schema.GroupVersionResource{Group: "kubermatic.k8s.io", Version: "v1", Resource: "namespacetemplates"}
+
+var namespacetemplatesKind = schema.GroupVersionKind{Group: "kubermatic.k8s.io", Version: "v1", Kind: "NamespaceTemplate"}
+
+// Get takes name of the namespaceTemplate, and returns the corresponding namespaceTemplate object, and an error if there is any.
+func (c *FakeNamespaceTemplates) Get(ctx context.Context, name string, options v1.GetOptions) (result *kubermaticv1.NamespaceTemplate, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(namespacetemplatesResource, name), &kubermaticv1.NamespaceTemplate{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*kubermaticv1.NamespaceTemplate), err
+}
+
+// List takes label and field selectors, and returns the list of NamespaceTemplates that match those selectors.
+func (c *FakeNamespaceTemplates) List(ctx context.Context, opts v1.ListOptions) (result *kubermaticv1.NamespaceTemplateList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(namespacetemplatesResource, namespacetemplatesKind, opts), &kubermaticv1.NamespaceTemplateList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &kubermaticv1.NamespaceTemplateList{ListMeta: obj.(*kubermaticv1.NamespaceTemplateList).ListMeta}
+	for _, item := range obj.(*kubermaticv1.NamespaceTemplateList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested namespaceTemplates.
+func (c *FakeNamespaceTemplates) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(namespacetemplatesResource, opts))
+}
+
+// Create takes the representation of a namespaceTemplate and creates it.  Returns the server's representation of the namespaceTemplate, and an error, if there is any.
+func (c *FakeNamespaceTemplates) Create(ctx context.Context, namespaceTemplate *kubermaticv1.NamespaceTemplate, opts v1.CreateOptions) (result *kubermaticv1.NamespaceTemplate, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(namespacetemplatesResource, namespaceTemplate), &kubermaticv1.NamespaceTemplate{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*kubermaticv1.NamespaceTemplate), err
+}
+
+// Update takes the representation of a namespaceTemplate and updates it. Returns the server's representation of the namespaceTemplate, and an error, if there is any.
+func (c *FakeNamespaceTemplates) Update(ctx context.Context, namespaceTemplate *kubermaticv1.NamespaceTemplate, opts v1.UpdateOptions) (result *kubermaticv1.NamespaceTemplate, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(namespacetemplatesResource, namespaceTemplate), &kubermaticv1.NamespaceTemplate{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*kubermaticv1.NamespaceTemplate), err
+}
+
+// Delete takes name of the namespaceTemplate and deletes it. Returns an error if one occurs.
+func (c *FakeNamespaceTemplates) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteAction(namespacetemplatesResource, name), &kubermaticv1.NamespaceTemplate{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeNamespaceTemplates) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(namespacetemplatesResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &kubermaticv1.NamespaceTemplateList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched namespaceTemplate.
+func (c *FakeNamespaceTemplates) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *kubermaticv1.NamespaceTemplate, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(namespacetemplatesResource, name, pt, data, subresources...), &kubermaticv1.NamespaceTemplate{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*kubermaticv1.NamespaceTemplate), err
+}
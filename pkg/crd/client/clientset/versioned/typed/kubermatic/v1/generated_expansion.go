@@ -6,10 +6,16 @@ type AddonExpansion interface{}
 
 type AddonConfigExpansion interface{}
 
+type AddonRolloutExpansion interface{}
+
 type AlertmanagerExpansion interface{}
 
 type ClusterExpansion interface{}
 
+type ClusterNamespaceExpansion interface{}
+
+type ClusterSpecRevisionExpansion interface{}
+
 type ClusterTemplateExpansion interface{}
 
 type ClusterTemplateInstanceExpansion interface{}
@@ -26,6 +32,8 @@ type ExternalClusterExpansion interface{}
 
 type KubermaticSettingExpansion interface{}
 
+type NamespaceTemplateExpansion interface{}
+
 type ProjectExpansion interface{}
 
 type RuleGroupExpansion interface{}
@@ -12,8 +12,11 @@ type KubermaticV1Interface interface {
 	RESTClient() rest.Interface
 	AddonsGetter
 	AddonConfigsGetter
+	AddonRolloutsGetter
 	AlertmanagersGetter
 	ClustersGetter
+	ClusterNamespacesGetter
+	ClusterSpecRevisionsGetter
 	ClusterTemplatesGetter
 	ClusterTemplateInstancesGetter
 	ConstraintsGetter
@@ -22,6 +25,7 @@ type KubermaticV1Interface interface {
 	EtcdRestoresGetter
 	ExternalClustersGetter
 	KubermaticSettingsGetter
+	NamespaceTemplatesGetter
 	ProjectsGetter
 	RuleGroupsGetter
 	UsersGetter
@@ -43,6 +47,10 @@ func (c *KubermaticV1Client) AddonConfigs() AddonConfigInterface {
 	return newAddonConfigs(c)
 }
 
+func (c *KubermaticV1Client) AddonRollouts() AddonRolloutInterface {
+	return newAddonRollouts(c)
+}
+
 func (c *KubermaticV1Client) Alertmanagers(namespace string) AlertmanagerInterface {
 	return newAlertmanagers(c, namespace)
 }
@@ -51,6 +59,14 @@ func (c *KubermaticV1Client) Clusters() ClusterInterface {
 	return newClusters(c)
 }
 
+func (c *KubermaticV1Client) ClusterNamespaces() ClusterNamespaceInterface {
+	return newClusterNamespaces(c)
+}
+
+func (c *KubermaticV1Client) ClusterSpecRevisions() ClusterSpecRevisionInterface {
+	return newClusterSpecRevisions(c)
+}
+
 func (c *KubermaticV1Client) ClusterTemplates() ClusterTemplateInterface {
 	return newClusterTemplates(c)
 }
@@ -83,6 +99,10 @@ func (c *KubermaticV1Client) KubermaticSettings() KubermaticSettingInterface {
 	return newKubermaticSettings(c)
 }
 
+func (c *KubermaticV1Client) NamespaceTemplates() NamespaceTemplateInterface {
+	return newNamespaceTemplates(c)
+}
+
 func (c *KubermaticV1Client) Projects() ProjectInterface {
 	return newProjects(c)
 }
@@ -0,0 +1,152 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+	"time"
+
+	scheme "k8c.io/kubermatic/v2/pkg/crd/client/clientset/versioned/scheme"
+	v1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// NamespaceTemplatesGetter has a method to return a NamespaceTemplateInterface.
+// A group's client should implement this interface.
+type NamespaceTemplatesGetter interface {
+	NamespaceTemplates() NamespaceTemplateInterface
+}
+
+// NamespaceTemplateInterface has methods to work with NamespaceTemplate resources.
+type NamespaceTemplateInterface interface {
+	Create(ctx context.Context, namespaceTemplate *v1.NamespaceTemplate, opts metav1.CreateOptions) (*v1.NamespaceTemplate, error)
+	Update(ctx context.Context, namespaceTemplate *v1.NamespaceTemplate, opts metav1.UpdateOptions) (*v1.NamespaceTemplate, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.NamespaceTemplate, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*v1.NamespaceTemplateList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.NamespaceTemplate, err error)
+	NamespaceTemplateExpansion
+}
+
+// namespaceTemplates implements NamespaceTemplateInterface
+type namespaceTemplates struct {
+	client rest.Interface
+}
+
+// newNamespaceTemplates returns a NamespaceTemplates
+func newNamespaceTemplates(c *KubermaticV1Client) *namespaceTemplates {
+	return &namespaceTemplates{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the namespaceTemplate, and returns the corresponding namespaceTemplate object, and an error if there is any.
+func (c *namespaceTemplates) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.NamespaceTemplate, err error) {
+	result = &v1.NamespaceTemplate{}
+	err = c.client.Get().
+		Resource("namespacetemplates").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of NamespaceTemplates that match those selectors.
+func (c *namespaceTemplates) List(ctx context.Context, opts metav1.ListOptions) (result *v1.NamespaceTemplateList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1.NamespaceTemplateList{}
+	err = c.client.Get().
+		Resource("namespacetemplates").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested namespaceTemplates.
+func (c *namespaceTemplates) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Resource("namespacetemplates").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a namespaceTemplate and creates it.  Returns the server's representation of the namespaceTemplate, and an error, if there is any.
+func (c *namespaceTemplates) Create(ctx context.Context, namespaceTemplate *v1.NamespaceTemplate, opts metav1.CreateOptions) (result *v1.NamespaceTemplate, err error) {
+	result = &v1.NamespaceTemplate{}
+	err = c.client.Post().
+		Resource("namespacetemplates").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(namespaceTemplate).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a namespaceTemplate and updates it. Returns the server's representation of the namespaceTemplate, and an error, if there is any.
+func (c *namespaceTemplates) Update(ctx context.Context, namespaceTemplate *v1.NamespaceTemplate, opts metav1.UpdateOptions) (result *v1.NamespaceTemplate, err error) {
+	result = &v1.NamespaceTemplate{}
+	err = c.client.Put().
+		Resource("namespacetemplates").
+		Name(namespaceTemplate.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(namespaceTemplate).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the namespaceTemplate and deletes it. Returns an error if one occurs.
+func (c *namespaceTemplates) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("namespacetemplates").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *namespaceTemplates) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Resource("namespacetemplates").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched namespaceTemplate.
+func (c *namespaceTemplates) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.NamespaceTemplate, err error) {
+	result = &v1.NamespaceTemplate{}
+	err = c.client.Patch(pt).
+		Resource("namespacetemplates").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
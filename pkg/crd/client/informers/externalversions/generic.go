@@ -41,10 +41,16 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubermatic().V1().Addons().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("addonconfigs"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubermatic().V1().AddonConfigs().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("addonrollouts"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubermatic().V1().AddonRollouts().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("alertmanagers"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubermatic().V1().Alertmanagers().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("clusters"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubermatic().V1().Clusters().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("clusternamespaces"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubermatic().V1().ClusterNamespaces().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("clusterspecrevisions"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubermatic().V1().ClusterSpecRevisions().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("clustertemplates"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubermatic().V1().ClusterTemplates().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("clustertemplateinstances"):
@@ -61,6 +67,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubermatic().V1().ExternalClusters().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("kubermaticsettings"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubermatic().V1().KubermaticSettings().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("namespacetemplates"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubermatic().V1().NamespaceTemplates().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("projects"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubermatic().V1().Projects().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("rulegroups"):
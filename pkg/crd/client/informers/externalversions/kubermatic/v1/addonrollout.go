@@ -0,0 +1,73 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+	time "time"
+
+	versioned "k8c.io/kubermatic/v2/pkg/crd/client/clientset/versioned"
+	internalinterfaces "k8c.io/kubermatic/v2/pkg/crd/client/informers/externalversions/internalinterfaces"
+	v1 "k8c.io/kubermatic/v2/pkg/crd/client/listers/kubermatic/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// AddonRolloutInformer provides access to a shared informer and lister for
+// AddonRollouts.
+type AddonRolloutInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1.AddonRolloutLister
+}
+
+type addonRolloutInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+}
+
+// NewAddonRolloutInformer constructs a new informer for AddonRollout type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewAddonRolloutInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredAddonRolloutInformer(client, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredAddonRolloutInformer constructs a new informer for AddonRollout type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredAddonRolloutInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.KubermaticV1().AddonRollouts().List(context.TODO(), options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.KubermaticV1().AddonRollouts().Watch(context.TODO(), options)
+			},
+		},
+		&kubermaticv1.AddonRollout{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *addonRolloutInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredAddonRolloutInformer(client, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *addonRolloutInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&kubermaticv1.AddonRollout{}, f.defaultInformer)
+}
+
+func (f *addonRolloutInformer) Lister() v1.AddonRolloutLister {
+	return v1.NewAddonRolloutLister(f.Informer().GetIndexer())
+}
@@ -0,0 +1,73 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+	time "time"
+
+	versioned "k8c.io/kubermatic/v2/pkg/crd/client/clientset/versioned"
+	internalinterfaces "k8c.io/kubermatic/v2/pkg/crd/client/informers/externalversions/internalinterfaces"
+	v1 "k8c.io/kubermatic/v2/pkg/crd/client/listers/kubermatic/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// ClusterNamespaceInformer provides access to a shared informer and lister for
+// ClusterNamespaces.
+type ClusterNamespaceInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1.ClusterNamespaceLister
+}
+
+type clusterNamespaceInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+}
+
+// NewClusterNamespaceInformer constructs a new informer for ClusterNamespace type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewClusterNamespaceInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredClusterNamespaceInformer(client, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredClusterNamespaceInformer constructs a new informer for ClusterNamespace type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredClusterNamespaceInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.KubermaticV1().ClusterNamespaces().List(context.TODO(), options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.KubermaticV1().ClusterNamespaces().Watch(context.TODO(), options)
+			},
+		},
+		&kubermaticv1.ClusterNamespace{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *clusterNamespaceInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredClusterNamespaceInformer(client, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *clusterNamespaceInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&kubermaticv1.ClusterNamespace{}, f.defaultInformer)
+}
+
+func (f *clusterNamespaceInformer) Lister() v1.ClusterNamespaceLister {
+	return v1.NewClusterNamespaceLister(f.Informer().GetIndexer())
+}
@@ -12,10 +12,16 @@ type Interface interface {
 	Addons() AddonInformer
 	// AddonConfigs returns a AddonConfigInformer.
 	AddonConfigs() AddonConfigInformer
+	// AddonRollouts returns a AddonRolloutInformer.
+	AddonRollouts() AddonRolloutInformer
 	// Alertmanagers returns a AlertmanagerInformer.
 	Alertmanagers() AlertmanagerInformer
 	// Clusters returns a ClusterInformer.
 	Clusters() ClusterInformer
+	// ClusterNamespaces returns a ClusterNamespaceInformer.
+	ClusterNamespaces() ClusterNamespaceInformer
+	// ClusterSpecRevisions returns a ClusterSpecRevisionInformer.
+	ClusterSpecRevisions() ClusterSpecRevisionInformer
 	// ClusterTemplates returns a ClusterTemplateInformer.
 	ClusterTemplates() ClusterTemplateInformer
 	// ClusterTemplateInstances returns a ClusterTemplateInstanceInformer.
@@ -32,6 +38,8 @@ type Interface interface {
 	ExternalClusters() ExternalClusterInformer
 	// KubermaticSettings returns a KubermaticSettingInformer.
 	KubermaticSettings() KubermaticSettingInformer
+	// NamespaceTemplates returns a NamespaceTemplateInformer.
+	NamespaceTemplates() NamespaceTemplateInformer
 	// Projects returns a ProjectInformer.
 	Projects() ProjectInformer
 	// RuleGroups returns a RuleGroupInformer.
@@ -67,6 +75,11 @@ func (v *version) AddonConfigs() AddonConfigInformer {
 	return &addonConfigInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// AddonRollouts returns a AddonRolloutInformer.
+func (v *version) AddonRollouts() AddonRolloutInformer {
+	return &addonRolloutInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // Alertmanagers returns a AlertmanagerInformer.
 func (v *version) Alertmanagers() AlertmanagerInformer {
 	return &alertmanagerInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -77,6 +90,16 @@ func (v *version) Clusters() ClusterInformer {
 	return &clusterInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// ClusterNamespaces returns a ClusterNamespaceInformer.
+func (v *version) ClusterNamespaces() ClusterNamespaceInformer {
+	return &clusterNamespaceInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// ClusterSpecRevisions returns a ClusterSpecRevisionInformer.
+func (v *version) ClusterSpecRevisions() ClusterSpecRevisionInformer {
+	return &clusterSpecRevisionInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // ClusterTemplates returns a ClusterTemplateInformer.
 func (v *version) ClusterTemplates() ClusterTemplateInformer {
 	return &clusterTemplateInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
@@ -117,6 +140,11 @@ func (v *version) KubermaticSettings() KubermaticSettingInformer {
 	return &kubermaticSettingInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// NamespaceTemplates returns a NamespaceTemplateInformer.
+func (v *version) NamespaceTemplates() NamespaceTemplateInformer {
+	return &namespaceTemplateInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // Projects returns a ProjectInformer.
 func (v *version) Projects() ProjectInformer {
 	return &projectInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
@@ -0,0 +1,52 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// AddonRolloutLister helps list AddonRollouts.
+// All objects returned here must be treated as read-only.
+type AddonRolloutLister interface {
+	// List lists all AddonRollouts in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.AddonRollout, err error)
+	// Get retrieves the AddonRollout from the index for a given name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1.AddonRollout, error)
+	AddonRolloutListerExpansion
+}
+
+// addonRolloutLister implements the AddonRolloutLister interface.
+type addonRolloutLister struct {
+	indexer cache.Indexer
+}
+
+// NewAddonRolloutLister returns a new AddonRolloutLister.
+func NewAddonRolloutLister(indexer cache.Indexer) AddonRolloutLister {
+	return &addonRolloutLister{indexer: indexer}
+}
+
+// List lists all AddonRollouts in the indexer.
+func (s *addonRolloutLister) List(selector labels.Selector) (ret []*v1.AddonRollout, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.AddonRollout))
+	})
+	return ret, err
+}
+
+// Get retrieves the AddonRollout from the index for a given name.
+func (s *addonRolloutLister) Get(name string) (*v1.AddonRollout, error) {
+	obj, exists, err := s.indexer.GetByKey(name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1.Resource("addonrollout"), name)
+	}
+	return obj.(*v1.AddonRollout), nil
+}
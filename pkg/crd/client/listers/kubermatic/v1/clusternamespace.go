@@ -0,0 +1,52 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ClusterNamespaceLister helps list ClusterNamespaces.
+// All objects returned here must be treated as read-only.
+type ClusterNamespaceLister interface {
+	// List lists all ClusterNamespaces in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.ClusterNamespace, err error)
+	// Get retrieves the ClusterNamespace from the index for a given name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1.ClusterNamespace, error)
+	ClusterNamespaceListerExpansion
+}
+
+// clusterNamespaceLister implements the ClusterNamespaceLister interface.
+type clusterNamespaceLister struct {
+	indexer cache.Indexer
+}
+
+// NewClusterNamespaceLister returns a new ClusterNamespaceLister.
+func NewClusterNamespaceLister(indexer cache.Indexer) ClusterNamespaceLister {
+	return &clusterNamespaceLister{indexer: indexer}
+}
+
+// List lists all ClusterNamespaces in the indexer.
+func (s *clusterNamespaceLister) List(selector labels.Selector) (ret []*v1.ClusterNamespace, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.ClusterNamespace))
+	})
+	return ret, err
+}
+
+// Get retrieves the ClusterNamespace from the index for a given name.
+func (s *clusterNamespaceLister) Get(name string) (*v1.ClusterNamespace, error) {
+	obj, exists, err := s.indexer.GetByKey(name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1.Resource("clusternamespace"), name)
+	}
+	return obj.(*v1.ClusterNamespace), nil
+}
@@ -0,0 +1,52 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ClusterSpecRevisionLister helps list ClusterSpecRevisions.
+// All objects returned here must be treated as read-only.
+type ClusterSpecRevisionLister interface {
+	// List lists all ClusterSpecRevisions in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.ClusterSpecRevision, err error)
+	// Get retrieves the ClusterSpecRevision from the index for a given name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1.ClusterSpecRevision, error)
+	ClusterSpecRevisionListerExpansion
+}
+
+// clusterSpecRevisionLister implements the ClusterSpecRevisionLister interface.
+type clusterSpecRevisionLister struct {
+	indexer cache.Indexer
+}
+
+// NewClusterSpecRevisionLister returns a new ClusterSpecRevisionLister.
+func NewClusterSpecRevisionLister(indexer cache.Indexer) ClusterSpecRevisionLister {
+	return &clusterSpecRevisionLister{indexer: indexer}
+}
+
+// List lists all ClusterSpecRevisions in the indexer.
+func (s *clusterSpecRevisionLister) List(selector labels.Selector) (ret []*v1.ClusterSpecRevision, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.ClusterSpecRevision))
+	})
+	return ret, err
+}
+
+// Get retrieves the ClusterSpecRevision from the index for a given name.
+func (s *clusterSpecRevisionLister) Get(name string) (*v1.ClusterSpecRevision, error) {
+	obj, exists, err := s.indexer.GetByKey(name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1.Resource("clusterspecrevision"), name)
+	}
+	return obj.(*v1.ClusterSpecRevision), nil
+}
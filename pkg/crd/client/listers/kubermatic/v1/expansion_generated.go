@@ -14,6 +14,10 @@ type AddonNamespaceListerExpansion interface{}
 // AddonConfigLister.
 type AddonConfigListerExpansion interface{}
 
+// AddonRolloutListerExpansion allows custom methods to be added to
+// AddonRolloutLister.
+type AddonRolloutListerExpansion interface{}
+
 // AlertmanagerListerExpansion allows custom methods to be added to
 // AlertmanagerLister.
 type AlertmanagerListerExpansion interface{}
@@ -26,6 +30,14 @@ type AlertmanagerNamespaceListerExpansion interface{}
 // ClusterLister.
 type ClusterListerExpansion interface{}
 
+// ClusterNamespaceListerExpansion allows custom methods to be added to
+// ClusterNamespaceLister.
+type ClusterNamespaceListerExpansion interface{}
+
+// ClusterSpecRevisionListerExpansion allows custom methods to be added to
+// ClusterSpecRevisionLister.
+type ClusterSpecRevisionListerExpansion interface{}
+
 // ClusterTemplateListerExpansion allows custom methods to be added to
 // ClusterTemplateLister.
 type ClusterTemplateListerExpansion interface{}
@@ -70,6 +82,10 @@ type ExternalClusterListerExpansion interface{}
 // KubermaticSettingLister.
 type KubermaticSettingListerExpansion interface{}
 
+// NamespaceTemplateListerExpansion allows custom methods to be added to
+// NamespaceTemplateLister.
+type NamespaceTemplateListerExpansion interface{}
+
 // ProjectListerExpansion allows custom methods to be added to
 // ProjectLister.
 type ProjectListerExpansion interface{}
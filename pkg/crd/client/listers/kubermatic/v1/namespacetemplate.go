@@ -0,0 +1,52 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// NamespaceTemplateLister helps list NamespaceTemplates.
+// All objects returned here must be treated as read-only.
+type NamespaceTemplateLister interface {
+	// List lists all NamespaceTemplates in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.NamespaceTemplate, err error)
+	// Get retrieves the NamespaceTemplate from the index for a given name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1.NamespaceTemplate, error)
+	NamespaceTemplateListerExpansion
+}
+
+// namespaceTemplateLister implements the NamespaceTemplateLister interface.
+type namespaceTemplateLister struct {
+	indexer cache.Indexer
+}
+
+// NewNamespaceTemplateLister returns a new NamespaceTemplateLister.
+func NewNamespaceTemplateLister(indexer cache.Indexer) NamespaceTemplateLister {
+	return &namespaceTemplateLister{indexer: indexer}
+}
+
+// List lists all NamespaceTemplates in the indexer.
+func (s *namespaceTemplateLister) List(selector labels.Selector) (ret []*v1.NamespaceTemplate, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.NamespaceTemplate))
+	})
+	return ret, err
+}
+
+// Get retrieves the NamespaceTemplate from the index for a given name.
+func (s *namespaceTemplateLister) Get(name string) (*v1.NamespaceTemplate, error) {
+	obj, exists, err := s.indexer.GetByKey(name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1.Resource("namespacetemplate"), name)
+	}
+	return obj.(*v1.NamespaceTemplate), nil
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// AddonRolloutResourceName represents "Resource" defined in Kubernetes
+	AddonRolloutResourceName = "addonrollouts"
+
+	// AddonRolloutKindName represents "Kind" defined in Kubernetes
+	AddonRolloutKindName = "AddonRollout"
+
+	// AddonRolloutLabelKey is the label put on addons which are managed by an addon rollout.
+	// Its value is the name of the rollout.
+	AddonRolloutLabelKey = "kubermatic.io/addon-rollout"
+
+	// AddonRolloutVersionAnnotation is the annotation on managed addons holding the
+	// rollout version which was applied to them.
+	AddonRolloutVersionAnnotation = "kubermatic.io/addon-rollout-version"
+)
+
+// AddonRolloutPhase is the phase of a whole addon rollout.
+type AddonRolloutPhase string
+
+const (
+	// AddonRolloutProgressing means that the addon is being rolled out wave by wave.
+	AddonRolloutProgressing AddonRolloutPhase = "Progressing"
+	// AddonRolloutHalted means that more clusters failed than allowed and the rollout was stopped.
+	AddonRolloutHalted AddonRolloutPhase = "Halted"
+	// AddonRolloutCompleted means that all waves were rolled out.
+	AddonRolloutCompleted AddonRolloutPhase = "Completed"
+)
+
+// AddonRolloutClusterPhase is the phase of a rollout in a single cluster.
+type AddonRolloutClusterPhase string
+
+const (
+	// AddonRolloutClusterPending means that the wave of the cluster was not started yet.
+	AddonRolloutClusterPending AddonRolloutClusterPhase = "Pending"
+	// AddonRolloutClusterInstalling means that the addon was applied and its health is awaited.
+	AddonRolloutClusterInstalling AddonRolloutClusterPhase = "Installing"
+	// AddonRolloutClusterHealthy means that the addon was installed and the cluster is healthy.
+	AddonRolloutClusterHealthy AddonRolloutClusterPhase = "Healthy"
+	// AddonRolloutClusterFailed means that the cluster did not become healthy in time.
+	AddonRolloutClusterFailed AddonRolloutClusterPhase = "Failed"
+	// AddonRolloutClusterSkipped means that the cluster already has an addon of the same name
+	// which is not managed by this rollout.
+	AddonRolloutClusterSkipped AddonRolloutClusterPhase = "Skipped"
+)
+
+//+genclient
+//+genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AddonRollout installs an addon into all clusters matching a label selector. The clusters are
+// processed in waves, a wave is only started once all clusters of the previous waves became healthy.
+type AddonRollout struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AddonRolloutSpec   `json:"spec"`
+	Status AddonRolloutStatus `json:"status,omitempty"`
+}
+
+// AddonRolloutSpec specifies which addon is rolled out to which clusters and how.
+type AddonRolloutSpec struct {
+	// Addon is the name of the addon to install.
+	Addon string `json:"addon"`
+	// Version identifies the addon configuration being rolled out. Changing it
+	// restarts the rollout from the first wave.
+	Version string `json:"version"`
+	// Variables is free form data to use for parsing the manifest templates of the addon.
+	Variables runtime.RawExtension `json:"variables,omitempty"`
+	// ClusterSelector selects the clusters across all seeds the addon is installed into.
+	ClusterSelector metav1.LabelSelector `json:"clusterSelector"`
+	// Waves define the cumulative percentage of the selected clusters which the addon
+	// is installed into per step. Defaults to a single wave with all clusters.
+	Waves []AddonRolloutWave `json:"waves,omitempty"`
+	// HealthTimeout is the time a cluster has to become healthy after the addon was
+	// applied before it is considered failed. Defaults to 10 minutes.
+	HealthTimeout *metav1.Duration `json:"healthTimeout,omitempty"`
+	// MaxFailures is the number of failed clusters which is tolerated before the
+	// rollout is halted.
+	MaxFailures int `json:"maxFailures,omitempty"`
+	// Paused prevents the next wave from being started.
+	Paused bool `json:"paused,omitempty"`
+}
+
+// AddonRolloutWave is a single step of an addon rollout.
+type AddonRolloutWave struct {
+	// Percentage of all selected clusters which have the addon installed once this wave is done.
+	Percentage int `json:"percentage"`
+}
+
+// AddonRolloutStatus reports the progress of an addon rollout.
+type AddonRolloutStatus struct {
+	// Phase is the phase of the rollout.
+	Phase AddonRolloutPhase `json:"phase,omitempty"`
+	// Version is the version of the spec this status belongs to.
+	Version string `json:"version,omitempty"`
+	// CurrentWave is the index of the wave being rolled out.
+	CurrentWave int `json:"currentWave"`
+	// Clusters is the state of the rollout in every selected cluster.
+	Clusters []AddonRolloutClusterStatus `json:"clusters,omitempty"`
+	// Message explains the phase, e.g. why the rollout was halted.
+	Message string `json:"message,omitempty"`
+}
+
+// AddonRolloutClusterStatus is the state of an addon rollout in a single cluster.
+type AddonRolloutClusterStatus struct {
+	// Name is the name of the cluster.
+	Name string `json:"name"`
+	// Seed is the name of the seed the cluster runs on.
+	Seed string `json:"seed"`
+	// Wave is the index of the wave the cluster belongs to.
+	Wave int `json:"wave"`
+	// Phase is the phase of the rollout in this cluster.
+	Phase AddonRolloutClusterPhase `json:"phase"`
+	// Message explains the phase, e.g. why the cluster failed.
+	Message string `json:"message,omitempty"`
+	// LastTransitionTime is the time the phase changed the last time.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AddonRolloutList specifies a list of addon rollouts
+type AddonRolloutList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []AddonRollout `json:"items"`
+}
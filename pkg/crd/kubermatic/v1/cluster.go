@@ -48,6 +48,14 @@ const (
 
 	// ForceRestartAnnotation is key of the annotation used to restart machine deployments.
 	ForceRestartAnnotation = "forceRestart"
+
+	// SkipPreDeleteHooksAnnotation can be set to "true" on a cluster to continue its deletion
+	// without waiting for the pre-delete hooks of its project.
+	SkipPreDeleteHooksAnnotation = "kubermatic.io/skip-pre-delete-hooks"
+
+	// InheritedPresetAnnotation records the name of the project default preset whose credentials
+	// were bound to a cluster that was created without explicit credentials.
+	InheritedPresetAnnotation = "kubermatic.io/inherited-preset"
 )
 
 const (
@@ -72,6 +80,18 @@ const (
 	AzureBasicLBSKU    = LBSKU("basic")
 )
 
+// AzureNetworkPlugin is the networking model of the nodes of an Azure cluster.
+type AzureNetworkPlugin string
+
+const (
+	// AzureNetworkPluginKubenet assigns the pods IPs from the cluster's pod CIDR, which are
+	// routed between the nodes via the cluster's route table.
+	AzureNetworkPluginKubenet = AzureNetworkPlugin("kubenet")
+	// AzureNetworkPluginAzure assigns the pods IPs from the cluster's subnet, so that they are
+	// directly reachable within the VNet.
+	AzureNetworkPluginAzure = AzureNetworkPlugin("azure")
+)
+
 // ProtectedClusterLabels is a set of labels that must not be set by users on clusters,
 // as they are security relevant.
 var ProtectedClusterLabels = sets.NewString(WorkerNameLabelKey, ProjectIDLabelKey)
@@ -106,6 +126,13 @@ type ClusterSpec struct {
 	ClusterNetwork  ClusterNetworkingConfig   `json:"clusterNetwork"`
 	MachineNetworks []MachineNetworkingConfig `json:"machineNetworks,omitempty"`
 
+	// Optional: AdditionalClouds are clouds of other providers which node deployments of the
+	// cluster can be created in, for example to burst from an on-premise vSphere datacenter to
+	// Azure. Every provider can only be used once and not for the cloud of the cluster itself.
+	// Kubermatic does not create any infrastructure in these clouds, the nodes use the networks
+	// referenced in the cloud spec and reach the API server via its external address.
+	AdditionalClouds []CloudSpec `json:"additionalClouds,omitempty"`
+
 	// Version defines the wanted version of the control plane
 	Version semver.Semver `json:"version"`
 	// MasterVersion is Deprecated
@@ -170,11 +197,30 @@ type ClusterSpec struct {
 	// MLA contains monitoring, logging and alerting related settings for the user cluster.
 	MLA *MLASettings `json:"mla,omitempty"`
 
+	// LogShipping configures shipping the container logs of the user cluster to an external destination.
+	LogShipping *LogShippingSettings `json:"logShipping,omitempty"`
+
 	// ContainerRuntime to use, i.e. Docker or containerd. By default containerd will be used.
 	ContainerRuntime string `json:"containerRuntime,omitempty"`
 
 	// CNIPlugin contains the spec of the CNI plugin to be installed in the cluster.
 	CNIPlugin *CNIPluginSettings `json:"cniPlugin,omitempty"`
+
+	// Optional: PatchUpgradePolicy enables automatic upgrades of the control plane to new patch
+	// releases of its current minor version.
+	PatchUpgradePolicy *PatchUpgradePolicy `json:"patchUpgradePolicy,omitempty"`
+}
+
+// PatchUpgradePolicy configures the automatic upgrade of the control plane to the latest patch
+// release of its minor version. Upgrades are only applied during the update window of the
+// cluster, if it has one. The upgrade is verified by waiting for the control plane to become
+// healthy; if it does not, the control plane is rolled back to the previous version.
+type PatchUpgradePolicy struct {
+	// Enabled enables the automatic patch upgrades.
+	Enabled bool `json:"enabled"`
+	// Optional: HealthCheckTimeout is the time the upgraded control plane has to become healthy
+	// before it is rolled back. Defaults to 15 minutes.
+	HealthCheckTimeout *metav1.Duration `json:"healthCheckTimeout,omitempty"`
 }
 
 // CNIPluginSettings contains the spec of the CNI plugin used by the Cluster.
@@ -213,6 +259,10 @@ const (
 	// ClusterFeatureEtcdLauncher enables features related to the experimental etcd-launcher. This includes user-cluster
 	// etcd scaling, automatic volume recovery and new backup/restore contorllers.
 	ClusterFeatureEtcdLauncher = "etcdLauncher"
+
+	// ClusterFeatureNodeProblemRemediation enables replacing machines whose nodes report a problem
+	// which does not recover without a reboot, like a kernel deadlock, for longer than a few minutes.
+	ClusterFeatureNodeProblemRemediation = "nodeProblemRemediation"
 )
 
 // ClusterConditionType is used to indicate the type of a cluster condition. For all condition
@@ -247,6 +297,22 @@ const (
 
 	ClusterConditionEtcdClusterInitialized ClusterConditionType = "EtcdClusterInitialized"
 
+	// The Azure conditions report the provisioning state of the individual cloud resources
+	// of Azure clusters. They are not part of AllClusterConditionTypes, as they are only
+	// set for clusters on Azure.
+	ClusterConditionAzureResourceGroupReady   ClusterConditionType = "AzureResourceGroupReady"
+	ClusterConditionAzureVNetReady            ClusterConditionType = "AzureVNetReady"
+	ClusterConditionAzureSubnetReady          ClusterConditionType = "AzureSubnetReady"
+	ClusterConditionAzureRouteTableReady      ClusterConditionType = "AzureRouteTableReady"
+	ClusterConditionAzureSecurityGroupReady   ClusterConditionType = "AzureSecurityGroupReady"
+	ClusterConditionAzureAvailabilitySetReady ClusterConditionType = "AzureAvailabilitySetReady"
+
+	// ClusterConditionCloudProviderHealthy reports whether the cloud resources the cluster depends
+	// on are still usable, so that problems with the cloud infrastructure can be told apart from
+	// problems with the control plane. It is only set for cloud providers which probe their
+	// resources, currently Azure.
+	ClusterConditionCloudProviderHealthy ClusterConditionType = "CloudProviderHealthy"
+
 	// ClusterConditionNone is a special value indicating that no cluster condition should be set
 	ClusterConditionNone ClusterConditionType = ""
 	// This condition is met when a CSI migration is ongoing and the CSI
@@ -333,6 +399,164 @@ type ClusterStatus struct {
 
 	// InheritedLabels are labels the cluster inherited from the project. They are read-only for users.
 	InheritedLabels map[string]string `json:"inheritedLabels,omitempty"`
+
+	// APIDeprecations contains the result of the last scan of the cluster for APIs which are
+	// removed in the next Kubernetes minor version.
+	APIDeprecations *APIDeprecationReport `json:"apiDeprecations,omitempty"`
+
+	// CredentialRotations contains the time each of the credentials Kubermatic generates for the
+	// control plane of the cluster was last rotated.
+	CredentialRotations []CredentialRotation `json:"credentialRotations,omitempty"`
+
+	// Azure contains the state of the Azure resources Kubermatic manages for the cluster.
+	Azure *AzureClusterStatus `json:"azure,omitempty"`
+
+	// CloudProviderResources are the provider-native identifiers, like Azure resource IDs or AWS
+	// IDs and ARNs, of the cloud resources the cluster uses. They are recorded once the cloud
+	// provider has been initialized, so that they do not have to be reconstructed from names.
+	CloudProviderResources []CloudProviderResource `json:"cloudProviderResources,omitempty"`
+
+	// NodeProblems are the problems currently reported by the nodes of the cluster, for example by
+	// the node-problem-detector addon or by the kubelet.
+	NodeProblems []NodeProblem `json:"nodeProblems,omitempty"`
+
+	// PatchUpgrade is the state of the last automatic patch upgrade of the control plane.
+	PatchUpgrade *PatchUpgradeStatus `json:"patchUpgrade,omitempty"`
+}
+
+// PatchUpgradePhase is the phase of an automatic patch upgrade.
+type PatchUpgradePhase string
+
+const (
+	// PatchUpgradePhaseInProgress means the control plane has been upgraded and is waiting to
+	// become healthy.
+	PatchUpgradePhaseInProgress PatchUpgradePhase = "InProgress"
+	// PatchUpgradePhaseSucceeded means the upgraded control plane became healthy.
+	PatchUpgradePhaseSucceeded PatchUpgradePhase = "Succeeded"
+	// PatchUpgradePhaseRolledBack means the upgraded control plane did not become healthy in
+	// time and has been rolled back. The patch release is not applied again.
+	PatchUpgradePhaseRolledBack PatchUpgradePhase = "RolledBack"
+)
+
+// PatchUpgradeStatus is the state of an automatic patch upgrade of the control plane.
+type PatchUpgradeStatus struct {
+	Phase PatchUpgradePhase `json:"phase"`
+	// FromVersion is the version the control plane was upgraded from.
+	FromVersion string `json:"fromVersion"`
+	// ToVersion is the patch release the control plane was upgraded to.
+	ToVersion string `json:"toVersion"`
+	// StartedAt is the time the upgrade was started.
+	StartedAt metav1.Time `json:"startedAt"`
+	// FinishedAt is the time the upgrade succeeded or was rolled back.
+	FinishedAt *metav1.Time `json:"finishedAt,omitempty"`
+}
+
+// NodeProblem is a condition of a node of the user cluster which indicates a problem.
+type NodeProblem struct {
+	// Node is the name of the node.
+	Node string `json:"node"`
+	// Type is the type of the node condition, like "KernelDeadlock" or "DiskPressure".
+	Type    corev1.NodeConditionType `json:"type"`
+	Reason  string                   `json:"reason,omitempty"`
+	Message string                   `json:"message,omitempty"`
+	// Since is the time the node started to report the problem.
+	Since metav1.Time `json:"since"`
+}
+
+// CloudProviderResource is a cloud resource used by a cluster.
+type CloudProviderResource struct {
+	// Type is the type of the resource, like "resourceGroup" or "securityGroup".
+	Type string `json:"type"`
+	// ID is the provider-native identifier of the resource.
+	ID string `json:"id"`
+	// Managed is true if the resource has been created by Kubermatic and is deleted together
+	// with the cluster.
+	Managed bool `json:"managed,omitempty"`
+}
+
+// AzureClusterStatus contains the state of the Azure resources Kubermatic manages for a cluster.
+type AzureClusterStatus struct {
+	// AvailabilitySets are the availability sets of the MachineDeployments of the cluster, if
+	// it has an availability set per MachineDeployment.
+	AvailabilitySets []AzureAvailabilitySet `json:"availabilitySets,omitempty"`
+	// ServicePrincipal is the service principal created for the cluster, if it has a service
+	// principal of its own.
+	ServicePrincipal *AzureServicePrincipal `json:"servicePrincipal,omitempty"`
+	// Subnets are the names of the additional subnets Kubermatic created in the VNet of the
+	// cluster. Additional subnets which existed before are not listed, as they are never deleted.
+	Subnets []string `json:"subnets,omitempty"`
+	// BootDiagnosticsStorageURI is the blob endpoint of the storage account the boot diagnostics
+	// of the nodes are written to, once it is available.
+	BootDiagnosticsStorageURI string `json:"bootDiagnosticsStorageURI,omitempty"`
+	// LogAnalyticsWorkspaceID is the ID of the Log Analytics workspace the monitoring agent
+	// sends its data to, once it is available.
+	LogAnalyticsWorkspaceID string `json:"logAnalyticsWorkspaceID,omitempty"`
+	// EgressFirewallRulesID is the ID of the Azure Firewall Kubermatic added the rule collections
+	// of the cluster to, so that they can be removed once the firewall is changed.
+	EgressFirewallRulesID string `json:"egressFirewallRulesID,omitempty"`
+}
+
+// AzureServicePrincipal is a service principal Kubermatic created for a cluster.
+type AzureServicePrincipal struct {
+	// ApplicationObjectID is the object ID of the Azure AD application of the service principal.
+	ApplicationObjectID string `json:"applicationObjectID"`
+	// ClientID is the application ID the service principal authenticates with.
+	ClientID string `json:"clientID,omitempty"`
+	// ObjectID is the object ID of the service principal.
+	ObjectID string `json:"objectID,omitempty"`
+	// RoleAssignment is the name of the role assignment granting the service principal access
+	// to the resource group of the cluster.
+	RoleAssignment string `json:"roleAssignment,omitempty"`
+}
+
+// AzureAvailabilitySet is the availability set the machines of a MachineDeployment are placed in.
+type AzureAvailabilitySet struct {
+	// MachineDeployment is the name of the MachineDeployment.
+	MachineDeployment string `json:"machineDeployment"`
+	// Name is the name of the availability set in the resource group of the cluster.
+	Name string `json:"name"`
+	// Created is true once the availability set has been created.
+	Created bool `json:"created,omitempty"`
+}
+
+// AdminTokenCredentialName is the name of the admin token in the credential rotations of a cluster.
+const AdminTokenCredentialName = "admin-token"
+
+// CredentialRotation is the last rotation of a credential of the control plane of a cluster.
+type CredentialRotation struct {
+	// Name is the name of the secret in the cluster namespace containing the credential, or
+	// "admin-token" for the admin token of the cluster.
+	Name string `json:"name"`
+	// RotationTime is the time the credential was generated.
+	RotationTime metav1.Time `json:"rotationTime"`
+}
+
+// APIDeprecationReport lists the APIs served by a cluster that are removed in the next
+// Kubernetes minor version.
+type APIDeprecationReport struct {
+	// ScanTime is the time of the scan.
+	ScanTime metav1.Time `json:"scanTime"`
+	// KubernetesVersion is the version of the cluster at the time of the scan.
+	KubernetesVersion string `json:"kubernetesVersion"`
+	// TargetVersion is the Kubernetes minor version the APIs were checked against.
+	TargetVersion string `json:"targetVersion"`
+	// RemovedAPIs are the APIs which are no longer served in the target version.
+	RemovedAPIs []RemovedAPI `json:"removedAPIs,omitempty"`
+}
+
+// RemovedAPI is an API served by a cluster which is removed in a later Kubernetes version.
+type RemovedAPI struct {
+	Group    string `json:"group"`
+	Version  string `json:"version"`
+	Resource string `json:"resource"`
+	// RemovedIn is the Kubernetes minor version which no longer serves the API.
+	RemovedIn string `json:"removedIn"`
+	// Replacement is the API version to migrate to.
+	Replacement string `json:"replacement,omitempty"`
+	// Requested is true if the API server received requests for the API since it was started.
+	// APIs which are served but not requested are listed for completeness, as manifests applied
+	// while the API server was down can still use them.
+	Requested bool `json:"requested"`
 }
 
 // HasConditionValue returns true if the cluster status has the given condition with the given status.
@@ -348,6 +572,29 @@ func (cs *ClusterStatus) HasConditionValue(conditionType ClusterConditionType, c
 	return false
 }
 
+// GetCredentialRotationTime returns the time the credential with the given name was last rotated.
+func (cs *ClusterStatus) GetCredentialRotationTime(name string) (metav1.Time, bool) {
+	for _, rotation := range cs.CredentialRotations {
+		if rotation.Name == name {
+			return rotation.RotationTime, true
+		}
+	}
+
+	return metav1.Time{}, false
+}
+
+// SetCredentialRotationTime records the time the credential with the given name was rotated.
+func (cs *ClusterStatus) SetCredentialRotationTime(name string, rotationTime metav1.Time) {
+	for i := range cs.CredentialRotations {
+		if cs.CredentialRotations[i].Name == name {
+			cs.CredentialRotations[i].RotationTime = rotationTime
+			return
+		}
+	}
+
+	cs.CredentialRotations = append(cs.CredentialRotations, CredentialRotation{Name: name, RotationTime: rotationTime})
+}
+
 type ClusterStatusError string
 
 const (
@@ -395,6 +642,29 @@ type MLASettings struct {
 	LoggingEnabled bool `json:"loggingEnabled,omitempty"`
 }
 
+// LogShippingDestinationType is the type of a log shipping destination.
+type LogShippingDestinationType string
+
+const (
+	LogShippingDestinationLoki          LogShippingDestinationType = "loki"
+	LogShippingDestinationElasticsearch LogShippingDestinationType = "elasticsearch"
+	LogShippingDestinationSyslog        LogShippingDestinationType = "syslog"
+)
+
+type LogShippingSettings struct {
+	// Type is the type of the destination, one of loki, elasticsearch or syslog.
+	Type LogShippingDestinationType `json:"type"`
+	// Endpoint is the URL of the destination, e.g. https://loki.example.com:3100 or
+	// tcp://syslog.example.com:514. The https scheme and the tls scheme for syslog
+	// enable TLS, the certificate of the destination is verified against the CA bundle.
+	Endpoint string `json:"endpoint"`
+	// Labels are added to all shipped log records, in addition to the cluster and
+	// project labels identifying the tenant.
+	Labels map[string]string `json:"labels,omitempty"`
+	// InsecureSkipVerify disables verifying the certificate of the destination.
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
 type ComponentSettings struct {
 	Apiserver         APIServerSettings       `json:"apiserver"`
 	ControllerManager ControllerSettings      `json:"controllerManager"`
@@ -468,6 +738,11 @@ type ClusterNetworkingConfig struct {
 	// NodeLocalDNSCacheEnabled controls whether the NodeLocal DNS Cache feature is enabled.
 	// Defaults to true.
 	NodeLocalDNSCacheEnabled *bool `json:"nodeLocalDNSCacheEnabled,omitempty"`
+
+	// NodeCIDRMaskSize is the mask size of the pod CIDR assigned to each node, which limits the
+	// number of nodes to 2^(NodeCIDRMaskSize - pod CIDR mask size). Node deployments cannot be
+	// scaled beyond this number of nodes. Defaults to 24.
+	NodeCIDRMaskSize *int32 `json:"nodeCIDRMaskSize,omitempty"`
 }
 
 // MachineNetworkingConfig specifies the networking parameters used for IPAM.
@@ -572,9 +847,275 @@ type AzureCloudSpec struct {
 	SubnetName        string `json:"subnet"`
 	RouteTableName    string `json:"routeTable"`
 	SecurityGroup     string `json:"securityGroup"`
-	AvailabilitySet   string `json:"availabilitySet"`
+	// Optional: RouteTableID is the resource ID of an existing route table in the subscription of
+	// the VNet, e.g. in the resource group of the VNet, which is used instead of creating one in
+	// the resource group of the cluster. If the subnet already exists, it has to be associated with
+	// the route table, otherwise the subnet is created with it. The route table is neither modified
+	// nor deleted by Kubermatic. Cannot be changed after the cluster has been created.
+	RouteTableID string `json:"routeTableID,omitempty"`
+	// Optional: SecurityGroupID is the resource ID of an existing security group in the
+	// subscription of the VNet, which is used instead of creating one in the resource group of
+	// the cluster. It has to allow the traffic of the nodes and is associated with the subnet
+	// instead of the network interfaces of the nodes, so an existing subnet has to be associated
+	// with it already. The security group is not deleted by Kubermatic. Cannot be changed after the
+	// cluster has been created.
+	SecurityGroupID string `json:"securityGroupID,omitempty"`
+	// Optional: VNetSubscriptionID is the subscription the VNet and its resource group live in,
+	// if it differs from the subscription of the cluster, e.g. in a hub-and-spoke layout where
+	// the network is owned by another team. The VNet, the subnet and the security group have to
+	// exist already, as well as the route table if one is needed, and the route table and the
+	// security group have to be referenced by ID. The credentials of the cluster need
+	// permissions on them. Cannot be changed after the cluster has been created.
+	VNetSubscriptionID string `json:"vnetSubscriptionID,omitempty"`
+	// Optional: VNetTenantID is the Azure AD tenant of the VNet subscription, if it differs from
+	// the tenant of the cluster. The service principal of the cluster has to be a multi-tenant
+	// application which is registered in that tenant, so that it can authorize against both.
+	// Cannot be changed after the cluster has been created.
+	VNetTenantID string `json:"vnetTenantID,omitempty"`
+	// Optional: VNetCIDR is the address space of the VNet if it is created by Kubermatic. Defaults
+	// to the one configured in the datacenter or to 10.0.0.0/16. Cannot be changed after the
+	// cluster has been created.
+	VNetCIDR string `json:"vnetCIDR,omitempty"`
+	// Optional: SubnetCIDR is the address range of the subnet if it is created by Kubermatic. It
+	// has to be within the address space of the VNet. Defaults to the one configured in the
+	// datacenter or to the address space of the VNet. Cannot be changed after the cluster has been
+	// created.
+	SubnetCIDR string `json:"subnetCIDR,omitempty"`
+	// Optional: AllowedIPRanges are the address ranges SSH access to the nodes is allowed from, if
+	// the security group is created by Kubermatic. Defaults to the ones configured in the
+	// datacenter, if unset SSH is allowed from everywhere. Private clusters only allow SSH from
+	// within their VNet. Cannot be changed after the cluster has been created.
+	AllowedIPRanges []string `json:"allowedIPRanges,omitempty"`
+	AvailabilitySet string   `json:"availabilitySet"`
+	// Optional: If set to true, a proximity placement group is created for the cluster and the
+	// availability set is assigned to it, so that all worker nodes are placed physically close to
+	// each other. Only takes effect when the availability set is created by Kubermatic.
+	EnableProximityPlacementGroup bool `json:"enableProximityPlacementGroup,omitempty"`
+	// ProximityPlacementGroupID is the Azure resource ID of the proximity placement group the
+	// cluster's availability set is assigned to.
+	ProximityPlacementGroupID string `json:"proximityPlacementGroupID,omitempty"`
+	// Optional: If set to true, the machines of every MachineDeployment created afterwards are
+	// placed in an availability set of their own instead of the one of the cluster, as an
+	// availability set holds at most 200 VMs. The availability sets are listed in the status of
+	// the cluster and deleted together with it.
+	AvailabilitySetPerMachineDeployment bool `json:"availabilitySetPerMachineDeployment,omitempty"`
+	// Optional: If set to true, no public endpoints are created for the cluster. The API server
+	// is exposed to the nodes via a private endpoint in the cluster's VNet, which requires the
+	// LoadBalancer expose strategy and a datacenter with Private Link configured. Cannot be
+	// changed after the cluster has been created.
+	PrivateCluster bool `json:"privateCluster,omitempty"`
+	// PrivateEndpoint is the name of the private endpoint connecting the cluster's VNet to the API server.
+	PrivateEndpoint string `json:"privateEndpoint,omitempty"`
+	// PrivateEndpointIP is the IP address of the private endpoint within the cluster's subnet.
+	PrivateEndpointIP string `json:"privateEndpointIP,omitempty"`
+	// Optional: If set to true, a private DNS zone is linked to the cluster's VNet, resolving the
+	// API server name and the hostnames of the nodes, which are registered automatically. This
+	// allows clusters in isolated VNets to resolve internal names without further setup. Private
+	// clusters always get a private DNS zone, but nodes are only registered if this is set. Cannot
+	// be changed after the cluster has been created.
+	EnablePrivateDNSZone bool `json:"enablePrivateDNSZone,omitempty"`
+	// PrivateDNSZone is the name of the private DNS zone resolving the API server name within the cluster's VNet.
+	// It is named after the external name of the cluster.
+	PrivateDNSZone string `json:"privateDNSZone,omitempty"`
+	// Optional: DNSServers are the IP addresses of the DNS servers the nodes use, for example to
+	// resolve corporate DNS names. They are only applied to VNets created by Kubermatic; if unset,
+	// the DNS settings of the VNet are left untouched. Running nodes pick up changes once they
+	// renew their DHCP lease or are restarted.
+	DNSServers []string `json:"dnsServers,omitempty"`
+	// Optional: PeerVNets are the resource IDs of hub VNets the cluster's VNet is peered with, in
+	// addition to the ones configured in the datacenter. The hub VNets may live in other resource
+	// groups or subscriptions. If the credentials allow it, the peering back from the hub is
+	// created as well, otherwise it has to be set up by the owner of the hub VNet. Only VNets
+	// created by Kubermatic are peered, VNets provided by the user may be shared with other clusters.
+	PeerVNets []string `json:"peerVNets,omitempty"`
 	// LoadBalancerSKU sets the LB type that will be used for the Azure cluster, possible values are "basic" and "standard", if empty, "basic" will be used
 	LoadBalancerSKU LBSKU `json:"loadBalancerSKU"`
+	// Optional: NetworkPlugin is either "kubenet" or "azure", defaults to "kubenet". With "azure"
+	// (Azure CNI) every node reserves IPs for its pods in the cluster's subnet, which must be
+	// large enough for this, and no route table is created unless routes are configured. Cannot
+	// be changed after the cluster has been created.
+	NetworkPlugin AzureNetworkPlugin `json:"networkPlugin,omitempty"`
+	// Tags are applied to all Azure resources created for the cluster. They are merged with the
+	// tags configured in the datacenter, tags defined here take precedence.
+	Tags map[string]string `json:"tags,omitempty"`
+	// Optional: Routes are added to the route table created by Kubermatic, for example to force
+	// the egress traffic of the nodes through an Azure Firewall or a network virtual appliance.
+	// Other routes in the route table are left untouched.
+	Routes []AzureRoute `json:"routes,omitempty"`
+	// Optional: EgressFirewall routes all egress traffic of the nodes through an existing Azure
+	// Firewall, by adding a default route to its private IP address to the route table created by
+	// Kubermatic. The firewall must be in the cluster's VNet or in a VNet peered with it.
+	EgressFirewall *AzureEgressFirewall `json:"egressFirewall,omitempty"`
+	// Optional: ServiceEndpoints are the Azure services, for example "Microsoft.Storage",
+	// "Microsoft.KeyVault" or "Microsoft.Sql", whose traffic from the nodes is routed over the
+	// Microsoft backbone. They are only applied to subnets created by Kubermatic; if unset, the
+	// service endpoints of the subnet are left untouched.
+	ServiceEndpoints []string `json:"serviceEndpoints,omitempty"`
+	// Optional: PrivateEndpoints are created in the cluster's subnet to reach PaaS resources,
+	// like storage accounts or key vaults, via private IP addresses. Name resolution for them
+	// has to be set up separately, e.g. with private DNS zones linked to the VNet.
+	PrivateEndpoints []AzurePrivateEndpoint `json:"privateEndpoints,omitempty"`
+	// Optional: AdditionalSubnets are created in the cluster's VNet next to the cluster's subnet and
+	// can be selected by MachineDeployments, to separate workloads like frontends, backends or GPU
+	// nodes on the network layer. Each subnet is associated with a security group and the route
+	// table of the cluster. Their address ranges are added to the address space of the VNet if it
+	// does not contain them yet. Subnets created by Kubermatic are deleted once they are removed
+	// from the list, which fails as long as nodes are still placed in them.
+	AdditionalSubnets []AzureSubnet `json:"additionalSubnets,omitempty"`
+	// Optional: EnableBastion provisions an AzureBastionSubnet and a Bastion host with a public IP
+	// in the cluster's VNet, to reach the nodes for debugging without exposing SSH to the internet.
+	// If the VNet has no AzureBastionSubnet yet, 10.1.0.0/26 is added to its address space for it.
+	EnableBastion bool `json:"enableBastion,omitempty"`
+	// Optional: BootDiagnostics enables boot diagnostics for the nodes which request it, storing
+	// their serial console output and screenshots in a storage account.
+	BootDiagnostics *AzureBootDiagnostics `json:"bootDiagnostics,omitempty"`
+	// Optional: LogAnalytics links the cluster to a Log Analytics workspace and deploys the
+	// monitoring agent to the nodes, so that their metrics and logs are available in VM insights.
+	LogAnalytics *AzureLogAnalytics `json:"logAnalytics,omitempty"`
+	// Optional: ApplicationSecurityGroup is the name of an application security group in the
+	// resource group, which is created if it does not exist. The NICs of the nodes are added to it
+	// and the inbound rules of the security group created by Kubermatic target it instead of all
+	// addresses, so that they keep applying when node IPs change. Cannot be changed after the
+	// cluster has been created.
+	ApplicationSecurityGroup string `json:"applicationSecurityGroup,omitempty"`
+	// Optional: SecurityGroupRulePriority is the lowest priority used for the rules Kubermatic adds
+	// to the security group, allowing to reserve the priorities below it for own rules. Must be
+	// between 100 and 3396, defaults to 100. If a priority is already taken by another rule in an
+	// existing security group, the next free one is used.
+	SecurityGroupRulePriority int32 `json:"securityGroupRulePriority,omitempty"`
+	// Optional: DiskEncryptionSetID is the ID of a disk encryption set in the cluster's subscription
+	// and the datacenter's region, which is used to encrypt the OS disks of the nodes with
+	// customer-managed keys. Only applies to nodes created after it has been set.
+	DiskEncryptionSetID string `json:"diskEncryptionSetID,omitempty"`
+	// Optional: KMS configures an Azure Key Vault key to encrypt the secrets of the user cluster
+	// in etcd. Cannot be changed or removed once it has been set.
+	KMS *AzureKMSSettings `json:"kms,omitempty"`
+	// Optional: If set to true, Kubermatic creates a service principal for the cluster, which
+	// only has the Contributor role on the resource group of the cluster, and stores it in the
+	// cluster namespace. The control plane and the nodes use it instead of the credentials of the
+	// cluster, which are still used to create the cloud resources and the service principal and
+	// therefore need permissions to register applications in Azure AD. The VNet has to be in the
+	// resource group of the cluster. Cannot be changed after the cluster has been created.
+	ClusterServicePrincipal bool `json:"clusterServicePrincipal,omitempty"`
+	// Optional: AdoptedResources lists the pre-existing resources referenced above which Kubermatic
+	// takes over, any of "resourceGroup", "vnet", "subnet", "routeTable" and "securityGroup".
+	// Adopted resources are tagged as owned by the cluster, reconciled like the resources created
+	// by Kubermatic and deleted together with the cluster. Adoption fails if a resource is already
+	// tagged as owned by another cluster. Referenced resources which are not listed here are left
+	// untouched. Adoption cannot be undone by removing a resource from the list.
+	AdoptedResources []string `json:"adoptedResources,omitempty"`
+	// Optional: NodeSecurity are the security settings all node deployments of the cluster have
+	// to use. Node deployments which do not enable them are rejected.
+	NodeSecurity *AzureNodeSecurity `json:"nodeSecurity,omitempty"`
+}
+
+// AzureNodeSecurity are the security settings required for the nodes of an Azure cluster.
+type AzureNodeSecurity struct {
+	// Optional: EncryptionAtHost requires the nodes to encrypt their temporary disks and the caches
+	// of their OS and data disks on the VM host. The EncryptionAtHost feature of Microsoft.Compute
+	// must be registered in the subscription of the cluster.
+	EncryptionAtHost bool `json:"encryptionAtHost,omitempty"`
+	// Optional: TrustedLaunch requires the nodes to be trusted launch VMs, which needs Gen2 images.
+	TrustedLaunch bool `json:"trustedLaunch,omitempty"`
+	// Optional: SecureBoot requires Secure Boot to be enabled on the nodes. Requires TrustedLaunch.
+	SecureBoot bool `json:"secureBoot,omitempty"`
+	// Optional: VTPM requires the virtual TPM to be enabled on the nodes. Requires TrustedLaunch.
+	VTPM bool `json:"vTPM,omitempty"`
+}
+
+// AzureKMSSettings configures Azure Key Vault as KMS provider for the encryption at rest of the
+// secrets of a user cluster.
+type AzureKMSSettings struct {
+	// KeyVaultName is the name of an existing key vault in the cluster's subscription. The service
+	// principal of the cluster needs permissions to get, create, encrypt and decrypt its keys.
+	KeyVaultName string `json:"keyVaultName"`
+	// KeyName is the name of the key used for the encryption, an RSA key is created if the key
+	// vault does not contain a key with this name.
+	KeyName string `json:"keyName"`
+	// Optional: KeyVersion is the version of the key, defaults to the current version of the key
+	// when the cluster is reconciled for the first time.
+	KeyVersion string `json:"keyVersion,omitempty"`
+}
+
+// AzureBootDiagnostics configures the storage account the boot diagnostics of the nodes of an
+// Azure cluster are written to.
+type AzureBootDiagnostics struct {
+	// Optional: StorageAccountID is the ID of an existing storage account in the datacenter's
+	// region. If it is empty, Kubermatic creates a storage account in the resource group of the
+	// cluster, which is deleted together with the cluster or once boot diagnostics are disabled.
+	StorageAccountID string `json:"storageAccountID,omitempty"`
+}
+
+// AzureLogAnalytics configures the Log Analytics workspace the monitoring agent on the nodes of
+// an Azure cluster sends its data to.
+type AzureLogAnalytics struct {
+	// Optional: WorkspaceID is the ID of an existing Log Analytics workspace, which may be in
+	// another resource group or subscription. If it is empty, Kubermatic creates a workspace in
+	// the resource group of the cluster, which is deleted together with the cluster or once the
+	// integration is disabled.
+	WorkspaceID string `json:"workspaceID,omitempty"`
+	// Optional: RetentionInDays is the data retention of the workspace created by Kubermatic,
+	// between 30 and 730 days. Defaults to 30 days.
+	RetentionInDays int32 `json:"retentionInDays,omitempty"`
+}
+
+// AzureEgressFirewall is an existing Azure Firewall the egress traffic of the nodes of an Azure
+// cluster is routed through.
+type AzureEgressFirewall struct {
+	// ID is the resource ID of the Azure Firewall. It may live in another resource group or
+	// subscription.
+	ID string `json:"id"`
+	// Optional: If set to true, an application and a network rule collection named after the
+	// cluster are added to the firewall, which allow the nodes to reach the API server of the
+	// cluster, NTP servers and the FQDNs needed to install and run them. This is only possible for
+	// firewalls using classic rules, not for firewalls managed by a Firewall Policy.
+	CreateRules bool `json:"createRules,omitempty"`
+	// Optional: AllowedFQDNs are allowed over HTTP and HTTPS in addition to the FQDNs needed by the
+	// nodes, for example private registries. Only used if CreateRules is set.
+	AllowedFQDNs []string `json:"allowedFQDNs,omitempty"`
+	// Optional: RulePriority is the lowest priority of the rule collections, between 100 and
+	// 65000. The rule collections get the first priority from here on which is not used yet.
+	// Defaults to 1000.
+	RulePriority int32 `json:"rulePriority,omitempty"`
+}
+
+// AzureRoute is a user-defined route in the route table of an Azure cluster.
+type AzureRoute struct {
+	// Name of the route, unique within the cluster.
+	Name string `json:"name"`
+	// AddressPrefix is the destination CIDR the route applies to, for example "0.0.0.0/0".
+	AddressPrefix string `json:"addressPrefix"`
+	// NextHopType is the type of hop the packets are sent to, one of "VirtualNetworkGateway",
+	// "VnetLocal", "Internet", "VirtualAppliance" and "None".
+	NextHopType string `json:"nextHopType"`
+	// NextHopIPAddress is the IP address the packets are forwarded to. It is only allowed, and
+	// required, if the next hop type is "VirtualAppliance".
+	NextHopIPAddress string `json:"nextHopIPAddress,omitempty"`
+}
+
+// AzureSubnet is an additional subnet in the VNet of an Azure cluster.
+type AzureSubnet struct {
+	// Name of the subnet, unique within the VNet.
+	Name string `json:"name"`
+	// CIDR is the address range of the subnet, for example "10.2.0.0/16". It must not overlap
+	// the other subnets of the cluster.
+	CIDR string `json:"cidr"`
+	// Optional: SecurityGroup is the name of an existing security group in the resource group of
+	// the cluster, which is associated with the subnet and assigned to the nodes placed in it.
+	// Defaults to the security group of the cluster.
+	SecurityGroup string `json:"securityGroup,omitempty"`
+}
+
+// AzurePrivateEndpoint is a private endpoint in the subnet of an Azure cluster, connecting to a PaaS resource.
+type AzurePrivateEndpoint struct {
+	// Name of the private endpoint, unique within the cluster.
+	Name string `json:"name"`
+	// PrivateLinkResourceID is the resource ID of the resource the private endpoint connects to,
+	// for example a storage account.
+	PrivateLinkResourceID string `json:"privateLinkResourceID"`
+	// GroupIDs are the sub-resources of the resource the private endpoint connects to, for
+	// example "blob" for a storage account or "vault" for a key vault.
+	GroupIDs []string `json:"groupIDs"`
 }
 
 // VSphereCredentials credentials represents a credential for accessing vSphere
@@ -746,6 +1287,9 @@ type ExtendedClusterHealth struct {
 	UserClusterControllerManager HealthStatus `json:"userClusterControllerManager"`
 	GatekeeperController         HealthStatus `json:"gatekeeperController,omitempty"`
 	GatekeeperAudit              HealthStatus `json:"gatekeeperAudit,omitempty"`
+	LogShipping                  HealthStatus `json:"logShipping,omitempty"`
+	// Nodes is down if one of the nodes of the cluster reports a problem.
+	Nodes HealthStatus `json:"nodes,omitempty"`
 }
 
 // AllHealthy returns if all components are healthy. Gatekeeper components not included as they are optional and not
@@ -802,6 +1346,26 @@ func NewBytes(b64 string) Bytes {
 	return Bytes(bs)
 }
 
+// AdditionalCloud returns the additional cloud of the cluster in the given datacenter, or nil if
+// there is none.
+func (spec *ClusterSpec) AdditionalCloud(datacenter string) *CloudSpec {
+	for i := range spec.AdditionalClouds {
+		if spec.AdditionalClouds[i].DatacenterName == datacenter {
+			return &spec.AdditionalClouds[i]
+		}
+	}
+	return nil
+}
+
+// ForAdditionalCloud returns a copy of the cluster with the given additional cloud in place of
+// its own cloud, so that the nodes of the additional cloud can be handled like the ones of the
+// cluster's cloud. The copy must not be persisted.
+func (cluster *Cluster) ForAdditionalCloud(cloud CloudSpec) *Cluster {
+	c := cluster.DeepCopy()
+	c.Spec.Cloud = *cloud.DeepCopy()
+	return c
+}
+
 func (cluster *Cluster) GetSecretName() string {
 	if cluster.Spec.Cloud.AWS != nil {
 		return fmt.Sprintf("%s-aws-%s", CredentialPrefix, cluster.Name)
@@ -0,0 +1,94 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+const (
+	// ClusterNamespaceResourceName represents "Resource" defined in Kubernetes
+	ClusterNamespaceResourceName = "clusternamespaces"
+
+	// ClusterNamespaceKindName represents "Kind" defined in Kubernetes
+	ClusterNamespaceKindName = "ClusterNamespace"
+
+	// ClusterNamespaceClusterLabelKey is the label holding the name of the cluster a namespace belongs to.
+	ClusterNamespaceClusterLabelKey = "cluster"
+
+	// ClusterNamespaceLabelKey is put on all objects created in a user cluster for a cluster
+	// namespace. Its value is the name of the ClusterNamespace.
+	ClusterNamespaceLabelKey = "kubermatic.io/cluster-namespace"
+)
+
+// ClusterNamespacePhase is the phase of a namespace in a user cluster.
+type ClusterNamespacePhase string
+
+const (
+	// ClusterNamespacePending means that the namespace was not created in the user cluster yet.
+	ClusterNamespacePending ClusterNamespacePhase = "Pending"
+	// ClusterNamespaceReady means that the namespace and all objects of its template were created.
+	ClusterNamespaceReady ClusterNamespacePhase = "Ready"
+	// ClusterNamespaceFailed means that the namespace or its objects could not be created.
+	ClusterNamespaceFailed ClusterNamespacePhase = "Failed"
+)
+
+//+genclient
+//+genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterNamespace is a namespace which a project member created in a user cluster via the API.
+// It keeps a copy of the template which was applied, so it is known who created the namespace
+// and with which quota, even after the template has been changed. ClusterNamespaces live in the
+// seed, are owned by their cluster and removed together with it.
+type ClusterNamespace struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterNamespaceSpec   `json:"spec,omitempty"`
+	Status ClusterNamespaceStatus `json:"status,omitempty"`
+}
+
+// ClusterNamespaceSpec specifies the namespace and how it was created.
+type ClusterNamespaceSpec struct {
+	// ClusterName is the name of the cluster the namespace is created in.
+	ClusterName string `json:"clusterName"`
+	// Namespace is the name of the namespace in the user cluster.
+	Namespace string `json:"namespace"`
+	// Template is the name of the namespace template which was applied.
+	Template string `json:"template,omitempty"`
+	// TemplateSpec is the spec of the template at the time the namespace was created.
+	TemplateSpec NamespaceTemplateSpec `json:"templateSpec,omitempty"`
+	// CreatedBy is the email of the user who created the namespace.
+	CreatedBy string `json:"createdBy,omitempty"`
+}
+
+// ClusterNamespaceStatus reports whether the namespace was created in the user cluster.
+type ClusterNamespaceStatus struct {
+	// Phase is the phase of the namespace.
+	Phase ClusterNamespacePhase `json:"phase,omitempty"`
+	// Message explains the phase, e.g. why the namespace could not be created.
+	Message string `json:"message,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterNamespaceList specifies a list of cluster namespaces
+type ClusterNamespaceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ClusterNamespace `json:"items"`
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+const (
+	// ClusterSpecRevisionResourceName represents "Resource" defined in Kubernetes
+	ClusterSpecRevisionResourceName = "clusterspecrevisions"
+
+	// ClusterSpecRevisionKindName represents "Kind" defined in Kubernetes
+	ClusterSpecRevisionKindName = "ClusterSpecRevision"
+
+	// ClusterSpecRevisionClusterLabelKey is the label holding the name of the cluster a revision belongs to.
+	ClusterSpecRevisionClusterLabelKey = "cluster"
+)
+
+//+genclient
+//+genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterSpecRevision records a single change of a cluster's spec. Revisions are
+// owned by their cluster and removed together with it.
+type ClusterSpecRevision struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ClusterSpecRevisionSpec `json:"spec,omitempty"`
+}
+
+// ClusterSpecRevisionSpec specifies who changed the cluster spec, when and how.
+type ClusterSpecRevisionSpec struct {
+	// ClusterName is the name of the cluster this revision belongs to.
+	ClusterName string `json:"clusterName"`
+	// Revision is the sequence number of the change, starting at 1.
+	Revision int64 `json:"revision"`
+	// Author is the email of the user who made the change.
+	Author string `json:"author,omitempty"`
+	// Timestamp is the time when the change was made.
+	Timestamp metav1.Time `json:"timestamp"`
+	// Diff is the JSON merge patch which turns the spec of the previous revision
+	// into this one. It is empty for the first revision.
+	Diff string `json:"diff,omitempty"`
+	// ClusterSpec is the complete cluster spec after the change.
+	ClusterSpec ClusterSpec `json:"clusterSpec"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterSpecRevisionList specifies a list of cluster spec revisions
+type ClusterSpecRevisionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ClusterSpecRevision `json:"items"`
+}
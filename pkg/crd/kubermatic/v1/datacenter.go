@@ -17,12 +17,28 @@ limitations under the License.
 package v1
 
 import (
+	"fmt"
+
 	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// RegistryCacheMetricsPortOffset is added to the port of a cached registry to get the
+// port of the Prometheus metrics endpoint of its cache.
+const RegistryCacheMetricsPortOffset = 1000
+
+// DefaultRegistryCacheRegistries are the upstream registries cached by the registry
+// cache if none are configured explicitly.
+var DefaultRegistryCacheRegistries = []RegistryCacheRegistry{
+	{Upstream: "docker.io", Port: 5000},
+	{Upstream: "quay.io", Port: 5001},
+	{Upstream: "gcr.io", Port: 5002},
+	{Upstream: "k8s.gcr.io", Port: 5003},
+}
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // SeedDatacenterList is the type representing a SeedDatacenterList
@@ -45,7 +61,8 @@ type Seed struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec SeedSpec `json:"spec"`
+	Spec   SeedSpec   `json:"spec"`
+	Status SeedStatus `json:"status,omitempty"`
 }
 
 func (s *Seed) SetDefaults() {
@@ -91,6 +108,111 @@ type SeedSpec struct {
 	ExposeStrategy ExposeStrategy `json:"expose_strategy,omitempty"`
 	// Optional: MLA allows configuring seed level MLA (Monitoring, Logging & Alerting) stack settings.
 	MLA *SeedMLASettings `json:"mla,omitempty"`
+	// Optional: RegistryCache can be used to deploy a pull-through registry cache into the seed
+	// cluster. If configured, images of user cluster components are pulled through the cache.
+	RegistryCache *RegistryCacheConfig `json:"registry_cache,omitempty"`
+	// Optional: ControlPlaneDensity configures how many user cluster control planes a single
+	// node of the seed cluster should host. The number of control planes per node is always
+	// exposed as metric by the seed-controller-manager.
+	ControlPlaneDensity *ControlPlaneDensitySettings `json:"control_plane_density,omitempty"`
+	// Optional: AzureRateLimits limits the rate of the requests the seed-controller-manager sends
+	// to the Azure Resource Manager API, to stay below the throttling limits of the subscriptions
+	// when many clusters are reconciled at the same time.
+	AzureRateLimits *AzureRateLimitSettings `json:"azure_rate_limits,omitempty"`
+	// Optional: AzureProxy configures the HTTP proxy the seed-controller-manager uses for the
+	// requests to the Azure API, for seeds which can reach it only through a corporate proxy.
+	// If not set, the HTTPS_PROXY and NO_PROXY environment variables are honoured.
+	AzureProxy *AzureProxySettings `json:"azure_proxy,omitempty"`
+}
+
+// SeedStatus stores status information about a seed.
+type SeedStatus struct {
+	// Conditions contains conditions the seed is in, its primary use case is status signaling
+	// between the Kubermatic Operator and the administrators.
+	Conditions []SeedCondition `json:"conditions,omitempty"`
+}
+
+// SeedConditionType is used to indicate the type of a seed condition. For all condition types,
+// the `true` value must indicate success.
+type SeedConditionType string
+
+const (
+	// SeedConditionComponentsInSync indicates that the components the Kubermatic Operator deploys
+	// into the seed cluster have not been changed manually.
+	SeedConditionComponentsInSync SeedConditionType = "ComponentsInSync"
+
+	// ReasonSeedComponentsDrifted is used if components were changed manually and the changes
+	// are kept, because the Kubermatic Operator is not configured to revert them.
+	ReasonSeedComponentsDrifted = "ComponentsDrifted"
+	// ReasonSeedComponentsDriftReverted is used if manual changes to components were reverted.
+	ReasonSeedComponentsDriftReverted = "ComponentsDriftReverted"
+
+	// SeedConditionPresetsSynced indicates that all Presets were synced from the master into the seed.
+	SeedConditionPresetsSynced SeedConditionType = "PresetsSynced"
+	// SeedConditionAddonConfigsSynced indicates that all AddonConfigs were synced from the master into the seed.
+	SeedConditionAddonConfigsSynced SeedConditionType = "AddonConfigsSynced"
+	// SeedConditionConstraintTemplatesSynced indicates that all ConstraintTemplates were synced from the
+	// master into the seed.
+	SeedConditionConstraintTemplatesSynced SeedConditionType = "ConstraintTemplatesSynced"
+	// SeedConditionVersionsSynced indicates that the Kubernetes versions and updates of all
+	// KubermaticConfigurations were synced from the master into the seed.
+	SeedConditionVersionsSynced SeedConditionType = "VersionsSynced"
+
+	// ReasonSeedResourcesSyncFailed is used if resources could not be synced into the seed.
+	ReasonSeedResourcesSyncFailed = "SyncFailed"
+)
+
+type SeedCondition struct {
+	// Type of seed condition.
+	Type SeedConditionType `json:"type"`
+	// Status of the condition, one of True, False, Unknown.
+	Status corev1.ConditionStatus `json:"status"`
+	// Last time we got an update on a given condition.
+	// +optional
+	LastHeartbeatTime metav1.Time `json:"lastHeartbeatTime,omitempty"`
+	// Last time the condition transit from one status to another.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// (brief) reason for the condition's last transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Human readable message indicating details about last transition.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ControlPlaneDensitySettings configures how many user cluster control planes a seed node should host.
+type ControlPlaneDensitySettings struct {
+	// MaxControlPlanesPerNode is the number of user cluster control planes with pods on a seed node
+	// above which the node is considered overloaded. Overloaded nodes get a warning event, and the
+	// number of nodes needed to stay below the threshold is exposed as metric.
+	MaxControlPlanesPerNode int `json:"max_control_planes_per_node"`
+	// Optional: If set to true, pods of the control planes on the most overloaded node are evicted
+	// one at a time, honouring their PodDisruptionBudgets, so that the scheduler places them on
+	// nodes with fewer control planes. Only pods of Deployments are evicted, and only while a
+	// schedulable node below the threshold exists.
+	Rebalance bool `json:"rebalance,omitempty"`
+}
+
+// AzureRateLimitSettings configures a token bucket rate limiter for the requests to the Azure API.
+// Every subscription has its own bucket, shared by all clusters using it.
+type AzureRateLimitSettings struct {
+	// QPS is the number of requests per second sent to the API of a subscription.
+	QPS float32 `json:"qps"`
+	// Burst is the number of requests which can be sent at once before the QPS limit applies.
+	Burst int `json:"burst"`
+}
+
+// AzureProxySettings configures the HTTP proxy for the requests to the Azure API.
+type AzureProxySettings struct {
+	// URL of the proxy, e.g. http://proxy.example.com:3128. Requests to the API are tunneled
+	// through the proxy using CONNECT.
+	URL string `json:"url"`
+	// Optional: NoProxy is a comma-separated list of hosts and domains which are accessed directly.
+	NoProxy string `json:"no_proxy,omitempty"`
+	// Optional: CredentialsReference references a Secret with the `username` and `password` keys,
+	// which are used to authenticate against the proxy.
+	CredentialsReference *providerconfig.GlobalSecretKeySelector `json:"credentials_reference,omitempty"`
 }
 
 type NodeportProxyConfig struct {
@@ -117,6 +239,72 @@ type NodeportProxyComponent struct {
 	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
 }
 
+// RegistryCacheConfig configures the seed-local pull-through registry cache. Every
+// upstream registry is served over TLS on its own port of a shared LoadBalancer service.
+type RegistryCacheConfig struct {
+	// Endpoint is the hostname (without port) under which the LoadBalancer service of the
+	// cache is reachable from the seed and user cluster nodes.
+	Endpoint string `json:"endpoint"`
+	// TLSSecretName is the name of a Secret of type kubernetes.io/tls in the KKP namespace
+	// of the seed cluster, holding the serving certificate of the cache for the endpoint.
+	// The certificate must be trusted by the container runtime of all nodes pulling
+	// through the cache.
+	TLSSecretName string `json:"tls_secret_name"`
+	// Optional: Registries is the list of upstream registries to cache, each served on
+	// its own port. Defaults to docker.io on port 5000, quay.io on 5001, gcr.io on 5002 and
+	// k8s.gcr.io on 5003.
+	Registries []RegistryCacheRegistry `json:"registries,omitempty"`
+	// Optional: ExcludedImages are image repositories without tag, like
+	// quay.io/kubermatic/machine-controller, that are always pulled from their upstream
+	// registry instead of the cache.
+	ExcludedImages []string `json:"excluded_images,omitempty"`
+	// Optional: StorageClassName is the storage class of the volumes holding the cached
+	// images of each registry. The volumes are shared by all replicas of the cache, so the
+	// storage class must support the ReadWriteMany access mode. Defaults to the default
+	// storage class of the seed cluster.
+	StorageClassName *string `json:"storage_class_name,omitempty"`
+	// Optional: StorageSize is the size of the volume holding the cached images of
+	// each registry. Defaults to 20Gi.
+	StorageSize *resource.Quantity `json:"storage_size,omitempty"`
+	// Annotations are used to further tweak the LoadBalancer integration with the
+	// cloud provider where the seed cluster is running.
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// DockerRepository is the repository containing the registry image.
+	DockerRepository string `json:"docker_repository,omitempty"`
+	// Resources describes the requested and maximum allowed CPU/memory usage of the
+	// cache for each registry.
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// RegistryCacheRegistry is an upstream registry cached by the registry cache.
+type RegistryCacheRegistry struct {
+	// Upstream is the registry to cache, like quay.io.
+	Upstream string `json:"upstream"`
+	// Port is the port the cache of the registry is served on. It is part of the image
+	// references of the cached images and thus must not be changed while the cache is used.
+	Port int32 `json:"port"`
+}
+
+// UpstreamRegistries returns the registries that are cached, falling back to
+// DefaultRegistryCacheRegistries if none are configured.
+func (c *RegistryCacheConfig) UpstreamRegistries() []RegistryCacheRegistry {
+	if len(c.Registries) == 0 {
+		return DefaultRegistryCacheRegistries
+	}
+	return c.Registries
+}
+
+// Mirror returns the address of the cache for the given upstream registry, or an
+// empty string if the registry is not cached.
+func (c *RegistryCacheConfig) Mirror(registry string) string {
+	for _, upstream := range c.UpstreamRegistries() {
+		if upstream.Upstream == registry {
+			return fmt.Sprintf("%s:%d", c.Endpoint, upstream.Port)
+		}
+	}
+	return ""
+}
+
 type Datacenter struct {
 	// Optional: Country of the seed as ISO-3166 two-letter code, e.g. DE or UK.
 	// For informational purposes in the Kubermatic dashboard only.
@@ -169,6 +357,25 @@ type DatacenterSpec struct {
 	// EnforcePodSecurityPolicy enforces pod security policy plugin on every clusters within the DC,
 	// ignoring cluster-specific settings
 	EnforcePodSecurityPolicy bool `json:"enforcePodSecurityPolicy,omitempty"`
+
+	// Optional: CapacityLimits are hard limits for the number of clusters and nodes in the DC,
+	// enforced when clusters and node deployments are created or scaled.
+	CapacityLimits *DatacenterCapacityLimits `json:"capacityLimits,omitempty"`
+
+	// Optional: MachineFlavorFilter restricts the machine flavors of the datacenter further than
+	// the filter of its cloud provider in the global settings. Allowed flavors and categories
+	// replace the ones of the provider if set, denied flavors are added to them.
+	MachineFlavorFilter *MachineFlavorFilter `json:"machineFlavorFilter,omitempty"`
+}
+
+// DatacenterCapacityLimits limits the number of clusters and nodes in a datacenter, so that it
+// and the quota of its cloud account cannot be oversubscribed. A limit of 0 means unlimited.
+type DatacenterCapacityLimits struct {
+	// Optional: MaxClusters is the maximum number of clusters in the datacenter.
+	MaxClusters int `json:"maxClusters,omitempty"`
+	// Optional: MaxNodes is the maximum number of nodes in the datacenter, counted as the sum
+	// of the replicas of all node deployments of its clusters.
+	MaxNodes int `json:"maxNodes,omitempty"`
 }
 
 // ImageList defines a map of operating system and the image to use
@@ -236,7 +443,143 @@ type OpenstackNodeSizeRequirements struct {
 type DatacenterSpecAzure struct {
 	// Region to use, for example "westeurope". A list of available regions can be
 	// found at https://azure.microsoft.com/en-us/global-infrastructure/locations/
+	// The locations available to a subscription are listed by the /api/v2/providers/azure/locations endpoint.
 	Location string `json:"location"`
+	// Optional: Tags are applied to all Azure resources created for clusters in this datacenter,
+	// for example to satisfy cost-allocation policies.
+	Tags map[string]string `json:"tags,omitempty"`
+	// Optional: If set to true, a CanNotDelete management lock is placed on the resource groups
+	// created by Kubermatic, protecting the cluster infrastructure from accidental deletion. The
+	// lock is removed as soon as the cluster is deleted, before its nodes and load balancers are
+	// torn down.
+	LockResourceGroup bool `json:"lockResourceGroup,omitempty"`
+	// Optional: PrivateLink must be configured to allow private clusters in this datacenter. The
+	// seed cluster must run on Azure, its cloud provider creates a Private Link service for the
+	// API server of every private cluster.
+	PrivateLink *AzurePrivateLinkSettings `json:"privateLink,omitempty"`
+	// Optional: PeerVNets are the resource IDs of hub VNets the VNet of every cluster in this
+	// datacenter is peered with, for example to reach shared services or on-premises networks.
+	// Only VNets created by Kubermatic are peered.
+	PeerVNets []string `json:"peerVNets,omitempty"`
+	// Optional: DDoSProtectionPlanID is the resource ID of an existing DDoS Protection Plan, which
+	// is associated with every VNet created by Kubermatic in this datacenter. The plan may live in
+	// another resource group or subscription. Removing it does not disassociate the plan from
+	// existing VNets.
+	DDoSProtectionPlanID string `json:"ddosProtectionPlanID,omitempty"`
+	// Optional: FaultDomainCount is the number of fault domains of the availability sets created
+	// by Kubermatic, between 1 and 3. It must not exceed the maximum of the region and defaults to
+	// it. Only applies to availability sets created after it has been changed.
+	FaultDomainCount *int32 `json:"faultDomainCount,omitempty"`
+	// Optional: UpdateDomainCount is the number of update domains of the availability sets created
+	// by Kubermatic, between 1 and 20, defaults to 20. Only applies to availability sets created
+	// after it has been changed.
+	UpdateDomainCount *int32 `json:"updateDomainCount,omitempty"`
+	// Optional: If set to true, the terms of the marketplace plans of node images are accepted
+	// with the credentials of the cluster when they have not been accepted in its subscription
+	// yet. Otherwise node deployments using such images are rejected until the terms have been
+	// accepted manually.
+	AcceptMarketplaceTerms bool `json:"acceptMarketplaceTerms,omitempty"`
+	// Optional: Environment is the name of the Azure cloud the location belongs to, one of
+	// "AzurePublicCloud", "AzureUSGovernmentCloud" or "AzureChinaCloud". The API uses the
+	// endpoints of this cloud for listing sizes, zones and network resources. Defaults to
+	// "AzurePublicCloud".
+	Environment string `json:"environment,omitempty"`
+	// Optional: LoadBalancerSKU is the load balancer SKU of new clusters which do not set one,
+	// either "basic" or "standard". Defaults to "basic".
+	LoadBalancerSKU LBSKU `json:"loadBalancerSKU,omitempty"`
+	// Optional: VNetCIDR is the address space of the VNets created for new clusters which do not
+	// set one. Defaults to 10.0.0.0/16.
+	VNetCIDR string `json:"vnetCIDR,omitempty"`
+	// Optional: SubnetCIDR is the address range of the subnets created for new clusters which do
+	// not set one. Defaults to the address space of the VNet.
+	SubnetCIDR string `json:"subnetCIDR,omitempty"`
+	// Optional: AllowedIPRanges are the address ranges SSH access to the nodes of new clusters is
+	// allowed from, unless the cluster sets its own.
+	AllowedIPRanges []string `json:"allowedIPRanges,omitempty"`
+	// Optional: NodeDefaults are the settings of the nodes created via the API which do not set
+	// them, so that nodes are created with consistent, cost-controlled defaults.
+	NodeDefaults *AzureNodeDefaults `json:"nodeDefaults,omitempty"`
+	// Optional: Cleanup limits how long the deletion of the Azure resources of a cluster may take
+	// before it is reported as stuck.
+	Cleanup *AzureCleanupSettings `json:"cleanup,omitempty"`
+	// Optional: NamingTemplate is a Go template for the names of the resource groups, VNets,
+	// subnets, security groups, route tables and availability sets created for clusters, for
+	// example "{{ .ResourceType }}-{{ .ProjectID }}-{{ .ClusterName }}". The variables are
+	// ClusterName, ProjectID, Datacenter and ResourceType, one of "rg", "vnet", "snet", "nsg",
+	// "rt" and "avail". Only applies to resources created after it has been changed. Defaults to
+	// "kubernetes-{{ .ClusterName }}".
+	NamingTemplate string `json:"namingTemplate,omitempty"`
+}
+
+// AzureCleanupSettings limit the deletion of the Azure resources of clusters. A step of the
+// deletion, for example the deletion of the security group, is retried until it succeeds. Once a
+// step has used up its attempts, it is reported as stuck and, if the cluster is annotated with
+// "azure.kubermatic.io/force-cleanup", skipped, leaving its resources behind.
+type AzureCleanupSettings struct {
+	// Optional: StepTimeout is the time a single attempt of a step may take, including waiting for
+	// Azure to finish the deletion. Defaults to 20m.
+	StepTimeout *metav1.Duration `json:"stepTimeout,omitempty"`
+	// Optional: MaxAttempts is the number of failed attempts of a step after which it is reported
+	// as stuck. Deletions which are still running in Azure are not counted. Defaults to 10.
+	MaxAttempts int `json:"maxAttempts,omitempty"`
+}
+
+// AzureNodeDefaults are the defaults of the node deployments of an Azure datacenter.
+type AzureNodeDefaults struct {
+	// Optional: VMSize is the size of the VMs, for example "Standard_D2s_v3".
+	VMSize string `json:"vmSize,omitempty"`
+	// Optional: OSDiskType is the storage account type of the OS disks, one of "Standard_LRS",
+	// "StandardSSD_LRS" or "Premium_LRS".
+	OSDiskType string `json:"osDiskType,omitempty"`
+	// Optional: OSDiskSize is the size of the OS disks in GB.
+	OSDiskSize int32 `json:"osDiskSize,omitempty"`
+	// Optional: Images are the images of the nodes per operating system. They take precedence
+	// over the default images of the operating systems, including their Gen2 variants.
+	Images map[providerconfig.OperatingSystem]AzureNodeImage `json:"images,omitempty"`
+}
+
+// AzureNodeImage is the image of Azure nodes, either an image ID or a marketplace image.
+type AzureNodeImage struct {
+	// Optional: ID of a managed image or of an image definition or version of a Shared Image
+	// Gallery.
+	ID string `json:"id,omitempty"`
+	// Optional: Reference to a marketplace image. It cannot be combined with an image ID.
+	Reference *AzureNodeImageReference `json:"reference,omitempty"`
+	// Optional: Plan is the marketplace plan of the image, which is required for images of offers
+	// whose terms must be accepted.
+	Plan *AzureNodeImagePlan `json:"plan,omitempty"`
+}
+
+// AzureNodeImageReference references an image of the Azure marketplace.
+type AzureNodeImageReference struct {
+	// Publisher of the image, for example "Canonical".
+	Publisher string `json:"publisher"`
+	// Offer of the image, for example "UbuntuServer".
+	Offer string `json:"offer"`
+	// SKU of the image, for example "18_04-lts-gen2".
+	SKU string `json:"sku"`
+	// Optional: Version of the image, defaults to "latest".
+	Version string `json:"version,omitempty"`
+}
+
+// AzureNodeImagePlan is the marketplace plan of an Azure image.
+type AzureNodeImagePlan struct {
+	// Name of the plan, usually the SKU of the image.
+	Name string `json:"name"`
+	// Publisher of the plan.
+	Publisher string `json:"publisher"`
+	// Product of the plan, usually the offer of the image.
+	Product string `json:"product"`
+}
+
+// AzurePrivateLinkSettings describes where the Private Link services for the API servers
+// of private clusters are created.
+type AzurePrivateLinkSettings struct {
+	// SubscriptionID is the ID of the subscription the seed cluster runs in.
+	SubscriptionID string `json:"subscriptionID"`
+	// ResourceGroup is the resource group in which the seed's cloud provider creates
+	// its load balancers and Private Link services.
+	ResourceGroup string `json:"resourceGroup"`
 }
 
 // DatacenterSpecVSphere describes a vSphere datacenter
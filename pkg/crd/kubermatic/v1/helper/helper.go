@@ -132,6 +132,55 @@ func SetClusterCondition(
 	})
 }
 
+// SetSeedCondition sets a condition on the given seed using the provided type, status,
+// reason and message. The timestamps are only updated if the condition changed.
+func SetSeedCondition(
+	s *kubermaticv1.Seed,
+	conditionType kubermaticv1.SeedConditionType,
+	status corev1.ConditionStatus,
+	reason string,
+	message string,
+) {
+	newCondition := kubermaticv1.SeedCondition{
+		Type:    conditionType,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	}
+
+	pos := -1
+	for i, condition := range s.Status.Conditions {
+		if condition.Type == conditionType {
+			pos = i
+			break
+		}
+	}
+
+	if pos >= 0 {
+		oldCondition := s.Status.Conditions[pos]
+		if oldCondition.Status == status && oldCondition.Reason == reason && oldCondition.Message == message {
+			return
+		}
+		newCondition.LastTransitionTime = oldCondition.LastTransitionTime
+		if oldCondition.Status != status {
+			newCondition.LastTransitionTime = metav1.Now()
+		}
+	} else {
+		newCondition.LastTransitionTime = metav1.Now()
+	}
+	newCondition.LastHeartbeatTime = metav1.Now()
+
+	if pos >= 0 {
+		s.Status.Conditions[pos] = newCondition
+	} else {
+		s.Status.Conditions = append(s.Status.Conditions, newCondition)
+	}
+	// Has to be sorted, otherwise we may end up creating patches that just re-arrange them.
+	sort.SliceStable(s.Status.Conditions, func(i, j int) bool {
+		return s.Status.Conditions[i].Type < s.Status.Conditions[j].Type
+	})
+}
+
 // ClusterReconciliationSuccessful checks if cluster has all conditions that are
 // required for it to be healthy. ignoreKubermaticVersion should only be set in tests.
 func ClusterReconciliationSuccessful(cluster *kubermaticv1.Cluster, versions kubermatic.Versions, ignoreKubermaticVersion bool) (missingConditions []kubermaticv1.ClusterConditionType, success bool) {
@@ -0,0 +1,56 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"context"
+	"reflect"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+
+	"k8s.io/client-go/util/retry"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// UpdateCluster applies modify to the cluster and persists the result with a JSON merge
+// patch. The patch is guarded by the resourceVersion of the cluster, so changes made by
+// other controllers in the meantime, like added finalizers, are never overwritten. On a
+// conflict the latest version of the cluster is fetched and modify is applied again,
+// modify must therefore only depend on the cluster it is given.
+// The passed cluster is updated in place to the persisted state.
+func UpdateCluster(ctx context.Context, client ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster, modify func(*kubermaticv1.Cluster)) error {
+	refetch := false
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if refetch {
+			current := &kubermaticv1.Cluster{}
+			if err := client.Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(cluster), current); err != nil {
+				return err
+			}
+			*cluster = *current
+		}
+		refetch = true
+
+		oldCluster := cluster.DeepCopy()
+		modify(cluster)
+		if reflect.DeepEqual(oldCluster, cluster) {
+			return nil
+		}
+
+		return client.Patch(ctx, cluster, ctrlruntimeclient.MergeFromWithOptions(oldCluster, ctrlruntimeclient.MergeFromWithOptimisticLock{}))
+	})
+}
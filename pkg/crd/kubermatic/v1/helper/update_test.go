@@ -0,0 +1,152 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestUpdateClusterWithStaleCopies(t *testing.T) {
+	ctx := context.Background()
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "test",
+			Finalizers: []string{"existing"},
+		},
+	}
+	client := fakectrlruntimeclient.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(cluster).Build()
+
+	// both controllers work on the same version of the cluster
+	first := &kubermaticv1.Cluster{}
+	if err := client.Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(cluster), first); err != nil {
+		t.Fatalf("failed to get cluster: %v", err)
+	}
+	second := first.DeepCopy()
+
+	if err := UpdateCluster(ctx, client, first, func(c *kubermaticv1.Cluster) {
+		kuberneteshelper.AddFinalizer(c, "first")
+	}); err != nil {
+		t.Fatalf("failed to add first finalizer: %v", err)
+	}
+
+	if err := UpdateCluster(ctx, client, second, func(c *kubermaticv1.Cluster) {
+		kuberneteshelper.RemoveFinalizer(c, "existing")
+		kuberneteshelper.AddFinalizer(c, "second")
+	}); err != nil {
+		t.Fatalf("failed to add second finalizer: %v", err)
+	}
+
+	if expected := sets.NewString("first", "second"); !sets.NewString(second.Finalizers...).Equal(expected) {
+		t.Errorf("expected the passed cluster to be updated to finalizers %v, got %v", expected.List(), second.Finalizers)
+	}
+
+	updated := &kubermaticv1.Cluster{}
+	if err := client.Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(cluster), updated); err != nil {
+		t.Fatalf("failed to get cluster: %v", err)
+	}
+	if expected := sets.NewString("first", "second"); !sets.NewString(updated.Finalizers...).Equal(expected) {
+		t.Errorf("expected finalizers %v, got %v", expected.List(), updated.Finalizers)
+	}
+}
+
+func TestUpdateClusterConcurrently(t *testing.T) {
+	const controllers = 10
+
+	ctx := context.Background()
+	cluster := &kubermaticv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test"}}
+	client := fakectrlruntimeclient.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(cluster).Build()
+
+	expected := sets.NewString()
+	errs := make(chan error, controllers)
+	wg := sync.WaitGroup{}
+	for i := 0; i < controllers; i++ {
+		finalizer := fmt.Sprintf("controller-%d", i)
+		expected.Insert(finalizer)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			c := &kubermaticv1.Cluster{}
+			if err := client.Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(cluster), c); err != nil {
+				errs <- err
+				return
+			}
+			errs <- UpdateCluster(ctx, client, c, func(c *kubermaticv1.Cluster) {
+				kuberneteshelper.AddFinalizer(c, finalizer)
+			})
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("failed to add finalizer: %v", err)
+		}
+	}
+
+	updated := &kubermaticv1.Cluster{}
+	if err := client.Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(cluster), updated); err != nil {
+		t.Fatalf("failed to get cluster: %v", err)
+	}
+	if !sets.NewString(updated.Finalizers...).Equal(expected) {
+		t.Errorf("expected finalizers %v, got %v", expected.List(), updated.Finalizers)
+	}
+}
+
+func TestUpdateClusterWithoutChanges(t *testing.T) {
+	ctx := context.Background()
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "test",
+			Finalizers: []string{"existing"},
+		},
+	}
+	client := fakectrlruntimeclient.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(cluster).Build()
+
+	current := &kubermaticv1.Cluster{}
+	if err := client.Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(cluster), current); err != nil {
+		t.Fatalf("failed to get cluster: %v", err)
+	}
+	resourceVersion := current.ResourceVersion
+
+	if err := UpdateCluster(ctx, client, current, func(c *kubermaticv1.Cluster) {
+		kuberneteshelper.AddFinalizer(c, "existing")
+	}); err != nil {
+		t.Fatalf("failed to update cluster: %v", err)
+	}
+
+	if err := client.Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(cluster), current); err != nil {
+		t.Fatalf("failed to get cluster: %v", err)
+	}
+	if current.ResourceVersion != resourceVersion {
+		t.Errorf("expected no update of the cluster, but the resource version changed from %s to %s", resourceVersion, current.ResourceVersion)
+	}
+}
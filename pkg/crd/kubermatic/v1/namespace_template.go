@@ -0,0 +1,81 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// NamespaceTemplateResourceName represents "Resource" defined in Kubernetes
+	NamespaceTemplateResourceName = "namespacetemplates"
+
+	// NamespaceTemplateKindName represents "Kind" defined in Kubernetes
+	NamespaceTemplateKindName = "NamespaceTemplate"
+)
+
+//+genclient
+//+genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NamespaceTemplate is defined by admins and describes the objects which are created together
+// with a namespace that project members request for their user clusters.
+type NamespaceTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec NamespaceTemplateSpec `json:"spec,omitempty"`
+}
+
+// NamespaceTemplateSpec specifies the objects created in a namespace.
+type NamespaceTemplateSpec struct {
+	// Description tells users what the template is meant for.
+	Description string `json:"description,omitempty"`
+	// Labels are put on the namespace, e.g. to select it in network policies.
+	Labels map[string]string `json:"labels,omitempty"`
+	// ResourceQuota is the quota of the namespace.
+	ResourceQuota *corev1.ResourceQuotaSpec `json:"resourceQuota,omitempty"`
+	// LimitRange sets the default and maximum resources of the containers in the namespace.
+	LimitRange *corev1.LimitRangeSpec `json:"limitRange,omitempty"`
+	// NetworkPolicy is the network policy of the namespace.
+	NetworkPolicy *networkingv1.NetworkPolicySpec `json:"networkPolicy,omitempty"`
+	// RoleBindings bind cluster roles to groups of project members inside the namespace.
+	RoleBindings []NamespaceRoleBinding `json:"roleBindings,omitempty"`
+}
+
+// NamespaceRoleBinding binds a cluster role to groups inside a namespace.
+type NamespaceRoleBinding struct {
+	// Name is the name of the role binding.
+	Name string `json:"name"`
+	// ClusterRole is the name of the cluster role to bind, e.g. "edit".
+	ClusterRole string `json:"clusterRole"`
+	// Groups are the groups of project members in the user cluster, i.e. "owners",
+	// "editors" and "viewers".
+	Groups []string `json:"groups"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NamespaceTemplateList specifies a list of namespace templates
+type NamespaceTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []NamespaceTemplate `json:"items"`
+}
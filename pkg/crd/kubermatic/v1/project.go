@@ -17,6 +17,9 @@ limitations under the License.
 package v1
 
 import (
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -56,11 +59,108 @@ type Project struct {
 // ProjectSpec is a specification of a project.
 type ProjectSpec struct {
 	Name string `json:"name"`
+	// Optional: PreDeleteHooks are Jobs run in the user clusters of the project when they are
+	// deleted, e.g. to deprovision external resources created by operators in the cluster. The
+	// nodes and cloud resources of a cluster are only removed once all hooks have completed,
+	// failed or timed out.
+	PreDeleteHooks []ClusterPreDeleteHook `json:"preDeleteHooks,omitempty"`
+	// Optional: DefaultPresets maps cloud provider names (e.g. "azure") to the name of the preset
+	// whose credentials are used for clusters of the project that are created without explicit
+	// credentials. Admins can forbid this inheritance via the global settings.
+	DefaultPresets map[string]string `json:"defaultPresets,omitempty"`
+}
+
+// DefaultPreDeleteHookTimeout is the time a cluster waits for its pre-delete hooks to finish if
+// they configure no timeout.
+const DefaultPreDeleteHookTimeout = 10 * time.Minute
+
+// ClusterPreDeleteHook is a Job run in a user cluster when the cluster is deleted.
+type ClusterPreDeleteHook struct {
+	// Name of the hook. The Job is created as "pre-delete-<name>" in the kube-system namespace
+	// of the user cluster.
+	Name string `json:"name"`
+	// Job is the spec of the Job. Service accounts used by it must exist in the kube-system
+	// namespace of the user cluster.
+	Job batchv1.JobSpec `json:"job"`
+	// Optional: Timeout is the time after the deletion of the cluster has been requested after
+	// which the deletion continues, even if the Job has not finished yet. Defaults to 10 minutes.
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
 }
 
 // ProjectStatus represents the current status of a project.
 type ProjectStatus struct {
 	Phase string `json:"phase"`
+	// Deletion tracks the teardown of the resources of the project once it is deleted.
+	Deletion *ProjectDeletionStatus `json:"deletion,omitempty"`
+}
+
+// ProjectDeletionStage is a stage of the teardown of a project. The resources of a stage are only
+// deleted once all resources of the previous stages are gone.
+type ProjectDeletionStage string
+
+const (
+	ProjectDeletionStageBackups         ProjectDeletionStage = "Backups"
+	ProjectDeletionStageClusters        ProjectDeletionStage = "Clusters"
+	ProjectDeletionStageServiceAccounts ProjectDeletionStage = "ServiceAccounts"
+	ProjectDeletionStageSSHKeys         ProjectDeletionStage = "SSHKeys"
+	ProjectDeletionStageCompleted       ProjectDeletionStage = "Completed"
+)
+
+// ProjectDeletionStages are the stages of the teardown of a project in the order they run in.
+var ProjectDeletionStages = []ProjectDeletionStage{
+	ProjectDeletionStageBackups,
+	ProjectDeletionStageClusters,
+	ProjectDeletionStageServiceAccounts,
+	ProjectDeletionStageSSHKeys,
+}
+
+// These are the kinds of the resources which are deleted together with a project.
+const (
+	ProjectDeletionKindEtcdBackupConfig = EtcdBackupConfigKindName
+	ProjectDeletionKindCluster          = ClusterKindName
+	ProjectDeletionKindServiceAccount   = "ServiceAccount"
+	ProjectDeletionKindSSHKey           = "SSHKey"
+)
+
+// ProjectDeletionResourcePhase is the progress of the deletion of a single resource of a project.
+type ProjectDeletionResourcePhase string
+
+const (
+	// ProjectDeletionResourcePending means that the stage of the resource has not started yet.
+	ProjectDeletionResourcePending ProjectDeletionResourcePhase = "Pending"
+	// ProjectDeletionResourceDeleting means that the resource has been deleted, but still exists
+	// because its own cleanup has not finished yet.
+	ProjectDeletionResourceDeleting ProjectDeletionResourcePhase = "Deleting"
+	// ProjectDeletionResourceDeleted means that the resource is gone.
+	ProjectDeletionResourceDeleted ProjectDeletionResourcePhase = "Deleted"
+)
+
+// ProjectDeletionStatus is the progress of the teardown of a project. It is kept in the status of
+// the project, so that the teardown continues where it stopped if the controller is restarted.
+type ProjectDeletionStatus struct {
+	// Stage is the stage whose resources are currently deleted.
+	Stage ProjectDeletionStage `json:"stage"`
+	// Resources are all resources which are deleted together with the project. Resources which
+	// are created while the teardown runs are added once they are found.
+	Resources []ProjectDeletionResource `json:"resources,omitempty"`
+}
+
+// ProjectDeletionResource is a resource which is deleted together with its project.
+type ProjectDeletionResource struct {
+	// Kind is the kind of the resource, e.g. "Cluster" or "ServiceAccount".
+	Kind string `json:"kind"`
+	// Name is the ID of the resource as used by the API, e.g. the name of a cluster object or
+	// the ID of a service account. The tokens of a service account are removed together with it.
+	Name string `json:"name"`
+	// DisplayName is the name of the resource as chosen by the user, if it has one.
+	DisplayName string `json:"displayName,omitempty"`
+	// Namespace is the namespace of the resource object, if it is namespaced.
+	Namespace string `json:"namespace,omitempty"`
+	// Seed is the name of the seed the resource object is stored in. It is empty for resources
+	// stored in the master cluster.
+	Seed  string                       `json:"seed,omitempty"`
+	Stage ProjectDeletionStage         `json:"stage"`
+	Phase ProjectDeletionResourcePhase `json:"phase"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -89,10 +89,18 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&ClusterTemplateList{},
 		&ClusterTemplateInstance{},
 		&ClusterTemplateInstanceList{},
+		&ClusterSpecRevision{},
+		&ClusterSpecRevisionList{},
 		&RuleGroup{},
 		&RuleGroupList{},
 		&WhitelistedRegistry{},
 		&WhitelistedRegistryList{},
+		&AddonRollout{},
+		&AddonRolloutList{},
+		&NamespaceTemplate{},
+		&NamespaceTemplateList{},
+		&ClusterNamespace{},
+		&ClusterNamespaceList{},
 	)
 
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
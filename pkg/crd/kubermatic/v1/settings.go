@@ -60,6 +60,19 @@ type SettingSpec struct {
 
 	MachineDeploymentVMResourceQuota MachineDeploymentVMResourceQuota `json:"machineDeploymentVMResourceQuota"`
 
+	// MachineFlavorFilters curate the machine flavors, e.g. instance types or VM sizes, which are
+	// listed and can be used for nodes, by cloud provider name, e.g. "aws" or "azure".
+	// Datacenters can restrict them further.
+	MachineFlavorFilters map[string]MachineFlavorFilter `json:"machineFlavorFilters,omitempty"`
+
+	// ClusterAdmissionWebhook is an external webhook which is called before a cluster is created
+	// and which can deny or mutate it, e.g. to integrate approval or CMDB systems.
+	ClusterAdmissionWebhook *ClusterAdmissionWebhook `json:"clusterAdmissionWebhook,omitempty"`
+
+	// ForbidPresetInheritance disables the default presets of projects, so that clusters created
+	// without explicit credentials no longer inherit the credentials of a preset.
+	ForbidPresetInheritance bool `json:"forbidPresetInheritance,omitempty"`
+
 	// TODO: Datacenters, presets, user management, Google Analytics and default addons.
 }
 
@@ -90,6 +103,56 @@ type MachineDeploymentVMResourceQuota struct {
 	EnableGPU bool `json:"enableGPU"`
 }
 
+// MachineFlavorCategory is a size category of machine flavors, based on their vCPUs and memory.
+type MachineFlavorCategory string
+
+const (
+	// MachineFlavorCategorySmall are flavors with up to 2 vCPUs and 8 GB of memory.
+	MachineFlavorCategorySmall MachineFlavorCategory = "small"
+	// MachineFlavorCategoryMedium are flavors with up to 8 vCPUs and 32 GB of memory.
+	MachineFlavorCategoryMedium MachineFlavorCategory = "medium"
+	// MachineFlavorCategoryLarge are flavors with up to 32 vCPUs and 128 GB of memory.
+	MachineFlavorCategoryLarge MachineFlavorCategory = "large"
+	// MachineFlavorCategoryXLarge are all larger flavors.
+	MachineFlavorCategoryXLarge MachineFlavorCategory = "xlarge"
+)
+
+// MachineFlavorFilter curates the machine flavors users can choose for their nodes. Flavor
+// names may end with "*" to match all flavors with the prefix, e.g. "Standard_D*".
+type MachineFlavorFilter struct {
+	// Optional: Allowed are the only flavors which can be chosen.
+	Allowed []string `json:"allowed,omitempty"`
+	// Optional: Denied are flavors which cannot be chosen, even if they are allowed.
+	Denied []string `json:"denied,omitempty"`
+	// Optional: Categories are the only size categories of which flavors are listed. As the vCPUs
+	// and memory of a flavor are only known when listing them, node deployments are not checked
+	// against the categories.
+	Categories []MachineFlavorCategory `json:"categories,omitempty"`
+}
+
+// ClusterAdmissionFailurePolicy defines how errors calling the cluster admission webhook are handled.
+type ClusterAdmissionFailurePolicy string
+
+const (
+	// ClusterAdmissionFailurePolicyFail rejects the cluster creation if the webhook cannot be called.
+	ClusterAdmissionFailurePolicyFail ClusterAdmissionFailurePolicy = "Fail"
+	// ClusterAdmissionFailurePolicyIgnore creates the cluster if the webhook cannot be called.
+	ClusterAdmissionFailurePolicyIgnore ClusterAdmissionFailurePolicy = "Ignore"
+)
+
+type ClusterAdmissionWebhook struct {
+	// URL of the webhook, must use HTTPS. It is called with a POST request for every cluster
+	// created through the API.
+	URL string `json:"url"`
+	// CABundle is a PEM encoded CA bundle used to verify the serving certificate of the webhook.
+	// If empty, the system trust roots are used.
+	CABundle string `json:"caBundle,omitempty"`
+	// TimeoutSeconds is the time to wait for the webhook to respond, defaults to 10 seconds.
+	TimeoutSeconds int `json:"timeoutSeconds,omitempty"`
+	// FailurePolicy is either Fail or Ignore, defaults to Fail.
+	FailurePolicy ClusterAdmissionFailurePolicy `json:"failurePolicy,omitempty"`
+}
+
 type OpaOptions struct {
 	Enabled  bool `json:"enabled"`
 	Enforced bool `json:"enforced"`
@@ -8,10 +8,34 @@ import (
 	types "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
 	v1beta1 "github.com/open-policy-agent/frameworks/constraint/pkg/apis/templates/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIDeprecationReport) DeepCopyInto(out *APIDeprecationReport) {
+	*out = *in
+	in.ScanTime.DeepCopyInto(&out.ScanTime)
+	if in.RemovedAPIs != nil {
+		in, out := &in.RemovedAPIs, &out.RemovedAPIs
+		*out = make([]RemovedAPI, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIDeprecationReport.
+func (in *APIDeprecationReport) DeepCopy() *APIDeprecationReport {
+	if in == nil {
+		return nil
+	}
+	out := new(APIDeprecationReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *APIServerSettings) DeepCopyInto(out *APIServerSettings) {
 	*out = *in
@@ -248,6 +272,151 @@ func (in *AddonList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonRollout) DeepCopyInto(out *AddonRollout) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonRollout.
+func (in *AddonRollout) DeepCopy() *AddonRollout {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonRollout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AddonRollout) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonRolloutClusterStatus) DeepCopyInto(out *AddonRolloutClusterStatus) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonRolloutClusterStatus.
+func (in *AddonRolloutClusterStatus) DeepCopy() *AddonRolloutClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonRolloutClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonRolloutList) DeepCopyInto(out *AddonRolloutList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AddonRollout, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonRolloutList.
+func (in *AddonRolloutList) DeepCopy() *AddonRolloutList {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonRolloutList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AddonRolloutList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonRolloutSpec) DeepCopyInto(out *AddonRolloutSpec) {
+	*out = *in
+	in.Variables.DeepCopyInto(&out.Variables)
+	in.ClusterSelector.DeepCopyInto(&out.ClusterSelector)
+	if in.Waves != nil {
+		in, out := &in.Waves, &out.Waves
+		*out = make([]AddonRolloutWave, len(*in))
+		copy(*out, *in)
+	}
+	if in.HealthTimeout != nil {
+		in, out := &in.HealthTimeout, &out.HealthTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonRolloutSpec.
+func (in *AddonRolloutSpec) DeepCopy() *AddonRolloutSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonRolloutSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonRolloutStatus) DeepCopyInto(out *AddonRolloutStatus) {
+	*out = *in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]AddonRolloutClusterStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonRolloutStatus.
+func (in *AddonRolloutStatus) DeepCopy() *AddonRolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonRolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonRolloutWave) DeepCopyInto(out *AddonRolloutWave) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonRolloutWave.
+func (in *AddonRolloutWave) DeepCopy() *AddonRolloutWave {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonRolloutWave)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AddonSpec) DeepCopyInto(out *AddonSpec) {
 	*out = *in
@@ -562,138 +731,584 @@ func (in *Azure) DeepCopy() *Azure {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AzureCloudSpec) DeepCopyInto(out *AzureCloudSpec) {
+func (in *AzureAvailabilitySet) DeepCopyInto(out *AzureAvailabilitySet) {
 	*out = *in
-	if in.CredentialsReference != nil {
-		in, out := &in.CredentialsReference, &out.CredentialsReference
-		*out = new(types.GlobalSecretKeySelector)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureAvailabilitySet.
+func (in *AzureAvailabilitySet) DeepCopy() *AzureAvailabilitySet {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureAvailabilitySet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureBootDiagnostics) DeepCopyInto(out *AzureBootDiagnostics) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureBootDiagnostics.
+func (in *AzureBootDiagnostics) DeepCopy() *AzureBootDiagnostics {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureBootDiagnostics)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureCleanupSettings) DeepCopyInto(out *AzureCleanupSettings) {
+	*out = *in
+	if in.StepTimeout != nil {
+		in, out := &in.StepTimeout, &out.StepTimeout
+		*out = new(metav1.Duration)
 		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureCloudSpec.
-func (in *AzureCloudSpec) DeepCopy() *AzureCloudSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureCleanupSettings.
+func (in *AzureCleanupSettings) DeepCopy() *AzureCleanupSettings {
 	if in == nil {
 		return nil
 	}
-	out := new(AzureCloudSpec)
+	out := new(AzureCleanupSettings)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BackupStatus) DeepCopyInto(out *BackupStatus) {
+func (in *AzureCloudSpec) DeepCopyInto(out *AzureCloudSpec) {
 	*out = *in
-	if in.ScheduledTime != nil {
-		in, out := &in.ScheduledTime, &out.ScheduledTime
-		*out = (*in).DeepCopy()
+	if in.CredentialsReference != nil {
+		in, out := &in.CredentialsReference, &out.CredentialsReference
+		*out = new(types.GlobalSecretKeySelector)
+		**out = **in
 	}
-	if in.BackupStartTime != nil {
-		in, out := &in.BackupStartTime, &out.BackupStartTime
-		*out = (*in).DeepCopy()
+	if in.AllowedIPRanges != nil {
+		in, out := &in.AllowedIPRanges, &out.AllowedIPRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.BackupFinishedTime != nil {
-		in, out := &in.BackupFinishedTime, &out.BackupFinishedTime
-		*out = (*in).DeepCopy()
+	if in.DNSServers != nil {
+		in, out := &in.DNSServers, &out.DNSServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.DeleteStartTime != nil {
-		in, out := &in.DeleteStartTime, &out.DeleteStartTime
-		*out = (*in).DeepCopy()
+	if in.PeerVNets != nil {
+		in, out := &in.PeerVNets, &out.PeerVNets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.DeleteFinishedTime != nil {
-		in, out := &in.DeleteFinishedTime, &out.DeleteFinishedTime
-		*out = (*in).DeepCopy()
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]AzureRoute, len(*in))
+		copy(*out, *in)
+	}
+	if in.EgressFirewall != nil {
+		in, out := &in.EgressFirewall, &out.EgressFirewall
+		*out = new(AzureEgressFirewall)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceEndpoints != nil {
+		in, out := &in.ServiceEndpoints, &out.ServiceEndpoints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PrivateEndpoints != nil {
+		in, out := &in.PrivateEndpoints, &out.PrivateEndpoints
+		*out = make([]AzurePrivateEndpoint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AdditionalSubnets != nil {
+		in, out := &in.AdditionalSubnets, &out.AdditionalSubnets
+		*out = make([]AzureSubnet, len(*in))
+		copy(*out, *in)
+	}
+	if in.BootDiagnostics != nil {
+		in, out := &in.BootDiagnostics, &out.BootDiagnostics
+		*out = new(AzureBootDiagnostics)
+		**out = **in
+	}
+	if in.LogAnalytics != nil {
+		in, out := &in.LogAnalytics, &out.LogAnalytics
+		*out = new(AzureLogAnalytics)
+		**out = **in
+	}
+	if in.KMS != nil {
+		in, out := &in.KMS, &out.KMS
+		*out = new(AzureKMSSettings)
+		**out = **in
+	}
+	if in.AdoptedResources != nil {
+		in, out := &in.AdoptedResources, &out.AdoptedResources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeSecurity != nil {
+		in, out := &in.NodeSecurity, &out.NodeSecurity
+		*out = new(AzureNodeSecurity)
+		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupStatus.
-func (in *BackupStatus) DeepCopy() *BackupStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureCloudSpec.
+func (in *AzureCloudSpec) DeepCopy() *AzureCloudSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(BackupStatus)
+	out := new(AzureCloudSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BringYourOwnCloudSpec) DeepCopyInto(out *BringYourOwnCloudSpec) {
+func (in *AzureClusterStatus) DeepCopyInto(out *AzureClusterStatus) {
 	*out = *in
+	if in.AvailabilitySets != nil {
+		in, out := &in.AvailabilitySets, &out.AvailabilitySets
+		*out = make([]AzureAvailabilitySet, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServicePrincipal != nil {
+		in, out := &in.ServicePrincipal, &out.ServicePrincipal
+		*out = new(AzureServicePrincipal)
+		**out = **in
+	}
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BringYourOwnCloudSpec.
-func (in *BringYourOwnCloudSpec) DeepCopy() *BringYourOwnCloudSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureClusterStatus.
+func (in *AzureClusterStatus) DeepCopy() *AzureClusterStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(BringYourOwnCloudSpec)
+	out := new(AzureClusterStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in Bytes) DeepCopyInto(out *Bytes) {
-	{
-		in := &in
-		*out = make(Bytes, len(*in))
+func (in *AzureEgressFirewall) DeepCopyInto(out *AzureEgressFirewall) {
+	*out = *in
+	if in.AllowedFQDNs != nil {
+		in, out := &in.AllowedFQDNs, &out.AllowedFQDNs
+		*out = make([]string, len(*in))
 		copy(*out, *in)
-		return
 	}
+	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Bytes.
-func (in Bytes) DeepCopy() Bytes {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureEgressFirewall.
+func (in *AzureEgressFirewall) DeepCopy() *AzureEgressFirewall {
 	if in == nil {
 		return nil
 	}
-	out := new(Bytes)
+	out := new(AzureEgressFirewall)
 	in.DeepCopyInto(out)
-	return *out
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CNIPluginSettings) DeepCopyInto(out *CNIPluginSettings) {
+func (in *AzureKMSSettings) DeepCopyInto(out *AzureKMSSettings) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNIPluginSettings.
-func (in *CNIPluginSettings) DeepCopy() *CNIPluginSettings {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureKMSSettings.
+func (in *AzureKMSSettings) DeepCopy() *AzureKMSSettings {
 	if in == nil {
 		return nil
 	}
-	out := new(CNIPluginSettings)
+	out := new(AzureKMSSettings)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CleanupOptions) DeepCopyInto(out *CleanupOptions) {
+func (in *AzureLogAnalytics) DeepCopyInto(out *AzureLogAnalytics) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CleanupOptions.
-func (in *CleanupOptions) DeepCopy() *CleanupOptions {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureLogAnalytics.
+func (in *AzureLogAnalytics) DeepCopy() *AzureLogAnalytics {
 	if in == nil {
 		return nil
 	}
-	out := new(CleanupOptions)
+	out := new(AzureLogAnalytics)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CloudSpec) DeepCopyInto(out *CloudSpec) {
+func (in *AzureNodeDefaults) DeepCopyInto(out *AzureNodeDefaults) {
 	*out = *in
-	if in.Fake != nil {
-		in, out := &in.Fake, &out.Fake
-		*out = new(FakeCloudSpec)
-		**out = **in
-	}
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make(map[types.OperatingSystem]AzureNodeImage, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureNodeDefaults.
+func (in *AzureNodeDefaults) DeepCopy() *AzureNodeDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureNodeDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureNodeImage) DeepCopyInto(out *AzureNodeImage) {
+	*out = *in
+	if in.Reference != nil {
+		in, out := &in.Reference, &out.Reference
+		*out = new(AzureNodeImageReference)
+		**out = **in
+	}
+	if in.Plan != nil {
+		in, out := &in.Plan, &out.Plan
+		*out = new(AzureNodeImagePlan)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureNodeImage.
+func (in *AzureNodeImage) DeepCopy() *AzureNodeImage {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureNodeImage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureNodeImagePlan) DeepCopyInto(out *AzureNodeImagePlan) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureNodeImagePlan.
+func (in *AzureNodeImagePlan) DeepCopy() *AzureNodeImagePlan {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureNodeImagePlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureNodeImageReference) DeepCopyInto(out *AzureNodeImageReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureNodeImageReference.
+func (in *AzureNodeImageReference) DeepCopy() *AzureNodeImageReference {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureNodeImageReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureNodeSecurity) DeepCopyInto(out *AzureNodeSecurity) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureNodeSecurity.
+func (in *AzureNodeSecurity) DeepCopy() *AzureNodeSecurity {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureNodeSecurity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzurePrivateEndpoint) DeepCopyInto(out *AzurePrivateEndpoint) {
+	*out = *in
+	if in.GroupIDs != nil {
+		in, out := &in.GroupIDs, &out.GroupIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzurePrivateEndpoint.
+func (in *AzurePrivateEndpoint) DeepCopy() *AzurePrivateEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(AzurePrivateEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzurePrivateLinkSettings) DeepCopyInto(out *AzurePrivateLinkSettings) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzurePrivateLinkSettings.
+func (in *AzurePrivateLinkSettings) DeepCopy() *AzurePrivateLinkSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(AzurePrivateLinkSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureProxySettings) DeepCopyInto(out *AzureProxySettings) {
+	*out = *in
+	if in.CredentialsReference != nil {
+		in, out := &in.CredentialsReference, &out.CredentialsReference
+		*out = new(types.GlobalSecretKeySelector)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureProxySettings.
+func (in *AzureProxySettings) DeepCopy() *AzureProxySettings {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureProxySettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureRateLimitSettings) DeepCopyInto(out *AzureRateLimitSettings) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureRateLimitSettings.
+func (in *AzureRateLimitSettings) DeepCopy() *AzureRateLimitSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureRateLimitSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureRoute) DeepCopyInto(out *AzureRoute) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureRoute.
+func (in *AzureRoute) DeepCopy() *AzureRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureServicePrincipal) DeepCopyInto(out *AzureServicePrincipal) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureServicePrincipal.
+func (in *AzureServicePrincipal) DeepCopy() *AzureServicePrincipal {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureServicePrincipal)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureSubnet) DeepCopyInto(out *AzureSubnet) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureSubnet.
+func (in *AzureSubnet) DeepCopy() *AzureSubnet {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureSubnet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupStatus) DeepCopyInto(out *BackupStatus) {
+	*out = *in
+	if in.ScheduledTime != nil {
+		in, out := &in.ScheduledTime, &out.ScheduledTime
+		*out = (*in).DeepCopy()
+	}
+	if in.BackupStartTime != nil {
+		in, out := &in.BackupStartTime, &out.BackupStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.BackupFinishedTime != nil {
+		in, out := &in.BackupFinishedTime, &out.BackupFinishedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.DeleteStartTime != nil {
+		in, out := &in.DeleteStartTime, &out.DeleteStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.DeleteFinishedTime != nil {
+		in, out := &in.DeleteFinishedTime, &out.DeleteFinishedTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupStatus.
+func (in *BackupStatus) DeepCopy() *BackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BringYourOwnCloudSpec) DeepCopyInto(out *BringYourOwnCloudSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BringYourOwnCloudSpec.
+func (in *BringYourOwnCloudSpec) DeepCopy() *BringYourOwnCloudSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BringYourOwnCloudSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in Bytes) DeepCopyInto(out *Bytes) {
+	{
+		in := &in
+		*out = make(Bytes, len(*in))
+		copy(*out, *in)
+		return
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Bytes.
+func (in Bytes) DeepCopy() Bytes {
+	if in == nil {
+		return nil
+	}
+	out := new(Bytes)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CNIPluginSettings) DeepCopyInto(out *CNIPluginSettings) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNIPluginSettings.
+func (in *CNIPluginSettings) DeepCopy() *CNIPluginSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(CNIPluginSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CleanupOptions) DeepCopyInto(out *CleanupOptions) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CleanupOptions.
+func (in *CleanupOptions) DeepCopy() *CleanupOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(CleanupOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudProviderResource) DeepCopyInto(out *CloudProviderResource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudProviderResource.
+func (in *CloudProviderResource) DeepCopy() *CloudProviderResource {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudProviderResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudSpec) DeepCopyInto(out *CloudSpec) {
+	*out = *in
+	if in.Fake != nil {
+		in, out := &in.Fake, &out.Fake
+		*out = new(FakeCloudSpec)
+		**out = **in
+	}
 	if in.Digitalocean != nil {
 		in, out := &in.Digitalocean, &out.Digitalocean
 		*out = new(DigitaloceanCloudSpec)
@@ -812,6 +1427,22 @@ func (in *ClusterAddress) DeepCopy() *ClusterAddress {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterAdmissionWebhook) DeepCopyInto(out *ClusterAdmissionWebhook) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAdmissionWebhook.
+func (in *ClusterAdmissionWebhook) DeepCopy() *ClusterAdmissionWebhook {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterAdmissionWebhook)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterCondition) DeepCopyInto(out *ClusterCondition) {
 	*out = *in
@@ -863,6 +1494,100 @@ func (in *ClusterList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterNamespace) DeepCopyInto(out *ClusterNamespace) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterNamespace.
+func (in *ClusterNamespace) DeepCopy() *ClusterNamespace {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterNamespace)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterNamespace) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterNamespaceList) DeepCopyInto(out *ClusterNamespaceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterNamespace, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterNamespaceList.
+func (in *ClusterNamespaceList) DeepCopy() *ClusterNamespaceList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterNamespaceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterNamespaceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterNamespaceSpec) DeepCopyInto(out *ClusterNamespaceSpec) {
+	*out = *in
+	in.TemplateSpec.DeepCopyInto(&out.TemplateSpec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterNamespaceSpec.
+func (in *ClusterNamespaceSpec) DeepCopy() *ClusterNamespaceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterNamespaceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterNamespaceStatus) DeepCopyInto(out *ClusterNamespaceStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterNamespaceStatus.
+func (in *ClusterNamespaceStatus) DeepCopy() *ClusterNamespaceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterNamespaceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterNetworkingConfig) DeepCopyInto(out *ClusterNetworkingConfig) {
 	*out = *in
@@ -873,6 +1598,11 @@ func (in *ClusterNetworkingConfig) DeepCopyInto(out *ClusterNetworkingConfig) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.NodeCIDRMaskSize != nil {
+		in, out := &in.NodeCIDRMaskSize, &out.NodeCIDRMaskSize
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
@@ -886,6 +1616,28 @@ func (in *ClusterNetworkingConfig) DeepCopy() *ClusterNetworkingConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPreDeleteHook) DeepCopyInto(out *ClusterPreDeleteHook) {
+	*out = *in
+	in.Job.DeepCopyInto(&out.Job)
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPreDeleteHook.
+func (in *ClusterPreDeleteHook) DeepCopy() *ClusterPreDeleteHook {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPreDeleteHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 	*out = *in
@@ -898,6 +1650,13 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.AdditionalClouds != nil {
+		in, out := &in.AdditionalClouds, &out.AdditionalClouds
+		*out = make([]CloudSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	out.Version = in.Version.DeepCopy()
 	in.ComponentsOverride.DeepCopyInto(&out.ComponentsOverride)
 	out.OIDC = in.OIDC
@@ -940,30 +1699,118 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		*out = new(OPAIntegrationSettings)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.ServiceAccount != nil {
-		in, out := &in.ServiceAccount, &out.ServiceAccount
-		*out = new(ServiceAccountSettings)
-		(*in).DeepCopyInto(*out)
+	if in.ServiceAccount != nil {
+		in, out := &in.ServiceAccount, &out.ServiceAccount
+		*out = new(ServiceAccountSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MLA != nil {
+		in, out := &in.MLA, &out.MLA
+		*out = new(MLASettings)
+		**out = **in
+	}
+	if in.LogShipping != nil {
+		in, out := &in.LogShipping, &out.LogShipping
+		*out = new(LogShippingSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CNIPlugin != nil {
+		in, out := &in.CNIPlugin, &out.CNIPlugin
+		*out = new(CNIPluginSettings)
+		**out = **in
+	}
+	if in.PatchUpgradePolicy != nil {
+		in, out := &in.PatchUpgradePolicy, &out.PatchUpgradePolicy
+		*out = new(PatchUpgradePolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSpec.
+func (in *ClusterSpec) DeepCopy() *ClusterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterSpecRevision) DeepCopyInto(out *ClusterSpecRevision) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSpecRevision.
+func (in *ClusterSpecRevision) DeepCopy() *ClusterSpecRevision {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterSpecRevision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterSpecRevision) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterSpecRevisionList) DeepCopyInto(out *ClusterSpecRevisionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterSpecRevision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.MLA != nil {
-		in, out := &in.MLA, &out.MLA
-		*out = new(MLASettings)
-		**out = **in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSpecRevisionList.
+func (in *ClusterSpecRevisionList) DeepCopy() *ClusterSpecRevisionList {
+	if in == nil {
+		return nil
 	}
-	if in.CNIPlugin != nil {
-		in, out := &in.CNIPlugin, &out.CNIPlugin
-		*out = new(CNIPluginSettings)
-		**out = **in
+	out := new(ClusterSpecRevisionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterSpecRevisionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterSpecRevisionSpec) DeepCopyInto(out *ClusterSpecRevisionSpec) {
+	*out = *in
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
+	in.ClusterSpec.DeepCopyInto(&out.ClusterSpec)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSpec.
-func (in *ClusterSpec) DeepCopy() *ClusterSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSpecRevisionSpec.
+func (in *ClusterSpecRevisionSpec) DeepCopy() *ClusterSpecRevisionSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterSpec)
+	out := new(ClusterSpecRevisionSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1022,6 +1869,40 @@ func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.APIDeprecations != nil {
+		in, out := &in.APIDeprecations, &out.APIDeprecations
+		*out = new(APIDeprecationReport)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CredentialRotations != nil {
+		in, out := &in.CredentialRotations, &out.CredentialRotations
+		*out = make([]CredentialRotation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(AzureClusterStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CloudProviderResources != nil {
+		in, out := &in.CloudProviderResources, &out.CloudProviderResources
+		*out = make([]CloudProviderResource, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeProblems != nil {
+		in, out := &in.NodeProblems, &out.NodeProblems
+		*out = make([]NodeProblem, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PatchUpgrade != nil {
+		in, out := &in.PatchUpgrade, &out.PatchUpgrade
+		*out = new(PatchUpgradeStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1414,6 +2295,22 @@ func (in *ConstraintTemplateSpec) DeepCopy() *ConstraintTemplateSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneDensitySettings) DeepCopyInto(out *ControlPlaneDensitySettings) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneDensitySettings.
+func (in *ControlPlaneDensitySettings) DeepCopy() *ControlPlaneDensitySettings {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneDensitySettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ControllerSettings) DeepCopyInto(out *ControllerSettings) {
 	*out = *in
@@ -1432,6 +2329,23 @@ func (in *ControllerSettings) DeepCopy() *ControllerSettings {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialRotation) DeepCopyInto(out *CredentialRotation) {
+	*out = *in
+	in.RotationTime.DeepCopyInto(&out.RotationTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialRotation.
+func (in *CredentialRotation) DeepCopy() *CredentialRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CustomLink) DeepCopyInto(out *CustomLink) {
 	*out = *in
@@ -1490,6 +2404,22 @@ func (in *Datacenter) DeepCopy() *Datacenter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatacenterCapacityLimits) DeepCopyInto(out *DatacenterCapacityLimits) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatacenterCapacityLimits.
+func (in *DatacenterCapacityLimits) DeepCopy() *DatacenterCapacityLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(DatacenterCapacityLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DatacenterSpec) DeepCopyInto(out *DatacenterSpec) {
 	*out = *in
@@ -1511,7 +2441,7 @@ func (in *DatacenterSpec) DeepCopyInto(out *DatacenterSpec) {
 	if in.Azure != nil {
 		in, out := &in.Azure, &out.Azure
 		*out = new(DatacenterSpecAzure)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Openstack != nil {
 		in, out := &in.Openstack, &out.Openstack
@@ -1563,6 +2493,16 @@ func (in *DatacenterSpec) DeepCopyInto(out *DatacenterSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.CapacityLimits != nil {
+		in, out := &in.CapacityLimits, &out.CapacityLimits
+		*out = new(DatacenterCapacityLimits)
+		**out = **in
+	}
+	if in.MachineFlavorFilter != nil {
+		in, out := &in.MachineFlavorFilter, &out.MachineFlavorFilter
+		*out = new(MachineFlavorFilter)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1634,6 +2574,48 @@ func (in *DatacenterSpecAnexia) DeepCopy() *DatacenterSpecAnexia {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DatacenterSpecAzure) DeepCopyInto(out *DatacenterSpecAzure) {
 	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PrivateLink != nil {
+		in, out := &in.PrivateLink, &out.PrivateLink
+		*out = new(AzurePrivateLinkSettings)
+		**out = **in
+	}
+	if in.PeerVNets != nil {
+		in, out := &in.PeerVNets, &out.PeerVNets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FaultDomainCount != nil {
+		in, out := &in.FaultDomainCount, &out.FaultDomainCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.UpdateDomainCount != nil {
+		in, out := &in.UpdateDomainCount, &out.UpdateDomainCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.AllowedIPRanges != nil {
+		in, out := &in.AllowedIPRanges, &out.AllowedIPRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeDefaults != nil {
+		in, out := &in.NodeDefaults, &out.NodeDefaults
+		*out = new(AzureNodeDefaults)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Cleanup != nil {
+		in, out := &in.Cleanup, &out.Cleanup
+		*out = new(AzureCleanupSettings)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -2615,6 +3597,29 @@ func (in *LeaderElectionSettings) DeepCopy() *LeaderElectionSettings {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogShippingSettings) DeepCopyInto(out *LogShippingSettings) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogShippingSettings.
+func (in *LogShippingSettings) DeepCopy() *LogShippingSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(LogShippingSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MLASettings) DeepCopyInto(out *MLASettings) {
 	*out = *in
@@ -2637,84 +3642,241 @@ func (in *MachineDeploymentVMResourceQuota) DeepCopyInto(out *MachineDeploymentV
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineDeploymentVMResourceQuota.
-func (in *MachineDeploymentVMResourceQuota) DeepCopy() *MachineDeploymentVMResourceQuota {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineDeploymentVMResourceQuota.
+func (in *MachineDeploymentVMResourceQuota) DeepCopy() *MachineDeploymentVMResourceQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineDeploymentVMResourceQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineFlavorFilter) DeepCopyInto(out *MachineFlavorFilter) {
+	*out = *in
+	if in.Allowed != nil {
+		in, out := &in.Allowed, &out.Allowed
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Denied != nil {
+		in, out := &in.Denied, &out.Denied
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Categories != nil {
+		in, out := &in.Categories, &out.Categories
+		*out = make([]MachineFlavorCategory, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineFlavorFilter.
+func (in *MachineFlavorFilter) DeepCopy() *MachineFlavorFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineFlavorFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineNetworkingConfig) DeepCopyInto(out *MachineNetworkingConfig) {
+	*out = *in
+	if in.DNSServers != nil {
+		in, out := &in.DNSServers, &out.DNSServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineNetworkingConfig.
+func (in *MachineNetworkingConfig) DeepCopy() *MachineNetworkingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineNetworkingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Match) DeepCopyInto(out *Match) {
+	*out = *in
+	if in.Kinds != nil {
+		in, out := &in.Kinds, &out.Kinds
+		*out = make([]Kind, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludedNamespaces != nil {
+		in, out := &in.ExcludedNamespaces, &out.ExcludedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.LabelSelector.DeepCopyInto(&out.LabelSelector)
+	in.NamespaceSelector.DeepCopyInto(&out.NamespaceSelector)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Match.
+func (in *Match) DeepCopy() *Match {
+	if in == nil {
+		return nil
+	}
+	out := new(Match)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MlaOptions) DeepCopyInto(out *MlaOptions) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MlaOptions.
+func (in *MlaOptions) DeepCopy() *MlaOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(MlaOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceRoleBinding) DeepCopyInto(out *NamespaceRoleBinding) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceRoleBinding.
+func (in *NamespaceRoleBinding) DeepCopy() *NamespaceRoleBinding {
 	if in == nil {
 		return nil
 	}
-	out := new(MachineDeploymentVMResourceQuota)
+	out := new(NamespaceRoleBinding)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MachineNetworkingConfig) DeepCopyInto(out *MachineNetworkingConfig) {
+func (in *NamespaceTemplate) DeepCopyInto(out *NamespaceTemplate) {
 	*out = *in
-	if in.DNSServers != nil {
-		in, out := &in.DNSServers, &out.DNSServers
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineNetworkingConfig.
-func (in *MachineNetworkingConfig) DeepCopy() *MachineNetworkingConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceTemplate.
+func (in *NamespaceTemplate) DeepCopy() *NamespaceTemplate {
 	if in == nil {
 		return nil
 	}
-	out := new(MachineNetworkingConfig)
+	out := new(NamespaceTemplate)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NamespaceTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Match) DeepCopyInto(out *Match) {
+func (in *NamespaceTemplateList) DeepCopyInto(out *NamespaceTemplateList) {
 	*out = *in
-	if in.Kinds != nil {
-		in, out := &in.Kinds, &out.Kinds
-		*out = make([]Kind, len(*in))
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NamespaceTemplate, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.Namespaces != nil {
-		in, out := &in.Namespaces, &out.Namespaces
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.ExcludedNamespaces != nil {
-		in, out := &in.ExcludedNamespaces, &out.ExcludedNamespaces
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	in.LabelSelector.DeepCopyInto(&out.LabelSelector)
-	in.NamespaceSelector.DeepCopyInto(&out.NamespaceSelector)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Match.
-func (in *Match) DeepCopy() *Match {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceTemplateList.
+func (in *NamespaceTemplateList) DeepCopy() *NamespaceTemplateList {
 	if in == nil {
 		return nil
 	}
-	out := new(Match)
+	out := new(NamespaceTemplateList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NamespaceTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MlaOptions) DeepCopyInto(out *MlaOptions) {
+func (in *NamespaceTemplateSpec) DeepCopyInto(out *NamespaceTemplateSpec) {
 	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ResourceQuota != nil {
+		in, out := &in.ResourceQuota, &out.ResourceQuota
+		*out = new(corev1.ResourceQuotaSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LimitRange != nil {
+		in, out := &in.LimitRange, &out.LimitRange
+		*out = new(corev1.LimitRangeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(networkingv1.NetworkPolicySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RoleBindings != nil {
+		in, out := &in.RoleBindings, &out.RoleBindings
+		*out = make([]NamespaceRoleBinding, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MlaOptions.
-func (in *MlaOptions) DeepCopy() *MlaOptions {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceTemplateSpec.
+func (in *NamespaceTemplateSpec) DeepCopy() *NamespaceTemplateSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(MlaOptions)
+	out := new(NamespaceTemplateSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -2740,6 +3902,23 @@ func (in *NetworkRanges) DeepCopy() *NetworkRanges {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeProblem) DeepCopyInto(out *NodeProblem) {
+	*out = *in
+	in.Since.DeepCopyInto(&out.Since)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeProblem.
+func (in *NodeProblem) DeepCopy() *NodeProblem {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeProblem)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeSettings) DeepCopyInto(out *NodeSettings) {
 	*out = *in
@@ -2960,6 +4139,48 @@ func (in *PacketCloudSpec) DeepCopy() *PacketCloudSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PatchUpgradePolicy) DeepCopyInto(out *PatchUpgradePolicy) {
+	*out = *in
+	if in.HealthCheckTimeout != nil {
+		in, out := &in.HealthCheckTimeout, &out.HealthCheckTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PatchUpgradePolicy.
+func (in *PatchUpgradePolicy) DeepCopy() *PatchUpgradePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PatchUpgradePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PatchUpgradeStatus) DeepCopyInto(out *PatchUpgradeStatus) {
+	*out = *in
+	in.StartedAt.DeepCopyInto(&out.StartedAt)
+	if in.FinishedAt != nil {
+		in, out := &in.FinishedAt, &out.FinishedAt
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PatchUpgradeStatus.
+func (in *PatchUpgradeStatus) DeepCopy() *PatchUpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PatchUpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Preset) DeepCopyInto(out *Preset) {
 	*out = *in
@@ -3132,8 +4353,8 @@ func (in *Project) DeepCopyInto(out *Project) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -3155,6 +4376,43 @@ func (in *Project) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectDeletionResource) DeepCopyInto(out *ProjectDeletionResource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectDeletionResource.
+func (in *ProjectDeletionResource) DeepCopy() *ProjectDeletionResource {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectDeletionResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectDeletionStatus) DeepCopyInto(out *ProjectDeletionStatus) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]ProjectDeletionResource, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectDeletionStatus.
+func (in *ProjectDeletionStatus) DeepCopy() *ProjectDeletionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectDeletionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProjectGroup) DeepCopyInto(out *ProjectGroup) {
 	*out = *in
@@ -3207,6 +4465,20 @@ func (in *ProjectList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProjectSpec) DeepCopyInto(out *ProjectSpec) {
 	*out = *in
+	if in.PreDeleteHooks != nil {
+		in, out := &in.PreDeleteHooks, &out.PreDeleteHooks
+		*out = make([]ClusterPreDeleteHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DefaultPresets != nil {
+		in, out := &in.DefaultPresets, &out.DefaultPresets
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -3223,6 +4495,11 @@ func (in *ProjectSpec) DeepCopy() *ProjectSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProjectStatus) DeepCopyInto(out *ProjectStatus) {
 	*out = *in
+	if in.Deletion != nil {
+		in, out := &in.Deletion, &out.Deletion
+		*out = new(ProjectDeletionStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -3288,6 +4565,82 @@ func (in *RSAKeys) DeepCopy() *RSAKeys {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistryCacheConfig) DeepCopyInto(out *RegistryCacheConfig) {
+	*out = *in
+	if in.Registries != nil {
+		in, out := &in.Registries, &out.Registries
+		*out = make([]RegistryCacheRegistry, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludedImages != nil {
+		in, out := &in.ExcludedImages, &out.ExcludedImages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.StorageSize != nil {
+		in, out := &in.StorageSize, &out.StorageSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryCacheConfig.
+func (in *RegistryCacheConfig) DeepCopy() *RegistryCacheConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistryCacheConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistryCacheRegistry) DeepCopyInto(out *RegistryCacheRegistry) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryCacheRegistry.
+func (in *RegistryCacheRegistry) DeepCopy() *RegistryCacheRegistry {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistryCacheRegistry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemovedAPI) DeepCopyInto(out *RemovedAPI) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemovedAPI.
+func (in *RemovedAPI) DeepCopy() *RemovedAPI {
+	if in == nil {
+		return nil
+	}
+	out := new(RemovedAPI)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RuleGroup) DeepCopyInto(out *RuleGroup) {
 	*out = *in
@@ -3397,6 +4750,7 @@ func (in *Seed) DeepCopyInto(out *Seed) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -3418,6 +4772,24 @@ func (in *Seed) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SeedCondition) DeepCopyInto(out *SeedCondition) {
+	*out = *in
+	in.LastHeartbeatTime.DeepCopyInto(&out.LastHeartbeatTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeedCondition.
+func (in *SeedCondition) DeepCopy() *SeedCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(SeedCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SeedList) DeepCopyInto(out *SeedList) {
 	*out = *in
@@ -3489,6 +4861,26 @@ func (in *SeedSpec) DeepCopyInto(out *SeedSpec) {
 		*out = new(SeedMLASettings)
 		**out = **in
 	}
+	if in.RegistryCache != nil {
+		in, out := &in.RegistryCache, &out.RegistryCache
+		*out = new(RegistryCacheConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ControlPlaneDensity != nil {
+		in, out := &in.ControlPlaneDensity, &out.ControlPlaneDensity
+		*out = new(ControlPlaneDensitySettings)
+		**out = **in
+	}
+	if in.AzureRateLimits != nil {
+		in, out := &in.AzureRateLimits, &out.AzureRateLimits
+		*out = new(AzureRateLimitSettings)
+		**out = **in
+	}
+	if in.AzureProxy != nil {
+		in, out := &in.AzureProxy, &out.AzureProxy
+		*out = new(AzureProxySettings)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -3502,6 +4894,29 @@ func (in *SeedSpec) DeepCopy() *SeedSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SeedStatus) DeepCopyInto(out *SeedStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]SeedCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeedStatus.
+func (in *SeedStatus) DeepCopy() *SeedStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SeedStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceAccountSettings) DeepCopyInto(out *ServiceAccountSettings) {
 	*out = *in
@@ -3535,6 +4950,18 @@ func (in *SettingSpec) DeepCopyInto(out *SettingSpec) {
 	out.OpaOptions = in.OpaOptions
 	out.MlaOptions = in.MlaOptions
 	out.MachineDeploymentVMResourceQuota = in.MachineDeploymentVMResourceQuota
+	if in.MachineFlavorFilters != nil {
+		in, out := &in.MachineFlavorFilters, &out.MachineFlavorFilters
+		*out = make(map[string]MachineFlavorFilter, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.ClusterAdmissionWebhook != nil {
+		in, out := &in.ClusterAdmissionWebhook, &out.ClusterAdmissionWebhook
+		*out = new(ClusterAdmissionWebhook)
+		**out = **in
+	}
 	return
 }
 
@@ -72,6 +72,12 @@ type KubermaticConfigurationSpec struct {
 	// Proxy allows to configure Kubermatic to use proxies to talk to the
 	// world outside of its cluster.
 	Proxy KubermaticProxyConfiguration `json:"proxy,omitempty"`
+	// ObjectStorage configures the buckets Kubermatic stores artifacts like etcd backups,
+	// metering reports and support bundles in.
+	ObjectStorage KubermaticObjectStorageConfiguration `json:"objectStorage,omitempty"`
+	// DriftDetection configures how the operator handles manual changes to the components it
+	// deploys into seed clusters.
+	DriftDetection KubermaticDriftDetectionConfiguration `json:"driftDetection,omitempty"`
 }
 
 // KubermaticAuthConfiguration defines keys and URLs for Dex.
@@ -151,11 +157,51 @@ type KubermaticBackupRestoreConfiguration struct {
 	// Enabled enables the new etcd backup and restore controllers.
 	Enabled bool `json:"enabled,omitempty"`
 	// S3Endpoint is the S3 API endpoint to use for backup and restore. Defaults to s3.amazonaws.com.
+	// Only used if objectStorage.backups is not set.
 	S3Endpoint string `json:"s3Endpoint,omitempty"`
 	// S3BucketName is the S3 bucket name to use for backup and restore.
+	// Only used if objectStorage.backups is not set.
 	S3BucketName string `json:"s3BucketName,omitempty"`
 }
 
+// KubermaticObjectStorageConfiguration configures a bucket per kind of artifact. The credentials
+// of each bucket are read from a Secret in the kube-system namespace of every seed, which is
+// named "s3-credentials" for backups and "<purpose>-storage-credentials" for the other artifacts,
+// e.g. "metering-reports-storage-credentials".
+type KubermaticObjectStorageConfiguration struct {
+	// Backups is the bucket etcd backups are stored in. If not set, the S3 bucket configured
+	// in seedController.backupRestore is used.
+	Backups *ObjectStorageLocation `json:"backups,omitempty"`
+	// MeteringReports is the bucket metering reports are stored in.
+	MeteringReports *ObjectStorageLocation `json:"meteringReports,omitempty"`
+	// SupportBundles is the bucket support bundles are stored in.
+	SupportBundles *ObjectStorageLocation `json:"supportBundles,omitempty"`
+}
+
+// KubermaticDriftDetectionConfiguration configures the handling of manual changes to the images,
+// flags and replicas of the Deployments in seed clusters. Such changes are always reported in
+// the ComponentsInSync condition of the Seed.
+type KubermaticDriftDetectionConfiguration struct {
+	// AutoRevert makes the operator revert manual changes. Otherwise, changed Deployments are
+	// left untouched, including by updates of the KubermaticConfiguration, until the changes
+	// are undone manually or AutoRevert is enabled.
+	AutoRevert bool `json:"autoRevert,omitempty"`
+}
+
+// ObjectStorageLocation describes a bucket in an object storage.
+type ObjectStorageLocation struct {
+	// Provider is one of "s3", "gcs", "azureblob" or "swift". Defaults to "s3".
+	Provider string `json:"provider,omitempty"`
+	// Bucket is the name of the bucket, or of the container for Azure Blob Storage and Swift.
+	Bucket string `json:"bucket"`
+	// Endpoint is the S3 API endpoint, the base URL of the Azure storage service or the
+	// Keystone URL for Swift. Defaults to s3.amazonaws.com for S3 and is not used for
+	// Google Cloud Storage.
+	Endpoint string `json:"endpoint,omitempty"`
+	// Region is the region of the bucket for S3 and of the object store for Swift.
+	Region string `json:"region,omitempty"`
+}
+
 // KubermaticUserClusterConfiguration controls various aspects of the user-created clusters.
 type KubermaticUserClusterConfiguration struct {
 	// KubermaticDockerRepository is the repository containing the Kubermatic user-cluster-controller-manager image.
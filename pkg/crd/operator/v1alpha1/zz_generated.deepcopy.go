@@ -193,6 +193,8 @@ func (in *KubermaticConfigurationSpec) DeepCopyInto(out *KubermaticConfiguration
 	in.Versions.DeepCopyInto(&out.Versions)
 	in.VerticalPodAutoscaler.DeepCopyInto(&out.VerticalPodAutoscaler)
 	out.Proxy = in.Proxy
+	in.ObjectStorage.DeepCopyInto(&out.ObjectStorage)
+	out.DriftDetection = in.DriftDetection
 	return
 }
 
@@ -206,6 +208,22 @@ func (in *KubermaticConfigurationSpec) DeepCopy() *KubermaticConfigurationSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubermaticDriftDetectionConfiguration) DeepCopyInto(out *KubermaticDriftDetectionConfiguration) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubermaticDriftDetectionConfiguration.
+func (in *KubermaticDriftDetectionConfiguration) DeepCopy() *KubermaticDriftDetectionConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(KubermaticDriftDetectionConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubermaticIngressConfiguration) DeepCopyInto(out *KubermaticIngressConfiguration) {
 	*out = *in
@@ -251,6 +269,37 @@ func (in *KubermaticMasterControllerConfiguration) DeepCopy() *KubermaticMasterC
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubermaticObjectStorageConfiguration) DeepCopyInto(out *KubermaticObjectStorageConfiguration) {
+	*out = *in
+	if in.Backups != nil {
+		in, out := &in.Backups, &out.Backups
+		*out = new(ObjectStorageLocation)
+		**out = **in
+	}
+	if in.MeteringReports != nil {
+		in, out := &in.MeteringReports, &out.MeteringReports
+		*out = new(ObjectStorageLocation)
+		**out = **in
+	}
+	if in.SupportBundles != nil {
+		in, out := &in.SupportBundles, &out.SupportBundles
+		*out = new(ObjectStorageLocation)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubermaticObjectStorageConfiguration.
+func (in *KubermaticObjectStorageConfiguration) DeepCopy() *KubermaticObjectStorageConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(KubermaticObjectStorageConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubermaticProjectsMigratorConfiguration) DeepCopyInto(out *KubermaticProjectsMigratorConfiguration) {
 	*out = *in
@@ -481,6 +530,22 @@ func (in *MachineControllerConfiguration) DeepCopy() *MachineControllerConfigura
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStorageLocation) DeepCopyInto(out *ObjectStorageLocation) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStorageLocation.
+func (in *ObjectStorageLocation) DeepCopy() *ObjectStorageLocation {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStorageLocation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Update) DeepCopyInto(out *Update) {
 	*out = *in
@@ -0,0 +1,139 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// SchemaVersion is the version of the Event schema. It is increased on incompatible changes
+// of the event or of one of its payloads, so consumers can reject events they do not understand.
+const SchemaVersion = "v1"
+
+// EventType is the type of an event. It determines the payload of the event and, together with
+// the topic prefix, the topic the event is published to.
+type EventType string
+
+const (
+	// ClusterCreated is published once a new cluster has been created.
+	ClusterCreated EventType = "cluster.created"
+	// ClusterDeleting is published once the deletion of a cluster has started.
+	ClusterDeleting EventType = "cluster.deleting"
+	// ClusterDeleted is published once a cluster and all of its resources are gone.
+	ClusterDeleted EventType = "cluster.deleted"
+	// ClusterVersionChanged is published when the Kubernetes version of a cluster changes.
+	ClusterVersionChanged EventType = "cluster.version.changed"
+	// ClusterHealthChanged is published when the health of a control plane component changes.
+	ClusterHealthChanged EventType = "cluster.health.changed"
+	// NodesScaled is published when the replicas of a MachineDeployment change.
+	NodesScaled EventType = "nodes.scaled"
+	// BackupCompleted is published when an etcd backup has been completed.
+	BackupCompleted EventType = "backup.completed"
+	// BackupFailed is published when an etcd backup has failed.
+	BackupFailed EventType = "backup.failed"
+)
+
+// Event is the envelope of all events published to the bus.
+type Event struct {
+	// SchemaVersion is the version of the schema of the event.
+	SchemaVersion string `json:"schemaVersion"`
+	// ID uniquely identifies the event, consumers can use it to deduplicate events.
+	ID   string    `json:"id"`
+	Type EventType `json:"type"`
+	Time time.Time `json:"time"`
+	// Seed is the name of the seed the event originates from.
+	Seed    string `json:"seed"`
+	Project string `json:"project,omitempty"`
+	Cluster string `json:"cluster,omitempty"`
+	// Data is the payload of the event, its structure depends on the type.
+	Data interface{} `json:"data,omitempty"`
+}
+
+// ClusterData is the payload of the cluster lifecycle events.
+type ClusterData struct {
+	HumanReadableName string `json:"humanReadableName,omitempty"`
+	Provider          string `json:"provider,omitempty"`
+	Datacenter        string `json:"datacenter,omitempty"`
+	Version           string `json:"version,omitempty"`
+	PreviousVersion   string `json:"previousVersion,omitempty"`
+}
+
+// HealthData is the payload of ClusterHealthChanged events.
+type HealthData struct {
+	Component string `json:"component"`
+	Previous  string `json:"previous"`
+	Current   string `json:"current"`
+}
+
+// NodesScaledData is the payload of NodesScaled events.
+type NodesScaledData struct {
+	MachineDeployment string `json:"machineDeployment"`
+	Previous          int32  `json:"previous"`
+	Current           int32  `json:"current"`
+}
+
+// BackupData is the payload of the backup events.
+type BackupData struct {
+	BackupConfig string     `json:"backupConfig"`
+	Backup       string     `json:"backup"`
+	Message      string     `json:"message,omitempty"`
+	StartTime    *time.Time `json:"startTime,omitempty"`
+	FinishedTime *time.Time `json:"finishedTime,omitempty"`
+}
+
+// Publisher publishes events to a message bus.
+type Publisher interface {
+	// Publish publishes the event to the topic of its type. It returns once the bus has
+	// accepted the event.
+	Publish(ctx context.Context, event Event) error
+	// Close flushes pending events and closes the connection to the bus.
+	Close() error
+}
+
+// Topic returns the topic, respectively NATS subject, events of the given type are published to.
+func Topic(prefix string, eventType EventType) string {
+	if prefix == "" {
+		return string(eventType)
+	}
+	return prefix + "." + string(eventType)
+}
+
+// NewPublisher returns a publisher for the bus with the given URL, all topics start with the
+// prefix. Supported are NATS servers (nats://host:port, tls://host:port) and Kafka brokers
+// (kafka://host:port[,host:port...]).
+func NewPublisher(rawURL, topicPrefix string) (Publisher, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid event bus URL: %v", err)
+	}
+
+	switch u.Scheme {
+	case "nats", "tls":
+		return newNATSPublisher(rawURL, topicPrefix)
+	case "kafka":
+		if u.Host == "" {
+			return nil, fmt.Errorf("no Kafka brokers in event bus URL %q", rawURL)
+		}
+		return newKafkaPublisher(strings.Split(u.Host, ","), topicPrefix), nil
+	default:
+		return nil, fmt.Errorf("unsupported event bus URL scheme %q, must be one of nats, tls or kafka", u.Scheme)
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventbus
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestNewPublisher(t *testing.T) {
+	testCases := []struct {
+		name        string
+		url         string
+		expectError bool
+	}{
+		{
+			name: "kafka brokers",
+			url:  "kafka://kafka-0:9092,kafka-1:9092",
+		},
+		{
+			name:        "kafka without brokers",
+			url:         "kafka://",
+			expectError: true,
+		},
+		{
+			name:        "unsupported scheme",
+			url:         "amqp://rabbitmq:5672",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			publisher, err := NewPublisher(tc.url, "kubermatic")
+			if (err != nil) != tc.expectError {
+				t.Fatalf("expected error: %v, got %v", tc.expectError, err)
+			}
+			if publisher != nil {
+				if err := publisher.Close(); err != nil {
+					t.Errorf("failed to close publisher: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestTopic(t *testing.T) {
+	if topic := Topic("kubermatic", ClusterCreated); topic != "kubermatic.cluster.created" {
+		t.Errorf("unexpected topic %q", topic)
+	}
+	if topic := Topic("", BackupFailed); topic != "backup.failed" {
+		t.Errorf("unexpected topic %q", topic)
+	}
+}
+
+func TestEventSchema(t *testing.T) {
+	event := Event{
+		SchemaVersion: SchemaVersion,
+		ID:            "5c8b2c36",
+		Type:          NodesScaled,
+		Time:          time.Date(2021, 8, 1, 12, 0, 0, 0, time.UTC),
+		Seed:          "europe-west3",
+		Project:       "zxd9b5gm",
+		Cluster:       "w225mx4z66",
+		Data:          NodesScaledData{MachineDeployment: "workers", Previous: 3, Current: 5},
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		t.Fatalf("failed to marshal event: %v", err)
+	}
+
+	expected := `{"schemaVersion":"v1","id":"5c8b2c36","type":"nodes.scaled","time":"2021-08-01T12:00:00Z","seed":"europe-west3",` +
+		`"project":"zxd9b5gm","cluster":"w225mx4z66","data":{"machineDeployment":"workers","previous":3,"current":5}}`
+	if string(data) != expected {
+		t.Errorf("unexpected event\nexpected: %s\ngot:      %s", expected, data)
+	}
+}
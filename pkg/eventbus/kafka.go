@@ -0,0 +1,69 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// schemaVersionHeader is the Kafka message header containing the schema version, so consumers
+// can skip events without decoding them.
+const schemaVersionHeader = "schemaVersion"
+
+type kafkaPublisher struct {
+	writer      *kafka.Writer
+	topicPrefix string
+}
+
+func newKafkaPublisher(brokers []string, topicPrefix string) *kafkaPublisher {
+	return &kafkaPublisher{
+		writer: &kafka.Writer{
+			Addr: kafka.TCP(brokers...),
+			// Events are keyed by cluster, hashing keeps the events of a cluster in order.
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireAll,
+		},
+		topicPrefix: topicPrefix,
+	}
+}
+
+func (p *kafkaPublisher) Publish(ctx context.Context, event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %v", err)
+	}
+
+	message := kafka.Message{
+		Topic:   Topic(p.topicPrefix, event.Type),
+		Key:     []byte(event.Cluster),
+		Value:   data,
+		Headers: []kafka.Header{{Key: schemaVersionHeader, Value: []byte(event.SchemaVersion)}},
+	}
+	if err := p.writer.WriteMessages(ctx, message); err != nil {
+		return fmt.Errorf("failed to publish event: %v", err)
+	}
+
+	return nil
+}
+
+func (p *kafkaPublisher) Close() error {
+	return p.writer.Close()
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+)
+
+type natsPublisher struct {
+	conn        *nats.Conn
+	topicPrefix string
+}
+
+func newNATSPublisher(url, topicPrefix string) (*natsPublisher, error) {
+	// The publisher lives as long as the controller manager, so it must never give up reconnecting.
+	conn, err := nats.Connect(url, nats.Name("kubermatic"), nats.MaxReconnects(-1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to NATS: %v", err)
+	}
+
+	return &natsPublisher{conn: conn, topicPrefix: topicPrefix}, nil
+}
+
+func (p *natsPublisher) Publish(ctx context.Context, event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %v", err)
+	}
+
+	if err := p.conn.Publish(Topic(p.topicPrefix, event.Type), data); err != nil {
+		return fmt.Errorf("failed to publish event: %v", err)
+	}
+
+	// Publish only buffers the event, flushing makes sure the server received it.
+	if err := p.conn.FlushWithContext(ctx); err != nil {
+		return fmt.Errorf("failed to flush event: %v", err)
+	}
+
+	return nil
+}
+
+func (p *natsPublisher) Close() error {
+	return p.conn.Drain()
+}
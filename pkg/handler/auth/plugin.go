@@ -32,6 +32,9 @@ type TokenClaims struct {
 	Subject string
 	Groups  []string
 	Expiry  apiv1.Time
+	// ProjectID and Scope are only set for service account tokens
+	ProjectID string
+	Scope     string
 }
 
 // TokenExtractorVerifier combines TokenVerifier and TokenExtractor interfaces
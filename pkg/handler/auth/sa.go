@@ -53,6 +53,11 @@ func (s *ServiceAccountAuthClient) Verify(ctx context.Context, token string) (To
 	if err != nil {
 		return TokenClaims{}, err
 	}
+	// tokens signed with the same key for other purposes, e.g. kubeconfig
+	// sharing links, don't carry a token ID and must not authenticate a user
+	if customClaims.TokenID == "" {
+		return TokenClaims{}, fmt.Errorf("sa: the token is not a service account token")
+	}
 
 	tokenList, err := s.saTokenProvider.ListUnsecured(&provider.ServiceAccountTokenListOptions{TokenID: customClaims.TokenID})
 	if kerrors.IsNotFound(err) {
@@ -71,8 +76,10 @@ func (s *ServiceAccountAuthClient) Verify(ctx context.Context, token string) (To
 	}
 
 	return TokenClaims{
-		Name:    customClaims.TokenID,
-		Email:   customClaims.Email,
-		Subject: customClaims.Email,
+		Name:      customClaims.TokenID,
+		Email:     customClaims.Email,
+		Subject:   customClaims.Email,
+		ProjectID: customClaims.ProjectID,
+		Scope:     customClaims.Scope,
 	}, nil
 }
@@ -0,0 +1,186 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/util/errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// checkDatacenterClusterLimit returns a Forbidden error if the datacenter already holds the
+// maximum number of clusters.
+func checkDatacenterClusterLimit(clusterProvider provider.ClusterProvider, dc *kubermaticv1.Datacenter, dcName string) error {
+	if dc.Spec.CapacityLimits == nil || dc.Spec.CapacityLimits.MaxClusters <= 0 {
+		return nil
+	}
+
+	clusters, err := datacenterClusters(clusterProvider, dcName)
+	if err != nil {
+		return err
+	}
+
+	if len(clusters) >= dc.Spec.CapacityLimits.MaxClusters {
+		return errors.New(http.StatusForbidden, fmt.Sprintf("datacenter %q has reached its limit of %d clusters", dcName, dc.Spec.CapacityLimits.MaxClusters))
+	}
+
+	return nil
+}
+
+// checkDatacenterNodeLimit returns a Forbidden error if setting the node deployment with the given
+// name in the cluster to the given number of replicas would exceed the maximum number of nodes in
+// the cluster's datacenter. The node deployment does not need to exist yet.
+func checkDatacenterNodeLimit(ctx context.Context, clusterProvider provider.ClusterProvider, dc *kubermaticv1.Datacenter, cluster *kubermaticv1.Cluster, nodeDeploymentName string, replicas int) error {
+	if dc.Spec.CapacityLimits == nil || dc.Spec.CapacityLimits.MaxNodes <= 0 {
+		return nil
+	}
+
+	dcName := cluster.Spec.Cloud.DatacenterName
+	clusters, err := datacenterClusters(clusterProvider, dcName)
+	if err != nil {
+		return err
+	}
+
+	nodes := replicas
+	for i := range clusters {
+		c := &clusters[i]
+
+		count, err := countPendingNodes(c, cluster.Name, nodeDeploymentName)
+		if err != nil {
+			return err
+		}
+		nodes += count
+
+		client, err := clusterProvider.GetAdminClientForCustomerCluster(ctx, c)
+		if err != nil {
+			return common.KubernetesErrorToHTTPError(err)
+		}
+		count, err = countNodes(ctx, client, c.Name == cluster.Name, nodeDeploymentName)
+		if err != nil {
+			// the control plane of new clusters is not reachable until it is up, but they have no
+			// node deployments yet besides the initial one, which is counted above
+			if c.Name == cluster.Name {
+				return common.KubernetesErrorToHTTPError(err)
+			}
+			continue
+		}
+		nodes += count
+	}
+
+	if nodes > dc.Spec.CapacityLimits.MaxNodes {
+		return errors.New(http.StatusForbidden, fmt.Sprintf("datacenter %q is limited to %d nodes, this change would result in %d", dcName, dc.Spec.CapacityLimits.MaxNodes, nodes))
+	}
+
+	return nil
+}
+
+// checkClusterNodeLimit returns a Forbidden error if setting the node deployment with the given name
+// to the given number of replicas would result in more nodes than the pod CIDR of the cluster can
+// be split into. The existing nodes are only counted if a client for the cluster is given, which
+// is not the case for clusters which are being created.
+func checkClusterNodeLimit(ctx context.Context, client ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster, nodeDeploymentName string, replicas int) error {
+	maxNodes, err := resources.MaxNodes(cluster.Spec.ClusterNetwork)
+	if err != nil {
+		// the pod CIDR is defaulted and validated when the cluster is created
+		return nil
+	}
+
+	nodes := replicas
+	if client != nil {
+		count, err := countNodes(ctx, client, true, nodeDeploymentName)
+		if err != nil {
+			return common.KubernetesErrorToHTTPError(err)
+		}
+		nodes += count
+	}
+
+	if nodes > maxNodes {
+		return errors.New(http.StatusForbidden, fmt.Sprintf("the pod CIDR %s of the cluster with a node CIDR mask size of %d is limited to %d nodes, this change would result in %d",
+			cluster.Spec.ClusterNetwork.Pods.CIDRBlocks[0], resources.NodeCIDRMaskSize(cluster.Spec.ClusterNetwork), maxNodes, nodes))
+	}
+
+	return nil
+}
+
+// datacenterClusters returns the clusters in the given datacenter which are not being deleted.
+func datacenterClusters(clusterProvider provider.ClusterProvider, dcName string) ([]kubermaticv1.Cluster, error) {
+	clusterList, err := clusterProvider.ListAll()
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	var clusters []kubermaticv1.Cluster
+	for _, cluster := range clusterList.Items {
+		if cluster.Spec.Cloud.DatacenterName == dcName && cluster.DeletionTimestamp == nil {
+			clusters = append(clusters, cluster)
+		}
+	}
+
+	return clusters, nil
+}
+
+// countPendingNodes returns the replicas of the initial node deployment of the cluster, as long as
+// it has not been created yet.
+func countPendingNodes(cluster *kubermaticv1.Cluster, skipClusterName, skipNodeDeploymentName string) (int, error) {
+	request, ok := cluster.Annotations[apiv1.InitialMachineDeploymentRequestAnnotation]
+	if !ok {
+		return 0, nil
+	}
+
+	nodeDeployment := &apiv1.NodeDeployment{}
+	if err := json.Unmarshal([]byte(request), nodeDeployment); err != nil {
+		return 0, fmt.Errorf("cannot unmarshal initial node deployment of cluster %q: %v", cluster.Name, err)
+	}
+	if cluster.Name == skipClusterName && nodeDeployment.Name == skipNodeDeploymentName {
+		return 0, nil
+	}
+
+	return int(nodeDeployment.Spec.Replicas), nil
+}
+
+// countNodes returns the sum of the replicas of the machine deployments in the user cluster,
+// optionally skipping the machine deployment with the given name.
+func countNodes(ctx context.Context, client ctrlruntimeclient.Client, skip bool, skipName string) (int, error) {
+	machineDeployments := &clusterv1alpha1.MachineDeploymentList{}
+	if err := client.List(ctx, machineDeployments, ctrlruntimeclient.InNamespace(metav1.NamespaceSystem)); err != nil {
+		return 0, err
+	}
+
+	nodes := 0
+	for _, md := range machineDeployments.Items {
+		if skip && md.Name == skipName {
+			continue
+		}
+		if md.Spec.Replicas != nil {
+			nodes += int(*md.Spec.Replicas)
+		}
+	}
+
+	return nodes, nil
+}
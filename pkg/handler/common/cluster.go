@@ -28,6 +28,7 @@ import (
 	"go.uber.org/zap"
 
 	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1/helper"
 	"k8c.io/kubermatic/v2/pkg/handler/middleware"
@@ -36,6 +37,8 @@ import (
 	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
 	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
 	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/provider/cloud"
+	"k8c.io/kubermatic/v2/pkg/provider/cloud/azure"
 	kubernetesprovider "k8c.io/kubermatic/v2/pkg/provider/kubernetes"
 	"k8c.io/kubermatic/v2/pkg/resources/cloudcontroller"
 	"k8c.io/kubermatic/v2/pkg/resources/cluster"
@@ -71,7 +74,7 @@ type patchCluster struct {
 func CreateEndpoint(ctx context.Context, projectID string, body apiv1.CreateClusterSpec,
 	projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider,
 	seedsGetter provider.SeedsGetter, credentialManager provider.PresetProvider, exposeStrategy kubermaticv1.ExposeStrategy,
-	userInfoGetter provider.UserInfoGetter, caBundle *x509.CertPool) (interface{}, error) {
+	userInfoGetter provider.UserInfoGetter, caBundle *x509.CertPool, admissionWebhook *kubermaticv1.ClusterAdmissionWebhook, forbidPresetInheritance bool) (interface{}, error) {
 
 	clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
 	privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
@@ -86,8 +89,7 @@ func CreateEndpoint(ctx context.Context, projectID string, body apiv1.CreateClus
 		return nil, common.KubernetesErrorToHTTPError(err)
 	}
 
-	partialCluster, err := GenerateCluster(ctx, projectID, body, seedsGetter, credentialManager, exposeStrategy, userInfoGetter, caBundle)
-	if err != nil {
+	if err := checkDatacenterClusterLimit(clusterProvider, dc, body.Cluster.Spec.Cloud.DatacenterName); err != nil {
 		return nil, err
 	}
 
@@ -95,6 +97,24 @@ func CreateEndpoint(ctx context.Context, projectID string, body apiv1.CreateClus
 	if err != nil {
 		return nil, common.KubernetesErrorToHTTPError(err)
 	}
+
+	inheritedPreset := InheritDefaultPreset(project, &body, forbidPresetInheritance)
+	partialCluster, err := GenerateCluster(ctx, projectID, body, seedsGetter, credentialManager, exposeStrategy, userInfoGetter, caBundle)
+	if err != nil {
+		return nil, err
+	}
+	if inheritedPreset != "" {
+		partialCluster.Annotations[kubermaticv1.InheritedPresetAnnotation] = inheritedPreset
+	}
+
+	if _, ok := partialCluster.Annotations[apiv1.InitialMachineDeploymentRequestAnnotation]; ok {
+		if err := checkDatacenterNodeLimit(ctx, clusterProvider, dc, partialCluster, body.NodeDeployment.Name, int(body.NodeDeployment.Spec.Replicas)); err != nil {
+			return nil, err
+		}
+		if err := checkClusterNodeLimit(ctx, nil, partialCluster, body.NodeDeployment.Name, int(body.NodeDeployment.Spec.Replicas)); err != nil {
+			return nil, err
+		}
+	}
 	existingClusters, err := clusterProvider.List(project, &provider.ClusterListOptions{ClusterSpecName: partialCluster.Spec.HumanReadableName})
 	if err != nil {
 		return nil, common.KubernetesErrorToHTTPError(err)
@@ -104,6 +124,26 @@ func CreateEndpoint(ctx context.Context, projectID string, body apiv1.CreateClus
 		return nil, errors.NewAlreadyExists("cluster", partialCluster.Spec.HumanReadableName)
 	}
 
+	userInfo := adminUserInfo
+	if !adminUserInfo.IsAdmin {
+		if userInfo, err = userInfoGetter(ctx, projectID); err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+	}
+	partialCluster, err = AdmitCluster(ctx, admissionWebhook, userInfo, project, partialCluster, dc)
+	if err != nil {
+		return nil, err
+	}
+	// The admission webhook must not lift the enforcements of the datacenter.
+	if dc.Spec.EnforceAuditLogging {
+		partialCluster.Spec.AuditLogging = &kubermaticv1.AuditLoggingSettings{
+			Enabled: true,
+		}
+	}
+	if dc.Spec.EnforcePodSecurityPolicy {
+		partialCluster.Spec.UsePodSecurityPolicyAdmissionPlugin = true
+	}
+
 	if err := kubernetesprovider.CreateOrUpdateCredentialSecretForCluster(ctx, privilegedClusterProvider.GetSeedClusterAdminRuntimeClient(), partialCluster); err != nil {
 		return nil, err
 	}
@@ -114,6 +154,10 @@ func CreateEndpoint(ctx context.Context, projectID string, body apiv1.CreateClus
 		return nil, common.KubernetesErrorToHTTPError(err)
 	}
 
+	if err := privilegedClusterProvider.RecordSpecRevisionUnsecured(nil, newCluster, newCluster.Status.UserEmail); err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
 	log := kubermaticlog.Logger.With("cluster", newCluster.Name)
 
 	// Block for up to 10 seconds to give the rbac controller time to create the bindings.
@@ -162,6 +206,10 @@ func GenerateCluster(ctx context.Context, projectID string, body apiv1.CreateClu
 		return nil, errors.NewBadRequest("invalid cluster: %v", err)
 	}
 
+	if err := validateAdditionalClouds(adminUserInfo, seedsGetter, spec); err != nil {
+		return nil, err
+	}
+
 	// master level ExposeStrategy is the default
 	spec.ExposeStrategy = exposeStrategy
 	if seed.Spec.ExposeStrategy != "" {
@@ -362,6 +410,80 @@ func PatchEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter,
 		return nil, fmt.Errorf("error getting dc: %v", err)
 	}
 
+	newInternalCluster, err := patchInternalCluster(oldInternalCluster, dc, patch)
+	if err != nil {
+		return nil, err
+	}
+
+	incompatibleKubelets, err := common.CheckClusterVersionSkew(ctx, userInfoGetter, clusterProvider, newInternalCluster, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing nodes' version skew: %v", err)
+	}
+	if len(incompatibleKubelets) > 0 {
+		return nil, errors.NewBadRequest("Cluster contains nodes running the following incompatible kubelet versions: %v. Upgrade your nodes before you upgrade the cluster.", incompatibleKubelets)
+	}
+
+	if err := kubernetesprovider.CreateOrUpdateCredentialSecretForCluster(ctx, privilegedClusterProvider.GetSeedClusterAdminRuntimeClient(), newInternalCluster); err != nil {
+		return nil, err
+	}
+
+	// Enforce audit logging
+	if dc.Spec.EnforceAuditLogging {
+		newInternalCluster.Spec.AuditLogging = &kubermaticv1.AuditLoggingSettings{
+			Enabled: true,
+		}
+	}
+
+	// Enforce PodSecurityPolicy
+	if dc.Spec.EnforcePodSecurityPolicy {
+		newInternalCluster.Spec.UsePodSecurityPolicyAdmissionPlugin = true
+	}
+
+	assertedClusterProvider, ok := clusterProvider.(*kubernetesprovider.ClusterProvider)
+	if !ok {
+		return nil, errors.New(http.StatusInternalServerError, "failed to assert clusterProvider")
+	}
+	if err := validation.ValidateUpdateCluster(ctx, newInternalCluster, oldInternalCluster, dc, assertedClusterProvider, caBundle); err != nil {
+		return nil, errors.NewBadRequest("invalid cluster: %v", err)
+	}
+	if err := validateAdditionalClouds(userInfo, seedsGetter, &newInternalCluster.Spec); err != nil {
+		return nil, err
+	}
+	if err = validation.ValidateUpdateWindow(newInternalCluster.Spec.UpdateWindow); err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	updatedCluster, err := updateCluster(ctx, userInfoGetter, clusterProvider, privilegedClusterProvider, project, newInternalCluster)
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	if err := privilegedClusterProvider.RecordSpecRevisionUnsecured(oldInternalCluster, updatedCluster, userInfo.Email); err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	return ConvertInternalClusterToExternal(updatedCluster, dc, true), nil
+}
+
+// patchInternalCluster applies a JSON merge patch of the external representation to a copy of the cluster.
+// validateAdditionalClouds validates the additional clouds of the cluster spec against their datacenters.
+func validateAdditionalClouds(userInfo *provider.UserInfo, seedsGetter provider.SeedsGetter, spec *kubermaticv1.ClusterSpec) error {
+	datacenters := map[string]*kubermaticv1.Datacenter{}
+	for _, cloud := range spec.AdditionalClouds {
+		_, dc, err := provider.DatacenterFromSeedMap(userInfo, seedsGetter, cloud.DatacenterName)
+		if err != nil {
+			return errors.NewBadRequest("invalid additional cloud %q: %v", cloud.DatacenterName, err)
+		}
+		datacenters[cloud.DatacenterName] = dc
+	}
+
+	if err := validation.ValidateAdditionalClouds(spec, datacenters); err != nil {
+		return errors.NewBadRequest("invalid cluster: %v", err)
+	}
+	return nil
+}
+
+func patchInternalCluster(oldInternalCluster *kubermaticv1.Cluster, dc *kubermaticv1.Datacenter, patch []byte) (*kubermaticv1.Cluster, error) {
 	// Converting to API type as it is the type exposed externally.
 	externalCluster := ConvertInternalClusterToExternal(oldInternalCluster, dc, false)
 
@@ -384,8 +506,7 @@ func PatchEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter,
 	}
 
 	var patchedCluster *apiv1.Cluster
-	err = json.Unmarshal(patchedClusterJSON, &patchedCluster)
-	if err != nil {
+	if err := json.Unmarshal(patchedClusterJSON, &patchedCluster); err != nil {
 		return nil, errors.NewBadRequest("cannot decode patched cluster: %v", err)
 	}
 
@@ -396,6 +517,7 @@ func PatchEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter,
 	newInternalCluster.Labels = patchedCluster.Labels
 	newInternalCluster.Spec.Cloud = patchedCluster.Spec.Cloud
 	newInternalCluster.Spec.MachineNetworks = patchedCluster.Spec.MachineNetworks
+	newInternalCluster.Spec.AdditionalClouds = patchedCluster.Spec.AdditionalClouds
 	newInternalCluster.Spec.Version = patchedCluster.Spec.Version
 	newInternalCluster.Spec.OIDC = patchedCluster.Spec.OIDC
 	newInternalCluster.Spec.UsePodSecurityPolicyAdmissionPlugin = patchedCluster.Spec.UsePodSecurityPolicyAdmissionPlugin
@@ -407,49 +529,11 @@ func PatchEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter,
 	newInternalCluster.Spec.PodNodeSelectorAdmissionPluginConfig = patchedCluster.Spec.PodNodeSelectorAdmissionPluginConfig
 	newInternalCluster.Spec.ServiceAccount = patchedCluster.Spec.ServiceAccount
 	newInternalCluster.Spec.MLA = patchedCluster.Spec.MLA
+	newInternalCluster.Spec.LogShipping = patchedCluster.Spec.LogShipping
+	newInternalCluster.Spec.PatchUpgradePolicy = patchedCluster.Spec.PatchUpgradePolicy
 	newInternalCluster.Spec.ContainerRuntime = patchedCluster.Spec.ContainerRuntime
 
-	incompatibleKubelets, err := common.CheckClusterVersionSkew(ctx, userInfoGetter, clusterProvider, newInternalCluster, projectID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to check existing nodes' version skew: %v", err)
-	}
-	if len(incompatibleKubelets) > 0 {
-		return nil, errors.NewBadRequest("Cluster contains nodes running the following incompatible kubelet versions: %v. Upgrade your nodes before you upgrade the cluster.", incompatibleKubelets)
-	}
-
-	if err := kubernetesprovider.CreateOrUpdateCredentialSecretForCluster(ctx, privilegedClusterProvider.GetSeedClusterAdminRuntimeClient(), newInternalCluster); err != nil {
-		return nil, err
-	}
-
-	// Enforce audit logging
-	if dc.Spec.EnforceAuditLogging {
-		newInternalCluster.Spec.AuditLogging = &kubermaticv1.AuditLoggingSettings{
-			Enabled: true,
-		}
-	}
-
-	// Enforce PodSecurityPolicy
-	if dc.Spec.EnforcePodSecurityPolicy {
-		newInternalCluster.Spec.UsePodSecurityPolicyAdmissionPlugin = true
-	}
-
-	assertedClusterProvider, ok := clusterProvider.(*kubernetesprovider.ClusterProvider)
-	if !ok {
-		return nil, errors.New(http.StatusInternalServerError, "failed to assert clusterProvider")
-	}
-	if err := validation.ValidateUpdateCluster(ctx, newInternalCluster, oldInternalCluster, dc, assertedClusterProvider, caBundle); err != nil {
-		return nil, errors.NewBadRequest("invalid cluster: %v", err)
-	}
-	if err = validation.ValidateUpdateWindow(newInternalCluster.Spec.UpdateWindow); err != nil {
-		return nil, common.KubernetesErrorToHTTPError(err)
-	}
-
-	updatedCluster, err := updateCluster(ctx, userInfoGetter, clusterProvider, privilegedClusterProvider, project, newInternalCluster)
-	if err != nil {
-		return nil, common.KubernetesErrorToHTTPError(err)
-	}
-
-	return ConvertInternalClusterToExternal(updatedCluster, dc, true), nil
+	return newInternalCluster, nil
 }
 
 func GetClusterEventsEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectID, clusterID, eventType string, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider) (interface{}, error) {
@@ -510,9 +594,157 @@ func HealthEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter,
 		UserClusterControllerManager: existingCluster.Status.ExtendedHealth.UserClusterControllerManager,
 		GatekeeperController:         existingCluster.Status.ExtendedHealth.GatekeeperController,
 		GatekeeperAudit:              existingCluster.Status.ExtendedHealth.GatekeeperAudit,
+		LogShipping:                  existingCluster.Status.ExtendedHealth.LogShipping,
+		Nodes:                        existingCluster.Status.ExtendedHealth.Nodes,
 	}, nil
 }
 
+// ListCredentialRotationsEndpoint returns the age of the credentials generated for the control plane
+// of the cluster, as recorded by the credential rotation controller.
+func ListCredentialRotationsEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectID, clusterID string, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider) (interface{}, error) {
+	cluster, err := GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, projectID, clusterID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	rotations := make([]apiv2.ClusterCredentialRotation, 0, len(cluster.Status.CredentialRotations))
+	for _, rotation := range cluster.Status.CredentialRotations {
+		rotations = append(rotations, apiv2.ClusterCredentialRotation{
+			Name:         rotation.Name,
+			RotationTime: apiv1.NewTime(rotation.RotationTime.Time),
+			AgeSeconds:   int64(now.Sub(rotation.RotationTime.Time).Seconds()),
+		})
+	}
+
+	return rotations, nil
+}
+
+// GetInfrastructureEndpoint queries the cloud provider for the live state of the resources used by
+// the cluster and reports where they drifted from the cluster spec.
+func GetInfrastructureEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectID, clusterID string, projectProvider provider.ProjectProvider,
+	privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter, caBundle *x509.CertPool) (interface{}, error) {
+	privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
+
+	cluster, err := GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, projectID, clusterID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	adminUserInfo, err := userInfoGetter(ctx, "")
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+	_, dc, err := provider.DatacenterFromSeedMap(adminUserInfo, seedsGetter, cluster.Spec.Cloud.DatacenterName)
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	secretKeyGetter := provider.SecretKeySelectorValueFuncFactory(ctx, privilegedClusterProvider.GetSeedClusterAdminRuntimeClient())
+	cloudProvider, err := cloud.Provider(dc, secretKeyGetter, caBundle)
+	if err != nil {
+		return nil, errors.New(http.StatusInternalServerError, err.Error())
+	}
+
+	var resources []provider.InfrastructureResource
+	switch cloudProvider := cloudProvider.(type) {
+	case *azure.Azure:
+		resources, err = cloudProvider.GetInfrastructure(cluster)
+		if err != nil {
+			return nil, errors.New(azure.HTTPStatusCode(err, http.StatusBadGateway), err.Error())
+		}
+	default:
+		return nil, errors.NewNotImplemented()
+	}
+
+	result := make([]apiv2.ClusterInfrastructureResource, 0, len(resources))
+	for _, resource := range resources {
+		result = append(result, apiv2.ClusterInfrastructureResource{
+			Type:            resource.Type,
+			Name:            resource.Name,
+			ID:              resource.ID,
+			Managed:         resource.Managed,
+			State:           string(resource.State),
+			Tags:            resource.Tags,
+			AddressPrefixes: resource.AddressPrefixes,
+			Drift:           resource.Drift,
+		})
+	}
+
+	return result, nil
+}
+
+// planClusterID is used as the name of the cluster when planning its infrastructure, as the
+// actual name is only generated when the cluster is created.
+const planClusterID = "cluster-id"
+
+// PlanInfrastructureEndpoint returns the cloud resources which would be created for a new cluster
+// with the given spec, without creating the cluster or calling the cloud provider.
+func PlanInfrastructureEndpoint(ctx context.Context, projectID string, body apiv1.CreateClusterSpec,
+	projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider,
+	seedsGetter provider.SeedsGetter, credentialManager provider.PresetProvider, exposeStrategy kubermaticv1.ExposeStrategy,
+	userInfoGetter provider.UserInfoGetter, caBundle *x509.CertPool, forbidPresetInheritance bool) (interface{}, error) {
+	privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
+
+	project, err := common.GetProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, projectID, &provider.ProjectGetOptions{IncludeUninitialized: false})
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	adminUserInfo, err := userInfoGetter(ctx, "")
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+	_, dc, err := provider.DatacenterFromSeedMap(adminUserInfo, seedsGetter, body.Cluster.Spec.Cloud.DatacenterName)
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	InheritDefaultPreset(project, &body, forbidPresetInheritance)
+	partialCluster, err := GenerateCluster(ctx, projectID, body, seedsGetter, credentialManager, exposeStrategy, userInfoGetter, caBundle)
+	if err != nil {
+		return nil, err
+	}
+	partialCluster.Name = planClusterID
+
+	secretKeyGetter := provider.SecretKeySelectorValueFuncFactory(ctx, privilegedClusterProvider.GetSeedClusterAdminRuntimeClient())
+	cloudProvider, err := cloud.Provider(dc, secretKeyGetter, caBundle)
+	if err != nil {
+		return nil, errors.New(http.StatusInternalServerError, err.Error())
+	}
+
+	var resources []provider.PlannedInfrastructureResource
+	switch cloudProvider := cloudProvider.(type) {
+	case *azure.Azure:
+		resources, err = cloudProvider.PlanCloudProvider(partialCluster)
+		if err != nil {
+			return nil, errors.NewBadRequest("failed to plan infrastructure: %v", err)
+		}
+	default:
+		return nil, errors.NewNotImplemented()
+	}
+
+	result := make([]apiv2.ClusterInfrastructurePlanResource, 0, len(resources))
+	for _, resource := range resources {
+		rules := make([]apiv2.ClusterInfrastructurePlanSecurityRule, 0, len(resource.Rules))
+		for _, rule := range resource.Rules {
+			rules = append(rules, apiv2.ClusterInfrastructurePlanSecurityRule(rule))
+		}
+		result = append(result, apiv2.ClusterInfrastructurePlanResource{
+			Type:            resource.Type,
+			Name:            resource.Name,
+			Action:          string(resource.Action),
+			Parent:          resource.Parent,
+			Tags:            resource.Tags,
+			AddressPrefixes: resource.AddressPrefixes,
+			Rules:           rules,
+			Properties:      resource.Properties,
+		})
+	}
+
+	return result, nil
+}
+
 func GetMetricsEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectID, clusterID string, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider) (interface{}, error) {
 	privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
 	clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
@@ -917,10 +1149,12 @@ func ConvertInternalClusterToExternal(internalCluster *kubermaticv1.Cluster, dat
 		},
 		Labels:          internalCluster.Labels,
 		InheritedLabels: internalCluster.Status.InheritedLabels,
+		InheritedPreset: internalCluster.Annotations[kubermaticv1.InheritedPresetAnnotation],
 		Spec: apiv1.ClusterSpec{
 			Cloud:                                internalCluster.Spec.Cloud,
 			Version:                              internalCluster.Spec.Version,
 			MachineNetworks:                      internalCluster.Spec.MachineNetworks,
+			AdditionalClouds:                     internalCluster.Spec.AdditionalClouds,
 			OIDC:                                 internalCluster.Spec.OIDC,
 			UpdateWindow:                         internalCluster.Spec.UpdateWindow,
 			AuditLogging:                         internalCluster.Spec.AuditLogging,
@@ -932,13 +1166,17 @@ func ConvertInternalClusterToExternal(internalCluster *kubermaticv1.Cluster, dat
 			PodNodeSelectorAdmissionPluginConfig: internalCluster.Spec.PodNodeSelectorAdmissionPluginConfig,
 			ServiceAccount:                       internalCluster.Spec.ServiceAccount,
 			MLA:                                  internalCluster.Spec.MLA,
+			LogShipping:                          internalCluster.Spec.LogShipping,
+			PatchUpgradePolicy:                   internalCluster.Spec.PatchUpgradePolicy,
 			ContainerRuntime:                     internalCluster.Spec.ContainerRuntime,
 			ClusterNetwork:                       &internalCluster.Spec.ClusterNetwork,
 		},
 		Status: apiv1.ClusterStatus{
-			Version:              internalCluster.Spec.Version,
-			URL:                  internalCluster.Address.URL,
-			ExternalCCMMigration: convertInternalCCMStatusToExternal(internalCluster, datacenter),
+			Version:                internalCluster.Spec.Version,
+			URL:                    internalCluster.Address.URL,
+			ExternalCCMMigration:   convertInternalCCMStatusToExternal(internalCluster, datacenter),
+			CloudProviderResources: internalCluster.Status.CloudProviderResources,
+			NodeProblems:           internalCluster.Status.NodeProblems,
 		},
 		Type: apiv1.KubernetesClusterType,
 	}
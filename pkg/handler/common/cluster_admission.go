@@ -0,0 +1,181 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/util/errors"
+)
+
+const defaultClusterAdmissionTimeout = 10 * time.Second
+
+// ClusterAdmissionRequest is sent to the cluster admission webhook before a cluster is created.
+type ClusterAdmissionRequest struct {
+	User    ClusterAdmissionUser    `json:"user"`
+	Project ClusterAdmissionProject `json:"project"`
+	// Cluster is the cluster to be created, without cloud provider credentials.
+	Cluster *apiv1.Cluster `json:"cluster"`
+}
+
+// ClusterAdmissionUser is the user creating the cluster.
+type ClusterAdmissionUser struct {
+	Email   string `json:"email"`
+	Group   string `json:"group"`
+	IsAdmin bool   `json:"isAdmin"`
+}
+
+// ClusterAdmissionProject is the project the cluster is created in.
+type ClusterAdmissionProject struct {
+	ID     string            `json:"id"`
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// ClusterAdmissionResponse is the answer of the cluster admission webhook.
+type ClusterAdmissionResponse struct {
+	// Allowed is whether the cluster may be created.
+	Allowed bool `json:"allowed"`
+	// Message is shown to the user if the cluster is denied.
+	Message string `json:"message,omitempty"`
+	// Patch is an optional JSON merge patch applied to the cluster of the request. It can change
+	// the same fields as patching a cluster through the API, except for the datacenter.
+	Patch json.RawMessage `json:"patch,omitempty"`
+}
+
+// AdmitCluster calls the cluster admission webhook, if one is configured, and returns the cluster
+// with the changes requested by the webhook applied. Depending on the failure policy of the
+// webhook, the cluster is either denied or admitted unchanged if the webhook cannot be called.
+func AdmitCluster(ctx context.Context, webhook *kubermaticv1.ClusterAdmissionWebhook, userInfo *provider.UserInfo, project *kubermaticv1.Project, cluster *kubermaticv1.Cluster, dc *kubermaticv1.Datacenter) (*kubermaticv1.Cluster, error) {
+	if webhook == nil || webhook.URL == "" {
+		return cluster, nil
+	}
+
+	request := ClusterAdmissionRequest{
+		User: ClusterAdmissionUser{
+			Email:   userInfo.Email,
+			Group:   userInfo.Group,
+			IsAdmin: userInfo.IsAdmin,
+		},
+		Project: ClusterAdmissionProject{
+			ID:     project.Name,
+			Name:   project.Spec.Name,
+			Labels: project.Labels,
+		},
+		Cluster: ConvertInternalClusterToExternal(cluster, dc, false),
+	}
+
+	response, err := callClusterAdmissionWebhook(ctx, webhook, request)
+	if err != nil {
+		if webhook.FailurePolicy == kubermaticv1.ClusterAdmissionFailurePolicyIgnore {
+			kubermaticlog.Logger.Warnw("Failed to call cluster admission webhook, admitting cluster", "cluster", cluster.Name, "error", err)
+			return cluster, nil
+		}
+		return nil, errors.New(http.StatusInternalServerError, fmt.Sprintf("failed to call cluster admission webhook: %v", err))
+	}
+
+	if !response.Allowed {
+		message := response.Message
+		if message == "" {
+			message = "cluster creation denied by admission webhook"
+		}
+		return nil, errors.New(http.StatusForbidden, message)
+	}
+
+	if len(response.Patch) == 0 {
+		return cluster, nil
+	}
+
+	patchedCluster, err := patchInternalCluster(cluster, dc, response.Patch)
+	if err != nil {
+		return nil, errors.New(http.StatusInternalServerError, fmt.Sprintf("invalid patch returned by cluster admission webhook: %v", err))
+	}
+	if patchedCluster.Spec.Cloud.DatacenterName != cluster.Spec.Cloud.DatacenterName {
+		return nil, errors.New(http.StatusInternalServerError, "cluster admission webhook must not change the datacenter")
+	}
+
+	// The project ID label is required for the ownership of the cluster and cannot be changed.
+	if patchedCluster.Labels == nil {
+		patchedCluster.Labels = map[string]string{}
+	}
+	patchedCluster.Labels[kubermaticv1.ProjectIDLabelKey] = cluster.Labels[kubermaticv1.ProjectIDLabelKey]
+
+	return patchedCluster, nil
+}
+
+func callClusterAdmissionWebhook(ctx context.Context, webhook *kubermaticv1.ClusterAdmissionWebhook, request ClusterAdmissionRequest) (*ClusterAdmissionResponse, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %v", err)
+	}
+
+	timeout := defaultClusterAdmissionTimeout
+	if webhook.TimeoutSeconds > 0 {
+		timeout = time.Duration(webhook.TimeoutSeconds) * time.Second
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if webhook.CABundle != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(webhook.CABundle)) {
+			return nil, fmt.Errorf("CA bundle contains no valid certificate")
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+
+	resp, err := (&http.Client{Transport: transport}).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	response := &ClusterAdmissionResponse{}
+	if err := json.Unmarshal(respBody, response); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %v", err)
+	}
+
+	return response, nil
+}
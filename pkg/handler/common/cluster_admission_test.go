@@ -0,0 +1,158 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/util/errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAdmitCluster(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		response             string
+		statusCode           int
+		failurePolicy        kubermaticv1.ClusterAdmissionFailurePolicy
+		expectedCode         int
+		expectedLabels       map[string]string
+		expectedAuditLogging bool
+	}{
+		{
+			name:           "cluster is admitted unchanged",
+			response:       `{"allowed": true}`,
+			expectedLabels: map[string]string{kubermaticv1.ProjectIDLabelKey: "my-project", "team": "a"},
+		},
+		{
+			name:         "cluster is denied",
+			response:     `{"allowed": false, "message": "no cost center"}`,
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:                 "cluster is mutated",
+			response:             `{"allowed": true, "patch": {"labels": {"cmdb-id": "1234", "project-id": "other"}, "spec": {"auditLogging": {"enabled": true}}}}`,
+			expectedLabels:       map[string]string{kubermaticv1.ProjectIDLabelKey: "my-project", "team": "a", "cmdb-id": "1234"},
+			expectedAuditLogging: true,
+		},
+		{
+			name:         "datacenter must not be changed",
+			response:     `{"allowed": true, "patch": {"spec": {"cloud": {"dc": "other"}}}}`,
+			expectedCode: http.StatusInternalServerError,
+		},
+		{
+			name:         "failing webhook denies the cluster",
+			statusCode:   http.StatusInternalServerError,
+			expectedCode: http.StatusInternalServerError,
+		},
+		{
+			name:           "failing webhook is ignored",
+			statusCode:     http.StatusInternalServerError,
+			failurePolicy:  kubermaticv1.ClusterAdmissionFailurePolicyIgnore,
+			expectedLabels: map[string]string{kubermaticv1.ProjectIDLabelKey: "my-project", "team": "a"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				request     ClusterAdmissionRequest
+				requestBody []byte
+			)
+			server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requestBody, _ = ioutil.ReadAll(r.Body)
+				if err := json.Unmarshal(requestBody, &request); err != nil {
+					t.Errorf("failed to decode request: %v", err)
+				}
+				if tc.statusCode != 0 {
+					w.WriteHeader(tc.statusCode)
+					return
+				}
+				_, _ = w.Write([]byte(tc.response))
+			}))
+			defer server.Close()
+
+			webhook := &kubermaticv1.ClusterAdmissionWebhook{
+				URL:           server.URL,
+				CABundle:      string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})),
+				FailurePolicy: tc.failurePolicy,
+			}
+			cluster := &kubermaticv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "abcd",
+					Labels: map[string]string{kubermaticv1.ProjectIDLabelKey: "my-project", "team": "a"},
+				},
+				Spec: kubermaticv1.ClusterSpec{
+					HumanReadableName: "my-cluster",
+					Cloud: kubermaticv1.CloudSpec{
+						DatacenterName: "my-dc",
+						Fake:           &kubermaticv1.FakeCloudSpec{Token: "secret-token"},
+					},
+				},
+			}
+			project := &kubermaticv1.Project{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-project"},
+				Spec:       kubermaticv1.ProjectSpec{Name: "My Project"},
+			}
+			userInfo := &provider.UserInfo{Email: "john@acme.com", Group: "owners-my-project"}
+
+			admitted, err := AdmitCluster(context.Background(), webhook, userInfo, project, cluster, &kubermaticv1.Datacenter{})
+			if tc.expectedCode != 0 {
+				httpErr, ok := err.(errors.HTTPError)
+				if !ok {
+					t.Fatalf("expected an HTTP error with code %d, got %v", tc.expectedCode, err)
+				}
+				if httpErr.StatusCode() != tc.expectedCode {
+					t.Fatalf("expected status code %d, got %d: %v", tc.expectedCode, httpErr.StatusCode(), err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to admit cluster: %v", err)
+			}
+
+			if request.User.Email != userInfo.Email || request.Project.ID != project.Name || request.Cluster.Name != "my-cluster" {
+				t.Errorf("unexpected request %+v", request)
+			}
+			if strings.Contains(string(requestBody), "secret-token") {
+				t.Error("expected credentials to be removed from the request")
+			}
+
+			if len(admitted.Labels) != len(tc.expectedLabels) {
+				t.Fatalf("expected labels %v, got %v", tc.expectedLabels, admitted.Labels)
+			}
+			for k, v := range tc.expectedLabels {
+				if admitted.Labels[k] != v {
+					t.Fatalf("expected labels %v, got %v", tc.expectedLabels, admitted.Labels)
+				}
+			}
+			if auditLogging := admitted.Spec.AuditLogging != nil && admitted.Spec.AuditLogging.Enabled; auditLogging != tc.expectedAuditLogging {
+				t.Errorf("expected audit logging to be %v, got %v", tc.expectedAuditLogging, auditLogging)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"crypto/x509"
+	"net/http"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/handler/middleware"
+	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	kubernetesprovider "k8c.io/kubermatic/v2/pkg/provider/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/util/errors"
+	"k8c.io/kubermatic/v2/pkg/validation"
+)
+
+func ListClusterSpecRevisionsEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectID, clusterID string,
+	projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider) (interface{}, error) {
+	clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
+	privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
+
+	project, err := common.GetProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, projectID, nil)
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	cluster, err := GetInternalCluster(ctx, userInfoGetter, clusterProvider, privilegedClusterProvider, project, projectID, clusterID, &provider.ClusterGetOptions{})
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	revisions, err := privilegedClusterProvider.ListSpecRevisionsUnsecured(cluster)
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	result := make([]apiv2.ClusterSpecRevision, len(revisions))
+	for i, revision := range revisions {
+		result[i] = convertInternalClusterSpecRevisionToExternal(&revision)
+	}
+	return result, nil
+}
+
+func RollbackClusterSpecEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectID, clusterID string, revision int64,
+	seedsGetter provider.SeedsGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider,
+	caBundle *x509.CertPool) (interface{}, error) {
+	clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
+	privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
+
+	project, err := common.GetProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, projectID, nil)
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	oldInternalCluster, err := GetInternalCluster(ctx, userInfoGetter, clusterProvider, privilegedClusterProvider, project, projectID, clusterID, &provider.ClusterGetOptions{})
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	specRevision, err := privilegedClusterProvider.GetSpecRevisionUnsecured(oldInternalCluster, revision)
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	userInfo, err := userInfoGetter(ctx, "")
+	if err != nil {
+		return nil, errors.New(http.StatusInternalServerError, err.Error())
+	}
+	_, dc, err := provider.DatacenterFromSeedMap(userInfo, seedsGetter, oldInternalCluster.Spec.Cloud.DatacenterName)
+	if err != nil {
+		return nil, errors.New(http.StatusInternalServerError, err.Error())
+	}
+
+	newInternalCluster := oldInternalCluster.DeepCopy()
+	rollbackClusterSpec(&newInternalCluster.Spec, specRevision.Spec.ClusterSpec.DeepCopy())
+
+	// Enforce audit logging
+	if dc.Spec.EnforceAuditLogging {
+		newInternalCluster.Spec.AuditLogging = &kubermaticv1.AuditLoggingSettings{
+			Enabled: true,
+		}
+	}
+
+	// Enforce PodSecurityPolicy
+	if dc.Spec.EnforcePodSecurityPolicy {
+		newInternalCluster.Spec.UsePodSecurityPolicyAdmissionPlugin = true
+	}
+
+	assertedClusterProvider, ok := clusterProvider.(*kubernetesprovider.ClusterProvider)
+	if !ok {
+		return nil, errors.New(http.StatusInternalServerError, "failed to assert clusterProvider")
+	}
+	if err := validation.ValidateUpdateCluster(ctx, newInternalCluster, oldInternalCluster, dc, assertedClusterProvider, caBundle); err != nil {
+		return nil, errors.NewBadRequest("invalid cluster: %v", err)
+	}
+	if err = validation.ValidateUpdateWindow(newInternalCluster.Spec.UpdateWindow); err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	updatedCluster, err := updateCluster(ctx, userInfoGetter, clusterProvider, privilegedClusterProvider, project, newInternalCluster)
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	if err := privilegedClusterProvider.RecordSpecRevisionUnsecured(oldInternalCluster, updatedCluster, userInfo.Email); err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	return ConvertInternalClusterToExternal(updatedCluster, dc, true), nil
+}
+
+// rollbackClusterSpec restores the fields of the spec which can safely be changed on a
+// running cluster. Fields like the cloud spec, the version or the networking are kept,
+// as reverting them could break the cluster or its nodes.
+func rollbackClusterSpec(spec *kubermaticv1.ClusterSpec, revision *kubermaticv1.ClusterSpec) {
+	spec.HumanReadableName = revision.HumanReadableName
+	spec.UpdateWindow = revision.UpdateWindow
+	spec.UsePodSecurityPolicyAdmissionPlugin = revision.UsePodSecurityPolicyAdmissionPlugin
+	spec.UsePodNodeSelectorAdmissionPlugin = revision.UsePodNodeSelectorAdmissionPlugin
+	spec.PodNodeSelectorAdmissionPluginConfig = revision.PodNodeSelectorAdmissionPluginConfig
+	spec.AdmissionPlugins = revision.AdmissionPlugins
+	spec.AuditLogging = revision.AuditLogging
+	spec.OPAIntegration = revision.OPAIntegration
+	spec.MLA = revision.MLA
+	spec.LogShipping = revision.LogShipping
+	spec.PatchUpgradePolicy = revision.PatchUpgradePolicy
+}
+
+func convertInternalClusterSpecRevisionToExternal(revision *kubermaticv1.ClusterSpecRevision) apiv2.ClusterSpecRevision {
+	return apiv2.ClusterSpecRevision{
+		Revision:  revision.Spec.Revision,
+		Author:    revision.Spec.Author,
+		Timestamp: apiv1.NewTime(revision.Spec.Timestamp.Time),
+		Diff:      revision.Spec.Diff,
+	}
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/handler/middleware"
+	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/util/errors"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+func ListClusterNamespacesEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectID, clusterID string,
+	projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider) (interface{}, error) {
+	privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
+
+	cluster, err := getClusterForNamespaces(ctx, userInfoGetter, projectID, clusterID, projectProvider, privilegedProjectProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces, err := privilegedClusterProvider.ListNamespacesUnsecured(cluster)
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	result := make([]apiv2.ClusterNamespace, len(namespaces))
+	for i := range namespaces {
+		result[i] = convertInternalClusterNamespaceToExternal(&namespaces[i])
+	}
+	return result, nil
+}
+
+func CreateClusterNamespaceEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectID, clusterID, namespace, templateName string,
+	projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider,
+	namespaceTemplateProvider provider.PrivilegedNamespaceTemplateProvider) (interface{}, error) {
+	privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
+
+	if err := validateClusterNamespaceName(namespace); err != nil {
+		return nil, err
+	}
+
+	cluster, err := getClusterForNamespaces(ctx, userInfoGetter, projectID, clusterID, projectProvider, privilegedProjectProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	var template *kubermaticv1.NamespaceTemplate
+	if templateName != "" {
+		template, err = namespaceTemplateProvider.GetUnsecured(templateName)
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				return nil, errors.NewBadRequest("namespace template %q does not exist", templateName)
+			}
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+	}
+
+	userInfo, err := userInfoGetter(ctx, "")
+	if err != nil {
+		return nil, errors.New(http.StatusInternalServerError, err.Error())
+	}
+
+	clusterNamespace, err := privilegedClusterProvider.CreateNamespaceUnsecured(cluster, namespace, template, userInfo.Email)
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	return convertInternalClusterNamespaceToExternal(clusterNamespace), nil
+}
+
+func DeleteClusterNamespaceEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectID, clusterID, namespace string,
+	projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider) (interface{}, error) {
+	privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
+
+	cluster, err := getClusterForNamespaces(ctx, userInfoGetter, projectID, clusterID, projectProvider, privilegedProjectProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := privilegedClusterProvider.DeleteNamespaceUnsecured(cluster, namespace); err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	return nil, nil
+}
+
+// getClusterForNamespaces returns the cluster if the user has access to it.
+func getClusterForNamespaces(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectID, clusterID string,
+	projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider) (*kubermaticv1.Cluster, error) {
+	clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
+	privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
+
+	project, err := common.GetProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, projectID, nil)
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	cluster, err := GetInternalCluster(ctx, userInfoGetter, clusterProvider, privilegedClusterProvider, project, projectID, clusterID, &provider.ClusterGetOptions{})
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+	return cluster, nil
+}
+
+// validateClusterNamespaceName rejects invalid names and the namespaces which belong to Kubernetes.
+func validateClusterNamespaceName(namespace string) error {
+	if msgs := validation.IsDNS1123Label(namespace); len(msgs) > 0 {
+		return errors.NewBadRequest("invalid namespace name %q: %s", namespace, strings.Join(msgs, ", "))
+	}
+	if namespace == "default" || strings.HasPrefix(namespace, "kube-") {
+		return errors.NewBadRequest("the namespace %q is reserved", namespace)
+	}
+	return nil
+}
+
+func convertInternalClusterNamespaceToExternal(clusterNamespace *kubermaticv1.ClusterNamespace) apiv2.ClusterNamespace {
+	return apiv2.ClusterNamespace{
+		Name:              clusterNamespace.Spec.Namespace,
+		Template:          clusterNamespace.Spec.Template,
+		CreatedBy:         clusterNamespace.Spec.CreatedBy,
+		CreationTimestamp: apiv1.NewTime(clusterNamespace.CreationTimestamp.Time),
+		Status:            clusterNamespace.Status,
+	}
+}
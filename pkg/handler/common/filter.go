@@ -16,7 +16,12 @@ limitations under the License.
 
 package common
 
-import "math"
+import (
+	"math"
+	"strings"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+)
 
 // Filter is a CPU filter function applied to a single record.
 type Filter func(record, min, max int) bool
@@ -50,3 +55,86 @@ func FilterGPU(record int, enableGPU bool) bool {
 
 	return true
 }
+
+// FlavorCategory returns the size category of a machine flavor with the given number of vCPUs and
+// memory in GB.
+func FlavorCategory(cpus, memory int) kubermaticv1.MachineFlavorCategory {
+	switch {
+	case cpus <= 2 && memory <= 8:
+		return kubermaticv1.MachineFlavorCategorySmall
+	case cpus <= 8 && memory <= 32:
+		return kubermaticv1.MachineFlavorCategoryMedium
+	case cpus <= 32 && memory <= 128:
+		return kubermaticv1.MachineFlavorCategoryLarge
+	default:
+		return kubermaticv1.MachineFlavorCategoryXLarge
+	}
+}
+
+// MachineFlavorFilter returns the machine flavor filter of the cloud provider from the global
+// settings, restricted further by the filter of a datacenter, if any.
+func MachineFlavorFilter(settings *kubermaticv1.KubermaticSetting, providerName string, dcFilter *kubermaticv1.MachineFlavorFilter) kubermaticv1.MachineFlavorFilter {
+	var filter kubermaticv1.MachineFlavorFilter
+	if settings != nil {
+		if providerFilter, ok := settings.Spec.MachineFlavorFilters[providerName]; ok {
+			filter = *providerFilter.DeepCopy()
+		}
+	}
+
+	if dcFilter == nil {
+		return filter
+	}
+	if len(dcFilter.Allowed) > 0 {
+		filter.Allowed = dcFilter.Allowed
+	}
+	if len(dcFilter.Categories) > 0 {
+		filter.Categories = dcFilter.Categories
+	}
+	filter.Denied = append(filter.Denied, dcFilter.Denied...)
+
+	return filter
+}
+
+// matchesFlavor returns whether the name of a machine flavor matches any of the patterns, which
+// match all flavors with their prefix if they end with "*".
+func matchesFlavor(name string, patterns []string) bool {
+	name = strings.ToLower(name)
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(pattern)
+		if prefix := strings.TrimSuffix(pattern, "*"); prefix != pattern {
+			if strings.HasPrefix(name, prefix) {
+				return true
+			}
+		} else if name == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+// IsFlavorAllowed returns whether a machine flavor is allowed and not denied by the filter.
+func IsFlavorAllowed(name string, filter kubermaticv1.MachineFlavorFilter) bool {
+	if len(filter.Allowed) > 0 && !matchesFlavor(name, filter.Allowed) {
+		return false
+	}
+	return !matchesFlavor(name, filter.Denied)
+}
+
+// FilterFlavor returns whether a machine flavor with the given number of vCPUs and memory in GB is
+// allowed by the filter, including its categories.
+func FilterFlavor(name string, cpus, memory int, filter kubermaticv1.MachineFlavorFilter) bool {
+	if !IsFlavorAllowed(name, filter) {
+		return false
+	}
+	if len(filter.Categories) == 0 {
+		return true
+	}
+
+	category := FlavorCategory(cpus, memory)
+	for _, c := range filter.Categories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+)
+
+func TestMachineFlavorFilter(t *testing.T) {
+	settings := &kubermaticv1.KubermaticSetting{
+		Spec: kubermaticv1.SettingSpec{
+			MachineFlavorFilters: map[string]kubermaticv1.MachineFlavorFilter{
+				"azure": {
+					Allowed:    []string{"Standard_D*"},
+					Denied:     []string{"Standard_D64s_v3"},
+					Categories: []kubermaticv1.MachineFlavorCategory{kubermaticv1.MachineFlavorCategoryMedium},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name         string
+		providerName string
+		dcFilter     *kubermaticv1.MachineFlavorFilter
+		expected     kubermaticv1.MachineFlavorFilter
+	}{
+		{
+			name:         "provider without filter",
+			providerName: "aws",
+		},
+		{
+			name:         "provider filter",
+			providerName: "azure",
+			expected:     settings.Spec.MachineFlavorFilters["azure"],
+		},
+		{
+			name:         "datacenter filter replaces allowed flavors and adds denied ones",
+			providerName: "azure",
+			dcFilter: &kubermaticv1.MachineFlavorFilter{
+				Allowed: []string{"Standard_B*"},
+				Denied:  []string{"Standard_B1ls"},
+			},
+			expected: kubermaticv1.MachineFlavorFilter{
+				Allowed:    []string{"Standard_B*"},
+				Denied:     []string{"Standard_D64s_v3", "Standard_B1ls"},
+				Categories: []kubermaticv1.MachineFlavorCategory{kubermaticv1.MachineFlavorCategoryMedium},
+			},
+		},
+		{
+			name:         "datacenter filter replaces categories",
+			providerName: "aws",
+			dcFilter: &kubermaticv1.MachineFlavorFilter{
+				Categories: []kubermaticv1.MachineFlavorCategory{kubermaticv1.MachineFlavorCategorySmall},
+			},
+			expected: kubermaticv1.MachineFlavorFilter{
+				Categories: []kubermaticv1.MachineFlavorCategory{kubermaticv1.MachineFlavorCategorySmall},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			filter := MachineFlavorFilter(settings, tc.providerName, tc.dcFilter)
+			if diff := deep.Equal(filter, tc.expected); diff != nil {
+				t.Errorf("unexpected filter: %v", diff)
+			}
+		})
+	}
+
+	// the filter of the settings must not be modified
+	if denied := settings.Spec.MachineFlavorFilters["azure"].Denied; len(denied) != 1 {
+		t.Errorf("the denied flavors of the settings have been modified: %v", denied)
+	}
+}
+
+func TestFilterFlavor(t *testing.T) {
+	filter := kubermaticv1.MachineFlavorFilter{
+		Allowed:    []string{"standard_d*", "Standard_B2s"},
+		Denied:     []string{"Standard_D2s_*"},
+		Categories: []kubermaticv1.MachineFlavorCategory{kubermaticv1.MachineFlavorCategorySmall, kubermaticv1.MachineFlavorCategoryMedium},
+	}
+
+	testCases := []struct {
+		name     string
+		flavor   string
+		cpus     int
+		memory   int
+		filter   kubermaticv1.MachineFlavorFilter
+		expected bool
+	}{
+		{
+			name:     "empty filter",
+			flavor:   "Standard_M128s",
+			cpus:     128,
+			memory:   2048,
+			expected: true,
+		},
+		{
+			name:     "allowed by prefix",
+			flavor:   "Standard_D4s_v3",
+			cpus:     4,
+			memory:   16,
+			filter:   filter,
+			expected: true,
+		},
+		{
+			name:     "allowed by name",
+			flavor:   "Standard_B2s",
+			cpus:     2,
+			memory:   4,
+			filter:   filter,
+			expected: true,
+		},
+		{
+			name:   "not allowed",
+			flavor: "Standard_B4ms",
+			cpus:   4,
+			memory: 16,
+			filter: filter,
+		},
+		{
+			name:   "denied",
+			flavor: "Standard_D2s_v3",
+			cpus:   2,
+			memory: 8,
+			filter: filter,
+		},
+		{
+			name:   "not in allowed categories",
+			flavor: "Standard_D32s_v3",
+			cpus:   32,
+			memory: 128,
+			filter: filter,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if allowed := FilterFlavor(tc.flavor, tc.cpus, tc.memory, tc.filter); allowed != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, allowed)
+			}
+		})
+	}
+}
@@ -0,0 +1,354 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"gopkg.in/square/go-jose.v2/jwt"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/handler/middleware"
+	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/serviceaccount"
+	kcerrors "k8c.io/kubermatic/v2/pkg/util/errors"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/kubernetes"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// SharedKubeconfigPath is the path under which kubeconfig sharing links can be redeemed.
+	SharedKubeconfigPath = "/api/v2/kubeconfig/shared"
+
+	kubeconfigShareLinkAudience   = "kubermatic-kubeconfig-share"
+	defaultKubeconfigShareLinkTTL = time.Hour
+	maxKubeconfigShareLinkTTL     = 24 * time.Hour
+	// minKubeconfigShareTokenTTL is the shortest lifetime the API server of a user
+	// cluster accepts for requested service account tokens.
+	minKubeconfigShareTokenTTL = 10 * time.Minute
+
+	// Every sharing link is backed by a service account in this namespace of the user cluster.
+	// Its tokens are requested with the lifetime of the link, deleting the service account
+	// revokes the link and all tokens issued for it.
+	kubeconfigShareNamespace          = "kubermatic-kubeconfig-share"
+	kubeconfigShareClusterRoleBinding = "kubermatic:kubeconfig-share"
+	kubeconfigShareServiceAccountName = "kubeconfig-share-"
+	kubeconfigShareExpiryAnnotation   = "kubermatic.io/kubeconfig-share-expiry"
+	kubeconfigShareCreatorAnnotation  = "kubermatic.io/kubeconfig-share-creator"
+	// viewersClusterRole is the cluster role of project viewers in user clusters.
+	viewersClusterRole = "system:kubermatic:viewers"
+)
+
+var errKubeconfigShareLinkRevoked = kcerrors.New(http.StatusUnauthorized, "invalid kubeconfig sharing link: the link has been revoked")
+
+// KubeconfigShareLinkClaims are the verified claims of a kubeconfig sharing link.
+type KubeconfigShareLinkClaims struct {
+	ProjectID string
+	ClusterID string
+	LinkID    string
+	Expiry    time.Time
+}
+
+// CreateKubeconfigShareLinkEndpoint generates a signed link that allows anyone in possession of it
+// to download a viewer kubeconfig of the given cluster until the link expires or is revoked.
+func CreateKubeconfigShareLinkEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter, tokenGenerator serviceaccount.TokenGenerator, projectID, clusterID string, spec apiv1.KubeconfigShareLinkSpec, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider) (interface{}, error) {
+	cluster, adminUserInfo, err := getClusterForKubeconfigSharing(ctx, userInfoGetter, projectID, clusterID, projectProvider, privilegedProjectProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := defaultKubeconfigShareLinkTTL
+	if spec.ExpiresIn != 0 {
+		ttl = time.Duration(spec.ExpiresIn) * time.Second
+		if ttl < 0 || ttl > maxKubeconfigShareLinkTTL {
+			return nil, kcerrors.NewBadRequest("the link lifetime must be between 1 and %d seconds", int64(maxKubeconfigShareLinkTTL.Seconds()))
+		}
+	}
+
+	clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
+	client, err := clusterProvider.GetAdminClientForCustomerCluster(ctx, cluster)
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	now := serviceaccount.Now()
+	expiry := now.Add(ttl)
+
+	if err := ensureKubeconfigShareRBAC(ctx, client); err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+	if err := deleteExpiredKubeconfigShareLinks(ctx, client, now); err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	sa := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: kubeconfigShareNamespace,
+			Name:      kubeconfigShareServiceAccountName + rand.String(10),
+			Annotations: map[string]string{
+				kubeconfigShareExpiryAnnotation:  expiry.UTC().Format(time.RFC3339),
+				kubeconfigShareCreatorAnnotation: adminUserInfo.Email,
+			},
+		},
+	}
+	if err := client.Create(ctx, sa); err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	claims := &jwt.Claims{
+		ID:        sa.Name,
+		Subject:   cluster.Name,
+		Audience:  jwt.Audience{kubeconfigShareLinkAudience},
+		IssuedAt:  jwt.NewNumericDate(now),
+		NotBefore: jwt.NewNumericDate(now),
+		Expiry:    jwt.NewNumericDate(expiry),
+	}
+	customClaims := &serviceaccount.CustomTokenClaim{
+		Email:     adminUserInfo.Email,
+		ProjectID: projectID,
+	}
+	token, err := tokenGenerator.Generate(claims, customClaims)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign the kubeconfig sharing link: %v", err)
+	}
+
+	return apiv1.KubeconfigShareLink{
+		ID:     sa.Name,
+		URL:    fmt.Sprintf("%s?token=%s", SharedKubeconfigPath, url.QueryEscape(token)),
+		Expiry: apiv1.NewTime(expiry),
+	}, nil
+}
+
+// RevokeKubeconfigShareLinkEndpoint revokes a kubeconfig sharing link, including the tokens
+// that have already been issued for it.
+func RevokeKubeconfigShareLinkEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectID, clusterID, linkID string, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider) (interface{}, error) {
+	cluster, _, err := getClusterForKubeconfigSharing(ctx, userInfoGetter, projectID, clusterID, projectProvider, privilegedProjectProvider)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(linkID, kubeconfigShareServiceAccountName) {
+		return nil, kcerrors.NewNotFound("kubeconfig sharing link", linkID)
+	}
+
+	clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
+	client, err := clusterProvider.GetAdminClientForCustomerCluster(ctx, cluster)
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	sa := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: kubeconfigShareNamespace,
+			Name:      linkID,
+		},
+	}
+	if err := client.Delete(ctx, sa); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, kcerrors.NewNotFound("kubeconfig sharing link", linkID)
+		}
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	return nil, nil
+}
+
+// getClusterForKubeconfigSharing returns the cluster and verifies that the user is allowed to share
+// its kubeconfig, which viewers are not.
+func getClusterForKubeconfigSharing(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectID, clusterID string, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider) (*kubermaticv1.Cluster, *provider.UserInfo, error) {
+	cluster, err := GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, projectID, clusterID, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	adminUserInfo, err := userInfoGetter(ctx, "")
+	if err != nil {
+		return nil, nil, common.KubernetesErrorToHTTPError(err)
+	}
+	if !adminUserInfo.IsAdmin {
+		userInfo, err := userInfoGetter(ctx, projectID)
+		if err != nil {
+			return nil, nil, common.KubernetesErrorToHTTPError(err)
+		}
+		if strings.HasPrefix(userInfo.Group, "viewers") {
+			return nil, nil, kcerrors.New(http.StatusForbidden, "viewers are not allowed to share the cluster kubeconfig")
+		}
+	}
+
+	return cluster, adminUserInfo, nil
+}
+
+// ensureKubeconfigShareRBAC creates the namespace of the sharing link service accounts and
+// grants them the permissions of project viewers.
+func ensureKubeconfigShareRBAC(ctx context.Context, client ctrlruntimeclient.Client) error {
+	namespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: kubeconfigShareNamespace,
+		},
+	}
+	if err := client.Create(ctx, namespace); err != nil && !kerrors.IsAlreadyExists(err) {
+		return err
+	}
+
+	binding := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: kubeconfigShareClusterRoleBinding,
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				APIGroup: rbacv1.GroupName,
+				Kind:     rbacv1.GroupKind,
+				Name:     serviceaccountGroup(kubeconfigShareNamespace),
+			},
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     viewersClusterRole,
+		},
+	}
+	if err := client.Create(ctx, binding); err != nil && !kerrors.IsAlreadyExists(err) {
+		return err
+	}
+
+	return nil
+}
+
+// deleteExpiredKubeconfigShareLinks deletes the service accounts of links that have expired.
+func deleteExpiredKubeconfigShareLinks(ctx context.Context, client ctrlruntimeclient.Client, now time.Time) error {
+	serviceAccounts := &corev1.ServiceAccountList{}
+	if err := client.List(ctx, serviceAccounts, ctrlruntimeclient.InNamespace(kubeconfigShareNamespace)); err != nil {
+		return err
+	}
+
+	for i, sa := range serviceAccounts.Items {
+		expiry, err := time.Parse(time.RFC3339, sa.Annotations[kubeconfigShareExpiryAnnotation])
+		if err != nil || expiry.After(now) {
+			continue
+		}
+		if err := client.Delete(ctx, &serviceAccounts.Items[i]); err != nil && !kerrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func serviceaccountGroup(namespace string) string {
+	return "system:serviceaccounts:" + namespace
+}
+
+// ParseKubeconfigShareToken verifies the token of a kubeconfig sharing link and returns its claims.
+func ParseKubeconfigShareToken(tokenAuthenticator serviceaccount.TokenAuthenticator, token string) (*KubeconfigShareLinkClaims, error) {
+	claims, customClaims, err := tokenAuthenticator.Authenticate(token)
+	if err != nil {
+		return nil, kcerrors.New(http.StatusUnauthorized, fmt.Sprintf("invalid kubeconfig sharing link: %v", err))
+	}
+	if !claims.Audience.Contains(kubeconfigShareLinkAudience) || claims.Subject == "" || claims.ID == "" || claims.Expiry == nil || customClaims.ProjectID == "" {
+		return nil, kcerrors.New(http.StatusUnauthorized, "invalid kubeconfig sharing link: the token was not issued for sharing a kubeconfig")
+	}
+
+	return &KubeconfigShareLinkClaims{
+		ProjectID: customClaims.ProjectID,
+		ClusterID: claims.Subject,
+		LinkID:    claims.ID,
+		Expiry:    claims.Expiry.Time(),
+	}, nil
+}
+
+// GetSharedKubeconfigEndpoint returns a viewer kubeconfig for a cluster whose sharing link has already been verified.
+// The kubeconfig authenticates with a service account token that expires together with the link.
+func GetSharedKubeconfigEndpoint(ctx context.Context, privilegedProjectProvider provider.PrivilegedProjectProvider, link *KubeconfigShareLinkClaims) (interface{}, error) {
+	clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
+	privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
+
+	project, err := privilegedProjectProvider.GetUnsecured(link.ProjectID, nil)
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+	cluster, err := privilegedClusterProvider.GetUnsecured(project, link.ClusterID, nil)
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	userClusterClient, err := clusterProvider.GetAdminClientForCustomerCluster(ctx, cluster)
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+	sa := &corev1.ServiceAccount{}
+	if err := userClusterClient.Get(ctx, types.NamespacedName{Namespace: kubeconfigShareNamespace, Name: link.LinkID}, sa); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, errKubeconfigShareLinkRevoked
+		}
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	cfg, err := clusterProvider.GetAdminClientConfigForCustomerCluster(ctx, cluster)
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+	client, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// the API server does not issue tokens for less than ten minutes, so a link redeemed
+	// shortly before its expiry yields a token that outlives it by at most that much
+	ttl := time.Until(link.Expiry)
+	if ttl < minKubeconfigShareTokenTTL {
+		ttl = minKubeconfigShareTokenTTL
+	}
+	expirationSeconds := int64(ttl.Seconds())
+	tokenRequest := &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{
+			ExpirationSeconds: &expirationSeconds,
+		},
+	}
+	tokenRequest, err = client.CoreV1().ServiceAccounts(kubeconfigShareNamespace).CreateToken(ctx, link.LinkID, tokenRequest, metav1.CreateOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, errKubeconfigShareLinkRevoked
+		}
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	// the viewer kubeconfig provides the address and CA of the cluster
+	clientCfg, err := clusterProvider.GetViewerKubeconfigForCustomerCluster(cluster)
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+	for name := range clientCfg.AuthInfos {
+		clientCfg.AuthInfos[name] = &clientcmdapi.AuthInfo{Token: tokenRequest.Status.Token}
+	}
+
+	return &encodeKubeConifgResponse{clientCfg: clientCfg, filePrefix: "viewer"}, nil
+}
@@ -18,9 +18,11 @@ package common
 
 import (
 	"context"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"reflect"
 	"strconv"
 	"strings"
 	"time"
@@ -29,14 +31,19 @@ import (
 	jsonpatch "github.com/evanphx/json-patch"
 
 	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
 	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/handler/middleware"
 	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
 	"k8c.io/kubermatic/v2/pkg/handler/v1/label"
 	machineconversions "k8c.io/kubermatic/v2/pkg/machine"
 	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/provider/cloud/azure"
+	"k8c.io/kubermatic/v2/pkg/provider/cloud/openstack"
 	kubernetesprovider "k8c.io/kubermatic/v2/pkg/provider/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/resources"
 	machineresource "k8c.io/kubermatic/v2/pkg/resources/machine"
 	k8cerrors "k8c.io/kubermatic/v2/pkg/util/errors"
 	"k8c.io/kubermatic/v2/pkg/validation/nodeupdate"
@@ -48,6 +55,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	"k8s.io/utils/pointer"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -60,7 +68,7 @@ const (
 	MachineDeploymentEventNormalType  = "normal"
 )
 
-func CreateMachineDeployment(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, sshKeyProvider provider.SSHKeyProvider, seedsGetter provider.SeedsGetter, machineDeployment apiv1.NodeDeployment, projectID, clusterID string) (interface{}, error) {
+func CreateMachineDeployment(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, sshKeyProvider provider.SSHKeyProvider, seedsGetter provider.SeedsGetter, settingsProvider provider.SettingsProvider, caBundle *x509.CertPool, machineDeployment apiv1.NodeDeployment, projectID, clusterID string) (interface{}, error) {
 	clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
 
 	project, err := common.GetProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, projectID, nil)
@@ -100,23 +108,66 @@ func CreateMachineDeployment(ctx context.Context, userInfoGetter provider.UserIn
 		return nil, fmt.Errorf("error getting dc: %v", err)
 	}
 
+	nodeCluster, nodeDC, err := getNodeCluster(userInfo, seedsGetter, cluster, dc, &machineDeployment)
+	if err != nil {
+		return nil, err
+	}
+
+	machineresource.SetAzureNodeDefaults(&machineDeployment, nodeDC)
 	nd, err := machineresource.Validate(&machineDeployment, cluster.Spec.Version.Semver())
 	if err != nil {
 		return nil, k8cerrors.NewBadRequest(fmt.Sprintf("node deployment validation failed: %s", err.Error()))
 	}
 
+	if err := checkDatacenterNodeLimit(ctx, clusterProvider, nodeDC, cluster, nd.Name, int(nd.Spec.Replicas)); err != nil {
+		return nil, err
+	}
+	if err := checkClusterNodeLimit(ctx, client, cluster, nd.Name, int(nd.Spec.Replicas)); err != nil {
+		return nil, err
+	}
+	if err := validateNodeFlavor(settingsProvider, nodeDC, nil, nd); err != nil {
+		return nil, err
+	}
+
 	assertedClusterProvider, ok := clusterProvider.(*kubernetesprovider.ClusterProvider)
 	if !ok {
 		return nil, k8cerrors.New(http.StatusInternalServerError, "clusterprovider is not a kubernetesprovider.Clusterprovider, can not create secret")
 	}
 
+	if nodeCluster == cluster {
+		cluster, err = azure.AddMachineDeploymentAvailabilitySet(ctx, assertedClusterProvider.GetSeedClusterAdminRuntimeClient(), cluster, nd)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		nodeCluster = cluster
+	}
+
 	data := common.CredentialsData{
 		Ctx:               ctx,
-		KubermaticCluster: cluster,
+		KubermaticCluster: nodeCluster,
 		Client:            assertedClusterProvider.GetSeedClusterAdminRuntimeClient(),
 	}
 
-	md, err := machineresource.Deployment(cluster, nd, dc, keys, data)
+	if err := validateOpenstackNodeVolumeSettings(data, nodeDC, nil, nd, caBundle); err != nil {
+		return nil, err
+	}
+	if err := validateAzureNodeSecurityProfile(data, nodeDC, nil, nd); err != nil {
+		return nil, err
+	}
+	if err := validateAzureNodeAcceleratedNetworking(data, nodeDC, nil, nd); err != nil {
+		return nil, err
+	}
+	if err := validateAzureNodeImage(data, nodeDC, nil, nd); err != nil {
+		return nil, err
+	}
+	if err := validateAzureNodeSubnet(nodeCluster, nd); err != nil {
+		return nil, err
+	}
+	if err := machineresource.ValidateAzureNodeSecurityPolicy(nodeCluster, nd); err != nil {
+		return nil, k8cerrors.NewBadRequest(fmt.Sprintf("node deployment validation failed: %s", err.Error()))
+	}
+
+	md, err := machineresource.Deployment(nodeCluster, nd, nodeDC, keys, data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create machine deployment from template: %v", err)
 	}
@@ -128,6 +179,93 @@ func CreateMachineDeployment(ctx context.Context, userInfoGetter provider.UserIn
 	return outputMachineDeployment(md)
 }
 
+// PreviewMachineDeploymentBootstrapConfig renders the bootstrap configuration the machines of the given
+// machine deployment would be provisioned with, without creating the machine deployment.
+func PreviewMachineDeploymentBootstrapConfig(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, sshKeyProvider provider.SSHKeyProvider, seedsGetter provider.SeedsGetter, machineDeployment apiv1.NodeDeployment, projectID, clusterID string) (interface{}, error) {
+	clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
+
+	project, err := common.GetProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, projectID, nil)
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	cluster, err := GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, projectID, clusterID, &provider.ClusterGetOptions{CheckInitStatus: true})
+	if err != nil {
+		return nil, err
+	}
+
+	isBYO, err := common.IsBringYourOwnProvider(cluster.Spec.Cloud)
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+	if isBYO {
+		return nil, k8cerrors.NewBadRequest("You cannot create a node deployment for KubeAdm provider")
+	}
+
+	keys, err := sshKeyProvider.List(project, &provider.SSHKeyListOptions{ClusterName: clusterID})
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	userInfo, err := userInfoGetter(ctx, "")
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+	_, dc, err := provider.DatacenterFromSeedMap(userInfo, seedsGetter, cluster.Spec.Cloud.DatacenterName)
+	if err != nil {
+		return nil, fmt.Errorf("error getting dc: %v", err)
+	}
+
+	nodeCluster, dc, err := getNodeCluster(userInfo, seedsGetter, cluster, dc, &machineDeployment)
+	if err != nil {
+		return nil, err
+	}
+
+	machineresource.SetAzureNodeDefaults(&machineDeployment, dc)
+	nd, err := machineresource.Validate(&machineDeployment, cluster.Spec.Version.Semver())
+	if err != nil {
+		return nil, k8cerrors.NewBadRequest(fmt.Sprintf("node deployment validation failed: %s", err.Error()))
+	}
+
+	assertedClusterProvider, ok := clusterProvider.(*kubernetesprovider.ClusterProvider)
+	if !ok {
+		return nil, k8cerrors.New(http.StatusInternalServerError, "clusterprovider is not a kubernetesprovider.Clusterprovider, can not render bootstrap config")
+	}
+	seedClient := assertedClusterProvider.GetSeedClusterAdminRuntimeClient()
+
+	data := common.CredentialsData{
+		Ctx:               ctx,
+		KubermaticCluster: nodeCluster,
+		Client:            seedClient,
+	}
+
+	md, err := machineresource.Deployment(nodeCluster, nd, dc, keys, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create machine deployment from template: %v", err)
+	}
+
+	caSecret := &corev1.Secret{}
+	if err := seedClient.Get(ctx, types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: resources.CASecretName}, caSecret); err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	content, format, err := machineresource.BootstrapConfig(md, cluster, dc, string(caSecret.Data[resources.CACertSecretKey]))
+	if err != nil {
+		return nil, k8cerrors.NewBadRequest(fmt.Sprintf("failed to render bootstrap config: %v", err))
+	}
+
+	config, err := providerconfig.GetConfig(md.Spec.Template.Spec.ProviderSpec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get provider spec: %v", err)
+	}
+
+	return &apiv2.MachineDeploymentBootstrapConfig{
+		OperatingSystem: string(config.OperatingSystem),
+		Format:          format,
+		Content:         content,
+	}, nil
+}
+
 func outputMachineDeployment(md *clusterv1alpha1.MachineDeployment) (*apiv1.NodeDeployment, error) {
 	nodeStatus := apiv1.NodeStatus{}
 	nodeStatus.MachineName = md.Name
@@ -159,6 +297,35 @@ func outputMachineDeployment(md *clusterv1alpha1.MachineDeployment) (*apiv1.Node
 
 	hasDynamicConfig := md.Spec.Template.Spec.ConfigSource != nil
 
+	instanceTypesInUse, err := machineconversions.GetInstanceTypesInUse(md)
+	if err != nil {
+		return nil, err
+	}
+
+	canaryRollout, err := machineconversions.GetCanaryRollout(md)
+	if err != nil {
+		return nil, err
+	}
+
+	canaryRolloutStatus, err := machineconversions.GetCanaryRolloutStatus(md)
+	if err != nil {
+		return nil, err
+	}
+
+	diskLayout, err := machineconversions.GetDiskLayout(md)
+	if err != nil {
+		return nil, err
+	}
+
+	frozenReplicas, err := machineconversions.GetFrozenReplicas(md)
+	if err != nil {
+		return nil, err
+	}
+	var replicasFrozen *bool
+	if frozenReplicas != nil {
+		replicasFrozen = pointer.BoolPtr(true)
+	}
+
 	return &apiv1.NodeDeployment{
 		ObjectMeta: apiv1.ObjectMeta{
 			ID:                md.Name,
@@ -176,11 +343,18 @@ func outputMachineDeployment(md *clusterv1alpha1.MachineDeployment) (*apiv1.Node
 				},
 				OperatingSystem: *operatingSystemSpec,
 				Cloud:           *cloudSpec,
+				Datacenter:      machineconversions.GetDatacenter(md),
+				DiskLayout:      diskLayout,
 			},
-			Paused:        &md.Spec.Paused,
-			DynamicConfig: &hasDynamicConfig,
+			Paused:                &md.Spec.Paused,
+			DynamicConfig:         &hasDynamicConfig,
+			FallbackInstanceTypes: machineconversions.GetFallbackInstanceTypes(md),
+			CanaryRollout:         canaryRollout,
+			ReplicasFrozen:        replicasFrozen,
 		},
-		Status: md.Status,
+		Status:             md.Status,
+		InstanceTypesInUse: instanceTypesInUse,
+		CanaryRollout:      canaryRolloutStatus,
 	}, nil
 }
 
@@ -282,6 +456,11 @@ func ListMachineDeploymentNodes(ctx context.Context, userInfoGetter provider.Use
 		return nil, common.KubernetesErrorToHTTPError(err)
 	}
 
+	localVolumes, err := getLocalVolumes(ctx, cluster, clusterProvider, nodeList.Items)
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
 	var nodesV1 []*apiv1.Node
 	for i := range machines.Items {
 		node := getNodeForMachine(&machines.Items[i], nodeList.Items)
@@ -289,6 +468,9 @@ func ListMachineDeploymentNodes(ctx context.Context, userInfoGetter provider.Use
 		if err != nil {
 			return nil, fmt.Errorf("failed to output machine %s: %v", machines.Items[i].Name, err)
 		}
+		if node != nil {
+			outNode.Status.LocalVolumes = localVolumes[node.Name]
+		}
 
 		nodesV1 = append(nodesV1, outNode)
 	}
@@ -319,6 +501,11 @@ func ListNodesForCluster(ctx context.Context, userInfoGetter provider.UserInfoGe
 		return nil, common.KubernetesErrorToHTTPError(err)
 	}
 
+	localVolumes, err := getLocalVolumes(ctx, cluster, clusterProvider, nodeList.Items)
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
 	// The following is a bit tricky. We might have a node which is not created by a machine and vice versa...
 	var nodesV1 []*apiv1.Node
 	matchedMachineNodes := sets.NewString()
@@ -339,6 +526,9 @@ func ListNodesForCluster(ctx context.Context, userInfoGetter provider.UserInfoGe
 		if err != nil {
 			return nil, fmt.Errorf("failed to output machine %s: %v", machineList.Items[i].Name, err)
 		}
+		if node != nil {
+			outNode.Status.LocalVolumes = localVolumes[node.Name]
+		}
 
 		nodesV1 = append(nodesV1, outNode)
 	}
@@ -346,7 +536,9 @@ func ListNodesForCluster(ctx context.Context, userInfoGetter provider.UserInfoGe
 	// Now all nodes, which do not belong to a machine - Relevant for BYO
 	for i := range nodeList.Items {
 		if !matchedMachineNodes.Has(string(nodeList.Items[i].UID)) {
-			nodesV1 = append(nodesV1, outputNode(&nodeList.Items[i], hideInitialConditions))
+			outNode := outputNode(&nodeList.Items[i], hideInitialConditions)
+			outNode.Status.LocalVolumes = localVolumes[nodeList.Items[i].Name]
+			nodesV1 = append(nodesV1, outNode)
 		}
 	}
 	return nodesV1, nil
@@ -428,7 +620,88 @@ func ListMachineDeploymentMetrics(ctx context.Context, userInfoGetter provider.U
 	return ConvertNodeMetrics(nodeDeploymentNodesMetrics, availableResources)
 }
 
-func PatchMachineDeployment(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, sshKeyProvider provider.SSHKeyProvider, seedsGetter provider.SeedsGetter, projectID, clusterID, machineDeploymentID string, patch json.RawMessage) (interface{}, error) {
+// ListMachineDeploymentsMetrics returns the per-node and the aggregated metrics of all machine deployments
+// of the cluster. The metrics are fetched from the metrics-server of the user cluster.
+func ListMachineDeploymentsMetrics(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, projectID, clusterID string) (interface{}, error) {
+	clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
+
+	cluster, err := GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, projectID, clusterID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// check if logged user has privileges to list machine deployments. If yes then we can use privileged client to
+	// get metrics
+	client, err := common.GetClusterClient(ctx, userInfoGetter, clusterProvider, cluster, projectID)
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	machineDeployments := &clusterv1alpha1.MachineDeploymentList{}
+	if err := client.List(ctx, machineDeployments, ctrlruntimeclient.InNamespace(metav1.NamespaceSystem)); err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	machines := &clusterv1alpha1.MachineList{}
+	if err := client.List(ctx, machines, ctrlruntimeclient.InNamespace(metav1.NamespaceSystem)); err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	nodeList, err := getNodeList(ctx, cluster, clusterProvider)
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	dynamicCLient, err := clusterProvider.GetAdminClientForCustomerCluster(ctx, cluster)
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	allNodeMetricsList := &v1beta1.NodeMetricsList{}
+	if err := dynamicCLient.List(ctx, allNodeMetricsList); err != nil {
+		// Happens during cluster creation when the CRD is not setup yet
+		if _, ok := err.(*meta.NoKindMatchError); !ok {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+	}
+
+	result := make([]apiv1.MachineDeploymentMetrics, 0, len(machineDeployments.Items))
+	for _, md := range machineDeployments.Items {
+		selector := labels.SelectorFromSet(md.Spec.Selector.MatchLabels)
+
+		availableResources := make(map[string]corev1.ResourceList)
+		for i := range machines.Items {
+			if !selector.Matches(labels.Set(machines.Items[i].Labels)) {
+				continue
+			}
+			if n := getNodeForMachine(&machines.Items[i], nodeList.Items); n != nil {
+				availableResources[n.Name] = n.Status.Allocatable
+			}
+		}
+
+		machineDeploymentNodesMetrics := make([]v1beta1.NodeMetrics, 0)
+		for _, m := range allNodeMetricsList.Items {
+			if _, ok := availableResources[m.Name]; ok {
+				machineDeploymentNodesMetrics = append(machineDeploymentNodesMetrics, m)
+			}
+		}
+
+		nodeMetrics, err := ConvertNodeMetrics(machineDeploymentNodesMetrics, availableResources)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, apiv1.MachineDeploymentMetrics{
+			Name:        md.Name,
+			Utilization: AggregateNodeMetrics(machineDeploymentNodesMetrics, availableResources),
+			Nodes:       nodeMetrics,
+		})
+	}
+
+	return result, nil
+}
+
+func PatchMachineDeployment(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, sshKeyProvider provider.SSHKeyProvider, seedsGetter provider.SeedsGetter, settingsProvider provider.SettingsProvider, caBundle *x509.CertPool, projectID, clusterID, machineDeploymentID string, patch json.RawMessage) (interface{}, error) {
 	clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
 	userInfo, err := userInfoGetter(ctx, "")
 	if err != nil {
@@ -484,12 +757,54 @@ func PatchMachineDeployment(ctx context.Context, userInfoGetter provider.UserInf
 	if err = nodeupdate.EnsureVersionCompatible(cluster.Spec.Version.Semver(), kversion); err != nil {
 		return nil, k8cerrors.NewBadRequest(err.Error())
 	}
+	if err = machineresource.ValidateFallbackInstanceTypes(patchedNodeDeployment); err != nil {
+		return nil, k8cerrors.NewBadRequest(err.Error())
+	}
+	if err = machineresource.ValidateOpenstackRootDisk(patchedNodeDeployment); err != nil {
+		return nil, k8cerrors.NewBadRequest(err.Error())
+	}
+	if err = machineresource.ValidateAzureSecurityProfile(patchedNodeDeployment); err != nil {
+		return nil, k8cerrors.NewBadRequest(err.Error())
+	}
+	if err = machineresource.ValidateAzureImage(patchedNodeDeployment); err != nil {
+		return nil, k8cerrors.NewBadRequest(err.Error())
+	}
+	if err = machineresource.ValidateCanaryRollout(patchedNodeDeployment); err != nil {
+		return nil, k8cerrors.NewBadRequest(err.Error())
+	}
+	if err = machineresource.ValidateDiskLayout(patchedNodeDeployment); err != nil {
+		return nil, k8cerrors.NewBadRequest(err.Error())
+	}
+
+	if patchedNodeDeployment.Spec.Template.Datacenter != nodeDeployment.Spec.Template.Datacenter {
+		return nil, k8cerrors.NewBadRequest("the datacenter of a node deployment cannot be changed")
+	}
 
 	_, dc, err := provider.DatacenterFromSeedMap(userInfo, seedsGetter, cluster.Spec.Cloud.DatacenterName)
 	if err != nil {
 		return nil, fmt.Errorf("error getting dc: %v", err)
 	}
 
+	nodeCluster, dc, err := getNodeCluster(userInfo, seedsGetter, cluster, dc, patchedNodeDeployment)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateFrozenReplicas(machineDeployment, patchedNodeDeployment); err != nil {
+		return nil, err
+	}
+
+	// Only scaling up is limited, so that node deployments can still be changed in a datacenter
+	// whose limit has been lowered below its current size.
+	if machineDeployment.Spec.Replicas == nil || patchedNodeDeployment.Spec.Replicas > *machineDeployment.Spec.Replicas {
+		if err := checkDatacenterNodeLimit(ctx, clusterProvider, dc, cluster, machineDeploymentID, int(patchedNodeDeployment.Spec.Replicas)); err != nil {
+			return nil, err
+		}
+		if err := checkClusterNodeLimit(ctx, client, cluster, machineDeploymentID, int(patchedNodeDeployment.Spec.Replicas)); err != nil {
+			return nil, err
+		}
+	}
+
 	keys, err := sshKeyProvider.List(project, &provider.SSHKeyListOptions{ClusterName: clusterID})
 	if err != nil {
 		return nil, common.KubernetesErrorToHTTPError(err)
@@ -501,19 +816,58 @@ func PatchMachineDeployment(ctx context.Context, userInfoGetter provider.UserInf
 	}
 	data := common.CredentialsData{
 		Ctx:               ctx,
-		KubermaticCluster: cluster,
+		KubermaticCluster: nodeCluster,
 		Client:            assertedClusterProvider.GetSeedClusterAdminRuntimeClient(),
 	}
-	patchedMachineDeployment, err := machineresource.Deployment(cluster, patchedNodeDeployment, dc, keys, data)
+	if err := validateNodeFlavor(settingsProvider, dc, nodeDeployment, patchedNodeDeployment); err != nil {
+		return nil, err
+	}
+	if err := validateOpenstackNodeVolumeSettings(data, dc, nodeDeployment, patchedNodeDeployment, caBundle); err != nil {
+		return nil, err
+	}
+	if err := validateAzureNodeSecurityProfile(data, dc, nodeDeployment, patchedNodeDeployment); err != nil {
+		return nil, err
+	}
+	if err := validateAzureNodeAcceleratedNetworking(data, dc, nodeDeployment, patchedNodeDeployment); err != nil {
+		return nil, err
+	}
+	if err := validateAzureNodeImage(data, dc, nodeDeployment, patchedNodeDeployment); err != nil {
+		return nil, err
+	}
+	if err := validateAzureNodeSubnet(nodeCluster, patchedNodeDeployment); err != nil {
+		return nil, err
+	}
+	if err := machineresource.ValidateAzureNodeSecurityPolicy(nodeCluster, patchedNodeDeployment); err != nil {
+		return nil, k8cerrors.NewBadRequest(err.Error())
+	}
+
+	patchedMachineDeployment, err := machineresource.Deployment(nodeCluster, patchedNodeDeployment, dc, keys, data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create machine deployment from template: %v", err)
 	}
 
 	// Only the fields from NodeDeploymentSpec will be updated by a patch.
 	// It ensures that the name and resource version are set and the selector stays the same.
+	existingMachineDeployment := machineDeployment.DeepCopy()
 	machineDeployment.Spec.Template.Spec = patchedMachineDeployment.Spec.Template.Spec
 	machineDeployment.Spec.Replicas = patchedMachineDeployment.Spec.Replicas
 	machineDeployment.Spec.Paused = patchedMachineDeployment.Spec.Paused
+	if patchedNodeDeployment.Spec.ReplicasFrozen != nil && *patchedNodeDeployment.Spec.ReplicasFrozen {
+		machineconversions.FreezeReplicas(machineDeployment)
+	} else {
+		machineconversions.UnfreezeReplicas(machineDeployment)
+	}
+	machineconversions.SetFallbackInstanceTypes(machineDeployment, patchedNodeDeployment.Spec.FallbackInstanceTypes)
+	if err := machineconversions.SetCanaryRollout(machineDeployment, patchedNodeDeployment.Spec.CanaryRollout); err != nil {
+		return nil, err
+	}
+	if err := machineconversions.SetDiskLayout(machineDeployment, patchedNodeDeployment.Spec.Template.DiskLayout); err != nil {
+		return nil, err
+	}
+	// with canary rollouts, a new OS image is only rolled out once canary nodes passed the validation
+	if err := machineconversions.DeferImageChange(existingMachineDeployment, machineDeployment); err != nil {
+		return nil, fmt.Errorf("failed to defer OS image change: %v", err)
+	}
 
 	if err := client.Update(ctx, machineDeployment); err != nil {
 		return nil, fmt.Errorf("failed to update machine deployment: %v", err)
@@ -522,6 +876,259 @@ func PatchMachineDeployment(ctx context.Context, userInfoGetter provider.UserInf
 	return outputMachineDeployment(machineDeployment)
 }
 
+// validateFrozenReplicas rejects changes of the replicas of a frozen node deployment, unless
+// the patch unfreezes it.
+func validateFrozenReplicas(md *clusterv1alpha1.MachineDeployment, patched *apiv1.NodeDeployment) error {
+	frozen, err := machineconversions.GetFrozenReplicas(md)
+	if err != nil || frozen == nil {
+		return err
+	}
+	if patched.Spec.ReplicasFrozen != nil && *patched.Spec.ReplicasFrozen && patched.Spec.Replicas != *frozen {
+		return k8cerrors.NewBadRequest(fmt.Sprintf("the replicas of the node deployment are frozen at %d, unfreeze it to scale it", *frozen))
+	}
+	return nil
+}
+
+// getNodeCluster returns the cluster and the datacenter the machines of the node deployment are
+// created with. For node deployments in an additional cloud, this is a copy of the cluster using
+// the additional cloud in place of its own and the datacenter of the additional cloud.
+func getNodeCluster(userInfo *provider.UserInfo, seedsGetter provider.SeedsGetter, cluster *kubermaticv1.Cluster, dc *kubermaticv1.Datacenter, nd *apiv1.NodeDeployment) (*kubermaticv1.Cluster, *kubermaticv1.Datacenter, error) {
+	datacenter := nd.Spec.Template.Datacenter
+	if datacenter == "" || datacenter == cluster.Spec.Cloud.DatacenterName {
+		return cluster, dc, nil
+	}
+
+	cloud := cluster.Spec.AdditionalCloud(datacenter)
+	if cloud == nil {
+		return nil, nil, k8cerrors.NewBadRequest(fmt.Sprintf("datacenter %q is not an additional cloud of the cluster", datacenter))
+	}
+	_, nodeDC, err := provider.DatacenterFromSeedMap(userInfo, seedsGetter, datacenter)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting dc: %v", err)
+	}
+
+	nodeCluster := cluster.ForAdditionalCloud(*cloud)
+	providerName, err := provider.ClusterCloudProviderName(nodeCluster.Spec.Cloud)
+	if err != nil {
+		return nil, nil, common.KubernetesErrorToHTTPError(err)
+	}
+	nodeProviderName, err := provider.NodeCloudProviderName(nd.Spec.Template.Cloud)
+	if err != nil {
+		return nil, nil, k8cerrors.NewBadRequest(err.Error())
+	}
+	if providerName != nodeProviderName {
+		return nil, nil, k8cerrors.NewBadRequest(fmt.Sprintf("datacenter %q is a %s datacenter, but the node deployment is configured for %s", datacenter, providerName, nodeProviderName))
+	}
+
+	return nodeCluster, nodeDC, nil
+}
+
+// validateOpenstackNodeVolumeSettings checks the availability zone and the root disk volume type of an
+// OpenStack node deployment against the cloud. Settings which are unchanged compared to the existing
+// node deployment are not checked again.
+func validateOpenstackNodeVolumeSettings(data common.CredentialsData, dc *kubermaticv1.Datacenter, existing, nd *apiv1.NodeDeployment, caBundle *x509.CertPool) error {
+	spec := nd.Spec.Template.Cloud.Openstack
+	if spec == nil || dc.Spec.Openstack == nil {
+		return nil
+	}
+
+	availabilityZone, volumeType := spec.AvailabilityZone, spec.RootDiskVolumeType
+	if existing != nil && existing.Spec.Template.Cloud.Openstack != nil {
+		if availabilityZone == existing.Spec.Template.Cloud.Openstack.AvailabilityZone {
+			availabilityZone = ""
+		}
+		if volumeType == existing.Spec.Template.Cloud.Openstack.RootDiskVolumeType {
+			volumeType = ""
+		}
+	}
+	if availabilityZone == "" && volumeType == "" {
+		return nil
+	}
+
+	credentials, err := resources.GetOpenstackCredentials(data)
+	if err != nil {
+		return fmt.Errorf("failed to get credentials: %v", err)
+	}
+	if err := openstack.ValidateNodeVolumeSettings(dc.Spec.Openstack.AuthURL, dc.Spec.Openstack.Region, &credentials, caBundle, availabilityZone, volumeType); err != nil {
+		return k8cerrors.NewBadRequest(fmt.Sprintf("node deployment validation failed: %s", err.Error()))
+	}
+
+	return nil
+}
+
+// validateAzureNodeSecurityProfile checks that the VM size of an Azure node deployment supports its
+// image generation, trusted launch and encryption at host settings. Unchanged settings are not
+// checked again.
+func validateAzureNodeSecurityProfile(data common.CredentialsData, dc *kubermaticv1.Datacenter, existing, nd *apiv1.NodeDeployment) error {
+	spec := nd.Spec.Template.Cloud.Azure
+	if spec == nil || dc.Spec.Azure == nil {
+		return nil
+	}
+	if spec.HyperVGeneration != apiv1.AzureHyperVGenerationV2 && spec.SecurityType == "" && !spec.EnableEncryptionAtHost {
+		return nil
+	}
+	if existing != nil && existing.Spec.Template.Cloud.Azure != nil {
+		old := existing.Spec.Template.Cloud.Azure
+		if spec.Size == old.Size && spec.HyperVGeneration == old.HyperVGeneration && spec.SecurityType == old.SecurityType && spec.EnableEncryptionAtHost == old.EnableEncryptionAtHost {
+			return nil
+		}
+	}
+
+	credentials, err := resources.GetAzureCredentials(data)
+	if err != nil {
+		return fmt.Errorf("failed to get credentials: %v", err)
+	}
+	if err := azure.ValidateNodeSecurityProfile(data.Ctx, spec, dc.Spec.Azure.Location, azure.Credentials{
+		TenantID:       credentials.TenantID,
+		SubscriptionID: credentials.SubscriptionID,
+		ClientID:       credentials.ClientID,
+		ClientSecret:   credentials.ClientSecret,
+	}); err != nil {
+		return k8cerrors.NewBadRequest(fmt.Sprintf("node deployment validation failed: %s", err.Error()))
+	}
+
+	return nil
+}
+
+// validateAzureNodeAcceleratedNetworking checks that the VM size of an Azure node deployment with
+// accelerated networking supports it. Unchanged settings are not checked again.
+func validateAzureNodeAcceleratedNetworking(data common.CredentialsData, dc *kubermaticv1.Datacenter, existing, nd *apiv1.NodeDeployment) error {
+	spec := nd.Spec.Template.Cloud.Azure
+	if spec == nil || dc.Spec.Azure == nil || !spec.EnableAcceleratedNetworking {
+		return nil
+	}
+	if existing != nil && existing.Spec.Template.Cloud.Azure != nil {
+		old := existing.Spec.Template.Cloud.Azure
+		if spec.Size == old.Size && old.EnableAcceleratedNetworking {
+			return nil
+		}
+	}
+
+	credentials, err := resources.GetAzureCredentials(data)
+	if err != nil {
+		return fmt.Errorf("failed to get credentials: %v", err)
+	}
+	if err := azure.ValidateNodeAcceleratedNetworking(data.Ctx, spec, dc.Spec.Azure.Location, azure.Credentials{
+		TenantID:       credentials.TenantID,
+		SubscriptionID: credentials.SubscriptionID,
+		ClientID:       credentials.ClientID,
+		ClientSecret:   credentials.ClientSecret,
+	}); err != nil {
+		return k8cerrors.NewBadRequest(fmt.Sprintf("node deployment validation failed: %s", err.Error()))
+	}
+
+	return nil
+}
+
+// validateAzureNodeSubnet checks that an Azure node deployment is placed in one of the subnets of the cluster.
+func validateAzureNodeSubnet(cluster *kubermaticv1.Cluster, nd *apiv1.NodeDeployment) error {
+	spec := nd.Spec.Template.Cloud.Azure
+	if spec == nil || spec.Subnet == "" || cluster.Spec.Cloud.Azure == nil || spec.Subnet == cluster.Spec.Cloud.Azure.SubnetName {
+		return nil
+	}
+	for _, subnet := range cluster.Spec.Cloud.Azure.AdditionalSubnets {
+		if subnet.Name == spec.Subnet {
+			return nil
+		}
+	}
+
+	return k8cerrors.NewBadRequest(fmt.Sprintf("node deployment validation failed: subnet %q is not a subnet of the cluster", spec.Subnet))
+}
+
+// nodeFlavor returns the machine flavor, e.g. the instance type or VM size, of a node deployment
+// or an empty string for providers without named flavors.
+func nodeFlavor(spec apiv1.NodeCloudSpec) string {
+	switch {
+	case spec.AWS != nil:
+		return spec.AWS.InstanceType
+	case spec.Azure != nil:
+		return spec.Azure.Size
+	case spec.Digitalocean != nil:
+		return spec.Digitalocean.Size
+	case spec.GCP != nil:
+		return spec.GCP.MachineType
+	case spec.Hetzner != nil:
+		return spec.Hetzner.Type
+	case spec.Alibaba != nil:
+		return spec.Alibaba.InstanceType
+	case spec.Openstack != nil:
+		return spec.Openstack.Flavor
+	case spec.Packet != nil:
+		return spec.Packet.InstanceType
+	default:
+		return ""
+	}
+}
+
+// validateNodeFlavor checks that the machine flavor of a node deployment is allowed by the machine
+// flavor filters of its cloud provider and datacenter. Unchanged flavors are not checked again, so
+// that existing node deployments can still be scaled after the filters have been changed.
+func validateNodeFlavor(settingsProvider provider.SettingsProvider, dc *kubermaticv1.Datacenter, existing, nd *apiv1.NodeDeployment) error {
+	flavor := nodeFlavor(nd.Spec.Template.Cloud)
+	if flavor == "" || (existing != nil && nodeFlavor(existing.Spec.Template.Cloud) == flavor) {
+		return nil
+	}
+
+	providerName, err := provider.NodeCloudProviderName(nd.Spec.Template.Cloud)
+	if err != nil {
+		return k8cerrors.NewBadRequest(err.Error())
+	}
+	settings, err := settingsProvider.GetGlobalSettings()
+	if err != nil {
+		return common.KubernetesErrorToHTTPError(err)
+	}
+
+	if !IsFlavorAllowed(flavor, MachineFlavorFilter(settings, providerName, dc.Spec.MachineFlavorFilter)) {
+		return k8cerrors.NewBadRequest(fmt.Sprintf("node deployment validation failed: machine flavor %q is not allowed in this datacenter", flavor))
+	}
+	return nil
+}
+
+// validateAzureNodeImage checks that the gallery image of an Azure node deployment is usable and
+// that the terms of the marketplace plan of its image are accepted, accepting them if the
+// datacenter permits it. Unchanged images are not checked again.
+func validateAzureNodeImage(data common.CredentialsData, dc *kubermaticv1.Datacenter, existing, nd *apiv1.NodeDeployment) error {
+	spec := nd.Spec.Template.Cloud.Azure
+	if spec == nil || dc.Spec.Azure == nil {
+		return nil
+	}
+	plan, err := machineresource.AzureImagePlan(nd.Spec.Template)
+	if err != nil {
+		return k8cerrors.NewBadRequest(err.Error())
+	}
+	if spec.ImageID == "" && plan == nil {
+		return nil
+	}
+	if existing != nil && existing.Spec.Template.Cloud.Azure != nil {
+		old := existing.Spec.Template.Cloud.Azure
+		existingPlan, err := machineresource.AzureImagePlan(existing.Spec.Template)
+		if err == nil && spec.ImageID == old.ImageID && spec.HyperVGeneration == old.HyperVGeneration && reflect.DeepEqual(plan, existingPlan) {
+			return nil
+		}
+	}
+
+	credentials, err := resources.GetAzureCredentials(data)
+	if err != nil {
+		return fmt.Errorf("failed to get credentials: %v", err)
+	}
+	azureCredentials := azure.Credentials{
+		TenantID:       credentials.TenantID,
+		SubscriptionID: credentials.SubscriptionID,
+		ClientID:       credentials.ClientID,
+		ClientSecret:   credentials.ClientSecret,
+	}
+	if err := azure.ValidateNodeImage(data.Ctx, spec, dc.Spec.Azure.Location, azureCredentials); err != nil {
+		return k8cerrors.NewBadRequest(fmt.Sprintf("node deployment validation failed: %s", err.Error()))
+	}
+	if plan != nil {
+		if err := azure.EnsureMarketplaceTerms(data.Ctx, *plan, dc.Spec.Azure.AcceptMarketplaceTerms, azureCredentials); err != nil {
+			return k8cerrors.NewBadRequest(fmt.Sprintf("node deployment validation failed: %s", err.Error()))
+		}
+	}
+
+	return nil
+}
+
 func RestartMachineDeployment(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, projectID, clusterID, machineDeploymentID string) (interface{}, error) {
 	clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
 	cluster, err := GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, projectID, clusterID, nil)
@@ -783,6 +1390,21 @@ func getNodeList(ctx context.Context, cluster *kubermaticv1.Cluster, clusterProv
 	return nodeList, nil
 }
 
+// getLocalVolumes returns the capacity of the local persistent volumes of the given nodes of the
+// cluster, by node name.
+func getLocalVolumes(ctx context.Context, cluster *kubermaticv1.Cluster, clusterProvider provider.ClusterProvider, nodes []corev1.Node) (map[string]*apiv1.NodeLocalVolumes, error) {
+	client, err := clusterProvider.GetAdminClientForCustomerCluster(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	volumeList := &corev1.PersistentVolumeList{}
+	if err := client.List(ctx, volumeList); err != nil {
+		return nil, err
+	}
+	return LocalVolumesByNode(volumeList.Items, nodes), nil
+}
+
 func getMachinesForNodeDeployment(ctx context.Context, clusterProvider provider.ClusterProvider, userInfoGetter provider.UserInfoGetter, cluster *kubermaticv1.Cluster, projectID, nodeDeploymentID string) (*clusterv1alpha1.MachineList, error) {
 
 	client, err := common.GetClusterClient(ctx, userInfoGetter, clusterProvider, cluster, projectID)
@@ -23,6 +23,7 @@ import (
 	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/metrics/pkg/apis/metrics/v1beta1"
 )
 
@@ -67,3 +68,107 @@ func ConvertNodeMetrics(metrics []v1beta1.NodeMetrics, availableResources map[st
 
 	return nodeMetrics, nil
 }
+
+// AggregateNodeMetrics sums up the metrics of the given nodes, the percentages are calculated from the totals.
+func AggregateNodeMetrics(metrics []v1beta1.NodeMetrics, availableResources map[string]corev1.ResourceList) apiv1.NodesMetric {
+	var cpuUsed, cpuAvailable, memoryUsed, memoryAvailable resource.Quantity
+
+	for _, m := range metrics {
+		available := availableResources[m.Name]
+		if quantity, found := available[corev1.ResourceCPU]; found {
+			cpuUsed.Add(m.Usage[corev1.ResourceCPU])
+			cpuAvailable.Add(quantity)
+		}
+		if quantity, found := available[corev1.ResourceMemory]; found {
+			memoryUsed.Add(m.Usage[corev1.ResourceMemory])
+			memoryAvailable.Add(quantity)
+		}
+	}
+
+	// cpu in mili cores, memory in bytes
+	aggregated := apiv1.NodesMetric{
+		CPUTotalMillicores:     cpuUsed.MilliValue(),
+		CPUAvailableMillicores: cpuAvailable.MilliValue(),
+		MemoryTotalBytes:       memoryUsed.Value() / (1024 * 1024),
+		MemoryAvailableBytes:   memoryAvailable.Value() / (1024 * 1024),
+	}
+	if cpuAvailable.MilliValue() > 0 {
+		fraction := float64(cpuUsed.MilliValue()) / float64(cpuAvailable.MilliValue()) * 100
+		aggregated.CPUUsedPercentage = int64(fraction)
+	}
+	if memoryAvailable.Value() > 0 {
+		fraction := float64(memoryUsed.Value()) / float64(memoryAvailable.Value()) * 100
+		aggregated.MemoryUsedPercentage = int64(fraction)
+	}
+
+	return aggregated
+}
+
+// localStorageClassName is the storage class of the persistent volumes of the
+// local-volume-provisioner addon.
+const localStorageClassName = "local-storage"
+
+// LocalVolumesByNode sums up the capacity of the local persistent volumes per node. Local volumes
+// are assigned to their node by the hostname in their node affinity.
+func LocalVolumesByNode(volumes []corev1.PersistentVolume, nodes []corev1.Node) map[string]*apiv1.NodeLocalVolumes {
+	nodeNames := map[string]string{}
+	for _, node := range nodes {
+		hostname := node.Labels[corev1.LabelHostname]
+		if hostname == "" {
+			hostname = node.Name
+		}
+		nodeNames[hostname] = node.Name
+	}
+
+	capacities := map[string]*resource.Quantity{}
+	available := map[string]*resource.Quantity{}
+	result := map[string]*apiv1.NodeLocalVolumes{}
+	for _, volume := range volumes {
+		if volume.Spec.StorageClassName != localStorageClassName {
+			continue
+		}
+		nodeName, ok := nodeNames[localVolumeHostname(volume)]
+		if !ok {
+			continue
+		}
+
+		localVolumes, ok := result[nodeName]
+		if !ok {
+			localVolumes = &apiv1.NodeLocalVolumes{}
+			result[nodeName] = localVolumes
+			capacities[nodeName] = &resource.Quantity{}
+			available[nodeName] = &resource.Quantity{}
+		}
+
+		size := volume.Spec.Capacity[corev1.ResourceStorage]
+		localVolumes.Volumes++
+		capacities[nodeName].Add(size)
+		if volume.Spec.ClaimRef != nil {
+			localVolumes.BoundVolumes++
+		} else {
+			available[nodeName].Add(size)
+		}
+	}
+
+	for nodeName, localVolumes := range result {
+		localVolumes.Capacity = capacities[nodeName].String()
+		localVolumes.Available = available[nodeName].String()
+	}
+
+	return result
+}
+
+// localVolumeHostname returns the hostname of the node a local persistent volume is on.
+func localVolumeHostname(volume corev1.PersistentVolume) string {
+	if volume.Spec.NodeAffinity == nil || volume.Spec.NodeAffinity.Required == nil {
+		return ""
+	}
+	for _, term := range volume.Spec.NodeAffinity.Required.NodeSelectorTerms {
+		for _, expression := range term.MatchExpressions {
+			if expression.Key == corev1.LabelHostname && expression.Operator == corev1.NodeSelectorOpIn && len(expression.Values) > 0 {
+				return expression.Values[0]
+			}
+		}
+	}
+	return ""
+}
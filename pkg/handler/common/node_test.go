@@ -0,0 +1,77 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"reflect"
+	"testing"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func localVolume(storageClass, hostname, size string, bound bool) corev1.PersistentVolume {
+	volume := corev1.PersistentVolume{
+		Spec: corev1.PersistentVolumeSpec{
+			StorageClassName: storageClass,
+			Capacity:         corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(size)},
+			NodeAffinity: &corev1.VolumeNodeAffinity{
+				Required: &corev1.NodeSelector{
+					NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+						MatchExpressions: []corev1.NodeSelectorRequirement{{
+							Key:      corev1.LabelHostname,
+							Operator: corev1.NodeSelectorOpIn,
+							Values:   []string{hostname},
+						}},
+					}},
+				},
+			},
+		},
+	}
+	if bound {
+		volume.Spec.ClaimRef = &corev1.ObjectReference{Namespace: "default", Name: "data"}
+	}
+	return volume
+}
+
+func TestLocalVolumesByNode(t *testing.T) {
+	t.Parallel()
+	nodes := []corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-a", Labels: map[string]string{corev1.LabelHostname: "host-a"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-b"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-c"}},
+	}
+	volumes := []corev1.PersistentVolume{
+		localVolume(localStorageClassName, "host-a", "100Gi", false),
+		localVolume(localStorageClassName, "host-a", "50Gi", true),
+		localVolume(localStorageClassName, "node-b", "200Gi", true),
+		localVolume(localStorageClassName, "unknown", "10Gi", false),
+		localVolume("standard", "node-c", "10Gi", false),
+	}
+
+	expected := map[string]*apiv1.NodeLocalVolumes{
+		"node-a": {Volumes: 2, BoundVolumes: 1, Capacity: "150Gi", Available: "100Gi"},
+		"node-b": {Volumes: 1, BoundVolumes: 1, Capacity: "200Gi", Available: "0"},
+	}
+
+	if got := LocalVolumesByNode(volumes, nodes); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %+v, got %+v", expected, got)
+	}
+}
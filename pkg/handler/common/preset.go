@@ -0,0 +1,75 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/provider"
+)
+
+// InheritDefaultPreset binds the default preset of the project for the cloud provider of the
+// cluster, if the cluster is created without a preset or credentials and the inheritance is not
+// forbidden by the admins. It returns the name of the inherited preset, or an empty string if
+// the cluster does not inherit a preset.
+func InheritDefaultPreset(project *kubermaticv1.Project, body *apiv1.CreateClusterSpec, forbidden bool) string {
+	if forbidden || body.Cluster.Credential != "" || hasCloudCredentials(body.Cluster.Spec.Cloud) {
+		return ""
+	}
+
+	providerName, err := provider.ClusterCloudProviderName(body.Cluster.Spec.Cloud)
+	if err != nil {
+		return ""
+	}
+
+	presetName := project.Spec.DefaultPresets[providerName]
+	body.Cluster.Credential = presetName
+
+	return presetName
+}
+
+// hasCloudCredentials returns true if the cloud spec contains credentials or a reference to them.
+func hasCloudCredentials(cloud kubermaticv1.CloudSpec) bool {
+	switch {
+	case cloud.Alibaba != nil:
+		return cloud.Alibaba.CredentialsReference != nil || cloud.Alibaba.AccessKeyID != ""
+	case cloud.Anexia != nil:
+		return cloud.Anexia.CredentialsReference != nil || cloud.Anexia.Token != ""
+	case cloud.AWS != nil:
+		return cloud.AWS.CredentialsReference != nil || cloud.AWS.AccessKeyID != ""
+	case cloud.Azure != nil:
+		return cloud.Azure.CredentialsReference != nil || cloud.Azure.ClientID != ""
+	case cloud.Digitalocean != nil:
+		return cloud.Digitalocean.CredentialsReference != nil || cloud.Digitalocean.Token != ""
+	case cloud.Fake != nil:
+		return cloud.Fake.Token != ""
+	case cloud.GCP != nil:
+		return cloud.GCP.CredentialsReference != nil || cloud.GCP.ServiceAccount != ""
+	case cloud.Hetzner != nil:
+		return cloud.Hetzner.CredentialsReference != nil || cloud.Hetzner.Token != ""
+	case cloud.Kubevirt != nil:
+		return cloud.Kubevirt.CredentialsReference != nil || cloud.Kubevirt.Kubeconfig != ""
+	case cloud.Openstack != nil:
+		return cloud.Openstack.CredentialsReference != nil || cloud.Openstack.Username != "" ||
+			cloud.Openstack.ApplicationCredentialID != "" || cloud.Openstack.UseToken
+	case cloud.Packet != nil:
+		return cloud.Packet.CredentialsReference != nil || cloud.Packet.APIKey != ""
+	case cloud.VSphere != nil:
+		return cloud.VSphere.CredentialsReference != nil || cloud.VSphere.Username != ""
+	}
+	return false
+}
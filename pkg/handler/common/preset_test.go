@@ -0,0 +1,86 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+)
+
+func TestInheritDefaultPreset(t *testing.T) {
+	project := &kubermaticv1.Project{
+		Spec: kubermaticv1.ProjectSpec{
+			DefaultPresets: map[string]string{"azure": "azure-prod"},
+		},
+	}
+
+	testCases := []struct {
+		name               string
+		credential         string
+		cloud              kubermaticv1.CloudSpec
+		forbidden          bool
+		expectedPreset     string
+		expectedCredential string
+	}{
+		{
+			name:               "cluster without credentials inherits the default preset",
+			cloud:              kubermaticv1.CloudSpec{Azure: &kubermaticv1.AzureCloudSpec{}},
+			expectedPreset:     "azure-prod",
+			expectedCredential: "azure-prod",
+		},
+		{
+			name:               "explicit preset is kept",
+			credential:         "azure-dev",
+			cloud:              kubermaticv1.CloudSpec{Azure: &kubermaticv1.AzureCloudSpec{}},
+			expectedCredential: "azure-dev",
+		},
+		{
+			name:  "explicit credentials are kept",
+			cloud: kubermaticv1.CloudSpec{Azure: &kubermaticv1.AzureCloudSpec{ClientID: "client"}},
+		},
+		{
+			name:  "no default preset for the provider",
+			cloud: kubermaticv1.CloudSpec{AWS: &kubermaticv1.AWSCloudSpec{}},
+		},
+		{
+			name:      "inheritance forbidden by the admins",
+			cloud:     kubermaticv1.CloudSpec{Azure: &kubermaticv1.AzureCloudSpec{}},
+			forbidden: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			body := &apiv1.CreateClusterSpec{
+				Cluster: apiv1.Cluster{
+					Credential: tc.credential,
+					Spec:       apiv1.ClusterSpec{Cloud: tc.cloud},
+				},
+			}
+
+			preset := InheritDefaultPreset(project, body, tc.forbidden)
+			if preset != tc.expectedPreset {
+				t.Errorf("expected inherited preset %q, got %q", tc.expectedPreset, preset)
+			}
+			if body.Cluster.Credential != tc.expectedCredential {
+				t.Errorf("expected credential %q, got %q", tc.expectedCredential, body.Cluster.Credential)
+			}
+		})
+	}
+}
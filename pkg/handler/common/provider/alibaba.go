@@ -77,11 +77,12 @@ func AlibabaInstanceTypesWithClusterCredentialsEndpoint(ctx context.Context, use
 		return nil, common.KubernetesErrorToHTTPError(err)
 	}
 
-	return ListAlibabaInstanceTypes(accessKeyID, accessKeySecret, region, settings.Spec.MachineDeploymentVMResourceQuota)
+	flavorFilter := handlercommon.MachineFlavorFilter(settings, provider.AlibabaCloudProvider, datacenter.Spec.MachineFlavorFilter)
+	return ListAlibabaInstanceTypes(accessKeyID, accessKeySecret, region, settings.Spec.MachineDeploymentVMResourceQuota, flavorFilter)
 
 }
 
-func ListAlibabaInstanceTypes(accessKeyID string, accessKeySecret string, region string, quota kubermaticv1.MachineDeploymentVMResourceQuota) (apiv1.AlibabaInstanceTypeList, error) {
+func ListAlibabaInstanceTypes(accessKeyID string, accessKeySecret string, region string, quota kubermaticv1.MachineDeploymentVMResourceQuota, flavorFilter kubermaticv1.MachineFlavorFilter) (apiv1.AlibabaInstanceTypeList, error) {
 	// Alibaba has way too many instance types that are not all available in each region
 	// recommendedInstanceFamilies are those families that are recommended in this document:
 	// https://www.alibabacloud.com/help/doc-detail/25378.htm?spm=a2c63.p38356.b99.47.7acf342enhNVmo
@@ -136,10 +137,10 @@ func ListAlibabaInstanceTypes(accessKeyID string, accessKeySecret string, region
 		}
 	}
 
-	return filterByQuota(instanceTypes, quota), nil
+	return filterByQuota(instanceTypes, quota, flavorFilter), nil
 }
 
-func filterByQuota(instances apiv1.AlibabaInstanceTypeList, quota kubermaticv1.MachineDeploymentVMResourceQuota) apiv1.AlibabaInstanceTypeList {
+func filterByQuota(instances apiv1.AlibabaInstanceTypeList, quota kubermaticv1.MachineDeploymentVMResourceQuota, flavorFilter kubermaticv1.MachineFlavorFilter) apiv1.AlibabaInstanceTypeList {
 	filteredRecords := apiv1.AlibabaInstanceTypeList{}
 
 	// Range over the records and apply all the filters to each record.
@@ -153,6 +154,9 @@ func filterByQuota(instances apiv1.AlibabaInstanceTypeList, quota kubermaticv1.M
 		if !handlercommon.FilterMemory(int(r.MemorySize), quota.MinRAM, quota.MaxRAM) {
 			keep = false
 		}
+		if !handlercommon.FilterFlavor(r.ID, r.CPUCoreCount, int(r.MemorySize), flavorFilter) {
+			keep = false
+		}
 
 		if keep {
 			filteredRecords = append(filteredRecords, r)
@@ -122,7 +122,8 @@ func AWSSizeNoCredentialsEndpoint(ctx context.Context, userInfoGetter provider.U
 		return nil, common.KubernetesErrorToHTTPError(err)
 	}
 
-	return AWSSizes(dc.Spec.AWS.Region, architecture, settings.Spec.MachineDeploymentVMResourceQuota)
+	flavorFilter := handlercommon.MachineFlavorFilter(settings, provider.AWSCloudProvider, dc.Spec.MachineFlavorFilter)
+	return AWSSizes(dc.Spec.AWS.Region, architecture, settings.Spec.MachineDeploymentVMResourceQuota, flavorFilter)
 }
 
 func ListAWSSubnets(accessKeyID, secretAccessKey, vpcID string, datacenter *kubermaticv1.Datacenter) (apiv1.AWSSubnetList, error) {
@@ -228,7 +229,7 @@ func SetDefaultSubnet(machineDeployments *clusterv1alpha1.MachineDeploymentList,
 	return subnets, nil
 }
 
-func AWSSizes(region, architecture string, quota kubermaticv1.MachineDeploymentVMResourceQuota) (apiv1.AWSSizeList, error) {
+func AWSSizes(region, architecture string, quota kubermaticv1.MachineDeploymentVMResourceQuota, flavorFilter kubermaticv1.MachineFlavorFilter) (apiv1.AWSSizeList, error) {
 	if data == nil {
 		return nil, fmt.Errorf("AWS instance type data not initialized")
 	}
@@ -265,7 +266,7 @@ func AWSSizes(region, architecture string, quota kubermaticv1.MachineDeploymentV
 		})
 	}
 
-	return filterAWSByQuota(sizes, quota), nil
+	return filterAWSByQuota(sizes, quota, flavorFilter), nil
 }
 
 func isARM64Architecture(physicalProcessor string) bool {
@@ -284,7 +285,7 @@ func isValidArchitecture(architecture, processorType string) bool {
 	return true
 }
 
-func filterAWSByQuota(instances apiv1.AWSSizeList, quota kubermaticv1.MachineDeploymentVMResourceQuota) apiv1.AWSSizeList {
+func filterAWSByQuota(instances apiv1.AWSSizeList, quota kubermaticv1.MachineDeploymentVMResourceQuota, flavorFilter kubermaticv1.MachineFlavorFilter) apiv1.AWSSizeList {
 	filteredRecords := apiv1.AWSSizeList{}
 
 	// Range over the records and apply all the filters to each record.
@@ -307,6 +308,9 @@ func filterAWSByQuota(instances apiv1.AWSSizeList, quota kubermaticv1.MachineDep
 		if !handlercommon.FilterMemory(int(r.Memory), quota.MinRAM, quota.MaxRAM) {
 			keep = false
 		}
+		if !handlercommon.FilterFlavor(r.Name, r.VCPUs, int(r.Memory), flavorFilter) {
+			keep = false
+		}
 
 		if keep {
 			filteredRecords = append(filteredRecords, r)
@@ -85,7 +85,7 @@ func TestAWSSizeARMFiltering(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			awsSizeList, err := provider.AWSSizes(test.region, test.architecture, test.resourceQuota)
+			awsSizeList, err := provider.AWSSizes(test.region, test.architecture, test.resourceQuota, v1.MachineFlavorFilter{})
 			if err != nil {
 				t.Fatal(err)
 			}
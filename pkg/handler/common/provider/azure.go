@@ -20,11 +20,15 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-12-01/compute"
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-06-01/network"
 	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2018-02-01/resources"
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2018-06-01/subscriptions"
 	"github.com/Azure/go-autorest/autorest/azure/auth"
+	"github.com/Azure/go-autorest/autorest/to"
 
 	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
@@ -36,6 +40,8 @@ import (
 	"k8c.io/kubermatic/v2/pkg/provider/cloud/azure"
 	kubernetesprovider "k8c.io/kubermatic/v2/pkg/provider/kubernetes"
 	"k8c.io/kubermatic/v2/pkg/util/errors"
+
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 // https://docs.microsoft.com/en-us/azure/virtual-machines/sizes-gpu
@@ -46,44 +52,40 @@ var gpuInstanceFamilies = map[string]int32{"Standard_NC6": 1, "Standard_NC12": 2
 	"Standard_ND40rs_v2": 8, "Standard_NV6": 1, "Standard_NV12": 2, "Standard_NV24": 4, "Standard_NV12s_v3": 1, "Standard_NV24s_v3": 2, "Standard_NV48s_v3": 4,
 	"Standard_NV32as_v4": 1}
 
-var NewAzureClientSet = func(subscriptionID, clientID, clientSecret, tenantID string) (AzureClientSet, error) {
-	var err error
-	sizesClient := compute.NewVirtualMachineSizesClient(subscriptionID)
-	sizesClient.Authorizer, err = auth.NewClientCredentialsConfig(clientID, clientSecret, tenantID).Authorizer()
-	if err != nil {
-		return nil, err
-	}
-	skusClient := compute.NewResourceSkusClient(subscriptionID)
-	skusClient.Authorizer, err = auth.NewClientCredentialsConfig(clientID, clientSecret, tenantID).Authorizer()
-	if err != nil {
-		return nil, err
-	}
-	securityGroupsClient := network.NewSecurityGroupsClient(subscriptionID)
-	securityGroupsClient.Authorizer, err = auth.NewClientCredentialsConfig(clientID, clientSecret, tenantID).Authorizer()
-	if err != nil {
-		return nil, err
-	}
-	resourceGroupsClient := resources.NewGroupsClient(subscriptionID)
-	resourceGroupsClient.Authorizer, err = auth.NewClientCredentialsConfig(clientID, clientSecret, tenantID).Authorizer()
-	if err != nil {
-		return nil, err
-	}
-	routeTablesClient := network.NewRouteTablesClient(subscriptionID)
-	routeTablesClient.Authorizer, err = auth.NewClientCredentialsConfig(clientID, clientSecret, tenantID).Authorizer()
+var NewAzureClientSet = func(subscriptionID, clientID, clientSecret, tenantID, environment string) (AzureClientSet, error) {
+	env, err := azure.GetEnvironment(environment)
 	if err != nil {
-		return nil, err
-	}
-	subnetsClient := network.NewSubnetsClient(subscriptionID)
-	subnetsClient.Authorizer, err = auth.NewClientCredentialsConfig(clientID, clientSecret, tenantID).Authorizer()
-	if err != nil {
-		return nil, err
+		return nil, errors.NewBadRequest(err.Error())
 	}
-	vnetClient := network.NewVirtualNetworksClient(subscriptionID)
-	vnetClient.Authorizer, err = auth.NewClientCredentialsConfig(clientID, clientSecret, tenantID).Authorizer()
+	config := auth.NewClientCredentialsConfig(clientID, clientSecret, tenantID)
+	config.AADEndpoint = env.ActiveDirectoryEndpoint
+	config.Resource = env.ResourceManagerEndpoint
+	authorizer, err := config.Authorizer()
 	if err != nil {
 		return nil, err
 	}
 
+	sizesClient := compute.NewVirtualMachineSizesClientWithBaseURI(env.ResourceManagerEndpoint, subscriptionID)
+	sizesClient.Authorizer = authorizer
+	skusClient := compute.NewResourceSkusClientWithBaseURI(env.ResourceManagerEndpoint, subscriptionID)
+	skusClient.Authorizer = authorizer
+	securityGroupsClient := network.NewSecurityGroupsClientWithBaseURI(env.ResourceManagerEndpoint, subscriptionID)
+	securityGroupsClient.Authorizer = authorizer
+	resourceGroupsClient := resources.NewGroupsClientWithBaseURI(env.ResourceManagerEndpoint, subscriptionID)
+	resourceGroupsClient.Authorizer = authorizer
+	routeTablesClient := network.NewRouteTablesClientWithBaseURI(env.ResourceManagerEndpoint, subscriptionID)
+	routeTablesClient.Authorizer = authorizer
+	subnetsClient := network.NewSubnetsClientWithBaseURI(env.ResourceManagerEndpoint, subscriptionID)
+	subnetsClient.Authorizer = authorizer
+	vnetClient := network.NewVirtualNetworksClientWithBaseURI(env.ResourceManagerEndpoint, subscriptionID)
+	vnetClient.Authorizer = authorizer
+	subscriptionsClient := subscriptions.NewClientWithBaseURI(env.ResourceManagerEndpoint)
+	subscriptionsClient.Authorizer = authorizer
+	providersClient := resources.NewProvidersClientWithBaseURI(env.ResourceManagerEndpoint, subscriptionID)
+	providersClient.Authorizer = authorizer
+	usageClient := compute.NewUsageClientWithBaseURI(env.ResourceManagerEndpoint, subscriptionID)
+	usageClient.Authorizer = authorizer
+
 	return &azureClientSetImpl{
 		vmSizeClient:         sizesClient,
 		skusClient:           skusClient,
@@ -92,6 +94,10 @@ var NewAzureClientSet = func(subscriptionID, clientID, clientSecret, tenantID st
 		subnetsClient:        subnetsClient,
 		vnetClient:           vnetClient,
 		routeTablesClient:    routeTablesClient,
+		subscriptionsClient:  subscriptionsClient,
+		providersClient:      providersClient,
+		usageClient:          usageClient,
+		subscriptionID:       subscriptionID,
 	}, nil
 }
 
@@ -103,6 +109,10 @@ type azureClientSetImpl struct {
 	resourceGroupsClient resources.GroupsClient
 	subnetsClient        network.SubnetsClient
 	vnetClient           network.VirtualNetworksClient
+	subscriptionsClient  subscriptions.Client
+	providersClient      resources.ProvidersClient
+	usageClient          compute.UsageClient
+	subscriptionID       string
 }
 
 type AzureClientSet interface {
@@ -113,12 +123,15 @@ type AzureClientSet interface {
 	ListRouteTables(ctx context.Context, resourceGroupName string) ([]network.RouteTable, error)
 	ListVnets(ctx context.Context, resourceGroupName string) ([]network.VirtualNetwork, error)
 	ListSubnets(ctx context.Context, resourceGroupName, virtualNetworkName string) ([]network.Subnet, error)
+	ListLocations(ctx context.Context) ([]subscriptions.Location, error)
+	GetResourceProvider(ctx context.Context, namespace string) (resources.Provider, error)
+	ListUsages(ctx context.Context, location string) ([]compute.Usage, error)
 }
 
 func (s *azureClientSetImpl) ListSKU(ctx context.Context, location string) ([]compute.ResourceSku, error) {
 	skuList, err := s.skusClient.List(ctx, location)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list SKU resource: %v", err)
+		return nil, fmt.Errorf("failed to list SKU resource: %w", err)
 	}
 	return skuList.Values(), nil
 }
@@ -126,56 +139,159 @@ func (s *azureClientSetImpl) ListSKU(ctx context.Context, location string) ([]co
 func (s *azureClientSetImpl) ListVMSize(ctx context.Context, location string) ([]compute.VirtualMachineSize, error) {
 	sizesResult, err := s.vmSizeClient.List(ctx, location)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list sizes: %v", err)
+		return nil, fmt.Errorf("failed to list sizes: %w", err)
 	}
 	return *sizesResult.Value, nil
 }
 
 func (s *azureClientSetImpl) ListSecurityGroups(ctx context.Context, resourceGroupName string) ([]network.SecurityGroup, error) {
-	securityGroups, err := s.securityGroupsClient.List(ctx, resourceGroupName)
+	var securityGroups []network.SecurityGroup
+	iter, err := s.securityGroupsClient.ListComplete(ctx, resourceGroupName)
+	for ; err == nil && iter.NotDone(); err = iter.NextWithContext(ctx) {
+		securityGroups = append(securityGroups, iter.Value())
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to list security groups: %v", err)
+		return nil, fmt.Errorf("failed to list security groups: %w", err)
 	}
-	return securityGroups.Values(), nil
+	return securityGroups, nil
 }
 
 func (s *azureClientSetImpl) ListRouteTables(ctx context.Context, resourceGroupName string) ([]network.RouteTable, error) {
-	routeTables, err := s.routeTablesClient.List(ctx, resourceGroupName)
+	var routeTables []network.RouteTable
+	iter, err := s.routeTablesClient.ListComplete(ctx, resourceGroupName)
+	for ; err == nil && iter.NotDone(); err = iter.NextWithContext(ctx) {
+		routeTables = append(routeTables, iter.Value())
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to list resource groups: %v", err)
+		return nil, fmt.Errorf("failed to list route tables: %w", err)
 	}
-	return routeTables.Values(), nil
-
+	return routeTables, nil
 }
 
 func (s *azureClientSetImpl) ListResourceGroups(ctx context.Context) ([]resources.Group, error) {
-	resourceGroups, err := s.resourceGroupsClient.List(ctx, "", nil)
+	var resourceGroups []resources.Group
+	iter, err := s.resourceGroupsClient.ListComplete(ctx, "", nil)
+	for ; err == nil && iter.NotDone(); err = iter.NextWithContext(ctx) {
+		resourceGroups = append(resourceGroups, iter.Value())
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to list resource groups: %v", err)
+		return nil, fmt.Errorf("failed to list resource groups: %w", err)
 	}
-	return resourceGroups.Values(), nil
-
+	return resourceGroups, nil
 }
 
 func (s *azureClientSetImpl) ListSubnets(ctx context.Context, resourceGroupName, virtualNetworkName string) ([]network.Subnet, error) {
-	subnets, err := s.subnetsClient.List(ctx, resourceGroupName, virtualNetworkName)
+	var subnets []network.Subnet
+	iter, err := s.subnetsClient.ListComplete(ctx, resourceGroupName, virtualNetworkName)
+	for ; err == nil && iter.NotDone(); err = iter.NextWithContext(ctx) {
+		subnets = append(subnets, iter.Value())
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to list subnets: %v", err)
+		return nil, fmt.Errorf("failed to list subnets: %w", err)
 	}
-	return subnets.Values(), nil
-
+	return subnets, nil
 }
 
 func (s *azureClientSetImpl) ListVnets(ctx context.Context, resourceGroupName string) ([]network.VirtualNetwork, error) {
-	vnets, err := s.vnetClient.List(ctx, resourceGroupName)
+	var vnets []network.VirtualNetwork
+	iter, err := s.vnetClient.ListComplete(ctx, resourceGroupName)
+	for ; err == nil && iter.NotDone(); err = iter.NextWithContext(ctx) {
+		vnets = append(vnets, iter.Value())
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to list vnets: %v", err)
+		return nil, fmt.Errorf("failed to list vnets: %w", err)
 	}
-	return vnets.Values(), nil
+	return vnets, nil
+}
 
+func (s *azureClientSetImpl) ListLocations(ctx context.Context) ([]subscriptions.Location, error) {
+	locations, err := s.subscriptionsClient.ListLocations(ctx, s.subscriptionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list locations: %w", err)
+	}
+	if locations.Value == nil {
+		return nil, nil
+	}
+	return *locations.Value, nil
 }
 
-func AzureSizeWithClusterCredentialsEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter, settingsProvider provider.SettingsProvider, projectID, clusterID string) (interface{}, error) {
+func (s *azureClientSetImpl) GetResourceProvider(ctx context.Context, namespace string) (resources.Provider, error) {
+	provider, err := s.providersClient.Get(ctx, namespace, "")
+	if err != nil {
+		return resources.Provider{}, fmt.Errorf("failed to get resource provider %s: %w", namespace, err)
+	}
+	return provider, nil
+}
+
+func (s *azureClientSetImpl) ListUsages(ctx context.Context, location string) ([]compute.Usage, error) {
+	usages, err := s.usageClient.ListComplete(ctx, location)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list usages: %w", err)
+	}
+	var usageList []compute.Usage
+	for ; usages.NotDone(); err = usages.NextWithContext(ctx) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to list usages: %w", err)
+		}
+		usageList = append(usageList, usages.Value())
+	}
+	return usageList, nil
+}
+
+// AzureSizeFilter restricts the listed VM sizes to the ones which can be provisioned with the
+// given requirements, in addition to the machine deployment quota of the global settings.
+type AzureSizeFilter struct {
+	// Zone is an availability zone in which the sizes must be available.
+	// in: query
+	Zone string `json:"zone,omitempty"`
+	// AcceleratedNetworking lists only sizes supporting accelerated networking.
+	// in: query
+	AcceleratedNetworking bool `json:"acceleratedNetworking,omitempty"`
+	// MinCPU is the minimum number of vCPUs of the sizes.
+	// in: query
+	MinCPU int `json:"minCPU,omitempty"`
+	// MinMemory is the minimum memory of the sizes in GB.
+	// in: query
+	MinMemory int `json:"minMemory,omitempty"`
+	// QuotaHeadroom lists only sizes of which another VM fits into the remaining vCPU quotas
+	// of the subscription in the location.
+	// in: query
+	QuotaHeadroom bool `json:"quotaHeadroom,omitempty"`
+}
+
+// DecodeAzureSizeFilter decodes the query parameters of a request for Azure VM sizes.
+func DecodeAzureSizeFilter(r *http.Request) (AzureSizeFilter, error) {
+	var err error
+	query := r.URL.Query()
+	filter := AzureSizeFilter{
+		Zone: query.Get("zone"),
+	}
+
+	if value := query.Get("acceleratedNetworking"); value != "" {
+		if filter.AcceleratedNetworking, err = strconv.ParseBool(value); err != nil {
+			return filter, errors.NewBadRequest("invalid value for acceleratedNetworking: %s", value)
+		}
+	}
+	if value := query.Get("quotaHeadroom"); value != "" {
+		if filter.QuotaHeadroom, err = strconv.ParseBool(value); err != nil {
+			return filter, errors.NewBadRequest("invalid value for quotaHeadroom: %s", value)
+		}
+	}
+	if value := query.Get("minCPU"); value != "" {
+		if filter.MinCPU, err = strconv.Atoi(value); err != nil || filter.MinCPU < 0 {
+			return filter, errors.NewBadRequest("invalid value for minCPU: %s", value)
+		}
+	}
+	if value := query.Get("minMemory"); value != "" {
+		if filter.MinMemory, err = strconv.Atoi(value); err != nil || filter.MinMemory < 0 {
+			return filter, errors.NewBadRequest("invalid value for minMemory: %s", value)
+		}
+	}
+
+	return filter, nil
+}
+
+func AzureSizeWithClusterCredentialsEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter, settingsProvider provider.SettingsProvider, projectID, clusterID string, sizeFilter AzureSizeFilter) (interface{}, error) {
 	clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
 
 	cluster, err := handlercommon.GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, projectID, clusterID, &provider.ClusterGetOptions{CheckInitStatus: true})
@@ -214,7 +330,8 @@ func AzureSizeWithClusterCredentialsEndpoint(ctx context.Context, userInfoGetter
 	if err != nil {
 		return nil, common.KubernetesErrorToHTTPError(err)
 	}
-	return AzureSize(ctx, settings.Spec.MachineDeploymentVMResourceQuota, creds.SubscriptionID, creds.ClientID, creds.ClientSecret, creds.TenantID, azureLocation)
+	flavorFilter := handlercommon.MachineFlavorFilter(settings, provider.AzureCloudProvider, datacenter.Spec.MachineFlavorFilter)
+	return AzureSize(ctx, settings.Spec.MachineDeploymentVMResourceQuota, flavorFilter, sizeFilter, creds.SubscriptionID, creds.ClientID, creds.ClientSecret, creds.TenantID, datacenter.Spec.Azure.Environment, azureLocation)
 }
 
 func AzureAvailabilityZonesWithClusterCredentialsEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter, projectID, clusterID, skuName string) (interface{}, error) {
@@ -252,7 +369,23 @@ func AzureAvailabilityZonesWithClusterCredentialsEndpoint(ctx context.Context, u
 	if err != nil {
 		return nil, err
 	}
-	return AzureSKUAvailabilityZones(ctx, creds.SubscriptionID, creds.ClientID, creds.ClientSecret, creds.TenantID, azureLocation, skuName)
+	return AzureSKUAvailabilityZones(ctx, creds.SubscriptionID, creds.ClientID, creds.ClientSecret, creds.TenantID, datacenter.Spec.Azure.Environment, azureLocation, skuName)
+}
+
+// AzureDatacenterEnvironment returns the name of the Azure cloud of the datacenter with the given
+// name. Requests without a datacenter use the public cloud.
+func AzureDatacenterEnvironment(userInfo *provider.UserInfo, seedsGetter provider.SeedsGetter, datacenterName string) (string, error) {
+	if datacenterName == "" {
+		return "", nil
+	}
+	_, datacenter, err := provider.DatacenterFromSeedMap(userInfo, seedsGetter, datacenterName)
+	if err != nil {
+		return "", err
+	}
+	if datacenter.Spec.Azure == nil {
+		return "", errors.NewBadRequest("datacenter %q is not an Azure datacenter", datacenterName)
+	}
+	return datacenter.Spec.Azure.Environment, nil
 }
 
 func isVirtualMachinesType(sku compute.ResourceSku) bool {
@@ -276,6 +409,19 @@ func isLocation(sku compute.ResourceSku, location string) bool {
 	return false
 }
 
+// skuCapabilities returns the capabilities of a SKU by name.
+func skuCapabilities(sku compute.ResourceSku) map[string]string {
+	capabilities := map[string]string{}
+	if sku.Capabilities != nil {
+		for _, capability := range *sku.Capabilities {
+			if capability.Name != nil && capability.Value != nil {
+				capabilities[*capability.Name] = *capability.Value
+			}
+		}
+	}
+	return capabilities
+}
+
 // isValidVM checks all constrains for VM
 func isValidVM(sku compute.ResourceSku, location string) bool {
 
@@ -307,59 +453,135 @@ func isValidVM(sku compute.ResourceSku, location string) bool {
 	return true
 }
 
-func AzureSize(ctx context.Context, quota kubermaticv1.MachineDeploymentVMResourceQuota, subscriptionID, clientID, clientSecret, tenantID, location string) (apiv1.AzureSizeList, error) {
-	sizesClient, err := NewAzureClientSet(subscriptionID, clientID, clientSecret, tenantID)
+// skuZones returns the availability zones of a location in which the SKU can be used by the subscription.
+func skuZones(sku compute.ResourceSku, location string) sets.String {
+	zones := sets.NewString()
+	if sku.LocationInfo != nil {
+		for _, info := range *sku.LocationInfo {
+			if info.Zones != nil && strings.EqualFold(to.String(info.Location), location) {
+				zones.Insert(*info.Zones...)
+			}
+		}
+	}
+	if sku.Restrictions != nil {
+		for _, r := range *sku.Restrictions {
+			if r.Type == compute.Zone && r.RestrictionInfo != nil && r.RestrictionInfo.Zones != nil {
+				zones.Delete(*r.RestrictionInfo.Zones...)
+			}
+		}
+	}
+	return zones
+}
+
+// isAvailableSKU checks the zone and capability requirements of the size filter against a SKU.
+func isAvailableSKU(sku compute.ResourceSku, capabilities map[string]string, location string, sizeFilter AzureSizeFilter) bool {
+	if sizeFilter.Zone != "" && !skuZones(sku, location).Has(sizeFilter.Zone) {
+		return false
+	}
+	if sizeFilter.AcceleratedNetworking && !azure.SupportsAcceleratedNetworking(capabilities) {
+		return false
+	}
+	return true
+}
+
+// remainingQuotas returns the remaining amount of the quotas of the subscription in a location, by
+// quota name.
+func remainingQuotas(usages []compute.Usage) map[string]int64 {
+	remaining := make(map[string]int64, len(usages))
+	for _, usage := range usages {
+		if usage.Name == nil {
+			continue
+		}
+		remaining[to.String(usage.Name.Value)] = to.Int64(usage.Limit) - int64(to.Int32(usage.CurrentValue))
+	}
+	return remaining
+}
+
+func AzureSize(ctx context.Context, quota kubermaticv1.MachineDeploymentVMResourceQuota, flavorFilter kubermaticv1.MachineFlavorFilter, sizeFilter AzureSizeFilter, subscriptionID, clientID, clientSecret, tenantID, environment, location string) (apiv1.AzureSizeList, error) {
+	sizesClient, err := NewAzureClientSet(subscriptionID, clientID, clientSecret, tenantID, environment)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create authorizer for size client: %v", err)
 	}
 
 	skuList, err := sizesClient.ListSKU(ctx, location)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list SKU resource: %v", err)
+		return nil, azureErrorToHTTPError(err, "failed to list SKU resource")
 	}
 
-	// prepare set of valid VM size types from SKU resources
-	validSKUSet := make(map[string]struct{}, len(skuList))
+	var remaining map[string]int64
+	if sizeFilter.QuotaHeadroom {
+		usages, err := sizesClient.ListUsages(ctx, location)
+		if err != nil {
+			return nil, azureErrorToHTTPError(err, "failed to list usages")
+		}
+		remaining = remainingQuotas(usages)
+	}
+
+	// prepare the capabilities and families of valid VM size types from SKU resources
+	validSKUSet := make(map[string]map[string]string, len(skuList))
+	skuFamilies := make(map[string]string, len(skuList))
 	for _, v := range skuList {
 		if isValidVM(v, location) {
-			validSKUSet[*v.Name] = struct{}{}
+			capabilities := skuCapabilities(v)
+			if isAvailableSKU(v, capabilities, location, sizeFilter) {
+				validSKUSet[*v.Name] = capabilities
+				skuFamilies[*v.Name] = to.String(v.Family)
+			}
 		}
 	}
 
 	// get all available VM size types for given location
 	listVMSize, err := sizesClient.ListVMSize(ctx, location)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list sizes: %v", err)
+		return nil, azureErrorToHTTPError(err, "failed to list sizes")
 	}
 
 	var sizeList apiv1.AzureSizeList
 	for _, v := range listVMSize {
 		if v.Name != nil {
 			vmName := *v.Name
-			_, okSKU := validSKUSet[vmName]
+			capabilities, okSKU := validSKUSet[vmName]
 			gpus, okGPU := gpuInstanceFamilies[vmName]
+			if okSKU && remaining != nil && !azure.HasVCPUHeadroom(remaining, skuFamilies[vmName], int64(to.Int32(v.NumberOfCores))) {
+				okSKU = false
+			}
 			if okSKU {
 				s := apiv1.AzureSize{
 					Name:          vmName,
 					NumberOfCores: *v.NumberOfCores,
 					// TODO: Use this to validate user-defined disk size.
-					OsDiskSizeInMB:       *v.OsDiskSizeInMB,
-					ResourceDiskSizeInMB: *v.ResourceDiskSizeInMB,
-					MemoryInMB:           *v.MemoryInMB,
-					MaxDataDiskCount:     *v.MaxDataDiskCount,
+					OsDiskSizeInMB:                 *v.OsDiskSizeInMB,
+					ResourceDiskSizeInMB:           *v.ResourceDiskSizeInMB,
+					MemoryInMB:                     *v.MemoryInMB,
+					MaxDataDiskCount:               *v.MaxDataDiskCount,
+					HyperVGenerations:              azure.SupportedHyperVGenerations(capabilities),
+					TrustedLaunchSupported:         azure.SupportsTrustedLaunch(capabilities),
+					AcceleratedNetworkingSupported: azure.SupportsAcceleratedNetworking(capabilities),
 				}
-				if okGPU {
+				// sizes not reporting their GPUs are looked up in the list of known GPU sizes
+				s.NumberOfGPUs = azure.NumberOfGPUs(capabilities)
+				if s.NumberOfGPUs == 0 && okGPU {
 					s.NumberOfGPUs = gpus
 				}
+				s.NvidiaGPU = s.NumberOfGPUs > 0 && azure.IsNvidiaGPUSize(vmName)
 				sizeList = append(sizeList, s)
 			}
 		}
 	}
 
-	return filterAzureByQuota(sizeList, quota), nil
+	quota.MinCPU = maxInt(quota.MinCPU, sizeFilter.MinCPU)
+	quota.MinRAM = maxInt(quota.MinRAM, sizeFilter.MinMemory)
+	return filterAzureByQuota(sizeList, quota, flavorFilter), nil
 }
 
-func filterAzureByQuota(instances apiv1.AzureSizeList, quota kubermaticv1.MachineDeploymentVMResourceQuota) apiv1.AzureSizeList {
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func filterAzureByQuota(instances apiv1.AzureSizeList, quota kubermaticv1.MachineDeploymentVMResourceQuota, flavorFilter kubermaticv1.MachineFlavorFilter) apiv1.AzureSizeList {
 	filteredRecords := apiv1.AzureSizeList{}
 
 	// Range over the records and apply all the filters to each record.
@@ -377,6 +599,9 @@ func filterAzureByQuota(instances apiv1.AzureSizeList, quota kubermaticv1.Machin
 		if !handlercommon.FilterMemory(int(r.MemoryInMB/1024), quota.MinRAM, quota.MaxRAM) {
 			keep = false
 		}
+		if !handlercommon.FilterFlavor(r.Name, int(r.NumberOfCores), int(r.MemoryInMB/1024), flavorFilter) {
+			keep = false
+		}
 
 		if keep {
 			filteredRecords = append(filteredRecords, r)
@@ -386,15 +611,15 @@ func filterAzureByQuota(instances apiv1.AzureSizeList, quota kubermaticv1.Machin
 	return filteredRecords
 }
 
-func AzureSKUAvailabilityZones(ctx context.Context, subscriptionID, clientID, clientSecret, tenantID, location, skuName string) (*apiv1.AzureAvailabilityZonesList, error) {
-	azSKUClient, err := NewAzureClientSet(subscriptionID, clientID, clientSecret, tenantID)
+func AzureSKUAvailabilityZones(ctx context.Context, subscriptionID, clientID, clientSecret, tenantID, environment, location, skuName string) (*apiv1.AzureAvailabilityZonesList, error) {
+	azSKUClient, err := NewAzureClientSet(subscriptionID, clientID, clientSecret, tenantID, environment)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create authorizer for sku client: %v", err)
 	}
 
 	skuList, err := azSKUClient.ListSKU(ctx, location)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list sku resource: %v", err)
+		return nil, azureErrorToHTTPError(err, "failed to list sku resource")
 	}
 
 	var azZones = &apiv1.AzureAvailabilityZonesList{}
@@ -414,105 +639,129 @@ func AzureSKUAvailabilityZones(ctx context.Context, subscriptionID, clientID, cl
 	return nil, nil
 }
 
-func AzureSecurityGroupEndpoint(ctx context.Context, subscriptionID, clientID, clientSecret, tenantID, location, resourceGroup string) (*apiv1.AzureSecurityGroupsList, error) {
-	securityGroupsClient, err := NewAzureClientSet(subscriptionID, clientID, clientSecret, tenantID)
+func AzureSecurityGroupEndpoint(ctx context.Context, subscriptionID, clientID, clientSecret, tenantID, environment, location, resourceGroup string) (*apiv1.AzureSecurityGroupsList, error) {
+	securityGroupsClient, err := NewAzureClientSet(subscriptionID, clientID, clientSecret, tenantID, environment)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create authorizer for security groups client: %v", err)
 	}
 
 	securityGroupList, err := securityGroupsClient.ListSecurityGroups(ctx, resourceGroup)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list security group resources: %v", err)
+		return nil, azureErrorToHTTPError(err, "failed to list security group resources")
 	}
 
-	apiSecurityGroups := &apiv1.AzureSecurityGroupsList{}
-	for _, sg := range securityGroupList {
-		if location == *sg.Location {
-			apiSecurityGroups.SecurityGroups = append(apiSecurityGroups.SecurityGroups, *sg.Name)
-		}
-	}
-
-	return apiSecurityGroups, nil
+	return &apiv1.AzureSecurityGroupsList{SecurityGroups: azure.SecurityGroupNames(securityGroupList, location)}, nil
 }
 
-func AzureResourceGroupEndpoint(ctx context.Context, subscriptionID, clientID, clientSecret, tenantID, location string) (*apiv1.AzureResourceGroupsList, error) {
-	securityGroupsClient, err := NewAzureClientSet(subscriptionID, clientID, clientSecret, tenantID)
+func AzureResourceGroupEndpoint(ctx context.Context, subscriptionID, clientID, clientSecret, tenantID, environment, location string) (*apiv1.AzureResourceGroupsList, error) {
+	resourceGroupsClient, err := NewAzureClientSet(subscriptionID, clientID, clientSecret, tenantID, environment)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create authorizer for security groups client: %v", err)
+		return nil, fmt.Errorf("failed to create authorizer for resource groups client: %v", err)
 	}
 
-	resourceGroupList, err := securityGroupsClient.ListResourceGroups(ctx)
+	resourceGroupList, err := resourceGroupsClient.ListResourceGroups(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list security group resources: %v", err)
+		return nil, azureErrorToHTTPError(err, "failed to list resource group resources")
 	}
 
-	apiResourceGroups := &apiv1.AzureResourceGroupsList{}
-	for _, rg := range resourceGroupList {
-		if location == *rg.Location {
-			apiResourceGroups.ResourceGroups = append(apiResourceGroups.ResourceGroups, *rg.Name)
-		}
-	}
-
-	return apiResourceGroups, nil
+	return &apiv1.AzureResourceGroupsList{ResourceGroups: azure.ResourceGroupNames(resourceGroupList, location)}, nil
 }
 
-func AzureRouteTableEndpoint(ctx context.Context, subscriptionID, clientID, clientSecret, tenantID, location, resourceGroup string) (*apiv1.AzureRouteTablesList, error) {
-	routeTableClient, err := NewAzureClientSet(subscriptionID, clientID, clientSecret, tenantID)
+func AzureRouteTableEndpoint(ctx context.Context, subscriptionID, clientID, clientSecret, tenantID, environment, location, resourceGroup string) (*apiv1.AzureRouteTablesList, error) {
+	routeTableClient, err := NewAzureClientSet(subscriptionID, clientID, clientSecret, tenantID, environment)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create authorizer for security groups client: %v", err)
+		return nil, fmt.Errorf("failed to create authorizer for route tables client: %v", err)
 	}
 
 	routeTableList, err := routeTableClient.ListRouteTables(ctx, resourceGroup)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list route table resources: %v", err)
+		return nil, azureErrorToHTTPError(err, "failed to list route table resources")
 	}
 
-	apiRouteTables := &apiv1.AzureRouteTablesList{}
-	for _, rt := range routeTableList {
-		if location == *rt.Location {
-			apiRouteTables.RouteTables = append(apiRouteTables.RouteTables, *rt.Name)
-		}
-	}
-
-	return apiRouteTables, nil
+	return &apiv1.AzureRouteTablesList{RouteTables: azure.RouteTableNames(routeTableList, location)}, nil
 }
 
-func AzureVnetEndpoint(ctx context.Context, subscriptionID, clientID, clientSecret, tenantID, location, resourceGroup string) (*apiv1.AzureVirtualNetworksList, error) {
-	vnetClient, err := NewAzureClientSet(subscriptionID, clientID, clientSecret, tenantID)
+func AzureVnetEndpoint(ctx context.Context, subscriptionID, clientID, clientSecret, tenantID, environment, location, resourceGroup string) (*apiv1.AzureVirtualNetworksList, error) {
+	vnetClient, err := NewAzureClientSet(subscriptionID, clientID, clientSecret, tenantID, environment)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create authorizer for virtual network client: %v", err)
 	}
 
 	vnetList, err := vnetClient.ListVnets(ctx, resourceGroup)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list virtual network resources: %v", err)
+		return nil, azureErrorToHTTPError(err, "failed to list virtual network resources")
 	}
 
-	vnets := &apiv1.AzureVirtualNetworksList{}
-	for _, vn := range vnetList {
-		if location == *vn.Location {
-			vnets.VirtualNetworks = append(vnets.VirtualNetworks, *vn.Name)
-		}
-	}
-
-	return vnets, nil
+	return &apiv1.AzureVirtualNetworksList{VirtualNetworks: azure.VirtualNetworkNames(vnetList, location)}, nil
 }
 
-func AzureSubnetEndpoint(ctx context.Context, subscriptionID, clientID, clientSecret, tenantID, resourceGroup, virtualNetwork string) (*apiv1.AzureSubnetsList, error) {
-	subnetClient, err := NewAzureClientSet(subscriptionID, clientID, clientSecret, tenantID)
+func AzureSubnetEndpoint(ctx context.Context, subscriptionID, clientID, clientSecret, tenantID, environment, resourceGroup, virtualNetwork string) (*apiv1.AzureSubnetsList, error) {
+	subnetClient, err := NewAzureClientSet(subscriptionID, clientID, clientSecret, tenantID, environment)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create authorizer for subnet client: %v", err)
 	}
 
 	subnetList, err := subnetClient.ListSubnets(ctx, resourceGroup, virtualNetwork)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list virtual network resources: %v", err)
+		return nil, azureErrorToHTTPError(err, "failed to list subnet resources")
 	}
 
-	subnets := &apiv1.AzureSubnetsList{}
-	for _, sb := range subnetList {
-		subnets.Subnets = append(subnets.Subnets, *sb.Name)
+	return &apiv1.AzureSubnetsList{Subnets: azure.SubnetNames(subnetList)}, nil
+}
+
+// AzureLocationEndpoint lists the locations of the subscription in which the resources of clusters
+// and of the given additional resource providers can be created.
+func AzureLocationEndpoint(ctx context.Context, subscriptionID, clientID, clientSecret, tenantID, environment string, resourceProviders []string) (*apiv1.AzureLocationList, error) {
+	locationClient, err := NewAzureClientSet(subscriptionID, clientID, clientSecret, tenantID, environment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authorizer for location client: %v", err)
 	}
 
-	return subnets, nil
+	locationList, err := locationClient.ListLocations(ctx)
+	if err != nil {
+		return nil, azureErrorToHTTPError(err, "failed to list locations")
+	}
+
+	namespaces := sets.NewString(azure.RequiredResourceProviders()...)
+	for _, namespace := range resourceProviders {
+		if namespace = strings.TrimSpace(namespace); namespace != "" {
+			namespaces.Insert(namespace)
+		}
+	}
+
+	var providers []resources.Provider
+	for _, namespace := range namespaces.List() {
+		provider, err := locationClient.GetResourceProvider(ctx, namespace)
+		if err != nil {
+			return nil, azureErrorToHTTPError(err, "failed to get resource provider")
+		}
+		providers = append(providers, provider)
+	}
+
+	locations := &apiv1.AzureLocationList{Locations: []apiv1.AzureLocation{}}
+	for _, location := range azure.FilterLocations(locationList, providers) {
+		locations.Locations = append(locations.Locations, apiv1.AzureLocation{
+			Name:        to.String(location.Name),
+			DisplayName: to.String(location.DisplayName),
+		})
+	}
+
+	return locations, nil
+}
+
+func AzureCostEstimate(ctx context.Context, req azure.CostEstimateRequest) (*apiv1.AzureCostEstimate, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.NewBadRequest(err.Error())
+	}
+
+	estimate, err := azure.EstimateCosts(ctx, req)
+	if err != nil {
+		return nil, errors.New(http.StatusBadGateway, fmt.Sprintf("failed to estimate costs: %v", err))
+	}
+	return estimate, nil
+}
+
+// azureErrorToHTTPError returns an error with the HTTP status code matching the error returned by Azure.
+func azureErrorToHTTPError(err error, message string) error {
+	return errors.New(azure.HTTPStatusCode(err, http.StatusInternalServerError), fmt.Sprintf("%s: %v", message, err))
 }
@@ -39,7 +39,7 @@ import (
 var reStandard = regexp.MustCompile("(^s|S)")
 var reOptimized = regexp.MustCompile("(^c|C)")
 
-func DigitaloceanSizeWithClusterCredentialsEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, settingsProvider provider.SettingsProvider, projectID, clusterID string) (interface{}, error) {
+func DigitaloceanSizeWithClusterCredentialsEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter, settingsProvider provider.SettingsProvider, projectID, clusterID string) (interface{}, error) {
 
 	clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
 
@@ -67,11 +67,21 @@ func DigitaloceanSizeWithClusterCredentialsEndpoint(ctx context.Context, userInf
 		return nil, common.KubernetesErrorToHTTPError(err)
 	}
 
-	return DigitaloceanSize(ctx, settings.Spec.MachineDeploymentVMResourceQuota, accessToken)
+	userInfo, err := userInfoGetter(ctx, "")
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+	_, datacenter, err := provider.DatacenterFromSeedMap(userInfo, seedsGetter, cluster.Spec.Cloud.DatacenterName)
+	if err != nil {
+		return nil, fmt.Errorf("error getting dc: %v", err)
+	}
+
+	flavorFilter := handlercommon.MachineFlavorFilter(settings, provider.DigitaloceanCloudProvider, datacenter.Spec.MachineFlavorFilter)
+	return DigitaloceanSize(ctx, settings.Spec.MachineDeploymentVMResourceQuota, flavorFilter, accessToken)
 
 }
 
-func DigitaloceanSize(ctx context.Context, quota kubermaticv1.MachineDeploymentVMResourceQuota, token string) (apiv1.DigitaloceanSizeList, error) {
+func DigitaloceanSize(ctx context.Context, quota kubermaticv1.MachineDeploymentVMResourceQuota, flavorFilter kubermaticv1.MachineFlavorFilter, token string) (apiv1.DigitaloceanSizeList, error) {
 	static := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
 	client := godo.NewClient(oauth2.NewClient(context.Background(), static))
 
@@ -112,10 +122,10 @@ func DigitaloceanSize(ctx context.Context, quota kubermaticv1.MachineDeploymentV
 		}
 	}
 
-	return filterDigitalOceanByQuota(sizeList, quota), nil
+	return filterDigitalOceanByQuota(sizeList, quota, flavorFilter), nil
 }
 
-func filterDigitalOceanByQuota(instances apiv1.DigitaloceanSizeList, quota kubermaticv1.MachineDeploymentVMResourceQuota) apiv1.DigitaloceanSizeList {
+func filterDigitalOceanByQuota(instances apiv1.DigitaloceanSizeList, quota kubermaticv1.MachineDeploymentVMResourceQuota, flavorFilter kubermaticv1.MachineFlavorFilter) apiv1.DigitaloceanSizeList {
 	filteredRecords := apiv1.DigitaloceanSizeList{
 		Standard:  []apiv1.DigitaloceanSize{},
 		Optimized: []apiv1.DigitaloceanSize{},
@@ -130,6 +140,9 @@ func filterDigitalOceanByQuota(instances apiv1.DigitaloceanSizeList, quota kuber
 		if !handlercommon.FilterMemory(r.Memory/1024, quota.MinRAM, quota.MaxRAM) {
 			keep = false
 		}
+		if !handlercommon.FilterFlavor(r.Slug, r.VCPUs, r.Memory/1024, flavorFilter) {
+			keep = false
+		}
 
 		if keep {
 			filteredRecords.Optimized = append(filteredRecords.Optimized, r)
@@ -144,6 +157,9 @@ func filterDigitalOceanByQuota(instances apiv1.DigitaloceanSizeList, quota kuber
 		if !handlercommon.FilterMemory(r.Memory/1024, quota.MinRAM, quota.MaxRAM) {
 			keep = false
 		}
+		if !handlercommon.FilterFlavor(r.Slug, r.VCPUs, r.Memory/1024, flavorFilter) {
+			keep = false
+		}
 
 		if keep {
 			filteredRecords.Standard = append(filteredRecords.Standard, r)
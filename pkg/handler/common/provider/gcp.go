@@ -18,6 +18,7 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"regexp"
 	"strings"
@@ -38,7 +39,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 )
 
-func GCPSizeWithClusterCredentialsEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, settingsProvider provider.SettingsProvider, projectID, clusterID, zone string) (interface{}, error) {
+func GCPSizeWithClusterCredentialsEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter, settingsProvider provider.SettingsProvider, projectID, clusterID, zone string) (interface{}, error) {
 	clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
 	cluster, err := handlercommon.GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, projectID, clusterID, &provider.ClusterGetOptions{CheckInitStatus: true})
 	if err != nil {
@@ -64,7 +65,17 @@ func GCPSizeWithClusterCredentialsEndpoint(ctx context.Context, userInfoGetter p
 		return nil, common.KubernetesErrorToHTTPError(err)
 	}
 
-	return ListGCPSizes(ctx, settings.Spec.MachineDeploymentVMResourceQuota, sa, zone)
+	userInfo, err := userInfoGetter(ctx, "")
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+	_, datacenter, err := provider.DatacenterFromSeedMap(userInfo, seedsGetter, cluster.Spec.Cloud.DatacenterName)
+	if err != nil {
+		return nil, fmt.Errorf("error getting dc: %v", err)
+	}
+
+	flavorFilter := handlercommon.MachineFlavorFilter(settings, provider.GCPCloudProvider, datacenter.Spec.MachineFlavorFilter)
+	return ListGCPSizes(ctx, settings.Spec.MachineDeploymentVMResourceQuota, flavorFilter, sa, zone)
 }
 
 func GCPZoneWithClusterCredentialsEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter, projectID, clusterID string) (interface{}, error) {
@@ -306,7 +317,7 @@ func ListGCPZones(ctx context.Context, userInfo *provider.UserInfo, sa, datacent
 	return zones, err
 }
 
-func ListGCPSizes(ctx context.Context, quota kubermaticv1.MachineDeploymentVMResourceQuota, sa, zone string) (apiv1.GCPMachineSizeList, error) {
+func ListGCPSizes(ctx context.Context, quota kubermaticv1.MachineDeploymentVMResourceQuota, flavorFilter kubermaticv1.MachineFlavorFilter, sa, zone string) (apiv1.GCPMachineSizeList, error) {
 	sizes := apiv1.GCPMachineSizeList{}
 
 	computeService, project, err := gcp.ConnectToComputeService(sa)
@@ -328,10 +339,10 @@ func ListGCPSizes(ctx context.Context, quota kubermaticv1.MachineDeploymentVMRes
 		return nil
 	})
 
-	return filterGCPByQuota(sizes, quota), err
+	return filterGCPByQuota(sizes, quota, flavorFilter), err
 }
 
-func filterGCPByQuota(instances apiv1.GCPMachineSizeList, quota kubermaticv1.MachineDeploymentVMResourceQuota) apiv1.GCPMachineSizeList {
+func filterGCPByQuota(instances apiv1.GCPMachineSizeList, quota kubermaticv1.MachineDeploymentVMResourceQuota, flavorFilter kubermaticv1.MachineFlavorFilter) apiv1.GCPMachineSizeList {
 	filteredRecords := apiv1.GCPMachineSizeList{}
 
 	// Range over the records and apply all the filters to each record.
@@ -345,6 +356,9 @@ func filterGCPByQuota(instances apiv1.GCPMachineSizeList, quota kubermaticv1.Mac
 		if !handlercommon.FilterMemory(int(r.Memory/1024), quota.MinRAM, quota.MaxRAM) {
 			keep = false
 		}
+		if !handlercommon.FilterFlavor(r.Name, int(r.VCPUs), int(r.Memory/1024), flavorFilter) {
+			keep = false
+		}
 
 		if keep {
 			filteredRecords = append(filteredRecords, r)
@@ -38,7 +38,7 @@ import (
 var reStandardSize = regexp.MustCompile("(^cx|^cpx)")
 var reDedicatedSize = regexp.MustCompile("(^ccx)")
 
-func HetznerSizeWithClusterCredentialsEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, settingsProvider provider.SettingsProvider, projectID, clusterID string) (interface{}, error) {
+func HetznerSizeWithClusterCredentialsEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter, settingsProvider provider.SettingsProvider, projectID, clusterID string) (interface{}, error) {
 	clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
 
 	cluster, err := handlercommon.GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, projectID, clusterID, &provider.ClusterGetOptions{CheckInitStatus: true})
@@ -66,11 +66,21 @@ func HetznerSizeWithClusterCredentialsEndpoint(ctx context.Context, userInfoGett
 		return nil, common.KubernetesErrorToHTTPError(err)
 	}
 
-	return HetznerSize(ctx, settings.Spec.MachineDeploymentVMResourceQuota, hetznerToken)
+	userInfo, err := userInfoGetter(ctx, "")
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+	_, datacenter, err := provider.DatacenterFromSeedMap(userInfo, seedsGetter, cluster.Spec.Cloud.DatacenterName)
+	if err != nil {
+		return nil, fmt.Errorf("error getting dc: %v", err)
+	}
+
+	flavorFilter := handlercommon.MachineFlavorFilter(settings, provider.HetznerCloudProvider, datacenter.Spec.MachineFlavorFilter)
+	return HetznerSize(ctx, settings.Spec.MachineDeploymentVMResourceQuota, flavorFilter, hetznerToken)
 
 }
 
-func HetznerSize(ctx context.Context, quota kubermaticv1.MachineDeploymentVMResourceQuota, token string) (apiv1.HetznerSizeList, error) {
+func HetznerSize(ctx context.Context, quota kubermaticv1.MachineDeploymentVMResourceQuota, flavorFilter kubermaticv1.MachineFlavorFilter, token string) (apiv1.HetznerSizeList, error) {
 	client := hcloud.NewClient(hcloud.WithToken(token))
 
 	listOptions := hcloud.ServerTypeListOpts{
@@ -104,10 +114,10 @@ func HetznerSize(ctx context.Context, quota kubermaticv1.MachineDeploymentVMReso
 		}
 	}
 
-	return filterHetznerByQuota(sizeList, quota), nil
+	return filterHetznerByQuota(sizeList, quota, flavorFilter), nil
 }
 
-func filterHetznerByQuota(instances apiv1.HetznerSizeList, quota kubermaticv1.MachineDeploymentVMResourceQuota) apiv1.HetznerSizeList {
+func filterHetznerByQuota(instances apiv1.HetznerSizeList, quota kubermaticv1.MachineDeploymentVMResourceQuota, flavorFilter kubermaticv1.MachineFlavorFilter) apiv1.HetznerSizeList {
 	filteredRecords := apiv1.HetznerSizeList{
 		Standard:  []apiv1.HetznerSize{},
 		Dedicated: []apiv1.HetznerSize{},
@@ -124,6 +134,9 @@ func filterHetznerByQuota(instances apiv1.HetznerSizeList, quota kubermaticv1.Ma
 		if !handlercommon.FilterMemory(int(r.Memory), quota.MinRAM, quota.MaxRAM) {
 			keep = false
 		}
+		if !handlercommon.FilterFlavor(r.Name, r.Cores, int(r.Memory), flavorFilter) {
+			keep = false
+		}
 
 		if keep {
 			filteredRecords.Standard = append(filteredRecords.Standard, r)
@@ -138,6 +151,9 @@ func filterHetznerByQuota(instances apiv1.HetznerSizeList, quota kubermaticv1.Ma
 		if !handlercommon.FilterMemory(int(r.Memory), quota.MinRAM, quota.MaxRAM) {
 			keep = false
 		}
+		if !handlercommon.FilterFlavor(r.Name, r.Cores, int(r.Memory), flavorFilter) {
+			keep = false
+		}
 
 		if keep {
 			filteredRecords.Dedicated = append(filteredRecords.Dedicated, r)
@@ -64,7 +64,8 @@ func OpenstackSizeWithClusterCredentialsEndpoint(ctx context.Context, userInfoGe
 		return nil, common.KubernetesErrorToHTTPError(err)
 	}
 
-	return GetOpenstackSizes(creds, datacenter, settings.Spec.MachineDeploymentVMResourceQuota, caBundle)
+	flavorFilter := handlercommon.MachineFlavorFilter(settings, provider.OpenstackCloudProvider, datacenter.Spec.MachineFlavorFilter)
+	return GetOpenstackSizes(creds, datacenter, settings.Spec.MachineDeploymentVMResourceQuota, flavorFilter, caBundle)
 }
 
 func OpenstackTenantWithClusterCredentialsEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter,
@@ -273,7 +274,7 @@ func GetOpenstackTenants(userInfo *provider.UserInfo, seedsGetter provider.Seeds
 }
 
 func GetOpenstackSizes(credentials *resources.OpenstackCredentials, datacenter *kubermaticv1.Datacenter,
-	quota kubermaticv1.MachineDeploymentVMResourceQuota, caBundle *x509.CertPool) ([]apiv1.OpenstackSize, error) {
+	quota kubermaticv1.MachineDeploymentVMResourceQuota, flavorFilter kubermaticv1.MachineFlavorFilter, caBundle *x509.CertPool) ([]apiv1.OpenstackSize, error) {
 	flavors, err := openstack.GetFlavors(datacenter.Spec.Openstack.AuthURL,
 		datacenter.Spec.Openstack.Region, credentials, caBundle)
 	if err != nil {
@@ -298,10 +299,10 @@ func GetOpenstackSizes(credentials *resources.OpenstackCredentials, datacenter *
 		}
 	}
 
-	return filterOpenStackByQuota(apiSizes, quota), nil
+	return filterOpenStackByQuota(apiSizes, quota, flavorFilter), nil
 }
 
-func filterOpenStackByQuota(instances []apiv1.OpenstackSize, quota kubermaticv1.MachineDeploymentVMResourceQuota) []apiv1.OpenstackSize {
+func filterOpenStackByQuota(instances []apiv1.OpenstackSize, quota kubermaticv1.MachineDeploymentVMResourceQuota, flavorFilter kubermaticv1.MachineFlavorFilter) []apiv1.OpenstackSize {
 	var filteredRecords []apiv1.OpenstackSize
 
 	filteredRecords = make([]apiv1.OpenstackSize, 0)
@@ -316,6 +317,9 @@ func filterOpenStackByQuota(instances []apiv1.OpenstackSize, quota kubermaticv1.
 		if !handlercommon.FilterMemory(r.Memory/1024, quota.MinRAM, quota.MaxRAM) {
 			keep = false
 		}
+		if !handlercommon.FilterFlavor(r.Slug, r.VCPUs, r.Memory/1024, flavorFilter) {
+			keep = false
+		}
 
 		if keep {
 			filteredRecords = append(filteredRecords, r)
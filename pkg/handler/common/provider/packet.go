@@ -41,7 +41,7 @@ type plansRoot struct {
 	Plans []packngo.Plan `json:"plans"`
 }
 
-func PacketSizesWithClusterCredentialsEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, settingsProvider provider.SettingsProvider, projectID, clusterID string) (interface{}, error) {
+func PacketSizesWithClusterCredentialsEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter, settingsProvider provider.SettingsProvider, projectID, clusterID string) (interface{}, error) {
 
 	clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
 	cluster, err := handlercommon.GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, projectID, clusterID, &provider.ClusterGetOptions{CheckInitStatus: true})
@@ -67,11 +67,21 @@ func PacketSizesWithClusterCredentialsEndpoint(ctx context.Context, userInfoGett
 		return nil, common.KubernetesErrorToHTTPError(err)
 	}
 
-	return PacketSizes(apiKey, projectID, settings.Spec.MachineDeploymentVMResourceQuota)
+	userInfo, err := userInfoGetter(ctx, "")
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+	_, datacenter, err := provider.DatacenterFromSeedMap(userInfo, seedsGetter, cluster.Spec.Cloud.DatacenterName)
+	if err != nil {
+		return nil, fmt.Errorf("error getting dc: %v", err)
+	}
+
+	flavorFilter := handlercommon.MachineFlavorFilter(settings, provider.PacketCloudProvider, datacenter.Spec.MachineFlavorFilter)
+	return PacketSizes(apiKey, projectID, settings.Spec.MachineDeploymentVMResourceQuota, flavorFilter)
 
 }
 
-func PacketSizes(apiKey, projectID string, quota kubermaticv1.MachineDeploymentVMResourceQuota) (apiv1.PacketSizeList, error) {
+func PacketSizes(apiKey, projectID string, quota kubermaticv1.MachineDeploymentVMResourceQuota, flavorFilter kubermaticv1.MachineFlavorFilter) (apiv1.PacketSizeList, error) {
 	sizes := apiv1.PacketSizeList{}
 	root := new(plansRoot)
 
@@ -99,10 +109,10 @@ func PacketSizes(apiKey, projectID string, quota kubermaticv1.MachineDeploymentV
 		sizes = append(sizes, toPacketSize(plan))
 	}
 
-	return filterPacketByQuota(sizes, quota), nil
+	return filterPacketByQuota(sizes, quota, flavorFilter), nil
 }
 
-func filterPacketByQuota(instances apiv1.PacketSizeList, quota kubermaticv1.MachineDeploymentVMResourceQuota) apiv1.PacketSizeList {
+func filterPacketByQuota(instances apiv1.PacketSizeList, quota kubermaticv1.MachineDeploymentVMResourceQuota, flavorFilter kubermaticv1.MachineFlavorFilter) apiv1.PacketSizeList {
 	filteredRecords := apiv1.PacketSizeList{}
 
 	// Range over the records and apply all the filters to each record.
@@ -121,6 +131,10 @@ func filterPacketByQuota(instances apiv1.PacketSizeList, quota kubermaticv1.Mach
 				keep = false
 			}
 
+			if !handlercommon.FilterFlavor(r.Name, r.CPUs[0].Count, memory, flavorFilter) {
+				keep = false
+			}
+
 			if keep {
 				filteredRecords = append(filteredRecords, r)
 			}
@@ -18,12 +18,15 @@ package common
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 
 	"github.com/Masterminds/semver/v3"
 
 	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
 	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/handler/middleware"
 	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
 	"k8c.io/kubermatic/v2/pkg/provider"
@@ -79,6 +82,43 @@ func GetUpgradesEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGe
 	return upgrades, nil
 }
 
+func GetUpgradePreflightEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectID, clusterID string, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider) (interface{}, error) {
+	cluster, err := GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, projectID, clusterID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return convertAPIDeprecationReportToPreflight(cluster), nil
+}
+
+// convertAPIDeprecationReportToPreflight returns the pre-flight result for the cluster's
+// API deprecation report. Reports of a previous Kubernetes version are ignored, as they
+// were checked against a different target version.
+func convertAPIDeprecationReportToPreflight(cluster *kubermaticv1.Cluster) *apiv2.ClusterUpgradePreflight {
+	current := cluster.Spec.Version.Semver()
+	preflight := &apiv2.ClusterUpgradePreflight{
+		TargetVersion: fmt.Sprintf("%d.%d", current.Major(), current.Minor()+1),
+		RemovedAPIs:   []kubermaticv1.RemovedAPI{},
+	}
+
+	report := cluster.Status.APIDeprecations
+	if report == nil || report.KubernetesVersion != current.String() {
+		return preflight
+	}
+
+	scanTime := apiv1.NewTime(report.ScanTime.Time)
+	preflight.ScanTime = &scanTime
+	preflight.Compatible = true
+	for _, api := range report.RemovedAPIs {
+		preflight.RemovedAPIs = append(preflight.RemovedAPIs, api)
+		if api.Requested {
+			preflight.Compatible = false
+		}
+	}
+
+	return preflight
+}
+
 func UpgradeNodeDeploymentsEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectID, clusterID string, version apiv1.MasterVersion, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider) (interface{}, error) {
 	clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
 
@@ -30,6 +30,7 @@ import (
 	"k8c.io/kubermatic/v2/pkg/handler/auth"
 	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
 	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/serviceaccount"
 	kubermaticcontext "k8c.io/kubermatic/v2/pkg/util/context"
 	k8cerrors "k8c.io/kubermatic/v2/pkg/util/errors"
 	"k8c.io/kubermatic/v2/pkg/util/hash"
@@ -202,56 +203,112 @@ func UserInfoUnauthorized(userProjectMapper provider.ProjectMemberMapper, userPr
 func TokenVerifier(tokenVerifier auth.TokenVerifier, userProvider provider.UserProvider) endpoint.Middleware {
 	return func(next endpoint.Endpoint) endpoint.Endpoint {
 		return func(ctx context.Context, request interface{}) (response interface{}, err error) {
-			if rawTokenNotFoundErr := ctx.Value(noTokenFoundKey); rawTokenNotFoundErr != nil {
-				tokenNotFoundErr, ok := rawTokenNotFoundErr.(error)
-				if !ok {
-					return nil, k8cerrors.NewNotAuthorized()
-				}
-				return nil, k8cerrors.NewWithDetails(http.StatusUnauthorized, "not authorized", []string{tokenNotFoundErr.Error()})
+			claims, user, err := verifyToken(ctx, tokenVerifier, userProvider)
+			if err != nil {
+				return nil, err
 			}
 
-			t := ctx.Value(RawTokenContextKey)
-			token, ok := t.(string)
-			if !ok || token == "" {
-				return nil, k8cerrors.NewNotAuthorized()
+			// scoped tokens are only accepted by the endpoints of their scope
+			if claims.Scope != "" {
+				return nil, k8cerrors.New(http.StatusForbidden, fmt.Sprintf("forbidden: the token is restricted to the %s scope", claims.Scope))
 			}
 
-			verifyCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
-			defer cancel()
-			claims, err := tokenVerifier.Verify(verifyCtx, token)
-			if err != nil {
-				return nil, k8cerrors.New(http.StatusUnauthorized, fmt.Sprintf("access denied due to an invalid token, details = %v", err))
-			}
+			ctx = context.WithValue(ctx, TokenExpiryContextKey, claims.Expiry)
+			return next(context.WithValue(ctx, AuthenticatedUserContextKey, user), request)
+		}
+	}
+}
 
-			if claims.Subject == "" {
-				return nil, k8cerrors.NewNotAuthorized()
+// MonitoringTokenVerifier is the authorizer for the read-only monitoring endpoints. It only accepts service account
+// tokens with the monitoring scope that were issued for the project of the request, and stores a UserInfo under
+// UserInfoContextKey that grants read access to the clusters of that project, see ScopedUserInfoGetter.
+func MonitoringTokenVerifier(tokenVerifier auth.TokenVerifier, userProvider provider.UserProvider) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+			prjIDGetter, ok := request.(common.ProjectIDGetter)
+			if !ok {
+				return nil, k8cerrors.NewBadRequest("you can only use MonitoringTokenVerifier for endpoints that accepts project ID")
 			}
 
-			id, err := hash.GetUserID(claims.Subject)
+			claims, user, err := verifyToken(ctx, tokenVerifier, userProvider)
 			if err != nil {
-				return nil, k8cerrors.NewNotAuthorized()
+				return nil, err
 			}
 
-			user := apiv1.User{
-				ObjectMeta: apiv1.ObjectMeta{
-					ID:   id,
-					Name: claims.Name,
-				},
-				Email: claims.Email,
+			if claims.Scope != serviceaccount.MonitoringScope {
+				return nil, k8cerrors.New(http.StatusForbidden, fmt.Sprintf("forbidden: only tokens with the %s scope are accepted", serviceaccount.MonitoringScope))
 			}
-
-			if user.ID == "" {
-				return nil, k8cerrors.NewNotAuthorized()
-			}
-
-			if err := checkBlockedTokens(claims.Email, token, userProvider); err != nil {
-				return nil, err
+			if projectID := prjIDGetter.GetProjectID(); claims.ProjectID != projectID {
+				return nil, k8cerrors.New(http.StatusForbidden, fmt.Sprintf("forbidden: the token is not valid for the project %s", projectID))
 			}
 
+			// the privileged providers are used to read the clusters, the access is confined to
+			// the project of the token by the check above and to reads by the monitoring endpoints
+			uInfo := &provider.UserInfo{Email: user.Email, IsAdmin: true}
 			ctx = context.WithValue(ctx, TokenExpiryContextKey, claims.Expiry)
-			return next(context.WithValue(ctx, AuthenticatedUserContextKey, user), request)
+			ctx = context.WithValue(ctx, AuthenticatedUserContextKey, user)
+			return next(context.WithValue(ctx, UserInfoContextKey, uInfo), request)
+		}
+	}
+}
+
+// ScopedUserInfoGetter is a provider.UserInfoGetter that returns the UserInfo stored in the ctx by MonitoringTokenVerifier.
+func ScopedUserInfoGetter(ctx context.Context, _ string) (*provider.UserInfo, error) {
+	uInfo, ok := ctx.Value(UserInfoContextKey).(*provider.UserInfo)
+	if !ok {
+		return nil, k8cerrors.New(http.StatusInternalServerError, "no user info in context found")
+	}
+	return uInfo, nil
+}
+
+func verifyToken(ctx context.Context, tokenVerifier auth.TokenVerifier, userProvider provider.UserProvider) (auth.TokenClaims, apiv1.User, error) {
+	if rawTokenNotFoundErr := ctx.Value(noTokenFoundKey); rawTokenNotFoundErr != nil {
+		tokenNotFoundErr, ok := rawTokenNotFoundErr.(error)
+		if !ok {
+			return auth.TokenClaims{}, apiv1.User{}, k8cerrors.NewNotAuthorized()
 		}
+		return auth.TokenClaims{}, apiv1.User{}, k8cerrors.NewWithDetails(http.StatusUnauthorized, "not authorized", []string{tokenNotFoundErr.Error()})
 	}
+
+	t := ctx.Value(RawTokenContextKey)
+	token, ok := t.(string)
+	if !ok || token == "" {
+		return auth.TokenClaims{}, apiv1.User{}, k8cerrors.NewNotAuthorized()
+	}
+
+	verifyCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	claims, err := tokenVerifier.Verify(verifyCtx, token)
+	if err != nil {
+		return auth.TokenClaims{}, apiv1.User{}, k8cerrors.New(http.StatusUnauthorized, fmt.Sprintf("access denied due to an invalid token, details = %v", err))
+	}
+
+	if claims.Subject == "" {
+		return auth.TokenClaims{}, apiv1.User{}, k8cerrors.NewNotAuthorized()
+	}
+
+	id, err := hash.GetUserID(claims.Subject)
+	if err != nil {
+		return auth.TokenClaims{}, apiv1.User{}, k8cerrors.NewNotAuthorized()
+	}
+
+	user := apiv1.User{
+		ObjectMeta: apiv1.ObjectMeta{
+			ID:   id,
+			Name: claims.Name,
+		},
+		Email: claims.Email,
+	}
+
+	if user.ID == "" {
+		return auth.TokenClaims{}, apiv1.User{}, k8cerrors.NewNotAuthorized()
+	}
+
+	if err := checkBlockedTokens(claims.Email, token, userProvider); err != nil {
+		return auth.TokenClaims{}, apiv1.User{}, err
+	}
+
+	return claims, user, nil
 }
 
 // Addons is a middleware that injects the current AddonProvider into the ctx
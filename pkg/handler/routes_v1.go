@@ -170,6 +170,10 @@ func (r Routing) RegisterV1(mux *mux.Router, metrics common.ServerMetrics) {
 		Path("/providers/azure/availabilityzones").
 		Handler(r.listAzureSKUAvailabilityZones())
 
+	mux.Methods(http.MethodGet).
+		Path("/providers/azure/costestimate").
+		Handler(r.getAzureCostEstimate())
+
 	mux.Methods(http.MethodGet).
 		Path("/providers/openstack/sizes").
 		Handler(r.listOpenstackSizes())
@@ -260,6 +264,10 @@ func (r Routing) RegisterV1(mux *mux.Router, metrics common.ServerMetrics) {
 		Path("/projects/{project_id}").
 		Handler(r.deleteProject())
 
+	mux.Methods(http.MethodGet).
+		Path("/projects/{project_id}/deletionreport").
+		Handler(r.getProjectDeletionReport())
+
 	//
 	// Defines a set of HTTP endpoints for SSH Keys that belong to a project
 	mux.Methods(http.MethodPost).
@@ -1020,7 +1028,7 @@ func (r Routing) listAzureSizes() http.Handler {
 		endpoint.Chain(
 			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
 			middleware.UserSaver(r.userProvider),
-		)(provider.AzureSizeEndpoint(r.presetsProvider, r.userInfoGetter, r.settingsProvider)),
+		)(provider.AzureSizeEndpoint(r.presetsProvider, r.seedsGetter, r.userInfoGetter, r.settingsProvider)),
 		provider.DecodeAzureSizesReq,
 		EncodeJSON,
 		r.defaultServerOptions()...,
@@ -1042,13 +1050,35 @@ func (r Routing) listAzureSKUAvailabilityZones() http.Handler {
 		endpoint.Chain(
 			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
 			middleware.UserSaver(r.userProvider),
-		)(provider.AzureAvailabilityZonesEndpoint(r.presetsProvider, r.userInfoGetter)),
+		)(provider.AzureAvailabilityZonesEndpoint(r.presetsProvider, r.seedsGetter, r.userInfoGetter)),
 		provider.DecodeAzureAvailabilityZonesReq,
 		EncodeJSON,
 		r.defaultServerOptions()...,
 	)
 }
 
+// swagger:route GET /api/v1/providers/azure/costestimate azure getAzureCostEstimate
+//
+// Estimates the monthly costs of Azure nodes from the retail prices of the VM size and managed disks
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: AzureCostEstimate
+func (r Routing) getAzureCostEstimate() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+		)(provider.AzureCostEstimateEndpoint()),
+		provider.DecodeAzureCostEstimateReq,
+		EncodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
 // swagger:route GET /api/v1/providers/openstack/sizes openstack listOpenstackSizes
 //
 // Lists sizes from openstack
@@ -1154,7 +1184,7 @@ func (r Routing) listPacketSizesNoCredentials() http.Handler {
 			middleware.UserSaver(r.userProvider),
 			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
 			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
-		)(provider.PacketSizesWithClusterCredentialsEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter, r.settingsProvider)),
+		)(provider.PacketSizesWithClusterCredentialsEndpoint(r.projectProvider, r.privilegedProjectProvider, r.seedsGetter, r.userInfoGetter, r.settingsProvider)),
 		provider.DecodePacketSizesNoCredentialsReq,
 		EncodeJSON,
 		r.defaultServerOptions()...,
@@ -1838,6 +1868,31 @@ func (r Routing) deleteProject() http.Handler {
 	)
 }
 
+// swagger:route GET /api/v1/projects/{project_id}/deletionreport project getProjectDeletionReport
+//
+//    Lists the resources which are removed together with the project. For a project
+//    which is being deleted, the report shows the progress of each deletion stage.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: ProjectDeletionReport
+//       401: empty
+//       403: empty
+func (r Routing) getProjectDeletionReport() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+		)(project.GetDeletionReportEndpoint(r.projectProvider, r.privilegedProjectProvider, r.sshKeyProvider, r.privilegedServiceAccountProvider, r.seedsGetter, r.seedsClientGetter, r.userInfoGetter)),
+		project.DecodeDeletionReport,
+		EncodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
 // swagger:route POST /api/v1/projects/{project_id}/dc/{dc}/clusters project createCluster
 //
 //     Creates a cluster for the given project.
@@ -2820,7 +2875,7 @@ func (r Routing) listGCPSizesNoCredentials() http.Handler {
 			middleware.UserSaver(r.userProvider),
 			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
 			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
-		)(provider.GCPSizeWithClusterCredentialsEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter, r.settingsProvider)),
+		)(provider.GCPSizeWithClusterCredentialsEndpoint(r.projectProvider, r.privilegedProjectProvider, r.seedsGetter, r.userInfoGetter, r.settingsProvider)),
 		provider.DecodeGCPTypesNoCredentialReq,
 		EncodeJSON,
 		r.defaultServerOptions()...,
@@ -2940,7 +2995,7 @@ func (r Routing) listHetznerSizesNoCredentials() http.Handler {
 			middleware.UserSaver(r.userProvider),
 			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
 			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
-		)(provider.HetznerSizeWithClusterCredentialsEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter, r.settingsProvider)),
+		)(provider.HetznerSizeWithClusterCredentialsEndpoint(r.projectProvider, r.privilegedProjectProvider, r.seedsGetter, r.userInfoGetter, r.settingsProvider)),
 		provider.DecodeHetznerSizesNoCredentialsReq,
 		EncodeJSON,
 		r.defaultServerOptions()...,
@@ -2964,7 +3019,7 @@ func (r Routing) listDigitaloceanSizesNoCredentials() http.Handler {
 			middleware.UserSaver(r.userProvider),
 			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
 			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
-		)(provider.DigitaloceanSizeWithClusterCredentialsEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter, r.settingsProvider)),
+		)(provider.DigitaloceanSizeWithClusterCredentialsEndpoint(r.projectProvider, r.privilegedProjectProvider, r.seedsGetter, r.userInfoGetter, r.settingsProvider)),
 		provider.DecodeDoSizesNoCredentialsReq,
 		EncodeJSON,
 		r.defaultServerOptions()...,
@@ -3287,7 +3342,7 @@ func (r Routing) createNodeDeployment() http.Handler {
 			middleware.UserSaver(r.userProvider),
 			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
 			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
-		)(node.CreateNodeDeployment(r.sshKeyProvider, r.projectProvider, r.privilegedProjectProvider, r.seedsGetter, r.userInfoGetter)),
+		)(node.CreateNodeDeployment(r.sshKeyProvider, r.projectProvider, r.privilegedProjectProvider, r.seedsGetter, r.userInfoGetter, r.settingsProvider, r.caBundle)),
 		node.DecodeCreateNodeDeployment,
 		SetStatusCreatedHeader(EncodeJSON),
 		r.defaultServerOptions()...,
@@ -3448,7 +3503,7 @@ func (r Routing) patchNodeDeployment() http.Handler {
 			middleware.UserSaver(r.userProvider),
 			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
 			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
-		)(node.PatchNodeDeployment(r.sshKeyProvider, r.projectProvider, r.privilegedProjectProvider, r.seedsGetter, r.userInfoGetter)),
+		)(node.PatchNodeDeployment(r.sshKeyProvider, r.projectProvider, r.privilegedProjectProvider, r.seedsGetter, r.userInfoGetter, r.settingsProvider, r.caBundle)),
 		node.DecodePatchNodeDeployment,
 		EncodeJSON,
 		r.defaultServerOptions()...,
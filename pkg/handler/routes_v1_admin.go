@@ -52,6 +52,10 @@ func (r Routing) RegisterV1Admin(mux *mux.Router) {
 		Path("/admin/settings").
 		Handler(r.patchKubermaticSettings())
 
+	mux.Methods(http.MethodGet).
+		Path("/admin/statistics").
+		Handler(r.getFleetStatistics())
+
 	// Defines a set of HTTP endpoints for the admission plugins
 	mux.Methods(http.MethodGet).
 		Path("/admin/admission/plugins").
@@ -87,6 +91,30 @@ func (r Routing) RegisterV1Admin(mux *mux.Router) {
 		Handler(r.deleteSeed())
 }
 
+// swagger:route GET /api/v1/admin/statistics admin getFleetStatistics
+//
+//     Gets statistics about all clusters of all seeds. The statistics are cached for a few minutes.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: FleetStatistics
+//       401: empty
+//       403: empty
+func (r Routing) getFleetStatistics() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+		)(admin.FleetStatisticsEndpoint(r.userInfoGetter, r.fleetStatisticsAggregator)),
+		common.DecodeEmptyReq,
+		EncodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
 // swagger:route GET /api/v1/admin/settings admin getKubermaticSettings
 //
 //     Gets the global settings.
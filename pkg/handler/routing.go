@@ -28,6 +28,7 @@ import (
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/handler/auth"
 	"k8c.io/kubermatic/v2/pkg/handler/middleware"
+	"k8c.io/kubermatic/v2/pkg/handler/v1/admin"
 	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
 	"k8c.io/kubermatic/v2/pkg/provider"
 	"k8c.io/kubermatic/v2/pkg/serviceaccount"
@@ -77,6 +78,7 @@ type Routing struct {
 	settingsWatcher                       watcher.SettingsWatcher
 	userWatcher                           watcher.UserWatcher
 	caBundle                              *x509.CertPool
+	fleetStatisticsAggregator             *admin.FleetStatisticsAggregator
 }
 
 // NewRouting creates a new Routing.
@@ -120,6 +122,7 @@ func NewRouting(routingParams RoutingParams) Routing {
 		userWatcher:                           routingParams.UserWatcher,
 		versions:                              routingParams.Versions,
 		caBundle:                              routingParams.CABundle,
+		fleetStatisticsAggregator:             admin.NewFleetStatisticsAggregator(routingParams.SeedsGetter, routingParams.ClusterProviderGetter, routingParams.UpdateManager, admin.DefaultFleetStatisticsTTL),
 	}
 }
 
@@ -178,6 +181,8 @@ type RoutingParams struct {
 	RuleGroupProviderGetter               provider.RuleGroupProviderGetter
 	PrivilegedWhitelistedRegistryProvider provider.PrivilegedWhitelistedRegistryProvider
 	EtcdBackupConfigProviderGetter        provider.EtcdBackupConfigProviderGetter
+	PrivilegedAddonRolloutProvider        provider.PrivilegedAddonRolloutProvider
+	PrivilegedNamespaceTemplateProvider   provider.PrivilegedNamespaceTemplateProvider
 	Versions                              kubermatic.Versions
 	CABundle                              *x509.CertPool
 }
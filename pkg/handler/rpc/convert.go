@@ -0,0 +1,80 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rpc
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	grpcv1 "k8c.io/kubermatic/v2/pkg/api/grpc/v1"
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	"k8c.io/kubermatic/v2/pkg/provider"
+)
+
+func convertObjectMeta(meta apiv1.ObjectMeta) *grpcv1.ObjectMeta {
+	out := &grpcv1.ObjectMeta{
+		Id:   meta.ID,
+		Name: meta.Name,
+	}
+	if !meta.CreationTimestamp.IsZero() {
+		out.CreationTimestamp = timestamppb.New(meta.CreationTimestamp.Time)
+	}
+	if meta.DeletionTimestamp != nil {
+		out.DeletionTimestamp = timestamppb.New(meta.DeletionTimestamp.Time)
+	}
+	return out
+}
+
+func convertCluster(cluster *apiv1.Cluster) *grpcv1.Cluster {
+	// the provider name is only informational, clusters without a known provider are
+	// returned with an empty one
+	providerName, _ := provider.ClusterCloudProviderName(cluster.Spec.Cloud)
+
+	return &grpcv1.Cluster{
+		Metadata:   convertObjectMeta(cluster.ObjectMeta),
+		Labels:     cluster.Labels,
+		Type:       cluster.Type,
+		Credential: cluster.Credential,
+		Spec: &grpcv1.ClusterSpec{
+			Datacenter: cluster.Spec.Cloud.DatacenterName,
+			Provider:   providerName,
+			Version:    cluster.Spec.Version.String(),
+		},
+		Status: &grpcv1.ClusterStatus{
+			Version: cluster.Status.Version.String(),
+			Url:     cluster.Status.URL,
+		},
+	}
+}
+
+func convertNodeDeployment(nd *apiv1.NodeDeployment) *grpcv1.NodeDeployment {
+	return &grpcv1.NodeDeployment{
+		Metadata: convertObjectMeta(nd.ObjectMeta),
+		Spec: &grpcv1.NodeDeploymentSpec{
+			Replicas:       nd.Spec.Replicas,
+			KubeletVersion: nd.Spec.Template.Versions.Kubelet,
+			Paused:         nd.Spec.Paused != nil && *nd.Spec.Paused,
+			ReplicasFrozen: nd.Spec.ReplicasFrozen != nil && *nd.Spec.ReplicasFrozen,
+		},
+		Status: &grpcv1.NodeDeploymentStatus{
+			Replicas:            nd.Status.Replicas,
+			UpdatedReplicas:     nd.Status.UpdatedReplicas,
+			ReadyReplicas:       nd.Status.ReadyReplicas,
+			AvailableReplicas:   nd.Status.AvailableReplicas,
+			UnavailableReplicas: nd.Status.UnavailableReplicas,
+		},
+	}
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rpc
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	grpcv1 "k8c.io/kubermatic/v2/pkg/api/grpc/v1"
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+)
+
+// apiModelFields maps the fields of the gRPC messages to the JSON paths of the fields of the API
+// models they are converted from. Fields that are added to cluster.proto must be added here and
+// to the conversion in convert.go.
+var apiModelFields = map[proto.Message]struct {
+	model  interface{}
+	fields map[string]string
+}{
+	&grpcv1.Cluster{}: {
+		model: apiv1.Cluster{},
+		fields: map[string]string{
+			"metadata.id":                 "id",
+			"metadata.name":               "name",
+			"metadata.creation_timestamp": "creationTimestamp",
+			"metadata.deletion_timestamp": "deletionTimestamp",
+			"labels":                      "labels",
+			"type":                        "type",
+			"credential":                  "credential",
+			"spec.datacenter":             "spec.cloud.dc",
+			"spec.provider":               "spec.cloud",
+			"spec.version":                "spec.version",
+			"status.version":              "status.version",
+			"status.url":                  "status.url",
+		},
+	},
+	&grpcv1.NodeDeployment{}: {
+		model: apiv1.NodeDeployment{},
+		fields: map[string]string{
+			"metadata.id":                 "id",
+			"metadata.name":               "name",
+			"metadata.creation_timestamp": "creationTimestamp",
+			"metadata.deletion_timestamp": "deletionTimestamp",
+			"spec.replicas":               "spec.replicas",
+			"spec.kubelet_version":        "spec.template.versions.kubelet",
+			"spec.paused":                 "spec.paused",
+			"spec.replicas_frozen":        "spec.replicasFrozen",
+			"status.replicas":             "status.replicas",
+			"status.updated_replicas":     "status.updatedReplicas",
+			"status.ready_replicas":       "status.readyReplicas",
+			"status.available_replicas":   "status.availableReplicas",
+			"status.unavailable_replicas": "status.unavailableReplicas",
+		},
+	},
+}
+
+// TestProtoMatchesAPIModels makes sure that cluster.proto does not drift from the API models:
+// every field of the messages must be mapped to a field of the model, and every mapped field
+// must exist in the model.
+func TestProtoMatchesAPIModels(t *testing.T) {
+	for message, mapping := range apiModelFields {
+		name := message.ProtoReflect().Descriptor().FullName()
+
+		fields := protoFields(message.ProtoReflect().Descriptor(), "")
+		for _, field := range fields {
+			if _, ok := mapping.fields[field]; !ok {
+				t.Errorf("field %q of %s is not mapped to a field of %T", field, name, mapping.model)
+			}
+		}
+
+		for field, path := range mapping.fields {
+			if !contains(fields, field) {
+				t.Errorf("mapped field %q does not exist in %s", field, name)
+			}
+			if !hasJSONPath(reflect.TypeOf(mapping.model), strings.Split(path, ".")) {
+				t.Errorf("field %q of %s is mapped to %q, which does not exist in %T", field, name, path, mapping.model)
+			}
+		}
+	}
+}
+
+// protoFields returns the paths of all fields of the message that are not messages themselves.
+// Timestamps are considered values.
+func protoFields(message protoreflect.MessageDescriptor, prefix string) []string {
+	timestamp := (&timestamppb.Timestamp{}).ProtoReflect().Descriptor().FullName()
+
+	var fields []string
+	for i := 0; i < message.Fields().Len(); i++ {
+		field := message.Fields().Get(i)
+		path := prefix + string(field.Name())
+
+		if field.Kind() == protoreflect.MessageKind && !field.IsMap() && field.Message().FullName() != timestamp {
+			fields = append(fields, protoFields(field.Message(), path+".")...)
+			continue
+		}
+		fields = append(fields, path)
+	}
+	return fields
+}
+
+// hasJSONPath returns whether the type has a field with the given JSON path.
+func hasJSONPath(t reflect.Type, path []string) bool {
+	if len(path) == 0 {
+		return true
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if field.Anonymous && name == "" {
+			if hasJSONPath(field.Type, path) {
+				return true
+			}
+			continue
+		}
+		if name == path[0] {
+			return hasJSONPath(field.Type, path[1:])
+		}
+	}
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
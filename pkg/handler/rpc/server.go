@@ -0,0 +1,394 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package rpc implements the gRPC API of the cluster and node deployment operations. The
+// requests are served by the same endpoints and middlewares as the REST API, so that
+// authentication, authorization and validation are identical for both.
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/go-kit/kit/endpoint"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	grpcv1 "k8c.io/kubermatic/v2/pkg/api/grpc/v1"
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	"k8c.io/kubermatic/v2/pkg/handler"
+	handlercommon "k8c.io/kubermatic/v2/pkg/handler/common"
+	"k8c.io/kubermatic/v2/pkg/handler/middleware"
+	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
+	"k8c.io/kubermatic/v2/pkg/handler/v2/cluster"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	kubermaticerrors "k8c.io/kubermatic/v2/pkg/util/errors"
+
+	kwatch "k8s.io/apimachinery/pkg/watch"
+	"k8s.io/klog"
+)
+
+// Server serves the ClusterService.
+type Server struct {
+	params handler.RoutingParams
+
+	listClusters            endpoint.Endpoint
+	getCluster              endpoint.Endpoint
+	watchClusters           endpoint.Endpoint
+	listNodeDeployments     endpoint.Endpoint
+	getNodeDeployment       endpoint.Endpoint
+	patchNodeDeployment     endpoint.Endpoint
+	watchMachineDeployments endpoint.Endpoint
+}
+
+var _ grpcv1.ClusterServiceServer = &Server{}
+
+// NewServer creates a new Server.
+func NewServer(params handler.RoutingParams) *Server {
+	userChain := endpoint.Chain(
+		middleware.TokenVerifier(params.TokenVerifiers, params.UserProvider),
+		middleware.UserSaver(params.UserProvider),
+	)
+	clusterChain := endpoint.Chain(
+		middleware.TokenVerifier(params.TokenVerifiers, params.UserProvider),
+		middleware.UserSaver(params.UserProvider),
+		middleware.SetClusterProvider(params.ClusterProviderGetter, params.SeedsGetter),
+		middleware.SetPrivilegedClusterProvider(params.ClusterProviderGetter, params.SeedsGetter),
+	)
+
+	return &Server{
+		params: params,
+
+		listClusters: userChain(cluster.ListEndpoint(params.ProjectProvider, params.PrivilegedProjectProvider, params.SeedsGetter, params.ClusterProviderGetter, params.UserInfoGetter)),
+		getCluster:   clusterChain(cluster.GetEndpoint(params.ProjectProvider, params.PrivilegedProjectProvider, params.SeedsGetter, params.UserInfoGetter)),
+		watchClusters: userChain(func(ctx context.Context, request interface{}) (interface{}, error) {
+			req := request.(common.GetProjectRq)
+			return watchClusters(ctx, params, req.ProjectID)
+		}),
+		listNodeDeployments: clusterChain(func(ctx context.Context, request interface{}) (interface{}, error) {
+			req := request.(nodeDeploymentReq)
+			return handlercommon.ListMachineDeployments(ctx, params.UserInfoGetter, params.ProjectProvider, params.PrivilegedProjectProvider, req.ProjectID, req.ClusterID)
+		}),
+		getNodeDeployment: clusterChain(func(ctx context.Context, request interface{}) (interface{}, error) {
+			req := request.(nodeDeploymentReq)
+			return handlercommon.GetMachineDeployment(ctx, params.UserInfoGetter, params.ProjectProvider, params.PrivilegedProjectProvider, req.ProjectID, req.ClusterID, req.NodeDeploymentID)
+		}),
+		patchNodeDeployment: clusterChain(func(ctx context.Context, request interface{}) (interface{}, error) {
+			req := request.(nodeDeploymentReq)
+			return handlercommon.PatchMachineDeployment(ctx, params.UserInfoGetter, params.ProjectProvider, params.PrivilegedProjectProvider, params.SSHKeyProvider, params.SeedsGetter, params.SettingsProvider, params.CABundle, req.ProjectID, req.ClusterID, req.NodeDeploymentID, req.Patch)
+		}),
+		watchMachineDeployments: clusterChain(func(ctx context.Context, request interface{}) (interface{}, error) {
+			req := request.(nodeDeploymentReq)
+			cluster, err := handlercommon.GetCluster(ctx, params.ProjectProvider, params.PrivilegedProjectProvider, params.UserInfoGetter, req.ProjectID, req.ClusterID, nil)
+			if err != nil {
+				return nil, err
+			}
+			clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
+			return clusterProvider.WatchMachineDeployments(ctx, cluster)
+		}),
+	}
+}
+
+// Register registers the ClusterService with the given gRPC server.
+func (s *Server) Register(server *grpc.Server) {
+	grpcv1.RegisterClusterServiceServer(server, s)
+}
+
+// nodeDeploymentReq is the request of the node deployment endpoints.
+type nodeDeploymentReq struct {
+	common.ProjectReq
+	ClusterID        string
+	NodeDeploymentID string
+	Patch            json.RawMessage
+}
+
+// GetSeedCluster returns the SeedCluster object
+func (req nodeDeploymentReq) GetSeedCluster() apiv1.SeedCluster {
+	return apiv1.SeedCluster{
+		ClusterID: req.ClusterID,
+	}
+}
+
+func (s *Server) ListClusters(ctx context.Context, req *grpcv1.ListClustersRequest) (*grpcv1.ListClustersResponse, error) {
+	clusters, err := s.doListClusters(ctx, req.GetProjectId())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &grpcv1.ListClustersResponse{}
+	for _, cluster := range clusters {
+		resp.Clusters = append(resp.Clusters, convertCluster(cluster))
+	}
+	return resp, nil
+}
+
+func (s *Server) GetCluster(ctx context.Context, req *grpcv1.GetClusterRequest) (*grpcv1.Cluster, error) {
+	resp, err := s.call(ctx, s.getCluster, cluster.GetClusterReq{
+		ProjectReq: common.ProjectReq{ProjectID: req.GetProjectId()},
+		ClusterID:  req.GetClusterId(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return convertCluster(resp.(*apiv1.Cluster)), nil
+}
+
+func (s *Server) WatchClusters(req *grpcv1.WatchClustersRequest, stream grpcv1.ClusterService_WatchClustersServer) error {
+	ctx := stream.Context()
+
+	w := watcher{
+		start: func() (kwatch.Interface, error) {
+			resp, err := s.call(ctx, s.watchClusters, common.GetProjectRq{ProjectReq: common.ProjectReq{ProjectID: req.GetProjectId()}})
+			if err != nil {
+				return nil, err
+			}
+			return resp.(kwatch.Interface), nil
+		},
+		list: func() ([]object, error) {
+			clusters, err := s.doListClusters(ctx, req.GetProjectId())
+			if err != nil {
+				return nil, err
+			}
+
+			objects := make([]object, 0, len(clusters))
+			for _, cluster := range clusters {
+				objects = append(objects, object{id: cluster.ID, message: convertCluster(cluster)})
+			}
+			return objects, nil
+		},
+		get: func(id string) (proto.Message, error) {
+			cluster, err := s.GetCluster(ctx, &grpcv1.GetClusterRequest{ProjectId: req.GetProjectId(), ClusterId: id})
+			if status.Code(err) == codes.NotFound {
+				return nil, nil
+			}
+			return cluster, err
+		},
+	}
+	send := func(event watchEvent) error {
+		return stream.Send(&grpcv1.ClusterEvent{Type: event.eventType, Cluster: event.message.(*grpcv1.Cluster)})
+	}
+
+	return watch(ctx, w, send)
+}
+
+func (s *Server) ListNodeDeployments(ctx context.Context, req *grpcv1.ListNodeDeploymentsRequest) (*grpcv1.ListNodeDeploymentsResponse, error) {
+	nodeDeployments, err := s.doListNodeDeployments(ctx, req.GetProjectId(), req.GetClusterId())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &grpcv1.ListNodeDeploymentsResponse{}
+	for _, nd := range nodeDeployments {
+		resp.NodeDeployments = append(resp.NodeDeployments, convertNodeDeployment(nd))
+	}
+	return resp, nil
+}
+
+func (s *Server) GetNodeDeployment(ctx context.Context, req *grpcv1.GetNodeDeploymentRequest) (*grpcv1.NodeDeployment, error) {
+	resp, err := s.call(ctx, s.getNodeDeployment, nodeDeploymentReq{
+		ProjectReq:       common.ProjectReq{ProjectID: req.GetProjectId()},
+		ClusterID:        req.GetClusterId(),
+		NodeDeploymentID: req.GetNodeDeploymentId(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return convertNodeDeployment(resp.(*apiv1.NodeDeployment)), nil
+}
+
+func (s *Server) ScaleNodeDeployment(ctx context.Context, req *grpcv1.ScaleNodeDeploymentRequest) (*grpcv1.NodeDeployment, error) {
+	if req.GetReplicas() < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "replicas must not be negative, got %d", req.GetReplicas())
+	}
+
+	// scaling is a patch of the replicas, so that it is subject to the same validation as
+	// patches of the REST API, e.g. of frozen replicas
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{"replicas": req.GetReplicas()},
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create patch: %v", err)
+	}
+
+	resp, err := s.call(ctx, s.patchNodeDeployment, nodeDeploymentReq{
+		ProjectReq:       common.ProjectReq{ProjectID: req.GetProjectId()},
+		ClusterID:        req.GetClusterId(),
+		NodeDeploymentID: req.GetNodeDeploymentId(),
+		Patch:            patch,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return convertNodeDeployment(resp.(*apiv1.NodeDeployment)), nil
+}
+
+func (s *Server) WatchNodeDeployments(req *grpcv1.WatchNodeDeploymentsRequest, stream grpcv1.ClusterService_WatchNodeDeploymentsServer) error {
+	ctx := stream.Context()
+
+	w := watcher{
+		start: func() (kwatch.Interface, error) {
+			resp, err := s.call(ctx, s.watchMachineDeployments, nodeDeploymentReq{
+				ProjectReq: common.ProjectReq{ProjectID: req.GetProjectId()},
+				ClusterID:  req.GetClusterId(),
+			})
+			if err != nil {
+				return nil, err
+			}
+			return resp.(kwatch.Interface), nil
+		},
+		list: func() ([]object, error) {
+			nodeDeployments, err := s.doListNodeDeployments(ctx, req.GetProjectId(), req.GetClusterId())
+			if err != nil {
+				return nil, err
+			}
+
+			objects := make([]object, 0, len(nodeDeployments))
+			for _, nd := range nodeDeployments {
+				objects = append(objects, object{id: nd.ID, message: convertNodeDeployment(nd)})
+			}
+			return objects, nil
+		},
+		get: func(id string) (proto.Message, error) {
+			nd, err := s.GetNodeDeployment(ctx, &grpcv1.GetNodeDeploymentRequest{
+				ProjectId:        req.GetProjectId(),
+				ClusterId:        req.GetClusterId(),
+				NodeDeploymentId: id,
+			})
+			if status.Code(err) == codes.NotFound {
+				return nil, nil
+			}
+			return nd, err
+		},
+	}
+	send := func(event watchEvent) error {
+		return stream.Send(&grpcv1.NodeDeploymentEvent{Type: event.eventType, NodeDeployment: event.message.(*grpcv1.NodeDeployment)})
+	}
+
+	return watch(ctx, w, send)
+}
+
+func (s *Server) doListClusters(ctx context.Context, projectID string) ([]*apiv1.Cluster, error) {
+	resp, err := s.call(ctx, s.listClusters, common.GetProjectRq{ProjectReq: common.ProjectReq{ProjectID: projectID}})
+	if err != nil {
+		return nil, err
+	}
+	return resp.([]*apiv1.Cluster), nil
+}
+
+func (s *Server) doListNodeDeployments(ctx context.Context, projectID, clusterID string) ([]*apiv1.NodeDeployment, error) {
+	resp, err := s.call(ctx, s.listNodeDeployments, nodeDeploymentReq{
+		ProjectReq: common.ProjectReq{ProjectID: projectID},
+		ClusterID:  clusterID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.([]*apiv1.NodeDeployment), nil
+}
+
+// watchClusters watches the clusters of the project in all seeds. Seeds whose clusters cannot
+// be watched are skipped, the same way the list of clusters skips them.
+func watchClusters(ctx context.Context, params handler.RoutingParams, projectID string) (kwatch.Interface, error) {
+	if _, err := common.GetProject(ctx, params.UserInfoGetter, params.ProjectProvider, params.PrivilegedProjectProvider, projectID, nil); err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	seeds, err := params.SeedsGetter()
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	var watches []kwatch.Interface
+	for _, seed := range seeds {
+		clusterProvider, err := params.ClusterProviderGetter(seed)
+		if err != nil {
+			klog.Errorf("failed to create cluster provider for seed %s: %v", seed.Name, err)
+			continue
+		}
+		w, err := clusterProvider.WatchClusters(ctx, projectID)
+		if err != nil {
+			klog.Errorf("failed to watch clusters in seed %s: %v", seed.Name, err)
+			continue
+		}
+		watches = append(watches, w)
+	}
+
+	return newMergedWatch(watches), nil
+}
+
+// call invokes the endpoint with the token of the request and converts its errors to gRPC
+// status errors.
+func (s *Server) call(ctx context.Context, e endpoint.Endpoint, request interface{}) (interface{}, error) {
+	resp, err := e(s.withToken(ctx), request)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return resp, nil
+}
+
+// withToken extracts the token from the metadata of the request the same way the REST API
+// extracts it from the HTTP request.
+func (s *Server) withToken(ctx context.Context) context.Context {
+	r := &http.Request{Header: http.Header{}, URL: &url.URL{}}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for key, values := range md {
+			for _, value := range values {
+				r.Header.Add(key, value)
+			}
+		}
+	}
+	return middleware.TokenExtractor(s.params.TokenExtractors)(ctx, r)
+}
+
+// toStatusError converts the errors of the endpoints, which carry HTTP status codes, to gRPC
+// status errors.
+func toStatusError(err error) error {
+	httpErr, ok := err.(kubermaticerrors.HTTPError)
+	if !ok {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	code := codes.Unknown
+	switch httpErr.StatusCode() {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		code = codes.InvalidArgument
+	case http.StatusUnauthorized:
+		code = codes.Unauthenticated
+	case http.StatusForbidden:
+		code = codes.PermissionDenied
+	case http.StatusNotFound:
+		code = codes.NotFound
+	case http.StatusConflict:
+		code = codes.AlreadyExists
+	case http.StatusPreconditionFailed:
+		code = codes.FailedPrecondition
+	case http.StatusTooManyRequests:
+		code = codes.ResourceExhausted
+	case http.StatusNotImplemented:
+		code = codes.Unimplemented
+	case http.StatusServiceUnavailable:
+		code = codes.Unavailable
+	case http.StatusInternalServerError:
+		code = codes.Internal
+	}
+
+	return status.Error(code, httpErr.Error())
+}
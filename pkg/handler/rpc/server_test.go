@@ -0,0 +1,300 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	grpcv1 "k8c.io/kubermatic/v2/pkg/api/grpc/v1"
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/handler"
+	"k8c.io/kubermatic/v2/pkg/handler/auth"
+	"k8c.io/kubermatic/v2/pkg/handler/middleware"
+	"k8c.io/kubermatic/v2/pkg/semver"
+	kubermaticerrors "k8c.io/kubermatic/v2/pkg/util/errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kwatch "k8s.io/apimachinery/pkg/watch"
+	"k8s.io/utils/pointer"
+)
+
+func TestConvertCluster(t *testing.T) {
+	created := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	cluster := &apiv1.Cluster{
+		ObjectMeta: apiv1.ObjectMeta{
+			ID:                "abcd",
+			Name:              "test",
+			CreationTimestamp: apiv1.NewTime(created),
+		},
+		Labels: map[string]string{"team": "a"},
+		Type:   "kubernetes",
+		Spec: apiv1.ClusterSpec{
+			Cloud:   kubermaticv1.CloudSpec{DatacenterName: "azure-westeurope", Azure: &kubermaticv1.AzureCloudSpec{}},
+			Version: *semver.NewSemverOrDie("1.20.4"),
+		},
+		Status: apiv1.ClusterStatus{
+			Version: *semver.NewSemverOrDie("1.20.4"),
+			URL:     "https://abcd.example.com:31000",
+		},
+	}
+
+	expected := &grpcv1.Cluster{
+		Metadata: &grpcv1.ObjectMeta{Id: "abcd", Name: "test", CreationTimestamp: convertObjectMeta(cluster.ObjectMeta).CreationTimestamp},
+		Labels:   map[string]string{"team": "a"},
+		Type:     "kubernetes",
+		Spec:     &grpcv1.ClusterSpec{Datacenter: "azure-westeurope", Provider: "azure", Version: "1.20.4"},
+		Status:   &grpcv1.ClusterStatus{Version: "1.20.4", Url: "https://abcd.example.com:31000"},
+	}
+
+	converted := convertCluster(cluster)
+	if !proto.Equal(converted, expected) {
+		t.Fatalf("expected %v, got %v", expected, converted)
+	}
+	if !converted.Metadata.CreationTimestamp.AsTime().Equal(created) {
+		t.Fatalf("expected the creation timestamp %v, got %v", created, converted.Metadata.CreationTimestamp.AsTime())
+	}
+	if converted.Metadata.DeletionTimestamp != nil {
+		t.Fatalf("expected no deletion timestamp, got %v", converted.Metadata.DeletionTimestamp)
+	}
+}
+
+func TestConvertNodeDeployment(t *testing.T) {
+	nd := &apiv1.NodeDeployment{
+		ObjectMeta: apiv1.ObjectMeta{ID: "workers", Name: "workers"},
+		Spec: apiv1.NodeDeploymentSpec{
+			Replicas:       3,
+			Template:       apiv1.NodeSpec{Versions: apiv1.NodeVersionInfo{Kubelet: "1.20.4"}},
+			ReplicasFrozen: pointer.BoolPtr(true),
+		},
+	}
+	nd.Status.Replicas = 3
+	nd.Status.ReadyReplicas = 2
+
+	expected := &grpcv1.NodeDeployment{
+		Metadata: &grpcv1.ObjectMeta{Id: "workers", Name: "workers"},
+		Spec:     &grpcv1.NodeDeploymentSpec{Replicas: 3, KubeletVersion: "1.20.4", ReplicasFrozen: true},
+		Status:   &grpcv1.NodeDeploymentStatus{Replicas: 3, ReadyReplicas: 2},
+	}
+
+	if converted := convertNodeDeployment(nd); !proto.Equal(converted, expected) {
+		t.Fatalf("expected %v, got %v", expected, converted)
+	}
+}
+
+func TestToStatusError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected codes.Code
+	}{
+		{
+			name:     "not found",
+			err:      kubermaticerrors.NewNotFound("cluster", "abcd"),
+			expected: codes.NotFound,
+		},
+		{
+			name:     "unauthorized",
+			err:      kubermaticerrors.New(http.StatusUnauthorized, "invalid token"),
+			expected: codes.Unauthenticated,
+		},
+		{
+			name:     "forbidden",
+			err:      kubermaticerrors.New(http.StatusForbidden, "forbidden"),
+			expected: codes.PermissionDenied,
+		},
+		{
+			name:     "bad request",
+			err:      kubermaticerrors.NewBadRequest("the replicas are frozen"),
+			expected: codes.InvalidArgument,
+		},
+		{
+			name:     "plain error",
+			err:      errors.New("boom"),
+			expected: codes.Internal,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := toStatusError(test.err)
+			if code := status.Code(err); code != test.expected {
+				t.Fatalf("expected code %v, got %v", test.expected, code)
+			}
+			if msg := status.Convert(err).Message(); msg != test.err.Error() {
+				t.Fatalf("expected message %q, got %q", test.err.Error(), msg)
+			}
+		})
+	}
+}
+
+func TestWithToken(t *testing.T) {
+	s := &Server{params: handler.RoutingParams{TokenExtractors: auth.NewHeaderBearerTokenExtractor("Authorization")}}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer token"))
+	if token := s.withToken(ctx).Value(middleware.RawTokenContextKey); token != "token" {
+		t.Fatalf("expected the token to be extracted from the metadata, got %v", token)
+	}
+
+	if token := s.withToken(context.Background()).Value(middleware.RawTokenContextKey); token != nil {
+		t.Fatalf("expected no token without metadata, got %v", token)
+	}
+}
+
+func TestDiffObjects(t *testing.T) {
+	a := &grpcv1.Cluster{Metadata: &grpcv1.ObjectMeta{Id: "a"}}
+	b := &grpcv1.Cluster{Metadata: &grpcv1.ObjectMeta{Id: "b"}}
+	bModified := &grpcv1.Cluster{Metadata: &grpcv1.ObjectMeta{Id: "b"}, Type: "kubernetes"}
+	c := &grpcv1.Cluster{Metadata: &grpcv1.ObjectMeta{Id: "c"}}
+
+	events, seen := diffObjects(nil, []object{{id: "a", message: a}, {id: "b", message: b}})
+	assertEvents(t, events, []watchEvent{
+		{eventType: grpcv1.EventType_EVENT_TYPE_ADDED, message: a},
+		{eventType: grpcv1.EventType_EVENT_TYPE_ADDED, message: b},
+	})
+
+	events, seen = diffObjects(seen, []object{{id: "b", message: bModified}, {id: "c", message: c}})
+	assertEvents(t, events, []watchEvent{
+		{eventType: grpcv1.EventType_EVENT_TYPE_MODIFIED, message: bModified},
+		{eventType: grpcv1.EventType_EVENT_TYPE_ADDED, message: c},
+		{eventType: grpcv1.EventType_EVENT_TYPE_DELETED, message: a},
+	})
+
+	events, _ = diffObjects(seen, []object{{id: "b", message: proto.Clone(bModified)}, {id: "c", message: c}})
+	assertEvents(t, events, nil)
+}
+
+func assertEvents(t *testing.T, events, expected []watchEvent) {
+	t.Helper()
+
+	if len(events) != len(expected) {
+		t.Fatalf("expected %d events, got %d: %v", len(expected), len(events), events)
+	}
+	for i := range events {
+		if events[i].eventType != expected[i].eventType || !proto.Equal(events[i].message, expected[i].message) {
+			t.Fatalf("expected event %d to be %v %v, got %v %v", i, expected[i].eventType, expected[i].message, events[i].eventType, events[i].message)
+		}
+	}
+}
+
+func TestWatch(t *testing.T) {
+	a := &grpcv1.Cluster{Metadata: &grpcv1.ObjectMeta{Id: "a"}}
+	b := &grpcv1.Cluster{Metadata: &grpcv1.ObjectMeta{Id: "b"}}
+	bModified := &grpcv1.Cluster{Metadata: &grpcv1.ObjectMeta{Id: "b"}, Type: "kubernetes"}
+	c := &grpcv1.Cluster{Metadata: &grpcv1.ObjectMeta{Id: "c"}}
+
+	lock := sync.Mutex{}
+	store := map[string]proto.Message{"a": a, "b": b}
+	watches := make(chan *kwatch.FakeWatcher, 2)
+	w := watcher{
+		start: func() (kwatch.Interface, error) {
+			fake := kwatch.NewFakeWithChanSize(10, false)
+			watches <- fake
+			return fake, nil
+		},
+		list: func() ([]object, error) {
+			lock.Lock()
+			defer lock.Unlock()
+
+			var objects []object
+			for _, id := range []string{"a", "b", "c"} {
+				if message, ok := store[id]; ok {
+					objects = append(objects, object{id: id, message: message})
+				}
+			}
+			return objects, nil
+		},
+		get: func(id string) (proto.Message, error) {
+			lock.Lock()
+			defer lock.Unlock()
+
+			return store[id], nil
+		},
+	}
+	update := func(id string, message proto.Message) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		if message == nil {
+			delete(store, id)
+		} else {
+			store[id] = message
+		}
+	}
+	cluster := func(id string) *kubermaticv1.Cluster {
+		return &kubermaticv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: id}}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := make(chan watchEvent)
+	result := make(chan error)
+	go func() {
+		result <- watch(ctx, w, func(event watchEvent) error {
+			events <- event
+			return nil
+		})
+	}()
+	next := func() watchEvent {
+		select {
+		case event := <-events:
+			return event
+		case <-time.After(10 * time.Second):
+			t.Fatal("timed out waiting for an event")
+		}
+		return watchEvent{}
+	}
+
+	fake := <-watches
+	assertEvents(t, []watchEvent{next(), next()}, []watchEvent{
+		{eventType: grpcv1.EventType_EVENT_TYPE_ADDED, message: a},
+		{eventType: grpcv1.EventType_EVENT_TYPE_ADDED, message: b},
+	})
+
+	update("b", bModified)
+	fake.Modify(cluster("b"))
+	// an event without changes is not sent
+	fake.Modify(cluster("a"))
+	update("a", nil)
+	fake.Delete(cluster("a"))
+	assertEvents(t, []watchEvent{next(), next()}, []watchEvent{
+		{eventType: grpcv1.EventType_EVENT_TYPE_MODIFIED, message: bModified},
+		{eventType: grpcv1.EventType_EVENT_TYPE_DELETED, message: a},
+	})
+
+	// changes while the watch is restarted are caught up by listing the objects
+	update("c", c)
+	fake.Stop()
+	<-watches
+	assertEvents(t, []watchEvent{next()}, []watchEvent{
+		{eventType: grpcv1.EventType_EVENT_TYPE_ADDED, message: c},
+	})
+
+	cancel()
+	if err := <-result; err != nil {
+		t.Fatalf("expected watch to end without error, got %v", err)
+	}
+}
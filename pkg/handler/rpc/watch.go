@@ -0,0 +1,247 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rpc
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	grpcv1 "k8c.io/kubermatic/v2/pkg/api/grpc/v1"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	kwatch "k8s.io/apimachinery/pkg/watch"
+)
+
+// object is a watched object together with its ID.
+type object struct {
+	id      string
+	message proto.Message
+}
+
+// watchEvent is a change of a watched object.
+type watchEvent struct {
+	eventType grpcv1.EventType
+	message   proto.Message
+}
+
+// diffObjects returns the changes between the previously seen and the current objects and the
+// objects to compare the next list with. Added and modified objects are returned in the order
+// of the current list, deleted ones sorted by their ID.
+func diffObjects(previous map[string]proto.Message, current []object) ([]watchEvent, map[string]proto.Message) {
+	var events []watchEvent
+	seen := make(map[string]proto.Message, len(current))
+
+	for _, obj := range current {
+		seen[obj.id] = obj.message
+
+		old, ok := previous[obj.id]
+		switch {
+		case !ok:
+			events = append(events, watchEvent{eventType: grpcv1.EventType_EVENT_TYPE_ADDED, message: obj.message})
+		case !proto.Equal(old, obj.message):
+			events = append(events, watchEvent{eventType: grpcv1.EventType_EVENT_TYPE_MODIFIED, message: obj.message})
+		}
+	}
+
+	var deleted []string
+	for id := range previous {
+		if _, ok := seen[id]; !ok {
+			deleted = append(deleted, id)
+		}
+	}
+	sort.Strings(deleted)
+	for _, id := range deleted {
+		events = append(events, watchEvent{eventType: grpcv1.EventType_EVENT_TYPE_DELETED, message: previous[id]})
+	}
+
+	return events, seen
+}
+
+// watcher provides the objects of a watch.
+type watcher struct {
+	// start starts a Kubernetes watch of the objects. The names of the objects in its events
+	// are the IDs of the objects.
+	start func() (kwatch.Interface, error)
+	// list returns all objects.
+	list func() ([]object, error)
+	// get returns the object with the given ID, or nil if it does not exist.
+	get func(id string) (proto.Message, error)
+}
+
+// watchRestartDelay is the delay before a Kubernetes watch that ended is started again.
+const watchRestartDelay = time.Second
+
+// watch sends the changes of the objects until the context is done or listing, getting or
+// sending fails. The objects are listed once when the Kubernetes watch starts, afterwards only
+// the objects its events refer to are fetched. Objects are always fetched through the endpoints
+// of the API, so that the changes are subject to the same authorization as the REST API. When
+// the Kubernetes watch ends, it is started again and the objects are listed to catch up with
+// the changes that were missed in between.
+func watch(ctx context.Context, w watcher, send func(watchEvent) error) error {
+	var known map[string]proto.Message
+	for {
+		kw, err := w.start()
+		if err != nil {
+			return err
+		}
+
+		known, err = watchOnce(ctx, w, kw, known, send)
+		kw.Stop()
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(watchRestartDelay):
+		}
+	}
+}
+
+// watchOnce lists the objects and sends their changes until the context is done or the
+// Kubernetes watch ends. It returns the objects sent so far.
+func watchOnce(ctx context.Context, w watcher, kw kwatch.Interface, known map[string]proto.Message, send func(watchEvent) error) (map[string]proto.Message, error) {
+	current, err := w.list()
+	if err != nil {
+		return nil, err
+	}
+
+	var events []watchEvent
+	events, known = diffObjects(known, current)
+	for _, event := range events {
+		if err := send(event); err != nil {
+			return nil, err
+		}
+	}
+
+	for {
+		var kevent kwatch.Event
+		select {
+		case <-ctx.Done():
+			return known, nil
+		case e, ok := <-kw.ResultChan():
+			if !ok {
+				return known, nil
+			}
+			kevent = e
+		}
+
+		switch kevent.Type {
+		case kwatch.Error:
+			// e.g. the resource version is too old, the watch is restarted and catches up by
+			// listing the objects
+			return known, nil
+		case kwatch.Bookmark:
+			continue
+		}
+
+		accessor, err := meta.Accessor(kevent.Object)
+		if err != nil {
+			continue
+		}
+		id := accessor.GetName()
+
+		message, err := w.get(id)
+		if err != nil {
+			return nil, err
+		}
+
+		old, ok := known[id]
+		var event watchEvent
+		switch {
+		case message == nil && ok:
+			delete(known, id)
+			event = watchEvent{eventType: grpcv1.EventType_EVENT_TYPE_DELETED, message: old}
+		case message == nil:
+			continue
+		case !ok:
+			known[id] = message
+			event = watchEvent{eventType: grpcv1.EventType_EVENT_TYPE_ADDED, message: message}
+		case !proto.Equal(old, message):
+			known[id] = message
+			event = watchEvent{eventType: grpcv1.EventType_EVENT_TYPE_MODIFIED, message: message}
+		default:
+			continue
+		}
+
+		if err := send(event); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// mergedWatch merges the events of several Kubernetes watches. It ends as soon as one of the
+// watches ends.
+type mergedWatch struct {
+	watches []kwatch.Interface
+	result  chan kwatch.Event
+	stop    chan struct{}
+	once    sync.Once
+}
+
+var _ kwatch.Interface = &mergedWatch{}
+
+func newMergedWatch(watches []kwatch.Interface) *mergedWatch {
+	m := &mergedWatch{
+		watches: watches,
+		result:  make(chan kwatch.Event),
+		stop:    make(chan struct{}),
+	}
+
+	wg := sync.WaitGroup{}
+	for _, w := range watches {
+		wg.Add(1)
+		go func(w kwatch.Interface) {
+			defer wg.Done()
+			defer m.Stop()
+			for event := range w.ResultChan() {
+				select {
+				case m.result <- event:
+				case <-m.stop:
+					return
+				}
+			}
+		}(w)
+	}
+	go func() {
+		wg.Wait()
+		close(m.result)
+	}()
+
+	if len(watches) == 0 {
+		m.Stop()
+	}
+	return m
+}
+
+func (m *mergedWatch) Stop() {
+	m.once.Do(func() {
+		close(m.stop)
+		for _, w := range m.watches {
+			w.Stop()
+		}
+	})
+}
+
+func (m *mergedWatch) ResultChan() <-chan kwatch.Event {
+	return m.result
+}
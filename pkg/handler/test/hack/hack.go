@@ -87,7 +87,9 @@ func NewTestRouting(
 	kubermaticVersions kubermatic.Versions,
 	defaultConstraintProvider provider.DefaultConstraintProvider,
 	privilegedWhitelistedRegistryProvider provider.PrivilegedWhitelistedRegistryProvider,
-	etcdBackupConfigProviderGetter provider.EtcdBackupConfigProviderGetter) http.Handler {
+	etcdBackupConfigProviderGetter provider.EtcdBackupConfigProviderGetter,
+	privilegedAddonRolloutProvider provider.PrivilegedAddonRolloutProvider,
+	privilegedNamespaceTemplateProvider provider.PrivilegedNamespaceTemplateProvider) http.Handler {
 
 	updateManager := version.New(versions, updates)
 
@@ -138,6 +140,8 @@ func NewTestRouting(
 		RuleGroupProviderGetter:               ruleGroupProviderGetter,
 		PrivilegedWhitelistedRegistryProvider: privilegedWhitelistedRegistryProvider,
 		EtcdBackupConfigProviderGetter:        etcdBackupConfigProviderGetter,
+		PrivilegedAddonRolloutProvider:        privilegedAddonRolloutProvider,
+		PrivilegedNamespaceTemplateProvider:   privilegedNamespaceTemplateProvider,
 		Versions:                              kubermaticVersions,
 		CABundle:                              certificates.NewFakeCABundle().CertPool(),
 	}
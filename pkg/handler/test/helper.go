@@ -196,6 +196,8 @@ type newRoutingFunc func(
 	defaultConstraintProvider provider.DefaultConstraintProvider,
 	privilegedWhitelistedRegistryProvider provider.PrivilegedWhitelistedRegistryProvider,
 	etcdBackupConfigProviderGetter provider.EtcdBackupConfigProviderGetter,
+	privilegedAddonRolloutProvider provider.PrivilegedAddonRolloutProvider,
+	privilegedNamespaceTemplateProvider provider.PrivilegedNamespaceTemplateProvider,
 ) http.Handler
 
 func getRuntimeObjects(objs ...ctrlruntimeclient.Object) []runtime.Object {
@@ -481,6 +483,8 @@ func initTestEndpoint(user apiv1.User, seedsGetter provider.SeedsGetter, kubeObj
 		fakeDefaultConstraintProvider,
 		fakePrivilegedWhitelistedRegistryProvider,
 		etcdBackupConfigProviderGetter,
+		kubernetes.NewAddonRolloutPrivilegedProvider(fakeClient),
+		kubernetes.NewNamespaceTemplatePrivilegedProvider(fakeClient),
 	)
 
 	return mainRouter, &ClientsSets{kubermaticClient, fakeClient, kubernetesClient, tokenAuth, tokenGenerator}, nil
@@ -619,6 +623,10 @@ func (f *fakeUserClusterConnection) GetClient(_ context.Context, _ *kubermaticv1
 	return f.fakeDynamicClient, nil
 }
 
+func (f *fakeUserClusterConnection) GetClientConfig(_ context.Context, _ *kubermaticv1.Cluster, _ ...k8cuserclusterclient.ConfigOption) (*restclient.Config, error) {
+	return &restclient.Config{}, nil
+}
+
 // ClientsSets a simple wrapper that holds fake client sets
 type ClientsSets struct {
 	FakeKubermaticClient *kubermaticfakeclientset.Clientset
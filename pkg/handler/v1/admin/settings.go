@@ -30,6 +30,9 @@ import (
 	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
 	"k8c.io/kubermatic/v2/pkg/provider"
 	"k8c.io/kubermatic/v2/pkg/util/errors"
+	"k8c.io/kubermatic/v2/pkg/validation"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 // KubermaticSettingsEndpoint returns global settings
@@ -83,6 +86,9 @@ func UpdateKubermaticSettingsEndpoint(userInfoGetter provider.UserInfoGetter, se
 		if err != nil {
 			return nil, errors.NewBadRequest("cannot decode patched settings: %v", err)
 		}
+		if errs := validation.ValidateClusterAdmissionWebhook(patchedGlobalSettingsSpec.ClusterAdmissionWebhook, field.NewPath("clusterAdmissionWebhook")); len(errs) > 0 {
+			return nil, errors.NewBadRequest("invalid settings: %v", errs.ToAggregate())
+		}
 
 		existingGlobalSettings.Spec = *patchedGlobalSettingsSpec
 		globalSettings, err := settingsProvider.UpdateGlobalSettings(userInfo, existingGlobalSettings)
@@ -0,0 +1,301 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admin
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	k8cerrors "k8c.io/kubermatic/v2/pkg/util/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// DefaultFleetStatisticsTTL is the duration computed fleet statistics are served from the cache.
+	DefaultFleetStatisticsTTL = 5 * time.Minute
+
+	// clusterProvisioningGracePeriod is the time a new cluster has to become healthy before it is
+	// considered to be failing.
+	clusterProvisioningGracePeriod = 15 * time.Minute
+
+	// clusterStatisticsTimeout is the time the nodes of a single cluster are counted for, so that
+	// an unresponsive cluster does not hold up the statistics.
+	clusterStatisticsTimeout = 10 * time.Second
+
+	// clusterStatisticsWorkers is the number of clusters whose nodes are counted in parallel.
+	clusterStatisticsWorkers = 10
+
+	// nodeListPageSize is the number of nodes listed per request.
+	nodeListPageSize = 500
+)
+
+// FleetStatisticsAggregator computes statistics about all clusters of all seeds. This requires
+// listing the clusters of every seed and the nodes of every cluster, so the result is computed in
+// the background and served from a cache.
+type FleetStatisticsAggregator struct {
+	seedsGetter           provider.SeedsGetter
+	clusterProviderGetter provider.ClusterProviderGetter
+	updateManager         common.UpdateManager
+	ttl                   time.Duration
+	now                   func() time.Time
+
+	lock       sync.Mutex
+	statistics *apiv1.FleetStatistics
+	err        error
+	// computed is closed once the running computation finished, it is nil while none is running
+	computed chan struct{}
+}
+
+// NewFleetStatisticsAggregator returns an aggregator which caches the statistics for the given ttl.
+func NewFleetStatisticsAggregator(seedsGetter provider.SeedsGetter, clusterProviderGetter provider.ClusterProviderGetter, updateManager common.UpdateManager, ttl time.Duration) *FleetStatisticsAggregator {
+	return &FleetStatisticsAggregator{
+		seedsGetter:           seedsGetter,
+		clusterProviderGetter: clusterProviderGetter,
+		updateManager:         updateManager,
+		ttl:                   ttl,
+		now:                   time.Now,
+	}
+}
+
+// Get returns the cached statistics. Once they are older than the ttl they are recomputed in the
+// background while the previous result is still served. Only the very first callers wait for the
+// statistics to be computed, at most until their context is done.
+func (a *FleetStatisticsAggregator) Get(ctx context.Context) (*apiv1.FleetStatistics, error) {
+	a.lock.Lock()
+	statistics := a.statistics
+	if statistics == nil || a.now().Sub(statistics.GeneratedAt.Time) >= a.ttl {
+		a.computeInBackground()
+	}
+	computed := a.computed
+	a.lock.Unlock()
+
+	if statistics != nil {
+		return statistics, nil
+	}
+
+	select {
+	case <-computed:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if a.statistics == nil {
+		return nil, a.err
+	}
+	return a.statistics, nil
+}
+
+// computeInBackground starts computing the statistics unless a computation is running already.
+// The computation does not use the context of a request, so it is not aborted with the request
+// that triggered it. The lock must be held.
+func (a *FleetStatisticsAggregator) computeInBackground() {
+	if a.computed != nil {
+		return
+	}
+
+	computed := make(chan struct{})
+	a.computed = computed
+
+	go func() {
+		defer close(computed)
+
+		statistics, err := a.compute(context.Background())
+
+		a.lock.Lock()
+		defer a.lock.Unlock()
+
+		// a failed computation keeps the previous statistics, they are recomputed with the next call
+		if err == nil {
+			a.statistics = statistics
+		}
+		a.err = err
+		a.computed = nil
+	}()
+}
+
+func (a *FleetStatisticsAggregator) compute(ctx context.Context) (*apiv1.FleetStatistics, error) {
+	seeds, err := a.seedsGetter()
+	if err != nil {
+		return nil, err
+	}
+
+	statistics := &apiv1.FleetStatistics{
+		ClustersByProvider: map[string]int{},
+		ClustersByVersion:  map[string]int{},
+		ClustersBySeed:     map[string]int{},
+		GeneratedAt:        apiv1.NewTime(a.now()),
+	}
+
+	seedNames := make([]string, 0, len(seeds))
+	for name := range seeds {
+		seedNames = append(seedNames, name)
+	}
+	sort.Strings(seedNames)
+
+	// the possible upgrades only depend on the version, so they are looked up once per version
+	pendingUpgrades := map[string]bool{}
+
+	// the nodes are counted in parallel, as this requires a request to every cluster
+	var (
+		wg      sync.WaitGroup
+		nodes   int64
+		workers = make(chan struct{}, clusterStatisticsWorkers)
+	)
+	defer wg.Wait()
+
+	for _, seedName := range seedNames {
+		clusterProvider, err := a.clusterProviderGetter(seeds[seedName])
+		if err != nil {
+			statistics.UnreachableSeeds = append(statistics.UnreachableSeeds, seedName)
+			continue
+		}
+
+		clusters, err := clusterProvider.ListAll()
+		if err != nil {
+			statistics.UnreachableSeeds = append(statistics.UnreachableSeeds, seedName)
+			continue
+		}
+
+		statistics.ClustersBySeed[seedName] = 0
+		for i := range clusters.Items {
+			cluster := &clusters.Items[i]
+			if cluster.DeletionTimestamp != nil {
+				continue
+			}
+
+			statistics.Clusters++
+			statistics.ClustersBySeed[seedName]++
+
+			providerName, err := provider.ClusterCloudProviderName(cluster.Spec.Cloud)
+			if err != nil || providerName == "" {
+				providerName = "unknown"
+			}
+			statistics.ClustersByProvider[providerName]++
+
+			version := cluster.Spec.Version.String()
+			statistics.ClustersByVersion[version]++
+
+			pending, checked := pendingUpgrades[version]
+			if !checked {
+				updates, err := a.updateManager.GetPossibleUpdates(version, apiv1.KubernetesClusterType)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get possible updates for version %s: %v", version, err)
+				}
+				pending = len(updates) > 0
+				pendingUpgrades[version] = pending
+			}
+			if pending {
+				statistics.PendingUpgrades++
+			}
+
+			if a.isFailing(cluster) {
+				statistics.FailingClusters++
+			}
+
+			// nodes can only be counted for clusters with a running API server, the statistics
+			// are best effort for the others
+			if cluster.Status.ExtendedHealth.Apiserver != kubermaticv1.HealthStatusUp {
+				continue
+			}
+
+			wg.Add(1)
+			workers <- struct{}{}
+			go func(clusterProvider provider.ClusterProvider, cluster *kubermaticv1.Cluster) {
+				defer func() {
+					<-workers
+					wg.Done()
+				}()
+				atomic.AddInt64(&nodes, int64(countNodes(ctx, clusterProvider, cluster)))
+			}(clusterProvider, cluster)
+		}
+	}
+
+	wg.Wait()
+	statistics.Nodes = int(nodes)
+
+	return statistics, nil
+}
+
+// countNodes returns the number of nodes of the cluster. The nodes are listed in pages and
+// only until the timeout for a single cluster is exceeded.
+func countNodes(ctx context.Context, clusterProvider provider.ClusterProvider, cluster *kubermaticv1.Cluster) int {
+	ctx, cancel := context.WithTimeout(ctx, clusterStatisticsTimeout)
+	defer cancel()
+
+	client, err := clusterProvider.GetAdminClientForCustomerCluster(ctx, cluster)
+	if err != nil {
+		return 0
+	}
+
+	count := 0
+	nodes := &corev1.NodeList{}
+	for {
+		if err := client.List(ctx, nodes, ctrlruntimeclient.Limit(nodeListPageSize), ctrlruntimeclient.Continue(nodes.Continue)); err != nil {
+			return count
+		}
+		count += len(nodes.Items)
+		if nodes.Continue == "" {
+			return count
+		}
+	}
+}
+
+// isFailing returns true if the controllers reported an error for the cluster or if it is not
+// healthy although it has been created a while ago.
+func (a *FleetStatisticsAggregator) isFailing(cluster *kubermaticv1.Cluster) bool {
+	if cluster.Status.ErrorReason != nil {
+		return true
+	}
+
+	return !cluster.Status.ExtendedHealth.AllHealthy() && a.now().Sub(cluster.CreationTimestamp.Time) > clusterProvisioningGracePeriod
+}
+
+// FleetStatisticsEndpoint returns statistics about all clusters of all seeds
+func FleetStatisticsEndpoint(userInfoGetter provider.UserInfoGetter, aggregator *FleetStatisticsAggregator) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		userInfo, err := userInfoGetter(ctx, "")
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		if !userInfo.IsAdmin {
+			return nil, k8cerrors.New(http.StatusForbidden, fmt.Sprintf("forbidden: \"%s\" doesn't have admin rights", userInfo.Email))
+		}
+
+		statistics, err := aggregator.Get(ctx)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		return statistics, nil
+	}
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admin_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/go-test/deep"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/handler/test"
+	"k8c.io/kubermatic/v2/pkg/handler/test/hack"
+	k8csemver "k8c.io/kubermatic/v2/pkg/semver"
+	"k8c.io/kubermatic/v2/pkg/version"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestGetFleetStatisticsEndpoint(t *testing.T) {
+	t.Parallel()
+
+	failingCluster := test.GenCluster("failingcluster", "failing", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC))
+	reason := kubermaticv1.ReconcileClusterError
+	failingCluster.Status.ErrorReason = &reason
+	failingCluster.Status.ExtendedHealth.Apiserver = kubermaticv1.HealthStatusDown
+	failingCluster.Spec.Version = *k8csemver.NewSemverOrDie("1.6.0")
+
+	versions := []*version.Version{
+		{
+			Version: semver.MustParse("1.6.0"),
+			Type:    apiv1.KubernetesClusterType,
+		},
+		{
+			Version: semver.MustParse("1.6.1"),
+			Type:    apiv1.KubernetesClusterType,
+		},
+	}
+	updates := []*version.Update{
+		{
+			From:      "1.6.0",
+			To:        "1.6.1",
+			Automatic: false,
+			Type:      apiv1.KubernetesClusterType,
+		},
+	}
+
+	testcases := []struct {
+		name                   string
+		expectedResponse       *apiv1.FleetStatistics
+		expectedError          string
+		httpStatus             int
+		existingAPIUser        *apiv1.User
+		existingKubermaticObjs []ctrlruntimeclient.Object
+		existingKubernetesObjs []ctrlruntimeclient.Object
+	}{
+		// scenario 1
+		{
+			name:                   "scenario 1: not authorized user gets fleet statistics",
+			expectedError:          `{"error":{"code":403,"message":"forbidden: \"bob@acme.com\" doesn't have admin rights"}}`,
+			httpStatus:             http.StatusForbidden,
+			existingKubermaticObjs: []ctrlruntimeclient.Object{test.GenTestSeed(), test.GenDefaultCluster()},
+			existingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 2
+		{
+			name:       "scenario 2: authorized user gets fleet statistics",
+			httpStatus: http.StatusOK,
+			existingKubermaticObjs: []ctrlruntimeclient.Object{
+				genUser("Bob", "bob@acme.com", true),
+				test.GenTestSeed(),
+				test.GenDefaultCluster(),
+				failingCluster,
+			},
+			existingKubernetesObjs: []ctrlruntimeclient.Object{
+				&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}},
+				&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-2"}},
+			},
+			existingAPIUser: test.GenDefaultAPIUser(),
+			expectedResponse: &apiv1.FleetStatistics{
+				Clusters:           2,
+				ClustersByProvider: map[string]int{"fake": 2},
+				ClustersByVersion:  map[string]int{"1.6.0": 1, "9.9.9": 1},
+				ClustersBySeed:     map[string]int{"us-central1": 2},
+				// nodes are only counted for clusters with a running API server
+				Nodes:           2,
+				FailingClusters: 1,
+				PendingUpgrades: 1,
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/api/v1/admin/statistics", strings.NewReader(""))
+			res := httptest.NewRecorder()
+			ep, _, err := test.CreateTestEndpointAndGetClients(*tc.existingAPIUser, nil, tc.existingKubernetesObjs, nil, tc.existingKubermaticObjs, versions, updates, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.httpStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.httpStatus, res.Code, res.Body.String())
+			}
+
+			if tc.expectedResponse == nil {
+				test.CompareWithResult(t, res, tc.expectedError)
+				return
+			}
+
+			statistics := &apiv1.FleetStatistics{}
+			if err := json.Unmarshal(res.Body.Bytes(), statistics); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			if statistics.GeneratedAt.IsZero() {
+				t.Error("expected the generation time to be set")
+			}
+			statistics.GeneratedAt = apiv1.Time{}
+
+			if diff := deep.Equal(statistics, tc.expectedResponse); diff != nil {
+				t.Errorf("got different statistics than expected, diff: %v", diff)
+			}
+		})
+	}
+}
@@ -90,5 +90,6 @@ func ConvertInternalProjectToExternal(kubermaticProject *kubermaticapiv1.Project
 		Status:         kubermaticProject.Status.Phase,
 		Owners:         projectOwners,
 		ClustersNumber: clustersNumber,
+		DefaultPresets: kubermaticProject.Spec.DefaultPresets,
 	}
 }
@@ -671,6 +671,8 @@ func ConvertInternalDCToExternalSpec(dc *kubermaticv1.Datacenter, seedName strin
 		RequiredEmailDomains:     dc.Spec.RequiredEmailDomains,
 		EnforceAuditLogging:      dc.Spec.EnforceAuditLogging,
 		EnforcePodSecurityPolicy: dc.Spec.EnforcePodSecurityPolicy,
+		CapacityLimits:           dc.Spec.CapacityLimits,
+		MachineFlavorFilter:      dc.Spec.MachineFlavorFilter,
 	}, nil
 }
 
@@ -697,6 +699,8 @@ func convertExternalDCToInternal(datacenter *apiv1.DatacenterSpec) kubermaticv1.
 			RequiredEmailDomains:     datacenter.RequiredEmailDomains,
 			EnforceAuditLogging:      datacenter.EnforceAuditLogging,
 			EnforcePodSecurityPolicy: datacenter.EnforcePodSecurityPolicy,
+			CapacityLimits:           datacenter.CapacityLimits,
+			MachineFlavorFilter:      datacenter.MachineFlavorFilter,
 		},
 	}
 }
@@ -293,6 +293,28 @@ func TestCreateNodeDeployment(t *testing.T) {
 			),
 			ExistingAPIUser: test.GenDefaultAPIUser(),
 		},
+
+		// scenario 8
+		{
+			Name:             "scenario 8: machine flavor is denied by the global settings",
+			Body:             `{"spec":{"replicas":1,"template":{"cloud":{"digitalocean":{"size":"s-1vcpu-1gb","backups":false,"ipv6":false,"monitoring":false,"tags":[]}},"operatingSystem":{"ubuntu":{"distUpgradeOnBoot":false}}}}}`,
+			ExpectedResponse: `{"error":{"code":400,"message":"node deployment validation failed: machine flavor \"s-1vcpu-1gb\" is not allowed in this datacenter"}}`,
+			HTTPStatus:       http.StatusBadRequest,
+			ProjectID:        test.GenDefaultProject().Name,
+			ClusterID:        test.GenDefaultCluster().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenTestSeed(),
+				genTestCluster(true),
+				func() *kubermaticv1.KubermaticSetting {
+					settings := test.GenDefaultGlobalSettings()
+					settings.Spec.MachineFlavorFilters = map[string]kubermaticv1.MachineFlavorFilter{
+						"digitalocean": {Denied: []string{"s-1vcpu-*"}},
+					}
+					return settings
+				}(),
+			),
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+		},
 	}
 
 	for _, tc := range testcases {
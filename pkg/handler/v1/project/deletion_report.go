@@ -0,0 +1,176 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package project
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/go-kit/kit/endpoint"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/util/errors"
+
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GetDeletionReportEndpoint defines an HTTP endpoint that lists everything which is destroyed
+// together with a project. For a project which is being deleted, it reports the progress of the
+// staged deletion instead.
+func GetDeletionReportEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider,
+	sshKeyProvider provider.SSHKeyProvider, privilegedServiceAccountProvider provider.PrivilegedServiceAccountProvider,
+	seedsGetter provider.SeedsGetter, seedsClientGetter provider.SeedClientGetter, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(deletionReportRq)
+		if !ok {
+			return nil, errors.NewBadRequest("invalid request")
+		}
+		if len(req.ProjectID) == 0 {
+			return nil, errors.NewBadRequest("the id of the project cannot be empty")
+		}
+
+		project, err := common.GetProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, req.ProjectID, &provider.ProjectGetOptions{IncludeUninitialized: true})
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		if project.Status.Deletion != nil {
+			return convertDeletionStatusToReport(project.Status.Deletion), nil
+		}
+
+		var resources []apiv1.ProjectDeletionReportResource
+
+		seeds, err := seedsGetter()
+		if err != nil {
+			return nil, errors.New(http.StatusInternalServerError, fmt.Sprintf("failed to list seeds: %v", err))
+		}
+		seedNames := make([]string, 0, len(seeds))
+		for seedName := range seeds {
+			seedNames = append(seedNames, seedName)
+		}
+		sort.Strings(seedNames)
+
+		var backups, clusters []apiv1.ProjectDeletionReportResource
+		for _, seedName := range seedNames {
+			seedClient, err := seedsClientGetter(seeds[seedName])
+			if err != nil {
+				return nil, errors.New(http.StatusInternalServerError, fmt.Sprintf("failed to get client for seed %s: %v", seedName, err))
+			}
+
+			clusterList := &kubermaticv1.ClusterList{}
+			if err := seedClient.List(ctx, clusterList, ctrlruntimeclient.MatchingLabels{kubermaticv1.ProjectIDLabelKey: project.Name}); err != nil {
+				return nil, common.KubernetesErrorToHTTPError(err)
+			}
+
+			for _, cluster := range clusterList.Items {
+				clusters = append(clusters, pendingReportResource(kubermaticv1.ProjectDeletionKindCluster, cluster.Name, cluster.Spec.HumanReadableName, seedName, kubermaticv1.ProjectDeletionStageClusters))
+				if cluster.Status.NamespaceName == "" {
+					continue
+				}
+
+				backupConfigs := &kubermaticv1.EtcdBackupConfigList{}
+				if err := seedClient.List(ctx, backupConfigs, ctrlruntimeclient.InNamespace(cluster.Status.NamespaceName)); err != nil {
+					return nil, common.KubernetesErrorToHTTPError(err)
+				}
+				for _, backupConfig := range backupConfigs.Items {
+					backups = append(backups, pendingReportResource(kubermaticv1.ProjectDeletionKindEtcdBackupConfig, backupConfig.Name, backupConfig.Spec.Name, seedName, kubermaticv1.ProjectDeletionStageBackups))
+				}
+			}
+		}
+		resources = append(resources, backups...)
+		resources = append(resources, clusters...)
+
+		serviceAccounts, err := privilegedServiceAccountProvider.ListUnsecuredProjectServiceAccount(project, nil)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		for _, sa := range serviceAccounts {
+			resources = append(resources, pendingReportResource(kubermaticv1.ProjectDeletionKindServiceAccount, sa.Name, sa.Spec.Name, "", kubermaticv1.ProjectDeletionStageServiceAccounts))
+		}
+
+		keys, err := sshKeyProvider.List(project, nil)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		for _, key := range keys {
+			resources = append(resources, pendingReportResource(kubermaticv1.ProjectDeletionKindSSHKey, key.Name, key.Spec.Name, "", kubermaticv1.ProjectDeletionStageSSHKeys))
+		}
+
+		if resources == nil {
+			resources = []apiv1.ProjectDeletionReportResource{}
+		}
+		return &apiv1.ProjectDeletionReport{Resources: resources}, nil
+	}
+}
+
+func pendingReportResource(kind, id, name, seed string, stage kubermaticv1.ProjectDeletionStage) apiv1.ProjectDeletionReportResource {
+	return apiv1.ProjectDeletionReportResource{
+		Kind:  kind,
+		ID:    id,
+		Name:  name,
+		Seed:  seed,
+		Stage: string(stage),
+		Phase: string(kubermaticv1.ProjectDeletionResourcePending),
+	}
+}
+
+func convertDeletionStatusToReport(status *kubermaticv1.ProjectDeletionStatus) *apiv1.ProjectDeletionReport {
+	stageIndex := map[kubermaticv1.ProjectDeletionStage]int{}
+	for i, stage := range kubermaticv1.ProjectDeletionStages {
+		stageIndex[stage] = i
+	}
+
+	report := &apiv1.ProjectDeletionReport{
+		Stage:     string(status.Stage),
+		Resources: make([]apiv1.ProjectDeletionReportResource, 0, len(status.Resources)),
+	}
+	for _, res := range status.Resources {
+		report.Resources = append(report.Resources, apiv1.ProjectDeletionReportResource{
+			Kind:  res.Kind,
+			ID:    res.Name,
+			Name:  res.DisplayName,
+			Seed:  res.Seed,
+			Stage: string(res.Stage),
+			Phase: string(res.Phase),
+		})
+	}
+	sort.SliceStable(report.Resources, func(i, j int) bool {
+		return stageIndex[kubermaticv1.ProjectDeletionStage(report.Resources[i].Stage)] < stageIndex[kubermaticv1.ProjectDeletionStage(report.Resources[j].Stage)]
+	})
+
+	return report
+}
+
+// deletionReportRq defines HTTP request for getProjectDeletionReport endpoint
+// swagger:parameters getProjectDeletionReport
+type deletionReportRq struct {
+	common.ProjectReq
+}
+
+// DecodeDeletionReport decodes an HTTP request into deletionReportRq
+func DecodeDeletionReport(c context.Context, r *http.Request) (interface{}, error) {
+	req, err := common.DecodeProjectRequest(c, r)
+	if err != nil {
+		return nil, err
+	}
+	return deletionReportRq{ProjectReq: req.(common.ProjectReq)}, nil
+}
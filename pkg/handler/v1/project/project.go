@@ -34,8 +34,10 @@ import (
 	"k8c.io/kubermatic/v2/pkg/provider"
 	"k8c.io/kubermatic/v2/pkg/provider/kubernetes"
 	"k8c.io/kubermatic/v2/pkg/util/errors"
+	"k8c.io/kubermatic/v2/pkg/validation"
 
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 // CreateEndpoint defines an HTTP endpoint that creates a new project in the system
@@ -350,6 +352,7 @@ func UpdateEndpoint(projectProvider provider.ProjectProvider, privilegedProjectP
 
 		kubermaticProject.Spec.Name = req.Body.Name
 		kubermaticProject.Labels = req.Body.Labels
+		kubermaticProject.Spec.DefaultPresets = req.Body.DefaultPresets
 
 		project, err := updateProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, kubermaticProject)
 		if err != nil {
@@ -438,6 +441,9 @@ func (r updateRq) validate() error {
 	if len(r.Body.Name) == 0 {
 		return fmt.Errorf("the name of the project cannot be empty")
 	}
+	if errs := validation.ValidateProjectDefaultPresets(r.Body.DefaultPresets, field.NewPath("defaultPresets")); len(errs) > 0 {
+		return errs.ToAggregate()
+	}
 	return nil
 }
 
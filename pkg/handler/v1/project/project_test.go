@@ -564,6 +564,10 @@ func (f *fakeUserClusterConnection) GetClient(_ context.Context, _ *kubermaticap
 	return f.fakeDynamicClient, nil
 }
 
+func (f *fakeUserClusterConnection) GetClientConfig(_ context.Context, _ *kubermaticapiv1.Cluster, _ ...k8cuserclusterclient.ConfigOption) (*restclient.Config, error) {
+	return &restclient.Config{}, nil
+}
+
 func TestGetProjectEndpoint(t *testing.T) {
 	t.Parallel()
 	testcases := []struct {
@@ -868,6 +872,103 @@ func TestDeleteProjectEndpoint(t *testing.T) {
 	}
 }
 
+func TestGetProjectDeletionReportEndpoint(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name                      string
+		ProjectToSync             string
+		ExpectedResponse          string
+		HTTPStatus                int
+		ExistingKubermaticObjects []ctrlruntimeclient.Object
+		ExistingAPIUser           *apiv1.User
+	}{
+		{
+			Name:          "scenario 1: the report of a live project lists its resources in deletion order",
+			ProjectToSync: test.GenDefaultProject().Name,
+			ExpectedResponse: `{"resources":[` +
+				`{"kind":"Cluster","id":"defClusterID","name":"defClusterName","seed":"us-central1","stage":"Clusters","phase":"Pending"},` +
+				`{"kind":"ServiceAccount","id":"1","name":"test-1","stage":"ServiceAccounts","phase":"Pending"},` +
+				`{"kind":"SSHKey","id":"key-abc-yafn","name":"yafn","stage":"SSHKeys","phase":"Pending"}]}`,
+			HTTPStatus: http.StatusOK,
+			ExistingKubermaticObjects: test.GenDefaultKubermaticObjects(
+				test.GenTestSeed(),
+				test.GenDefaultCluster(),
+				test.GenProjectServiceAccount("1", "test-1", "editors", test.GenDefaultProject().Name),
+				genSSHKey("abc", "yafn", test.GenDefaultProject().Name),
+			),
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+		},
+		{
+			Name:          "scenario 2: the report of a project being deleted shows the deletion progress",
+			ProjectToSync: test.GenDefaultProject().Name,
+			ExpectedResponse: `{"stage":"ServiceAccounts","resources":[` +
+				`{"kind":"Cluster","id":"defClusterID","name":"defClusterName","seed":"us-central1","stage":"Clusters","phase":"Deleted"},` +
+				`{"kind":"ServiceAccount","id":"1","name":"test-1","stage":"ServiceAccounts","phase":"Deleting"}]}`,
+			HTTPStatus: http.StatusOK,
+			ExistingKubermaticObjects: func() []ctrlruntimeclient.Object {
+				project := test.GenDefaultProject()
+				project.Status.Deletion = &kubermaticv1.ProjectDeletionStatus{
+					Stage: kubermaticv1.ProjectDeletionStageServiceAccounts,
+					Resources: []kubermaticv1.ProjectDeletionResource{
+						{
+							Kind:        kubermaticv1.ProjectDeletionKindServiceAccount,
+							Name:        "1",
+							DisplayName: "test-1",
+							Stage:       kubermaticv1.ProjectDeletionStageServiceAccounts,
+							Phase:       kubermaticv1.ProjectDeletionResourceDeleting,
+						},
+						{
+							Kind:        kubermaticv1.ProjectDeletionKindCluster,
+							Name:        test.DefaultClusterID,
+							DisplayName: test.DefaultClusterName,
+							Seed:        "us-central1",
+							Stage:       kubermaticv1.ProjectDeletionStageClusters,
+							Phase:       kubermaticv1.ProjectDeletionResourceDeleted,
+						},
+					},
+				}
+				return []ctrlruntimeclient.Object{
+					project,
+					test.GenDefaultUser(),
+					test.GenDefaultOwnerBinding(),
+				}
+			}(),
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+		},
+		{
+			Name:             "scenario 3: the user John can't get the report of Bob's project",
+			ProjectToSync:    test.GenDefaultProject().Name,
+			ExpectedResponse: `{"error":{"code":403,"message":"forbidden: \"john@acme.com\" doesn't belong to the given project = my-first-project-ID"}}`,
+			HTTPStatus:       http.StatusForbidden,
+			ExistingKubermaticObjects: test.GenDefaultKubermaticObjects(
+				test.GenUser("JohnID", "John", "john@acme.com"),
+			),
+			ExistingAPIUser: test.GenAPIUser("John", "john@acme.com"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			// test data
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/v1/projects/%s/deletionreport", tc.ProjectToSync), strings.NewReader(""))
+			res := httptest.NewRecorder()
+			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, []ctrlruntimeclient.Object{}, tc.ExistingKubermaticObjects, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			// act
+			ep.ServeHTTP(res, req)
+
+			// validate
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+			test.CompareWithResult(t, res, tc.ExpectedResponse)
+		})
+	}
+}
+
 func TestServiceAccountProjectAccess(t *testing.T) {
 	t.Parallel()
 	testcases := []struct {
@@ -1035,3 +1136,21 @@ func genToken(sa *kubermaticv1.User, projectID, tokenName string) (*v1.Secret, e
 	secret.Type = "Opaque"
 	return secret, nil
 }
+
+func genSSHKey(keyID, keyName, projectID string) *kubermaticv1.UserSSHKey {
+	return &kubermaticv1.UserSSHKey{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: fmt.Sprintf("key-%s-%s", keyID, keyName),
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: "kubermatic.k8s.io/v1",
+					Kind:       "Project",
+					Name:       projectID,
+				},
+			},
+		},
+		Spec: kubermaticv1.SSHKeySpec{
+			Name: keyName,
+		},
+	}
+}
@@ -23,6 +23,7 @@ import (
 
 	"github.com/go-kit/kit/endpoint"
 
+	handlercommon "k8c.io/kubermatic/v2/pkg/handler/common"
 	providercommon "k8c.io/kubermatic/v2/pkg/handler/common/provider"
 	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
 	"k8c.io/kubermatic/v2/pkg/provider"
@@ -124,7 +125,7 @@ func AlibabaInstanceTypesEndpoint(presetsProvider provider.PresetProvider, userI
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
 
-		return providercommon.ListAlibabaInstanceTypes(accessKeyID, accessKeySecret, req.Region, settings.Spec.MachineDeploymentVMResourceQuota)
+		return providercommon.ListAlibabaInstanceTypes(accessKeyID, accessKeySecret, req.Region, settings.Spec.MachineDeploymentVMResourceQuota, handlercommon.MachineFlavorFilter(settings, provider.AlibabaCloudProvider, nil))
 	}
 }
 
@@ -183,7 +183,7 @@ func AWSSizeEndpoint(settingsProvider provider.SettingsProvider) endpoint.Endpoi
 		if err != nil {
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
-		return providercommon.AWSSizes(req.Region, req.Architecture, settings.Spec.MachineDeploymentVMResourceQuota)
+		return providercommon.AWSSizes(req.Region, req.Architecture, settings.Spec.MachineDeploymentVMResourceQuota, handlercommon.MachineFlavorFilter(settings, provider.AWSCloudProvider, nil))
 	}
 }
 
@@ -20,23 +20,26 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/go-kit/kit/endpoint"
 
+	handlercommon "k8c.io/kubermatic/v2/pkg/handler/common"
 	providercommon "k8c.io/kubermatic/v2/pkg/handler/common/provider"
 	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
 	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/provider/cloud/azure"
 	"k8c.io/kubermatic/v2/pkg/util/errors"
 )
 
 func AzureSizeWithClusterCredentialsEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter, userInfoGetter provider.UserInfoGetter, settingsProvider provider.SettingsProvider) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(AzureSizeNoCredentialsReq)
-		return providercommon.AzureSizeWithClusterCredentialsEndpoint(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, seedsGetter, settingsProvider, req.ProjectID, req.ClusterID)
+		return providercommon.AzureSizeWithClusterCredentialsEndpoint(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, seedsGetter, settingsProvider, req.ProjectID, req.ClusterID, req.AzureSizeFilter)
 	}
 }
 
-func AzureSizeEndpoint(presetsProvider provider.PresetProvider, userInfoGetter provider.UserInfoGetter, settingsProvider provider.SettingsProvider) endpoint.Endpoint {
+func AzureSizeEndpoint(presetsProvider provider.PresetProvider, seedsGetter provider.SeedsGetter, userInfoGetter provider.UserInfoGetter, settingsProvider provider.SettingsProvider) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(AzureSizeReq)
 
@@ -62,11 +65,15 @@ func AzureSizeEndpoint(presetsProvider provider.PresetProvider, userInfoGetter p
 				tenantID = credentials.TenantID
 			}
 		}
+		environment, err := providercommon.AzureDatacenterEnvironment(userInfo, seedsGetter, req.DatacenterName)
+		if err != nil {
+			return nil, err
+		}
 		settings, err := settingsProvider.GetGlobalSettings()
 		if err != nil {
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
-		return providercommon.AzureSize(ctx, settings.Spec.MachineDeploymentVMResourceQuota, subscriptionID, clientID, clientSecret, tenantID, req.Location)
+		return providercommon.AzureSize(ctx, settings.Spec.MachineDeploymentVMResourceQuota, handlercommon.MachineFlavorFilter(settings, provider.AzureCloudProvider, nil), req.AzureSizeFilter, subscriptionID, clientID, clientSecret, tenantID, environment, req.Location)
 	}
 }
 
@@ -77,7 +84,7 @@ func AzureAvailabilityZonesWithClusterCredentialsEndpoint(projectProvider provid
 	}
 }
 
-func AzureAvailabilityZonesEndpoint(presetsProvider provider.PresetProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+func AzureAvailabilityZonesEndpoint(presetsProvider provider.PresetProvider, seedsGetter provider.SeedsGetter, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(AvailabilityZonesReq)
 
@@ -105,7 +112,26 @@ func AzureAvailabilityZonesEndpoint(presetsProvider provider.PresetProvider, use
 				tenantID = credentials.TenantID
 			}
 		}
-		return providercommon.AzureSKUAvailabilityZones(ctx, subscriptionID, clientID, clientSecret, tenantID, location, skuName)
+		environment, err := providercommon.AzureDatacenterEnvironment(userInfo, seedsGetter, req.DatacenterName)
+		if err != nil {
+			return nil, err
+		}
+		return providercommon.AzureSKUAvailabilityZones(ctx, subscriptionID, clientID, clientSecret, tenantID, environment, location, skuName)
+	}
+}
+
+func AzureCostEstimateEndpoint() endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(AzureCostEstimateReq)
+		return providercommon.AzureCostEstimate(ctx, azure.CostEstimateRequest{
+			Location:     req.Location,
+			Size:         req.Size,
+			DiskType:     req.DiskType,
+			OSDiskSize:   req.OSDiskSize,
+			DataDiskSize: req.DataDiskSize,
+			Replicas:     req.Replicas,
+			Currency:     req.Currency,
+		})
 	}
 }
 
@@ -127,6 +153,9 @@ type AvailabilityZonesReq struct {
 	// in: header
 	// Credential predefined Kubermatic credential name from the presets
 	Credential string
+	// in: header
+	// DatacenterName is the optional name of the datacenter, whose Azure cloud is used for the request
+	DatacenterName string
 }
 
 // AzureSizeNoCredentialsReq represent a request for Azure VM sizes
@@ -134,6 +163,7 @@ type AvailabilityZonesReq struct {
 // swagger:parameters listAzureSizesNoCredentials
 type AzureSizeNoCredentialsReq struct {
 	common.GetClusterReq
+	providercommon.AzureSizeFilter
 }
 
 func DecodeAzureSizesNoCredentialsReq(c context.Context, r *http.Request) (interface{}, error) {
@@ -144,6 +174,10 @@ func DecodeAzureSizesNoCredentialsReq(c context.Context, r *http.Request) (inter
 	}
 
 	req.GetClusterReq = cr.(common.GetClusterReq)
+	req.AzureSizeFilter, err = providercommon.DecodeAzureSizeFilter(r)
+	if err != nil {
+		return nil, err
+	}
 	return req, nil
 }
 
@@ -163,10 +197,15 @@ type AzureSizeReq struct {
 	// in: header
 	// Credential predefined Kubermatic credential name from the presets
 	Credential string
+	// in: header
+	// DatacenterName is the optional name of the datacenter, whose Azure cloud is used for the request
+	DatacenterName string
+	providercommon.AzureSizeFilter
 }
 
 func DecodeAzureSizesReq(_ context.Context, r *http.Request) (interface{}, error) {
 	var req AzureSizeReq
+	var err error
 
 	req.SubscriptionID = r.Header.Get("SubscriptionID")
 	req.TenantID = r.Header.Get("TenantID")
@@ -174,6 +213,11 @@ func DecodeAzureSizesReq(_ context.Context, r *http.Request) (interface{}, error
 	req.ClientSecret = r.Header.Get("ClientSecret")
 	req.Location = r.Header.Get("Location")
 	req.Credential = r.Header.Get("Credential")
+	req.DatacenterName = r.Header.Get("DatacenterName")
+	req.AzureSizeFilter, err = providercommon.DecodeAzureSizeFilter(r)
+	if err != nil {
+		return nil, err
+	}
 	return req, nil
 }
 
@@ -187,6 +231,7 @@ func DecodeAzureAvailabilityZonesReq(_ context.Context, r *http.Request) (interf
 	req.Location = r.Header.Get("Location")
 	req.SKUName = r.Header.Get("SKUName")
 	req.Credential = r.Header.Get("Credential")
+	req.DatacenterName = r.Header.Get("DatacenterName")
 	return req, nil
 }
 
@@ -211,3 +256,63 @@ func DecodeAzureAvailabilityZonesNoCredentialsReq(c context.Context, r *http.Req
 	req.SKUName = r.Header.Get("SKUName")
 	return req, nil
 }
+
+// AzureCostEstimateReq represent a request for the estimated costs of Azure nodes
+// swagger:parameters getAzureCostEstimate
+type AzureCostEstimateReq struct {
+	// in: header
+	Location string
+	// in: header
+	// Size is the VM size of the nodes
+	Size string
+	// in: header
+	// DiskType is the storage account type of the disks, one of Standard_LRS, StandardSSD_LRS or
+	// Premium_LRS. Defaults to Standard_LRS.
+	DiskType string
+	// in: header
+	// OSDiskSize is the size of the OS disk in GB
+	OSDiskSize int32
+	// in: header
+	// DataDiskSize is the size of the data disk in GB
+	DataDiskSize int32
+	// in: header
+	// Replicas is the number of nodes, defaults to 1
+	Replicas int32
+	// in: header
+	// Currency is the ISO 4217 code of the currency of the prices, defaults to USD
+	Currency string
+}
+
+func DecodeAzureCostEstimateReq(_ context.Context, r *http.Request) (interface{}, error) {
+	req := AzureCostEstimateReq{
+		Location: r.Header.Get("Location"),
+		Size:     r.Header.Get("Size"),
+		DiskType: r.Header.Get("DiskType"),
+		Currency: r.Header.Get("Currency"),
+	}
+
+	var err error
+	if req.OSDiskSize, err = decodeInt32Header(r, "OSDiskSize", 0); err != nil {
+		return nil, err
+	}
+	if req.DataDiskSize, err = decodeInt32Header(r, "DataDiskSize", 0); err != nil {
+		return nil, err
+	}
+	if req.Replicas, err = decodeInt32Header(r, "Replicas", 1); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// decodeInt32Header returns the value of an integer header, or the default if the header is not set.
+func decodeInt32Header(r *http.Request, header string, defaultValue int32) (int32, error) {
+	raw := r.Header.Get(header)
+	if raw == "" {
+		return defaultValue, nil
+	}
+	value, err := strconv.ParseInt(raw, 10, 32)
+	if err != nil {
+		return 0, errors.NewBadRequest("invalid value of the %s header: %v", header, err)
+	}
+	return int32(value), nil
+}
@@ -27,6 +27,8 @@ import (
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-12-01/compute"
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-06-01/network"
 	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2018-02-01/resources"
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2018-06-01/subscriptions"
+	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/stretchr/testify/assert"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
@@ -57,6 +59,8 @@ func TestAzureSizeEndpoint(t *testing.T) {
 		name             string
 		secret           string
 		location         string
+		datacenter       string
+		query            string
 		httpStatus       int
 		expectedResponse string
 	}{
@@ -71,8 +75,8 @@ func TestAzureSizeEndpoint(t *testing.T) {
 			location:   locationUS,
 			secret:     "secret",
 			expectedResponse: `[
-				{"name":"Standard_GS3", "maxDataDiskCount": 3, "memoryInMB": 2048, "numberOfCores": 8, "numberOfGPUs": 0, "osDiskSizeInMB": 1024, "resourceDiskSizeInMB":1024},
-				{"name":"Standard_A5", "maxDataDiskCount": 3, "memoryInMB": 2048, "numberOfCores": 8, "numberOfGPUs": 0, "osDiskSizeInMB": 1024, "resourceDiskSizeInMB":1024}
+				{"name":"Standard_GS3", "maxDataDiskCount": 3, "memoryInMB": 2048, "numberOfCores": 8, "numberOfGPUs": 0, "osDiskSizeInMB": 1024, "resourceDiskSizeInMB":1024, "hyperVGenerations": ["V1"], "acceleratedNetworkingSupported": true},
+				{"name":"Standard_A5", "maxDataDiskCount": 3, "memoryInMB": 2048, "numberOfCores": 8, "numberOfGPUs": 0, "osDiskSizeInMB": 1024, "resourceDiskSizeInMB":1024, "hyperVGenerations": ["V1"]}
 			]`,
 		},
 		{
@@ -81,21 +85,85 @@ func TestAzureSizeEndpoint(t *testing.T) {
 			location:   locationEU,
 			secret:     "secret",
 			expectedResponse: `[
-				{"name":"Standard_GS3", "maxDataDiskCount": 3, "memoryInMB": 2048, "numberOfCores": 8, "numberOfGPUs": 0, "osDiskSizeInMB": 1024, "resourceDiskSizeInMB":1024}
+				{"name":"Standard_GS3", "maxDataDiskCount": 3, "memoryInMB": 2048, "numberOfCores": 8, "numberOfGPUs": 0, "osDiskSizeInMB": 1024, "resourceDiskSizeInMB":1024, "hyperVGenerations": ["V1", "V2"], "trustedLaunchSupported": true}
 			]`,
 		},
+		{
+			name:       "test US location with sizes available in a zone",
+			httpStatus: http.StatusOK,
+			location:   locationUS,
+			query:      "?zone=2",
+			secret:     "secret",
+			expectedResponse: `[
+				{"name":"Standard_GS3", "maxDataDiskCount": 3, "memoryInMB": 2048, "numberOfCores": 8, "numberOfGPUs": 0, "osDiskSizeInMB": 1024, "resourceDiskSizeInMB":1024, "hyperVGenerations": ["V1"], "acceleratedNetworkingSupported": true}
+			]`,
+		},
+		{
+			name:       "test US location with sizes supporting accelerated networking",
+			httpStatus: http.StatusOK,
+			location:   locationUS,
+			query:      "?acceleratedNetworking=true",
+			secret:     "secret",
+			expectedResponse: `[
+				{"name":"Standard_GS3", "maxDataDiskCount": 3, "memoryInMB": 2048, "numberOfCores": 8, "numberOfGPUs": 0, "osDiskSizeInMB": 1024, "resourceDiskSizeInMB":1024, "hyperVGenerations": ["V1"], "acceleratedNetworkingSupported": true}
+			]`,
+		},
+		{
+			name:       "test US location with sizes fitting into the remaining quota",
+			httpStatus: http.StatusOK,
+			location:   locationUS,
+			query:      "?quotaHeadroom=true",
+			secret:     "secret",
+			expectedResponse: `[
+				{"name":"Standard_GS3", "maxDataDiskCount": 3, "memoryInMB": 2048, "numberOfCores": 8, "numberOfGPUs": 0, "osDiskSizeInMB": 1024, "resourceDiskSizeInMB":1024, "hyperVGenerations": ["V1"], "acceleratedNetworkingSupported": true}
+			]`,
+		},
+		{
+			name:             "test US location with more vCPUs than any size",
+			httpStatus:       http.StatusOK,
+			location:         locationUS,
+			query:            "?minCPU=16&minMemory=2",
+			secret:           "secret",
+			expectedResponse: `[]`,
+		},
+		{
+			name:       "test US location in the Azure cloud of a datacenter",
+			httpStatus: http.StatusOK,
+			location:   locationUS,
+			datacenter: datacenterName,
+			query:      "?zone=1",
+			secret:     "secret",
+			expectedResponse: `[
+				{"name":"Standard_GS3", "maxDataDiskCount": 3, "memoryInMB": 2048, "numberOfCores": 8, "numberOfGPUs": 0, "osDiskSizeInMB": 1024, "resourceDiskSizeInMB":1024, "hyperVGenerations": ["V1"], "acceleratedNetworkingSupported": true}
+			]`,
+		},
+		{
+			name:       "test unknown datacenter",
+			httpStatus: http.StatusNotFound,
+			location:   locationUS,
+			datacenter: "unknown",
+			secret:     "secret",
+		},
+		{
+			name:       "test invalid filter",
+			httpStatus: http.StatusBadRequest,
+			location:   locationUS,
+			query:      "?minCPU=many",
+			secret:     "secret",
+		},
 	}
 
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
 
-			req := httptest.NewRequest("GET", "/api/v1/providers/azure/sizes", strings.NewReader(""))
+			req := httptest.NewRequest("GET", "/api/v1/providers/azure/sizes"+tc.query, strings.NewReader(""))
 
 			req.Header.Add("SubscriptionID", testID)
 			req.Header.Add("ClientID", testID)
 			req.Header.Add("ClientSecret", tc.secret)
 			req.Header.Add("TenantID", testID)
 			req.Header.Add("Location", tc.location)
+			req.Header.Add("DatacenterName", tc.datacenter)
 
 			providercommon.NewAzureClientSet = MockNewSizeClient
 
@@ -134,7 +202,8 @@ func buildAzureDatacenterMeta() provider.SeedsGetter {
 							Country:  "JP",
 							Spec: kubermaticv1.DatacenterSpec{
 								Azure: &kubermaticv1.DatacenterSpecAzure{
-									Location: "ap-northeast",
+									Location:    "ap-northeast",
+									Environment: "AzureUSGovernmentCloud",
 								},
 							},
 						},
@@ -145,11 +214,14 @@ func buildAzureDatacenterMeta() provider.SeedsGetter {
 	}
 }
 
-func MockNewSizeClient(subscriptionID, clientID, clientSecret, tenantID string) (providercommon.AzureClientSet, error) {
+func MockNewSizeClient(subscriptionID, clientID, clientSecret, tenantID, environment string) (providercommon.AzureClientSet, error) {
 
 	if len(clientSecret) == 0 || len(subscriptionID) == 0 || len(clientID) == 0 || len(tenantID) == 0 {
 		return nil, fmt.Errorf("")
 	}
+	if environment != "" && environment != "AzureUSGovernmentCloud" {
+		return nil, fmt.Errorf("unexpected environment %q", environment)
+	}
 
 	return &mockSizeClientImpl{}, nil
 }
@@ -161,24 +233,43 @@ func (s *mockSizeClientImpl) ListSKU(ctx context.Context, location string) ([]co
 	resourceType := "virtualMachines"
 	tier := "Standard"
 
+	hyperVGenerations, generations := "HyperVGenerations", "V1,V2"
+	acceleratedNetworking, enabled := "AcceleratedNetworkingEnabled", "True"
+	familyGS, familyA := "standardGSFamily", "standardAFamily"
+	locationUS := locationUS
+
 	resultList := []compute.ResourceSku{
 		{
 			Locations:    &[]string{locationEU},
 			Name:         &standardGS3,
 			ResourceType: &resourceType,
 			Tier:         &tier,
+			Capabilities: &[]compute.ResourceSkuCapabilities{
+				{Name: &hyperVGenerations, Value: &generations},
+			},
 		},
 		{
 			Locations:    &[]string{locationUS},
 			Name:         &standardGS3,
 			ResourceType: &resourceType,
 			Tier:         &tier,
+			Family:       &familyGS,
+			LocationInfo: &[]compute.ResourceSkuLocationInfo{
+				{Location: &locationUS, Zones: &[]string{"1", "2", "3"}},
+			},
+			Restrictions: &[]compute.ResourceSkuRestrictions{
+				{Type: compute.Zone, RestrictionInfo: &compute.ResourceSkuRestrictionInfo{Zones: &[]string{"3"}}},
+			},
+			Capabilities: &[]compute.ResourceSkuCapabilities{
+				{Name: &acceleratedNetworking, Value: &enabled},
+			},
 		},
 		{
 			Locations:    &[]string{locationUS},
 			Name:         &standardA5,
 			ResourceType: &resourceType,
 			Tier:         &tier,
+			Family:       &familyA,
 		},
 	}
 
@@ -243,3 +334,19 @@ func (s *mockSizeClientImpl) ListVnets(_ context.Context, _ string) ([]network.V
 func (s *mockSizeClientImpl) ListSubnets(_ context.Context, _, _ string) ([]network.Subnet, error) {
 	return nil, nil
 }
+
+func (s *mockSizeClientImpl) ListLocations(_ context.Context) ([]subscriptions.Location, error) {
+	return nil, nil
+}
+
+func (s *mockSizeClientImpl) GetResourceProvider(_ context.Context, _ string) (resources.Provider, error) {
+	return resources.Provider{}, nil
+}
+
+func (s *mockSizeClientImpl) ListUsages(_ context.Context, _ string) ([]compute.Usage, error) {
+	cores, familyA := "cores", "standardAFamily"
+	return []compute.Usage{
+		{Name: &compute.UsageName{Value: &cores}, CurrentValue: to.Int32Ptr(10), Limit: to.Int64Ptr(100)},
+		{Name: &compute.UsageName{Value: &familyA}, CurrentValue: to.Int32Ptr(4), Limit: to.Int64Ptr(10)},
+	}, nil
+}
@@ -23,16 +23,17 @@ import (
 
 	"github.com/go-kit/kit/endpoint"
 
+	handlercommon "k8c.io/kubermatic/v2/pkg/handler/common"
 	providercommon "k8c.io/kubermatic/v2/pkg/handler/common/provider"
 	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
 	"k8c.io/kubermatic/v2/pkg/provider"
 	"k8c.io/kubermatic/v2/pkg/util/errors"
 )
 
-func DigitaloceanSizeWithClusterCredentialsEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter, settingsProvider provider.SettingsProvider) endpoint.Endpoint {
+func DigitaloceanSizeWithClusterCredentialsEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter, userInfoGetter provider.UserInfoGetter, settingsProvider provider.SettingsProvider) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(DoSizesNoCredentialsReq)
-		return providercommon.DigitaloceanSizeWithClusterCredentialsEndpoint(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, settingsProvider, req.ProjectID, req.ClusterID)
+		return providercommon.DigitaloceanSizeWithClusterCredentialsEndpoint(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, seedsGetter, settingsProvider, req.ProjectID, req.ClusterID)
 	}
 }
 
@@ -61,7 +62,7 @@ func DigitaloceanSizeEndpoint(presetsProvider provider.PresetProvider, userInfoG
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
 
-		return providercommon.DigitaloceanSize(ctx, settings.Spec.MachineDeploymentVMResourceQuota, token)
+		return providercommon.DigitaloceanSize(ctx, settings.Spec.MachineDeploymentVMResourceQuota, handlercommon.MachineFlavorFilter(settings, provider.DigitaloceanCloudProvider, nil), token)
 	}
 }
 
@@ -25,6 +25,7 @@ import (
 
 	"github.com/go-kit/kit/endpoint"
 
+	handlercommon "k8c.io/kubermatic/v2/pkg/handler/common"
 	providercommon "k8c.io/kubermatic/v2/pkg/handler/common/provider"
 	"k8c.io/kubermatic/v2/pkg/handler/middleware"
 	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
@@ -91,7 +92,7 @@ func OpenstackSizeEndpoint(seedsGetter provider.SeedsGetter, presetsProvider pro
 		if err != nil {
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
-		return providercommon.GetOpenstackSizes(cred, datacenter, settings.Spec.MachineDeploymentVMResourceQuota, caBundle)
+		return providercommon.GetOpenstackSizes(cred, datacenter, settings.Spec.MachineDeploymentVMResourceQuota, handlercommon.MachineFlavorFilter(settings, provider.OpenstackCloudProvider, datacenter.Spec.MachineFlavorFilter), caBundle)
 	}
 }
 
@@ -69,7 +69,9 @@ func CreateTokenEndpoint(projectProvider provider.ProjectProvider, privilegedPro
 
 		tokenID := rand.String(10)
 
-		token, err := tokenGenerator.Generate(serviceaccount.Claims(sa.Spec.Email, project.Name, tokenID))
+		claims, customClaims := serviceaccount.Claims(sa.Spec.Email, project.Name, tokenID)
+		customClaims.Scope = req.Body.Scope
+		token, err := tokenGenerator.Generate(claims, customClaims)
 		if err != nil {
 			return nil, errors.New(http.StatusInternalServerError, "can not generate token data")
 		}
@@ -188,7 +190,7 @@ func UpdateTokenEndpoint(projectProvider provider.ProjectProvider, privilegedPro
 			return nil, errors.NewBadRequest(err.Error())
 		}
 
-		secret, err := updateEndpoint(ctx, projectProvider, privilegedProjectProvider, serviceAccountProvider, privilegedServiceAccount, serviceAccountTokenProvider, privilegedServiceAccountTokenProvider, userInfoGetter, tokenGenerator, req.ProjectID, req.ServiceAccountID, req.TokenID, req.Body.Name, true)
+		secret, err := updateEndpoint(ctx, projectProvider, privilegedProjectProvider, serviceAccountProvider, privilegedServiceAccount, serviceAccountTokenProvider, privilegedServiceAccountTokenProvider, userInfoGetter, tokenAuthenticator, tokenGenerator, req.ProjectID, req.ServiceAccountID, req.TokenID, req.Body.Name, true)
 		if err != nil {
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
@@ -219,7 +221,7 @@ func PatchTokenEndpoint(projectProvider provider.ProjectProvider, privilegedProj
 			return nil, errors.NewBadRequest("new name can not be empty")
 		}
 
-		secret, err := updateEndpoint(ctx, projectProvider, privilegedProjectProvider, serviceAccountProvider, privilegedServiceAccount, serviceAccountTokenProvider, privilegedServiceAccountTokenProvider, userInfoGetter, tokenGenerator, req.ProjectID, req.ServiceAccountID, req.TokenID, tokenReq.Name, false)
+		secret, err := updateEndpoint(ctx, projectProvider, privilegedProjectProvider, serviceAccountProvider, privilegedServiceAccount, serviceAccountTokenProvider, privilegedServiceAccountTokenProvider, userInfoGetter, tokenAuthenticator, tokenGenerator, req.ProjectID, req.ServiceAccountID, req.TokenID, tokenReq.Name, false)
 		if err != nil {
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
@@ -292,7 +294,7 @@ func getSAToken(ctx context.Context, userInfoGetter provider.UserInfoGetter, ser
 }
 
 func updateEndpoint(ctx context.Context, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, serviceAccountProvider provider.ServiceAccountProvider,
-	privilegedServiceAccount provider.PrivilegedServiceAccountProvider, serviceAccountTokenProvider provider.ServiceAccountTokenProvider, privilegedServiceAccountTokenProvider provider.PrivilegedServiceAccountTokenProvider, userInfoGetter provider.UserInfoGetter, tokenGenerator serviceaccount.TokenGenerator,
+	privilegedServiceAccount provider.PrivilegedServiceAccountProvider, serviceAccountTokenProvider provider.ServiceAccountTokenProvider, privilegedServiceAccountTokenProvider provider.PrivilegedServiceAccountTokenProvider, userInfoGetter provider.UserInfoGetter, tokenAuthenticator serviceaccount.TokenAuthenticator, tokenGenerator serviceaccount.TokenGenerator,
 	projectID, saID, tokenID, newName string, regenerateToken bool) (*v1.Secret, error) {
 
 	project, err := common.GetProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, projectID, nil)
@@ -331,7 +333,14 @@ func updateEndpoint(ctx context.Context, projectProvider provider.ProjectProvide
 	}
 
 	if regenerateToken {
-		token, err := tokenGenerator.Generate(serviceaccount.Claims(sa.Spec.Email, project.Name, existingSecret.Name))
+		// the regenerated token keeps the scope of the one it replaces
+		_, existingClaims, err := tokenAuthenticator.Authenticate(string(existingSecret.Data["token"]))
+		if err != nil {
+			return nil, fmt.Errorf("can not read the existing token data: %w", err)
+		}
+		claims, customClaims := serviceaccount.Claims(sa.Spec.Email, project.Name, existingSecret.Name)
+		customClaims.Scope = existingClaims.Scope
+		token, err := tokenGenerator.Generate(claims, customClaims)
 		if err != nil {
 			return nil, fmt.Errorf("can not generate token data")
 		}
@@ -417,6 +426,9 @@ func (r addTokenReq) Validate() error {
 	if utf8.RuneCountInString(r.Body.Name) > 50 {
 		return fmt.Errorf("the name is too long, max 50 chars")
 	}
+	if r.Body.Scope != "" && r.Body.Scope != serviceaccount.MonitoringScope {
+		return fmt.Errorf("invalid token scope %q, only %q is supported", r.Body.Scope, serviceaccount.MonitoringScope)
+	}
 
 	return nil
 }
@@ -623,12 +635,13 @@ func convertInternalTokenToPublicExternal(internal *v1.Secret, authenticator ser
 		return nil, fmt.Errorf("can not find token data")
 	}
 
-	publicClaim, _, err := authenticator.Authenticate(string(token))
+	publicClaim, customClaim, err := authenticator.Authenticate(string(token))
 	if err != nil {
 		return nil, fmt.Errorf("unable to create a token for %s due to %v", internal.Name, err)
 	}
 
 	externalToken.Expiry = apiv1.NewTime(publicClaim.Expiry.Time())
+	externalToken.Scope = customClaim.Scope
 	externalToken.ID = internal.Name
 	name, ok := internal.Labels["name"]
 	if !ok {
@@ -45,6 +45,7 @@ func TestCreateTokenProject(t *testing.T) {
 		existingKubernetesObjs []ctrlruntimeclient.Object
 		expectedErrorResponse  string
 		expectedName           string
+		expectedScope          string
 		projectToSync          string
 		saToSync               string
 		httpStatus             int
@@ -122,6 +123,47 @@ func TestCreateTokenProject(t *testing.T) {
 			saToSync:               "1",
 			expectedName:           "test",
 		},
+		{
+			name:       "scenario 4: create a monitoring token for serviceaccount-1",
+			body:       `{"name":"status-page","scope":"monitoring"}`,
+			httpStatus: http.StatusCreated,
+			existingKubermaticObjs: []ctrlruntimeclient.Object{
+				/*add projects*/
+				test.GenProject("plan9", kubermaticapiv1.ProjectActive, test.DefaultCreationTimestamp()),
+				/*add bindings*/
+				test.GenBinding("plan9-ID", "john@acme.com", "owners"),
+				test.GenBinding("plan9-ID", "serviceaccount-1@sa.kubermatic.io", "viewers"),
+				/*add users*/
+				test.GenUser("", "john", "john@acme.com"),
+				test.GenProjectServiceAccount("1", "test-1", "viewers", "plan9-ID"),
+			},
+			existingKubernetesObjs: []ctrlruntimeclient.Object{},
+			existingAPIUser:        *test.GenAPIUser("john", "john@acme.com"),
+			projectToSync:          "plan9-ID",
+			saToSync:               "1",
+			expectedName:           "status-page",
+			expectedScope:          "monitoring",
+		},
+		{
+			name:       "scenario 5: a token with an unknown scope can not be created",
+			body:       `{"name":"status-page","scope":"clusters"}`,
+			httpStatus: http.StatusBadRequest,
+			existingKubermaticObjs: []ctrlruntimeclient.Object{
+				/*add projects*/
+				test.GenProject("plan9", kubermaticapiv1.ProjectActive, test.DefaultCreationTimestamp()),
+				/*add bindings*/
+				test.GenBinding("plan9-ID", "john@acme.com", "owners"),
+				test.GenBinding("plan9-ID", "serviceaccount-1@sa.kubermatic.io", "viewers"),
+				/*add users*/
+				test.GenUser("", "john", "john@acme.com"),
+				test.GenProjectServiceAccount("1", "test-1", "viewers", "plan9-ID"),
+			},
+			existingKubernetesObjs: []ctrlruntimeclient.Object{},
+			existingAPIUser:        *test.GenAPIUser("john", "john@acme.com"),
+			projectToSync:          "plan9-ID",
+			saToSync:               "1",
+			expectedErrorResponse:  `{"error":{"code":400,"message":"invalid token scope \"clusters\", only \"monitoring\" is supported"}}`,
+		},
 	}
 
 	for _, tc := range testcases {
@@ -166,6 +208,9 @@ func TestCreateTokenProject(t *testing.T) {
 				if saTokenClaim.Email != fmt.Sprintf("serviceaccount-%s@sa.kubermatic.io", tc.saToSync) {
 					t.Fatalf("expected email %s@sa.kubermatic.io got %s", tc.saToSync, saTokenClaim.Email)
 				}
+				if saTokenClaim.Scope != tc.expectedScope || saToken.Scope != tc.expectedScope {
+					t.Fatalf("expected scope %q got %q (claim %q)", tc.expectedScope, saToken.Scope, saTokenClaim.Scope)
+				}
 			}
 		})
 	}
@@ -0,0 +1,327 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addonrollout
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/go-kit/kit/endpoint"
+	"github.com/gorilla/mux"
+
+	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/util/errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func CreateEndpoint(userInfoGetter provider.UserInfoGetter, addonRolloutProvider provider.PrivilegedAddonRolloutProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(createAddonRolloutReq)
+
+		if err := verifyAdmin(ctx, userInfoGetter); err != nil {
+			return nil, err
+		}
+
+		rollout := &kubermaticv1.AddonRollout{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: req.Body.Name,
+			},
+		}
+		if err := convertExternalSpecToInternal(req.Body.Spec, &rollout.Spec); err != nil {
+			return nil, errors.NewBadRequest(err.Error())
+		}
+
+		rollout, err := addonRolloutProvider.CreateUnsecured(rollout)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		return convertInternalAddonRolloutToExternal(rollout)
+	}
+}
+
+// createAddonRolloutReq represents a request for creating an addon rollout
+// swagger:parameters createAddonRollout
+type createAddonRolloutReq struct {
+	// in: body
+	Body addonRolloutBody
+}
+
+type addonRolloutBody struct {
+	// Name of the addon rollout
+	Name string `json:"name"`
+	// Spec of the addon rollout
+	Spec apiv2.AddonRolloutSpec `json:"spec"`
+}
+
+func DecodeCreateAddonRolloutRequest(c context.Context, r *http.Request) (interface{}, error) {
+	var req createAddonRolloutReq
+
+	if err := json.NewDecoder(r.Body).Decode(&req.Body); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func GetEndpoint(userInfoGetter provider.UserInfoGetter, addonRolloutProvider provider.PrivilegedAddonRolloutProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(getAddonRolloutReq)
+
+		if err := verifyAdmin(ctx, userInfoGetter); err != nil {
+			return nil, err
+		}
+
+		rollout, err := addonRolloutProvider.GetUnsecured(req.AddonRolloutName)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		return convertInternalAddonRolloutToExternal(rollout)
+	}
+}
+
+// getAddonRolloutReq represents a request for getting an addon rollout
+// swagger:parameters getAddonRollout deleteAddonRollout
+type getAddonRolloutReq struct {
+	// in: path
+	// required: true
+	AddonRolloutName string `json:"addon_rollout"`
+}
+
+func DecodeGetAddonRolloutRequest(c context.Context, r *http.Request) (interface{}, error) {
+	var req getAddonRolloutReq
+
+	name := mux.Vars(r)["addon_rollout"]
+	if name == "" {
+		return "", fmt.Errorf("'addon_rollout' parameter is required but was not provided")
+	}
+	req.AddonRolloutName = name
+
+	return req, nil
+}
+
+func ListEndpoint(userInfoGetter provider.UserInfoGetter, addonRolloutProvider provider.PrivilegedAddonRolloutProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		if err := verifyAdmin(ctx, userInfoGetter); err != nil {
+			return nil, err
+		}
+
+		rollouts, err := addonRolloutProvider.ListUnsecured()
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		result := make([]*apiv2.AddonRollout, 0, len(rollouts.Items))
+		for i := range rollouts.Items {
+			rollout, err := convertInternalAddonRolloutToExternal(&rollouts.Items[i])
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, rollout)
+		}
+
+		return result, nil
+	}
+}
+
+func DeleteEndpoint(userInfoGetter provider.UserInfoGetter, addonRolloutProvider provider.PrivilegedAddonRolloutProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(getAddonRolloutReq)
+
+		if err := verifyAdmin(ctx, userInfoGetter); err != nil {
+			return nil, err
+		}
+
+		if err := addonRolloutProvider.DeleteUnsecured(req.AddonRolloutName); err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		return nil, nil
+	}
+}
+
+// patchAddonRolloutReq defines HTTP request for patching addon rollouts
+// swagger:parameters patchAddonRollout
+type patchAddonRolloutReq struct {
+	getAddonRolloutReq
+	// in: body
+	Patch json.RawMessage
+}
+
+// DecodePatchAddonRolloutReq decodes http request into patchAddonRolloutReq
+func DecodePatchAddonRolloutReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req patchAddonRolloutReq
+
+	getReq, err := DecodeGetAddonRolloutRequest(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.getAddonRolloutReq = getReq.(getAddonRolloutReq)
+
+	if req.Patch, err = ioutil.ReadAll(r.Body); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func PatchEndpoint(userInfoGetter provider.UserInfoGetter, addonRolloutProvider provider.PrivilegedAddonRolloutProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(patchAddonRolloutReq)
+
+		if err := verifyAdmin(ctx, userInfoGetter); err != nil {
+			return nil, err
+		}
+
+		original, err := addonRolloutProvider.GetUnsecured(req.AddonRolloutName)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		originalAPIRollout, err := convertInternalAddonRolloutToExternal(original)
+		if err != nil {
+			return nil, err
+		}
+
+		originalJSON, err := json.Marshal(originalAPIRollout)
+		if err != nil {
+			return nil, errors.New(http.StatusInternalServerError, fmt.Sprintf("failed to convert current addon rollout: %v", err))
+		}
+
+		patchedJSON, err := jsonpatch.MergePatch(originalJSON, req.Patch)
+		if err != nil {
+			return nil, errors.New(http.StatusBadRequest, fmt.Sprintf("failed to merge patch addon rollout: %v", err))
+		}
+
+		var patched *apiv2.AddonRollout
+		if err := json.Unmarshal(patchedJSON, &patched); err != nil {
+			return nil, errors.New(http.StatusBadRequest, fmt.Sprintf("failed to unmarshal patched addon rollout: %v", err))
+		}
+
+		if patched.Name != original.Name {
+			return nil, errors.New(http.StatusBadRequest, fmt.Sprintf("changing the addon rollout name is not allowed: %q to %q", original.Name, patched.Name))
+		}
+
+		rollout := original.DeepCopy()
+		rollout.Spec = kubermaticv1.AddonRolloutSpec{}
+		if err := convertExternalSpecToInternal(patched.Spec, &rollout.Spec); err != nil {
+			return nil, errors.NewBadRequest(err.Error())
+		}
+
+		rollout, err = addonRolloutProvider.PatchUnsecured(rollout)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		return convertInternalAddonRolloutToExternal(rollout)
+	}
+}
+
+func verifyAdmin(ctx context.Context, userInfoGetter provider.UserInfoGetter) error {
+	adminUserInfo, err := userInfoGetter(ctx, "")
+	if err != nil {
+		return common.KubernetesErrorToHTTPError(err)
+	}
+	if !adminUserInfo.IsAdmin {
+		return errors.New(http.StatusForbidden,
+			fmt.Sprintf("forbidden: \"%s\" doesn't have admin rights", adminUserInfo.Email))
+	}
+	return nil
+}
+
+func convertExternalSpecToInternal(external apiv2.AddonRolloutSpec, internal *kubermaticv1.AddonRolloutSpec) error {
+	if external.Addon == "" {
+		return fmt.Errorf("the addon name is required")
+	}
+	if external.Version == "" {
+		return fmt.Errorf("the version is required")
+	}
+	if _, err := metav1.LabelSelectorAsSelector(&external.ClusterSelector); err != nil {
+		return fmt.Errorf("invalid cluster selector: %v", err)
+	}
+	for _, wave := range external.Waves {
+		if wave.Percentage <= 0 || wave.Percentage > 100 {
+			return fmt.Errorf("wave percentages must be between 1 and 100, got %d", wave.Percentage)
+		}
+	}
+	if external.MaxFailures < 0 {
+		return fmt.Errorf("the number of tolerated failures must not be negative")
+	}
+
+	internal.Addon = external.Addon
+	internal.Version = external.Version
+	internal.ClusterSelector = external.ClusterSelector
+	internal.Waves = external.Waves
+	internal.MaxFailures = external.MaxFailures
+	internal.Paused = external.Paused
+
+	if external.HealthTimeout != "" {
+		timeout, err := time.ParseDuration(external.HealthTimeout)
+		if err != nil {
+			return fmt.Errorf("invalid health timeout: %v", err)
+		}
+		internal.HealthTimeout = &metav1.Duration{Duration: timeout}
+	}
+
+	if len(external.Variables) > 0 {
+		raw, err := json.Marshal(external.Variables)
+		if err != nil {
+			return fmt.Errorf("invalid variables: %v", err)
+		}
+		internal.Variables = runtime.RawExtension{Raw: raw}
+	}
+
+	return nil
+}
+
+func convertInternalAddonRolloutToExternal(rollout *kubermaticv1.AddonRollout) (*apiv2.AddonRollout, error) {
+	result := &apiv2.AddonRollout{
+		Name: rollout.Name,
+		Spec: apiv2.AddonRolloutSpec{
+			Addon:           rollout.Spec.Addon,
+			Version:         rollout.Spec.Version,
+			ClusterSelector: rollout.Spec.ClusterSelector,
+			Waves:           rollout.Spec.Waves,
+			MaxFailures:     rollout.Spec.MaxFailures,
+			Paused:          rollout.Spec.Paused,
+		},
+		Status: rollout.Status,
+	}
+
+	if rollout.Spec.HealthTimeout != nil {
+		result.Spec.HealthTimeout = rollout.Spec.HealthTimeout.Duration.String()
+	}
+
+	if len(rollout.Spec.Variables.Raw) > 0 {
+		if err := json.Unmarshal(rollout.Spec.Variables.Raw, &result.Spec.Variables); err != nil {
+			return nil, errors.New(http.StatusInternalServerError, fmt.Sprintf("failed to convert addon rollout variables: %v", err))
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,220 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addonrollout_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/handler/test"
+	"k8c.io/kubermatic/v2/pkg/handler/test/hack"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestCreateAddonRollout(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name             string
+		Body             string
+		ExpectedResponse string
+		HTTPStatus       int
+		ExistingAPIUser  *apiv1.User
+	}{
+		{
+			Name:             "scenario 1: admin can create an addon rollout",
+			Body:             `{"name":"monitoring","spec":{"addon":"node-exporter","version":"v1","variables":{"port":9100},"clusterSelector":{"matchLabels":{"env":"prod"}},"waves":[{"percentage":20},{"percentage":100}],"healthTimeout":"15m","maxFailures":1}}`,
+			ExpectedResponse: `{"name":"monitoring","spec":{"addon":"node-exporter","version":"v1","variables":{"port":9100},"clusterSelector":{"matchLabels":{"env":"prod"}},"waves":[{"percentage":20},{"percentage":100}],"healthTimeout":"15m0s","maxFailures":1},"status":{"currentWave":0}}`,
+			HTTPStatus:       http.StatusCreated,
+			ExistingAPIUser:  test.GenDefaultAdminAPIUser(),
+		},
+		{
+			Name:             "scenario 2: wave percentages must be valid",
+			Body:             `{"name":"monitoring","spec":{"addon":"node-exporter","version":"v1","waves":[{"percentage":120}]}}`,
+			ExpectedResponse: `{"error":{"code":400,"message":"wave percentages must be between 1 and 100, got 120"}}`,
+			HTTPStatus:       http.StatusBadRequest,
+			ExistingAPIUser:  test.GenDefaultAdminAPIUser(),
+		},
+		{
+			Name:             "scenario 3: non-admin can not create an addon rollout",
+			Body:             `{"name":"monitoring","spec":{"addon":"node-exporter","version":"v1"}}`,
+			ExpectedResponse: `{"error":{"code":403,"message":"forbidden: \"bob@acme.com\" doesn't have admin rights"}}`,
+			HTTPStatus:       http.StatusForbidden,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/api/v2/addonrollouts", strings.NewReader(tc.Body))
+			res := httptest.NewRecorder()
+			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, nil, []ctrlruntimeclient.Object{test.APIUserToKubermaticUser(*tc.ExistingAPIUser)}, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+
+			test.CompareWithResult(t, res, tc.ExpectedResponse)
+		})
+	}
+}
+
+func TestGetAddonRollout(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name             string
+		RolloutName      string
+		ExpectedResponse string
+		HTTPStatus       int
+		ExistingAPIUser  *apiv1.User
+	}{
+		{
+			Name:             "scenario 1: admin can get the progress of an addon rollout",
+			RolloutName:      "monitoring",
+			ExpectedResponse: `{"name":"monitoring","spec":{"addon":"node-exporter","version":"v1","clusterSelector":{"matchLabels":{"env":"prod"}}},"status":{"phase":"Halted","version":"v1","currentWave":0,"clusters":[{"name":"a","seed":"europe","wave":0,"phase":"Failed","message":"cluster did not become healthy within 10m0s","lastTransitionTime":null}],"message":"1 clusters failed, at most 0 failures are tolerated"}}`,
+			HTTPStatus:       http.StatusOK,
+			ExistingAPIUser:  test.GenDefaultAdminAPIUser(),
+		},
+		{
+			Name:             "scenario 2: admin cannot get a non-existing addon rollout",
+			RolloutName:      "missing",
+			ExpectedResponse: `{"error":{"code":404,"message":"addonrollouts.kubermatic.k8s.io \"missing\" not found"}}`,
+			HTTPStatus:       http.StatusNotFound,
+			ExistingAPIUser:  test.GenDefaultAdminAPIUser(),
+		},
+		{
+			Name:             "scenario 3: non-admin can not get an addon rollout",
+			RolloutName:      "monitoring",
+			ExpectedResponse: `{"error":{"code":403,"message":"forbidden: \"bob@acme.com\" doesn't have admin rights"}}`,
+			HTTPStatus:       http.StatusForbidden,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			existingObjects := []ctrlruntimeclient.Object{genAddonRollout(), test.APIUserToKubermaticUser(*tc.ExistingAPIUser)}
+
+			req := httptest.NewRequest("GET", "/api/v2/addonrollouts/"+tc.RolloutName, nil)
+			res := httptest.NewRecorder()
+			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, nil, existingObjects, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+
+			test.CompareWithResult(t, res, tc.ExpectedResponse)
+		})
+	}
+}
+
+func TestPatchAddonRollout(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name             string
+		Patch            string
+		ExpectedResponse string
+		HTTPStatus       int
+		ExistingAPIUser  *apiv1.User
+	}{
+		{
+			Name:             "scenario 1: admin can roll out a new version",
+			Patch:            `{"spec":{"version":"v2","paused":true}}`,
+			ExpectedResponse: `{"name":"monitoring","spec":{"addon":"node-exporter","version":"v2","clusterSelector":{"matchLabels":{"env":"prod"}},"paused":true},"status":{"phase":"Halted","version":"v1","currentWave":0,"clusters":[{"name":"a","seed":"europe","wave":0,"phase":"Failed","message":"cluster did not become healthy within 10m0s","lastTransitionTime":null}],"message":"1 clusters failed, at most 0 failures are tolerated"}}`,
+			HTTPStatus:       http.StatusOK,
+			ExistingAPIUser:  test.GenDefaultAdminAPIUser(),
+		},
+		{
+			Name:             "scenario 2: the name can not be changed",
+			Patch:            `{"name":"logging"}`,
+			ExpectedResponse: `{"error":{"code":400,"message":"changing the addon rollout name is not allowed: \"monitoring\" to \"logging\""}}`,
+			HTTPStatus:       http.StatusBadRequest,
+			ExistingAPIUser:  test.GenDefaultAdminAPIUser(),
+		},
+		{
+			Name:             "scenario 3: non-admin can not patch an addon rollout",
+			Patch:            `{"spec":{"paused":true}}`,
+			ExpectedResponse: `{"error":{"code":403,"message":"forbidden: \"bob@acme.com\" doesn't have admin rights"}}`,
+			HTTPStatus:       http.StatusForbidden,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			existingObjects := []ctrlruntimeclient.Object{genAddonRollout(), test.APIUserToKubermaticUser(*tc.ExistingAPIUser)}
+
+			req := httptest.NewRequest("PATCH", "/api/v2/addonrollouts/monitoring", strings.NewReader(tc.Patch))
+			res := httptest.NewRecorder()
+			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, nil, existingObjects, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+
+			test.CompareWithResult(t, res, tc.ExpectedResponse)
+		})
+	}
+}
+
+func genAddonRollout() *kubermaticv1.AddonRollout {
+	return &kubermaticv1.AddonRollout{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "monitoring",
+		},
+		Spec: kubermaticv1.AddonRolloutSpec{
+			Addon:   "node-exporter",
+			Version: "v1",
+			ClusterSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{"env": "prod"},
+			},
+		},
+		Status: kubermaticv1.AddonRolloutStatus{
+			Phase:   kubermaticv1.AddonRolloutHalted,
+			Version: "v1",
+			Clusters: []kubermaticv1.AddonRolloutClusterStatus{
+				{
+					Name:    "a",
+					Seed:    "europe",
+					Phase:   kubermaticv1.AddonRolloutClusterFailed,
+					Message: "cluster did not become healthy within 10m0s",
+				},
+			},
+			Message: "1 clusters failed, at most 0 failures are tolerated",
+		},
+	}
+}
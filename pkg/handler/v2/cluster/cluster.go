@@ -54,7 +54,26 @@ func CreateEndpoint(projectProvider provider.ProjectProvider, privilegedProjectP
 		}
 
 		return handlercommon.CreateEndpoint(ctx, req.ProjectID, req.Body, projectProvider, privilegedProjectProvider,
-			seedsGetter, credentialManager, exposeStrategy, userInfoGetter, caBundle)
+			seedsGetter, credentialManager, exposeStrategy, userInfoGetter, caBundle, globalSettings.Spec.ClusterAdmissionWebhook, globalSettings.Spec.ForbidPresetInheritance)
+	}
+}
+
+// PlanInfrastructureEndpoint returns the cloud resources which would be created for the cluster
+func PlanInfrastructureEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter, credentialManager provider.PresetProvider,
+	exposeStrategy kubermaticv1.ExposeStrategy, userInfoGetter provider.UserInfoGetter, settingsProvider provider.SettingsProvider, updateManager common.UpdateManager, caBundle *x509.CertPool) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(CreateClusterReq)
+		globalSettings, err := settingsProvider.GetGlobalSettings()
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		err = req.Validate(globalSettings.Spec.ClusterTypeOptions, updateManager)
+		if err != nil {
+			return nil, errors.NewBadRequest(err.Error())
+		}
+
+		return handlercommon.PlanInfrastructureEndpoint(ctx, req.ProjectID, req.Body, projectProvider, privilegedProjectProvider,
+			seedsGetter, credentialManager, exposeStrategy, userInfoGetter, caBundle, globalSettings.Spec.ForbidPresetInheritance)
 	}
 }
 
@@ -124,6 +143,20 @@ func HealthEndpoint(projectProvider provider.ProjectProvider, privilegedProjectP
 	}
 }
 
+func ListCredentialRotationsEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(GetClusterReq)
+		return handlercommon.ListCredentialRotationsEndpoint(ctx, userInfoGetter, req.ProjectID, req.ClusterID, projectProvider, privilegedProjectProvider)
+	}
+}
+
+func GetInfrastructureEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter, userInfoGetter provider.UserInfoGetter, caBundle *x509.CertPool) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(GetClusterReq)
+		return handlercommon.GetInfrastructureEndpoint(ctx, userInfoGetter, req.ProjectID, req.ClusterID, projectProvider, privilegedProjectProvider, seedsGetter, caBundle)
+	}
+}
+
 func MigrateEndpointToExternalCCM(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(GetClusterReq)
@@ -297,7 +330,7 @@ func DecodeAssignSSHKeyReq(c context.Context, r *http.Request) (interface{}, err
 }
 
 // EventsReq defines HTTP request for getClusterEventsV2 endpoint
-// swagger:parameters getClusterEventsV2
+// swagger:parameters getClusterEventsV2 getMonitoringClusterEventsV2
 type EventsReq struct {
 	common.ProjectReq
 	// in: path
@@ -439,7 +472,7 @@ func DecodeDeleteReq(c context.Context, r *http.Request) (interface{}, error) {
 }
 
 // GetClusterReq defines HTTP request for getCluster endpoint.
-// swagger:parameters getClusterV2 getClusterHealthV2 getOidcClusterKubeconfigV2 getClusterKubeconfigV2 getClusterMetricsV2 listNamespaceV2 getClusterUpgradesV2 listAWSSizesNoCredentialsV2 listAWSSubnetsNoCredentialsV2 listGCPNetworksNoCredentialsV2 listGCPZonesNoCredentialsV2 listHetznerSizesNoCredentialsV2 listDigitaloceanSizesNoCredentialsV2 migrateClusterToExternalCCM
+// swagger:parameters getClusterV2 getClusterHealthV2 getOidcClusterKubeconfigV2 getClusterKubeconfigV2 getClusterMetricsV2 listNamespaceV2 getClusterUpgradesV2 listAWSSizesNoCredentialsV2 listAWSSubnetsNoCredentialsV2 listGCPNetworksNoCredentialsV2 listGCPZonesNoCredentialsV2 listHetznerSizesNoCredentialsV2 listDigitaloceanSizesNoCredentialsV2 migrateClusterToExternalCCM listClusterSpecRevisionsV2 getClusterUpgradePreflight getMonitoringClusterHealthV2 getMonitoringClusterMetricsV2
 type GetClusterReq struct {
 	common.ProjectReq
 	// in: path
@@ -33,6 +33,7 @@ import (
 	"k8c.io/kubermatic/v2/pkg/handler/test/hack"
 	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
 	"k8c.io/kubermatic/v2/pkg/semver"
+	"k8c.io/kubermatic/v2/pkg/serviceaccount"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -249,6 +250,25 @@ func TestCreateClusterEndpoint(t *testing.T) {
 			ProjectToSync:   test.GenDefaultProject().Name,
 			ExistingAPIUser: test.GenDefaultAPIUser(),
 		},
+		// scenario 15
+		{
+			Name:             "scenario 15: a cluster is rejected when the datacenter has reached its cluster limit",
+			Body:             `{"cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}}}`,
+			ExpectedResponse: `{"error":{"code":403,"message":"datacenter \"fake-dc\" has reached its limit of 1 clusters"}}`,
+			HTTPStatus:       http.StatusForbidden,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenTestSeed(func(seed *kubermaticv1.Seed) {
+					dc := seed.Spec.Datacenters["fake-dc"]
+					dc.Spec.CapacityLimits = &kubermaticv1.DatacenterCapacityLimits{MaxClusters: 1}
+					seed.Spec.Datacenters["fake-dc"] = dc
+				}),
+				test.GenCluster("clusterabcd", "clusterAbcd", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC), func(cluster *kubermaticv1.Cluster) {
+					cluster.Spec.Cloud.DatacenterName = "fake-dc"
+				}),
+			),
+			ProjectToSync:   test.GenDefaultProject().Name,
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+		},
 	}
 
 	for _, tc := range testcases {
@@ -1297,6 +1317,171 @@ func TestGetClusterHealth(t *testing.T) {
 	}
 }
 
+func TestGetMonitoringClusterHealth(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name             string
+		Path             string
+		TokenScope       string
+		TokenProject     string
+		ExpectedResponse string
+		HTTPStatus       int
+	}{
+		{
+			Name:             "scenario 1: a monitoring token can get the cluster health status",
+			Path:             "/api/v2/projects/my-first-project-ID/monitoring/clusters/keen-snyder/health",
+			TokenScope:       serviceaccount.MonitoringScope,
+			TokenProject:     test.GenDefaultProject().Name,
+			ExpectedResponse: `{"apiserver":1,"scheduler":0,"controller":1,"machineController":0,"etcd":1,"cloudProviderInfrastructure":1,"userClusterControllerManager":1}`,
+			HTTPStatus:       http.StatusOK,
+		},
+		{
+			Name:             "scenario 2: a monitoring token can not be used for another project",
+			Path:             "/api/v2/projects/my-first-project-ID/monitoring/clusters/keen-snyder/health",
+			TokenScope:       serviceaccount.MonitoringScope,
+			TokenProject:     "my-second-project-ID",
+			ExpectedResponse: `{"error":{"code":403,"message":"forbidden: the token is not valid for the project my-first-project-ID"}}`,
+			HTTPStatus:       http.StatusForbidden,
+		},
+		{
+			Name:             "scenario 3: a token without scope is not accepted by the monitoring endpoints",
+			Path:             "/api/v2/projects/my-first-project-ID/monitoring/clusters/keen-snyder/health",
+			TokenProject:     test.GenDefaultProject().Name,
+			ExpectedResponse: `{"error":{"code":403,"message":"forbidden: only tokens with the monitoring scope are accepted"}}`,
+			HTTPStatus:       http.StatusForbidden,
+		},
+		{
+			Name:             "scenario 4: a monitoring token is not accepted by the regular endpoints",
+			Path:             "/api/v2/projects/my-first-project-ID/clusters/keen-snyder/health",
+			TokenScope:       serviceaccount.MonitoringScope,
+			TokenProject:     test.GenDefaultProject().Name,
+			ExpectedResponse: `{"error":{"code":403,"message":"forbidden: the token is restricted to the monitoring scope"}}`,
+			HTTPStatus:       http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			sa := test.GenProjectServiceAccount("1", "status-page", "viewers", test.GenDefaultProject().Name)
+			cluster := test.GenCluster("keen-snyder", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC))
+			cluster.Status.ExtendedHealth = kubermaticv1.ExtendedClusterHealth{
+				Apiserver:                    kubermaticv1.HealthStatusUp,
+				Scheduler:                    kubermaticv1.HealthStatusDown,
+				Controller:                   kubermaticv1.HealthStatusUp,
+				MachineController:            kubermaticv1.HealthStatusDown,
+				Etcd:                         kubermaticv1.HealthStatusUp,
+				CloudProviderInfrastructure:  kubermaticv1.HealthStatusUp,
+				UserClusterControllerManager: kubermaticv1.HealthStatusUp,
+			}
+
+			tokenGenerator, err := serviceaccount.JWTTokenGenerator([]byte(test.TestServiceAccountHashKey))
+			if err != nil {
+				t.Fatalf("failed to create token generator: %v", err)
+			}
+			claims, customClaims := serviceaccount.Claims(sa.Spec.Email, tc.TokenProject, "fake")
+			customClaims.Scope = tc.TokenScope
+			token, err := tokenGenerator.Generate(claims, customClaims)
+			if err != nil {
+				t.Fatalf("failed to generate token: %v", err)
+			}
+			tokenSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "sa-token-fake",
+					Labels: map[string]string{
+						kubermaticv1.ProjectIDLabelKey: tc.TokenProject,
+						"name":                         "fake",
+					},
+				},
+				Data: map[string][]byte{"token": []byte(token)},
+				Type: corev1.SecretTypeOpaque,
+			}
+
+			kubermaticObj := test.GenDefaultKubermaticObjects(
+				test.GenTestSeed(),
+				test.GenBinding(test.GenDefaultProject().Name, sa.Spec.Email, "viewers"),
+				sa,
+				cluster,
+			)
+			apiSA := apiv1.User{ObjectMeta: apiv1.ObjectMeta{Name: sa.Name}, Email: sa.Spec.Email}
+			ep, err := test.CreateTestEndpoint(apiSA, []ctrlruntimeclient.Object{tokenSecret}, kubermaticObj, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			req := httptest.NewRequest("GET", tc.Path, strings.NewReader(""))
+			req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
+			res := httptest.NewRecorder()
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+
+			test.CompareWithResult(t, res, tc.ExpectedResponse)
+		})
+	}
+}
+
+func TestListClusterCredentialRotations(t *testing.T) {
+	t.Parallel()
+
+	rotationTime := time.Date(2021, 7, 1, 12, 0, 0, 0, time.UTC)
+	cluster := test.GenCluster("foo", "foo", test.GenDefaultProject().Name, time.Now())
+	cluster.Status.SetCredentialRotationTime(kubermaticv1.AdminTokenCredentialName, metav1.NewTime(rotationTime))
+	cluster.Status.SetCredentialRotationTime("openvpn-server-certificates", metav1.NewTime(rotationTime.Add(time.Hour)))
+
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v2/projects/%s/clusters/foo/credentialrotations", test.ProjectName), nil)
+	res := httptest.NewRecorder()
+
+	ep, err := test.CreateTestEndpoint(*test.GenDefaultAPIUser(), nil, test.GenDefaultKubermaticObjects(test.GenTestSeed(), cluster), nil, nil, hack.NewTestRouting)
+	if err != nil {
+		t.Fatalf("failed to create test endpoint due to %v", err)
+	}
+	ep.ServeHTTP(res, req)
+
+	if res.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got %d: %s", res.Code, res.Body.String())
+	}
+
+	var rotations []struct {
+		Name         string    `json:"name"`
+		RotationTime time.Time `json:"rotationTime"`
+		AgeSeconds   int64     `json:"ageSeconds"`
+	}
+	if err := json.Unmarshal(res.Body.Bytes(), &rotations); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if len(rotations) != 2 {
+		t.Fatalf("expected two credentials, got %s", res.Body.String())
+	}
+	if rotations[0].Name != kubermaticv1.AdminTokenCredentialName || !rotations[0].RotationTime.Equal(rotationTime) {
+		t.Errorf("expected admin token rotated at %v, got %+v", rotationTime, rotations[0])
+	}
+	if rotations[1].Name != "openvpn-server-certificates" || rotations[0].AgeSeconds-rotations[1].AgeSeconds != 3600 {
+		t.Errorf("expected OpenVPN server certificates one hour younger than the admin token, got %+v", rotations)
+	}
+}
+
+func TestGetClusterInfrastructureNotImplemented(t *testing.T) {
+	t.Parallel()
+
+	cluster := test.GenCluster("foo", "foo", test.GenDefaultProject().Name, time.Now())
+
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v2/projects/%s/clusters/foo/infrastructure", test.ProjectName), nil)
+	res := httptest.NewRecorder()
+
+	ep, err := test.CreateTestEndpoint(*test.GenDefaultAPIUser(), nil, test.GenDefaultKubermaticObjects(test.GenTestSeed(), cluster), nil, nil, hack.NewTestRouting)
+	if err != nil {
+		t.Fatalf("failed to create test endpoint due to %v", err)
+	}
+	ep.ServeHTTP(res, req)
+
+	if res.Code != http.StatusNotImplemented {
+		t.Fatalf("expected status code 501 for a cluster on the fake cloud provider, got %d: %s", res.Code, res.Body.String())
+	}
+}
+
 func TestGetClusterMetrics(t *testing.T) {
 	t.Parallel()
 	cpuQuantity, err := resource.ParseQuantity("290")
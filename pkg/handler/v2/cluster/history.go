@@ -0,0 +1,74 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"crypto/x509"
+	"net/http"
+	"strconv"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/gorilla/mux"
+
+	handlercommon "k8c.io/kubermatic/v2/pkg/handler/common"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	kcerrors "k8c.io/kubermatic/v2/pkg/util/errors"
+)
+
+func ListClusterSpecRevisionsEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(GetClusterReq)
+		return handlercommon.ListClusterSpecRevisionsEndpoint(ctx, userInfoGetter, req.ProjectID, req.ClusterID, projectProvider, privilegedProjectProvider)
+	}
+}
+
+func RollbackClusterSpecEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider,
+	seedsGetter provider.SeedsGetter, userInfoGetter provider.UserInfoGetter, caBundle *x509.CertPool) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(rollbackClusterSpecReq)
+		return handlercommon.RollbackClusterSpecEndpoint(ctx, userInfoGetter, req.ProjectID, req.ClusterID, req.Revision, seedsGetter,
+			projectProvider, privilegedProjectProvider, caBundle)
+	}
+}
+
+// rollbackClusterSpecReq defines HTTP request for rollbackClusterSpec endpoint
+// swagger:parameters rollbackClusterSpecV2
+type rollbackClusterSpecReq struct {
+	GetClusterReq
+	// in: path
+	// required: true
+	Revision int64 `json:"revision"`
+}
+
+func DecodeRollbackClusterSpecReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req rollbackClusterSpecReq
+
+	clusterReq, err := DecodeGetClusterReq(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.GetClusterReq = clusterReq.(GetClusterReq)
+
+	revision, err := strconv.ParseInt(mux.Vars(r)["revision"], 10, 64)
+	if err != nil || revision < 1 {
+		return nil, kcerrors.NewBadRequest("invalid revision %q", mux.Vars(r)["revision"])
+	}
+	req.Revision = revision
+
+	return req, nil
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/handler/test"
+	"k8c.io/kubermatic/v2/pkg/handler/test/hack"
+	"k8c.io/kubermatic/v2/pkg/semver"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestClusterSpecHistory(t *testing.T) {
+	t.Parallel()
+
+	cluster := test.GenCluster("keen-snyder", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC))
+	cluster.Spec.Cloud.DatacenterName = "fake-dc"
+	cluster.Status.UserEmail = "john@acme.com"
+
+	ep, _, err := test.CreateTestEndpointAndGetClients(*test.GenDefaultAPIUser(), nil, nil, nil, test.GenDefaultKubermaticObjects(test.GenTestSeed(), cluster), nil, nil, hack.NewTestRouting)
+	if err != nil {
+		t.Fatalf("failed to create test endpoint due to %v", err)
+	}
+
+	serve := func(method, path, body string, expectedStatus int) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, fmt.Sprintf("/api/v2/projects/%s/clusters/keen-snyder%s", test.GenDefaultProject().Name, path), strings.NewReader(body))
+		res := httptest.NewRecorder()
+		ep.ServeHTTP(res, req)
+		if res.Code != expectedStatus {
+			t.Fatalf("expected HTTP status code %d for %s %s, got %d: %s", expectedStatus, method, path, res.Code, res.Body.String())
+		}
+		return res
+	}
+
+	listRevisions := func() []apiv2.ClusterSpecRevision {
+		var revisions []apiv2.ClusterSpecRevision
+		if err := json.Unmarshal(serve(http.MethodGet, "/history", "", http.StatusOK).Body.Bytes(), &revisions); err != nil {
+			t.Fatalf("failed to decode revisions: %v", err)
+		}
+		return revisions
+	}
+
+	if revisions := listRevisions(); len(revisions) != 0 {
+		t.Fatalf("expected no revisions before the first change, got %v", revisions)
+	}
+
+	serve(http.MethodPatch, "", `{"name":"renamed"}`, http.StatusOK)
+
+	revisions := listRevisions()
+	if len(revisions) != 2 {
+		t.Fatalf("expected the initial spec and the change to be recorded, got %v", revisions)
+	}
+	if revisions[0].Revision != 1 || revisions[0].Author != "john@acme.com" || revisions[0].Diff != "" {
+		t.Fatalf("unexpected initial revision: %+v", revisions[0])
+	}
+	if revisions[1].Revision != 2 || revisions[1].Author != test.GenDefaultAPIUser().Email || revisions[1].Diff != `{"humanReadableName":"renamed"}` {
+		t.Fatalf("unexpected revision: %+v", revisions[1])
+	}
+
+	// patching without changes does not record a revision
+	serve(http.MethodPatch, "", `{"name":"renamed"}`, http.StatusOK)
+	if revisions := listRevisions(); len(revisions) != 2 {
+		t.Fatalf("expected no new revision, got %v", revisions)
+	}
+
+	var rolledBack apiv1.Cluster
+	if err := json.Unmarshal(serve(http.MethodPost, "/history/1/rollback", "", http.StatusOK).Body.Bytes(), &rolledBack); err != nil {
+		t.Fatalf("failed to decode cluster: %v", err)
+	}
+	if rolledBack.Name != "clusterAbc" {
+		t.Fatalf("expected the cluster name to be rolled back, got %q", rolledBack.Name)
+	}
+
+	revisions = listRevisions()
+	if len(revisions) != 3 || revisions[2].Diff != `{"humanReadableName":"clusterAbc"}` {
+		t.Fatalf("expected the rollback to be recorded as a new revision, got %v", revisions)
+	}
+
+	serve(http.MethodPost, "/history/10/rollback", "", http.StatusNotFound)
+	serve(http.MethodPost, "/history/latest/rollback", "", http.StatusBadRequest)
+}
+
+func TestRollbackClusterSpecKeepsUnsafeFields(t *testing.T) {
+	t.Parallel()
+
+	cluster := test.GenCluster("keen-snyder", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC))
+	cluster.Spec.Cloud.DatacenterName = "fake-dc"
+
+	revision := &kubermaticv1.ClusterSpecRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "keen-snyder-1",
+			Labels: map[string]string{kubermaticv1.ClusterSpecRevisionClusterLabelKey: cluster.Name},
+		},
+		Spec: kubermaticv1.ClusterSpecRevisionSpec{
+			ClusterName: cluster.Name,
+			Revision:    1,
+			ClusterSpec: *cluster.Spec.DeepCopy(),
+		},
+	}
+	revision.Spec.ClusterSpec.HumanReadableName = "oldName"
+	revision.Spec.ClusterSpec.Version = *semver.NewSemverOrDie("1.0.0")
+
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/v2/projects/%s/clusters/keen-snyder/history/1/rollback", test.GenDefaultProject().Name), nil)
+	res := httptest.NewRecorder()
+	ep, err := test.CreateTestEndpoint(*test.GenDefaultAPIUser(), nil, test.GenDefaultKubermaticObjects(test.GenTestSeed(), cluster, revision), nil, nil, hack.NewTestRouting)
+	if err != nil {
+		t.Fatalf("failed to create test endpoint due to %v", err)
+	}
+
+	ep.ServeHTTP(res, req)
+
+	if res.Code != http.StatusOK {
+		t.Fatalf("expected HTTP status code %d, got %d: %s", http.StatusOK, res.Code, res.Body.String())
+	}
+	var rolledBack apiv1.Cluster
+	if err := json.Unmarshal(res.Body.Bytes(), &rolledBack); err != nil {
+		t.Fatalf("failed to decode cluster: %v", err)
+	}
+	if rolledBack.Name != "oldName" {
+		t.Fatalf("expected the cluster name to be rolled back, got %q", rolledBack.Name)
+	}
+	if !rolledBack.Spec.Version.Equal(&cluster.Spec.Version) {
+		t.Fatalf("expected the cluster version %q to be kept, got %q", cluster.Spec.Version.String(), rolledBack.Spec.Version.String())
+	}
+}
@@ -18,12 +18,19 @@ package cluster
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 
 	"github.com/go-kit/kit/endpoint"
+	"github.com/gorilla/mux"
 
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
 	handlercommon "k8c.io/kubermatic/v2/pkg/handler/common"
+	"k8c.io/kubermatic/v2/pkg/handler/middleware"
+	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
 	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/serviceaccount"
+	kcerrors "k8c.io/kubermatic/v2/pkg/util/errors"
 )
 
 func GetAdminKubeconfigEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
@@ -43,3 +50,105 @@ func GetOidcKubeconfigEndpoint(projectProvider provider.ProjectProvider, privile
 		return handlercommon.GetOidcKubeconfigEndpoint(ctx, userInfoGetter, req.ProjectID, req.ClusterID, projectProvider, privilegedProjectProvider)
 	}
 }
+
+func CreateKubeconfigShareLinkEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter, tokenGenerator serviceaccount.TokenGenerator) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(createKubeconfigShareLinkReq)
+		return handlercommon.CreateKubeconfigShareLinkEndpoint(ctx, userInfoGetter, tokenGenerator, req.ProjectID, req.ClusterID, req.Body, projectProvider, privilegedProjectProvider)
+	}
+}
+
+// createKubeconfigShareLinkReq defines HTTP request for createClusterKubeconfigShareLinkV2 endpoint
+// swagger:parameters createClusterKubeconfigShareLinkV2
+type createKubeconfigShareLinkReq struct {
+	GetClusterReq
+	// in: body
+	Body apiv1.KubeconfigShareLinkSpec
+}
+
+func DecodeCreateKubeconfigShareLinkReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req createKubeconfigShareLinkReq
+
+	clusterReq, err := DecodeGetClusterReq(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.GetClusterReq = clusterReq.(GetClusterReq)
+
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req.Body); err != nil {
+			return nil, kcerrors.NewBadRequest("unable to parse the request body: %v", err)
+		}
+	}
+
+	return req, nil
+}
+
+func GetSharedKubeconfigEndpoint(tokenAuthenticator serviceaccount.TokenAuthenticator, privilegedProjectProvider provider.PrivilegedProjectProvider, clusterProviderGetter provider.ClusterProviderGetter, seedsGetter provider.SeedsGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(getSharedKubeconfigReq)
+
+		// the cluster, and with it the seed, is only known once the link has been verified
+		link, err := handlercommon.ParseKubeconfigShareToken(tokenAuthenticator, req.Token)
+		if err != nil {
+			return nil, err
+		}
+
+		getKubeconfig := middleware.SetPrivilegedClusterProvider(clusterProviderGetter, seedsGetter)(func(ctx context.Context, _ interface{}) (interface{}, error) {
+			return handlercommon.GetSharedKubeconfigEndpoint(ctx, privilegedProjectProvider, link)
+		})
+		return getKubeconfig(ctx, GetClusterReq{ProjectReq: common.ProjectReq{ProjectID: link.ProjectID}, ClusterID: link.ClusterID})
+	}
+}
+
+func RevokeKubeconfigShareLinkEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(revokeKubeconfigShareLinkReq)
+		return handlercommon.RevokeKubeconfigShareLinkEndpoint(ctx, userInfoGetter, req.ProjectID, req.ClusterID, req.LinkID, projectProvider, privilegedProjectProvider)
+	}
+}
+
+// revokeKubeconfigShareLinkReq defines HTTP request for revokeClusterKubeconfigShareLinkV2 endpoint
+// swagger:parameters revokeClusterKubeconfigShareLinkV2
+type revokeKubeconfigShareLinkReq struct {
+	GetClusterReq
+	// in: path
+	// required: true
+	LinkID string `json:"link_id"`
+}
+
+func DecodeRevokeKubeconfigShareLinkReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req revokeKubeconfigShareLinkReq
+
+	clusterReq, err := DecodeGetClusterReq(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.GetClusterReq = clusterReq.(GetClusterReq)
+
+	req.LinkID = mux.Vars(r)["link_id"]
+	if req.LinkID == "" {
+		return nil, kcerrors.NewBadRequest("'link_id' parameter is required but was not provided")
+	}
+
+	return req, nil
+}
+
+// getSharedKubeconfigReq defines HTTP request for getSharedClusterKubeconfigV2 endpoint
+// swagger:parameters getSharedClusterKubeconfigV2
+type getSharedKubeconfigReq struct {
+	// in: query
+	// required: true
+	Token string `json:"token"`
+}
+
+func DecodeGetSharedKubeconfigReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req getSharedKubeconfigReq
+
+	req.Token = r.URL.Query().Get("token")
+	if req.Token == "" {
+		return nil, kcerrors.NewBadRequest("the token query parameter is required")
+	}
+
+	return req, nil
+}
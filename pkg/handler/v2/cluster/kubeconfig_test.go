@@ -17,18 +17,26 @@ limitations under the License.
 package cluster_test
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
 	kubermaticapiv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/handler/test"
 	"k8c.io/kubermatic/v2/pkg/handler/test/hack"
+	"k8c.io/kubermatic/v2/pkg/serviceaccount"
 
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -192,6 +200,187 @@ func TestGetMasterKubeconfig(t *testing.T) {
 
 }
 
+func TestKubeconfigShareLink(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name                   string
+		Body                   string
+		Group                  string
+		HTTPStatus             int
+		ExpectedResponseString string
+	}{
+		{
+			Name:       "scenario 1: owner shares a viewer kubeconfig",
+			Group:      "owners",
+			HTTPStatus: http.StatusCreated,
+		},
+		{
+			Name:       "scenario 2: editor shares a viewer kubeconfig with a custom lifetime",
+			Body:       `{"expiresIn":600}`,
+			Group:      "editors",
+			HTTPStatus: http.StatusCreated,
+		},
+		{
+			Name:                   "scenario 3: viewer can not share the kubeconfig",
+			Group:                  "viewers",
+			HTTPStatus:             http.StatusForbidden,
+			ExpectedResponseString: `{"error":{"code":403,"message":"viewers are not allowed to share the cluster kubeconfig"}}`,
+		},
+		{
+			Name:                   "scenario 4: the lifetime of the link is limited",
+			Body:                   `{"expiresIn":86401}`,
+			Group:                  "owners",
+			HTTPStatus:             http.StatusBadRequest,
+			ExpectedResponseString: `{"error":{"code":400,"message":"the link lifetime must be between 1 and 86400 seconds"}}`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			ep, clients, err := test.CreateTestEndpointAndGetClients(*test.GenAPIUser("john", "john@acme.com"), nil, genKubeconfigSecrets(), []ctrlruntimeclient.Object{}, genKubeconfigShareObjects(tc.Group), nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			req := httptest.NewRequest("POST", "/api/v2/projects/foo-ID/clusters/cluster-foo/kubeconfig/share", strings.NewReader(tc.Body))
+			res := httptest.NewRecorder()
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+			if res.Code != http.StatusCreated {
+				test.CompareWithResult(t, res, tc.ExpectedResponseString)
+				return
+			}
+
+			link := &apiv1.KubeconfigShareLink{}
+			if err := json.Unmarshal(res.Body.Bytes(), link); err != nil {
+				t.Fatalf("failed to unmarshal the sharing link: %v", err)
+			}
+
+			sa := &corev1.ServiceAccount{}
+			if err := clients.FakeClient.Get(context.Background(), types.NamespacedName{Namespace: "kubermatic-kubeconfig-share", Name: link.ID}, sa); err != nil {
+				t.Fatalf("failed to get the service account of the link: %v", err)
+			}
+			if expiry := sa.Annotations["kubermatic.io/kubeconfig-share-expiry"]; expiry != link.Expiry.UTC().Format(time.RFC3339) {
+				t.Errorf("expected the service account to expire at %v, got %q", link.Expiry, expiry)
+			}
+			binding := &rbacv1.ClusterRoleBinding{}
+			if err := clients.FakeClient.Get(context.Background(), types.NamespacedName{Name: "kubermatic:kubeconfig-share"}, binding); err != nil {
+				t.Fatalf("failed to get the cluster role binding of the links: %v", err)
+			}
+			if binding.RoleRef.Name != "system:kubermatic:viewers" {
+				t.Errorf("expected the links to be bound to the viewers role, got %q", binding.RoleRef.Name)
+			}
+
+			req = httptest.NewRequest("DELETE", "/api/v2/projects/foo-ID/clusters/cluster-foo/kubeconfig/share/"+link.ID, nil)
+			res = httptest.NewRecorder()
+			ep.ServeHTTP(res, req)
+
+			if res.Code != http.StatusOK {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", http.StatusOK, res.Code, res.Body.String())
+			}
+
+			req = httptest.NewRequest("GET", link.URL, nil)
+			res = httptest.NewRecorder()
+			ep.ServeHTTP(res, req)
+
+			if res.Code != http.StatusUnauthorized {
+				t.Fatalf("Expected HTTP status code %d for a revoked link, got %d: %s", http.StatusUnauthorized, res.Code, res.Body.String())
+			}
+			test.CompareWithResult(t, res, `{"error":{"code":401,"message":"invalid kubeconfig sharing link: the link has been revoked"}}`)
+		})
+	}
+}
+
+func TestKubeconfigShareLinkDeletesExpiredLinks(t *testing.T) {
+	t.Parallel()
+	expired := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "kubermatic-kubeconfig-share",
+			Name:      "kubeconfig-share-expired",
+			Annotations: map[string]string{
+				"kubermatic.io/kubeconfig-share-expiry": time.Now().Add(-time.Minute).UTC().Format(time.RFC3339),
+			},
+		},
+	}
+	ep, clients, err := test.CreateTestEndpointAndGetClients(*test.GenAPIUser("john", "john@acme.com"), nil, append(genKubeconfigSecrets(), expired), []ctrlruntimeclient.Object{}, genKubeconfigShareObjects("owners"), nil, nil, hack.NewTestRouting)
+	if err != nil {
+		t.Fatalf("failed to create test endpoint due to %v", err)
+	}
+
+	req := httptest.NewRequest("POST", "/api/v2/projects/foo-ID/clusters/cluster-foo/kubeconfig/share", nil)
+	res := httptest.NewRecorder()
+	ep.ServeHTTP(res, req)
+
+	if res.Code != http.StatusCreated {
+		t.Fatalf("Expected HTTP status code %d, got %d: %s", http.StatusCreated, res.Code, res.Body.String())
+	}
+	if err := clients.FakeClient.Get(context.Background(), ctrlruntimeclient.ObjectKeyFromObject(expired), &corev1.ServiceAccount{}); !kerrors.IsNotFound(err) {
+		t.Errorf("expected the service account of the expired link to be deleted, got %v", err)
+	}
+}
+
+func TestGetSharedKubeconfigRejectsInvalidTokens(t *testing.T) {
+	t.Parallel()
+	ep, clients, err := test.CreateTestEndpointAndGetClients(*test.GenAPIUser("john", "john@acme.com"), nil, genKubeconfigSecrets(), []ctrlruntimeclient.Object{}, genKubeconfigShareObjects("owners"), nil, nil, hack.NewTestRouting)
+	if err != nil {
+		t.Fatalf("failed to create test endpoint due to %v", err)
+	}
+
+	saToken, err := clients.TokenGenerator.Generate(serviceaccount.Claims("serviceaccount-abcd@sa.kubermatic.io", "foo-ID", "sa-token-abcd"))
+	if err != nil {
+		t.Fatalf("failed to generate a service account token: %v", err)
+	}
+
+	for name, token := range map[string]string{
+		"malformed token":       "garbage",
+		"service account token": saToken,
+	} {
+		req := httptest.NewRequest("GET", "/api/v2/kubeconfig/shared?token="+token, nil)
+		res := httptest.NewRecorder()
+		ep.ServeHTTP(res, req)
+
+		if res.Code != http.StatusUnauthorized {
+			t.Errorf("%s: expected HTTP status code %d, got %d: %s", name, http.StatusUnauthorized, res.Code, res.Body.String())
+		}
+	}
+}
+
+func genKubeconfigShareObjects(group string) []ctrlruntimeclient.Object {
+	return []ctrlruntimeclient.Object{
+		test.GenTestSeed(),
+		test.GenProject("foo", kubermaticapiv1.ProjectActive, test.DefaultCreationTimestamp()),
+		test.GenBinding("foo-ID", "john@acme.com", group),
+		test.GenUser("", "john", "john@acme.com"),
+		test.GenCluster("cluster-foo", "cluster-foo", "foo-ID", test.DefaultCreationTimestamp()),
+	}
+}
+
+func genKubeconfigSecrets() []ctrlruntimeclient.Object {
+	return []ctrlruntimeclient.Object{
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "cluster-cluster-foo",
+				Name:      "admin-kubeconfig",
+			},
+			Data: map[string][]byte{
+				"kubeconfig": []byte(test.GenerateTestKubeconfig("cluster-foo", test.IDToken)),
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "cluster-cluster-foo",
+				Name:      "viewer-kubeconfig",
+			},
+			Data: map[string][]byte{
+				"kubeconfig": []byte(test.GenerateTestKubeconfig("cluster-foo", test.IDViewerToken)),
+			},
+		},
+	}
+}
+
 func genToken(tokenID string) string {
 	return fmt.Sprintf(`apiVersion: v1
 clusters:
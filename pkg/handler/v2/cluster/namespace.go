@@ -0,0 +1,111 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/gorilla/mux"
+
+	handlercommon "k8c.io/kubermatic/v2/pkg/handler/common"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	kcerrors "k8c.io/kubermatic/v2/pkg/util/errors"
+)
+
+func ListClusterNamespacesEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(GetClusterReq)
+		return handlercommon.ListClusterNamespacesEndpoint(ctx, userInfoGetter, req.ProjectID, req.ClusterID, projectProvider, privilegedProjectProvider)
+	}
+}
+
+func CreateClusterNamespaceEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider,
+	userInfoGetter provider.UserInfoGetter, namespaceTemplateProvider provider.PrivilegedNamespaceTemplateProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(createClusterNamespaceReq)
+		return handlercommon.CreateClusterNamespaceEndpoint(ctx, userInfoGetter, req.ProjectID, req.ClusterID, req.Body.Name, req.Body.Template,
+			projectProvider, privilegedProjectProvider, namespaceTemplateProvider)
+	}
+}
+
+func DeleteClusterNamespaceEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(clusterNamespaceReq)
+		return handlercommon.DeleteClusterNamespaceEndpoint(ctx, userInfoGetter, req.ProjectID, req.ClusterID, req.Namespace, projectProvider, privilegedProjectProvider)
+	}
+}
+
+// createClusterNamespaceReq defines HTTP request for createClusterNamespace endpoint
+// swagger:parameters createClusterNamespace
+type createClusterNamespaceReq struct {
+	GetClusterReq
+	// in: body
+	// required: true
+	Body clusterNamespaceBody
+}
+
+type clusterNamespaceBody struct {
+	// Name of the namespace in the user cluster
+	Name string `json:"name"`
+	// Template is the name of the namespace template to apply, optional
+	Template string `json:"template,omitempty"`
+}
+
+func DecodeCreateClusterNamespaceReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req createClusterNamespaceReq
+
+	clusterReq, err := DecodeGetClusterReq(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.GetClusterReq = clusterReq.(GetClusterReq)
+
+	if err := json.NewDecoder(r.Body).Decode(&req.Body); err != nil {
+		return nil, kcerrors.NewBadRequest("unable to parse the namespace: %v", err)
+	}
+
+	return req, nil
+}
+
+// clusterNamespaceReq defines HTTP request for deleteClusterNamespace endpoint
+// swagger:parameters deleteClusterNamespace
+type clusterNamespaceReq struct {
+	GetClusterReq
+	// in: path
+	// required: true
+	Namespace string `json:"namespace"`
+}
+
+func DecodeClusterNamespaceReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req clusterNamespaceReq
+
+	clusterReq, err := DecodeGetClusterReq(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.GetClusterReq = clusterReq.(GetClusterReq)
+
+	req.Namespace = mux.Vars(r)["namespace"]
+	if req.Namespace == "" {
+		return nil, kcerrors.NewBadRequest("'namespace' parameter is required but was not provided")
+	}
+
+	return req, nil
+}
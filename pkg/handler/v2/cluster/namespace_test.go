@@ -0,0 +1,101 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/handler/test"
+	"k8c.io/kubermatic/v2/pkg/handler/test/hack"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestClusterNamespaces(t *testing.T) {
+	t.Parallel()
+
+	cluster := test.GenCluster("keen-snyder", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC))
+	template := &kubermaticv1.NamespaceTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "small"},
+		Spec: kubermaticv1.NamespaceTemplateSpec{
+			ResourceQuota: &corev1.ResourceQuotaSpec{
+				Hard: corev1.ResourceList{corev1.ResourceLimitsCPU: resource.MustParse("4")},
+			},
+			RoleBindings: []kubermaticv1.NamespaceRoleBinding{
+				{Name: "editors", ClusterRole: "edit", Groups: []string{"editors"}},
+			},
+		},
+	}
+
+	ep, clients, err := test.CreateTestEndpointAndGetClients(*test.GenDefaultAPIUser(), nil, nil, nil, test.GenDefaultKubermaticObjects(test.GenTestSeed(), cluster, template), nil, nil, hack.NewTestRouting)
+	if err != nil {
+		t.Fatalf("failed to create test endpoint due to %v", err)
+	}
+
+	serve := func(method, path, body string, expectedStatus int) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, fmt.Sprintf("/api/v2/projects/%s/clusters/keen-snyder/clusternamespaces%s", test.GenDefaultProject().Name, path), strings.NewReader(body))
+		res := httptest.NewRecorder()
+		ep.ServeHTTP(res, req)
+		if res.Code != expectedStatus {
+			t.Fatalf("expected HTTP status code %d for %s %s, got %d: %s", expectedStatus, method, path, res.Code, res.Body.String())
+		}
+		return res
+	}
+
+	serve(http.MethodPost, "", `{"name":"team-a","template":"small"}`, http.StatusCreated)
+	serve(http.MethodPost, "", `{"name":"team-b"}`, http.StatusCreated)
+	serve(http.MethodPost, "", `{"name":"team-a"}`, http.StatusConflict)
+	serve(http.MethodPost, "", `{"name":"kube-team"}`, http.StatusBadRequest)
+	serve(http.MethodPost, "", `{"name":"Team_C"}`, http.StatusBadRequest)
+	serve(http.MethodPost, "", `{"name":"team-c","template":"missing"}`, http.StatusBadRequest)
+
+	// The template is recorded as it was when the namespace was created.
+	clusterNamespace := &kubermaticv1.ClusterNamespace{}
+	if err := clients.FakeClient.Get(context.Background(), types.NamespacedName{Name: "keen-snyder-team-a"}, clusterNamespace); err != nil {
+		t.Fatalf("failed to get cluster namespace: %v", err)
+	}
+	if !equality.Semantic.DeepEqual(clusterNamespace.Spec.TemplateSpec, template.Spec) {
+		t.Fatalf("expected the template spec to be recorded, got %+v", clusterNamespace.Spec.TemplateSpec)
+	}
+	if clusterNamespace.Spec.CreatedBy != test.GenDefaultAPIUser().Email {
+		t.Fatalf("expected the namespace to be created by %q, got %q", test.GenDefaultAPIUser().Email, clusterNamespace.Spec.CreatedBy)
+	}
+
+	var namespaces []apiv2.ClusterNamespace
+	if err := json.Unmarshal(serve(http.MethodGet, "", "", http.StatusOK).Body.Bytes(), &namespaces); err != nil {
+		t.Fatalf("failed to decode namespaces: %v", err)
+	}
+	if len(namespaces) != 2 || namespaces[0].Name != "team-a" || namespaces[0].Template != "small" || namespaces[1].Name != "team-b" {
+		t.Fatalf("unexpected namespaces: %+v", namespaces)
+	}
+
+	serve(http.MethodDelete, "/team-b", "", http.StatusOK)
+	serve(http.MethodDelete, "/team-b", "", http.StatusNotFound)
+}
@@ -40,6 +40,16 @@ func GetUpgradesEndpoint(updateManager common.UpdateManager, projectProvider pro
 	}
 }
 
+func GetUpgradePreflightEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(GetClusterReq)
+		if !ok {
+			return nil, errors.NewWrongRequest(request, common.GetClusterReq{})
+		}
+		return handlercommon.GetUpgradePreflightEndpoint(ctx, userInfoGetter, req.ProjectID, req.ClusterID, projectProvider, privilegedProjectProvider)
+	}
+}
+
 func UpgradeNodeDeploymentsEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req, ok := request.(UpgradeNodeDeploymentsReq)
@@ -36,6 +36,7 @@ import (
 	k8csemver "k8c.io/kubermatic/v2/pkg/semver"
 	"k8c.io/kubermatic/v2/pkg/version"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -264,6 +265,91 @@ func TestGetClusterUpgrades(t *testing.T) {
 	}
 }
 
+func TestGetClusterUpgradePreflight(t *testing.T) {
+	t.Parallel()
+
+	scanTime := metav1.NewTime(time.Date(2021, 7, 1, 12, 0, 0, 0, time.UTC))
+	removedAPIs := []kubermaticv1.RemovedAPI{
+		{
+			Group:       "networking.k8s.io",
+			Version:     "v1beta1",
+			Resource:    "ingresses",
+			RemovedIn:   "1.22",
+			Replacement: "networking.k8s.io/v1",
+			Requested:   true,
+		},
+		{
+			Group:       "rbac.authorization.k8s.io",
+			Version:     "v1beta1",
+			Resource:    "roles",
+			RemovedIn:   "1.22",
+			Replacement: "rbac.authorization.k8s.io/v1",
+		},
+	}
+
+	tests := []struct {
+		name             string
+		report           *kubermaticv1.APIDeprecationReport
+		expectedResponse string
+	}{
+		{
+			name:             "cluster has not been scanned yet",
+			expectedResponse: `{"targetVersion":"1.22","removedAPIs":[],"compatible":false}`,
+		},
+		{
+			name: "requested removed APIs make the cluster incompatible",
+			report: &kubermaticv1.APIDeprecationReport{
+				ScanTime:          scanTime,
+				KubernetesVersion: "1.21.3",
+				TargetVersion:     "1.22",
+				RemovedAPIs:       removedAPIs,
+			},
+			expectedResponse: `{"targetVersion":"1.22","scanTime":"2021-07-01T12:00:00Z","removedAPIs":[{"group":"networking.k8s.io","version":"v1beta1","resource":"ingresses","removedIn":"1.22","replacement":"networking.k8s.io/v1","requested":true},{"group":"rbac.authorization.k8s.io","version":"v1beta1","resource":"roles","removedIn":"1.22","replacement":"rbac.authorization.k8s.io/v1","requested":false}],"compatible":false}`,
+		},
+		{
+			name: "served but not requested removed APIs keep the cluster compatible",
+			report: &kubermaticv1.APIDeprecationReport{
+				ScanTime:          scanTime,
+				KubernetesVersion: "1.21.3",
+				TargetVersion:     "1.22",
+				RemovedAPIs:       removedAPIs[1:],
+			},
+			expectedResponse: `{"targetVersion":"1.22","scanTime":"2021-07-01T12:00:00Z","removedAPIs":[{"group":"rbac.authorization.k8s.io","version":"v1beta1","resource":"roles","removedIn":"1.22","replacement":"rbac.authorization.k8s.io/v1","requested":false}],"compatible":true}`,
+		},
+		{
+			name: "report of the previous version is ignored",
+			report: &kubermaticv1.APIDeprecationReport{
+				ScanTime:          scanTime,
+				KubernetesVersion: "1.20.9",
+				TargetVersion:     "1.21",
+			},
+			expectedResponse: `{"targetVersion":"1.22","removedAPIs":[],"compatible":false}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := test.GenCluster("foo", "foo", test.GenDefaultProject().Name, time.Now())
+			cluster.Spec.Version = *k8csemver.NewSemverOrDie("1.21.3")
+			cluster.Status.APIDeprecations = tc.report
+
+			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v2/projects/%s/clusters/foo/upgrades/preflight", test.ProjectName), nil)
+			res := httptest.NewRecorder()
+
+			ep, err := test.CreateTestEndpoint(*test.GenDefaultAPIUser(), nil, test.GenDefaultKubermaticObjects(test.GenTestSeed(), cluster), nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+			ep.ServeHTTP(res, req)
+
+			if res.Code != http.StatusOK {
+				t.Fatalf("expected status code 200, got %d: %s", res.Code, res.Body.String())
+			}
+			test.CompareWithResult(t, res, tc.expectedResponse)
+		})
+	}
+}
+
 func TestUpgradeClusterNodeDeployments(t *testing.T) {
 	t.Parallel()
 
@@ -728,6 +728,8 @@ func convertInternalClusterTemplatetoExternal(template *kubermaticv1.ClusterTemp
 				PodNodeSelectorAdmissionPluginConfig: template.Spec.PodNodeSelectorAdmissionPluginConfig,
 				ServiceAccount:                       template.Spec.ServiceAccount,
 				MLA:                                  template.Spec.MLA,
+				LogShipping:                          template.Spec.LogShipping,
+				PatchUpgradePolicy:                   template.Spec.PatchUpgradePolicy,
 				ContainerRuntime:                     template.Spec.ContainerRuntime,
 			},
 		},
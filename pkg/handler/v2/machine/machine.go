@@ -18,6 +18,7 @@ package machine
 
 import (
 	"context"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -34,15 +35,15 @@ import (
 	"k8c.io/kubermatic/v2/pkg/provider"
 )
 
-func CreateMachineDeployment(sshKeyProvider provider.SSHKeyProvider, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+func CreateMachineDeployment(sshKeyProvider provider.SSHKeyProvider, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter, userInfoGetter provider.UserInfoGetter, settingsProvider provider.SettingsProvider, caBundle *x509.CertPool) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(createMachineDeploymentReq)
-		return handlercommon.CreateMachineDeployment(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, sshKeyProvider, seedsGetter, req.Body, req.ProjectID, req.ClusterID)
+		return handlercommon.CreateMachineDeployment(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, sshKeyProvider, seedsGetter, settingsProvider, caBundle, req.Body, req.ProjectID, req.ClusterID)
 	}
 }
 
-// createMachineDeploymentReq defines HTTP request for createMachineDeployment
-// swagger:parameters createMachineDeployment
+// createMachineDeploymentReq defines HTTP request for createMachineDeployment and previewMachineDeploymentBootstrapConfig
+// swagger:parameters createMachineDeployment previewMachineDeploymentBootstrapConfig
 type createMachineDeploymentReq struct {
 	common.ProjectReq
 	// in: path
@@ -80,6 +81,13 @@ func (req createMachineDeploymentReq) GetSeedCluster() apiv1.SeedCluster {
 	}
 }
 
+func PreviewMachineDeploymentBootstrapConfig(sshKeyProvider provider.SSHKeyProvider, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(createMachineDeploymentReq)
+		return handlercommon.PreviewMachineDeploymentBootstrapConfig(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, sshKeyProvider, seedsGetter, req.Body, req.ProjectID, req.ClusterID)
+	}
+}
+
 func DeleteMachineDeploymentNode(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(deleteMachineDeploymentNodeReq)
@@ -128,8 +136,8 @@ func (req deleteMachineDeploymentNodeReq) GetSeedCluster() apiv1.SeedCluster {
 	}
 }
 
-// listMachineDeploymentsReq defines HTTP request for listMachineDeployments
-// swagger:parameters listMachineDeployments
+// listMachineDeploymentsReq defines HTTP request for listMachineDeployments and listMachineDeploymentsMetrics
+// swagger:parameters listMachineDeployments listMachineDeploymentsMetrics
 type listMachineDeploymentsReq struct {
 	common.ProjectReq
 	// in: path
@@ -168,6 +176,13 @@ func ListMachineDeployments(projectProvider provider.ProjectProvider, privileged
 	}
 }
 
+func ListMachineDeploymentsMetrics(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listMachineDeploymentsReq)
+		return handlercommon.ListMachineDeploymentsMetrics(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, req.ProjectID, req.ClusterID)
+	}
+}
+
 func GetMachineDeployment(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(machineDeploymentReq)
@@ -399,10 +414,10 @@ func DecodePatchMachineDeployment(c context.Context, r *http.Request) (interface
 	return req, nil
 }
 
-func PatchMachineDeployment(sshKeyProvider provider.SSHKeyProvider, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+func PatchMachineDeployment(sshKeyProvider provider.SSHKeyProvider, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter, userInfoGetter provider.UserInfoGetter, settingsProvider provider.SettingsProvider, caBundle *x509.CertPool) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(patchMachineDeploymentReq)
-		return handlercommon.PatchMachineDeployment(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, sshKeyProvider, seedsGetter, req.ProjectID, req.ClusterID, req.MachineDeploymentID, req.Patch)
+		return handlercommon.PatchMachineDeployment(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, sshKeyProvider, seedsGetter, settingsProvider, caBundle, req.ProjectID, req.ClusterID, req.MachineDeploymentID, req.Patch)
 	}
 }
 
@@ -30,6 +30,7 @@ import (
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/handler/test"
 	"k8c.io/kubermatic/v2/pkg/handler/test/hack"
+	machineconversions "k8c.io/kubermatic/v2/pkg/machine"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -195,6 +196,69 @@ func TestCreateMachineDeployment(t *testing.T) {
 	}
 }
 
+func TestPreviewMachineDeploymentBootstrapConfig(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name                   string
+		Body                   string
+		ExpectedResponse       string
+		ProjectID              string
+		ClusterID              string
+		HTTPStatus             int
+		ExistingAPIUser        *apiv1.User
+		ExistingKubermaticObjs []ctrlruntimeclient.Object
+	}{
+		// scenario 1
+		{
+			Name:             "scenario 1: cluster components are not ready",
+			Body:             `{"spec":{"replicas":1,"template":{"cloud":{"digitalocean":{"size":"s-1vcpu-1gb","backups":false,"ipv6":false,"monitoring":false,"tags":[]}},"operatingSystem":{"ubuntu":{"distUpgradeOnBoot":false}}}}}`,
+			ExpectedResponse: `{"error":{"code":503,"message":"Cluster components are not ready yet"}}`,
+			HTTPStatus:       http.StatusServiceUnavailable,
+			ProjectID:        test.GenDefaultProject().Name,
+			ClusterID:        test.GenDefaultCluster().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenTestSeed(),
+				genTestCluster(false),
+			),
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+		},
+
+		// scenario 2
+		{
+			Name:             "scenario 2: kubelet version is too old",
+			Body:             `{"spec":{"replicas":1,"template":{"cloud":{"digitalocean":{"size":"s-1vcpu-1gb","backups":false,"ipv6":false,"monitoring":false,"tags":[]}},"operatingSystem":{"ubuntu":{"distUpgradeOnBoot":false}},"versions":{"kubelet":"9.6.0"}}}}`,
+			ExpectedResponse: `{"error":{"code":400,"message":"node deployment validation failed: kubelet version 9.6.0 is not compatible with control plane version 9.9.9"}}`,
+			HTTPStatus:       http.StatusBadRequest,
+			ProjectID:        test.GenDefaultProject().Name,
+			ClusterID:        test.GenDefaultCluster().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenTestSeed(),
+				genTestCluster(true),
+			),
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", fmt.Sprintf("/api/v2/projects/%s/clusters/%s/machinedeployments/bootstrapconfig", tc.ProjectID, tc.ClusterID), strings.NewReader(tc.Body))
+			res := httptest.NewRecorder()
+			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, []ctrlruntimeclient.Object{}, tc.ExistingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+
+			test.CompareWithResult(t, res, tc.ExpectedResponse)
+		})
+	}
+}
+
 func TestDeleteMachineDeploymentNode(t *testing.T) {
 	t.Parallel()
 	testcases := []struct {
@@ -1394,6 +1458,134 @@ func TestMachineDeploymentMetrics(t *testing.T) {
 	}
 }
 
+func TestListMachineDeploymentsMetrics(t *testing.T) {
+	t.Parallel()
+
+	cpuQuantity, err := resource.ParseQuantity("290104582")
+	if err != nil {
+		t.Fatal(err)
+	}
+	memoryQuantity, err := resource.ParseQuantity("687202304")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cpuUsageQuantity, err := resource.ParseQuantity("145052291")
+	if err != nil {
+		t.Fatal(err)
+	}
+	memoryUsageQuantity, err := resource.ParseQuantity("171800576")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testcases := []struct {
+		Name                       string
+		ExpectedResponse           string
+		HTTPStatus                 int
+		ProjectIDToSync            string
+		ClusterIDToSync            string
+		ExistingAPIUser            *apiv1.User
+		ExistingNodes              []*corev1.Node
+		ExistingMachineDeployments []*clusterv1alpha1.MachineDeployment
+		ExistingMachines           []*clusterv1alpha1.Machine
+		ExistingKubermaticObjs     []ctrlruntimeclient.Object
+		ExistingMetrics            []*v1beta1.NodeMetrics
+	}{
+		// scenario 1
+		{
+			Name:            "scenario 1: list metrics of all machine deployments",
+			HTTPStatus:      http.StatusOK,
+			ClusterIDToSync: test.GenDefaultCluster().Name,
+			ProjectIDToSync: test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenTestSeed(),
+				test.GenDefaultCluster(),
+			),
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+			ExistingNodes: []*corev1.Node{
+				{ObjectMeta: metav1.ObjectMeta{Name: "venus-1"}, Status: corev1.NodeStatus{Allocatable: map[corev1.ResourceName]resource.Quantity{"cpu": cpuQuantity, "memory": memoryQuantity}}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "venus-2"}, Status: corev1.NodeStatus{Allocatable: map[corev1.ResourceName]resource.Quantity{"cpu": cpuQuantity, "memory": memoryQuantity}}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "mars-1"}, Status: corev1.NodeStatus{Allocatable: map[corev1.ResourceName]resource.Quantity{"cpu": cpuQuantity, "memory": memoryQuantity}}},
+			},
+			ExistingMachineDeployments: []*clusterv1alpha1.MachineDeployment{
+				genTestMachineDeployment("venus", `{"cloudProvider":"digitalocean","cloudProviderSpec":{"token":"dummy-token","region":"fra1","size":"2GB"}, "operatingSystem":"ubuntu", "operatingSystemSpec":{"distUpgradeOnBoot":true}}`, map[string]string{"md-id": "123"}, false),
+				genTestMachineDeployment("mars", `{"cloudProvider":"digitalocean","cloudProviderSpec":{"token":"dummy-token","region":"fra1","size":"2GB"}, "operatingSystem":"ubuntu", "operatingSystemSpec":{"distUpgradeOnBoot":true}}`, map[string]string{"md-id": "456"}, false),
+			},
+			ExistingMachines: []*clusterv1alpha1.Machine{
+				genTestMachine("venus-1", `{"cloudProvider":"digitalocean","cloudProviderSpec":{"token":"dummy-token","region":"fra1","size":"2GB"},"operatingSystem":"ubuntu","containerRuntimeInfo":{"name":"docker","version":"1.13"},"operatingSystemSpec":{"distUpgradeOnBoot":true}}`, map[string]string{"md-id": "123", "some-other": "xyz"}, nil),
+				genTestMachine("venus-2", `{"cloudProvider":"digitalocean","cloudProviderSpec":{"token":"dummy-token","region":"fra1","size":"2GB"},"operatingSystem":"ubuntu","containerRuntimeInfo":{"name":"docker","version":"1.13"},"operatingSystemSpec":{"distUpgradeOnBoot":true}}`, map[string]string{"md-id": "123", "xyz": "abc"}, nil),
+				genTestMachine("mars-1", `{"cloudProvider":"digitalocean","cloudProviderSpec":{"token":"dummy-token","region":"fra1","size":"2GB"},"operatingSystem":"ubuntu","containerRuntimeInfo":{"name":"docker","version":"1.13"},"operatingSystemSpec":{"distUpgradeOnBoot":true}}`, map[string]string{"md-id": "456"}, nil),
+			},
+			ExistingMetrics: []*v1beta1.NodeMetrics{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "venus-1"},
+					Usage:      map[corev1.ResourceName]resource.Quantity{"cpu": cpuQuantity, "memory": memoryQuantity},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "venus-2"},
+					Usage:      map[corev1.ResourceName]resource.Quantity{"cpu": cpuUsageQuantity, "memory": memoryUsageQuantity},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "mars-1"},
+					Usage:      map[corev1.ResourceName]resource.Quantity{"cpu": cpuUsageQuantity, "memory": memoryUsageQuantity},
+				},
+			},
+			ExpectedResponse: `[{"name":"mars","utilization":{"memoryTotalBytes":163,"memoryAvailableBytes":655,"memoryUsedPercentage":25,"cpuTotalMillicores":145052291000,"cpuAvailableMillicores":290104582000,"cpuUsedPercentage":50},"nodes":[{"name":"mars-1","memoryTotalBytes":163,"memoryAvailableBytes":655,"memoryUsedPercentage":25,"cpuTotalMillicores":145052291000,"cpuAvailableMillicores":290104582000,"cpuUsedPercentage":50}]},{"name":"venus","utilization":{"memoryTotalBytes":819,"memoryAvailableBytes":1310,"memoryUsedPercentage":62,"cpuTotalMillicores":435156873000,"cpuAvailableMillicores":580209164000,"cpuUsedPercentage":75},"nodes":[{"name":"venus-1","memoryTotalBytes":655,"memoryAvailableBytes":655,"memoryUsedPercentage":100,"cpuTotalMillicores":290104582000,"cpuAvailableMillicores":290104582000,"cpuUsedPercentage":100},{"name":"venus-2","memoryTotalBytes":163,"memoryAvailableBytes":655,"memoryUsedPercentage":25,"cpuTotalMillicores":145052291000,"cpuAvailableMillicores":290104582000,"cpuUsedPercentage":50}]}]`,
+		},
+		// scenario 2
+		{
+			Name:            "scenario 2: the user John can not get Bob's metrics",
+			HTTPStatus:      http.StatusForbidden,
+			ClusterIDToSync: test.GenDefaultCluster().Name,
+			ProjectIDToSync: test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenTestSeed(),
+				test.GenDefaultCluster(),
+				test.GenAdminUser("John", "john@acme.com", false),
+			),
+			ExistingAPIUser: test.GenAPIUser("John", "john@acme.com"),
+			ExistingMachineDeployments: []*clusterv1alpha1.MachineDeployment{
+				genTestMachineDeployment("venus", `{"cloudProvider":"digitalocean","cloudProviderSpec":{"token":"dummy-token","region":"fra1","size":"2GB"}, "operatingSystem":"ubuntu", "operatingSystemSpec":{"distUpgradeOnBoot":true}}`, map[string]string{"md-id": "123"}, false),
+			},
+			ExpectedResponse: `{"error":{"code":403,"message":"forbidden: \"john@acme.com\" doesn't belong to the given project = my-first-project-ID"}}`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/v2/projects/%s/clusters/%s/machinedeployments/metrics", tc.ProjectIDToSync, tc.ClusterIDToSync), strings.NewReader(""))
+			res := httptest.NewRecorder()
+			kubermaticObj := []ctrlruntimeclient.Object{}
+			machineObj := []ctrlruntimeclient.Object{}
+			kubernetesObj := []ctrlruntimeclient.Object{}
+			for _, existingNode := range tc.ExistingNodes {
+				kubernetesObj = append(kubernetesObj, existingNode)
+			}
+			for _, existingMetric := range tc.ExistingMetrics {
+				machineObj = append(machineObj, existingMetric)
+			}
+			for _, existingMachineDeployment := range tc.ExistingMachineDeployments {
+				machineObj = append(machineObj, existingMachineDeployment)
+			}
+			for _, existingMachine := range tc.ExistingMachines {
+				machineObj = append(machineObj, existingMachine)
+			}
+			kubermaticObj = append(kubermaticObj, tc.ExistingKubermaticObjs...)
+			ep, _, err := test.CreateTestEndpointAndGetClients(*tc.ExistingAPIUser, nil, kubernetesObj, machineObj, kubermaticObj, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+			test.CompareWithResult(t, res, tc.ExpectedResponse)
+		})
+	}
+}
+
 func TestPatchMachineDeployment(t *testing.T) {
 	t.Parallel()
 
@@ -1527,6 +1719,102 @@ func TestPatchMachineDeployment(t *testing.T) {
 				test.GenAdminUser("John", "john@acme.com", false),
 			),
 		},
+		// Scenario 8: Scaling up beyond the node limit of the datacenter.
+		{
+			Name:                       "Scenario 8: Scaling up beyond the node limit of the datacenter is forbidden",
+			Body:                       fmt.Sprintf(`{"spec":{"replicas":%v}}`, replicasUpdated),
+			ExpectedResponse:           `{"error":{"code":403,"message":"datacenter \"regular-do1\" is limited to 2 nodes, this change would result in 3"}}`,
+			cluster:                    "keen-snyder",
+			HTTPStatus:                 http.StatusForbidden,
+			project:                    test.GenDefaultProject().Name,
+			ExistingAPIUser:            test.GenDefaultAPIUser(),
+			NodeDeploymentID:           "venus",
+			ExistingMachineDeployments: []*clusterv1alpha1.MachineDeployment{genTestMachineDeployment("venus", `{"cloudProvider":"digitalocean","cloudProviderSpec":{"token":"dummy-token","region":"fra1","size":"2GB"}, "operatingSystem":"ubuntu", "operatingSystemSpec":{"distUpgradeOnBoot":true}}`, nil, false)},
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenTestSeed(withNodeLimit(2)),
+				genTestCluster(true),
+			),
+		},
+		// Scenario 9: Scaling up within the node limit of the datacenter.
+		{
+			Name:                       "Scenario 9: Scaling up within the node limit of the datacenter",
+			Body:                       fmt.Sprintf(`{"spec":{"replicas":%v}}`, replicasUpdated),
+			ExpectedResponse:           fmt.Sprintf(`{"id":"venus","name":"venus","creationTimestamp":"0001-01-01T00:00:00Z","spec":{"replicas":%v,"template":{"cloud":{"digitalocean":{"size":"2GB","backups":false,"ipv6":false,"monitoring":false,"tags":["kubernetes","kubernetes-cluster-defClusterID","system-cluster-defClusterID","system-project-my-first-project-ID"]}},"operatingSystem":{"ubuntu":{"distUpgradeOnBoot":true}},"versions":{"kubelet":"v9.9.9"},"labels":{"system/cluster":"defClusterID","system/project":"my-first-project-ID"}},"paused":false,"dynamicConfig":false},"status":{}}`, replicasUpdated),
+			cluster:                    "keen-snyder",
+			HTTPStatus:                 http.StatusOK,
+			project:                    test.GenDefaultProject().Name,
+			ExistingAPIUser:            test.GenDefaultAPIUser(),
+			NodeDeploymentID:           "venus",
+			ExistingMachineDeployments: []*clusterv1alpha1.MachineDeployment{genTestMachineDeployment("venus", `{"cloudProvider":"digitalocean","cloudProviderSpec":{"token":"dummy-token","region":"fra1","size":"2GB"}, "operatingSystem":"ubuntu", "operatingSystemSpec":{"distUpgradeOnBoot":true}}`, nil, false)},
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenTestSeed(withNodeLimit(3)),
+				genTestCluster(true),
+			),
+		},
+		// Scenario 10: Freeze the replicas.
+		{
+			Name:                       "Scenario 10: Freeze the replicas",
+			Body:                       `{"spec":{"replicasFrozen":true}}`,
+			ExpectedResponse:           `{"id":"venus","name":"venus","creationTimestamp":"0001-01-01T00:00:00Z","spec":{"replicas":1,"template":{"cloud":{"digitalocean":{"size":"2GB","backups":false,"ipv6":false,"monitoring":false,"tags":["kubernetes","kubernetes-cluster-defClusterID","system-cluster-defClusterID","system-project-my-first-project-ID"]}},"operatingSystem":{"ubuntu":{"distUpgradeOnBoot":true}},"versions":{"kubelet":"v9.9.9"},"labels":{"system/cluster":"defClusterID","system/project":"my-first-project-ID"}},"paused":false,"dynamicConfig":false,"replicasFrozen":true},"status":{}}`,
+			cluster:                    "keen-snyder",
+			HTTPStatus:                 http.StatusOK,
+			project:                    test.GenDefaultProject().Name,
+			ExistingAPIUser:            test.GenDefaultAPIUser(),
+			NodeDeploymentID:           "venus",
+			ExistingMachineDeployments: []*clusterv1alpha1.MachineDeployment{genTestMachineDeployment("venus", `{"cloudProvider":"digitalocean","cloudProviderSpec":{"token":"dummy-token","region":"fra1","size":"2GB"}, "operatingSystem":"ubuntu", "operatingSystemSpec":{"distUpgradeOnBoot":true}}`, nil, false)},
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenTestSeed(),
+				genTestCluster(true),
+			),
+		},
+		// Scenario 11: Scaling a frozen machine deployment.
+		{
+			Name:                       "Scenario 11: Scaling a frozen machine deployment is rejected",
+			Body:                       fmt.Sprintf(`{"spec":{"replicas":%v}}`, replicasUpdated),
+			ExpectedResponse:           `{"error":{"code":400,"message":"the replicas of the node deployment are frozen at 1, unfreeze it to scale it"}}`,
+			cluster:                    "keen-snyder",
+			HTTPStatus:                 http.StatusBadRequest,
+			project:                    test.GenDefaultProject().Name,
+			ExistingAPIUser:            test.GenDefaultAPIUser(),
+			NodeDeploymentID:           "venus",
+			ExistingMachineDeployments: []*clusterv1alpha1.MachineDeployment{genFrozenMachineDeployment("venus", `{"cloudProvider":"digitalocean","cloudProviderSpec":{"token":"dummy-token","region":"fra1","size":"2GB"}, "operatingSystem":"ubuntu", "operatingSystemSpec":{"distUpgradeOnBoot":true}}`)},
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenTestSeed(),
+				genTestCluster(true),
+			),
+		},
+		// Scenario 12: Unfreeze and scale a machine deployment.
+		{
+			Name:                       "Scenario 12: Unfreeze and scale a machine deployment",
+			Body:                       fmt.Sprintf(`{"spec":{"replicas":%v,"replicasFrozen":false}}`, replicasUpdated),
+			ExpectedResponse:           fmt.Sprintf(`{"id":"venus","name":"venus","creationTimestamp":"0001-01-01T00:00:00Z","spec":{"replicas":%v,"template":{"cloud":{"digitalocean":{"size":"2GB","backups":false,"ipv6":false,"monitoring":false,"tags":["kubernetes","kubernetes-cluster-defClusterID","system-cluster-defClusterID","system-project-my-first-project-ID"]}},"operatingSystem":{"ubuntu":{"distUpgradeOnBoot":true}},"versions":{"kubelet":"v9.9.9"},"labels":{"system/cluster":"defClusterID","system/project":"my-first-project-ID"}},"paused":false,"dynamicConfig":false},"status":{}}`, replicasUpdated),
+			cluster:                    "keen-snyder",
+			HTTPStatus:                 http.StatusOK,
+			project:                    test.GenDefaultProject().Name,
+			ExistingAPIUser:            test.GenDefaultAPIUser(),
+			NodeDeploymentID:           "venus",
+			ExistingMachineDeployments: []*clusterv1alpha1.MachineDeployment{genFrozenMachineDeployment("venus", `{"cloudProvider":"digitalocean","cloudProviderSpec":{"token":"dummy-token","region":"fra1","size":"2GB"}, "operatingSystem":"ubuntu", "operatingSystemSpec":{"distUpgradeOnBoot":true}}`)},
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenTestSeed(),
+				genTestCluster(true),
+			),
+		},
+		// Scenario 13: Scaling up beyond the number of nodes the pod CIDR can be split into.
+		{
+			Name:                       "Scenario 13: Scaling up beyond the node limit of the pod CIDR is forbidden",
+			Body:                       fmt.Sprintf(`{"spec":{"replicas":%v}}`, replicasUpdated),
+			ExpectedResponse:           `{"error":{"code":403,"message":"the pod CIDR 172.25.0.0/23 of the cluster with a node CIDR mask size of 24 is limited to 2 nodes, this change would result in 3"}}`,
+			cluster:                    "keen-snyder",
+			HTTPStatus:                 http.StatusForbidden,
+			project:                    test.GenDefaultProject().Name,
+			ExistingAPIUser:            test.GenDefaultAPIUser(),
+			NodeDeploymentID:           "venus",
+			ExistingMachineDeployments: []*clusterv1alpha1.MachineDeployment{genTestMachineDeployment("venus", `{"cloudProvider":"digitalocean","cloudProviderSpec":{"token":"dummy-token","region":"fra1","size":"2GB"}, "operatingSystem":"ubuntu", "operatingSystemSpec":{"distUpgradeOnBoot":true}}`, nil, false)},
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenTestSeed(),
+				withPodCIDR(genTestCluster(true), "172.25.0.0/23"),
+			),
+		},
 	}
 
 	for _, tc := range testcases {
@@ -1902,3 +2190,22 @@ func genTestMachine(name, rawProviderSpec string, labels map[string]string, owne
 func genTestMachineDeployment(name, rawProviderSpec string, selector map[string]string, dynamicConfig bool) *clusterv1alpha1.MachineDeployment {
 	return test.GenTestMachineDeployment(name, rawProviderSpec, selector, dynamicConfig)
 }
+
+func genFrozenMachineDeployment(name, rawProviderSpec string) *clusterv1alpha1.MachineDeployment {
+	md := genTestMachineDeployment(name, rawProviderSpec, nil, false)
+	machineconversions.FreezeReplicas(md)
+	return md
+}
+
+func withPodCIDR(cluster *kubermaticv1.Cluster, cidr string) *kubermaticv1.Cluster {
+	cluster.Spec.ClusterNetwork.Pods.CIDRBlocks = []string{cidr}
+	return cluster
+}
+
+func withNodeLimit(maxNodes int) func(seed *kubermaticv1.Seed) {
+	return func(seed *kubermaticv1.Seed) {
+		dc := seed.Spec.Datacenters["regular-do1"]
+		dc.Spec.CapacityLimits = &kubermaticv1.DatacenterCapacityLimits{MaxNodes: maxNodes}
+		seed.Spec.Datacenters["regular-do1"] = dc
+	}
+}
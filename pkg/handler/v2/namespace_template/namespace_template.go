@@ -0,0 +1,204 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namespacetemplate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/gorilla/mux"
+
+	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
+	"k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/rbac"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/util/errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// projectGroups are the groups of project members in user clusters, which templates can bind roles to.
+var projectGroups = sets.NewString(rbac.OwnerGroupNamePrefix, rbac.EditorGroupNamePrefix, rbac.ViewerGroupNamePrefix)
+
+func CreateEndpoint(userInfoGetter provider.UserInfoGetter, namespaceTemplateProvider provider.PrivilegedNamespaceTemplateProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(createNamespaceTemplateReq)
+
+		if err := verifyAdmin(ctx, userInfoGetter); err != nil {
+			return nil, err
+		}
+
+		if err := validateSpec(req.Body.Spec); err != nil {
+			return nil, errors.NewBadRequest(err.Error())
+		}
+
+		template := &kubermaticv1.NamespaceTemplate{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: req.Body.Name,
+			},
+			Spec: req.Body.Spec,
+		}
+
+		template, err := namespaceTemplateProvider.CreateUnsecured(template)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		return convertInternalNamespaceTemplateToExternal(template), nil
+	}
+}
+
+// createNamespaceTemplateReq represents a request for creating a namespace template
+// swagger:parameters createNamespaceTemplate
+type createNamespaceTemplateReq struct {
+	// in: body
+	Body namespaceTemplateBody
+}
+
+type namespaceTemplateBody struct {
+	// Name of the namespace template
+	Name string `json:"name"`
+	// Spec of the namespace template
+	Spec kubermaticv1.NamespaceTemplateSpec `json:"spec"`
+}
+
+func DecodeCreateNamespaceTemplateRequest(c context.Context, r *http.Request) (interface{}, error) {
+	var req createNamespaceTemplateReq
+
+	if err := json.NewDecoder(r.Body).Decode(&req.Body); err != nil {
+		return nil, errors.NewBadRequest("unable to parse the namespace template: %v", err)
+	}
+
+	return req, nil
+}
+
+func GetEndpoint(namespaceTemplateProvider provider.PrivilegedNamespaceTemplateProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(getNamespaceTemplateReq)
+
+		template, err := namespaceTemplateProvider.GetUnsecured(req.NamespaceTemplateName)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		return convertInternalNamespaceTemplateToExternal(template), nil
+	}
+}
+
+// getNamespaceTemplateReq represents a request for getting a namespace template
+// swagger:parameters getNamespaceTemplate deleteNamespaceTemplate
+type getNamespaceTemplateReq struct {
+	// in: path
+	// required: true
+	NamespaceTemplateName string `json:"namespace_template"`
+}
+
+func DecodeGetNamespaceTemplateRequest(c context.Context, r *http.Request) (interface{}, error) {
+	var req getNamespaceTemplateReq
+
+	name := mux.Vars(r)["namespace_template"]
+	if name == "" {
+		return "", fmt.Errorf("'namespace_template' parameter is required but was not provided")
+	}
+	req.NamespaceTemplateName = name
+
+	return req, nil
+}
+
+// ListEndpoint lists the namespace templates, they are visible to all users so they can
+// pick one when creating a namespace.
+func ListEndpoint(namespaceTemplateProvider provider.PrivilegedNamespaceTemplateProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		templates, err := namespaceTemplateProvider.ListUnsecured()
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		result := make([]*apiv2.NamespaceTemplate, 0, len(templates.Items))
+		for i := range templates.Items {
+			result = append(result, convertInternalNamespaceTemplateToExternal(&templates.Items[i]))
+		}
+
+		return result, nil
+	}
+}
+
+func DeleteEndpoint(userInfoGetter provider.UserInfoGetter, namespaceTemplateProvider provider.PrivilegedNamespaceTemplateProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(getNamespaceTemplateReq)
+
+		if err := verifyAdmin(ctx, userInfoGetter); err != nil {
+			return nil, err
+		}
+
+		if err := namespaceTemplateProvider.DeleteUnsecured(req.NamespaceTemplateName); err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		return nil, nil
+	}
+}
+
+func verifyAdmin(ctx context.Context, userInfoGetter provider.UserInfoGetter) error {
+	adminUserInfo, err := userInfoGetter(ctx, "")
+	if err != nil {
+		return common.KubernetesErrorToHTTPError(err)
+	}
+	if !adminUserInfo.IsAdmin {
+		return errors.New(http.StatusForbidden,
+			fmt.Sprintf("forbidden: \"%s\" doesn't have admin rights", adminUserInfo.Email))
+	}
+	return nil
+}
+
+func validateSpec(spec kubermaticv1.NamespaceTemplateSpec) error {
+	names := sets.NewString()
+	for _, binding := range spec.RoleBindings {
+		if binding.Name == "" {
+			return fmt.Errorf("the role binding name is required")
+		}
+		if names.Has(binding.Name) {
+			return fmt.Errorf("the role binding name %q is used more than once", binding.Name)
+		}
+		names.Insert(binding.Name)
+
+		if binding.ClusterRole == "" {
+			return fmt.Errorf("the cluster role of role binding %q is required", binding.Name)
+		}
+		if len(binding.Groups) == 0 {
+			return fmt.Errorf("role binding %q must bind at least one group", binding.Name)
+		}
+		for _, group := range binding.Groups {
+			if !projectGroups.Has(group) {
+				return fmt.Errorf("role binding %q binds the unknown group %q, supported groups are %v", binding.Name, group, projectGroups.List())
+			}
+		}
+	}
+	return nil
+}
+
+func convertInternalNamespaceTemplateToExternal(template *kubermaticv1.NamespaceTemplate) *apiv2.NamespaceTemplate {
+	return &apiv2.NamespaceTemplate{
+		Name: template.Name,
+		Spec: template.Spec,
+	}
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namespacetemplate_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/handler/test"
+	"k8c.io/kubermatic/v2/pkg/handler/test/hack"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestCreateNamespaceTemplate(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name             string
+		Body             string
+		ExpectedResponse string
+		HTTPStatus       int
+		ExistingAPIUser  *apiv1.User
+	}{
+		{
+			Name:             "scenario 1: admin can create a namespace template",
+			Body:             `{"name":"small","spec":{"description":"small team","resourceQuota":{"hard":{"limits.cpu":"4"}},"roleBindings":[{"name":"editors","clusterRole":"edit","groups":["owners","editors"]}]}}`,
+			ExpectedResponse: `{"name":"small","spec":{"description":"small team","resourceQuota":{"hard":{"limits.cpu":"4"}},"roleBindings":[{"name":"editors","clusterRole":"edit","groups":["owners","editors"]}]}}`,
+			HTTPStatus:       http.StatusCreated,
+			ExistingAPIUser:  test.GenDefaultAdminAPIUser(),
+		},
+		{
+			Name:             "scenario 2: role bindings can only bind project groups",
+			Body:             `{"name":"small","spec":{"roleBindings":[{"name":"admins","clusterRole":"admin","groups":["system:masters"]}]}}`,
+			ExpectedResponse: `{"error":{"code":400,"message":"role binding \"admins\" binds the unknown group \"system:masters\", supported groups are [editors owners viewers]"}}`,
+			HTTPStatus:       http.StatusBadRequest,
+			ExistingAPIUser:  test.GenDefaultAdminAPIUser(),
+		},
+		{
+			Name:             "scenario 3: non-admin can not create a namespace template",
+			Body:             `{"name":"small","spec":{}}`,
+			ExpectedResponse: `{"error":{"code":403,"message":"forbidden: \"bob@acme.com\" doesn't have admin rights"}}`,
+			HTTPStatus:       http.StatusForbidden,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/api/v2/namespacetemplates", strings.NewReader(tc.Body))
+			res := httptest.NewRecorder()
+			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, nil, []ctrlruntimeclient.Object{test.APIUserToKubermaticUser(*tc.ExistingAPIUser)}, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+
+			test.CompareWithResult(t, res, tc.ExpectedResponse)
+		})
+	}
+}
+
+func TestListNamespaceTemplates(t *testing.T) {
+	t.Parallel()
+
+	existingObjects := []ctrlruntimeclient.Object{genNamespaceTemplate(), test.APIUserToKubermaticUser(*test.GenDefaultAPIUser())}
+
+	req := httptest.NewRequest("GET", "/api/v2/namespacetemplates", nil)
+	res := httptest.NewRecorder()
+	ep, err := test.CreateTestEndpoint(*test.GenDefaultAPIUser(), nil, existingObjects, nil, nil, hack.NewTestRouting)
+	if err != nil {
+		t.Fatalf("failed to create test endpoint due to %v", err)
+	}
+
+	ep.ServeHTTP(res, req)
+
+	// all users can see the templates to pick one for their namespaces
+	if res.Code != http.StatusOK {
+		t.Fatalf("Expected HTTP status code %d, got %d: %s", http.StatusOK, res.Code, res.Body.String())
+	}
+	test.CompareWithResult(t, res, `[{"name":"small","spec":{"resourceQuota":{"hard":{"limits.cpu":"4"}}}}]`)
+}
+
+func TestDeleteNamespaceTemplate(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name             string
+		TemplateName     string
+		ExpectedResponse string
+		HTTPStatus       int
+		ExistingAPIUser  *apiv1.User
+	}{
+		{
+			Name:             "scenario 1: admin can delete a namespace template",
+			TemplateName:     "small",
+			ExpectedResponse: `{}`,
+			HTTPStatus:       http.StatusOK,
+			ExistingAPIUser:  test.GenDefaultAdminAPIUser(),
+		},
+		{
+			Name:             "scenario 2: non-admin can not delete a namespace template",
+			TemplateName:     "small",
+			ExpectedResponse: `{"error":{"code":403,"message":"forbidden: \"bob@acme.com\" doesn't have admin rights"}}`,
+			HTTPStatus:       http.StatusForbidden,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			existingObjects := []ctrlruntimeclient.Object{genNamespaceTemplate(), test.APIUserToKubermaticUser(*tc.ExistingAPIUser)}
+
+			req := httptest.NewRequest("DELETE", "/api/v2/namespacetemplates/"+tc.TemplateName, nil)
+			res := httptest.NewRecorder()
+			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, nil, existingObjects, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+
+			test.CompareWithResult(t, res, tc.ExpectedResponse)
+		})
+	}
+}
+
+func genNamespaceTemplate() *kubermaticv1.NamespaceTemplate {
+	return &kubermaticv1.NamespaceTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "small",
+		},
+		Spec: kubermaticv1.NamespaceTemplateSpec{
+			ResourceQuota: &corev1.ResourceQuotaSpec{
+				Hard: corev1.ResourceList{corev1.ResourceLimitsCPU: resource.MustParse("4")},
+			},
+		},
+	}
+}
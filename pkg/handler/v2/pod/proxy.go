@@ -0,0 +1,215 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"strings"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	transporthttp "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+
+	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
+	"k8c.io/kubermatic/v2/pkg/handler/v2/cluster"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	kubermaticerrors "k8c.io/kubermatic/v2/pkg/util/errors"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+	restclient "k8s.io/client-go/rest"
+)
+
+const (
+	// LogSubresource is the pod subresource streaming the container logs
+	LogSubresource = "log"
+	// ExecSubresource is the pod subresource executing a command in a container
+	ExecSubresource = "exec"
+)
+
+// Minimal wrapper to implement the http.Handler interface
+type dynamicHTTPHandler func(http.ResponseWriter, *http.Request)
+
+// ServeHTTP implements http.Handler
+func (dHandler dynamicHTTPHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	dHandler(w, r)
+}
+
+// ProxyEndpoint proxies the given pod subresource of the user cluster. The request is sent
+// to the API server of the user cluster impersonating the KKP user, hence the RBAC rules
+// inside the user cluster decide whether the user is allowed to read the logs or exec into
+// the pod. Upgrade requests (SPDY and websockets) are passed through, so the endpoint can be
+// used by kubectl compatible clients as well as the dashboard terminal.
+func ProxyEndpoint(
+	log *zap.SugaredLogger,
+	extractor transporthttp.RequestFunc,
+	projectProvider provider.ProjectProvider,
+	privilegedProjectProvider provider.PrivilegedProjectProvider,
+	userInfoGetter provider.UserInfoGetter,
+	subresource string,
+	middlewares endpoint.Middleware) http.Handler {
+	return dynamicHTTPHandler(func(w http.ResponseWriter, r *http.Request) {
+		log := log.With("endpoint", "pod-"+subresource+"-proxy", "uri", r.URL.Path)
+		ctx := extractor(r.Context(), r)
+
+		request, err := cluster.DecodeGetClusterReq(ctx, r)
+		if err != nil {
+			common.WriteHTTPError(log, w, kubermaticerrors.New(http.StatusBadRequest, err.Error()))
+			return
+		}
+
+		namespace, podName, err := decodePod(r)
+		if err != nil {
+			common.WriteHTTPError(log, w, kubermaticerrors.New(http.StatusBadRequest, err.Error()))
+			return
+		}
+
+		// The endpoint the middleware is called with is the innermost one, hence we must
+		// define it as closure and pass it to the middleware() call below.
+		ep := func(ctx context.Context, request interface{}) (interface{}, error) {
+			userCluster, clusterProvider, err := cluster.GetClusterProviderFromRequest(ctx, request, projectProvider, privilegedProjectProvider, userInfoGetter)
+			if err != nil {
+				common.WriteHTTPError(log, w, err)
+				return nil, nil
+			}
+			req, ok := request.(cluster.GetClusterReq)
+			if !ok {
+				common.WriteHTTPError(log, w, kubermaticerrors.New(http.StatusBadRequest, "invalid request"))
+				return nil, nil
+			}
+			userInfo, err := userInfoGetter(ctx, req.ProjectID)
+			if err != nil {
+				common.WriteHTTPError(log, w, kubermaticerrors.New(http.StatusInternalServerError, "couldn't get userInfo"))
+				return nil, nil
+			}
+
+			cfg, err := clusterProvider.GetClientConfigForCustomerCluster(ctx, userInfo, userCluster)
+			if err != nil {
+				common.WriteHTTPError(log, w, kubermaticerrors.New(http.StatusInternalServerError, fmt.Sprintf("failed to get client config for user %q: %v", userInfo.Email, err)))
+				return nil, nil
+			}
+
+			proxy, err := newPodProxy(log, cfg, namespace, podName, subresource)
+			if err != nil {
+				common.WriteHTTPError(log, w, kubermaticerrors.New(http.StatusInternalServerError, err.Error()))
+				return nil, nil
+			}
+
+			// Every request is recorded, so operators can reconstruct who looked into which workload
+			auditLog := log.With(
+				"audit", true,
+				"user", userInfo.Email,
+				"group", userInfo.Group,
+				"project", req.ProjectID,
+				"cluster", userCluster.Name,
+				"namespace", namespace,
+				"pod", podName,
+				"subresource", subresource,
+				"container", r.URL.Query().Get("container"),
+			)
+			if subresource == ExecSubresource {
+				auditLog = auditLog.With("command", r.URL.Query()["command"], "tty", r.URL.Query().Get("tty"))
+			}
+
+			start := time.Now()
+			auditLog.Info("Proxying pod request to user cluster")
+			proxy.ServeHTTP(w, r)
+			auditLog.Infow("Finished proxying pod request to user cluster", "duration", time.Since(start).String())
+
+			return nil, nil
+		}
+
+		if _, err := middlewares(ep)(ctx, request); err != nil {
+			common.WriteHTTPError(log, w, err)
+			return
+		}
+	})
+}
+
+func decodePod(r *http.Request) (string, string, error) {
+	namespace := mux.Vars(r)["namespace"]
+	if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+		return "", "", fmt.Errorf("invalid namespace %q: %s", namespace, strings.Join(errs, ", "))
+	}
+
+	podName := mux.Vars(r)["pod"]
+	if errs := validation.IsDNS1123Subdomain(podName); len(errs) > 0 {
+		return "", "", fmt.Errorf("invalid pod name %q: %s", podName, strings.Join(errs, ", "))
+	}
+
+	return namespace, podName, nil
+}
+
+// newPodProxy returns a reverse proxy sending requests to the given pod subresource using the
+// credentials and impersonation settings of cfg. The query of the original request (container,
+// command, follow, tailLines...) is passed to the API server as is.
+func newPodProxy(log *zap.SugaredLogger, cfg *restclient.Config, namespace, podName, subresource string) (*httputil.ReverseProxy, error) {
+	host, _, err := restclient.DefaultServerURL(cfg.Host, "", schema.GroupVersion{}, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse API server URL: %v", err)
+	}
+
+	tlsConfig, err := restclient.TLSConfigFor(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build TLS config: %v", err)
+	}
+
+	// HTTP/2 is not enabled on purpose, it doesn't support the connection upgrades required for exec
+	transport := &http.Transport{
+		Proxy:               http.ProxyFromEnvironment,
+		TLSClientConfig:     tlsConfig,
+		TLSHandshakeTimeout: 10 * time.Second,
+	}
+
+	roundTripper, err := restclient.HTTPWrappersForConfig(cfg, transport)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build transport: %v", err)
+	}
+
+	path := fmt.Sprintf("/api/v1/namespaces/%s/pods/%s/%s", namespace, podName, subresource)
+
+	return &httputil.ReverseProxy{
+		Director: func(req *http.Request) {
+			req.URL.Scheme = host.Scheme
+			req.URL.Host = host.Host
+			req.URL.Path = strings.TrimSuffix(host.Path, "/") + path
+			req.URL.RawPath = ""
+			req.Host = host.Host
+
+			// The credentials of the KKP user must never reach the user cluster, the round tripper
+			// authenticates with the cluster credentials and impersonates the user instead.
+			req.Header.Del("Authorization")
+			req.Header.Del("Cookie")
+			for name := range req.Header {
+				if strings.HasPrefix(strings.ToLower(name), "impersonate-") {
+					req.Header.Del(name)
+				}
+			}
+		},
+		Transport: roundTripper,
+		// Stream the logs to the client as soon as they arrive
+		FlushInterval: -1,
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			common.WriteHTTPError(log, w, kubermaticerrors.New(http.StatusBadGateway, fmt.Sprintf("failed to proxy request to the user cluster: %v", err)))
+		},
+	}, nil
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+
+	restclient "k8s.io/client-go/rest"
+)
+
+func TestPodProxy(t *testing.T) {
+	var received *http.Request
+	apiServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		_, _ = w.Write([]byte("log line"))
+	}))
+	defer apiServer.Close()
+
+	cfg := &restclient.Config{
+		Host:            apiServer.URL,
+		BearerToken:     "cluster-admin-token",
+		TLSClientConfig: restclient.TLSClientConfig{Insecure: true},
+		Impersonate: restclient.ImpersonationConfig{
+			UserName: "bob@acme.com",
+			Groups:   []string{"editors", "system:authenticated"},
+		},
+	}
+
+	proxy, err := newPodProxy(kubermaticlog.Logger, cfg, "default", "nginx", LogSubresource)
+	if err != nil {
+		t.Fatalf("failed to create proxy: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v2/projects/foo/clusters/bar/namespaces/default/pods/nginx/log?container=app&tailLines=10", nil)
+	req.Header.Set("Authorization", "Bearer kkp-user-token")
+	req.Header.Set("Impersonate-User", "system:admin")
+	res := httptest.NewRecorder()
+
+	proxy.ServeHTTP(res, req)
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		t.Fatalf("failed to read response: %v", err)
+	}
+	if res.Code != http.StatusOK || string(body) != "log line" {
+		t.Fatalf("unexpected response %d: %s", res.Code, body)
+	}
+	if received == nil {
+		t.Fatal("request did not reach the API server")
+	}
+
+	if received.URL.Path != "/api/v1/namespaces/default/pods/nginx/log" {
+		t.Errorf("expected request for the pod log subresource, got %q", received.URL.Path)
+	}
+	if query := received.URL.RawQuery; query != "container=app&tailLines=10" {
+		t.Errorf("expected the query to be passed through, got %q", query)
+	}
+	if auth := received.Header.Get("Authorization"); auth != "Bearer cluster-admin-token" {
+		t.Errorf("expected the cluster credentials to be used, got %q", auth)
+	}
+	if user := received.Header.Get("Impersonate-User"); user != "bob@acme.com" {
+		t.Errorf("expected to impersonate bob@acme.com, got %q", user)
+	}
+	if groups := received.Header.Values("Impersonate-Group"); len(groups) != 2 || groups[0] != "editors" || groups[1] != "system:authenticated" {
+		t.Errorf("expected to impersonate the groups of the user, got %v", groups)
+	}
+}
+
+func TestDecodePod(t *testing.T) {
+	testCases := []struct {
+		name      string
+		namespace string
+		pod       string
+		expectErr bool
+	}{
+		{
+			name:      "valid pod",
+			namespace: "kube-system",
+			pod:       "coredns-5d4dd4b4db-x2x7k",
+		},
+		{
+			name:      "invalid namespace",
+			namespace: "../secrets",
+			pod:       "nginx",
+			expectErr: true,
+		},
+		{
+			name:      "invalid pod name",
+			namespace: "default",
+			pod:       "nginx/exec",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/", nil), map[string]string{
+				"namespace": tc.namespace,
+				"pod":       tc.pod,
+			})
+
+			namespace, pod, err := decodePod(req)
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("expected error = %v, got %v", tc.expectErr, err)
+			}
+			if err == nil && (namespace != tc.namespace || pod != tc.pod) {
+				t.Errorf("expected %s/%s, got %s/%s", tc.namespace, tc.pod, namespace, pod)
+			}
+		})
+	}
+}
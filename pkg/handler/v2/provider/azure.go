@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/go-kit/kit/endpoint"
 
@@ -34,7 +35,7 @@ import (
 func AzureSizeWithClusterCredentialsEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter, userInfoGetter provider.UserInfoGetter, settingsProvider provider.SettingsProvider) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(azureSizeNoCredentialsReq)
-		return providercommon.AzureSizeWithClusterCredentialsEndpoint(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, seedsGetter, settingsProvider, req.ProjectID, req.ClusterID)
+		return providercommon.AzureSizeWithClusterCredentialsEndpoint(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, seedsGetter, settingsProvider, req.ProjectID, req.ClusterID, req.AzureSizeFilter)
 	}
 }
 
@@ -45,58 +46,69 @@ func AzureAvailabilityZonesWithClusterCredentialsEndpoint(projectProvider provid
 	}
 }
 
-func AzureSecurityGroupsEndpoint(presetsProvider provider.PresetProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+func AzureSecurityGroupsEndpoint(presetsProvider provider.PresetProvider, seedsGetter provider.SeedsGetter, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(azureSecurityGroupsReq)
-		credentials, err := getAzureCredentialsFromReq(ctx, req.azureCommonReq, userInfoGetter, presetsProvider)
+		credentials, err := getAzureCredentialsFromReq(ctx, req.azureCommonReq, userInfoGetter, presetsProvider, seedsGetter)
 		if err != nil {
 			return nil, err
 		}
-		return providercommon.AzureSecurityGroupEndpoint(ctx, credentials.subscriptionID, credentials.clientID, credentials.clientSecret, credentials.tenantID, req.Location, req.ResourceGroup)
+		return providercommon.AzureSecurityGroupEndpoint(ctx, credentials.subscriptionID, credentials.clientID, credentials.clientSecret, credentials.tenantID, credentials.environment, req.Location, req.ResourceGroup)
 	}
 }
 
-func AzureResourceGroupsEndpoint(presetsProvider provider.PresetProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+func AzureResourceGroupsEndpoint(presetsProvider provider.PresetProvider, seedsGetter provider.SeedsGetter, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(azureResourceGroupsReq)
-		credentials, err := getAzureCredentialsFromReq(ctx, req.azureCommonReq, userInfoGetter, presetsProvider)
+		credentials, err := getAzureCredentialsFromReq(ctx, req.azureCommonReq, userInfoGetter, presetsProvider, seedsGetter)
 		if err != nil {
 			return nil, err
 		}
-		return providercommon.AzureResourceGroupEndpoint(ctx, credentials.subscriptionID, credentials.clientID, credentials.clientSecret, credentials.tenantID, req.Location)
+		return providercommon.AzureResourceGroupEndpoint(ctx, credentials.subscriptionID, credentials.clientID, credentials.clientSecret, credentials.tenantID, credentials.environment, req.Location)
 	}
 }
 
-func AzureRouteTablesEndpoint(presetsProvider provider.PresetProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+func AzureRouteTablesEndpoint(presetsProvider provider.PresetProvider, seedsGetter provider.SeedsGetter, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(azureRouteTablesReq)
-		credentials, err := getAzureCredentialsFromReq(ctx, req.azureCommonReq, userInfoGetter, presetsProvider)
+		credentials, err := getAzureCredentialsFromReq(ctx, req.azureCommonReq, userInfoGetter, presetsProvider, seedsGetter)
 		if err != nil {
 			return nil, err
 		}
-		return providercommon.AzureRouteTableEndpoint(ctx, credentials.subscriptionID, credentials.clientID, credentials.clientSecret, credentials.tenantID, req.Location, req.ResourceGroup)
+		return providercommon.AzureRouteTableEndpoint(ctx, credentials.subscriptionID, credentials.clientID, credentials.clientSecret, credentials.tenantID, credentials.environment, req.Location, req.ResourceGroup)
 	}
 }
 
-func AzureVirtualNetworksEndpoint(presetsProvider provider.PresetProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+func AzureVirtualNetworksEndpoint(presetsProvider provider.PresetProvider, seedsGetter provider.SeedsGetter, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(azureVirtualNetworksReq)
-		credentials, err := getAzureCredentialsFromReq(ctx, req.azureCommonReq, userInfoGetter, presetsProvider)
+		credentials, err := getAzureCredentialsFromReq(ctx, req.azureCommonReq, userInfoGetter, presetsProvider, seedsGetter)
 		if err != nil {
 			return nil, err
 		}
-		return providercommon.AzureVnetEndpoint(ctx, credentials.subscriptionID, credentials.clientID, credentials.clientSecret, credentials.tenantID, req.Location, req.ResourceGroup)
+		return providercommon.AzureVnetEndpoint(ctx, credentials.subscriptionID, credentials.clientID, credentials.clientSecret, credentials.tenantID, credentials.environment, req.Location, req.ResourceGroup)
 	}
 }
 
-func AzureSubnetsEndpoint(presetsProvider provider.PresetProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+func AzureSubnetsEndpoint(presetsProvider provider.PresetProvider, seedsGetter provider.SeedsGetter, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(azureSubnetsReq)
-		credentials, err := getAzureCredentialsFromReq(ctx, req.azureCommonReq, userInfoGetter, presetsProvider)
+		credentials, err := getAzureCredentialsFromReq(ctx, req.azureCommonReq, userInfoGetter, presetsProvider, seedsGetter)
 		if err != nil {
 			return nil, err
 		}
-		return providercommon.AzureSubnetEndpoint(ctx, credentials.subscriptionID, credentials.clientID, credentials.clientSecret, credentials.tenantID, req.ResourceGroup, req.VirtualNetwork)
+		return providercommon.AzureSubnetEndpoint(ctx, credentials.subscriptionID, credentials.clientID, credentials.clientSecret, credentials.tenantID, credentials.environment, req.ResourceGroup, req.VirtualNetwork)
+	}
+}
+
+func AzureLocationsEndpoint(presetsProvider provider.PresetProvider, seedsGetter provider.SeedsGetter, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(azureLocationsReq)
+		credentials, err := getAzureCredentialsFromReq(ctx, req.azureCommonReq, userInfoGetter, presetsProvider, seedsGetter)
+		if err != nil {
+			return nil, err
+		}
+		return providercommon.AzureLocationEndpoint(ctx, credentials.subscriptionID, credentials.clientID, credentials.clientSecret, credentials.tenantID, credentials.environment, strings.Split(req.ResourceProviders, ","))
 	}
 }
 
@@ -105,9 +117,10 @@ type azureCredentials struct {
 	tenantID       string
 	clientID       string
 	clientSecret   string
+	environment    string
 }
 
-func getAzureCredentialsFromReq(ctx context.Context, req azureCommonReq, userInfoGetter provider.UserInfoGetter, presetsProvider provider.PresetProvider) (*azureCredentials, error) {
+func getAzureCredentialsFromReq(ctx context.Context, req azureCommonReq, userInfoGetter provider.UserInfoGetter, presetsProvider provider.PresetProvider, seedsGetter provider.SeedsGetter) (*azureCredentials, error) {
 	subscriptionID := req.SubscriptionID
 	clientID := req.ClientID
 	clientSecret := req.ClientSecret
@@ -131,11 +144,17 @@ func getAzureCredentialsFromReq(ctx context.Context, req azureCommonReq, userInf
 		}
 	}
 
+	environment, err := providercommon.AzureDatacenterEnvironment(userInfo, seedsGetter, req.DatacenterName)
+	if err != nil {
+		return nil, err
+	}
+
 	return &azureCredentials{
 		subscriptionID: subscriptionID,
 		tenantID:       tenantID,
 		clientID:       clientID,
 		clientSecret:   clientSecret,
+		environment:    environment,
 	}, nil
 }
 
@@ -144,6 +163,7 @@ func getAzureCredentialsFromReq(ctx context.Context, req azureCommonReq, userInf
 // swagger:parameters listAzureSizesNoCredentialsV2
 type azureSizeNoCredentialsReq struct {
 	cluster.GetClusterReq
+	providercommon.AzureSizeFilter
 }
 
 // GetSeedCluster returns the SeedCluster object
@@ -155,16 +175,32 @@ func (req azureSizeNoCredentialsReq) GetSeedCluster() apiv1.SeedCluster {
 
 func DecodeAzureSizesNoCredentialsReq(c context.Context, r *http.Request) (interface{}, error) {
 	var req azureSizeNoCredentialsReq
-	clusterID, err := common.DecodeClusterID(c, r)
+	cr, err := decodeAzureClusterReq(c, r)
+	if err != nil {
+		return nil, err
+	}
+
+	req.GetClusterReq = cr
+	req.AzureSizeFilter, err = providercommon.DecodeAzureSizeFilter(r)
 	if err != nil {
 		return nil, err
 	}
+	return req, nil
+}
+
+// decodeAzureClusterReq decodes the project and cluster of a request for Azure resources.
+func decodeAzureClusterReq(c context.Context, r *http.Request) (cluster.GetClusterReq, error) {
+	var req cluster.GetClusterReq
+	clusterID, err := common.DecodeClusterID(c, r)
+	if err != nil {
+		return req, err
+	}
 
 	req.ClusterID = clusterID
 
 	pr, err := common.DecodeProjectRequest(c, r)
 	if err != nil {
-		return nil, err
+		return req, err
 	}
 	req.ProjectReq = pr.(common.ProjectReq)
 	return req, nil
@@ -174,7 +210,7 @@ func DecodeAzureSizesNoCredentialsReq(c context.Context, r *http.Request) (inter
 // note that the request doesn't have credentials for authN
 // swagger:parameters listAzureAvailabilityZonesNoCredentialsV2
 type azureAvailabilityZonesNoCredentialsReq struct {
-	azureSizeNoCredentialsReq
+	cluster.GetClusterReq
 	// in: header
 	// name: SKUName
 	SKUName string
@@ -189,11 +225,11 @@ func (req azureAvailabilityZonesNoCredentialsReq) GetSeedCluster() apiv1.SeedClu
 
 func DecodeAzureAvailabilityZonesNoCredentialsReq(c context.Context, r *http.Request) (interface{}, error) {
 	var req azureAvailabilityZonesNoCredentialsReq
-	lr, err := DecodeAzureSizesNoCredentialsReq(c, r)
+	cr, err := decodeAzureClusterReq(c, r)
 	if err != nil {
 		return nil, err
 	}
-	req.azureSizeNoCredentialsReq = lr.(azureSizeNoCredentialsReq)
+	req.GetClusterReq = cr
 	req.SKUName = r.Header.Get("SKUName")
 	return req, nil
 }
@@ -211,6 +247,9 @@ type azureCommonReq struct {
 	// in: header
 	// Credential predefined Kubermatic credential name from the presets
 	Credential string
+	// in: header
+	// DatacenterName is the optional name of the datacenter, whose Azure cloud is used for the request
+	DatacenterName string
 }
 
 func DecodeAzureCommonReq(_ context.Context, r *http.Request) (interface{}, error) {
@@ -221,6 +260,7 @@ func DecodeAzureCommonReq(_ context.Context, r *http.Request) (interface{}, erro
 	req.ClientID = r.Header.Get("ClientID")
 	req.ClientSecret = r.Header.Get("ClientSecret")
 	req.Credential = r.Header.Get("Credential")
+	req.DatacenterName = r.Header.Get("DatacenterName")
 	return req, nil
 }
 
@@ -232,6 +272,7 @@ type azureSecurityGroupsReq struct {
 	// in: header
 	ResourceGroup string
 	// in: header
+	// Location is optional, without it the resources in all locations are listed
 	Location string
 }
 
@@ -244,6 +285,9 @@ func DecodeAzureSecurityGroupsReq(c context.Context, r *http.Request) (interface
 	req.azureCommonReq = common.(azureCommonReq)
 	req.ResourceGroup = r.Header.Get("ResourceGroup")
 	req.Location = r.Header.Get("Location")
+	if req.ResourceGroup == "" {
+		return nil, errors.NewBadRequest("listing Azure security groups needs the header 'ResourceGroup'")
+	}
 	return req, nil
 }
 
@@ -253,6 +297,7 @@ type azureResourceGroupsReq struct {
 	azureCommonReq
 
 	// in: header
+	// Location is optional, without it the resources in all locations are listed
 	Location string
 }
 
@@ -276,6 +321,7 @@ type azureRouteTablesReq struct {
 	// in: header
 	ResourceGroup string
 	// in: header
+	// Location is optional, without it the resources in all locations are listed
 	Location string
 }
 
@@ -288,6 +334,9 @@ func DecodeAzureRouteTablesReq(c context.Context, r *http.Request) (interface{},
 	req.azureCommonReq = common.(azureCommonReq)
 	req.ResourceGroup = r.Header.Get("ResourceGroup")
 	req.Location = r.Header.Get("Location")
+	if req.ResourceGroup == "" {
+		return nil, errors.NewBadRequest("listing Azure route tables needs the header 'ResourceGroup'")
+	}
 	return req, nil
 }
 
@@ -299,6 +348,7 @@ type azureVirtualNetworksReq struct {
 	// in: header
 	ResourceGroup string
 	// in: header
+	// Location is optional, without it the resources in all locations are listed
 	Location string
 }
 
@@ -311,6 +361,9 @@ func DecodeAzureVirtualNetworksReq(c context.Context, r *http.Request) (interfac
 	req.azureCommonReq = common.(azureCommonReq)
 	req.ResourceGroup = r.Header.Get("ResourceGroup")
 	req.Location = r.Header.Get("Location")
+	if req.ResourceGroup == "" {
+		return nil, errors.NewBadRequest("listing Azure virtual networks needs the header 'ResourceGroup'")
+	}
 	return req, nil
 }
 
@@ -334,5 +387,33 @@ func DecodeAzureSubnetsReq(c context.Context, r *http.Request) (interface{}, err
 	req.azureCommonReq = common.(azureCommonReq)
 	req.ResourceGroup = r.Header.Get("ResourceGroup")
 	req.VirtualNetwork = r.Header.Get("VirtualNetwork")
+	if req.ResourceGroup == "" {
+		return nil, errors.NewBadRequest("listing Azure subnets needs the header 'ResourceGroup'")
+	}
+	if req.VirtualNetwork == "" {
+		return nil, errors.NewBadRequest("listing Azure subnets needs the header 'VirtualNetwork'")
+	}
+	return req, nil
+}
+
+// azureLocationsReq represent a request for the locations available to an Azure subscription
+// swagger:parameters listAzureLocations
+type azureLocationsReq struct {
+	azureCommonReq
+
+	// in: header
+	// ResourceProviders is a comma separated list of resource provider namespaces which have to be
+	// available in the locations in addition to the ones required for every cluster.
+	ResourceProviders string
+}
+
+func DecodeAzureLocationsReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req azureLocationsReq
+	common, err := DecodeAzureCommonReq(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.azureCommonReq = common.(azureCommonReq)
+	req.ResourceProviders = r.Header.Get("ResourceProviders")
 	return req, nil
 }
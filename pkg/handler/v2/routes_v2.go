@@ -28,6 +28,7 @@ import (
 	"k8c.io/kubermatic/v2/pkg/handler/middleware"
 	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
 	"k8c.io/kubermatic/v2/pkg/handler/v2/addon"
+	addonrollout "k8c.io/kubermatic/v2/pkg/handler/v2/addon_rollout"
 	"k8c.io/kubermatic/v2/pkg/handler/v2/alertmanager"
 	"k8c.io/kubermatic/v2/pkg/handler/v2/cluster"
 	clustertemplate "k8c.io/kubermatic/v2/pkg/handler/v2/cluster_template"
@@ -36,7 +37,9 @@ import (
 	externalcluster "k8c.io/kubermatic/v2/pkg/handler/v2/external_cluster"
 	"k8c.io/kubermatic/v2/pkg/handler/v2/gatekeeperconfig"
 	kubernetesdashboard "k8c.io/kubermatic/v2/pkg/handler/v2/kubernetes-dashboard"
+	"k8c.io/kubermatic/v2/pkg/handler/v2/pod"
 	"k8c.io/kubermatic/v2/pkg/handler/v2/machine"
+	namespacetemplate "k8c.io/kubermatic/v2/pkg/handler/v2/namespace_template"
 	"k8c.io/kubermatic/v2/pkg/handler/v2/preset"
 	"k8c.io/kubermatic/v2/pkg/handler/v2/provider"
 	"k8c.io/kubermatic/v2/pkg/handler/v2/rulegroup"
@@ -52,6 +55,10 @@ func (r Routing) RegisterV2(mux *mux.Router, metrics common.ServerMetrics) {
 		Path("/projects/{project_id}/clusters").
 		Handler(r.createCluster())
 
+	mux.Methods(http.MethodPost).
+		Path("/projects/{project_id}/clusters/infrastructureplan").
+		Handler(r.planClusterInfrastructure())
+
 	mux.Methods(http.MethodGet).
 		Path("/projects/{project_id}/clusters").
 		Handler(r.listClusters())
@@ -76,6 +83,34 @@ func (r Routing) RegisterV2(mux *mux.Router, metrics common.ServerMetrics) {
 		Path("/projects/{project_id}/clusters/{cluster_id}/health").
 		Handler(r.getClusterHealth())
 
+	mux.Methods(http.MethodGet).
+		Path("/projects/{project_id}/clusters/{cluster_id}/credentialrotations").
+		Handler(r.listClusterCredentialRotations())
+
+	mux.Methods(http.MethodGet).
+		Path("/projects/{project_id}/clusters/{cluster_id}/infrastructure").
+		Handler(r.getClusterInfrastructure())
+
+	mux.Methods(http.MethodGet).
+		Path("/projects/{project_id}/clusters/{cluster_id}/history").
+		Handler(r.listClusterSpecRevisions())
+
+	mux.Methods(http.MethodPost).
+		Path("/projects/{project_id}/clusters/{cluster_id}/history/{revision}/rollback").
+		Handler(r.rollbackClusterSpec())
+
+	mux.Methods(http.MethodGet).
+		Path("/projects/{project_id}/clusters/{cluster_id}/clusternamespaces").
+		Handler(r.listClusterNamespaces())
+
+	mux.Methods(http.MethodPost).
+		Path("/projects/{project_id}/clusters/{cluster_id}/clusternamespaces").
+		Handler(r.createClusterNamespace())
+
+	mux.Methods(http.MethodDelete).
+		Path("/projects/{project_id}/clusters/{cluster_id}/clusternamespaces/{namespace}").
+		Handler(r.deleteClusterNamespace())
+
 	mux.Methods(http.MethodPost).
 		Path("/projects/{project_id}/clusters/{cluster_id}/externalccmmigration").
 		Handler(r.migrateClusterToExternalCCM())
@@ -84,6 +119,18 @@ func (r Routing) RegisterV2(mux *mux.Router, metrics common.ServerMetrics) {
 		Path("/projects/{project_id}/clusters/{cluster_id}/kubeconfig").
 		Handler(r.getClusterKubeconfig())
 
+	mux.Methods(http.MethodPost).
+		Path("/projects/{project_id}/clusters/{cluster_id}/kubeconfig/share").
+		Handler(r.createClusterKubeconfigShareLink())
+
+	mux.Methods(http.MethodDelete).
+		Path("/projects/{project_id}/clusters/{cluster_id}/kubeconfig/share/{link_id}").
+		Handler(r.revokeClusterKubeconfigShareLink())
+
+	mux.Methods(http.MethodGet).
+		Path("/kubeconfig/shared").
+		Handler(r.getSharedClusterKubeconfig())
+
 	mux.Methods(http.MethodPut).
 		Path("/projects/{project_id}/clusters/{cluster_id}/token").
 		Handler(r.revokeClusterAdminToken())
@@ -100,6 +147,19 @@ func (r Routing) RegisterV2(mux *mux.Router, metrics common.ServerMetrics) {
 		Path("/projects/{project_id}/clusters/{cluster_id}/metrics").
 		Handler(r.getClusterMetrics())
 
+	// Defines the read-only endpoints that accept the monitoring scoped service account tokens
+	mux.Methods(http.MethodGet).
+		Path("/projects/{project_id}/monitoring/clusters/{cluster_id}/health").
+		Handler(r.getMonitoringClusterHealth())
+
+	mux.Methods(http.MethodGet).
+		Path("/projects/{project_id}/monitoring/clusters/{cluster_id}/metrics").
+		Handler(r.getMonitoringClusterMetrics())
+
+	mux.Methods(http.MethodGet).
+		Path("/projects/{project_id}/monitoring/clusters/{cluster_id}/events").
+		Handler(r.getMonitoringClusterEvents())
+
 	mux.Methods(http.MethodGet).
 		Path("/projects/{project_id}/clusters/{cluster_id}/namespaces").
 		Handler(r.listNamespace())
@@ -108,6 +168,10 @@ func (r Routing) RegisterV2(mux *mux.Router, metrics common.ServerMetrics) {
 		Path("/projects/{project_id}/clusters/{cluster_id}/upgrades").
 		Handler(r.getClusterUpgrades())
 
+	mux.Methods(http.MethodGet).
+		Path("/projects/{project_id}/clusters/{cluster_id}/upgrades/preflight").
+		Handler(r.getClusterUpgradePreflight())
+
 	mux.Methods(http.MethodPut).
 		Path("/projects/{project_id}/clusters/{cluster_id}/nodes/upgrades").
 		Handler(r.upgradeClusterNodeDeployments())
@@ -157,6 +221,10 @@ func (r Routing) RegisterV2(mux *mux.Router, metrics common.ServerMetrics) {
 		Path("/projects/{project_id}/clusters/{cluster_id}/machinedeployments").
 		Handler(r.createMachineDeployment())
 
+	mux.Methods(http.MethodPost).
+		Path("/projects/{project_id}/clusters/{cluster_id}/machinedeployments/bootstrapconfig").
+		Handler(r.previewMachineDeploymentBootstrapConfig())
+
 	mux.Methods(http.MethodDelete).
 		Path("/projects/{project_id}/clusters/{cluster_id}/machinedeployments/nodes/{node_id}").
 		Handler(r.deleteMachineDeploymentNode())
@@ -165,6 +233,10 @@ func (r Routing) RegisterV2(mux *mux.Router, metrics common.ServerMetrics) {
 		Path("/projects/{project_id}/clusters/{cluster_id}/machinedeployments").
 		Handler(r.listMachineDeployments())
 
+	mux.Methods(http.MethodGet).
+		Path("/projects/{project_id}/clusters/{cluster_id}/machinedeployments/metrics").
+		Handler(r.listMachineDeploymentsMetrics())
+
 	mux.Methods(http.MethodGet).
 		Path("/projects/{project_id}/clusters/{cluster_id}/machinedeployments/{machinedeployment_id}").
 		Handler(r.getMachineDeployment())
@@ -475,12 +547,25 @@ func (r Routing) RegisterV2(mux *mux.Router, metrics common.ServerMetrics) {
 	mux.PathPrefix("/projects/{project_id}/clusters/{cluster_id}/dashboard/proxy").
 		Handler(r.kubernetesDashboardProxy())
 
+	// Defines a set of endpoints to troubleshoot workloads running in the user cluster
+	mux.Methods(http.MethodGet).
+		Path("/projects/{project_id}/clusters/{cluster_id}/namespaces/{namespace}/pods/{pod}/log").
+		Handler(r.podLogProxy())
+
+	mux.Methods(http.MethodGet, http.MethodPost).
+		Path("/projects/{project_id}/clusters/{cluster_id}/namespaces/{namespace}/pods/{pod}/exec").
+		Handler(r.podExecProxy())
+
 	// Defines a set of HTTP endpoint for interacting with
 	// various cloud providers
 	mux.Methods(http.MethodGet).
 		Path("/providers/azure/securitygroups").
 		Handler(r.listAzureSecurityGroups())
 
+	mux.Methods(http.MethodGet).
+		Path("/providers/azure/locations").
+		Handler(r.listAzureLocations())
+
 	mux.Methods(http.MethodGet).
 		Path("/providers/azure/resourcegroups").
 		Handler(r.listAzureResourceGroups())
@@ -594,6 +679,44 @@ func (r Routing) RegisterV2(mux *mux.Router, metrics common.ServerMetrics) {
 		Path("/whitelistedregistries/{whitelisted_registry}").
 		Handler(r.patchWhitelistedRegistry())
 
+	// Defines a set of HTTP endpoints for managing addon rollouts
+	mux.Methods(http.MethodPost).
+		Path("/addonrollouts").
+		Handler(r.createAddonRollout())
+
+	mux.Methods(http.MethodGet).
+		Path("/addonrollouts").
+		Handler(r.listAddonRollouts())
+
+	mux.Methods(http.MethodGet).
+		Path("/addonrollouts/{addon_rollout}").
+		Handler(r.getAddonRollout())
+
+	mux.Methods(http.MethodDelete).
+		Path("/addonrollouts/{addon_rollout}").
+		Handler(r.deleteAddonRollout())
+
+	mux.Methods(http.MethodPatch).
+		Path("/addonrollouts/{addon_rollout}").
+		Handler(r.patchAddonRollout())
+
+	// Defines a set of HTTP endpoints for managing namespace templates
+	mux.Methods(http.MethodPost).
+		Path("/namespacetemplates").
+		Handler(r.createNamespaceTemplate())
+
+	mux.Methods(http.MethodGet).
+		Path("/namespacetemplates").
+		Handler(r.listNamespaceTemplates())
+
+	mux.Methods(http.MethodGet).
+		Path("/namespacetemplates/{namespace_template}").
+		Handler(r.getNamespaceTemplate())
+
+	mux.Methods(http.MethodDelete).
+		Path("/namespacetemplates/{namespace_template}").
+		Handler(r.deleteNamespaceTemplate())
+
 	// Defines a set of HTTP endpoints for managing etcd backup configs
 	mux.Methods(http.MethodPost).
 		Path("/projects/{project_id}/clusters/{cluster_id}/etcdbackupconfigs").
@@ -647,6 +770,39 @@ func (r Routing) createCluster() http.Handler {
 	)
 }
 
+// swagger:route POST /api/v2/projects/{project_id}/clusters/infrastructureplan project planClusterInfrastructure
+//
+//     Returns the cloud resources which would be created for a cluster with the given spec,
+//     without creating the cluster or any cloud resources. The names of the resources contain
+//     the placeholder "cluster-id" instead of the ID the cluster gets when it is created.
+//     Only supported for Azure clusters.
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: []ClusterInfrastructurePlanResource
+//       401: empty
+//       403: empty
+func (r Routing) planClusterInfrastructure() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+		)(cluster.PlanInfrastructureEndpoint(r.projectProvider, r.privilegedProjectProvider, r.seedsGetter,
+			r.presetsProvider, r.exposeStrategy, r.userInfoGetter, r.settingsProvider, r.updateManager, r.caBundle)),
+		cluster.DecodeCreateReq,
+		handler.EncodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
 // swagger:route GET /api/v2/projects/{project_id}/clusters project listClustersV2
 //
 //     Lists clusters for the specified project.
@@ -777,6 +933,142 @@ func (r Routing) getClusterEvents() http.Handler {
 	)
 }
 
+// swagger:route GET /api/v2/projects/{project_id}/clusters/{cluster_id}/history project listClusterSpecRevisionsV2
+//
+//     Lists the recorded changes of the cluster spec, oldest first.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: []ClusterSpecRevision
+//       401: empty
+//       403: empty
+func (r Routing) listClusterSpecRevisions() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+		)(cluster.ListClusterSpecRevisionsEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter)),
+		cluster.DecodeGetClusterReq,
+		handler.EncodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route POST /api/v2/projects/{project_id}/clusters/{cluster_id}/history/{revision}/rollback project rollbackClusterSpecV2
+//
+//     Rolls the cluster spec back to the given revision. Only the fields which can safely be
+//     changed on a running cluster are restored, like the name, the admission plugins, audit
+//     logging, OPA integration, MLA settings and the update window.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: Cluster
+//       401: empty
+//       403: empty
+func (r Routing) rollbackClusterSpec() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+		)(cluster.RollbackClusterSpecEndpoint(r.projectProvider, r.privilegedProjectProvider, r.seedsGetter, r.userInfoGetter, r.caBundle)),
+		cluster.DecodeRollbackClusterSpecReq,
+		handler.EncodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route GET /api/v2/projects/{project_id}/clusters/{cluster_id}/clusternamespaces project listClusterNamespaces
+//
+//     Lists the namespaces which were created in the cluster via the API.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: []ClusterNamespace
+//       401: empty
+//       403: empty
+func (r Routing) listClusterNamespaces() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+		)(cluster.ListClusterNamespacesEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter)),
+		cluster.DecodeGetClusterReq,
+		handler.EncodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route POST /api/v2/projects/{project_id}/clusters/{cluster_id}/clusternamespaces project createClusterNamespace
+//
+//     Creates a namespace in the cluster. The resource quota, limit range, network policy and
+//     role bindings of the given namespace template are created together with the namespace.
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       201: ClusterNamespace
+//       401: empty
+//       403: empty
+func (r Routing) createClusterNamespace() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+		)(cluster.CreateClusterNamespaceEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter, r.privilegedNamespaceTemplateProvider)),
+		cluster.DecodeCreateClusterNamespaceReq,
+		handler.SetStatusCreatedHeader(handler.EncodeJSON),
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route DELETE /api/v2/projects/{project_id}/clusters/{cluster_id}/clusternamespaces/{namespace} project deleteClusterNamespace
+//
+//     Deletes a namespace which was created via the API, together with everything in it.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: empty
+//       401: empty
+//       403: empty
+func (r Routing) deleteClusterNamespace() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+		)(cluster.DeleteClusterNamespaceEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter)),
+		cluster.DecodeClusterNamespaceReq,
+		handler.EncodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
 // swagger:route GET /api/v2/projects/{project_id}/clusters/{cluster_id}/health project getClusterHealthV2
 //
 //     Returns the cluster's component health status
@@ -803,6 +1095,60 @@ func (r Routing) getClusterHealth() http.Handler {
 	)
 }
 
+// swagger:route GET /api/v2/projects/{project_id}/clusters/{cluster_id}/credentialrotations project listClusterCredentialRotations
+//
+//     Lists the age of the tokens and certificates generated for the control plane of the cluster,
+//     which are rotated periodically.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: []ClusterCredentialRotation
+//       401: empty
+//       403: empty
+func (r Routing) listClusterCredentialRotations() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+		)(cluster.ListCredentialRotationsEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter)),
+		cluster.DecodeGetClusterReq,
+		handler.EncodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route GET /api/v2/projects/{project_id}/clusters/{cluster_id}/infrastructure project getClusterInfrastructure
+//
+//     Queries the cloud provider for the live state of the resources used by the cluster and
+//     reports the differences to the cluster spec. Only supported for Azure clusters.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: []ClusterInfrastructureResource
+//       401: empty
+//       403: empty
+func (r Routing) getClusterInfrastructure() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+		)(cluster.GetInfrastructureEndpoint(r.projectProvider, r.privilegedProjectProvider, r.seedsGetter, r.userInfoGetter, r.caBundle)),
+		cluster.DecodeGetClusterReq,
+		handler.EncodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
 // getClusterKubeconfig returns the kubeconfig for the cluster.
 // swagger:route GET /api/v2/projects/{project_id}/clusters/{cluster_id}/kubeconfig project getClusterKubeconfigV2
 //
@@ -830,6 +1176,84 @@ func (r Routing) getClusterKubeconfig() http.Handler {
 	)
 }
 
+// swagger:route POST /api/v2/projects/{project_id}/clusters/{cluster_id}/kubeconfig/share project createClusterKubeconfigShareLinkV2
+//
+//     Creates a time-limited link to download a viewer kubeconfig for the specified cluster.
+//
+//     The link can be used without authentication until it expires or is revoked. The kubeconfig
+//     authenticates with a token that expires together with the link.
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       201: KubeconfigShareLink
+//       401: empty
+//       403: empty
+func (r Routing) createClusterKubeconfigShareLink() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+		)(cluster.CreateKubeconfigShareLinkEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter, r.saTokenGenerator)),
+		cluster.DecodeCreateKubeconfigShareLinkReq,
+		handler.SetStatusCreatedHeader(handler.EncodeJSON),
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route DELETE /api/v2/projects/{project_id}/clusters/{cluster_id}/kubeconfig/share/{link_id} project revokeClusterKubeconfigShareLinkV2
+//
+//     Revokes a kubeconfig sharing link and the tokens of the kubeconfigs downloaded with it.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: empty
+//       401: empty
+//       403: empty
+func (r Routing) revokeClusterKubeconfigShareLink() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+		)(cluster.RevokeKubeconfigShareLinkEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter)),
+		cluster.DecodeRevokeKubeconfigShareLinkReq,
+		handler.EncodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route GET /api/v2/kubeconfig/shared project getSharedClusterKubeconfigV2
+//
+//     Gets a viewer kubeconfig for the cluster a sharing link was created for.
+//
+//     Produces:
+//     - application/octet-stream
+//
+//     Responses:
+//       default: errorResponse
+//       200: Kubeconfig
+//       401: empty
+func (r Routing) getSharedClusterKubeconfig() http.Handler {
+	return httptransport.NewServer(
+		cluster.GetSharedKubeconfigEndpoint(r.saTokenAuthenticator, r.privilegedProjectProvider, r.clusterProviderGetter, r.seedsGetter),
+		cluster.DecodeGetSharedKubeconfigReq,
+		cluster.EncodeKubeconfig,
+		r.defaultServerOptions()...,
+	)
+}
+
 // getOidcClusterKubeconfig returns the oidc kubeconfig for the cluster.
 // swagger:route GET /api/v2/projects/{project_id}/clusters/{cluster_id}/oidckubeconfig project getOidcClusterKubeconfigV2
 //
@@ -883,6 +1307,81 @@ func (r Routing) getClusterMetrics() http.Handler {
 	)
 }
 
+// swagger:route GET /api/v2/projects/{project_id}/monitoring/clusters/{cluster_id}/health project getMonitoringClusterHealthV2
+//
+//     Returns the cluster's component health status. Only accepts service account tokens with the monitoring scope.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: ClusterHealth
+//       401: empty
+//       403: empty
+func (r Routing) getMonitoringClusterHealth() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.MonitoringTokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+		)(cluster.HealthEndpoint(r.projectProvider, r.privilegedProjectProvider, middleware.ScopedUserInfoGetter)),
+		cluster.DecodeGetClusterReq,
+		handler.EncodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route GET /api/v2/projects/{project_id}/monitoring/clusters/{cluster_id}/metrics project getMonitoringClusterMetricsV2
+//
+//     Gets cluster metrics. Only accepts service account tokens with the monitoring scope.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: ClusterMetrics
+//       401: empty
+//       403: empty
+func (r Routing) getMonitoringClusterMetrics() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.MonitoringTokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+		)(cluster.GetMetricsEndpoint(r.projectProvider, r.privilegedProjectProvider, middleware.ScopedUserInfoGetter)),
+		cluster.DecodeGetClusterReq,
+		handler.EncodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route GET /api/v2/projects/{project_id}/monitoring/clusters/{cluster_id}/events project getMonitoringClusterEventsV2
+//
+//     Gets the events related to the specified cluster. Only accepts service account tokens with the monitoring scope.
+//
+//     Produces:
+//     - application/yaml
+//
+//     Responses:
+//       default: errorResponse
+//       200: []Event
+//       401: empty
+//       403: empty
+func (r Routing) getMonitoringClusterEvents() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.MonitoringTokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+		)(cluster.GetClusterEventsEndpoint(r.projectProvider, r.privilegedProjectProvider, middleware.ScopedUserInfoGetter)),
+		cluster.DecodeGetClusterEvents,
+		handler.EncodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
 // swagger:route GET /api/v2/projects/{project_id}/clusters/{cluster_id}/namespaces project listNamespaceV2
 //
 //     Lists all namespaces in the cluster
@@ -935,6 +1434,33 @@ func (r Routing) getClusterUpgrades() http.Handler {
 	)
 }
 
+// swagger:route GET /api/v2/projects/{project_id}/clusters/{cluster_id}/upgrades/preflight project getClusterUpgradePreflight
+//
+//    Gets the compatibility of the cluster with the next Kubernetes minor version, based on the
+//    last scan for APIs which are removed in that version.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: ClusterUpgradePreflight
+//       401: empty
+//       403: empty
+func (r Routing) getClusterUpgradePreflight() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+		)(cluster.GetUpgradePreflightEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter)),
+		cluster.DecodeGetClusterReq,
+		handler.EncodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
 // swagger:route PUT /api/v2/projects/{project_id}/clusters/{cluster_id}/nodes/upgrades project upgradeClusterNodeDeploymentsV2
 //
 //    Upgrades node deployments in a cluster
@@ -1781,34 +2307,64 @@ func (r Routing) createGatekeeperConfig() http.Handler {
 
 // swagger:route PATCH /api/v2/projects/{project_id}/clusters/{cluster_id}/gatekeeper/config project patchGatekeeperConfig
 //
-//     Patches the gatekeeper config for the specified cluster.
+//     Patches the gatekeeper config for the specified cluster.
+//
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: GatekeeperConfig
+//       401: empty
+//       403: empty
+func (r Routing) patchGatekeeperConfig() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+		)(gatekeeperconfig.PatchEndpoint(r.userInfoGetter, r.projectProvider, r.privilegedProjectProvider)),
+		gatekeeperconfig.DecodePatchGatekeeperConfigReq,
+		handler.EncodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route POST /api/v2/projects/{project_id}/clusters/{cluster_id}/machinedeployments project createMachineDeployment
+//
+//     Creates a machine deployment that will belong to the given cluster
 //
+//     Consumes:
+//     - application/json
 //
 //     Produces:
 //     - application/json
 //
 //     Responses:
 //       default: errorResponse
-//       200: GatekeeperConfig
+//       201: NodeDeployment
 //       401: empty
 //       403: empty
-func (r Routing) patchGatekeeperConfig() http.Handler {
+func (r Routing) createMachineDeployment() http.Handler {
 	return httptransport.NewServer(
 		endpoint.Chain(
 			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
 			middleware.UserSaver(r.userProvider),
 			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
 			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
-		)(gatekeeperconfig.PatchEndpoint(r.userInfoGetter, r.projectProvider, r.privilegedProjectProvider)),
-		gatekeeperconfig.DecodePatchGatekeeperConfigReq,
-		handler.EncodeJSON,
+		)(machine.CreateMachineDeployment(r.sshKeyProvider, r.projectProvider, r.privilegedProjectProvider, r.seedsGetter, r.userInfoGetter, r.settingsProvider, r.caBundle)),
+		machine.DecodeCreateMachineDeployment,
+		handler.SetStatusCreatedHeader(handler.EncodeJSON),
 		r.defaultServerOptions()...,
 	)
 }
 
-// swagger:route POST /api/v2/projects/{project_id}/clusters/{cluster_id}/machinedeployments project createMachineDeployment
+// swagger:route POST /api/v2/projects/{project_id}/clusters/{cluster_id}/machinedeployments/bootstrapconfig project previewMachineDeploymentBootstrapConfig
 //
-//     Creates a machine deployment that will belong to the given cluster
+//     Renders the bootstrap configuration the machines of the given machine deployment would be provisioned with,
+//     without creating the machine deployment. Secrets like the bootstrap token and the cloud config are redacted.
 //
 //     Consumes:
 //     - application/json
@@ -1818,19 +2374,19 @@ func (r Routing) patchGatekeeperConfig() http.Handler {
 //
 //     Responses:
 //       default: errorResponse
-//       201: NodeDeployment
+//       200: MachineDeploymentBootstrapConfig
 //       401: empty
 //       403: empty
-func (r Routing) createMachineDeployment() http.Handler {
+func (r Routing) previewMachineDeploymentBootstrapConfig() http.Handler {
 	return httptransport.NewServer(
 		endpoint.Chain(
 			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
 			middleware.UserSaver(r.userProvider),
 			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
 			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
-		)(machine.CreateMachineDeployment(r.sshKeyProvider, r.projectProvider, r.privilegedProjectProvider, r.seedsGetter, r.userInfoGetter)),
+		)(machine.PreviewMachineDeploymentBootstrapConfig(r.sshKeyProvider, r.projectProvider, r.privilegedProjectProvider, r.seedsGetter, r.userInfoGetter)),
 		machine.DecodeCreateMachineDeployment,
-		handler.SetStatusCreatedHeader(handler.EncodeJSON),
+		handler.EncodeJSON,
 		r.defaultServerOptions()...,
 	)
 }
@@ -1993,6 +2549,32 @@ func (r Routing) listMachineDeploymentMetrics() http.Handler {
 	)
 }
 
+// swagger:route GET /api/v2/projects/{project_id}/clusters/{cluster_id}/machinedeployments/metrics metric listMachineDeploymentsMetrics
+//
+//     Lists the node metrics of all machine deployments of the given cluster, aggregated per machine deployment.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: []MachineDeploymentMetrics
+//       401: empty
+//       403: empty
+func (r Routing) listMachineDeploymentsMetrics() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+		)(machine.ListMachineDeploymentsMetrics(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter)),
+		machine.DecodeListMachineDeployments,
+		handler.EncodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
 // swagger:route PATCH /api/v2/projects/{project_id}/clusters/{cluster_id}/machinedeployments/{machinedeployment_id} project patchMachineDeployment
 //
 //     Patches a machine deployment that is assigned to the given cluster. Please note that at the moment only
@@ -2016,7 +2598,7 @@ func (r Routing) patchMachineDeployment() http.Handler {
 			middleware.UserSaver(r.userProvider),
 			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
 			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
-		)(machine.PatchMachineDeployment(r.sshKeyProvider, r.projectProvider, r.privilegedProjectProvider, r.seedsGetter, r.userInfoGetter)),
+		)(machine.PatchMachineDeployment(r.sshKeyProvider, r.projectProvider, r.privilegedProjectProvider, r.seedsGetter, r.userInfoGetter, r.settingsProvider, r.caBundle)),
 		machine.DecodePatchMachineDeployment,
 		handler.EncodeJSON,
 		r.defaultServerOptions()...,
@@ -2614,7 +3196,7 @@ func (r Routing) listGCPSizesNoCredentials() http.Handler {
 			middleware.UserSaver(r.userProvider),
 			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
 			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
-		)(provider.GCPSizeWithClusterCredentialsEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter, r.settingsProvider)),
+		)(provider.GCPSizeWithClusterCredentialsEndpoint(r.projectProvider, r.privilegedProjectProvider, r.seedsGetter, r.userInfoGetter, r.settingsProvider)),
 		provider.DecodeGCPTypesNoCredentialReq,
 		handler.EncodeJSON,
 		r.defaultServerOptions()...,
@@ -2786,7 +3368,7 @@ func (r Routing) listHetznerSizesNoCredentials() http.Handler {
 			middleware.UserSaver(r.userProvider),
 			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
 			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
-		)(provider.HetznerSizeWithClusterCredentialsEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter, r.settingsProvider)),
+		)(provider.HetznerSizeWithClusterCredentialsEndpoint(r.projectProvider, r.privilegedProjectProvider, r.seedsGetter, r.userInfoGetter, r.settingsProvider)),
 		cluster.DecodeGetClusterReq,
 		handler.EncodeJSON,
 		r.defaultServerOptions()...,
@@ -2810,7 +3392,7 @@ func (r Routing) listDigitaloceanSizesNoCredentials() http.Handler {
 			middleware.UserSaver(r.userProvider),
 			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
 			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
-		)(provider.DigitaloceanSizeWithClusterCredentialsEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter, r.settingsProvider)),
+		)(provider.DigitaloceanSizeWithClusterCredentialsEndpoint(r.projectProvider, r.privilegedProjectProvider, r.seedsGetter, r.userInfoGetter, r.settingsProvider)),
 		cluster.DecodeGetClusterReq,
 		handler.EncodeJSON,
 		r.defaultServerOptions()...,
@@ -3152,7 +3734,7 @@ func (r Routing) listPacketSizesNoCredentials() http.Handler {
 			middleware.UserSaver(r.userProvider),
 			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
 			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
-		)(provider.PacketSizesWithClusterCredentialsEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter, r.settingsProvider)),
+		)(provider.PacketSizesWithClusterCredentialsEndpoint(r.projectProvider, r.privilegedProjectProvider, r.seedsGetter, r.userInfoGetter, r.settingsProvider)),
 		provider.DecodePacketSizesNoCredentialsReq,
 		handler.EncodeJSON,
 		r.defaultServerOptions()...,
@@ -3233,6 +3815,47 @@ func (r Routing) kubernetesDashboardProxy() http.Handler {
 	)
 }
 
+// swagger:route GET /api/v2/projects/{project_id}/clusters/{cluster_id}/namespaces/{namespace}/pods/{pod}/log
+//
+//    Streams the logs of a pod in the user cluster. The request is impersonating the user, so
+//    it is subject to the RBAC rules of the user cluster. The query parameters of the
+//    Kubernetes log endpoint (container, follow, tailLines, sinceSeconds...) are supported.
+//
+//     Responses:
+//       default: empty
+func (r Routing) podLogProxy() http.Handler {
+	return r.podProxy(pod.LogSubresource)
+}
+
+// swagger:route GET /api/v2/projects/{project_id}/clusters/{cluster_id}/namespaces/{namespace}/pods/{pod}/exec
+//
+//    Executes a command in a pod of the user cluster. The request is impersonating the user, so
+//    it is subject to the RBAC rules of the user cluster. The connection is upgraded to a SPDY or
+//    websocket stream, the query parameters of the Kubernetes exec endpoint (container, command,
+//    stdin, stdout, stderr, tty) are supported.
+//
+//     Responses:
+//       default: empty
+func (r Routing) podExecProxy() http.Handler {
+	return r.podProxy(pod.ExecSubresource)
+}
+
+func (r Routing) podProxy(subresource string) http.Handler {
+	return pod.ProxyEndpoint(
+		r.log,
+		middleware.TokenExtractor(r.tokenExtractors),
+		r.projectProvider,
+		r.privilegedProjectProvider,
+		r.userInfoGetter,
+		subresource,
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+		),
+	)
+}
+
 // swagger:route GET /api/v2/providers/azure/securitygroups azure listAzureSecurityGroups
 //
 // Lists available VM security groups
@@ -3248,13 +3871,35 @@ func (r Routing) listAzureSecurityGroups() http.Handler {
 		endpoint.Chain(
 			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
 			middleware.UserSaver(r.userProvider),
-		)(provider.AzureSecurityGroupsEndpoint(r.presetsProvider, r.userInfoGetter)),
+		)(provider.AzureSecurityGroupsEndpoint(r.presetsProvider, r.seedsGetter, r.userInfoGetter)),
 		provider.DecodeAzureSecurityGroupsReq,
 		handler.EncodeJSON,
 		r.defaultServerOptions()...,
 	)
 }
 
+// swagger:route GET /api/v2/providers/azure/locations azure listAzureLocations
+//
+// Lists the locations available to the subscription in which clusters can be created
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: AzureLocationList
+func (r Routing) listAzureLocations() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+		)(provider.AzureLocationsEndpoint(r.presetsProvider, r.seedsGetter, r.userInfoGetter)),
+		provider.DecodeAzureLocationsReq,
+		handler.EncodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
 // swagger:route GET /api/v2/providers/azure/resourcegroups azure listAzureResourceGroups
 //
 // Lists available VM resource groups
@@ -3270,7 +3915,7 @@ func (r Routing) listAzureResourceGroups() http.Handler {
 		endpoint.Chain(
 			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
 			middleware.UserSaver(r.userProvider),
-		)(provider.AzureResourceGroupsEndpoint(r.presetsProvider, r.userInfoGetter)),
+		)(provider.AzureResourceGroupsEndpoint(r.presetsProvider, r.seedsGetter, r.userInfoGetter)),
 		provider.DecodeAzureResourceGroupsReq,
 		handler.EncodeJSON,
 		r.defaultServerOptions()...,
@@ -3292,7 +3937,7 @@ func (r Routing) listAzureRouteTables() http.Handler {
 		endpoint.Chain(
 			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
 			middleware.UserSaver(r.userProvider),
-		)(provider.AzureRouteTablesEndpoint(r.presetsProvider, r.userInfoGetter)),
+		)(provider.AzureRouteTablesEndpoint(r.presetsProvider, r.seedsGetter, r.userInfoGetter)),
 		provider.DecodeAzureRouteTablesReq,
 		handler.EncodeJSON,
 		r.defaultServerOptions()...,
@@ -3314,7 +3959,7 @@ func (r Routing) listAzureVnets() http.Handler {
 		endpoint.Chain(
 			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
 			middleware.UserSaver(r.userProvider),
-		)(provider.AzureVirtualNetworksEndpoint(r.presetsProvider, r.userInfoGetter)),
+		)(provider.AzureVirtualNetworksEndpoint(r.presetsProvider, r.seedsGetter, r.userInfoGetter)),
 		provider.DecodeAzureVirtualNetworksReq,
 		handler.EncodeJSON,
 		r.defaultServerOptions()...,
@@ -3358,7 +4003,7 @@ func (r Routing) listAzureSubnets() http.Handler {
 		endpoint.Chain(
 			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
 			middleware.UserSaver(r.userProvider),
-		)(provider.AzureSubnetsEndpoint(r.presetsProvider, r.userInfoGetter)),
+		)(provider.AzureSubnetsEndpoint(r.presetsProvider, r.seedsGetter, r.userInfoGetter)),
 		provider.DecodeAzureSubnetsReq,
 		handler.EncodeJSON,
 		r.defaultServerOptions()...,
@@ -4269,3 +4914,228 @@ func (r Routing) patchEtcdBackupConfig() http.Handler {
 		r.defaultServerOptions()...,
 	)
 }
+
+// swagger:route POST /api/v2/addonrollouts addonrollout createAddonRollout
+//
+//     Creates an addon rollout which installs an addon into all selected clusters in waves.
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       201: AddonRollout
+//       401: empty
+//       403: empty
+func (r Routing) createAddonRollout() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+		)(addonrollout.CreateEndpoint(r.userInfoGetter, r.privilegedAddonRolloutProvider)),
+		addonrollout.DecodeCreateAddonRolloutRequest,
+		handler.SetStatusCreatedHeader(handler.EncodeJSON),
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route GET /api/v2/addonrollouts addonrollout listAddonRollouts
+//
+//     Lists addon rollouts.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: []AddonRollout
+//       401: empty
+//       403: empty
+func (r Routing) listAddonRollouts() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+		)(addonrollout.ListEndpoint(r.userInfoGetter, r.privilegedAddonRolloutProvider)),
+		common.DecodeEmptyReq,
+		handler.EncodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route GET /api/v2/addonrollouts/{addon_rollout} addonrollout getAddonRollout
+//
+//     Gets the addon rollout specified by name, including the progress in every cluster.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: AddonRollout
+//       401: empty
+//       403: empty
+func (r Routing) getAddonRollout() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+		)(addonrollout.GetEndpoint(r.userInfoGetter, r.privilegedAddonRolloutProvider)),
+		addonrollout.DecodeGetAddonRolloutRequest,
+		handler.EncodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route DELETE /api/v2/addonrollouts/{addon_rollout} addonrollout deleteAddonRollout
+//
+//     Deletes the given addon rollout and removes its addon from all clusters.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: empty
+//       401: empty
+//       403: empty
+func (r Routing) deleteAddonRollout() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+		)(addonrollout.DeleteEndpoint(r.userInfoGetter, r.privilegedAddonRolloutProvider)),
+		addonrollout.DecodeGetAddonRolloutRequest,
+		handler.EncodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route PATCH /api/v2/addonrollouts/{addon_rollout} addonrollout patchAddonRollout
+//
+//     Patches the given addon rollout, e.g. to pause it or to roll out a new version.
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: AddonRollout
+//       401: empty
+//       403: empty
+func (r Routing) patchAddonRollout() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+		)(addonrollout.PatchEndpoint(r.userInfoGetter, r.privilegedAddonRolloutProvider)),
+		addonrollout.DecodePatchAddonRolloutReq,
+		handler.EncodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route POST /api/v2/namespacetemplates namespacetemplate createNamespaceTemplate
+//
+//     Creates a namespace template, which users can apply to the namespaces they create in their clusters.
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       201: NamespaceTemplate
+//       401: empty
+//       403: empty
+func (r Routing) createNamespaceTemplate() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+		)(namespacetemplate.CreateEndpoint(r.userInfoGetter, r.privilegedNamespaceTemplateProvider)),
+		namespacetemplate.DecodeCreateNamespaceTemplateRequest,
+		handler.SetStatusCreatedHeader(handler.EncodeJSON),
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route GET /api/v2/namespacetemplates namespacetemplate listNamespaceTemplates
+//
+//     Lists namespace templates.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: []NamespaceTemplate
+//       401: empty
+//       403: empty
+func (r Routing) listNamespaceTemplates() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+		)(namespacetemplate.ListEndpoint(r.privilegedNamespaceTemplateProvider)),
+		common.DecodeEmptyReq,
+		handler.EncodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route GET /api/v2/namespacetemplates/{namespace_template} namespacetemplate getNamespaceTemplate
+//
+//     Gets the namespace template specified by name.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: NamespaceTemplate
+//       401: empty
+//       403: empty
+func (r Routing) getNamespaceTemplate() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+		)(namespacetemplate.GetEndpoint(r.privilegedNamespaceTemplateProvider)),
+		namespacetemplate.DecodeGetNamespaceTemplateRequest,
+		handler.EncodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route DELETE /api/v2/namespacetemplates/{namespace_template} namespacetemplate deleteNamespaceTemplate
+//
+//     Deletes the given namespace template. Namespaces created with it are not changed.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: empty
+//       401: empty
+//       403: empty
+func (r Routing) deleteNamespaceTemplate() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+		)(namespacetemplate.DeleteEndpoint(r.userInfoGetter, r.privilegedNamespaceTemplateProvider)),
+		namespacetemplate.DecodeGetNamespaceTemplateRequest,
+		handler.EncodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
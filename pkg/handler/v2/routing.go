@@ -87,6 +87,8 @@ type Routing struct {
 	ruleGroupProviderGetter               provider.RuleGroupProviderGetter
 	privilegedWhitelistedRegistryProvider provider.PrivilegedWhitelistedRegistryProvider
 	etcdBackupConfigProviderGetter        provider.EtcdBackupConfigProviderGetter
+	privilegedAddonRolloutProvider        provider.PrivilegedAddonRolloutProvider
+	privilegedNamespaceTemplateProvider   provider.PrivilegedNamespaceTemplateProvider
 	versions                              kubermatic.Versions
 	caBundle                              *x509.CertPool
 }
@@ -141,6 +143,8 @@ func NewV2Routing(routingParams handler.RoutingParams) Routing {
 		ruleGroupProviderGetter:               routingParams.RuleGroupProviderGetter,
 		privilegedWhitelistedRegistryProvider: routingParams.PrivilegedWhitelistedRegistryProvider,
 		etcdBackupConfigProviderGetter:        routingParams.EtcdBackupConfigProviderGetter,
+		privilegedAddonRolloutProvider:        routingParams.PrivilegedAddonRolloutProvider,
+		privilegedNamespaceTemplateProvider:   routingParams.PrivilegedNamespaceTemplateProvider,
 		versions:                              routingParams.Versions,
 		caBundle:                              routingParams.CABundle,
 	}
@@ -0,0 +1,173 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	azure "github.com/kubermatic/machine-controller/pkg/cloudprovider/provider/azure/types"
+	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+)
+
+// AzureSecurityConfig holds the settings of Azure machines which are passed to the machine
+// controller in addition to the fields of its Azure config.
+type AzureSecurityConfig struct {
+	// HyperVGeneration is the generation of the image of the VM.
+	HyperVGeneration string `json:"hyperVGeneration,omitempty"`
+	// SecurityProfile is passed to the VM as is.
+	SecurityProfile *AzureSecurityProfile `json:"securityProfile,omitempty"`
+}
+
+// AzureSecurityProfile is the security profile of an Azure VM.
+type AzureSecurityProfile struct {
+	SecurityType     string             `json:"securityType,omitempty"`
+	UefiSettings     *AzureUefiSettings `json:"uefiSettings,omitempty"`
+	EncryptionAtHost bool               `json:"encryptionAtHost,omitempty"`
+}
+
+// AzureUefiSettings are the UEFI settings of a trusted launch VM.
+type AzureUefiSettings struct {
+	SecureBootEnabled bool `json:"secureBootEnabled,omitempty"`
+	VTPMEnabled       bool `json:"vTpmEnabled,omitempty"`
+}
+
+// NewAzureSecurityConfig returns the security config for the machines of an Azure node spec.
+func NewAzureSecurityConfig(spec *apiv1.AzureNodeSpec) AzureSecurityConfig {
+	config := AzureSecurityConfig{
+		HyperVGeneration: spec.HyperVGeneration,
+	}
+	if spec.SecurityType != "" || spec.EnableEncryptionAtHost {
+		config.SecurityProfile = &AzureSecurityProfile{
+			EncryptionAtHost: spec.EnableEncryptionAtHost,
+		}
+	}
+	if spec.SecurityType != "" {
+		config.SecurityProfile.SecurityType = spec.SecurityType
+		config.SecurityProfile.UefiSettings = &AzureUefiSettings{
+			SecureBootEnabled: spec.EnableSecureBoot,
+			VTPMEnabled:       spec.EnableVTPM,
+		}
+	}
+	return config
+}
+
+// Apply sets the fields of the security config on an Azure node spec.
+func (c AzureSecurityConfig) Apply(spec *apiv1.AzureNodeSpec) {
+	spec.HyperVGeneration = c.HyperVGeneration
+	if c.SecurityProfile != nil {
+		spec.SecurityType = c.SecurityProfile.SecurityType
+		spec.EnableEncryptionAtHost = c.SecurityProfile.EncryptionAtHost
+		if c.SecurityProfile.UefiSettings != nil {
+			spec.EnableSecureBoot = c.SecurityProfile.UefiSettings.SecureBootEnabled
+			spec.EnableVTPM = c.SecurityProfile.UefiSettings.VTPMEnabled
+		}
+	}
+}
+
+// AzureDiagnosticsConfig holds the boot diagnostics settings of Azure machines, which are passed
+// to the machine controller in addition to the fields of its Azure config.
+type AzureDiagnosticsConfig struct {
+	// BootDiagnosticsStorageURI is the blob endpoint of the storage account the boot diagnostics
+	// of the VM are written to. Boot diagnostics are disabled if it is empty.
+	BootDiagnosticsStorageURI string `json:"bootDiagnosticsStorageURI,omitempty"`
+}
+
+// AzureNetworkingConfig holds the networking settings of Azure machines, which are passed to the
+// machine controller in addition to the fields of its Azure config.
+type AzureNetworkingConfig struct {
+	// EnableAcceleratedNetworking enables accelerated networking on the network interface of the VM.
+	EnableAcceleratedNetworking bool `json:"enableAcceleratedNetworking,omitempty"`
+}
+
+// AzureGen2Image is a Gen2 marketplace image of an operating system.
+type AzureGen2Image struct {
+	Reference azure.ImageReference
+	Plan      *azure.ImagePlan
+}
+
+// AzureGen2Images are the Gen2 variants of the default images the machine controller uses for
+// the operating systems.
+var AzureGen2Images = map[providerconfig.OperatingSystem]AzureGen2Image{
+	providerconfig.OperatingSystemCentOS: {
+		Reference: azure.ImageReference{Publisher: "OpenLogic", Offer: "CentOS", Sku: "7_9-gen2", Version: "latest"},
+	},
+	providerconfig.OperatingSystemUbuntu: {
+		Reference: azure.ImageReference{Publisher: "Canonical", Offer: "UbuntuServer", Sku: "18_04-lts-gen2", Version: "latest"},
+	},
+	providerconfig.OperatingSystemRHEL: {
+		Reference: azure.ImageReference{Publisher: "RedHat", Offer: "rhel-byos", Sku: "rhel-lvm83-gen2", Version: "latest"},
+		Plan:      &azure.ImagePlan{Name: "rhel-lvm83-gen2", Publisher: "redhat", Product: "rhel-byos"},
+	},
+	providerconfig.OperatingSystemFlatcar: {
+		Reference: azure.ImageReference{Publisher: "kinvolk", Offer: "flatcar-container-linux", Sku: "stable-gen2", Version: "latest"},
+		Plan:      &azure.ImagePlan{Name: "stable-gen2", Publisher: "kinvolk", Product: "flatcar-container-linux"},
+	},
+}
+
+// NewAzureImageReference returns the machine controller image reference for a marketplace image.
+func NewAzureImageReference(reference *apiv1.AzureImageReference) *azure.ImageReference {
+	if reference == nil {
+		return nil
+	}
+	version := reference.Version
+	if version == "" {
+		version = "latest"
+	}
+	return &azure.ImageReference{
+		Publisher: reference.Publisher,
+		Offer:     reference.Offer,
+		Sku:       reference.SKU,
+		Version:   version,
+	}
+}
+
+// NewAzureImagePlan returns the machine controller image plan for a marketplace plan.
+func NewAzureImagePlan(plan *apiv1.AzureImagePlan) *azure.ImagePlan {
+	if plan == nil {
+		return nil
+	}
+	return &azure.ImagePlan{
+		Name:      plan.Name,
+		Publisher: plan.Publisher,
+		Product:   plan.Product,
+	}
+}
+
+// applyAzureImage sets the marketplace image of an Azure config on the node spec, unless it is
+// the Gen2 default image of the operating system, which is derived from the Hyper-V generation.
+// applyAzureImage sets the marketplace image of an Azure config on the node spec, unless it is
+// the Gen2 default image of the operating system, which is derived from the Hyper-V generation.
+func applyAzureImage(config *azure.RawConfig, os providerconfig.OperatingSystem, spec *apiv1.AzureNodeSpec) {
+	if image, ok := AzureGen2Images[os]; ok && config.ImageReference != nil && spec.HyperVGeneration == apiv1.AzureHyperVGenerationV2 && *config.ImageReference == image.Reference {
+		return
+	}
+
+	if reference := config.ImageReference; reference != nil {
+		spec.ImageReference = &apiv1.AzureImageReference{
+			Publisher: reference.Publisher,
+			Offer:     reference.Offer,
+			SKU:       reference.Sku,
+			Version:   reference.Version,
+		}
+	}
+	if plan := config.ImagePlan; plan != nil {
+		spec.ImagePlan = &apiv1.AzureImagePlan{
+			Name:      plan.Name,
+			Publisher: plan.Publisher,
+			Product:   plan.Product,
+		}
+	}
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"reflect"
+	"testing"
+
+	azure "github.com/kubermatic/machine-controller/pkg/cloudprovider/provider/azure/types"
+	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+)
+
+func TestApplyAzureImage(t *testing.T) {
+	gen2Flatcar := AzureGen2Images[providerconfig.OperatingSystemFlatcar]
+
+	testCases := []struct {
+		name             string
+		config           azure.RawConfig
+		hyperVGeneration string
+		want             apiv1.AzureNodeSpec
+	}{
+		{
+			name: "no marketplace image",
+		},
+		{
+			name:             "Gen2 default image",
+			config:           azure.RawConfig{ImageReference: &gen2Flatcar.Reference, ImagePlan: gen2Flatcar.Plan},
+			hyperVGeneration: apiv1.AzureHyperVGenerationV2,
+			want:             apiv1.AzureNodeSpec{HyperVGeneration: apiv1.AzureHyperVGenerationV2},
+		},
+		{
+			name: "marketplace image with plan",
+			config: azure.RawConfig{
+				ImageReference: &azure.ImageReference{Publisher: "kinvolk", Offer: "flatcar-container-linux-free", Sku: "stable", Version: "latest"},
+				ImagePlan:      &azure.ImagePlan{Name: "stable", Publisher: "kinvolk", Product: "flatcar-container-linux-free"},
+			},
+			want: apiv1.AzureNodeSpec{
+				ImageReference: &apiv1.AzureImageReference{Publisher: "kinvolk", Offer: "flatcar-container-linux-free", SKU: "stable", Version: "latest"},
+				ImagePlan:      &apiv1.AzureImagePlan{Name: "stable", Publisher: "kinvolk", Product: "flatcar-container-linux-free"},
+			},
+		},
+		{
+			name: "gallery image with plan",
+			config: azure.RawConfig{
+				ImageID:   providerconfig.ConfigVarString{Value: "/subscriptions/sub/resourceGroups/images/providers/Microsoft.Compute/galleries/shared/images/flatcar"},
+				ImagePlan: &azure.ImagePlan{Name: "stable", Publisher: "kinvolk", Product: "flatcar-container-linux-free"},
+			},
+			want: apiv1.AzureNodeSpec{
+				ImagePlan: &apiv1.AzureImagePlan{Name: "stable", Publisher: "kinvolk", Product: "flatcar-container-linux-free"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			spec := apiv1.AzureNodeSpec{HyperVGeneration: tc.hyperVGeneration}
+			applyAzureImage(&tc.config, providerconfig.OperatingSystemFlatcar, &spec)
+			if !reflect.DeepEqual(spec, tc.want) {
+				t.Errorf("expected %+v, got %+v", tc.want, spec)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"encoding/json"
+	"fmt"
+
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+)
+
+const (
+	// CanaryRolloutAnnotation holds the JSON-encoded canary rollout settings of a machine deployment.
+	CanaryRolloutAnnotation = "kubermatic.io/canary-rollout"
+	// CanaryImageAnnotation holds the OS image a machine deployment with canary rollouts is
+	// updated to once the canary machines created with it passed the validation.
+	CanaryImageAnnotation = "kubermatic.io/canary-image"
+	// CanaryStatusAnnotation holds the JSON-encoded status of the latest canary rollout of a
+	// machine deployment.
+	CanaryStatusAnnotation = "kubermatic.io/canary-status"
+	// CanaryOfLabel is set on canary machine deployments, its value is the name of the machine
+	// deployment whose OS image is being rolled out.
+	CanaryOfLabel = "kubermatic.io/canary-of"
+)
+
+// imageFields maps the cloud providers supporting canary rollouts to the field of their
+// provider spec that holds the OS image.
+var imageFields = map[providerconfig.CloudProvider]string{
+	providerconfig.CloudProviderAWS:       "ami",
+	providerconfig.CloudProviderAzure:     "imageID",
+	providerconfig.CloudProviderGoogle:    "customImage",
+	providerconfig.CloudProviderHetzner:   "image",
+	providerconfig.CloudProviderOpenstack: "image",
+	providerconfig.CloudProviderVsphere:   "templateVMName",
+}
+
+// SupportsCanaryRollouts returns true if the OS image of the machines of the given cloud
+// provider can be rolled out using canary machines.
+func SupportsCanaryRollouts(cloudProvider providerconfig.CloudProvider) bool {
+	_, ok := imageFields[cloudProvider]
+	return ok
+}
+
+// GetImage returns the OS image of the machines created from the given spec. An empty image
+// means that the machine-controller picks the default image.
+func GetImage(machineSpec clusterv1alpha1.MachineSpec) (string, error) {
+	return getProviderSpecField(machineSpec, imageFields, "canary rollouts")
+}
+
+// SetImage changes the OS image of the machines created from the given spec.
+func SetImage(machineSpec *clusterv1alpha1.MachineSpec, image string) error {
+	return setProviderSpecField(machineSpec, imageFields, "canary rollouts", image)
+}
+
+// GetCanaryRollout returns the canary rollout settings of the machine deployment, or nil if
+// canary rollouts are disabled.
+func GetCanaryRollout(md *clusterv1alpha1.MachineDeployment) (*apiv1.CanaryRolloutSettings, error) {
+	value := md.Annotations[CanaryRolloutAnnotation]
+	if value == "" {
+		return nil, nil
+	}
+
+	settings := &apiv1.CanaryRolloutSettings{}
+	if err := json.Unmarshal([]byte(value), settings); err != nil {
+		return nil, fmt.Errorf("failed to parse annotation %s: %v", CanaryRolloutAnnotation, err)
+	}
+
+	return settings, nil
+}
+
+// SetCanaryRollout configures the canary rollouts of the machine deployment.
+func SetCanaryRollout(md *clusterv1alpha1.MachineDeployment, settings *apiv1.CanaryRolloutSettings) error {
+	if settings == nil {
+		delete(md.Annotations, CanaryRolloutAnnotation)
+		return nil
+	}
+
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return err
+	}
+	if md.Annotations == nil {
+		md.Annotations = map[string]string{}
+	}
+	md.Annotations[CanaryRolloutAnnotation] = string(data)
+
+	return nil
+}
+
+// GetCanaryRolloutStatus returns the status of the latest canary rollout of the machine
+// deployment, or nil if there was none.
+func GetCanaryRolloutStatus(md *clusterv1alpha1.MachineDeployment) (*apiv1.CanaryRolloutStatus, error) {
+	value := md.Annotations[CanaryStatusAnnotation]
+	if value == "" {
+		return nil, nil
+	}
+
+	status := &apiv1.CanaryRolloutStatus{}
+	if err := json.Unmarshal([]byte(value), status); err != nil {
+		return nil, fmt.Errorf("failed to parse annotation %s: %v", CanaryStatusAnnotation, err)
+	}
+
+	return status, nil
+}
+
+// SetCanaryRolloutStatus records the status of the latest canary rollout of the machine deployment.
+func SetCanaryRolloutStatus(md *clusterv1alpha1.MachineDeployment, status *apiv1.CanaryRolloutStatus) error {
+	data, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+	if md.Annotations == nil {
+		md.Annotations = map[string]string{}
+	}
+	md.Annotations[CanaryStatusAnnotation] = string(data)
+
+	return nil
+}
+
+// DeferImageChange keeps the OS image of an existing machine deployment with canary rollouts
+// when its template is updated. The new image is recorded in an annotation instead, from where
+// it is rolled out once the canary machines passed the validation.
+func DeferImageChange(existing, updated *clusterv1alpha1.MachineDeployment) error {
+	settings, err := GetCanaryRollout(updated)
+	if err != nil || settings == nil {
+		return err
+	}
+
+	current, err := GetImage(existing.Spec.Template.Spec)
+	if err != nil {
+		return err
+	}
+	desired, err := GetImage(updated.Spec.Template.Spec)
+	if err != nil {
+		return err
+	}
+
+	if desired == current {
+		// a new image that has not been rolled out yet is discarded when the image is reverted
+		delete(updated.Annotations, CanaryImageAnnotation)
+		return nil
+	}
+
+	if err := SetImage(&updated.Spec.Template.Spec, current); err != nil {
+		return err
+	}
+	updated.Annotations[CanaryImageAnnotation] = desired
+
+	return nil
+}
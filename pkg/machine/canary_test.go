@@ -0,0 +1,93 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine_test
+
+import (
+	"fmt"
+	"testing"
+
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	"k8c.io/kubermatic/v2/pkg/machine"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func imageMachineDeployment(t *testing.T, image string, settings *apiv1.CanaryRolloutSettings) *clusterv1alpha1.MachineDeployment {
+	md := &clusterv1alpha1.MachineDeployment{}
+	md.Spec.Template.Spec.ProviderSpec.Value = &runtime.RawExtension{Raw: []byte(fmt.Sprintf(`{"cloudProvider":"openstack","cloudProviderSpec":{"image":%q,"flavor":"m1.small"}}`, image))}
+	if err := machine.SetCanaryRollout(md, settings); err != nil {
+		t.Fatalf("failed to set canary rollout: %v", err)
+	}
+	return md
+}
+
+func TestDeferImageChange(t *testing.T) {
+	testCases := []struct {
+		name            string
+		settings        *apiv1.CanaryRolloutSettings
+		existingImage   string
+		updatedImage    string
+		expectedImage   string
+		expectedPending string
+	}{
+		{
+			name:          "image change without canary rollouts",
+			existingImage: "ubuntu-v1",
+			updatedImage:  "ubuntu-v2",
+			expectedImage: "ubuntu-v2",
+		},
+		{
+			name:            "image change with canary rollouts",
+			settings:        &apiv1.CanaryRolloutSettings{Replicas: 1},
+			existingImage:   "ubuntu-v1",
+			updatedImage:    "ubuntu-v2",
+			expectedImage:   "ubuntu-v1",
+			expectedPending: "ubuntu-v2",
+		},
+		{
+			name:          "unchanged image with canary rollouts",
+			settings:      &apiv1.CanaryRolloutSettings{Replicas: 1},
+			existingImage: "ubuntu-v1",
+			updatedImage:  "ubuntu-v1",
+			expectedImage: "ubuntu-v1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			existing := imageMachineDeployment(t, tc.existingImage, tc.settings)
+			updated := imageMachineDeployment(t, tc.updatedImage, tc.settings)
+
+			if err := machine.DeferImageChange(existing, updated); err != nil {
+				t.Fatalf("failed to defer image change: %v", err)
+			}
+
+			image, err := machine.GetImage(updated.Spec.Template.Spec)
+			if err != nil {
+				t.Fatalf("failed to get image: %v", err)
+			}
+			if image != tc.expectedImage {
+				t.Errorf("expected image %q, got %q", tc.expectedImage, image)
+			}
+			if pending := updated.Annotations[machine.CanaryImageAnnotation]; pending != tc.expectedPending {
+				t.Errorf("expected pending image %q, got %q", tc.expectedPending, pending)
+			}
+		})
+	}
+}
@@ -153,7 +153,24 @@ func GetAPIV2NodeCloudSpec(machineSpec clusterv1alpha1.MachineSpec) (*apiv1.Node
 			Zones:          config.Zones,
 			DataDiskSize:   config.DataDiskSize,
 			OSDiskSize:     config.OSDiskSize,
+			Subnet:         config.SubnetName.Value,
 		}
+		securityConfig := AzureSecurityConfig{}
+		if err := json.Unmarshal(decodedProviderSpec.CloudProviderSpec.Raw, &securityConfig); err != nil {
+			return nil, fmt.Errorf("failed to parse Azure config: %v", err)
+		}
+		securityConfig.Apply(cloudSpec.Azure)
+		diagnosticsConfig := AzureDiagnosticsConfig{}
+		if err := json.Unmarshal(decodedProviderSpec.CloudProviderSpec.Raw, &diagnosticsConfig); err != nil {
+			return nil, fmt.Errorf("failed to parse Azure config: %v", err)
+		}
+		cloudSpec.Azure.BootDiagnostics = diagnosticsConfig.BootDiagnosticsStorageURI != ""
+		networkingConfig := AzureNetworkingConfig{}
+		if err := json.Unmarshal(decodedProviderSpec.CloudProviderSpec.Raw, &networkingConfig); err != nil {
+			return nil, fmt.Errorf("failed to parse Azure config: %v", err)
+		}
+		cloudSpec.Azure.EnableAcceleratedNetworking = networkingConfig.EnableAcceleratedNetworking
+		applyAzureImage(config, decodedProviderSpec.OperatingSystem, cloudSpec.Azure)
 	case providerconfig.CloudProviderDigitalocean:
 		config := &digitalocean.RawConfig{}
 		if err := json.Unmarshal(decodedProviderSpec.CloudProviderSpec.Raw, &config); err != nil {
@@ -184,6 +201,7 @@ func GetAPIV2NodeCloudSpec(machineSpec clusterv1alpha1.MachineSpec) (*apiv1.Node
 		cloudSpec.Openstack.UseFloatingIP = config.FloatingIPPool.Value != ""
 		if config.RootDiskSizeGB != nil && *config.RootDiskSizeGB > 0 {
 			cloudSpec.Openstack.RootDiskSizeGB = config.RootDiskSizeGB
+			cloudSpec.Openstack.RootDiskVolumeType = config.RootDiskVolumeType.Value
 		}
 	case providerconfig.CloudProviderHetzner:
 		config := &hetzner.RawConfig{}
@@ -0,0 +1,50 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+)
+
+const (
+	// DatacenterAnnotation holds the name of the additional cloud of the cluster a machine
+	// deployment creates its machines in. It is not set for machine deployments in the cloud
+	// of the cluster.
+	DatacenterAnnotation = "kubermatic.io/datacenter"
+	// CloudProviderLabel is set on the nodes of clusters with additional clouds to the name of
+	// the cloud provider they run on, so that provider specific workloads like CSI drivers can
+	// be kept off the nodes of other providers.
+	CloudProviderLabel = "kubermatic.io/cloud-provider"
+)
+
+// GetDatacenter returns the additional cloud the machine deployment creates its machines in,
+// or an empty string for the cloud of the cluster.
+func GetDatacenter(md *clusterv1alpha1.MachineDeployment) string {
+	return md.Annotations[DatacenterAnnotation]
+}
+
+// SetDatacenter configures the additional cloud the machine deployment creates its machines in.
+func SetDatacenter(md *clusterv1alpha1.MachineDeployment, datacenter string) {
+	if datacenter == "" {
+		delete(md.Annotations, DatacenterAnnotation)
+		return
+	}
+	if md.Annotations == nil {
+		md.Annotations = map[string]string{}
+	}
+	md.Annotations[DatacenterAnnotation] = datacenter
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"encoding/json"
+	"fmt"
+
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+)
+
+const (
+	// DiskLayoutAnnotation holds the JSON-encoded disk layout of a machine deployment.
+	DiskLayoutAnnotation = "kubermatic.io/disk-layout"
+
+	// dataDisksField is the field of the cloud provider spec that holds the data disks.
+	dataDisksField = "dataDisks"
+)
+
+// DataDisk is a data disk in the cloud provider spec of a machine. The machine-controller
+// attaches it to the machine, which formats and mounts it while being bootstrapped.
+type DataDisk struct {
+	// Size of the disk in GB.
+	Size int32 `json:"size"`
+	// Type of the disk, the default of the cloud provider is used if empty.
+	Type string `json:"type,omitempty"`
+	// Device is the path of the block device of the disk on the machine.
+	Device string `json:"device"`
+	// MountPoint is the path the disk is mounted at.
+	MountPoint string `json:"mountPoint"`
+	// Filesystem the disk is formatted with.
+	Filesystem string `json:"filesystem"`
+}
+
+// dataDiskDevices returns the path of the block device of the data disk with the given index,
+// which depends on how the cloud provider attaches the disks.
+var dataDiskDevices = map[providerconfig.CloudProvider]func(index int) string{
+	providerconfig.CloudProviderAWS: func(index int) string {
+		return fmt.Sprintf("/dev/sd%c", 'f'+index)
+	},
+	providerconfig.CloudProviderAzure: func(index int) string {
+		return fmt.Sprintf("/dev/disk/azure/scsi1/lun%d", index)
+	},
+	providerconfig.CloudProviderGoogle: func(index int) string {
+		return fmt.Sprintf("/dev/disk/by-id/google-data-disk-%d", index)
+	},
+	providerconfig.CloudProviderOpenstack: func(index int) string {
+		return fmt.Sprintf("/dev/vd%c", 'b'+index)
+	},
+	providerconfig.CloudProviderVsphere: func(index int) string {
+		return fmt.Sprintf("/dev/sd%c", 'b'+index)
+	},
+}
+
+// MaxDataDisks is the maximum number of data disks of a machine, which keeps the device names
+// of all cloud providers within a single letter.
+const MaxDataDisks = 8
+
+// SupportsDataDisks returns true if data disks can be attached to the machines of the given cloud provider.
+func SupportsDataDisks(cloudProvider providerconfig.CloudProvider) bool {
+	_, ok := dataDiskDevices[cloudProvider]
+	return ok
+}
+
+// NewDataDisks returns the data disks of the machines of the given cloud provider for the data
+// disks of a disk layout.
+func NewDataDisks(cloudProvider providerconfig.CloudProvider, specs []apiv1.DataDiskSpec) ([]DataDisk, error) {
+	if len(specs) == 0 {
+		return nil, nil
+	}
+
+	device, ok := dataDiskDevices[cloudProvider]
+	if !ok {
+		return nil, fmt.Errorf("cloud provider %q does not support data disks", cloudProvider)
+	}
+	if len(specs) > MaxDataDisks {
+		return nil, fmt.Errorf("at most %d data disks are supported", MaxDataDisks)
+	}
+
+	disks := make([]DataDisk, 0, len(specs))
+	for i, spec := range specs {
+		filesystem := spec.Filesystem
+		if filesystem == "" {
+			filesystem = apiv1.DataDiskFilesystemExt4
+		}
+		disks = append(disks, DataDisk{
+			Size:       spec.Size,
+			Type:       spec.Type,
+			Device:     device(i),
+			MountPoint: spec.MountPoint,
+			Filesystem: filesystem,
+		})
+	}
+
+	return disks, nil
+}
+
+// GetDataDisks returns the data disks of the machines created from the given spec.
+func GetDataDisks(machineSpec clusterv1alpha1.MachineSpec) ([]DataDisk, error) {
+	config, err := providerconfig.GetConfig(machineSpec.ProviderSpec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get machine providerConfig: %v", err)
+	}
+
+	cloudSpec := map[string]json.RawMessage{}
+	if err := json.Unmarshal(config.CloudProviderSpec.Raw, &cloudSpec); err != nil {
+		return nil, fmt.Errorf("failed to parse cloud provider spec: %v", err)
+	}
+
+	raw, ok := cloudSpec[dataDisksField]
+	if !ok {
+		return nil, nil
+	}
+
+	var disks []DataDisk
+	if err := json.Unmarshal(raw, &disks); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", dataDisksField, err)
+	}
+
+	return disks, nil
+}
+
+// SetDataDisks adds the data disks to the given provider config.
+func SetDataDisks(config *providerconfig.Config, disks []DataDisk) error {
+	if len(disks) == 0 {
+		return nil
+	}
+
+	cloudSpec := map[string]json.RawMessage{}
+	if err := json.Unmarshal(config.CloudProviderSpec.Raw, &cloudSpec); err != nil {
+		return fmt.Errorf("failed to parse cloud provider spec: %v", err)
+	}
+
+	var err error
+	if cloudSpec[dataDisksField], err = json.Marshal(disks); err != nil {
+		return err
+	}
+	config.CloudProviderSpec.Raw, err = json.Marshal(cloudSpec)
+
+	return err
+}
+
+// GetDiskLayout returns the disk layout of the machine deployment, or nil if it has none.
+func GetDiskLayout(md *clusterv1alpha1.MachineDeployment) (*apiv1.DiskLayout, error) {
+	value := md.Annotations[DiskLayoutAnnotation]
+	if value == "" {
+		return nil, nil
+	}
+
+	layout := &apiv1.DiskLayout{}
+	if err := json.Unmarshal([]byte(value), layout); err != nil {
+		return nil, fmt.Errorf("failed to parse annotation %s: %v", DiskLayoutAnnotation, err)
+	}
+
+	return layout, nil
+}
+
+// SetDiskLayout records the disk layout of the machine deployment, so that it is preserved when
+// the machine deployment is converted back into a node deployment.
+func SetDiskLayout(md *clusterv1alpha1.MachineDeployment, layout *apiv1.DiskLayout) error {
+	if layout == nil {
+		delete(md.Annotations, DiskLayoutAnnotation)
+		return nil
+	}
+
+	data, err := json.Marshal(layout)
+	if err != nil {
+		return err
+	}
+	if md.Annotations == nil {
+		md.Annotations = map[string]string{}
+	}
+	md.Annotations[DiskLayoutAnnotation] = string(data)
+
+	return nil
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestDataDisks(t *testing.T) {
+	disks, err := NewDataDisks(providerconfig.CloudProviderAzure, []apiv1.DataDiskSpec{
+		{Size: 100, Type: "Premium_LRS", MountPoint: "/var/lib/containerd"},
+		{Size: 200, MountPoint: "/mnt/disks/ssd0", Filesystem: apiv1.DataDiskFilesystemXFS},
+	})
+	if err != nil {
+		t.Fatalf("failed to create data disks: %v", err)
+	}
+
+	want := []DataDisk{
+		{Size: 100, Type: "Premium_LRS", Device: "/dev/disk/azure/scsi1/lun0", MountPoint: "/var/lib/containerd", Filesystem: apiv1.DataDiskFilesystemExt4},
+		{Size: 200, Device: "/dev/disk/azure/scsi1/lun1", MountPoint: "/mnt/disks/ssd0", Filesystem: apiv1.DataDiskFilesystemXFS},
+	}
+	if !reflect.DeepEqual(disks, want) {
+		t.Fatalf("expected data disks %+v, got %+v", want, disks)
+	}
+
+	config := &providerconfig.Config{
+		CloudProvider:     providerconfig.CloudProviderAzure,
+		CloudProviderSpec: runtime.RawExtension{Raw: []byte(`{"vmSize":"Standard_D2s_v3"}`)},
+	}
+	if err := SetDataDisks(config, disks); err != nil {
+		t.Fatalf("failed to set data disks: %v", err)
+	}
+
+	raw, err := json.Marshal(config)
+	if err != nil {
+		t.Fatalf("failed to marshal provider config: %v", err)
+	}
+	spec := clusterv1alpha1.MachineSpec{}
+	spec.ProviderSpec.Value = &runtime.RawExtension{Raw: raw}
+
+	got, err := GetDataDisks(spec)
+	if err != nil {
+		t.Fatalf("failed to get data disks: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected data disks %+v, got %+v", want, got)
+	}
+}
+
+func TestNewDataDisksUnsupportedProvider(t *testing.T) {
+	if _, err := NewDataDisks(providerconfig.CloudProviderHetzner, []apiv1.DataDiskSpec{{Size: 10, MountPoint: "/data"}}); err == nil {
+		t.Fatal("expected an error for a cloud provider without data disk support")
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"fmt"
+	"strconv"
+
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+)
+
+const (
+	// ReplicasFrozenAnnotation holds the number of replicas a frozen machine deployment is kept at.
+	ReplicasFrozenAnnotation = "kubermatic.io/replicas-frozen"
+
+	// AutoscalerMinSizeAnnotation and AutoscalerMaxSizeAnnotation enable the cluster autoscaler
+	// for a machine deployment.
+	AutoscalerMinSizeAnnotation = "cluster.k8s.io/cluster-api-autoscaler-node-group-min-size"
+	AutoscalerMaxSizeAnnotation = "cluster.k8s.io/cluster-api-autoscaler-node-group-max-size"
+
+	// frozenAnnotationPrefix is prepended to the autoscaler annotations of frozen machine
+	// deployments, so that the cluster autoscaler ignores them until they are unfrozen.
+	frozenAnnotationPrefix = "frozen.kubermatic.io/"
+)
+
+var autoscalerAnnotations = []string{AutoscalerMinSizeAnnotation, AutoscalerMaxSizeAnnotation}
+
+// GetFrozenReplicas returns the number of replicas the machine deployment is frozen at, or nil
+// if its replicas are not frozen.
+func GetFrozenReplicas(md *clusterv1alpha1.MachineDeployment) (*int32, error) {
+	value, ok := md.Annotations[ReplicasFrozenAnnotation]
+	if !ok {
+		return nil, nil
+	}
+
+	replicas, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse annotation %s: %v", ReplicasFrozenAnnotation, err)
+	}
+	frozen := int32(replicas)
+
+	return &frozen, nil
+}
+
+// FreezeReplicas freezes the machine deployment at its current number of replicas. The cluster
+// autoscaler annotations are moved aside while the machine deployment is frozen.
+func FreezeReplicas(md *clusterv1alpha1.MachineDeployment) {
+	if md.Annotations == nil {
+		md.Annotations = map[string]string{}
+	}
+
+	var replicas int32
+	if md.Spec.Replicas != nil {
+		replicas = *md.Spec.Replicas
+	}
+	md.Annotations[ReplicasFrozenAnnotation] = strconv.Itoa(int(replicas))
+
+	for _, annotation := range autoscalerAnnotations {
+		if value, ok := md.Annotations[annotation]; ok {
+			md.Annotations[frozenAnnotationPrefix+annotation] = value
+			delete(md.Annotations, annotation)
+		}
+	}
+}
+
+// UnfreezeReplicas unfreezes the replicas of the machine deployment and restores its cluster
+// autoscaler annotations.
+func UnfreezeReplicas(md *clusterv1alpha1.MachineDeployment) {
+	delete(md.Annotations, ReplicasFrozenAnnotation)
+
+	for _, annotation := range autoscalerAnnotations {
+		if value, ok := md.Annotations[frozenAnnotationPrefix+annotation]; ok {
+			md.Annotations[annotation] = value
+			delete(md.Annotations, frozenAnnotationPrefix+annotation)
+		}
+	}
+}
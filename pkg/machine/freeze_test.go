@@ -0,0 +1,65 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine_test
+
+import (
+	"testing"
+
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+
+	"k8c.io/kubermatic/v2/pkg/machine"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestFreezeReplicas(t *testing.T) {
+	md := &clusterv1alpha1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				machine.AutoscalerMinSizeAnnotation: "1",
+				machine.AutoscalerMaxSizeAnnotation: "5",
+			},
+		},
+		Spec: clusterv1alpha1.MachineDeploymentSpec{Replicas: pointer.Int32Ptr(3)},
+	}
+
+	machine.FreezeReplicas(md)
+
+	frozen, err := machine.GetFrozenReplicas(md)
+	if err != nil {
+		t.Fatalf("failed to get frozen replicas: %v", err)
+	}
+	if frozen == nil || *frozen != 3 {
+		t.Fatalf("expected the replicas to be frozen at 3, got %v", frozen)
+	}
+	if _, ok := md.Annotations[machine.AutoscalerMinSizeAnnotation]; ok {
+		t.Errorf("expected the autoscaler annotations to be removed, got %v", md.Annotations)
+	}
+
+	machine.UnfreezeReplicas(md)
+
+	if frozen, err = machine.GetFrozenReplicas(md); err != nil || frozen != nil {
+		t.Fatalf("expected the replicas to be unfrozen, got %v (error %v)", frozen, err)
+	}
+	if md.Annotations[machine.AutoscalerMinSizeAnnotation] != "1" || md.Annotations[machine.AutoscalerMaxSizeAnnotation] != "5" {
+		t.Errorf("expected the autoscaler annotations to be restored, got %v", md.Annotations)
+	}
+	if len(md.Annotations) != 2 {
+		t.Errorf("expected no other annotations to be left, got %v", md.Annotations)
+	}
+}
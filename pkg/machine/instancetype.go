@@ -0,0 +1,187 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// FallbackInstanceTypesAnnotation holds the comma-separated instance types, in order of priority,
+	// that a machine deployment falls back to when its instance type runs out of capacity.
+	FallbackInstanceTypesAnnotation = "kubermatic.io/fallback-instance-types"
+	// InstanceTypesInUseAnnotation holds the JSON-encoded number of machines per instance type
+	// of a machine deployment with fallback instance types.
+	InstanceTypesInUseAnnotation = "kubermatic.io/instance-types-in-use"
+	// FallbackOfLabel is set on fallback machine deployments and their machines, its value is the
+	// name of the machine deployment whose machines ran out of capacity.
+	FallbackOfLabel = "kubermatic.io/fallback-of"
+)
+
+// instanceTypeFields maps the cloud providers supporting fallback instance types to the
+// field of their provider spec that holds the instance type.
+var instanceTypeFields = map[providerconfig.CloudProvider]string{
+	providerconfig.CloudProviderAWS:          "instanceType",
+	providerconfig.CloudProviderAzure:        "vmSize",
+	providerconfig.CloudProviderDigitalocean: "size",
+	providerconfig.CloudProviderGoogle:       "machineType",
+	providerconfig.CloudProviderHetzner:      "serverType",
+	providerconfig.CloudProviderOpenstack:    "flavor",
+	providerconfig.CloudProviderPacket:       "instanceType",
+	providerconfig.CloudProviderAlibaba:      "instanceType",
+}
+
+// SupportsFallbackInstanceTypes returns true if the instance type of the machines of the
+// given cloud provider can be changed by falling back to another instance type.
+func SupportsFallbackInstanceTypes(cloudProvider providerconfig.CloudProvider) bool {
+	_, ok := instanceTypeFields[cloudProvider]
+	return ok
+}
+
+// GetFallbackInstanceTypes returns the fallback instance types configured on the machine deployment.
+func GetFallbackInstanceTypes(md *clusterv1alpha1.MachineDeployment) []string {
+	value := md.Annotations[FallbackInstanceTypesAnnotation]
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+// SetFallbackInstanceTypes configures the fallback instance types of the machine deployment.
+func SetFallbackInstanceTypes(md *clusterv1alpha1.MachineDeployment, instanceTypes []string) {
+	if len(instanceTypes) == 0 {
+		delete(md.Annotations, FallbackInstanceTypesAnnotation)
+		return
+	}
+	if md.Annotations == nil {
+		md.Annotations = map[string]string{}
+	}
+	md.Annotations[FallbackInstanceTypesAnnotation] = strings.Join(instanceTypes, ",")
+}
+
+// GetInstanceTypesInUse returns the number of machines per instance type of the machine deployment.
+func GetInstanceTypesInUse(md *clusterv1alpha1.MachineDeployment) (map[string]int32, error) {
+	value := md.Annotations[InstanceTypesInUseAnnotation]
+	if value == "" {
+		return nil, nil
+	}
+
+	inUse := map[string]int32{}
+	if err := json.Unmarshal([]byte(value), &inUse); err != nil {
+		return nil, fmt.Errorf("failed to parse annotation %s: %v", InstanceTypesInUseAnnotation, err)
+	}
+
+	return inUse, nil
+}
+
+// NextInstanceType returns the instance type to fall back to from the given one, or an
+// empty string if all fallback instance types have been used up.
+func NextInstanceType(current string, fallbacks []string) string {
+	for i, instanceType := range fallbacks {
+		if instanceType == current {
+			if i+1 < len(fallbacks) {
+				return fallbacks[i+1]
+			}
+			return ""
+		}
+	}
+
+	if len(fallbacks) > 0 {
+		return fallbacks[0]
+	}
+	return ""
+}
+
+// GetInstanceType returns the instance type of the machines created from the given spec.
+func GetInstanceType(machineSpec clusterv1alpha1.MachineSpec) (string, error) {
+	return getProviderSpecField(machineSpec, instanceTypeFields, "fallback instance types")
+}
+
+// SetInstanceType changes the instance type of the machines created from the given spec.
+func SetInstanceType(machineSpec *clusterv1alpha1.MachineSpec, instanceType string) error {
+	return setProviderSpecField(machineSpec, instanceTypeFields, "fallback instance types", instanceType)
+}
+
+// getProviderSpecField returns the value of the string field of the cloud provider spec that is
+// listed for the cloud provider of the machines in the given fields.
+func getProviderSpecField(machineSpec clusterv1alpha1.MachineSpec, fields map[providerconfig.CloudProvider]string, feature string) (string, error) {
+	_, cloudSpec, field, err := decodeProviderSpecField(machineSpec, fields, feature)
+	if err != nil {
+		return "", err
+	}
+
+	value := providerconfig.ConfigVarString{}
+	if raw, ok := cloudSpec[field]; ok {
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return "", fmt.Errorf("failed to parse %s: %v", field, err)
+		}
+	}
+
+	return value.Value, nil
+}
+
+// setProviderSpecField changes the string field of the cloud provider spec that is listed for the
+// cloud provider of the machines in the given fields.
+func setProviderSpecField(machineSpec *clusterv1alpha1.MachineSpec, fields map[providerconfig.CloudProvider]string, feature, value string) error {
+	config, cloudSpec, field, err := decodeProviderSpecField(*machineSpec, fields, feature)
+	if err != nil {
+		return err
+	}
+
+	if cloudSpec[field], err = json.Marshal(providerconfig.ConfigVarString{Value: value}); err != nil {
+		return err
+	}
+	if config.CloudProviderSpec.Raw, err = json.Marshal(cloudSpec); err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+	machineSpec.ProviderSpec.Value = &runtime.RawExtension{Raw: b}
+
+	return nil
+}
+
+// decodeProviderSpecField decodes the provider spec, keeping all fields of the cloud provider
+// spec other than the given one untouched.
+func decodeProviderSpecField(machineSpec clusterv1alpha1.MachineSpec, fields map[providerconfig.CloudProvider]string, feature string) (*providerconfig.Config, map[string]json.RawMessage, string, error) {
+	config, err := providerconfig.GetConfig(machineSpec.ProviderSpec)
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("failed to get machine providerConfig: %v", err)
+	}
+
+	field, ok := fields[config.CloudProvider]
+	if !ok {
+		return nil, nil, "", fmt.Errorf("cloud provider %q does not support %s", config.CloudProvider, feature)
+	}
+
+	cloudSpec := map[string]json.RawMessage{}
+	if err := json.Unmarshal(config.CloudProviderSpec.Raw, &cloudSpec); err != nil {
+		return nil, nil, "", fmt.Errorf("failed to parse cloud provider spec: %v", err)
+	}
+
+	return config, cloudSpec, field, nil
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine_test
+
+import (
+	"testing"
+
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
+
+	"k8c.io/kubermatic/v2/pkg/machine"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/json"
+)
+
+func TestSetInstanceType(t *testing.T) {
+	spec := clusterv1alpha1.MachineSpec{
+		ProviderSpec: clusterv1alpha1.ProviderSpec{
+			Value: &runtime.RawExtension{Raw: []byte(`{"cloudProvider":"azure","cloudProviderSpec":{"vmSize":{"value":"Standard_B2s"},"osDiskSize":30}}`)},
+		},
+	}
+
+	instanceType, err := machine.GetInstanceType(spec)
+	if err != nil {
+		t.Fatalf("failed to get instance type: %v", err)
+	}
+	if instanceType != "Standard_B2s" {
+		t.Fatalf("expected instance type %q, got %q", "Standard_B2s", instanceType)
+	}
+
+	if err := machine.SetInstanceType(&spec, "Standard_D2s_v3"); err != nil {
+		t.Fatalf("failed to set instance type: %v", err)
+	}
+
+	if instanceType, err = machine.GetInstanceType(spec); err != nil {
+		t.Fatalf("failed to get instance type: %v", err)
+	}
+	if instanceType != "Standard_D2s_v3" {
+		t.Errorf("expected instance type %q, got %q", "Standard_D2s_v3", instanceType)
+	}
+
+	config, err := providerconfig.GetConfig(spec.ProviderSpec)
+	if err != nil {
+		t.Fatalf("failed to get provider config: %v", err)
+	}
+	cloudSpec := map[string]interface{}{}
+	if err := json.Unmarshal(config.CloudProviderSpec.Raw, &cloudSpec); err != nil {
+		t.Fatalf("failed to parse cloud provider spec: %v", err)
+	}
+	if cloudSpec["osDiskSize"] != int64(30) {
+		t.Errorf("expected other fields to be kept, got %v", cloudSpec)
+	}
+}
+
+func TestSetInstanceTypeUnsupportedProvider(t *testing.T) {
+	spec := clusterv1alpha1.MachineSpec{
+		ProviderSpec: clusterv1alpha1.ProviderSpec{
+			Value: &runtime.RawExtension{Raw: []byte(`{"cloudProvider":"vsphere","cloudProviderSpec":{"cpus":2}}`)},
+		},
+	}
+
+	if err := machine.SetInstanceType(&spec, "large"); err == nil {
+		t.Error("expected an error for a cloud provider without instance types")
+	}
+}
+
+func TestNextInstanceType(t *testing.T) {
+	testcases := []struct {
+		name      string
+		current   string
+		fallbacks []string
+		expected  string
+	}{
+		{
+			name:      "primary instance type",
+			current:   "t3.large",
+			fallbacks: []string{"m5.large", "m4.large"},
+			expected:  "m5.large",
+		},
+		{
+			name:      "fallback instance type",
+			current:   "m5.large",
+			fallbacks: []string{"m5.large", "m4.large"},
+			expected:  "m4.large",
+		},
+		{
+			name:      "last fallback instance type",
+			current:   "m4.large",
+			fallbacks: []string{"m5.large", "m4.large"},
+			expected:  "",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if next := machine.NextInstanceType(tc.current, tc.fallbacks); next != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, next)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package objectstorage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/storage"
+)
+
+type azureBlobStore struct {
+	container *storage.Container
+}
+
+func newAzureBlobStore(cfg Config) (*azureBlobStore, error) {
+	baseURL := cfg.Endpoint
+	if baseURL == "" {
+		baseURL = storage.DefaultBaseURL
+	}
+
+	client, err := storage.NewClient(cfg.Credentials.AccountName, cfg.Credentials.AccountKey, baseURL, storage.DefaultAPIVersion, !cfg.Insecure)
+	if err != nil {
+		return nil, err
+	}
+
+	service := client.GetBlobService()
+	return &azureBlobStore{container: service.GetContainerReference(cfg.Bucket)}, nil
+}
+
+// The Azure storage client does not support contexts.
+
+func (s *azureBlobStore) EnsureBucket(_ context.Context) error {
+	_, err := s.container.CreateIfNotExists(nil)
+	return err
+}
+
+func (s *azureBlobStore) Upload(_ context.Context, key string, r io.Reader, _ int64) error {
+	return s.container.GetBlobReference(key).CreateBlockBlobFromReader(r, nil)
+}
+
+func (s *azureBlobStore) Download(_ context.Context, key string, w io.Writer) error {
+	body, err := s.container.GetBlobReference(key).Get(nil)
+	if err != nil {
+		return azureBlobError(err)
+	}
+	defer body.Close()
+
+	_, err = io.Copy(w, body)
+	return err
+}
+
+func (s *azureBlobStore) Stat(_ context.Context, key string) (Object, error) {
+	blob := s.container.GetBlobReference(key)
+	if err := blob.GetProperties(nil); err != nil {
+		return Object{}, azureBlobError(err)
+	}
+	return azureBlobObject(blob), nil
+}
+
+func (s *azureBlobStore) List(_ context.Context, prefix string) ([]Object, error) {
+	var objects []Object
+
+	params := storage.ListBlobsParameters{Prefix: prefix}
+	for {
+		resp, err := s.container.ListBlobs(params)
+		if err != nil {
+			return nil, err
+		}
+		for i := range resp.Blobs {
+			objects = append(objects, azureBlobObject(&resp.Blobs[i]))
+		}
+
+		if resp.NextMarker == "" {
+			return objects, nil
+		}
+		params.Marker = resp.NextMarker
+	}
+}
+
+func (s *azureBlobStore) Delete(_ context.Context, key string) error {
+	_, err := s.container.GetBlobReference(key).DeleteIfExists(nil)
+	return err
+}
+
+func azureBlobObject(blob *storage.Blob) Object {
+	return Object{
+		Key:          blob.Name,
+		Size:         blob.Properties.ContentLength,
+		LastModified: time.Time(blob.Properties.LastModified),
+	}
+}
+
+func azureBlobError(err error) error {
+	var serviceErr storage.AzureStorageServiceError
+	if errors.As(err, &serviceErr) && serviceErr.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+	return err
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package objectstorage
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+	storage "google.golang.org/api/storage/v1"
+)
+
+type gcsStore struct {
+	service   *storage.Service
+	bucket    string
+	projectID string
+}
+
+func newGCSStore(ctx context.Context, cfg Config) (*gcsStore, error) {
+	serviceAccount, err := base64.StdEncoding.DecodeString(cfg.Credentials.ServiceAccount)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding service account: %w", err)
+	}
+
+	sam := map[string]string{}
+	if err := json.Unmarshal(serviceAccount, &sam); err != nil {
+		return nil, fmt.Errorf("failed unmarshaling service account: %w", err)
+	}
+
+	conf, err := google.JWTConfigFromJSON(serviceAccount, storage.DevstorageReadWriteScope)
+	if err != nil {
+		return nil, err
+	}
+
+	service, err := storage.NewService(ctx, option.WithHTTPClient(conf.Client(ctx)))
+	if err != nil {
+		return nil, fmt.Errorf("cannot connect to Google Cloud Storage: %w", err)
+	}
+
+	return &gcsStore{service: service, bucket: cfg.Bucket, projectID: sam["project_id"]}, nil
+}
+
+func (s *gcsStore) EnsureBucket(ctx context.Context) error {
+	_, err := s.service.Buckets.Get(s.bucket).Context(ctx).Do()
+	if err == nil || !gcsNotFound(err) {
+		return err
+	}
+
+	if s.projectID == "" {
+		return errors.New("cannot create bucket, the service account has no project_id")
+	}
+	_, err = s.service.Buckets.Insert(s.projectID, &storage.Bucket{Name: s.bucket}).Context(ctx).Do()
+	return err
+}
+
+func (s *gcsStore) Upload(ctx context.Context, key string, r io.Reader, _ int64) error {
+	_, err := s.service.Objects.Insert(s.bucket, &storage.Object{Name: key}).Media(r).Context(ctx).Do()
+	return err
+}
+
+func (s *gcsStore) Download(ctx context.Context, key string, w io.Writer) error {
+	resp, err := s.service.Objects.Get(s.bucket, key).Context(ctx).Download()
+	if err != nil {
+		return gcsError(err)
+	}
+	defer resp.Body.Close()
+
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
+
+func (s *gcsStore) Stat(ctx context.Context, key string) (Object, error) {
+	object, err := s.service.Objects.Get(s.bucket, key).Context(ctx).Do()
+	if err != nil {
+		return Object{}, gcsError(err)
+	}
+	return gcsObject(object), nil
+}
+
+func (s *gcsStore) List(ctx context.Context, prefix string) ([]Object, error) {
+	var objects []Object
+	err := s.service.Objects.List(s.bucket).Prefix(prefix).Pages(ctx, func(page *storage.Objects) error {
+		for _, object := range page.Items {
+			objects = append(objects, gcsObject(object))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return objects, nil
+}
+
+func (s *gcsStore) Delete(ctx context.Context, key string) error {
+	err := s.service.Objects.Delete(s.bucket, key).Context(ctx).Do()
+	if gcsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func gcsObject(object *storage.Object) Object {
+	// the time is only used to order objects, an unparseable time is treated as the oldest
+	lastModified, _ := time.Parse(time.RFC3339, object.Updated)
+	return Object{Key: object.Name, Size: int64(object.Size), LastModified: lastModified}
+}
+
+func gcsNotFound(err error) bool {
+	var apiErr *googleapi.Error
+	return errors.As(err, &apiErr) && apiErr.Code == http.StatusNotFound
+}
+
+func gcsError(err error) error {
+	if gcsNotFound(err) {
+		return ErrNotFound
+	}
+	return err
+}
@@ -0,0 +1,279 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package objectstorage provides a common interface to the object storages Kubermatic
+// stores artifacts like etcd backups, metering reports and support bundles in.
+package objectstorage
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// Provider is the type of an object storage.
+type Provider string
+
+const (
+	ProviderS3        Provider = "s3"
+	ProviderGCS       Provider = "gcs"
+	ProviderAzureBlob Provider = "azureblob"
+	ProviderSwift     Provider = "swift"
+)
+
+// SupportedProviders are the object storages Kubermatic can store artifacts in.
+var SupportedProviders = []Provider{ProviderS3, ProviderGCS, ProviderAzureBlob, ProviderSwift}
+
+// Purpose is the kind of artifact stored in a bucket. Each purpose can use its own
+// bucket and credentials.
+type Purpose string
+
+const (
+	PurposeBackups         Purpose = "backups"
+	PurposeMeteringReports Purpose = "metering-reports"
+	PurposeSupportBundles  Purpose = "support-bundles"
+)
+
+// Purposes are all kinds of artifacts Kubermatic stores in object storages.
+var Purposes = []Purpose{PurposeBackups, PurposeMeteringReports, PurposeSupportBundles}
+
+// SettingsConfigMapName returns the name of the ConfigMap in the kube-system namespace of
+// the seed which contains the location of the bucket for the given purpose. Backups keep
+// using the ConfigMap which was used when only S3 was supported.
+func SettingsConfigMapName(purpose Purpose) string {
+	if purpose == PurposeBackups {
+		return "s3-settings"
+	}
+	return fmt.Sprintf("%s-storage-settings", purpose)
+}
+
+// CredentialsSecretName returns the name of the Secret in the kube-system namespace of
+// the seed which contains the credentials for the bucket of the given purpose.
+func CredentialsSecretName(purpose Purpose) string {
+	if purpose == PurposeBackups {
+		return "s3-credentials"
+	}
+	return fmt.Sprintf("%s-storage-credentials", purpose)
+}
+
+// Keys in the settings ConfigMap and the credentials Secret.
+const (
+	ProviderKey   = "PROVIDER"
+	BucketNameKey = "BUCKET_NAME"
+	EndpointKey   = "ENDPOINT"
+	RegionKey     = "REGION"
+
+	// S3
+	AccessKeyIDKey     = "ACCESS_KEY_ID"
+	SecretAccessKeyKey = "SECRET_ACCESS_KEY"
+	// Google Cloud Storage, the base64 encoded JSON of a service account
+	ServiceAccountKey = "SERVICE_ACCOUNT"
+	// Azure Blob Storage
+	AccountNameKey = "ACCOUNT_NAME"
+	AccountKeyKey  = "ACCOUNT_KEY"
+	// OpenStack Swift
+	UsernameKey = "USERNAME"
+	PasswordKey = "PASSWORD"
+	DomainKey   = "DOMAIN"
+	TenantKey   = "TENANT"
+)
+
+// DefaultS3Endpoint is used for S3 buckets without an endpoint.
+const DefaultS3Endpoint = "s3.amazonaws.com"
+
+// ErrNotFound is returned when an object does not exist.
+var ErrNotFound = errors.New("object not found")
+
+// Config describes a bucket and how to access it.
+type Config struct {
+	Provider Provider
+	// Bucket is the name of the bucket, or of the container for Azure and Swift.
+	Bucket string
+	// Endpoint is the S3 endpoint, the base URL of the Azure storage service or the
+	// Keystone URL for Swift. It is not used for Google Cloud Storage.
+	Endpoint string
+	// Region is the region of S3 buckets and of the Swift object store.
+	Region string
+	// Insecure disables TLS for S3 and Azure.
+	Insecure bool
+	// RootCAs are used to verify the certificates of S3 and Swift endpoints. The system
+	// certificates are used if not set.
+	RootCAs *x509.CertPool
+
+	Credentials Credentials
+}
+
+// Credentials contains the credentials of all providers, only the ones of the
+// configured provider are used.
+type Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+
+	ServiceAccount string
+
+	AccountName string
+	AccountKey  string
+
+	Username string
+	Password string
+	Domain   string
+	Tenant   string
+}
+
+// ConfigFromData returns the config for the given settings and credentials, which use
+// the keys of the settings ConfigMap and credentials Secret. Settings without a provider
+// are for S3, as this was the only supported provider before.
+func ConfigFromData(data map[string]string) (Config, error) {
+	cfg := Config{
+		Provider: Provider(data[ProviderKey]),
+		Bucket:   data[BucketNameKey],
+		Endpoint: data[EndpointKey],
+		Region:   data[RegionKey],
+		Credentials: Credentials{
+			AccessKeyID:     data[AccessKeyIDKey],
+			SecretAccessKey: data[SecretAccessKeyKey],
+			ServiceAccount:  data[ServiceAccountKey],
+			AccountName:     data[AccountNameKey],
+			AccountKey:      data[AccountKeyKey],
+			Username:        data[UsernameKey],
+			Password:        data[PasswordKey],
+			Domain:          data[DomainKey],
+			Tenant:          data[TenantKey],
+		},
+	}
+
+	if cfg.Provider == "" {
+		cfg.Provider = ProviderS3
+	}
+	if cfg.Provider == ProviderS3 && cfg.Endpoint == "" {
+		cfg.Endpoint = DefaultS3Endpoint
+	}
+
+	return cfg, cfg.Validate()
+}
+
+// Validate returns an error if the config misses settings required by its provider.
+func (c Config) Validate() error {
+	if c.Bucket == "" {
+		return errors.New("bucket name not set")
+	}
+
+	switch c.Provider {
+	case ProviderS3:
+		if c.Endpoint == "" {
+			return errors.New("no S3 endpoint set")
+		}
+	case ProviderGCS:
+		if c.Credentials.ServiceAccount == "" {
+			return errors.New("no service account set for Google Cloud Storage")
+		}
+	case ProviderAzureBlob:
+		if c.Credentials.AccountName == "" || c.Credentials.AccountKey == "" {
+			return errors.New("no account name or key set for Azure Blob Storage")
+		}
+	case ProviderSwift:
+		if c.Endpoint == "" {
+			return errors.New("no Keystone endpoint set for Swift")
+		}
+		if c.Credentials.Username == "" || c.Credentials.Password == "" {
+			return errors.New("no username or password set for Swift")
+		}
+	default:
+		return fmt.Errorf("unsupported object storage provider %q, must be one of %v", c.Provider, SupportedProviders)
+	}
+
+	return nil
+}
+
+// Object is an object in a bucket.
+type Object struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+}
+
+// Store stores objects in a bucket.
+type Store interface {
+	// EnsureBucket creates the bucket if it does not exist yet.
+	EnsureBucket(ctx context.Context) error
+	// Upload stores the content of the reader as the given object. Size is the number
+	// of bytes in the reader, or -1 if unknown.
+	Upload(ctx context.Context, key string, r io.Reader, size int64) error
+	// Download writes the content of the given object to the writer.
+	Download(ctx context.Context, key string, w io.Writer) error
+	// Stat returns ErrNotFound if the object does not exist.
+	Stat(ctx context.Context, key string) (Object, error)
+	// List returns all objects whose keys start with the given prefix.
+	List(ctx context.Context, prefix string) ([]Object, error)
+	// Delete removes the given object, it is no error if the object does not exist.
+	Delete(ctx context.Context, key string) error
+}
+
+// New returns the store for the bucket described by the config.
+func New(ctx context.Context, cfg Config) (Store, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	switch cfg.Provider {
+	case ProviderS3:
+		return newS3Store(cfg)
+	case ProviderGCS:
+		return newGCSStore(ctx, cfg)
+	case ProviderAzureBlob:
+		return newAzureBlobStore(cfg)
+	case ProviderSwift:
+		return newSwiftStore(cfg)
+	}
+
+	// already checked by Validate
+	return nil, fmt.Errorf("unsupported object storage provider %q", cfg.Provider)
+}
+
+// UploadFile stores the given file as the given object.
+func UploadFile(ctx context.Context, store Store, key, filename string) error {
+	f, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	return store.Upload(ctx, key, f, info.Size())
+}
+
+// DownloadFile writes the given object to the given file.
+func DownloadFile(ctx context.Context, store Store, key, filename string) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+
+	if err := store.Download(ctx, key, f); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package objectstorage
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestConfigFromData(t *testing.T) {
+	testCases := []struct {
+		name          string
+		data          map[string]string
+		expected      Config
+		expectedError bool
+	}{
+		{
+			name: "settings without provider are for S3",
+			data: map[string]string{
+				BucketNameKey:      "backups",
+				AccessKeyIDKey:     "key",
+				SecretAccessKeyKey: "secret",
+			},
+			expected: Config{
+				Provider: ProviderS3,
+				Bucket:   "backups",
+				Endpoint: DefaultS3Endpoint,
+				Credentials: Credentials{
+					AccessKeyID:     "key",
+					SecretAccessKey: "secret",
+				},
+			},
+		},
+		{
+			name: "azure blob storage",
+			data: map[string]string{
+				ProviderKey:    string(ProviderAzureBlob),
+				BucketNameKey:  "backups",
+				AccountNameKey: "account",
+				AccountKeyKey:  "key",
+			},
+			expected: Config{
+				Provider: ProviderAzureBlob,
+				Bucket:   "backups",
+				Credentials: Credentials{
+					AccountName: "account",
+					AccountKey:  "key",
+				},
+			},
+		},
+		{
+			name: "swift",
+			data: map[string]string{
+				ProviderKey:   string(ProviderSwift),
+				BucketNameKey: "backups",
+				EndpointKey:   "https://keystone:5000/v3",
+				RegionKey:     "dbl",
+				UsernameKey:   "user",
+				PasswordKey:   "password",
+				DomainKey:     "default",
+				TenantKey:     "kubermatic",
+			},
+			expected: Config{
+				Provider: ProviderSwift,
+				Bucket:   "backups",
+				Endpoint: "https://keystone:5000/v3",
+				Region:   "dbl",
+				Credentials: Credentials{
+					Username: "user",
+					Password: "password",
+					Domain:   "default",
+					Tenant:   "kubermatic",
+				},
+			},
+		},
+		{
+			name:          "no bucket",
+			data:          map[string]string{ProviderKey: string(ProviderS3)},
+			expectedError: true,
+		},
+		{
+			name: "gcs without service account",
+			data: map[string]string{
+				ProviderKey:   string(ProviderGCS),
+				BucketNameKey: "backups",
+			},
+			expectedError: true,
+		},
+		{
+			name: "unsupported provider",
+			data: map[string]string{
+				ProviderKey:   "ftp",
+				BucketNameKey: "backups",
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg, err := ConfigFromData(tc.data)
+			if (err != nil) != tc.expectedError {
+				t.Fatalf("expected error: %v, got: %v", tc.expectedError, err)
+			}
+			if tc.expectedError {
+				return
+			}
+			if diff := deep.Equal(cfg, tc.expected); diff != nil {
+				t.Errorf("unexpected config: %v", diff)
+			}
+		})
+	}
+}
+
+func TestSettingsConfigMapName(t *testing.T) {
+	if name := SettingsConfigMapName(PurposeBackups); name != "s3-settings" {
+		t.Errorf("expected backups to keep using the s3-settings ConfigMap, got %q", name)
+	}
+	if name := CredentialsSecretName(PurposeBackups); name != "s3-credentials" {
+		t.Errorf("expected backups to keep using the s3-credentials Secret, got %q", name)
+	}
+	if name := SettingsConfigMapName(PurposeSupportBundles); name != "support-bundles-storage-settings" {
+		t.Errorf("unexpected settings ConfigMap name %q", name)
+	}
+}
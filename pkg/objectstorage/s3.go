@@ -0,0 +1,108 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package objectstorage
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"net/http"
+
+	"github.com/minio/minio-go"
+)
+
+type s3Store struct {
+	client *minio.Client
+	bucket string
+	region string
+}
+
+func newS3Store(cfg Config) (*s3Store, error) {
+	client, err := minio.NewWithRegion(cfg.Endpoint, cfg.Credentials.AccessKeyID, cfg.Credentials.SecretAccessKey, !cfg.Insecure, cfg.Region)
+	if err != nil {
+		return nil, err
+	}
+	client.SetAppInfo("kubermatic", "v0.1")
+
+	if cfg.RootCAs != nil {
+		client.SetCustomTransport(&http.Transport{
+			TLSClientConfig:    &tls.Config{RootCAs: cfg.RootCAs},
+			DisableCompression: true,
+		})
+	}
+
+	return &s3Store{client: client, bucket: cfg.Bucket, region: cfg.Region}, nil
+}
+
+func (s *s3Store) EnsureBucket(_ context.Context) error {
+	exists, err := s.client.BucketExists(s.bucket)
+	if err != nil || exists {
+		return err
+	}
+	return s.client.MakeBucket(s.bucket, s.region)
+}
+
+func (s *s3Store) Upload(ctx context.Context, key string, r io.Reader, size int64) error {
+	_, err := s.client.PutObjectWithContext(ctx, s.bucket, key, r, size, minio.PutObjectOptions{})
+	return err
+}
+
+func (s *s3Store) Download(ctx context.Context, key string, w io.Writer) error {
+	object, err := s.client.GetObjectWithContext(ctx, s.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return s3Error(err)
+	}
+	defer object.Close()
+
+	_, err = io.Copy(w, object)
+	return s3Error(err)
+}
+
+func (s *s3Store) Stat(_ context.Context, key string) (Object, error) {
+	info, err := s.client.StatObject(s.bucket, key, minio.StatObjectOptions{})
+	if err != nil {
+		return Object{}, s3Error(err)
+	}
+	return Object{Key: info.Key, Size: info.Size, LastModified: info.LastModified}, nil
+}
+
+func (s *s3Store) List(_ context.Context, prefix string) ([]Object, error) {
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+
+	var objects []Object
+	for info := range s.client.ListObjects(s.bucket, prefix, true, doneCh) {
+		if info.Err != nil {
+			return nil, info.Err
+		}
+		objects = append(objects, Object{Key: info.Key, Size: info.Size, LastModified: info.LastModified})
+	}
+
+	return objects, nil
+}
+
+func (s *s3Store) Delete(_ context.Context, key string) error {
+	// S3 does not return an error for objects which do not exist
+	return s.client.RemoveObject(s.bucket, key)
+}
+
+func s3Error(err error) error {
+	if err != nil && minio.ToErrorResponse(err).Code == "NoSuchKey" {
+		return ErrNotFound
+	}
+	return err
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package objectstorage
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gophercloud/gophercloud"
+	goopenstack "github.com/gophercloud/gophercloud/openstack"
+	"github.com/gophercloud/gophercloud/openstack/objectstorage/v1/containers"
+	"github.com/gophercloud/gophercloud/openstack/objectstorage/v1/objects"
+	"github.com/gophercloud/gophercloud/pagination"
+)
+
+type swiftStore struct {
+	client    *gophercloud.ServiceClient
+	container string
+}
+
+func newSwiftStore(cfg Config) (*swiftStore, error) {
+	authClient, err := goopenstack.NewClient(cfg.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.RootCAs != nil {
+		authClient.HTTPClient.Transport = &http.Transport{TLSClientConfig: &tls.Config{RootCAs: cfg.RootCAs}}
+	}
+
+	opts := gophercloud.AuthOptions{
+		IdentityEndpoint: cfg.Endpoint,
+		Username:         cfg.Credentials.Username,
+		Password:         cfg.Credentials.Password,
+		DomainName:       cfg.Credentials.Domain,
+		TenantName:       cfg.Credentials.Tenant,
+	}
+	if err := goopenstack.Authenticate(authClient, opts); err != nil {
+		return nil, err
+	}
+
+	client, err := goopenstack.NewObjectStorageV1(authClient, gophercloud.EndpointOpts{Region: cfg.Region})
+	if err != nil {
+		return nil, err
+	}
+
+	return &swiftStore{client: client, container: cfg.Bucket}, nil
+}
+
+// The gophercloud version in use does not support contexts per request.
+
+func (s *swiftStore) EnsureBucket(_ context.Context) error {
+	// creating a container which already exists is no error in Swift
+	return containers.Create(s.client, s.container, nil).Err
+}
+
+func (s *swiftStore) Upload(_ context.Context, key string, r io.Reader, _ int64) error {
+	return objects.Create(s.client, s.container, key, objects.CreateOpts{Content: r}).Err
+}
+
+func (s *swiftStore) Download(_ context.Context, key string, w io.Writer) error {
+	result := objects.Download(s.client, s.container, key, nil)
+	if result.Err != nil {
+		return swiftError(result.Err)
+	}
+	defer result.Body.Close()
+
+	_, err := io.Copy(w, result.Body)
+	return err
+}
+
+func (s *swiftStore) Stat(_ context.Context, key string) (Object, error) {
+	header, err := objects.Get(s.client, s.container, key, nil).Extract()
+	if err != nil {
+		return Object{}, swiftError(err)
+	}
+	return Object{Key: key, Size: header.ContentLength, LastModified: header.LastModified}, nil
+}
+
+func (s *swiftStore) List(_ context.Context, prefix string) ([]Object, error) {
+	var result []Object
+	err := objects.List(s.client, s.container, objects.ListOpts{Full: true, Prefix: prefix}).EachPage(func(page pagination.Page) (bool, error) {
+		infos, err := objects.ExtractInfo(page)
+		if err != nil {
+			return false, err
+		}
+		for _, info := range infos {
+			result = append(result, Object{Key: info.Name, Size: info.Bytes, LastModified: info.LastModified})
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *swiftStore) Delete(_ context.Context, key string) error {
+	err := objects.Delete(s.client, s.container, key, nil).Err
+	if errors.Is(swiftError(err), ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+func swiftError(err error) error {
+	var notFound gophercloud.ErrDefault404
+	if errors.As(err, &notFound) {
+		return ErrNotFound
+	}
+	return err
+}
@@ -535,7 +535,7 @@ func (a *AmazonEC2) InitializeCloudProvider(cluster *kubermaticv1.Cluster, updat
 		}
 	}
 
-	return cluster, nil
+	return reconcileCloudProviderResources(cluster, update, client.IAM)
 }
 
 // GetCredentialsForCluster returns the credentials for the passed in cloud spec or an error
@@ -0,0 +1,121 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/provider"
+)
+
+// reconcileCloudProviderResources records the IDs and ARNs of the AWS resources of the cluster in
+// its status.
+func reconcileCloudProviderResources(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, client iamiface.IAMAPI) (*kubermaticv1.Cluster, error) {
+	resources, err := cloudProviderResources(cluster, client)
+	if err != nil {
+		return cluster, err
+	}
+	if reflect.DeepEqual(cluster.Status.CloudProviderResources, resources) {
+		return cluster, nil
+	}
+
+	return update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+		updatedCluster.Status.CloudProviderResources = resources
+	})
+}
+
+// cloudProviderResources returns the IDs and ARNs of the AWS resources used by the cluster. The
+// ARNs of the IAM resources contain the account ID, so they are looked up once and taken from the
+// status of the cluster afterwards.
+func cloudProviderResources(cluster *kubermaticv1.Cluster, client iamiface.IAMAPI) ([]kubermaticv1.CloudProviderResource, error) {
+	spec := cluster.Spec.Cloud.AWS
+
+	var resources []kubermaticv1.CloudProviderResource
+	add := func(resourceType, id, finalizer string) {
+		if id == "" {
+			return
+		}
+		resources = append(resources, kubermaticv1.CloudProviderResource{
+			Type:    resourceType,
+			ID:      id,
+			Managed: finalizer != "" && kuberneteshelper.HasFinalizer(cluster, finalizer),
+		})
+	}
+
+	add("vpc", spec.VPCID, "")
+	add("routeTable", spec.RouteTableID, "")
+	add("securityGroup", spec.SecurityGroupID, securityGroupCleanupFinalizer)
+
+	if spec.ControlPlaneRoleARN != "" {
+		arn, err := iamARN(cluster, "controlPlaneRole", spec.ControlPlaneRoleARN, func() (*string, error) {
+			out, err := client.GetRole(&iam.GetRoleInput{RoleName: aws.String(spec.ControlPlaneRoleARN)})
+			if err != nil {
+				return nil, err
+			}
+			return out.Role.Arn, nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get control plane role %q: %v", spec.ControlPlaneRoleARN, err)
+		}
+		add("controlPlaneRole", arn, controlPlaneRoleCleanupFinalizer)
+	}
+
+	if spec.InstanceProfileName != "" {
+		arn, err := iamARN(cluster, "instanceProfile", spec.InstanceProfileName, func() (*string, error) {
+			out, err := client.GetInstanceProfile(&iam.GetInstanceProfileInput{InstanceProfileName: aws.String(spec.InstanceProfileName)})
+			if err != nil {
+				return nil, err
+			}
+			return out.InstanceProfile.Arn, nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get instance profile %q: %v", spec.InstanceProfileName, err)
+		}
+		add("instanceProfile", arn, instanceProfileCleanupFinalizer)
+	}
+
+	return resources, nil
+}
+
+// iamARN returns the ARN of the IAM resource with the given name. Names which already are ARNs are
+// returned as they are, otherwise the ARN is taken from the status of the cluster if it has been
+// recorded for the same name before, or looked up.
+func iamARN(cluster *kubermaticv1.Cluster, resourceType, name string, lookup func() (*string, error)) (string, error) {
+	if strings.HasPrefix(name, "arn:") {
+		return name, nil
+	}
+
+	for _, resource := range cluster.Status.CloudProviderResources {
+		if resource.Type == resourceType && strings.HasSuffix(resource.ID, "/"+name) {
+			return resource.ID, nil
+		}
+	}
+
+	arn, err := lookup()
+	if err != nil {
+		return "", err
+	}
+	return aws.StringValue(arn), nil
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCloudProviderResources(t *testing.T) {
+	const (
+		roleARN    = "arn:aws:iam::123456789012:role/kubernetes-abcd1234-control-plane"
+		profileARN = "arn:aws:iam::123456789012:instance-profile/kubernetes-abcd1234"
+	)
+
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "abcd1234",
+			Finalizers: []string{securityGroupCleanupFinalizer, controlPlaneRoleCleanupFinalizer, instanceProfileCleanupFinalizer},
+		},
+		Spec: kubermaticv1.ClusterSpec{
+			Cloud: kubermaticv1.CloudSpec{
+				AWS: &kubermaticv1.AWSCloudSpec{
+					VPCID:               "vpc-1",
+					RouteTableID:        "rtb-1",
+					SecurityGroupID:     "sg-1",
+					ControlPlaneRoleARN: "kubernetes-abcd1234-control-plane",
+					InstanceProfileName: "kubernetes-abcd1234",
+				},
+			},
+		},
+	}
+
+	lookups := 0
+	client := &fakeInstanceProfileClient{
+		getRole: func(input *iam.GetRoleInput) (*iam.GetRoleOutput, error) {
+			lookups++
+			return &iam.GetRoleOutput{Role: &iam.Role{RoleName: input.RoleName, Arn: aws.String(roleARN)}}, nil
+		},
+		getInstanceProfile: func(input *iam.GetInstanceProfileInput) (*iam.GetInstanceProfileOutput, error) {
+			lookups++
+			return &iam.GetInstanceProfileOutput{InstanceProfile: &iam.InstanceProfile{InstanceProfileName: input.InstanceProfileName, Arn: aws.String(profileARN)}}, nil
+		},
+	}
+
+	expected := []kubermaticv1.CloudProviderResource{
+		{Type: "vpc", ID: "vpc-1"},
+		{Type: "routeTable", ID: "rtb-1"},
+		{Type: "securityGroup", ID: "sg-1", Managed: true},
+		{Type: "controlPlaneRole", ID: roleARN, Managed: true},
+		{Type: "instanceProfile", ID: profileARN, Managed: true},
+	}
+
+	resources, err := cloudProviderResources(cluster, client)
+	if err != nil {
+		t.Fatalf("failed to get resources: %v", err)
+	}
+	if !reflect.DeepEqual(resources, expected) {
+		t.Errorf("expected resources\n%+v\ngot\n%+v", expected, resources)
+	}
+
+	// once recorded, the ARNs are not looked up again
+	cluster.Status.CloudProviderResources = resources
+	if resources, err = cloudProviderResources(cluster, client); err != nil {
+		t.Fatalf("failed to get resources: %v", err)
+	}
+	if !reflect.DeepEqual(resources, expected) {
+		t.Errorf("expected resources\n%+v\ngot\n%+v", expected, resources)
+	}
+	if lookups != 2 {
+		t.Errorf("expected the ARNs to be looked up once, got %d lookups", lookups)
+	}
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Azure/go-autorest/autorest/to"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/provider"
+)
+
+// adoptableResource is a pre-existing resource type Kubermatic can take over.
+type adoptableResource struct {
+	finalizer string
+	name      func(cloud kubermaticv1.CloudSpec) string
+	// tags returns the tags of the resource, it is nil for resources which do not support tags.
+	tags func(ctx context.Context, cloud kubermaticv1.CloudSpec, credentials Credentials) (map[string]*string, error)
+}
+
+// adoptableResources are the resource types that can be listed in the adopted resources of the
+// cloud spec, in the order they are adopted.
+var adoptableResources = []struct {
+	resourceType string
+	adoptableResource
+}{
+	{"resourceGroup", adoptableResource{
+		finalizer: FinalizerResourceGroup,
+		name:      func(cloud kubermaticv1.CloudSpec) string { return cloud.Azure.ResourceGroup },
+		tags: func(ctx context.Context, cloud kubermaticv1.CloudSpec, credentials Credentials) (map[string]*string, error) {
+			client, err := getGroupsClient(cloud, credentials)
+			if err != nil {
+				return nil, err
+			}
+			group, err := client.Get(ctx, cloud.Azure.ResourceGroup)
+			return group.Tags, err
+		},
+	}},
+	{"vnet", adoptableResource{
+		finalizer: FinalizerVNet,
+		name:      func(cloud kubermaticv1.CloudSpec) string { return cloud.Azure.VNetName },
+		tags: func(ctx context.Context, cloud kubermaticv1.CloudSpec, credentials Credentials) (map[string]*string, error) {
+			client, err := getNetworksClient(cloud, credentials)
+			if err != nil {
+				return nil, err
+			}
+			vnet, err := client.Get(ctx, vnetResourceGroup(cloud), cloud.Azure.VNetName, "")
+			return vnet.Tags, err
+		},
+	}},
+	{"subnet", adoptableResource{
+		finalizer: FinalizerSubnet,
+		name:      func(cloud kubermaticv1.CloudSpec) string { return cloud.Azure.SubnetName },
+	}},
+	{"routeTable", adoptableResource{
+		finalizer: FinalizerRouteTable,
+		name:      func(cloud kubermaticv1.CloudSpec) string { return cloud.Azure.RouteTableName },
+		tags: func(ctx context.Context, cloud kubermaticv1.CloudSpec, credentials Credentials) (map[string]*string, error) {
+			client, err := getRouteTablesClient(cloud, credentials)
+			if err != nil {
+				return nil, err
+			}
+			routeTable, err := client.Get(ctx, RouteTableResourceGroup(cloud), cloud.Azure.RouteTableName, "")
+			return routeTable.Tags, err
+		},
+	}},
+	{"securityGroup", adoptableResource{
+		finalizer: FinalizerSecurityGroup,
+		name:      func(cloud kubermaticv1.CloudSpec) string { return cloud.Azure.SecurityGroup },
+		tags: func(ctx context.Context, cloud kubermaticv1.CloudSpec, credentials Credentials) (map[string]*string, error) {
+			client, err := getSecurityGroupsClient(cloud, credentials)
+			if err != nil {
+				return nil, err
+			}
+			sg, err := client.Get(ctx, SecurityGroupResourceGroup(cloud), cloud.Azure.SecurityGroup, "")
+			return sg.Tags, err
+		},
+	}},
+}
+
+// validateAdoptedResources checks that the adopted resources are known resource types which are
+// referenced in the cloud spec.
+func validateAdoptedResources(cloud kubermaticv1.CloudSpec) error {
+	seen := map[string]struct{}{}
+
+	for _, resourceType := range cloud.Azure.AdoptedResources {
+		resource, ok := findAdoptableResource(resourceType)
+		if !ok {
+			return fmt.Errorf("resources of type %q cannot be adopted", resourceType)
+		}
+		if _, ok := seen[resourceType]; ok {
+			return fmt.Errorf("duplicate adopted resource %q", resourceType)
+		}
+		seen[resourceType] = struct{}{}
+
+		if resource.name(cloud) == "" {
+			return fmt.Errorf("cannot adopt %s, no existing resource is referenced", resourceType)
+		}
+	}
+
+	return nil
+}
+
+func findAdoptableResource(resourceType string) (adoptableResource, bool) {
+	for _, r := range adoptableResources {
+		if r.resourceType == resourceType {
+			return r.adoptableResource, true
+		}
+	}
+	return adoptableResource{}, false
+}
+
+// isAdopted returns true if the given resource type is listed in the adopted resources of the cloud spec.
+func isAdopted(cloud kubermaticv1.CloudSpec, resourceType string) bool {
+	for _, adopted := range cloud.Azure.AdoptedResources {
+		if adopted == resourceType {
+			return true
+		}
+	}
+	return false
+}
+
+// verifyOwnership makes sure a resource is not owned by another cluster, based on its cluster tag.
+func verifyOwnership(tags map[string]*string, clusterName string) error {
+	if owner := to.String(tags[clusterTagKey]); owner != "" && owner != clusterName {
+		return fmt.Errorf("it is owned by cluster %q", owner)
+	}
+	return nil
+}
+
+// adoptResources takes over the pre-existing resources listed in the adopted resources of the
+// cloud spec, by adding their finalizers to the cluster. From then on they are managed like the
+// resources created by Kubermatic, so reconcileTags adds the missing cluster tags.
+func (a *Azure) adoptResources(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, credentials Credentials) (*kubermaticv1.Cluster, error) {
+	var err error
+
+	for _, r := range adoptableResources {
+		if !isAdopted(cluster.Spec.Cloud, r.resourceType) || kuberneteshelper.HasFinalizer(cluster, r.finalizer) {
+			continue
+		}
+
+		name := r.name(cluster.Spec.Cloud)
+		if name == "" {
+			continue
+		}
+
+		if r.tags != nil {
+			tags, err := r.tags(a.ctx, cluster.Spec.Cloud, credentials)
+			if err != nil {
+				return cluster, fmt.Errorf("failed to get %s %q: %w", r.resourceType, name, err)
+			}
+			if err := verifyOwnership(tags, cluster.Name); err != nil {
+				err = fmt.Errorf("cannot adopt %s %q: %w", r.resourceType, name, err)
+				a.recordError(cluster, "FailedToAdoptResource", err)
+				return cluster, err
+			}
+		}
+
+		a.log.Infow("adopting resource", "cluster", cluster.Name, "type", r.resourceType, "name", name)
+		cluster, err = update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+			kuberneteshelper.AddFinalizer(updatedCluster, r.finalizer)
+		})
+		if err != nil {
+			return nil, err
+		}
+		a.recordEvent(cluster, "AdoptedResource", "Adopted %s %q", r.resourceType, name)
+	}
+
+	return cluster, nil
+}
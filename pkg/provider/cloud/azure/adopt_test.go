@@ -0,0 +1,110 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest/to"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+)
+
+func TestValidateAdoptedResources(t *testing.T) {
+	testCases := []struct {
+		name          string
+		spec          kubermaticv1.AzureCloudSpec
+		expectedError bool
+	}{
+		{
+			name: "nothing adopted",
+			spec: kubermaticv1.AzureCloudSpec{ResourceGroup: "existing"},
+		},
+		{
+			name: "referenced resources adopted",
+			spec: kubermaticv1.AzureCloudSpec{
+				ResourceGroup:    "existing",
+				VNetName:         "existing",
+				SubnetName:       "existing",
+				AdoptedResources: []string{"resourceGroup", "vnet", "subnet"},
+			},
+		},
+		{
+			name: "unknown resource type",
+			spec: kubermaticv1.AzureCloudSpec{
+				AvailabilitySet:  "existing",
+				AdoptedResources: []string{"availabilitySet"},
+			},
+			expectedError: true,
+		},
+		{
+			name: "duplicate resource type",
+			spec: kubermaticv1.AzureCloudSpec{
+				VNetName:         "existing",
+				AdoptedResources: []string{"vnet", "vnet"},
+			},
+			expectedError: true,
+		},
+		{
+			name: "resource not referenced",
+			spec: kubermaticv1.AzureCloudSpec{
+				AdoptedResources: []string{"securityGroup"},
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			spec := tc.spec
+			err := validateAdoptedResources(kubermaticv1.CloudSpec{Azure: &spec})
+			if (err != nil) != tc.expectedError {
+				t.Fatalf("expected error: %v, got: %v", tc.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestVerifyOwnership(t *testing.T) {
+	testCases := []struct {
+		name          string
+		tags          map[string]*string
+		expectedError bool
+	}{
+		{
+			name: "untagged resource",
+		},
+		{
+			name: "resource owned by the cluster",
+			tags: map[string]*string{clusterTagKey: to.StringPtr("cluster-a")},
+		},
+		{
+			name:          "resource owned by another cluster",
+			tags:          map[string]*string{clusterTagKey: to.StringPtr("cluster-b"), "team": to.StringPtr("x")},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := verifyOwnership(tc.tags, "cluster-a")
+			if (err != nil) != tc.expectedError {
+				t.Fatalf("expected error: %v, got: %v", tc.expectedError, err)
+			}
+		})
+	}
+}
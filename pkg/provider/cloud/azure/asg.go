@@ -0,0 +1,233 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-06-01/network"
+	network2020 "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	autorestazure "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/to"
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/provider"
+)
+
+// reconcileApplicationSecurityGroup creates the application security group if it does not exist
+// and adds the NICs of all nodes in the cluster's subnet to it. It has to run before the security
+// group is created, as its rules reference the application security group.
+func (a *Azure) reconcileApplicationSecurityGroup(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, credentials Credentials, location string, tags map[string]*string) (*kubermaticv1.Cluster, error) {
+	var err error
+	name := cluster.Spec.Cloud.Azure.ApplicationSecurityGroup
+	if name == "" {
+		return cluster, nil
+	}
+
+	asgClient, err := getApplicationSecurityGroupsClient(cluster.Spec.Cloud, credentials)
+	if err != nil {
+		return cluster, err
+	}
+
+	_, err = asgClient.Get(a.ctx, cluster.Spec.Cloud.Azure.ResourceGroup, name)
+	if err != nil {
+		if !isNotFound(err) {
+			return cluster, fmt.Errorf("failed to get application security group %q: %w", name, err)
+		}
+
+		a.log.With("cluster", cluster.Name).Infow("creating application security group", "applicationSecurityGroup", name)
+		if err = a.pollOperation(cluster, update, credentials, "create-application-security-group", func() (autorestazure.FutureAPI, error) {
+			future, err := asgClient.CreateOrUpdate(a.ctx, cluster.Spec.Cloud.Azure.ResourceGroup, name, network.ApplicationSecurityGroup{
+				Name:     to.StringPtr(name),
+				Location: to.StringPtr(location),
+				Tags:     tags,
+			})
+			if err != nil {
+				return nil, err
+			}
+			return future.FutureAPI, nil
+		}); err != nil {
+			return cluster, fmt.Errorf("failed to create application security group %q: %w", name, err)
+		}
+
+		// only application security groups created by Kubermatic are deleted with the cluster
+		cluster, err = update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+			kuberneteshelper.AddFinalizer(updatedCluster, FinalizerApplicationSecurityGroup)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := a.assignNodesToApplicationSecurityGroup(cluster, credentials); err != nil {
+		return cluster, fmt.Errorf("failed to add nodes to application security group %q: %w", name, err)
+	}
+
+	return cluster, nil
+}
+
+// assignNodesToApplicationSecurityGroup adds the network interfaces in the cluster's subnet to the
+// application security group. The NICs are created by the machine-controller, which does not know
+// about application security groups, so new nodes are added as the cluster is reconciled.
+func (a *Azure) assignNodesToApplicationSecurityGroup(cluster *kubermaticv1.Cluster, credentials Credentials) error {
+	interfacesClient, err := getInterfacesClient(cluster.Spec.Cloud, credentials)
+	if err != nil {
+		return err
+	}
+
+	asgID := assembleApplicationSecurityGroupID(cluster.Spec.Cloud, credentials.SubscriptionID)
+	subnetID := assembleSubnetIDForSubscription(cluster.Spec.Cloud, credentials.SubscriptionID)
+
+	iter, err := interfacesClient.ListComplete(a.ctx, cluster.Spec.Cloud.Azure.ResourceGroup)
+	if err != nil {
+		return fmt.Errorf("failed to list network interfaces: %w", err)
+	}
+
+	for ; iter.NotDone(); err = iter.NextWithContext(a.ctx) {
+		if err != nil {
+			return fmt.Errorf("failed to list network interfaces: %w", err)
+		}
+
+		nic := iter.Value()
+		if !addToApplicationSecurityGroup(&nic, subnetID, asgID) {
+			continue
+		}
+
+		a.log.With("cluster", cluster.Name).Infow("adding network interface to application security group", "networkInterface", to.String(nic.Name))
+		if _, err := interfacesClient.CreateOrUpdate(a.ctx, cluster.Spec.Cloud.Azure.ResourceGroup, to.String(nic.Name), nic); err != nil {
+			return fmt.Errorf("failed to update network interface %q: %w", to.String(nic.Name), err)
+		}
+	}
+
+	return nil
+}
+
+// cleanUpApplicationSecurityGroup deletes the application security group, if it was created by
+// Kubermatic. It has to run after the security group has been deleted.
+func (a *Azure) cleanUpApplicationSecurityGroup(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, credentials Credentials, logger *zap.SugaredLogger) (*kubermaticv1.Cluster, error) {
+	if !kuberneteshelper.HasFinalizer(cluster, FinalizerApplicationSecurityGroup) {
+		return cluster, nil
+	}
+
+	name := cluster.Spec.Cloud.Azure.ApplicationSecurityGroup
+	logger.Infow("deleting application security group", "applicationSecurityGroup", name)
+	if err := a.pollOperation(cluster, update, credentials, "delete-application-security-group", func() (autorestazure.FutureAPI, error) {
+		asgClient, err := getApplicationSecurityGroupsClient(cluster.Spec.Cloud, credentials)
+		if err != nil {
+			return nil, err
+		}
+
+		future, err := asgClient.Delete(a.ctx, cluster.Spec.Cloud.Azure.ResourceGroup, name)
+		if err != nil {
+			return nil, err
+		}
+		return future.FutureAPI, nil
+	}); err != nil {
+		if !isNotFound(err) {
+			return cluster, fmt.Errorf("failed to delete application security group %q: %w", name, err)
+		}
+	}
+
+	return update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+		kuberneteshelper.RemoveFinalizer(updatedCluster, FinalizerApplicationSecurityGroup)
+	})
+}
+
+// addToApplicationSecurityGroup adds the application security group to the IP configurations of
+// the NIC which are in the given subnet. It returns true if the NIC was changed.
+func addToApplicationSecurityGroup(nic *network.Interface, subnetID, asgID string) bool {
+	if nic.InterfacePropertiesFormat == nil || nic.IPConfigurations == nil {
+		return false
+	}
+
+	changed := false
+	for i, ipConfig := range *nic.IPConfigurations {
+		props := ipConfig.InterfaceIPConfigurationPropertiesFormat
+		if props == nil || props.Subnet == nil || !strings.EqualFold(to.String(props.Subnet.ID), subnetID) {
+			continue
+		}
+
+		var groups []network.ApplicationSecurityGroup
+		if props.ApplicationSecurityGroups != nil {
+			groups = *props.ApplicationSecurityGroups
+		}
+		if hasApplicationSecurityGroup(groups, asgID) {
+			continue
+		}
+
+		groups = append(groups, network.ApplicationSecurityGroup{ID: to.StringPtr(asgID)})
+		(*nic.IPConfigurations)[i].ApplicationSecurityGroups = &groups
+		changed = true
+	}
+
+	return changed
+}
+
+func hasApplicationSecurityGroup(groups []network.ApplicationSecurityGroup, asgID string) bool {
+	for _, group := range groups {
+		if strings.EqualFold(to.String(group.ID), asgID) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// targetApplicationSecurityGroup makes the inbound rules apply to the members of the application
+// security group instead of all destination addresses.
+func targetApplicationSecurityGroup(rules []network2020.SecurityRule, asgID string) {
+	for i := range rules {
+		props := rules[i].SecurityRulePropertiesFormat
+		if props == nil || props.Direction != network2020.SecurityRuleDirectionInbound {
+			continue
+		}
+
+		props.DestinationAddressPrefix = nil
+		props.DestinationApplicationSecurityGroups = &[]network2020.ApplicationSecurityGroup{{ID: to.StringPtr(asgID)}}
+	}
+}
+
+// assembleApplicationSecurityGroupID returns the full ID of the cluster's application security group.
+func assembleApplicationSecurityGroupID(cloud kubermaticv1.CloudSpec, subscriptionID string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/applicationSecurityGroups/%s",
+		subscriptionID, cloud.Azure.ResourceGroup, cloud.Azure.ApplicationSecurityGroup)
+}
+
+func getApplicationSecurityGroupsClient(cloud kubermaticv1.CloudSpec, credentials Credentials) (*network.ApplicationSecurityGroupsClient, error) {
+	var err error
+	asgClient := network.NewApplicationSecurityGroupsClient(credentials.SubscriptionID)
+	err = configureClient(&asgClient.Client, credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authorizer: %s", err.Error())
+	}
+
+	return &asgClient, nil
+}
+
+func getInterfacesClient(cloud kubermaticv1.CloudSpec, credentials Credentials) (*network.InterfacesClient, error) {
+	var err error
+	interfacesClient := network.NewInterfacesClient(credentials.SubscriptionID)
+	err = configureClient(&interfacesClient.Client, credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authorizer: %s", err.Error())
+	}
+
+	return &interfacesClient, nil
+}
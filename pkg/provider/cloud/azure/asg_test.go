@@ -0,0 +1,123 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-06-01/network"
+	network2020 "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+)
+
+func TestAddToApplicationSecurityGroup(t *testing.T) {
+	const (
+		subnetID = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/vnet/subnets/subnet"
+		asgID    = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/applicationSecurityGroups/asg"
+	)
+
+	nicInSubnet := func(id string, groups ...string) network.Interface {
+		var asgs []network.ApplicationSecurityGroup
+		for _, group := range groups {
+			asgs = append(asgs, network.ApplicationSecurityGroup{ID: to.StringPtr(group)})
+		}
+		return network.Interface{InterfacePropertiesFormat: &network.InterfacePropertiesFormat{
+			IPConfigurations: &[]network.InterfaceIPConfiguration{{
+				InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{
+					Subnet:                    &network.Subnet{ID: to.StringPtr(id)},
+					ApplicationSecurityGroups: &asgs,
+				},
+			}},
+		}}
+	}
+
+	tests := []struct {
+		name           string
+		nic            network.Interface
+		changed        bool
+		expectedGroups int
+	}{
+		{
+			name:           "NIC in the cluster subnet is added",
+			nic:            nicInSubnet(subnetID),
+			changed:        true,
+			expectedGroups: 1,
+		},
+		{
+			name:           "other groups of the NIC are kept",
+			nic:            nicInSubnet(subnetID, "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/applicationSecurityGroups/other"),
+			changed:        true,
+			expectedGroups: 2,
+		},
+		{
+			name:           "NIC already in the group",
+			nic:            nicInSubnet(subnetID, "/subscriptions/sub/resourceGroups/RG/providers/Microsoft.Network/applicationSecurityGroups/asg"),
+			changed:        false,
+			expectedGroups: 1,
+		},
+		{
+			name:           "NIC in another subnet",
+			nic:            nicInSubnet("/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/vnet/subnets/other"),
+			changed:        false,
+			expectedGroups: 0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if changed := addToApplicationSecurityGroup(&test.nic, subnetID, asgID); changed != test.changed {
+				t.Errorf("expected changed to be %v, got %v", test.changed, changed)
+			}
+			groups := (*test.nic.IPConfigurations)[0].ApplicationSecurityGroups
+			if len(*groups) != test.expectedGroups {
+				t.Errorf("expected %d application security groups, got %d", test.expectedGroups, len(*groups))
+			}
+		})
+	}
+}
+
+func TestTargetApplicationSecurityGroup(t *testing.T) {
+	const asgID = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/applicationSecurityGroups/asg"
+
+	rules := []network2020.SecurityRule{
+		icmpAllowRule(kubermaticv1.CloudSpec{Azure: &kubermaticv1.AzureCloudSpec{}}),
+		{
+			Name: to.StringPtr("outbound_allow_all"),
+			SecurityRulePropertiesFormat: &network2020.SecurityRulePropertiesFormat{
+				Direction:                network2020.SecurityRuleDirectionOutbound,
+				DestinationAddressPrefix: to.StringPtr("*"),
+			},
+		},
+	}
+
+	targetApplicationSecurityGroup(rules, asgID)
+
+	inbound := rules[0].SecurityRulePropertiesFormat
+	if inbound.DestinationAddressPrefix != nil {
+		t.Errorf("expected the destination address prefix of inbound rules to be removed, got %q", *inbound.DestinationAddressPrefix)
+	}
+	if inbound.DestinationApplicationSecurityGroups == nil || to.String((*inbound.DestinationApplicationSecurityGroups)[0].ID) != asgID {
+		t.Errorf("expected inbound rules to target the application security group")
+	}
+
+	outbound := rules[1].SecurityRulePropertiesFormat
+	if to.String(outbound.DestinationAddressPrefix) != "*" || outbound.DestinationApplicationSecurityGroups != nil {
+		t.Errorf("expected outbound rules to be left untouched")
+	}
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"fmt"
+	"strings"
+
+	network2020 "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	autorestazure "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/to"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/provider"
+)
+
+// subnetAssociations are the IDs of the security group and the route table the cluster's subnet
+// has to be associated with. Empty IDs are not checked.
+type subnetAssociations struct {
+	securityGroupID string
+	routeTableID    string
+}
+
+// desiredSubnetAssociations returns the associations of the cluster's subnet with the security
+// group and the route table of the cluster.
+func desiredSubnetAssociations(cloud kubermaticv1.CloudSpec, subscriptionID string) subnetAssociations {
+	var associations subnetAssociations
+	if cloud.Azure.SecurityGroup != "" {
+		associations.securityGroupID = assembleSecurityGroupID(cloud, subscriptionID, cloud.Azure.SecurityGroup)
+	}
+	if cloud.Azure.RouteTableName != "" {
+		associations.routeTableID = assembleRouteTableID(cloud, subscriptionID)
+	}
+
+	return associations
+}
+
+// driftedSubnetAssociations returns the names of the associations of the subnet which do not
+// match the desired ones, e.g. because the security group or the route table was detached in the
+// portal.
+func driftedSubnetAssociations(subnet network2020.Subnet, desired subnetAssociations) []string {
+	var drifted []string
+
+	if desired.securityGroupID != "" {
+		if subnet.SubnetPropertiesFormat == nil || subnet.NetworkSecurityGroup == nil || !strings.EqualFold(to.String(subnet.NetworkSecurityGroup.ID), desired.securityGroupID) {
+			drifted = append(drifted, "security group")
+		}
+	}
+	if desired.routeTableID != "" {
+		if subnet.SubnetPropertiesFormat == nil || subnet.RouteTable == nil || !strings.EqualFold(to.String(subnet.RouteTable.ID), desired.routeTableID) {
+			drifted = append(drifted, "route table")
+		}
+	}
+
+	return drifted
+}
+
+// associateSubnet sets the desired security group and route table on the subnet, leaving all
+// other properties as they are.
+func associateSubnet(subnet network2020.Subnet, desired subnetAssociations) network2020.Subnet {
+	if subnet.SubnetPropertiesFormat == nil {
+		subnet.SubnetPropertiesFormat = &network2020.SubnetPropertiesFormat{}
+	}
+	if desired.securityGroupID != "" {
+		subnet.NetworkSecurityGroup = &network2020.SecurityGroup{ID: to.StringPtr(desired.securityGroupID)}
+	}
+	if desired.routeTableID != "" {
+		subnet.RouteTable = &network2020.RouteTable{ID: to.StringPtr(desired.routeTableID)}
+	}
+
+	return subnet
+}
+
+// reconcileSubnetAssociations verifies that the cluster's subnet is still associated with the
+// security group and the route table of the cluster and restores the associations otherwise.
+// Without them new nodes lose the rules for the traffic between nodes and the routes to the pods.
+func (a *Azure) reconcileSubnetAssociations(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, credentials Credentials) (*kubermaticv1.Cluster, error) {
+	cloud := cluster.Spec.Cloud
+	if cloud.Azure.SubnetName == "" {
+		return cluster, nil
+	}
+
+	desired := desiredSubnetAssociations(cloud, credentials.SubscriptionID)
+	if desired.securityGroupID == "" && desired.routeTableID == "" {
+		return cluster, nil
+	}
+
+	subnetsClient, err := getPrivateLinkSubnetsClient(cloud, credentials)
+	if err != nil {
+		return cluster, err
+	}
+
+	subnet, err := subnetsClient.Get(a.ctx, vnetResourceGroup(cloud), cloud.Azure.VNetName, cloud.Azure.SubnetName, "")
+	if err != nil {
+		return cluster, fmt.Errorf("failed to get subnetwork %q: %w", cloud.Azure.SubnetName, err)
+	}
+
+	drifted := driftedSubnetAssociations(subnet, desired)
+	if len(drifted) == 0 {
+		return cluster, nil
+	}
+
+	a.log.With("cluster", cluster.Name).Infow("restoring subnet associations", "subnet", cloud.Azure.SubnetName, "associations", drifted)
+	a.recordWarning(cluster, "SubnetAssociationDrift", "The %s of subnet %q changed outside of Kubermatic, restoring it", strings.Join(drifted, " and "), cloud.Azure.SubnetName)
+
+	if err = a.pollOperation(cluster, update, credentials, "associate-subnet", func() (autorestazure.FutureAPI, error) {
+		future, err := subnetsClient.CreateOrUpdate(a.ctx, vnetResourceGroup(cloud), cloud.Azure.VNetName, cloud.Azure.SubnetName, associateSubnet(subnet, desired))
+		if err != nil {
+			return nil, err
+		}
+
+		return future.FutureAPI, nil
+	}); err != nil {
+		a.recordError(cluster, "FailedToAssociateSubnet", err)
+		return cluster, fmt.Errorf("failed to restore the associations of subnetwork %q: %w", cloud.Azure.SubnetName, err)
+	}
+	a.recordEvent(cluster, "AssociatedSubnet", "Restored the %s of subnet %q", strings.Join(drifted, " and "), cloud.Azure.SubnetName)
+
+	return cluster, nil
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"reflect"
+	"testing"
+
+	network2020 "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+)
+
+const (
+	clusterSecurityGroupID = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/networkSecurityGroups/cluster-abc"
+	clusterRouteTableID    = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/routeTables/cluster-abc"
+)
+
+func TestDesiredSubnetAssociations(t *testing.T) {
+	tests := []struct {
+		name     string
+		azure    kubermaticv1.AzureCloudSpec
+		expected subnetAssociations
+	}{
+		{
+			name:  "security group and route table created by Kubermatic",
+			azure: kubermaticv1.AzureCloudSpec{ResourceGroup: "rg", SecurityGroup: "cluster-abc", RouteTableName: "cluster-abc"},
+			expected: subnetAssociations{
+				securityGroupID: clusterSecurityGroupID,
+				routeTableID:    clusterRouteTableID,
+			},
+		},
+		{
+			name:  "security group and route table referenced by ID",
+			azure: kubermaticv1.AzureCloudSpec{ResourceGroup: "other", SecurityGroup: "cluster-abc", SecurityGroupID: clusterSecurityGroupID, RouteTableName: "cluster-abc", RouteTableID: clusterRouteTableID},
+			expected: subnetAssociations{
+				securityGroupID: clusterSecurityGroupID,
+				routeTableID:    clusterRouteTableID,
+			},
+		},
+		{
+			name:     "no route table",
+			azure:    kubermaticv1.AzureCloudSpec{ResourceGroup: "rg", SecurityGroup: "cluster-abc"},
+			expected: subnetAssociations{securityGroupID: clusterSecurityGroupID},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			azure := test.azure
+			if associations := desiredSubnetAssociations(kubermaticv1.CloudSpec{Azure: &azure}, "sub"); associations != test.expected {
+				t.Fatalf("expected %+v, got %+v", test.expected, associations)
+			}
+		})
+	}
+}
+
+func TestDriftedSubnetAssociations(t *testing.T) {
+	desired := subnetAssociations{securityGroupID: clusterSecurityGroupID, routeTableID: clusterRouteTableID}
+
+	tests := []struct {
+		name     string
+		subnet   network2020.Subnet
+		desired  subnetAssociations
+		expected []string
+	}{
+		{
+			name: "associated",
+			subnet: network2020.Subnet{SubnetPropertiesFormat: &network2020.SubnetPropertiesFormat{
+				NetworkSecurityGroup: &network2020.SecurityGroup{ID: to.StringPtr(clusterSecurityGroupID)},
+				// Azure does not preserve the case of resource group names in IDs
+				RouteTable: &network2020.RouteTable{ID: to.StringPtr("/subscriptions/sub/resourceGroups/RG/providers/Microsoft.Network/routeTables/cluster-abc")},
+			}},
+			desired: desired,
+		},
+		{
+			name: "security group detached",
+			subnet: network2020.Subnet{SubnetPropertiesFormat: &network2020.SubnetPropertiesFormat{
+				RouteTable: &network2020.RouteTable{ID: to.StringPtr(clusterRouteTableID)},
+			}},
+			desired:  desired,
+			expected: []string{"security group"},
+		},
+		{
+			name: "route table replaced",
+			subnet: network2020.Subnet{SubnetPropertiesFormat: &network2020.SubnetPropertiesFormat{
+				NetworkSecurityGroup: &network2020.SecurityGroup{ID: to.StringPtr(clusterSecurityGroupID)},
+				RouteTable:           &network2020.RouteTable{ID: to.StringPtr("/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/routeTables/other")},
+			}},
+			desired:  desired,
+			expected: []string{"route table"},
+		},
+		{
+			name:     "both detached",
+			subnet:   network2020.Subnet{},
+			desired:  desired,
+			expected: []string{"security group", "route table"},
+		},
+		{
+			name:    "route table not checked",
+			subnet:  network2020.Subnet{SubnetPropertiesFormat: &network2020.SubnetPropertiesFormat{NetworkSecurityGroup: &network2020.SecurityGroup{ID: to.StringPtr(clusterSecurityGroupID)}}},
+			desired: subnetAssociations{securityGroupID: clusterSecurityGroupID},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if drifted := driftedSubnetAssociations(test.subnet, test.desired); !reflect.DeepEqual(drifted, test.expected) {
+				t.Fatalf("expected %v, got %v", test.expected, drifted)
+			}
+		})
+	}
+}
+
+func TestAssociateSubnet(t *testing.T) {
+	subnet := network2020.Subnet{
+		Name: to.StringPtr("cluster-abc"),
+		SubnetPropertiesFormat: &network2020.SubnetPropertiesFormat{
+			AddressPrefix:    to.StringPtr("10.0.0.0/16"),
+			ServiceEndpoints: &[]network2020.ServiceEndpointPropertiesFormat{{Service: to.StringPtr("Microsoft.Storage")}},
+		},
+	}
+	desired := subnetAssociations{securityGroupID: clusterSecurityGroupID, routeTableID: clusterRouteTableID}
+
+	associated := associateSubnet(subnet, desired)
+	if drifted := driftedSubnetAssociations(associated, desired); len(drifted) > 0 {
+		t.Fatalf("expected the subnet to be associated, but the %v drifted", drifted)
+	}
+	if to.String(associated.AddressPrefix) != "10.0.0.0/16" || associated.ServiceEndpoints == nil || len(*associated.ServiceEndpoints) != 1 {
+		t.Fatalf("expected the other properties of the subnet to be preserved, got %+v", associated.SubnetPropertiesFormat)
+	}
+}
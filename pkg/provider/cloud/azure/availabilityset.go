@@ -0,0 +1,254 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"crypto/sha1"
+	"fmt"
+	"strconv"
+
+	compute2020 "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-30/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	"go.uber.org/zap"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1/helper"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/provider"
+
+	"k8s.io/apimachinery/pkg/util/rand"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// maxAvailabilitySetNameLength is the maximum length of the name of an availability set.
+	maxAvailabilitySetNameLength = 80
+	// maxUpdateDomainCount is the maximum number of update domains of an availability set.
+	maxUpdateDomainCount = 20
+)
+
+// AddMachineDeploymentAvailabilitySet records the availability set for the machines of the given
+// MachineDeployment in the status of the cluster, if the cluster has an availability set per
+// MachineDeployment, and returns the updated cluster. It has to be called before the
+// MachineDeployment is created, the availability set itself is created by the cloud controller
+// afterwards. Machines cannot be created until then, which the machine-controller retries.
+// MachineDeployments without a name are given one, as the availability set is bound to it.
+func AddMachineDeploymentAvailabilitySet(ctx context.Context, client ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster, nd *apiv1.NodeDeployment) (*kubermaticv1.Cluster, error) {
+	if cluster.Spec.Cloud.Azure == nil || !cluster.Spec.Cloud.Azure.AvailabilitySetPerMachineDeployment {
+		return cluster, nil
+	}
+
+	if nd.Name == "" {
+		nd.Name = fmt.Sprintf("%s-worker-%s", cluster.Spec.HumanReadableName, rand.String(5))
+	}
+	machineDeployment := nd.Name
+
+	if cluster.Status.Azure != nil && findAvailabilitySet(cluster.Status.Azure.AvailabilitySets, machineDeployment) != nil {
+		return cluster, nil
+	}
+
+	cluster = cluster.DeepCopy()
+	if err := kubermaticv1helper.UpdateCluster(ctx, client, cluster, func(c *kubermaticv1.Cluster) {
+		if c.Status.Azure == nil {
+			c.Status.Azure = &kubermaticv1.AzureClusterStatus{}
+		}
+		if findAvailabilitySet(c.Status.Azure.AvailabilitySets, machineDeployment) == nil {
+			c.Status.Azure.AvailabilitySets = append(c.Status.Azure.AvailabilitySets, kubermaticv1.AzureAvailabilitySet{
+				MachineDeployment: machineDeployment,
+				Name:              machineDeploymentAvailabilitySetName(c.Name, machineDeployment),
+			})
+		}
+	}); err != nil {
+		return nil, fmt.Errorf("failed to add availability set for MachineDeployment %q: %w", machineDeployment, err)
+	}
+
+	return cluster, nil
+}
+
+// machineDeploymentAvailabilitySetName returns the name of the availability set of a
+// MachineDeployment. Names which exceed the limit of Azure are shortened and made unique by a hash
+// of the name of the MachineDeployment.
+func machineDeploymentAvailabilitySetName(cluster, machineDeployment string) string {
+	name := fmt.Sprintf("%s%s-%s", resourceNamePrefix, cluster, machineDeployment)
+	if len(name) <= maxAvailabilitySetNameLength {
+		return name
+	}
+
+	hash := fmt.Sprintf("%x", sha1.Sum([]byte(machineDeployment)))[:8]
+	return fmt.Sprintf("%s-%s", name[:maxAvailabilitySetNameLength-len(hash)-1], hash)
+}
+
+func findAvailabilitySet(availabilitySets []kubermaticv1.AzureAvailabilitySet, machineDeployment string) *kubermaticv1.AzureAvailabilitySet {
+	for i := range availabilitySets {
+		if availabilitySets[i].MachineDeployment == machineDeployment {
+			return &availabilitySets[i]
+		}
+	}
+	return nil
+}
+
+// reconcileMachineDeploymentAvailabilitySets creates the availability sets of the MachineDeployments
+// recorded in the status of the cluster which have not been created yet. They are assigned to the
+// proximity placement group of the cluster, if there is one.
+func (a *Azure) reconcileMachineDeploymentAvailabilitySets(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, credentials Credentials, location string, tags map[string]*string) (*kubermaticv1.Cluster, error) {
+	if cluster.Status.Azure == nil {
+		return cluster, nil
+	}
+
+	for _, as := range cluster.Status.Azure.AvailabilitySets {
+		if as.Created {
+			continue
+		}
+
+		faultDomainCount, updateDomainCount, err := a.availabilitySetDomainCounts(credentials)
+		if err != nil {
+			return cluster, err
+		}
+
+		a.log.With("cluster", cluster.Name).Infow("ensuring AvailabilitySet", "availabilitySet", as.Name, "machineDeployment", as.MachineDeployment)
+		if err := ensureAvailabilitySet(a.ctx, as.Name, location, faultDomainCount, updateDomainCount, tags, cluster.Spec.Cloud, credentials); err != nil {
+			return cluster, fmt.Errorf("failed to ensure AvailabilitySet %q exists: %w", as.Name, err)
+		}
+
+		machineDeployment := as.MachineDeployment
+		cluster, err = update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+			kuberneteshelper.AddFinalizer(updatedCluster, FinalizerMachineDeploymentAvailabilitySets)
+			if updatedCluster.Status.Azure == nil {
+				return
+			}
+			if updated := findAvailabilitySet(updatedCluster.Status.Azure.AvailabilitySets, machineDeployment); updated != nil {
+				updated.Created = true
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return cluster, nil
+}
+
+// cleanUpMachineDeploymentAvailabilitySets deletes the availability sets of the MachineDeployments.
+// It has to run before the proximity placement group is deleted.
+func (a *Azure) cleanUpMachineDeploymentAvailabilitySets(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, credentials Credentials, logger *zap.SugaredLogger) (*kubermaticv1.Cluster, error) {
+	if !kuberneteshelper.HasFinalizer(cluster, FinalizerMachineDeploymentAvailabilitySets) {
+		return cluster, nil
+	}
+
+	asClient, err := getAvailabilitySetClient(cluster.Spec.Cloud, credentials)
+	if err != nil {
+		return cluster, err
+	}
+
+	if cluster.Status.Azure != nil {
+		for _, as := range cluster.Status.Azure.AvailabilitySets {
+			if !as.Created {
+				continue
+			}
+
+			logger.Infow("deleting availability set", "availabilitySet", as.Name, "machineDeployment", as.MachineDeployment)
+			if _, err := asClient.Delete(a.ctx, cluster.Spec.Cloud.Azure.ResourceGroup, as.Name); err != nil {
+				if !isNotFound(err) {
+					return cluster, fmt.Errorf("failed to delete availability set %q: %w", as.Name, err)
+				}
+			}
+		}
+	}
+
+	return update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+		kuberneteshelper.RemoveFinalizer(updatedCluster, FinalizerMachineDeploymentAvailabilitySets)
+	})
+}
+
+// availabilitySetDomainCounts returns the number of fault and update domains of the availability
+// sets created in the datacenter. The configured number of fault domains must not exceed the
+// maximum of the region.
+func (a *Azure) availabilitySetDomainCounts(credentials Credentials) (int32, int32, error) {
+	maxFaultDomainCount, err := a.maxFaultDomainCount(credentials)
+	if err != nil {
+		return 0, 0, err
+	}
+	return domainCounts(a.dc, maxFaultDomainCount)
+}
+
+// domainCounts returns the configured number of fault and update domains of the datacenter. The
+// number of fault domains defaults to the given maximum of the region, 0 means it is unknown.
+func domainCounts(dc *kubermaticv1.DatacenterSpecAzure, maxFaultDomainCount int32) (int32, int32, error) {
+	updateDomainCount := int32(maxUpdateDomainCount)
+	if dc.UpdateDomainCount != nil {
+		updateDomainCount = *dc.UpdateDomainCount
+	}
+
+	switch {
+	case dc.FaultDomainCount == nil && maxFaultDomainCount == 0:
+		return 0, 0, fmt.Errorf("could not determine the number of fault domains, unknown region %q", dc.Location)
+	case dc.FaultDomainCount == nil:
+		return maxFaultDomainCount, updateDomainCount, nil
+	case maxFaultDomainCount != 0 && *dc.FaultDomainCount > maxFaultDomainCount:
+		return 0, 0, fmt.Errorf("the datacenter is configured with %d fault domains, but region %q only supports %d", *dc.FaultDomainCount, dc.Location, maxFaultDomainCount)
+	}
+
+	return *dc.FaultDomainCount, updateDomainCount, nil
+}
+
+// maxFaultDomainCount returns the maximum number of fault domains of availability sets in the
+// region of the datacenter, as reported by the SKU API. Regions which are not reported fall back
+// to the static list, 0 is returned for unknown regions.
+func (a *Azure) maxFaultDomainCount(credentials Credentials) (int32, error) {
+	client, err := getResourceSkusClient(credentials)
+	if err != nil {
+		return 0, err
+	}
+
+	iter, err := client.ListComplete(a.ctx, fmt.Sprintf("location eq '%s'", a.dc.Location))
+	if err != nil {
+		return 0, fmt.Errorf("failed to list SKUs: %w", err)
+	}
+	for ; iter.NotDone(); err = iter.NextWithContext(a.ctx) {
+		if err != nil {
+			return 0, fmt.Errorf("failed to list SKUs: %w", err)
+		}
+		if count, ok := skuMaxFaultDomainCount(iter.Value()); ok {
+			return count, nil
+		}
+	}
+
+	return faultDomainsPerRegion[a.dc.Location], nil
+}
+
+// skuMaxFaultDomainCount returns the maximum number of fault domains if the SKU is the one of
+// aligned availability sets, which are created by Kubermatic.
+func skuMaxFaultDomainCount(sku compute2020.ResourceSku) (int32, bool) {
+	if to.String(sku.ResourceType) != "availabilitySets" || to.String(sku.Name) != "Aligned" || sku.Capabilities == nil {
+		return 0, false
+	}
+
+	for _, capability := range *sku.Capabilities {
+		if to.String(capability.Name) != "MaximumPlatformFaultDomainCount" {
+			continue
+		}
+		count, err := strconv.ParseInt(to.String(capability.Value), 10, 32)
+		if err != nil || count < 1 {
+			return 0, false
+		}
+		return int32(count), true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	compute2020 "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-30/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestMachineDeploymentAvailabilitySetName(t *testing.T) {
+	if name := machineDeploymentAvailabilitySetName("abcd1234", "workers"); name != "kubernetes-abcd1234-workers" {
+		t.Errorf("expected name %q, got %q", "kubernetes-abcd1234-workers", name)
+	}
+
+	long := strings.Repeat("a", 70)
+	first := machineDeploymentAvailabilitySetName("abcd1234", long+"-first")
+	second := machineDeploymentAvailabilitySetName("abcd1234", long+"-second")
+	if len(first) != maxAvailabilitySetNameLength || len(second) != maxAvailabilitySetNameLength {
+		t.Errorf("expected long names to be shortened to %d characters, got %q and %q", maxAvailabilitySetNameLength, first, second)
+	}
+	if first == second {
+		t.Errorf("expected shortened names of different MachineDeployments to differ, got %q for both", first)
+	}
+}
+
+func TestAddMachineDeploymentAvailabilitySet(t *testing.T) {
+	ctx := context.Background()
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "abcd1234"},
+		Spec: kubermaticv1.ClusterSpec{
+			HumanReadableName: "test",
+			Cloud: kubermaticv1.CloudSpec{
+				Azure: &kubermaticv1.AzureCloudSpec{AvailabilitySetPerMachineDeployment: true},
+			},
+		},
+	}
+	client := fakectrlruntimeclient.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(cluster).Build()
+
+	current := &kubermaticv1.Cluster{}
+	if err := client.Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(cluster), current); err != nil {
+		t.Fatalf("failed to get cluster: %v", err)
+	}
+
+	named := &apiv1.NodeDeployment{ObjectMeta: apiv1.ObjectMeta{Name: "workers"}}
+	unnamed := &apiv1.NodeDeployment{}
+	for _, nd := range []*apiv1.NodeDeployment{named, unnamed, named} {
+		var err error
+		if current, err = AddMachineDeploymentAvailabilitySet(ctx, client, current, nd); err != nil {
+			t.Fatalf("failed to add availability set: %v", err)
+		}
+	}
+
+	if !strings.HasPrefix(unnamed.Name, "test-worker-") {
+		t.Errorf("expected the MachineDeployment without name to be named, got %q", unnamed.Name)
+	}
+
+	updated := &kubermaticv1.Cluster{}
+	if err := client.Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(cluster), updated); err != nil {
+		t.Fatalf("failed to get cluster: %v", err)
+	}
+	if updated.Status.Azure == nil || len(updated.Status.Azure.AvailabilitySets) != 2 {
+		t.Fatalf("expected one availability set per MachineDeployment, got %+v", updated.Status.Azure)
+	}
+	if as := findAvailabilitySet(updated.Status.Azure.AvailabilitySets, "workers"); as == nil || as.Name != "kubernetes-abcd1234-workers" || as.Created {
+		t.Errorf("expected availability set %q to be recorded but not created yet, got %+v", "kubernetes-abcd1234-workers", as)
+	}
+}
+
+func TestAddMachineDeploymentAvailabilitySetDisabled(t *testing.T) {
+	cluster := &kubermaticv1.Cluster{
+		Spec: kubermaticv1.ClusterSpec{
+			Cloud: kubermaticv1.CloudSpec{Azure: &kubermaticv1.AzureCloudSpec{}},
+		},
+	}
+	nd := &apiv1.NodeDeployment{}
+
+	// the cluster is not updated, so no client is needed
+	updated, err := AddMachineDeploymentAvailabilitySet(context.Background(), nil, cluster, nd)
+	if err != nil {
+		t.Fatalf("failed to add availability set: %v", err)
+	}
+	if updated.Status.Azure != nil || nd.Name != "" {
+		t.Errorf("expected neither the cluster nor the MachineDeployment to be changed, got %+v and %q", updated.Status.Azure, nd.Name)
+	}
+}
+
+func TestDomainCounts(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		dc                   kubermaticv1.DatacenterSpecAzure
+		maxFaultDomainCount  int32
+		expectedFaultDomains int32
+		expectedUpdateDomain int32
+		expectedError        bool
+	}{
+		{
+			name:                 "defaults to the maximum of the region",
+			dc:                   kubermaticv1.DatacenterSpecAzure{Location: "westeurope"},
+			maxFaultDomainCount:  3,
+			expectedFaultDomains: 3,
+			expectedUpdateDomain: 20,
+		},
+		{
+			name: "configured counts",
+			dc: kubermaticv1.DatacenterSpecAzure{
+				Location:          "westeurope",
+				FaultDomainCount:  pointer.Int32Ptr(2),
+				UpdateDomainCount: pointer.Int32Ptr(5),
+			},
+			maxFaultDomainCount:  3,
+			expectedFaultDomains: 2,
+			expectedUpdateDomain: 5,
+		},
+		{
+			name:                "configured fault domains exceed the maximum of the region",
+			dc:                  kubermaticv1.DatacenterSpecAzure{Location: "eastasia", FaultDomainCount: pointer.Int32Ptr(3)},
+			maxFaultDomainCount: 2,
+			expectedError:       true,
+		},
+		{
+			name:                 "configured fault domains in an unknown region",
+			dc:                   kubermaticv1.DatacenterSpecAzure{Location: "atlantis", FaultDomainCount: pointer.Int32Ptr(2)},
+			expectedFaultDomains: 2,
+			expectedUpdateDomain: 20,
+		},
+		{
+			name:          "unknown region",
+			dc:            kubermaticv1.DatacenterSpecAzure{Location: "atlantis"},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			faultDomains, updateDomains, err := domainCounts(&tc.dc, tc.maxFaultDomainCount)
+			if (err != nil) != tc.expectedError {
+				t.Fatalf("expected error: %v, got: %v", tc.expectedError, err)
+			}
+			if faultDomains != tc.expectedFaultDomains || updateDomains != tc.expectedUpdateDomain {
+				t.Errorf("expected %d fault and %d update domains, got %d and %d", tc.expectedFaultDomains, tc.expectedUpdateDomain, faultDomains, updateDomains)
+			}
+		})
+	}
+}
+
+func TestSKUMaxFaultDomainCount(t *testing.T) {
+	sku := func(resourceType, name, value string) compute2020.ResourceSku {
+		return compute2020.ResourceSku{
+			ResourceType: to.StringPtr(resourceType),
+			Name:         to.StringPtr(name),
+			Capabilities: &[]compute2020.ResourceSkuCapabilities{
+				{Name: to.StringPtr("MaximumPlatformFaultDomainCount"), Value: to.StringPtr(value)},
+			},
+		}
+	}
+
+	if count, ok := skuMaxFaultDomainCount(sku("availabilitySets", "Aligned", "2")); !ok || count != 2 {
+		t.Errorf("expected 2 fault domains, got %d (found: %v)", count, ok)
+	}
+	if _, ok := skuMaxFaultDomainCount(sku("availabilitySets", "Classic", "3")); ok {
+		t.Error("expected classic availability sets to be ignored")
+	}
+	if _, ok := skuMaxFaultDomainCount(sku("virtualMachines", "Standard_D2s_v3", "3")); ok {
+		t.Error("expected VM sizes to be ignored")
+	}
+	if _, ok := skuMaxFaultDomainCount(sku("availabilitySets", "Aligned", "many")); ok {
+		t.Error("expected an invalid capability value to be ignored")
+	}
+}
@@ -0,0 +1,338 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"fmt"
+
+	network2020 "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	autorestazure "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/to"
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/provider"
+)
+
+const (
+	// bastionSubnetName is the name Azure requires for the subnet of Bastion hosts.
+	bastionSubnetName = "AzureBastionSubnet"
+	// bastionSubnetCIDR is added to the address space of the VNet if it has no AzureBastionSubnet yet.
+	// It must not overlap the cluster's subnet, which uses 10.0.0.0/16 in VNets created by Kubermatic.
+	bastionSubnetCIDR = "10.1.0.0/26"
+)
+
+func bastionName(cluster *kubermaticv1.Cluster) string {
+	return resourceNamePrefix + cluster.Name + "-bastion"
+}
+
+// reconcileBastion creates the AzureBastionSubnet, a public IP and the Bastion host in the cluster's
+// VNet if the Bastion is enabled, and removes them again once it is disabled.
+func (a *Azure) reconcileBastion(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, credentials Credentials, location string, tags map[string]*string) (*kubermaticv1.Cluster, error) {
+	var err error
+	logger := a.log.With("cluster", cluster.Name)
+
+	if !cluster.Spec.Cloud.Azure.EnableBastion {
+		return a.cleanUpBastion(cluster, update, credentials, logger)
+	}
+	if kuberneteshelper.HasFinalizer(cluster, FinalizerBastion) {
+		return cluster, nil
+	}
+
+	if err = a.pollOperation(cluster, update, credentials, "add-bastion-address-space", func() (autorestazure.FutureAPI, error) {
+		return ensureBastionAddressSpace(a.ctx, cluster.Spec.Cloud, credentials)
+	}); err != nil {
+		return cluster, fmt.Errorf("failed to add the Bastion address range to virtual network %q: %w", cluster.Spec.Cloud.Azure.VNetName, err)
+	}
+
+	if err = a.pollOperation(cluster, update, credentials, "create-bastion-subnet", func() (autorestazure.FutureAPI, error) {
+		return ensureBastionSubnet(a.ctx, cluster.Spec.Cloud, credentials)
+	}); err != nil {
+		return cluster, fmt.Errorf("failed to create subnetwork %q: %w", bastionSubnetName, err)
+	}
+
+	name := bastionName(cluster)
+
+	logger.Infow("ensuring Bastion public IP", "publicIP", name)
+	if err = a.pollOperation(cluster, update, credentials, "create-bastion-public-ip", func() (autorestazure.FutureAPI, error) {
+		return ensureBastionPublicIP(a.ctx, cluster.Spec.Cloud, name, location, tags, credentials)
+	}); err != nil {
+		return cluster, fmt.Errorf("failed to create public IP %q: %w", name, err)
+	}
+
+	logger.Infow("ensuring Bastion host", "bastionHost", name)
+	if err = a.pollOperation(cluster, update, credentials, "create-bastion-host", func() (autorestazure.FutureAPI, error) {
+		return ensureBastionHost(a.ctx, cluster.Spec.Cloud, name, location, tags, credentials)
+	}); err != nil {
+		return cluster, fmt.Errorf("failed to create Bastion host %q: %w", name, err)
+	}
+
+	cluster, err = update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+		kuberneteshelper.AddFinalizer(updatedCluster, FinalizerBastion)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return cluster, nil
+}
+
+// cleanUpBastion removes the Bastion host and its public IP. The AzureBastionSubnet is kept, as the
+// VNet may not belong to the cluster, and is removed together with VNets created by Kubermatic.
+func (a *Azure) cleanUpBastion(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, credentials Credentials, logger *zap.SugaredLogger) (*kubermaticv1.Cluster, error) {
+	var err error
+
+	if !kuberneteshelper.HasFinalizer(cluster, FinalizerBastion) {
+		return cluster, nil
+	}
+
+	name := bastionName(cluster)
+
+	logger.Infow("deleting Bastion host", "bastionHost", name)
+	if err = a.pollOperation(cluster, update, credentials, "delete-bastion-host", func() (autorestazure.FutureAPI, error) {
+		hostsClient, err := getBastionHostsClient(cluster.Spec.Cloud, credentials)
+		if err != nil {
+			return nil, err
+		}
+
+		future, err := hostsClient.Delete(a.ctx, cluster.Spec.Cloud.Azure.ResourceGroup, name)
+		if err != nil {
+			return nil, err
+		}
+		return future.FutureAPI, nil
+	}); err != nil {
+		if !isNotFound(err) {
+			return cluster, fmt.Errorf("failed to delete Bastion host %q: %w", name, err)
+		}
+	}
+
+	logger.Infow("deleting Bastion public IP", "publicIP", name)
+	if err = a.pollOperation(cluster, update, credentials, "delete-bastion-public-ip", func() (autorestazure.FutureAPI, error) {
+		ipsClient, err := getBastionPublicIPAddressesClient(cluster.Spec.Cloud, credentials)
+		if err != nil {
+			return nil, err
+		}
+
+		future, err := ipsClient.Delete(a.ctx, cluster.Spec.Cloud.Azure.ResourceGroup, name)
+		if err != nil {
+			return nil, err
+		}
+		return future.FutureAPI, nil
+	}); err != nil {
+		if !isNotFound(err) {
+			return cluster, fmt.Errorf("failed to delete public IP %q: %w", name, err)
+		}
+	}
+
+	cluster, err = update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+		kuberneteshelper.RemoveFinalizer(updatedCluster, FinalizerBastion)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return cluster, nil
+}
+
+// ensureBastionAddressSpace will start adding the Bastion address range to the VNet, unless the VNet
+// already has an AzureBastionSubnet or contains the range.
+func ensureBastionAddressSpace(ctx context.Context, cloud kubermaticv1.CloudSpec, credentials Credentials) (autorestazure.FutureAPI, error) {
+	networksClient, err := getBastionVirtualNetworksClient(cloud, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	vnet, err := networksClient.Get(ctx, vnetResourceGroup(cloud), cloud.Azure.VNetName, "")
+	if err != nil {
+		return nil, err
+	}
+	if hasSubnet(vnet, bastionSubnetName) || hasAddressPrefix(vnet, bastionSubnetCIDR) {
+		return nil, nil
+	}
+
+	if vnet.VirtualNetworkPropertiesFormat == nil {
+		vnet.VirtualNetworkPropertiesFormat = &network2020.VirtualNetworkPropertiesFormat{}
+	}
+	if vnet.AddressSpace == nil {
+		vnet.AddressSpace = &network2020.AddressSpace{}
+	}
+	prefixes := append(to.StringSlice(vnet.AddressSpace.AddressPrefixes), bastionSubnetCIDR)
+	vnet.AddressSpace.AddressPrefixes = &prefixes
+
+	// the whole VNet is sent, as its subnets would be removed otherwise
+	future, err := networksClient.CreateOrUpdate(ctx, vnetResourceGroup(cloud), cloud.Azure.VNetName, vnet)
+	if err != nil {
+		return nil, err
+	}
+
+	return future.FutureAPI, nil
+}
+
+// ensureBastionSubnet will start creating the AzureBastionSubnet, if it does not exist yet.
+func ensureBastionSubnet(ctx context.Context, cloud kubermaticv1.CloudSpec, credentials Credentials) (autorestazure.FutureAPI, error) {
+	subnetsClient, err := getPrivateLinkSubnetsClient(cloud, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = subnetsClient.Get(ctx, vnetResourceGroup(cloud), cloud.Azure.VNetName, bastionSubnetName, "")
+	if err == nil {
+		return nil, nil
+	}
+	if !isNotFound(err) {
+		return nil, err
+	}
+
+	parameters := network2020.Subnet{
+		Name: to.StringPtr(bastionSubnetName),
+		SubnetPropertiesFormat: &network2020.SubnetPropertiesFormat{
+			AddressPrefix: to.StringPtr(bastionSubnetCIDR),
+		},
+	}
+
+	future, err := subnetsClient.CreateOrUpdate(ctx, vnetResourceGroup(cloud), cloud.Azure.VNetName, bastionSubnetName, parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	return future.FutureAPI, nil
+}
+
+// ensureBastionPublicIP will start creating or updating the static public IP of the Bastion host.
+// Bastion hosts require the Standard SKU.
+func ensureBastionPublicIP(ctx context.Context, cloud kubermaticv1.CloudSpec, name, location string, tags map[string]*string, credentials Credentials) (autorestazure.FutureAPI, error) {
+	ipsClient, err := getBastionPublicIPAddressesClient(cloud, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	parameters := network2020.PublicIPAddress{
+		Name:     to.StringPtr(name),
+		Location: to.StringPtr(location),
+		Tags:     tags,
+		Sku:      &network2020.PublicIPAddressSku{Name: network2020.PublicIPAddressSkuNameStandard},
+		PublicIPAddressPropertiesFormat: &network2020.PublicIPAddressPropertiesFormat{
+			PublicIPAllocationMethod: network2020.Static,
+		},
+	}
+
+	future, err := ipsClient.CreateOrUpdate(ctx, cloud.Azure.ResourceGroup, name, parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	return future.FutureAPI, nil
+}
+
+// ensureBastionHost will start creating or updating the Bastion host in the AzureBastionSubnet.
+func ensureBastionHost(ctx context.Context, cloud kubermaticv1.CloudSpec, name, location string, tags map[string]*string, credentials Credentials) (autorestazure.FutureAPI, error) {
+	hostsClient, err := getBastionHostsClient(cloud, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	subnetID := fmt.Sprintf("%s/subnets/%s", assembleVNetID(cloud, credentials.SubscriptionID), bastionSubnetName)
+	publicIPID := fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/publicIPAddresses/%s",
+		credentials.SubscriptionID, cloud.Azure.ResourceGroup, name)
+
+	parameters := network2020.BastionHost{
+		Name:     to.StringPtr(name),
+		Location: to.StringPtr(location),
+		Tags:     tags,
+		BastionHostPropertiesFormat: &network2020.BastionHostPropertiesFormat{
+			IPConfigurations: &[]network2020.BastionHostIPConfiguration{
+				{
+					Name: to.StringPtr(name),
+					BastionHostIPConfigurationPropertiesFormat: &network2020.BastionHostIPConfigurationPropertiesFormat{
+						Subnet:          &network2020.SubResource{ID: to.StringPtr(subnetID)},
+						PublicIPAddress: &network2020.SubResource{ID: to.StringPtr(publicIPID)},
+					},
+				},
+			},
+		},
+	}
+
+	future, err := hostsClient.CreateOrUpdate(ctx, cloud.Azure.ResourceGroup, name, parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	return future.FutureAPI, nil
+}
+
+// hasSubnet returns true if the VNet contains a subnet with the given name.
+func hasSubnet(vnet network2020.VirtualNetwork, name string) bool {
+	if vnet.VirtualNetworkPropertiesFormat == nil || vnet.Subnets == nil {
+		return false
+	}
+	for _, subnet := range *vnet.Subnets {
+		if to.String(subnet.Name) == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasAddressPrefix returns true if the given prefix is part of the VNet's address space.
+func hasAddressPrefix(vnet network2020.VirtualNetwork, prefix string) bool {
+	if vnet.VirtualNetworkPropertiesFormat == nil || vnet.AddressSpace == nil {
+		return false
+	}
+	for _, existing := range to.StringSlice(vnet.AddressSpace.AddressPrefixes) {
+		if existing == prefix {
+			return true
+		}
+	}
+
+	return false
+}
+
+func getBastionHostsClient(cloud kubermaticv1.CloudSpec, credentials Credentials) (*network2020.BastionHostsClient, error) {
+	var err error
+	hostsClient := network2020.NewBastionHostsClient(credentials.SubscriptionID)
+	err = configureClient(&hostsClient.Client, credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authorizer: %s", err.Error())
+	}
+
+	return &hostsClient, nil
+}
+
+func getBastionPublicIPAddressesClient(cloud kubermaticv1.CloudSpec, credentials Credentials) (*network2020.PublicIPAddressesClient, error) {
+	var err error
+	ipsClient := network2020.NewPublicIPAddressesClient(credentials.SubscriptionID)
+	err = configureClient(&ipsClient.Client, credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authorizer: %s", err.Error())
+	}
+
+	return &ipsClient, nil
+}
+
+func getBastionVirtualNetworksClient(cloud kubermaticv1.CloudSpec, credentials Credentials) (*network2020.VirtualNetworksClient, error) {
+	var err error
+	networksClient := network2020.NewVirtualNetworksClient(credentials.SubscriptionID)
+	err = configureClient(&networksClient.Client, credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authorizer: %s", err.Error())
+	}
+
+	return &networksClient, nil
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"testing"
+
+	network2020 "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+)
+
+func TestBastionVNetChecks(t *testing.T) {
+	tests := []struct {
+		name             string
+		vnet             network2020.VirtualNetwork
+		hasSubnet        bool
+		hasAddressPrefix bool
+	}{
+		{
+			name:             "vnet without properties",
+			vnet:             network2020.VirtualNetwork{},
+			hasSubnet:        false,
+			hasAddressPrefix: false,
+		},
+		{
+			name: "vnet without bastion subnet",
+			vnet: network2020.VirtualNetwork{VirtualNetworkPropertiesFormat: &network2020.VirtualNetworkPropertiesFormat{
+				AddressSpace: &network2020.AddressSpace{AddressPrefixes: &[]string{"10.0.0.0/16"}},
+				Subnets:      &[]network2020.Subnet{{Name: to.StringPtr("kubernetes-abc")}},
+			}},
+			hasSubnet:        false,
+			hasAddressPrefix: false,
+		},
+		{
+			name: "vnet with bastion subnet",
+			vnet: network2020.VirtualNetwork{VirtualNetworkPropertiesFormat: &network2020.VirtualNetworkPropertiesFormat{
+				AddressSpace: &network2020.AddressSpace{AddressPrefixes: &[]string{"10.0.0.0/16", bastionSubnetCIDR}},
+				Subnets:      &[]network2020.Subnet{{Name: to.StringPtr("kubernetes-abc")}, {Name: to.StringPtr(bastionSubnetName)}},
+			}},
+			hasSubnet:        true,
+			hasAddressPrefix: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := hasSubnet(test.vnet, bastionSubnetName); got != test.hasSubnet {
+				t.Errorf("expected hasSubnet to be %v, got %v", test.hasSubnet, got)
+			}
+			if got := hasAddressPrefix(test.vnet, bastionSubnetCIDR); got != test.hasAddressPrefix {
+				t.Errorf("expected hasAddressPrefix to be %v, got %v", test.hasAddressPrefix, got)
+			}
+		})
+	}
+}
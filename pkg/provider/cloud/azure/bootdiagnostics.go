@@ -0,0 +1,252 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+	autorestazure "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/to"
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/provider"
+)
+
+const (
+	// bootDiagnosticsStorageAccountPrefix is the prefix of the storage accounts Kubermatic creates
+	// for boot diagnostics.
+	bootDiagnosticsStorageAccountPrefix = "kkp"
+	// maxStorageAccountNameLength is the maximum length of storage account names.
+	maxStorageAccountNameLength = 24
+)
+
+// bootDiagnosticsStorageAccountName returns the name of the storage account created for the boot
+// diagnostics of the cluster. Storage account names are globally unique and may only contain
+// lowercase letters and digits, so the name ends with a hash of the subscription and the cluster.
+func bootDiagnosticsStorageAccountName(cluster *kubermaticv1.Cluster, subscriptionID string) string {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(subscriptionID + "/" + cluster.Name))
+	suffix := fmt.Sprintf("%08x", hash.Sum32())
+
+	name := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return -1
+	}, strings.ToLower(cluster.Name))
+	if max := maxStorageAccountNameLength - len(bootDiagnosticsStorageAccountPrefix) - len(suffix); len(name) > max {
+		name = name[:max]
+	}
+
+	return bootDiagnosticsStorageAccountPrefix + name + suffix
+}
+
+// parseStorageAccountID returns the subscription, resource group and name of a storage account.
+func parseStorageAccountID(id string) (autorestazure.Resource, error) {
+	account, err := autorestazure.ParseResourceID(id)
+	if err != nil {
+		return account, fmt.Errorf("invalid storage account ID %q: %w", id, err)
+	}
+	if !strings.EqualFold(account.Provider, "Microsoft.Storage") || !strings.EqualFold(account.ResourceType, "storageAccounts") {
+		return account, fmt.Errorf("%q is not the ID of a storage account", id)
+	}
+
+	return account, nil
+}
+
+// blobEndpoint returns the blob endpoint of a storage account, which VMs write their boot
+// diagnostics to.
+func blobEndpoint(account storage.Account) (string, error) {
+	if account.AccountProperties == nil || account.PrimaryEndpoints == nil || to.String(account.PrimaryEndpoints.Blob) == "" {
+		return "", fmt.Errorf("storage account %q has no blob endpoint", to.String(account.Name))
+	}
+
+	return to.String(account.PrimaryEndpoints.Blob), nil
+}
+
+// reconcileBootDiagnostics ensures the storage account for the boot diagnostics of the nodes exists
+// and records its blob endpoint in the cluster status. Storage accounts created by Kubermatic are
+// removed again once boot diagnostics are disabled or an existing storage account is configured.
+func (a *Azure) reconcileBootDiagnostics(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, credentials Credentials, location string, tags map[string]*string) (*kubermaticv1.Cluster, error) {
+	var err error
+	logger := a.log.With("cluster", cluster.Name)
+
+	bootDiagnostics := cluster.Spec.Cloud.Azure.BootDiagnostics
+	if bootDiagnostics == nil || bootDiagnostics.StorageAccountID != "" {
+		if cluster, err = a.cleanUpBootDiagnostics(cluster, update, credentials, logger); err != nil {
+			return cluster, err
+		}
+	}
+	if bootDiagnostics == nil {
+		return a.setBootDiagnosticsStorageURI(cluster, update, "")
+	}
+
+	var account storage.Account
+	if bootDiagnostics.StorageAccountID != "" {
+		account, err = getStorageAccount(a.ctx, bootDiagnostics.StorageAccountID, credentials)
+		if err != nil {
+			return cluster, fmt.Errorf("failed to get storage account %q: %w", bootDiagnostics.StorageAccountID, err)
+		}
+	} else {
+		name := bootDiagnosticsStorageAccountName(cluster, credentials.SubscriptionID)
+
+		logger.Infow("ensuring boot diagnostics storage account", "storageAccount", name)
+		if err = a.pollOperation(cluster, update, credentials, "create-boot-diagnostics-storage-account", func() (autorestazure.FutureAPI, error) {
+			return ensureBootDiagnosticsStorageAccount(a.ctx, cluster.Spec.Cloud, name, location, tags, credentials)
+		}); err != nil {
+			a.recordError(cluster, "FailedToEnsureBootDiagnosticsStorageAccount", err)
+			return cluster, fmt.Errorf("failed to create storage account %q: %w", name, err)
+		}
+
+		if !kuberneteshelper.HasFinalizer(cluster, FinalizerBootDiagnosticsStorageAccount) {
+			a.recordEvent(cluster, "EnsuredBootDiagnosticsStorageAccount", "Ensured boot diagnostics storage account %q", name)
+			cluster, err = update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+				kuberneteshelper.AddFinalizer(updatedCluster, FinalizerBootDiagnosticsStorageAccount)
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		storageClient, err := getStorageAccountsClient(credentials.SubscriptionID, credentials)
+		if err != nil {
+			return cluster, err
+		}
+		account, err = storageClient.GetProperties(a.ctx, cluster.Spec.Cloud.Azure.ResourceGroup, name, "")
+		if err != nil {
+			return cluster, fmt.Errorf("failed to get storage account %q: %w", name, err)
+		}
+	}
+
+	uri, err := blobEndpoint(account)
+	if err != nil {
+		return cluster, err
+	}
+
+	return a.setBootDiagnosticsStorageURI(cluster, update, uri)
+}
+
+// setBootDiagnosticsStorageURI records the blob endpoint for boot diagnostics in the cluster status.
+func (a *Azure) setBootDiagnosticsStorageURI(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, uri string) (*kubermaticv1.Cluster, error) {
+	current := ""
+	if cluster.Status.Azure != nil {
+		current = cluster.Status.Azure.BootDiagnosticsStorageURI
+	}
+	if current == uri {
+		return cluster, nil
+	}
+
+	return update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+		if updatedCluster.Status.Azure == nil {
+			updatedCluster.Status.Azure = &kubermaticv1.AzureClusterStatus{}
+		}
+		updatedCluster.Status.Azure.BootDiagnosticsStorageURI = uri
+	})
+}
+
+// cleanUpBootDiagnostics deletes the storage account Kubermatic created for boot diagnostics.
+// Storage accounts specified by the user are never deleted.
+func (a *Azure) cleanUpBootDiagnostics(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, credentials Credentials, logger *zap.SugaredLogger) (*kubermaticv1.Cluster, error) {
+	if !kuberneteshelper.HasFinalizer(cluster, FinalizerBootDiagnosticsStorageAccount) {
+		return cluster, nil
+	}
+
+	name := bootDiagnosticsStorageAccountName(cluster, credentials.SubscriptionID)
+
+	storageClient, err := getStorageAccountsClient(credentials.SubscriptionID, credentials)
+	if err != nil {
+		return cluster, err
+	}
+
+	logger.Infow("deleting boot diagnostics storage account", "storageAccount", name)
+	if _, err = storageClient.Delete(a.ctx, cluster.Spec.Cloud.Azure.ResourceGroup, name); err != nil && !isNotFound(err) {
+		return cluster, fmt.Errorf("failed to delete storage account %q: %w", name, err)
+	}
+
+	return update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+		kuberneteshelper.RemoveFinalizer(updatedCluster, FinalizerBootDiagnosticsStorageAccount)
+	})
+}
+
+// ensureBootDiagnosticsStorageAccount will start creating the storage account for boot diagnostics,
+// if it does not exist yet. Only HTTPS access is allowed and the blobs are not publicly readable.
+func ensureBootDiagnosticsStorageAccount(ctx context.Context, cloud kubermaticv1.CloudSpec, name, location string, tags map[string]*string, credentials Credentials) (autorestazure.FutureAPI, error) {
+	storageClient, err := getStorageAccountsClient(credentials.SubscriptionID, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = storageClient.GetProperties(ctx, cloud.Azure.ResourceGroup, name, "")
+	if err == nil {
+		return nil, nil
+	}
+	if !isNotFound(err) {
+		return nil, err
+	}
+
+	parameters := storage.AccountCreateParameters{
+		Sku:      &storage.Sku{Name: storage.StandardLRS},
+		Kind:     storage.StorageV2,
+		Location: to.StringPtr(location),
+		Tags:     tags,
+		AccountPropertiesCreateParameters: &storage.AccountPropertiesCreateParameters{
+			EnableHTTPSTrafficOnly: to.BoolPtr(true),
+			MinimumTLSVersion:      storage.TLS12,
+			AllowBlobPublicAccess:  to.BoolPtr(false),
+		},
+	}
+
+	future, err := storageClient.Create(ctx, cloud.Azure.ResourceGroup, name, parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	return future.FutureAPI, nil
+}
+
+// getStorageAccount returns the storage account with the given ID, which may be in another
+// subscription than the cluster.
+func getStorageAccount(ctx context.Context, id string, credentials Credentials) (storage.Account, error) {
+	resource, err := parseStorageAccountID(id)
+	if err != nil {
+		return storage.Account{}, err
+	}
+
+	storageClient, err := getStorageAccountsClient(resource.SubscriptionID, credentials)
+	if err != nil {
+		return storage.Account{}, err
+	}
+
+	return storageClient.GetProperties(ctx, resource.ResourceGroup, resource.ResourceName, "")
+}
+
+func getStorageAccountsClient(subscriptionID string, credentials Credentials) (*storage.AccountsClient, error) {
+	var err error
+	storageClient := storage.NewAccountsClient(subscriptionID)
+	err = configureClient(&storageClient.Client, credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authorizer: %s", err.Error())
+	}
+
+	return &storageClient, nil
+}
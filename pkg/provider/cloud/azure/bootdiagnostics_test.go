@@ -0,0 +1,122 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+	"github.com/Azure/go-autorest/autorest/to"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestBootDiagnosticsStorageAccountName(t *testing.T) {
+	validName := regexp.MustCompile(`^[a-z0-9]{3,24}$`)
+
+	tests := []struct {
+		name        string
+		clusterName string
+	}{
+		{
+			name:        "generated cluster name",
+			clusterName: "xrh2wzn5bd",
+		},
+		{
+			name:        "long cluster name with dashes",
+			clusterName: "a-very-long-cluster-name-exceeding-the-limit",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cluster := &kubermaticv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: test.clusterName}}
+
+			name := bootDiagnosticsStorageAccountName(cluster, "subscription-a")
+			if !validName.MatchString(name) {
+				t.Errorf("%q is not a valid storage account name", name)
+			}
+			if again := bootDiagnosticsStorageAccountName(cluster, "subscription-a"); again != name {
+				t.Errorf("expected the name to be stable, got %q and %q", name, again)
+			}
+			if other := bootDiagnosticsStorageAccountName(cluster, "subscription-b"); other == name {
+				t.Errorf("expected the name to differ between subscriptions, got %q for both", name)
+			}
+		})
+	}
+}
+
+func TestParseStorageAccountID(t *testing.T) {
+	tests := []struct {
+		name          string
+		id            string
+		expectedGroup string
+		expectedName  string
+		expectedError bool
+	}{
+		{
+			name:          "storage account",
+			id:            "/subscriptions/sub/resourceGroups/diagnostics/providers/Microsoft.Storage/storageAccounts/bootlogs",
+			expectedGroup: "diagnostics",
+			expectedName:  "bootlogs",
+		},
+		{
+			name:          "other resource type",
+			id:            "/subscriptions/sub/resourceGroups/diagnostics/providers/Microsoft.Network/virtualNetworks/vnet",
+			expectedError: true,
+		},
+		{
+			name:          "invalid ID",
+			id:            "bootlogs",
+			expectedError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			account, err := parseStorageAccountID(test.id)
+			if (err != nil) != test.expectedError {
+				t.Fatalf("expected error to be %v, got %v", test.expectedError, err)
+			}
+			if test.expectedError {
+				return
+			}
+			if account.ResourceGroup != test.expectedGroup || account.ResourceName != test.expectedName {
+				t.Errorf("expected %s/%s, got %s/%s", test.expectedGroup, test.expectedName, account.ResourceGroup, account.ResourceName)
+			}
+		})
+	}
+}
+
+func TestBlobEndpoint(t *testing.T) {
+	uri, err := blobEndpoint(storage.Account{AccountProperties: &storage.AccountProperties{
+		PrimaryEndpoints: &storage.Endpoints{Blob: to.StringPtr("https://bootlogs.blob.core.windows.net/")},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if uri != "https://bootlogs.blob.core.windows.net/" {
+		t.Errorf("unexpected blob endpoint %q", uri)
+	}
+
+	if _, err := blobEndpoint(storage.Account{Name: to.StringPtr("bootlogs")}); err == nil {
+		t.Error("expected an error for a storage account without endpoints")
+	}
+}
@@ -0,0 +1,372 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/provider"
+)
+
+const (
+	// ForceCleanupAnnotation makes the deletion of a cluster skip the cleanup steps which have used
+	// up their attempts, so that a cluster whose Azure resources cannot be deleted, for example
+	// because a security group is still attached to an unknown network interface, can be removed.
+	// The resources of the skipped steps are left behind and reported in a warning event.
+	ForceCleanupAnnotation = "azure.kubermatic.io/force-cleanup"
+
+	// cleanupAttemptsAnnotationPrefix is the prefix of the cluster annotations counting the
+	// failed attempts of the cleanup steps.
+	cleanupAttemptsAnnotationPrefix = "azure.kubermatic.io/cleanup-attempts-"
+	// cleanupSkippedAnnotationPrefix is the prefix of the cluster annotations marking the cleanup
+	// steps which have been skipped, so that steps without finalizers are not run again.
+	cleanupSkippedAnnotationPrefix = "azure.kubermatic.io/cleanup-skipped-"
+
+	defaultCleanupStepTimeout = 20 * time.Minute
+	defaultCleanupMaxAttempts = 10
+)
+
+// cleanupStep is a step of the deletion of the Azure resources of a cluster.
+type cleanupStep struct {
+	// name identifies the step in annotations and logs.
+	name string
+	// finalizers are removed by the step once it has succeeded. A step is only run while the
+	// cluster has one of them, steps without finalizers are always run.
+	finalizers []string
+	// resources describes the resources the step deletes, which are left behind if it is skipped.
+	resources func(*kubermaticv1.Cluster) string
+	run       func(*kubermaticv1.Cluster) (*kubermaticv1.Cluster, error)
+}
+
+// cleanupSteps returns the steps of the deletion of the Azure resources of a cluster, in the
+// order they have to be run in.
+func (a *Azure) cleanupSteps(update provider.ClusterUpdater, credentials Credentials, logger *zap.SugaredLogger) []cleanupStep {
+	withLogger := func(cleanUp func(*kubermaticv1.Cluster, provider.ClusterUpdater, Credentials, *zap.SugaredLogger) (*kubermaticv1.Cluster, error)) func(*kubermaticv1.Cluster) (*kubermaticv1.Cluster, error) {
+		return func(cluster *kubermaticv1.Cluster) (*kubermaticv1.Cluster, error) {
+			return cleanUp(cluster, update, credentials, logger)
+		}
+	}
+	describe := func(format string, name func(*kubermaticv1.Cluster) string) func(*kubermaticv1.Cluster) string {
+		return func(cluster *kubermaticv1.Cluster) string {
+			return fmt.Sprintf(format, name(cluster))
+		}
+	}
+	resourceGroup := func(cluster *kubermaticv1.Cluster) string { return cluster.Spec.Cloud.Azure.ResourceGroup }
+
+	return []cleanupStep{
+		{
+			// The lock prevents the deletion of every resource in the resource group,
+			// so it must be removed before anything else.
+			name:       "resource-group-lock",
+			finalizers: []string{FinalizerResourceGroupLock},
+			resources:  describe("management lock of resource group %q", resourceGroup),
+			run: func(cluster *kubermaticv1.Cluster) (*kubermaticv1.Cluster, error) {
+				return a.removeResourceGroupLock(cluster, update, credentials)
+			},
+		},
+		{
+			name:       "private-dns-zone",
+			finalizers: []string{FinalizerPrivateDNSZoneLink, FinalizerPrivateDNSZone},
+			resources: describe("private DNS zone %q and its VNet link", func(cluster *kubermaticv1.Cluster) string {
+				return cluster.Spec.Cloud.Azure.PrivateDNSZone
+			}),
+			run: withLogger(a.cleanUpPrivateDNSZone),
+		},
+		{
+			name:       "private-endpoint",
+			finalizers: []string{FinalizerPrivateEndpoint},
+			resources: describe("private endpoint %q", func(cluster *kubermaticv1.Cluster) string {
+				return cluster.Spec.Cloud.Azure.PrivateEndpoint
+			}),
+			run: withLogger(a.cleanUpPrivateCluster),
+		},
+		{
+			name:       "private-endpoints",
+			finalizers: []string{FinalizerPrivateEndpoints},
+			resources:  describe("private endpoints in resource group %q", resourceGroup),
+			run:        withLogger(a.cleanUpPrivateEndpoints),
+		},
+		{
+			name:       "bastion",
+			finalizers: []string{FinalizerBastion},
+			resources:  describe("Bastion host %q and its public IP", bastionName),
+			run:        withLogger(a.cleanUpBastion),
+		},
+		{
+			name:       "boot-diagnostics",
+			finalizers: []string{FinalizerBootDiagnosticsStorageAccount},
+			resources: describe("boot diagnostics storage account %q", func(cluster *kubermaticv1.Cluster) string {
+				return bootDiagnosticsStorageAccountName(cluster, credentials.SubscriptionID)
+			}),
+			run: withLogger(a.cleanUpBootDiagnostics),
+		},
+		{
+			name:       "log-analytics",
+			finalizers: []string{FinalizerLogAnalyticsWorkspace},
+			resources:  describe("Log Analytics workspace %q", logAnalyticsWorkspaceName),
+			run:        withLogger(a.cleanUpLogAnalytics),
+		},
+		{
+			name:       "additional-subnets",
+			finalizers: []string{FinalizerAdditionalSubnets},
+			resources: describe("additional subnets of VNet %q", func(cluster *kubermaticv1.Cluster) string {
+				return cluster.Spec.Cloud.Azure.VNetName
+			}),
+			run: withLogger(a.cleanUpAdditionalSubnets),
+		},
+		{
+			name:       "vnet-peerings",
+			finalizers: []string{FinalizerVNetPeerings},
+			resources: describe("peerings of VNet %q", func(cluster *kubermaticv1.Cluster) string {
+				return cluster.Spec.Cloud.Azure.VNetName
+			}),
+			run: withLogger(a.cleanUpVNetPeerings),
+		},
+		{
+			name:       "egress-firewall",
+			finalizers: []string{FinalizerEgressFirewall},
+			resources: describe("rule collections of Azure Firewall %q", func(cluster *kubermaticv1.Cluster) string {
+				if cluster.Status.Azure == nil {
+					return ""
+				}
+				return cluster.Status.Azure.EgressFirewallRulesID
+			}),
+			run: withLogger(a.cleanUpEgressFirewall),
+		},
+		{
+			name:       "service-principal",
+			finalizers: []string{FinalizerClusterServicePrincipal},
+			resources:  describe("service principal of cluster %q", func(cluster *kubermaticv1.Cluster) string { return cluster.Name }),
+			run:        withLogger(a.cleanUpClusterServicePrincipal),
+		},
+		{
+			name:      "leaked-resources",
+			resources: describe("network interfaces, disks and public IPs of the machines in resource group %q", resourceGroup),
+			run:       withLogger(a.cleanUpLeakedResources),
+		},
+		{
+			name:       "security-group",
+			finalizers: []string{FinalizerSecurityGroup},
+			resources: describe("security group %q", func(cluster *kubermaticv1.Cluster) string {
+				return cluster.Spec.Cloud.Azure.SecurityGroup
+			}),
+			run: withLogger(a.cleanUpSecurityGroup),
+		},
+		{
+			name:       "application-security-group",
+			finalizers: []string{FinalizerApplicationSecurityGroup},
+			resources: describe("application security group %q", func(cluster *kubermaticv1.Cluster) string {
+				return cluster.Spec.Cloud.Azure.ApplicationSecurityGroup
+			}),
+			run: withLogger(a.cleanUpApplicationSecurityGroup),
+		},
+		{
+			name:       "route-table",
+			finalizers: []string{FinalizerRouteTable},
+			resources: describe("route table %q", func(cluster *kubermaticv1.Cluster) string {
+				return cluster.Spec.Cloud.Azure.RouteTableName
+			}),
+			run: withLogger(a.cleanUpRouteTable),
+		},
+		{
+			name:       "subnet",
+			finalizers: []string{FinalizerSubnet},
+			resources: describe("subnet %q", func(cluster *kubermaticv1.Cluster) string {
+				return cluster.Spec.Cloud.Azure.SubnetName
+			}),
+			run: withLogger(a.cleanUpSubnet),
+		},
+		{
+			name:       "vnet",
+			finalizers: []string{FinalizerVNet},
+			resources: describe("virtual network %q", func(cluster *kubermaticv1.Cluster) string {
+				return cluster.Spec.Cloud.Azure.VNetName
+			}),
+			run: withLogger(a.cleanUpVNet),
+		},
+		{
+			name:       "resource-group",
+			finalizers: []string{FinalizerResourceGroup},
+			resources:  describe("resource group %q", resourceGroup),
+			run:        withLogger(a.cleanUpResourceGroup),
+		},
+		{
+			name:       "availability-set",
+			finalizers: []string{FinalizerAvailabilitySet},
+			resources: describe("availability set %q", func(cluster *kubermaticv1.Cluster) string {
+				return cluster.Spec.Cloud.Azure.AvailabilitySet
+			}),
+			run: withLogger(a.cleanUpAvailabilitySet),
+		},
+		{
+			name:       "machine-deployment-availability-sets",
+			finalizers: []string{FinalizerMachineDeploymentAvailabilitySets},
+			resources:  describe("availability sets of the machine deployments in resource group %q", resourceGroup),
+			run:        withLogger(a.cleanUpMachineDeploymentAvailabilitySets),
+		},
+		{
+			// the proximity placement group can only be deleted once the availability sets are gone
+			name:       "proximity-placement-group",
+			finalizers: []string{FinalizerProximityPlacementGroup},
+			resources: describe("proximity placement group %q", func(cluster *kubermaticv1.Cluster) string {
+				return cluster.Spec.Cloud.Azure.ProximityPlacementGroupID
+			}),
+			run: withLogger(a.cleanUpProximityPlacementGroup),
+		},
+	}
+}
+
+// runCleanupStep runs a single attempt of the step, which may take up to the step timeout of the
+// datacenter. Failed attempts are counted in an annotation of the cluster; once the step has
+// used up its attempts, a warning event is emitted and, if the cluster has the force-cleanup
+// annotation, the step is skipped. Operations which are still running are not failed attempts.
+func (a *Azure) runCleanupStep(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, step cleanupStep, logger *zap.SugaredLogger) (*kubermaticv1.Cluster, error) {
+	if len(step.finalizers) > 0 && !kuberneteshelper.HasAnyFinalizer(cluster, step.finalizers...) {
+		return cluster, nil
+	}
+	if cleanupSkipped(cluster, step.name) {
+		return cluster, nil
+	}
+
+	maxAttempts := a.cleanupMaxAttempts()
+	attempts := cleanupAttempts(cluster, step.name)
+	if attempts >= maxAttempts && forceCleanup(cluster) {
+		return a.skipCleanupStep(cluster, update, step, logger)
+	}
+
+	timeout := a.cleanupStepTimeout()
+	ctx, cancel := context.WithTimeout(a.ctx, timeout)
+	// the steps use the context of the provider, which is created for every reconciliation
+	parent := a.ctx
+	a.ctx = ctx
+	updated, err := step.run(cluster)
+	a.ctx = parent
+	timedOut := errors.Is(ctx.Err(), context.DeadlineExceeded)
+	cancel()
+
+	if err == nil {
+		if attempts > 0 {
+			if err := setClusterAnnotation(updated, update, cleanupAttemptsAnnotation(step.name), ""); err != nil {
+				return updated, fmt.Errorf("failed to reset the attempts of cleanup step %q: %w", step.name, err)
+			}
+		}
+		return updated, nil
+	}
+
+	if updated != nil {
+		cluster = updated
+	}
+	if errors.Is(err, ErrOperationInProgress) {
+		return cluster, err
+	}
+	if timedOut {
+		err = fmt.Errorf("cleanup step %q timed out after %v: %w", step.name, timeout, err)
+	}
+
+	attempts++
+	if updateErr := setClusterAnnotation(cluster, update, cleanupAttemptsAnnotation(step.name), strconv.Itoa(attempts)); updateErr != nil {
+		logger.Warnw("failed to record failed cleanup attempt", "step", step.name, "error", updateErr)
+	}
+
+	if attempts < maxAttempts {
+		return cluster, err
+	}
+
+	if forceCleanup(cluster) {
+		logger.Warnw("cleanup step failed", "step", step.name, "attempts", attempts, "error", err)
+		return a.skipCleanupStep(cluster, update, step, logger)
+	}
+
+	if attempts == maxAttempts {
+		a.recordWarning(cluster, "CleanupStuck", "Deleting the %s failed %d times, annotate the cluster with %s=true to skip it and leave the resources behind: %v",
+			step.resources(cluster), attempts, ForceCleanupAnnotation, err)
+	}
+
+	return cluster, err
+}
+
+// skipCleanupStep removes the finalizers of the step without deleting its resources, which are
+// reported in a warning event and the logs so that they can be removed manually. The step is
+// marked as skipped, as steps without finalizers would otherwise be run again.
+func (a *Azure) skipCleanupStep(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, step cleanupStep, logger *zap.SugaredLogger) (*kubermaticv1.Cluster, error) {
+	resources := step.resources(cluster)
+
+	logger.Warnw("skipping cleanup step, leaving its resources behind", "step", step.name, "resources", resources)
+	a.recordWarning(cluster, "LeftResourcesBehind", "Skipped the deletion of the %s as requested by the %s annotation, the resources have to be deleted manually",
+		resources, ForceCleanupAnnotation)
+
+	return update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+		kuberneteshelper.RemoveFinalizer(updatedCluster, step.finalizers...)
+		delete(updatedCluster.Annotations, cleanupAttemptsAnnotation(step.name))
+		if updatedCluster.Annotations == nil {
+			updatedCluster.Annotations = map[string]string{}
+		}
+		updatedCluster.Annotations[cleanupSkippedAnnotation(step.name)] = "true"
+	})
+}
+
+func cleanupAttemptsAnnotation(step string) string {
+	return cleanupAttemptsAnnotationPrefix + step
+}
+
+func cleanupSkippedAnnotation(step string) string {
+	return cleanupSkippedAnnotationPrefix + step
+}
+
+func cleanupSkipped(cluster *kubermaticv1.Cluster, step string) bool {
+	skipped, _ := strconv.ParseBool(cluster.Annotations[cleanupSkippedAnnotation(step)])
+	return skipped
+}
+
+// cleanupAttempts returns the number of failed attempts of the step, invalid values count as none.
+func cleanupAttempts(cluster *kubermaticv1.Cluster, step string) int {
+	attempts, err := strconv.Atoi(cluster.Annotations[cleanupAttemptsAnnotation(step)])
+	if err != nil {
+		return 0
+	}
+
+	return attempts
+}
+
+func forceCleanup(cluster *kubermaticv1.Cluster) bool {
+	force, _ := strconv.ParseBool(cluster.Annotations[ForceCleanupAnnotation])
+	return force
+}
+
+func (a *Azure) cleanupStepTimeout() time.Duration {
+	if a.dc.Cleanup != nil && a.dc.Cleanup.StepTimeout != nil && a.dc.Cleanup.StepTimeout.Duration > 0 {
+		return a.dc.Cleanup.StepTimeout.Duration
+	}
+
+	return defaultCleanupStepTimeout
+}
+
+func (a *Azure) cleanupMaxAttempts() int {
+	if a.dc.Cleanup != nil && a.dc.Cleanup.MaxAttempts > 0 {
+		return a.dc.Cleanup.MaxAttempts
+	}
+
+	return defaultCleanupMaxAttempts
+}
@@ -0,0 +1,270 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func testCleanupStep(run func(*kubermaticv1.Cluster) (*kubermaticv1.Cluster, error)) cleanupStep {
+	return cleanupStep{
+		name:       "security-group",
+		finalizers: []string{FinalizerSecurityGroup},
+		resources: func(cluster *kubermaticv1.Cluster) string {
+			return "security group " + cluster.Spec.Cloud.Azure.SecurityGroup
+		},
+		run: run,
+	}
+}
+
+func testCleanupCluster(annotations map[string]string) *kubermaticv1.Cluster {
+	return &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-cluster",
+			Annotations: annotations,
+			Finalizers:  []string{FinalizerSecurityGroup, FinalizerVNet},
+		},
+		Spec: kubermaticv1.ClusterSpec{
+			Cloud: kubermaticv1.CloudSpec{
+				Azure: &kubermaticv1.AzureCloudSpec{SecurityGroup: "kubernetes-test-cluster"},
+			},
+		},
+	}
+}
+
+func TestRunCleanupStep(t *testing.T) {
+	stuck := errors.New("security group is in use by a network interface")
+	inProgress := fmt.Errorf("operation %q: %w", "delete-security-group", ErrOperationInProgress)
+
+	tests := []struct {
+		name                string
+		annotations         map[string]string
+		err                 error
+		expectedErr         bool
+		expectedFinalizer   bool
+		expectedAttempts    string
+		expectedEventReason string
+	}{
+		{
+			name:              "successful step",
+			annotations:       map[string]string{cleanupAttemptsAnnotation("security-group"): "2"},
+			expectedFinalizer: false,
+		},
+		{
+			name:              "failed attempt is counted",
+			annotations:       map[string]string{cleanupAttemptsAnnotation("security-group"): "1"},
+			err:               stuck,
+			expectedErr:       true,
+			expectedFinalizer: true,
+			expectedAttempts:  "2",
+		},
+		{
+			name:                "last attempt reports the step as stuck",
+			annotations:         map[string]string{cleanupAttemptsAnnotation("security-group"): "2"},
+			err:                 stuck,
+			expectedErr:         true,
+			expectedFinalizer:   true,
+			expectedAttempts:    "3",
+			expectedEventReason: "CleanupStuck",
+		},
+		{
+			name: "last attempt skips the step if forced",
+			annotations: map[string]string{
+				cleanupAttemptsAnnotation("security-group"): "2",
+				ForceCleanupAnnotation:                      "true",
+			},
+			err:                 stuck,
+			expectedFinalizer:   false,
+			expectedEventReason: "LeftResourcesBehind",
+		},
+		{
+			name:              "force does not skip steps with attempts left",
+			annotations:       map[string]string{ForceCleanupAnnotation: "true"},
+			err:               stuck,
+			expectedErr:       true,
+			expectedFinalizer: true,
+			expectedAttempts:  "1",
+		},
+		{
+			name:              "running operation is not counted as a failed attempt",
+			annotations:       map[string]string{cleanupAttemptsAnnotation("security-group"): "1"},
+			err:               inProgress,
+			expectedErr:       true,
+			expectedFinalizer: true,
+			expectedAttempts:  "1",
+		},
+		{
+			name: "running operation of the last attempt is not skipped if forced",
+			annotations: map[string]string{
+				cleanupAttemptsAnnotation("security-group"): "2",
+				ForceCleanupAnnotation:                      "true",
+			},
+			err:               inProgress,
+			expectedErr:       true,
+			expectedFinalizer: true,
+			expectedAttempts:  "2",
+		},
+		{
+			name: "exhausted step is skipped without running it if forced",
+			annotations: map[string]string{
+				cleanupAttemptsAnnotation("security-group"): "5",
+				ForceCleanupAnnotation:                      "true",
+			},
+			err:                 errors.New("step must not be run"),
+			expectedFinalizer:   false,
+			expectedEventReason: "LeftResourcesBehind",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(10)
+			a := &Azure{
+				ctx:      context.Background(),
+				log:      zap.NewNop().Sugar(),
+				recorder: recorder,
+				dc:       &kubermaticv1.DatacenterSpecAzure{Cleanup: &kubermaticv1.AzureCleanupSettings{MaxAttempts: 3}},
+			}
+			cluster := testCleanupCluster(test.annotations)
+			stored := cluster.DeepCopy()
+			update := fakeClusterUpdater(stored)
+
+			step := testCleanupStep(func(cluster *kubermaticv1.Cluster) (*kubermaticv1.Cluster, error) {
+				if test.err != nil {
+					return cluster, test.err
+				}
+				return update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+					kuberneteshelper.RemoveFinalizer(updatedCluster, FinalizerSecurityGroup)
+				})
+			})
+
+			_, err := a.runCleanupStep(cluster, update, step, a.log)
+			if (err != nil) != test.expectedErr {
+				t.Fatalf("expected error: %v, got %v", test.expectedErr, err)
+			}
+
+			if hasFinalizer := kuberneteshelper.HasFinalizer(stored, FinalizerSecurityGroup); hasFinalizer != test.expectedFinalizer {
+				t.Errorf("expected finalizer: %v, got %v", test.expectedFinalizer, hasFinalizer)
+			}
+			if !kuberneteshelper.HasFinalizer(stored, FinalizerVNet) {
+				t.Error("expected the finalizers of other steps to be kept")
+			}
+			if attempts := stored.Annotations[cleanupAttemptsAnnotation("security-group")]; attempts != test.expectedAttempts {
+				t.Errorf("expected %q attempts, got %q", test.expectedAttempts, attempts)
+			}
+
+			select {
+			case event := <-recorder.Events:
+				if test.expectedEventReason == "" || !strings.Contains(event, test.expectedEventReason) {
+					t.Errorf("expected event %q, got %q", test.expectedEventReason, event)
+				}
+				if test.expectedEventReason == "LeftResourcesBehind" && !strings.Contains(event, "kubernetes-test-cluster") {
+					t.Errorf("expected the event to name the resources left behind, got %q", event)
+				}
+			default:
+				if test.expectedEventReason != "" {
+					t.Errorf("expected event %q, got none", test.expectedEventReason)
+				}
+			}
+		})
+	}
+}
+
+func TestRunCleanupStepTimeout(t *testing.T) {
+	a := &Azure{
+		ctx: context.Background(),
+		log: zap.NewNop().Sugar(),
+		dc:  &kubermaticv1.DatacenterSpecAzure{Cleanup: &kubermaticv1.AzureCleanupSettings{StepTimeout: &metav1.Duration{Duration: 10 * time.Millisecond}}},
+	}
+	cluster := testCleanupCluster(nil)
+	stored := cluster.DeepCopy()
+
+	step := testCleanupStep(func(cluster *kubermaticv1.Cluster) (*kubermaticv1.Cluster, error) {
+		<-a.ctx.Done()
+		return cluster, a.ctx.Err()
+	})
+
+	_, err := a.runCleanupStep(cluster, fakeClusterUpdater(stored), step, a.log)
+	if !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("expected the step to time out, got %v", err)
+	}
+	if a.ctx.Err() != nil {
+		t.Fatal("expected the context of the provider to be restored")
+	}
+	if attempts := stored.Annotations[cleanupAttemptsAnnotation("security-group")]; attempts != "1" {
+		t.Errorf("expected the timeout to count as a failed attempt, got %q attempts", attempts)
+	}
+}
+
+func TestRunCleanupStepWithoutFinalizer(t *testing.T) {
+	a := &Azure{ctx: context.Background(), log: zap.NewNop().Sugar(), dc: &kubermaticv1.DatacenterSpecAzure{}}
+	cluster := testCleanupCluster(nil)
+	cluster.Finalizers = []string{FinalizerVNet}
+
+	step := testCleanupStep(func(cluster *kubermaticv1.Cluster) (*kubermaticv1.Cluster, error) {
+		return nil, errors.New("step must not be run")
+	})
+
+	if _, err := a.runCleanupStep(cluster, fakeClusterUpdater(cluster.DeepCopy()), step, a.log); err != nil {
+		t.Fatalf("expected the step to be skipped, got %v", err)
+	}
+}
+
+func TestSkippedCleanupStepWithoutFinalizers(t *testing.T) {
+	a := &Azure{
+		ctx: context.Background(),
+		log: zap.NewNop().Sugar(),
+		dc:  &kubermaticv1.DatacenterSpecAzure{Cleanup: &kubermaticv1.AzureCleanupSettings{MaxAttempts: 1}},
+	}
+	cluster := testCleanupCluster(map[string]string{ForceCleanupAnnotation: "true"})
+	stored := cluster.DeepCopy()
+	update := fakeClusterUpdater(stored)
+
+	runs := 0
+	step := cleanupStep{
+		name:      "leaked-resources",
+		resources: func(*kubermaticv1.Cluster) string { return "leaked resources" },
+		run: func(cluster *kubermaticv1.Cluster) (*kubermaticv1.Cluster, error) {
+			runs++
+			return cluster, errors.New("network interface is still attached")
+		},
+	}
+
+	cluster, err := a.runCleanupStep(cluster, update, step, a.log)
+	if err != nil {
+		t.Fatalf("expected the step to be skipped after its last attempt, got %v", err)
+	}
+	if _, err := a.runCleanupStep(cluster, update, step, a.log); err != nil {
+		t.Fatalf("expected the skipped step not to be run again, got %v", err)
+	}
+	if runs != 1 {
+		t.Errorf("expected the step to be run once, got %d runs", runs)
+	}
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-06-01/network"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+)
+
+const (
+	// azureCNIMaxPodsPerNode is the number of pods a node can run with Azure CNI, an IP of the
+	// subnet is reserved for each of them on the network interface of the node.
+	azureCNIMaxPodsPerNode = 30
+	// azureCNIMinimumNodes is the number of nodes the subnet of a cluster using Azure CNI must
+	// at least have room for.
+	azureCNIMinimumNodes = 3
+	// subnetReservedIPs is the number of IPs Azure reserves in every subnet.
+	subnetReservedIPs = 5
+)
+
+// networkPlugin returns the network plugin of the cluster, with the default applied.
+func networkPlugin(cloud kubermaticv1.CloudSpec) kubermaticv1.AzureNetworkPlugin {
+	if cloud.Azure.NetworkPlugin == "" {
+		return kubermaticv1.AzureNetworkPluginKubenet
+	}
+	return cloud.Azure.NetworkPlugin
+}
+
+// usesAzureCNI returns whether the pods of the cluster get their IPs from the cluster's subnet.
+func usesAzureCNI(cloud kubermaticv1.CloudSpec) bool {
+	return networkPlugin(cloud) == kubermaticv1.AzureNetworkPluginAzure
+}
+
+// needsRouteTable returns whether a route table has to be created for the cluster. With Azure
+// CNI the pods are reachable without routes, so it is only needed for the user-defined routes
+// and the route to the egress firewall.
+func needsRouteTable(cloud kubermaticv1.CloudSpec) bool {
+	return !usesAzureCNI(cloud) || len(cloud.Azure.Routes) > 0 || cloud.Azure.EgressFirewall != nil
+}
+
+// validateAzureCNISubnet checks that the subnet has room for the IPs of the nodes and their
+// pods of a minimal cluster using Azure CNI.
+func validateAzureCNISubnet(subnet network.Subnet) error {
+	if subnet.SubnetPropertiesFormat == nil || subnet.AddressPrefix == nil {
+		return errors.New("subnet has no address prefix")
+	}
+
+	_, ipNet, err := net.ParseCIDR(*subnet.AddressPrefix)
+	if err != nil {
+		return fmt.Errorf("invalid address prefix %q of subnet: %w", *subnet.AddressPrefix, err)
+	}
+
+	ones, bits := ipNet.Mask.Size()
+	// larger subnets than this are always large enough and would overflow on 32 bit platforms
+	if bits-ones > 16 {
+		return nil
+	}
+
+	available := (1 << (bits - ones)) - subnetReservedIPs
+	required := azureCNIMinimumNodes * (azureCNIMaxPodsPerNode + 1)
+	if available < required {
+		return fmt.Errorf("subnet %s has %d usable IPs, but Azure CNI requires at least %d for %d nodes with %d pods each",
+			*subnet.AddressPrefix, available, required, azureCNIMinimumNodes, azureCNIMaxPodsPerNode)
+	}
+
+	return nil
+}
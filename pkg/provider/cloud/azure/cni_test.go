@@ -0,0 +1,109 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-06-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+)
+
+func TestValidateAzureCNISubnet(t *testing.T) {
+	testCases := []struct {
+		name          string
+		addressPrefix *string
+		expectedError bool
+	}{
+		{
+			name:          "large subnet",
+			addressPrefix: to.StringPtr("10.0.0.0/16"),
+		},
+		{
+			name:          "subnet with room for three nodes",
+			addressPrefix: to.StringPtr("10.0.0.0/25"),
+		},
+		{
+			name:          "subnet too small for three nodes",
+			addressPrefix: to.StringPtr("10.0.0.0/26"),
+			expectedError: true,
+		},
+		{
+			name:          "subnet without address prefix",
+			expectedError: true,
+		},
+		{
+			name:          "invalid address prefix",
+			addressPrefix: to.StringPtr("10.0.0.0"),
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			subnet := network.Subnet{
+				SubnetPropertiesFormat: &network.SubnetPropertiesFormat{AddressPrefix: tc.addressPrefix},
+			}
+
+			err := validateAzureCNISubnet(subnet)
+			if (err != nil) != tc.expectedError {
+				t.Errorf("expected error to be %v, got %v", tc.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestNeedsRouteTable(t *testing.T) {
+	testCases := []struct {
+		name     string
+		spec     kubermaticv1.AzureCloudSpec
+		expected bool
+	}{
+		{
+			name:     "kubenet by default",
+			expected: true,
+		},
+		{
+			name:     "kubenet",
+			spec:     kubermaticv1.AzureCloudSpec{NetworkPlugin: kubermaticv1.AzureNetworkPluginKubenet},
+			expected: true,
+		},
+		{
+			name: "Azure CNI",
+			spec: kubermaticv1.AzureCloudSpec{NetworkPlugin: kubermaticv1.AzureNetworkPluginAzure},
+		},
+		{
+			name: "Azure CNI with user-defined routes",
+			spec: kubermaticv1.AzureCloudSpec{
+				NetworkPlugin: kubermaticv1.AzureNetworkPluginAzure,
+				Routes:        []kubermaticv1.AzureRoute{{Name: "internet", AddressPrefix: "0.0.0.0/0", NextHopType: "Internet"}},
+			},
+			expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			spec := tc.spec
+			if needsRouteTable(kubermaticv1.CloudSpec{Azure: &spec}) != tc.expected {
+				t.Errorf("expected route table to be needed: %v", tc.expected)
+			}
+		})
+	}
+}
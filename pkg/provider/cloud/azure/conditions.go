@@ -0,0 +1,121 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"strings"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1/helper"
+	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	reasonProvisioned            = "Provisioned"
+	reasonProvisioningInProgress = "ProvisioningInProgress"
+	reasonProvisioningFailed     = "ProvisioningFailed"
+	reasonPending                = "Pending"
+)
+
+// resourceConditionTypes returns the conditions reporting the state of the cloud resources
+// of the cluster, in the order in which the resources are provisioned.
+func resourceConditionTypes(cloud kubermaticv1.CloudSpec) []kubermaticv1.ClusterConditionType {
+	types := []kubermaticv1.ClusterConditionType{
+		kubermaticv1.ClusterConditionAzureResourceGroupReady,
+		kubermaticv1.ClusterConditionAzureVNetReady,
+		kubermaticv1.ClusterConditionAzureSubnetReady,
+	}
+	if hasRouteTable(cloud) {
+		types = append(types, kubermaticv1.ClusterConditionAzureRouteTableReady)
+	}
+
+	return append(types,
+		kubermaticv1.ClusterConditionAzureSecurityGroupReady,
+		kubermaticv1.ClusterConditionAzureAvailabilitySetReady,
+	)
+}
+
+// hasRouteTable returns true if the cluster uses a route table, either because one is required
+// or because the user provided one.
+func hasRouteTable(cloud kubermaticv1.CloudSpec) bool {
+	return cloud.Azure.RouteTableName != "" || needsRouteTable(cloud)
+}
+
+// provisioningProgress tracks which cloud resource is currently provisioned, so that an error
+// can be attributed to it.
+type provisioningProgress struct {
+	completed []kubermaticv1.ClusterConditionType
+	current   kubermaticv1.ClusterConditionType
+}
+
+// start marks the current resource as provisioned and continues with the given one. Steps
+// which do not belong to any resource start ClusterConditionNone.
+func (p *provisioningProgress) start(conditionType kubermaticv1.ClusterConditionType) {
+	if p.current != kubermaticv1.ClusterConditionNone {
+		p.completed = append(p.completed, p.current)
+	}
+	p.current = conditionType
+}
+
+// setResourceConditions updates the resource conditions of the cluster after provisioning
+// stopped with the given error. The resource being provisioned when the error occurred is
+// reported as failed, or as in progress if a long-running operation is still pending. Resources
+// which were not reached yet are reported as pending unless they already have a condition.
+func setResourceConditions(cluster *kubermaticv1.Cluster, versions kubermatic.Versions, progress provisioningProgress, err error) {
+	if err == nil {
+		progress.start(kubermaticv1.ClusterConditionNone)
+	}
+
+	reached := sets.NewString()
+	for _, conditionType := range progress.completed {
+		reached.Insert(string(conditionType))
+		kubermaticv1helper.SetClusterCondition(cluster, versions, conditionType, corev1.ConditionTrue, reasonProvisioned, "")
+	}
+
+	if progress.current != kubermaticv1.ClusterConditionNone {
+		reached.Insert(string(progress.current))
+		if hasPendingOperation(cluster) {
+			kubermaticv1helper.SetClusterCondition(cluster, versions, progress.current, corev1.ConditionUnknown, reasonProvisioningInProgress, err.Error())
+		} else {
+			kubermaticv1helper.SetClusterCondition(cluster, versions, progress.current, corev1.ConditionFalse, reasonProvisioningFailed, err.Error())
+		}
+	}
+
+	for _, conditionType := range resourceConditionTypes(cluster.Spec.Cloud) {
+		if reached.Has(string(conditionType)) {
+			continue
+		}
+		if _, condition := kubermaticv1helper.GetClusterCondition(cluster, conditionType); condition == nil {
+			kubermaticv1helper.SetClusterCondition(cluster, versions, conditionType, corev1.ConditionUnknown, reasonPending, "Waiting for the preceding resources to be provisioned")
+		}
+	}
+}
+
+// hasPendingOperation returns true if the cluster carries a persisted long-running operation,
+// which is the case until the operation has finished.
+func hasPendingOperation(cluster *kubermaticv1.Cluster) bool {
+	for key := range cluster.Annotations {
+		if strings.HasPrefix(key, operationAnnotationPrefix) {
+			return true
+		}
+	}
+
+	return false
+}
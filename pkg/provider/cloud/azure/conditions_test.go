@@ -0,0 +1,161 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"errors"
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1/helper"
+	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSetResourceConditions(t *testing.T) {
+	testCases := []struct {
+		name        string
+		cloud       kubermaticv1.AzureCloudSpec
+		annotations map[string]string
+		existing    []kubermaticv1.ClusterConditionType
+		steps       []kubermaticv1.ClusterConditionType
+		err         error
+		expected    map[kubermaticv1.ClusterConditionType]corev1.ConditionStatus
+		reasons     map[kubermaticv1.ClusterConditionType]string
+	}{
+		{
+			name:  "all resources provisioned",
+			cloud: kubermaticv1.AzureCloudSpec{NetworkPlugin: kubermaticv1.AzureNetworkPluginAzure},
+			steps: []kubermaticv1.ClusterConditionType{
+				kubermaticv1.ClusterConditionAzureResourceGroupReady,
+				kubermaticv1.ClusterConditionAzureVNetReady,
+				kubermaticv1.ClusterConditionAzureSubnetReady,
+				kubermaticv1.ClusterConditionNone,
+				kubermaticv1.ClusterConditionAzureSecurityGroupReady,
+				kubermaticv1.ClusterConditionNone,
+				kubermaticv1.ClusterConditionAzureAvailabilitySetReady,
+			},
+			expected: map[kubermaticv1.ClusterConditionType]corev1.ConditionStatus{
+				kubermaticv1.ClusterConditionAzureResourceGroupReady:   corev1.ConditionTrue,
+				kubermaticv1.ClusterConditionAzureVNetReady:            corev1.ConditionTrue,
+				kubermaticv1.ClusterConditionAzureSubnetReady:          corev1.ConditionTrue,
+				kubermaticv1.ClusterConditionAzureSecurityGroupReady:   corev1.ConditionTrue,
+				kubermaticv1.ClusterConditionAzureAvailabilitySetReady: corev1.ConditionTrue,
+			},
+		},
+		{
+			name: "vnet failed",
+			steps: []kubermaticv1.ClusterConditionType{
+				kubermaticv1.ClusterConditionAzureResourceGroupReady,
+				kubermaticv1.ClusterConditionAzureVNetReady,
+			},
+			err: errors.New("quota exceeded"),
+			expected: map[kubermaticv1.ClusterConditionType]corev1.ConditionStatus{
+				kubermaticv1.ClusterConditionAzureResourceGroupReady:   corev1.ConditionTrue,
+				kubermaticv1.ClusterConditionAzureVNetReady:            corev1.ConditionFalse,
+				kubermaticv1.ClusterConditionAzureSubnetReady:          corev1.ConditionUnknown,
+				kubermaticv1.ClusterConditionAzureRouteTableReady:      corev1.ConditionUnknown,
+				kubermaticv1.ClusterConditionAzureSecurityGroupReady:   corev1.ConditionUnknown,
+				kubermaticv1.ClusterConditionAzureAvailabilitySetReady: corev1.ConditionUnknown,
+			},
+			reasons: map[kubermaticv1.ClusterConditionType]string{
+				kubermaticv1.ClusterConditionAzureVNetReady:   reasonProvisioningFailed,
+				kubermaticv1.ClusterConditionAzureSubnetReady: reasonPending,
+			},
+		},
+		{
+			name:        "subnet operation still running",
+			cloud:       kubermaticv1.AzureCloudSpec{NetworkPlugin: kubermaticv1.AzureNetworkPluginAzure},
+			annotations: map[string]string{operationAnnotation("create-subnet"): finishedOperation},
+			existing:    []kubermaticv1.ClusterConditionType{kubermaticv1.ClusterConditionAzureSecurityGroupReady},
+			steps: []kubermaticv1.ClusterConditionType{
+				kubermaticv1.ClusterConditionAzureResourceGroupReady,
+				kubermaticv1.ClusterConditionAzureVNetReady,
+				kubermaticv1.ClusterConditionAzureSubnetReady,
+			},
+			err: errors.New("context deadline exceeded"),
+			expected: map[kubermaticv1.ClusterConditionType]corev1.ConditionStatus{
+				kubermaticv1.ClusterConditionAzureResourceGroupReady:   corev1.ConditionTrue,
+				kubermaticv1.ClusterConditionAzureVNetReady:            corev1.ConditionTrue,
+				kubermaticv1.ClusterConditionAzureSubnetReady:          corev1.ConditionUnknown,
+				kubermaticv1.ClusterConditionAzureSecurityGroupReady:   corev1.ConditionTrue,
+				kubermaticv1.ClusterConditionAzureAvailabilitySetReady: corev1.ConditionUnknown,
+			},
+			reasons: map[kubermaticv1.ClusterConditionType]string{
+				kubermaticv1.ClusterConditionAzureSubnetReady: reasonProvisioningInProgress,
+			},
+		},
+		{
+			name:  "error outside of a resource",
+			cloud: kubermaticv1.AzureCloudSpec{NetworkPlugin: kubermaticv1.AzureNetworkPluginAzure},
+			steps: []kubermaticv1.ClusterConditionType{
+				kubermaticv1.ClusterConditionAzureResourceGroupReady,
+				kubermaticv1.ClusterConditionAzureVNetReady,
+				kubermaticv1.ClusterConditionAzureSubnetReady,
+				kubermaticv1.ClusterConditionNone,
+			},
+			err: errors.New("failed to reconcile application security group"),
+			expected: map[kubermaticv1.ClusterConditionType]corev1.ConditionStatus{
+				kubermaticv1.ClusterConditionAzureResourceGroupReady:   corev1.ConditionTrue,
+				kubermaticv1.ClusterConditionAzureVNetReady:            corev1.ConditionTrue,
+				kubermaticv1.ClusterConditionAzureSubnetReady:          corev1.ConditionTrue,
+				kubermaticv1.ClusterConditionAzureSecurityGroupReady:   corev1.ConditionUnknown,
+				kubermaticv1.ClusterConditionAzureAvailabilitySetReady: corev1.ConditionUnknown,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			versions := kubermatic.NewFakeVersions()
+			cloud := tc.cloud
+			cluster := &kubermaticv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Annotations: tc.annotations},
+				Spec: kubermaticv1.ClusterSpec{
+					Cloud: kubermaticv1.CloudSpec{Azure: &cloud},
+				},
+			}
+			for _, conditionType := range tc.existing {
+				kubermaticv1helper.SetClusterCondition(cluster, versions, conditionType, corev1.ConditionTrue, reasonProvisioned, "")
+			}
+
+			progress := provisioningProgress{}
+			for _, step := range tc.steps {
+				progress.start(step)
+			}
+			setResourceConditions(cluster, versions, progress, tc.err)
+
+			if len(cluster.Status.Conditions) != len(tc.expected) {
+				t.Fatalf("expected %d conditions, got %v", len(tc.expected), cluster.Status.Conditions)
+			}
+			for conditionType, status := range tc.expected {
+				_, condition := kubermaticv1helper.GetClusterCondition(cluster, conditionType)
+				if condition == nil {
+					t.Fatalf("expected condition %q to be set", conditionType)
+				}
+				if condition.Status != status {
+					t.Errorf("expected condition %q to be %q, got %q", conditionType, status, condition.Status)
+				}
+				if reason, ok := tc.reasons[conditionType]; ok && condition.Reason != reason {
+					t.Errorf("expected condition %q to have reason %q, got %q", conditionType, reason, condition.Reason)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"fmt"
+	"net"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+)
+
+// setDatacenterDefaults fills in the settings of new clusters which default to the ones of the
+// datacenter, so that they are visible in the spec before any resources are created. The names
+// of the resources created by Kubermatic are derived from the cluster name, which is not known
+// yet, and are set once the resources have been created.
+func setDatacenterDefaults(cloud *kubermaticv1.CloudSpec, dc *kubermaticv1.DatacenterSpecAzure) {
+	if cloud.Azure.LoadBalancerSKU == "" {
+		cloud.Azure.LoadBalancerSKU = dc.LoadBalancerSKU
+		if cloud.Azure.LoadBalancerSKU == "" {
+			cloud.Azure.LoadBalancerSKU = kubermaticv1.AzureBasicLBSKU
+		}
+	}
+
+	// the address ranges only apply to resources created by Kubermatic
+	if cloud.Azure.VNetName == "" && cloud.Azure.VNetCIDR == "" {
+		cloud.Azure.VNetCIDR = dc.VNetCIDR
+		if cloud.Azure.VNetCIDR == "" {
+			cloud.Azure.VNetCIDR = defaultVNetCIDR
+		}
+	}
+	if cloud.Azure.SubnetName == "" && cloud.Azure.SubnetCIDR == "" {
+		cloud.Azure.SubnetCIDR = dc.SubnetCIDR
+		if cloud.Azure.SubnetCIDR == "" && cloud.Azure.VNetName == "" {
+			cloud.Azure.SubnetCIDR = cloud.Azure.VNetCIDR
+		}
+		if cloud.Azure.SubnetCIDR == "" {
+			cloud.Azure.SubnetCIDR = defaultSubnetCIDR
+		}
+	}
+	if cloud.Azure.SecurityGroup == "" && len(cloud.Azure.AllowedIPRanges) == 0 && !cloud.Azure.PrivateCluster {
+		cloud.Azure.AllowedIPRanges = append([]string(nil), dc.AllowedIPRanges...)
+	}
+}
+
+// vnetCIDR returns the address space of the VNet created by Kubermatic.
+func vnetCIDR(cloud kubermaticv1.CloudSpec) string {
+	if cloud.Azure.VNetCIDR != "" {
+		return cloud.Azure.VNetCIDR
+	}
+	return defaultVNetCIDR
+}
+
+// subnetCIDR returns the address range of the subnet created by Kubermatic.
+func subnetCIDR(cloud kubermaticv1.CloudSpec) string {
+	if cloud.Azure.SubnetCIDR != "" {
+		return cloud.Azure.SubnetCIDR
+	}
+	return defaultSubnetCIDR
+}
+
+// validateAddressRanges checks the address ranges of the resources created by Kubermatic and
+// that the subnet fits into the VNet if both are created.
+func validateAddressRanges(cloud kubermaticv1.CloudSpec) error {
+	_, vnet, err := net.ParseCIDR(vnetCIDR(cloud))
+	if err != nil {
+		return fmt.Errorf("invalid VNet CIDR %q: %v", cloud.Azure.VNetCIDR, err)
+	}
+	subnetIP, subnet, err := net.ParseCIDR(subnetCIDR(cloud))
+	if err != nil {
+		return fmt.Errorf("invalid subnet CIDR %q: %v", cloud.Azure.SubnetCIDR, err)
+	}
+	if cloud.Azure.VNetName == "" && cloud.Azure.SubnetName == "" {
+		vnetSize, _ := vnet.Mask.Size()
+		subnetSize, _ := subnet.Mask.Size()
+		if !vnet.Contains(subnetIP) || subnetSize < vnetSize {
+			return fmt.Errorf("subnet CIDR %q is not within the VNet CIDR %q", subnetCIDR(cloud), vnetCIDR(cloud))
+		}
+	}
+
+	for _, ipRange := range cloud.Azure.AllowedIPRanges {
+		if _, _, err := net.ParseCIDR(ipRange); err != nil {
+			return fmt.Errorf("invalid allowed IP range %q: %v", ipRange, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest/to"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+func TestSetDatacenterDefaults(t *testing.T) {
+	tests := []struct {
+		name     string
+		dc       kubermaticv1.DatacenterSpecAzure
+		azure    kubermaticv1.AzureCloudSpec
+		expected kubermaticv1.AzureCloudSpec
+	}{
+		{
+			name: "built-in defaults",
+			expected: kubermaticv1.AzureCloudSpec{
+				LoadBalancerSKU: kubermaticv1.AzureBasicLBSKU,
+				VNetCIDR:        defaultVNetCIDR,
+				SubnetCIDR:      defaultSubnetCIDR,
+			},
+		},
+		{
+			name: "datacenter defaults",
+			dc: kubermaticv1.DatacenterSpecAzure{
+				LoadBalancerSKU: kubermaticv1.AzureStandardLBSKU,
+				VNetCIDR:        "10.10.0.0/16",
+				SubnetCIDR:      "10.10.1.0/24",
+				AllowedIPRanges: []string{"192.0.2.0/24"},
+			},
+			expected: kubermaticv1.AzureCloudSpec{
+				LoadBalancerSKU: kubermaticv1.AzureStandardLBSKU,
+				VNetCIDR:        "10.10.0.0/16",
+				SubnetCIDR:      "10.10.1.0/24",
+				AllowedIPRanges: []string{"192.0.2.0/24"},
+			},
+		},
+		{
+			name: "subnet defaults to the VNet address space",
+			dc:   kubermaticv1.DatacenterSpecAzure{VNetCIDR: "10.10.0.0/16"},
+			expected: kubermaticv1.AzureCloudSpec{
+				LoadBalancerSKU: kubermaticv1.AzureBasicLBSKU,
+				VNetCIDR:        "10.10.0.0/16",
+				SubnetCIDR:      "10.10.0.0/16",
+			},
+		},
+		{
+			name: "cluster settings take precedence",
+			dc: kubermaticv1.DatacenterSpecAzure{
+				LoadBalancerSKU: kubermaticv1.AzureStandardLBSKU,
+				VNetCIDR:        "10.10.0.0/16",
+				AllowedIPRanges: []string{"192.0.2.0/24"},
+			},
+			azure: kubermaticv1.AzureCloudSpec{
+				LoadBalancerSKU: kubermaticv1.AzureBasicLBSKU,
+				VNetCIDR:        "10.20.0.0/16",
+				SubnetCIDR:      "10.20.1.0/24",
+				AllowedIPRanges: []string{"198.51.100.0/24"},
+			},
+			expected: kubermaticv1.AzureCloudSpec{
+				LoadBalancerSKU: kubermaticv1.AzureBasicLBSKU,
+				VNetCIDR:        "10.20.0.0/16",
+				SubnetCIDR:      "10.20.1.0/24",
+				AllowedIPRanges: []string{"198.51.100.0/24"},
+			},
+		},
+		{
+			name: "existing resources",
+			dc: kubermaticv1.DatacenterSpecAzure{
+				VNetCIDR:        "10.10.0.0/16",
+				AllowedIPRanges: []string{"192.0.2.0/24"},
+			},
+			azure: kubermaticv1.AzureCloudSpec{
+				VNetName:      "vnet",
+				SubnetName:    "subnet",
+				SecurityGroup: "nsg",
+			},
+			expected: kubermaticv1.AzureCloudSpec{
+				VNetName:        "vnet",
+				SubnetName:      "subnet",
+				SecurityGroup:   "nsg",
+				LoadBalancerSKU: kubermaticv1.AzureBasicLBSKU,
+			},
+		},
+		{
+			name: "private cluster",
+			dc:   kubermaticv1.DatacenterSpecAzure{AllowedIPRanges: []string{"192.0.2.0/24"}},
+			azure: kubermaticv1.AzureCloudSpec{
+				PrivateCluster: true,
+			},
+			expected: kubermaticv1.AzureCloudSpec{
+				PrivateCluster:  true,
+				LoadBalancerSKU: kubermaticv1.AzureBasicLBSKU,
+				VNetCIDR:        defaultVNetCIDR,
+				SubnetCIDR:      defaultSubnetCIDR,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cloud := kubermaticv1.CloudSpec{Azure: test.azure.DeepCopy()}
+			setDatacenterDefaults(&cloud, &test.dc)
+
+			if !equality.Semantic.DeepEqual(*cloud.Azure, test.expected) {
+				t.Errorf("expected %+v, got %+v", test.expected, *cloud.Azure)
+			}
+		})
+	}
+}
+
+func TestValidateAddressRanges(t *testing.T) {
+	tests := []struct {
+		name          string
+		azure         kubermaticv1.AzureCloudSpec
+		expectedError string
+	}{
+		{
+			name: "defaults",
+		},
+		{
+			name:  "subnet within the VNet",
+			azure: kubermaticv1.AzureCloudSpec{VNetCIDR: "10.10.0.0/16", SubnetCIDR: "10.10.1.0/24", AllowedIPRanges: []string{"192.0.2.0/24"}},
+		},
+		{
+			name:          "subnet outside of the VNet",
+			azure:         kubermaticv1.AzureCloudSpec{VNetCIDR: "10.10.0.0/16", SubnetCIDR: "10.20.0.0/24"},
+			expectedError: `subnet CIDR "10.20.0.0/24" is not within the VNet CIDR "10.10.0.0/16"`,
+		},
+		{
+			name:          "subnet larger than the VNet",
+			azure:         kubermaticv1.AzureCloudSpec{VNetCIDR: "10.10.0.0/24", SubnetCIDR: "10.10.0.0/16"},
+			expectedError: `subnet CIDR "10.10.0.0/16" is not within the VNet CIDR "10.10.0.0/24"`,
+		},
+		{
+			name:  "subnet in an existing VNet",
+			azure: kubermaticv1.AzureCloudSpec{VNetName: "vnet", SubnetCIDR: "10.20.0.0/24"},
+		},
+		{
+			name:          "invalid allowed IP range",
+			azure:         kubermaticv1.AzureCloudSpec{AllowedIPRanges: []string{"192.0.2.1"}},
+			expectedError: `invalid allowed IP range "192.0.2.1": invalid CIDR address: 192.0.2.1`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateAddressRanges(kubermaticv1.CloudSpec{Azure: &test.azure})
+			if test.expectedError == "" && err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if test.expectedError != "" && (err == nil || err.Error() != test.expectedError) {
+				t.Fatalf("expected error %q, got %v", test.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestSSHRuleAllowedIPRanges(t *testing.T) {
+	cloud := kubermaticv1.CloudSpec{Azure: &kubermaticv1.AzureCloudSpec{AllowedIPRanges: []string{"192.0.2.0/24", "198.51.100.0/24"}}}
+
+	ssh := securityGroupRules(cloud, "sub")[0]
+	if ssh.SourceAddressPrefix != nil || ssh.SourceAddressPrefixes == nil || len(*ssh.SourceAddressPrefixes) != 2 {
+		t.Fatalf("expected the SSH rule to be restricted to the allowed IP ranges, got %v and %v", to.String(ssh.SourceAddressPrefix), ssh.SourceAddressPrefixes)
+	}
+
+	cloud.Azure.PrivateCluster = true
+	ssh = securityGroupRules(cloud, "sub")[0]
+	if to.String(ssh.SourceAddressPrefix) != "VirtualNetwork" || ssh.SourceAddressPrefixes != nil {
+		t.Fatalf("expected private clusters to only allow SSH from the VNet, got %v and %v", to.String(ssh.SourceAddressPrefix), ssh.SourceAddressPrefixes)
+	}
+}
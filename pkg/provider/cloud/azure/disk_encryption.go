@@ -0,0 +1,95 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	compute2020 "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-30/compute"
+	autorestazure "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/to"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+)
+
+// validateDiskEncryptionSetID ensures the ID refers to a disk encryption set in the given subscription.
+func validateDiskEncryptionSetID(id, subscriptionID string) (autorestazure.Resource, error) {
+	des, err := autorestazure.ParseResourceID(id)
+	if err != nil {
+		return des, fmt.Errorf("invalid disk encryption set ID %q: %w", id, err)
+	}
+	if !strings.EqualFold(des.Provider, "Microsoft.Compute") || !strings.EqualFold(des.ResourceType, "diskEncryptionSets") {
+		return des, fmt.Errorf("%q is not the ID of a disk encryption set", id)
+	}
+	// Azure only allows to encrypt disks with disk encryption sets of the same subscription.
+	if !strings.EqualFold(des.SubscriptionID, subscriptionID) {
+		return des, fmt.Errorf("disk encryption set %q must be in subscription %q", id, subscriptionID)
+	}
+
+	return des, nil
+}
+
+// validateDiskEncryptionSet ensures the disk encryption set of the cluster exists, is located in the
+// datacenter's region and is ready to encrypt the OS disks of the nodes.
+func validateDiskEncryptionSet(ctx context.Context, cloud kubermaticv1.CloudSpec, credentials Credentials, location string) error {
+	id := cloud.Azure.DiskEncryptionSetID
+
+	resource, err := validateDiskEncryptionSetID(id, credentials.SubscriptionID)
+	if err != nil {
+		return err
+	}
+
+	desClient, err := getDiskEncryptionSetsClient(cloud, credentials)
+	if err != nil {
+		return err
+	}
+
+	des, err := desClient.Get(ctx, resource.ResourceGroup, resource.ResourceName)
+	if err != nil {
+		return fmt.Errorf("failed to get disk encryption set %q: %w", id, err)
+	}
+
+	return checkDiskEncryptionSet(des, location)
+}
+
+// checkDiskEncryptionSet verifies that the disk encryption set can be used for disks in the given location.
+func checkDiskEncryptionSet(des compute2020.DiskEncryptionSet, location string) error {
+	if des.Location != nil && !strings.EqualFold(*des.Location, location) {
+		return fmt.Errorf("disk encryption set %q is located in %q, but the datacenter is located in %q", to.String(des.Name), *des.Location, location)
+	}
+	if des.EncryptionSetProperties == nil || des.ActiveKey == nil {
+		return fmt.Errorf("disk encryption set %q has no active key", to.String(des.Name))
+	}
+	if state := to.String(des.ProvisioningState); state != "" && state != "Succeeded" {
+		return fmt.Errorf("disk encryption set %q is not ready, provisioning state is %q", to.String(des.Name), state)
+	}
+
+	return nil
+}
+
+func getDiskEncryptionSetsClient(cloud kubermaticv1.CloudSpec, credentials Credentials) (*compute2020.DiskEncryptionSetsClient, error) {
+	var err error
+	desClient := compute2020.NewDiskEncryptionSetsClient(credentials.SubscriptionID)
+	err = configureClient(&desClient.Client, credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authorizer: %s", err.Error())
+	}
+
+	return &desClient, nil
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"testing"
+
+	compute2020 "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-30/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+)
+
+func TestValidateDiskEncryptionSetID(t *testing.T) {
+	tests := []struct {
+		name        string
+		id          string
+		expectedErr bool
+	}{
+		{
+			name: "valid ID",
+			id:   "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/diskEncryptionSets/des",
+		},
+		{
+			name:        "malformed ID",
+			id:          "des",
+			expectedErr: true,
+		},
+		{
+			name:        "other resource type",
+			id:          "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/availabilitySets/as",
+			expectedErr: true,
+		},
+		{
+			name:        "other subscription",
+			id:          "/subscriptions/other/resourceGroups/rg/providers/Microsoft.Compute/diskEncryptionSets/des",
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			des, err := validateDiskEncryptionSetID(test.id, "sub")
+			if (err != nil) != test.expectedErr {
+				t.Fatalf("expected error to be %v, got %v", test.expectedErr, err)
+			}
+			if err == nil && (des.ResourceGroup != "rg" || des.ResourceName != "des") {
+				t.Errorf("expected resource group rg and name des, got %q and %q", des.ResourceGroup, des.ResourceName)
+			}
+		})
+	}
+}
+
+func TestCheckDiskEncryptionSet(t *testing.T) {
+	des := func(location, state string, withKey bool) compute2020.DiskEncryptionSet {
+		props := &compute2020.EncryptionSetProperties{ProvisioningState: to.StringPtr(state)}
+		if withKey {
+			props.ActiveKey = &compute2020.KeyVaultAndKeyReference{KeyURL: to.StringPtr("https://vault.vault.azure.net/keys/key/version")}
+		}
+		return compute2020.DiskEncryptionSet{
+			Name:                    to.StringPtr("des"),
+			Location:                to.StringPtr(location),
+			EncryptionSetProperties: props,
+		}
+	}
+
+	tests := []struct {
+		name        string
+		des         compute2020.DiskEncryptionSet
+		expectedErr bool
+	}{
+		{
+			name: "usable disk encryption set",
+			des:  des("westeurope", "Succeeded", true),
+		},
+		{
+			name:        "other region",
+			des:         des("eastus", "Succeeded", true),
+			expectedErr: true,
+		},
+		{
+			name:        "no active key",
+			des:         des("westeurope", "Succeeded", false),
+			expectedErr: true,
+		},
+		{
+			name:        "still provisioning",
+			des:         des("westeurope", "Updating", true),
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := checkDiskEncryptionSet(test.des, "westeurope")
+			if (err != nil) != test.expectedErr {
+				t.Errorf("expected error to be %v, got %v", test.expectedErr, err)
+			}
+		})
+	}
+}
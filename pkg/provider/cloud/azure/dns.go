@@ -0,0 +1,321 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/privatedns/mgmt/2018-09-01/privatedns"
+	autorestazure "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/to"
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/provider"
+)
+
+const (
+	// privateDNSZoneLinkName is the name of the link between the private DNS zone and the cluster's VNet.
+	privateDNSZoneLinkName = "kubermatic-cluster-vnet"
+	// privateDNSRecordTTL is the TTL in seconds of the records in the private DNS zone.
+	privateDNSRecordTTL = 300
+)
+
+// privateDNSZoneName returns the name of the private DNS zone of the cluster. The zone is named
+// after the external name of the cluster, so it only shadows the name of this cluster within the
+// VNet and does not collide with the zones of other clusters sharing the VNet.
+func privateDNSZoneName(externalName string) (string, error) {
+	zone := strings.TrimSuffix(externalName, ".")
+	labels := strings.Split(zone, ".")
+	if len(labels) < 2 {
+		return "", fmt.Errorf("%q is not a fully qualified domain name", externalName)
+	}
+	for _, label := range labels {
+		if label == "" {
+			return "", fmt.Errorf("%q is not a fully qualified domain name", externalName)
+		}
+	}
+
+	return zone, nil
+}
+
+// apiServerRecordName returns the name of the API server record within the private DNS zone. The
+// record is at the apex of zones named after the external name. Zones of clusters created by earlier
+// versions are the parent domain of the external name, they are kept for the existing clusters.
+func apiServerRecordName(externalName, zone string) (string, error) {
+	name := strings.TrimSuffix(externalName, ".")
+	if name == zone {
+		return "@", nil
+	}
+	if record := strings.TrimSuffix(name, "."+zone); record != name && record != "" {
+		return record, nil
+	}
+
+	return "", fmt.Errorf("%q is not within the private DNS zone %q", externalName, zone)
+}
+
+// apiServerIP returns the IP the API server is reachable at from within the cluster's VNet.
+func apiServerIP(cluster *kubermaticv1.Cluster) string {
+	if cluster.Spec.Cloud.Azure.PrivateCluster {
+		return cluster.Spec.Cloud.Azure.PrivateEndpointIP
+	}
+	return cluster.Address.IP
+}
+
+// reconcilePrivateDNSZone creates the private DNS zone resolving the API server name and links it
+// to the cluster's VNet. If enabled, the hostnames of all VMs in the VNet are registered in the zone.
+// The API server record is reconciled on every pass, as the address of the API server can change.
+func (a *Azure) reconcilePrivateDNSZone(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, credentials Credentials, tags map[string]*string) (*kubermaticv1.Cluster, error) {
+	var err error
+	logger := a.log.With("cluster", cluster.Name)
+
+	// the external name is set once the cluster address has been reconciled
+	if cluster.Spec.Cloud.Azure.PrivateDNSZone == "" && cluster.Address.ExternalName != "" {
+		zone, err := privateDNSZoneName(cluster.Address.ExternalName)
+		if err != nil {
+			return cluster, err
+		}
+
+		logger.Infow("ensuring private DNS zone", "privateDNSZone", zone)
+		if err = a.pollOperation(cluster, update, credentials, "create-private-dns-zone", func() (autorestazure.FutureAPI, error) {
+			return ensurePrivateDNSZone(a.ctx, cluster.Spec.Cloud, zone, tags, credentials)
+		}); err != nil {
+			return cluster, fmt.Errorf("failed to create or update private DNS zone %q: %w", zone, err)
+		}
+
+		cluster, err = update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+			updatedCluster.Spec.Cloud.Azure.PrivateDNSZone = zone
+			kuberneteshelper.AddFinalizer(updatedCluster, FinalizerPrivateDNSZone)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if zone := cluster.Spec.Cloud.Azure.PrivateDNSZone; zone != "" && apiServerIP(cluster) != "" {
+		record, err := apiServerRecordName(cluster.Address.ExternalName, zone)
+		if err != nil {
+			return cluster, err
+		}
+		if err = ensurePrivateDNSRecord(a.ctx, cluster.Spec.Cloud, zone, record, apiServerIP(cluster), credentials); err != nil {
+			return cluster, fmt.Errorf("failed to create or update record %q in private DNS zone %q: %w", record, zone, err)
+		}
+	}
+
+	if cluster.Spec.Cloud.Azure.PrivateDNSZone != "" && !kuberneteshelper.HasFinalizer(cluster, FinalizerPrivateDNSZoneLink) {
+		logger.Infow("linking private DNS zone", "privateDNSZone", cluster.Spec.Cloud.Azure.PrivateDNSZone, "vnet", cluster.Spec.Cloud.Azure.VNetName)
+		if err = a.pollOperation(cluster, update, credentials, "create-private-dns-zone-link", func() (autorestazure.FutureAPI, error) {
+			return ensurePrivateDNSZoneLink(a.ctx, cluster.Spec.Cloud, tags, credentials)
+		}); err != nil {
+			return cluster, fmt.Errorf("failed to link private DNS zone %q to virtual network %q: %w", cluster.Spec.Cloud.Azure.PrivateDNSZone, cluster.Spec.Cloud.Azure.VNetName, err)
+		}
+
+		cluster, err = update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+			kuberneteshelper.AddFinalizer(updatedCluster, FinalizerPrivateDNSZoneLink)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return cluster, nil
+}
+
+// cleanUpPrivateDNSZone removes the link between the private DNS zone and the VNet and the zone
+// itself. Removing the link deregisters the nodes from the zone.
+func (a *Azure) cleanUpPrivateDNSZone(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, credentials Credentials, logger *zap.SugaredLogger) (*kubermaticv1.Cluster, error) {
+	var err error
+
+	// a zone cannot be deleted as long as it is linked to a VNet
+	if kuberneteshelper.HasFinalizer(cluster, FinalizerPrivateDNSZoneLink) {
+		logger.Infow("deleting private DNS zone link", "privateDNSZone", cluster.Spec.Cloud.Azure.PrivateDNSZone)
+		if err := a.pollOperation(cluster, update, credentials, "delete-private-dns-zone-link", func() (autorestazure.FutureAPI, error) {
+			return deletePrivateDNSZoneLink(a.ctx, cluster.Spec.Cloud, credentials)
+		}); err != nil {
+			if !isNotFound(err) {
+				return cluster, fmt.Errorf("failed to delete link of private DNS zone %q: %w", cluster.Spec.Cloud.Azure.PrivateDNSZone, err)
+			}
+		}
+
+		cluster, err = update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+			kuberneteshelper.RemoveFinalizer(updatedCluster, FinalizerPrivateDNSZoneLink)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if kuberneteshelper.HasFinalizer(cluster, FinalizerPrivateDNSZone) {
+		logger.Infow("deleting private DNS zone", "privateDNSZone", cluster.Spec.Cloud.Azure.PrivateDNSZone)
+		if err := a.pollOperation(cluster, update, credentials, "delete-private-dns-zone", func() (autorestazure.FutureAPI, error) {
+			return deletePrivateDNSZone(a.ctx, cluster.Spec.Cloud, credentials)
+		}); err != nil {
+			if !isNotFound(err) {
+				return cluster, fmt.Errorf("failed to delete private DNS zone %q: %w", cluster.Spec.Cloud.Azure.PrivateDNSZone, err)
+			}
+		}
+
+		cluster, err = update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+			kuberneteshelper.RemoveFinalizer(updatedCluster, FinalizerPrivateDNSZone)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return cluster, nil
+}
+
+// ensurePrivateDNSZone will start creating or updating the private DNS zone. The call is idempotent.
+func ensurePrivateDNSZone(ctx context.Context, cloud kubermaticv1.CloudSpec, zone string, tags map[string]*string, credentials Credentials) (autorestazure.FutureAPI, error) {
+	zonesClient, err := getPrivateDNSZonesClient(cloud, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	future, err := zonesClient.CreateOrUpdate(ctx, cloud.Azure.ResourceGroup, zone, privatedns.PrivateZone{
+		Location: to.StringPtr("global"),
+		Tags:     tags,
+	}, "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	return future.FutureAPI, nil
+}
+
+// ensurePrivateDNSZoneLink will start linking the private DNS zone to the cluster's VNet. The VMs in
+// the VNet are registered in the zone if a private DNS zone was requested explicitly. The call is idempotent.
+func ensurePrivateDNSZoneLink(ctx context.Context, cloud kubermaticv1.CloudSpec, tags map[string]*string, credentials Credentials) (autorestazure.FutureAPI, error) {
+	linksClient, err := getPrivateDNSZoneLinksClient(cloud, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	future, err := linksClient.CreateOrUpdate(ctx, cloud.Azure.ResourceGroup, cloud.Azure.PrivateDNSZone, privateDNSZoneLinkName, privatedns.VirtualNetworkLink{
+		Location: to.StringPtr("global"),
+		Tags:     tags,
+		VirtualNetworkLinkProperties: &privatedns.VirtualNetworkLinkProperties{
+			VirtualNetwork: &privatedns.SubResource{
+				ID: to.StringPtr(assembleVNetID(cloud, credentials.SubscriptionID)),
+			},
+			RegistrationEnabled: to.BoolPtr(cloud.Azure.EnablePrivateDNSZone),
+		},
+	}, "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	return future.FutureAPI, nil
+}
+
+// ensurePrivateDNSRecord will create or update an A record in the private DNS zone unless it
+// resolves to the IP already. The call is idempotent.
+func ensurePrivateDNSRecord(ctx context.Context, cloud kubermaticv1.CloudSpec, zone, record, ip string, credentials Credentials) error {
+	recordSetsClient, err := getPrivateDNSRecordSetsClient(cloud, credentials)
+	if err != nil {
+		return err
+	}
+
+	existing, err := recordSetsClient.Get(ctx, cloud.Azure.ResourceGroup, zone, privatedns.A, record)
+	if err != nil && !isNotFound(err) {
+		return err
+	}
+	if err == nil && hasARecord(existing, ip) {
+		return nil
+	}
+
+	_, err = recordSetsClient.CreateOrUpdate(ctx, cloud.Azure.ResourceGroup, zone, privatedns.A, record, privatedns.RecordSet{
+		RecordSetProperties: &privatedns.RecordSetProperties{
+			TTL:      to.Int64Ptr(privateDNSRecordTTL),
+			ARecords: &[]privatedns.ARecord{{Ipv4Address: to.StringPtr(ip)}},
+		},
+	}, "", "")
+	return err
+}
+
+// hasARecord returns true if the record set resolves to the given IP only.
+func hasARecord(recordSet privatedns.RecordSet, ip string) bool {
+	if recordSet.RecordSetProperties == nil || recordSet.ARecords == nil || len(*recordSet.ARecords) != 1 {
+		return false
+	}
+	return to.String((*recordSet.ARecords)[0].Ipv4Address) == ip
+}
+
+func deletePrivateDNSZoneLink(ctx context.Context, cloud kubermaticv1.CloudSpec, credentials Credentials) (autorestazure.FutureAPI, error) {
+	linksClient, err := getPrivateDNSZoneLinksClient(cloud, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	future, err := linksClient.Delete(ctx, cloud.Azure.ResourceGroup, cloud.Azure.PrivateDNSZone, privateDNSZoneLinkName, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return future.FutureAPI, nil
+}
+
+func deletePrivateDNSZone(ctx context.Context, cloud kubermaticv1.CloudSpec, credentials Credentials) (autorestazure.FutureAPI, error) {
+	zonesClient, err := getPrivateDNSZonesClient(cloud, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	future, err := zonesClient.Delete(ctx, cloud.Azure.ResourceGroup, cloud.Azure.PrivateDNSZone, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return future.FutureAPI, nil
+}
+
+func getPrivateDNSZonesClient(cloud kubermaticv1.CloudSpec, credentials Credentials) (*privatedns.PrivateZonesClient, error) {
+	var err error
+	zonesClient := privatedns.NewPrivateZonesClient(credentials.SubscriptionID)
+	err = configureClient(&zonesClient.Client, credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authorizer: %s", err.Error())
+	}
+
+	return &zonesClient, nil
+}
+
+func getPrivateDNSZoneLinksClient(cloud kubermaticv1.CloudSpec, credentials Credentials) (*privatedns.VirtualNetworkLinksClient, error) {
+	var err error
+	linksClient := privatedns.NewVirtualNetworkLinksClient(credentials.SubscriptionID)
+	err = configureClient(&linksClient.Client, credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authorizer: %s", err.Error())
+	}
+
+	return &linksClient, nil
+}
+
+func getPrivateDNSRecordSetsClient(cloud kubermaticv1.CloudSpec, credentials Credentials) (*privatedns.RecordSetsClient, error) {
+	var err error
+	recordSetsClient := privatedns.NewRecordSetsClient(credentials.SubscriptionID)
+	err = configureClient(&recordSetsClient.Client, credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authorizer: %s", err.Error())
+	}
+
+	return &recordSetsClient, nil
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+)
+
+func TestPrivateDNSZoneName(t *testing.T) {
+	testCases := []struct {
+		name          string
+		externalName  string
+		expectedZone  string
+		expectedError bool
+	}{
+		{
+			name:         "cluster name in seed domain",
+			externalName: "abcdef.europe-west3-c.dev.kubermatic.io",
+			expectedZone: "abcdef.europe-west3-c.dev.kubermatic.io",
+		},
+		{
+			name:         "trailing dot is ignored",
+			externalName: "abcdef.example.com.",
+			expectedZone: "abcdef.example.com",
+		},
+		{
+			name:          "not fully qualified",
+			externalName:  "abcdef",
+			expectedError: true,
+		},
+		{
+			name:          "empty label",
+			externalName:  ".example.com",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			zone, err := privateDNSZoneName(tc.externalName)
+			if (err != nil) != tc.expectedError {
+				t.Fatalf("expected error: %v, got: %v", tc.expectedError, err)
+			}
+			if zone != tc.expectedZone {
+				t.Errorf("expected zone %q, got %q", tc.expectedZone, zone)
+			}
+		})
+	}
+}
+
+func TestAPIServerRecordName(t *testing.T) {
+	testCases := []struct {
+		name           string
+		externalName   string
+		zone           string
+		expectedRecord string
+		expectedError  bool
+	}{
+		{
+			name:           "zone named after the cluster",
+			externalName:   "abcdef.example.com.",
+			zone:           "abcdef.example.com",
+			expectedRecord: "@",
+		},
+		{
+			name:           "zone of the parent domain",
+			externalName:   "abcdef.example.com",
+			zone:           "example.com",
+			expectedRecord: "abcdef",
+		},
+		{
+			name:          "external name outside of the zone",
+			externalName:  "abcdef.example.com",
+			zone:          "other.example.com",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			record, err := apiServerRecordName(tc.externalName, tc.zone)
+			if (err != nil) != tc.expectedError {
+				t.Fatalf("expected error: %v, got: %v", tc.expectedError, err)
+			}
+			if record != tc.expectedRecord {
+				t.Errorf("expected record %q, got %q", tc.expectedRecord, record)
+			}
+		})
+	}
+}
+
+func TestAPIServerIP(t *testing.T) {
+	cluster := &kubermaticv1.Cluster{
+		Spec: kubermaticv1.ClusterSpec{
+			Cloud: kubermaticv1.CloudSpec{
+				Azure: &kubermaticv1.AzureCloudSpec{
+					PrivateEndpointIP: "10.0.0.5",
+				},
+			},
+		},
+		Address: kubermaticv1.ClusterAddress{
+			IP: "1.2.3.4",
+		},
+	}
+
+	if ip := apiServerIP(cluster); ip != "1.2.3.4" {
+		t.Errorf("expected the cluster address for public clusters, got %q", ip)
+	}
+
+	cluster.Spec.Cloud.Azure.PrivateCluster = true
+	if ip := apiServerIP(cluster); ip != "10.0.0.5" {
+		t.Errorf("expected the private endpoint IP for private clusters, got %q", ip)
+	}
+}
@@ -0,0 +1,354 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"sort"
+	"strings"
+
+	network2020 "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	autorestazure "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/to"
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/provider"
+)
+
+// privateEndpointNamePrefix is the name prefix of the user-defined private endpoints, which
+// distinguishes them from the private endpoint of private clusters.
+const privateEndpointNamePrefix = "kubermatic-pe-"
+
+var privateEndpointNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9._-]{0,38}[a-zA-Z0-9_])?$`)
+
+// validateServiceEndpoints checks that the service endpoints refer to Azure services.
+func validateServiceEndpoints(services []string) error {
+	seen := map[string]struct{}{}
+
+	for _, service := range services {
+		if !strings.HasPrefix(service, "Microsoft.") || len(service) == len("Microsoft.") {
+			return fmt.Errorf("invalid service endpoint %q, expected the name of an Azure service like \"Microsoft.Storage\"", service)
+		}
+		if _, ok := seen[strings.ToLower(service)]; ok {
+			return fmt.Errorf("duplicate service endpoint %q", service)
+		}
+		seen[strings.ToLower(service)] = struct{}{}
+	}
+
+	return nil
+}
+
+// hasServiceEndpoints returns true if exactly the given services are enabled on the subnet.
+func hasServiceEndpoints(subnet network2020.Subnet, services []string) bool {
+	existing := map[string]struct{}{}
+	if subnet.SubnetPropertiesFormat != nil && subnet.ServiceEndpoints != nil {
+		for _, endpoint := range *subnet.ServiceEndpoints {
+			existing[strings.ToLower(to.String(endpoint.Service))] = struct{}{}
+		}
+	}
+
+	if len(existing) != len(services) {
+		return false
+	}
+	for _, service := range services {
+		if _, ok := existing[strings.ToLower(service)]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// serviceEndpoints returns the service endpoints for the given services. The locations of
+// already enabled service endpoints are kept, new ones default to the subnet's region.
+func serviceEndpoints(existing *[]network2020.ServiceEndpointPropertiesFormat, services []string) *[]network2020.ServiceEndpointPropertiesFormat {
+	locations := map[string]*[]string{}
+	if existing != nil {
+		for _, endpoint := range *existing {
+			locations[strings.ToLower(to.String(endpoint.Service))] = endpoint.Locations
+		}
+	}
+
+	endpoints := make([]network2020.ServiceEndpointPropertiesFormat, 0, len(services))
+	for _, service := range services {
+		endpoints = append(endpoints, network2020.ServiceEndpointPropertiesFormat{
+			Service:   to.StringPtr(service),
+			Locations: locations[strings.ToLower(service)],
+		})
+	}
+
+	return &endpoints
+}
+
+// validatePrivateEndpoints checks that the user-defined private endpoints can be created.
+func validatePrivateEndpoints(endpoints []kubermaticv1.AzurePrivateEndpoint) error {
+	names := map[string]struct{}{}
+
+	for _, endpoint := range endpoints {
+		if !privateEndpointNameRegexp.MatchString(endpoint.Name) {
+			return fmt.Errorf("invalid private endpoint name %q", endpoint.Name)
+		}
+		if _, ok := names[endpoint.Name]; ok {
+			return fmt.Errorf("duplicate private endpoint name %q", endpoint.Name)
+		}
+		names[endpoint.Name] = struct{}{}
+
+		if _, err := autorestazure.ParseResourceID(endpoint.PrivateLinkResourceID); err != nil {
+			return fmt.Errorf("invalid resource ID %q of private endpoint %q: %w", endpoint.PrivateLinkResourceID, endpoint.Name, err)
+		}
+		if len(endpoint.GroupIDs) == 0 {
+			return fmt.Errorf("private endpoint %q requires at least one group ID", endpoint.Name)
+		}
+	}
+
+	return nil
+}
+
+// privateEndpointName returns the name of the user-defined private endpoint in Azure, which
+// contains the cluster name as the endpoints of all clusters may share a resource group.
+func privateEndpointName(cluster *kubermaticv1.Cluster, name string) string {
+	return privateEndpointNamePrefix + cluster.Name + "-" + name
+}
+
+// privateEndpointOperation returns the name of an operation on the given private endpoint. The
+// name is hashed, as the operation name is part of an annotation key.
+func privateEndpointOperation(action, name string) string {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(name))
+	return fmt.Sprintf("%s-private-endpoint-%08x", action, hash.Sum32())
+}
+
+// isPrivateEndpointUpToDate returns true if the private endpoint in Azure connects to the
+// resource of the user-defined private endpoint.
+func isPrivateEndpointUpToDate(existing network2020.PrivateEndpoint, endpoint kubermaticv1.AzurePrivateEndpoint) bool {
+	if existing.PrivateEndpointProperties == nil || existing.PrivateLinkServiceConnections == nil || len(*existing.PrivateLinkServiceConnections) != 1 {
+		return false
+	}
+
+	connection := (*existing.PrivateLinkServiceConnections)[0]
+	if connection.PrivateLinkServiceConnectionProperties == nil || !strings.EqualFold(to.String(connection.PrivateLinkServiceID), endpoint.PrivateLinkResourceID) {
+		return false
+	}
+
+	var groupIDs []string
+	if connection.GroupIds != nil {
+		groupIDs = append(groupIDs, *connection.GroupIds...)
+	}
+	desiredGroupIDs := append([]string{}, endpoint.GroupIDs...)
+	sort.Strings(groupIDs)
+	sort.Strings(desiredGroupIDs)
+
+	return strings.Join(groupIDs, ",") == strings.Join(desiredGroupIDs, ",")
+}
+
+// reconcilePrivateEndpoints creates the user-defined private endpoints in the cluster's subnet and
+// removes the ones which are not configured anymore.
+func (a *Azure) reconcilePrivateEndpoints(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, credentials Credentials, location string, tags map[string]*string) (*kubermaticv1.Cluster, error) {
+	var err error
+	logger := a.log.With("cluster", cluster.Name)
+
+	endpoints := cluster.Spec.Cloud.Azure.PrivateEndpoints
+	if len(endpoints) == 0 && !kuberneteshelper.HasFinalizer(cluster, FinalizerPrivateEndpoints) {
+		return cluster, nil
+	}
+
+	// the finalizer is added first, so that no private endpoint is left behind if the cluster
+	// is deleted while they are being created
+	if len(endpoints) > 0 && !kuberneteshelper.HasFinalizer(cluster, FinalizerPrivateEndpoints) {
+		cluster, err = update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+			kuberneteshelper.AddFinalizer(updatedCluster, FinalizerPrivateEndpoints)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	existing, err := listPrivateEndpoints(a.ctx, cluster, credentials)
+	if err != nil {
+		return cluster, fmt.Errorf("failed to list private endpoints: %w", err)
+	}
+
+	desired := map[string]struct{}{}
+	for _, endpoint := range endpoints {
+		endpoint := endpoint
+		name := privateEndpointName(cluster, endpoint.Name)
+		desired[name] = struct{}{}
+
+		if current, ok := existing[name]; ok && isPrivateEndpointUpToDate(current, endpoint) {
+			continue
+		}
+
+		// private endpoints can only be placed in subnets with disabled network policies
+		if err = a.pollOperation(cluster, update, credentials, "disable-subnet-network-policies", func() (autorestazure.FutureAPI, error) {
+			return disablePrivateEndpointNetworkPolicies(a.ctx, cluster.Spec.Cloud, credentials)
+		}); err != nil {
+			return cluster, fmt.Errorf("failed to disable network policies of subnetwork %q: %w", cluster.Spec.Cloud.Azure.SubnetName, err)
+		}
+
+		logger.Infow("ensuring private endpoint", "privateEndpoint", name, "resource", endpoint.PrivateLinkResourceID)
+		if err = a.pollOperation(cluster, update, credentials, privateEndpointOperation("create", name), func() (autorestazure.FutureAPI, error) {
+			return ensureUserPrivateEndpoint(a.ctx, cluster.Spec.Cloud, name, endpoint, location, tags, credentials)
+		}); err != nil {
+			return cluster, fmt.Errorf("failed to create or update private endpoint %q: %w", name, err)
+		}
+	}
+
+	for name := range existing {
+		if _, ok := desired[name]; ok {
+			continue
+		}
+
+		if cluster, err = a.deleteUserPrivateEndpoint(cluster, update, credentials, name, logger); err != nil {
+			return cluster, err
+		}
+	}
+
+	if len(endpoints) == 0 {
+		cluster, err = update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+			kuberneteshelper.RemoveFinalizer(updatedCluster, FinalizerPrivateEndpoints)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return cluster, nil
+}
+
+// cleanUpPrivateEndpoints removes all user-defined private endpoints, which must happen before
+// the subnet can be deleted.
+func (a *Azure) cleanUpPrivateEndpoints(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, credentials Credentials, logger *zap.SugaredLogger) (*kubermaticv1.Cluster, error) {
+	var err error
+
+	if !kuberneteshelper.HasFinalizer(cluster, FinalizerPrivateEndpoints) {
+		return cluster, nil
+	}
+
+	existing, err := listPrivateEndpoints(a.ctx, cluster, credentials)
+	if err != nil {
+		if !isNotFound(err) {
+			return cluster, fmt.Errorf("failed to list private endpoints: %w", err)
+		}
+	}
+
+	for name := range existing {
+		if cluster, err = a.deleteUserPrivateEndpoint(cluster, update, credentials, name, logger); err != nil {
+			return cluster, err
+		}
+	}
+
+	cluster, err = update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+		kuberneteshelper.RemoveFinalizer(updatedCluster, FinalizerPrivateEndpoints)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return cluster, nil
+}
+
+func (a *Azure) deleteUserPrivateEndpoint(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, credentials Credentials, name string, logger *zap.SugaredLogger) (*kubermaticv1.Cluster, error) {
+	logger.Infow("deleting private endpoint", "privateEndpoint", name)
+	if err := a.pollOperation(cluster, update, credentials, privateEndpointOperation("delete", name), func() (autorestazure.FutureAPI, error) {
+		endpointsClient, err := getPrivateEndpointsClient(cluster.Spec.Cloud, credentials)
+		if err != nil {
+			return nil, err
+		}
+
+		future, err := endpointsClient.Delete(a.ctx, cluster.Spec.Cloud.Azure.ResourceGroup, name)
+		if err != nil {
+			return nil, err
+		}
+
+		return future.FutureAPI, nil
+	}); err != nil {
+		if !isNotFound(err) {
+			return cluster, fmt.Errorf("failed to delete private endpoint %q: %w", name, err)
+		}
+	}
+
+	return cluster, nil
+}
+
+// listPrivateEndpoints returns the user-defined private endpoints of the cluster, keyed by name.
+func listPrivateEndpoints(ctx context.Context, cluster *kubermaticv1.Cluster, credentials Credentials) (map[string]network2020.PrivateEndpoint, error) {
+	endpointsClient, err := getPrivateEndpointsClient(cluster.Spec.Cloud, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints := map[string]network2020.PrivateEndpoint{}
+	prefix := privateEndpointName(cluster, "")
+
+	it, err := endpointsClient.ListComplete(ctx, cluster.Spec.Cloud.Azure.ResourceGroup)
+	if err != nil {
+		return nil, err
+	}
+	for ; it.NotDone(); err = it.NextWithContext(ctx) {
+		if err != nil {
+			return nil, err
+		}
+
+		endpoint := it.Value()
+		if endpoint.Name != nil && strings.HasPrefix(*endpoint.Name, prefix) && to.String(endpoint.Tags[clusterTagKey]) == cluster.Name {
+			endpoints[*endpoint.Name] = endpoint
+		}
+	}
+
+	return endpoints, nil
+}
+
+// ensureUserPrivateEndpoint will start creating or updating a user-defined private endpoint in the
+// cluster's subnet. The call is idempotent.
+func ensureUserPrivateEndpoint(ctx context.Context, cloud kubermaticv1.CloudSpec, name string, endpoint kubermaticv1.AzurePrivateEndpoint, location string, tags map[string]*string, credentials Credentials) (autorestazure.FutureAPI, error) {
+	endpointsClient, err := getPrivateEndpointsClient(cloud, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	parameters := network2020.PrivateEndpoint{
+		Name:     to.StringPtr(name),
+		Location: to.StringPtr(location),
+		Tags:     tags,
+		PrivateEndpointProperties: &network2020.PrivateEndpointProperties{
+			Subnet: &network2020.Subnet{
+				ID: to.StringPtr(assembleSubnetIDForSubscription(cloud, credentials.SubscriptionID)),
+			},
+			PrivateLinkServiceConnections: &[]network2020.PrivateLinkServiceConnection{
+				{
+					Name: to.StringPtr(name),
+					PrivateLinkServiceConnectionProperties: &network2020.PrivateLinkServiceConnectionProperties{
+						PrivateLinkServiceID: to.StringPtr(endpoint.PrivateLinkResourceID),
+						GroupIds:             to.StringSlicePtr(endpoint.GroupIDs),
+					},
+				},
+			},
+		},
+	}
+
+	future, err := endpointsClient.CreateOrUpdate(ctx, cloud.Azure.ResourceGroup, name, parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	return future.FutureAPI, nil
+}
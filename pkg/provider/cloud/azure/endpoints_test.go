@@ -0,0 +1,191 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"testing"
+
+	network2020 "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+)
+
+const storageAccountID = "/subscriptions/sub/resourceGroups/data/providers/Microsoft.Storage/storageAccounts/backups"
+
+func TestValidateServiceEndpoints(t *testing.T) {
+	testCases := []struct {
+		name          string
+		services      []string
+		expectedError bool
+	}{
+		{
+			name:     "valid services",
+			services: []string{"Microsoft.Storage", "Microsoft.KeyVault", "Microsoft.Sql"},
+		},
+		{
+			name:          "missing namespace",
+			services:      []string{"Storage"},
+			expectedError: true,
+		},
+		{
+			name:          "only namespace",
+			services:      []string{"Microsoft."},
+			expectedError: true,
+		},
+		{
+			name:          "duplicate service",
+			services:      []string{"Microsoft.Storage", "microsoft.storage"},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateServiceEndpoints(tc.services)
+			if (err != nil) != tc.expectedError {
+				t.Fatalf("expected error: %v, got: %v", tc.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestServiceEndpoints(t *testing.T) {
+	subnet := network2020.Subnet{
+		SubnetPropertiesFormat: &network2020.SubnetPropertiesFormat{
+			ServiceEndpoints: &[]network2020.ServiceEndpointPropertiesFormat{
+				{Service: to.StringPtr("Microsoft.Storage"), Locations: &[]string{"westeurope", "northeurope"}},
+			},
+		},
+	}
+
+	if !hasServiceEndpoints(subnet, []string{"microsoft.storage"}) {
+		t.Error("expected service endpoints to be compared case-insensitively")
+	}
+	if hasServiceEndpoints(subnet, []string{"Microsoft.Storage", "Microsoft.KeyVault"}) {
+		t.Error("expected missing service endpoint to be detected")
+	}
+	if hasServiceEndpoints(subnet, nil) {
+		t.Error("expected superfluous service endpoint to be detected")
+	}
+	if !hasServiceEndpoints(network2020.Subnet{}, nil) {
+		t.Error("expected subnet without properties to have no service endpoints")
+	}
+
+	endpoints := *serviceEndpoints(subnet.ServiceEndpoints, []string{"Microsoft.Storage", "Microsoft.KeyVault"})
+	if len(endpoints) != 2 {
+		t.Fatalf("expected 2 service endpoints, got %d", len(endpoints))
+	}
+	if endpoints[0].Locations == nil || len(*endpoints[0].Locations) != 2 {
+		t.Errorf("expected locations of existing service endpoint to be kept, got %v", endpoints[0].Locations)
+	}
+	if endpoints[1].Locations != nil {
+		t.Errorf("expected new service endpoint to use the default location, got %v", *endpoints[1].Locations)
+	}
+}
+
+func TestValidatePrivateEndpoints(t *testing.T) {
+	backups := kubermaticv1.AzurePrivateEndpoint{
+		Name:                  "backups",
+		PrivateLinkResourceID: storageAccountID,
+		GroupIDs:              []string{"blob"},
+	}
+
+	testCases := []struct {
+		name          string
+		endpoints     []kubermaticv1.AzurePrivateEndpoint
+		expectedError bool
+	}{
+		{
+			name:      "valid private endpoint",
+			endpoints: []kubermaticv1.AzurePrivateEndpoint{backups},
+		},
+		{
+			name:          "duplicate name",
+			endpoints:     []kubermaticv1.AzurePrivateEndpoint{backups, backups},
+			expectedError: true,
+		},
+		{
+			name:          "invalid name",
+			endpoints:     []kubermaticv1.AzurePrivateEndpoint{{Name: "back ups", PrivateLinkResourceID: storageAccountID, GroupIDs: []string{"blob"}}},
+			expectedError: true,
+		},
+		{
+			name:          "name too long",
+			endpoints:     []kubermaticv1.AzurePrivateEndpoint{{Name: "backups-of-the-production-database-in-westeurope", PrivateLinkResourceID: storageAccountID, GroupIDs: []string{"blob"}}},
+			expectedError: true,
+		},
+		{
+			name:          "invalid resource ID",
+			endpoints:     []kubermaticv1.AzurePrivateEndpoint{{Name: "backups", PrivateLinkResourceID: "backups", GroupIDs: []string{"blob"}}},
+			expectedError: true,
+		},
+		{
+			name:          "missing group ID",
+			endpoints:     []kubermaticv1.AzurePrivateEndpoint{{Name: "backups", PrivateLinkResourceID: storageAccountID}},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validatePrivateEndpoints(tc.endpoints)
+			if (err != nil) != tc.expectedError {
+				t.Fatalf("expected error: %v, got: %v", tc.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestIsPrivateEndpointUpToDate(t *testing.T) {
+	existing := network2020.PrivateEndpoint{
+		PrivateEndpointProperties: &network2020.PrivateEndpointProperties{
+			PrivateLinkServiceConnections: &[]network2020.PrivateLinkServiceConnection{
+				{
+					PrivateLinkServiceConnectionProperties: &network2020.PrivateLinkServiceConnectionProperties{
+						PrivateLinkServiceID: to.StringPtr(storageAccountID),
+						GroupIds:             &[]string{"file", "blob"},
+					},
+				},
+			},
+		},
+	}
+
+	if !isPrivateEndpointUpToDate(existing, kubermaticv1.AzurePrivateEndpoint{Name: "backups", PrivateLinkResourceID: storageAccountID, GroupIDs: []string{"blob", "file"}}) {
+		t.Error("expected private endpoint to be up to date")
+	}
+	if isPrivateEndpointUpToDate(existing, kubermaticv1.AzurePrivateEndpoint{Name: "backups", PrivateLinkResourceID: storageAccountID, GroupIDs: []string{"blob"}}) {
+		t.Error("expected changed group IDs to be detected")
+	}
+	if isPrivateEndpointUpToDate(existing, kubermaticv1.AzurePrivateEndpoint{Name: "backups", PrivateLinkResourceID: storageAccountID + "2", GroupIDs: []string{"blob", "file"}}) {
+		t.Error("expected changed resource to be detected")
+	}
+	if isPrivateEndpointUpToDate(network2020.PrivateEndpoint{}, kubermaticv1.AzurePrivateEndpoint{Name: "backups", PrivateLinkResourceID: storageAccountID, GroupIDs: []string{"blob"}}) {
+		t.Error("expected private endpoint without properties to be outdated")
+	}
+}
+
+func TestPrivateEndpointOperation(t *testing.T) {
+	name := privateEndpointName(&kubermaticv1.Cluster{}, "backups-of-the-production-database")
+
+	for _, action := range []string{"create", "delete"} {
+		// the operation name is part of an annotation key, whose name part is limited to 63 characters
+		if key := "operation-" + privateEndpointOperation(action, name); len(key) > 63 {
+			t.Errorf("operation name %q is too long", key)
+		}
+	}
+}
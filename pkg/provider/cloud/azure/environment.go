@@ -0,0 +1,36 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"fmt"
+
+	autorestazure "github.com/Azure/go-autorest/autorest/azure"
+)
+
+// GetEnvironment returns the Azure cloud with the given name, e.g. "AzureUSGovernmentCloud". An
+// empty name refers to the public cloud.
+func GetEnvironment(name string) (autorestazure.Environment, error) {
+	if name == "" {
+		return autorestazure.PublicCloud, nil
+	}
+	environment, err := autorestazure.EnvironmentFromName(name)
+	if err != nil {
+		return environment, fmt.Errorf("unknown Azure environment %q", name)
+	}
+	return environment, nil
+}
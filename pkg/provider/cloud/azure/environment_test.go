@@ -0,0 +1,62 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"testing"
+)
+
+func TestGetEnvironment(t *testing.T) {
+	testCases := []struct {
+		name                    string
+		environment             string
+		resourceManagerEndpoint string
+		errExpected             bool
+	}{
+		{
+			name:                    "public cloud by default",
+			resourceManagerEndpoint: "https://management.azure.com/",
+		},
+		{
+			name:                    "US government cloud",
+			environment:             "AzureUSGovernmentCloud",
+			resourceManagerEndpoint: "https://management.usgovcloudapi.net/",
+		},
+		{
+			name:                    "China cloud",
+			environment:             "AzureChinaCloud",
+			resourceManagerEndpoint: "https://management.chinacloudapi.cn/",
+		},
+		{
+			name:        "unknown cloud",
+			environment: "AzureMoonCloud",
+			errExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			environment, err := GetEnvironment(tc.environment)
+			if (err != nil) != tc.errExpected {
+				t.Fatalf("expected error: %v, got %v", tc.errExpected, err)
+			}
+			if err == nil && environment.ResourceManagerEndpoint != tc.resourceManagerEndpoint {
+				t.Errorf("expected resource manager endpoint %q, got %q", tc.resourceManagerEndpoint, environment.ResourceManagerEndpoint)
+			}
+		})
+	}
+}
@@ -0,0 +1,228 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	autorestazure "github.com/Azure/go-autorest/autorest/azure"
+)
+
+var (
+	// ErrNotFound is returned when an Azure resource does not exist.
+	ErrNotFound = errors.New("azure resource not found")
+	// ErrQuotaExceeded is returned when a quota or limit of the subscription was hit.
+	ErrQuotaExceeded = errors.New("azure quota exceeded")
+	// ErrAuthorization is returned when the credentials are invalid or lack permissions.
+	ErrAuthorization = errors.New("azure authorization failed")
+	// ErrThrottled is returned when Azure rejected a request because of too many requests.
+	ErrThrottled = errors.New("azure request throttled")
+	// ErrConflict is returned when a resource is in a conflicting state, e.g. because
+	// another operation on it is still in progress.
+	ErrConflict = errors.New("azure resource conflict")
+	// ErrOperationInProgress is returned when a long-running operation has been started or
+	// polled and did not finish yet. The cluster has to be reconciled again to continue.
+	ErrOperationInProgress = errors.New("azure operation in progress")
+)
+
+// defaultRetryAfter is used for throttled requests without a Retry-After header.
+const defaultRetryAfter = 30 * time.Second
+
+// Error is an error returned by the Azure API, classified into one of the
+// provider errors above. It can be matched with errors.Is against them.
+type Error struct {
+	// Kind is one of the provider errors, or nil if the error could not be classified.
+	Kind error
+	// StatusCode is the HTTP status code of the response, if any.
+	StatusCode int
+	// Code is the error code returned by Azure, e.g. "ResourceGroupNotFound".
+	Code string
+	// RetryAfter is the delay requested by Azure for throttled requests.
+	RetryAfter time.Duration
+
+	err error
+}
+
+func (e *Error) Error() string {
+	return e.err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+func (e *Error) Is(target error) bool {
+	return e.Kind != nil && e.Kind == target
+}
+
+// ClassifyError returns the given error as *Error if it was returned by the Azure API.
+// Errors which are already classified or which do not come from Azure are returned unchanged.
+func ClassifyError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var azureErr *Error
+	if errors.As(err, &azureErr) {
+		return err
+	}
+
+	classified := &Error{err: err}
+
+	var reqErr *autorestazure.RequestError
+	var serviceErr *autorestazure.ServiceError
+	var serviceErrValue autorestazure.ServiceError
+	var detErr autorest.DetailedError
+	switch {
+	case errors.As(err, &reqErr):
+		classified.setDetails(reqErr.DetailedError)
+		if reqErr.ServiceError != nil {
+			classified.Code = reqErr.ServiceError.Code
+		}
+	case errors.As(err, &detErr):
+		classified.setDetails(detErr)
+	}
+
+	switch {
+	case classified.Code != "":
+	case errors.As(err, &serviceErr):
+		classified.Code = serviceErr.Code
+	case errors.As(err, &serviceErrValue):
+		classified.Code = serviceErrValue.Code
+	}
+
+	classified.Kind = errorKind(classified.StatusCode, classified.Code)
+	if classified.Kind == nil && classified.StatusCode == 0 && classified.Code == "" {
+		return err
+	}
+	if classified.Kind == ErrThrottled && classified.RetryAfter == 0 {
+		classified.RetryAfter = defaultRetryAfter
+	}
+
+	return classified
+}
+
+func (e *Error) setDetails(detErr autorest.DetailedError) {
+	if statusCode, ok := detErr.StatusCode.(int); ok {
+		e.StatusCode = statusCode
+	}
+	if detErr.Response != nil {
+		if e.StatusCode == 0 {
+			e.StatusCode = detErr.Response.StatusCode
+		}
+		e.RetryAfter = parseRetryAfter(detErr.Response.Header.Get("Retry-After"))
+	}
+}
+
+// errorKind maps the status code and Azure error code of a response to a provider error.
+// The error code takes precedence, as Azure reports e.g. quota errors with different status codes.
+func errorKind(statusCode int, code string) error {
+	switch {
+	case strings.Contains(code, "Quota") || strings.HasSuffix(code, "LimitReached") || strings.HasSuffix(code, "LimitExceeded"):
+		return ErrQuotaExceeded
+	case strings.HasSuffix(code, "NotFound"):
+		return ErrNotFound
+	case strings.HasPrefix(code, "AuthorizationFailed") || strings.HasPrefix(code, "InvalidAuthenticationToken") || code == "LinkedAuthorizationFailed":
+		return ErrAuthorization
+	case code == "TooManyRequests" || strings.HasSuffix(code, "Throttled"):
+		return ErrThrottled
+	case code == "AnotherOperationInProgress" || code == "Conflict":
+		return ErrConflict
+	}
+
+	switch statusCode {
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return ErrAuthorization
+	case http.StatusTooManyRequests:
+		return ErrThrottled
+	case http.StatusConflict:
+		return ErrConflict
+	}
+
+	return nil
+}
+
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// isNotFound returns true if the error was caused by a missing resource.
+func isNotFound(err error) bool {
+	return errors.Is(ClassifyError(err), ErrNotFound)
+}
+
+// isForbidden returns true if the error was caused by missing permissions.
+func isForbidden(err error) bool {
+	return errors.Is(ClassifyError(err), ErrAuthorization)
+}
+
+// isConflict returns true if the error was caused by a conflicting resource.
+func isConflict(err error) bool {
+	return errors.Is(ClassifyError(err), ErrConflict)
+}
+
+// IsTerminalError returns true if retrying the failed request will not succeed
+// without the user changing the credentials or the quotas of the subscription.
+func IsTerminalError(err error) bool {
+	err = ClassifyError(err)
+	return errors.Is(err, ErrAuthorization) || errors.Is(err, ErrQuotaExceeded)
+}
+
+// RetryAfter returns the delay after which a throttled request should be retried,
+// and false if the error was not caused by throttling.
+func RetryAfter(err error) (time.Duration, bool) {
+	var azureErr *Error
+	if !errors.As(ClassifyError(err), &azureErr) || azureErr.Kind != ErrThrottled {
+		return 0, false
+	}
+	return azureErr.RetryAfter, true
+}
+
+// HTTPStatusCode returns the HTTP status code the API should respond with for the error,
+// or defaultCode if the error was not returned by Azure or could not be classified.
+func HTTPStatusCode(err error, defaultCode int) int {
+	err = ClassifyError(err)
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrAuthorization), errors.Is(err, ErrQuotaExceeded):
+		return http.StatusForbidden
+	case errors.Is(err, ErrThrottled):
+		return http.StatusTooManyRequests
+	case errors.Is(err, ErrConflict):
+		return http.StatusConflict
+	}
+	return defaultCode
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	autorestazure "github.com/Azure/go-autorest/autorest/azure"
+)
+
+func requestError(statusCode int, code string) error {
+	return autorest.DetailedError{
+		Original: &autorestazure.RequestError{
+			DetailedError: autorest.DetailedError{StatusCode: statusCode},
+			ServiceError:  &autorestazure.ServiceError{Code: code},
+		},
+		StatusCode: statusCode,
+	}
+}
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		kind           error
+		httpStatusCode int
+	}{
+		{
+			name:           "not found",
+			err:            autorest.DetailedError{StatusCode: http.StatusNotFound},
+			kind:           ErrNotFound,
+			httpStatusCode: http.StatusNotFound,
+		},
+		{
+			name:           "wrapped resource group not found",
+			err:            fmt.Errorf("failed to get security group: %w", requestError(http.StatusNotFound, "ResourceGroupNotFound")),
+			kind:           ErrNotFound,
+			httpStatusCode: http.StatusNotFound,
+		},
+		{
+			name:           "quota exceeded reported as conflict",
+			err:            requestError(http.StatusConflict, "QuotaExceeded"),
+			kind:           ErrQuotaExceeded,
+			httpStatusCode: http.StatusForbidden,
+		},
+		{
+			name:           "public IP limit reached",
+			err:            requestError(http.StatusBadRequest, "PublicIPCountLimitReached"),
+			kind:           ErrQuotaExceeded,
+			httpStatusCode: http.StatusForbidden,
+		},
+		{
+			name:           "authorization failed",
+			err:            requestError(http.StatusForbidden, "AuthorizationFailed"),
+			kind:           ErrAuthorization,
+			httpStatusCode: http.StatusForbidden,
+		},
+		{
+			name:           "invalid client secret",
+			err:            autorest.DetailedError{StatusCode: http.StatusUnauthorized},
+			kind:           ErrAuthorization,
+			httpStatusCode: http.StatusForbidden,
+		},
+		{
+			name:           "throttled",
+			err:            autorest.DetailedError{StatusCode: http.StatusTooManyRequests},
+			kind:           ErrThrottled,
+			httpStatusCode: http.StatusTooManyRequests,
+		},
+		{
+			name:           "another operation in progress",
+			err:            requestError(http.StatusBadRequest, "AnotherOperationInProgress"),
+			kind:           ErrConflict,
+			httpStatusCode: http.StatusConflict,
+		},
+		{
+			name:           "failed long-running operation",
+			err:            fmt.Errorf("failed to delete virtual network: %w", &autorestazure.ServiceError{Code: "InUseSubnetCannotBeDeleted"}),
+			httpStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:           "unrelated error",
+			err:            errors.New("invalid CIDR"),
+			httpStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	kinds := []error{ErrNotFound, ErrQuotaExceeded, ErrAuthorization, ErrThrottled, ErrConflict}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ClassifyError(test.err)
+			for _, kind := range kinds {
+				if is := errors.Is(err, kind); is != (kind == test.kind) {
+					t.Errorf("expected errors.Is(err, %q) to be %v", kind, !is)
+				}
+			}
+
+			if err.Error() != test.err.Error() {
+				t.Errorf("expected error message %q, got %q", test.err.Error(), err.Error())
+			}
+
+			if code := HTTPStatusCode(test.err, http.StatusInternalServerError); code != test.httpStatusCode {
+				t.Errorf("expected HTTP status code %d, got %d", test.httpStatusCode, code)
+			}
+
+			if terminal := IsTerminalError(test.err); terminal != (test.kind == ErrAuthorization || test.kind == ErrQuotaExceeded) {
+				t.Errorf("expected IsTerminalError to be %v", !terminal)
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		retryAfter time.Duration
+		throttled  bool
+	}{
+		{
+			name: "Retry-After header",
+			err: autorest.DetailedError{
+				StatusCode: http.StatusTooManyRequests,
+				Response:   &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": []string{"17"}}},
+			},
+			retryAfter: 17 * time.Second,
+			throttled:  true,
+		},
+		{
+			name:       "without Retry-After header",
+			err:        fmt.Errorf("failed to list NICs: %w", requestError(http.StatusTooManyRequests, "SubscriptionRequestsThrottled")),
+			retryAfter: defaultRetryAfter,
+			throttled:  true,
+		},
+		{
+			name: "not throttled",
+			err:  autorest.DetailedError{StatusCode: http.StatusNotFound},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			retryAfter, throttled := RetryAfter(test.err)
+			if throttled != test.throttled {
+				t.Fatalf("expected throttled to be %v", test.throttled)
+			}
+			if retryAfter != test.retryAfter {
+				t.Errorf("expected retry after %v, got %v", test.retryAfter, retryAfter)
+			}
+		})
+	}
+}
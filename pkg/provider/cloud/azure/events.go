@@ -0,0 +1,69 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"errors"
+	"fmt"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+// SetEventRecorder sets the recorder used to emit an event on the cluster for every
+// provisioning and cleanup step, so that cluster owners can follow the progress without
+// access to the controller logs. Without a recorder no events are emitted.
+func (a *Azure) SetEventRecorder(recorder record.EventRecorder) {
+	a.recorder = recorder
+}
+
+// recordEvent emits a normal event on the cluster.
+func (a *Azure) recordEvent(cluster *kubermaticv1.Cluster, reason, messageFmt string, args ...interface{}) {
+	if a.recorder == nil || cluster == nil {
+		return
+	}
+	a.recorder.Eventf(cluster, corev1.EventTypeNormal, reason, messageFmt, args...)
+}
+
+// recordError emits a warning event on the cluster for the failed step.
+func (a *Azure) recordError(cluster *kubermaticv1.Cluster, reason string, err error) {
+	// operations which are still running are not an error
+	if a.recorder == nil || cluster == nil || errors.Is(err, ErrOperationInProgress) {
+		return
+	}
+	a.recorder.Event(cluster, corev1.EventTypeWarning, reason, errorEventMessage(err))
+}
+
+// recordWarning emits a warning event on the cluster.
+func (a *Azure) recordWarning(cluster *kubermaticv1.Cluster, reason, messageFmt string, args ...interface{}) {
+	if a.recorder == nil || cluster == nil {
+		return
+	}
+	a.recorder.Eventf(cluster, corev1.EventTypeWarning, reason, messageFmt, args...)
+}
+
+// errorEventMessage prefixes the error with the HTTP status code returned by Azure, if
+// any, as it is usually the quickest hint at the cause (e.g. 403 for missing permissions).
+func errorEventMessage(err error) string {
+	var azureErr *Error
+	if errors.As(ClassifyError(err), &azureErr) && azureErr.StatusCode != 0 {
+		return fmt.Sprintf("%d: %v", azureErr.StatusCode, err)
+	}
+	return err.Error()
+}
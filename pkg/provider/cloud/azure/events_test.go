@@ -0,0 +1,68 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestRecordError(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	a := &Azure{}
+	cluster := &kubermaticv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"}}
+
+	// without a recorder no events are emitted
+	a.recordError(cluster, "FailedToDeleteRouteTable", errors.New("boom"))
+
+	a.SetEventRecorder(recorder)
+	a.recordEvent(cluster, "EnsuredVNet", "Ensured virtual network %q", "kubernetes-test-cluster")
+	a.recordError(cluster, "FailedToDeleteRouteTable", autorest.DetailedError{
+		StatusCode: http.StatusForbidden,
+		Message:    "authorization failed",
+	})
+
+	expected := []string{
+		`Normal EnsuredVNet Ensured virtual network "kubernetes-test-cluster"`,
+		"Warning FailedToDeleteRouteTable 403: ",
+	}
+	for _, prefix := range expected {
+		select {
+		case event := <-recorder.Events:
+			if !strings.HasPrefix(event, prefix) {
+				t.Errorf("expected event starting with %q, got %q", prefix, event)
+			}
+		default:
+			t.Fatalf("expected event starting with %q, got none", prefix)
+		}
+	}
+
+	select {
+	case event := <-recorder.Events:
+		t.Errorf("unexpected event %q", event)
+	default:
+	}
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-06-01/network"
+	autorestazure "github.com/Azure/go-autorest/autorest/azure"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+)
+
+// setReferencedResourceNames sets the names of the route table and the security group referenced
+// by their IDs, which are used in the cloud config and to look them up.
+func setReferencedResourceNames(cloud *kubermaticv1.CloudSpec) {
+	if cloud.Azure.RouteTableID != "" && cloud.Azure.RouteTableName == "" {
+		if routeTable, err := autorestazure.ParseResourceID(cloud.Azure.RouteTableID); err == nil {
+			cloud.Azure.RouteTableName = routeTable.ResourceName
+		}
+	}
+	if cloud.Azure.SecurityGroupID != "" && cloud.Azure.SecurityGroup == "" {
+		if sg, err := autorestazure.ParseResourceID(cloud.Azure.SecurityGroupID); err == nil {
+			cloud.Azure.SecurityGroup = sg.ResourceName
+		}
+	}
+}
+
+// RouteTableResourceGroup returns the resource group of the cluster's route table.
+func RouteTableResourceGroup(cloud kubermaticv1.CloudSpec) string {
+	return referencedResourceGroup(cloud, cloud.Azure.RouteTableID)
+}
+
+// SecurityGroupResourceGroup returns the resource group of the cluster's security group.
+func SecurityGroupResourceGroup(cloud kubermaticv1.CloudSpec) string {
+	return referencedResourceGroup(cloud, cloud.Azure.SecurityGroupID)
+}
+
+// referencedResourceGroup returns the resource group of the resource with the given ID, or the
+// resource group of the cluster if no ID is given.
+func referencedResourceGroup(cloud kubermaticv1.CloudSpec, id string) string {
+	if id != "" {
+		if resource, err := autorestazure.ParseResourceID(id); err == nil {
+			return resource.ResourceGroup
+		}
+	}
+	return cloud.Azure.ResourceGroup
+}
+
+// validateReferencedResourceIDs checks that the route table and the security group referenced by
+// their IDs are in the given subscription and match the names in the cloud spec.
+func validateReferencedResourceIDs(cloud kubermaticv1.CloudSpec, subscriptionID string) error {
+	if cloud.Azure.RouteTableID != "" {
+		if err := validateReferencedResourceID(cloud.Azure.RouteTableID, "routeTables", cloud.Azure.RouteTableName, subscriptionID); err != nil {
+			return fmt.Errorf("invalid route table: %w", err)
+		}
+		if len(cloud.Azure.Routes) > 0 {
+			return fmt.Errorf("routes can only be added to route tables created by Kubermatic, not to route table %q", cloud.Azure.RouteTableID)
+		}
+	}
+	if cloud.Azure.SecurityGroupID != "" {
+		if err := validateReferencedResourceID(cloud.Azure.SecurityGroupID, "networkSecurityGroups", cloud.Azure.SecurityGroup, subscriptionID); err != nil {
+			return fmt.Errorf("invalid security group: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func validateReferencedResourceID(id, resourceType, name, subscriptionID string) error {
+	resource, err := autorestazure.ParseResourceID(id)
+	if err != nil {
+		return fmt.Errorf("invalid ID %q: %w", id, err)
+	}
+	if !strings.EqualFold(resource.Provider, "Microsoft.Network") || !strings.EqualFold(resource.ResourceType, resourceType) {
+		return fmt.Errorf("%q is not the ID of a resource of type %s", id, resourceType)
+	}
+	// the clients of the cluster are bound to its subscription
+	if subscriptionID != "" && !strings.EqualFold(resource.SubscriptionID, subscriptionID) {
+		return fmt.Errorf("%q must be in subscription %q", id, subscriptionID)
+	}
+	if name != "" && name != resource.ResourceName {
+		return fmt.Errorf("the name %q does not match the ID %q", name, id)
+	}
+
+	return nil
+}
+
+// isSecurityGroupAssociated returns true if the subnet is associated with the given security group.
+func isSecurityGroupAssociated(subnet network.Subnet, securityGroupID string) bool {
+	if subnet.SubnetPropertiesFormat == nil || subnet.NetworkSecurityGroup == nil || subnet.NetworkSecurityGroup.ID == nil {
+		return false
+	}
+
+	return strings.EqualFold(*subnet.NetworkSecurityGroup.ID, securityGroupID)
+}
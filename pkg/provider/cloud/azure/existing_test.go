@@ -0,0 +1,104 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+)
+
+const (
+	testRouteTableID    = "/subscriptions/sub/resourceGroups/network-rg/providers/Microsoft.Network/routeTables/shared-rt"
+	testSecurityGroupID = "/subscriptions/sub/resourceGroups/network-rg/providers/Microsoft.Network/networkSecurityGroups/shared-nsg"
+)
+
+func TestReferencedResources(t *testing.T) {
+	cloud := kubermaticv1.CloudSpec{Azure: &kubermaticv1.AzureCloudSpec{
+		ResourceGroup:   "cluster-rg",
+		RouteTableID:    testRouteTableID,
+		SecurityGroupID: testSecurityGroupID,
+	}}
+
+	setReferencedResourceNames(&cloud)
+
+	if cloud.Azure.RouteTableName != "shared-rt" || cloud.Azure.SecurityGroup != "shared-nsg" {
+		t.Fatalf("expected the names to be taken from the IDs, got %q and %q", cloud.Azure.RouteTableName, cloud.Azure.SecurityGroup)
+	}
+	if rg := RouteTableResourceGroup(cloud); rg != "network-rg" {
+		t.Errorf("expected route table resource group %q, got %q", "network-rg", rg)
+	}
+	if rg := SecurityGroupResourceGroup(cloud); rg != "network-rg" {
+		t.Errorf("expected security group resource group %q, got %q", "network-rg", rg)
+	}
+	if id := assembleSecurityGroupID(cloud, "sub", cloud.Azure.SecurityGroup); id != testSecurityGroupID {
+		t.Errorf("expected security group ID %q, got %q", testSecurityGroupID, id)
+	}
+
+	cloud.Azure.RouteTableID = ""
+	if rg := RouteTableResourceGroup(cloud); rg != "cluster-rg" {
+		t.Errorf("expected route tables without ID in the cluster resource group, got %q", rg)
+	}
+}
+
+func TestValidateReferencedResourceIDs(t *testing.T) {
+	tests := []struct {
+		name          string
+		azure         kubermaticv1.AzureCloudSpec
+		expectedError string
+	}{
+		{
+			name:  "route table and security group in the subscription",
+			azure: kubermaticv1.AzureCloudSpec{RouteTableID: testRouteTableID, SecurityGroupID: testSecurityGroupID},
+		},
+		{
+			name:          "route table in another subscription",
+			azure:         kubermaticv1.AzureCloudSpec{RouteTableID: "/subscriptions/other/resourceGroups/network-rg/providers/Microsoft.Network/routeTables/shared-rt"},
+			expectedError: `invalid route table: "/subscriptions/other/resourceGroups/network-rg/providers/Microsoft.Network/routeTables/shared-rt" must be in subscription "sub"`,
+		},
+		{
+			name:          "security group ID of another resource type",
+			azure:         kubermaticv1.AzureCloudSpec{SecurityGroupID: testRouteTableID},
+			expectedError: `invalid security group: "` + testRouteTableID + `" is not the ID of a resource of type networkSecurityGroups`,
+		},
+		{
+			name:          "name not matching the ID",
+			azure:         kubermaticv1.AzureCloudSpec{SecurityGroup: "other", SecurityGroupID: testSecurityGroupID},
+			expectedError: `invalid security group: the name "other" does not match the ID "` + testSecurityGroupID + `"`,
+		},
+		{
+			name: "routes in a route table referenced by ID",
+			azure: kubermaticv1.AzureCloudSpec{
+				RouteTableID: testRouteTableID,
+				Routes:       []kubermaticv1.AzureRoute{{Name: "firewall", AddressPrefix: "0.0.0.0/0", NextHopType: "Internet"}},
+			},
+			expectedError: `routes can only be added to route tables created by Kubermatic, not to route table "` + testRouteTableID + `"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateReferencedResourceIDs(kubermaticv1.CloudSpec{Azure: &test.azure}, "sub")
+			if test.expectedError == "" && err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if test.expectedError != "" && (err == nil || err.Error() != test.expectedError) {
+				t.Fatalf("expected error %q, got %v", test.expectedError, err)
+			}
+		})
+	}
+}
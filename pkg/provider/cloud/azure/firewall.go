@@ -0,0 +1,751 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-06-01/network"
+	network2020 "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	autorestazure "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/to"
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/provider"
+)
+
+const (
+	// FinalizerEgressFirewall will instruct the removal of the default route to the egress firewall
+	// and of the rule collections Kubermatic added to it
+	FinalizerEgressFirewall = "kubermatic.io/cleanup-azure-egress-firewall"
+
+	// egressFirewallRouteName is the name of the default route to the firewall. It does not use the
+	// prefix of the user-defined routes, so that it is left untouched when reconciling them.
+	egressFirewallRouteName = "kubermatic-egress-firewall"
+	// egressFirewallAddressPrefix is the destination of the route to the firewall.
+	egressFirewallAddressPrefix = "0.0.0.0/0"
+
+	defaultEgressFirewallRulePriority int32 = 1000
+	minEgressFirewallRulePriority     int32 = 100
+	maxEgressFirewallRulePriority     int32 = 65000
+)
+
+// requiredEgressFQDNs are the FQDNs the nodes need to reach over HTTP and HTTPS to pull the
+// images of the cluster components, install their packages and talk to the Azure APIs.
+var requiredEgressFQDNs = []string{
+	"k8s.gcr.io",
+	"gcr.io",
+	"storage.googleapis.com",
+	"quay.io",
+	"*.quay.io",
+	"docker.io",
+	"*.docker.io",
+	"production.cloudflare.docker.com",
+	"packages.cloud.google.com",
+	"github.com",
+	"*.githubusercontent.com",
+	"management.azure.com",
+	"login.microsoftonline.com",
+	"*.ubuntu.com",
+	"*.centos.org",
+	"*.flatcar-linux.net",
+}
+
+// parseAzureFirewallID returns the subscription, resource group and name of an Azure Firewall.
+func parseAzureFirewallID(id string) (autorestazure.Resource, error) {
+	firewall, err := autorestazure.ParseResourceID(id)
+	if err != nil {
+		return firewall, fmt.Errorf("invalid Azure Firewall ID %q: %w", id, err)
+	}
+	if !strings.EqualFold(firewall.Provider, "Microsoft.Network") || !strings.EqualFold(firewall.ResourceType, "azureFirewalls") {
+		return firewall, fmt.Errorf("%q is not the ID of an Azure Firewall", id)
+	}
+
+	return firewall, nil
+}
+
+// validateEgressFirewall checks that the default route to the firewall can be added to the route
+// table created by Kubermatic without conflicting with the user-defined routes.
+func validateEgressFirewall(cloud kubermaticv1.CloudSpec) error {
+	firewall := cloud.Azure.EgressFirewall
+	if firewall == nil {
+		return nil
+	}
+
+	if _, err := parseAzureFirewallID(firewall.ID); err != nil {
+		return err
+	}
+	if p := firewall.RulePriority; p != 0 && (p < minEgressFirewallRulePriority || p > maxEgressFirewallRulePriority) {
+		return fmt.Errorf("egress firewall rule priority must be between %d and %d, got %d", minEgressFirewallRulePriority, maxEgressFirewallRulePriority, p)
+	}
+	if len(firewall.AllowedFQDNs) > 0 && !firewall.CreateRules {
+		return errors.New("allowed FQDNs can only be configured if the rules of the egress firewall are created by Kubermatic")
+	}
+	for _, fqdn := range firewall.AllowedFQDNs {
+		if fqdn == "" || strings.ContainsAny(fqdn, " /:") {
+			return fmt.Errorf("invalid FQDN %q", fqdn)
+		}
+	}
+	if cloud.Azure.RouteTableID != "" {
+		return errors.New("the egress firewall can only be used with the route table created by Kubermatic")
+	}
+	for _, route := range cloud.Azure.Routes {
+		if route.AddressPrefix == egressFirewallAddressPrefix {
+			return fmt.Errorf("route %q conflicts with the default route to the egress firewall", route.Name)
+		}
+	}
+
+	return nil
+}
+
+// egressFirewallRuleCollectionName returns the name of the rule collections of the cluster.
+func egressFirewallRuleCollectionName(cluster *kubermaticv1.Cluster) string {
+	return "kubermatic-" + cluster.Name
+}
+
+// egressFirewallRulePriority returns the lowest priority of the rule collections of the cluster.
+func egressFirewallRulePriority(firewall *kubermaticv1.AzureEgressFirewall) int32 {
+	if firewall.RulePriority != 0 {
+		return firewall.RulePriority
+	}
+	return defaultEgressFirewallRulePriority
+}
+
+// egressFirewallRoute returns the default route to the private IP address of the firewall.
+func egressFirewallRoute(privateIP string) kubermaticv1.AzureRoute {
+	return kubermaticv1.AzureRoute{
+		Name:             egressFirewallRouteName,
+		AddressPrefix:    egressFirewallAddressPrefix,
+		NextHopType:      string(network.RouteNextHopTypeVirtualAppliance),
+		NextHopIPAddress: privateIP,
+	}
+}
+
+// firewallAddress returns the private IP address of the firewall and the ID of the VNet it is
+// deployed to.
+func firewallAddress(firewall network2020.AzureFirewall) (string, string, error) {
+	if firewall.AzureFirewallPropertiesFormat == nil || firewall.IPConfigurations == nil || len(*firewall.IPConfigurations) == 0 {
+		return "", "", fmt.Errorf("firewall %q has no IP configuration", to.String(firewall.Name))
+	}
+
+	config := (*firewall.IPConfigurations)[0]
+	if config.AzureFirewallIPConfigurationPropertiesFormat == nil || to.String(config.PrivateIPAddress) == "" {
+		return "", "", fmt.Errorf("firewall %q has no private IP address yet", to.String(firewall.Name))
+	}
+	if config.Subnet == nil || to.String(config.Subnet.ID) == "" {
+		return "", "", fmt.Errorf("firewall %q is not deployed to a virtual network", to.String(firewall.Name))
+	}
+
+	return *config.PrivateIPAddress, vnetIDOfSubnet(*config.Subnet.ID), nil
+}
+
+// vnetIDOfSubnet returns the ID of the VNet the subnet with the given ID belongs to.
+func vnetIDOfSubnet(subnetID string) string {
+	if i := strings.Index(strings.ToLower(subnetID), "/subnets/"); i >= 0 {
+		return subnetID[:i]
+	}
+	return subnetID
+}
+
+// isFirewallReachable returns true if the firewall is deployed to the cluster's VNet or to a VNet
+// the cluster's VNet is peered with.
+func isFirewallReachable(firewallVNetID, clusterVNetID string, peerings []network.VirtualNetworkPeering) bool {
+	if strings.EqualFold(firewallVNetID, clusterVNetID) {
+		return true
+	}
+
+	for _, peering := range peerings {
+		if peering.VirtualNetworkPeeringPropertiesFormat == nil || peering.RemoteVirtualNetwork == nil {
+			continue
+		}
+		if peering.PeeringState == network.VirtualNetworkPeeringStateConnected && strings.EqualFold(to.String(peering.RemoteVirtualNetwork.ID), firewallVNetID) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// egressFirewallRuleCollections returns the application and network rule collections which allow
+// the nodes in the given address ranges to reach everything they need.
+func egressFirewallRuleCollections(cluster *kubermaticv1.Cluster, sources []string, priority int32) (network2020.AzureFirewallApplicationRuleCollection, network2020.AzureFirewallNetworkRuleCollection) {
+	name := egressFirewallRuleCollectionName(cluster)
+	firewall := cluster.Spec.Cloud.Azure.EgressFirewall
+
+	webProtocols := []network2020.AzureFirewallApplicationRuleProtocol{
+		{ProtocolType: network2020.AzureFirewallApplicationRuleProtocolTypeHTTP, Port: to.Int32Ptr(80)},
+		{ProtocolType: network2020.AzureFirewallApplicationRuleProtocolTypeHTTPS, Port: to.Int32Ptr(443)},
+	}
+	applicationRules := []network2020.AzureFirewallApplicationRule{
+		{
+			Name:            to.StringPtr("required-fqdns"),
+			SourceAddresses: to.StringSlicePtr(sources),
+			Protocols:       &webProtocols,
+			TargetFqdns:     to.StringSlicePtr(requiredEgressFQDNs),
+		},
+	}
+	if len(firewall.AllowedFQDNs) > 0 {
+		applicationRules = append(applicationRules, network2020.AzureFirewallApplicationRule{
+			Name:            to.StringPtr("allowed-fqdns"),
+			SourceAddresses: to.StringSlicePtr(sources),
+			Protocols:       &webProtocols,
+			TargetFqdns:     to.StringSlicePtr(firewall.AllowedFQDNs),
+		})
+	}
+
+	networkRules := []network2020.AzureFirewallNetworkRule{
+		{
+			Name:                 to.StringPtr("ntp"),
+			Protocols:            &[]network2020.AzureFirewallNetworkRuleProtocol{network2020.UDP},
+			SourceAddresses:      to.StringSlicePtr(sources),
+			DestinationAddresses: to.StringSlicePtr([]string{"*"}),
+			DestinationPorts:     to.StringSlicePtr([]string{"123"}),
+		},
+	}
+	// the address of the API server is only known once its load balancer has been created
+	if cluster.Address.IP != "" && cluster.Address.Port != 0 {
+		networkRules = append([]network2020.AzureFirewallNetworkRule{{
+			Name:                 to.StringPtr("api-server"),
+			Protocols:            &[]network2020.AzureFirewallNetworkRuleProtocol{network2020.TCP},
+			SourceAddresses:      to.StringSlicePtr(sources),
+			DestinationAddresses: to.StringSlicePtr([]string{cluster.Address.IP}),
+			DestinationPorts:     to.StringSlicePtr([]string{strconv.Itoa(int(cluster.Address.Port))}),
+		}}, networkRules...)
+	}
+
+	action := &network2020.AzureFirewallRCAction{Type: network2020.AzureFirewallRCActionTypeAllow}
+
+	applicationCollection := network2020.AzureFirewallApplicationRuleCollection{
+		Name: to.StringPtr(name),
+		AzureFirewallApplicationRuleCollectionPropertiesFormat: &network2020.AzureFirewallApplicationRuleCollectionPropertiesFormat{
+			Priority: to.Int32Ptr(priority),
+			Action:   action,
+			Rules:    &applicationRules,
+		},
+	}
+	networkCollection := network2020.AzureFirewallNetworkRuleCollection{
+		Name: to.StringPtr(name),
+		AzureFirewallNetworkRuleCollectionPropertiesFormat: &network2020.AzureFirewallNetworkRuleCollectionPropertiesFormat{
+			Priority: to.Int32Ptr(priority),
+			Action:   action,
+			Rules:    &networkRules,
+		},
+	}
+
+	return applicationCollection, networkCollection
+}
+
+// egressFirewallRulePriorityFor returns the priority of the rule collections of the cluster. The
+// priority of existing collections is kept, otherwise the first priority starting at the configured
+// one is used, which neither an application nor a network rule collection uses yet.
+func egressFirewallRulePriorityFor(firewall network2020.AzureFirewall, name string, base int32) (int32, error) {
+	used := map[int32]bool{}
+
+	if firewall.AzureFirewallPropertiesFormat != nil {
+		for _, collection := range applicationRuleCollections(firewall) {
+			if collection.AzureFirewallApplicationRuleCollectionPropertiesFormat == nil || collection.Priority == nil {
+				continue
+			}
+			if to.String(collection.Name) == name {
+				return *collection.Priority, nil
+			}
+			used[*collection.Priority] = true
+		}
+		for _, collection := range networkRuleCollections(firewall) {
+			if collection.AzureFirewallNetworkRuleCollectionPropertiesFormat == nil || collection.Priority == nil {
+				continue
+			}
+			if to.String(collection.Name) == name {
+				return *collection.Priority, nil
+			}
+			used[*collection.Priority] = true
+		}
+	}
+
+	for p := base; p <= maxEgressFirewallRulePriority; p++ {
+		if !used[p] {
+			return p, nil
+		}
+	}
+
+	return 0, fmt.Errorf("no free rule collection priority left between %d and %d", base, maxEgressFirewallRulePriority)
+}
+
+func applicationRuleCollections(firewall network2020.AzureFirewall) []network2020.AzureFirewallApplicationRuleCollection {
+	if firewall.AzureFirewallPropertiesFormat == nil || firewall.ApplicationRuleCollections == nil {
+		return nil
+	}
+	return *firewall.ApplicationRuleCollections
+}
+
+func networkRuleCollections(firewall network2020.AzureFirewall) []network2020.AzureFirewallNetworkRuleCollection {
+	if firewall.AzureFirewallPropertiesFormat == nil || firewall.NetworkRuleCollections == nil {
+		return nil
+	}
+	return *firewall.NetworkRuleCollections
+}
+
+// setEgressFirewallRuleCollections replaces the rule collections with the given name in the
+// firewall, nil collections remove them. It returns true if the firewall has been changed.
+func setEgressFirewallRuleCollections(firewall *network2020.AzureFirewall, name string, applicationCollection *network2020.AzureFirewallApplicationRuleCollection, networkCollection *network2020.AzureFirewallNetworkRuleCollection) bool {
+	changed := false
+
+	if firewall.AzureFirewallPropertiesFormat == nil {
+		firewall.AzureFirewallPropertiesFormat = &network2020.AzureFirewallPropertiesFormat{}
+	}
+
+	applicationCollections := []network2020.AzureFirewallApplicationRuleCollection{}
+	found := false
+	for _, collection := range applicationRuleCollections(*firewall) {
+		if to.String(collection.Name) != name {
+			applicationCollections = append(applicationCollections, collection)
+			continue
+		}
+		found = true
+		if applicationCollection == nil {
+			changed = true
+		} else if applicationRuleCollectionKey(collection) != applicationRuleCollectionKey(*applicationCollection) {
+			applicationCollections = append(applicationCollections, *applicationCollection)
+			changed = true
+		} else {
+			applicationCollections = append(applicationCollections, collection)
+		}
+	}
+	if !found && applicationCollection != nil {
+		applicationCollections = append(applicationCollections, *applicationCollection)
+		changed = true
+	}
+	firewall.ApplicationRuleCollections = &applicationCollections
+
+	networkCollections := []network2020.AzureFirewallNetworkRuleCollection{}
+	found = false
+	for _, collection := range networkRuleCollections(*firewall) {
+		if to.String(collection.Name) != name {
+			networkCollections = append(networkCollections, collection)
+			continue
+		}
+		found = true
+		if networkCollection == nil {
+			changed = true
+		} else if networkRuleCollectionKey(collection) != networkRuleCollectionKey(*networkCollection) {
+			networkCollections = append(networkCollections, *networkCollection)
+			changed = true
+		} else {
+			networkCollections = append(networkCollections, collection)
+		}
+	}
+	if !found && networkCollection != nil {
+		networkCollections = append(networkCollections, *networkCollection)
+		changed = true
+	}
+	firewall.NetworkRuleCollections = &networkCollections
+
+	return changed
+}
+
+// applicationRuleCollectionKey returns a representation of the collection which only contains
+// the fields set by Kubermatic, so that collections returned by Azure can be compared with them.
+func applicationRuleCollectionKey(collection network2020.AzureFirewallApplicationRuleCollection) string {
+	props := collection.AzureFirewallApplicationRuleCollectionPropertiesFormat
+	if props == nil {
+		return ""
+	}
+
+	var rules []string
+	if props.Rules != nil {
+		for _, rule := range *props.Rules {
+			var protocols []string
+			if rule.Protocols != nil {
+				for _, protocol := range *rule.Protocols {
+					protocols = append(protocols, fmt.Sprintf("%s:%d", protocol.ProtocolType, to.Int32(protocol.Port)))
+				}
+			}
+			rules = append(rules, strings.Join([]string{
+				to.String(rule.Name),
+				sortedKey(to.StringSlice(rule.SourceAddresses)),
+				sortedKey(protocols),
+				sortedKey(to.StringSlice(rule.TargetFqdns)),
+			}, "|"))
+		}
+	}
+
+	return ruleCollectionKey(props.Priority, props.Action, rules)
+}
+
+// networkRuleCollectionKey is the equivalent of applicationRuleCollectionKey for network rules.
+func networkRuleCollectionKey(collection network2020.AzureFirewallNetworkRuleCollection) string {
+	props := collection.AzureFirewallNetworkRuleCollectionPropertiesFormat
+	if props == nil {
+		return ""
+	}
+
+	var rules []string
+	if props.Rules != nil {
+		for _, rule := range *props.Rules {
+			var protocols []string
+			if rule.Protocols != nil {
+				for _, protocol := range *rule.Protocols {
+					protocols = append(protocols, string(protocol))
+				}
+			}
+			rules = append(rules, strings.Join([]string{
+				to.String(rule.Name),
+				sortedKey(to.StringSlice(rule.SourceAddresses)),
+				sortedKey(protocols),
+				sortedKey(to.StringSlice(rule.DestinationAddresses)),
+				sortedKey(to.StringSlice(rule.DestinationPorts)),
+			}, "|"))
+		}
+	}
+
+	return ruleCollectionKey(props.Priority, props.Action, rules)
+}
+
+func ruleCollectionKey(priority *int32, action *network2020.AzureFirewallRCAction, rules []string) string {
+	actionType := ""
+	if action != nil {
+		actionType = string(action.Type)
+	}
+
+	return fmt.Sprintf("%d|%s|%s", to.Int32(priority), actionType, sortedKey(rules))
+}
+
+func sortedKey(values []string) string {
+	sorted := make([]string, 0, len(values))
+	for _, value := range values {
+		sorted = append(sorted, strings.ToLower(value))
+	}
+	sort.Strings(sorted)
+
+	return strings.Join(sorted, ",")
+}
+
+// reconcileEgressFirewall routes the egress traffic of the nodes through the configured Azure
+// Firewall and adds the rules the nodes need to it, if requested. Once the firewall is removed
+// from the cloud spec, the route and the rules are removed again.
+func (a *Azure) reconcileEgressFirewall(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, credentials Credentials) (*kubermaticv1.Cluster, error) {
+	var err error
+	logger := a.log.With("cluster", cluster.Name)
+
+	egressFirewall := cluster.Spec.Cloud.Azure.EgressFirewall
+	if egressFirewall == nil {
+		if !kuberneteshelper.HasFinalizer(cluster, FinalizerEgressFirewall) {
+			return cluster, nil
+		}
+
+		logger.Infow("removing egress firewall route", "routeTable", cluster.Spec.Cloud.Azure.RouteTableName)
+		if err = deleteEgressFirewallRoute(a.ctx, cluster.Spec.Cloud, credentials); err != nil {
+			return cluster, fmt.Errorf("failed to delete route to the egress firewall: %w", err)
+		}
+		if cluster, err = a.cleanUpEgressFirewall(cluster, update, credentials, logger); err != nil {
+			return cluster, err
+		}
+		a.recordEvent(cluster, "RemovedEgressFirewall", "Removed the route to the egress firewall")
+
+		return cluster, nil
+	}
+
+	if !kuberneteshelper.HasFinalizer(cluster, FinalizerRouteTable) {
+		return cluster, errors.New("the egress firewall requires the route table created by Kubermatic")
+	}
+
+	// the finalizer is added first, so that no rules are left behind if the cluster
+	// is deleted while they are being created
+	if !kuberneteshelper.HasFinalizer(cluster, FinalizerEgressFirewall) {
+		cluster, err = update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+			kuberneteshelper.AddFinalizer(updatedCluster, FinalizerEgressFirewall)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	firewall, err := getAzureFirewall(a.ctx, egressFirewall.ID, credentials)
+	if err != nil {
+		a.recordError(cluster, "FailedToEnsureEgressFirewall", err)
+		return cluster, fmt.Errorf("failed to get Azure Firewall %q: %w", egressFirewall.ID, err)
+	}
+
+	privateIP, firewallVNetID, err := firewallAddress(firewall)
+	if err != nil {
+		a.recordError(cluster, "FailedToEnsureEgressFirewall", err)
+		return cluster, err
+	}
+
+	// the route is only added once the nodes can reach the firewall, otherwise they would lose
+	// their egress connectivity
+	peerings, err := listAllVNetPeerings(a.ctx, cluster.Spec.Cloud, credentials)
+	if err != nil {
+		return cluster, fmt.Errorf("failed to list peerings of virtual network %q: %w", cluster.Spec.Cloud.Azure.VNetName, err)
+	}
+	if !isFirewallReachable(firewallVNetID, assembleVNetID(cluster.Spec.Cloud, credentials.SubscriptionID), peerings) {
+		err = fmt.Errorf("firewall %q is neither deployed to virtual network %q nor to a virtual network connected to it by a peering", egressFirewall.ID, cluster.Spec.Cloud.Azure.VNetName)
+		a.recordError(cluster, "EgressFirewallUnreachable", err)
+		return cluster, err
+	}
+
+	// rules on another firewall are removed before the traffic is routed through the new one
+	if cluster.Status.Azure != nil && cluster.Status.Azure.EgressFirewallRulesID != "" &&
+		(!egressFirewall.CreateRules || !strings.EqualFold(cluster.Status.Azure.EgressFirewallRulesID, egressFirewall.ID)) {
+		if cluster, err = a.removeEgressFirewallRules(cluster, update, credentials, logger); err != nil {
+			return cluster, err
+		}
+	}
+
+	if egressFirewall.CreateRules {
+		if cluster, err = a.ensureEgressFirewallRules(cluster, update, credentials, firewall, logger); err != nil {
+			a.recordError(cluster, "FailedToEnsureEgressFirewallRules", err)
+			return cluster, err
+		}
+	}
+
+	routesClient, err := getRoutesClient(credentials)
+	if err != nil {
+		return cluster, err
+	}
+
+	route := egressFirewallRoute(privateIP)
+	existing, err := routesClient.Get(a.ctx, RouteTableResourceGroup(cluster.Spec.Cloud), cluster.Spec.Cloud.Azure.RouteTableName, egressFirewallRouteName)
+	if err != nil && !isNotFound(err) {
+		return cluster, fmt.Errorf("failed to get route to the egress firewall: %w", err)
+	}
+	if err != nil || !isRouteUpToDate(existing, route) {
+		logger.Infow("ensuring egress firewall route", "routeTable", cluster.Spec.Cloud.Azure.RouteTableName, "nextHop", privateIP)
+		if err = ensureRoute(a.ctx, routesClient, cluster.Spec.Cloud, egressFirewallRouteName, route); err != nil {
+			a.recordError(cluster, "FailedToEnsureEgressFirewall", err)
+			return cluster, fmt.Errorf("failed to create or update route to the egress firewall: %w", err)
+		}
+		a.recordEvent(cluster, "EnsuredEgressFirewall", "Routed egress traffic through Azure Firewall %q", to.String(firewall.Name))
+	}
+
+	return cluster, nil
+}
+
+// ensureEgressFirewallRules adds the rule collections of the cluster to the firewall, or updates
+// them if they changed, and records the firewall in the cluster status.
+func (a *Azure) ensureEgressFirewallRules(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, credentials Credentials, firewall network2020.AzureFirewall, logger *zap.SugaredLogger) (*kubermaticv1.Cluster, error) {
+	egressFirewall := cluster.Spec.Cloud.Azure.EgressFirewall
+
+	if firewall.AzureFirewallPropertiesFormat != nil && firewall.FirewallPolicy != nil {
+		return cluster, fmt.Errorf("firewall %q is managed by a Firewall Policy, its rules cannot be created by Kubermatic", egressFirewall.ID)
+	}
+
+	sources, err := nodeAddressPrefixes(a.ctx, cluster.Spec.Cloud, credentials)
+	if err != nil {
+		return cluster, err
+	}
+
+	name := egressFirewallRuleCollectionName(cluster)
+	priority, err := egressFirewallRulePriorityFor(firewall, name, egressFirewallRulePriority(egressFirewall))
+	if err != nil {
+		return cluster, err
+	}
+
+	applicationCollection, networkCollection := egressFirewallRuleCollections(cluster, sources, priority)
+	if setEgressFirewallRuleCollections(&firewall, name, &applicationCollection, &networkCollection) {
+		logger.Infow("ensuring egress firewall rules", "firewall", egressFirewall.ID, "priority", priority)
+		if err = a.pollOperation(cluster, update, credentials, "update-egress-firewall-rules", func() (autorestazure.FutureAPI, error) {
+			return updateAzureFirewall(a.ctx, egressFirewall.ID, firewall, credentials)
+		}); err != nil {
+			return cluster, fmt.Errorf("failed to update rules of Azure Firewall %q: %w", egressFirewall.ID, err)
+		}
+		a.recordEvent(cluster, "EnsuredEgressFirewallRules", "Ensured rule collection %q of Azure Firewall %q", name, to.String(firewall.Name))
+	}
+
+	if cluster.Status.Azure != nil && cluster.Status.Azure.EgressFirewallRulesID == egressFirewall.ID {
+		return cluster, nil
+	}
+
+	return update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+		if updatedCluster.Status.Azure == nil {
+			updatedCluster.Status.Azure = &kubermaticv1.AzureClusterStatus{}
+		}
+		updatedCluster.Status.Azure.EgressFirewallRulesID = egressFirewall.ID
+	})
+}
+
+// removeEgressFirewallRules removes the rule collections of the cluster from the firewall recorded
+// in the cluster status.
+func (a *Azure) removeEgressFirewallRules(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, credentials Credentials, logger *zap.SugaredLogger) (*kubermaticv1.Cluster, error) {
+	if cluster.Status.Azure == nil || cluster.Status.Azure.EgressFirewallRulesID == "" {
+		return cluster, nil
+	}
+	firewallID := cluster.Status.Azure.EgressFirewallRulesID
+
+	firewall, err := getAzureFirewall(a.ctx, firewallID, credentials)
+	if err != nil && !isNotFound(err) {
+		return cluster, fmt.Errorf("failed to get Azure Firewall %q: %w", firewallID, err)
+	}
+
+	if err == nil && setEgressFirewallRuleCollections(&firewall, egressFirewallRuleCollectionName(cluster), nil, nil) {
+		logger.Infow("removing egress firewall rules", "firewall", firewallID)
+		if err = a.pollOperation(cluster, update, credentials, "delete-egress-firewall-rules", func() (autorestazure.FutureAPI, error) {
+			return updateAzureFirewall(a.ctx, firewallID, firewall, credentials)
+		}); err != nil {
+			return cluster, fmt.Errorf("failed to remove rules from Azure Firewall %q: %w", firewallID, err)
+		}
+	}
+
+	return update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+		if updatedCluster.Status.Azure != nil {
+			updatedCluster.Status.Azure.EgressFirewallRulesID = ""
+		}
+	})
+}
+
+// cleanUpEgressFirewall removes the rule collections of the cluster from the firewall. The route
+// to the firewall is removed together with the route table.
+func (a *Azure) cleanUpEgressFirewall(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, credentials Credentials, logger *zap.SugaredLogger) (*kubermaticv1.Cluster, error) {
+	var err error
+
+	if !kuberneteshelper.HasFinalizer(cluster, FinalizerEgressFirewall) {
+		return cluster, nil
+	}
+
+	if cluster, err = a.removeEgressFirewallRules(cluster, update, credentials, logger); err != nil {
+		return cluster, err
+	}
+
+	return update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+		kuberneteshelper.RemoveFinalizer(updatedCluster, FinalizerEgressFirewall)
+	})
+}
+
+// nodeAddressPrefixes returns the address ranges of the subnets the nodes of the cluster are placed in.
+func nodeAddressPrefixes(ctx context.Context, cloud kubermaticv1.CloudSpec, credentials Credentials) ([]string, error) {
+	subnetsClient, err := getSubnetsClient(cloud, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	subnet, err := subnetsClient.Get(ctx, vnetResourceGroup(cloud), cloud.Azure.VNetName, cloud.Azure.SubnetName, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subnetwork %q: %w", cloud.Azure.SubnetName, err)
+	}
+	if subnet.SubnetPropertiesFormat == nil || to.String(subnet.AddressPrefix) == "" {
+		return nil, fmt.Errorf("subnetwork %q has no address prefix", cloud.Azure.SubnetName)
+	}
+
+	prefixes := []string{*subnet.AddressPrefix}
+	for _, additional := range cloud.Azure.AdditionalSubnets {
+		if _, _, err := net.ParseCIDR(additional.CIDR); err == nil {
+			prefixes = append(prefixes, additional.CIDR)
+		}
+	}
+
+	return prefixes, nil
+}
+
+// listAllVNetPeerings returns all peerings of the cluster's VNet, including the ones not created by Kubermatic.
+func listAllVNetPeerings(ctx context.Context, cloud kubermaticv1.CloudSpec, credentials Credentials) ([]network.VirtualNetworkPeering, error) {
+	credentials = networkCredentials(cloud, credentials)
+	peeringsClient, err := getVNetPeeringsClient(credentials.SubscriptionID, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	var peerings []network.VirtualNetworkPeering
+
+	it, err := peeringsClient.ListComplete(ctx, vnetResourceGroup(cloud), cloud.Azure.VNetName)
+	if err != nil {
+		return nil, err
+	}
+	for ; it.NotDone(); err = it.NextWithContext(ctx) {
+		if err != nil {
+			return nil, err
+		}
+		peerings = append(peerings, it.Value())
+	}
+
+	return peerings, nil
+}
+
+func deleteEgressFirewallRoute(ctx context.Context, cloud kubermaticv1.CloudSpec, credentials Credentials) error {
+	if cloud.Azure.RouteTableName == "" {
+		return nil
+	}
+
+	routesClient, err := getRoutesClient(credentials)
+	if err != nil {
+		return err
+	}
+
+	future, err := routesClient.Delete(ctx, RouteTableResourceGroup(cloud), cloud.Azure.RouteTableName, egressFirewallRouteName)
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	return future.WaitForCompletionRef(ctx, routesClient.Client)
+}
+
+func getAzureFirewall(ctx context.Context, id string, credentials Credentials) (network2020.AzureFirewall, error) {
+	resource, err := parseAzureFirewallID(id)
+	if err != nil {
+		return network2020.AzureFirewall{}, err
+	}
+
+	firewallsClient, err := getAzureFirewallsClient(resource.SubscriptionID, credentials)
+	if err != nil {
+		return network2020.AzureFirewall{}, err
+	}
+
+	return firewallsClient.Get(ctx, resource.ResourceGroup, resource.ResourceName)
+}
+
+// updateAzureFirewall will start updating the firewall, including its rule collections.
+func updateAzureFirewall(ctx context.Context, id string, firewall network2020.AzureFirewall, credentials Credentials) (autorestazure.FutureAPI, error) {
+	resource, err := parseAzureFirewallID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	firewallsClient, err := getAzureFirewallsClient(resource.SubscriptionID, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	future, err := firewallsClient.CreateOrUpdate(ctx, resource.ResourceGroup, resource.ResourceName, firewall)
+	if err != nil {
+		return nil, err
+	}
+
+	return future.FutureAPI, nil
+}
+
+// getAzureFirewallsClient returns a client for the Azure Firewalls in the given subscription,
+// which is not necessarily the one of the cluster.
+func getAzureFirewallsClient(subscriptionID string, credentials Credentials) (*network2020.AzureFirewallsClient, error) {
+	var err error
+	firewallsClient := network2020.NewAzureFirewallsClient(subscriptionID)
+	err = configureClient(&firewallsClient.Client, credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authorizer: %s", err.Error())
+	}
+
+	return &firewallsClient, nil
+}
@@ -0,0 +1,276 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-06-01/network"
+	network2020 "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	testFirewallID     = "/subscriptions/hub/resourceGroups/hub-rg/providers/Microsoft.Network/azureFirewalls/hub-fw"
+	testFirewallVNetID = "/subscriptions/hub/resourceGroups/hub-rg/providers/Microsoft.Network/virtualNetworks/hub-vnet"
+)
+
+func TestValidateEgressFirewall(t *testing.T) {
+	tests := []struct {
+		name        string
+		azure       kubermaticv1.AzureCloudSpec
+		expectedErr bool
+	}{
+		{
+			name: "no egress firewall",
+		},
+		{
+			name: "valid egress firewall",
+			azure: kubermaticv1.AzureCloudSpec{
+				EgressFirewall: &kubermaticv1.AzureEgressFirewall{ID: testFirewallID, CreateRules: true, AllowedFQDNs: []string{"registry.example.com", "*.example.org"}, RulePriority: 2000},
+			},
+		},
+		{
+			name: "not the ID of a firewall",
+			azure: kubermaticv1.AzureCloudSpec{
+				EgressFirewall: &kubermaticv1.AzureEgressFirewall{ID: testFirewallVNetID},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "rule priority out of range",
+			azure: kubermaticv1.AzureCloudSpec{
+				EgressFirewall: &kubermaticv1.AzureEgressFirewall{ID: testFirewallID, CreateRules: true, RulePriority: 99},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "allowed FQDNs without rules",
+			azure: kubermaticv1.AzureCloudSpec{
+				EgressFirewall: &kubermaticv1.AzureEgressFirewall{ID: testFirewallID, AllowedFQDNs: []string{"registry.example.com"}},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "invalid FQDN",
+			azure: kubermaticv1.AzureCloudSpec{
+				EgressFirewall: &kubermaticv1.AzureEgressFirewall{ID: testFirewallID, CreateRules: true, AllowedFQDNs: []string{"https://registry.example.com"}},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "existing route table",
+			azure: kubermaticv1.AzureCloudSpec{
+				RouteTableID:   "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/routeTables/rt",
+				EgressFirewall: &kubermaticv1.AzureEgressFirewall{ID: testFirewallID},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "conflicting default route",
+			azure: kubermaticv1.AzureCloudSpec{
+				Routes:         []kubermaticv1.AzureRoute{{Name: "default", AddressPrefix: "0.0.0.0/0", NextHopType: "Internet"}},
+				EgressFirewall: &kubermaticv1.AzureEgressFirewall{ID: testFirewallID},
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			azure := test.azure
+			if err := validateEgressFirewall(kubermaticv1.CloudSpec{Azure: &azure}); (err != nil) != test.expectedErr {
+				t.Fatalf("expected error: %v, got %v", test.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestFirewallAddress(t *testing.T) {
+	firewall := network2020.AzureFirewall{
+		Name: to.StringPtr("hub-fw"),
+		AzureFirewallPropertiesFormat: &network2020.AzureFirewallPropertiesFormat{
+			IPConfigurations: &[]network2020.AzureFirewallIPConfiguration{{
+				AzureFirewallIPConfigurationPropertiesFormat: &network2020.AzureFirewallIPConfigurationPropertiesFormat{
+					PrivateIPAddress: to.StringPtr("10.100.0.4"),
+					Subnet:           &network2020.SubResource{ID: to.StringPtr(testFirewallVNetID + "/subnets/AzureFirewallSubnet")},
+				},
+			}},
+		},
+	}
+
+	ip, vnetID, err := firewallAddress(firewall)
+	if err != nil {
+		t.Fatalf("failed to get address of firewall: %v", err)
+	}
+	if ip != "10.100.0.4" {
+		t.Errorf("expected private IP %q, got %q", "10.100.0.4", ip)
+	}
+	if vnetID != testFirewallVNetID {
+		t.Errorf("expected VNet %q, got %q", testFirewallVNetID, vnetID)
+	}
+
+	if _, _, err := firewallAddress(network2020.AzureFirewall{Name: to.StringPtr("hub-fw")}); err == nil {
+		t.Error("expected an error for a firewall without IP configuration")
+	}
+}
+
+func TestIsFirewallReachable(t *testing.T) {
+	clusterVNetID := "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/kubernetes-test"
+
+	peering := func(remote string, state network.VirtualNetworkPeeringState) network.VirtualNetworkPeering {
+		return network.VirtualNetworkPeering{
+			VirtualNetworkPeeringPropertiesFormat: &network.VirtualNetworkPeeringPropertiesFormat{
+				RemoteVirtualNetwork: &network.SubResource{ID: to.StringPtr(remote)},
+				PeeringState:         state,
+			},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		firewallVNetID string
+		peerings       []network.VirtualNetworkPeering
+		expected       bool
+	}{
+		{
+			name:           "firewall in the cluster's VNet",
+			firewallVNetID: "/subscriptions/sub/resourceGroups/RG/providers/Microsoft.Network/virtualNetworks/kubernetes-test",
+			expected:       true,
+		},
+		{
+			name:           "firewall in a peered VNet",
+			firewallVNetID: testFirewallVNetID,
+			peerings:       []network.VirtualNetworkPeering{peering(testFirewallVNetID, network.VirtualNetworkPeeringStateConnected)},
+			expected:       true,
+		},
+		{
+			name:           "peering is not connected yet",
+			firewallVNetID: testFirewallVNetID,
+			peerings:       []network.VirtualNetworkPeering{peering(testFirewallVNetID, network.VirtualNetworkPeeringStateInitiated)},
+		},
+		{
+			name:           "firewall in an unrelated VNet",
+			firewallVNetID: testFirewallVNetID,
+			peerings:       []network.VirtualNetworkPeering{peering(clusterVNetID+"-other", network.VirtualNetworkPeeringStateConnected)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if reachable := isFirewallReachable(test.firewallVNetID, clusterVNetID, test.peerings); reachable != test.expected {
+				t.Errorf("expected reachable: %v, got %v", test.expected, reachable)
+			}
+		})
+	}
+}
+
+func TestEgressFirewallRulePriorityFor(t *testing.T) {
+	collection := func(name string, priority int32) network2020.AzureFirewallApplicationRuleCollection {
+		return network2020.AzureFirewallApplicationRuleCollection{
+			Name: to.StringPtr(name),
+			AzureFirewallApplicationRuleCollectionPropertiesFormat: &network2020.AzureFirewallApplicationRuleCollectionPropertiesFormat{
+				Priority: to.Int32Ptr(priority),
+			},
+		}
+	}
+	firewall := network2020.AzureFirewall{
+		AzureFirewallPropertiesFormat: &network2020.AzureFirewallPropertiesFormat{
+			ApplicationRuleCollections: &[]network2020.AzureFirewallApplicationRuleCollection{collection("other", 1000), collection("kubermatic-existing", 1500)},
+			NetworkRuleCollections: &[]network2020.AzureFirewallNetworkRuleCollection{{
+				Name: to.StringPtr("other-network"),
+				AzureFirewallNetworkRuleCollectionPropertiesFormat: &network2020.AzureFirewallNetworkRuleCollectionPropertiesFormat{
+					Priority: to.Int32Ptr(1001),
+				},
+			}},
+		},
+	}
+
+	if priority, err := egressFirewallRulePriorityFor(firewall, "kubermatic-new", 1000); err != nil || priority != 1002 {
+		t.Errorf("expected the first free priority 1002, got %d (%v)", priority, err)
+	}
+	if priority, err := egressFirewallRulePriorityFor(firewall, "kubermatic-existing", 1000); err != nil || priority != 1500 {
+		t.Errorf("expected the priority of the existing collection 1500, got %d (%v)", priority, err)
+	}
+}
+
+func TestSetEgressFirewallRuleCollections(t *testing.T) {
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec: kubermaticv1.ClusterSpec{
+			Cloud: kubermaticv1.CloudSpec{
+				Azure: &kubermaticv1.AzureCloudSpec{
+					EgressFirewall: &kubermaticv1.AzureEgressFirewall{ID: testFirewallID, CreateRules: true},
+				},
+			},
+		},
+		Address: kubermaticv1.ClusterAddress{IP: "20.30.40.50", Port: 6443},
+	}
+	name := egressFirewallRuleCollectionName(cluster)
+	other := network2020.AzureFirewallNetworkRuleCollection{Name: to.StringPtr("other")}
+
+	firewall := network2020.AzureFirewall{
+		AzureFirewallPropertiesFormat: &network2020.AzureFirewallPropertiesFormat{
+			NetworkRuleCollections: &[]network2020.AzureFirewallNetworkRuleCollection{other},
+		},
+	}
+
+	applicationCollection, networkCollection := egressFirewallRuleCollections(cluster, []string{"10.0.0.0/16"}, 1000)
+	if !setEgressFirewallRuleCollections(&firewall, name, &applicationCollection, &networkCollection) {
+		t.Fatal("expected the rule collections to be added")
+	}
+	if len(*firewall.ApplicationRuleCollections) != 1 || len(*firewall.NetworkRuleCollections) != 2 {
+		t.Fatalf("expected 1 application and 2 network rule collections, got %d and %d", len(*firewall.ApplicationRuleCollections), len(*firewall.NetworkRuleCollections))
+	}
+	if rules := *(*firewall.NetworkRuleCollections)[1].Rules; to.String(rules[0].Name) != "api-server" || to.StringSlice(rules[0].DestinationPorts)[0] != "6443" {
+		t.Errorf("expected a rule for the API server, got %q", to.String(rules[0].Name))
+	}
+
+	// collections returned by Azure carry additional fields and may order the addresses differently
+	returned := (*firewall.ApplicationRuleCollections)[0]
+	returned.Etag = to.StringPtr("etag")
+	returned.ProvisioningState = network2020.Succeeded
+	fqdns := append([]string{}, requiredEgressFQDNs...)
+	fqdns[0], fqdns[1] = fqdns[1], fqdns[0]
+	(*returned.Rules)[0].TargetFqdns = &fqdns
+	(*firewall.ApplicationRuleCollections)[0] = returned
+
+	applicationCollection, networkCollection = egressFirewallRuleCollections(cluster, []string{"10.0.0.0/16"}, 1000)
+	if setEgressFirewallRuleCollections(&firewall, name, &applicationCollection, &networkCollection) {
+		t.Error("expected up-to-date rule collections to be kept")
+	}
+
+	cluster.Spec.Cloud.Azure.EgressFirewall.AllowedFQDNs = []string{"registry.example.com"}
+	applicationCollection, networkCollection = egressFirewallRuleCollections(cluster, []string{"10.0.0.0/16"}, 1000)
+	if !setEgressFirewallRuleCollections(&firewall, name, &applicationCollection, &networkCollection) {
+		t.Error("expected the rule collections to be updated")
+	}
+
+	if !setEgressFirewallRuleCollections(&firewall, name, nil, nil) {
+		t.Fatal("expected the rule collections to be removed")
+	}
+	if len(*firewall.ApplicationRuleCollections) != 0 || len(*firewall.NetworkRuleCollections) != 1 || to.String((*firewall.NetworkRuleCollections)[0].Name) != "other" {
+		t.Error("expected only the rule collections of the cluster to be removed")
+	}
+	if setEgressFirewallRuleCollections(&firewall, name, nil, nil) {
+		t.Error("expected no change when removing missing rule collections")
+	}
+}
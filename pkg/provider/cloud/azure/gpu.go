@@ -0,0 +1,48 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"regexp"
+	"strconv"
+)
+
+// gpusCapability is the number of GPUs of a VM size.
+const gpusCapability = "GPUs"
+
+var (
+	// gpuSizePattern matches the VM sizes of the GPU optimized N-series, see
+	// https://docs.microsoft.com/en-us/azure/virtual-machines/sizes-gpu
+	gpuSizePattern = regexp.MustCompile(`^Standard_N[CDV]\d`)
+	// amdGPUSizePattern matches the N-series VM sizes with AMD instead of NVIDIA GPUs, like the NVv4 series.
+	amdGPUSizePattern = regexp.MustCompile(`^Standard_NV\d+as_v4$|_MI\d+X_`)
+)
+
+// NumberOfGPUs returns the number of GPUs of a VM size with the given capabilities.
+func NumberOfGPUs(capabilities map[string]string) int32 {
+	gpus, err := strconv.ParseInt(capabilities[gpusCapability], 10, 32)
+	if err != nil {
+		return 0
+	}
+	return int32(gpus)
+}
+
+// IsNvidiaGPUSize returns true if VMs of the given size have NVIDIA GPUs, which need the driver of
+// the nvidia-gpu-driver addon.
+func IsNvidiaGPUSize(size string) bool {
+	return gpuSizePattern.MatchString(size) && !amdGPUSizePattern.MatchString(size)
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"testing"
+)
+
+func TestNumberOfGPUs(t *testing.T) {
+	testCases := []struct {
+		name         string
+		capabilities map[string]string
+		expected     int32
+	}{
+		{
+			name:         "GPU size",
+			capabilities: map[string]string{gpusCapability: "4"},
+			expected:     4,
+		},
+		{
+			name:         "size without GPUs",
+			capabilities: map[string]string{},
+		},
+		{
+			name:         "invalid capability",
+			capabilities: map[string]string{gpusCapability: "many"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := NumberOfGPUs(tc.capabilities); got != tc.expected {
+				t.Errorf("expected %d GPUs, got %d", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestIsNvidiaGPUSize(t *testing.T) {
+	testCases := map[string]bool{
+		"Standard_NC6":               true,
+		"Standard_NC24rs_v3":         true,
+		"Standard_NC4as_T4_v3":       true,
+		"Standard_ND40rs_v2":         true,
+		"Standard_NV12s_v3":          true,
+		"Standard_NV32as_v4":         false,
+		"Standard_ND96isr_MI300X_v5": false,
+		"Standard_D2s_v3":            false,
+		"Standard_NP10s":             false,
+		"Standard_NV36ads_A10_v5":    true,
+	}
+
+	for size, expected := range testCases {
+		if got := IsNvidiaGPUSize(size); got != expected {
+			t.Errorf("expected %v for size %q, got %v", expected, size, got)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Azure/go-autorest/autorest/adal"
+	"github.com/Azure/go-autorest/autorest/azure/auth"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1/helper"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	reasonHealthy               = "Healthy"
+	reasonInvalidCredentials    = "InvalidCredentials"
+	reasonResourceGroupNotFound = "ResourceGroupNotFound"
+	reasonSubnetNotFound        = "SubnetNotFound"
+	reasonHealthCheckFailed     = "HealthCheckFailed"
+)
+
+// healthCheck is a single check of the health probe. Its reason is reported if the check fails
+// because the resource is gone or the credentials were rejected.
+type healthCheck struct {
+	reason string
+	check  func() error
+}
+
+// healthCondition is the state of the CloudProviderHealthy condition.
+type healthCondition struct {
+	status  corev1.ConditionStatus
+	reason  string
+	message string
+}
+
+// evaluateHealthChecks runs the checks in order and returns the condition for the first failing
+// one. Failures which do not prove that the infrastructure is broken, e.g. throttled requests or
+// network errors, leave the health unknown.
+func evaluateHealthChecks(checks []healthCheck) healthCondition {
+	for _, c := range checks {
+		err := c.check()
+		if err == nil {
+			continue
+		}
+
+		var refreshErr adal.TokenRefreshError
+		if errors.As(err, &refreshErr) || errors.Is(ClassifyError(err), ErrNotFound) || errors.Is(ClassifyError(err), ErrAuthorization) {
+			return healthCondition{status: corev1.ConditionFalse, reason: c.reason, message: err.Error()}
+		}
+
+		return healthCondition{status: corev1.ConditionUnknown, reason: reasonHealthCheckFailed, message: err.Error()}
+	}
+
+	return healthCondition{status: corev1.ConditionTrue, reason: reasonHealthy}
+}
+
+// healthChecks returns the checks of the health probe. The resource group and the subnet are
+// only checked once the infrastructure was provisioned, as they may not exist before.
+func (a *Azure) healthChecks(cluster *kubermaticv1.Cluster, credentials Credentials) []healthCheck {
+	cloud := cluster.Spec.Cloud
+	checks := []healthCheck{
+		{
+			reason: reasonInvalidCredentials,
+			check: func() error {
+				return checkServicePrincipalToken(a.ctx, credentials)
+			},
+		},
+	}
+
+	if cluster.Status.ExtendedHealth.CloudProviderInfrastructure != kubermaticv1.HealthStatusUp {
+		return checks
+	}
+
+	if cloud.Azure.ResourceGroup != "" {
+		checks = append(checks, healthCheck{
+			reason: reasonResourceGroupNotFound,
+			check: func() error {
+				groupsClient, err := getGroupsClient(cloud, credentials)
+				if err != nil {
+					return err
+				}
+				if _, err = groupsClient.Get(a.ctx, cloud.Azure.ResourceGroup); err != nil {
+					return fmt.Errorf("failed to get resource group %q: %w", cloud.Azure.ResourceGroup, err)
+				}
+				return nil
+			},
+		})
+	}
+
+	if cloud.Azure.VNetName != "" && cloud.Azure.SubnetName != "" {
+		checks = append(checks, healthCheck{
+			reason: reasonSubnetNotFound,
+			check: func() error {
+				subnetsClient, err := getPrivateLinkSubnetsClient(cloud, credentials)
+				if err != nil {
+					return err
+				}
+				if _, err = subnetsClient.Get(a.ctx, vnetResourceGroup(cloud), cloud.Azure.VNetName, cloud.Azure.SubnetName, ""); err != nil {
+					return fmt.Errorf("failed to get subnetwork %q: %w", cloud.Azure.SubnetName, err)
+				}
+				return nil
+			},
+		})
+	}
+
+	return checks
+}
+
+// checkServicePrincipalToken acquires a new token for the service principal, which fails if
+// its secret expired or the service principal was deleted.
+func checkServicePrincipalToken(ctx context.Context, credentials Credentials) error {
+	token, err := auth.NewClientCredentialsConfig(credentials.ClientID, credentials.ClientSecret, credentials.TenantID).ServicePrincipalToken()
+	if err != nil {
+		return err
+	}
+	// the token must be acquired through the proxy as well
+	if sender := apiProxy.get(); sender != nil {
+		token.SetSender(sender)
+	}
+
+	if err := token.RefreshWithContext(ctx); err != nil {
+		return fmt.Errorf("failed to acquire a token for service principal %q: %w", credentials.ClientID, err)
+	}
+
+	return nil
+}
+
+// ReconcileHealth probes the cloud resources the cluster depends on with a few lightweight
+// requests and reports the result in the CloudProviderHealthy condition of the cluster.
+func (a *Azure) ReconcileHealth(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater) (*kubermaticv1.Cluster, error) {
+	var condition healthCondition
+
+	credentials, err := GetCredentialsForCluster(cluster.Spec.Cloud, a.secretKeySelector)
+	if err != nil {
+		condition = healthCondition{status: corev1.ConditionFalse, reason: reasonInvalidCredentials, message: err.Error()}
+	} else {
+		condition = evaluateHealthChecks(a.healthChecks(cluster, credentials))
+	}
+
+	if condition.status != corev1.ConditionTrue && !cluster.Status.HasConditionValue(kubermaticv1.ClusterConditionCloudProviderHealthy, condition.status) {
+		a.log.With("cluster", cluster.Name).Infow("cloud provider is unhealthy", "reason", condition.reason, "message", condition.message)
+		a.recordWarning(cluster, "CloudProviderUnhealthy", "%s: %s", condition.reason, condition.message)
+	}
+
+	return update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+		kubermaticv1helper.SetClusterCondition(updatedCluster, kubermatic.NewDefaultVersions(), kubermaticv1.ClusterConditionCloudProviderHealthy, condition.status, condition.reason, condition.message)
+	})
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+type fakeTokenRefreshError struct{}
+
+func (fakeTokenRefreshError) Error() string {
+	return "AADSTS7000222: the provided client secret keys are expired"
+}
+
+func (fakeTokenRefreshError) Response() *http.Response {
+	return &http.Response{StatusCode: http.StatusUnauthorized}
+}
+
+func TestEvaluateHealthChecks(t *testing.T) {
+	succeeding := func() error { return nil }
+	failing := func(err error) func() error {
+		return func() error { return err }
+	}
+
+	tests := []struct {
+		name           string
+		checks         []healthCheck
+		expectedStatus corev1.ConditionStatus
+		expectedReason string
+	}{
+		{
+			name: "healthy",
+			checks: []healthCheck{
+				{reason: reasonInvalidCredentials, check: succeeding},
+				{reason: reasonResourceGroupNotFound, check: succeeding},
+			},
+			expectedStatus: corev1.ConditionTrue,
+			expectedReason: reasonHealthy,
+		},
+		{
+			name: "expired client secret",
+			checks: []healthCheck{
+				{reason: reasonInvalidCredentials, check: failing(fmt.Errorf("failed to acquire a token: %w", fakeTokenRefreshError{}))},
+				{reason: reasonResourceGroupNotFound, check: succeeding},
+			},
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: reasonInvalidCredentials,
+		},
+		{
+			name: "deleted resource group",
+			checks: []healthCheck{
+				{reason: reasonInvalidCredentials, check: succeeding},
+				{reason: reasonResourceGroupNotFound, check: failing(fmt.Errorf("failed to get resource group: %w", autorest.DetailedError{StatusCode: http.StatusNotFound}))},
+				{reason: reasonSubnetNotFound, check: failing(autorest.DetailedError{StatusCode: http.StatusNotFound})},
+			},
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: reasonResourceGroupNotFound,
+		},
+		{
+			name: "missing permissions",
+			checks: []healthCheck{
+				{reason: reasonSubnetNotFound, check: failing(autorest.DetailedError{StatusCode: http.StatusForbidden})},
+			},
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: reasonSubnetNotFound,
+		},
+		{
+			name: "throttled",
+			checks: []healthCheck{
+				{reason: reasonResourceGroupNotFound, check: failing(autorest.DetailedError{StatusCode: http.StatusTooManyRequests})},
+			},
+			expectedStatus: corev1.ConditionUnknown,
+			expectedReason: reasonHealthCheckFailed,
+		},
+		{
+			name: "network error",
+			checks: []healthCheck{
+				{reason: reasonInvalidCredentials, check: failing(errors.New("dial tcp: i/o timeout"))},
+			},
+			expectedStatus: corev1.ConditionUnknown,
+			expectedReason: reasonHealthCheckFailed,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			condition := evaluateHealthChecks(test.checks)
+			if condition.status != test.expectedStatus || condition.reason != test.expectedReason {
+				t.Fatalf("expected %s/%s, got %s/%s (%s)", test.expectedStatus, test.expectedReason, condition.status, condition.reason, condition.message)
+			}
+		})
+	}
+}
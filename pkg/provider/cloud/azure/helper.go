@@ -18,6 +18,11 @@ package azure
 
 import (
 	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-06-01/network"
+	autorestazure "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/to"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 )
@@ -29,3 +34,81 @@ func assembleSubnetID(cloud kubermaticv1.CloudSpec) string {
 	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/virtualNetworks/%s/subnets/%s",
 		cloud.Azure.SubscriptionID, cloud.Azure.ResourceGroup, cloud.Azure.VNetName, cloud.Azure.SubnetName)
 }
+
+// assembleSubnetIDForSubscription assembles the subnet ID like assembleSubnetID, but takes the
+// subscription from the credentials, as the cloud spec does not contain it when the credentials
+// are referenced from a secret.
+func assembleSubnetIDForSubscription(cloud kubermaticv1.CloudSpec, subscriptionID string) string {
+	return fmt.Sprintf("%s/subnets/%s", assembleVNetID(cloud, subscriptionID), cloud.Azure.SubnetName)
+}
+
+// assembleVNetID returns the full ID of the cluster's virtual network, which lives in the given
+// subscription unless another one is configured for it.
+func assembleVNetID(cloud kubermaticv1.CloudSpec, subscriptionID string) string {
+	if cloud.Azure.VNetSubscriptionID != "" {
+		subscriptionID = cloud.Azure.VNetSubscriptionID
+	}
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/virtualNetworks/%s",
+		subscriptionID, vnetResourceGroup(cloud), cloud.Azure.VNetName)
+}
+
+// vnetResourceGroup returns the resource group the virtual network lives in.
+func vnetResourceGroup(cloud kubermaticv1.CloudSpec) string {
+	if cloud.Azure.VNetResourceGroup != "" {
+		return cloud.Azure.VNetResourceGroup
+	}
+	return cloud.Azure.ResourceGroup
+}
+
+// isRouteTableAssociated returns true if the subnet has no route table bound to it yet
+// or if it is bound to the given route table.
+func isRouteTableAssociated(subnet network.Subnet, routeTable network.RouteTable) bool {
+	if subnet.SubnetPropertiesFormat == nil || subnet.RouteTable == nil || subnet.RouteTable.ID == nil {
+		return true
+	}
+	if routeTable.ID == nil {
+		return false
+	}
+
+	return strings.EqualFold(*subnet.RouteTable.ID, *routeTable.ID)
+}
+
+// hasDNSServers returns true if the virtual network uses exactly the given DNS servers, in order.
+func hasDNSServers(vnet network.VirtualNetwork, dnsServers []string) bool {
+	var current []string
+	if vnet.VirtualNetworkPropertiesFormat != nil && vnet.DhcpOptions != nil && vnet.DhcpOptions.DNSServers != nil {
+		current = *vnet.DhcpOptions.DNSServers
+	}
+	if len(current) != len(dnsServers) {
+		return false
+	}
+	for i := range current {
+		if current[i] != dnsServers[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// hasDDoSProtectionPlan returns true if DDoS protection is enabled on the VNet using the given plan.
+func hasDDoSProtectionPlan(vnet network.VirtualNetwork, ddosProtectionPlanID string) bool {
+	if vnet.VirtualNetworkPropertiesFormat == nil || !to.Bool(vnet.EnableDdosProtection) || vnet.DdosProtectionPlan == nil {
+		return false
+	}
+
+	return strings.EqualFold(to.String(vnet.DdosProtectionPlan.ID), ddosProtectionPlanID)
+}
+
+// validateDDoSProtectionPlanID checks that the ID refers to a DDoS Protection Plan.
+func validateDDoSProtectionPlanID(id string) error {
+	plan, err := autorestazure.ParseResourceID(id)
+	if err != nil {
+		return fmt.Errorf("invalid DDoS protection plan ID %q: %w", id, err)
+	}
+	if !strings.EqualFold(plan.Provider, "Microsoft.Network") || !strings.EqualFold(plan.ResourceType, "ddosProtectionPlans") {
+		return fmt.Errorf("%q is not the ID of a DDoS protection plan", id)
+	}
+
+	return nil
+}
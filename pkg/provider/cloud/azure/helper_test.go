@@ -0,0 +1,185 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-06-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+)
+
+func TestIsRouteTableAssociated(t *testing.T) {
+	const routeTableID = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/routeTables/rt"
+
+	tests := []struct {
+		name       string
+		subnet     network.Subnet
+		routeTable network.RouteTable
+		associated bool
+	}{
+		{
+			name:       "subnet without route table",
+			subnet:     network.Subnet{SubnetPropertiesFormat: &network.SubnetPropertiesFormat{}},
+			routeTable: network.RouteTable{ID: to.StringPtr(routeTableID)},
+			associated: true,
+		},
+		{
+			name: "subnet bound to the route table",
+			subnet: network.Subnet{SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
+				RouteTable: &network.RouteTable{ID: to.StringPtr("/subscriptions/sub/resourceGroups/RG/providers/Microsoft.Network/routeTables/rt")},
+			}},
+			routeTable: network.RouteTable{ID: to.StringPtr(routeTableID)},
+			associated: true,
+		},
+		{
+			name: "subnet bound to a different route table",
+			subnet: network.Subnet{SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
+				RouteTable: &network.RouteTable{ID: to.StringPtr("/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/routeTables/other")},
+			}},
+			routeTable: network.RouteTable{ID: to.StringPtr(routeTableID)},
+			associated: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if associated := isRouteTableAssociated(test.subnet, test.routeTable); associated != test.associated {
+				t.Fatalf("expected associated to be %v, got %v", test.associated, associated)
+			}
+		})
+	}
+}
+
+func TestHasDNSServers(t *testing.T) {
+	tests := []struct {
+		name       string
+		vnet       network.VirtualNetwork
+		dnsServers []string
+		expected   bool
+	}{
+		{
+			name:       "vnet using the Azure DNS",
+			vnet:       network.VirtualNetwork{VirtualNetworkPropertiesFormat: &network.VirtualNetworkPropertiesFormat{}},
+			dnsServers: []string{"10.1.0.4"},
+			expected:   false,
+		},
+		{
+			name: "vnet using the DNS servers",
+			vnet: network.VirtualNetwork{VirtualNetworkPropertiesFormat: &network.VirtualNetworkPropertiesFormat{
+				DhcpOptions: &network.DhcpOptions{DNSServers: &[]string{"10.1.0.4", "10.1.0.5"}},
+			}},
+			dnsServers: []string{"10.1.0.4", "10.1.0.5"},
+			expected:   true,
+		},
+		{
+			name: "vnet using the DNS servers in a different order",
+			vnet: network.VirtualNetwork{VirtualNetworkPropertiesFormat: &network.VirtualNetworkPropertiesFormat{
+				DhcpOptions: &network.DhcpOptions{DNSServers: &[]string{"10.1.0.5", "10.1.0.4"}},
+			}},
+			dnsServers: []string{"10.1.0.4", "10.1.0.5"},
+			expected:   false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if result := hasDNSServers(test.vnet, test.dnsServers); result != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestHasDDoSProtectionPlan(t *testing.T) {
+	const planID = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/ddosProtectionPlans/plan"
+
+	tests := []struct {
+		name     string
+		vnet     network.VirtualNetwork
+		expected bool
+	}{
+		{
+			name:     "vnet without DDoS protection",
+			vnet:     network.VirtualNetwork{VirtualNetworkPropertiesFormat: &network.VirtualNetworkPropertiesFormat{}},
+			expected: false,
+		},
+		{
+			name: "vnet protected by the plan",
+			vnet: network.VirtualNetwork{VirtualNetworkPropertiesFormat: &network.VirtualNetworkPropertiesFormat{
+				EnableDdosProtection: to.BoolPtr(true),
+				DdosProtectionPlan:   &network.SubResource{ID: to.StringPtr("/subscriptions/sub/resourceGroups/RG/providers/Microsoft.Network/ddosProtectionPlans/plan")},
+			}},
+			expected: true,
+		},
+		{
+			name: "vnet with the plan but protection disabled",
+			vnet: network.VirtualNetwork{VirtualNetworkPropertiesFormat: &network.VirtualNetworkPropertiesFormat{
+				EnableDdosProtection: to.BoolPtr(false),
+				DdosProtectionPlan:   &network.SubResource{ID: to.StringPtr(planID)},
+			}},
+			expected: false,
+		},
+		{
+			name: "vnet protected by a different plan",
+			vnet: network.VirtualNetwork{VirtualNetworkPropertiesFormat: &network.VirtualNetworkPropertiesFormat{
+				EnableDdosProtection: to.BoolPtr(true),
+				DdosProtectionPlan:   &network.SubResource{ID: to.StringPtr("/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/ddosProtectionPlans/other")},
+			}},
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if result := hasDDoSProtectionPlan(test.vnet, planID); result != test.expected {
+				t.Fatalf("expected %v, got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestValidateDDoSProtectionPlanID(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      string
+		wantErr bool
+	}{
+		{
+			name: "valid plan ID",
+			id:   "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/ddosProtectionPlans/plan",
+		},
+		{
+			name:    "malformed ID",
+			id:      "plan",
+			wantErr: true,
+		},
+		{
+			name:    "ID of a different resource type",
+			id:      "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/vnet",
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := validateDDoSProtectionPlanID(test.id); (err != nil) != test.wantErr {
+				t.Fatalf("expected error to be %v, got %v", test.wantErr, err)
+			}
+		})
+	}
+}
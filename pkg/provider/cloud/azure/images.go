@@ -0,0 +1,225 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	compute2020 "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-30/compute"
+	"github.com/Azure/azure-sdk-for-go/services/marketplaceordering/mgmt/2015-06-01/marketplaceordering"
+	"github.com/Azure/go-autorest/autorest/to"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+)
+
+// galleryImageIDRegexp matches the IDs of image definitions and versions of Shared Image Galleries.
+var galleryImageIDRegexp = regexp.MustCompile(`(?i)^/subscriptions/([^/]+)/resourceGroups/([^/]+)/providers/Microsoft\.Compute/galleries/([^/]+)/images/([^/]+)(?:/versions/([^/]+))?$`)
+
+// galleryImageID is the parsed ID of a gallery image definition or version.
+type galleryImageID struct {
+	subscriptionID string
+	resourceGroup  string
+	gallery        string
+	image          string
+	// version is empty for image definitions, which resolve to their latest version.
+	version string
+}
+
+// parseGalleryImageID parses the ID of a gallery image. It returns nil for other IDs, like the
+// ones of managed images.
+func parseGalleryImageID(id string) *galleryImageID {
+	match := galleryImageIDRegexp.FindStringSubmatch(id)
+	if match == nil {
+		return nil
+	}
+	return &galleryImageID{
+		subscriptionID: match[1],
+		resourceGroup:  match[2],
+		gallery:        match[3],
+		image:          match[4],
+		version:        match[5],
+	}
+}
+
+// ValidateNodeImage checks that the gallery image of an Azure node spec exists, is available in
+// the location and matches the Hyper-V generation and the plan of the spec. Node specs using
+// other images are not checked.
+func ValidateNodeImage(ctx context.Context, spec *apiv1.AzureNodeSpec, location string, credentials Credentials) error {
+	id := parseGalleryImageID(spec.ImageID)
+	if id == nil {
+		return nil
+	}
+
+	imagesClient, err := getGalleryImagesClient(id.subscriptionID, credentials)
+	if err != nil {
+		return err
+	}
+	image, err := imagesClient.Get(ctx, id.resourceGroup, id.gallery, id.image)
+	if err != nil {
+		if isNotFound(err) {
+			return fmt.Errorf("gallery image %q does not exist", id.image)
+		}
+		return fmt.Errorf("failed to get gallery image %q: %w", id.image, err)
+	}
+
+	var version *compute2020.GalleryImageVersion
+	if id.version != "" {
+		versionsClient, err := getGalleryImageVersionsClient(id.subscriptionID, credentials)
+		if err != nil {
+			return err
+		}
+		imageVersion, err := versionsClient.Get(ctx, id.resourceGroup, id.gallery, id.image, id.version, "")
+		if err != nil {
+			if isNotFound(err) {
+				return fmt.Errorf("version %q of gallery image %q does not exist", id.version, id.image)
+			}
+			return fmt.Errorf("failed to get version %q of gallery image %q: %w", id.version, id.image, err)
+		}
+		version = &imageVersion
+	}
+
+	return checkGalleryImage(spec, image, version, location)
+}
+
+// checkGalleryImage checks the settings of a node spec against its gallery image and the version
+// of it, which is nil if the spec uses the latest version.
+func checkGalleryImage(spec *apiv1.AzureNodeSpec, image compute2020.GalleryImage, version *compute2020.GalleryImageVersion, location string) error {
+	name := to.String(image.Name)
+	if props := image.GalleryImageProperties; props != nil {
+		if props.OsType != compute2020.Linux {
+			return fmt.Errorf("gallery image %q is not a Linux image", name)
+		}
+
+		generation := string(props.HyperVGeneration)
+		if generation == "" {
+			generation = apiv1.AzureHyperVGenerationV1
+		}
+		specGeneration := spec.HyperVGeneration
+		if specGeneration == "" {
+			specGeneration = apiv1.AzureHyperVGenerationV1
+		}
+		if !strings.EqualFold(generation, specGeneration) {
+			return fmt.Errorf("gallery image %q is a %s image, but the Hyper-V generation %s is configured", name, generation, specGeneration)
+		}
+
+		if plan := props.PurchasePlan; plan != nil {
+			if spec.ImagePlan == nil {
+				return fmt.Errorf("gallery image %q requires the image plan %q of product %q by %q", name, to.String(plan.Name), to.String(plan.Product), to.String(plan.Publisher))
+			}
+			if !strings.EqualFold(spec.ImagePlan.Name, to.String(plan.Name)) || !strings.EqualFold(spec.ImagePlan.Product, to.String(plan.Product)) || !strings.EqualFold(spec.ImagePlan.Publisher, to.String(plan.Publisher)) {
+				return fmt.Errorf("the image plan does not match the plan %q of product %q by %q of gallery image %q", to.String(plan.Name), to.String(plan.Product), to.String(plan.Publisher), name)
+			}
+		}
+	}
+
+	if version == nil {
+		return nil
+	}
+	props := version.GalleryImageVersionProperties
+	if props == nil {
+		return nil
+	}
+	if props.ProvisioningState != compute2020.ProvisioningState3Succeeded {
+		return fmt.Errorf("version %q of gallery image %q is not ready, it is in state %s", to.String(version.Name), name, props.ProvisioningState)
+	}
+	if props.PublishingProfile != nil && props.PublishingProfile.TargetRegions != nil {
+		for _, region := range *props.PublishingProfile.TargetRegions {
+			if normalizeLocation(to.String(region.Name)) == normalizeLocation(location) {
+				return nil
+			}
+		}
+		return fmt.Errorf("version %q of gallery image %q is not replicated to %s", to.String(version.Name), name, location)
+	}
+
+	return nil
+}
+
+// normalizeLocation returns the name of a location, which the Azure API returns either as name,
+// like "westeurope", or as display name, like "West Europe".
+func normalizeLocation(location string) string {
+	return strings.ToLower(strings.ReplaceAll(location, " ", ""))
+}
+
+// EnsureMarketplaceTerms checks that the terms of a marketplace plan are accepted in the
+// subscription of the credentials. If they are not, they are accepted if accept is true.
+func EnsureMarketplaceTerms(ctx context.Context, plan apiv1.AzureImagePlan, accept bool, credentials Credentials) error {
+	client, err := getMarketplaceAgreementsClient(credentials)
+	if err != nil {
+		return err
+	}
+
+	terms, err := client.Get(ctx, plan.Publisher, plan.Product, plan.Name)
+	if err != nil {
+		if isNotFound(err) {
+			return fmt.Errorf("marketplace plan %q of product %q by %q does not exist", plan.Name, plan.Product, plan.Publisher)
+		}
+		return fmt.Errorf("failed to get the terms of marketplace plan %q: %w", plan.Name, err)
+	}
+	if termsAccepted(terms) {
+		return nil
+	}
+	if !accept {
+		return fmt.Errorf("the terms of marketplace plan %q of product %q by %q have not been accepted in subscription %s", plan.Name, plan.Product, plan.Publisher, credentials.SubscriptionID)
+	}
+
+	if _, err := client.Sign(ctx, plan.Publisher, plan.Product, plan.Name); err != nil {
+		return fmt.Errorf("failed to accept the terms of marketplace plan %q: %w", plan.Name, err)
+	}
+
+	return nil
+}
+
+// termsAccepted returns true if any version of the marketplace terms has been accepted.
+func termsAccepted(terms marketplaceordering.AgreementTerms) bool {
+	return terms.AgreementProperties != nil && to.Bool(terms.Accepted)
+}
+
+func getGalleryImagesClient(subscriptionID string, credentials Credentials) (*compute2020.GalleryImagesClient, error) {
+	var err error
+	client := compute2020.NewGalleryImagesClient(subscriptionID)
+	err = configureClient(&client.Client, credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authorizer: %s", err.Error())
+	}
+
+	return &client, nil
+}
+
+func getGalleryImageVersionsClient(subscriptionID string, credentials Credentials) (*compute2020.GalleryImageVersionsClient, error) {
+	var err error
+	client := compute2020.NewGalleryImageVersionsClient(subscriptionID)
+	err = configureClient(&client.Client, credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authorizer: %s", err.Error())
+	}
+
+	return &client, nil
+}
+
+func getMarketplaceAgreementsClient(credentials Credentials) (*marketplaceordering.MarketplaceAgreementsClient, error) {
+	var err error
+	client := marketplaceordering.NewMarketplaceAgreementsClient(credentials.SubscriptionID)
+	err = configureClient(&client.Client, credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authorizer: %s", err.Error())
+	}
+
+	return &client, nil
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"testing"
+
+	compute2020 "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-30/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+)
+
+func TestParseGalleryImageID(t *testing.T) {
+	testCases := []struct {
+		name string
+		id   string
+		want *galleryImageID
+	}{
+		{
+			name: "image definition",
+			id:   "/subscriptions/sub/resourceGroups/images/providers/Microsoft.Compute/galleries/shared/images/ubuntu",
+			want: &galleryImageID{subscriptionID: "sub", resourceGroup: "images", gallery: "shared", image: "ubuntu"},
+		},
+		{
+			name: "image version",
+			id:   "/subscriptions/sub/resourceGroups/images/providers/Microsoft.Compute/galleries/shared/images/ubuntu/versions/1.2.3",
+			want: &galleryImageID{subscriptionID: "sub", resourceGroup: "images", gallery: "shared", image: "ubuntu", version: "1.2.3"},
+		},
+		{
+			name: "managed image",
+			id:   "/subscriptions/sub/resourceGroups/images/providers/Microsoft.Compute/images/ubuntu",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := parseGalleryImageID(tc.id)
+			if (got == nil) != (tc.want == nil) || (got != nil && *got != *tc.want) {
+				t.Errorf("expected %+v, got %+v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestCheckGalleryImage(t *testing.T) {
+	image := func(generation compute2020.HyperVGeneration, plan *compute2020.ImagePurchasePlan) compute2020.GalleryImage {
+		return compute2020.GalleryImage{
+			Name: to.StringPtr("ubuntu"),
+			GalleryImageProperties: &compute2020.GalleryImageProperties{
+				OsType:           compute2020.Linux,
+				HyperVGeneration: generation,
+				PurchasePlan:     plan,
+			},
+		}
+	}
+	version := func(state compute2020.ProvisioningState3, regions ...string) *compute2020.GalleryImageVersion {
+		var targetRegions []compute2020.TargetRegion
+		for _, region := range regions {
+			targetRegions = append(targetRegions, compute2020.TargetRegion{Name: to.StringPtr(region)})
+		}
+		return &compute2020.GalleryImageVersion{
+			Name: to.StringPtr("1.2.3"),
+			GalleryImageVersionProperties: &compute2020.GalleryImageVersionProperties{
+				ProvisioningState: state,
+				PublishingProfile: &compute2020.GalleryImageVersionPublishingProfile{TargetRegions: &targetRegions},
+			},
+		}
+	}
+	purchasePlan := &compute2020.ImagePurchasePlan{Name: to.StringPtr("stable"), Publisher: to.StringPtr("kinvolk"), Product: to.StringPtr("flatcar-container-linux")}
+	plan := &apiv1.AzureImagePlan{Name: "stable", Publisher: "kinvolk", Product: "flatcar-container-linux"}
+
+	testCases := []struct {
+		name    string
+		spec    apiv1.AzureNodeSpec
+		image   compute2020.GalleryImage
+		version *compute2020.GalleryImageVersion
+		wantErr bool
+	}{
+		{
+			name:  "latest version of a Gen1 image",
+			image: image("", nil),
+		},
+		{
+			name:    "version replicated to the location",
+			image:   image(compute2020.V1, nil),
+			version: version(compute2020.ProvisioningState3Succeeded, "North Europe", "West Europe"),
+		},
+		{
+			name:    "version not replicated to the location",
+			image:   image(compute2020.V1, nil),
+			version: version(compute2020.ProvisioningState3Succeeded, "northeurope"),
+			wantErr: true,
+		},
+		{
+			name:    "version still being created",
+			image:   image(compute2020.V1, nil),
+			version: version(compute2020.ProvisioningState3Creating, "westeurope"),
+			wantErr: true,
+		},
+		{
+			name:    "Gen2 image without Gen2 node spec",
+			image:   image(compute2020.V2, nil),
+			wantErr: true,
+		},
+		{
+			name:  "Gen2 image",
+			spec:  apiv1.AzureNodeSpec{HyperVGeneration: apiv1.AzureHyperVGenerationV2},
+			image: image(compute2020.V2, nil),
+		},
+		{
+			name:  "image with matching plan",
+			spec:  apiv1.AzureNodeSpec{ImagePlan: plan},
+			image: image("", purchasePlan),
+		},
+		{
+			name:    "image with plan missing in the node spec",
+			image:   image("", purchasePlan),
+			wantErr: true,
+		},
+		{
+			name:    "image with other plan",
+			spec:    apiv1.AzureNodeSpec{ImagePlan: &apiv1.AzureImagePlan{Name: "alpha", Publisher: "kinvolk", Product: "flatcar-container-linux"}},
+			image:   image("", purchasePlan),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkGalleryImage(&tc.spec, tc.image, tc.version, "westeurope")
+			if (err != nil) != tc.wantErr {
+				t.Errorf("expected error %t, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
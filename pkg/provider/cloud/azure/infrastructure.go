@@ -0,0 +1,321 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-06-01/network"
+	network2020 "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/provider"
+)
+
+// GetInfrastructure queries Azure for the live state of the resources of the cluster and
+// compares them to the cluster spec.
+func (a *Azure) GetInfrastructure(cluster *kubermaticv1.Cluster) ([]provider.InfrastructureResource, error) {
+	credentials, err := GetCredentialsForCluster(cluster.Spec.Cloud, a.secretKeySelector)
+	if err != nil {
+		return nil, err
+	}
+
+	cloud := cluster.Spec.Cloud
+	tags := a.resourceTags(cluster)
+	var resources []provider.InfrastructureResource
+
+	if cloud.Azure.ResourceGroup != "" {
+		resource := newInfrastructureResource(cluster, "resourceGroup", cloud.Azure.ResourceGroup, FinalizerResourceGroup)
+		client, err := getGroupsClient(cloud, credentials)
+		if err != nil {
+			return nil, err
+		}
+		group, err := client.Get(a.ctx, cloud.Azure.ResourceGroup)
+		if err := observeInfrastructureResource(&resource, err); err != nil {
+			return nil, err
+		}
+		if resource.State != provider.InfrastructureResourceDeleted {
+			resource.ID = to.String(group.ID)
+			if group.Properties != nil {
+				resource.State = provisioningState(to.String(group.Properties.ProvisioningState))
+			}
+			setInfrastructureTags(&resource, group.Tags, tags)
+		}
+		resources = append(resources, resource)
+	}
+
+	if cloud.Azure.VNetName != "" {
+		resource := newInfrastructureResource(cluster, "vnet", cloud.Azure.VNetName, FinalizerVNet)
+		client, err := getNetworksClient(cloud, credentials)
+		if err != nil {
+			return nil, err
+		}
+		vnet, err := client.Get(a.ctx, vnetResourceGroup(cloud), cloud.Azure.VNetName, "")
+		if err := observeInfrastructureResource(&resource, err); err != nil {
+			return nil, err
+		}
+		if resource.State != provider.InfrastructureResourceDeleted {
+			resource.ID = to.String(vnet.ID)
+			if vnet.VirtualNetworkPropertiesFormat != nil {
+				resource.State = provisioningState(to.String(vnet.ProvisioningState))
+				if vnet.AddressSpace != nil && vnet.AddressSpace.AddressPrefixes != nil {
+					resource.AddressPrefixes = *vnet.AddressSpace.AddressPrefixes
+				}
+			}
+			setInfrastructureTags(&resource, vnet.Tags, tags)
+			resource.Drift = append(resource.Drift, vnetDrift(vnet, cloud)...)
+		}
+		resources = append(resources, resource)
+	}
+
+	if cloud.Azure.SubnetName != "" {
+		// subnets do not support tags
+		resource := newInfrastructureResource(cluster, "subnet", cloud.Azure.SubnetName, FinalizerSubnet)
+		client, err := getPrivateLinkSubnetsClient(cloud, credentials)
+		if err != nil {
+			return nil, err
+		}
+		subnet, err := client.Get(a.ctx, vnetResourceGroup(cloud), cloud.Azure.VNetName, cloud.Azure.SubnetName, "")
+		if err := observeInfrastructureResource(&resource, err); err != nil {
+			return nil, err
+		}
+		if resource.State != provider.InfrastructureResourceDeleted {
+			resource.ID = to.String(subnet.ID)
+			if subnet.SubnetPropertiesFormat != nil {
+				resource.State = provisioningState(string(subnet.ProvisioningState))
+				if subnet.AddressPrefix != nil {
+					resource.AddressPrefixes = []string{*subnet.AddressPrefix}
+				} else if subnet.AddressPrefixes != nil {
+					resource.AddressPrefixes = *subnet.AddressPrefixes
+				}
+			}
+			resource.Drift = append(resource.Drift, subnetDrift(subnet, cloud)...)
+		}
+		resources = append(resources, resource)
+	}
+
+	if cloud.Azure.SecurityGroup != "" {
+		resource := newInfrastructureResource(cluster, "securityGroup", cloud.Azure.SecurityGroup, FinalizerSecurityGroup)
+		client, err := getSecurityGroupsClient(cloud, credentials)
+		if err != nil {
+			return nil, err
+		}
+		nsg, err := client.Get(a.ctx, SecurityGroupResourceGroup(cloud), cloud.Azure.SecurityGroup, "")
+		if err := observeInfrastructureResource(&resource, err); err != nil {
+			return nil, err
+		}
+		if resource.State != provider.InfrastructureResourceDeleted {
+			resource.ID = to.String(nsg.ID)
+			if nsg.SecurityGroupPropertiesFormat != nil {
+				resource.State = provisioningState(string(nsg.ProvisioningState))
+			}
+			setInfrastructureTags(&resource, nsg.Tags, tags)
+		}
+		resources = append(resources, resource)
+	}
+
+	if cloud.Azure.RouteTableName != "" {
+		resource := newInfrastructureResource(cluster, "routeTable", cloud.Azure.RouteTableName, FinalizerRouteTable)
+		client, err := getRouteTablesClient(cloud, credentials)
+		if err != nil {
+			return nil, err
+		}
+		routeTable, err := client.Get(a.ctx, RouteTableResourceGroup(cloud), cloud.Azure.RouteTableName, "")
+		if err := observeInfrastructureResource(&resource, err); err != nil {
+			return nil, err
+		}
+		if resource.State != provider.InfrastructureResourceDeleted {
+			resource.ID = to.String(routeTable.ID)
+			if routeTable.RouteTablePropertiesFormat != nil {
+				resource.State = provisioningState(to.String(routeTable.ProvisioningState))
+			}
+			setInfrastructureTags(&resource, routeTable.Tags, tags)
+			// routes are only managed in route tables created by Kubermatic
+			if resource.Managed {
+				resource.Drift = append(resource.Drift, routeTableDrift(routeTable, cloud.Azure.Routes)...)
+			}
+		}
+		resources = append(resources, resource)
+	}
+
+	availabilitySets := []provider.InfrastructureResource{}
+	if cloud.Azure.AvailabilitySet != "" {
+		availabilitySets = append(availabilitySets, newInfrastructureResource(cluster, "availabilitySet", cloud.Azure.AvailabilitySet, FinalizerAvailabilitySet))
+	}
+	if cluster.Status.Azure != nil {
+		for _, as := range cluster.Status.Azure.AvailabilitySets {
+			if as.Created {
+				availabilitySets = append(availabilitySets, newInfrastructureResource(cluster, "availabilitySet", as.Name, FinalizerMachineDeploymentAvailabilitySets))
+			}
+		}
+	}
+	if len(availabilitySets) > 0 {
+		client, err := getAvailabilitySetClient(cloud, credentials)
+		if err != nil {
+			return nil, err
+		}
+		for _, resource := range availabilitySets {
+			as, err := client.Get(a.ctx, cloud.Azure.ResourceGroup, resource.Name)
+			if err := observeInfrastructureResource(&resource, err); err != nil {
+				return nil, err
+			}
+			if resource.State != provider.InfrastructureResourceDeleted {
+				// availability sets have no provisioning state
+				resource.ID = to.String(as.ID)
+				setInfrastructureTags(&resource, as.Tags, tags)
+			}
+			resources = append(resources, resource)
+		}
+	}
+
+	return resources, nil
+}
+
+func newInfrastructureResource(cluster *kubermaticv1.Cluster, resourceType, name, finalizer string) provider.InfrastructureResource {
+	return provider.InfrastructureResource{
+		Type:    resourceType,
+		Name:    name,
+		Managed: kuberneteshelper.HasFinalizer(cluster, finalizer),
+		State:   provider.InfrastructureResourceExists,
+	}
+}
+
+// provisioningState maps the provisioning state of an Azure resource to the state of the resource.
+func provisioningState(state string) provider.InfrastructureResourceState {
+	switch strings.ToLower(state) {
+	case "", "succeeded":
+		return provider.InfrastructureResourceExists
+	case "failed", "canceled":
+		return provider.InfrastructureResourceFailed
+	case "deleting":
+		return provider.InfrastructureResourceDeleted
+	default:
+		return provider.InfrastructureResourceProvisioning
+	}
+}
+
+// tagDrift returns the tags of a resource as a plain map and the desired tags it is missing.
+// Additional tags, for example added by an Azure Policy, are not considered as drift.
+func tagDrift(existing, desired map[string]*string) (map[string]string, []string) {
+	tags := map[string]string{}
+	for k, v := range existing {
+		tags[k] = to.String(v)
+	}
+
+	var drift []string
+	for k, v := range desired {
+		if current, ok := tags[k]; !ok {
+			drift = append(drift, fmt.Sprintf("tag %q is missing", k))
+		} else if current != to.String(v) {
+			drift = append(drift, fmt.Sprintf("tag %q is %q instead of %q", k, current, to.String(v)))
+		}
+	}
+	for _, k := range strings.Split(tags[managedTagsKey], ",") {
+		if _, ok := desired[k]; !ok && k != "" {
+			if _, ok := tags[k]; ok {
+				drift = append(drift, fmt.Sprintf("tag %q is no longer managed", k))
+			}
+		}
+	}
+	sort.Strings(drift)
+
+	return tags, drift
+}
+
+// vnetDrift compares the settings of the virtual network Kubermatic reconciles to the cloud spec.
+func vnetDrift(vnet network.VirtualNetwork, cloud kubermaticv1.CloudSpec) []string {
+	if len(cloud.Azure.DNSServers) > 0 && !hasDNSServers(vnet, cloud.Azure.DNSServers) {
+		return []string{fmt.Sprintf("the DNS servers are not %v", cloud.Azure.DNSServers)}
+	}
+	return nil
+}
+
+// subnetDrift checks the associations and service endpoints of the subnet.
+func subnetDrift(subnet network2020.Subnet, cloud kubermaticv1.CloudSpec) []string {
+	var drift []string
+
+	var nsgID, routeTableID string
+	if subnet.SubnetPropertiesFormat != nil {
+		if subnet.NetworkSecurityGroup != nil {
+			nsgID = to.String(subnet.NetworkSecurityGroup.ID)
+		}
+		if subnet.RouteTable != nil {
+			routeTableID = to.String(subnet.RouteTable.ID)
+		}
+	}
+
+	if cloud.Azure.SecurityGroup != "" && !strings.HasSuffix(strings.ToLower(nsgID), "/"+strings.ToLower(cloud.Azure.SecurityGroup)) {
+		drift = append(drift, fmt.Sprintf("the subnet is not associated with security group %q", cloud.Azure.SecurityGroup))
+	}
+	if cloud.Azure.RouteTableName != "" && !strings.HasSuffix(strings.ToLower(routeTableID), "/"+strings.ToLower(cloud.Azure.RouteTableName)) {
+		drift = append(drift, fmt.Sprintf("the subnet is not associated with route table %q", cloud.Azure.RouteTableName))
+	}
+	if len(cloud.Azure.ServiceEndpoints) > 0 && !hasServiceEndpoints(subnet, cloud.Azure.ServiceEndpoints) {
+		drift = append(drift, fmt.Sprintf("the subnet does not have the service endpoints %v", cloud.Azure.ServiceEndpoints))
+	}
+
+	return drift
+}
+
+// routeTableDrift returns the user-defined routes which are missing or differ in the route table.
+func routeTableDrift(routeTable network.RouteTable, routes []kubermaticv1.AzureRoute) []string {
+	existing := map[string]network.Route{}
+	if routeTable.RouteTablePropertiesFormat != nil && routeTable.Routes != nil {
+		for _, route := range *routeTable.Routes {
+			existing[to.String(route.Name)] = route
+		}
+	}
+
+	var drift []string
+	for _, route := range routes {
+		current, ok := existing[routeNamePrefix+route.Name]
+		if !ok {
+			drift = append(drift, fmt.Sprintf("route %q is missing", route.Name))
+		} else if !isRouteUpToDate(current, route) {
+			drift = append(drift, fmt.Sprintf("route %q differs from the cluster spec", route.Name))
+		}
+	}
+
+	return drift
+}
+
+// observeInfrastructureResource records a resource which was not found as deleted. Other errors are returned.
+func observeInfrastructureResource(resource *provider.InfrastructureResource, err error) error {
+	if err == nil {
+		return nil
+	}
+	if isNotFound(err) {
+		resource.State = provider.InfrastructureResourceDeleted
+		resource.Drift = append(resource.Drift, "the resource does not exist")
+		return nil
+	}
+	return fmt.Errorf("failed to get %s %q: %w", resource.Type, resource.Name, err)
+}
+
+// setInfrastructureTags records the tags of the resource. Missing Kubermatic tags are only
+// reported as drift for resources managed by Kubermatic.
+func setInfrastructureTags(resource *provider.InfrastructureResource, existing, desired map[string]*string) {
+	tags, drift := tagDrift(existing, desired)
+	resource.Tags = tags
+	if resource.Managed {
+		resource.Drift = append(resource.Drift, drift...)
+	}
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-06-01/network"
+	network2020 "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/provider"
+)
+
+func TestProvisioningState(t *testing.T) {
+	tests := map[string]provider.InfrastructureResourceState{
+		"Succeeded": provider.InfrastructureResourceExists,
+		"":          provider.InfrastructureResourceExists,
+		"Updating":  provider.InfrastructureResourceProvisioning,
+		"Deleting":  provider.InfrastructureResourceDeleted,
+		"Failed":    provider.InfrastructureResourceFailed,
+	}
+
+	for state, expected := range tests {
+		if got := provisioningState(state); got != expected {
+			t.Errorf("expected state %q for %q, got %q", expected, state, got)
+		}
+	}
+}
+
+func TestTagDrift(t *testing.T) {
+	existing := map[string]*string{
+		"cluster": to.StringPtr("abc"),
+		"env":     to.StringPtr("dev"),
+		"policy":  to.StringPtr("added"),
+	}
+	desired := map[string]*string{
+		"cluster": to.StringPtr("abc"),
+		"env":     to.StringPtr("prod"),
+		"team":    to.StringPtr("platform"),
+	}
+
+	tags, drift := tagDrift(existing, desired)
+
+	expectedTags := map[string]string{"cluster": "abc", "env": "dev", "policy": "added"}
+	if !reflect.DeepEqual(tags, expectedTags) {
+		t.Errorf("expected tags %v, got %v", expectedTags, tags)
+	}
+	expectedDrift := []string{`tag "env" is "dev" instead of "prod"`, `tag "team" is missing`}
+	if !reflect.DeepEqual(drift, expectedDrift) {
+		t.Errorf("expected drift %v, got %v", expectedDrift, drift)
+	}
+}
+
+func TestSubnetDrift(t *testing.T) {
+	cloud := kubermaticv1.CloudSpec{
+		Azure: &kubermaticv1.AzureCloudSpec{
+			SecurityGroup:  "kubernetes-abc",
+			RouteTableName: "kubernetes-abc",
+		},
+	}
+
+	subnet := network2020.Subnet{
+		SubnetPropertiesFormat: &network2020.SubnetPropertiesFormat{
+			NetworkSecurityGroup: &network2020.SecurityGroup{ID: to.StringPtr("/subscriptions/s/resourceGroups/rg/providers/Microsoft.Network/networkSecurityGroups/kubernetes-abc")},
+			RouteTable:           &network2020.RouteTable{ID: to.StringPtr("/subscriptions/s/resourceGroups/rg/providers/Microsoft.Network/routeTables/other")},
+		},
+	}
+
+	expected := []string{`the subnet is not associated with route table "kubernetes-abc"`}
+	if drift := subnetDrift(subnet, cloud); !reflect.DeepEqual(drift, expected) {
+		t.Errorf("expected drift %v, got %v", expected, drift)
+	}
+}
+
+func TestRouteTableDrift(t *testing.T) {
+	routeTable := network.RouteTable{
+		RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{
+			Routes: &[]network.Route{
+				{
+					Name: to.StringPtr(routeNamePrefix + "onprem"),
+					RoutePropertiesFormat: &network.RoutePropertiesFormat{
+						AddressPrefix: to.StringPtr("10.0.0.0/8"),
+						NextHopType:   network.RouteNextHopTypeVirtualNetworkGateway,
+					},
+				},
+				{
+					Name: to.StringPtr(routeNamePrefix + "firewall"),
+					RoutePropertiesFormat: &network.RoutePropertiesFormat{
+						AddressPrefix:    to.StringPtr("0.0.0.0/0"),
+						NextHopType:      network.RouteNextHopTypeVirtualAppliance,
+						NextHopIPAddress: to.StringPtr("10.1.0.4"),
+					},
+				},
+			},
+		},
+	}
+
+	routes := []kubermaticv1.AzureRoute{
+		{Name: "onprem", AddressPrefix: "10.0.0.0/8", NextHopType: "VirtualNetworkGateway"},
+		{Name: "firewall", AddressPrefix: "0.0.0.0/0", NextHopType: "VirtualAppliance", NextHopIPAddress: "10.1.0.5"},
+		{Name: "internet", AddressPrefix: "1.2.3.4/32", NextHopType: "Internet"},
+	}
+
+	expected := []string{`route "firewall" differs from the cluster spec`, `route "internet" is missing`}
+	if drift := routeTableDrift(routeTable, routes); !reflect.DeepEqual(drift, expected) {
+		t.Errorf("expected drift %v, got %v", expected, drift)
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"sort"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-06-01/network"
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2018-02-01/resources"
+	"github.com/Azure/go-autorest/autorest/to"
+)
+
+// The functions in this file select the existing resources users can pick for their clusters.
+// Locations are compared in their normalized form ("West Europe" matches "westeurope"), an
+// empty location matches all resources. The returned names are sorted and never nil.
+
+// isInLocation returns true if the location of a resource matches the requested location.
+func isInLocation(resourceLocation *string, location string) bool {
+	return location == "" || normalizeLocation(to.String(resourceLocation)) == normalizeLocation(location)
+}
+
+// appendName appends the name of a resource, unless it is missing.
+func appendName(names []string, name *string) []string {
+	if to.String(name) == "" {
+		return names
+	}
+	return append(names, *name)
+}
+
+// ResourceGroupNames returns the names of the resource groups in the location.
+func ResourceGroupNames(groups []resources.Group, location string) []string {
+	names := []string{}
+	for _, group := range groups {
+		if isInLocation(group.Location, location) {
+			names = appendName(names, group.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// VirtualNetworkNames returns the names of the virtual networks in the location.
+func VirtualNetworkNames(vnets []network.VirtualNetwork, location string) []string {
+	names := []string{}
+	for _, vnet := range vnets {
+		if isInLocation(vnet.Location, location) {
+			names = appendName(names, vnet.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SubnetNames returns the names of the subnets of a virtual network. Subnets are in the location
+// of their virtual network, so they are not filtered.
+func SubnetNames(subnets []network.Subnet) []string {
+	names := []string{}
+	for _, subnet := range subnets {
+		names = appendName(names, subnet.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SecurityGroupNames returns the names of the network security groups in the location.
+func SecurityGroupNames(groups []network.SecurityGroup, location string) []string {
+	names := []string{}
+	for _, group := range groups {
+		if isInLocation(group.Location, location) {
+			names = appendName(names, group.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// RouteTableNames returns the names of the route tables in the location.
+func RouteTableNames(routeTables []network.RouteTable, location string) []string {
+	names := []string{}
+	for _, routeTable := range routeTables {
+		if isInLocation(routeTable.Location, location) {
+			names = appendName(names, routeTable.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-06-01/network"
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2018-02-01/resources"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/go-test/deep"
+)
+
+func TestResourceGroupNames(t *testing.T) {
+	groups := []resources.Group{
+		{Name: to.StringPtr("rg-weu-2"), Location: to.StringPtr("westeurope")},
+		{Name: to.StringPtr("rg-neu"), Location: to.StringPtr("northeurope")},
+		{Name: to.StringPtr("rg-weu-1"), Location: to.StringPtr("westeurope")},
+		{Location: to.StringPtr("westeurope")},
+		{Name: to.StringPtr("rg-unknown")},
+	}
+
+	testCases := []struct {
+		name     string
+		location string
+		expected []string
+	}{
+		{
+			name:     "normalized location",
+			location: "West Europe",
+			expected: []string{"rg-weu-1", "rg-weu-2"},
+		},
+		{
+			name:     "all locations",
+			expected: []string{"rg-neu", "rg-unknown", "rg-weu-1", "rg-weu-2"},
+		},
+		{
+			name:     "location without resource groups",
+			location: "eastus",
+			expected: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := deep.Equal(ResourceGroupNames(groups, tc.location), tc.expected); diff != nil {
+				t.Errorf("unexpected resource groups: %v", diff)
+			}
+		})
+	}
+}
+
+func TestNetworkResourceNames(t *testing.T) {
+	vnets := []network.VirtualNetwork{
+		{Name: to.StringPtr("vnet-b"), Location: to.StringPtr("westeurope")},
+		{Name: to.StringPtr("vnet-a"), Location: to.StringPtr("westeurope")},
+		{Name: to.StringPtr("vnet-c"), Location: to.StringPtr("eastus")},
+	}
+	if diff := deep.Equal(VirtualNetworkNames(vnets, "westeurope"), []string{"vnet-a", "vnet-b"}); diff != nil {
+		t.Errorf("unexpected virtual networks: %v", diff)
+	}
+
+	subnets := []network.Subnet{{Name: to.StringPtr("nodes")}, {Name: to.StringPtr("bastion")}, {}}
+	if diff := deep.Equal(SubnetNames(subnets), []string{"bastion", "nodes"}); diff != nil {
+		t.Errorf("unexpected subnets: %v", diff)
+	}
+
+	securityGroups := []network.SecurityGroup{
+		{Name: to.StringPtr("nsg-nodes"), Location: to.StringPtr("westeurope")},
+		{Name: to.StringPtr("nsg-other"), Location: to.StringPtr("eastus")},
+	}
+	if diff := deep.Equal(SecurityGroupNames(securityGroups, "WestEurope"), []string{"nsg-nodes"}); diff != nil {
+		t.Errorf("unexpected security groups: %v", diff)
+	}
+
+	routeTables := []network.RouteTable{{Name: to.StringPtr("rt-other"), Location: to.StringPtr("eastus")}}
+	if diff := deep.Equal(RouteTableNames(routeTables, "westeurope"), []string{}); diff != nil {
+		t.Errorf("unexpected route tables: %v", diff)
+	}
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/keyvault/v7.1/keyvault"
+	autorestazure "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/azure/auth"
+	"github.com/Azure/go-autorest/autorest/to"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/provider"
+)
+
+var (
+	keyVaultNameRegexp = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9-]{1,22}[a-zA-Z0-9]$`)
+	keyNameRegexp      = regexp.MustCompile(`^[a-zA-Z0-9-]{1,127}$`)
+)
+
+// reconcileKMSKey creates the key used to encrypt the secrets of the cluster if it does not exist
+// yet, and pins the key version in the cluster spec, so that the KMS plugin keeps using the same
+// version when the key gets rotated.
+func (a *Azure) reconcileKMSKey(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, credentials Credentials) (*kubermaticv1.Cluster, error) {
+	kms := cluster.Spec.Cloud.Azure.KMS
+	if kms == nil || kms.KeyVersion != "" {
+		return cluster, nil
+	}
+
+	keysClient, err := getKeyVaultClient(credentials)
+	if err != nil {
+		return cluster, err
+	}
+	vaultURL := keyVaultURL(kms.KeyVaultName)
+
+	key, err := keysClient.GetKey(a.ctx, vaultURL, kms.KeyName, "")
+	if err != nil {
+		if !isNotFound(err) {
+			return cluster, fmt.Errorf("failed to get key %q of key vault %q: %w", kms.KeyName, kms.KeyVaultName, err)
+		}
+
+		a.log.Infow("creating KMS key", "cluster", cluster.Name, "keyVault", kms.KeyVaultName, "key", kms.KeyName)
+		key, err = keysClient.CreateKey(a.ctx, vaultURL, kms.KeyName, keyvault.KeyCreateParameters{
+			Kty:     keyvault.RSA,
+			KeySize: to.Int32Ptr(2048),
+			KeyOps: &[]keyvault.JSONWebKeyOperation{
+				keyvault.Encrypt,
+				keyvault.Decrypt,
+				keyvault.WrapKey,
+				keyvault.UnwrapKey,
+			},
+			Tags: map[string]*string{clusterTagKey: to.StringPtr(cluster.Name)},
+		})
+		if err != nil {
+			return cluster, fmt.Errorf("failed to create key %q in key vault %q: %w", kms.KeyName, kms.KeyVaultName, err)
+		}
+	}
+
+	version, err := keyVersionFromID(key)
+	if err != nil {
+		return cluster, err
+	}
+
+	return update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+		updatedCluster.Spec.Cloud.Azure.KMS.KeyVersion = version
+	})
+}
+
+// keyVersionFromID returns the version of the key, which is the last segment of its ID,
+// e.g. https://my-vault.vault.azure.net/keys/my-key/<version>.
+func keyVersionFromID(key keyvault.KeyBundle) (string, error) {
+	if key.Key == nil || key.Key.Kid == nil {
+		return "", errors.New("key has no ID")
+	}
+
+	segments := strings.Split(strings.TrimSuffix(*key.Key.Kid, "/"), "/")
+	if len(segments) < 2 || segments[len(segments)-2] == "keys" {
+		return "", fmt.Errorf("key ID %q contains no version", *key.Key.Kid)
+	}
+
+	return segments[len(segments)-1], nil
+}
+
+// validateKMSSettings ensures the names of the key vault and the key are valid.
+func validateKMSSettings(kms *kubermaticv1.AzureKMSSettings) error {
+	if kms == nil {
+		return nil
+	}
+	if !keyVaultNameRegexp.MatchString(kms.KeyVaultName) {
+		return fmt.Errorf("invalid key vault name %q, it must have 3 to 24 alphanumeric characters or dashes and start with a letter", kms.KeyVaultName)
+	}
+	if !keyNameRegexp.MatchString(kms.KeyName) {
+		return fmt.Errorf("invalid key name %q, it must have 1 to 127 alphanumeric characters or dashes", kms.KeyName)
+	}
+
+	return nil
+}
+
+// validateKMSSettingsUpdate ensures that the encryption of a cluster is not changed once it has
+// been enabled, as the existing secrets could not be decrypted anymore. Only the key version may
+// be pinned after the cluster has been created.
+func validateKMSSettingsUpdate(oldKMS, newKMS *kubermaticv1.AzureKMSSettings) error {
+	if oldKMS == nil {
+		return nil
+	}
+	if newKMS == nil {
+		return errors.New("disabling the KMS encryption is not allowed")
+	}
+	if oldKMS.KeyVaultName != newKMS.KeyVaultName || oldKMS.KeyName != newKMS.KeyName {
+		return errors.New("changing the KMS key vault or key is not allowed")
+	}
+	if oldKMS.KeyVersion != "" && oldKMS.KeyVersion != newKMS.KeyVersion {
+		return errors.New("changing the KMS key version is not allowed")
+	}
+
+	return nil
+}
+
+func keyVaultURL(name string) string {
+	return fmt.Sprintf("https://%s.%s", name, autorestazure.PublicCloud.KeyVaultDNSSuffix)
+}
+
+func getKeyVaultClient(credentials Credentials) (*keyvault.BaseClient, error) {
+	var err error
+	keysClient := keyvault.New()
+	config := auth.NewClientCredentialsConfig(credentials.ClientID, credentials.ClientSecret, credentials.TenantID)
+	config.Resource = strings.TrimSuffix(autorestazure.PublicCloud.ResourceIdentifiers.KeyVault, "/")
+	keysClient.Authorizer, err = config.Authorizer()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authorizer: %s", err.Error())
+	}
+
+	return &keysClient, nil
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/keyvault/v7.1/keyvault"
+	"github.com/Azure/go-autorest/autorest/to"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+)
+
+func TestKeyVersionFromID(t *testing.T) {
+	tests := []struct {
+		name            string
+		kid             *string
+		expectedVersion string
+		expectedErr     bool
+	}{
+		{
+			name:            "versioned key ID",
+			kid:             to.StringPtr("https://my-vault.vault.azure.net/keys/my-key/0123abcd"),
+			expectedVersion: "0123abcd",
+		},
+		{
+			name:        "key ID without version",
+			kid:         to.StringPtr("https://my-vault.vault.azure.net/keys/my-key"),
+			expectedErr: true,
+		},
+		{
+			name:        "missing key ID",
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			version, err := keyVersionFromID(keyvault.KeyBundle{Key: &keyvault.JSONWebKey{Kid: test.kid}})
+			if (err != nil) != test.expectedErr {
+				t.Fatalf("expected error to be %v, got %v", test.expectedErr, err)
+			}
+			if version != test.expectedVersion {
+				t.Errorf("expected version %q, got %q", test.expectedVersion, version)
+			}
+		})
+	}
+}
+
+func TestValidateKMSSettings(t *testing.T) {
+	tests := []struct {
+		name        string
+		kms         *kubermaticv1.AzureKMSSettings
+		expectedErr bool
+	}{
+		{
+			name: "KMS disabled",
+		},
+		{
+			name: "valid settings",
+			kms:  &kubermaticv1.AzureKMSSettings{KeyVaultName: "my-vault", KeyName: "etcd-key"},
+		},
+		{
+			name:        "vault name too short",
+			kms:         &kubermaticv1.AzureKMSSettings{KeyVaultName: "kv", KeyName: "etcd-key"},
+			expectedErr: true,
+		},
+		{
+			name:        "vault name starting with a digit",
+			kms:         &kubermaticv1.AzureKMSSettings{KeyVaultName: "1vault", KeyName: "etcd-key"},
+			expectedErr: true,
+		},
+		{
+			name:        "missing key name",
+			kms:         &kubermaticv1.AzureKMSSettings{KeyVaultName: "my-vault"},
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := validateKMSSettings(test.kms); (err != nil) != test.expectedErr {
+				t.Errorf("expected error to be %v, got %v", test.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateKMSSettingsUpdate(t *testing.T) {
+	kms := func(vault, key, version string) *kubermaticv1.AzureKMSSettings {
+		return &kubermaticv1.AzureKMSSettings{KeyVaultName: vault, KeyName: key, KeyVersion: version}
+	}
+
+	tests := []struct {
+		name        string
+		oldKMS      *kubermaticv1.AzureKMSSettings
+		newKMS      *kubermaticv1.AzureKMSSettings
+		expectedErr bool
+	}{
+		{
+			name:   "enabling KMS",
+			newKMS: kms("my-vault", "etcd-key", ""),
+		},
+		{
+			name:   "pinning the key version",
+			oldKMS: kms("my-vault", "etcd-key", ""),
+			newKMS: kms("my-vault", "etcd-key", "v1"),
+		},
+		{
+			name:        "disabling KMS",
+			oldKMS:      kms("my-vault", "etcd-key", "v1"),
+			expectedErr: true,
+		},
+		{
+			name:        "changing the key",
+			oldKMS:      kms("my-vault", "etcd-key", "v1"),
+			newKMS:      kms("my-vault", "other-key", "v1"),
+			expectedErr: true,
+		},
+		{
+			name:        "changing the key version",
+			oldKMS:      kms("my-vault", "etcd-key", "v1"),
+			newKMS:      kms("my-vault", "etcd-key", "v2"),
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := validateKMSSettingsUpdate(test.oldKMS, test.newKMS); (err != nil) != test.expectedErr {
+				t.Errorf("expected error to be %v, got %v", test.expectedErr, err)
+			}
+		})
+	}
+}
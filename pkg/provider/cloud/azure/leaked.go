@@ -0,0 +1,120 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"fmt"
+	"hash/fnv"
+
+	autorestazure "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/to"
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/provider"
+)
+
+// leakableResourceTypes are the types of the resources the machine-controller creates for each
+// machine, which are left behind if the deletion of the machine failed halfway.
+var leakableResourceTypes = map[string]bool{
+	"Microsoft.Network/networkInterfaces": true,
+	"Microsoft.Compute/disks":             true,
+	"Microsoft.Network/publicIPAddresses": true,
+}
+
+// cleanUpLeakedResources deletes the network interfaces, disks and public IPs of the cluster's machines
+// which are still left in the resource group. If Kubermatic created the resource group, they are removed
+// together with it. It has to run before the security group and the subnet are deleted, as these cannot
+// be deleted while network interfaces still use them. The resources are deleted one after another,
+// as a public IP cannot be deleted while it is still attached to a network interface.
+func (a *Azure) cleanUpLeakedResources(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, credentials Credentials, logger *zap.SugaredLogger) (*kubermaticv1.Cluster, error) {
+	if kuberneteshelper.HasFinalizer(cluster, FinalizerResourceGroup) {
+		return cluster, nil
+	}
+
+	resourcesClient, err := getResourcesClient(credentials)
+	if err != nil {
+		return cluster, err
+	}
+
+	filter := fmt.Sprintf("tagName eq '%s' and tagValue eq '%s'", clusterTagKey, cluster.Name)
+	list, err := resourcesClient.ListByResourceGroupComplete(a.ctx, cluster.Spec.Cloud.Azure.ResourceGroup, filter, "", nil)
+	if err != nil {
+		if isNotFound(err) {
+			return cluster, nil
+		}
+		return cluster, fmt.Errorf("failed to list resources of the cluster: %w", err)
+	}
+
+	var tagged []TaggedResource
+	for ; list.NotDone(); err = list.NextWithContext(a.ctx) {
+		if err != nil {
+			return cluster, fmt.Errorf("failed to list resources of the cluster: %w", err)
+		}
+		resource := list.Value()
+		tagged = append(tagged, TaggedResource{
+			ID:      to.String(resource.ID),
+			Type:    to.String(resource.Type),
+			Cluster: to.String(resource.Tags[clusterTagKey]),
+		})
+	}
+
+	for _, resource := range leakedResources(tagged, cluster.Name) {
+		resource := resource
+
+		logger.Infow("deleting leaked resource", "resource", resource.ID)
+		if err := a.pollOperation(cluster, update, credentials, leakedResourceOperation(resource.ID), func() (autorestazure.FutureAPI, error) {
+			future, err := resourcesClient.DeleteByID(a.ctx, resource.ID, deletionAPIVersions[resource.Type])
+			if err != nil {
+				return nil, err
+			}
+
+			return future.FutureAPI, nil
+		}); err != nil && !isNotFound(err) {
+			err = fmt.Errorf("failed to delete %q: %w", resource.ID, err)
+			a.recordError(cluster, "FailedToDeleteLeakedResource", err)
+			return cluster, err
+		}
+		a.recordEvent(cluster, "DeletedLeakedResource", "Deleted leaked resource %q", resource.ID)
+	}
+
+	return cluster, nil
+}
+
+// leakedResourceOperation returns the name of the deletion of a leaked resource, which is derived
+// from a hash of its ID to fit into an annotation key.
+func leakedResourceOperation(id string) string {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(id))
+	return fmt.Sprintf("delete-leaked-%08x", hash.Sum32())
+}
+
+// leakedResources returns the machine resources of the cluster, sorted in the order in which they
+// have to be deleted.
+func leakedResources(tagged []TaggedResource, clusterName string) []TaggedResource {
+	var leaked []TaggedResource
+	for _, resource := range tagged {
+		if resource.Cluster == clusterName && leakableResourceTypes[resource.Type] {
+			leaked = append(leaked, resource)
+		}
+	}
+
+	sortForDeletion(leaked)
+
+	return leaked
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"testing"
+)
+
+func TestLeakedResources(t *testing.T) {
+	tagged := []TaggedResource{
+		{ID: "pip", Type: "Microsoft.Network/publicIPAddresses", Cluster: "test-cluster"},
+		{ID: "disk", Type: "Microsoft.Compute/disks", Cluster: "test-cluster"},
+		{ID: "nic", Type: "Microsoft.Network/networkInterfaces", Cluster: "test-cluster"},
+		{ID: "nic-other", Type: "Microsoft.Network/networkInterfaces", Cluster: "other-cluster"},
+		{ID: "nsg", Type: "Microsoft.Network/networkSecurityGroups", Cluster: "test-cluster"},
+	}
+
+	leaked := leakedResources(tagged, "test-cluster")
+
+	expected := []string{"nic", "pip", "disk"}
+	if len(leaked) != len(expected) {
+		t.Fatalf("expected %d leaked resources, got %d: %v", len(expected), len(leaked), leaked)
+	}
+	for i, id := range expected {
+		if leaked[i].ID != id {
+			t.Errorf("expected leaked resource %d to be %q, got %q", i, id, leaked[i].ID)
+		}
+	}
+}
+
+func TestLeakedResourceOperation(t *testing.T) {
+	nic := "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/networkInterfaces/machine-with-a-rather-long-name-nic"
+	disk := "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/disks/machine-with-a-rather-long-name-os-disk"
+
+	// the operation name is part of an annotation key, whose name part is limited to 63 characters
+	if key := "operation-" + leakedResourceOperation(nic); len(key) > 63 {
+		t.Errorf("operation name %q is too long", key)
+	}
+	if leakedResourceOperation(nic) == leakedResourceOperation(disk) {
+		t.Error("expected the deletions of different resources to be different operations")
+	}
+}
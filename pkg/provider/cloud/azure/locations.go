@@ -0,0 +1,110 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2018-02-01/resources"
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2018-06-01/subscriptions"
+	"github.com/Azure/go-autorest/autorest/to"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// RequiredResourceTypes are the resource types created for every cluster, by the namespace of
+// their resource provider. A location is only usable for clusters if all of them are available.
+var RequiredResourceTypes = map[string][]string{
+	"Microsoft.Compute": {"virtualMachines", "disks", "availabilitySets"},
+	"Microsoft.Network": {"virtualNetworks", "networkSecurityGroups", "routeTables", "publicIPAddresses", "loadBalancers"},
+}
+
+// RequiredResourceProviders returns the namespaces of the resource providers in RequiredResourceTypes.
+func RequiredResourceProviders() []string {
+	return sets.StringKeySet(RequiredResourceTypes).List()
+}
+
+// requiredResourceTypes returns the required resource types of a resource provider namespace.
+func requiredResourceTypes(namespace string) []string {
+	for ns, types := range RequiredResourceTypes {
+		if strings.EqualFold(ns, namespace) {
+			return types
+		}
+	}
+	return nil
+}
+
+// providerLocations returns the normalized names of the locations in which the resource provider
+// can create all of its required resource types. For providers without required resource types,
+// it is enough for any of its resource types to be available in a location.
+func providerLocations(provider resources.Provider) sets.String {
+	typeLocations := map[string]sets.String{}
+	anyType := sets.NewString()
+	if provider.ResourceTypes != nil {
+		for _, resourceType := range *provider.ResourceTypes {
+			locations := sets.NewString()
+			if resourceType.Locations != nil {
+				for _, location := range *resourceType.Locations {
+					locations.Insert(normalizeLocation(location))
+				}
+			}
+			typeLocations[strings.ToLower(to.String(resourceType.ResourceType))] = locations
+			anyType = anyType.Union(locations)
+		}
+	}
+
+	required := requiredResourceTypes(to.String(provider.Namespace))
+	if len(required) == 0 {
+		return anyType
+	}
+
+	locations := anyType
+	for _, resourceType := range required {
+		locations = locations.Intersection(typeLocations[strings.ToLower(resourceType)])
+	}
+	return locations
+}
+
+// FilterLocations returns the locations of a subscription in which all the given resource providers
+// are available, sorted by name.
+func FilterLocations(locations []subscriptions.Location, providers []resources.Provider) []subscriptions.Location {
+	var providerSets []sets.String
+	for _, provider := range providers {
+		providerSets = append(providerSets, providerLocations(provider))
+	}
+
+	var filtered []subscriptions.Location
+	for _, location := range locations {
+		name := normalizeLocation(to.String(location.Name))
+		available := true
+		for _, supported := range providerSets {
+			if !supported.Has(name) {
+				available = false
+				break
+			}
+		}
+		if available {
+			filtered = append(filtered, location)
+		}
+	}
+
+	sort.Slice(filtered, func(i, j int) bool {
+		return to.String(filtered[i].Name) < to.String(filtered[j].Name)
+	})
+	return filtered
+}
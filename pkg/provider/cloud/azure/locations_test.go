@@ -0,0 +1,114 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2018-02-01/resources"
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2018-06-01/subscriptions"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/go-test/deep"
+)
+
+func genResourceProvider(namespace string, typeLocations map[string][]string) resources.Provider {
+	var resourceTypes []resources.ProviderResourceType
+	for resourceType, locations := range typeLocations {
+		resourceTypes = append(resourceTypes, resources.ProviderResourceType{
+			ResourceType: to.StringPtr(resourceType),
+			Locations:    to.StringSlicePtr(locations),
+		})
+	}
+	return resources.Provider{
+		Namespace:     to.StringPtr(namespace),
+		ResourceTypes: &resourceTypes,
+	}
+}
+
+func genLocations(names ...string) []subscriptions.Location {
+	var locations []subscriptions.Location
+	for _, name := range names {
+		locations = append(locations, subscriptions.Location{Name: to.StringPtr(name)})
+	}
+	return locations
+}
+
+func TestFilterLocations(t *testing.T) {
+	compute := genResourceProvider("Microsoft.Compute", map[string][]string{
+		"virtualMachines":  {"West Europe", "East US", "Germany West Central"},
+		"disks":            {"West Europe", "East US", "Germany West Central"},
+		"availabilitySets": {"West Europe", "East US"},
+		"operations":       {},
+	})
+	network := genResourceProvider("Microsoft.Network", map[string][]string{
+		"virtualNetworks":       {"West Europe", "East US", "Germany West Central"},
+		"networkSecurityGroups": {"West Europe", "East US", "Germany West Central"},
+		"routeTables":           {"West Europe", "East US", "Germany West Central"},
+		"publicIPAddresses":     {"West Europe", "East US", "Germany West Central"},
+		"loadBalancers":         {"West Europe", "East US", "Germany West Central"},
+	})
+	networkWithoutLoadBalancers := genResourceProvider("Microsoft.Network", map[string][]string{
+		"virtualNetworks":       {"West Europe", "East US"},
+		"networkSecurityGroups": {"West Europe", "East US"},
+		"routeTables":           {"West Europe", "East US"},
+		"publicIPAddresses":     {"West Europe", "East US"},
+	})
+	insights := genResourceProvider("Microsoft.OperationalInsights", map[string][]string{
+		"workspaces":  {"West Europe"},
+		"querypacks":  {"Germany West Central"},
+		"deployments": {},
+	})
+
+	testCases := []struct {
+		name      string
+		locations []subscriptions.Location
+		providers []resources.Provider
+		expected  []subscriptions.Location
+	}{
+		{
+			name:      "no required providers",
+			locations: genLocations("westeurope", "brazilsouth", "eastus"),
+			expected:  genLocations("brazilsouth", "eastus", "westeurope"),
+		},
+		{
+			name:      "locations without all required resource types are filtered out",
+			locations: genLocations("westeurope", "brazilsouth", "eastus", "germanywestcentral"),
+			providers: []resources.Provider{compute, network},
+			expected:  genLocations("eastus", "westeurope"),
+		},
+		{
+			name:      "required resource types missing in a provider",
+			locations: genLocations("westeurope", "eastus"),
+			providers: []resources.Provider{compute, networkWithoutLoadBalancers},
+		},
+		{
+			name:      "any resource type of other providers",
+			locations: genLocations("westeurope", "eastus", "germanywestcentral"),
+			providers: []resources.Provider{network, insights},
+			expected:  genLocations("germanywestcentral", "westeurope"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			filtered := FilterLocations(tc.locations, tc.providers)
+			if diff := deep.Equal(filtered, tc.expected); diff != nil {
+				t.Errorf("unexpected locations: %v", diff)
+			}
+		})
+	}
+}
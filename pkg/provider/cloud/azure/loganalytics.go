@@ -0,0 +1,284 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/operationalinsights/mgmt/2020-08-01/operationalinsights"
+	autorestazure "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/to"
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/provider"
+)
+
+const (
+	// FinalizerLogAnalyticsWorkspace will instruct the deletion of the Log Analytics workspace
+	FinalizerLogAnalyticsWorkspace = "kubermatic.io/cleanup-azure-log-analytics-workspace"
+
+	// defaultLogAnalyticsRetentionInDays is the data retention of the workspaces created by Kubermatic.
+	defaultLogAnalyticsRetentionInDays = 30
+	minLogAnalyticsRetentionInDays     = 30
+	maxLogAnalyticsRetentionInDays     = 730
+)
+
+// LogAnalyticsWorkspace is what the monitoring agent on the nodes needs to send data to a workspace.
+type LogAnalyticsWorkspace struct {
+	// CustomerID is the ID the agent identifies the workspace with, not the resource ID.
+	CustomerID string
+	SharedKey  string
+}
+
+func logAnalyticsWorkspaceName(cluster *kubermaticv1.Cluster) string {
+	return resourceNamePrefix + cluster.Name
+}
+
+// parseLogAnalyticsWorkspaceID returns the subscription, resource group and name of a Log Analytics workspace.
+func parseLogAnalyticsWorkspaceID(id string) (autorestazure.Resource, error) {
+	workspace, err := autorestazure.ParseResourceID(id)
+	if err != nil {
+		return workspace, fmt.Errorf("invalid Log Analytics workspace ID %q: %w", id, err)
+	}
+	if !strings.EqualFold(workspace.Provider, "Microsoft.OperationalInsights") || !strings.EqualFold(workspace.ResourceType, "workspaces") {
+		return workspace, fmt.Errorf("%q is not the ID of a Log Analytics workspace", id)
+	}
+
+	return workspace, nil
+}
+
+func validateLogAnalytics(logAnalytics *kubermaticv1.AzureLogAnalytics) error {
+	if logAnalytics == nil {
+		return nil
+	}
+	if logAnalytics.WorkspaceID != "" {
+		if _, err := parseLogAnalyticsWorkspaceID(logAnalytics.WorkspaceID); err != nil {
+			return err
+		}
+		if logAnalytics.RetentionInDays != 0 {
+			return errors.New("the retention can only be configured for the Log Analytics workspace created by Kubermatic")
+		}
+	}
+	if days := logAnalytics.RetentionInDays; days != 0 && (days < minLogAnalyticsRetentionInDays || days > maxLogAnalyticsRetentionInDays) {
+		return fmt.Errorf("the retention of the Log Analytics workspace must be between %d and %d days", minLogAnalyticsRetentionInDays, maxLogAnalyticsRetentionInDays)
+	}
+
+	return nil
+}
+
+// reconcileLogAnalytics ensures the Log Analytics workspace of the cluster exists and records its ID
+// in the cluster status. Workspaces created by Kubermatic are removed again once the integration is
+// disabled or an existing workspace is configured.
+func (a *Azure) reconcileLogAnalytics(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, credentials Credentials, location string, tags map[string]*string) (*kubermaticv1.Cluster, error) {
+	var err error
+	logger := a.log.With("cluster", cluster.Name)
+
+	logAnalytics := cluster.Spec.Cloud.Azure.LogAnalytics
+	if logAnalytics == nil || logAnalytics.WorkspaceID != "" {
+		if cluster, err = a.cleanUpLogAnalytics(cluster, update, credentials, logger); err != nil {
+			return cluster, err
+		}
+	}
+	if logAnalytics == nil {
+		return a.setLogAnalyticsWorkspaceID(cluster, update, "")
+	}
+	if logAnalytics.WorkspaceID != "" {
+		return a.setLogAnalyticsWorkspaceID(cluster, update, logAnalytics.WorkspaceID)
+	}
+
+	name := logAnalyticsWorkspaceName(cluster)
+	logger.Infow("ensuring Log Analytics workspace", "workspace", name)
+	if err = a.pollOperation(cluster, update, credentials, "create-log-analytics-workspace", func() (autorestazure.FutureAPI, error) {
+		return ensureLogAnalyticsWorkspace(a.ctx, cluster.Spec.Cloud, name, location, tags, credentials)
+	}); err != nil {
+		a.recordError(cluster, "FailedToEnsureLogAnalyticsWorkspace", err)
+		return cluster, fmt.Errorf("failed to create Log Analytics workspace %q: %w", name, err)
+	}
+
+	if !kuberneteshelper.HasFinalizer(cluster, FinalizerLogAnalyticsWorkspace) {
+		a.recordEvent(cluster, "EnsuredLogAnalyticsWorkspace", "Ensured Log Analytics workspace %q", name)
+		cluster, err = update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+			kuberneteshelper.AddFinalizer(updatedCluster, FinalizerLogAnalyticsWorkspace)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	workspacesClient, err := getWorkspacesClient(credentials.SubscriptionID, credentials)
+	if err != nil {
+		return cluster, err
+	}
+	workspace, err := workspacesClient.Get(a.ctx, cluster.Spec.Cloud.Azure.ResourceGroup, name)
+	if err != nil {
+		return cluster, fmt.Errorf("failed to get Log Analytics workspace %q: %w", name, err)
+	}
+
+	return a.setLogAnalyticsWorkspaceID(cluster, update, to.String(workspace.ID))
+}
+
+// setLogAnalyticsWorkspaceID records the ID of the Log Analytics workspace in the cluster status.
+func (a *Azure) setLogAnalyticsWorkspaceID(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, id string) (*kubermaticv1.Cluster, error) {
+	current := ""
+	if cluster.Status.Azure != nil {
+		current = cluster.Status.Azure.LogAnalyticsWorkspaceID
+	}
+	if current == id {
+		return cluster, nil
+	}
+
+	return update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+		if updatedCluster.Status.Azure == nil {
+			updatedCluster.Status.Azure = &kubermaticv1.AzureClusterStatus{}
+		}
+		updatedCluster.Status.Azure.LogAnalyticsWorkspaceID = id
+	})
+}
+
+// GetLogAnalyticsWorkspace returns the customer ID and the primary shared key of the Log Analytics
+// workspace of the cluster, or nil if it is not available yet.
+func (a *Azure) GetLogAnalyticsWorkspace(cluster *kubermaticv1.Cluster) (*LogAnalyticsWorkspace, error) {
+	if cluster.Status.Azure == nil || cluster.Status.Azure.LogAnalyticsWorkspaceID == "" {
+		return nil, nil
+	}
+
+	credentials, err := GetCredentialsForCluster(cluster.Spec.Cloud, a.secretKeySelector)
+	if err != nil {
+		return nil, err
+	}
+
+	resource, err := parseLogAnalyticsWorkspaceID(cluster.Status.Azure.LogAnalyticsWorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	workspacesClient, err := getWorkspacesClient(resource.SubscriptionID, credentials)
+	if err != nil {
+		return nil, err
+	}
+	workspace, err := workspacesClient.Get(a.ctx, resource.ResourceGroup, resource.ResourceName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Log Analytics workspace %q: %w", resource.ResourceName, err)
+	}
+	if workspace.WorkspaceProperties == nil || to.String(workspace.CustomerID) == "" {
+		return nil, nil
+	}
+
+	sharedKeysClient, err := getSharedKeysClient(resource.SubscriptionID, credentials)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := sharedKeysClient.GetSharedKeys(a.ctx, resource.ResourceGroup, resource.ResourceName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the shared keys of Log Analytics workspace %q: %w", resource.ResourceName, err)
+	}
+
+	return &LogAnalyticsWorkspace{
+		CustomerID: to.String(workspace.CustomerID),
+		SharedKey:  to.String(keys.PrimarySharedKey),
+	}, nil
+}
+
+// cleanUpLogAnalytics deletes the Log Analytics workspace Kubermatic created for the cluster. The
+// workspace is deleted permanently, so that a new cluster with the same name does not recover it.
+// Workspaces specified by the user are never deleted.
+func (a *Azure) cleanUpLogAnalytics(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, credentials Credentials, logger *zap.SugaredLogger) (*kubermaticv1.Cluster, error) {
+	if !kuberneteshelper.HasFinalizer(cluster, FinalizerLogAnalyticsWorkspace) {
+		return cluster, nil
+	}
+
+	name := logAnalyticsWorkspaceName(cluster)
+
+	workspacesClient, err := getWorkspacesClient(credentials.SubscriptionID, credentials)
+	if err != nil {
+		return cluster, err
+	}
+
+	logger.Infow("deleting Log Analytics workspace", "workspace", name)
+	if _, err = workspacesClient.Delete(a.ctx, cluster.Spec.Cloud.Azure.ResourceGroup, name, to.BoolPtr(true)); err != nil && !isNotFound(err) {
+		return cluster, fmt.Errorf("failed to delete Log Analytics workspace %q: %w", name, err)
+	}
+
+	return update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+		kuberneteshelper.RemoveFinalizer(updatedCluster, FinalizerLogAnalyticsWorkspace)
+	})
+}
+
+// ensureLogAnalyticsWorkspace will start creating the Log Analytics workspace of the cluster, if it
+// does not exist yet.
+func ensureLogAnalyticsWorkspace(ctx context.Context, cloud kubermaticv1.CloudSpec, name, location string, tags map[string]*string, credentials Credentials) (autorestazure.FutureAPI, error) {
+	workspacesClient, err := getWorkspacesClient(credentials.SubscriptionID, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = workspacesClient.Get(ctx, cloud.Azure.ResourceGroup, name)
+	if err == nil {
+		return nil, nil
+	}
+	if !isNotFound(err) {
+		return nil, err
+	}
+
+	retention := cloud.Azure.LogAnalytics.RetentionInDays
+	if retention == 0 {
+		retention = defaultLogAnalyticsRetentionInDays
+	}
+
+	parameters := operationalinsights.Workspace{
+		Location: to.StringPtr(location),
+		Tags:     tags,
+		WorkspaceProperties: &operationalinsights.WorkspaceProperties{
+			Sku:             &operationalinsights.WorkspaceSku{Name: operationalinsights.WorkspaceSkuNameEnumPerGB2018},
+			RetentionInDays: to.Int32Ptr(retention),
+		},
+	}
+
+	future, err := workspacesClient.CreateOrUpdate(ctx, cloud.Azure.ResourceGroup, name, parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	return future.FutureAPI, nil
+}
+
+func getWorkspacesClient(subscriptionID string, credentials Credentials) (*operationalinsights.WorkspacesClient, error) {
+	var err error
+	workspacesClient := operationalinsights.NewWorkspacesClient(subscriptionID)
+	err = configureClient(&workspacesClient.Client, credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authorizer: %s", err.Error())
+	}
+
+	return &workspacesClient, nil
+}
+
+func getSharedKeysClient(subscriptionID string, credentials Credentials) (*operationalinsights.SharedKeysClient, error) {
+	var err error
+	sharedKeysClient := operationalinsights.NewSharedKeysClient(subscriptionID)
+	err = configureClient(&sharedKeysClient.Client, credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authorizer: %s", err.Error())
+	}
+
+	return &sharedKeysClient, nil
+}
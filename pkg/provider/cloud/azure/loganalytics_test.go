@@ -0,0 +1,129 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+)
+
+func TestParseLogAnalyticsWorkspaceID(t *testing.T) {
+	tests := []struct {
+		name                 string
+		id                   string
+		expectedSubscription string
+		expectedGroup        string
+		expectedName         string
+		expectedError        bool
+	}{
+		{
+			name:                 "workspace",
+			id:                   "/subscriptions/sub/resourceGroups/monitoring/providers/Microsoft.OperationalInsights/workspaces/logs",
+			expectedSubscription: "sub",
+			expectedGroup:        "monitoring",
+			expectedName:         "logs",
+		},
+		{
+			name:                 "lower case provider",
+			id:                   "/subscriptions/sub/resourcegroups/monitoring/providers/microsoft.operationalinsights/workspaces/logs",
+			expectedSubscription: "sub",
+			expectedGroup:        "monitoring",
+			expectedName:         "logs",
+		},
+		{
+			name:          "other resource type",
+			id:            "/subscriptions/sub/resourceGroups/monitoring/providers/Microsoft.Storage/storageAccounts/logs",
+			expectedError: true,
+		},
+		{
+			name:          "invalid ID",
+			id:            "logs",
+			expectedError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			workspace, err := parseLogAnalyticsWorkspaceID(test.id)
+			if (err != nil) != test.expectedError {
+				t.Fatalf("expected error to be %v, got %v", test.expectedError, err)
+			}
+			if test.expectedError {
+				return
+			}
+			if workspace.SubscriptionID != test.expectedSubscription || workspace.ResourceGroup != test.expectedGroup || workspace.ResourceName != test.expectedName {
+				t.Errorf("expected %s/%s/%s, got %s/%s/%s", test.expectedSubscription, test.expectedGroup, test.expectedName, workspace.SubscriptionID, workspace.ResourceGroup, workspace.ResourceName)
+			}
+		})
+	}
+}
+
+func TestValidateLogAnalytics(t *testing.T) {
+	workspaceID := "/subscriptions/sub/resourceGroups/monitoring/providers/Microsoft.OperationalInsights/workspaces/logs"
+
+	tests := []struct {
+		name          string
+		logAnalytics  *kubermaticv1.AzureLogAnalytics
+		expectedError bool
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name:         "created workspace with default retention",
+			logAnalytics: &kubermaticv1.AzureLogAnalytics{},
+		},
+		{
+			name:         "created workspace with retention",
+			logAnalytics: &kubermaticv1.AzureLogAnalytics{RetentionInDays: 90},
+		},
+		{
+			name:          "retention too short",
+			logAnalytics:  &kubermaticv1.AzureLogAnalytics{RetentionInDays: 7},
+			expectedError: true,
+		},
+		{
+			name:          "retention too long",
+			logAnalytics:  &kubermaticv1.AzureLogAnalytics{RetentionInDays: 731},
+			expectedError: true,
+		},
+		{
+			name:         "existing workspace",
+			logAnalytics: &kubermaticv1.AzureLogAnalytics{WorkspaceID: workspaceID},
+		},
+		{
+			name:          "existing workspace with retention",
+			logAnalytics:  &kubermaticv1.AzureLogAnalytics{WorkspaceID: workspaceID, RetentionInDays: 90},
+			expectedError: true,
+		},
+		{
+			name:          "invalid workspace ID",
+			logAnalytics:  &kubermaticv1.AzureLogAnalytics{WorkspaceID: "logs"},
+			expectedError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateLogAnalytics(test.logAnalytics)
+			if (err != nil) != test.expectedError {
+				t.Errorf("expected error to be %v, got %v", test.expectedError, err)
+			}
+		})
+	}
+}
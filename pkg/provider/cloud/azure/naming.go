@@ -0,0 +1,152 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+)
+
+// Resource types of the naming template, using the abbreviations recommended by the Azure Cloud
+// Adoption Framework, so that templates like "{{ .ResourceType }}-{{ .ClusterName }}" produce
+// names which follow the usual conventions.
+const (
+	resourceTypeResourceGroup   = "rg"
+	resourceTypeVNet            = "vnet"
+	resourceTypeSubnet          = "snet"
+	resourceTypeSecurityGroup   = "nsg"
+	resourceTypeRouteTable      = "rt"
+	resourceTypeAvailabilitySet = "avail"
+)
+
+// namingTemplateData are the variables available in the naming template of a datacenter.
+type namingTemplateData struct {
+	ClusterName  string
+	ProjectID    string
+	Datacenter   string
+	ResourceType string
+}
+
+// namingRule describes the names Azure accepts for a resource type, see
+// https://docs.microsoft.com/en-us/azure/azure-resource-manager/management/resource-name-rules
+type namingRule struct {
+	kind      string
+	maxLength int
+	minLength int
+	pattern   *regexp.Regexp
+}
+
+var (
+	// resource group names may contain parentheses and must not end with a period
+	resourceGroupNamePattern = regexp.MustCompile(`^[-\w.()]*[-\w()]$`)
+	// network and compute resource names must start with an alphanumeric character and end with
+	// an alphanumeric character or an underscore
+	networkResourceNamePattern = regexp.MustCompile(`^[a-zA-Z0-9]([-\w.]*\w)?$`)
+
+	namingRules = map[string]namingRule{
+		resourceTypeResourceGroup:   {kind: "resource group", minLength: 1, maxLength: 90, pattern: resourceGroupNamePattern},
+		resourceTypeVNet:            {kind: "virtual network", minLength: 2, maxLength: 64, pattern: networkResourceNamePattern},
+		resourceTypeSubnet:          {kind: "subnet", minLength: 1, maxLength: 80, pattern: networkResourceNamePattern},
+		resourceTypeSecurityGroup:   {kind: "security group", minLength: 1, maxLength: 80, pattern: networkResourceNamePattern},
+		resourceTypeRouteTable:      {kind: "route table", minLength: 1, maxLength: 80, pattern: networkResourceNamePattern},
+		resourceTypeAvailabilitySet: {kind: "availability set", minLength: 1, maxLength: 80, pattern: networkResourceNamePattern},
+	}
+)
+
+// resourceName returns the name of a resource of the given type created for the cluster. It is
+// rendered from the naming template of the datacenter, without one the name is "kubernetes-"
+// followed by the name of the cluster.
+func (a *Azure) resourceName(cluster *kubermaticv1.Cluster, resourceType string) (string, error) {
+	if a.dc.NamingTemplate == "" {
+		return resourceNamePrefix + cluster.Name, nil
+	}
+
+	name, err := renderResourceName(a.dc.NamingTemplate, namingTemplateData{
+		ClusterName:  cluster.Name,
+		ProjectID:    cluster.Labels[kubermaticv1.ProjectIDLabelKey],
+		Datacenter:   cluster.Spec.Cloud.DatacenterName,
+		ResourceType: resourceType,
+	})
+	if err != nil {
+		return "", err
+	}
+	if err := validateResourceName(resourceType, name); err != nil {
+		return "", err
+	}
+
+	return name, nil
+}
+
+// ValidateNamingTemplate checks that the naming template of a datacenter can be rendered and
+// results in valid names for all resource types, using a cluster and a project with names of
+// the length generated by Kubermatic.
+func ValidateNamingTemplate(namingTemplate, datacenter string) error {
+	if namingTemplate == "" {
+		return nil
+	}
+
+	for _, resourceType := range []string{resourceTypeResourceGroup, resourceTypeVNet, resourceTypeSubnet, resourceTypeSecurityGroup, resourceTypeRouteTable, resourceTypeAvailabilitySet} {
+		name, err := renderResourceName(namingTemplate, namingTemplateData{
+			ClusterName:  "abcdefghij",
+			ProjectID:    "abcdefghij",
+			Datacenter:   datacenter,
+			ResourceType: resourceType,
+		})
+		if err != nil {
+			return err
+		}
+		if err := validateResourceName(resourceType, name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func renderResourceName(namingTemplate string, data namingTemplateData) (string, error) {
+	tmpl, err := template.New("name").Option("missingkey=error").Parse(namingTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse naming template: %w", err)
+	}
+
+	var name strings.Builder
+	if err := tmpl.Execute(&name, data); err != nil {
+		return "", fmt.Errorf("failed to render naming template: %w", err)
+	}
+
+	return name.String(), nil
+}
+
+func validateResourceName(resourceType, name string) error {
+	rule, ok := namingRules[resourceType]
+	if !ok {
+		return fmt.Errorf("unknown resource type %q", resourceType)
+	}
+
+	if len(name) < rule.minLength || len(name) > rule.maxLength {
+		return fmt.Errorf("%s name %q must be between %d and %d characters long", rule.kind, name, rule.minLength, rule.maxLength)
+	}
+	if !rule.pattern.MatchString(name) {
+		return fmt.Errorf("%s name %q contains invalid characters or starts or ends with an invalid character", rule.kind, name)
+	}
+
+	return nil
+}
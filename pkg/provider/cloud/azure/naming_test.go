@@ -0,0 +1,141 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"strings"
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestResourceName(t *testing.T) {
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "abcd12345x",
+			Labels: map[string]string{kubermaticv1.ProjectIDLabelKey: "proj123456"},
+		},
+		Spec: kubermaticv1.ClusterSpec{
+			Cloud: kubermaticv1.CloudSpec{DatacenterName: "azure-westeurope", Azure: &kubermaticv1.AzureCloudSpec{}},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		namingTemplate string
+		resourceType   string
+		expected       string
+		expectedErr    bool
+	}{
+		{
+			name:         "default name",
+			resourceType: resourceTypeVNet,
+			expected:     "kubernetes-abcd12345x",
+		},
+		{
+			name:           "all variables",
+			namingTemplate: "{{ .ResourceType }}-{{ .ProjectID }}-{{ .Datacenter }}-{{ .ClusterName }}",
+			resourceType:   resourceTypeSecurityGroup,
+			expected:       "nsg-proj123456-azure-westeurope-abcd12345x",
+		},
+		{
+			name:           "resource group names may end with a parenthesis",
+			namingTemplate: "{{ .ResourceType }}-{{ .ClusterName }}-(prod)",
+			resourceType:   resourceTypeResourceGroup,
+			expected:       "rg-abcd12345x-(prod)",
+		},
+		{
+			name:           "parentheses are only valid in resource group names",
+			namingTemplate: "{{ .ResourceType }}-{{ .ClusterName }}-(prod)",
+			resourceType:   resourceTypeSubnet,
+			expectedErr:    true,
+		},
+		{
+			name:           "names must not end with a hyphen",
+			namingTemplate: "{{ .ClusterName }}-",
+			resourceType:   resourceTypeRouteTable,
+			expectedErr:    true,
+		},
+		{
+			name:           "names must not be too long",
+			namingTemplate: strings.Repeat("a", 60) + "-{{ .ClusterName }}",
+			resourceType:   resourceTypeVNet,
+			expectedErr:    true,
+		},
+		{
+			name:           "unknown variable",
+			namingTemplate: "{{ .Cluster }}",
+			resourceType:   resourceTypeAvailabilitySet,
+			expectedErr:    true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := &Azure{dc: &kubermaticv1.DatacenterSpecAzure{NamingTemplate: test.namingTemplate}}
+
+			name, err := a.resourceName(cluster, test.resourceType)
+			if (err != nil) != test.expectedErr {
+				t.Fatalf("expected error: %v, got %v", test.expectedErr, err)
+			}
+			if name != test.expected {
+				t.Errorf("expected name %q, got %q", test.expected, name)
+			}
+		})
+	}
+}
+
+func TestValidateNamingTemplate(t *testing.T) {
+	tests := []struct {
+		name           string
+		namingTemplate string
+		expectedErr    bool
+	}{
+		{
+			name: "no template",
+		},
+		{
+			name:           "valid template",
+			namingTemplate: "{{ .ResourceType }}-{{ .ProjectID }}-{{ .Datacenter }}-{{ .ClusterName }}",
+		},
+		{
+			name:           "invalid syntax",
+			namingTemplate: "{{ .ClusterName",
+			expectedErr:    true,
+		},
+		{
+			name:           "invalid character",
+			namingTemplate: "{{ .ClusterName }}/{{ .ResourceType }}",
+			expectedErr:    true,
+		},
+		{
+			name:           "too long for a VNet",
+			namingTemplate: strings.Repeat("a", 55) + "-{{ .ClusterName }}",
+			expectedErr:    true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := ValidateNamingTemplate(test.namingTemplate, "azure-westeurope"); (err != nil) != test.expectedErr {
+				t.Fatalf("expected error: %v, got %v", test.expectedErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest"
+	autorestazure "github.com/Azure/go-autorest/autorest/azure"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/provider"
+)
+
+// operationAnnotationPrefix is the prefix of the cluster annotations used to persist
+// long-running Azure operations. The annotation value is the serialized future, which
+// contains the polling URLs, so that the next reconciliation, also of a restarted controller,
+// polls the operation instead of starting it again.
+const operationAnnotationPrefix = "azure.kubermatic.io/operation-"
+
+func operationAnnotation(operation string) string {
+	return operationAnnotationPrefix + operation
+}
+
+// hasTerminated returns true if the given future status indicates that the operation
+// is not running anymore, regardless of whether it succeeded.
+func hasTerminated(status string) bool {
+	for _, s := range []string{"Succeeded", "Failed", "Canceled"} {
+		if strings.EqualFold(status, s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// pollOperation drives the long-running operation identified by the given name without blocking
+// the reconciliation. If the cluster carries a persisted future for the operation, its status is
+// polled once, otherwise the operation is started. Operations which did not finish right away are
+// persisted and ErrOperationInProgress is returned, so that the cluster is reconciled again later.
+// The annotation is removed once the operation has terminated. The start function may return a nil
+// future if there is nothing to do.
+func (a *Azure) pollOperation(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, credentials Credentials, operation string, start func() (autorestazure.FutureAPI, error)) error {
+	key := operationAnnotation(operation)
+
+	client, err := getOperationsClient(credentials)
+	if err != nil {
+		return err
+	}
+
+	var future autorestazure.FutureAPI
+	if data, ok := cluster.Annotations[key]; ok {
+		resumed := &autorestazure.Future{}
+		if err := resumed.UnmarshalJSON([]byte(data)); err != nil {
+			a.log.Warnw("discarding invalid persisted operation", "cluster", cluster.Name, "operation", operation, "error", err)
+		} else {
+			a.log.Debugw("resuming operation", "cluster", cluster.Name, "operation", operation, "url", resumed.PollingURL())
+			future = resumed
+		}
+	}
+
+	if future == nil {
+		started, err := start()
+		if err != nil {
+			return err
+		}
+		// nothing to wait for
+		if started == nil {
+			return nil
+		}
+
+		// operations which finished right away are not persisted
+		done, err := started.DoneWithContext(a.ctx, client)
+		if done {
+			return err
+		}
+
+		data, marshalErr := started.MarshalJSON()
+		if marshalErr != nil {
+			return fmt.Errorf("failed to serialize operation: %w", marshalErr)
+		}
+		if updateErr := setClusterAnnotation(cluster, update, key, string(data)); updateErr != nil {
+			return fmt.Errorf("failed to persist operation: %w", updateErr)
+		}
+
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("operation %q: %w", operation, ErrOperationInProgress)
+	}
+
+	// errors while polling are returned with the operation kept, so polling is retried with the
+	// next reconciliation
+	done, err := future.DoneWithContext(a.ctx, client)
+	if !done {
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("operation %q: %w", operation, ErrOperationInProgress)
+	}
+
+	if updateErr := setClusterAnnotation(cluster, update, key, ""); updateErr != nil {
+		return fmt.Errorf("failed to remove finished operation: %w", updateErr)
+	}
+
+	return err
+}
+
+// cleanupOperations removes the persisted operations which are not running anymore,
+// for example because the controller was restarted after the operation had finished
+// but before the annotation was removed.
+func (a *Azure) cleanupOperations(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, credentials Credentials) error {
+	var client *autorest.Client
+
+	for key, data := range cluster.Annotations {
+		if !strings.HasPrefix(key, operationAnnotationPrefix) {
+			continue
+		}
+
+		future := &autorestazure.Future{}
+		if err := future.UnmarshalJSON([]byte(data)); err == nil && !hasTerminated(future.Status()) {
+			if client == nil {
+				c, err := getOperationsClient(credentials)
+				if err != nil {
+					return err
+				}
+				client = &c
+			}
+
+			// errors are ignored on purpose, the operation is only garbage-collected once
+			// Azure reports that it has terminated
+			if done, _ := future.DoneWithContext(a.ctx, client); !done {
+				continue
+			}
+		}
+
+		if err := setClusterAnnotation(cluster, update, key, ""); err != nil {
+			return fmt.Errorf("failed to remove finished operation %q: %w", strings.TrimPrefix(key, operationAnnotationPrefix), err)
+		}
+	}
+
+	return nil
+}
+
+// setClusterAnnotation sets the annotation both on the cluster object and in the API.
+// An empty value removes the annotation.
+func setClusterAnnotation(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, key, value string) error {
+	modify := func(c *kubermaticv1.Cluster) {
+		if value == "" {
+			delete(c.Annotations, key)
+			return
+		}
+		if c.Annotations == nil {
+			c.Annotations = map[string]string{}
+		}
+		c.Annotations[key] = value
+	}
+
+	// the cluster object itself is not replaced, as callers might have local modifications
+	// on it which were not yet persisted
+	if _, err := update(cluster.Name, modify); err != nil {
+		return err
+	}
+	modify(cluster)
+
+	return nil
+}
+
+func getOperationsClient(credentials Credentials) (autorest.Client, error) {
+	var err error
+	client := autorest.NewClientWithUserAgent("")
+	err = configureClient(&client, credentials)
+	if err != nil {
+		return client, fmt.Errorf("failed to create authorizer: %s", err.Error())
+	}
+
+	return client, nil
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	autorestazure "github.com/Azure/go-autorest/autorest/azure"
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/provider"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const finishedOperation = `{"method":"DELETE","pollingMethod":"Location","pollingURI":"https://management.azure.com/operations/1","lroState":"Succeeded","resultURI":""}`
+
+var testCredentials = Credentials{
+	TenantID:       "tenant",
+	SubscriptionID: "subscription",
+	ClientID:       "client",
+	ClientSecret:   "secret",
+}
+
+func fakeClusterUpdater(cluster *kubermaticv1.Cluster) provider.ClusterUpdater {
+	return func(_ string, modify func(*kubermaticv1.Cluster), _ ...provider.UpdaterOption) (*kubermaticv1.Cluster, error) {
+		modify(cluster)
+		return cluster.DeepCopy(), nil
+	}
+}
+
+func TestPollOperationResumesPersistedOperation(t *testing.T) {
+	a := &Azure{ctx: context.Background(), log: zap.NewNop().Sugar()}
+	key := operationAnnotation("delete-vnet")
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-cluster",
+			Annotations: map[string]string{key: finishedOperation},
+		},
+	}
+	stored := cluster.DeepCopy()
+
+	err := a.pollOperation(cluster, fakeClusterUpdater(stored), testCredentials, "delete-vnet", func() (autorestazure.FutureAPI, error) {
+		return nil, errors.New("operation must not be started again")
+	})
+	if err != nil {
+		t.Fatalf("failed to poll operation: %v", err)
+	}
+
+	if _, ok := cluster.Annotations[key]; ok {
+		t.Error("expected operation to be removed from the cluster object")
+	}
+	if _, ok := stored.Annotations[key]; ok {
+		t.Error("expected operation to be removed from the stored cluster")
+	}
+}
+
+func TestPollOperationDoesNotPersistFinishedOperation(t *testing.T) {
+	a := &Azure{ctx: context.Background(), log: zap.NewNop().Sugar()}
+	cluster := &kubermaticv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"}}
+	stored := cluster.DeepCopy()
+
+	err := a.pollOperation(cluster, fakeClusterUpdater(stored), testCredentials, "delete-vnet", func() (autorestazure.FutureAPI, error) {
+		future := &autorestazure.Future{}
+		return future, future.UnmarshalJSON([]byte(finishedOperation))
+	})
+	if err != nil {
+		t.Fatalf("failed to poll operation: %v", err)
+	}
+
+	if len(cluster.Annotations) != 0 || len(stored.Annotations) != 0 {
+		t.Errorf("expected no operation to be persisted, got %v", stored.Annotations)
+	}
+}
+
+func TestCleanupOperations(t *testing.T) {
+	a := &Azure{ctx: context.Background(), log: zap.NewNop().Sugar()}
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-cluster",
+			Annotations: map[string]string{
+				operationAnnotation("delete-vnet"):   finishedOperation,
+				operationAnnotation("delete-subnet"): "not-json",
+				"unrelated":                          "value",
+			},
+		},
+	}
+	stored := cluster.DeepCopy()
+
+	if err := a.cleanupOperations(cluster, fakeClusterUpdater(stored), testCredentials); err != nil {
+		t.Fatalf("failed to clean up operations: %v", err)
+	}
+
+	expected := map[string]string{"unrelated": "value"}
+	for _, c := range []*kubermaticv1.Cluster{cluster, stored} {
+		if len(c.Annotations) != len(expected) || c.Annotations["unrelated"] != "value" {
+			t.Errorf("expected annotations %v, got %v", expected, c.Annotations)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2018-02-01/resources"
+	"github.com/Azure/go-autorest/autorest/to"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	resourceGroupType = "Microsoft.Resources/resourceGroups"
+
+	// orphanGracePeriod is the minimum age of an orphaned resource, so that the resources of a
+	// cluster which was created after the clusters were listed are not considered orphaned.
+	orphanGracePeriod = time.Hour
+)
+
+// deletionAPIVersions are the API versions used to delete the types of resources which are created
+// for a cluster, either by Kubermatic or by the machine-controller. Orphaned resources of other
+// types, including resource groups, are only reported, never deleted.
+var deletionAPIVersions = map[string]string{
+	"Microsoft.Compute/virtualMachines":           "2018-06-01",
+	"Microsoft.Compute/disks":                     "2018-06-01",
+	"Microsoft.Compute/availabilitySets":          "2018-06-01",
+	"Microsoft.Compute/proximityPlacementGroups":  "2018-06-01",
+	"Microsoft.Network/networkInterfaces":         "2020-08-01",
+	"Microsoft.Network/publicIPAddresses":         "2020-08-01",
+	"Microsoft.Network/networkSecurityGroups":     "2020-08-01",
+	"Microsoft.Network/applicationSecurityGroups": "2020-08-01",
+	"Microsoft.Network/routeTables":               "2020-08-01",
+	"Microsoft.Network/virtualNetworks":           "2020-08-01",
+	"Microsoft.Network/privateEndpoints":          "2020-08-01",
+}
+
+// deletionOrder makes sure resources are deleted before the resources they depend on, e.g. virtual
+// machines before their network interfaces and disks. Resource groups are reported last, as they
+// can only be removed once everything inside of them is gone.
+var deletionOrder = map[string]int{
+	"Microsoft.Compute/virtualMachines":   0,
+	"Microsoft.Network/privateEndpoints":  1,
+	"Microsoft.Network/networkInterfaces": 2,
+	"Microsoft.Compute/disks":             3,
+	"Microsoft.Network/publicIPAddresses": 3,
+	"Microsoft.Network/virtualNetworks":   5,
+	resourceGroupType:                     6,
+}
+
+// TaggedResource is a resource or resource group which is tagged as belonging to a cluster.
+type TaggedResource struct {
+	ID      string
+	Type    string
+	Cluster string
+	// Installation is the ID of the Kubermatic installation which created the resource.
+	Installation string
+	// Managed is set if the resource carries the managed tags marker, which is only set by Kubermatic.
+	Managed bool
+	// Created is the creation time of the resource, it is unknown for resource groups.
+	Created time.Time
+}
+
+// Deletable returns whether orphaned resources of this type can be deleted.
+func (r TaggedResource) Deletable() bool {
+	_, ok := deletionAPIVersions[r.Type]
+	return ok
+}
+
+func newTaggedResource(id, resourceType *string, tags map[string]*string) TaggedResource {
+	_, managed := tags[managedTagsKey]
+	return TaggedResource{
+		ID:           to.String(id),
+		Type:         to.String(resourceType),
+		Cluster:      to.String(tags[clusterTagKey]),
+		Installation: to.String(tags[installationTagKey]),
+		Managed:      managed,
+	}
+}
+
+func getResourcesClient(credentials Credentials) (*resources.Client, error) {
+	var err error
+	resourcesClient := resources.NewClient(credentials.SubscriptionID)
+	err = configureClient(&resourcesClient.Client, credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authorizer: %s", err.Error())
+	}
+
+	return &resourcesClient, nil
+}
+
+// ListClusterResources returns all resources and resource groups in the subscription of the credentials
+// which carry the cluster tag.
+func ListClusterResources(ctx context.Context, credentials Credentials) ([]TaggedResource, error) {
+	filter := fmt.Sprintf("tagName eq '%s'", clusterTagKey)
+	var tagged []TaggedResource
+
+	groupsClient, err := getGroupsClient(kubermaticv1.CloudSpec{}, credentials)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := groupsClient.ListComplete(ctx, filter, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list resource groups: %w", err)
+	}
+	for ; groups.NotDone(); err = groups.NextWithContext(ctx) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to list resource groups: %w", err)
+		}
+		group := groups.Value()
+		tagged = append(tagged, newTaggedResource(group.ID, to.StringPtr(resourceGroupType), group.Tags))
+	}
+
+	resourcesClient, err := getResourcesClient(credentials)
+	if err != nil {
+		return nil, err
+	}
+	list, err := resourcesClient.ListComplete(ctx, filter, "createdTime", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list resources: %w", err)
+	}
+	for ; list.NotDone(); err = list.NextWithContext(ctx) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to list resources: %w", err)
+		}
+		resource := list.Value()
+		taggedResource := newTaggedResource(resource.ID, resource.Type, resource.Tags)
+		if resource.CreatedTime != nil {
+			taggedResource.Created = resource.CreatedTime.ToTime()
+		}
+		tagged = append(tagged, taggedResource)
+	}
+
+	return tagged, nil
+}
+
+// FindOrphanedResources returns the resources of the given installation whose cluster does not exist
+// anymore, sorted in the order in which they have to be deleted. Only resources which carry the
+// managed tags marker are considered, as the cluster tag alone is also used by other tools, and
+// resources younger than the grace period are skipped.
+func FindOrphanedResources(tagged []TaggedResource, clusters sets.String, installationID string, now time.Time) []TaggedResource {
+	if installationID == "" {
+		return nil
+	}
+
+	var orphaned []TaggedResource
+	for _, resource := range tagged {
+		if resource.Cluster == "" || clusters.Has(resource.Cluster) {
+			continue
+		}
+		if !resource.Managed || resource.Installation != installationID {
+			continue
+		}
+		if !resource.Created.IsZero() && now.Sub(resource.Created) < orphanGracePeriod {
+			continue
+		}
+		orphaned = append(orphaned, resource)
+	}
+
+	sortForDeletion(orphaned)
+
+	return orphaned
+}
+
+// sortForDeletion sorts the resources in the order in which they have to be deleted.
+func sortForDeletion(tagged []TaggedResource) {
+	sort.SliceStable(tagged, func(i, j int) bool {
+		return resourceDeletionOrder(tagged[i]) < resourceDeletionOrder(tagged[j])
+	})
+}
+
+func resourceDeletionOrder(resource TaggedResource) int {
+	if order, ok := deletionOrder[resource.Type]; ok {
+		return order
+	}
+	return 4
+}
+
+// DeleteResource deletes an orphaned resource and waits for the deletion to complete. Resources which
+// are already gone are ignored. Resource groups are never deleted, as that would also remove all
+// untagged resources inside of them.
+func DeleteResource(ctx context.Context, credentials Credentials, resource TaggedResource) error {
+	if !resource.Deletable() {
+		return fmt.Errorf("resources of type %q cannot be deleted", resource.Type)
+	}
+
+	resourcesClient, err := getResourcesClient(credentials)
+	if err != nil {
+		return err
+	}
+	future, err := resourcesClient.DeleteByID(ctx, resource.ID, deletionAPIVersions[resource.Type])
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to delete %q: %w", resource.ID, err)
+	}
+	if err := future.WaitForCompletionRef(ctx, resourcesClient.Client); err != nil && !isNotFound(err) {
+		return fmt.Errorf("failed to delete %q: %w", resource.ID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func TestFindOrphanedResources(t *testing.T) {
+	now := time.Now()
+	old := now.Add(-2 * orphanGracePeriod)
+	resource := func(id, resourceType, cluster string) TaggedResource {
+		return TaggedResource{ID: id, Type: resourceType, Cluster: cluster, Installation: "kkp", Managed: true, Created: old}
+	}
+
+	foreign := resource("nic-foreign", "Microsoft.Network/networkInterfaces", "gone")
+	foreign.Installation = "other-kkp"
+	unmanaged := resource("nic-unmanaged", "Microsoft.Network/networkInterfaces", "gone")
+	unmanaged.Managed = false
+	young := resource("nic-young", "Microsoft.Network/networkInterfaces", "new")
+	young.Created = now.Add(-time.Minute)
+	group := resource("rg-gone", resourceGroupType, "gone")
+	group.Created = time.Time{}
+
+	tagged := []TaggedResource{
+		group,
+		resource("vnet-gone", "Microsoft.Network/virtualNetworks", "gone"),
+		resource("pip-gone", "Microsoft.Network/publicIPAddresses", "gone"),
+		resource("nic-gone", "Microsoft.Network/networkInterfaces", "gone"),
+		resource("nic-alive", "Microsoft.Network/networkInterfaces", "alive"),
+		resource("vm-gone", "Microsoft.Compute/virtualMachines", "gone"),
+		resource("untagged", "Microsoft.Compute/disks", ""),
+		foreign,
+		unmanaged,
+		young,
+	}
+
+	orphaned := FindOrphanedResources(tagged, sets.NewString("alive"), "kkp", now)
+
+	expected := []string{"vm-gone", "nic-gone", "pip-gone", "vnet-gone", "rg-gone"}
+	if len(orphaned) != len(expected) {
+		t.Fatalf("expected %d orphaned resources, got %d: %v", len(expected), len(orphaned), orphaned)
+	}
+	for i, id := range expected {
+		if orphaned[i].ID != id {
+			t.Errorf("expected orphaned resource %d to be %q, got %q", i, id, orphaned[i].ID)
+		}
+	}
+
+	if orphaned := FindOrphanedResources(tagged, sets.NewString("alive"), "", now); len(orphaned) != 0 {
+		t.Errorf("expected no orphaned resources without an installation ID, got %v", orphaned)
+	}
+}
+
+func TestTaggedResourceDeletable(t *testing.T) {
+	testCases := []struct {
+		resourceType string
+		expected     bool
+	}{
+		{resourceType: resourceGroupType, expected: false},
+		{resourceType: "Microsoft.Network/networkInterfaces", expected: true},
+		{resourceType: "Microsoft.Compute/disks", expected: true},
+		{resourceType: "Microsoft.Storage/storageAccounts", expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.resourceType, func(t *testing.T) {
+			if deletable := (TaggedResource{Type: tc.resourceType}).Deletable(); deletable != tc.expected {
+				t.Errorf("expected deletable to be %v, got %v", tc.expected, deletable)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-06-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+)
+
+// ValidateClusterNetwork checks that the address space of the cluster's VNet does not overlap the
+// pod and service CIDRs of the cluster. Azure accepts such configurations, but traffic to the
+// overlapping IPs is routed into the VNet instead of to the pods and services.
+func (a *Azure) ValidateClusterNetwork(cloud kubermaticv1.CloudSpec, clusterNetwork kubermaticv1.ClusterNetworkingConfig) error {
+	var vnet *network.VirtualNetwork
+	if cloud.Azure.VNetName != "" {
+		credentials, err := GetCredentialsForCluster(cloud, a.secretKeySelector)
+		if err != nil {
+			return err
+		}
+
+		networksClient, err := getNetworksClient(cloud, credentials)
+		if err != nil {
+			return err
+		}
+
+		existing, err := networksClient.Get(a.ctx, vnetResourceGroup(cloud), cloud.Azure.VNetName, "")
+		if err != nil && !isNotFound(err) {
+			return fmt.Errorf("failed to get virtual network %q: %w", cloud.Azure.VNetName, err)
+		}
+		if err == nil {
+			vnet = &existing
+		}
+	}
+
+	return validateNoNetworkOverlap(vnetAddressPrefixes(cloud, vnet), cloud, clusterNetwork)
+}
+
+// ValidateNetworkOverlap checks the pod and service CIDRs against the address space of the VNet
+// Kubermatic creates for the cluster. It doesn't need credentials, so it can be used before the
+// cluster exists. Existing VNets are checked by ValidateClusterNetwork.
+func ValidateNetworkOverlap(cloud kubermaticv1.CloudSpec, clusterNetwork kubermaticv1.ClusterNetworkingConfig) error {
+	if cloud.Azure == nil || cloud.Azure.VNetName != "" {
+		return nil
+	}
+	return validateNoNetworkOverlap(vnetAddressPrefixes(cloud, nil), cloud, clusterNetwork)
+}
+
+// vnetAddressPrefixes returns the IP ranges of the cluster's VNet once the cluster has been
+// initialized. A nil VNet is created by Kubermatic with the configured address space.
+func vnetAddressPrefixes(cloud kubermaticv1.CloudSpec, vnet *network.VirtualNetwork) []string {
+	var prefixes []string
+	hasBastionSubnet := false
+
+	if vnet == nil {
+		prefixes = append(prefixes, vnetCIDR(cloud))
+	} else if vnet.VirtualNetworkPropertiesFormat != nil {
+		if vnet.AddressSpace != nil {
+			prefixes = append(prefixes, to.StringSlice(vnet.AddressSpace.AddressPrefixes)...)
+		}
+		if vnet.Subnets != nil {
+			for _, subnet := range *vnet.Subnets {
+				if to.String(subnet.Name) == bastionSubnetName {
+					hasBastionSubnet = true
+				}
+			}
+		}
+	}
+
+	// additional subnets and the Bastion subnet are added to the address space of the VNet
+	for _, subnet := range cloud.Azure.AdditionalSubnets {
+		prefixes = append(prefixes, subnet.CIDR)
+	}
+	if cloud.Azure.EnableBastion && !hasBastionSubnet {
+		prefixes = append(prefixes, bastionSubnetCIDR)
+	}
+
+	return prefixes
+}
+
+// validateNoNetworkOverlap returns an error if one of the prefixes overlaps the pod or service CIDR.
+// With Azure CNI the pods get their IPs from the subnet, so only the services are checked.
+func validateNoNetworkOverlap(prefixes []string, cloud kubermaticv1.CloudSpec, clusterNetwork kubermaticv1.ClusterNetworkingConfig) error {
+	clusterCIDRs := map[string][]string{
+		"services": clusterNetwork.Services.CIDRBlocks,
+	}
+	if !usesAzureCNI(cloud) {
+		clusterCIDRs["pods"] = clusterNetwork.Pods.CIDRBlocks
+	}
+
+	for _, kind := range []string{"pods", "services"} {
+		for _, cidr := range clusterCIDRs[kind] {
+			_, clusterNet, err := net.ParseCIDR(cidr)
+			if err != nil {
+				// malformed CIDRs are reported by the validation of the cluster network
+				continue
+			}
+
+			for _, prefix := range prefixes {
+				_, vnetNet, err := net.ParseCIDR(prefix)
+				if err != nil {
+					continue
+				}
+				if vnetNet.Contains(clusterNet.IP) || clusterNet.Contains(vnetNet.IP) {
+					return fmt.Errorf("the %s CIDR %s overlaps the address range %s of the VNet", kind, cidr, prefix)
+				}
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-06-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+)
+
+func TestValidateNoNetworkOverlap(t *testing.T) {
+	defaultNetwork := kubermaticv1.ClusterNetworkingConfig{
+		Pods:     kubermaticv1.NetworkRanges{CIDRBlocks: []string{"172.25.0.0/16"}},
+		Services: kubermaticv1.NetworkRanges{CIDRBlocks: []string{"10.240.16.0/20"}},
+	}
+
+	testCases := []struct {
+		name           string
+		cloud          kubermaticv1.AzureCloudSpec
+		vnet           *network.VirtualNetwork
+		clusterNetwork kubermaticv1.ClusterNetworkingConfig
+		expectedError  string
+	}{
+		{
+			name:           "default VNet and cluster network",
+			clusterNetwork: defaultNetwork,
+		},
+		{
+			name: "pod CIDR containing the default VNet",
+			clusterNetwork: kubermaticv1.ClusterNetworkingConfig{
+				Pods:     kubermaticv1.NetworkRanges{CIDRBlocks: []string{"10.0.0.0/8"}},
+				Services: kubermaticv1.NetworkRanges{CIDRBlocks: []string{"172.16.0.0/20"}},
+			},
+			expectedError: "the pods CIDR 10.0.0.0/8 overlaps the address range 10.0.0.0/16 of the VNet",
+		},
+		{
+			name: "service CIDR inside an existing VNet",
+			vnet: &network.VirtualNetwork{
+				VirtualNetworkPropertiesFormat: &network.VirtualNetworkPropertiesFormat{
+					AddressSpace: &network.AddressSpace{AddressPrefixes: &[]string{"10.240.0.0/16"}},
+				},
+			},
+			clusterNetwork: defaultNetwork,
+			expectedError:  "the services CIDR 10.240.16.0/20 overlaps the address range 10.240.0.0/16 of the VNet",
+		},
+		{
+			name: "additional subnet overlapping the pods",
+			cloud: kubermaticv1.AzureCloudSpec{
+				AdditionalSubnets: []kubermaticv1.AzureSubnet{{Name: "gpu", CIDR: "172.25.128.0/24"}},
+			},
+			clusterNetwork: defaultNetwork,
+			expectedError:  "the pods CIDR 172.25.0.0/16 overlaps the address range 172.25.128.0/24 of the VNet",
+		},
+		{
+			name:  "Bastion range overlapping the services",
+			cloud: kubermaticv1.AzureCloudSpec{EnableBastion: true},
+			clusterNetwork: kubermaticv1.ClusterNetworkingConfig{
+				Pods:     defaultNetwork.Pods,
+				Services: kubermaticv1.NetworkRanges{CIDRBlocks: []string{"10.1.0.0/20"}},
+			},
+			expectedError: "the services CIDR 10.1.0.0/20 overlaps the address range 10.1.0.0/26 of the VNet",
+		},
+		{
+			name:  "Bastion range is not added to VNets with a Bastion subnet",
+			cloud: kubermaticv1.AzureCloudSpec{EnableBastion: true},
+			vnet: &network.VirtualNetwork{
+				VirtualNetworkPropertiesFormat: &network.VirtualNetworkPropertiesFormat{
+					AddressSpace: &network.AddressSpace{AddressPrefixes: &[]string{"10.0.0.0/16"}},
+					Subnets:      &[]network.Subnet{{Name: to.StringPtr(bastionSubnetName)}},
+				},
+			},
+			clusterNetwork: kubermaticv1.ClusterNetworkingConfig{
+				Pods:     defaultNetwork.Pods,
+				Services: kubermaticv1.NetworkRanges{CIDRBlocks: []string{"10.1.0.0/20"}},
+			},
+		},
+		{
+			name:  "pods get IPs from the subnet with Azure CNI",
+			cloud: kubermaticv1.AzureCloudSpec{NetworkPlugin: kubermaticv1.AzureNetworkPluginAzure},
+			clusterNetwork: kubermaticv1.ClusterNetworkingConfig{
+				Pods:     kubermaticv1.NetworkRanges{CIDRBlocks: []string{"10.0.0.0/16"}},
+				Services: defaultNetwork.Services,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cloud := kubermaticv1.CloudSpec{Azure: &tc.cloud}
+
+			err := validateNoNetworkOverlap(vnetAddressPrefixes(cloud, tc.vnet), cloud, tc.clusterNetwork)
+			if tc.expectedError == "" && err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if tc.expectedError != "" && (err == nil || err.Error() != tc.expectedError) {
+				t.Fatalf("expected error %q, got %v", tc.expectedError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,381 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-06-01/network"
+	autorestazure "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/to"
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/provider"
+)
+
+const (
+	// vnetPeeringPrefix is the name prefix of the peerings from the cluster's VNet to the hub VNets.
+	// It is followed by the cluster name, which scopes the peerings to the cluster.
+	vnetPeeringPrefix = "kubermatic-hub-"
+	// reverseVNetPeeringPrefix is the name prefix of the peerings from the hub VNets back to the cluster's VNet.
+	reverseVNetPeeringPrefix = "kubermatic-cluster-"
+	// maxVNetPeeringNameLength is the maximum length of a peering name accepted by Azure.
+	maxVNetPeeringNameLength = 80
+)
+
+// desiredPeerVNets returns the resource IDs of the hub VNets configured in the datacenter and
+// the cluster, keyed by the name of the peering to each of them.
+func (a *Azure) desiredPeerVNets(clusterName string, cloud kubermaticv1.CloudSpec) (map[string]string, error) {
+	desired := map[string]string{}
+
+	for _, id := range append(append([]string{}, a.dc.PeerVNets...), cloud.Azure.PeerVNets...) {
+		name, err := vnetPeeringName(clusterName, id)
+		if err != nil {
+			return nil, err
+		}
+		desired[name] = id
+	}
+
+	return desired, nil
+}
+
+// vnetPeeringName returns the name of the peering from the cluster's VNet to the given hub VNet.
+// The name contains a hash of the ID, as hub VNets in different resource groups may share a name.
+func vnetPeeringName(clusterName, hubVNetID string) (string, error) {
+	hub, err := autorestazure.ParseResourceID(hubVNetID)
+	if err != nil {
+		return "", fmt.Errorf("invalid VNet ID %q: %w", hubVNetID, err)
+	}
+	if !strings.EqualFold(hub.Provider, "Microsoft.Network") || !strings.EqualFold(hub.ResourceType, "virtualNetworks") {
+		return "", fmt.Errorf("%q is not the ID of a virtual network", hubVNetID)
+	}
+
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(strings.ToLower(hubVNetID)))
+	suffix := fmt.Sprintf("-%08x", hash.Sum32())
+
+	prefix := clusterVNetPeeringPrefix(clusterName)
+	name := hub.ResourceName
+	if max := maxVNetPeeringNameLength - len(prefix) - len(suffix); len(name) > max {
+		name = name[:max]
+	}
+
+	return prefix + name + suffix, nil
+}
+
+// clusterVNetPeeringPrefix returns the name prefix of the peerings created for the given cluster.
+func clusterVNetPeeringPrefix(clusterName string) string {
+	return vnetPeeringPrefix + clusterName + "-"
+}
+
+// ownsVNetPeering returns whether the peering of the cluster's VNet with the given name was
+// created for the cluster. Peerings created before their names contained the cluster name are
+// only considered owned on VNets created by Kubermatic, which are not shared with other clusters.
+func ownsVNetPeering(cluster *kubermaticv1.Cluster, name string) bool {
+	if strings.HasPrefix(name, clusterVNetPeeringPrefix(cluster.Name)) {
+		return true
+	}
+	return kuberneteshelper.HasFinalizer(cluster, FinalizerVNet) && strings.HasPrefix(name, vnetPeeringPrefix)
+}
+
+// vnetPeeringOperation returns the name of an operation on the given peering. Only the hash
+// suffix of the peering name is used, as the operation name is part of an annotation key.
+func vnetPeeringOperation(action, name string) string {
+	return action + "-vnet-peering" + name[strings.LastIndex(name, "-"):]
+}
+
+// reconcileVNetPeerings peers the cluster's VNet with the configured hub VNets and removes the
+// peerings to hub VNets which are not configured anymore. Only VNets created by Kubermatic are
+// peered, VNets provided by the user may be shared with other clusters and are left untouched.
+func (a *Azure) reconcileVNetPeerings(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, credentials Credentials) (*kubermaticv1.Cluster, error) {
+	var err error
+	logger := a.log.With("cluster", cluster.Name)
+
+	desired, err := a.desiredPeerVNets(cluster.Name, cluster.Spec.Cloud)
+	if err != nil {
+		return cluster, err
+	}
+	if len(desired) > 0 && !kuberneteshelper.HasFinalizer(cluster, FinalizerVNet) {
+		a.recordWarning(cluster, "VNetPeeringSkipped", "Not peering virtual network %q with hub VNets, it is not managed by Kubermatic", cluster.Spec.Cloud.Azure.VNetName)
+		desired = map[string]string{}
+	}
+	if len(desired) == 0 && !kuberneteshelper.HasFinalizer(cluster, FinalizerVNetPeerings) {
+		return cluster, nil
+	}
+
+	// the finalizer is added first, so that no peering is left behind if the cluster
+	// is deleted while they are being created
+	if len(desired) > 0 && !kuberneteshelper.HasFinalizer(cluster, FinalizerVNetPeerings) {
+		cluster, err = update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+			kuberneteshelper.AddFinalizer(updatedCluster, FinalizerVNetPeerings)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	existing, err := listVNetPeerings(a.ctx, cluster, credentials)
+	if err != nil {
+		return cluster, fmt.Errorf("failed to list peerings of virtual network %q: %w", cluster.Spec.Cloud.Azure.VNetName, err)
+	}
+
+	names := make([]string, 0, len(desired))
+	for name := range desired {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		hubVNetID := desired[name]
+
+		peering, ok := existing[name]
+		if !ok {
+			logger.Infow("ensuring vnet peering", "vnet", cluster.Spec.Cloud.Azure.VNetName, "hub", hubVNetID)
+			if err = a.pollOperation(cluster, update, credentials, vnetPeeringOperation("create", name), func() (autorestazure.FutureAPI, error) {
+				return ensureVNetPeering(a.ctx, cluster.Spec.Cloud, name, hubVNetID, credentials)
+			}); err != nil {
+				return cluster, fmt.Errorf("failed to peer virtual network %q with %q: %w", cluster.Spec.Cloud.Azure.VNetName, hubVNetID, err)
+			}
+		}
+
+		// the peering only becomes connected once the hub VNet has been peered back
+		if !ok || peering.VirtualNetworkPeeringPropertiesFormat == nil || peering.PeeringState != network.VirtualNetworkPeeringStateConnected {
+			if err = a.pollOperation(cluster, update, credentials, vnetPeeringOperation("create-reverse", name), func() (autorestazure.FutureAPI, error) {
+				return ensureReverseVNetPeering(a.ctx, cluster, hubVNetID, credentials)
+			}); err != nil {
+				if !isForbidden(err) {
+					return cluster, fmt.Errorf("failed to peer virtual network %q with %q: %w", hubVNetID, cluster.Spec.Cloud.Azure.VNetName, err)
+				}
+				logger.Warnw("not allowed to peer hub vnet with cluster vnet, the peering must be created by the owner of the hub vnet", "hub", hubVNetID, "error", err)
+			}
+		}
+	}
+
+	for name, peering := range existing {
+		if _, ok := desired[name]; ok {
+			continue
+		}
+
+		if cluster, err = a.deleteVNetPeering(cluster, update, credentials, name, peering, logger); err != nil {
+			return cluster, err
+		}
+	}
+
+	if len(desired) == 0 {
+		cluster, err = update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+			kuberneteshelper.RemoveFinalizer(updatedCluster, FinalizerVNetPeerings)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return cluster, nil
+}
+
+// cleanUpVNetPeerings removes all peerings between the cluster's VNet and the hub VNets, which
+// must happen before the cluster's VNet can be deleted.
+func (a *Azure) cleanUpVNetPeerings(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, credentials Credentials, logger *zap.SugaredLogger) (*kubermaticv1.Cluster, error) {
+	var err error
+
+	if !kuberneteshelper.HasFinalizer(cluster, FinalizerVNetPeerings) {
+		return cluster, nil
+	}
+
+	existing, err := listVNetPeerings(a.ctx, cluster, credentials)
+	if err != nil {
+		if !isNotFound(err) {
+			return cluster, fmt.Errorf("failed to list peerings of virtual network %q: %w", cluster.Spec.Cloud.Azure.VNetName, err)
+		}
+	}
+
+	for name, peering := range existing {
+		if cluster, err = a.deleteVNetPeering(cluster, update, credentials, name, peering, logger); err != nil {
+			return cluster, err
+		}
+	}
+
+	cluster, err = update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+		kuberneteshelper.RemoveFinalizer(updatedCluster, FinalizerVNetPeerings)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return cluster, nil
+}
+
+// deleteVNetPeering removes the peering from the hub VNet back to the cluster's VNet, if the
+// credentials allow it, and the peering from the cluster's VNet to the hub VNet.
+func (a *Azure) deleteVNetPeering(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, credentials Credentials, name string, peering network.VirtualNetworkPeering, logger *zap.SugaredLogger) (*kubermaticv1.Cluster, error) {
+	if peering.VirtualNetworkPeeringPropertiesFormat != nil && peering.RemoteVirtualNetwork != nil && peering.RemoteVirtualNetwork.ID != nil {
+		hubVNetID := *peering.RemoteVirtualNetwork.ID
+
+		logger.Infow("deleting reverse vnet peering", "hub", hubVNetID)
+		if err := a.pollOperation(cluster, update, credentials, vnetPeeringOperation("delete-reverse", name), func() (autorestazure.FutureAPI, error) {
+			return deleteReverseVNetPeering(a.ctx, cluster, hubVNetID, credentials)
+		}); err != nil {
+			if !isForbidden(err) && !isNotFound(err) {
+				return cluster, fmt.Errorf("failed to delete peering of virtual network %q: %w", hubVNetID, err)
+			}
+		}
+	}
+
+	logger.Infow("deleting vnet peering", "vnet", cluster.Spec.Cloud.Azure.VNetName, "peering", name)
+	if err := a.pollOperation(cluster, update, credentials, vnetPeeringOperation("delete", name), func() (autorestazure.FutureAPI, error) {
+		return deleteVNetPeering(a.ctx, cluster.Spec.Cloud, name, credentials)
+	}); err != nil {
+		if !isNotFound(err) {
+			return cluster, fmt.Errorf("failed to delete peering %q of virtual network %q: %w", name, cluster.Spec.Cloud.Azure.VNetName, err)
+		}
+	}
+
+	return cluster, nil
+}
+
+// listVNetPeerings returns the peerings of the cluster's VNet created by Kubermatic for the cluster, keyed by name.
+func listVNetPeerings(ctx context.Context, cluster *kubermaticv1.Cluster, credentials Credentials) (map[string]network.VirtualNetworkPeering, error) {
+	cloud := cluster.Spec.Cloud
+	credentials = networkCredentials(cloud, credentials)
+	peeringsClient, err := getVNetPeeringsClient(credentials.SubscriptionID, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	peerings := map[string]network.VirtualNetworkPeering{}
+
+	it, err := peeringsClient.ListComplete(ctx, vnetResourceGroup(cloud), cloud.Azure.VNetName)
+	if err != nil {
+		return nil, err
+	}
+	for ; it.NotDone(); err = it.NextWithContext(ctx) {
+		if err != nil {
+			return nil, err
+		}
+
+		peering := it.Value()
+		if peering.Name != nil && ownsVNetPeering(cluster, *peering.Name) {
+			peerings[*peering.Name] = peering
+		}
+	}
+
+	return peerings, nil
+}
+
+// ensureVNetPeering will start peering the cluster's VNet with the hub VNet. The call is idempotent.
+func ensureVNetPeering(ctx context.Context, cloud kubermaticv1.CloudSpec, name, hubVNetID string, credentials Credentials) (autorestazure.FutureAPI, error) {
+	credentials = networkCredentials(cloud, credentials)
+	peeringsClient, err := getVNetPeeringsClient(credentials.SubscriptionID, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	future, err := peeringsClient.CreateOrUpdate(ctx, vnetResourceGroup(cloud), cloud.Azure.VNetName, name, network.VirtualNetworkPeering{
+		Name: to.StringPtr(name),
+		VirtualNetworkPeeringPropertiesFormat: &network.VirtualNetworkPeeringPropertiesFormat{
+			AllowVirtualNetworkAccess: to.BoolPtr(true),
+			AllowForwardedTraffic:     to.BoolPtr(true),
+			RemoteVirtualNetwork:      &network.SubResource{ID: to.StringPtr(hubVNetID)},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return future.FutureAPI, nil
+}
+
+// ensureReverseVNetPeering will start peering the hub VNet with the cluster's VNet. The call is idempotent.
+func ensureReverseVNetPeering(ctx context.Context, cluster *kubermaticv1.Cluster, hubVNetID string, credentials Credentials) (autorestazure.FutureAPI, error) {
+	hub, err := autorestazure.ParseResourceID(hubVNetID)
+	if err != nil {
+		return nil, err
+	}
+
+	peeringsClient, err := getVNetPeeringsClient(hub.SubscriptionID, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	name := reverseVNetPeeringPrefix + cluster.Name
+	future, err := peeringsClient.CreateOrUpdate(ctx, hub.ResourceGroup, hub.ResourceName, name, network.VirtualNetworkPeering{
+		Name: to.StringPtr(name),
+		VirtualNetworkPeeringPropertiesFormat: &network.VirtualNetworkPeeringPropertiesFormat{
+			AllowVirtualNetworkAccess: to.BoolPtr(true),
+			AllowForwardedTraffic:     to.BoolPtr(true),
+			RemoteVirtualNetwork:      &network.SubResource{ID: to.StringPtr(assembleVNetID(cluster.Spec.Cloud, credentials.SubscriptionID))},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return future.FutureAPI, nil
+}
+
+func deleteVNetPeering(ctx context.Context, cloud kubermaticv1.CloudSpec, name string, credentials Credentials) (autorestazure.FutureAPI, error) {
+	credentials = networkCredentials(cloud, credentials)
+	peeringsClient, err := getVNetPeeringsClient(credentials.SubscriptionID, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	future, err := peeringsClient.Delete(ctx, vnetResourceGroup(cloud), cloud.Azure.VNetName, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return future.FutureAPI, nil
+}
+
+func deleteReverseVNetPeering(ctx context.Context, cluster *kubermaticv1.Cluster, hubVNetID string, credentials Credentials) (autorestazure.FutureAPI, error) {
+	hub, err := autorestazure.ParseResourceID(hubVNetID)
+	if err != nil {
+		return nil, err
+	}
+
+	peeringsClient, err := getVNetPeeringsClient(hub.SubscriptionID, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	future, err := peeringsClient.Delete(ctx, hub.ResourceGroup, hub.ResourceName, reverseVNetPeeringPrefix+cluster.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return future.FutureAPI, nil
+}
+
+// getVNetPeeringsClient returns a client for the peerings of VNets in the given subscription,
+// which is not necessarily the one of the cluster.
+func getVNetPeeringsClient(subscriptionID string, credentials Credentials) (*network.VirtualNetworkPeeringsClient, error) {
+	var err error
+	peeringsClient := network.NewVirtualNetworkPeeringsClient(subscriptionID)
+	err = configureClient(&peeringsClient.Client, credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authorizer: %s", err.Error())
+	}
+
+	return &peeringsClient, nil
+}
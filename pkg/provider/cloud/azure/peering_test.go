@@ -0,0 +1,141 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"strings"
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const hubVNetID = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/hub/providers/Microsoft.Network/virtualNetworks/hub-vnet"
+
+func TestVNetPeeringName(t *testing.T) {
+	testCases := []struct {
+		name          string
+		hubVNetID     string
+		expectedError bool
+	}{
+		{
+			name:      "hub vnet",
+			hubVNetID: hubVNetID,
+		},
+		{
+			name:      "long hub vnet name",
+			hubVNetID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/hub/providers/Microsoft.Network/virtualNetworks/" + strings.Repeat("a", 64),
+		},
+		{
+			name:          "not a vnet",
+			hubVNetID:     "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/hub/providers/Microsoft.Network/networkSecurityGroups/hub-nsg",
+			expectedError: true,
+		},
+		{
+			name:          "invalid id",
+			hubVNetID:     "hub-vnet",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			name, err := vnetPeeringName("abcd1234", tc.hubVNetID)
+			if (err != nil) != tc.expectedError {
+				t.Fatalf("expected error: %v, got: %v", tc.expectedError, err)
+			}
+			if tc.expectedError {
+				return
+			}
+			if prefix := clusterVNetPeeringPrefix("abcd1234"); !strings.HasPrefix(name, prefix) {
+				t.Errorf("expected name %q to start with %q", name, prefix)
+			}
+			if len(name) > maxVNetPeeringNameLength {
+				t.Errorf("expected name %q to be at most %d characters long", name, maxVNetPeeringNameLength)
+			}
+		})
+	}
+}
+
+func TestDesiredPeerVNets(t *testing.T) {
+	a := &Azure{dc: &kubermaticv1.DatacenterSpecAzure{PeerVNets: []string{hubVNetID}}}
+
+	desired, err := a.desiredPeerVNets("abcd1234", kubermaticv1.CloudSpec{
+		Azure: &kubermaticv1.AzureCloudSpec{
+			PeerVNets: []string{
+				// configured in the datacenter as well
+				strings.ToLower(hubVNetID),
+				"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/other/providers/Microsoft.Network/virtualNetworks/hub-vnet",
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// VNets with the same name in different resource groups need distinct peerings
+	if len(desired) != 2 {
+		t.Errorf("expected 2 peerings, got %v", desired)
+	}
+}
+
+func TestOwnsVNetPeering(t *testing.T) {
+	testCases := []struct {
+		name          string
+		finalizers    []string
+		peeringName   string
+		expectedOwned bool
+	}{
+		{
+			name:          "peering of the cluster",
+			peeringName:   "kubermatic-hub-abcd1234-hub-vnet-0a1b2c3d",
+			expectedOwned: true,
+		},
+		{
+			name:          "peering of another cluster sharing the vnet",
+			peeringName:   "kubermatic-hub-efgh5678-hub-vnet-0a1b2c3d",
+			expectedOwned: false,
+		},
+		{
+			name:          "peering without cluster name on a user-provided vnet",
+			peeringName:   "kubermatic-hub-hub-vnet-0a1b2c3d",
+			expectedOwned: false,
+		},
+		{
+			name:          "peering without cluster name on a vnet created by kubermatic",
+			finalizers:    []string{FinalizerVNet},
+			peeringName:   "kubermatic-hub-hub-vnet-0a1b2c3d",
+			expectedOwned: true,
+		},
+		{
+			name:          "peering not created by kubermatic",
+			finalizers:    []string{FinalizerVNet},
+			peeringName:   "to-hub",
+			expectedOwned: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := &kubermaticv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "abcd1234", Finalizers: tc.finalizers}}
+			if owned := ownsVNetPeering(cluster, tc.peeringName); owned != tc.expectedOwned {
+				t.Errorf("expected owned to be %v, got %v", tc.expectedOwned, owned)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/authorization/mgmt/2015-07-01/authorization"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+)
+
+// requiredPermission is a set of actions Kubermatic needs on a resource group, named after the
+// built-in role which grants them.
+type requiredPermission struct {
+	role    string
+	actions []string
+}
+
+var (
+	networkContributorPermission = requiredPermission{
+		role: "Network Contributor",
+		actions: []string{
+			"Microsoft.Network/networkSecurityGroups/write",
+			"Microsoft.Network/routeTables/write",
+			"Microsoft.Network/networkInterfaces/write",
+			"Microsoft.Network/publicIPAddresses/write",
+			"Microsoft.Network/loadBalancers/write",
+		},
+	}
+	virtualMachineContributorPermission = requiredPermission{
+		role: "Virtual Machine Contributor",
+		actions: []string{
+			"Microsoft.Compute/virtualMachines/write",
+			"Microsoft.Compute/availabilitySets/write",
+			"Microsoft.Compute/disks/write",
+		},
+	}
+)
+
+// MissingPermission lists the actions the credentials of a cluster are not allowed to perform in
+// a resource group.
+type MissingPermission struct {
+	// Role is the built-in role which grants the actions.
+	Role          string
+	ResourceGroup string
+	Actions       []string
+}
+
+// MissingPermissionsError is returned by ValidateCloudSpec if the credentials of a cluster lack
+// role assignments needed to provision it.
+type MissingPermissionsError struct {
+	Missing []MissingPermission
+}
+
+func (e *MissingPermissionsError) Error() string {
+	var missing []string
+	for _, m := range e.Missing {
+		missing = append(missing, fmt.Sprintf("%s on resource group %q (%s)", m.Role, m.ResourceGroup, strings.Join(m.Actions, ", ")))
+	}
+	return fmt.Sprintf("the credentials are missing permissions: %s", strings.Join(missing, "; "))
+}
+
+// requiredPermissions returns the permissions Kubermatic needs per resource group for the cloud
+// spec. Resource groups which Kubermatic creates are not checked, since the permissions cannot be
+// probed before the resource group exists.
+func requiredPermissions(cloud kubermaticv1.CloudSpec) map[string][]requiredPermission {
+	vnetActions := []string{"Microsoft.Network/virtualNetworks/subnets/join/action"}
+	if cloud.Azure.VNetName == "" {
+		vnetActions = append(vnetActions, "Microsoft.Network/virtualNetworks/write")
+	}
+	if cloud.Azure.SubnetName == "" {
+		vnetActions = append(vnetActions, "Microsoft.Network/virtualNetworks/subnets/write")
+	}
+
+	required := map[string][]requiredPermission{}
+	if cloud.Azure.ResourceGroup != "" {
+		network := networkContributorPermission
+		network.actions = append(vnetActions, network.actions...)
+		required[cloud.Azure.ResourceGroup] = []requiredPermission{network, virtualMachineContributorPermission}
+	}
+
+	// the virtual network can be in a different resource group, the permissions in another
+	// subscription are not probed
+	if rg := cloud.Azure.VNetResourceGroup; rg != "" && rg != cloud.Azure.ResourceGroup && cloud.Azure.VNetSubscriptionID == "" {
+		required[rg] = []requiredPermission{{role: networkContributorPermission.role, actions: vnetActions}}
+	}
+
+	return required
+}
+
+// isActionAllowed returns true if one of the permissions allows the action. Actions are case
+// insensitive and the permissions can contain wildcards.
+func isActionAllowed(permissions []authorization.Permission, action string) bool {
+	for _, permission := range permissions {
+		if permission.Actions == nil || !matchesAnyAction(*permission.Actions, action) {
+			continue
+		}
+		if permission.NotActions != nil && matchesAnyAction(*permission.NotActions, action) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+func matchesAnyAction(patterns []string, action string) bool {
+	for _, pattern := range patterns {
+		expr := "(?i)^" + strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*") + "$"
+		if matched, err := regexp.MatchString(expr, action); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// missingPermissions checks the permissions of the credentials in a resource group.
+func missingPermissions(resourceGroup string, required []requiredPermission, permissions []authorization.Permission) []MissingPermission {
+	var missing []MissingPermission
+	for _, r := range required {
+		var actions []string
+		for _, action := range r.actions {
+			if !isActionAllowed(permissions, action) {
+				actions = append(actions, action)
+			}
+		}
+		if len(actions) > 0 {
+			missing = append(missing, MissingPermission{Role: r.role, ResourceGroup: resourceGroup, Actions: actions})
+		}
+	}
+	return missing
+}
+
+// validatePermissions probes the authorization API for the permissions the credentials hold in
+// the resource groups of the cluster. Credentials which are not allowed to read their own
+// permissions are not rejected, as provisioning might still succeed.
+func (a *Azure) validatePermissions(cloud kubermaticv1.CloudSpec, credentials Credentials) error {
+	client, err := getPermissionsClient(cloud, credentials)
+	if err != nil {
+		return err
+	}
+
+	required := requiredPermissions(cloud)
+	resourceGroups := make([]string, 0, len(required))
+	for rg := range required {
+		resourceGroups = append(resourceGroups, rg)
+	}
+	sort.Strings(resourceGroups)
+
+	var missing []MissingPermission
+	for _, rg := range resourceGroups {
+		var permissions []authorization.Permission
+		it, err := client.ListForResourceGroupComplete(a.ctx, rg)
+		if isForbidden(err) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to list permissions in resource group %q: %w", rg, err)
+		}
+		for ; it.NotDone(); err = it.NextWithContext(a.ctx) {
+			if err != nil {
+				return fmt.Errorf("failed to list permissions in resource group %q: %w", rg, err)
+			}
+			permissions = append(permissions, it.Value())
+		}
+
+		missing = append(missing, missingPermissions(rg, required[rg], permissions)...)
+	}
+
+	if len(missing) > 0 {
+		return &MissingPermissionsError{Missing: missing}
+	}
+	return nil
+}
+
+func getPermissionsClient(cloud kubermaticv1.CloudSpec, credentials Credentials) (*authorization.PermissionsClient, error) {
+	var err error
+	permissionsClient := authorization.NewPermissionsClient(credentials.SubscriptionID)
+	err = configureClient(&permissionsClient.Client, credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authorizer: %s", err.Error())
+	}
+
+	return &permissionsClient, nil
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/authorization/mgmt/2015-07-01/authorization"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+)
+
+func TestIsActionAllowed(t *testing.T) {
+	permissions := []authorization.Permission{
+		{
+			Actions:    &[]string{"Microsoft.Network/*"},
+			NotActions: &[]string{"Microsoft.Network/loadBalancers/*"},
+		},
+		{
+			Actions: &[]string{"microsoft.compute/virtualmachines/*", "Microsoft.Compute/*/read"},
+		},
+	}
+
+	tests := map[string]bool{
+		"Microsoft.Network/routeTables/write":     true,
+		"Microsoft.Network/loadBalancers/write":   false,
+		"Microsoft.Compute/virtualMachines/write": true,
+		"Microsoft.Compute/disks/read":            true,
+		"Microsoft.Compute/disks/write":           false,
+		"Microsoft.Storage/storageAccounts/write": false,
+	}
+
+	for action, expected := range tests {
+		if allowed := isActionAllowed(permissions, action); allowed != expected {
+			t.Errorf("expected %q to be allowed=%v", action, expected)
+		}
+	}
+}
+
+func TestRequiredPermissions(t *testing.T) {
+	cloud := kubermaticv1.CloudSpec{
+		Azure: &kubermaticv1.AzureCloudSpec{
+			ResourceGroup:     "cluster-rg",
+			VNetResourceGroup: "network-rg",
+			VNetName:          "shared-vnet",
+		},
+	}
+
+	required := requiredPermissions(cloud)
+
+	if len(required) != 2 {
+		t.Fatalf("expected permissions for two resource groups, got %v", required)
+	}
+	expected := []requiredPermission{{
+		role:    "Network Contributor",
+		actions: []string{"Microsoft.Network/virtualNetworks/subnets/join/action", "Microsoft.Network/virtualNetworks/subnets/write"},
+	}}
+	if !reflect.DeepEqual(required["network-rg"], expected) {
+		t.Errorf("expected %v in the virtual network resource group, got %v", expected, required["network-rg"])
+	}
+
+	cloud.Azure.VNetSubscriptionID = "network-sub"
+	if _, ok := requiredPermissions(cloud)["network-rg"]; ok {
+		t.Errorf("expected the virtual network resource group in another subscription not to be checked")
+	}
+
+	cloud.Azure.VNetSubscriptionID = ""
+	cloud.Azure.ResourceGroup = ""
+	cloud.Azure.VNetResourceGroup = ""
+	if required := requiredPermissions(cloud); len(required) != 0 {
+		t.Errorf("expected no permissions to be checked if Kubermatic creates the resource group, got %v", required)
+	}
+}
+
+func TestMissingPermissions(t *testing.T) {
+	cloud := kubermaticv1.CloudSpec{
+		Azure: &kubermaticv1.AzureCloudSpec{
+			ResourceGroup: "cluster-rg",
+			VNetName:      "vnet",
+			SubnetName:    "subnet",
+		},
+	}
+	// the credentials only have the Network Contributor role
+	permissions := []authorization.Permission{{Actions: &[]string{"Microsoft.Network/*", "Microsoft.Authorization/*/read"}}}
+
+	missing := missingPermissions("cluster-rg", requiredPermissions(cloud)["cluster-rg"], permissions)
+
+	expected := []MissingPermission{{
+		Role:          "Virtual Machine Contributor",
+		ResourceGroup: "cluster-rg",
+		Actions:       []string{"Microsoft.Compute/virtualMachines/write", "Microsoft.Compute/availabilitySets/write", "Microsoft.Compute/disks/write"},
+	}}
+	if !reflect.DeepEqual(missing, expected) {
+		t.Fatalf("expected %v, got %v", expected, missing)
+	}
+
+	err := &MissingPermissionsError{Missing: missing}
+	expectedMsg := `the credentials are missing permissions: Virtual Machine Contributor on resource group "cluster-rg" (Microsoft.Compute/virtualMachines/write, Microsoft.Compute/availabilitySets/write, Microsoft.Compute/disks/write)`
+	if err.Error() != expectedMsg {
+		t.Errorf("expected error %q, got %q", expectedMsg, err.Error())
+	}
+}
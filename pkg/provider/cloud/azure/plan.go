@@ -0,0 +1,417 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2018-06-01/compute"
+	network2020 "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	"github.com/Azure/azure-sdk-for-go/services/operationalinsights/mgmt/2020-08-01/operationalinsights"
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+	"github.com/Azure/go-autorest/autorest/to"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/provider"
+)
+
+// PlanCloudProvider returns the resources InitializeCloudProvider would create or update for the
+// cluster, without calling Azure. Resources specified by the user are assumed to exist, so the
+// plan only covers what Kubermatic manages. Checks which need the live state, like quotas or
+// whether the address ranges of existing VNets overlap, are not part of the plan.
+func (a *Azure) PlanCloudProvider(cluster *kubermaticv1.Cluster) ([]provider.PlannedInfrastructureResource, error) {
+	if cluster.Spec.Cloud.Azure == nil {
+		return nil, fmt.Errorf("cluster %q is not an Azure cluster", cluster.Name)
+	}
+
+	credentials, err := GetCredentialsForCluster(cluster.Spec.Cloud, a.secretKeySelector)
+	if err != nil {
+		return nil, err
+	}
+
+	// the names InitializeCloudProvider would generate are filled in on a copy, so that
+	// the following resources reference them
+	cloud := *cluster.Spec.Cloud.DeepCopy()
+	tags := to.StringMap(a.resourceTags(cluster))
+	location := a.dc.Location
+	generatedName := resourceNamePrefix + cluster.Name
+
+	var plan []provider.PlannedInfrastructureResource
+	add := func(resource provider.PlannedInfrastructureResource) {
+		if resource.Tags == nil && resource.Action != provider.PlannedInfrastructureUpdate {
+			resource.Tags = tags
+		}
+		plan = append(plan, resource)
+	}
+
+	if cloud.Azure.ResourceGroup == "" {
+		if cloud.Azure.ResourceGroup, err = a.resourceName(cluster, resourceTypeResourceGroup); err != nil {
+			return nil, err
+		}
+		add(provider.PlannedInfrastructureResource{
+			Type:       "resourceGroup",
+			Name:       cloud.Azure.ResourceGroup,
+			Action:     provider.PlannedInfrastructureCreate,
+			Parent:     credentials.SubscriptionID,
+			Properties: map[string]string{"location": location},
+		})
+
+		// only resource groups created by Kubermatic are locked
+		if a.dc.LockResourceGroup {
+			add(provider.PlannedInfrastructureResource{
+				Type:       "resourceGroupLock",
+				Name:       resourceGroupLockName,
+				Action:     provider.PlannedInfrastructureCreate,
+				Parent:     cloud.Azure.ResourceGroup,
+				Tags:       map[string]string{},
+				Properties: map[string]string{"level": "CanNotDelete"},
+			})
+		}
+	}
+
+	// the index of the created VNet in the plan, the Bastion subnet is added to its address space
+	vnetPlanIndex := -1
+	if cloud.Azure.VNetName == "" {
+		if cloud.Azure.VNetName, err = a.resourceName(cluster, resourceTypeVNet); err != nil {
+			return nil, err
+		}
+		vnetPlanIndex = len(plan)
+		properties := map[string]string{"location": location}
+		if len(cloud.Azure.DNSServers) > 0 {
+			properties["dnsServers"] = strings.Join(cloud.Azure.DNSServers, ",")
+		}
+		if a.dc.DDoSProtectionPlanID != "" {
+			properties["ddosProtectionPlan"] = a.dc.DDoSProtectionPlanID
+		}
+		add(provider.PlannedInfrastructureResource{
+			Type:            "vnet",
+			Name:            cloud.Azure.VNetName,
+			Action:          provider.PlannedInfrastructureCreate,
+			Parent:          vnetResourceGroup(cloud),
+			AddressPrefixes: []string{vnetCIDR(cloud)},
+			Properties:      properties,
+		})
+	}
+
+	if cloud.Azure.SubnetName == "" {
+		if cloud.Azure.SubnetName, err = a.resourceName(cluster, resourceTypeSubnet); err != nil {
+			return nil, err
+		}
+		var properties map[string]string
+		if len(cloud.Azure.ServiceEndpoints) > 0 {
+			properties = map[string]string{"serviceEndpoints": strings.Join(cloud.Azure.ServiceEndpoints, ",")}
+		}
+		add(provider.PlannedInfrastructureResource{
+			Type:            "subnet",
+			Name:            cloud.Azure.SubnetName,
+			Action:          provider.PlannedInfrastructureCreate,
+			Parent:          cloud.Azure.VNetName,
+			Tags:            map[string]string{},
+			AddressPrefixes: []string{subnetCIDR(cloud)},
+			Properties:      properties,
+		})
+	}
+
+	if cloud.Azure.RouteTableName == "" && needsRouteTable(cloud) {
+		if cloud.Azure.RouteTableName, err = a.resourceName(cluster, resourceTypeRouteTable); err != nil {
+			return nil, err
+		}
+		add(provider.PlannedInfrastructureResource{
+			Type:       "routeTable",
+			Name:       cloud.Azure.RouteTableName,
+			Action:     provider.PlannedInfrastructureCreate,
+			Parent:     cloud.Azure.ResourceGroup,
+			Properties: map[string]string{"location": location, "subnet": cloud.Azure.SubnetName},
+		})
+	}
+
+	if cloud.Azure.ApplicationSecurityGroup != "" {
+		add(provider.PlannedInfrastructureResource{
+			Type:       "applicationSecurityGroup",
+			Name:       cloud.Azure.ApplicationSecurityGroup,
+			Action:     provider.PlannedInfrastructureCreateIfMissing,
+			Parent:     cloud.Azure.ResourceGroup,
+			Properties: map[string]string{"location": location},
+		})
+	}
+
+	if cloud.Azure.SecurityGroup == "" {
+		if cloud.Azure.SecurityGroup, err = a.resourceName(cluster, resourceTypeSecurityGroup); err != nil {
+			return nil, err
+		}
+		add(provider.PlannedInfrastructureResource{
+			Type:       "securityGroup",
+			Name:       cloud.Azure.SecurityGroup,
+			Action:     provider.PlannedInfrastructureCreate,
+			Parent:     cloud.Azure.ResourceGroup,
+			Rules:      plannedSecurityRules(securityGroupRules(cloud, credentials.SubscriptionID)),
+			Properties: map[string]string{"location": location, "subnet": cloud.Azure.SubnetName},
+		})
+	}
+
+	for _, subnet := range cloud.Azure.AdditionalSubnets {
+		if vnetPlanIndex >= 0 {
+			plan[vnetPlanIndex].AddressPrefixes = append(plan[vnetPlanIndex].AddressPrefixes, subnet.CIDR)
+		} else {
+			add(provider.PlannedInfrastructureResource{
+				Type:            "vnet",
+				Name:            cloud.Azure.VNetName,
+				Action:          provider.PlannedInfrastructureUpdate,
+				Parent:          vnetResourceGroup(cloud),
+				AddressPrefixes: []string{subnet.CIDR},
+				Properties:      map[string]string{"change": fmt.Sprintf("the address range of subnet %q is added, unless the address space already contains it", subnet.Name)},
+			})
+		}
+		properties := map[string]string{"securityGroup": additionalSubnetSecurityGroup(cloud, subnet)}
+		if cloud.Azure.RouteTableName != "" {
+			properties["routeTable"] = cloud.Azure.RouteTableName
+		}
+		add(provider.PlannedInfrastructureResource{
+			Type:            "subnet",
+			Name:            subnet.Name,
+			Action:          provider.PlannedInfrastructureCreateIfMissing,
+			Parent:          cloud.Azure.VNetName,
+			Tags:            map[string]string{},
+			AddressPrefixes: []string{subnet.CIDR},
+			Properties:      properties,
+		})
+	}
+
+	peerings, err := a.desiredPeerVNets(cluster.Name, cloud)
+	if err != nil {
+		return nil, err
+	}
+	// only VNets created by Kubermatic are peered
+	if cluster.Spec.Cloud.Azure.VNetName != "" && !kuberneteshelper.HasFinalizer(cluster, FinalizerVNet) {
+		peerings = nil
+	}
+	for _, name := range sortedKeys(peerings) {
+		add(provider.PlannedInfrastructureResource{
+			Type:       "vnetPeering",
+			Name:       name,
+			Action:     provider.PlannedInfrastructureCreateIfMissing,
+			Parent:     cloud.Azure.VNetName,
+			Tags:       map[string]string{},
+			Properties: map[string]string{"remoteVirtualNetwork": peerings[name]},
+		})
+	}
+
+	if cloud.Azure.PrivateCluster && cloud.Azure.PrivateEndpoint == "" {
+		if a.dc.PrivateLink == nil {
+			return nil, fmt.Errorf("datacenter does not support private clusters, no Private Link settings configured")
+		}
+		cloud.Azure.PrivateEndpoint = generatedName
+		add(provider.PlannedInfrastructureResource{
+			Type:   "privateEndpoint",
+			Name:   cloud.Azure.PrivateEndpoint,
+			Action: provider.PlannedInfrastructureCreate,
+			Parent: cloud.Azure.ResourceGroup,
+			Properties: map[string]string{
+				"location":           location,
+				"subnet":             cloud.Azure.SubnetName,
+				"privateLinkService": a.privateLinkServiceID(cluster),
+			},
+		})
+	}
+
+	for _, endpoint := range cloud.Azure.PrivateEndpoints {
+		add(provider.PlannedInfrastructureResource{
+			Type:   "privateEndpoint",
+			Name:   privateEndpointName(cluster, endpoint.Name),
+			Action: provider.PlannedInfrastructureCreateIfMissing,
+			Parent: cloud.Azure.ResourceGroup,
+			Properties: map[string]string{
+				"location":            location,
+				"subnet":              cloud.Azure.SubnetName,
+				"privateLinkResource": endpoint.PrivateLinkResourceID,
+				"groupIDs":            strings.Join(endpoint.GroupIDs, ","),
+			},
+		})
+	}
+
+	if cloud.Azure.EnableBastion {
+		if vnetPlanIndex >= 0 {
+			plan[vnetPlanIndex].AddressPrefixes = append(plan[vnetPlanIndex].AddressPrefixes, bastionSubnetCIDR)
+		} else {
+			add(provider.PlannedInfrastructureResource{
+				Type:            "vnet",
+				Name:            cloud.Azure.VNetName,
+				Action:          provider.PlannedInfrastructureUpdate,
+				Parent:          vnetResourceGroup(cloud),
+				AddressPrefixes: []string{bastionSubnetCIDR},
+				Properties:      map[string]string{"change": "the address range of the Bastion subnet is added, unless the VNet already has a Bastion subnet"},
+			})
+		}
+		add(provider.PlannedInfrastructureResource{
+			Type:            "subnet",
+			Name:            bastionSubnetName,
+			Action:          provider.PlannedInfrastructureCreateIfMissing,
+			Parent:          cloud.Azure.VNetName,
+			Tags:            map[string]string{},
+			AddressPrefixes: []string{bastionSubnetCIDR},
+		})
+		add(provider.PlannedInfrastructureResource{
+			Type:       "publicIP",
+			Name:       bastionName(cluster),
+			Action:     provider.PlannedInfrastructureCreate,
+			Parent:     cloud.Azure.ResourceGroup,
+			Properties: map[string]string{"location": location, "sku": string(network2020.PublicIPAddressSkuNameStandard), "allocation": string(network2020.Static)},
+		})
+		add(provider.PlannedInfrastructureResource{
+			Type:       "bastionHost",
+			Name:       bastionName(cluster),
+			Action:     provider.PlannedInfrastructureCreate,
+			Parent:     cloud.Azure.ResourceGroup,
+			Properties: map[string]string{"location": location, "subnet": bastionSubnetName, "publicIP": bastionName(cluster)},
+		})
+	}
+
+	if bootDiagnostics := cloud.Azure.BootDiagnostics; bootDiagnostics != nil && bootDiagnostics.StorageAccountID == "" {
+		add(provider.PlannedInfrastructureResource{
+			Type:       "storageAccount",
+			Name:       bootDiagnosticsStorageAccountName(cluster, credentials.SubscriptionID),
+			Action:     provider.PlannedInfrastructureCreate,
+			Parent:     cloud.Azure.ResourceGroup,
+			Properties: map[string]string{"location": location, "sku": string(storage.StandardLRS), "kind": string(storage.StorageV2)},
+		})
+	}
+
+	if logAnalytics := cloud.Azure.LogAnalytics; logAnalytics != nil && logAnalytics.WorkspaceID == "" {
+		retention := logAnalytics.RetentionInDays
+		if retention == 0 {
+			retention = defaultLogAnalyticsRetentionInDays
+		}
+		add(provider.PlannedInfrastructureResource{
+			Type:       "logAnalyticsWorkspace",
+			Name:       logAnalyticsWorkspaceName(cluster),
+			Action:     provider.PlannedInfrastructureCreate,
+			Parent:     cloud.Azure.ResourceGroup,
+			Properties: map[string]string{"location": location, "sku": string(operationalinsights.WorkspaceSkuNameEnumPerGB2018), "retentionInDays": strconv.Itoa(int(retention))},
+		})
+	}
+
+	if kms := cloud.Azure.KMS; kms != nil && kms.KeyVersion == "" {
+		add(provider.PlannedInfrastructureResource{
+			Type:       "keyVaultKey",
+			Name:       kms.KeyName,
+			Action:     provider.PlannedInfrastructureCreateIfMissing,
+			Parent:     kms.KeyVaultName,
+			Tags:       map[string]string{},
+			Properties: map[string]string{"type": "RSA", "size": "2048"},
+		})
+	}
+
+	if (cloud.Azure.PrivateCluster || cloud.Azure.EnablePrivateDNSZone) && cloud.Azure.PrivateDNSZone == "" {
+		zone := cluster.Address.ExternalName
+		properties := map[string]string{"link": cloud.Azure.VNetName}
+		if zone == "" {
+			properties["name"] = "derived from the external name of the cluster once its address has been reconciled"
+		} else {
+			if zone, err = privateDNSZoneName(zone); err != nil {
+				return nil, err
+			}
+		}
+		add(provider.PlannedInfrastructureResource{
+			Type:       "privateDNSZone",
+			Name:       zone,
+			Action:     provider.PlannedInfrastructureCreate,
+			Parent:     cloud.Azure.ResourceGroup,
+			Properties: properties,
+		})
+	}
+
+	if cloud.Azure.AvailabilitySet == "" {
+		if cloud.Azure.EnableProximityPlacementGroup && cloud.Azure.ProximityPlacementGroupID == "" {
+			add(provider.PlannedInfrastructureResource{
+				Type:       "proximityPlacementGroup",
+				Name:       generatedName,
+				Action:     provider.PlannedInfrastructureCreate,
+				Parent:     cloud.Azure.ResourceGroup,
+				Properties: map[string]string{"location": location, "type": string(compute.Standard)},
+			})
+		}
+
+		// the plan is assembled without calling the Azure API, so the maximum of the region is not checked
+		faultDomainCount, updateDomainCount, err := domainCounts(a.dc, faultDomainsPerRegion[location])
+		if err != nil {
+			return nil, err
+		}
+		asName, err := a.resourceName(cluster, resourceTypeAvailabilitySet)
+		if err != nil {
+			return nil, err
+		}
+		add(provider.PlannedInfrastructureResource{
+			Type:   "availabilitySet",
+			Name:   asName,
+			Action: provider.PlannedInfrastructureCreate,
+			Parent: cloud.Azure.ResourceGroup,
+			Properties: map[string]string{
+				"location":          location,
+				"faultDomainCount":  strconv.Itoa(int(faultDomainCount)),
+				"updateDomainCount": strconv.Itoa(int(updateDomainCount)),
+			},
+		})
+	}
+
+	return plan, nil
+}
+
+// plannedSecurityRules converts the rules of a security group for the plan.
+func plannedSecurityRules(rules []network2020.SecurityRule) []provider.PlannedSecurityRule {
+	planned := make([]provider.PlannedSecurityRule, 0, len(rules))
+	for _, rule := range rules {
+		if rule.SecurityRulePropertiesFormat == nil {
+			continue
+		}
+
+		destination := to.String(rule.DestinationAddressPrefix)
+		if rule.DestinationApplicationSecurityGroups != nil && len(*rule.DestinationApplicationSecurityGroups) > 0 {
+			destination = to.String((*rule.DestinationApplicationSecurityGroups)[0].ID)
+		}
+
+		source := to.String(rule.SourceAddressPrefix)
+		if rule.SourceAddressPrefixes != nil {
+			source = strings.Join(*rule.SourceAddressPrefixes, ",")
+		}
+
+		planned = append(planned, provider.PlannedSecurityRule{
+			Name:                 to.String(rule.Name),
+			Direction:            string(rule.Direction),
+			Access:               string(rule.Access),
+			Protocol:             string(rule.Protocol),
+			Priority:             to.Int32(rule.Priority),
+			Source:               source,
+			SourcePortRange:      to.String(rule.SourcePortRange),
+			Destination:          destination,
+			DestinationPortRange: to.String(rule.DestinationPortRange),
+		})
+	}
+	return planned
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
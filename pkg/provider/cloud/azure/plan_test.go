@@ -0,0 +1,153 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/provider"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func planTestCluster(spec kubermaticv1.AzureCloudSpec) *kubermaticv1.Cluster {
+	spec.TenantID = "tenant"
+	spec.SubscriptionID = "subscription"
+	spec.ClientID = "client"
+	spec.ClientSecret = "secret"
+
+	return &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-id"},
+		Spec: kubermaticv1.ClusterSpec{
+			Cloud: kubermaticv1.CloudSpec{Azure: &spec},
+		},
+	}
+}
+
+func TestPlanCloudProvider(t *testing.T) {
+	testCases := []struct {
+		name          string
+		dc            kubermaticv1.DatacenterSpecAzure
+		spec          kubermaticv1.AzureCloudSpec
+		expectedTypes []string
+		expectedError bool
+	}{
+		{
+			name: "all resources are generated",
+			dc:   kubermaticv1.DatacenterSpecAzure{Location: "westeurope"},
+			expectedTypes: []string{
+				"resourceGroup", "vnet", "subnet", "routeTable", "securityGroup", "availabilitySet",
+			},
+		},
+		{
+			name: "resources provided by the user are not planned",
+			dc:   kubermaticv1.DatacenterSpecAzure{Location: "westeurope"},
+			spec: kubermaticv1.AzureCloudSpec{
+				ResourceGroup:   "rg",
+				VNetName:        "vnet",
+				SubnetName:      "subnet",
+				RouteTableName:  "rt",
+				SecurityGroup:   "sg",
+				AvailabilitySet: "as",
+			},
+		},
+		{
+			name: "bastion in an existing vnet",
+			dc:   kubermaticv1.DatacenterSpecAzure{Location: "westeurope"},
+			spec: kubermaticv1.AzureCloudSpec{
+				ResourceGroup:   "rg",
+				VNetName:        "vnet",
+				SubnetName:      "subnet",
+				RouteTableName:  "rt",
+				SecurityGroup:   "sg",
+				AvailabilitySet: "as",
+				EnableBastion:   true,
+			},
+			expectedTypes: []string{"vnet", "subnet", "publicIP", "bastionHost"},
+		},
+		{
+			name:          "unknown region",
+			dc:            kubermaticv1.DatacenterSpecAzure{Location: "atlantis"},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dc := tc.dc
+			a := &Azure{dc: &dc}
+
+			plan, err := a.PlanCloudProvider(planTestCluster(tc.spec))
+			if (err != nil) != tc.expectedError {
+				t.Fatalf("expected error: %v, got: %v", tc.expectedError, err)
+			}
+			if tc.expectedError {
+				return
+			}
+
+			if len(plan) != len(tc.expectedTypes) {
+				t.Fatalf("expected %d resources, got %d: %+v", len(tc.expectedTypes), len(plan), plan)
+			}
+			for i, resource := range plan {
+				if resource.Type != tc.expectedTypes[i] {
+					t.Errorf("expected resource %d to be of type %q, got %q", i, tc.expectedTypes[i], resource.Type)
+				}
+			}
+		})
+	}
+}
+
+func TestPlanCloudProviderGeneratedResources(t *testing.T) {
+	a := &Azure{dc: &kubermaticv1.DatacenterSpecAzure{Location: "westeurope"}}
+
+	plan, err := a.PlanCloudProvider(planTestCluster(kubermaticv1.AzureCloudSpec{EnableBastion: true}))
+	if err != nil {
+		t.Fatalf("failed to plan: %v", err)
+	}
+
+	resources := map[string]provider.PlannedInfrastructureResource{}
+	for _, resource := range plan {
+		resources[resource.Type+"/"+resource.Name] = resource
+	}
+
+	vnet, ok := resources["vnet/"+resourceNamePrefix+"cluster-id"]
+	if !ok {
+		t.Fatalf("expected the vnet to be planned, got %+v", plan)
+	}
+	if vnet.Action != provider.PlannedInfrastructureCreate {
+		t.Errorf("expected the vnet to be created, got action %q", vnet.Action)
+	}
+	if len(vnet.AddressPrefixes) != 2 || vnet.AddressPrefixes[0] != defaultVNetCIDR || vnet.AddressPrefixes[1] != bastionSubnetCIDR {
+		t.Errorf("expected the vnet to have the address prefixes %q and %q, got %v", defaultVNetCIDR, bastionSubnetCIDR, vnet.AddressPrefixes)
+	}
+	if vnet.Tags[clusterTagKey] != "cluster-id" {
+		t.Errorf("expected the vnet to be tagged with the cluster, got %v", vnet.Tags)
+	}
+
+	sg, ok := resources["securityGroup/"+resourceNamePrefix+"cluster-id"]
+	if !ok {
+		t.Fatalf("expected the security group to be planned, got %+v", plan)
+	}
+	if len(sg.Rules) == 0 {
+		t.Error("expected the security group to have rules")
+	}
+
+	if _, ok := resources["subnet/"+bastionSubnetName]; !ok {
+		t.Errorf("expected the bastion subnet to be planned, got %+v", plan)
+	}
+}
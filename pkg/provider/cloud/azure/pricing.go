@@ -0,0 +1,268 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	compute2020 "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-30/compute"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+)
+
+const (
+	// hoursPerMonth is the number of hours Azure bills for a month.
+	hoursPerMonth = 730
+	// defaultCurrency is the currency of the estimates if none is requested.
+	defaultCurrency = "USD"
+)
+
+// retailPricesURL is the endpoint of the Azure Retail Prices API, which can be queried without
+// credentials.
+var retailPricesURL = "https://prices.azure.com/api/retail/prices"
+
+// diskTier is a size tier of managed disks, disks are billed by the smallest tier they fit in.
+type diskTier struct {
+	suffix string
+	sizeGB int32
+}
+
+// https://docs.microsoft.com/en-us/azure/virtual-machines/disks-types
+var diskTiers = []diskTier{
+	{"1", 4}, {"2", 8}, {"3", 16}, {"4", 32}, {"6", 64}, {"10", 128}, {"15", 256}, {"20", 512},
+	{"30", 1024}, {"40", 2048}, {"50", 4096}, {"60", 8192}, {"70", 16384}, {"80", 32767},
+}
+
+// diskTierPrefixes maps the storage account types of managed disks to the prefix of their tiers.
+var diskTierPrefixes = map[compute2020.StorageAccountTypes]string{
+	compute2020.StorageAccountTypesPremiumLRS:     "P",
+	compute2020.StorageAccountTypesStandardSSDLRS: "E",
+	compute2020.StorageAccountTypesStandardLRS:    "S",
+}
+
+// retailPrice is an item returned by the Azure Retail Prices API.
+type retailPrice struct {
+	CurrencyCode  string  `json:"currencyCode"`
+	RetailPrice   float64 `json:"retailPrice"`
+	UnitOfMeasure string  `json:"unitOfMeasure"`
+	ProductName   string  `json:"productName"`
+	SkuName       string  `json:"skuName"`
+	MeterName     string  `json:"meterName"`
+}
+
+type retailPricesPage struct {
+	Items        []retailPrice `json:"Items"`
+	NextPageLink string        `json:"NextPageLink"`
+}
+
+// CostEstimateRequest describes the nodes whose costs are estimated.
+type CostEstimateRequest struct {
+	Location     string
+	Size         string
+	DiskType     string
+	OSDiskSize   int32
+	DataDiskSize int32
+	Replicas     int32
+	Currency     string
+}
+
+// Validate returns an error if the request is incomplete.
+func (r CostEstimateRequest) Validate() error {
+	if r.Location == "" {
+		return fmt.Errorf("location is required")
+	}
+	if r.Size == "" {
+		return fmt.Errorf("VM size is required")
+	}
+	if r.Replicas < 0 {
+		return fmt.Errorf("replicas must not be negative")
+	}
+	if r.OSDiskSize < 0 || r.DataDiskSize < 0 {
+		return fmt.Errorf("disk sizes must not be negative")
+	}
+	if _, ok := diskTierPrefixes[compute2020.StorageAccountTypes(r.DiskType)]; r.DiskType != "" && !ok {
+		return fmt.Errorf("unsupported disk type %q", r.DiskType)
+	}
+	return nil
+}
+
+// EstimateCosts returns the estimated monthly costs of the requested nodes based on the retail
+// prices of pay-as-you-go Linux VMs and managed disks. Reservations, savings plans and discounts
+// of the subscription are not taken into account.
+func EstimateCosts(ctx context.Context, req CostEstimateRequest) (*apiv1.AzureCostEstimate, error) {
+	if req.Currency == "" {
+		req.Currency = defaultCurrency
+	}
+	if req.DiskType == "" {
+		req.DiskType = string(compute2020.StorageAccountTypesStandardLRS)
+	}
+
+	vmPrice, err := vmHourlyPrice(ctx, req.Location, req.Size, req.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	estimate := &apiv1.AzureCostEstimate{
+		Currency:      req.Currency,
+		Replicas:      req.Replicas,
+		VMHourlyPrice: vmPrice,
+	}
+
+	if req.OSDiskSize > 0 {
+		tier, price, err := diskMonthlyPrice(ctx, req.Location, req.DiskType, req.OSDiskSize, req.Currency)
+		if err != nil {
+			return nil, err
+		}
+		estimate.OSDiskTier = tier
+		estimate.OSDiskMonthlyPrice = price
+	}
+	if req.DataDiskSize > 0 {
+		tier, price, err := diskMonthlyPrice(ctx, req.Location, req.DiskType, req.DataDiskSize, req.Currency)
+		if err != nil {
+			return nil, err
+		}
+		estimate.DataDiskTier = tier
+		estimate.DataDiskMonthlyPrice = price
+	}
+
+	estimate.NodeMonthlyPrice = vmPrice*hoursPerMonth + estimate.OSDiskMonthlyPrice + estimate.DataDiskMonthlyPrice
+	estimate.MonthlyPrice = estimate.NodeMonthlyPrice * float64(req.Replicas)
+
+	return estimate, nil
+}
+
+// vmHourlyPrice returns the pay-as-you-go price of a Linux VM per hour.
+func vmHourlyPrice(ctx context.Context, location, size, currency string) (float64, error) {
+	filter := fmt.Sprintf("serviceName eq 'Virtual Machines' and priceType eq 'Consumption' and armRegionName eq '%s' and armSkuName eq '%s'", location, size)
+	prices, err := listRetailPrices(ctx, filter, currency)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, price := range prices {
+		// Windows VMs include the license, spot and low priority VMs can be evicted at any time
+		if strings.Contains(price.ProductName, "Windows") || strings.Contains(price.SkuName, "Spot") || strings.Contains(price.SkuName, "Low Priority") {
+			continue
+		}
+		if price.UnitOfMeasure == "1 Hour" {
+			return price.RetailPrice, nil
+		}
+	}
+
+	return 0, fmt.Errorf("no price found for VM size %q in %s", size, location)
+}
+
+// diskMonthlyPrice returns the tier a managed disk of the given size is billed as and its price
+// per month.
+func diskMonthlyPrice(ctx context.Context, location, diskType string, sizeGB int32, currency string) (string, float64, error) {
+	tier, err := managedDiskTier(compute2020.StorageAccountTypes(diskType), sizeGB)
+	if err != nil {
+		return "", 0, err
+	}
+
+	// the redundancy of the disk is part of the SKU name, e.g. "P10 LRS"
+	redundancy := diskType[strings.LastIndex(diskType, "_")+1:]
+	skuName := fmt.Sprintf("%s %s", tier, redundancy)
+
+	filter := fmt.Sprintf("serviceName eq 'Storage' and priceType eq 'Consumption' and armRegionName eq '%s' and skuName eq '%s'", location, skuName)
+	prices, err := listRetailPrices(ctx, filter, currency)
+	if err != nil {
+		return "", 0, err
+	}
+
+	for _, price := range prices {
+		// the SKUs also have meters for transactions and disk bursting
+		if price.UnitOfMeasure == "1/Month" && strings.HasSuffix(price.MeterName, "Disk") {
+			return tier, price.RetailPrice, nil
+		}
+	}
+
+	return "", 0, fmt.Errorf("no price found for %s disk %q in %s", diskType, tier, location)
+}
+
+// managedDiskTier returns the smallest tier of the disk type a disk of the given size fits in.
+func managedDiskTier(diskType compute2020.StorageAccountTypes, sizeGB int32) (string, error) {
+	prefix, ok := diskTierPrefixes[diskType]
+	if !ok {
+		return "", fmt.Errorf("unsupported disk type %q", diskType)
+	}
+
+	for _, tier := range diskTiers {
+		// standard HDDs have no tiers smaller than 32 GB
+		if diskType == compute2020.StorageAccountTypesStandardLRS && tier.sizeGB < 32 {
+			continue
+		}
+		if sizeGB <= tier.sizeGB {
+			return prefix + tier.suffix, nil
+		}
+	}
+
+	return "", fmt.Errorf("disk size %d GB exceeds the largest managed disk", sizeGB)
+}
+
+// listRetailPrices returns all retail prices matching the OData filter.
+func listRetailPrices(ctx context.Context, filter, currency string) ([]retailPrice, error) {
+	query := url.Values{}
+	query.Set("currencyCode", currency)
+	query.Set("$filter", filter)
+	next := retailPricesURL + "?" + query.Encode()
+
+	client := apiProxy.get()
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	var prices []retailPrice
+	for next != "" {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, next, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		page, err := getRetailPricesPage(client, req)
+		if err != nil {
+			return nil, err
+		}
+		prices = append(prices, page.Items...)
+		next = page.NextPageLink
+	}
+
+	return prices, nil
+}
+
+func getRetailPricesPage(client *http.Client, req *http.Request) (*retailPricesPage, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query retail prices: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to query retail prices: %s", resp.Status)
+	}
+
+	page := &retailPricesPage{}
+	if err := json.NewDecoder(resp.Body).Decode(page); err != nil {
+		return nil, fmt.Errorf("failed to decode retail prices: %w", err)
+	}
+	return page, nil
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	compute2020 "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-30/compute"
+	"github.com/go-test/deep"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+)
+
+func TestManagedDiskTier(t *testing.T) {
+	tests := []struct {
+		name     string
+		diskType compute2020.StorageAccountTypes
+		sizeGB   int32
+		expected string
+		err      bool
+	}{
+		{
+			name:     "premium disk fitting a tier exactly",
+			diskType: compute2020.StorageAccountTypesPremiumLRS,
+			sizeGB:   128,
+			expected: "P10",
+		},
+		{
+			name:     "standard SSD rounded up to the next tier",
+			diskType: compute2020.StorageAccountTypesStandardSSDLRS,
+			sizeGB:   129,
+			expected: "E15",
+		},
+		{
+			name:     "small standard HDD billed as the smallest HDD tier",
+			diskType: compute2020.StorageAccountTypesStandardLRS,
+			sizeGB:   10,
+			expected: "S4",
+		},
+		{
+			name:     "disk exceeding the largest tier",
+			diskType: compute2020.StorageAccountTypesPremiumLRS,
+			sizeGB:   40000,
+			err:      true,
+		},
+		{
+			name:     "unsupported disk type",
+			diskType: compute2020.StorageAccountTypesUltraSSDLRS,
+			sizeGB:   128,
+			err:      true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tier, err := managedDiskTier(test.diskType, test.sizeGB)
+			if (err != nil) != test.err {
+				t.Fatalf("expected error: %v, got: %v", test.err, err)
+			}
+			if tier != test.expected {
+				t.Errorf("expected tier %q, got %q", test.expected, tier)
+			}
+		})
+	}
+}
+
+func TestEstimateCosts(t *testing.T) {
+	prices := map[string][]retailPrice{
+		"armSkuName eq 'Standard_D2s_v3'": {
+			{RetailPrice: 0.188, UnitOfMeasure: "1 Hour", ProductName: "Virtual Machines DSv3 Series Windows", SkuName: "D2s v3"},
+			{RetailPrice: 0.02, UnitOfMeasure: "1 Hour", ProductName: "Virtual Machines DSv3 Series", SkuName: "D2s v3 Spot"},
+			{RetailPrice: 0.096, UnitOfMeasure: "1 Hour", ProductName: "Virtual Machines DSv3 Series", SkuName: "D2s v3"},
+		},
+		"skuName eq 'P10 LRS'": {
+			{RetailPrice: 0.0005, UnitOfMeasure: "10K", MeterName: "Disk Operations"},
+			{RetailPrice: 19.71, UnitOfMeasure: "1/Month", MeterName: "P10 LRS Disk"},
+		},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if currency := r.URL.Query().Get("currencyCode"); currency != "EUR" {
+			t.Errorf("expected prices in EUR, got %q", currency)
+		}
+		page := retailPricesPage{}
+		for filter, items := range prices {
+			if strings.Contains(r.URL.Query().Get("$filter"), filter) {
+				page.Items = items
+			}
+		}
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+	defer server.Close()
+
+	defaultURL := retailPricesURL
+	retailPricesURL = server.URL
+	defer func() { retailPricesURL = defaultURL }()
+
+	estimate, err := EstimateCosts(context.Background(), CostEstimateRequest{
+		Location:   "westeurope",
+		Size:       "Standard_D2s_v3",
+		DiskType:   string(compute2020.StorageAccountTypesPremiumLRS),
+		OSDiskSize: 100,
+		Replicas:   3,
+		Currency:   "EUR",
+	})
+	if err != nil {
+		t.Fatalf("failed to estimate costs: %v", err)
+	}
+
+	nodePrice := 0.096*hoursPerMonth + 19.71
+	expected := &apiv1.AzureCostEstimate{
+		Currency:           "EUR",
+		Replicas:           3,
+		VMHourlyPrice:      0.096,
+		OSDiskTier:         "P10",
+		OSDiskMonthlyPrice: 19.71,
+		NodeMonthlyPrice:   nodePrice,
+		MonthlyPrice:       nodePrice * 3,
+	}
+	if diff := deep.Equal(estimate, expected); diff != nil {
+		t.Errorf("unexpected estimate, diff: %v", diff)
+	}
+
+	if _, err := EstimateCosts(context.Background(), CostEstimateRequest{Location: "westeurope", Size: "Standard_Unknown", Currency: "EUR"}); err == nil {
+		t.Error("expected an error for a VM size without price")
+	}
+}
@@ -0,0 +1,270 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	network2020 "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	autorestazure "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/to"
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	kubermaticresources "k8c.io/kubermatic/v2/pkg/resources"
+)
+
+// privateLinkServiceID returns the ID of the Private Link service which the seed's cloud provider
+// creates for the front LoadBalancer of the cluster.
+func (a *Azure) privateLinkServiceID(cluster *kubermaticv1.Cluster) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/privateLinkServices/%s",
+		a.dc.PrivateLink.SubscriptionID, a.dc.PrivateLink.ResourceGroup, kubermaticresources.AzurePrivateLinkServiceName(cluster))
+}
+
+// reconcilePrivateCluster creates the private endpoint connecting the cluster's VNet to the API server.
+func (a *Azure) reconcilePrivateCluster(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, credentials Credentials, location string, tags map[string]*string) (*kubermaticv1.Cluster, error) {
+	var err error
+	logger := a.log.With("cluster", cluster.Name)
+
+	if a.dc.PrivateLink == nil {
+		return cluster, fmt.Errorf("datacenter does not support private clusters, no Private Link settings configured")
+	}
+
+	if cluster.Spec.Cloud.Azure.PrivateEndpoint == "" {
+		cluster.Spec.Cloud.Azure.PrivateEndpoint = resourceNamePrefix + cluster.Name
+
+		// private endpoints can only be placed in subnets with disabled network policies
+		if err = a.pollOperation(cluster, update, credentials, "disable-subnet-network-policies", func() (autorestazure.FutureAPI, error) {
+			return disablePrivateEndpointNetworkPolicies(a.ctx, cluster.Spec.Cloud, credentials)
+		}); err != nil {
+			return cluster, fmt.Errorf("failed to disable network policies of subnetwork %q: %w", cluster.Spec.Cloud.Azure.SubnetName, err)
+		}
+
+		logger.Infow("ensuring private endpoint", "privateEndpoint", cluster.Spec.Cloud.Azure.PrivateEndpoint)
+		if err = a.pollOperation(cluster, update, credentials, "create-private-endpoint", func() (autorestazure.FutureAPI, error) {
+			return ensurePrivateEndpoint(a.ctx, cluster.Spec.Cloud, location, tags, a.privateLinkServiceID(cluster), credentials)
+		}); err != nil {
+			return cluster, fmt.Errorf("failed to create or update private endpoint %q: %w", cluster.Spec.Cloud.Azure.PrivateEndpoint, err)
+		}
+
+		cluster, err = update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+			updatedCluster.Spec.Cloud.Azure.PrivateEndpoint = cluster.Spec.Cloud.Azure.PrivateEndpoint
+			kuberneteshelper.AddFinalizer(updatedCluster, FinalizerPrivateEndpoint)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cluster.Spec.Cloud.Azure.PrivateEndpointIP == "" {
+		ip, err := getPrivateEndpointIP(a.ctx, cluster.Spec.Cloud, credentials)
+		if err != nil {
+			return cluster, fmt.Errorf("failed to get IP of private endpoint %q: %w", cluster.Spec.Cloud.Azure.PrivateEndpoint, err)
+		}
+
+		cluster, err = update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+			updatedCluster.Spec.Cloud.Azure.PrivateEndpointIP = ip
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return cluster, nil
+}
+
+// cleanUpPrivateCluster removes the private endpoint of the cluster. It must be removed before
+// the subnet and the VNet can be deleted.
+func (a *Azure) cleanUpPrivateCluster(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, credentials Credentials, logger *zap.SugaredLogger) (*kubermaticv1.Cluster, error) {
+	var err error
+
+	if kuberneteshelper.HasFinalizer(cluster, FinalizerPrivateEndpoint) {
+		logger.Infow("deleting private endpoint", "privateEndpoint", cluster.Spec.Cloud.Azure.PrivateEndpoint)
+		if err := a.pollOperation(cluster, update, credentials, "delete-private-endpoint", func() (autorestazure.FutureAPI, error) {
+			return deletePrivateEndpoint(a.ctx, cluster.Spec.Cloud, credentials)
+		}); err != nil {
+			if !isNotFound(err) {
+				return cluster, fmt.Errorf("failed to delete private endpoint %q: %w", cluster.Spec.Cloud.Azure.PrivateEndpoint, err)
+			}
+		}
+
+		cluster, err = update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+			kuberneteshelper.RemoveFinalizer(updatedCluster, FinalizerPrivateEndpoint)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return cluster, nil
+}
+
+// disablePrivateEndpointNetworkPolicies disables the network policies for private endpoints on the
+// cluster's subnet. No operation is started if they are disabled already.
+func disablePrivateEndpointNetworkPolicies(ctx context.Context, cloud kubermaticv1.CloudSpec, credentials Credentials) (autorestazure.FutureAPI, error) {
+	subnetsClient, err := getPrivateLinkSubnetsClient(cloud, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	subnet, err := subnetsClient.Get(ctx, vnetResourceGroup(cloud), cloud.Azure.VNetName, cloud.Azure.SubnetName, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if subnet.SubnetPropertiesFormat == nil {
+		subnet.SubnetPropertiesFormat = &network2020.SubnetPropertiesFormat{}
+	}
+	if strings.EqualFold(to.String(subnet.PrivateEndpointNetworkPolicies), "Disabled") {
+		return nil, nil
+	}
+	subnet.PrivateEndpointNetworkPolicies = to.StringPtr("Disabled")
+
+	future, err := subnetsClient.CreateOrUpdate(ctx, vnetResourceGroup(cloud), cloud.Azure.VNetName, cloud.Azure.SubnetName, subnet)
+	if err != nil {
+		return nil, err
+	}
+
+	return future.FutureAPI, nil
+}
+
+// ensurePrivateEndpoint will start creating or updating the private endpoint connected to the given
+// Private Link service. The call is idempotent.
+func ensurePrivateEndpoint(ctx context.Context, cloud kubermaticv1.CloudSpec, location string, tags map[string]*string, privateLinkServiceID string, credentials Credentials) (autorestazure.FutureAPI, error) {
+	endpointsClient, err := getPrivateEndpointsClient(cloud, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	parameters := network2020.PrivateEndpoint{
+		Name:     to.StringPtr(cloud.Azure.PrivateEndpoint),
+		Location: to.StringPtr(location),
+		Tags:     tags,
+		PrivateEndpointProperties: &network2020.PrivateEndpointProperties{
+			Subnet: &network2020.Subnet{
+				ID: to.StringPtr(assembleSubnetIDForSubscription(cloud, credentials.SubscriptionID)),
+			},
+			PrivateLinkServiceConnections: &[]network2020.PrivateLinkServiceConnection{
+				{
+					Name: to.StringPtr(cloud.Azure.PrivateEndpoint),
+					PrivateLinkServiceConnectionProperties: &network2020.PrivateLinkServiceConnectionProperties{
+						PrivateLinkServiceID: to.StringPtr(privateLinkServiceID),
+					},
+				},
+			},
+		},
+	}
+
+	future, err := endpointsClient.CreateOrUpdate(ctx, cloud.Azure.ResourceGroup, cloud.Azure.PrivateEndpoint, parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	return future.FutureAPI, nil
+}
+
+// getPrivateEndpointIP returns the private IP address of the private endpoint's network interface.
+func getPrivateEndpointIP(ctx context.Context, cloud kubermaticv1.CloudSpec, credentials Credentials) (string, error) {
+	endpointsClient, err := getPrivateEndpointsClient(cloud, credentials)
+	if err != nil {
+		return "", err
+	}
+
+	endpoint, err := endpointsClient.Get(ctx, cloud.Azure.ResourceGroup, cloud.Azure.PrivateEndpoint, "")
+	if err != nil {
+		return "", err
+	}
+	if endpoint.PrivateEndpointProperties == nil || endpoint.NetworkInterfaces == nil || len(*endpoint.NetworkInterfaces) == 0 {
+		return "", fmt.Errorf("private endpoint has no network interface")
+	}
+
+	nicID, err := autorestazure.ParseResourceID(to.String((*endpoint.NetworkInterfaces)[0].ID))
+	if err != nil {
+		return "", err
+	}
+
+	interfacesClient, err := getPrivateLinkInterfacesClient(cloud, credentials)
+	if err != nil {
+		return "", err
+	}
+
+	nic, err := interfacesClient.Get(ctx, nicID.ResourceGroup, nicID.ResourceName, "")
+	if err != nil {
+		return "", err
+	}
+	if nic.InterfacePropertiesFormat != nil && nic.IPConfigurations != nil {
+		for _, ipConfig := range *nic.IPConfigurations {
+			if ipConfig.InterfaceIPConfigurationPropertiesFormat != nil && to.String(ipConfig.PrivateIPAddress) != "" {
+				return *ipConfig.PrivateIPAddress, nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("network interface %q has no private IP address", nicID.ResourceName)
+}
+
+func deletePrivateEndpoint(ctx context.Context, cloud kubermaticv1.CloudSpec, credentials Credentials) (autorestazure.FutureAPI, error) {
+	endpointsClient, err := getPrivateEndpointsClient(cloud, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	future, err := endpointsClient.Delete(ctx, cloud.Azure.ResourceGroup, cloud.Azure.PrivateEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	return future.FutureAPI, nil
+}
+
+func getPrivateLinkSubnetsClient(cloud kubermaticv1.CloudSpec, credentials Credentials) (*network2020.SubnetsClient, error) {
+	var err error
+	credentials = networkCredentials(cloud, credentials)
+	subnetsClient := network2020.NewSubnetsClient(credentials.SubscriptionID)
+	err = configureClient(&subnetsClient.Client, credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authorizer: %s", err.Error())
+	}
+
+	return &subnetsClient, nil
+}
+
+func getPrivateEndpointsClient(cloud kubermaticv1.CloudSpec, credentials Credentials) (*network2020.PrivateEndpointsClient, error) {
+	var err error
+	endpointsClient := network2020.NewPrivateEndpointsClient(credentials.SubscriptionID)
+	err = configureClient(&endpointsClient.Client, credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authorizer: %s", err.Error())
+	}
+
+	return &endpointsClient, nil
+}
+
+func getPrivateLinkInterfacesClient(cloud kubermaticv1.CloudSpec, credentials Credentials) (*network2020.InterfacesClient, error) {
+	var err error
+	interfacesClient := network2020.NewInterfacesClient(credentials.SubscriptionID)
+	err = configureClient(&interfacesClient.Client, credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authorizer: %s", err.Error())
+	}
+
+	return &interfacesClient, nil
+}
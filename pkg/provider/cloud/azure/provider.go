@@ -20,13 +20,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"net/http"
+	"net"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2018-06-01/compute"
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-06-01/network"
+	network2020 "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2016-09-01/locks"
 	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2018-02-01/resources"
-	"github.com/Azure/go-autorest/autorest"
-	"github.com/Azure/go-autorest/autorest/azure/auth"
+	autorestazure "github.com/Azure/go-autorest/autorest/azure"
 	"github.com/Azure/go-autorest/autorest/to"
 	"go.uber.org/zap"
 
@@ -35,12 +37,26 @@ import (
 	"k8c.io/kubermatic/v2/pkg/log"
 	"k8c.io/kubermatic/v2/pkg/provider"
 	kubermaticresources "k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
 )
 
 const (
 	resourceNamePrefix = "kubernetes-"
 
 	clusterTagKey = "cluster"
+	// managedTagsKey is the tag that lists the keys of all tags Kubermatic has set on a
+	// resource, so that tags which were removed from the spec can be removed from Azure.
+	managedTagsKey = "kubermatic-managed-tags"
+	// installationTagKey identifies the Kubermatic installation that created a resource, so that
+	// the garbage collection of one installation never considers the resources of another one.
+	installationTagKey = "kubermatic-installation"
+	// maxTagValueLength is the maximum length of the value of an Azure tag.
+	maxTagValueLength = 256
+
+	resourceGroupLockName = "kubermatic-cluster-protection"
 
 	// FinalizerSecurityGroup will instruct the deletion of the security group
 	FinalizerSecurityGroup = "kubermatic.io/cleanup-azure-security-group"
@@ -54,10 +70,48 @@ const (
 	FinalizerResourceGroup = "kubermatic.io/cleanup-azure-resource-group"
 	// FinalizerAvailabilitySet will instruct the deletion of the availability set
 	FinalizerAvailabilitySet = "kubermatic.io/cleanup-azure-availability-set"
-
-	denyAllTCPSecGroupRuleName   = "deny_all_tcp"
-	denyAllUDPSecGroupRuleName   = "deny_all_udp"
-	allowAllICMPSecGroupRuleName = "icmp_by_allow_all"
+	// FinalizerMachineDeploymentAvailabilitySets will instruct the deletion of the availability sets of the MachineDeployments
+	FinalizerMachineDeploymentAvailabilitySets = "kubermatic.io/cleanup-azure-machine-deployment-availability-sets"
+	// FinalizerProximityPlacementGroup will instruct the deletion of the proximity placement group
+	FinalizerProximityPlacementGroup = "kubermatic.io/cleanup-azure-proximity-placement-group"
+	// FinalizerPrivateEndpoint will instruct the deletion of the private endpoint of private clusters
+	FinalizerPrivateEndpoint = "kubermatic.io/cleanup-azure-private-endpoint"
+	// FinalizerPrivateDNSZone will instruct the deletion of the private DNS zone
+	FinalizerPrivateDNSZone = "kubermatic.io/cleanup-azure-private-dns-zone"
+	// FinalizerPrivateDNSZoneLink will instruct the deletion of the link between the private DNS zone and the VNet
+	FinalizerPrivateDNSZoneLink = "kubermatic.io/cleanup-azure-private-dns-zone-link"
+	// FinalizerResourceGroupLock will instruct the deletion of the management lock on the resource group
+	FinalizerResourceGroupLock = "kubermatic.io/cleanup-azure-resource-group-lock"
+	// FinalizerVNetPeerings will instruct the deletion of the peerings between the VNet and the hub VNets
+	FinalizerVNetPeerings = "kubermatic.io/cleanup-azure-vnet-peerings"
+	// FinalizerPrivateEndpoints will instruct the deletion of the user-defined private endpoints
+	FinalizerPrivateEndpoints = "kubermatic.io/cleanup-azure-private-endpoints"
+	// FinalizerAdditionalSubnets will instruct the deletion of the additional subnets created by Kubermatic
+	FinalizerAdditionalSubnets = "kubermatic.io/cleanup-azure-additional-subnets"
+	// FinalizerBastion will instruct the deletion of the Bastion host and its public IP
+	FinalizerBastion = "kubermatic.io/cleanup-azure-bastion"
+	// FinalizerBootDiagnosticsStorageAccount will instruct the deletion of the storage account for boot diagnostics
+	FinalizerBootDiagnosticsStorageAccount = "kubermatic.io/cleanup-azure-boot-diagnostics-storage-account"
+	// FinalizerApplicationSecurityGroup will instruct the deletion of the application security group
+	FinalizerApplicationSecurityGroup = "kubermatic.io/cleanup-azure-application-security-group"
+
+	allowICMPSecGroupRuleName = "icmp_allow_all"
+
+	// Older clusters allowed ICMP by denying all TCP and UDP traffic and allowing everything else,
+	// these rules get replaced by the ICMP rule.
+	legacyDenyAllTCPSecGroupRuleName   = "deny_all_tcp"
+	legacyDenyAllUDPSecGroupRuleName   = "deny_all_udp"
+	legacyAllowAllICMPSecGroupRuleName = "icmp_by_allow_all"
+
+	// Priorities of the generated security rules are offsets from the configured base priority.
+	defaultSecurityGroupRulePriority int32 = 100
+	maxSecurityGroupRulePriority     int32 = 4096
+	icmpSecGroupRulePriorityOffset   int32 = 700
+
+	// defaultVNetCIDR is the address space of VNets created by Kubermatic.
+	defaultVNetCIDR = "10.0.0.0/16"
+	// defaultSubnetCIDR is the address range of subnets created by Kubermatic.
+	defaultSubnetCIDR = "10.0.0.0/16"
 )
 
 type Azure struct {
@@ -65,6 +119,8 @@ type Azure struct {
 	log               *zap.SugaredLogger
 	ctx               context.Context
 	secretKeySelector provider.SecretKeySelectorValueFunc
+	recorder          record.EventRecorder
+	installationID    string
 }
 
 // New returns a new Azure provider.
@@ -80,8 +136,8 @@ func New(dc *kubermaticv1.Datacenter, secretKeyGetter provider.SecretKeySelector
 	}, nil
 }
 
-// Azure API doesn't allow programmatically getting the number of available fault domains in a given region.
-// We must therefore hardcode these based on https://docs.microsoft.com/en-us/azure/virtual-machines/windows/manage-availability
+// faultDomainsPerRegion is the number of available fault domains of the regions, which is used if
+// the SKU API does not report it. It is based on https://docs.microsoft.com/en-us/azure/virtual-machines/windows/manage-availability
 //
 // The list of region codes was generated by `az account list-locations | jq .[].id --raw-output | cut -d/ -f5 | sed -e 's/^/"/' -e 's/$/" : ,/'`
 var faultDomainsPerRegion = map[string]int32{
@@ -113,22 +169,18 @@ var faultDomainsPerRegion = map[string]int32{
 	"koreasouth":         2,
 }
 
-func deleteSubnet(ctx context.Context, cloud kubermaticv1.CloudSpec, credentials Credentials) error {
+func deleteSubnet(ctx context.Context, cloud kubermaticv1.CloudSpec, credentials Credentials) (autorestazure.FutureAPI, error) {
 	subnetsClient, err := getSubnetsClient(cloud, credentials)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	deleteSubnetFuture, err := subnetsClient.Delete(ctx, cloud.Azure.ResourceGroup, cloud.Azure.VNetName, cloud.Azure.SubnetName)
 	if err != nil {
-		return err
-	}
-
-	if err = deleteSubnetFuture.WaitForCompletionRef(ctx, subnetsClient.Client); err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return deleteSubnetFuture.FutureAPI, nil
 }
 
 func deleteAvailabilitySet(ctx context.Context, cloud kubermaticv1.CloudSpec, credentials Credentials) error {
@@ -141,83 +193,92 @@ func deleteAvailabilitySet(ctx context.Context, cloud kubermaticv1.CloudSpec, cr
 	return err
 }
 
-func deleteVNet(ctx context.Context, cloud kubermaticv1.CloudSpec, credentials Credentials) error {
-	networksClient, err := getNetworksClient(cloud, credentials)
+func deleteProximityPlacementGroup(ctx context.Context, cloud kubermaticv1.CloudSpec, credentials Credentials) error {
+	ppgClient, err := getProximityPlacementGroupClient(cloud, credentials)
 	if err != nil {
 		return err
 	}
 
-	deleteVNetFuture, err := networksClient.Delete(ctx, cloud.Azure.ResourceGroup, cloud.Azure.VNetName)
+	resource, err := autorestazure.ParseResourceID(cloud.Azure.ProximityPlacementGroupID)
 	if err != nil {
 		return err
 	}
 
-	if err = deleteVNetFuture.WaitForCompletionRef(ctx, networksClient.Client); err != nil {
-		return err
+	_, err = ppgClient.Delete(ctx, resource.ResourceGroup, resource.ResourceName)
+	return err
+}
+
+func deleteVNet(ctx context.Context, cloud kubermaticv1.CloudSpec, credentials Credentials) (autorestazure.FutureAPI, error) {
+	networksClient, err := getNetworksClient(cloud, credentials)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	deleteVNetFuture, err := networksClient.Delete(ctx, cloud.Azure.ResourceGroup, cloud.Azure.VNetName)
+	if err != nil {
+		return nil, err
+	}
+
+	return deleteVNetFuture.FutureAPI, nil
 }
 
-func deleteResourceGroup(ctx context.Context, cloud kubermaticv1.CloudSpec, credentials Credentials) error {
+func deleteResourceGroup(ctx context.Context, cloud kubermaticv1.CloudSpec, credentials Credentials) (autorestazure.FutureAPI, error) {
 	groupsClient, err := getGroupsClient(cloud, credentials)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// We're doing a Get to see if its already gone or not.
 	// We could also directly call delete but the error response would need to be unpacked twice to get the correct error message.
 	// Doing a get is simpler.
 	if _, err := groupsClient.Get(ctx, cloud.Azure.ResourceGroup); err != nil {
-		return err
+		return nil, err
 	}
 
 	future, err := groupsClient.Delete(ctx, cloud.Azure.ResourceGroup)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if err = future.WaitForCompletionRef(ctx, groupsClient.Client); err != nil {
+	return future.FutureAPI, nil
+}
+
+func deleteResourceGroupLock(ctx context.Context, cloud kubermaticv1.CloudSpec, credentials Credentials) error {
+	locksClient, err := getManagementLocksClient(cloud, credentials)
+	if err != nil {
 		return err
 	}
 
-	return nil
+	_, err = locksClient.DeleteAtResourceGroupLevel(ctx, cloud.Azure.ResourceGroup, resourceGroupLockName)
+	return err
 }
 
-func deleteRouteTable(ctx context.Context, cloud kubermaticv1.CloudSpec, credentials Credentials) error {
+func deleteRouteTable(ctx context.Context, cloud kubermaticv1.CloudSpec, credentials Credentials) (autorestazure.FutureAPI, error) {
 	routeTablesClient, err := getRouteTablesClient(cloud, credentials)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	future, err := routeTablesClient.Delete(ctx, cloud.Azure.ResourceGroup, cloud.Azure.RouteTableName)
+	future, err := routeTablesClient.Delete(ctx, RouteTableResourceGroup(cloud), cloud.Azure.RouteTableName)
 	if err != nil {
-		return err
-	}
-
-	if err = future.WaitForCompletionRef(ctx, routeTablesClient.Client); err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return future.FutureAPI, nil
 }
 
-func deleteSecurityGroup(ctx context.Context, cloud kubermaticv1.CloudSpec, credentials Credentials) error {
+func deleteSecurityGroup(ctx context.Context, cloud kubermaticv1.CloudSpec, credentials Credentials) (autorestazure.FutureAPI, error) {
 	securityGroupsClient, err := getSecurityGroupsClient(cloud, credentials)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	future, err := securityGroupsClient.Delete(ctx, cloud.Azure.ResourceGroup, cloud.Azure.SecurityGroup)
+	future, err := securityGroupsClient.Delete(ctx, SecurityGroupResourceGroup(cloud), cloud.Azure.SecurityGroup)
 	if err != nil {
-		return err
-	}
-
-	if err = future.WaitForCompletionRef(ctx, securityGroupsClient.Client); err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return future.FutureAPI, nil
 }
 
 func (a *Azure) CleanUpCloudProvider(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater) (*kubermaticv1.Cluster, error) {
@@ -229,104 +290,134 @@ func (a *Azure) CleanUpCloudProvider(cluster *kubermaticv1.Cluster, update provi
 	}
 
 	logger := a.log.With("cluster", cluster.Name)
-	if kuberneteshelper.HasFinalizer(cluster, FinalizerSecurityGroup) {
-		logger.Infow("deleting security group", "group", cluster.Spec.Cloud.Azure.SecurityGroup)
-		if err := deleteSecurityGroup(a.ctx, cluster.Spec.Cloud, credentials); err != nil {
-			if detErr, ok := err.(autorest.DetailedError); !ok || detErr.StatusCode != http.StatusNotFound {
-				return cluster, fmt.Errorf("failed to delete security group %q: %v", cluster.Spec.Cloud.Azure.SecurityGroup, err)
-			}
-		}
-		cluster, err = update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
-			kuberneteshelper.RemoveFinalizer(updatedCluster, FinalizerSecurityGroup)
-		})
+
+	for _, step := range a.cleanupSteps(update, credentials, logger) {
+		cluster, err = a.runCleanupStep(cluster, update, step, logger)
 		if err != nil {
-			return nil, err
+			return cluster, err
 		}
 	}
 
-	if kuberneteshelper.HasFinalizer(cluster, FinalizerRouteTable) {
-		logger.Infow("deleting route table", "routeTableName", cluster.Spec.Cloud.Azure.RouteTableName)
-		if err := deleteRouteTable(a.ctx, cluster.Spec.Cloud, credentials); err != nil {
-			if detErr, ok := err.(autorest.DetailedError); !ok || detErr.StatusCode != http.StatusNotFound {
-				return cluster, fmt.Errorf("failed to delete route table %q: %v", cluster.Spec.Cloud.Azure.RouteTableName, err)
-			}
-		}
-		cluster, err = update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
-			kuberneteshelper.RemoveFinalizer(updatedCluster, FinalizerRouteTable)
-		})
-		if err != nil {
-			return nil, err
+	return cluster, nil
+}
+
+func (a *Azure) cleanUpSecurityGroup(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, credentials Credentials, logger *zap.SugaredLogger) (*kubermaticv1.Cluster, error) {
+	logger.Infow("deleting security group", "group", cluster.Spec.Cloud.Azure.SecurityGroup)
+	if err := a.pollOperation(cluster, update, credentials, "delete-security-group", func() (autorestazure.FutureAPI, error) {
+		return deleteSecurityGroup(a.ctx, cluster.Spec.Cloud, credentials)
+	}); err != nil {
+		if !isNotFound(err) {
+			a.recordError(cluster, "FailedToDeleteSecurityGroup", err)
+			return cluster, fmt.Errorf("failed to delete security group %q: %w", cluster.Spec.Cloud.Azure.SecurityGroup, err)
 		}
 	}
+	a.recordEvent(cluster, "DeletedSecurityGroup", "Deleted security group %q", cluster.Spec.Cloud.Azure.SecurityGroup)
 
-	if kuberneteshelper.HasFinalizer(cluster, FinalizerSubnet) {
-		logger.Infow("deleting subnet", "subnet", cluster.Spec.Cloud.Azure.SubnetName)
-		if err := deleteSubnet(a.ctx, cluster.Spec.Cloud, credentials); err != nil {
-			if detErr, ok := err.(autorest.DetailedError); !ok || detErr.StatusCode != http.StatusNotFound {
-				return cluster, fmt.Errorf("failed to delete sub-network %q: %v", cluster.Spec.Cloud.Azure.SubnetName, err)
-			}
-		}
-		cluster, err = update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
-			kuberneteshelper.RemoveFinalizer(updatedCluster, FinalizerSubnet)
-		})
-		if err != nil {
-			return nil, err
+	return update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+		kuberneteshelper.RemoveFinalizer(updatedCluster, FinalizerSecurityGroup)
+	})
+}
+
+func (a *Azure) cleanUpRouteTable(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, credentials Credentials, logger *zap.SugaredLogger) (*kubermaticv1.Cluster, error) {
+	logger.Infow("deleting route table", "routeTableName", cluster.Spec.Cloud.Azure.RouteTableName)
+	if err := a.pollOperation(cluster, update, credentials, "delete-route-table", func() (autorestazure.FutureAPI, error) {
+		return deleteRouteTable(a.ctx, cluster.Spec.Cloud, credentials)
+	}); err != nil {
+		if !isNotFound(err) {
+			a.recordError(cluster, "FailedToDeleteRouteTable", err)
+			return cluster, fmt.Errorf("failed to delete route table %q: %w", cluster.Spec.Cloud.Azure.RouteTableName, err)
 		}
 	}
+	a.recordEvent(cluster, "DeletedRouteTable", "Deleted route table %q", cluster.Spec.Cloud.Azure.RouteTableName)
 
-	if kuberneteshelper.HasFinalizer(cluster, FinalizerVNet) {
-		logger.Infow("deleting vnet", "vnet", cluster.Spec.Cloud.Azure.VNetName)
-		if err := deleteVNet(a.ctx, cluster.Spec.Cloud, credentials); err != nil {
-			if detErr, ok := err.(autorest.DetailedError); !ok || detErr.StatusCode != http.StatusNotFound {
-				return cluster, fmt.Errorf("failed to delete virtual network %q: %v", cluster.Spec.Cloud.Azure.VNetName, err)
-			}
-		}
+	return update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+		kuberneteshelper.RemoveFinalizer(updatedCluster, FinalizerRouteTable)
+	})
+}
 
-		cluster, err = update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
-			kuberneteshelper.RemoveFinalizer(updatedCluster, FinalizerVNet)
-		})
-		if err != nil {
-			return nil, err
+func (a *Azure) cleanUpSubnet(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, credentials Credentials, logger *zap.SugaredLogger) (*kubermaticv1.Cluster, error) {
+	logger.Infow("deleting subnet", "subnet", cluster.Spec.Cloud.Azure.SubnetName)
+	if err := a.pollOperation(cluster, update, credentials, "delete-subnet", func() (autorestazure.FutureAPI, error) {
+		return deleteSubnet(a.ctx, cluster.Spec.Cloud, credentials)
+	}); err != nil {
+		if !isNotFound(err) {
+			a.recordError(cluster, "FailedToDeleteSubnet", err)
+			return cluster, fmt.Errorf("failed to delete sub-network %q: %w", cluster.Spec.Cloud.Azure.SubnetName, err)
 		}
 	}
+	a.recordEvent(cluster, "DeletedSubnet", "Deleted subnet %q", cluster.Spec.Cloud.Azure.SubnetName)
 
-	if kuberneteshelper.HasFinalizer(cluster, FinalizerResourceGroup) {
-		logger.Infow("deleting resource group", "resourceGroup", cluster.Spec.Cloud.Azure.ResourceGroup)
-		if err := deleteResourceGroup(a.ctx, cluster.Spec.Cloud, credentials); err != nil {
-			if detErr, ok := err.(autorest.DetailedError); !ok || detErr.StatusCode != http.StatusNotFound {
-				return cluster, fmt.Errorf("failed to delete resource group %q: %v", cluster.Spec.Cloud.Azure.ResourceGroup, err)
-			}
+	return update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+		kuberneteshelper.RemoveFinalizer(updatedCluster, FinalizerSubnet)
+	})
+}
+
+func (a *Azure) cleanUpVNet(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, credentials Credentials, logger *zap.SugaredLogger) (*kubermaticv1.Cluster, error) {
+	logger.Infow("deleting vnet", "vnet", cluster.Spec.Cloud.Azure.VNetName)
+	if err := a.pollOperation(cluster, update, credentials, "delete-vnet", func() (autorestazure.FutureAPI, error) {
+		return deleteVNet(a.ctx, cluster.Spec.Cloud, credentials)
+	}); err != nil {
+		if !isNotFound(err) {
+			a.recordError(cluster, "FailedToDeleteVNet", err)
+			return cluster, fmt.Errorf("failed to delete virtual network %q: %w", cluster.Spec.Cloud.Azure.VNetName, err)
 		}
+	}
+	a.recordEvent(cluster, "DeletedVNet", "Deleted virtual network %q", cluster.Spec.Cloud.Azure.VNetName)
 
-		cluster, err = update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
-			kuberneteshelper.RemoveFinalizer(updatedCluster, FinalizerResourceGroup)
-		})
-		if err != nil {
-			return nil, err
+	return update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+		kuberneteshelper.RemoveFinalizer(updatedCluster, FinalizerVNet)
+	})
+}
+
+func (a *Azure) cleanUpResourceGroup(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, credentials Credentials, logger *zap.SugaredLogger) (*kubermaticv1.Cluster, error) {
+	logger.Infow("deleting resource group", "resourceGroup", cluster.Spec.Cloud.Azure.ResourceGroup)
+	if err := a.pollOperation(cluster, update, credentials, "delete-resource-group", func() (autorestazure.FutureAPI, error) {
+		return deleteResourceGroup(a.ctx, cluster.Spec.Cloud, credentials)
+	}); err != nil {
+		if !isNotFound(err) {
+			a.recordError(cluster, "FailedToDeleteResourceGroup", err)
+			return cluster, fmt.Errorf("failed to delete resource group %q: %w", cluster.Spec.Cloud.Azure.ResourceGroup, err)
 		}
 	}
+	a.recordEvent(cluster, "DeletedResourceGroup", "Deleted resource group %q", cluster.Spec.Cloud.Azure.ResourceGroup)
 
-	if kuberneteshelper.HasFinalizer(cluster, FinalizerAvailabilitySet) {
-		logger.Infow("deleting availability set", "availabilitySet", cluster.Spec.Cloud.Azure.AvailabilitySet)
-		if err := deleteAvailabilitySet(a.ctx, cluster.Spec.Cloud, credentials); err != nil {
-			if detErr, ok := err.(autorest.DetailedError); !ok || detErr.StatusCode != http.StatusNotFound {
-				return cluster, fmt.Errorf("failed to delete availability set %q: %v", cluster.Spec.Cloud.Azure.AvailabilitySet, err)
-			}
+	return update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+		kuberneteshelper.RemoveFinalizer(updatedCluster, FinalizerResourceGroup)
+	})
+}
+
+func (a *Azure) cleanUpAvailabilitySet(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, credentials Credentials, logger *zap.SugaredLogger) (*kubermaticv1.Cluster, error) {
+	logger.Infow("deleting availability set", "availabilitySet", cluster.Spec.Cloud.Azure.AvailabilitySet)
+	if err := deleteAvailabilitySet(a.ctx, cluster.Spec.Cloud, credentials); err != nil {
+		if !isNotFound(err) {
+			a.recordError(cluster, "FailedToDeleteAvailabilitySet", err)
+			return cluster, fmt.Errorf("failed to delete availability set %q: %w", cluster.Spec.Cloud.Azure.AvailabilitySet, err)
 		}
+	}
+	a.recordEvent(cluster, "DeletedAvailabilitySet", "Deleted availability set %q", cluster.Spec.Cloud.Azure.AvailabilitySet)
 
-		cluster, err = update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
-			kuberneteshelper.RemoveFinalizer(updatedCluster, FinalizerAvailabilitySet)
-		})
-		if err != nil {
-			return nil, err
+	return update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+		kuberneteshelper.RemoveFinalizer(updatedCluster, FinalizerAvailabilitySet)
+	})
+}
+
+func (a *Azure) cleanUpProximityPlacementGroup(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, credentials Credentials, logger *zap.SugaredLogger) (*kubermaticv1.Cluster, error) {
+	logger.Infow("deleting proximity placement group", "proximityPlacementGroup", cluster.Spec.Cloud.Azure.ProximityPlacementGroupID)
+	if err := deleteProximityPlacementGroup(a.ctx, cluster.Spec.Cloud, credentials); err != nil {
+		if !isNotFound(err) {
+			a.recordError(cluster, "FailedToDeleteProximityPlacementGroup", err)
+			return cluster, fmt.Errorf("failed to delete proximity placement group %q: %w", cluster.Spec.Cloud.Azure.ProximityPlacementGroupID, err)
 		}
 	}
+	a.recordEvent(cluster, "DeletedProximityPlacementGroup", "Deleted proximity placement group %q", cluster.Spec.Cloud.Azure.ProximityPlacementGroupID)
 
-	return cluster, nil
+	return update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+		kuberneteshelper.RemoveFinalizer(updatedCluster, FinalizerProximityPlacementGroup)
+	})
 }
 
 // ensureResourceGroup will create or update an Azure resource group. The call is idempotent.
-func ensureResourceGroup(ctx context.Context, cloud kubermaticv1.CloudSpec, location string, clusterName string, credentials Credentials) error {
+func ensureResourceGroup(ctx context.Context, cloud kubermaticv1.CloudSpec, location string, tags map[string]*string, credentials Credentials) error {
 	groupsClient, err := getGroupsClient(cloud, credentials)
 	if err != nil {
 		return err
@@ -335,123 +426,196 @@ func ensureResourceGroup(ctx context.Context, cloud kubermaticv1.CloudSpec, loca
 	parameters := resources.Group{
 		Name:     to.StringPtr(cloud.Azure.ResourceGroup),
 		Location: to.StringPtr(location),
-		Tags: map[string]*string{
-			clusterTagKey: to.StringPtr(clusterName),
-		},
+		Tags:     tags,
 	}
 	if _, err = groupsClient.CreateOrUpdate(ctx, cloud.Azure.ResourceGroup, parameters); err != nil {
-		return fmt.Errorf("failed to create or update resource group %q: %v", cloud.Azure.ResourceGroup, err)
+		return fmt.Errorf("failed to create or update resource group %q: %w", cloud.Azure.ResourceGroup, err)
 	}
 
 	return nil
 }
 
+// ensureResourceGroupLock will create or update a CanNotDelete management lock on the
+// cluster's resource group. The call is idempotent.
+func ensureResourceGroupLock(ctx context.Context, cloud kubermaticv1.CloudSpec, clusterName string, credentials Credentials) error {
+	locksClient, err := getManagementLocksClient(cloud, credentials)
+	if err != nil {
+		return err
+	}
+
+	parameters := locks.ManagementLockObject{
+		ManagementLockProperties: &locks.ManagementLockProperties{
+			Level: locks.CanNotDelete,
+			Notes: to.StringPtr(fmt.Sprintf("Protects the infrastructure of Kubermatic cluster %s. Managed by Kubermatic.", clusterName)),
+		},
+	}
+	if _, err = locksClient.CreateOrUpdateAtResourceGroupLevel(ctx, cloud.Azure.ResourceGroup, resourceGroupLockName, parameters); err != nil {
+		return fmt.Errorf("failed to create or update management lock on resource group %q: %w", cloud.Azure.ResourceGroup, err)
+	}
+
+	return nil
+}
+
+// removeResourceGroupLock deletes the management lock on the cluster's resource group and
+// removes the corresponding finalizer.
+func (a *Azure) removeResourceGroupLock(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, credentials Credentials) (*kubermaticv1.Cluster, error) {
+	a.log.With("cluster", cluster.Name).Infow("deleting resource group lock", "resourceGroup", cluster.Spec.Cloud.Azure.ResourceGroup)
+	if err := deleteResourceGroupLock(a.ctx, cluster.Spec.Cloud, credentials); err != nil {
+		if !isNotFound(err) {
+			a.recordError(cluster, "FailedToDeleteResourceGroupLock", err)
+			return cluster, fmt.Errorf("failed to delete management lock on resource group %q: %w", cluster.Spec.Cloud.Azure.ResourceGroup, err)
+		}
+	}
+	a.recordEvent(cluster, "DeletedResourceGroupLock", "Unlocked resource group %q", cluster.Spec.Cloud.Azure.ResourceGroup)
+
+	return update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+		kuberneteshelper.RemoveFinalizer(updatedCluster, FinalizerResourceGroupLock)
+	})
+}
+
+// UnlockResourceGroup removes the management lock from the resource group of a cluster that is
+// being deleted. It must run before the nodes and load balancers are torn down, because the lock
+// prevents machine-controller and the cloud controller manager from deleting their resources.
+func (a *Azure) UnlockResourceGroup(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater) (*kubermaticv1.Cluster, error) {
+	if !kuberneteshelper.HasFinalizer(cluster, FinalizerResourceGroupLock) {
+		return cluster, nil
+	}
+
+	credentials, err := GetCredentialsForCluster(cluster.Spec.Cloud, a.secretKeySelector)
+	if err != nil {
+		return cluster, err
+	}
+
+	return a.removeResourceGroupLock(cluster, update, credentials)
+}
+
 // ensureSecurityGroup will create or update an Azure security group. The call is idempotent.
-func (a *Azure) ensureSecurityGroup(cloud kubermaticv1.CloudSpec, location string, clusterName string, credentials Credentials) error {
+func (a *Azure) ensureSecurityGroup(cloud kubermaticv1.CloudSpec, location string, tags map[string]*string, credentials Credentials) error {
 	sgClient, err := getSecurityGroupsClient(cloud, credentials)
 	if err != nil {
 		return err
 	}
 
-	parameters := network.SecurityGroup{
+	rules := securityGroupRules(cloud, credentials.SubscriptionID)
+	parameters := network2020.SecurityGroup{
 		Name:     to.StringPtr(cloud.Azure.SecurityGroup),
 		Location: to.StringPtr(location),
-		Tags: map[string]*string{
-			clusterTagKey: to.StringPtr(clusterName),
-		},
-		SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{
-			Subnets: &[]network.Subnet{
+		Tags:     tags,
+		SecurityGroupPropertiesFormat: &network2020.SecurityGroupPropertiesFormat{
+			Subnets: &[]network2020.Subnet{
 				{
 					Name: to.StringPtr(cloud.Azure.SubnetName),
 					ID:   to.StringPtr(assembleSubnetID(cloud)),
 				},
 			},
-			// inbound
-			SecurityRules: &[]network.SecurityRule{
-				{
-					Name: to.StringPtr("ssh_ingress"),
-					SecurityRulePropertiesFormat: &network.SecurityRulePropertiesFormat{
-						Direction:                network.SecurityRuleDirectionInbound,
-						Protocol:                 network.SecurityRuleProtocolTCP,
-						SourceAddressPrefix:      to.StringPtr("*"),
-						SourcePortRange:          to.StringPtr("*"),
-						DestinationAddressPrefix: to.StringPtr("*"),
-						DestinationPortRange:     to.StringPtr("22"),
-						Access:                   network.SecurityRuleAccessAllow,
-						Priority:                 to.Int32Ptr(100),
-					},
-				},
-				{
-					Name: to.StringPtr("inter_node_comm"),
-					SecurityRulePropertiesFormat: &network.SecurityRulePropertiesFormat{
-						Direction:                network.SecurityRuleDirectionInbound,
-						Protocol:                 "*",
-						SourceAddressPrefix:      to.StringPtr("VirtualNetwork"),
-						SourcePortRange:          to.StringPtr("*"),
-						DestinationAddressPrefix: to.StringPtr("VirtualNetwork"),
-						DestinationPortRange:     to.StringPtr("*"),
-						Access:                   network.SecurityRuleAccessAllow,
-						Priority:                 to.Int32Ptr(200),
-					},
-				},
-				{
-					Name: to.StringPtr("azure_load_balancer"),
-					SecurityRulePropertiesFormat: &network.SecurityRulePropertiesFormat{
-						Direction:                network.SecurityRuleDirectionInbound,
-						Protocol:                 "*",
-						SourceAddressPrefix:      to.StringPtr("AzureLoadBalancer"),
-						SourcePortRange:          to.StringPtr("*"),
-						DestinationAddressPrefix: to.StringPtr("*"),
-						DestinationPortRange:     to.StringPtr("*"),
-						Access:                   network.SecurityRuleAccessAllow,
-						Priority:                 to.Int32Ptr(300),
-					},
-				},
-				// outbound
-				{
-					Name: to.StringPtr("outbound_allow_all"),
-					SecurityRulePropertiesFormat: &network.SecurityRulePropertiesFormat{
-						Direction:                network.SecurityRuleDirectionOutbound,
-						Protocol:                 "*",
-						SourceAddressPrefix:      to.StringPtr("*"),
-						SourcePortRange:          to.StringPtr("*"),
-						DestinationAddressPrefix: to.StringPtr("*"),
-						DestinationPortRange:     to.StringPtr("*"),
-						Access:                   network.SecurityRuleAccessAllow,
-						Priority:                 to.Int32Ptr(100),
-					},
-				},
-			},
+			SecurityRules: &rules,
 		},
 	}
 
-	updatedRules := append(*parameters.SecurityRules, tcpDenyAllRule(), udpDenyAllRule(), icmpAllowAllRule())
-	parameters.SecurityRules = &updatedRules
-
 	if _, err = sgClient.CreateOrUpdate(a.ctx, cloud.Azure.ResourceGroup, cloud.Azure.SecurityGroup, parameters); err != nil {
-		return fmt.Errorf("failed to create or update resource group %q: %v", cloud.Azure.ResourceGroup, err)
+		return fmt.Errorf("failed to create or update resource group %q: %w", cloud.Azure.ResourceGroup, err)
 	}
 
 	return nil
 }
 
-// ensureVNet will create or update an Azure virtual network in the specified resource group. The call is idempotent.
-func ensureVNet(ctx context.Context, cloud kubermaticv1.CloudSpec, location string, clusterName string, credentials Credentials) error {
+// securityGroupRules returns the rules of security groups created by Kubermatic.
+func securityGroupRules(cloud kubermaticv1.CloudSpec, subscriptionID string) []network2020.SecurityRule {
+	basePriority := securityGroupRulePriority(cloud)
+	rules := []network2020.SecurityRule{
+		// inbound
+		{
+			Name: to.StringPtr("ssh_ingress"),
+			SecurityRulePropertiesFormat: &network2020.SecurityRulePropertiesFormat{
+				Direction:                network2020.SecurityRuleDirectionInbound,
+				Protocol:                 network2020.SecurityRuleProtocolTCP,
+				SourceAddressPrefix:      to.StringPtr(inboundSourceAddressPrefix(cloud)),
+				SourcePortRange:          to.StringPtr("*"),
+				DestinationAddressPrefix: to.StringPtr("*"),
+				DestinationPortRange:     to.StringPtr("22"),
+				Access:                   network2020.SecurityRuleAccessAllow,
+				Priority:                 to.Int32Ptr(basePriority),
+			},
+		},
+		{
+			Name: to.StringPtr("inter_node_comm"),
+			SecurityRulePropertiesFormat: &network2020.SecurityRulePropertiesFormat{
+				Direction:                network2020.SecurityRuleDirectionInbound,
+				Protocol:                 "*",
+				SourceAddressPrefix:      to.StringPtr("VirtualNetwork"),
+				SourcePortRange:          to.StringPtr("*"),
+				DestinationAddressPrefix: to.StringPtr("VirtualNetwork"),
+				DestinationPortRange:     to.StringPtr("*"),
+				Access:                   network2020.SecurityRuleAccessAllow,
+				Priority:                 to.Int32Ptr(basePriority + 100),
+			},
+		},
+		{
+			Name: to.StringPtr("azure_load_balancer"),
+			SecurityRulePropertiesFormat: &network2020.SecurityRulePropertiesFormat{
+				Direction:                network2020.SecurityRuleDirectionInbound,
+				Protocol:                 "*",
+				SourceAddressPrefix:      to.StringPtr("AzureLoadBalancer"),
+				SourcePortRange:          to.StringPtr("*"),
+				DestinationAddressPrefix: to.StringPtr("*"),
+				DestinationPortRange:     to.StringPtr("*"),
+				Access:                   network2020.SecurityRuleAccessAllow,
+				Priority:                 to.Int32Ptr(basePriority + 200),
+			},
+		},
+		// outbound
+		{
+			Name: to.StringPtr("outbound_allow_all"),
+			SecurityRulePropertiesFormat: &network2020.SecurityRulePropertiesFormat{
+				Direction:                network2020.SecurityRuleDirectionOutbound,
+				Protocol:                 "*",
+				SourceAddressPrefix:      to.StringPtr("*"),
+				SourcePortRange:          to.StringPtr("*"),
+				DestinationAddressPrefix: to.StringPtr("*"),
+				DestinationPortRange:     to.StringPtr("*"),
+				Access:                   network2020.SecurityRuleAccessAllow,
+				Priority:                 to.Int32Ptr(basePriority),
+			},
+		},
+		icmpAllowRule(cloud),
+	}
+
+	// the SSH rule is restricted to the allowed IP ranges, private clusters only accept traffic
+	// from within their VNet anyway
+	if len(cloud.Azure.AllowedIPRanges) > 0 && !cloud.Azure.PrivateCluster {
+		rules[0].SourceAddressPrefix = nil
+		rules[0].SourceAddressPrefixes = to.StringSlicePtr(cloud.Azure.AllowedIPRanges)
+	}
+
+	if cloud.Azure.ApplicationSecurityGroup != "" {
+		targetApplicationSecurityGroup(rules, assembleApplicationSecurityGroupID(cloud, subscriptionID))
+	}
+
+	return rules
+}
+
+// ensureVNet will start creating or updating an Azure virtual network in the specified resource group. The call is idempotent.
+func ensureVNet(ctx context.Context, cloud kubermaticv1.CloudSpec, location string, tags map[string]*string, ddosProtectionPlanID string, credentials Credentials) (autorestazure.FutureAPI, error) {
 	networksClient, err := getNetworksClient(cloud, credentials)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	parameters := network.VirtualNetwork{
 		Name:     to.StringPtr(cloud.Azure.VNetName),
 		Location: to.StringPtr(location),
-		Tags: map[string]*string{
-			clusterTagKey: to.StringPtr(clusterName),
-		},
+		Tags:     tags,
 		VirtualNetworkPropertiesFormat: &network.VirtualNetworkPropertiesFormat{
-			AddressSpace: &network.AddressSpace{AddressPrefixes: &[]string{"10.0.0.0/16"}},
+			AddressSpace: &network.AddressSpace{AddressPrefixes: &[]string{vnetCIDR(cloud)}},
 		},
 	}
+	if len(cloud.Azure.DNSServers) > 0 {
+		parameters.DhcpOptions = &network.DhcpOptions{DNSServers: to.StringSlicePtr(cloud.Azure.DNSServers)}
+	}
+	if ddosProtectionPlanID != "" {
+		parameters.EnableDdosProtection = to.BoolPtr(true)
+		parameters.DdosProtectionPlan = &network.SubResource{ID: to.StringPtr(ddosProtectionPlanID)}
+	}
 
 	var resourceGroup = cloud.Azure.ResourceGroup
 	if cloud.Azure.VNetResourceGroup != "" {
@@ -459,56 +623,129 @@ func ensureVNet(ctx context.Context, cloud kubermaticv1.CloudSpec, location stri
 	}
 	future, err := networksClient.CreateOrUpdate(ctx, resourceGroup, cloud.Azure.VNetName, parameters)
 	if err != nil {
-		return fmt.Errorf("failed to create or update virtual network %q: %v", cloud.Azure.VNetName, err)
+		return nil, err
 	}
 
-	if err = future.WaitForCompletionRef(ctx, networksClient.Client); err != nil {
-		return fmt.Errorf("failed to create or update virtual network %q: %v", cloud.Azure.VNetName, err)
+	return future.FutureAPI, nil
+}
+
+// ensureVNetDNSServers will start updating the DNS servers of the virtual network, if they differ
+// from the ones configured in the cloud spec. The call is idempotent.
+func ensureVNetDNSServers(ctx context.Context, cloud kubermaticv1.CloudSpec, credentials Credentials) (autorestazure.FutureAPI, error) {
+	networksClient, err := getNetworksClient(cloud, credentials)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	vnet, err := networksClient.Get(ctx, vnetResourceGroup(cloud), cloud.Azure.VNetName, "")
+	if err != nil {
+		return nil, err
+	}
+	if hasDNSServers(vnet, cloud.Azure.DNSServers) {
+		return nil, nil
+	}
+	if vnet.VirtualNetworkPropertiesFormat == nil {
+		vnet.VirtualNetworkPropertiesFormat = &network.VirtualNetworkPropertiesFormat{}
+	}
+	vnet.DhcpOptions = &network.DhcpOptions{DNSServers: to.StringSlicePtr(cloud.Azure.DNSServers)}
+
+	// the whole VNet is sent, as its subnets would be removed otherwise
+	future, err := networksClient.CreateOrUpdate(ctx, vnetResourceGroup(cloud), cloud.Azure.VNetName, vnet)
+	if err != nil {
+		return nil, err
+	}
+
+	return future.FutureAPI, nil
 }
 
-// ensureSubnet will create or update an Azure subnetwork in the specified vnet. The call is idempotent.
-func ensureSubnet(ctx context.Context, cloud kubermaticv1.CloudSpec, credentials Credentials) error {
-	subnetsClient, err := getSubnetsClient(cloud, credentials)
+// ensureVNetDDoSProtectionPlan will start associating the virtual network with the DDoS Protection
+// Plan, if it is not associated with it yet. The call is idempotent.
+func ensureVNetDDoSProtectionPlan(ctx context.Context, cloud kubermaticv1.CloudSpec, ddosProtectionPlanID string, credentials Credentials) (autorestazure.FutureAPI, error) {
+	networksClient, err := getNetworksClient(cloud, credentials)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	parameters := network.Subnet{
-		Name: to.StringPtr(cloud.Azure.SubnetName),
-		SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
-			AddressPrefix: to.StringPtr("10.0.0.0/16"),
-		},
+	vnet, err := networksClient.Get(ctx, vnetResourceGroup(cloud), cloud.Azure.VNetName, "")
+	if err != nil {
+		return nil, err
+	}
+	if hasDDoSProtectionPlan(vnet, ddosProtectionPlanID) {
+		return nil, nil
+	}
+	if vnet.VirtualNetworkPropertiesFormat == nil {
+		vnet.VirtualNetworkPropertiesFormat = &network.VirtualNetworkPropertiesFormat{}
 	}
+	vnet.EnableDdosProtection = to.BoolPtr(true)
+	vnet.DdosProtectionPlan = &network.SubResource{ID: to.StringPtr(ddosProtectionPlanID)}
 
-	var resourceGroup = cloud.Azure.ResourceGroup
-	if cloud.Azure.VNetResourceGroup != "" {
-		resourceGroup = cloud.Azure.VNetResourceGroup
+	// the whole VNet is sent, as its subnets would be removed otherwise
+	future, err := networksClient.CreateOrUpdate(ctx, vnetResourceGroup(cloud), cloud.Azure.VNetName, vnet)
+	if err != nil {
+		return nil, err
+	}
+
+	return future.FutureAPI, nil
+}
+
+// ensureSubnet will start creating or updating an Azure subnetwork in the specified vnet. An existing
+// subnet is only updated if its service endpoints differ from the configured ones, all other settings,
+// like the associated route table and security group, are preserved. The call is idempotent.
+func ensureSubnet(ctx context.Context, cloud kubermaticv1.CloudSpec, credentials Credentials) (autorestazure.FutureAPI, error) {
+	subnetsClient, err := getPrivateLinkSubnetsClient(cloud, credentials)
+	if err != nil {
+		return nil, err
 	}
-	future, err := subnetsClient.CreateOrUpdate(ctx, resourceGroup, cloud.Azure.VNetName, cloud.Azure.SubnetName, parameters)
+
+	subnet, err := subnetsClient.Get(ctx, vnetResourceGroup(cloud), cloud.Azure.VNetName, cloud.Azure.SubnetName, "")
 	if err != nil {
-		return fmt.Errorf("failed to create or update subnetwork %q: %v", cloud.Azure.SubnetName, err)
+		if !isNotFound(err) {
+			return nil, err
+		}
+		subnet = network2020.Subnet{
+			Name: to.StringPtr(cloud.Azure.SubnetName),
+			SubnetPropertiesFormat: &network2020.SubnetPropertiesFormat{
+				AddressPrefix: to.StringPtr(subnetCIDR(cloud)),
+			},
+		}
+		// route tables and security groups referenced by ID are associated with the subnet right
+		// away, Kubermatic only associates the route table it creates itself later on
+		if cloud.Azure.RouteTableID != "" {
+			subnet.RouteTable = &network2020.RouteTable{ID: to.StringPtr(cloud.Azure.RouteTableID)}
+		}
+		if cloud.Azure.SecurityGroupID != "" {
+			subnet.NetworkSecurityGroup = &network2020.SecurityGroup{ID: to.StringPtr(cloud.Azure.SecurityGroupID)}
+		}
+	} else if len(cloud.Azure.ServiceEndpoints) == 0 || hasServiceEndpoints(subnet, cloud.Azure.ServiceEndpoints) {
+		return nil, nil
 	}
 
-	if err = future.WaitForCompletionRef(ctx, subnetsClient.Client); err != nil {
-		return fmt.Errorf("failed to create or update subnetwork %q: %v", cloud.Azure.SubnetName, err)
+	if len(cloud.Azure.ServiceEndpoints) > 0 {
+		if subnet.SubnetPropertiesFormat == nil {
+			subnet.SubnetPropertiesFormat = &network2020.SubnetPropertiesFormat{}
+		}
+		subnet.ServiceEndpoints = serviceEndpoints(subnet.ServiceEndpoints, cloud.Azure.ServiceEndpoints)
 	}
 
-	return nil
+	future, err := subnetsClient.CreateOrUpdate(ctx, vnetResourceGroup(cloud), cloud.Azure.VNetName, cloud.Azure.SubnetName, subnet)
+	if err != nil {
+		return nil, err
+	}
+
+	return future.FutureAPI, nil
 }
 
-// ensureRouteTable will create or update an Azure route table attached to the specified subnet. The call is idempotent.
-func ensureRouteTable(ctx context.Context, cloud kubermaticv1.CloudSpec, location string, credentials Credentials) error {
+// ensureRouteTable will start creating or updating an Azure route table attached to the specified subnet. The call is idempotent.
+func ensureRouteTable(ctx context.Context, cloud kubermaticv1.CloudSpec, location string, tags map[string]*string, credentials Credentials) (autorestazure.FutureAPI, error) {
 	routeTablesClient, err := getRouteTablesClient(cloud, credentials)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	parameters := network.RouteTable{
 		Name:     to.StringPtr(cloud.Azure.RouteTableName),
 		Location: to.StringPtr(location),
+		Tags:     tags,
 		RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{
 			Subnets: &[]network.Subnet{
 				{
@@ -521,17 +758,36 @@ func ensureRouteTable(ctx context.Context, cloud kubermaticv1.CloudSpec, locatio
 
 	future, err := routeTablesClient.CreateOrUpdate(ctx, cloud.Azure.ResourceGroup, cloud.Azure.RouteTableName, parameters)
 	if err != nil {
-		return fmt.Errorf("failed to create or update route table %q: %v", cloud.Azure.RouteTableName, err)
+		return nil, err
 	}
 
-	if err = future.WaitForCompletionRef(ctx, routeTablesClient.Client); err != nil {
-		return fmt.Errorf("failed to create or update route table %q: %v", cloud.Azure.RouteTableName, err)
+	return future.FutureAPI, nil
+}
+
+// InitializeCloudProvider provisions the cloud resources of the cluster and reports their state
+// in the cluster conditions.
+func (a *Azure) InitializeCloudProvider(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater) (*kubermaticv1.Cluster, error) {
+	progress := &provisioningProgress{}
+
+	initialized, err := a.initializeCloudProvider(cluster, update, progress)
+	if initialized == nil {
+		initialized = cluster
 	}
 
-	return nil
+	updated, updateErr := update(initialized.Name, func(updatedCluster *kubermaticv1.Cluster) {
+		setResourceConditions(updatedCluster, kubermatic.NewDefaultVersions(), *progress, err)
+	})
+	if err != nil {
+		return initialized, err
+	}
+	if updateErr != nil {
+		return nil, fmt.Errorf("failed to update resource conditions: %w", updateErr)
+	}
+
+	return updated, nil
 }
 
-func (a *Azure) InitializeCloudProvider(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater) (*kubermaticv1.Cluster, error) {
+func (a *Azure) initializeCloudProvider(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, progress *provisioningProgress) (*kubermaticv1.Cluster, error) {
 	var err error
 	logger := a.log.With("cluster", cluster.Name)
 	location := a.dc.Location
@@ -541,13 +797,42 @@ func (a *Azure) InitializeCloudProvider(cluster *kubermaticv1.Cluster, update pr
 		return nil, err
 	}
 
+	if err := a.cleanupOperations(cluster, update, credentials); err != nil {
+		return cluster, err
+	}
+
+	if err := a.preflightChecks(cluster, credentials); err != nil {
+		return cluster, err
+	}
+
+	if (cluster.Spec.Cloud.Azure.RouteTableID != "" && cluster.Spec.Cloud.Azure.RouteTableName == "") ||
+		(cluster.Spec.Cloud.Azure.SecurityGroupID != "" && cluster.Spec.Cloud.Azure.SecurityGroup == "") {
+		cluster, err = update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+			setReferencedResourceNames(&updatedCluster.Spec.Cloud)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cluster, err = a.adoptResources(cluster, update, credentials); err != nil {
+		return cluster, err
+	}
+
+	tags := a.resourceTags(cluster)
+
+	progress.start(kubermaticv1.ClusterConditionAzureResourceGroupReady)
 	if cluster.Spec.Cloud.Azure.ResourceGroup == "" {
-		cluster.Spec.Cloud.Azure.ResourceGroup = resourceNamePrefix + cluster.Name
+		if cluster.Spec.Cloud.Azure.ResourceGroup, err = a.resourceName(cluster, resourceTypeResourceGroup); err != nil {
+			return cluster, err
+		}
 
 		logger.Infow("ensuring resource group", "resourceGroup", cluster.Spec.Cloud.Azure.ResourceGroup)
-		if err = ensureResourceGroup(a.ctx, cluster.Spec.Cloud, location, cluster.Name, credentials); err != nil {
+		if err = ensureResourceGroup(a.ctx, cluster.Spec.Cloud, location, tags, credentials); err != nil {
+			a.recordError(cluster, "FailedToEnsureResourceGroup", err)
 			return cluster, err
 		}
+		a.recordEvent(cluster, "EnsuredResourceGroup", "Ensured resource group %q", cluster.Spec.Cloud.Azure.ResourceGroup)
 
 		cluster, err = update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
 			updatedCluster.Spec.Cloud.Azure.ResourceGroup = cluster.Spec.Cloud.Azure.ResourceGroup
@@ -558,13 +843,44 @@ func (a *Azure) InitializeCloudProvider(cluster *kubermaticv1.Cluster, update pr
 		}
 	}
 
+	// Only resource groups created or adopted by Kubermatic are locked, user-provided ones are left untouched.
+	if a.dc.LockResourceGroup && kuberneteshelper.HasFinalizer(cluster, FinalizerResourceGroup) {
+		if !kuberneteshelper.HasFinalizer(cluster, FinalizerResourceGroupLock) {
+			logger.Infow("ensuring resource group lock", "resourceGroup", cluster.Spec.Cloud.Azure.ResourceGroup)
+			if err = ensureResourceGroupLock(a.ctx, cluster.Spec.Cloud, cluster.Name, credentials); err != nil {
+				a.recordError(cluster, "FailedToEnsureResourceGroupLock", err)
+				return cluster, err
+			}
+			a.recordEvent(cluster, "EnsuredResourceGroupLock", "Locked resource group %q", cluster.Spec.Cloud.Azure.ResourceGroup)
+
+			cluster, err = update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+				kuberneteshelper.AddFinalizer(updatedCluster, FinalizerResourceGroupLock)
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+	} else if kuberneteshelper.HasFinalizer(cluster, FinalizerResourceGroupLock) {
+		// the option was disabled in the datacenter after the lock had been created
+		if cluster, err = a.removeResourceGroupLock(cluster, update, credentials); err != nil {
+			return cluster, err
+		}
+	}
+
+	progress.start(kubermaticv1.ClusterConditionAzureVNetReady)
 	if cluster.Spec.Cloud.Azure.VNetName == "" {
-		cluster.Spec.Cloud.Azure.VNetName = resourceNamePrefix + cluster.Name
+		if cluster.Spec.Cloud.Azure.VNetName, err = a.resourceName(cluster, resourceTypeVNet); err != nil {
+			return cluster, err
+		}
 
 		logger.Infow("ensuring vnet", "vnet", cluster.Spec.Cloud.Azure.VNetName)
-		if err = ensureVNet(a.ctx, cluster.Spec.Cloud, location, cluster.Name, credentials); err != nil {
-			return cluster, err
+		if err = a.pollOperation(cluster, update, credentials, "create-vnet", func() (autorestazure.FutureAPI, error) {
+			return ensureVNet(a.ctx, cluster.Spec.Cloud, location, tags, a.dc.DDoSProtectionPlanID, credentials)
+		}); err != nil {
+			a.recordError(cluster, "FailedToEnsureVNet", err)
+			return cluster, fmt.Errorf("failed to create or update virtual network %q: %w", cluster.Spec.Cloud.Azure.VNetName, err)
 		}
+		a.recordEvent(cluster, "EnsuredVNet", "Ensured virtual network %q", cluster.Spec.Cloud.Azure.VNetName)
 
 		cluster, err = update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
 			updatedCluster.Spec.Cloud.Azure.VNetName = cluster.Spec.Cloud.Azure.VNetName
@@ -575,13 +891,36 @@ func (a *Azure) InitializeCloudProvider(cluster *kubermaticv1.Cluster, update pr
 		}
 	}
 
+	if len(cluster.Spec.Cloud.Azure.DNSServers) > 0 && kuberneteshelper.HasFinalizer(cluster, FinalizerVNet) {
+		if err = a.pollOperation(cluster, update, credentials, "update-vnet-dns-servers", func() (autorestazure.FutureAPI, error) {
+			return ensureVNetDNSServers(a.ctx, cluster.Spec.Cloud, credentials)
+		}); err != nil {
+			return cluster, fmt.Errorf("failed to update DNS servers of virtual network %q: %w", cluster.Spec.Cloud.Azure.VNetName, err)
+		}
+	}
+
+	if a.dc.DDoSProtectionPlanID != "" && kuberneteshelper.HasFinalizer(cluster, FinalizerVNet) {
+		if err = a.pollOperation(cluster, update, credentials, "update-vnet-ddos-protection-plan", func() (autorestazure.FutureAPI, error) {
+			return ensureVNetDDoSProtectionPlan(a.ctx, cluster.Spec.Cloud, a.dc.DDoSProtectionPlanID, credentials)
+		}); err != nil {
+			return cluster, fmt.Errorf("failed to associate virtual network %q with DDoS protection plan: %w", cluster.Spec.Cloud.Azure.VNetName, err)
+		}
+	}
+
+	progress.start(kubermaticv1.ClusterConditionAzureSubnetReady)
 	if cluster.Spec.Cloud.Azure.SubnetName == "" {
-		cluster.Spec.Cloud.Azure.SubnetName = resourceNamePrefix + cluster.Name
+		if cluster.Spec.Cloud.Azure.SubnetName, err = a.resourceName(cluster, resourceTypeSubnet); err != nil {
+			return cluster, err
+		}
 
 		logger.Infow("ensuring subnet", "subnet", cluster.Spec.Cloud.Azure.SubnetName)
-		if err = ensureSubnet(a.ctx, cluster.Spec.Cloud, credentials); err != nil {
-			return cluster, err
+		if err = a.pollOperation(cluster, update, credentials, "create-subnet", func() (autorestazure.FutureAPI, error) {
+			return ensureSubnet(a.ctx, cluster.Spec.Cloud, credentials)
+		}); err != nil {
+			a.recordError(cluster, "FailedToEnsureSubnet", err)
+			return cluster, fmt.Errorf("failed to create or update subnetwork %q: %w", cluster.Spec.Cloud.Azure.SubnetName, err)
 		}
+		a.recordEvent(cluster, "EnsuredSubnet", "Ensured subnet %q", cluster.Spec.Cloud.Azure.SubnetName)
 
 		cluster, err = update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
 			updatedCluster.Spec.Cloud.Azure.SubnetName = cluster.Spec.Cloud.Azure.SubnetName
@@ -592,14 +931,31 @@ func (a *Azure) InitializeCloudProvider(cluster *kubermaticv1.Cluster, update pr
 		}
 	}
 
-	if cluster.Spec.Cloud.Azure.RouteTableName == "" {
-		cluster.Spec.Cloud.Azure.RouteTableName = resourceNamePrefix + cluster.Name
+	if len(cluster.Spec.Cloud.Azure.ServiceEndpoints) > 0 && kuberneteshelper.HasFinalizer(cluster, FinalizerSubnet) {
+		if err = a.pollOperation(cluster, update, credentials, "update-subnet-service-endpoints", func() (autorestazure.FutureAPI, error) {
+			return ensureSubnet(a.ctx, cluster.Spec.Cloud, credentials)
+		}); err != nil {
+			return cluster, fmt.Errorf("failed to update service endpoints of subnetwork %q: %w", cluster.Spec.Cloud.Azure.SubnetName, err)
+		}
+	}
 
-		logger.Infow("ensuring route table", "routeTableName", cluster.Spec.Cloud.Azure.RouteTableName)
-		if err = ensureRouteTable(a.ctx, cluster.Spec.Cloud, location, credentials); err != nil {
+	if hasRouteTable(cluster.Spec.Cloud) {
+		progress.start(kubermaticv1.ClusterConditionAzureRouteTableReady)
+	}
+	if cluster.Spec.Cloud.Azure.RouteTableName == "" && needsRouteTable(cluster.Spec.Cloud) {
+		if cluster.Spec.Cloud.Azure.RouteTableName, err = a.resourceName(cluster, resourceTypeRouteTable); err != nil {
 			return cluster, err
 		}
 
+		logger.Infow("ensuring route table", "routeTableName", cluster.Spec.Cloud.Azure.RouteTableName)
+		if err = a.pollOperation(cluster, update, credentials, "create-route-table", func() (autorestazure.FutureAPI, error) {
+			return ensureRouteTable(a.ctx, cluster.Spec.Cloud, location, tags, credentials)
+		}); err != nil {
+			a.recordError(cluster, "FailedToEnsureRouteTable", err)
+			return cluster, fmt.Errorf("failed to create or update route table %q: %w", cluster.Spec.Cloud.Azure.RouteTableName, err)
+		}
+		a.recordEvent(cluster, "EnsuredRouteTable", "Ensured route table %q", cluster.Spec.Cloud.Azure.RouteTableName)
+
 		cluster, err = update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
 			updatedCluster.Spec.Cloud.Azure.RouteTableName = cluster.Spec.Cloud.Azure.RouteTableName
 			kuberneteshelper.AddFinalizer(updatedCluster, FinalizerRouteTable)
@@ -609,13 +965,27 @@ func (a *Azure) InitializeCloudProvider(cluster *kubermaticv1.Cluster, update pr
 		}
 	}
 
+	if err := a.reconcileRoutes(cluster, credentials); err != nil {
+		return cluster, fmt.Errorf("failed to reconcile routes of route table %q: %w", cluster.Spec.Cloud.Azure.RouteTableName, err)
+	}
+
+	progress.start(kubermaticv1.ClusterConditionNone)
+	if cluster, err = a.reconcileApplicationSecurityGroup(cluster, update, credentials, location, tags); err != nil {
+		return cluster, err
+	}
+
+	progress.start(kubermaticv1.ClusterConditionAzureSecurityGroupReady)
 	if cluster.Spec.Cloud.Azure.SecurityGroup == "" {
-		cluster.Spec.Cloud.Azure.SecurityGroup = resourceNamePrefix + cluster.Name
+		if cluster.Spec.Cloud.Azure.SecurityGroup, err = a.resourceName(cluster, resourceTypeSecurityGroup); err != nil {
+			return cluster, err
+		}
 
 		logger.Infow("ensuring security group", "securityGroup", cluster.Spec.Cloud.Azure.SecurityGroup)
-		if err = a.ensureSecurityGroup(cluster.Spec.Cloud, location, cluster.Name, credentials); err != nil {
+		if err = a.ensureSecurityGroup(cluster.Spec.Cloud, location, tags, credentials); err != nil {
+			a.recordError(cluster, "FailedToEnsureSecurityGroup", err)
 			return cluster, err
 		}
+		a.recordEvent(cluster, "EnsuredSecurityGroup", "Ensured security group %q", cluster.Spec.Cloud.Azure.SecurityGroup)
 
 		cluster, err = update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
 			updatedCluster.Spec.Cloud.Azure.SecurityGroup = cluster.Spec.Cloud.Azure.SecurityGroup
@@ -626,13 +996,95 @@ func (a *Azure) InitializeCloudProvider(cluster *kubermaticv1.Cluster, update pr
 		}
 	}
 
+	if cluster, err = a.reconcileSubnetAssociations(cluster, update, credentials); err != nil {
+		return cluster, err
+	}
+
+	progress.start(kubermaticv1.ClusterConditionNone)
+	if cluster, err = a.reconcileAdditionalSubnets(cluster, update, credentials); err != nil {
+		return cluster, err
+	}
+
+	if cluster, err = a.reconcileVNetPeerings(cluster, update, credentials); err != nil {
+		return cluster, err
+	}
+
+	if cluster, err = a.reconcileEgressFirewall(cluster, update, credentials); err != nil {
+		return cluster, err
+	}
+
+	if cluster.Spec.Cloud.Azure.PrivateCluster {
+		if cluster, err = a.reconcilePrivateCluster(cluster, update, credentials, location, tags); err != nil {
+			return cluster, err
+		}
+	}
+
+	if cluster, err = a.reconcilePrivateEndpoints(cluster, update, credentials, location, tags); err != nil {
+		return cluster, err
+	}
+
+	if cluster, err = a.reconcileBastion(cluster, update, credentials, location, tags); err != nil {
+		return cluster, err
+	}
+
+	if cluster, err = a.reconcileBootDiagnostics(cluster, update, credentials, location, tags); err != nil {
+		return cluster, err
+	}
+
+	if cluster, err = a.reconcileLogAnalytics(cluster, update, credentials, location, tags); err != nil {
+		return cluster, err
+	}
+
+	if cluster, err = a.reconcileKMSKey(cluster, update, credentials); err != nil {
+		return cluster, err
+	}
+
+	if cluster.Spec.Cloud.Azure.PrivateCluster || cluster.Spec.Cloud.Azure.EnablePrivateDNSZone {
+		if cluster, err = a.reconcilePrivateDNSZone(cluster, update, credentials, tags); err != nil {
+			return cluster, err
+		}
+	}
+
+	progress.start(kubermaticv1.ClusterConditionAzureAvailabilitySetReady)
+	// An availability set can only be assigned to a proximity placement group as long as it
+	// does not contain any VMs, so the group is only created together with the availability set.
+	if cluster.Spec.Cloud.Azure.AvailabilitySet == "" && cluster.Spec.Cloud.Azure.EnableProximityPlacementGroup && cluster.Spec.Cloud.Azure.ProximityPlacementGroupID == "" {
+		ppgName := resourceNamePrefix + cluster.Name
+		logger.Infow("ensuring proximity placement group", "proximityPlacementGroup", ppgName)
+
+		ppgID, err := ensureProximityPlacementGroup(a.ctx, ppgName, location, tags, cluster.Spec.Cloud, credentials)
+		if err != nil {
+			a.recordError(cluster, "FailedToEnsureProximityPlacementGroup", err)
+			return nil, fmt.Errorf("failed to ensure proximity placement group exists: %w", err)
+		}
+		a.recordEvent(cluster, "EnsuredProximityPlacementGroup", "Ensured proximity placement group %q", ppgName)
+
+		cluster, err = update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+			updatedCluster.Spec.Cloud.Azure.ProximityPlacementGroupID = ppgID
+			kuberneteshelper.AddFinalizer(updatedCluster, FinalizerProximityPlacementGroup)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if cluster.Spec.Cloud.Azure.AvailabilitySet == "" {
-		asName := resourceNamePrefix + cluster.Name
+		asName, err := a.resourceName(cluster, resourceTypeAvailabilitySet)
+		if err != nil {
+			return cluster, err
+		}
 		logger.Infow("ensuring AvailabilitySet", "availabilitySet", asName)
 
-		if err := ensureAvailabilitySet(a.ctx, asName, location, cluster.Spec.Cloud, credentials); err != nil {
-			return nil, fmt.Errorf("failed to ensure AvailabilitySet exists: %v", err)
+		faultDomainCount, updateDomainCount, err := a.availabilitySetDomainCounts(credentials)
+		if err != nil {
+			a.recordError(cluster, "FailedToEnsureAvailabilitySet", err)
+			return nil, err
 		}
+		if err := ensureAvailabilitySet(a.ctx, asName, location, faultDomainCount, updateDomainCount, tags, cluster.Spec.Cloud, credentials); err != nil {
+			a.recordError(cluster, "FailedToEnsureAvailabilitySet", err)
+			return nil, fmt.Errorf("failed to ensure AvailabilitySet exists: %w", err)
+		}
+		a.recordEvent(cluster, "EnsuredAvailabilitySet", "Ensured availability set %q", asName)
 
 		cluster, err = update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
 			updatedCluster.Spec.Cloud.Azure.AvailabilitySet = asName
@@ -643,37 +1095,81 @@ func (a *Azure) InitializeCloudProvider(cluster *kubermaticv1.Cluster, update pr
 		}
 	}
 
-	return cluster, nil
+	if cluster, err = a.reconcileMachineDeploymentAvailabilitySets(cluster, update, credentials, location, tags); err != nil {
+		return cluster, err
+	}
+
+	progress.start(kubermaticv1.ClusterConditionNone)
+	if err := a.reconcileTags(cluster, tags, credentials); err != nil {
+		return cluster, fmt.Errorf("failed to reconcile tags: %w", err)
+	}
+
+	return reconcileCloudProviderResources(cluster, update, credentials.SubscriptionID)
 }
 
-func ensureAvailabilitySet(ctx context.Context, name, location string, cloud kubermaticv1.CloudSpec, credentials Credentials) error {
+func ensureAvailabilitySet(ctx context.Context, name, location string, faultDomainCount, updateDomainCount int32, tags map[string]*string, cloud kubermaticv1.CloudSpec, credentials Credentials) error {
 	client, err := getAvailabilitySetClient(cloud, credentials)
 	if err != nil {
 		return err
 	}
 
-	faultDomainCount, ok := faultDomainsPerRegion[location]
-	if !ok {
-		return fmt.Errorf("could not determine the number of fault domains, unknown region %q", location)
-	}
-
 	as := compute.AvailabilitySet{
 		Name:     to.StringPtr(name),
 		Location: to.StringPtr(location),
+		Tags:     tags,
 		Sku: &compute.Sku{
 			Name: to.StringPtr("Aligned"),
 		},
 		AvailabilitySetProperties: &compute.AvailabilitySetProperties{
 			PlatformFaultDomainCount:  to.Int32Ptr(faultDomainCount),
-			PlatformUpdateDomainCount: to.Int32Ptr(20),
+			PlatformUpdateDomainCount: to.Int32Ptr(updateDomainCount),
 		},
 	}
+	if cloud.Azure.ProximityPlacementGroupID != "" {
+		as.ProximityPlacementGroup = &compute.SubResource{
+			ID: to.StringPtr(cloud.Azure.ProximityPlacementGroupID),
+		}
+	}
 
 	_, err = client.CreateOrUpdate(ctx, cloud.Azure.ResourceGroup, name, as)
 	return err
 }
 
+// ensureProximityPlacementGroup will create or update a proximity placement group and return its
+// resource ID. The call is idempotent.
+func ensureProximityPlacementGroup(ctx context.Context, name, location string, tags map[string]*string, cloud kubermaticv1.CloudSpec, credentials Credentials) (string, error) {
+	client, err := getProximityPlacementGroupClient(cloud, credentials)
+	if err != nil {
+		return "", err
+	}
+
+	ppg := compute.ProximityPlacementGroup{
+		Name:     to.StringPtr(name),
+		Location: to.StringPtr(location),
+		Tags:     tags,
+		ProximityPlacementGroupProperties: &compute.ProximityPlacementGroupProperties{
+			ProximityPlacementGroupType: compute.Standard,
+		},
+	}
+
+	result, err := client.CreateOrUpdate(ctx, cloud.Azure.ResourceGroup, name, ppg)
+	if err != nil {
+		return "", err
+	}
+	if result.ID == nil {
+		return "", fmt.Errorf("proximity placement group %q has no ID", name)
+	}
+
+	return *result.ID, nil
+}
+
+// DefaultCloudSpec completes the names of the resources referenced by ID and applies the
+// defaults of the datacenter.
 func (a *Azure) DefaultCloudSpec(cloud *kubermaticv1.CloudSpec) error {
+	if cloud.Azure != nil {
+		setReferencedResourceNames(cloud)
+		setDatacenterDefaults(cloud, a.dc)
+	}
 	return nil
 }
 
@@ -683,6 +1179,22 @@ func (a *Azure) ValidateCloudSpec(cloud kubermaticv1.CloudSpec) error {
 		return err
 	}
 
+	setReferencedResourceNames(&cloud)
+	if err := validateVNetSubscription(cloud, credentials.SubscriptionID); err != nil {
+		return err
+	}
+	// the referenced route table and security group are associated with the subnet, so they
+	// have to be in the subscription of the VNet
+	if err := validateReferencedResourceIDs(cloud, networkCredentials(cloud, credentials).SubscriptionID); err != nil {
+		return err
+	}
+	if err := validateAddressRanges(cloud); err != nil {
+		return err
+	}
+	if err := a.validateManagedTags(cloud); err != nil {
+		return err
+	}
+
 	if cloud.Azure.ResourceGroup != "" {
 		rgClient, err := getGroupsClient(cloud, credentials)
 		if err != nil {
@@ -710,26 +1222,46 @@ func (a *Azure) ValidateCloudSpec(cloud kubermaticv1.CloudSpec) error {
 		}
 	}
 
+	var subnet network.Subnet
 	if cloud.Azure.SubnetName != "" {
 		subnetClient, err := getSubnetsClient(cloud, credentials)
 		if err != nil {
 			return err
 		}
 
-		if _, err = subnetClient.Get(a.ctx, resourceGroup, cloud.Azure.VNetName, cloud.Azure.SubnetName, ""); err != nil {
+		if subnet, err = subnetClient.Get(a.ctx, resourceGroup, cloud.Azure.VNetName, cloud.Azure.SubnetName, ""); err != nil {
 			return err
 		}
 	}
 
+	// subnets created by Kubermatic are large enough for Azure CNI
+	if cloud.Azure.SubnetName != "" && usesAzureCNI(cloud) {
+		if err := validateAzureCNISubnet(subnet); err != nil {
+			return fmt.Errorf("subnet %q is too small: %w", cloud.Azure.SubnetName, err)
+		}
+	}
+
 	if cloud.Azure.RouteTableName != "" {
 		routeTablesClient, err := getRouteTablesClient(cloud, credentials)
 		if err != nil {
 			return err
 		}
 
-		if _, err = routeTablesClient.Get(a.ctx, cloud.Azure.ResourceGroup, cloud.Azure.RouteTableName, ""); err != nil {
+		routeTable, err := routeTablesClient.Get(a.ctx, RouteTableResourceGroup(cloud), cloud.Azure.RouteTableName, "")
+		if err != nil {
 			return err
 		}
+
+		// A pre-existing subnet the cluster nodes will be placed in must either not be associated
+		// with any route table yet, Kubermatic associates it with the route table later on, or
+		// already be associated with this route table. A subnet can only have a single route table.
+		if cloud.Azure.SubnetName != "" && !isRouteTableAssociated(subnet, routeTable) {
+			return fmt.Errorf("subnet %q is associated with a different route table than %q", cloud.Azure.SubnetName, cloud.Azure.RouteTableName)
+		}
+		// route tables referenced by ID are not associated with existing subnets by Kubermatic
+		if cloud.Azure.RouteTableID != "" && cloud.Azure.SubnetName != "" && (subnet.SubnetPropertiesFormat == nil || subnet.RouteTable == nil) {
+			return fmt.Errorf("route table %q is not associated with subnet %q", cloud.Azure.RouteTableName, cloud.Azure.SubnetName)
+		}
 	}
 
 	if cloud.Azure.SecurityGroup != "" {
@@ -738,14 +1270,140 @@ func (a *Azure) ValidateCloudSpec(cloud kubermaticv1.CloudSpec) error {
 			return err
 		}
 
-		if _, err = sgClient.Get(a.ctx, cloud.Azure.ResourceGroup, cloud.Azure.SecurityGroup, ""); err != nil {
+		if _, err = sgClient.Get(a.ctx, SecurityGroupResourceGroup(cloud), cloud.Azure.SecurityGroup, ""); err != nil {
 			return err
 		}
+
+		// security groups referenced by ID apply to the nodes via the subnet
+		if cloud.Azure.SecurityGroupID != "" && cloud.Azure.SubnetName != "" && !isSecurityGroupAssociated(subnet, cloud.Azure.SecurityGroupID) {
+			return fmt.Errorf("security group %q is not associated with subnet %q", cloud.Azure.SecurityGroup, cloud.Azure.SubnetName)
+		}
 	}
 
-	return nil
+	if cloud.Azure.AvailabilitySet != "" {
+		asClient, err := getAvailabilitySetClient(cloud, credentials)
+		if err != nil {
+			return err
+		}
+
+		as, err := asClient.Get(a.ctx, cloud.Azure.ResourceGroup, cloud.Azure.AvailabilitySet)
+		if err != nil {
+			return err
+		}
+
+		if as.Location != nil && !strings.EqualFold(*as.Location, a.dc.Location) {
+			return fmt.Errorf("availability set %q is located in %q, but the datacenter is located in %q", cloud.Azure.AvailabilitySet, *as.Location, a.dc.Location)
+		}
+	}
+
+	if cloud.Azure.DiskEncryptionSetID != "" {
+		if err := validateDiskEncryptionSet(a.ctx, cloud, credentials, a.dc.Location); err != nil {
+			return err
+		}
+	}
+
+	if nodeSecurity := cloud.Azure.NodeSecurity; nodeSecurity != nil && nodeSecurity.EncryptionAtHost {
+		if err := validateEncryptionAtHostFeature(a.ctx, credentials); err != nil {
+			return err
+		}
+	}
+
+	if cloud.Azure.ProximityPlacementGroupID != "" {
+		if _, err := autorestazure.ParseResourceID(cloud.Azure.ProximityPlacementGroupID); err != nil {
+			return fmt.Errorf("invalid proximity placement group ID %q: %w", cloud.Azure.ProximityPlacementGroupID, err)
+		}
+	}
+
+	if bootDiagnostics := cloud.Azure.BootDiagnostics; bootDiagnostics != nil && bootDiagnostics.StorageAccountID != "" {
+		account, err := getStorageAccount(a.ctx, bootDiagnostics.StorageAccountID, credentials)
+		if err != nil {
+			return err
+		}
+
+		if account.Location != nil && !strings.EqualFold(*account.Location, a.dc.Location) {
+			return fmt.Errorf("storage account %q is located in %q, but the datacenter is located in %q", bootDiagnostics.StorageAccountID, *account.Location, a.dc.Location)
+		}
+	}
+
+	if err := validateLogAnalytics(cloud.Azure.LogAnalytics); err != nil {
+		return err
+	}
+	if logAnalytics := cloud.Azure.LogAnalytics; logAnalytics != nil && logAnalytics.WorkspaceID != "" {
+		workspace, err := parseLogAnalyticsWorkspaceID(logAnalytics.WorkspaceID)
+		if err != nil {
+			return err
+		}
+		workspacesClient, err := getWorkspacesClient(workspace.SubscriptionID, credentials)
+		if err != nil {
+			return err
+		}
+		if _, err = workspacesClient.Get(a.ctx, workspace.ResourceGroup, workspace.ResourceName); err != nil {
+			return fmt.Errorf("failed to get Log Analytics workspace %q: %w", logAnalytics.WorkspaceID, err)
+		}
+	}
+
+	if _, err := a.desiredPeerVNets("", cloud); err != nil {
+		return err
+	}
+
+	if a.dc.DDoSProtectionPlanID != "" {
+		if err := validateDDoSProtectionPlanID(a.dc.DDoSProtectionPlanID); err != nil {
+			return err
+		}
+	}
+
+	if err := validateKMSSettings(cloud.Azure.KMS); err != nil {
+		return err
+	}
+
+	if err := validateSecurityGroupRulePriority(cloud.Azure.SecurityGroupRulePriority); err != nil {
+		return err
+	}
+
+	if err := validateRoutes(cloud.Azure.Routes); err != nil {
+		return err
+	}
+
+	if err := validateEgressFirewall(cloud); err != nil {
+		return err
+	}
+
+	if err := validateServiceEndpoints(cloud.Azure.ServiceEndpoints); err != nil {
+		return err
+	}
+
+	if err := validatePrivateEndpoints(cloud.Azure.PrivateEndpoints); err != nil {
+		return err
+	}
+
+	if len(cloud.Azure.AdditionalSubnets) > 0 {
+		if err := a.validateClusterAdditionalSubnets(cloud, subnet, credentials); err != nil {
+			return err
+		}
+	}
+
+	if err := validateAdoptedResources(cloud); err != nil {
+		return err
+	}
+
+	// the availability set is created with the domain counts of the datacenter
+	if cloud.Azure.AvailabilitySet == "" {
+		if _, _, err := a.availabilitySetDomainCounts(credentials); err != nil {
+			return err
+		}
+	}
+
+	for _, server := range cloud.Azure.DNSServers {
+		if net.ParseIP(server) == nil {
+			return fmt.Errorf("invalid DNS server %q: not an IP address", server)
+		}
+	}
+
+	return a.validatePermissions(cloud, credentials)
 }
 
+// AddICMPRulesIfRequired will create a rule that allows ICMP traffic if it does not yet exist and
+// removes the rules older clusters used to allow ICMP.
 func (a *Azure) AddICMPRulesIfRequired(cluster *kubermaticv1.Cluster) error {
 	credentials, err := GetCredentialsForCluster(cluster.Spec.Cloud, a.secretKeySelector)
 	if err != nil {
@@ -753,65 +1411,122 @@ func (a *Azure) AddICMPRulesIfRequired(cluster *kubermaticv1.Cluster) error {
 	}
 
 	azure := cluster.Spec.Cloud.Azure
-	if azure.SecurityGroup == "" {
+	// security groups referenced by ID are not modified
+	if azure.SecurityGroup == "" || azure.SecurityGroupID != "" {
 		return nil
 	}
 	sgClient, err := getSecurityGroupsClient(cluster.Spec.Cloud, credentials)
 	if err != nil {
-		return fmt.Errorf("failed to get security group client: %v", err)
+		return fmt.Errorf("failed to get security group client: %w", err)
 	}
 	sg, err := sgClient.Get(a.ctx, azure.ResourceGroup, azure.SecurityGroup, "")
 	if err != nil {
-		return fmt.Errorf("failed to get security group %q: %v", azure.SecurityGroup, err)
+		return fmt.Errorf("failed to get security group %q: %w", azure.SecurityGroup, err)
+	}
+
+	icmpRule := icmpAllowRule(cluster.Spec.Cloud)
+	if azure.ApplicationSecurityGroup != "" {
+		targetApplicationSecurityGroup([]network2020.SecurityRule{icmpRule}, assembleApplicationSecurityGroupID(cluster.Spec.Cloud, credentials.SubscriptionID))
 	}
 
-	var hasDenyAllTCPRule, hasDenyAllUDPRule, hasICMPAllowAllRule bool
+	var existingRules []network2020.SecurityRule
 	if sg.SecurityRules != nil {
-		for _, rule := range *sg.SecurityRules {
-			if rule.Name == nil {
-				continue
-			}
-			// We trust that no one will alter the content of the rules
-			switch *rule.Name {
-			case denyAllTCPSecGroupRuleName:
-				hasDenyAllTCPRule = true
-			case denyAllUDPSecGroupRuleName:
-				hasDenyAllUDPRule = true
-			case allowAllICMPSecGroupRuleName:
-				hasICMPAllowAllRule = true
-			}
-		}
+		existingRules = *sg.SecurityRules
 	}
 
-	var newSecurityRules []network.SecurityRule
-	if !hasDenyAllTCPRule {
-		a.log.With("cluster", cluster.Name).Info("Creating TCP deny all rule")
-		newSecurityRules = append(newSecurityRules, tcpDenyAllRule())
+	securityRules, changed, err := reconcileICMPRules(existingRules, icmpRule)
+	if err != nil {
+		return fmt.Errorf("failed to add ICMP rule to security group %q: %w", azure.SecurityGroup, err)
 	}
-	if !hasDenyAllUDPRule {
-		a.log.With("cluster", cluster.Name).Info("Creating UDP deny all rule")
-		newSecurityRules = append(newSecurityRules, udpDenyAllRule())
+	if changed {
+		a.log.With("cluster", cluster.Name).Info("Updating ICMP rules of security group")
+		sg.SecurityRules = &securityRules
+		_, err := sgClient.CreateOrUpdate(a.ctx, azure.ResourceGroup, azure.SecurityGroup, sg)
+		if err != nil {
+			return fmt.Errorf("failed to update rules of security group %q: %w", *sg.Name, err)
+		}
 	}
-	if !hasICMPAllowAllRule {
-		a.log.With("cluster", cluster.Name).Info("Creating ICMP allow all rule")
-		newSecurityRules = append(newSecurityRules, icmpAllowAllRule())
+	return nil
+}
+
+// reconcileICMPRules drops the legacy ICMP workaround rules and adds the ICMP rule if it is missing.
+// If the priority of the ICMP rule is already taken by another rule, the next free one is used.
+// It returns the resulting rules and whether they differ from the given ones.
+func reconcileICMPRules(rules []network2020.SecurityRule, icmpRule network2020.SecurityRule) ([]network2020.SecurityRule, bool, error) {
+	var (
+		result      []network2020.SecurityRule
+		hasICMPRule bool
+		changed     bool
+	)
+
+	for _, rule := range rules {
+		// We trust that no one will alter the content of the rules
+		switch to.String(rule.Name) {
+		case legacyDenyAllTCPSecGroupRuleName, legacyDenyAllUDPSecGroupRuleName, legacyAllowAllICMPSecGroupRuleName:
+			changed = true
+			continue
+		case allowICMPSecGroupRuleName:
+			hasICMPRule = true
+		}
+		result = append(result, rule)
 	}
 
-	if len(newSecurityRules) > 0 {
-		newSecurityGroupRules := append(*sg.SecurityRules, newSecurityRules...)
-		sg.SecurityRules = &newSecurityGroupRules
-		_, err := sgClient.CreateOrUpdate(a.ctx, azure.ResourceGroup, azure.SecurityGroup, sg)
+	if !hasICMPRule {
+		priority, err := freeSecurityRulePriority(result, icmpRule.Direction, to.Int32(icmpRule.Priority))
 		if err != nil {
-			return fmt.Errorf("failed to add new rules to security group %q: %v", *sg.Name, err)
+			return nil, false, err
+		}
+		icmpRule.Priority = to.Int32Ptr(priority)
+
+		result = append(result, icmpRule)
+		changed = true
+	}
+
+	return result, changed, nil
+}
+
+// freeSecurityRulePriority returns the first priority starting at the given one which is not yet
+// used by any of the rules with the same direction.
+func freeSecurityRulePriority(rules []network2020.SecurityRule, direction network2020.SecurityRuleDirection, priority int32) (int32, error) {
+	used := map[int32]bool{}
+	for _, rule := range rules {
+		if rule.SecurityRulePropertiesFormat != nil && rule.Direction == direction && rule.Priority != nil {
+			used[*rule.Priority] = true
+		}
+	}
+
+	for p := priority; p <= maxSecurityGroupRulePriority; p++ {
+		if !used[p] {
+			return p, nil
 		}
 	}
+
+	return 0, fmt.Errorf("no free %s security rule priority left between %d and %d", strings.ToLower(string(direction)), priority, maxSecurityGroupRulePriority)
+}
+
+// securityGroupRulePriority returns the base priority of the rules Kubermatic adds to the security group.
+func securityGroupRulePriority(cloud kubermaticv1.CloudSpec) int32 {
+	if cloud.Azure.SecurityGroupRulePriority != 0 {
+		return cloud.Azure.SecurityGroupRulePriority
+	}
+	return defaultSecurityGroupRulePriority
+}
+
+// validateSecurityGroupRulePriority ensures all generated rules fit into the valid priority range.
+func validateSecurityGroupRulePriority(priority int32) error {
+	if priority == 0 {
+		return nil
+	}
+	if priority < defaultSecurityGroupRulePriority || priority+icmpSecGroupRulePriorityOffset > maxSecurityGroupRulePriority {
+		return fmt.Errorf("security group rule priority must be between %d and %d, got %d", defaultSecurityGroupRulePriority, maxSecurityGroupRulePriority-icmpSecGroupRulePriorityOffset, priority)
+	}
 	return nil
 }
 
 func getGroupsClient(cloud kubermaticv1.CloudSpec, credentials Credentials) (*resources.GroupsClient, error) {
 	var err error
 	groupsClient := resources.NewGroupsClient(credentials.SubscriptionID)
-	groupsClient.Authorizer, err = auth.NewClientCredentialsConfig(credentials.ClientID, credentials.ClientSecret, credentials.TenantID).Authorizer()
+	err = configureClient(&groupsClient.Client, credentials)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create authorizer: %s", err.Error())
 	}
@@ -819,10 +1534,22 @@ func getGroupsClient(cloud kubermaticv1.CloudSpec, credentials Credentials) (*re
 	return &groupsClient, nil
 }
 
+func getManagementLocksClient(cloud kubermaticv1.CloudSpec, credentials Credentials) (*locks.ManagementLocksClient, error) {
+	var err error
+	locksClient := locks.NewManagementLocksClient(credentials.SubscriptionID)
+	err = configureClient(&locksClient.Client, credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authorizer: %s", err.Error())
+	}
+
+	return &locksClient, nil
+}
+
 func getNetworksClient(cloud kubermaticv1.CloudSpec, credentials Credentials) (*network.VirtualNetworksClient, error) {
 	var err error
+	credentials = networkCredentials(cloud, credentials)
 	networksClient := network.NewVirtualNetworksClient(credentials.SubscriptionID)
-	networksClient.Authorizer, err = auth.NewClientCredentialsConfig(credentials.ClientID, credentials.ClientSecret, credentials.TenantID).Authorizer()
+	err = configureClient(&networksClient.Client, credentials)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create authorizer: %s", err.Error())
 	}
@@ -832,8 +1559,9 @@ func getNetworksClient(cloud kubermaticv1.CloudSpec, credentials Credentials) (*
 
 func getSubnetsClient(cloud kubermaticv1.CloudSpec, credentials Credentials) (*network.SubnetsClient, error) {
 	var err error
+	credentials = networkCredentials(cloud, credentials)
 	subnetsClient := network.NewSubnetsClient(credentials.SubscriptionID)
-	subnetsClient.Authorizer, err = auth.NewClientCredentialsConfig(credentials.ClientID, credentials.ClientSecret, credentials.TenantID).Authorizer()
+	err = configureClient(&subnetsClient.Client, credentials)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create authorizer: %s", err.Error())
 	}
@@ -843,8 +1571,9 @@ func getSubnetsClient(cloud kubermaticv1.CloudSpec, credentials Credentials) (*n
 
 func getRouteTablesClient(cloud kubermaticv1.CloudSpec, credentials Credentials) (*network.RouteTablesClient, error) {
 	var err error
+	credentials = networkCredentials(cloud, credentials)
 	routeTablesClient := network.NewRouteTablesClient(credentials.SubscriptionID)
-	routeTablesClient.Authorizer, err = auth.NewClientCredentialsConfig(credentials.ClientID, credentials.ClientSecret, credentials.TenantID).Authorizer()
+	err = configureClient(&routeTablesClient.Client, credentials)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create authorizer: %s", err.Error())
 	}
@@ -852,10 +1581,11 @@ func getRouteTablesClient(cloud kubermaticv1.CloudSpec, credentials Credentials)
 	return &routeTablesClient, nil
 }
 
-func getSecurityGroupsClient(cloud kubermaticv1.CloudSpec, credentials Credentials) (*network.SecurityGroupsClient, error) {
+func getSecurityGroupsClient(cloud kubermaticv1.CloudSpec, credentials Credentials) (*network2020.SecurityGroupsClient, error) {
 	var err error
-	securityGroupsClient := network.NewSecurityGroupsClient(credentials.SubscriptionID)
-	securityGroupsClient.Authorizer, err = auth.NewClientCredentialsConfig(credentials.ClientID, credentials.ClientSecret, credentials.TenantID).Authorizer()
+	credentials = networkCredentials(cloud, credentials)
+	securityGroupsClient := network2020.NewSecurityGroupsClient(credentials.SubscriptionID)
+	err = configureClient(&securityGroupsClient.Client, credentials)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create authorizer: %s", err.Error())
 	}
@@ -866,7 +1596,7 @@ func getSecurityGroupsClient(cloud kubermaticv1.CloudSpec, credentials Credentia
 func getAvailabilitySetClient(cloud kubermaticv1.CloudSpec, credentials Credentials) (*compute.AvailabilitySetsClient, error) {
 	var err error
 	asClient := compute.NewAvailabilitySetsClient(credentials.SubscriptionID)
-	asClient.Authorizer, err = auth.NewClientCredentialsConfig(credentials.ClientID, credentials.ClientSecret, credentials.TenantID).Authorizer()
+	err = configureClient(&asClient.Client, credentials)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create authorizer: %s", err.Error())
 	}
@@ -874,61 +1604,71 @@ func getAvailabilitySetClient(cloud kubermaticv1.CloudSpec, credentials Credenti
 	return &asClient, nil
 }
 
-func tcpDenyAllRule() network.SecurityRule {
-	return network.SecurityRule{
-		Name: to.StringPtr(denyAllTCPSecGroupRuleName),
-		SecurityRulePropertiesFormat: &network.SecurityRulePropertiesFormat{
-			Direction:                network.SecurityRuleDirectionInbound,
-			Protocol:                 "TCP",
-			SourceAddressPrefix:      to.StringPtr("*"),
-			SourcePortRange:          to.StringPtr("*"),
-			DestinationPortRange:     to.StringPtr("*"),
-			DestinationAddressPrefix: to.StringPtr("*"),
-			Access:                   network.SecurityRuleAccessDeny,
-			Priority:                 to.Int32Ptr(800),
-		},
+func getProximityPlacementGroupClient(cloud kubermaticv1.CloudSpec, credentials Credentials) (*compute.ProximityPlacementGroupsClient, error) {
+	var err error
+	ppgClient := compute.NewProximityPlacementGroupsClient(credentials.SubscriptionID)
+	err = configureClient(&ppgClient.Client, credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authorizer: %s", err.Error())
 	}
+
+	return &ppgClient, nil
 }
 
-func udpDenyAllRule() network.SecurityRule {
-	return network.SecurityRule{
-		Name: to.StringPtr(denyAllUDPSecGroupRuleName),
-		SecurityRulePropertiesFormat: &network.SecurityRulePropertiesFormat{
-			Direction:                network.SecurityRuleDirectionInbound,
-			Protocol:                 "UDP",
-			SourceAddressPrefix:      to.StringPtr("*"),
+func icmpAllowRule(cloud kubermaticv1.CloudSpec) network2020.SecurityRule {
+	return network2020.SecurityRule{
+		Name: to.StringPtr(allowICMPSecGroupRuleName),
+		SecurityRulePropertiesFormat: &network2020.SecurityRulePropertiesFormat{
+			Direction:                network2020.SecurityRuleDirectionInbound,
+			Protocol:                 network2020.SecurityRuleProtocolIcmp,
+			SourceAddressPrefix:      to.StringPtr(inboundSourceAddressPrefix(cloud)),
 			SourcePortRange:          to.StringPtr("*"),
-			DestinationPortRange:     to.StringPtr("*"),
 			DestinationAddressPrefix: to.StringPtr("*"),
-			Access:                   network.SecurityRuleAccessDeny,
-			Priority:                 to.Int32Ptr(801),
+			DestinationPortRange:     to.StringPtr("*"),
+			Access:                   network2020.SecurityRuleAccessAllow,
+			Priority:                 to.Int32Ptr(securityGroupRulePriority(cloud) + icmpSecGroupRulePriorityOffset),
 		},
 	}
 }
 
-// Alright, so here's the deal. We need to allow ICMP, but on Azure it is not possible
-// to specify ICMP as a protocol in a rule - only TCP or UDP.
-// Therefore we're hacking around it by first blocking all incoming TCP and UDP
-// and if these don't match, we have an "allow all" rule. Dirty, but the only way.
-// See also: https://tinyurl.com/azure-allow-icmp
-func icmpAllowAllRule() network.SecurityRule {
-	return network.SecurityRule{
-		Name: to.StringPtr(allowAllICMPSecGroupRuleName),
-		SecurityRulePropertiesFormat: &network.SecurityRulePropertiesFormat{
-			Direction:                network.SecurityRuleDirectionInbound,
-			Protocol:                 "*",
-			SourceAddressPrefix:      to.StringPtr("*"),
-			SourcePortRange:          to.StringPtr("*"),
-			DestinationAddressPrefix: to.StringPtr("*"),
-			DestinationPortRange:     to.StringPtr("*"),
-			Access:                   network.SecurityRuleAccessAllow,
-			Priority:                 to.Int32Ptr(900),
-		},
+// inboundSourceAddressPrefix returns the source of the inbound traffic allowed by the security
+// group, private clusters only accept traffic from within their VNet.
+func inboundSourceAddressPrefix(cloud kubermaticv1.CloudSpec) string {
+	if cloud.Azure.PrivateCluster {
+		return "VirtualNetwork"
 	}
+	return "*"
 }
 
 // ValidateCloudSpecUpdate verifies whether an update of cloud spec is valid and permitted
 func (a *Azure) ValidateCloudSpecUpdate(oldSpec kubermaticv1.CloudSpec, newSpec kubermaticv1.CloudSpec) error {
+	if oldSpec.Azure != nil && newSpec.Azure != nil && oldSpec.Azure.PrivateCluster != newSpec.Azure.PrivateCluster {
+		return errors.New("changing whether the cluster is private is not allowed")
+	}
+	if oldSpec.Azure != nil && newSpec.Azure != nil && oldSpec.Azure.EnablePrivateDNSZone != newSpec.Azure.EnablePrivateDNSZone {
+		return errors.New("changing whether a private DNS zone is created is not allowed")
+	}
+	if oldSpec.Azure != nil && newSpec.Azure != nil && oldSpec.Azure.ApplicationSecurityGroup != newSpec.Azure.ApplicationSecurityGroup {
+		return errors.New("changing the application security group is not allowed")
+	}
+	if oldSpec.Azure != nil && newSpec.Azure != nil && (oldSpec.Azure.RouteTableID != newSpec.Azure.RouteTableID || oldSpec.Azure.SecurityGroupID != newSpec.Azure.SecurityGroupID) {
+		return errors.New("changing the route table or security group referenced by ID is not allowed")
+	}
+	if oldSpec.Azure != nil && newSpec.Azure != nil && (oldSpec.Azure.VNetCIDR != newSpec.Azure.VNetCIDR || oldSpec.Azure.SubnetCIDR != newSpec.Azure.SubnetCIDR) {
+		return errors.New("changing the VNet or subnet CIDR is not allowed")
+	}
+	if oldSpec.Azure != nil && newSpec.Azure != nil && !sets.NewString(oldSpec.Azure.AllowedIPRanges...).Equal(sets.NewString(newSpec.Azure.AllowedIPRanges...)) {
+		return errors.New("changing the allowed IP ranges is not allowed")
+	}
+	if oldSpec.Azure != nil && newSpec.Azure != nil && networkPlugin(oldSpec) != networkPlugin(newSpec) {
+		return errors.New("changing the network plugin is not allowed")
+	}
+	if oldSpec.Azure != nil && newSpec.Azure != nil {
+		if err := validateKMSSettingsUpdate(oldSpec.Azure.KMS, newSpec.Azure.KMS); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -0,0 +1,193 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"testing"
+
+	network2020 "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+)
+
+func TestReconcileICMPRules(t *testing.T) {
+	icmpRule := icmpAllowRule(kubermaticv1.CloudSpec{Azure: &kubermaticv1.AzureCloudSpec{}})
+	rule := func(name string) network2020.SecurityRule {
+		return network2020.SecurityRule{Name: to.StringPtr(name)}
+	}
+
+	tests := []struct {
+		name            string
+		rules           []network2020.SecurityRule
+		expectedRules   []string
+		expectedChanged bool
+	}{
+		{
+			name:            "icmp rule gets added",
+			rules:           []network2020.SecurityRule{rule("ssh_ingress")},
+			expectedRules:   []string{"ssh_ingress", allowICMPSecGroupRuleName},
+			expectedChanged: true,
+		},
+		{
+			name:          "icmp rule already exists",
+			rules:         []network2020.SecurityRule{rule("ssh_ingress"), icmpRule},
+			expectedRules: []string{"ssh_ingress", allowICMPSecGroupRuleName},
+		},
+		{
+			name: "legacy rules get replaced",
+			rules: []network2020.SecurityRule{
+				rule("ssh_ingress"),
+				rule(legacyDenyAllTCPSecGroupRuleName),
+				rule(legacyDenyAllUDPSecGroupRuleName),
+				rule(legacyAllowAllICMPSecGroupRuleName),
+			},
+			expectedRules:   []string{"ssh_ingress", allowICMPSecGroupRuleName},
+			expectedChanged: true,
+		},
+		{
+			name: "legacy rules get removed if the icmp rule exists",
+			rules: []network2020.SecurityRule{
+				icmpRule,
+				rule(legacyDenyAllTCPSecGroupRuleName),
+			},
+			expectedRules:   []string{allowICMPSecGroupRuleName},
+			expectedChanged: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rules, changed, err := reconcileICMPRules(test.rules, icmpRule)
+			if err != nil {
+				t.Fatalf("failed to reconcile rules: %v", err)
+			}
+			if changed != test.expectedChanged {
+				t.Errorf("expected changed to be %v, got %v", test.expectedChanged, changed)
+			}
+
+			var names []string
+			for _, rule := range rules {
+				names = append(names, to.String(rule.Name))
+			}
+			if len(names) != len(test.expectedRules) {
+				t.Fatalf("expected rules %v, got %v", test.expectedRules, names)
+			}
+			for i := range names {
+				if names[i] != test.expectedRules[i] {
+					t.Fatalf("expected rules %v, got %v", test.expectedRules, names)
+				}
+			}
+		})
+	}
+}
+
+func TestICMPAllowRule(t *testing.T) {
+	rule := icmpAllowRule(kubermaticv1.CloudSpec{Azure: &kubermaticv1.AzureCloudSpec{PrivateCluster: true}})
+
+	if rule.Protocol != network2020.SecurityRuleProtocolIcmp {
+		t.Errorf("expected protocol %q, got %q", network2020.SecurityRuleProtocolIcmp, rule.Protocol)
+	}
+	if source := to.String(rule.SourceAddressPrefix); source != "VirtualNetwork" {
+		t.Errorf("expected private clusters to only allow ICMP from the VNet, got source %q", source)
+	}
+}
+
+func TestReconcileICMPRulesPriorityConflict(t *testing.T) {
+	icmpRule := icmpAllowRule(kubermaticv1.CloudSpec{Azure: &kubermaticv1.AzureCloudSpec{}})
+	rule := func(name string, direction network2020.SecurityRuleDirection, priority int32) network2020.SecurityRule {
+		return network2020.SecurityRule{
+			Name: to.StringPtr(name),
+			SecurityRulePropertiesFormat: &network2020.SecurityRulePropertiesFormat{
+				Direction: direction,
+				Priority:  to.Int32Ptr(priority),
+			},
+		}
+	}
+
+	rules, _, err := reconcileICMPRules([]network2020.SecurityRule{
+		rule("user_rule_1", network2020.SecurityRuleDirectionInbound, 800),
+		rule("user_rule_2", network2020.SecurityRuleDirectionInbound, 801),
+		rule("user_outbound", network2020.SecurityRuleDirectionOutbound, 802),
+	}, icmpRule)
+	if err != nil {
+		t.Fatalf("failed to reconcile rules: %v", err)
+	}
+
+	added := rules[len(rules)-1]
+	if priority := to.Int32(added.Priority); priority != 802 {
+		t.Errorf("expected the ICMP rule to get the next free inbound priority 802, got %d", priority)
+	}
+
+	var full []network2020.SecurityRule
+	for p := int32(800); p <= maxSecurityGroupRulePriority; p++ {
+		full = append(full, rule("user_rule", network2020.SecurityRuleDirectionInbound, p))
+	}
+	if _, _, err := reconcileICMPRules(full, icmpRule); err == nil {
+		t.Error("expected an error if no priority is left")
+	}
+}
+
+func TestSecurityGroupRulePriority(t *testing.T) {
+	tests := []struct {
+		name             string
+		priority         int32
+		expectedPriority int32
+		expectedErr      bool
+	}{
+		{
+			name:             "default",
+			expectedPriority: defaultSecurityGroupRulePriority,
+		},
+		{
+			name:             "custom base priority",
+			priority:         1000,
+			expectedPriority: 1000,
+		},
+		{
+			name:        "too low",
+			priority:    99,
+			expectedErr: true,
+		},
+		{
+			name:        "generated rules exceed the maximum",
+			priority:    3500,
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cloud := kubermaticv1.CloudSpec{Azure: &kubermaticv1.AzureCloudSpec{SecurityGroupRulePriority: test.priority}}
+
+			err := validateSecurityGroupRulePriority(test.priority)
+			if (err != nil) != test.expectedErr {
+				t.Fatalf("expected error to be %v, got %v", test.expectedErr, err)
+			}
+			if test.expectedErr {
+				return
+			}
+
+			if priority := securityGroupRulePriority(cloud); priority != test.expectedPriority {
+				t.Errorf("expected priority %d, got %d", test.expectedPriority, priority)
+			}
+			if priority := to.Int32(icmpAllowRule(cloud).Priority); priority != test.expectedPriority+icmpSecGroupRulePriorityOffset {
+				t.Errorf("expected ICMP rule priority %d, got %d", test.expectedPriority+icmpSecGroupRulePriorityOffset, priority)
+			}
+		})
+	}
+}
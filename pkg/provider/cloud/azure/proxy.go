@@ -0,0 +1,139 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sync"
+
+	"github.com/Azure/go-autorest/autorest"
+	"golang.org/x/net/http/httpproxy"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/provider"
+)
+
+const (
+	// ProxyUsernameKey is the key of the proxy username in the Secret of the proxy credentials
+	ProxyUsernameKey = "username"
+	// ProxyPasswordKey is the key of the proxy password in the Secret of the proxy credentials
+	ProxyPasswordKey = "password"
+)
+
+// proxySettings holds the HTTP client sending the requests to the Azure API through the
+// configured proxy, which is shared by all Azure clients of the process.
+type proxySettings struct {
+	lock   sync.Mutex
+	config *httpproxy.Config
+	client *http.Client
+}
+
+var apiProxy = &proxySettings{}
+
+// SetProxy configures the HTTP proxy for the requests to the Azure API, the proxy credentials
+// are read using secretKeySelector. The proxy applies to the clients created afterwards. With
+// nil settings, the proxy environment variables are honoured.
+func SetProxy(settings *kubermaticv1.AzureProxySettings, secretKeySelector provider.SecretKeySelectorValueFunc) error {
+	config, err := getProxyConfig(settings, secretKeySelector)
+	if err != nil {
+		return err
+	}
+
+	apiProxy.set(config)
+	return nil
+}
+
+func getProxyConfig(settings *kubermaticv1.AzureProxySettings, secretKeySelector provider.SecretKeySelectorValueFunc) (*httpproxy.Config, error) {
+	if settings == nil {
+		return nil, nil
+	}
+
+	proxyURL, err := url.Parse(settings.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL: %v", err)
+	}
+
+	if ref := settings.CredentialsReference; ref != nil {
+		username, err := secretKeySelector(ref, ProxyUsernameKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get proxy username: %v", err)
+		}
+		password, err := secretKeySelector(ref, ProxyPasswordKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get proxy password: %v", err)
+		}
+		// the transport sends the credentials in the Proxy-Authorization header, including
+		// the CONNECT requests tunneling HTTPS
+		proxyURL.User = url.UserPassword(username, password)
+	}
+
+	return &httpproxy.Config{
+		HTTPProxy:  proxyURL.String(),
+		HTTPSProxy: proxyURL.String(),
+		NoProxy:    settings.NoProxy,
+	}, nil
+}
+
+func (p *proxySettings) set(config *httpproxy.Config) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if reflect.DeepEqual(p.config, config) {
+		return
+	}
+	p.config = config
+	p.client = nil
+
+	if config == nil {
+		return
+	}
+
+	proxyFunc := config.ProxyFunc()
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	transport.Proxy = func(r *http.Request) (*url.URL, error) {
+		return proxyFunc(r.URL)
+	}
+	p.client = &http.Client{Transport: transport}
+}
+
+// get returns the HTTP client sending requests through the proxy, or nil if no proxy is configured.
+func (p *proxySettings) get() *http.Client {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	return p.client
+}
+
+// configureClient sets up the authorizer and the transport of an Azure SDK client.
+func configureClient(client *autorest.Client, credentials Credentials) error {
+	authorizer, err := getAuthorizer(credentials)
+	if err != nil {
+		return err
+	}
+	client.Authorizer = authorizer
+
+	if sender := apiProxy.get(); sender != nil {
+		client.Sender = sender
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestProxySettings(t *testing.T) {
+	var proxyAuthorization string
+	proxyServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxyAuthorization = r.Header.Get("Proxy-Authorization")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer proxyServer.Close()
+
+	settings := &kubermaticv1.AzureProxySettings{
+		URL:     proxyServer.URL,
+		NoProxy: "direct.example.com",
+		CredentialsReference: &providerconfig.GlobalSecretKeySelector{
+			ObjectReference: corev1.ObjectReference{Name: "azure-proxy", Namespace: "kubermatic"},
+		},
+	}
+	secretKeySelector := func(_ *providerconfig.GlobalSecretKeySelector, key string) (string, error) {
+		switch key {
+		case ProxyUsernameKey:
+			return "kubermatic", nil
+		case ProxyPasswordKey:
+			return "secret", nil
+		}
+		return "", errors.New("unknown key")
+	}
+
+	config, err := getProxyConfig(settings, secretKeySelector)
+	if err != nil {
+		t.Fatalf("failed to get proxy config: %v", err)
+	}
+
+	proxy := &proxySettings{}
+	if proxy.get() != nil {
+		t.Fatal("expected no client without settings")
+	}
+	proxy.set(config)
+	client := proxy.get()
+	if client == nil {
+		t.Fatal("expected a client with settings")
+	}
+
+	// plain HTTP requests are sent to the proxy as is, so it sees the absolute URL
+	res, err := client.Get("http://management.azure.example.com/subscriptions")
+	if err != nil {
+		t.Fatalf("failed to send request through the proxy: %v", err)
+	}
+	res.Body.Close()
+
+	expected := "Basic " + base64.StdEncoding.EncodeToString([]byte("kubermatic:secret"))
+	if proxyAuthorization != expected {
+		t.Errorf("expected proxy authorization %q, got %q", expected, proxyAuthorization)
+	}
+
+	transport := client.Transport.(*http.Transport)
+	req := httptest.NewRequest(http.MethodGet, "https://direct.example.com/", nil)
+	if proxyURL, err := transport.Proxy(req); err != nil || proxyURL != nil {
+		t.Errorf("expected hosts in the no proxy list to be accessed directly, got %v (%v)", proxyURL, err)
+	}
+
+	proxy.set(config)
+	if proxy.get() != client {
+		t.Error("expected the client to be kept if the settings did not change")
+	}
+
+	proxy.set(nil)
+	if proxy.get() != nil {
+		t.Error("expected no client after the settings were removed")
+	}
+}
@@ -0,0 +1,318 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	compute2020 "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-30/compute"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-06-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+)
+
+const (
+	// regionalVCPUsQuota is the name of the quota of all vCPUs of a subscription in a region.
+	regionalVCPUsQuota = "cores"
+	// publicIPAddressesQuota is the name of the quota of public IP addresses of a subscription in a region.
+	publicIPAddressesQuota = "PublicIPAddresses"
+	// maxResourceGroups is the number of resource groups a subscription can have.
+	maxResourceGroups = 980
+)
+
+// quotaUsage is the current usage and the limit of a quota.
+type quotaUsage struct {
+	// displayName is the localized name of the quota as shown in the Azure portal.
+	displayName string
+	current     int64
+	limit       int64
+}
+
+// checkQuota returns a message describing the shortfall if the quota does not allow to allocate
+// the required amount, or an empty string. Unknown quotas are not checked.
+func checkQuota(usages map[string]quotaUsage, quota string, required int64, location string) string {
+	usage, ok := usages[quota]
+	if !ok || required <= 0 {
+		return ""
+	}
+	available := usage.limit - usage.current
+	if available < 0 {
+		available = 0
+	}
+	if available < required {
+		return fmt.Sprintf("%d %q are required in %s, but only %d of %d are available", required, usage.displayName, location, available, usage.limit)
+	}
+	return ""
+}
+
+// initialNodeDeployment returns the MachineDeployment requested to be created together with the
+// cluster, if any.
+func initialNodeDeployment(cluster *kubermaticv1.Cluster) *apiv1.NodeDeployment {
+	request := cluster.Annotations[apiv1.InitialMachineDeploymentRequestAnnotation]
+	if request == "" {
+		return nil
+	}
+
+	nd := &apiv1.NodeDeployment{}
+	// invalid requests are reported by the controller creating the MachineDeployment
+	if err := json.Unmarshal([]byte(request), nd); err != nil || nd.Spec.Template.Cloud.Azure == nil {
+		return nil
+	}
+	return nd
+}
+
+// skuVCPUs returns the number of vCPUs of a VM size.
+func skuVCPUs(sku compute2020.ResourceSku) (int64, error) {
+	if sku.Capabilities != nil {
+		for _, capability := range *sku.Capabilities {
+			if to.String(capability.Name) == "vCPUs" {
+				return strconv.ParseInt(to.String(capability.Value), 10, 64)
+			}
+		}
+	}
+	return 0, fmt.Errorf("VM size %q has no vCPUs capability", to.String(sku.Name))
+}
+
+// preflightChecks verifies that the quotas of the subscription allow to create the cluster and its
+// initial MachineDeployment, so that the cluster creation fails right away with an actionable
+// error instead of machines failing to be created later on. It only runs until the
+// infrastructure of the cluster has been created once.
+func (a *Azure) preflightChecks(cluster *kubermaticv1.Cluster, credentials Credentials) error {
+	if cluster.Status.ExtendedHealth.CloudProviderInfrastructure == kubermaticv1.HealthStatusUp {
+		return nil
+	}
+
+	var failures []string
+
+	if nd := initialNodeDeployment(cluster); nd != nil {
+		msgs, err := a.checkNodeQuotas(cluster, nd, credentials)
+		if err != nil {
+			return fmt.Errorf("failed to check quotas: %w", err)
+		}
+		failures = append(failures, msgs...)
+	}
+
+	if cluster.Spec.Cloud.Azure.ResourceGroup == "" {
+		groups, err := a.countResourceGroups(cluster.Spec.Cloud, credentials)
+		if err != nil {
+			return fmt.Errorf("failed to check the number of resource groups: %w", err)
+		}
+		if groups >= maxResourceGroups {
+			failures = append(failures, fmt.Sprintf("the subscription already has the maximum of %d resource groups, delete unused ones or specify an existing resource group for the cluster", maxResourceGroups))
+		}
+	}
+
+	if len(failures) > 0 {
+		return &Error{
+			Kind: ErrQuotaExceeded,
+			err:  fmt.Errorf("insufficient Azure quota: %s; request a quota increase for the subscription or reduce the number or size of the nodes", strings.Join(failures, "; ")),
+		}
+	}
+	return nil
+}
+
+// checkNodeQuotas checks the vCPU and public IP quotas against the initial MachineDeployment.
+func (a *Azure) checkNodeQuotas(cluster *kubermaticv1.Cluster, nd *apiv1.NodeDeployment, credentials Credentials) ([]string, error) {
+	location := a.dc.Location
+	replicas := int64(nd.Spec.Replicas)
+	size := nd.Spec.Template.Cloud.Azure.Size
+
+	sku, err := a.getVMSizeSKU(size, credentials)
+	if err != nil {
+		return nil, err
+	}
+	vcpus, err := skuVCPUs(*sku)
+	if err != nil {
+		return nil, err
+	}
+
+	computeUsages, err := a.listComputeUsages(credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	var failures []string
+	if msg := checkQuota(computeUsages, regionalVCPUsQuota, replicas*vcpus, location); msg != "" {
+		failures = append(failures, msg)
+	}
+	if msg := checkQuota(computeUsages, to.String(sku.Family), replicas*vcpus, location); msg != "" {
+		failures = append(failures, msg)
+	}
+
+	// nodes of private clusters never get a public IP
+	if nd.Spec.Template.Cloud.Azure.AssignPublicIP && !cluster.Spec.Cloud.Azure.PrivateCluster {
+		networkUsages, err := a.listNetworkUsages(credentials)
+		if err != nil {
+			return nil, err
+		}
+		if msg := checkQuota(networkUsages, publicIPAddressesQuota, replicas, location); msg != "" {
+			failures = append(failures, msg)
+		}
+	}
+
+	return failures, nil
+}
+
+// getVMSizeSKU returns the SKU of the VM size in the location of the datacenter.
+func (a *Azure) getVMSizeSKU(size string, credentials Credentials) (*compute2020.ResourceSku, error) {
+	return getVMSizeSKU(a.ctx, size, a.dc.Location, credentials)
+}
+
+// getVMSizeSKU returns the SKU of the VM size in the location.
+func getVMSizeSKU(ctx context.Context, size, location string, credentials Credentials) (*compute2020.ResourceSku, error) {
+	client, err := getResourceSkusClient(credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	iter, err := client.ListComplete(ctx, fmt.Sprintf("location eq '%s'", location))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list VM sizes: %w", err)
+	}
+	for ; iter.NotDone(); err = iter.NextWithContext(ctx) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to list VM sizes: %w", err)
+		}
+		sku := iter.Value()
+		if to.String(sku.ResourceType) == "virtualMachines" && strings.EqualFold(to.String(sku.Name), size) {
+			return &sku, nil
+		}
+	}
+
+	return nil, fmt.Errorf("VM size %q is not available in %s", size, location)
+}
+
+// listComputeUsages returns the compute quotas of the subscription in the location of the datacenter.
+func (a *Azure) listComputeUsages(credentials Credentials) (map[string]quotaUsage, error) {
+	client, err := getComputeUsageClient(credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	usages := map[string]quotaUsage{}
+	iter, err := client.ListComplete(a.ctx, a.dc.Location)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list compute usages: %w", err)
+	}
+	for ; iter.NotDone(); err = iter.NextWithContext(a.ctx) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to list compute usages: %w", err)
+		}
+		usage := iter.Value()
+		if usage.Name == nil {
+			continue
+		}
+		usages[to.String(usage.Name.Value)] = quotaUsage{
+			displayName: to.String(usage.Name.LocalizedValue),
+			current:     int64(to.Int32(usage.CurrentValue)),
+			limit:       to.Int64(usage.Limit),
+		}
+	}
+
+	return usages, nil
+}
+
+// listNetworkUsages returns the network quotas of the subscription in the location of the datacenter.
+func (a *Azure) listNetworkUsages(credentials Credentials) (map[string]quotaUsage, error) {
+	client, err := getNetworkUsagesClient(credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	usages := map[string]quotaUsage{}
+	iter, err := client.ListComplete(a.ctx, a.dc.Location)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list network usages: %w", err)
+	}
+	for ; iter.NotDone(); err = iter.NextWithContext(a.ctx) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to list network usages: %w", err)
+		}
+		usage := iter.Value()
+		if usage.Name == nil {
+			continue
+		}
+		usages[to.String(usage.Name.Value)] = quotaUsage{
+			displayName: to.String(usage.Name.LocalizedValue),
+			current:     to.Int64(usage.CurrentValue),
+			limit:       to.Int64(usage.Limit),
+		}
+	}
+
+	return usages, nil
+}
+
+// countResourceGroups returns the number of resource groups in the subscription.
+func (a *Azure) countResourceGroups(cloud kubermaticv1.CloudSpec, credentials Credentials) (int, error) {
+	client, err := getGroupsClient(cloud, credentials)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	iter, err := client.ListComplete(a.ctx, "", nil)
+	if err != nil {
+		return 0, err
+	}
+	for ; iter.NotDone(); err = iter.NextWithContext(a.ctx) {
+		if err != nil {
+			return 0, err
+		}
+		count++
+	}
+
+	return count, nil
+}
+
+func getResourceSkusClient(credentials Credentials) (*compute2020.ResourceSkusClient, error) {
+	var err error
+	client := compute2020.NewResourceSkusClient(credentials.SubscriptionID)
+	err = configureClient(&client.Client, credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authorizer: %s", err.Error())
+	}
+
+	return &client, nil
+}
+
+func getComputeUsageClient(credentials Credentials) (*compute2020.UsageClient, error) {
+	var err error
+	client := compute2020.NewUsageClient(credentials.SubscriptionID)
+	err = configureClient(&client.Client, credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authorizer: %s", err.Error())
+	}
+
+	return &client, nil
+}
+
+func getNetworkUsagesClient(credentials Credentials) (*network.UsagesClient, error) {
+	var err error
+	client := network.NewUsagesClient(credentials.SubscriptionID)
+	err = configureClient(&client.Client, credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authorizer: %s", err.Error())
+	}
+
+	return &client, nil
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"testing"
+
+	compute2020 "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-30/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCheckQuota(t *testing.T) {
+	usages := map[string]quotaUsage{
+		"cores":              {displayName: "Total Regional vCPUs", current: 6, limit: 10},
+		"standardDSv3Family": {displayName: "Standard DSv3 Family vCPUs", current: 12, limit: 10},
+	}
+
+	tests := []struct {
+		name     string
+		quota    string
+		required int64
+		expected string
+	}{
+		{
+			name:     "enough quota",
+			quota:    "cores",
+			required: 4,
+		},
+		{
+			name:     "insufficient quota",
+			quota:    "cores",
+			required: 6,
+			expected: `6 "Total Regional vCPUs" are required in westeurope, but only 4 of 10 are available`,
+		},
+		{
+			name:     "exceeded quota",
+			quota:    "standardDSv3Family",
+			required: 2,
+			expected: `2 "Standard DSv3 Family vCPUs" are required in westeurope, but only 0 of 10 are available`,
+		},
+		{
+			name:     "unknown quota",
+			quota:    "standardNVFamily",
+			required: 100,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if msg := checkQuota(usages, tt.quota, tt.required, "westeurope"); msg != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, msg)
+			}
+		})
+	}
+}
+
+func TestSKUVCPUs(t *testing.T) {
+	sku := compute2020.ResourceSku{
+		Name: to.StringPtr("Standard_D4s_v3"),
+		Capabilities: &[]compute2020.ResourceSkuCapabilities{
+			{Name: to.StringPtr("MemoryGB"), Value: to.StringPtr("16")},
+			{Name: to.StringPtr("vCPUs"), Value: to.StringPtr("4")},
+		},
+	}
+	if vcpus, err := skuVCPUs(sku); err != nil || vcpus != 4 {
+		t.Errorf("expected 4 vCPUs, got %d (error %v)", vcpus, err)
+	}
+
+	if _, err := skuVCPUs(compute2020.ResourceSku{Name: to.StringPtr("Standard_Unknown")}); err == nil {
+		t.Error("expected an error for a VM size without vCPUs")
+	}
+}
+
+func TestInitialNodeDeployment(t *testing.T) {
+	cluster := &kubermaticv1.Cluster{}
+	if nd := initialNodeDeployment(cluster); nd != nil {
+		t.Errorf("expected no node deployment without annotation, got %+v", nd)
+	}
+
+	cluster.Annotations = map[string]string{
+		apiv1.InitialMachineDeploymentRequestAnnotation: `{"spec":{"replicas":3,"template":{"cloud":{"azure":{"size":"Standard_D2s_v3","assignPublicIP":true}}}}}`,
+	}
+	nd := initialNodeDeployment(cluster)
+	if nd == nil || nd.Spec.Replicas != 3 || nd.Spec.Template.Cloud.Azure.Size != "Standard_D2s_v3" || !nd.Spec.Template.Cloud.Azure.AssignPublicIP {
+		t.Errorf("expected the requested node deployment, got %+v", nd)
+	}
+}
+
+func TestPreflightChecksSkipped(t *testing.T) {
+	azure := &Azure{}
+	initialNodes := map[string]string{
+		apiv1.InitialMachineDeploymentRequestAnnotation: `{"spec":{"replicas":3,"template":{"cloud":{"azure":{"size":"Standard_D2s_v3"}}}}}`,
+	}
+
+	tests := []struct {
+		name    string
+		cluster *kubermaticv1.Cluster
+	}{
+		{
+			name: "infrastructure already created",
+			cluster: &kubermaticv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Annotations: initialNodes},
+				Spec: kubermaticv1.ClusterSpec{
+					Cloud: kubermaticv1.CloudSpec{Azure: &kubermaticv1.AzureCloudSpec{}},
+				},
+				Status: kubermaticv1.ClusterStatus{
+					ExtendedHealth: kubermaticv1.ExtendedClusterHealth{CloudProviderInfrastructure: kubermaticv1.HealthStatusUp},
+				},
+			},
+		},
+		{
+			name: "no initial nodes and existing resource group",
+			cluster: &kubermaticv1.Cluster{
+				Spec: kubermaticv1.ClusterSpec{
+					Cloud: kubermaticv1.CloudSpec{Azure: &kubermaticv1.AzureCloudSpec{ResourceGroup: "my-rg"}},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// no Azure API is called, so no credentials are needed
+			if err := azure.preflightChecks(tt.cluster, Credentials{}); err != nil {
+				t.Errorf("expected the checks to be skipped, got %v", err)
+			}
+		})
+	}
+}
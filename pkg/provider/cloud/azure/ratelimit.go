@@ -0,0 +1,148 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure/auth"
+	"github.com/prometheus/client_golang/prometheus"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+var (
+	rateLimiterWaitSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "kubermatic_azure_api_rate_limiter_wait_seconds",
+			Help:    "The time requests to the Azure API waited for the rate limiter of their subscription",
+			Buckets: prometheus.ExponentialBuckets(0.005, 4, 8),
+		},
+		[]string{"subscription"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(rateLimiterWaitSeconds)
+}
+
+// rateLimiters holds the token buckets of the subscriptions, which are shared by all Azure
+// clients of the process.
+type rateLimiters struct {
+	lock     sync.Mutex
+	settings *kubermaticv1.AzureRateLimitSettings
+	limiters map[string]flowcontrol.RateLimiter
+}
+
+var apiRateLimiters = &rateLimiters{limiters: map[string]flowcontrol.RateLimiter{}}
+
+// SetRateLimits configures the rate limits of the requests to the Azure API. The limits apply
+// to the clients created afterwards; if they changed, the buckets of all subscriptions are
+// replaced. With nil settings, requests are not limited.
+func SetRateLimits(settings *kubermaticv1.AzureRateLimitSettings) {
+	apiRateLimiters.set(settings)
+}
+
+func (l *rateLimiters) set(settings *kubermaticv1.AzureRateLimitSettings) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if equality.Semantic.DeepEqual(l.settings, settings) {
+		return
+	}
+	l.settings = settings.DeepCopy()
+	l.limiters = map[string]flowcontrol.RateLimiter{}
+}
+
+// get returns the rate limiter of the subscription, or nil if requests are not limited.
+func (l *rateLimiters) get(subscriptionID string) flowcontrol.RateLimiter {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.settings == nil || l.settings.QPS <= 0 {
+		return nil
+	}
+
+	limiter, ok := l.limiters[subscriptionID]
+	if !ok {
+		burst := l.settings.Burst
+		if burst < 1 {
+			burst = 1
+		}
+		limiter = flowcontrol.NewTokenBucketRateLimiter(l.settings.QPS, burst)
+		l.limiters[subscriptionID] = limiter
+	}
+
+	return limiter
+}
+
+// rateLimitedAuthorizer waits for the rate limiter of the subscription before authorizing a
+// request. As every request of the SDK clients is authorized, including the polling of
+// long-running operations, this limits all requests to the API.
+type rateLimitedAuthorizer struct {
+	autorest.Authorizer
+
+	subscriptionID string
+	limiter        flowcontrol.RateLimiter
+}
+
+func (a *rateLimitedAuthorizer) WithAuthorization() autorest.PrepareDecorator {
+	return func(p autorest.Preparer) autorest.Preparer {
+		authorized := a.Authorizer.WithAuthorization()(p)
+
+		return autorest.PreparerFunc(func(r *http.Request) (*http.Request, error) {
+			start := time.Now()
+			if err := a.limiter.Wait(r.Context()); err != nil {
+				return r, err
+			}
+			rateLimiterWaitSeconds.WithLabelValues(a.subscriptionID).Observe(time.Since(start).Seconds())
+
+			return authorized.Prepare(r)
+		})
+	}
+}
+
+// getAuthorizer returns the authorizer for the Azure Resource Manager API, which applies the
+// rate limit of the subscription if one is configured and uses the configured proxy.
+func getAuthorizer(credentials Credentials) (autorest.Authorizer, error) {
+	token, err := auth.NewClientCredentialsConfig(credentials.ClientID, credentials.ClientSecret, credentials.TenantID).ServicePrincipalToken()
+	if err != nil {
+		return nil, err
+	}
+	// the token must be acquired through the proxy as well
+	if sender := apiProxy.get(); sender != nil {
+		token.SetSender(sender)
+	}
+	authorizer := autorest.NewBearerAuthorizer(token)
+
+	limiter := apiRateLimiters.get(credentials.SubscriptionID)
+	if limiter == nil {
+		return authorizer, nil
+	}
+
+	return &rateLimitedAuthorizer{
+		Authorizer:     authorizer,
+		subscriptionID: credentials.SubscriptionID,
+		limiter:        limiter,
+	}, nil
+}
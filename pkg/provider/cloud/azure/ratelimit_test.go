@@ -0,0 +1,105 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+type countingLimiter struct {
+	flowcontrol.RateLimiter
+
+	waited int
+}
+
+func (l *countingLimiter) Wait(ctx context.Context) error {
+	l.waited++
+	return ctx.Err()
+}
+
+func TestRateLimiters(t *testing.T) {
+	limiters := &rateLimiters{limiters: map[string]flowcontrol.RateLimiter{}}
+
+	if limiter := limiters.get("subscription"); limiter != nil {
+		t.Fatal("expected no limiter without settings")
+	}
+
+	settings := &kubermaticv1.AzureRateLimitSettings{QPS: 5, Burst: 10}
+	limiters.set(settings)
+	first := limiters.get("subscription")
+	if first == nil {
+		t.Fatal("expected a limiter with settings")
+	}
+	if limiters.get("subscription") != first {
+		t.Error("expected the limiter of a subscription to be shared")
+	}
+	if limiters.get("other-subscription") == first {
+		t.Error("expected every subscription to have its own limiter")
+	}
+
+	limiters.set(settings.DeepCopy())
+	if limiters.get("subscription") != first {
+		t.Error("expected the limiter to be kept if the settings did not change")
+	}
+
+	limiters.set(&kubermaticv1.AzureRateLimitSettings{QPS: 10, Burst: 10})
+	if limiters.get("subscription") == first {
+		t.Error("expected the limiter to be replaced after the settings changed")
+	}
+
+	limiters.set(nil)
+	if limiter := limiters.get("subscription"); limiter != nil {
+		t.Error("expected no limiter after the settings were removed")
+	}
+}
+
+func TestRateLimitedAuthorizer(t *testing.T) {
+	limiter := &countingLimiter{}
+	authorizer := &rateLimitedAuthorizer{
+		Authorizer:     autorest.NullAuthorizer{},
+		subscriptionID: "subscription",
+		limiter:        limiter,
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "https://management.azure.com/subscriptions/subscription", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := autorest.Prepare(req, authorizer.WithAuthorization()); err != nil {
+			t.Fatalf("failed to prepare request: %v", err)
+		}
+	}
+	if limiter.waited != 3 {
+		t.Errorf("expected every request to wait for the limiter, waited %d times", limiter.waited)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := autorest.Prepare(req.WithContext(ctx), authorizer.WithAuthorization()); err == nil {
+		t.Error("expected preparing a request with a cancelled context to fail")
+	}
+}
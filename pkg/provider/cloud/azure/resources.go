@@ -0,0 +1,98 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"fmt"
+	"reflect"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/provider"
+)
+
+// reconcileCloudProviderResources records the resource IDs of the Azure resources of the cluster in
+// its status. The IDs are assembled from the names in the cloud spec, so no API calls are needed.
+func reconcileCloudProviderResources(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, subscriptionID string) (*kubermaticv1.Cluster, error) {
+	resources := cloudProviderResources(cluster, subscriptionID)
+	if reflect.DeepEqual(cluster.Status.CloudProviderResources, resources) {
+		return cluster, nil
+	}
+
+	return update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+		updatedCluster.Status.CloudProviderResources = resources
+	})
+}
+
+// cloudProviderResources returns the resource IDs of the Azure resources used by the cluster.
+func cloudProviderResources(cluster *kubermaticv1.Cluster, subscriptionID string) []kubermaticv1.CloudProviderResource {
+	cloud := cluster.Spec.Cloud
+	groupID := fmt.Sprintf("/subscriptions/%s/resourceGroups/%s", subscriptionID, cloud.Azure.ResourceGroup)
+
+	var resources []kubermaticv1.CloudProviderResource
+	add := func(resourceType, name, id, finalizer string) {
+		if name == "" {
+			return
+		}
+		resources = append(resources, kubermaticv1.CloudProviderResource{
+			Type:    resourceType,
+			ID:      id,
+			Managed: kuberneteshelper.HasFinalizer(cluster, finalizer),
+		})
+	}
+
+	add("resourceGroup", cloud.Azure.ResourceGroup, groupID, FinalizerResourceGroup)
+	add("vnet", cloud.Azure.VNetName, assembleVNetID(cloud, subscriptionID), FinalizerVNet)
+	add("subnet", cloud.Azure.SubnetName, assembleSubnetIDForSubscription(cloud, subscriptionID), FinalizerSubnet)
+	add("securityGroup", cloud.Azure.SecurityGroup, assembleSecurityGroupID(cloud, subscriptionID, cloud.Azure.SecurityGroup), FinalizerSecurityGroup)
+	add("routeTable", cloud.Azure.RouteTableName, assembleRouteTableID(cloud, subscriptionID), FinalizerRouteTable)
+	add("applicationSecurityGroup", cloud.Azure.ApplicationSecurityGroup, assembleApplicationSecurityGroupID(cloud, subscriptionID), FinalizerApplicationSecurityGroup)
+	add("availabilitySet", cloud.Azure.AvailabilitySet, groupID+"/providers/Microsoft.Compute/availabilitySets/"+cloud.Azure.AvailabilitySet, FinalizerAvailabilitySet)
+	if cluster.Status.Azure != nil {
+		for _, as := range cluster.Status.Azure.AvailabilitySets {
+			if as.Created {
+				add("availabilitySet", as.Name, groupID+"/providers/Microsoft.Compute/availabilitySets/"+as.Name, FinalizerMachineDeploymentAvailabilitySets)
+			}
+		}
+	}
+	if cluster.Status.Azure != nil {
+		for _, subnet := range cluster.Status.Azure.Subnets {
+			add("subnet", subnet, fmt.Sprintf("%s/subnets/%s", assembleVNetID(cloud, subscriptionID), subnet), FinalizerAdditionalSubnets)
+		}
+	}
+	add("proximityPlacementGroup", cloud.Azure.ProximityPlacementGroupID, cloud.Azure.ProximityPlacementGroupID, FinalizerProximityPlacementGroup)
+	add("privateEndpoint", cloud.Azure.PrivateEndpoint, groupID+"/providers/Microsoft.Network/privateEndpoints/"+cloud.Azure.PrivateEndpoint, FinalizerPrivateEndpoint)
+	add("privateDNSZone", cloud.Azure.PrivateDNSZone, groupID+"/providers/Microsoft.Network/privateDnsZones/"+cloud.Azure.PrivateDNSZone, FinalizerPrivateDNSZone)
+	if bootDiagnostics := cloud.Azure.BootDiagnostics; bootDiagnostics != nil {
+		if bootDiagnostics.StorageAccountID != "" {
+			add("storageAccount", bootDiagnostics.StorageAccountID, bootDiagnostics.StorageAccountID, FinalizerBootDiagnosticsStorageAccount)
+		} else {
+			name := bootDiagnosticsStorageAccountName(cluster, subscriptionID)
+			add("storageAccount", name, groupID+"/providers/Microsoft.Storage/storageAccounts/"+name, FinalizerBootDiagnosticsStorageAccount)
+		}
+	}
+	if logAnalytics := cloud.Azure.LogAnalytics; logAnalytics != nil {
+		if logAnalytics.WorkspaceID != "" {
+			add("logAnalyticsWorkspace", logAnalytics.WorkspaceID, logAnalytics.WorkspaceID, FinalizerLogAnalyticsWorkspace)
+		} else {
+			name := logAnalyticsWorkspaceName(cluster)
+			add("logAnalyticsWorkspace", name, groupID+"/providers/Microsoft.OperationalInsights/workspaces/"+name, FinalizerLogAnalyticsWorkspace)
+		}
+	}
+
+	return resources
+}
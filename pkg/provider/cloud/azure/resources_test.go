@@ -0,0 +1,68 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"reflect"
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCloudProviderResources(t *testing.T) {
+	const group = "/subscriptions/sub/resourceGroups/kubernetes-abcd1234"
+
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "abcd1234",
+			Finalizers: []string{FinalizerResourceGroup, FinalizerSecurityGroup, FinalizerMachineDeploymentAvailabilitySets},
+		},
+		Spec: kubermaticv1.ClusterSpec{
+			Cloud: kubermaticv1.CloudSpec{
+				Azure: &kubermaticv1.AzureCloudSpec{
+					ResourceGroup:     "kubernetes-abcd1234",
+					VNetResourceGroup: "network",
+					VNetName:          "vnet",
+					SubnetName:        "subnet",
+					SecurityGroup:     "kubernetes-abcd1234",
+				},
+			},
+		},
+		Status: kubermaticv1.ClusterStatus{
+			Azure: &kubermaticv1.AzureClusterStatus{
+				AvailabilitySets: []kubermaticv1.AzureAvailabilitySet{
+					{MachineDeployment: "workers", Name: "kubernetes-abcd1234-workers", Created: true},
+					{MachineDeployment: "pending", Name: "kubernetes-abcd1234-pending"},
+				},
+			},
+		},
+	}
+
+	expected := []kubermaticv1.CloudProviderResource{
+		{Type: "resourceGroup", ID: group, Managed: true},
+		{Type: "vnet", ID: "/subscriptions/sub/resourceGroups/network/providers/Microsoft.Network/virtualNetworks/vnet"},
+		{Type: "subnet", ID: "/subscriptions/sub/resourceGroups/network/providers/Microsoft.Network/virtualNetworks/vnet/subnets/subnet"},
+		{Type: "securityGroup", ID: group + "/providers/Microsoft.Network/networkSecurityGroups/kubernetes-abcd1234", Managed: true},
+		{Type: "availabilitySet", ID: group + "/providers/Microsoft.Compute/availabilitySets/kubernetes-abcd1234-workers", Managed: true},
+	}
+
+	if resources := cloudProviderResources(cluster, "sub"); !reflect.DeepEqual(resources, expected) {
+		t.Errorf("expected resources\n%+v\ngot\n%+v", expected, resources)
+	}
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-06-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+)
+
+// routeNamePrefix is the name prefix of the user-defined routes in the route table, which
+// distinguishes them from the routes of the cloud controller manager and manually added ones.
+const routeNamePrefix = "kubermatic-udr-"
+
+var routeNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9._-]{0,62}[a-zA-Z0-9_])?$`)
+
+// validateRoutes checks that the user-defined routes can be added to the route table.
+func validateRoutes(routes []kubermaticv1.AzureRoute) error {
+	names := map[string]struct{}{}
+
+	for _, route := range routes {
+		if !routeNameRegexp.MatchString(route.Name) {
+			return fmt.Errorf("invalid route name %q", route.Name)
+		}
+		if _, ok := names[route.Name]; ok {
+			return fmt.Errorf("duplicate route name %q", route.Name)
+		}
+		names[route.Name] = struct{}{}
+
+		if _, _, err := net.ParseCIDR(route.AddressPrefix); err != nil {
+			return fmt.Errorf("invalid address prefix %q of route %q: %w", route.AddressPrefix, route.Name, err)
+		}
+
+		switch network.RouteNextHopType(route.NextHopType) {
+		case network.RouteNextHopTypeVirtualAppliance:
+			if net.ParseIP(route.NextHopIPAddress) == nil {
+				return fmt.Errorf("route %q requires the IP address of the virtual appliance as next hop", route.Name)
+			}
+		case network.RouteNextHopTypeVirtualNetworkGateway, network.RouteNextHopTypeVnetLocal, network.RouteNextHopTypeInternet, network.RouteNextHopTypeNone:
+			if route.NextHopIPAddress != "" {
+				return fmt.Errorf("route %q only allows a next hop IP address for the next hop type %q", route.Name, network.RouteNextHopTypeVirtualAppliance)
+			}
+		default:
+			return fmt.Errorf("invalid next hop type %q of route %q", route.NextHopType, route.Name)
+		}
+	}
+
+	return nil
+}
+
+// isRouteUpToDate returns true if the route in the route table matches the user-defined route.
+func isRouteUpToDate(existing network.Route, route kubermaticv1.AzureRoute) bool {
+	if existing.RoutePropertiesFormat == nil {
+		return false
+	}
+
+	return to.String(existing.AddressPrefix) == route.AddressPrefix &&
+		strings.EqualFold(string(existing.NextHopType), route.NextHopType) &&
+		to.String(existing.NextHopIPAddress) == route.NextHopIPAddress
+}
+
+// reconcileRoutes adds the user-defined routes to the route table created by Kubermatic and
+// removes the ones which have been removed from the cloud spec.
+func (a *Azure) reconcileRoutes(cluster *kubermaticv1.Cluster, credentials Credentials) error {
+	cloud := cluster.Spec.Cloud
+
+	if !kuberneteshelper.HasFinalizer(cluster, FinalizerRouteTable) {
+		return nil
+	}
+
+	client, err := getRoutesClient(credentials)
+	if err != nil {
+		return err
+	}
+
+	existing := map[string]network.Route{}
+	it, err := client.ListComplete(a.ctx, RouteTableResourceGroup(cloud), cloud.Azure.RouteTableName)
+	if err != nil {
+		return fmt.Errorf("failed to list routes of route table %q: %w", cloud.Azure.RouteTableName, err)
+	}
+	for ; it.NotDone(); err = it.NextWithContext(a.ctx) {
+		if err != nil {
+			return fmt.Errorf("failed to list routes of route table %q: %w", cloud.Azure.RouteTableName, err)
+		}
+		if route := it.Value(); route.Name != nil && strings.HasPrefix(*route.Name, routeNamePrefix) {
+			existing[*route.Name] = route
+		}
+	}
+
+	for _, route := range cloud.Azure.Routes {
+		name := routeNamePrefix + route.Name
+		if current, ok := existing[name]; ok && isRouteUpToDate(current, route) {
+			delete(existing, name)
+			continue
+		}
+		delete(existing, name)
+
+		if err := ensureRoute(a.ctx, client, cloud, name, route); err != nil {
+			return fmt.Errorf("failed to create or update route %q: %w", route.Name, err)
+		}
+	}
+
+	// the remaining routes have been removed from the cloud spec
+	for name := range existing {
+		future, err := client.Delete(a.ctx, RouteTableResourceGroup(cloud), cloud.Azure.RouteTableName, name)
+		if err != nil {
+			return fmt.Errorf("failed to delete route %q: %w", strings.TrimPrefix(name, routeNamePrefix), err)
+		}
+		if err := future.WaitForCompletionRef(a.ctx, client.Client); err != nil {
+			return fmt.Errorf("failed to delete route %q: %w", strings.TrimPrefix(name, routeNamePrefix), err)
+		}
+	}
+
+	return nil
+}
+
+// ensureRoute creates or updates the user-defined route in the route table. The call is idempotent.
+func ensureRoute(ctx context.Context, client *network.RoutesClient, cloud kubermaticv1.CloudSpec, name string, route kubermaticv1.AzureRoute) error {
+	parameters := network.Route{
+		Name: to.StringPtr(name),
+		RoutePropertiesFormat: &network.RoutePropertiesFormat{
+			AddressPrefix: to.StringPtr(route.AddressPrefix),
+			NextHopType:   network.RouteNextHopType(route.NextHopType),
+		},
+	}
+	if route.NextHopIPAddress != "" {
+		parameters.NextHopIPAddress = to.StringPtr(route.NextHopIPAddress)
+	}
+
+	future, err := client.CreateOrUpdate(ctx, RouteTableResourceGroup(cloud), cloud.Azure.RouteTableName, name, parameters)
+	if err != nil {
+		return err
+	}
+
+	return future.WaitForCompletionRef(ctx, client.Client)
+}
+
+func getRoutesClient(credentials Credentials) (*network.RoutesClient, error) {
+	var err error
+	routesClient := network.NewRoutesClient(credentials.SubscriptionID)
+	err = configureClient(&routesClient.Client, credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authorizer: %s", err.Error())
+	}
+
+	return &routesClient, nil
+}
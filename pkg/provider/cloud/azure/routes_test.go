@@ -0,0 +1,110 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-06-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+)
+
+func TestValidateRoutes(t *testing.T) {
+	firewall := kubermaticv1.AzureRoute{
+		Name:             "firewall",
+		AddressPrefix:    "0.0.0.0/0",
+		NextHopType:      "VirtualAppliance",
+		NextHopIPAddress: "10.1.0.4",
+	}
+
+	testCases := []struct {
+		name          string
+		routes        []kubermaticv1.AzureRoute
+		expectedError bool
+	}{
+		{
+			name: "valid routes",
+			routes: []kubermaticv1.AzureRoute{
+				firewall,
+				{Name: "on-premises", AddressPrefix: "192.168.0.0/16", NextHopType: "VirtualNetworkGateway"},
+			},
+		},
+		{
+			name:          "duplicate name",
+			routes:        []kubermaticv1.AzureRoute{firewall, firewall},
+			expectedError: true,
+		},
+		{
+			name:          "invalid name",
+			routes:        []kubermaticv1.AzureRoute{{Name: "fire wall", AddressPrefix: "0.0.0.0/0", NextHopType: "Internet"}},
+			expectedError: true,
+		},
+		{
+			name:          "invalid address prefix",
+			routes:        []kubermaticv1.AzureRoute{{Name: "internet", AddressPrefix: "0.0.0.0", NextHopType: "Internet"}},
+			expectedError: true,
+		},
+		{
+			name:          "invalid next hop type",
+			routes:        []kubermaticv1.AzureRoute{{Name: "internet", AddressPrefix: "0.0.0.0/0", NextHopType: "Firewall"}},
+			expectedError: true,
+		},
+		{
+			name:          "virtual appliance without IP",
+			routes:        []kubermaticv1.AzureRoute{{Name: "firewall", AddressPrefix: "0.0.0.0/0", NextHopType: "VirtualAppliance"}},
+			expectedError: true,
+		},
+		{
+			name:          "IP without virtual appliance",
+			routes:        []kubermaticv1.AzureRoute{{Name: "internet", AddressPrefix: "0.0.0.0/0", NextHopType: "Internet", NextHopIPAddress: "10.1.0.4"}},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := validateRoutes(tc.routes); (err != nil) != tc.expectedError {
+				t.Errorf("expected error: %v, got: %v", tc.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestIsRouteUpToDate(t *testing.T) {
+	route := kubermaticv1.AzureRoute{
+		Name:             "firewall",
+		AddressPrefix:    "0.0.0.0/0",
+		NextHopType:      "VirtualAppliance",
+		NextHopIPAddress: "10.1.0.4",
+	}
+
+	existing := network.Route{RoutePropertiesFormat: &network.RoutePropertiesFormat{
+		AddressPrefix:    to.StringPtr("0.0.0.0/0"),
+		NextHopType:      network.RouteNextHopTypeVirtualAppliance,
+		NextHopIPAddress: to.StringPtr("10.1.0.4"),
+	}}
+	if !isRouteUpToDate(existing, route) {
+		t.Error("expected route to be up to date")
+	}
+
+	existing.NextHopIPAddress = to.StringPtr("10.1.0.5")
+	if isRouteUpToDate(existing, route) {
+		t.Error("expected route with a different next hop to be outdated")
+	}
+}
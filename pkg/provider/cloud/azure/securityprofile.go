@@ -0,0 +1,168 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	compute2020 "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-30/compute"
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2015-12-01/features"
+	"github.com/Azure/go-autorest/autorest/to"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+)
+
+const (
+	// hyperVGenerationsCapability lists the image generations a VM size supports, e.g. "V1,V2".
+	hyperVGenerationsCapability = "HyperVGenerations"
+	// trustedLaunchDisabledCapability is "True" for VM sizes which do not support trusted launch.
+	trustedLaunchDisabledCapability = "TrustedLaunchDisabled"
+	// encryptionAtHostSupportedCapability is "True" for VM sizes which support encryption at host.
+	encryptionAtHostSupportedCapability = "EncryptionAtHostSupported"
+)
+
+// ValidateNodeSecurityProfile checks that the VM size of an Azure node spec supports the image
+// generation, the trusted launch and the encryption at host settings of the spec in the location.
+// Node specs using neither Gen2 images, trusted launch nor encryption at host are not checked.
+func ValidateNodeSecurityProfile(ctx context.Context, spec *apiv1.AzureNodeSpec, location string, credentials Credentials) error {
+	if spec.HyperVGeneration != apiv1.AzureHyperVGenerationV2 && spec.SecurityType == "" && !spec.EnableEncryptionAtHost {
+		return nil
+	}
+
+	sku, err := getVMSizeSKU(ctx, spec.Size, location, credentials)
+	if err != nil {
+		return err
+	}
+	if isRestrictedInLocation(*sku, location) {
+		return fmt.Errorf("VM size %q is not available in %s for the subscription", spec.Size, location)
+	}
+	return checkSecurityProfileCapabilities(spec, skuCapabilities(*sku))
+}
+
+// checkSecurityProfileCapabilities checks the settings of a node spec against the capabilities of
+// its VM size.
+func checkSecurityProfileCapabilities(spec *apiv1.AzureNodeSpec, capabilities map[string]string) error {
+	if spec.HyperVGeneration == apiv1.AzureHyperVGenerationV2 && !supportsHyperVGeneration(capabilities, apiv1.AzureHyperVGenerationV2) {
+		return fmt.Errorf("VM size %q does not support %s images", spec.Size, apiv1.AzureHyperVGenerationV2)
+	}
+	if spec.SecurityType == apiv1.AzureSecurityTypeTrustedLaunch && !SupportsTrustedLaunch(capabilities) {
+		return fmt.Errorf("VM size %q does not support trusted launch", spec.Size)
+	}
+	if spec.EnableEncryptionAtHost && !SupportsEncryptionAtHost(capabilities) {
+		return fmt.Errorf("VM size %q does not support encryption at host", spec.Size)
+	}
+	return nil
+}
+
+// SupportedHyperVGenerations returns the image generations supported by a VM size with the given
+// capabilities.
+func SupportedHyperVGenerations(capabilities map[string]string) []string {
+	var generations []string
+	for _, generation := range []string{apiv1.AzureHyperVGenerationV1, apiv1.AzureHyperVGenerationV2} {
+		if supportsHyperVGeneration(capabilities, generation) {
+			generations = append(generations, generation)
+		}
+	}
+	return generations
+}
+
+// SupportsTrustedLaunch returns true if a VM size with the given capabilities supports trusted launch.
+func SupportsTrustedLaunch(capabilities map[string]string) bool {
+	return supportsHyperVGeneration(capabilities, apiv1.AzureHyperVGenerationV2) && !strings.EqualFold(capabilities[trustedLaunchDisabledCapability], "True")
+}
+
+// SupportsEncryptionAtHost returns true if a VM size with the given capabilities supports
+// encryption at host.
+func SupportsEncryptionAtHost(capabilities map[string]string) bool {
+	return strings.EqualFold(capabilities[encryptionAtHostSupportedCapability], "True")
+}
+
+// isRestrictedInLocation returns true if the SKU cannot be used in the location by the subscription.
+func isRestrictedInLocation(sku compute2020.ResourceSku, location string) bool {
+	if sku.Restrictions == nil {
+		return false
+	}
+	for _, restriction := range *sku.Restrictions {
+		if restriction.Type != compute2020.Location || restriction.RestrictionInfo == nil || restriction.RestrictionInfo.Locations == nil {
+			continue
+		}
+		for _, l := range *restriction.RestrictionInfo.Locations {
+			if strings.EqualFold(l, location) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// validateEncryptionAtHostFeature checks that the EncryptionAtHost feature is registered in the
+// subscription of the cluster, which Azure requires to create VMs with encryption at host.
+func validateEncryptionAtHostFeature(ctx context.Context, credentials Credentials) error {
+	client, err := getFeaturesClient(credentials)
+	if err != nil {
+		return err
+	}
+
+	feature, err := client.Get(ctx, "Microsoft.Compute", "EncryptionAtHost")
+	if err != nil {
+		return fmt.Errorf("failed to get the EncryptionAtHost feature: %w", err)
+	}
+	if feature.Properties == nil || !strings.EqualFold(to.String(feature.Properties.State), "Registered") {
+		return errors.New("encryption at host requires the EncryptionAtHost feature of Microsoft.Compute to be registered in the subscription")
+	}
+	return nil
+}
+
+func getFeaturesClient(credentials Credentials) (*features.Client, error) {
+	var err error
+	client := features.NewClient(credentials.SubscriptionID)
+	err = configureClient(&client.Client, credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authorizer: %s", err.Error())
+	}
+
+	return &client, nil
+}
+
+// supportsHyperVGeneration returns true if the VM size supports images of the generation. VM
+// sizes which do not report their generations only support Gen1 images.
+func supportsHyperVGeneration(capabilities map[string]string, generation string) bool {
+	generations, ok := capabilities[hyperVGenerationsCapability]
+	if !ok {
+		return generation == apiv1.AzureHyperVGenerationV1
+	}
+	for _, g := range strings.Split(generations, ",") {
+		if strings.EqualFold(strings.TrimSpace(g), generation) {
+			return true
+		}
+	}
+	return false
+}
+
+// skuCapabilities returns the capabilities of a SKU by name.
+func skuCapabilities(sku compute2020.ResourceSku) map[string]string {
+	capabilities := map[string]string{}
+	if sku.Capabilities != nil {
+		for _, capability := range *sku.Capabilities {
+			capabilities[to.String(capability.Name)] = to.String(capability.Value)
+		}
+	}
+	return capabilities
+}
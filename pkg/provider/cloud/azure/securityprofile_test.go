@@ -0,0 +1,116 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"testing"
+
+	compute2020 "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-30/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+)
+
+func TestCheckSecurityProfileCapabilities(t *testing.T) {
+	gen2 := map[string]string{hyperVGenerationsCapability: "V1,V2"}
+	gen2WithoutTrustedLaunch := map[string]string{hyperVGenerationsCapability: "V1,V2", trustedLaunchDisabledCapability: "True"}
+
+	testCases := []struct {
+		name         string
+		spec         apiv1.AzureNodeSpec
+		capabilities map[string]string
+		wantErr      bool
+	}{
+		{
+			name:         "Gen2 image on a Gen2 size",
+			spec:         apiv1.AzureNodeSpec{HyperVGeneration: apiv1.AzureHyperVGenerationV2},
+			capabilities: gen2,
+		},
+		{
+			name:         "Gen2 image on a size without reported generations",
+			spec:         apiv1.AzureNodeSpec{HyperVGeneration: apiv1.AzureHyperVGenerationV2},
+			capabilities: map[string]string{},
+			wantErr:      true,
+		},
+		{
+			name:         "trusted launch on a supporting size",
+			spec:         apiv1.AzureNodeSpec{HyperVGeneration: apiv1.AzureHyperVGenerationV2, SecurityType: apiv1.AzureSecurityTypeTrustedLaunch},
+			capabilities: gen2,
+		},
+		{
+			name:         "trusted launch disabled for the size",
+			spec:         apiv1.AzureNodeSpec{HyperVGeneration: apiv1.AzureHyperVGenerationV2, SecurityType: apiv1.AzureSecurityTypeTrustedLaunch},
+			capabilities: gen2WithoutTrustedLaunch,
+			wantErr:      true,
+		},
+		{
+			name:         "encryption at host on a supporting size",
+			spec:         apiv1.AzureNodeSpec{EnableEncryptionAtHost: true},
+			capabilities: map[string]string{encryptionAtHostSupportedCapability: "True"},
+		},
+		{
+			name:         "encryption at host on a size without support",
+			spec:         apiv1.AzureNodeSpec{EnableEncryptionAtHost: true},
+			capabilities: map[string]string{encryptionAtHostSupportedCapability: "False"},
+			wantErr:      true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkSecurityProfileCapabilities(&tc.spec, tc.capabilities)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("expected error %v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestSkuCapabilities(t *testing.T) {
+	sku := compute2020.ResourceSku{
+		Capabilities: &[]compute2020.ResourceSkuCapabilities{
+			{Name: to.StringPtr(hyperVGenerationsCapability), Value: to.StringPtr("V2")},
+		},
+	}
+
+	capabilities := skuCapabilities(sku)
+	if generations := SupportedHyperVGenerations(capabilities); len(generations) != 1 || generations[0] != apiv1.AzureHyperVGenerationV2 {
+		t.Errorf("expected only %s to be supported, got %v", apiv1.AzureHyperVGenerationV2, generations)
+	}
+	if !SupportsTrustedLaunch(capabilities) {
+		t.Error("expected trusted launch to be supported")
+	}
+	if SupportsEncryptionAtHost(capabilities) {
+		t.Error("expected encryption at host not to be supported")
+	}
+}
+
+func TestIsRestrictedInLocation(t *testing.T) {
+	sku := compute2020.ResourceSku{
+		Restrictions: &[]compute2020.ResourceSkuRestrictions{
+			{Type: compute2020.Zone, RestrictionInfo: &compute2020.ResourceSkuRestrictionInfo{Locations: &[]string{"westeurope"}}},
+			{Type: compute2020.Location, RestrictionInfo: &compute2020.ResourceSkuRestrictionInfo{Locations: &[]string{"eastus"}}},
+		},
+	}
+
+	if !isRestrictedInLocation(sku, "EastUS") {
+		t.Error("expected the size to be restricted in eastus")
+	}
+	if isRestrictedInLocation(sku, "westeurope") {
+		t.Error("expected a zone restriction not to restrict the size in westeurope")
+	}
+}
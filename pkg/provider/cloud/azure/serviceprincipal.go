@@ -0,0 +1,303 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/authorization/mgmt/2015-07-01/authorization"
+	"github.com/Azure/azure-sdk-for-go/services/graphrbac/1.6/graphrbac"
+	"github.com/Azure/go-autorest/autorest"
+	autorestazure "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/azure/auth"
+	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/Azure/go-autorest/autorest/to"
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/provider"
+)
+
+const (
+	// FinalizerClusterServicePrincipal will instruct the deletion of the service principal of the cluster
+	FinalizerClusterServicePrincipal = "kubermatic.io/cleanup-azure-cluster-service-principal"
+
+	// contributorRoleDefinitionID is the ID of the built-in Contributor role.
+	contributorRoleDefinitionID = "b24988ac-6180-42a0-ab88-20f7382dd24c"
+	// clusterServicePrincipalSecretValidity is the validity of the client secret of a cluster
+	// service principal. The service principal lives as long as the cluster, so its secret is
+	// valid for long enough to not expire in practice.
+	clusterServicePrincipalSecretValidity = 10 * 365 * 24 * time.Hour
+)
+
+func clusterServicePrincipalName(cluster *kubermaticv1.Cluster) string {
+	return resourceNamePrefix + cluster.Name
+}
+
+// roleAssignmentName returns the name of the role assignment of a cluster service principal.
+// Role assignments must be named with a GUID, which is derived from the cluster and the
+// service principal so that retried assignments do not create duplicates.
+func roleAssignmentName(cluster *kubermaticv1.Cluster, clientID string) string {
+	sum := sha1.Sum([]byte(cluster.Name + "/" + clientID))
+	// Set the version and variant bits of a name-based (SHA-1) UUID.
+	sum[6] = (sum[6] & 0x0f) | 0x50
+	sum[8] = (sum[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16])
+}
+
+func generateClientSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// CreateClusterServicePrincipal registers an application in Azure AD for the service principal of
+// the cluster and returns its credentials. The client secret cannot be retrieved later on, so the
+// caller must store the credentials before calling ReconcileClusterServicePrincipal. If the cluster
+// already has a service principal, e.g. because its credentials got lost, it is replaced.
+func (a *Azure) CreateClusterServicePrincipal(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater) (*kubermaticv1.Cluster, Credentials, error) {
+	credentials, err := GetCredentialsForCluster(cluster.Spec.Cloud, a.secretKeySelector)
+	if err != nil {
+		return nil, Credentials{}, err
+	}
+
+	logger := a.log.With("cluster", cluster.Name)
+
+	cluster, err = a.cleanUpClusterServicePrincipal(cluster, update, credentials, logger)
+	if err != nil {
+		return cluster, Credentials{}, err
+	}
+
+	clientSecret, err := generateClientSecret()
+	if err != nil {
+		return cluster, Credentials{}, fmt.Errorf("failed to generate client secret: %w", err)
+	}
+
+	applicationsClient, err := getApplicationsClient(cluster.Spec.Cloud, credentials)
+	if err != nil {
+		return cluster, Credentials{}, err
+	}
+
+	name := clusterServicePrincipalName(cluster)
+	logger.Infow("creating service principal application", "application", name)
+	application, err := applicationsClient.Create(a.ctx, graphrbac.ApplicationCreateParameters{
+		DisplayName:             to.StringPtr(name),
+		AvailableToOtherTenants: to.BoolPtr(false),
+		PasswordCredentials: &[]graphrbac.PasswordCredential{{
+			StartDate: &date.Time{Time: time.Now()},
+			EndDate:   &date.Time{Time: time.Now().Add(clusterServicePrincipalSecretValidity)},
+			Value:     to.StringPtr(clientSecret),
+		}},
+	})
+	if err != nil {
+		return cluster, Credentials{}, fmt.Errorf("failed to create application %q: %w", name, err)
+	}
+
+	servicePrincipal := &kubermaticv1.AzureServicePrincipal{
+		ApplicationObjectID: to.String(application.ObjectID),
+		ClientID:            to.String(application.AppID),
+	}
+	cluster, err = update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+		if updatedCluster.Status.Azure == nil {
+			updatedCluster.Status.Azure = &kubermaticv1.AzureClusterStatus{}
+		}
+		updatedCluster.Status.Azure.ServicePrincipal = servicePrincipal
+		kuberneteshelper.AddFinalizer(updatedCluster, FinalizerClusterServicePrincipal)
+	})
+	if err != nil {
+		return nil, Credentials{}, err
+	}
+
+	return cluster, Credentials{
+		TenantID:       credentials.TenantID,
+		SubscriptionID: credentials.SubscriptionID,
+		ClientID:       servicePrincipal.ClientID,
+		ClientSecret:   clientSecret,
+	}, nil
+}
+
+// ReconcileClusterServicePrincipal creates the service principal of the application created by
+// CreateClusterServicePrincipal and assigns it the Contributor role on the resource group of
+// the cluster. New service principals take a while to propagate in Azure AD, so the role
+// assignment may fail a few times until it succeeds.
+func (a *Azure) ReconcileClusterServicePrincipal(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater) (*kubermaticv1.Cluster, error) {
+	if cluster.Status.Azure == nil || cluster.Status.Azure.ServicePrincipal == nil {
+		return cluster, errors.New("the cluster has no service principal application")
+	}
+	if cluster.Status.Azure.ServicePrincipal.RoleAssignment != "" {
+		return cluster, nil
+	}
+
+	credentials, err := GetCredentialsForCluster(cluster.Spec.Cloud, a.secretKeySelector)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := a.log.With("cluster", cluster.Name)
+	servicePrincipal := cluster.Status.Azure.ServicePrincipal.DeepCopy()
+
+	if servicePrincipal.ObjectID == "" {
+		servicePrincipalsClient, err := getServicePrincipalsClient(cluster.Spec.Cloud, credentials)
+		if err != nil {
+			return cluster, err
+		}
+
+		logger.Infow("creating service principal", "clientID", servicePrincipal.ClientID)
+		created, err := servicePrincipalsClient.Create(a.ctx, graphrbac.ServicePrincipalCreateParameters{
+			AppID:          to.StringPtr(servicePrincipal.ClientID),
+			AccountEnabled: to.BoolPtr(true),
+		})
+		if err != nil {
+			return cluster, fmt.Errorf("failed to create service principal for application %q: %w", servicePrincipal.ClientID, err)
+		}
+
+		servicePrincipal.ObjectID = to.String(created.ObjectID)
+		cluster, err = update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+			updatedCluster.Status.Azure.ServicePrincipal.ObjectID = servicePrincipal.ObjectID
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	roleAssignmentsClient, err := getRoleAssignmentsClient(cluster.Spec.Cloud, credentials)
+	if err != nil {
+		return cluster, err
+	}
+
+	name := roleAssignmentName(cluster, servicePrincipal.ClientID)
+	scope := fmt.Sprintf("/subscriptions/%s/resourceGroups/%s", credentials.SubscriptionID, cluster.Spec.Cloud.Azure.ResourceGroup)
+	logger.Infow("assigning Contributor role to service principal", "resourceGroup", cluster.Spec.Cloud.Azure.ResourceGroup)
+	if _, err = roleAssignmentsClient.Create(a.ctx, scope, name, authorization.RoleAssignmentCreateParameters{
+		Properties: &authorization.RoleAssignmentProperties{
+			RoleDefinitionID: to.StringPtr(fmt.Sprintf("/subscriptions/%s/providers/Microsoft.Authorization/roleDefinitions/%s", credentials.SubscriptionID, contributorRoleDefinitionID)),
+			PrincipalID:      to.StringPtr(servicePrincipal.ObjectID),
+		},
+	}); err != nil {
+		if !isConflict(err) {
+			return cluster, fmt.Errorf("failed to assign Contributor role on resource group %q to service principal: %w", cluster.Spec.Cloud.Azure.ResourceGroup, err)
+		}
+	}
+
+	return update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+		updatedCluster.Status.Azure.ServicePrincipal.RoleAssignment = name
+	})
+}
+
+// cleanUpClusterServicePrincipal removes the role assignment and the application of the service
+// principal of the cluster, which deletes the service principal as well.
+func (a *Azure) cleanUpClusterServicePrincipal(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, credentials Credentials, logger *zap.SugaredLogger) (*kubermaticv1.Cluster, error) {
+	var err error
+
+	if !kuberneteshelper.HasFinalizer(cluster, FinalizerClusterServicePrincipal) {
+		return cluster, nil
+	}
+
+	if status := cluster.Status.Azure; status != nil && status.ServicePrincipal != nil {
+		servicePrincipal := status.ServicePrincipal
+
+		if servicePrincipal.RoleAssignment != "" {
+			roleAssignmentsClient, err := getRoleAssignmentsClient(cluster.Spec.Cloud, credentials)
+			if err != nil {
+				return cluster, err
+			}
+
+			scope := fmt.Sprintf("/subscriptions/%s/resourceGroups/%s", credentials.SubscriptionID, cluster.Spec.Cloud.Azure.ResourceGroup)
+			logger.Infow("deleting role assignment of service principal", "roleAssignment", servicePrincipal.RoleAssignment)
+			if _, err = roleAssignmentsClient.Delete(a.ctx, scope, servicePrincipal.RoleAssignment); err != nil {
+				if !isNotFound(err) {
+					return cluster, fmt.Errorf("failed to delete role assignment %q: %w", servicePrincipal.RoleAssignment, err)
+				}
+			}
+		}
+
+		applicationsClient, err := getApplicationsClient(cluster.Spec.Cloud, credentials)
+		if err != nil {
+			return cluster, err
+		}
+
+		logger.Infow("deleting service principal application", "clientID", servicePrincipal.ClientID)
+		if _, err = applicationsClient.Delete(a.ctx, servicePrincipal.ApplicationObjectID); err != nil {
+			if !isNotFound(err) {
+				return cluster, fmt.Errorf("failed to delete application %q: %w", servicePrincipal.ClientID, err)
+			}
+		}
+	}
+
+	cluster, err = update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+		if updatedCluster.Status.Azure != nil {
+			updatedCluster.Status.Azure.ServicePrincipal = nil
+		}
+		kuberneteshelper.RemoveFinalizer(updatedCluster, FinalizerClusterServicePrincipal)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return cluster, nil
+}
+
+func getGraphAuthorizer(credentials Credentials) (autorest.Authorizer, error) {
+	config := auth.NewClientCredentialsConfig(credentials.ClientID, credentials.ClientSecret, credentials.TenantID)
+	config.Resource = autorestazure.PublicCloud.GraphEndpoint
+	authorizer, err := config.Authorizer()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authorizer: %s", err.Error())
+	}
+	return authorizer, nil
+}
+
+func getApplicationsClient(cloud kubermaticv1.CloudSpec, credentials Credentials) (*graphrbac.ApplicationsClient, error) {
+	var err error
+	applicationsClient := graphrbac.NewApplicationsClient(credentials.TenantID)
+	applicationsClient.Authorizer, err = getGraphAuthorizer(credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	return &applicationsClient, nil
+}
+
+func getServicePrincipalsClient(cloud kubermaticv1.CloudSpec, credentials Credentials) (*graphrbac.ServicePrincipalsClient, error) {
+	var err error
+	servicePrincipalsClient := graphrbac.NewServicePrincipalsClient(credentials.TenantID)
+	servicePrincipalsClient.Authorizer, err = getGraphAuthorizer(credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	return &servicePrincipalsClient, nil
+}
+
+func getRoleAssignmentsClient(cloud kubermaticv1.CloudSpec, credentials Credentials) (*authorization.RoleAssignmentsClient, error) {
+	var err error
+	roleAssignmentsClient := authorization.NewRoleAssignmentsClient(credentials.SubscriptionID)
+	err = configureClient(&roleAssignmentsClient.Client, credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authorizer: %s", err.Error())
+	}
+
+	return &roleAssignmentsClient, nil
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"regexp"
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRoleAssignmentName(t *testing.T) {
+	guid := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-5[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	cluster := &kubermaticv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "abc"}}
+	other := &kubermaticv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "def"}}
+
+	name := roleAssignmentName(cluster, "client-a")
+	if !guid.MatchString(name) {
+		t.Fatalf("expected role assignment name to be a GUID, got %q", name)
+	}
+	if retried := roleAssignmentName(cluster, "client-a"); retried != name {
+		t.Errorf("expected the same name for the same service principal, got %q and %q", name, retried)
+	}
+	if replaced := roleAssignmentName(cluster, "client-b"); replaced == name {
+		t.Errorf("expected a different name for a replaced service principal, got %q", replaced)
+	}
+	if otherName := roleAssignmentName(other, "client-a"); otherName == name {
+		t.Errorf("expected a different name for another cluster, got %q", otherName)
+	}
+}
+
+func TestGenerateClientSecret(t *testing.T) {
+	secret, err := generateClientSecret()
+	if err != nil {
+		t.Fatalf("failed to generate client secret: %v", err)
+	}
+	if len(secret) < 40 {
+		t.Errorf("expected a client secret of at least 40 characters, got %d", len(secret))
+	}
+	if other, _ := generateClientSecret(); other == secret {
+		t.Error("expected two generated client secrets to differ")
+	}
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+)
+
+// acceleratedNetworkingCapability is "True" for VM sizes which support accelerated networking.
+const acceleratedNetworkingCapability = "AcceleratedNetworkingEnabled"
+
+// SupportsAcceleratedNetworking returns true if a VM size with the given capabilities supports
+// accelerated networking.
+func SupportsAcceleratedNetworking(capabilities map[string]string) bool {
+	return strings.EqualFold(capabilities[acceleratedNetworkingCapability], "True")
+}
+
+// ValidateNodeAcceleratedNetworking checks that the VM size of an Azure node spec supports
+// accelerated networking in the location, if it is enabled. Otherwise Azure would reject the
+// network interfaces of the VMs.
+func ValidateNodeAcceleratedNetworking(ctx context.Context, spec *apiv1.AzureNodeSpec, location string, credentials Credentials) error {
+	if !spec.EnableAcceleratedNetworking {
+		return nil
+	}
+
+	sku, err := getVMSizeSKU(ctx, spec.Size, location, credentials)
+	if err != nil {
+		return err
+	}
+	if isRestrictedInLocation(*sku, location) {
+		return fmt.Errorf("VM size %q is not available in %s for the subscription", spec.Size, location)
+	}
+	return checkAcceleratedNetworkingCapability(spec, skuCapabilities(*sku))
+}
+
+// checkAcceleratedNetworkingCapability checks the accelerated networking setting of a node spec
+// against the capabilities of its VM size.
+func checkAcceleratedNetworkingCapability(spec *apiv1.AzureNodeSpec, capabilities map[string]string) error {
+	if spec.EnableAcceleratedNetworking && !SupportsAcceleratedNetworking(capabilities) {
+		return fmt.Errorf("VM size %q does not support accelerated networking", spec.Size)
+	}
+	return nil
+}
+
+// HasVCPUHeadroom returns true if the remaining quotas, by quota name, allow to create another VM
+// with the given number of vCPUs of a VM size family. Both the quota of the family and the
+// regional vCPU quota must allow it, unknown quotas are not checked.
+func HasVCPUHeadroom(remaining map[string]int64, family string, vcpus int64) bool {
+	for _, quota := range []string{regionalVCPUsQuota, family} {
+		if available, ok := remaining[quota]; ok && available < vcpus {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"testing"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+)
+
+func TestHasVCPUHeadroom(t *testing.T) {
+	remaining := map[string]int64{
+		"cores":               10,
+		"standardDSv3Family":  4,
+		"standardFSv2Family":  16,
+		"standardNCSv3Family": 0,
+	}
+
+	testCases := []struct {
+		name     string
+		family   string
+		vcpus    int64
+		expected bool
+	}{
+		{
+			name:     "family and regional quota allow the size",
+			family:   "standardDSv3Family",
+			vcpus:    4,
+			expected: true,
+		},
+		{
+			name:   "family quota is exhausted",
+			family: "standardDSv3Family",
+			vcpus:  8,
+		},
+		{
+			name:   "regional quota is exhausted",
+			family: "standardFSv2Family",
+			vcpus:  16,
+		},
+		{
+			name:   "family without quota",
+			family: "standardNCSv3Family",
+			vcpus:  6,
+		},
+		{
+			name:     "unknown family",
+			family:   "standardMSFamily",
+			vcpus:    8,
+			expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if headroom := HasVCPUHeadroom(remaining, tc.family, tc.vcpus); headroom != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, headroom)
+			}
+		})
+	}
+}
+
+func TestSupportsAcceleratedNetworking(t *testing.T) {
+	if !SupportsAcceleratedNetworking(map[string]string{"AcceleratedNetworkingEnabled": "True"}) {
+		t.Error("expected accelerated networking to be supported")
+	}
+	if SupportsAcceleratedNetworking(map[string]string{"AcceleratedNetworkingEnabled": "False"}) {
+		t.Error("expected accelerated networking not to be supported")
+	}
+	if SupportsAcceleratedNetworking(map[string]string{}) {
+		t.Error("expected accelerated networking not to be supported without the capability")
+	}
+}
+
+func TestCheckAcceleratedNetworkingCapability(t *testing.T) {
+	testCases := []struct {
+		name         string
+		spec         apiv1.AzureNodeSpec
+		capabilities map[string]string
+		wantErr      bool
+	}{
+		{
+			name:         "accelerated networking on a supporting size",
+			spec:         apiv1.AzureNodeSpec{Size: "Standard_D4s_v3", EnableAcceleratedNetworking: true},
+			capabilities: map[string]string{acceleratedNetworkingCapability: "True"},
+		},
+		{
+			name:         "accelerated networking on a size without support",
+			spec:         apiv1.AzureNodeSpec{Size: "Standard_B2s", EnableAcceleratedNetworking: true},
+			capabilities: map[string]string{acceleratedNetworkingCapability: "False"},
+			wantErr:      true,
+		},
+		{
+			name:         "accelerated networking disabled",
+			spec:         apiv1.AzureNodeSpec{Size: "Standard_B2s"},
+			capabilities: map[string]string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			spec := tc.spec
+			if err := checkAcceleratedNetworkingCapability(&spec, tc.capabilities); (err != nil) != tc.wantErr {
+				t.Errorf("expected error: %v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
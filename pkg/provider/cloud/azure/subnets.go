@@ -0,0 +1,443 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"net"
+	"regexp"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-06-01/network"
+	network2020 "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	autorestazure "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/to"
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/provider"
+)
+
+var subnetNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9._-]{0,78}[a-zA-Z0-9_])?$`)
+
+// validateAdditionalSubnets checks that the additional subnets have unique names and address
+// ranges which overlap neither each other nor the given address ranges of the cluster's subnet
+// and the Bastion subnet.
+func validateAdditionalSubnets(subnets []kubermaticv1.AzureSubnet, clusterSubnetName string, reservedCIDRs []string) error {
+	names := map[string]struct{}{}
+	if clusterSubnetName != "" {
+		names[strings.ToLower(clusterSubnetName)] = struct{}{}
+	}
+	names[strings.ToLower(bastionSubnetName)] = struct{}{}
+
+	var ranges []*net.IPNet
+	for _, cidr := range reservedCIDRs {
+		if _, ipNet, err := net.ParseCIDR(cidr); err == nil {
+			ranges = append(ranges, ipNet)
+		}
+	}
+
+	for _, subnet := range subnets {
+		if !subnetNameRegexp.MatchString(subnet.Name) {
+			return fmt.Errorf("invalid subnet name %q", subnet.Name)
+		}
+		if _, ok := names[strings.ToLower(subnet.Name)]; ok {
+			return fmt.Errorf("duplicate subnet name %q", subnet.Name)
+		}
+		names[strings.ToLower(subnet.Name)] = struct{}{}
+
+		ip, ipNet, err := net.ParseCIDR(subnet.CIDR)
+		if err != nil {
+			return fmt.Errorf("invalid CIDR %q of subnet %q: %w", subnet.CIDR, subnet.Name, err)
+		}
+		if !ip.Equal(ipNet.IP) {
+			return fmt.Errorf("invalid CIDR %q of subnet %q: host bits must not be set, expected %q", subnet.CIDR, subnet.Name, ipNet.String())
+		}
+		for _, existing := range ranges {
+			if existing.Contains(ipNet.IP) || ipNet.Contains(existing.IP) {
+				return fmt.Errorf("CIDR %q of subnet %q overlaps %q", subnet.CIDR, subnet.Name, existing.String())
+			}
+		}
+		ranges = append(ranges, ipNet)
+	}
+
+	return nil
+}
+
+// validateClusterAdditionalSubnets checks the additional subnets against the cluster's subnet, which
+// is either the given existing one or created by Kubermatic, and that their security groups exist.
+func (a *Azure) validateClusterAdditionalSubnets(cloud kubermaticv1.CloudSpec, clusterSubnet network.Subnet, credentials Credentials) error {
+	var reserved []string
+	if cloud.Azure.SubnetName == "" {
+		reserved = append(reserved, subnetCIDR(cloud))
+	} else if clusterSubnet.SubnetPropertiesFormat != nil && clusterSubnet.AddressPrefix != nil {
+		reserved = append(reserved, *clusterSubnet.AddressPrefix)
+	}
+	if cloud.Azure.EnableBastion {
+		reserved = append(reserved, bastionSubnetCIDR)
+	}
+
+	if err := validateAdditionalSubnets(cloud.Azure.AdditionalSubnets, cloud.Azure.SubnetName, reserved); err != nil {
+		return err
+	}
+
+	sgClient, err := getSecurityGroupsClient(cloud, credentials)
+	if err != nil {
+		return err
+	}
+	for _, subnet := range cloud.Azure.AdditionalSubnets {
+		if subnet.SecurityGroup == "" {
+			continue
+		}
+		if cloud.Azure.ResourceGroup == "" {
+			return fmt.Errorf("security group %q of subnet %q requires the resource group of the cluster to be set", subnet.SecurityGroup, subnet.Name)
+		}
+		if _, err := sgClient.Get(a.ctx, cloud.Azure.ResourceGroup, subnet.SecurityGroup, ""); err != nil {
+			return fmt.Errorf("failed to get security group %q of subnet %q: %w", subnet.SecurityGroup, subnet.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// subnetOperation returns the name of an operation on the given additional subnet. The name is
+// hashed, as the operation name is part of an annotation key.
+func subnetOperation(action, name string) string {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(name))
+	return fmt.Sprintf("%s-subnet-%08x", action, hash.Sum32())
+}
+
+// additionalSubnetSecurityGroup returns the name of the security group associated with the additional subnet.
+func additionalSubnetSecurityGroup(cloud kubermaticv1.CloudSpec, subnet kubermaticv1.AzureSubnet) string {
+	if subnet.SecurityGroup != "" {
+		return subnet.SecurityGroup
+	}
+	return cloud.Azure.SecurityGroup
+}
+
+// isAdditionalSubnetUpToDate returns true if the subnet in Azure has the address range of the
+// additional subnet and is associated with the desired security group and route table.
+func isAdditionalSubnetUpToDate(existing network2020.Subnet, securityGroupID, routeTableID string) bool {
+	if existing.SubnetPropertiesFormat == nil {
+		return false
+	}
+	if existing.NetworkSecurityGroup == nil || !strings.EqualFold(to.String(existing.NetworkSecurityGroup.ID), securityGroupID) {
+		return false
+	}
+	if routeTableID == "" {
+		return true
+	}
+
+	return existing.RouteTable != nil && strings.EqualFold(to.String(existing.RouteTable.ID), routeTableID)
+}
+
+// reconcileAdditionalSubnets creates the additional subnets in the cluster's VNet, associates them
+// with their security group and the cluster's route table, and removes the subnets Kubermatic
+// created once they are not configured anymore.
+func (a *Azure) reconcileAdditionalSubnets(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, credentials Credentials) (*kubermaticv1.Cluster, error) {
+	var err error
+	logger := a.log.With("cluster", cluster.Name)
+
+	subnets := cluster.Spec.Cloud.Azure.AdditionalSubnets
+	if len(subnets) == 0 && !kuberneteshelper.HasFinalizer(cluster, FinalizerAdditionalSubnets) {
+		return cluster, nil
+	}
+
+	// the finalizer is added first, so that no subnet is left behind if the cluster is deleted
+	// while they are being created
+	if len(subnets) > 0 && !kuberneteshelper.HasFinalizer(cluster, FinalizerAdditionalSubnets) {
+		cluster, err = update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+			kuberneteshelper.AddFinalizer(updatedCluster, FinalizerAdditionalSubnets)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	subnetsClient, err := getPrivateLinkSubnetsClient(cluster.Spec.Cloud, credentials)
+	if err != nil {
+		return cluster, err
+	}
+
+	desired := map[string]struct{}{}
+	for _, subnet := range subnets {
+		subnet := subnet
+		desired[subnet.Name] = struct{}{}
+
+		existing, err := subnetsClient.Get(a.ctx, vnetResourceGroup(cluster.Spec.Cloud), cluster.Spec.Cloud.Azure.VNetName, subnet.Name, "")
+		found := err == nil
+		if !found {
+			if !isNotFound(err) {
+				return cluster, fmt.Errorf("failed to get subnetwork %q: %w", subnet.Name, err)
+			}
+
+			// the subnet is recorded before it is created, so that it is deleted together with the cluster
+			if !createdAdditionalSubnet(cluster, subnet.Name) {
+				cluster, err = update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+					if updatedCluster.Status.Azure == nil {
+						updatedCluster.Status.Azure = &kubermaticv1.AzureClusterStatus{}
+					}
+					if !createdAdditionalSubnet(updatedCluster, subnet.Name) {
+						updatedCluster.Status.Azure.Subnets = append(updatedCluster.Status.Azure.Subnets, subnet.Name)
+					}
+				})
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		securityGroupID := assembleSecurityGroupID(cluster.Spec.Cloud, credentials.SubscriptionID, additionalSubnetSecurityGroup(cluster.Spec.Cloud, subnet))
+		routeTableID := ""
+		if cluster.Spec.Cloud.Azure.RouteTableName != "" {
+			routeTableID = assembleRouteTableID(cluster.Spec.Cloud, credentials.SubscriptionID)
+		}
+		if found && isAdditionalSubnetUpToDate(existing, securityGroupID, routeTableID) {
+			continue
+		}
+
+		if err = a.pollOperation(cluster, update, credentials, subnetOperation("add-address-space", subnet.Name), func() (autorestazure.FutureAPI, error) {
+			return ensureAddressSpace(a.ctx, cluster.Spec.Cloud, subnet.CIDR, credentials)
+		}); err != nil {
+			return cluster, fmt.Errorf("failed to add the address range of subnetwork %q to virtual network %q: %w", subnet.Name, cluster.Spec.Cloud.Azure.VNetName, err)
+		}
+
+		logger.Infow("ensuring subnet", "subnet", subnet.Name, "cidr", subnet.CIDR)
+		if err = a.pollOperation(cluster, update, credentials, subnetOperation("create", subnet.Name), func() (autorestazure.FutureAPI, error) {
+			return ensureAdditionalSubnet(a.ctx, cluster.Spec.Cloud, subnet, securityGroupID, routeTableID, credentials)
+		}); err != nil {
+			a.recordError(cluster, "FailedToEnsureSubnet", err)
+			return cluster, fmt.Errorf("failed to create or update subnetwork %q: %w", subnet.Name, err)
+		}
+		a.recordEvent(cluster, "EnsuredSubnet", "Ensured subnet %q", subnet.Name)
+	}
+
+	if cluster.Status.Azure != nil {
+		for _, name := range cluster.Status.Azure.Subnets {
+			if _, ok := desired[name]; ok {
+				continue
+			}
+
+			if cluster, err = a.deleteAdditionalSubnet(cluster, update, credentials, name, logger); err != nil {
+				return cluster, err
+			}
+		}
+	}
+
+	if len(subnets) == 0 {
+		cluster, err = update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+			kuberneteshelper.RemoveFinalizer(updatedCluster, FinalizerAdditionalSubnets)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return cluster, nil
+}
+
+// cleanUpAdditionalSubnets removes the additional subnets Kubermatic created, which must happen
+// before their security groups and the route table can be deleted.
+func (a *Azure) cleanUpAdditionalSubnets(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, credentials Credentials, logger *zap.SugaredLogger) (*kubermaticv1.Cluster, error) {
+	var err error
+
+	if !kuberneteshelper.HasFinalizer(cluster, FinalizerAdditionalSubnets) {
+		return cluster, nil
+	}
+
+	if cluster.Status.Azure != nil {
+		for _, name := range cluster.Status.Azure.Subnets {
+			if cluster, err = a.deleteAdditionalSubnet(cluster, update, credentials, name, logger); err != nil {
+				return cluster, err
+			}
+		}
+	}
+
+	cluster, err = update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+		kuberneteshelper.RemoveFinalizer(updatedCluster, FinalizerAdditionalSubnets)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return cluster, nil
+}
+
+// deleteAdditionalSubnet deletes an additional subnet Kubermatic created and removes it from the
+// status of the cluster.
+func (a *Azure) deleteAdditionalSubnet(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, credentials Credentials, name string, logger *zap.SugaredLogger) (*kubermaticv1.Cluster, error) {
+	logger.Infow("deleting subnet", "subnet", name)
+	if err := a.pollOperation(cluster, update, credentials, subnetOperation("delete", name), func() (autorestazure.FutureAPI, error) {
+		subnetsClient, err := getPrivateLinkSubnetsClient(cluster.Spec.Cloud, credentials)
+		if err != nil {
+			return nil, err
+		}
+
+		future, err := subnetsClient.Delete(a.ctx, vnetResourceGroup(cluster.Spec.Cloud), cluster.Spec.Cloud.Azure.VNetName, name)
+		if err != nil {
+			return nil, err
+		}
+
+		return future.FutureAPI, nil
+	}); err != nil {
+		if !isNotFound(err) {
+			a.recordError(cluster, "FailedToDeleteSubnet", err)
+			return cluster, fmt.Errorf("failed to delete sub-network %q: %w", name, err)
+		}
+	}
+	a.recordEvent(cluster, "DeletedSubnet", "Deleted subnet %q", name)
+
+	return update(cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+		if updatedCluster.Status.Azure == nil {
+			return
+		}
+		var remaining []string
+		for _, subnet := range updatedCluster.Status.Azure.Subnets {
+			if subnet != name {
+				remaining = append(remaining, subnet)
+			}
+		}
+		updatedCluster.Status.Azure.Subnets = remaining
+	})
+}
+
+// createdAdditionalSubnet returns true if the additional subnet was created by Kubermatic.
+func createdAdditionalSubnet(cluster *kubermaticv1.Cluster, name string) bool {
+	if cluster.Status.Azure == nil {
+		return false
+	}
+	for _, subnet := range cluster.Status.Azure.Subnets {
+		if subnet == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ensureAddressSpace will start adding the given range to the address space of the VNet, unless
+// it is already contained in it.
+func ensureAddressSpace(ctx context.Context, cloud kubermaticv1.CloudSpec, cidr string, credentials Credentials) (autorestazure.FutureAPI, error) {
+	networksClient, err := getBastionVirtualNetworksClient(cloud, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	vnet, err := networksClient.Get(ctx, vnetResourceGroup(cloud), cloud.Azure.VNetName, "")
+	if err != nil {
+		return nil, err
+	}
+	if addressSpaceContains(vnet, cidr) {
+		return nil, nil
+	}
+
+	if vnet.VirtualNetworkPropertiesFormat == nil {
+		vnet.VirtualNetworkPropertiesFormat = &network2020.VirtualNetworkPropertiesFormat{}
+	}
+	if vnet.AddressSpace == nil {
+		vnet.AddressSpace = &network2020.AddressSpace{}
+	}
+	prefixes := append(to.StringSlice(vnet.AddressSpace.AddressPrefixes), cidr)
+	vnet.AddressSpace.AddressPrefixes = &prefixes
+
+	// the whole VNet is sent, as its subnets would be removed otherwise
+	future, err := networksClient.CreateOrUpdate(ctx, vnetResourceGroup(cloud), cloud.Azure.VNetName, vnet)
+	if err != nil {
+		return nil, err
+	}
+
+	return future.FutureAPI, nil
+}
+
+// addressSpaceContains returns true if the given range is within one of the address prefixes of the VNet.
+func addressSpaceContains(vnet network2020.VirtualNetwork, cidr string) bool {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil || vnet.VirtualNetworkPropertiesFormat == nil || vnet.AddressSpace == nil {
+		return false
+	}
+	size, _ := ipNet.Mask.Size()
+
+	for _, prefix := range to.StringSlice(vnet.AddressSpace.AddressPrefixes) {
+		_, existing, err := net.ParseCIDR(prefix)
+		if err != nil {
+			continue
+		}
+		if existingSize, _ := existing.Mask.Size(); existingSize <= size && existing.Contains(ipNet.IP) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ensureAdditionalSubnet will start creating or updating an additional subnet. Settings of an
+// existing subnet other than its address range, security group and route table are preserved.
+func ensureAdditionalSubnet(ctx context.Context, cloud kubermaticv1.CloudSpec, desired kubermaticv1.AzureSubnet, securityGroupID, routeTableID string, credentials Credentials) (autorestazure.FutureAPI, error) {
+	subnetsClient, err := getPrivateLinkSubnetsClient(cloud, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	subnet, err := subnetsClient.Get(ctx, vnetResourceGroup(cloud), cloud.Azure.VNetName, desired.Name, "")
+	if err != nil {
+		if !isNotFound(err) {
+			return nil, err
+		}
+		subnet = network2020.Subnet{Name: to.StringPtr(desired.Name)}
+	}
+
+	if subnet.SubnetPropertiesFormat == nil {
+		subnet.SubnetPropertiesFormat = &network2020.SubnetPropertiesFormat{}
+	}
+	if subnet.AddressPrefix == nil && subnet.AddressPrefixes == nil {
+		subnet.AddressPrefix = to.StringPtr(desired.CIDR)
+	}
+	subnet.NetworkSecurityGroup = &network2020.SecurityGroup{ID: to.StringPtr(securityGroupID)}
+	if routeTableID != "" {
+		subnet.RouteTable = &network2020.RouteTable{ID: to.StringPtr(routeTableID)}
+	}
+
+	future, err := subnetsClient.CreateOrUpdate(ctx, vnetResourceGroup(cloud), cloud.Azure.VNetName, desired.Name, subnet)
+	if err != nil {
+		return nil, err
+	}
+
+	return future.FutureAPI, nil
+}
+
+// assembleSecurityGroupID returns the full ID of a security group in the cluster's resource group,
+// or the ID of the cluster's security group if it is referenced by ID.
+func assembleSecurityGroupID(cloud kubermaticv1.CloudSpec, subscriptionID, name string) string {
+	if cloud.Azure.SecurityGroupID != "" && name == cloud.Azure.SecurityGroup {
+		return cloud.Azure.SecurityGroupID
+	}
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/networkSecurityGroups/%s",
+		subscriptionID, cloud.Azure.ResourceGroup, name)
+}
+
+// assembleRouteTableID returns the full ID of the cluster's route table.
+func assembleRouteTableID(cloud kubermaticv1.CloudSpec, subscriptionID string) string {
+	if cloud.Azure.RouteTableID != "" {
+		return cloud.Azure.RouteTableID
+	}
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/routeTables/%s",
+		subscriptionID, cloud.Azure.ResourceGroup, cloud.Azure.RouteTableName)
+}
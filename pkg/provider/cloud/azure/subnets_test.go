@@ -0,0 +1,191 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"testing"
+
+	network2020 "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+)
+
+func TestValidateAdditionalSubnets(t *testing.T) {
+	tests := []struct {
+		name    string
+		subnets []kubermaticv1.AzureSubnet
+		wantErr bool
+	}{
+		{
+			name: "valid subnets",
+			subnets: []kubermaticv1.AzureSubnet{
+				{Name: "frontend", CIDR: "10.2.0.0/24"},
+				{Name: "backend", CIDR: "10.2.1.0/24", SecurityGroup: "backend-nsg"},
+			},
+		},
+		{
+			name:    "invalid name",
+			subnets: []kubermaticv1.AzureSubnet{{Name: "-frontend", CIDR: "10.2.0.0/24"}},
+			wantErr: true,
+		},
+		{
+			name:    "name of the cluster subnet",
+			subnets: []kubermaticv1.AzureSubnet{{Name: "kubernetes-abc", CIDR: "10.2.0.0/24"}},
+			wantErr: true,
+		},
+		{
+			name:    "name of the Bastion subnet",
+			subnets: []kubermaticv1.AzureSubnet{{Name: bastionSubnetName, CIDR: "10.2.0.0/24"}},
+			wantErr: true,
+		},
+		{
+			name: "duplicate name",
+			subnets: []kubermaticv1.AzureSubnet{
+				{Name: "frontend", CIDR: "10.2.0.0/24"},
+				{Name: "Frontend", CIDR: "10.2.1.0/24"},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "invalid CIDR",
+			subnets: []kubermaticv1.AzureSubnet{{Name: "frontend", CIDR: "10.2.0.0"}},
+			wantErr: true,
+		},
+		{
+			name:    "CIDR with host bits",
+			subnets: []kubermaticv1.AzureSubnet{{Name: "frontend", CIDR: "10.2.0.1/24"}},
+			wantErr: true,
+		},
+		{
+			name:    "overlapping the cluster subnet",
+			subnets: []kubermaticv1.AzureSubnet{{Name: "frontend", CIDR: "10.0.128.0/24"}},
+			wantErr: true,
+		},
+		{
+			name:    "overlapping the Bastion subnet",
+			subnets: []kubermaticv1.AzureSubnet{{Name: "frontend", CIDR: "10.1.0.0/16"}},
+			wantErr: true,
+		},
+		{
+			name: "overlapping each other",
+			subnets: []kubermaticv1.AzureSubnet{
+				{Name: "frontend", CIDR: "10.2.0.0/16"},
+				{Name: "backend", CIDR: "10.2.1.0/24"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateAdditionalSubnets(test.subnets, "kubernetes-abc", []string{defaultSubnetCIDR, bastionSubnetCIDR})
+			if (err != nil) != test.wantErr {
+				t.Errorf("expected error = %v, got %v", test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestAddressSpaceContains(t *testing.T) {
+	vnet := network2020.VirtualNetwork{VirtualNetworkPropertiesFormat: &network2020.VirtualNetworkPropertiesFormat{
+		AddressSpace: &network2020.AddressSpace{AddressPrefixes: &[]string{"10.0.0.0/16", "10.2.0.0/24"}},
+	}}
+
+	tests := []struct {
+		cidr     string
+		contains bool
+	}{
+		{cidr: "10.0.0.0/16", contains: true},
+		{cidr: "10.0.4.0/24", contains: true},
+		{cidr: "10.2.0.0/24", contains: true},
+		{cidr: "10.2.0.0/16", contains: false},
+		{cidr: "10.3.0.0/24", contains: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.cidr, func(t *testing.T) {
+			if got := addressSpaceContains(vnet, test.cidr); got != test.contains {
+				t.Errorf("expected addressSpaceContains to be %v, got %v", test.contains, got)
+			}
+		})
+	}
+
+	if addressSpaceContains(network2020.VirtualNetwork{}, "10.0.0.0/16") {
+		t.Error("expected a VNet without address space not to contain any range")
+	}
+}
+
+func TestIsAdditionalSubnetUpToDate(t *testing.T) {
+	const (
+		securityGroupID = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/networkSecurityGroups/nsg"
+		routeTableID    = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/routeTables/rt"
+	)
+
+	tests := []struct {
+		name         string
+		subnet       network2020.Subnet
+		routeTableID string
+		upToDate     bool
+	}{
+		{
+			name:     "subnet without properties",
+			subnet:   network2020.Subnet{},
+			upToDate: false,
+		},
+		{
+			name: "subnet without security group",
+			subnet: network2020.Subnet{SubnetPropertiesFormat: &network2020.SubnetPropertiesFormat{
+				RouteTable: &network2020.RouteTable{ID: to.StringPtr(routeTableID)},
+			}},
+			routeTableID: routeTableID,
+			upToDate:     false,
+		},
+		{
+			name: "subnet without route table",
+			subnet: network2020.Subnet{SubnetPropertiesFormat: &network2020.SubnetPropertiesFormat{
+				NetworkSecurityGroup: &network2020.SecurityGroup{ID: to.StringPtr(securityGroupID)},
+			}},
+			routeTableID: routeTableID,
+			upToDate:     false,
+		},
+		{
+			name: "cluster without route table",
+			subnet: network2020.Subnet{SubnetPropertiesFormat: &network2020.SubnetPropertiesFormat{
+				NetworkSecurityGroup: &network2020.SecurityGroup{ID: to.StringPtr(securityGroupID)},
+			}},
+			upToDate: true,
+		},
+		{
+			name: "associated subnet with differently cased IDs",
+			subnet: network2020.Subnet{SubnetPropertiesFormat: &network2020.SubnetPropertiesFormat{
+				NetworkSecurityGroup: &network2020.SecurityGroup{ID: to.StringPtr("/subscriptions/sub/resourceGroups/RG/providers/Microsoft.Network/networkSecurityGroups/nsg")},
+				RouteTable:           &network2020.RouteTable{ID: to.StringPtr(routeTableID)},
+			}},
+			routeTableID: routeTableID,
+			upToDate:     true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := isAdditionalSubnetUpToDate(test.subnet, securityGroupID, test.routeTableID); got != test.upToDate {
+				t.Errorf("expected isAdditionalSubnetUpToDate to be %v, got %v", test.upToDate, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,232 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2018-06-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-06-01/network"
+	network2020 "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2018-02-01/resources"
+	autorestazure "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/to"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// SetInstallationID sets the ID of the Kubermatic installation, which is added as a tag to all
+// resources created for a cluster. Without an ID, the resources cannot be garbage collected
+// once their cluster is gone.
+func (a *Azure) SetInstallationID(installationID string) {
+	a.installationID = installationID
+}
+
+// resourceTags returns the tags for all resources created for the cluster. Datacenter tags
+// are overridden by cluster tags, the cluster and installation tags can never be overridden.
+// The keys of the datacenter and cluster tags are recorded in the managed tags marker.
+func (a *Azure) resourceTags(cluster *kubermaticv1.Cluster) map[string]*string {
+	tags := map[string]*string{}
+
+	for k, v := range a.dc.Tags {
+		tags[k] = to.StringPtr(v)
+	}
+	for k, v := range cluster.Spec.Cloud.Azure.Tags {
+		tags[k] = to.StringPtr(v)
+	}
+	tags[managedTagsKey] = to.StringPtr(managedTags(tags))
+	tags[clusterTagKey] = to.StringPtr(cluster.Name)
+	if a.installationID != "" {
+		tags[installationTagKey] = to.StringPtr(a.installationID)
+	}
+
+	return tags
+}
+
+// managedTags returns the value of the managed tags marker for the given tags.
+func managedTags(tags map[string]*string) string {
+	keys := sets.StringKeySet(tags).Delete(clusterTagKey, managedTagsKey, installationTagKey)
+	return strings.Join(keys.List(), ",")
+}
+
+// validateManagedTags makes sure the managed tags marker for the datacenter and cluster tags
+// fits into an Azure tag value.
+func (a *Azure) validateManagedTags(cloud kubermaticv1.CloudSpec) error {
+	tags := map[string]*string{}
+	for k, v := range a.dc.Tags {
+		tags[k] = to.StringPtr(v)
+	}
+	for k, v := range cloud.Azure.Tags {
+		tags[k] = to.StringPtr(v)
+	}
+	if marker := managedTags(tags); len(marker) > maxTagValueLength {
+		return fmt.Errorf("the keys of all tags joined by commas must not be longer than %d characters, got %d", maxTagValueLength, len(marker))
+	}
+	return nil
+}
+
+// mergeTags returns the existing tags with the desired tags applied on top and whether
+// the result differs from the existing tags. Tags that were set by Kubermatic according to
+// the managed tags marker, but are no longer desired, are removed. All other tags, for
+// example added by an Azure Policy, are preserved.
+func mergeTags(existing, desired map[string]*string) (map[string]*string, bool) {
+	merged := map[string]*string{}
+	for k, v := range existing {
+		merged[k] = v
+	}
+
+	changed := false
+	for _, k := range strings.Split(to.String(existing[managedTagsKey]), ",") {
+		if _, ok := desired[k]; ok {
+			continue
+		}
+		if _, ok := merged[k]; ok {
+			delete(merged, k)
+			changed = true
+		}
+	}
+
+	for k, v := range desired {
+		if current, ok := merged[k]; !ok || to.String(current) != to.String(v) {
+			merged[k] = v
+			changed = true
+		}
+	}
+
+	return merged, changed
+}
+
+// reconcileTags makes sure all Kubermatic-managed resources of the cluster carry the desired tags.
+// Resources that were provided by the user are left untouched unless they have been adopted.
+// Subnets are skipped as Azure does not support tags on them.
+func (a *Azure) reconcileTags(cluster *kubermaticv1.Cluster, tags map[string]*string, credentials Credentials) error {
+	cloud := cluster.Spec.Cloud
+
+	if kuberneteshelper.HasFinalizer(cluster, FinalizerResourceGroup) {
+		client, err := getGroupsClient(cloud, credentials)
+		if err != nil {
+			return err
+		}
+		group, err := client.Get(a.ctx, cloud.Azure.ResourceGroup)
+		if err != nil {
+			return fmt.Errorf("failed to get resource group %q: %w", cloud.Azure.ResourceGroup, err)
+		}
+		if merged, changed := mergeTags(group.Tags, tags); changed {
+			if _, err := client.Update(a.ctx, cloud.Azure.ResourceGroup, resources.GroupPatchable{Tags: merged}); err != nil {
+				return fmt.Errorf("failed to update tags of resource group %q: %w", cloud.Azure.ResourceGroup, err)
+			}
+		}
+	}
+
+	if kuberneteshelper.HasFinalizer(cluster, FinalizerVNet) {
+		client, err := getNetworksClient(cloud, credentials)
+		if err != nil {
+			return err
+		}
+		vnet, err := client.Get(a.ctx, vnetResourceGroup(cloud), cloud.Azure.VNetName, "")
+		if err != nil {
+			return fmt.Errorf("failed to get virtual network %q: %w", cloud.Azure.VNetName, err)
+		}
+		if merged, changed := mergeTags(vnet.Tags, tags); changed {
+			future, err := client.UpdateTags(a.ctx, vnetResourceGroup(cloud), cloud.Azure.VNetName, network.TagsObject{Tags: merged})
+			if err != nil {
+				return fmt.Errorf("failed to update tags of virtual network %q: %w", cloud.Azure.VNetName, err)
+			}
+			if err := future.WaitForCompletionRef(a.ctx, client.Client); err != nil {
+				return fmt.Errorf("failed to update tags of virtual network %q: %w", cloud.Azure.VNetName, err)
+			}
+		}
+	}
+
+	if kuberneteshelper.HasFinalizer(cluster, FinalizerRouteTable) {
+		client, err := getRouteTablesClient(cloud, credentials)
+		if err != nil {
+			return err
+		}
+		routeTable, err := client.Get(a.ctx, RouteTableResourceGroup(cloud), cloud.Azure.RouteTableName, "")
+		if err != nil {
+			return fmt.Errorf("failed to get route table %q: %w", cloud.Azure.RouteTableName, err)
+		}
+		if merged, changed := mergeTags(routeTable.Tags, tags); changed {
+			future, err := client.UpdateTags(a.ctx, RouteTableResourceGroup(cloud), cloud.Azure.RouteTableName, network.TagsObject{Tags: merged})
+			if err != nil {
+				return fmt.Errorf("failed to update tags of route table %q: %w", cloud.Azure.RouteTableName, err)
+			}
+			if err := future.WaitForCompletionRef(a.ctx, client.Client); err != nil {
+				return fmt.Errorf("failed to update tags of route table %q: %w", cloud.Azure.RouteTableName, err)
+			}
+		}
+	}
+
+	if kuberneteshelper.HasFinalizer(cluster, FinalizerSecurityGroup) {
+		client, err := getSecurityGroupsClient(cloud, credentials)
+		if err != nil {
+			return err
+		}
+		sg, err := client.Get(a.ctx, SecurityGroupResourceGroup(cloud), cloud.Azure.SecurityGroup, "")
+		if err != nil {
+			return fmt.Errorf("failed to get security group %q: %w", cloud.Azure.SecurityGroup, err)
+		}
+		if merged, changed := mergeTags(sg.Tags, tags); changed {
+			if _, err := client.UpdateTags(a.ctx, SecurityGroupResourceGroup(cloud), cloud.Azure.SecurityGroup, network2020.TagsObject{Tags: merged}); err != nil {
+				return fmt.Errorf("failed to update tags of security group %q: %w", cloud.Azure.SecurityGroup, err)
+			}
+		}
+	}
+
+	if kuberneteshelper.HasFinalizer(cluster, FinalizerAvailabilitySet) {
+		client, err := getAvailabilitySetClient(cloud, credentials)
+		if err != nil {
+			return err
+		}
+		as, err := client.Get(a.ctx, cloud.Azure.ResourceGroup, cloud.Azure.AvailabilitySet)
+		if err != nil {
+			return fmt.Errorf("failed to get availability set %q: %w", cloud.Azure.AvailabilitySet, err)
+		}
+		if merged, changed := mergeTags(as.Tags, tags); changed {
+			if _, err := client.Update(a.ctx, cloud.Azure.ResourceGroup, cloud.Azure.AvailabilitySet, compute.AvailabilitySetUpdate{Tags: merged}); err != nil {
+				return fmt.Errorf("failed to update tags of availability set %q: %w", cloud.Azure.AvailabilitySet, err)
+			}
+		}
+	}
+
+	if kuberneteshelper.HasFinalizer(cluster, FinalizerProximityPlacementGroup) {
+		client, err := getProximityPlacementGroupClient(cloud, credentials)
+		if err != nil {
+			return err
+		}
+		resource, err := autorestazure.ParseResourceID(cloud.Azure.ProximityPlacementGroupID)
+		if err != nil {
+			return fmt.Errorf("failed to parse proximity placement group ID %q: %w", cloud.Azure.ProximityPlacementGroupID, err)
+		}
+		ppg, err := client.Get(a.ctx, resource.ResourceGroup, resource.ResourceName)
+		if err != nil {
+			return fmt.Errorf("failed to get proximity placement group %q: %w", resource.ResourceName, err)
+		}
+		if merged, changed := mergeTags(ppg.Tags, tags); changed {
+			if _, err := client.Update(a.ctx, resource.ResourceGroup, resource.ResourceName, compute.ProximityPlacementGroupUpdate{Tags: merged}); err != nil {
+				return fmt.Errorf("failed to update tags of proximity placement group %q: %w", resource.ResourceName, err)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest/to"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestResourceTags(t *testing.T) {
+	a := &Azure{
+		dc: &kubermaticv1.DatacenterSpecAzure{
+			Tags: map[string]string{"costcenter": "dc", "env": "prod"},
+		},
+		installationID: "kkp",
+	}
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		Spec: kubermaticv1.ClusterSpec{
+			Cloud: kubermaticv1.CloudSpec{
+				Azure: &kubermaticv1.AzureCloudSpec{
+					Tags: map[string]string{"costcenter": "cluster", clusterTagKey: "overridden", installationTagKey: "overridden"},
+				},
+			},
+		},
+	}
+
+	tags := a.resourceTags(cluster)
+
+	expected := map[string]string{
+		"costcenter":       "cluster",
+		"env":              "prod",
+		clusterTagKey:      "test-cluster",
+		managedTagsKey:     "costcenter,env",
+		installationTagKey: "kkp",
+	}
+	if len(tags) != len(expected) {
+		t.Fatalf("expected %d tags, got %d", len(expected), len(tags))
+	}
+	for k, v := range expected {
+		if to.String(tags[k]) != v {
+			t.Errorf("expected tag %q to be %q, got %q", k, v, to.String(tags[k]))
+		}
+	}
+}
+
+func TestMergeTags(t *testing.T) {
+	tests := []struct {
+		name            string
+		existing        map[string]*string
+		desired         map[string]*string
+		expectedChanged bool
+		expectedLen     int
+	}{
+		{
+			name:            "tags already up to date",
+			existing:        map[string]*string{"a": to.StringPtr("1"), "policy": to.StringPtr("x")},
+			desired:         map[string]*string{"a": to.StringPtr("1")},
+			expectedChanged: false,
+			expectedLen:     2,
+		},
+		{
+			name:            "tag value changed",
+			existing:        map[string]*string{"a": to.StringPtr("1")},
+			desired:         map[string]*string{"a": to.StringPtr("2")},
+			expectedChanged: true,
+			expectedLen:     1,
+		},
+		{
+			name:            "tag missing",
+			existing:        nil,
+			desired:         map[string]*string{"a": to.StringPtr("1")},
+			expectedChanged: true,
+			expectedLen:     1,
+		},
+		{
+			name: "managed tag removed from spec",
+			existing: map[string]*string{
+				"a":            to.StringPtr("1"),
+				"b":            to.StringPtr("2"),
+				"policy":       to.StringPtr("x"),
+				managedTagsKey: to.StringPtr("a,b"),
+			},
+			desired:         map[string]*string{"a": to.StringPtr("1"), managedTagsKey: to.StringPtr("a")},
+			expectedChanged: true,
+			expectedLen:     3,
+		},
+		{
+			name:            "unmanaged tag without marker is preserved",
+			existing:        map[string]*string{"a": to.StringPtr("1"), "b": to.StringPtr("2")},
+			desired:         map[string]*string{"a": to.StringPtr("1"), managedTagsKey: to.StringPtr("a")},
+			expectedChanged: true,
+			expectedLen:     3,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			merged, changed := mergeTags(test.existing, test.desired)
+			if changed != test.expectedChanged {
+				t.Fatalf("expected changed to be %v, got %v", test.expectedChanged, changed)
+			}
+			if len(merged) != test.expectedLen {
+				t.Fatalf("expected %d merged tags, got %d", test.expectedLen, len(merged))
+			}
+			for k, v := range test.desired {
+				if to.String(merged[k]) != to.String(v) {
+					t.Errorf("expected tag %q to be %q, got %q", k, to.String(v), to.String(merged[k]))
+				}
+			}
+			if _, ok := merged["b"]; ok && test.existing[managedTagsKey] != nil {
+				t.Error("expected tag \"b\" to be removed")
+			}
+		})
+	}
+}
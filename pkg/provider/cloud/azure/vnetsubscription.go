@@ -0,0 +1,76 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+)
+
+// networkCredentials returns the credentials for the VNet of the cluster and the network
+// resources associated with its subnet, which are bound to the subscription and the tenant of
+// the VNet if it lives in another subscription than the cluster.
+func networkCredentials(cloud kubermaticv1.CloudSpec, credentials Credentials) Credentials {
+	if cloud.Azure.VNetSubscriptionID != "" {
+		credentials.SubscriptionID = cloud.Azure.VNetSubscriptionID
+	}
+	if cloud.Azure.VNetTenantID != "" {
+		credentials.TenantID = cloud.Azure.VNetTenantID
+	}
+
+	return credentials
+}
+
+// usesVNetSubscription returns true if the VNet of the cluster lives in another subscription
+// than the cluster.
+func usesVNetSubscription(cloud kubermaticv1.CloudSpec, subscriptionID string) bool {
+	return cloud.Azure.VNetSubscriptionID != "" && !strings.EqualFold(cloud.Azure.VNetSubscriptionID, subscriptionID)
+}
+
+// validateVNetSubscription checks that a cluster whose VNet lives in another subscription only
+// references existing network resources there. Kubermatic does not create resources in the
+// subscription of the VNet, and Azure only associates security groups, route tables and private
+// endpoints with subnets in their own subscription.
+func validateVNetSubscription(cloud kubermaticv1.CloudSpec, subscriptionID string) error {
+	if !usesVNetSubscription(cloud, subscriptionID) {
+		return nil
+	}
+
+	if cloud.Azure.VNetName == "" || cloud.Azure.SubnetName == "" {
+		return fmt.Errorf("a VNet in subscription %q requires an existing VNet and subnet", cloud.Azure.VNetSubscriptionID)
+	}
+	if cloud.Azure.SecurityGroupID == "" {
+		return fmt.Errorf("a VNet in subscription %q requires a security group referenced by ID", cloud.Azure.VNetSubscriptionID)
+	}
+	if needsRouteTable(cloud) && cloud.Azure.RouteTableID == "" {
+		return fmt.Errorf("a VNet in subscription %q requires a route table referenced by ID", cloud.Azure.VNetSubscriptionID)
+	}
+
+	switch {
+	case len(cloud.Azure.AdditionalSubnets) > 0:
+		return errors.New("additional subnets cannot be created in a VNet of another subscription")
+	case cloud.Azure.EnableBastion:
+		return errors.New("a Bastion host cannot be created in a VNet of another subscription")
+	case cloud.Azure.PrivateCluster || len(cloud.Azure.PrivateEndpoints) > 0:
+		return errors.New("private endpoints cannot be created in a VNet of another subscription")
+	}
+
+	return nil
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+)
+
+const (
+	hubRouteTableID    = "/subscriptions/network-sub/resourceGroups/network-rg/providers/Microsoft.Network/routeTables/spoke-rt"
+	hubSecurityGroupID = "/subscriptions/network-sub/resourceGroups/network-rg/providers/Microsoft.Network/networkSecurityGroups/spoke-nsg"
+)
+
+func TestNetworkCredentials(t *testing.T) {
+	credentials := Credentials{TenantID: "tenant", SubscriptionID: "sub", ClientID: "client", ClientSecret: "secret"}
+
+	tests := []struct {
+		name     string
+		azure    kubermaticv1.AzureCloudSpec
+		expected Credentials
+	}{
+		{
+			name:     "VNet in the subscription of the cluster",
+			azure:    kubermaticv1.AzureCloudSpec{},
+			expected: credentials,
+		},
+		{
+			name:     "VNet in another subscription",
+			azure:    kubermaticv1.AzureCloudSpec{VNetSubscriptionID: "network-sub"},
+			expected: Credentials{TenantID: "tenant", SubscriptionID: "network-sub", ClientID: "client", ClientSecret: "secret"},
+		},
+		{
+			name:     "VNet in another tenant",
+			azure:    kubermaticv1.AzureCloudSpec{VNetSubscriptionID: "network-sub", VNetTenantID: "network-tenant"},
+			expected: Credentials{TenantID: "network-tenant", SubscriptionID: "network-sub", ClientID: "client", ClientSecret: "secret"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			azure := test.azure
+			if actual := networkCredentials(kubermaticv1.CloudSpec{Azure: &azure}, credentials); actual != test.expected {
+				t.Fatalf("expected %+v, got %+v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestAssembleVNetIDInOtherSubscription(t *testing.T) {
+	cloud := kubermaticv1.CloudSpec{Azure: &kubermaticv1.AzureCloudSpec{
+		ResourceGroup:      "cluster-rg",
+		VNetResourceGroup:  "network-rg",
+		VNetName:           "spoke",
+		SubnetName:         "nodes",
+		VNetSubscriptionID: "network-sub",
+	}}
+
+	expected := "/subscriptions/network-sub/resourceGroups/network-rg/providers/Microsoft.Network/virtualNetworks/spoke/subnets/nodes"
+	if id := assembleSubnetIDForSubscription(cloud, "sub"); id != expected {
+		t.Fatalf("expected %q, got %q", expected, id)
+	}
+}
+
+func TestValidateVNetSubscription(t *testing.T) {
+	spoke := func(modify func(*kubermaticv1.AzureCloudSpec)) kubermaticv1.AzureCloudSpec {
+		azure := kubermaticv1.AzureCloudSpec{
+			ResourceGroup:      "cluster-rg",
+			VNetResourceGroup:  "network-rg",
+			VNetName:           "spoke",
+			SubnetName:         "nodes",
+			RouteTableID:       hubRouteTableID,
+			SecurityGroupID:    hubSecurityGroupID,
+			VNetSubscriptionID: "network-sub",
+		}
+		if modify != nil {
+			modify(&azure)
+		}
+		return azure
+	}
+
+	tests := []struct {
+		name    string
+		azure   kubermaticv1.AzureCloudSpec
+		wantErr bool
+	}{
+		{
+			name:  "existing network resources",
+			azure: spoke(nil),
+		},
+		{
+			name: "VNet in the subscription of the cluster",
+			azure: spoke(func(azure *kubermaticv1.AzureCloudSpec) {
+				azure.VNetSubscriptionID = "SUB"
+				azure.SubnetName = ""
+				azure.SecurityGroupID = ""
+			}),
+		},
+		{
+			name:    "subnet created by Kubermatic",
+			azure:   spoke(func(azure *kubermaticv1.AzureCloudSpec) { azure.SubnetName = "" }),
+			wantErr: true,
+		},
+		{
+			name:    "security group created by Kubermatic",
+			azure:   spoke(func(azure *kubermaticv1.AzureCloudSpec) { azure.SecurityGroupID = "" }),
+			wantErr: true,
+		},
+		{
+			name:    "route table created by Kubermatic",
+			azure:   spoke(func(azure *kubermaticv1.AzureCloudSpec) { azure.RouteTableID = "" }),
+			wantErr: true,
+		},
+		{
+			name: "no route table needed with Azure CNI",
+			azure: spoke(func(azure *kubermaticv1.AzureCloudSpec) {
+				azure.RouteTableID = ""
+				azure.NetworkPlugin = kubermaticv1.AzureNetworkPluginAzure
+			}),
+		},
+		{
+			name: "additional subnets",
+			azure: spoke(func(azure *kubermaticv1.AzureCloudSpec) {
+				azure.AdditionalSubnets = []kubermaticv1.AzureSubnet{{Name: "gpu", CIDR: "10.2.0.0/24"}}
+			}),
+			wantErr: true,
+		},
+		{
+			name:    "private cluster",
+			azure:   spoke(func(azure *kubermaticv1.AzureCloudSpec) { azure.PrivateCluster = true }),
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			azure := test.azure
+			if err := validateVNetSubscription(kubermaticv1.CloudSpec{Azure: &azure}, "sub"); (err != nil) != test.wantErr {
+				t.Fatalf("expected error = %v, got %v", test.wantErr, err)
+			}
+		})
+	}
+}
@@ -27,6 +27,7 @@ import (
 
 	"github.com/gophercloud/gophercloud"
 	goopenstack "github.com/gophercloud/gophercloud/openstack"
+	osvolumetypes "github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumetypes"
 	osavailabilityzones "github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/availabilityzones"
 	osflavors "github.com/gophercloud/gophercloud/openstack/compute/v2/flavors"
 	osprojects "github.com/gophercloud/gophercloud/openstack/identity/v3/projects"
@@ -41,6 +42,7 @@ import (
 	"k8c.io/kubermatic/v2/pkg/provider"
 	"k8c.io/kubermatic/v2/pkg/resources"
 
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog"
 )
 
@@ -200,13 +202,12 @@ func (os *Provider) InitializeCloudProvider(cluster *kubermaticv1.Cluster, updat
 	// clean-up is idempotent, if the cluster is deleted when resources associated
 	// to the finalizer are not created yet, it does not fail.
 	// The reason behind is that we have several controllers adding finalizers
-	// to the Cluster resource at the moment, and to avoid race conditions we
-	// need to use optimistic locking and return immediately in case of
-	// conflicts to retry later.
+	// to the Cluster resource at the moment, the updater takes care of
+	// retrying the update in case of conflicts.
 	if len(finalizers) > 0 {
 		cluster, err = update(cluster.Name, func(cluster *kubermaticv1.Cluster) {
 			kubernetes.AddFinalizer(cluster, finalizers...)
-		}, provider.UpdaterOptionOptimisticLock)
+		})
 		if err != nil {
 			return nil, fmt.Errorf("failed to add finalizers: %w", err)
 		}
@@ -383,7 +384,7 @@ func (os *Provider) CleanUpCloudProvider(cluster *kubermaticv1.Cluster, update p
 			RouterCleanupFinalizer,
 			OldNetworkCleanupFinalizer,
 		)
-	}, provider.UpdaterOptionOptimisticLock)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -467,6 +468,58 @@ func GetAvailabilityZones(authURL, region string, credentials *resources.Opensta
 	return availabilityZones, nil
 }
 
+// GetVolumeTypes lists the Cinder volume types for the given CloudSpec.DatacenterName and OpenstackSpec.Region
+func GetVolumeTypes(authURL, region string, credentials *resources.OpenstackCredentials, caBundle *x509.CertPool) ([]osvolumetypes.VolumeType, error) {
+	blockStorageClient, err := getBlockStorageClient(authURL, region, credentials, caBundle)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := osvolumetypes.List(blockStorageClient, osvolumetypes.ListOpts{}).AllPages()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list volume types: %v", err)
+	}
+	volumeTypes, err := osvolumetypes.ExtractVolumeTypes(page)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract volume types: %v", err)
+	}
+	return volumeTypes, nil
+}
+
+// ValidateNodeVolumeSettings checks that the availability zone and the root disk volume type of a
+// node deployment exist in the given region. Empty values are not checked.
+func ValidateNodeVolumeSettings(authURL, region string, credentials *resources.OpenstackCredentials, caBundle *x509.CertPool, availabilityZone, volumeType string) error {
+	if availabilityZone != "" {
+		availabilityZones, err := GetAvailabilityZones(authURL, region, credentials, caBundle)
+		if err != nil {
+			return err
+		}
+		names := sets.NewString()
+		for _, az := range availabilityZones {
+			names.Insert(az.ZoneName)
+		}
+		if !names.Has(availabilityZone) {
+			return fmt.Errorf("availability zone %q does not exist in region %q", availabilityZone, region)
+		}
+	}
+
+	if volumeType != "" {
+		volumeTypes, err := GetVolumeTypes(authURL, region, credentials, caBundle)
+		if err != nil {
+			return err
+		}
+		names := sets.NewString()
+		for _, vt := range volumeTypes {
+			names.Insert(vt.Name)
+		}
+		if !names.Has(volumeType) {
+			return fmt.Errorf("volume type %q does not exist in region %q", volumeType, region)
+		}
+	}
+
+	return nil
+}
+
 func getAuthClient(authURL string, credentials *resources.OpenstackCredentials, caBundle *x509.CertPool) (*gophercloud.ProviderClient, error) {
 	opts := gophercloud.AuthOptions{
 		IdentityEndpoint:            authURL,
@@ -544,6 +597,24 @@ func getComputeClient(authURL, region string, credentials *resources.OpenstackCr
 	return serviceClient, err
 }
 
+func getBlockStorageClient(authURL, region string, credentials *resources.OpenstackCredentials, caBundle *x509.CertPool) (*gophercloud.ServiceClient, error) {
+	authClient, err := getAuthClient(authURL, credentials, caBundle)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceClient, err := goopenstack.NewBlockStorageV3(authClient, gophercloud.EndpointOpts{Region: region})
+	if err != nil {
+		// this is special case for services that span only one region.
+		var endpointNotFound *gophercloud.ErrEndpointNotFound
+		if !errors.As(err, &endpointNotFound) {
+			return nil, err
+		}
+		return goopenstack.NewBlockStorageV3(authClient, gophercloud.EndpointOpts{})
+	}
+	return serviceClient, nil
+}
+
 // GetSubnets list all available subnet ids for a given CloudSpec
 func GetSubnets(authURL, region, networkID string, credentials *resources.OpenstackCredentials, caBundle *x509.CertPool) ([]ossubnets.Subnet, error) {
 	serviceClient, err := getNetClient(authURL, region, credentials, caBundle)
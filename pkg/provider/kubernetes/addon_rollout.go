@@ -0,0 +1,82 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PrivilegedAddonRolloutProvider struct that holds required components in order to manage addon rollouts
+type PrivilegedAddonRolloutProvider struct {
+	clientPrivileged ctrlruntimeclient.Client
+}
+
+// NewAddonRolloutPrivilegedProvider returns an addon rollout provider
+func NewAddonRolloutPrivilegedProvider(client ctrlruntimeclient.Client) *PrivilegedAddonRolloutProvider {
+	return &PrivilegedAddonRolloutProvider{
+		clientPrivileged: client,
+	}
+}
+
+// CreateUnsecured creates an addon rollout
+func (p *PrivilegedAddonRolloutProvider) CreateUnsecured(rollout *kubermaticv1.AddonRollout) (*kubermaticv1.AddonRollout, error) {
+	if err := p.clientPrivileged.Create(context.Background(), rollout); err != nil {
+		return nil, err
+	}
+
+	return rollout, nil
+}
+
+// GetUnsecured gets an addon rollout
+func (p *PrivilegedAddonRolloutProvider) GetUnsecured(name string) (*kubermaticv1.AddonRollout, error) {
+	rollout := &kubermaticv1.AddonRollout{}
+	err := p.clientPrivileged.Get(context.Background(), types.NamespacedName{Name: name}, rollout)
+	return rollout, err
+}
+
+// ListUnsecured lists all addon rollouts
+func (p *PrivilegedAddonRolloutProvider) ListUnsecured() (*kubermaticv1.AddonRolloutList, error) {
+	rollouts := &kubermaticv1.AddonRolloutList{}
+	err := p.clientPrivileged.List(context.Background(), rollouts)
+	return rollouts, err
+}
+
+// PatchUnsecured patches an addon rollout
+func (p *PrivilegedAddonRolloutProvider) PatchUnsecured(rollout *kubermaticv1.AddonRollout) (*kubermaticv1.AddonRollout, error) {
+	oldRollout, err := p.GetUnsecured(rollout.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.clientPrivileged.Patch(context.Background(), rollout, ctrlruntimeclient.MergeFrom(oldRollout)); err != nil {
+		return nil, err
+	}
+
+	return rollout, nil
+}
+
+// DeleteUnsecured deletes an addon rollout
+func (p *PrivilegedAddonRolloutProvider) DeleteUnsecured(name string) error {
+	rollout := &kubermaticv1.AddonRollout{}
+	rollout.Name = name
+	return p.clientPrivileged.Delete(context.Background(), rollout)
+}
@@ -23,8 +23,10 @@ import (
 	"reflect"
 	"strings"
 
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
 	k8cuserclusterclient "k8c.io/kubermatic/v2/pkg/cluster/client"
 	"k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/cloud"
+	kubermaticclientset "k8c.io/kubermatic/v2/pkg/crd/client/clientset/versioned"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
 	"k8c.io/kubermatic/v2/pkg/provider"
@@ -38,6 +40,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	restclient "k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -48,6 +52,7 @@ import (
 // UserClusterConnectionProvider offers functions to interact with an user cluster
 type UserClusterConnectionProvider interface {
 	GetClient(context.Context, *kubermaticv1.Cluster, ...k8cuserclusterclient.ConfigOption) (ctrlruntimeclient.Client, error)
+	GetClientConfig(context.Context, *kubermaticv1.Cluster, ...k8cuserclusterclient.ConfigOption) (*restclient.Config, error)
 }
 
 // extractGroupPrefixFunc is a function that knows how to extract a prefix (owners, editors) from "projectID-owners" group,
@@ -340,6 +345,7 @@ func (p *ClusterProvider) RevokeAdminKubeconfig(c *kubermaticv1.Cluster) error {
 	ctx := context.Background()
 	oldCluster := c.DeepCopy()
 	c.Address.AdminToken = kuberneteshelper.GenerateToken()
+	c.Status.SetCredentialRotationTime(kubermaticv1.AdminTokenCredentialName, metav1.Now())
 	if err := p.GetSeedClusterAdminRuntimeClient().Patch(ctx, c, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
 		return fmt.Errorf("failed to patch cluster with new token: %v", err)
 	}
@@ -353,6 +359,42 @@ func (p *ClusterProvider) GetAdminClientForCustomerCluster(ctx context.Context,
 	return p.userClusterConnProvider.GetClient(ctx, c)
 }
 
+// GetAdminClientConfigForCustomerCluster returns the rest config to talk to the given cluster
+//
+// Note that the config you will get has admin privileges
+func (p *ClusterProvider) GetAdminClientConfigForCustomerCluster(ctx context.Context, c *kubermaticv1.Cluster) (*restclient.Config, error) {
+	return p.userClusterConnProvider.GetClientConfig(ctx, c)
+}
+
+// WatchClusters watches the clusters of the given project in the seed
+//
+// Note that the watch uses admin privileges, callers must make sure the user has access to the project
+func (p *ClusterProvider) WatchClusters(ctx context.Context, projectID string) (watch.Interface, error) {
+	client, err := kubermaticclientset.NewForConfig(p.seedKubeconfig)
+	if err != nil {
+		return nil, err
+	}
+
+	selector := labels.SelectorFromSet(map[string]string{kubermaticv1.ProjectIDLabelKey: projectID})
+	return client.KubermaticV1().Clusters().Watch(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+}
+
+// WatchMachineDeployments watches the machine deployments in the given cluster
+//
+// Note that the watch uses admin privileges, callers must make sure the user has access to the cluster
+func (p *ClusterProvider) WatchMachineDeployments(ctx context.Context, c *kubermaticv1.Cluster) (watch.Interface, error) {
+	cfg, err := p.userClusterConnProvider.GetClientConfig(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	client, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Resource(clusterv1alpha1.SchemeGroupVersion.WithResource("machinedeployments")).Namespace(metav1.NamespaceSystem).Watch(ctx, metav1.ListOptions{})
+}
+
 // GetClientForCustomerCluster returns a client to interact with all resources in the given cluster
 //
 // Note that the client doesn't use admin account instead it authn/authz as userInfo(email, group)
@@ -361,6 +403,13 @@ func (p *ClusterProvider) GetClientForCustomerCluster(ctx context.Context, userI
 	return p.userClusterConnProvider.GetClient(ctx, c, p.withImpersonation(userInfo))
 }
 
+// GetClientConfigForCustomerCluster returns the rest config to talk to the given cluster as userInfo(email, group)
+//
+// Note that the config impersonates the user, so the RBAC rules inside the user cluster apply
+func (p *ClusterProvider) GetClientConfigForCustomerCluster(ctx context.Context, userInfo *provider.UserInfo, c *kubermaticv1.Cluster) (*restclient.Config, error) {
+	return p.userClusterConnProvider.GetClientConfig(ctx, c, p.withImpersonation(userInfo))
+}
+
 func (p *ClusterProvider) GetTokenForCustomerCluster(ctx context.Context, userInfo *provider.UserInfo, cluster *kubermaticv1.Cluster) (string, error) {
 	parts := strings.Split(userInfo.Group, "-")
 	switch parts[0] {
@@ -0,0 +1,106 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ListNamespacesUnsecured returns the namespaces created via the API in the given cluster, sorted by name.
+//
+// Note that the admin privileges are used to list the namespaces
+func (p *ClusterProvider) ListNamespacesUnsecured(cluster *kubermaticv1.Cluster) ([]kubermaticv1.ClusterNamespace, error) {
+	namespaceList := &kubermaticv1.ClusterNamespaceList{}
+	if err := p.client.List(context.Background(), namespaceList, ctrlruntimeclient.MatchingLabels{kubermaticv1.ClusterNamespaceClusterLabelKey: cluster.Name}); err != nil {
+		return nil, fmt.Errorf("failed to list cluster namespaces: %v", err)
+	}
+
+	namespaces := namespaceList.Items
+	sort.Slice(namespaces, func(i, j int) bool {
+		return namespaces[i].Spec.Namespace < namespaces[j].Spec.Namespace
+	})
+	return namespaces, nil
+}
+
+// GetNamespaceUnsecured returns the namespace with the given name created via the API in the cluster.
+//
+// Note that the admin privileges are used to get the namespace
+func (p *ClusterProvider) GetNamespaceUnsecured(cluster *kubermaticv1.Cluster, namespace string) (*kubermaticv1.ClusterNamespace, error) {
+	name := clusterNamespaceName(cluster.Name, namespace)
+	clusterNamespace := &kubermaticv1.ClusterNamespace{}
+	if err := p.client.Get(context.Background(), types.NamespacedName{Name: name}, clusterNamespace); err != nil {
+		return nil, err
+	}
+	if clusterNamespace.Spec.ClusterName != cluster.Name {
+		return nil, kerrors.NewNotFound(schema.GroupResource{}, name)
+	}
+	return clusterNamespace, nil
+}
+
+// CreateNamespaceUnsecured records a new namespace for the cluster, which is then created in the
+// user cluster together with the objects of the given template.
+//
+// Note that the admin privileges are used to create the namespace
+func (p *ClusterProvider) CreateNamespaceUnsecured(cluster *kubermaticv1.Cluster, namespace string, template *kubermaticv1.NamespaceTemplate, createdBy string) (*kubermaticv1.ClusterNamespace, error) {
+	clusterNamespace := &kubermaticv1.ClusterNamespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            clusterNamespaceName(cluster.Name, namespace),
+			Labels:          map[string]string{kubermaticv1.ClusterNamespaceClusterLabelKey: cluster.Name},
+			OwnerReferences: []metav1.OwnerReference{resources.GetClusterRef(cluster)},
+		},
+		Spec: kubermaticv1.ClusterNamespaceSpec{
+			ClusterName: cluster.Name,
+			Namespace:   namespace,
+			CreatedBy:   createdBy,
+		},
+	}
+	if template != nil {
+		clusterNamespace.Spec.Template = template.Name
+		clusterNamespace.Spec.TemplateSpec = *template.Spec.DeepCopy()
+	}
+
+	if err := p.client.Create(context.Background(), clusterNamespace); err != nil {
+		return nil, err
+	}
+	return clusterNamespace, nil
+}
+
+// DeleteNamespaceUnsecured deletes the namespace with the given name from the cluster.
+//
+// Note that the admin privileges are used to delete the namespace
+func (p *ClusterProvider) DeleteNamespaceUnsecured(cluster *kubermaticv1.Cluster, namespace string) error {
+	clusterNamespace, err := p.GetNamespaceUnsecured(cluster, namespace)
+	if err != nil {
+		return err
+	}
+	return p.client.Delete(context.Background(), clusterNamespace)
+}
+
+func clusterNamespaceName(clusterName, namespace string) string {
+	return fmt.Sprintf("%s-%s", clusterName, namespace)
+}
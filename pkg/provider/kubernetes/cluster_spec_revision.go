@@ -0,0 +1,168 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	jsonpatch "github.com/evanphx/json-patch"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// maxClusterSpecRevisions is the number of spec revisions kept per cluster,
+// older revisions are removed when a new one is recorded.
+const maxClusterSpecRevisions = 50
+
+// ListSpecRevisionsUnsecured returns the recorded spec revisions of the given cluster, oldest first.
+//
+// Note that the admin privileges are used to list the revisions
+func (p *ClusterProvider) ListSpecRevisionsUnsecured(cluster *kubermaticv1.Cluster) ([]kubermaticv1.ClusterSpecRevision, error) {
+	revisionList := &kubermaticv1.ClusterSpecRevisionList{}
+	if err := p.client.List(context.Background(), revisionList, ctrlruntimeclient.MatchingLabels{kubermaticv1.ClusterSpecRevisionClusterLabelKey: cluster.Name}); err != nil {
+		return nil, fmt.Errorf("failed to list cluster spec revisions: %v", err)
+	}
+
+	revisions := revisionList.Items
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Spec.Revision < revisions[j].Spec.Revision
+	})
+	return revisions, nil
+}
+
+// GetSpecRevisionUnsecured returns the given spec revision of the cluster.
+//
+// Note that the admin privileges are used to get the revision
+func (p *ClusterProvider) GetSpecRevisionUnsecured(cluster *kubermaticv1.Cluster, revision int64) (*kubermaticv1.ClusterSpecRevision, error) {
+	name := clusterSpecRevisionName(cluster.Name, revision)
+	specRevision := &kubermaticv1.ClusterSpecRevision{}
+	if err := p.client.Get(context.Background(), types.NamespacedName{Name: name}, specRevision); err != nil {
+		return nil, err
+	}
+	if specRevision.Spec.ClusterName != cluster.Name {
+		return nil, kerrors.NewNotFound(schema.GroupResource{}, name)
+	}
+	return specRevision, nil
+}
+
+// RecordSpecRevisionUnsecured records the change from oldCluster to newCluster as a new spec revision
+// authored by the given user, if their specs differ. oldCluster is nil for newly created clusters.
+// If nothing has been recorded for an existing cluster yet, its old spec is recorded first, attributed
+// to the cluster's creator.
+//
+// Note that the admin privileges are used to record the revision
+func (p *ClusterProvider) RecordSpecRevisionUnsecured(oldCluster, newCluster *kubermaticv1.Cluster, author string) error {
+	if oldCluster != nil {
+		diff, err := clusterSpecDiff(oldCluster.Spec, newCluster.Spec)
+		if err != nil {
+			return err
+		}
+		if string(diff) == "{}" {
+			return nil
+		}
+	}
+
+	revisions, err := p.ListSpecRevisionsUnsecured(newCluster)
+	if err != nil {
+		return err
+	}
+
+	if len(revisions) == 0 && oldCluster != nil {
+		initial, err := p.createSpecRevision(newCluster, nil, oldCluster.Spec, oldCluster.Status.UserEmail, oldCluster.CreationTimestamp)
+		if err != nil {
+			return err
+		}
+		revisions = append(revisions, *initial)
+	}
+
+	var previous *kubermaticv1.ClusterSpecRevision
+	if len(revisions) > 0 {
+		previous = &revisions[len(revisions)-1]
+	}
+	if _, err := p.createSpecRevision(newCluster, previous, newCluster.Spec, author, metav1.Now()); err != nil {
+		return err
+	}
+
+	for i := 0; i < len(revisions)+1-maxClusterSpecRevisions; i++ {
+		if err := p.client.Delete(context.Background(), &revisions[i]); err != nil && !kerrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete cluster spec revision %q: %v", revisions[i].Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (p *ClusterProvider) createSpecRevision(cluster *kubermaticv1.Cluster, previous *kubermaticv1.ClusterSpecRevision, spec kubermaticv1.ClusterSpec, author string, timestamp metav1.Time) (*kubermaticv1.ClusterSpecRevision, error) {
+	revision := int64(1)
+	diff := ""
+	if previous != nil {
+		revision = previous.Spec.Revision + 1
+
+		patch, err := clusterSpecDiff(previous.Spec.ClusterSpec, spec)
+		if err != nil {
+			return nil, err
+		}
+		diff = string(patch)
+	}
+
+	specRevision := &kubermaticv1.ClusterSpecRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            clusterSpecRevisionName(cluster.Name, revision),
+			Labels:          map[string]string{kubermaticv1.ClusterSpecRevisionClusterLabelKey: cluster.Name},
+			OwnerReferences: []metav1.OwnerReference{resources.GetClusterRef(cluster)},
+		},
+		Spec: kubermaticv1.ClusterSpecRevisionSpec{
+			ClusterName: cluster.Name,
+			Revision:    revision,
+			Author:      author,
+			Timestamp:   timestamp,
+			Diff:        diff,
+			ClusterSpec: *spec.DeepCopy(),
+		},
+	}
+	if err := p.client.Create(context.Background(), specRevision); err != nil {
+		return nil, fmt.Errorf("failed to create cluster spec revision: %v", err)
+	}
+	return specRevision, nil
+}
+
+// clusterSpecDiff returns the JSON merge patch which turns the old spec into the new one.
+func clusterSpecDiff(oldSpec, newSpec kubermaticv1.ClusterSpec) ([]byte, error) {
+	oldJSON, err := json.Marshal(oldSpec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode cluster spec: %v", err)
+	}
+	newJSON, err := json.Marshal(newSpec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode cluster spec: %v", err)
+	}
+	return jsonpatch.CreateMergePatch(oldJSON, newJSON)
+}
+
+func clusterSpecRevisionName(clusterName string, revision int64) string {
+	return fmt.Sprintf("%s-%d", clusterName, revision)
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"fmt"
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRecordSpecRevisionUnsecured(t *testing.T) {
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+		Spec:       kubermaticv1.ClusterSpec{HumanReadableName: "name-0"},
+		Status:     kubermaticv1.ClusterStatus{UserEmail: "creator@acme.com"},
+	}
+	provider := &ClusterProvider{client: fakectrlruntimeclient.NewClientBuilder().WithObjects(cluster).Build()}
+
+	oldCluster := cluster
+	for i := 1; i <= maxClusterSpecRevisions+5; i++ {
+		newCluster := oldCluster.DeepCopy()
+		newCluster.Spec.HumanReadableName = fmt.Sprintf("name-%d", i)
+		if err := provider.RecordSpecRevisionUnsecured(oldCluster, newCluster, "bob@acme.com"); err != nil {
+			t.Fatalf("failed to record revision: %v", err)
+		}
+		oldCluster = newCluster
+	}
+
+	// recording an unchanged spec is a no-op
+	if err := provider.RecordSpecRevisionUnsecured(oldCluster, oldCluster.DeepCopy(), "bob@acme.com"); err != nil {
+		t.Fatalf("failed to record revision: %v", err)
+	}
+
+	revisions, err := provider.ListSpecRevisionsUnsecured(cluster)
+	if err != nil {
+		t.Fatalf("failed to list revisions: %v", err)
+	}
+	if len(revisions) != maxClusterSpecRevisions {
+		t.Fatalf("expected %d revisions to be kept, got %d", maxClusterSpecRevisions, len(revisions))
+	}
+
+	latest := revisions[len(revisions)-1]
+	if latest.Spec.Revision != maxClusterSpecRevisions+6 {
+		t.Fatalf("expected the latest revision to be %d, got %d", maxClusterSpecRevisions+6, latest.Spec.Revision)
+	}
+	if latest.Spec.Author != "bob@acme.com" || latest.Spec.Diff != fmt.Sprintf(`{"humanReadableName":"name-%d"}`, maxClusterSpecRevisions+5) {
+		t.Fatalf("unexpected latest revision: %+v", latest.Spec)
+	}
+	if revisions[0].Spec.Revision != 7 {
+		t.Fatalf("expected the oldest revisions to be removed, but the oldest kept one is %d", revisions[0].Spec.Revision)
+	}
+
+	revision, err := provider.GetSpecRevisionUnsecured(cluster, 7)
+	if err != nil {
+		t.Fatalf("failed to get revision: %v", err)
+	}
+	if revision.Spec.ClusterSpec.HumanReadableName != "name-6" {
+		t.Fatalf("expected revision 7 to contain the spec after the 6th change, got %q", revision.Spec.ClusterSpec.HumanReadableName)
+	}
+}
@@ -36,7 +36,24 @@ import (
 )
 
 // CreateOrUpdateCredentialSecretForCluster creates a new secret for a credential.
+// The credentials of the additional clouds of the cluster are moved into secrets of their own.
 func CreateOrUpdateCredentialSecretForCluster(ctx context.Context, seedClient ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster) error {
+	if err := createOrUpdateCredentialSecret(ctx, seedClient, cluster); err != nil {
+		return err
+	}
+
+	for i, cloud := range cluster.Spec.AdditionalClouds {
+		additional := cluster.ForAdditionalCloud(cloud)
+		if err := createOrUpdateCredentialSecret(ctx, seedClient, additional); err != nil {
+			return fmt.Errorf("failed to create credential secret for additional cloud %q: %v", cloud.DatacenterName, err)
+		}
+		cluster.Spec.AdditionalClouds[i] = additional.Spec.Cloud
+	}
+
+	return nil
+}
+
+func createOrUpdateCredentialSecret(ctx context.Context, seedClient ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster) error {
 	if cluster.Spec.Cloud.AWS != nil {
 		return createOrUpdateAWSSecret(ctx, seedClient, cluster)
 	}
@@ -0,0 +1,68 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PrivilegedNamespaceTemplateProvider struct that holds required components in order to manage namespace templates
+type PrivilegedNamespaceTemplateProvider struct {
+	clientPrivileged ctrlruntimeclient.Client
+}
+
+// NewNamespaceTemplatePrivilegedProvider returns a namespace template provider
+func NewNamespaceTemplatePrivilegedProvider(client ctrlruntimeclient.Client) *PrivilegedNamespaceTemplateProvider {
+	return &PrivilegedNamespaceTemplateProvider{
+		clientPrivileged: client,
+	}
+}
+
+// CreateUnsecured creates a namespace template
+func (p *PrivilegedNamespaceTemplateProvider) CreateUnsecured(template *kubermaticv1.NamespaceTemplate) (*kubermaticv1.NamespaceTemplate, error) {
+	if err := p.clientPrivileged.Create(context.Background(), template); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// GetUnsecured gets a namespace template
+func (p *PrivilegedNamespaceTemplateProvider) GetUnsecured(name string) (*kubermaticv1.NamespaceTemplate, error) {
+	template := &kubermaticv1.NamespaceTemplate{}
+	err := p.clientPrivileged.Get(context.Background(), types.NamespacedName{Name: name}, template)
+	return template, err
+}
+
+// ListUnsecured lists all namespace templates
+func (p *PrivilegedNamespaceTemplateProvider) ListUnsecured() (*kubermaticv1.NamespaceTemplateList, error) {
+	templates := &kubermaticv1.NamespaceTemplateList{}
+	err := p.clientPrivileged.List(context.Background(), templates)
+	return templates, err
+}
+
+// DeleteUnsecured deletes a namespace template
+func (p *PrivilegedNamespaceTemplateProvider) DeleteUnsecured(name string) error {
+	template := &kubermaticv1.NamespaceTemplate{}
+	template.Name = name
+	return p.clientPrivileged.Delete(context.Background(), template)
+}
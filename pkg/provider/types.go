@@ -24,6 +24,7 @@ import (
 	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
 	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1/helper"
 	ksemver "k8c.io/kubermatic/v2/pkg/semver"
 
 	corev1 "k8s.io/api/core/v1"
@@ -32,6 +33,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 	"k8s.io/client-go/tools/record"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -75,12 +77,98 @@ type CloudProvider interface {
 	ValidateCloudSpecUpdate(oldSpec kubermaticv1.CloudSpec, newSpec kubermaticv1.CloudSpec) error
 }
 
+// ClusterNetworkValidator is implemented by cloud providers which check the pod and service CIDRs
+// of a cluster against the network the nodes are placed in.
+type ClusterNetworkValidator interface {
+	ValidateClusterNetwork(spec kubermaticv1.CloudSpec, clusterNetwork kubermaticv1.ClusterNetworkingConfig) error
+}
+
+// InfrastructureResourceState is the live state of a cloud resource of a cluster.
+type InfrastructureResourceState string
+
+const (
+	// InfrastructureResourceExists means the resource exists and is ready.
+	InfrastructureResourceExists InfrastructureResourceState = "Exists"
+	// InfrastructureResourceProvisioning means the resource is being created or updated.
+	InfrastructureResourceProvisioning InfrastructureResourceState = "Provisioning"
+	// InfrastructureResourceFailed means the last operation on the resource failed.
+	InfrastructureResourceFailed InfrastructureResourceState = "Failed"
+	// InfrastructureResourceDeleted means the resource does not exist (anymore) or is being deleted.
+	InfrastructureResourceDeleted InfrastructureResourceState = "Deleted"
+)
+
+// InfrastructureResource is the live state of a cloud resource used by a cluster, as reported by
+// the cloud provider.
+type InfrastructureResource struct {
+	// Type is the type of the resource, like "vnet" or "securityGroup".
+	Type string
+	// Name is the name of the resource in the cluster spec.
+	Name string
+	// ID is the provider-native identifier of the resource, if it exists.
+	ID string
+	// Managed is true if the resource has been created by Kubermatic.
+	Managed bool
+	State   InfrastructureResourceState
+	Tags    map[string]string
+	// AddressPrefixes are the IP ranges of network resources.
+	AddressPrefixes []string
+	// Drift describes the differences between the resource and the cluster spec.
+	Drift []string
+}
+
+// PlannedInfrastructureAction is the action a cloud provider would take on a resource.
+type PlannedInfrastructureAction string
+
+const (
+	// PlannedInfrastructureCreate means the resource would be created.
+	PlannedInfrastructureCreate PlannedInfrastructureAction = "Create"
+	// PlannedInfrastructureCreateIfMissing means the resource would be created unless a resource
+	// with the same name already exists, which would be used instead.
+	PlannedInfrastructureCreateIfMissing PlannedInfrastructureAction = "CreateIfMissing"
+	// PlannedInfrastructureUpdate means an existing resource, e.g. one specified by the user, would be updated.
+	PlannedInfrastructureUpdate PlannedInfrastructureAction = "Update"
+)
+
+// PlannedInfrastructureResource is a cloud resource which a cloud provider would create or update
+// when initializing a cluster.
+type PlannedInfrastructureResource struct {
+	// Type is the type of the resource, like "vnet" or "securityGroup".
+	Type   string
+	Name   string
+	Action PlannedInfrastructureAction
+	// Parent is the resource the resource is created in, like the resource group.
+	Parent string
+	Tags   map[string]string
+	// AddressPrefixes are the IP ranges of network resources.
+	AddressPrefixes []string
+	// Rules are the firewall rules of security groups.
+	Rules []PlannedSecurityRule
+	// Properties are further provider-specific settings of the resource.
+	Properties map[string]string
+}
+
+// PlannedSecurityRule is a firewall rule of a planned security group.
+type PlannedSecurityRule struct {
+	Name                 string
+	Direction            string
+	Access               string
+	Protocol             string
+	Priority             int32
+	Source               string
+	SourcePortRange      string
+	Destination          string
+	DestinationPortRange string
+}
+
 // UpdaterOption represent an option for the updater function.
 type UpdaterOption string
 
 const (
 	// UpdaterOptionOptimisticLock enables optimistic lock, to fail in case of
 	// potential conflict.
+	//
+	// Deprecated: updaters created by ClusterUpdaterFactory always use an
+	// optimistic lock and retry on conflicts, the option has no effect there.
 	UpdaterOptionOptimisticLock UpdaterOption = "OptimisticLock"
 )
 
@@ -101,9 +189,29 @@ func (c *UpdaterOptions) Apply(opts ...UpdaterOption) *UpdaterOptions {
 	return c
 }
 
-// ClusterUpdater defines a function to persist an update to a cluster
+// ClusterUpdater defines a function to persist an update to a cluster. Do not build your own
+// implementation, use ClusterUpdaterFactory.
 type ClusterUpdater func(string, func(*kubermaticv1.Cluster), ...UpdaterOption) (*kubermaticv1.Cluster, error)
 
+// ClusterUpdaterFactory returns a ClusterUpdater which applies the modification to the latest
+// version of the cluster and persists it with kubermaticv1helper.UpdateCluster, so concurrent
+// changes of other controllers are retried instead of being overwritten.
+func ClusterUpdaterFactory(ctx context.Context, client ctrlruntimeclient.Client) ClusterUpdater {
+	return func(name string, modify func(*kubermaticv1.Cluster), options ...UpdaterOption) (*kubermaticv1.Cluster, error) {
+		// validate the options, none of them changes the behaviour anymore
+		(&UpdaterOptions{}).Apply(options...)
+
+		cluster := &kubermaticv1.Cluster{}
+		if err := client.Get(ctx, types.NamespacedName{Name: name}, cluster); err != nil {
+			return nil, err
+		}
+		if err := kubermaticv1helper.UpdateCluster(ctx, client, cluster, modify); err != nil {
+			return nil, err
+		}
+		return cluster, nil
+	}
+}
+
 // ClusterListOptions allows to set filters that will be applied to filter the result.
 type ClusterListOptions struct {
 	// ClusterSpecName gets the clusters with the given name in the spec
@@ -209,11 +317,31 @@ type ClusterProvider interface {
 	// Note that the client you will get has admin privileges
 	GetAdminClientForCustomerCluster(context.Context, *kubermaticv1.Cluster) (ctrlruntimeclient.Client, error)
 
+	// GetAdminClientConfigForCustomerCluster returns the rest config to talk to the given cluster
+	//
+	// Note that the config you will get has admin privileges
+	GetAdminClientConfigForCustomerCluster(context.Context, *kubermaticv1.Cluster) (*restclient.Config, error)
+
+	// WatchClusters watches the clusters of the given project in the seed
+	//
+	// Note that the watch uses admin privileges, callers must make sure the user has access to the project
+	WatchClusters(ctx context.Context, projectID string) (watch.Interface, error)
+
+	// WatchMachineDeployments watches the machine deployments in the given cluster
+	//
+	// Note that the watch uses admin privileges, callers must make sure the user has access to the cluster
+	WatchMachineDeployments(context.Context, *kubermaticv1.Cluster) (watch.Interface, error)
+
 	// GetClientForCustomerCluster returns a client to interact with all resources in the given cluster
 	//
 	// Note that the client doesn't use admin account instead it authn/authz as userInfo(email, group)
 	GetClientForCustomerCluster(context.Context, *UserInfo, *kubermaticv1.Cluster) (ctrlruntimeclient.Client, error)
 
+	// GetClientConfigForCustomerCluster returns the rest config to talk to the given cluster
+	//
+	// Note that the config doesn't use admin account instead it authn/authz as userInfo(email, group)
+	GetClientConfigForCustomerCluster(context.Context, *UserInfo, *kubermaticv1.Cluster) (*restclient.Config, error)
+
 	// GetTokenForCustomerCluster returns a token for the given cluster with permissions granted to group that
 	// user belongs to.
 	GetTokenForCustomerCluster(context.Context, *UserInfo, *kubermaticv1.Cluster) (string, error)
@@ -254,6 +382,43 @@ type PrivilegedClusterProvider interface {
 	//
 	// Note that the admin privileges are used to create cluster
 	NewUnsecured(project *kubermaticv1.Project, cluster *kubermaticv1.Cluster, userEmail string) (*kubermaticv1.Cluster, error)
+
+	// ListSpecRevisionsUnsecured returns the recorded spec revisions of the given cluster, oldest first.
+	//
+	// Note that the admin privileges are used to list the revisions
+	ListSpecRevisionsUnsecured(cluster *kubermaticv1.Cluster) ([]kubermaticv1.ClusterSpecRevision, error)
+
+	// GetSpecRevisionUnsecured returns the given spec revision of the cluster.
+	//
+	// Note that the admin privileges are used to get the revision
+	GetSpecRevisionUnsecured(cluster *kubermaticv1.Cluster, revision int64) (*kubermaticv1.ClusterSpecRevision, error)
+
+	// RecordSpecRevisionUnsecured records the change from oldCluster to newCluster as a new spec revision
+	// authored by the given user. oldCluster is nil for newly created clusters.
+	//
+	// Note that the admin privileges are used to record the revision
+	RecordSpecRevisionUnsecured(oldCluster, newCluster *kubermaticv1.Cluster, author string) error
+
+	// ListNamespacesUnsecured returns the namespaces created via the API in the given cluster, sorted by name.
+	//
+	// Note that the admin privileges are used to list the namespaces
+	ListNamespacesUnsecured(cluster *kubermaticv1.Cluster) ([]kubermaticv1.ClusterNamespace, error)
+
+	// GetNamespaceUnsecured returns the namespace with the given name created via the API in the cluster.
+	//
+	// Note that the admin privileges are used to get the namespace
+	GetNamespaceUnsecured(cluster *kubermaticv1.Cluster, namespace string) (*kubermaticv1.ClusterNamespace, error)
+
+	// CreateNamespaceUnsecured records a new namespace for the cluster, which is then created in the
+	// user cluster together with the objects of the given template. The template is optional.
+	//
+	// Note that the admin privileges are used to create the namespace
+	CreateNamespaceUnsecured(cluster *kubermaticv1.Cluster, namespace string, template *kubermaticv1.NamespaceTemplate, createdBy string) (*kubermaticv1.ClusterNamespace, error)
+
+	// DeleteNamespaceUnsecured deletes the namespace with the given name from the cluster.
+	//
+	// Note that the admin privileges are used to delete the namespace
+	DeleteNamespaceUnsecured(cluster *kubermaticv1.Cluster, namespace string) error
 }
 
 // SSHKeyListOptions allows to set filters that will be applied to filter the result.
@@ -474,6 +639,48 @@ func ClusterCloudProviderName(spec kubermaticv1.CloudSpec) (string, error) {
 	return clouds[0], nil
 }
 
+// NodeCloudProviderName returns the provider name for the given NodeCloudSpec.
+func NodeCloudProviderName(spec apiv1.NodeCloudSpec) (string, error) {
+	var clouds []string
+	if spec.AWS != nil {
+		clouds = append(clouds, AWSCloudProvider)
+	}
+	if spec.Azure != nil {
+		clouds = append(clouds, AzureCloudProvider)
+	}
+	if spec.Digitalocean != nil {
+		clouds = append(clouds, DigitaloceanCloudProvider)
+	}
+	if spec.Openstack != nil {
+		clouds = append(clouds, OpenstackCloudProvider)
+	}
+	if spec.Packet != nil {
+		clouds = append(clouds, PacketCloudProvider)
+	}
+	if spec.Hetzner != nil {
+		clouds = append(clouds, HetznerCloudProvider)
+	}
+	if spec.VSphere != nil {
+		clouds = append(clouds, VSphereCloudProvider)
+	}
+	if spec.GCP != nil {
+		clouds = append(clouds, GCPCloudProvider)
+	}
+	if spec.Kubevirt != nil {
+		clouds = append(clouds, KubevirtCloudProvider)
+	}
+	if spec.Alibaba != nil {
+		clouds = append(clouds, AlibabaCloudProvider)
+	}
+	if spec.Anexia != nil {
+		clouds = append(clouds, AnexiaCloudProvider)
+	}
+	if len(clouds) != 1 {
+		return "", fmt.Errorf("exactly one cloud provider has to be set in NodeCloudSpec")
+	}
+	return clouds[0], nil
+}
+
 // ClusterCloudProvider returns the provider for the given cluster where
 // one of Cluster.Spec.Cloud.* is set.
 func ClusterCloudProvider(cps map[string]CloudProvider, c *kubermaticv1.Cluster) (string, CloudProvider, error) {
@@ -1118,3 +1325,63 @@ type PrivilegedEtcdBackupConfigProvider interface {
 	// is unsafe in a sense that it uses privileged account to patch the resource
 	PatchUnsecured(old, new *kubermaticv1.EtcdBackupConfig) (*kubermaticv1.EtcdBackupConfig, error)
 }
+
+// PrivilegedAddonRolloutProvider declares the set of method for interacting with addon rollouts
+type PrivilegedAddonRolloutProvider interface {
+	// CreateUnsecured creates the given addon rollout
+	//
+	// Note that this function:
+	// is unsafe in a sense that it uses privileged account to create the resource
+	CreateUnsecured(rollout *kubermaticv1.AddonRollout) (*kubermaticv1.AddonRollout, error)
+
+	// GetUnsecured gets the addon rollout with the given name
+	//
+	// Note that this function:
+	// is unsafe in a sense that it uses privileged account to get the resource
+	GetUnsecured(name string) (*kubermaticv1.AddonRollout, error)
+
+	// ListUnsecured gets a list of all addon rollouts
+	//
+	// Note that this function:
+	// is unsafe in a sense that it uses privileged account to get the resources
+	ListUnsecured() (*kubermaticv1.AddonRolloutList, error)
+
+	// PatchUnsecured patches an addon rollout
+	//
+	// Note that this function:
+	// is unsafe in a sense that it uses privileged account to update the resource
+	PatchUnsecured(rollout *kubermaticv1.AddonRollout) (*kubermaticv1.AddonRollout, error)
+
+	// DeleteUnsecured deletes the addon rollout with the given name
+	//
+	// Note that this function:
+	// is unsafe in a sense that it uses privileged account to delete the resource
+	DeleteUnsecured(name string) error
+}
+
+// PrivilegedNamespaceTemplateProvider declares the set of method for interacting with namespace templates
+type PrivilegedNamespaceTemplateProvider interface {
+	// CreateUnsecured creates the given namespace template
+	//
+	// Note that this function:
+	// is unsafe in a sense that it uses privileged account to create the resource
+	CreateUnsecured(template *kubermaticv1.NamespaceTemplate) (*kubermaticv1.NamespaceTemplate, error)
+
+	// GetUnsecured gets the namespace template with the given name
+	//
+	// Note that this function:
+	// is unsafe in a sense that it uses privileged account to get the resource
+	GetUnsecured(name string) (*kubermaticv1.NamespaceTemplate, error)
+
+	// ListUnsecured gets a list of all namespace templates
+	//
+	// Note that this function:
+	// is unsafe in a sense that it uses privileged account to get the resources
+	ListUnsecured() (*kubermaticv1.NamespaceTemplateList, error)
+
+	// DeleteUnsecured deletes the namespace template with the given name
+	//
+	// Note that this function:
+	// is unsafe in a sense that it uses privileged account to delete the resource
+	DeleteUnsecured(name string) error
+}
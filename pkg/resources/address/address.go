@@ -118,9 +118,13 @@ func (m *ModifiersBuilder) Build(ctx context.Context) ([]func(*kubermaticv1.Clus
 		}
 	}
 
+	// Private Azure clusters are reached via a private endpoint in the cluster's VNet, whose
+	// address is resolved by a private DNS zone, so a DNS name is used for the external name.
+	privateCluster := resources.IsAzurePrivateCluster(m.cluster)
+
 	// External Name
 	externalName := ""
-	if m.cluster.Spec.ExposeStrategy == kubermaticv1.ExposeStrategyLoadBalancer {
+	if m.cluster.Spec.ExposeStrategy == kubermaticv1.ExposeStrategyLoadBalancer && !privateCluster {
 		externalName = frontProxyLoadBalancerServiceIP
 	} else {
 		externalName = fmt.Sprintf("%s.%s.%s", m.cluster.Name, subdomain, m.externalURL)
@@ -147,17 +151,19 @@ func (m *ModifiersBuilder) Build(ctx context.Context) ([]func(*kubermaticv1.Clus
 	// When using the Tunneling expose strategy we disable KAS endpoints
 	// reconciliation, and we reconcile them with the agent IPs in the user
 	// controller manager.
-	switch m.cluster.Spec.ExposeStrategy {
-	case kubermaticv1.ExposeStrategyLoadBalancer:
+	switch {
+	case privateCluster:
+		ip = m.cluster.Spec.Cloud.Azure.PrivateEndpointIP
+	case m.cluster.Spec.ExposeStrategy == kubermaticv1.ExposeStrategyLoadBalancer:
 		ip = frontProxyLoadBalancerServiceIP
-	case kubermaticv1.ExposeStrategyNodePort:
+	case m.cluster.Spec.ExposeStrategy == kubermaticv1.ExposeStrategyNodePort:
 		var err error
 		// Always lookup IP address, in case it changes (IP's on AWS LB's change)
 		ip, err = m.getExternalIPv4(externalName)
 		if err != nil {
 			return nil, err
 		}
-	case kubermaticv1.ExposeStrategyTunneling:
+	case m.cluster.Spec.ExposeStrategy == kubermaticv1.ExposeStrategyTunneling:
 		ip = m.tunnelingAgentIP
 	}
 	if m.cluster.Address.IP != ip {
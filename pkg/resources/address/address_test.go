@@ -73,6 +73,7 @@ func TestSyncClusterAddress(t *testing.T) {
 		apiserverService     corev1.Service
 		frontproxyService    corev1.Service
 		exposeStrategy       kubermaticv1.ExposeStrategy
+		azure                *kubermaticv1.AzureCloudSpec
 		seedDNSOverwrite     string
 		expectedExternalName string
 		expectedIP           string
@@ -161,6 +162,30 @@ func TestSyncClusterAddress(t *testing.T) {
 			expectedPort:         int32(32000),
 			expectedURL:          fmt.Sprintf("https://%s.alias-europe-west3-c.%s:32000", fakeClusterName, fakeExternalURL),
 		},
+		{
+			name: "Verify properties for private Azure cluster",
+			apiserverService: corev1.Service{
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{{NodePort: int32(443)}},
+				},
+			},
+			frontproxyService: corev1.Service{
+				Status: corev1.ServiceStatus{
+					LoadBalancer: corev1.LoadBalancerStatus{
+						Ingress: []corev1.LoadBalancerIngress{{IP: "10.1.0.4"}},
+					},
+				},
+			},
+			exposeStrategy: kubermaticv1.ExposeStrategyLoadBalancer,
+			azure: &kubermaticv1.AzureCloudSpec{
+				PrivateCluster:    true,
+				PrivateEndpointIP: "10.0.0.5",
+			},
+			expectedExternalName: fmt.Sprintf("%s.%s.%s", fakeClusterName, fakeDCName, fakeExternalURL),
+			expectedIP:           "10.0.0.5",
+			expectedPort:         int32(443),
+			expectedURL:          fmt.Sprintf("https://%s.%s.%s:443", fakeClusterName, fakeDCName, fakeExternalURL),
+		},
 		{
 			name: "Verify error when service has less than one ports",
 			apiserverService: corev1.Service{
@@ -180,6 +205,7 @@ func TestSyncClusterAddress(t *testing.T) {
 				Spec: kubermaticv1.ClusterSpec{
 					Cloud: kubermaticv1.CloudSpec{
 						DatacenterName: fakeDCName,
+						Azure:          tc.azure,
 					},
 					ExposeStrategy: tc.exposeStrategy,
 				},
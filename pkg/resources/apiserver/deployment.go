@@ -89,6 +89,12 @@ func DeploymentCreator(data *resources.TemplateData, enableOIDCAuthentication bo
 			volumes := getVolumes()
 			volumeMounts := getVolumeMounts()
 
+			encryptionEnabled := IsEncryptionEnabled(data.Cluster())
+			if encryptionEnabled {
+				volumes = append(volumes, encryptionVolumes()...)
+				volumeMounts = append(volumeMounts, encryptionVolumeMounts()...)
+			}
+
 			podLabels, err := data.GetPodTemplateLabels(name, volumes, nil)
 			if err != nil {
 				return nil, err
@@ -223,6 +229,10 @@ func DeploymentCreator(data *resources.TemplateData, enableOIDCAuthentication bo
 				)
 			}
 
+			if encryptionEnabled {
+				dep.Spec.Template.Spec.Containers = append(dep.Spec.Template.Spec.Containers, azureKMSContainer(data))
+			}
+
 			dep.Spec.Template.Spec.Affinity = resources.HostnameAntiAffinity(name, data.Cluster().Name)
 
 			return dep, nil
@@ -322,6 +332,10 @@ func getApiserverFlags(data *resources.TemplateData, etcdEndpoints []string, ena
 		flags = append(flags, "--audit-policy-file", "/etc/kubernetes/audit/policy.yaml")
 	}
 
+	if IsEncryptionEnabled(data.Cluster()) {
+		flags = append(flags, "--encryption-provider-config", fmt.Sprintf("%s/%s", encryptionConfigurationPath, encryptionConfigurationFileName))
+	}
+
 	if *overrideFlags.EndpointReconcilingDisabled {
 		flags = append(flags, "--endpoint-reconciler-type=none")
 	}
@@ -0,0 +1,210 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v2"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/reconciling"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	encryptionConfigurationFileName = "encryption-configuration.yaml"
+	encryptionConfigurationPath     = "/etc/kubernetes/encryption"
+
+	azureKMSContainerName = "azure-kms"
+	azureKMSImage         = "oss/azure/kms/keyvault:v0.0.10"
+	azureKMSProviderName  = "azurekmsprovider"
+	azureKMSSocketVolume  = "azure-kms-socket"
+	azureKMSSocketPath    = "/opt/kms"
+	azureKMSHealthzPort   = 8787
+)
+
+// EncryptionConfiguration configures how the apiserver encrypts resources in etcd.
+type EncryptionConfiguration struct {
+	Kind       string `yaml:"kind"`
+	APIVersion string `yaml:"apiVersion"`
+
+	Resources []EncryptionResourceConfiguration `yaml:"resources"`
+}
+
+// EncryptionResourceConfiguration configures the providers used to encrypt a set of resources.
+// The first provider is used to encrypt, all of them are tried to decrypt.
+type EncryptionResourceConfiguration struct {
+	Resources []string             `yaml:"resources"`
+	Providers []EncryptionProvider `yaml:"providers"`
+}
+
+// EncryptionProvider is either a KMS provider or the identity provider for unencrypted data.
+type EncryptionProvider struct {
+	KMS      *KMSConfiguration `yaml:"kms,omitempty"`
+	Identity *struct{}         `yaml:"identity,omitempty"`
+}
+
+// KMSConfiguration configures a KMS plugin listening on a unix socket.
+type KMSConfiguration struct {
+	Name      string `yaml:"name"`
+	Endpoint  string `yaml:"endpoint"`
+	CacheSize int32  `yaml:"cachesize,omitempty"`
+	Timeout   string `yaml:"timeout,omitempty"`
+}
+
+// IsEncryptionEnabled returns whether the secrets of the cluster are encrypted with a KMS provider.
+func IsEncryptionEnabled(cluster *kubermaticv1.Cluster) bool {
+	return cluster.Spec.Cloud.Azure != nil && cluster.Spec.Cloud.Azure.KMS != nil
+}
+
+// EncryptionConfigurationCreator returns the configuration for the encryption at rest of the
+// secrets of the user cluster. Data written before the encryption was enabled stays readable.
+func EncryptionConfigurationCreator() reconciling.NamedConfigMapCreatorGetter {
+	return func() (string, reconciling.ConfigMapCreator) {
+		return resources.EncryptionConfigurationConfigMapName, func(cm *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+			if cm.Data == nil {
+				cm.Data = map[string]string{}
+			}
+
+			config := EncryptionConfiguration{
+				Kind:       "EncryptionConfiguration",
+				APIVersion: "apiserver.config.k8s.io/v1",
+				Resources: []EncryptionResourceConfiguration{
+					{
+						Resources: []string{"secrets"},
+						Providers: []EncryptionProvider{
+							{
+								KMS: &KMSConfiguration{
+									Name:      azureKMSProviderName,
+									Endpoint:  fmt.Sprintf("unix://%s/azurekms.socket", azureKMSSocketPath),
+									CacheSize: 1000,
+									Timeout:   "3s",
+								},
+							},
+							{
+								Identity: &struct{}{},
+							},
+						},
+					},
+				},
+			}
+
+			rawConfig, err := yaml.Marshal(config)
+			if err != nil {
+				return nil, err
+			}
+
+			cm.Data[encryptionConfigurationFileName] = string(rawConfig)
+
+			return cm, nil
+		}
+	}
+}
+
+// azureKMSContainer returns the sidecar running the Azure Key Vault KMS plugin, which encrypts
+// and decrypts the data encryption keys of the apiserver with the key in the key vault. It
+// authenticates with the credentials from the cloud config.
+func azureKMSContainer(data *resources.TemplateData) corev1.Container {
+	kms := data.Cluster().Spec.Cloud.Azure.KMS
+
+	return corev1.Container{
+		Name:  azureKMSContainerName,
+		Image: data.ImageRegistry(resources.RegistryMCR) + "/" + azureKMSImage,
+		Args: []string{
+			fmt.Sprintf("--keyvault-name=%s", kms.KeyVaultName),
+			fmt.Sprintf("--key-name=%s", kms.KeyName),
+			fmt.Sprintf("--key-version=%s", kms.KeyVersion),
+			fmt.Sprintf("--listen-addr=unix://%s/azurekms.socket", azureKMSSocketPath),
+			fmt.Sprintf("--config-file-path=/etc/kubernetes/cloud/%s", resources.CloudConfigConfigMapKey),
+			fmt.Sprintf("--healthz-port=%d", azureKMSHealthzPort),
+			"--healthz-path=/healthz",
+			"--log-format-json",
+		},
+		LivenessProbe: &corev1.Probe{
+			Handler: corev1.Handler{
+				HTTPGet: &corev1.HTTPGetAction{
+					Path: "/healthz",
+					Port: intstr.FromInt(azureKMSHealthzPort),
+				},
+			},
+			FailureThreshold: 3,
+			PeriodSeconds:    10,
+			TimeoutSeconds:   10,
+		},
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("32Mi"),
+				corev1.ResourceCPU:    resource.MustParse("10m"),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("128Mi"),
+				corev1.ResourceCPU:    resource.MustParse("100m"),
+			},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      resources.CloudConfigConfigMapName,
+				MountPath: "/etc/kubernetes/cloud",
+				ReadOnly:  true,
+			},
+			{
+				Name:      azureKMSSocketVolume,
+				MountPath: azureKMSSocketPath,
+			},
+		},
+	}
+}
+
+func encryptionVolumes() []corev1.Volume {
+	return []corev1.Volume{
+		{
+			Name: resources.EncryptionConfigurationConfigMapName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: resources.EncryptionConfigurationConfigMapName,
+					},
+				},
+			},
+		},
+		{
+			Name: azureKMSSocketVolume,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		},
+	}
+}
+
+func encryptionVolumeMounts() []corev1.VolumeMount {
+	return []corev1.VolumeMount{
+		{
+			Name:      resources.EncryptionConfigurationConfigMapName,
+			MountPath: encryptionConfigurationPath,
+			ReadOnly:  true,
+		},
+		{
+			Name:      azureKMSSocketVolume,
+			MountPath: azureKMSSocketPath,
+		},
+	}
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v2"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestEncryptionConfigurationCreator(t *testing.T) {
+	_, creator := EncryptionConfigurationCreator()()
+	cm, err := creator(&corev1.ConfigMap{})
+	if err != nil {
+		t.Fatalf("failed to create configmap: %v", err)
+	}
+
+	config := EncryptionConfiguration{}
+	if err := yaml.Unmarshal([]byte(cm.Data[encryptionConfigurationFileName]), &config); err != nil {
+		t.Fatalf("failed to decode encryption configuration: %v", err)
+	}
+
+	if len(config.Resources) != 1 || len(config.Resources[0].Providers) != 2 {
+		t.Fatalf("expected one resource with two providers, got %+v", config.Resources)
+	}
+	providers := config.Resources[0].Providers
+	if providers[0].KMS == nil || providers[0].KMS.Endpoint != "unix:///opt/kms/azurekms.socket" {
+		t.Errorf("expected the KMS plugin to be the first provider, got %+v", providers[0])
+	}
+	if providers[1].Identity == nil {
+		t.Errorf("expected existing unencrypted data to stay readable, got %+v", providers[1])
+	}
+}
@@ -30,6 +30,7 @@ import (
 	vsphere "github.com/kubermatic/machine-controller/pkg/cloudprovider/provider/vsphere/types"
 	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	azureprovider "k8c.io/kubermatic/v2/pkg/provider/cloud/azure"
 	gcp "k8c.io/kubermatic/v2/pkg/provider/cloud/gcp"
 	"k8c.io/kubermatic/v2/pkg/resources"
 	"k8c.io/kubermatic/v2/pkg/resources/reconciling"
@@ -120,7 +121,12 @@ func CloudConfig(
 			UseInstanceMetadata:        false,
 			LoadBalancerSku:            string(cloud.Azure.LoadBalancerSKU),
 		}
-		cloudConfig, err = azure.CloudConfigToString(azureCloudConfig)
+		// with Azure CNI the pods are reachable without routes, so the route table, which
+		// only exists for user-defined routes then, must not be managed by the cloud provider
+		if cloud.Azure.NetworkPlugin == kubermaticv1.AzureNetworkPluginAzure {
+			azureCloudConfig.RouteTableName = ""
+		}
+		cloudConfig, err = azureCloudConfigToString(cloud, azureCloudConfig)
 		if err != nil {
 			return cloudConfig, err
 		}
@@ -302,3 +308,31 @@ const (
 	FakeVMWareUUIDKeyName = "fakeVmwareUUID"
 	fakeVMWareUUID        = "VMware-42 00 00 00 00 00 00 00-00 00 00 00 00 00 00 00"
 )
+
+// azureCloudConfigToString adds the resource groups of route tables and security groups referenced
+// by ID and the subscription and tenant of the network resources to the cloud config, which the
+// machine-controller types do not support yet.
+func azureCloudConfigToString(cloud kubermaticv1.CloudSpec, c *azure.CloudConfig) (string, error) {
+	if cloud.Azure.RouteTableID == "" && cloud.Azure.SecurityGroupID == "" && cloud.Azure.VNetSubscriptionID == "" {
+		return azure.CloudConfigToString(c)
+	}
+
+	b, err := json.Marshal(struct {
+		*azure.CloudConfig
+		RouteTableResourceGroup       string `json:"routeTableResourceGroup,omitempty"`
+		SecurityGroupResourceGroup    string `json:"securityGroupResourceGroup,omitempty"`
+		NetworkResourceSubscriptionID string `json:"networkResourceSubscriptionID,omitempty"`
+		NetworkResourceTenantID       string `json:"networkResourceTenantID,omitempty"`
+	}{
+		CloudConfig:                   c,
+		RouteTableResourceGroup:       azureprovider.RouteTableResourceGroup(cloud),
+		SecurityGroupResourceGroup:    azureprovider.SecurityGroupResourceGroup(cloud),
+		NetworkResourceSubscriptionID: cloud.Azure.VNetSubscriptionID,
+		NetworkResourceTenantID:       cloud.Azure.VNetTenantID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal config: %v", err)
+	}
+
+	return string(b), nil
+}
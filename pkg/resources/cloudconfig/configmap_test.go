@@ -17,6 +17,7 @@ limitations under the License.
 package cloudconfig
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/go-test/deep"
@@ -308,3 +309,77 @@ func unmarshalINICloudConfig(t *testing.T, config interface{}, rawConfig string)
 		t.Fatalf("error occurred while marshaling config: %v", err)
 	}
 }
+
+func TestAzureCloudConfigReferencedResources(t *testing.T) {
+	cluster := &kubermaticv1.Cluster{
+		Spec: kubermaticv1.ClusterSpec{
+			Cloud: kubermaticv1.CloudSpec{
+				Azure: &kubermaticv1.AzureCloudSpec{
+					ResourceGroup:  "cluster-rg",
+					RouteTableName: "shared-rt",
+					RouteTableID:   "/subscriptions/sub/resourceGroups/network-rg/providers/Microsoft.Network/routeTables/shared-rt",
+					SecurityGroup:  "kubernetes-abc",
+				},
+			},
+		},
+	}
+	dc := &kubermaticv1.Datacenter{
+		Spec: kubermaticv1.DatacenterSpec{
+			Azure: &kubermaticv1.DatacenterSpecAzure{Location: "westeurope"},
+		},
+	}
+
+	cloudConfig, err := CloudConfig(cluster, dc, resources.Credentials{})
+	if err != nil {
+		t.Fatalf("Error trying to get cloud-config: %v", err)
+	}
+
+	actual := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(cloudConfig), &actual); err != nil {
+		t.Fatalf("failed to parse cloud-config: %v", err)
+	}
+	if actual["routeTableName"] != "shared-rt" || actual["routeTableResourceGroup"] != "network-rg" {
+		t.Errorf("expected the route table to be looked up in its own resource group, got %v", actual)
+	}
+	if actual["securityGroupResourceGroup"] != "cluster-rg" {
+		t.Errorf("expected the security group to be looked up in the cluster resource group, got %v", actual)
+	}
+}
+
+func TestAzureCloudConfigVNetSubscription(t *testing.T) {
+	cluster := &kubermaticv1.Cluster{
+		Spec: kubermaticv1.ClusterSpec{
+			Cloud: kubermaticv1.CloudSpec{
+				Azure: &kubermaticv1.AzureCloudSpec{
+					ResourceGroup:      "cluster-rg",
+					VNetResourceGroup:  "network-rg",
+					VNetName:           "hub-spoke",
+					SubnetName:         "nodes",
+					VNetSubscriptionID: "network-sub",
+					VNetTenantID:       "network-tenant",
+				},
+			},
+		},
+	}
+	dc := &kubermaticv1.Datacenter{
+		Spec: kubermaticv1.DatacenterSpec{
+			Azure: &kubermaticv1.DatacenterSpecAzure{Location: "westeurope"},
+		},
+	}
+
+	cloudConfig, err := CloudConfig(cluster, dc, resources.Credentials{})
+	if err != nil {
+		t.Fatalf("Error trying to get cloud-config: %v", err)
+	}
+
+	actual := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(cloudConfig), &actual); err != nil {
+		t.Fatalf("failed to parse cloud-config: %v", err)
+	}
+	if actual["networkResourceSubscriptionID"] != "network-sub" || actual["networkResourceTenantID"] != "network-tenant" {
+		t.Errorf("expected the network resources to be looked up in the subscription of the VNet, got %v", actual)
+	}
+	if actual["vnetResourceGroup"] != "network-rg" {
+		t.Errorf("expected the VNet to be looked up in its own resource group, got %v", actual)
+	}
+}
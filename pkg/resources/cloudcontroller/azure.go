@@ -0,0 +1,168 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudcontroller
+
+import (
+	"fmt"
+
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/reconciling"
+	"k8c.io/kubermatic/v2/pkg/resources/vpnsidecar"
+	"k8c.io/kubermatic/v2/pkg/semver"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+const (
+	azureCCMDeploymentName = "azure-cloud-controller-manager"
+)
+
+var (
+	azureResourceRequirements = corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("128Mi"),
+			corev1.ResourceCPU:    resource.MustParse("100m"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("512Mi"),
+			corev1.ResourceCPU:    resource.MustParse("1"),
+		},
+	}
+)
+
+func azureDeploymentCreator(data *resources.TemplateData) reconciling.NamedDeploymentCreatorGetter {
+	return func() (string, reconciling.DeploymentCreator) {
+		return azureCCMDeploymentName, func(dep *appsv1.Deployment) (*appsv1.Deployment, error) {
+			dep.Name = azureCCMDeploymentName
+			dep.Labels = resources.BaseAppLabels(azureCCMDeploymentName, nil)
+
+			dep.Spec.Selector = &metav1.LabelSelector{
+				MatchLabels: resources.BaseAppLabels(azureCCMDeploymentName, nil),
+			}
+
+			podLabels, err := data.GetPodTemplateLabels(azureCCMDeploymentName, dep.Spec.Template.Spec.Volumes, map[string]string{
+				"component": "cloud-controller-manager",
+				"tier":      "control-plane",
+			})
+			if err != nil {
+				return nil, err
+			}
+			dep.Spec.Template.ObjectMeta = metav1.ObjectMeta{
+				Labels: podLabels,
+			}
+
+			dep.Spec.Template.Spec.DNSPolicy, dep.Spec.Template.Spec.DNSConfig, err =
+				resources.UserClusterDNSPolicyAndConfig(data)
+			if err != nil {
+				return nil, err
+			}
+			dep.Spec.Template.Spec.AutomountServiceAccountToken = pointer.BoolPtr(false)
+
+			version, err := getAzureCCMVersion(data.Cluster().Spec.Version)
+			if err != nil {
+				return nil, err
+			}
+			openvpnSidecar, err := vpnsidecar.OpenVPNSidecarContainer(data, openvpnClientContainerName)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get openvpn sidecar: %v", err)
+			}
+			dep.Spec.Template.Spec.Containers = []corev1.Container{
+				getAzureCCMContainer(version, data),
+				*openvpnSidecar,
+			}
+
+			dep.Spec.Template.Spec.Volumes = append(getVolumes(), corev1.Volume{
+				Name: resources.CloudConfigConfigMapName,
+				VolumeSource: corev1.VolumeSource{
+					ConfigMap: &corev1.ConfigMapVolumeSource{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: resources.CloudConfigConfigMapName,
+						},
+					},
+				},
+			})
+
+			return dep, nil
+		}
+	}
+}
+
+func getAzureCCMContainer(version string, data *resources.TemplateData) corev1.Container {
+	return corev1.Container{
+		Name:  ccmContainerName,
+		Image: fmt.Sprintf("%s/oss/kubernetes/azure-cloud-controller-manager:v%s", data.ImageRegistry(resources.RegistryMCR), version),
+		Command: []string{
+			"/usr/local/bin/cloud-controller-manager",
+		},
+		Args: []string{
+			"--v=2",
+			"--cloud-provider=azure",
+			"--cloud-config=/etc/kubernetes/cloud/config",
+			"--kubeconfig=/etc/kubernetes/kubeconfig/kubeconfig",
+			"--authentication-kubeconfig=/etc/kubernetes/kubeconfig/kubeconfig",
+			"--authorization-kubeconfig=/etc/kubernetes/kubeconfig/kubeconfig",
+			// the nodes are initialized by the cloud-node-manager running
+			// inside the user cluster, as it requires the instance metadata
+			"--controllers=*,-cloud-node",
+			"--configure-cloud-routes=false",
+			"--leader-elect=true",
+			"--secure-port=10268",
+			"--port=0",
+			// Required so multiple clusters using the same resource group can allocate public IPs.
+			// Ref: https://github.com/kubernetes/kubernetes/pull/77630
+			"--cluster-name", data.Cluster().Name,
+		},
+		VolumeMounts: append(getVolumeMounts(), corev1.VolumeMount{
+			Name:      resources.CloudConfigConfigMapName,
+			MountPath: "/etc/kubernetes/cloud",
+			ReadOnly:  true,
+		}),
+		Resources: azureResourceRequirements,
+	}
+}
+
+const latestAzureCCMVersion = "1.0.3"
+
+func getAzureCCMVersion(version semver.Semver) (string, error) {
+	if version.Minor() < 19 {
+		return "", fmt.Errorf("kubernetes version %s is not supported", version.String())
+	}
+
+	switch version.Minor() {
+	case 19:
+		return "0.6.0", nil
+	case 20:
+		return "0.7.8", nil
+	case 21:
+		return latestAzureCCMVersion, nil
+	default:
+		return latestAzureCCMVersion, nil
+	}
+}
+
+// AzureCloudControllerSupported checks if this version of Kubernetes is supported
+// by our implementation of the external cloud controller.
+func AzureCloudControllerSupported(version semver.Semver) bool {
+	if _, err := getAzureCCMVersion(version); err != nil {
+		return false
+	}
+	return true
+}
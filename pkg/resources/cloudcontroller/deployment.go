@@ -47,6 +47,9 @@ func DeploymentCreator(data *resources.TemplateData) reconciling.NamedDeployment
 
 	case data.Cluster().Spec.Cloud.VSphere != nil:
 		creatorGetter = vsphereDeploymentCreator(data)
+
+	case data.Cluster().Spec.Cloud.Azure != nil:
+		creatorGetter = azureDeploymentCreator(data)
 	}
 
 	if creatorGetter != nil {
@@ -43,6 +43,7 @@ func Spec(apiCluster apiv1.Cluster, dc *kubermaticv1.Datacenter, secretKeyGetter
 		HumanReadableName:                    apiCluster.Name,
 		Cloud:                                apiCluster.Spec.Cloud,
 		MachineNetworks:                      apiCluster.Spec.MachineNetworks,
+		AdditionalClouds:                     apiCluster.Spec.AdditionalClouds,
 		OIDC:                                 apiCluster.Spec.OIDC,
 		UpdateWindow:                         apiCluster.Spec.UpdateWindow,
 		Version:                              apiCluster.Spec.Version,
@@ -55,6 +56,8 @@ func Spec(apiCluster apiv1.Cluster, dc *kubermaticv1.Datacenter, secretKeyGetter
 		PodNodeSelectorAdmissionPluginConfig: apiCluster.Spec.PodNodeSelectorAdmissionPluginConfig,
 		ServiceAccount:                       apiCluster.Spec.ServiceAccount,
 		MLA:                                  apiCluster.Spec.MLA,
+		LogShipping:                          apiCluster.Spec.LogShipping,
+		PatchUpgradePolicy:                   apiCluster.Spec.PatchUpgradePolicy,
 		ContainerRuntime:                     apiCluster.Spec.ContainerRuntime,
 	}
 
@@ -221,6 +221,10 @@ func getFlags(data *resources.TemplateData) ([]string, error) {
 		"--use-service-account-credentials",
 	}
 
+	if maskSize := data.Cluster().Spec.ClusterNetwork.NodeCIDRMaskSize; maskSize != nil {
+		flags = append(flags, "--node-cidr-mask-size", fmt.Sprintf("%d", *maskSize))
+	}
+
 	featureGates := []string{"RotateKubeletServerCertificate=true"}
 
 	// starting with k8s 1.21, this is always true and cannot be toggled anymore
@@ -23,6 +23,7 @@ import (
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/provider"
 
+	corev1 "k8s.io/api/core/v1"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -296,6 +297,10 @@ func GetAWSCredentials(data CredentialsData) (AWSCredentials, error) {
 }
 
 func GetAzureCredentials(data CredentialsData) (AzureCredentials, error) {
+	if HasAzureClusterServicePrincipal(data.Cluster()) {
+		return getAzureClusterServicePrincipalCredentials(data)
+	}
+
 	spec := data.Cluster().Spec.Cloud.Azure
 	azureCredentials := AzureCredentials{}
 	var err error
@@ -327,6 +332,34 @@ func GetAzureCredentials(data CredentialsData) (AzureCredentials, error) {
 	return azureCredentials, nil
 }
 
+// getAzureClusterServicePrincipalCredentials returns the credentials of the service principal
+// Kubermatic created for the cluster, which are stored in the cluster namespace.
+func getAzureClusterServicePrincipalCredentials(data CredentialsData) (AzureCredentials, error) {
+	secretKeySelector := &providerconfig.GlobalSecretKeySelector{
+		ObjectReference: corev1.ObjectReference{
+			Name:      AzureClusterServicePrincipalSecretName,
+			Namespace: data.Cluster().Status.NamespaceName,
+		},
+	}
+	azureCredentials := AzureCredentials{}
+	var err error
+
+	if azureCredentials.TenantID, err = data.GetGlobalSecretKeySelectorValue(secretKeySelector, AzureTenantID); err != nil {
+		return AzureCredentials{}, err
+	}
+	if azureCredentials.SubscriptionID, err = data.GetGlobalSecretKeySelectorValue(secretKeySelector, AzureSubscriptionID); err != nil {
+		return AzureCredentials{}, err
+	}
+	if azureCredentials.ClientID, err = data.GetGlobalSecretKeySelectorValue(secretKeySelector, AzureClientID); err != nil {
+		return AzureCredentials{}, err
+	}
+	if azureCredentials.ClientSecret, err = data.GetGlobalSecretKeySelectorValue(secretKeySelector, AzureClientSecret); err != nil {
+		return AzureCredentials{}, err
+	}
+
+	return azureCredentials, nil
+}
+
 func GetDigitaloceanCredentials(data CredentialsData) (DigitaloceanCredentials, error) {
 	spec := data.Cluster().Spec.Cloud.Digitalocean
 	digitaloceanCredentials := DigitaloceanCredentials{}
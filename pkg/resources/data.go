@@ -34,6 +34,7 @@ import (
 	"k8c.io/kubermatic/v2/pkg/kubernetes"
 	"k8c.io/kubermatic/v2/pkg/provider"
 	"k8c.io/kubermatic/v2/pkg/resources/certificates/triple"
+	"k8c.io/kubermatic/v2/pkg/resources/registry"
 	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -457,10 +458,17 @@ func (d *TemplateData) ProviderName() string {
 
 // ImageRegistry returns the image registry to use or the passed in default if no override is specified
 func (d *TemplateData) ImageRegistry(defaultRegistry string) string {
-	if d.OverwriteRegistry != "" {
-		return d.OverwriteRegistry
+	return registry.GetOverwriteFunc(d.OverwriteRegistry)(defaultRegistry)
+}
+
+// ImageRewriteFunc returns the function rewriting images to be pulled through the
+// registry cache of the seed. Without a registry cache, images are left untouched.
+func (d *TemplateData) ImageRewriteFunc() registry.ImageRewriteFunc {
+	var cache *kubermaticv1.RegistryCacheConfig
+	if d.seed != nil {
+		cache = d.seed.Spec.RegistryCache
 	}
-	return defaultRegistry
+	return registry.GetCacheRewriteFunc(cache)
 }
 
 // GetRootCA returns the root CA of the cluster
@@ -533,12 +541,10 @@ func (d *TemplateData) parseImage(image string) string {
 	domain := reference.Domain(named)
 	reminder := reference.Path(named)
 
-	if d.OverwriteRegistry != "" {
-		domain = d.OverwriteRegistry
-	}
 	if domain == "" {
 		domain = RegistryDocker
 	}
+	domain = d.ImageRegistry(domain)
 
 	return domain + "/" + reminder
 }
@@ -658,6 +664,9 @@ func GetKubernetesCloudProviderName(cluster *kubermaticv1.Cluster, externalCloud
 		}
 		return "vsphere"
 	case cluster.Spec.Cloud.Azure != nil:
+		if cluster.Spec.Features[kubermaticv1.ClusterFeatureExternalCloudProvider] {
+			return cloudProviderExternalFlag
+		}
 		return "azure"
 	case cluster.Spec.Cloud.GCP != nil:
 		return "gce"
@@ -699,6 +708,9 @@ func GetCSIMigrationFeatureGates(cluster *kubermaticv1.Cluster) []string {
 		if cluster.Spec.Cloud.VSphere != nil {
 			featureFlags = append(featureFlags, "CSIMigrationvSphere=true")
 		}
+		if cluster.Spec.Cloud.Azure != nil {
+			featureFlags = append(featureFlags, azureCSIMigrationFeatureGates(cluster, "")...)
+		}
 		// The CSIMigrationNeededAnnotation is removed when all kubelets have
 		// been migrated.
 		if kubermaticv1helper.ClusterConditionHasStatus(cluster, kubermaticv1.ClusterConditionCSIKubeletMigrationCompleted, corev1.ConditionTrue) {
@@ -708,11 +720,25 @@ func GetCSIMigrationFeatureGates(cluster *kubermaticv1.Cluster) []string {
 			if cluster.Spec.Cloud.VSphere != nil {
 				featureFlags = append(featureFlags, "CSIMigrationvSphereComplete=true")
 			}
+			if cluster.Spec.Cloud.Azure != nil {
+				featureFlags = append(featureFlags, azureCSIMigrationFeatureGates(cluster, "Complete")...)
+			}
 		}
 	}
 	return featureFlags
 }
 
+// azureCSIMigrationFeatureGates returns the CSI migration feature gates for the
+// Azure volume plugins, with the given suffix. The migration of Azure File only
+// got usable with Kubernetes 1.21, before that only Azure Disk volumes are migrated.
+func azureCSIMigrationFeatureGates(cluster *kubermaticv1.Cluster, suffix string) []string {
+	featureFlags := []string{fmt.Sprintf("CSIMigrationAzureDisk%s=true", suffix)}
+	if cluster.Spec.Version.Minor() >= 21 {
+		featureFlags = append(featureFlags, fmt.Sprintf("CSIMigrationAzureFile%s=true", suffix))
+	}
+	return featureFlags
+}
+
 func (d *TemplateData) Seed() *kubermaticv1.Seed {
 	return d.seed
 }
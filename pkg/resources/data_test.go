@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/semver"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -105,6 +106,62 @@ func TestGetCSIMigrationFeatureGates(t *testing.T) {
 			},
 			wantFeatureGates: sets.NewString("CSIMigration=true", "CSIMigrationOpenStack=true", "ExpandCSIVolumes=true", "CSIMigrationOpenStackComplete=true"),
 		},
+		{
+			name: "Azure CSI migration on Kubernetes 1.20",
+			cluster: &kubermaticv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-a",
+					Annotations: map[string]string{
+						kubermaticv1.CSIMigrationNeededAnnotation: "",
+					},
+				},
+				Spec: kubermaticv1.ClusterSpec{
+					Version: *semver.NewSemverOrDie("1.20.9"),
+					Features: map[string]bool{
+						kubermaticv1.ClusterFeatureExternalCloudProvider: true,
+					},
+					Cloud: kubermaticv1.CloudSpec{
+						Azure: &kubermaticv1.AzureCloudSpec{},
+					},
+				},
+				Status: kubermaticv1.ClusterStatus{
+					NamespaceName: "test",
+				},
+			},
+			wantFeatureGates: sets.NewString("CSIMigration=true", "CSIMigrationAzureDisk=true", "ExpandCSIVolumes=true"),
+		},
+		{
+			name: "Azure CSI migration completed on Kubernetes 1.21",
+			cluster: &kubermaticv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-a",
+					Annotations: map[string]string{
+						kubermaticv1.CSIMigrationNeededAnnotation: "",
+					},
+				},
+				Spec: kubermaticv1.ClusterSpec{
+					Version: *semver.NewSemverOrDie("1.21.3"),
+					Features: map[string]bool{
+						kubermaticv1.ClusterFeatureExternalCloudProvider: true,
+					},
+					Cloud: kubermaticv1.CloudSpec{
+						Azure: &kubermaticv1.AzureCloudSpec{},
+					},
+				},
+				Status: kubermaticv1.ClusterStatus{
+					NamespaceName: "test",
+					Conditions: []kubermaticv1.ClusterCondition{
+						{
+							Type:   kubermaticv1.ClusterConditionCSIKubeletMigrationCompleted,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+			},
+			wantFeatureGates: sets.NewString("CSIMigration=true", "ExpandCSIVolumes=true",
+				"CSIMigrationAzureDisk=true", "CSIMigrationAzureFile=true",
+				"CSIMigrationAzureDiskComplete=true", "CSIMigrationAzureFileComplete=true"),
+		},
 	}
 
 	for _, tc := range testCases {
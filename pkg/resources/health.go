@@ -65,3 +65,23 @@ func HealthyStatefulSet(ctx context.Context, client ctrlruntimeclient.Client, nn
 	}
 	return kubermaticv1.HealthStatusUp, nil
 }
+
+// HealthyDaemonSet tells if all pods of the daemonset are up to date and in Ready status
+func HealthyDaemonSet(ctx context.Context, client ctrlruntimeclient.Client, nn types.NamespacedName) (kubermaticv1.HealthStatus, error) {
+	daemonSet := &appsv1.DaemonSet{}
+	if err := client.Get(ctx, nn, daemonSet); err != nil {
+		if kerrors.IsNotFound(err) {
+			return kubermaticv1.HealthStatusDown, nil
+		}
+		return kubermaticv1.HealthStatusDown, err
+	}
+
+	// no nodes yet or update scenario
+	if daemonSet.Status.DesiredNumberScheduled == 0 || daemonSet.Status.UpdatedNumberScheduled != daemonSet.Status.DesiredNumberScheduled {
+		return kubermaticv1.HealthStatusProvisioning, nil
+	}
+	if daemonSet.Status.NumberReady < daemonSet.Status.DesiredNumberScheduled {
+		return kubermaticv1.HealthStatusDown, nil
+	}
+	return kubermaticv1.HealthStatusUp, nil
+}
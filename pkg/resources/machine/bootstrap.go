@@ -0,0 +1,214 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+	"github.com/kubermatic/machine-controller/pkg/apis/plugin"
+	"github.com/kubermatic/machine-controller/pkg/containerruntime"
+	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
+	"github.com/kubermatic/machine-controller/pkg/userdata/amzn2"
+	"github.com/kubermatic/machine-controller/pkg/userdata/centos"
+	"github.com/kubermatic/machine-controller/pkg/userdata/flatcar"
+	"github.com/kubermatic/machine-controller/pkg/userdata/rhel"
+	"github.com/kubermatic/machine-controller/pkg/userdata/sles"
+	"github.com/kubermatic/machine-controller/pkg/userdata/ubuntu"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	machineconversions "k8c.io/kubermatic/v2/pkg/machine"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/machinecontroller"
+
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+const (
+	// RedactedValue replaces secrets in the rendered bootstrap configuration.
+	RedactedValue = "REDACTED"
+
+	// BootstrapConfigFormatCloudInit is the format of bootstrap configurations rendered as cloud-config.
+	BootstrapConfigFormatCloudInit = "cloud-init"
+	// BootstrapConfigFormatIgnition is the format of bootstrap configurations rendered as Ignition config.
+	BootstrapConfigFormatIgnition = "ignition"
+)
+
+type userDataProvider interface {
+	UserData(req plugin.UserDataRequest) (string, error)
+}
+
+var userDataProviders = map[providerconfig.OperatingSystem]userDataProvider{
+	providerconfig.OperatingSystemUbuntu:       ubuntu.Provider{},
+	providerconfig.OperatingSystemCentOS:       centos.Provider{},
+	providerconfig.OperatingSystemAmazonLinux2: amzn2.Provider{},
+	providerconfig.OperatingSystemSLES:         sles.Provider{},
+	providerconfig.OperatingSystemRHEL:         rhel.Provider{},
+	providerconfig.OperatingSystemFlatcar:      flatcar.Provider{},
+}
+
+// BootstrapConfig renders the user data the machine-controller would generate for the machines of
+// the given machine deployment, using the node settings the machine-controller of the cluster is
+// configured with. The bootstrap token and the cloud config are replaced with RedactedValue, as
+// they contain credentials. It returns the rendered configuration and its format.
+func BootstrapConfig(md *clusterv1alpha1.MachineDeployment, cluster *kubermaticv1.Cluster, dc *kubermaticv1.Datacenter, caCert string) (string, string, error) {
+	spec := *md.Spec.Template.Spec.DeepCopy()
+	// the names of the machines are generated, so the name of the machine deployment is used for
+	// the hostname instead
+	spec.Name = md.Name
+
+	config, err := providerconfig.GetConfig(spec.ProviderSpec)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get provider spec: %v", err)
+	}
+	if config.OverwriteCloudConfig != nil {
+		config.OverwriteCloudConfig = &[]string{RedactedValue}[0]
+		rawConfig, err := json.Marshal(config)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to marshal provider spec: %v", err)
+		}
+		spec.ProviderSpec.Value.Raw = rawConfig
+	}
+
+	userDataProvider, ok := userDataProviders[config.OperatingSystem]
+	if !ok {
+		return "", "", fmt.Errorf("operating system %q is not supported", config.OperatingSystem)
+	}
+
+	dnsIPs, err := clusterDNSIPs(cluster)
+	if err != nil {
+		return "", "", err
+	}
+
+	req := plugin.UserDataRequest{
+		MachineSpec:           spec,
+		Kubeconfig:            bootstrapKubeconfig(cluster, caCert),
+		CloudProviderName:     inTreeCloudProviderName(config.CloudProvider),
+		DNSIPs:                dnsIPs,
+		ExternalCloudProvider: cluster.Spec.Features[kubermaticv1.ClusterFeatureExternalCloudProvider],
+		KubeletFeatureGates:   kubeletFeatureGates(cluster),
+	}
+	if req.CloudProviderName != "" {
+		req.CloudConfig = RedactedValue
+	}
+
+	var runtimeOpts []containerruntime.Opt
+	if node := dc.Node; node != nil {
+		req.HTTPProxy = node.HTTPProxy.String()
+		req.NoProxy = node.NoProxy.String()
+		req.PauseImage = node.PauseImage
+		req.HyperkubeImage = node.HyperkubeImage
+		runtimeOpts = append(runtimeOpts,
+			containerruntime.WithInsecureRegistries(node.InsecureRegistries),
+			containerruntime.WithRegistryMirrors(node.RegistryMirrors))
+	}
+	req.ContainerRuntime = containerruntime.Get(cluster.Spec.ContainerRuntime, runtimeOpts...)
+
+	userData, err := userDataProvider.UserData(req)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to render user data: %v", err)
+	}
+
+	format := BootstrapConfigFormatIgnition
+	if strings.HasPrefix(userData, "#cloud-config") {
+		format = BootstrapConfigFormatCloudInit
+	}
+
+	dataDisks, err := machineconversions.GetDataDisks(spec)
+	if err != nil {
+		return "", "", err
+	}
+	if userData, err = addDataDiskMounts(userData, format, dataDisks); err != nil {
+		return "", "", err
+	}
+
+	return userData, format, nil
+}
+
+// bootstrapKubeconfig returns the kubeconfig the machine-controller passes to new machines, with
+// the bootstrap token redacted.
+func bootstrapKubeconfig(cluster *kubermaticv1.Cluster, caCert string) *clientcmdapi.Config {
+	return &clientcmdapi.Config{
+		Clusters: map[string]*clientcmdapi.Cluster{
+			"": {
+				Server:                   cluster.Address.URL,
+				CertificateAuthorityData: []byte(caCert),
+			},
+		},
+		Contexts: map[string]*clientcmdapi.Context{
+			"": {
+				Cluster:  "",
+				AuthInfo: "",
+			},
+		},
+		CurrentContext: "",
+		AuthInfos: map[string]*clientcmdapi.AuthInfo{
+			"": {
+				Token: RedactedValue,
+			},
+		},
+	}
+}
+
+// clusterDNSIPs returns the DNS resolver of the nodes, like the -cluster-dns flag of the
+// machine-controller.
+func clusterDNSIPs(cluster *kubermaticv1.Cluster) ([]net.IP, error) {
+	dnsIP := machinecontroller.NodeLocalDNSCacheAddress
+	if enabled := cluster.Spec.ClusterNetwork.NodeLocalDNSCacheEnabled; enabled != nil && !*enabled {
+		var err error
+		dnsIP, err = resources.UserClusterDNSResolverIP(cluster)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return []net.IP{net.ParseIP(dnsIP)}, nil
+}
+
+// kubeletFeatureGates returns the feature gates the machine-controller webhook sets on machines.
+func kubeletFeatureGates(cluster *kubermaticv1.Cluster) map[string]bool {
+	featureGates := map[string]bool{}
+	for _, gate := range resources.GetCSIMigrationFeatureGates(cluster) {
+		parts := strings.SplitN(gate, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		if enabled, err := strconv.ParseBool(parts[1]); err == nil {
+			featureGates[parts[0]] = enabled
+		}
+	}
+
+	return featureGates
+}
+
+// inTreeCloudProviderName returns the name of the in-tree cloud provider the kubelet is configured
+// with, it is empty for providers without one.
+func inTreeCloudProviderName(cloudProvider providerconfig.CloudProvider) string {
+	switch cloudProvider {
+	case providerconfig.CloudProviderAWS,
+		providerconfig.CloudProviderAzure,
+		providerconfig.CloudProviderGoogle,
+		providerconfig.CloudProviderOpenstack,
+		providerconfig.CloudProviderVsphere:
+		return string(cloudProvider)
+	}
+
+	return ""
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestBootstrapConfigUnsupportedOperatingSystem(t *testing.T) {
+	rawConfig, err := json.Marshal(providerconfig.Config{
+		CloudProvider:       providerconfig.CloudProviderAWS,
+		CloudProviderSpec:   runtime.RawExtension{Raw: []byte(`{}`)},
+		OperatingSystem:     providerconfig.OperatingSystem("windows"),
+		OperatingSystemSpec: runtime.RawExtension{Raw: []byte(`{}`)},
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal provider config: %v", err)
+	}
+
+	md := &clusterv1alpha1.MachineDeployment{ObjectMeta: metav1.ObjectMeta{Name: "worker"}}
+	md.Spec.Template.Spec.ProviderSpec.Value = &runtime.RawExtension{Raw: rawConfig}
+
+	if _, _, err := BootstrapConfig(md, &kubermaticv1.Cluster{}, &kubermaticv1.Datacenter{}, ""); err == nil {
+		t.Fatal("expected an error for an unsupported operating system")
+	}
+}
+
+func TestKubeletFeatureGates(t *testing.T) {
+	tests := []struct {
+		name    string
+		cluster *kubermaticv1.Cluster
+		want    map[string]bool
+	}{
+		{
+			name:    "No CSI migration",
+			cluster: &kubermaticv1.Cluster{},
+			want:    map[string]bool{},
+		},
+		{
+			name: "CSI migration on vSphere",
+			cluster: &kubermaticv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{kubermaticv1.CSIMigrationNeededAnnotation: ""},
+				},
+				Spec: kubermaticv1.ClusterSpec{
+					Features: map[string]bool{kubermaticv1.ClusterFeatureExternalCloudProvider: true},
+					Cloud: kubermaticv1.CloudSpec{
+						VSphere: &kubermaticv1.VSphereCloudSpec{},
+					},
+				},
+			},
+			want: map[string]bool{
+				"CSIMigration":        true,
+				"ExpandCSIVolumes":    true,
+				"CSIMigrationvSphere": true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := kubeletFeatureGates(tt.cluster); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("kubeletFeatureGates() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInTreeCloudProviderName(t *testing.T) {
+	tests := []struct {
+		cloudProvider providerconfig.CloudProvider
+		want          string
+	}{
+		{cloudProvider: providerconfig.CloudProviderAWS, want: "aws"},
+		{cloudProvider: providerconfig.CloudProviderVsphere, want: "vsphere"},
+		{cloudProvider: providerconfig.CloudProviderHetzner, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.cloudProvider), func(t *testing.T) {
+			if got := inTreeCloudProviderName(tt.cloudProvider); got != tt.want {
+				t.Errorf("inTreeCloudProviderName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
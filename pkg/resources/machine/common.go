@@ -41,6 +41,7 @@ import (
 	"github.com/kubermatic/machine-controller/pkg/userdata/ubuntu"
 	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	machineconversions "k8c.io/kubermatic/v2/pkg/machine"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/json"
@@ -136,7 +137,22 @@ func getAWSProviderSpec(c *kubermaticv1.Cluster, nodeSpec apiv1.NodeSpec, dc *ku
 	return ext, nil
 }
 
-func getAzureProviderSpec(c *kubermaticv1.Cluster, nodeSpec apiv1.NodeSpec, dc *kubermaticv1.Datacenter) (*runtime.RawExtension, error) {
+// azureRawConfig extends the Azure machine config with the disk encryption set and the type used for
+// the OS disk, the security settings, the boot diagnostics and the networking settings of the VMs,
+// and the subscription and tenant of the VNet if it lives in another subscription.
+type azureRawConfig struct {
+	azure.RawConfig                           `json:",inline"`
+	machineconversions.AzureSecurityConfig    `json:",inline"`
+	machineconversions.AzureDiagnosticsConfig `json:",inline"`
+	machineconversions.AzureNetworkingConfig  `json:",inline"`
+
+	DiskEncryptionSetID providerconfig.ConfigVarString `json:"diskEncryptionSetID,omitempty"`
+	OSDiskType          providerconfig.ConfigVarString `json:"osDiskType,omitempty"`
+	VNetSubscriptionID  providerconfig.ConfigVarString `json:"vnetSubscriptionID,omitempty"`
+	VNetTenantID        providerconfig.ConfigVarString `json:"vnetTenantID,omitempty"`
+}
+
+func getAzureProviderSpec(c *kubermaticv1.Cluster, machineDeployment string, nodeSpec apiv1.NodeSpec, dc *kubermaticv1.Datacenter) (*runtime.RawExtension, error) {
 	config := azure.RawConfig{
 		Location:          providerconfig.ConfigVarString{Value: dc.Spec.Azure.Location},
 		ResourceGroup:     providerconfig.ConfigVarString{Value: c.Spec.Cloud.Azure.ResourceGroup},
@@ -147,7 +163,7 @@ func getAzureProviderSpec(c *kubermaticv1.Cluster, nodeSpec apiv1.NodeSpec, dc *
 		VNetName:          providerconfig.ConfigVarString{Value: c.Spec.Cloud.Azure.VNetName},
 		SubnetName:        providerconfig.ConfigVarString{Value: c.Spec.Cloud.Azure.SubnetName},
 		RouteTableName:    providerconfig.ConfigVarString{Value: c.Spec.Cloud.Azure.RouteTableName},
-		AvailabilitySet:   providerconfig.ConfigVarString{Value: c.Spec.Cloud.Azure.AvailabilitySet},
+		AvailabilitySet:   providerconfig.ConfigVarString{Value: azureAvailabilitySet(c, machineDeployment)},
 		SecurityGroupName: providerconfig.ConfigVarString{Value: c.Spec.Cloud.Azure.SecurityGroup},
 		Zones:             nodeSpec.Cloud.Azure.Zones,
 		ImageID:           providerconfig.ConfigVarString{Value: nodeSpec.Cloud.Azure.ImageID},
@@ -155,6 +171,31 @@ func getAzureProviderSpec(c *kubermaticv1.Cluster, nodeSpec apiv1.NodeSpec, dc *
 		// https://github.com/kubermatic/kubermatic/issues/5013#issuecomment-580357280
 		AssignPublicIP: providerconfig.ConfigVarBool{Value: nodeSpec.Cloud.Azure.AssignPublicIP},
 	}
+	// the machine-controller looks up the security group of the network interfaces in the resource
+	// group of the cluster, security groups referenced by ID apply via the subnet instead
+	if c.Spec.Cloud.Azure.SecurityGroupID != "" {
+		config.SecurityGroupName = providerconfig.ConfigVarString{}
+	}
+	if subnet := nodeSpec.Cloud.Azure.Subnet; subnet != "" && subnet != c.Spec.Cloud.Azure.SubnetName {
+		additionalSubnet := findAzureSubnet(c.Spec.Cloud.Azure.AdditionalSubnets, subnet)
+		if additionalSubnet == nil {
+			return nil, fmt.Errorf("subnet %q is not a subnet of the cluster", subnet)
+		}
+		config.SubnetName = providerconfig.ConfigVarString{Value: additionalSubnet.Name}
+		if additionalSubnet.SecurityGroup != "" {
+			config.SecurityGroupName = providerconfig.ConfigVarString{Value: additionalSubnet.SecurityGroup}
+		}
+	}
+	imageRef, imagePlan, err := azureImage(nodeSpec)
+	if err != nil {
+		return nil, err
+	}
+	config.ImageReference = imageRef
+	config.ImagePlan = imagePlan
+	// nodes of private clusters must not be reachable from the internet
+	if c.Spec.Cloud.Azure.PrivateCluster {
+		config.AssignPublicIP = providerconfig.ConfigVarBool{Value: false}
+	}
 	config.Tags = map[string]string{}
 	for key, value := range nodeSpec.Cloud.Azure.Tags {
 		config.Tags[key] = value
@@ -166,8 +207,31 @@ func getAzureProviderSpec(c *kubermaticv1.Cluster, nodeSpec apiv1.NodeSpec, dc *
 		config.Tags["system-project"] = projectID
 	}
 
+	rawConfig := azureRawConfig{
+		RawConfig:           config,
+		AzureSecurityConfig: machineconversions.NewAzureSecurityConfig(nodeSpec.Cloud.Azure),
+		AzureNetworkingConfig: machineconversions.AzureNetworkingConfig{
+			EnableAcceleratedNetworking: nodeSpec.Cloud.Azure.EnableAcceleratedNetworking,
+		},
+		DiskEncryptionSetID: providerconfig.ConfigVarString{Value: c.Spec.Cloud.Azure.DiskEncryptionSetID},
+		VNetSubscriptionID:  providerconfig.ConfigVarString{Value: c.Spec.Cloud.Azure.VNetSubscriptionID},
+		VNetTenantID:        providerconfig.ConfigVarString{Value: c.Spec.Cloud.Azure.VNetTenantID},
+	}
+	if layout := nodeSpec.DiskLayout; layout != nil && layout.OSDisk != nil {
+		rawConfig.OSDiskType = providerconfig.ConfigVarString{Value: layout.OSDisk.Type}
+	}
+	if nodeSpec.Cloud.Azure.BootDiagnostics {
+		if c.Spec.Cloud.Azure.BootDiagnostics == nil {
+			return nil, errors.New("boot diagnostics are not enabled for the cluster")
+		}
+		if c.Status.Azure == nil || c.Status.Azure.BootDiagnosticsStorageURI == "" {
+			return nil, errors.New("the boot diagnostics storage account of the cluster is not ready yet")
+		}
+		rawConfig.BootDiagnosticsStorageURI = c.Status.Azure.BootDiagnosticsStorageURI
+	}
+
 	ext := &runtime.RawExtension{}
-	b, err := json.Marshal(config)
+	b, err := json.Marshal(rawConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -176,6 +240,60 @@ func getAzureProviderSpec(c *kubermaticv1.Cluster, nodeSpec apiv1.NodeSpec, dc *
 	return ext, nil
 }
 
+// azureImage returns the marketplace image and plan of the machines of an Azure node spec. Gen2
+// node specs without an image of their own use the Gen2 variant of the default image of the
+// operating system.
+func azureImage(nodeSpec apiv1.NodeSpec) (*azure.ImageReference, *azure.ImagePlan, error) {
+	spec := nodeSpec.Cloud.Azure
+	if spec.ImageReference != nil || spec.ImageID != "" || spec.HyperVGeneration != apiv1.AzureHyperVGenerationV2 {
+		return machineconversions.NewAzureImageReference(spec.ImageReference), machineconversions.NewAzureImagePlan(spec.ImagePlan), nil
+	}
+
+	osName, err := getOsName(nodeSpec)
+	if err != nil {
+		return nil, nil, err
+	}
+	image, ok := machineconversions.AzureGen2Images[osName]
+	if !ok {
+		return nil, nil, fmt.Errorf("no Gen2 image is known for operating system %q, an image ID must be specified", osName)
+	}
+	reference := image.Reference
+	return &reference, image.Plan, nil
+}
+
+// AzureImagePlan returns the marketplace plan of the image of the machines of an Azure node
+// spec, or nil if the image has none.
+func AzureImagePlan(nodeSpec apiv1.NodeSpec) (*apiv1.AzureImagePlan, error) {
+	_, plan, err := azureImage(nodeSpec)
+	if err != nil || plan == nil {
+		return nil, err
+	}
+	return &apiv1.AzureImagePlan{Name: plan.Name, Publisher: plan.Publisher, Product: plan.Product}, nil
+}
+
+// azureAvailabilitySet returns the availability set the machines of the MachineDeployment are
+// placed in. MachineDeployments without an availability set of their own use the one of the cluster.
+func azureAvailabilitySet(c *kubermaticv1.Cluster, machineDeployment string) string {
+	if c.Status.Azure != nil {
+		for _, as := range c.Status.Azure.AvailabilitySets {
+			if as.MachineDeployment == machineDeployment {
+				return as.Name
+			}
+		}
+	}
+	return c.Spec.Cloud.Azure.AvailabilitySet
+}
+
+// findAzureSubnet returns the additional subnet with the given name, or nil if there is none.
+func findAzureSubnet(subnets []kubermaticv1.AzureSubnet, name string) *kubermaticv1.AzureSubnet {
+	for i := range subnets {
+		if subnets[i].Name == name {
+			return &subnets[i]
+		}
+	}
+	return nil
+}
+
 func getVSphereProviderSpec(c *kubermaticv1.Cluster, nodeSpec apiv1.NodeSpec, dc *kubermaticv1.Datacenter) (*runtime.RawExtension, error) {
 	var datastore = ""
 	// If `DatastoreCluster` is not specified we use either the Datastore
@@ -229,6 +347,7 @@ func getOpenstackProviderSpec(c *kubermaticv1.Cluster, nodeSpec apiv1.NodeSpec,
 
 	if nodeSpec.Cloud.Openstack.RootDiskSizeGB != nil && *nodeSpec.Cloud.Openstack.RootDiskSizeGB > 0 {
 		config.RootDiskSizeGB = nodeSpec.Cloud.Openstack.RootDiskSizeGB
+		config.RootDiskVolumeType = providerconfig.ConfigVarString{Value: nodeSpec.Cloud.Openstack.RootDiskVolumeType}
 	}
 
 	if dc.Spec.Openstack.TrustDevicePath != nil {
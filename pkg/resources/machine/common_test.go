@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"testing"
 
+	openstack "github.com/kubermatic/machine-controller/pkg/cloudprovider/provider/openstack/types"
 	vsphere "github.com/kubermatic/machine-controller/pkg/cloudprovider/provider/vsphere/types"
 	providerconfigtypes "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
 	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
@@ -133,3 +134,385 @@ func TestGetVSphereProviderSpec(t *testing.T) {
 		})
 	}
 }
+
+func TestGetAzureProviderSpec(t *testing.T) {
+	const desID = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/diskEncryptionSets/des"
+
+	cluster := &kubermaticv1.Cluster{
+		Spec: kubermaticv1.ClusterSpec{
+			Cloud: kubermaticv1.CloudSpec{
+				Azure: &kubermaticv1.AzureCloudSpec{
+					ResourceGroup:       "my-rg",
+					DiskEncryptionSetID: desID,
+					VNetSubscriptionID:  "network-sub",
+				},
+			},
+		},
+	}
+	nodeSpec := apiv1.NodeSpec{
+		Cloud: apiv1.NodeCloudSpec{
+			Azure: &apiv1.AzureNodeSpec{Size: "Standard_D2s_v3"},
+		},
+	}
+	dc := &kubermaticv1.Datacenter{
+		Spec: kubermaticv1.DatacenterSpec{
+			Azure: &kubermaticv1.DatacenterSpecAzure{Location: "westeurope"},
+		},
+	}
+
+	got, err := getAzureProviderSpec(cluster, "my-md", nodeSpec, dc)
+	if err != nil {
+		t.Fatalf("getAzureProviderSpec() error = %v", err)
+	}
+
+	gotRawConf := azureRawConfig{}
+	if err := json.Unmarshal(got.Raw, &gotRawConf); err != nil {
+		t.Fatalf("error occurred while unmarshaling raw config: %v", err)
+	}
+	if gotRawConf.DiskEncryptionSetID.Value != desID {
+		t.Errorf("expected disk encryption set %q, got %q", desID, gotRawConf.DiskEncryptionSetID.Value)
+	}
+	if gotRawConf.VNetSubscriptionID.Value != "network-sub" || gotRawConf.VNetTenantID.Value != "" {
+		t.Errorf("expected the subscription of the VNet to be passed on, got %q/%q", gotRawConf.VNetSubscriptionID.Value, gotRawConf.VNetTenantID.Value)
+	}
+	if gotRawConf.ResourceGroup.Value != "my-rg" || gotRawConf.VMSize.Value != "Standard_D2s_v3" {
+		t.Errorf("expected the Azure machine config to be kept, got %+v", gotRawConf.RawConfig)
+	}
+}
+
+func TestGetAzureProviderSpecAvailabilitySet(t *testing.T) {
+	cluster := &kubermaticv1.Cluster{
+		Spec: kubermaticv1.ClusterSpec{
+			Cloud: kubermaticv1.CloudSpec{
+				Azure: &kubermaticv1.AzureCloudSpec{
+					AvailabilitySet:                     "cluster-as",
+					AvailabilitySetPerMachineDeployment: true,
+				},
+			},
+		},
+		Status: kubermaticv1.ClusterStatus{
+			Azure: &kubermaticv1.AzureClusterStatus{
+				AvailabilitySets: []kubermaticv1.AzureAvailabilitySet{
+					{MachineDeployment: "workers", Name: "workers-as"},
+				},
+			},
+		},
+	}
+	nodeSpec := apiv1.NodeSpec{
+		Cloud: apiv1.NodeCloudSpec{
+			Azure: &apiv1.AzureNodeSpec{Size: "Standard_D2s_v3"},
+		},
+	}
+	dc := &kubermaticv1.Datacenter{
+		Spec: kubermaticv1.DatacenterSpec{
+			Azure: &kubermaticv1.DatacenterSpecAzure{Location: "westeurope"},
+		},
+	}
+
+	tests := []struct {
+		machineDeployment   string
+		wantAvailabilitySet string
+	}{
+		{machineDeployment: "workers", wantAvailabilitySet: "workers-as"},
+		// MachineDeployments created before the option was enabled stay in the cluster's availability set
+		{machineDeployment: "old-workers", wantAvailabilitySet: "cluster-as"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.machineDeployment, func(t *testing.T) {
+			got, err := getAzureProviderSpec(cluster, tt.machineDeployment, nodeSpec, dc)
+			if err != nil {
+				t.Fatalf("getAzureProviderSpec() error = %v", err)
+			}
+
+			gotRawConf := azureRawConfig{}
+			if err := json.Unmarshal(got.Raw, &gotRawConf); err != nil {
+				t.Fatalf("error occurred while unmarshaling raw config: %v", err)
+			}
+			if gotRawConf.AvailabilitySet.Value != tt.wantAvailabilitySet {
+				t.Errorf("expected availability set %q, got %q", tt.wantAvailabilitySet, gotRawConf.AvailabilitySet.Value)
+			}
+		})
+	}
+}
+
+func TestGetAzureProviderSpecTrustedLaunch(t *testing.T) {
+	cluster := &kubermaticv1.Cluster{
+		Spec: kubermaticv1.ClusterSpec{
+			Cloud: kubermaticv1.CloudSpec{
+				Azure: &kubermaticv1.AzureCloudSpec{},
+			},
+		},
+	}
+	dc := &kubermaticv1.Datacenter{
+		Spec: kubermaticv1.DatacenterSpec{
+			Azure: &kubermaticv1.DatacenterSpecAzure{Location: "westeurope"},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		imageID        string
+		imageReference *apiv1.AzureImageReference
+		imagePlan      *apiv1.AzureImagePlan
+		wantImageSku   string
+		wantImagePlan  string
+	}{
+		{name: "default Gen2 image", wantImageSku: "stable-gen2", wantImagePlan: "stable-gen2"},
+		{name: "custom image", imageID: "/subscriptions/sub/images/custom"},
+		{
+			name:           "marketplace image",
+			imageReference: &apiv1.AzureImageReference{Publisher: "kinvolk", Offer: "flatcar-container-linux-free", SKU: "stable-gen2"},
+			imagePlan:      &apiv1.AzureImagePlan{Name: "stable-gen2-free", Publisher: "kinvolk", Product: "flatcar-container-linux-free"},
+			wantImageSku:   "stable-gen2",
+			wantImagePlan:  "stable-gen2-free",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodeSpec := apiv1.NodeSpec{
+				OperatingSystem: apiv1.OperatingSystemSpec{Flatcar: &apiv1.FlatcarSpec{}},
+				Cloud: apiv1.NodeCloudSpec{
+					Azure: &apiv1.AzureNodeSpec{
+						Size:                        "Standard_D2s_v3",
+						ImageID:                     tt.imageID,
+						ImageReference:              tt.imageReference,
+						ImagePlan:                   tt.imagePlan,
+						HyperVGeneration:            apiv1.AzureHyperVGenerationV2,
+						SecurityType:                apiv1.AzureSecurityTypeTrustedLaunch,
+						EnableSecureBoot:            true,
+						EnableVTPM:                  true,
+						EnableEncryptionAtHost:      true,
+						EnableAcceleratedNetworking: true,
+					},
+				},
+			}
+
+			got, err := getAzureProviderSpec(cluster, "my-md", nodeSpec, dc)
+			if err != nil {
+				t.Fatalf("getAzureProviderSpec() error = %v", err)
+			}
+
+			gotRawConf := azureRawConfig{}
+			if err := json.Unmarshal(got.Raw, &gotRawConf); err != nil {
+				t.Fatalf("error occurred while unmarshaling raw config: %v", err)
+			}
+			profile := gotRawConf.SecurityProfile
+			if profile == nil || profile.SecurityType != apiv1.AzureSecurityTypeTrustedLaunch || !profile.UefiSettings.SecureBootEnabled || !profile.UefiSettings.VTPMEnabled {
+				t.Errorf("expected a trusted launch security profile with secure boot and vTPM, got %+v", profile)
+			}
+			if profile != nil && !profile.EncryptionAtHost {
+				t.Error("expected encryption at host to be enabled")
+			}
+			if !gotRawConf.EnableAcceleratedNetworking {
+				t.Error("expected accelerated networking to be enabled")
+			}
+			if tt.wantImageSku == "" {
+				if gotRawConf.ImageReference != nil || gotRawConf.ImagePlan != nil {
+					t.Errorf("expected no image reference for a custom image, got %+v", gotRawConf.ImageReference)
+				}
+				return
+			}
+			if gotRawConf.ImageReference == nil || gotRawConf.ImageReference.Sku != tt.wantImageSku {
+				t.Errorf("expected image sku %q, got %+v", tt.wantImageSku, gotRawConf.ImageReference)
+			}
+			if gotRawConf.ImagePlan == nil || gotRawConf.ImagePlan.Name != tt.wantImagePlan {
+				t.Errorf("expected image plan %q, got %+v", tt.wantImagePlan, gotRawConf.ImagePlan)
+			}
+		})
+	}
+}
+
+func TestGetOpenstackProviderSpecRootDiskVolumeType(t *testing.T) {
+	cluster := &kubermaticv1.Cluster{
+		Spec: kubermaticv1.ClusterSpec{
+			Cloud: kubermaticv1.CloudSpec{
+				Openstack: &kubermaticv1.OpenstackCloudSpec{Network: "net"},
+			},
+		},
+	}
+	dc := &kubermaticv1.Datacenter{
+		Spec: kubermaticv1.DatacenterSpec{
+			Openstack: &kubermaticv1.DatacenterSpecOpenstack{AvailabilityZone: "default-az"},
+		},
+	}
+	diskSize := 50
+
+	tests := []struct {
+		name               string
+		nodeSpec           apiv1.OpenstackNodeSpec
+		expectedVolumeType string
+		expectedZone       string
+	}{
+		{
+			name:               "volume type of root disk volume",
+			nodeSpec:           apiv1.OpenstackNodeSpec{RootDiskSizeGB: &diskSize, RootDiskVolumeType: "ssd", AvailabilityZone: "az-1"},
+			expectedVolumeType: "ssd",
+			expectedZone:       "az-1",
+		},
+		{
+			name:         "no volume type for ephemeral root disk",
+			nodeSpec:     apiv1.OpenstackNodeSpec{RootDiskVolumeType: "ssd"},
+			expectedZone: "default-az",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			nodeSpec := apiv1.NodeSpec{Cloud: apiv1.NodeCloudSpec{Openstack: &test.nodeSpec}}
+
+			got, err := getOpenstackProviderSpec(cluster, nodeSpec, dc)
+			if err != nil {
+				t.Fatalf("getOpenstackProviderSpec() error = %v", err)
+			}
+
+			gotRawConf := openstack.RawConfig{}
+			if err := json.Unmarshal(got.Raw, &gotRawConf); err != nil {
+				t.Fatalf("error occurred while unmarshaling raw config: %v", err)
+			}
+			if gotRawConf.RootDiskVolumeType.Value != test.expectedVolumeType {
+				t.Errorf("expected root disk volume type %q, got %q", test.expectedVolumeType, gotRawConf.RootDiskVolumeType.Value)
+			}
+			if gotRawConf.AvailabilityZone.Value != test.expectedZone {
+				t.Errorf("expected availability zone %q, got %q", test.expectedZone, gotRawConf.AvailabilityZone.Value)
+			}
+		})
+	}
+}
+
+func TestGetAzureProviderSpecSubnet(t *testing.T) {
+	cluster := &kubermaticv1.Cluster{
+		Spec: kubermaticv1.ClusterSpec{
+			Cloud: kubermaticv1.CloudSpec{
+				Azure: &kubermaticv1.AzureCloudSpec{
+					SubnetName:    "kubernetes-abc",
+					SecurityGroup: "kubernetes-abc",
+					AdditionalSubnets: []kubermaticv1.AzureSubnet{
+						{Name: "frontend", CIDR: "10.2.0.0/24"},
+						{Name: "gpu", CIDR: "10.2.1.0/24", SecurityGroup: "gpu-nsg"},
+					},
+				},
+			},
+		},
+	}
+	dc := &kubermaticv1.Datacenter{
+		Spec: kubermaticv1.DatacenterSpec{
+			Azure: &kubermaticv1.DatacenterSpecAzure{Location: "westeurope"},
+		},
+	}
+
+	tests := []struct {
+		name              string
+		subnet            string
+		wantSubnet        string
+		wantSecurityGroup string
+		wantErr           bool
+	}{
+		{name: "default subnet", wantSubnet: "kubernetes-abc", wantSecurityGroup: "kubernetes-abc"},
+		{name: "cluster subnet", subnet: "kubernetes-abc", wantSubnet: "kubernetes-abc", wantSecurityGroup: "kubernetes-abc"},
+		{name: "additional subnet", subnet: "frontend", wantSubnet: "frontend", wantSecurityGroup: "kubernetes-abc"},
+		{name: "additional subnet with security group", subnet: "gpu", wantSubnet: "gpu", wantSecurityGroup: "gpu-nsg"},
+		{name: "unknown subnet", subnet: "backend", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodeSpec := apiv1.NodeSpec{
+				Cloud: apiv1.NodeCloudSpec{
+					Azure: &apiv1.AzureNodeSpec{Size: "Standard_D2s_v3", Subnet: tt.subnet},
+				},
+			}
+
+			got, err := getAzureProviderSpec(cluster, "my-md", nodeSpec, dc)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getAzureProviderSpec() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			gotRawConf := azureRawConfig{}
+			if err := json.Unmarshal(got.Raw, &gotRawConf); err != nil {
+				t.Fatalf("error occurred while unmarshaling raw config: %v", err)
+			}
+			if gotRawConf.SubnetName.Value != tt.wantSubnet {
+				t.Errorf("expected subnet %q, got %q", tt.wantSubnet, gotRawConf.SubnetName.Value)
+			}
+			if gotRawConf.SecurityGroupName.Value != tt.wantSecurityGroup {
+				t.Errorf("expected security group %q, got %q", tt.wantSecurityGroup, gotRawConf.SecurityGroupName.Value)
+			}
+		})
+	}
+}
+
+func TestGetAzureProviderSpecBootDiagnostics(t *testing.T) {
+	dc := &kubermaticv1.Datacenter{
+		Spec: kubermaticv1.DatacenterSpec{
+			Azure: &kubermaticv1.DatacenterSpecAzure{Location: "westeurope"},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		bootDiagnostics bool
+		cloud           *kubermaticv1.AzureBootDiagnostics
+		status          *kubermaticv1.AzureClusterStatus
+		wantURI         string
+		wantErr         bool
+	}{
+		{
+			name:   "boot diagnostics not requested",
+			cloud:  &kubermaticv1.AzureBootDiagnostics{},
+			status: &kubermaticv1.AzureClusterStatus{BootDiagnosticsStorageURI: "https://kkpabc.blob.core.windows.net/"},
+		},
+		{
+			name:            "boot diagnostics requested",
+			bootDiagnostics: true,
+			cloud:           &kubermaticv1.AzureBootDiagnostics{},
+			status:          &kubermaticv1.AzureClusterStatus{BootDiagnosticsStorageURI: "https://kkpabc.blob.core.windows.net/"},
+			wantURI:         "https://kkpabc.blob.core.windows.net/",
+		},
+		{
+			name:            "boot diagnostics disabled for the cluster",
+			bootDiagnostics: true,
+			wantErr:         true,
+		},
+		{
+			name:            "storage account not ready",
+			bootDiagnostics: true,
+			cloud:           &kubermaticv1.AzureBootDiagnostics{},
+			wantErr:         true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cluster := &kubermaticv1.Cluster{
+				Spec: kubermaticv1.ClusterSpec{
+					Cloud: kubermaticv1.CloudSpec{
+						Azure: &kubermaticv1.AzureCloudSpec{BootDiagnostics: tt.cloud},
+					},
+				},
+				Status: kubermaticv1.ClusterStatus{Azure: tt.status},
+			}
+			nodeSpec := apiv1.NodeSpec{
+				Cloud: apiv1.NodeCloudSpec{
+					Azure: &apiv1.AzureNodeSpec{Size: "Standard_D2s_v3", BootDiagnostics: tt.bootDiagnostics},
+				},
+			}
+
+			got, err := getAzureProviderSpec(cluster, "my-md", nodeSpec, dc)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getAzureProviderSpec() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			gotRawConf := azureRawConfig{}
+			if err := json.Unmarshal(got.Raw, &gotRawConf); err != nil {
+				t.Fatalf("error occurred while unmarshaling raw config: %v", err)
+			}
+			if gotRawConf.BootDiagnosticsStorageURI != tt.wantURI {
+				t.Errorf("expected boot diagnostics storage URI %q, got %q", tt.wantURI, gotRawConf.BootDiagnosticsStorageURI)
+			}
+		})
+	}
+}
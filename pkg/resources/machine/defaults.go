@@ -0,0 +1,113 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"errors"
+	"fmt"
+
+	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+)
+
+// SetAzureNodeDefaults sets the VM size, the OS disk and the image of an Azure node deployment
+// to the node defaults of the datacenter, unless the node deployment sets them itself. The OS
+// disk size of the cloud spec and of the disk layout both count as set.
+func SetAzureNodeDefaults(nd *apiv1.NodeDeployment, dc *kubermaticv1.Datacenter) {
+	spec := nd.Spec.Template.Cloud.Azure
+	if spec == nil || dc.Spec.Azure == nil || dc.Spec.Azure.NodeDefaults == nil {
+		return
+	}
+	defaults := dc.Spec.Azure.NodeDefaults
+
+	if spec.Size == "" {
+		spec.Size = defaults.VMSize
+	}
+
+	layout := nd.Spec.Template.DiskLayout
+	hasOSDisk := layout != nil && layout.OSDisk != nil
+	if spec.OSDiskSize == 0 && (!hasOSDisk || layout.OSDisk.Size == 0) {
+		spec.OSDiskSize = defaults.OSDiskSize
+	}
+	if defaults.OSDiskType != "" && (!hasOSDisk || layout.OSDisk.Type == "") {
+		if layout == nil {
+			layout = &apiv1.DiskLayout{}
+			nd.Spec.Template.DiskLayout = layout
+		}
+		if layout.OSDisk == nil {
+			layout.OSDisk = &apiv1.OSDiskSpec{}
+		}
+		layout.OSDisk.Type = defaults.OSDiskType
+	}
+
+	if spec.ImageID != "" || spec.ImageReference != nil {
+		return
+	}
+	// node deployments without an operating system are rejected later on
+	osName, err := getOsName(nd.Spec.Template)
+	if err != nil {
+		return
+	}
+	image, ok := defaults.Images[osName]
+	if !ok {
+		return
+	}
+	setAzureNodeImage(spec, image)
+}
+
+// setAzureNodeImage sets the image of the node spec, an image plan of the node spec is kept.
+func setAzureNodeImage(spec *apiv1.AzureNodeSpec, image kubermaticv1.AzureNodeImage) {
+	spec.ImageID = image.ID
+	if ref := image.Reference; ref != nil {
+		spec.ImageReference = &apiv1.AzureImageReference{Publisher: ref.Publisher, Offer: ref.Offer, SKU: ref.SKU, Version: ref.Version}
+	}
+	if plan := image.Plan; plan != nil && spec.ImagePlan == nil {
+		spec.ImagePlan = &apiv1.AzureImagePlan{Name: plan.Name, Publisher: plan.Publisher, Product: plan.Product}
+	}
+}
+
+// ValidateAzureNodeDefaults checks the node defaults of an Azure datacenter the same way as the
+// settings of node deployments.
+func ValidateAzureNodeDefaults(defaults *kubermaticv1.AzureNodeDefaults) error {
+	if defaults == nil {
+		return nil
+	}
+
+	if defaults.OSDiskSize < 0 {
+		return errors.New("the OS disk size must not be negative")
+	}
+	if err := validateDiskType(providerconfig.CloudProviderAzure, defaults.OSDiskType); err != nil {
+		return fmt.Errorf("invalid OS disk type: %v", err)
+	}
+
+	for osName, image := range defaults.Images {
+		if image.ID == "" && image.Reference == nil {
+			return fmt.Errorf("the image of operating system %q requires an image ID or an image reference", osName)
+		}
+
+		nd := &apiv1.NodeDeployment{}
+		nd.Spec.Template.Cloud.Azure = &apiv1.AzureNodeSpec{}
+		setAzureNodeImage(nd.Spec.Template.Cloud.Azure, image)
+		if err := ValidateAzureImage(nd); err != nil {
+			return fmt.Errorf("invalid image of operating system %q: %v", osName, err)
+		}
+	}
+
+	return nil
+}
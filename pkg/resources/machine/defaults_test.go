@@ -0,0 +1,220 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"testing"
+
+	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+func TestSetAzureNodeDefaults(t *testing.T) {
+	dc := &kubermaticv1.Datacenter{
+		Spec: kubermaticv1.DatacenterSpec{
+			Azure: &kubermaticv1.DatacenterSpecAzure{
+				NodeDefaults: &kubermaticv1.AzureNodeDefaults{
+					VMSize:     "Standard_D2s_v3",
+					OSDiskType: "Premium_LRS",
+					OSDiskSize: 64,
+					Images: map[providerconfig.OperatingSystem]kubermaticv1.AzureNodeImage{
+						providerconfig.OperatingSystemUbuntu: {
+							Reference: &kubermaticv1.AzureNodeImageReference{Publisher: "Canonical", Offer: "UbuntuServer", SKU: "18_04-lts-gen2"},
+							Plan:      &kubermaticv1.AzureNodeImagePlan{Name: "18_04-lts-gen2", Publisher: "Canonical", Product: "UbuntuServer"},
+						},
+						providerconfig.OperatingSystemCentOS: {
+							ID: "/subscriptions/sub/resourceGroups/images/providers/Microsoft.Compute/images/centos",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		spec           apiv1.NodeSpec
+		expectedAzure  apiv1.AzureNodeSpec
+		expectedLayout *apiv1.DiskLayout
+	}{
+		{
+			name: "all defaults",
+			spec: apiv1.NodeSpec{
+				Cloud:           apiv1.NodeCloudSpec{Azure: &apiv1.AzureNodeSpec{}},
+				OperatingSystem: apiv1.OperatingSystemSpec{Ubuntu: &apiv1.UbuntuSpec{}},
+			},
+			expectedAzure: apiv1.AzureNodeSpec{
+				Size:           "Standard_D2s_v3",
+				OSDiskSize:     64,
+				ImageReference: &apiv1.AzureImageReference{Publisher: "Canonical", Offer: "UbuntuServer", SKU: "18_04-lts-gen2"},
+				ImagePlan:      &apiv1.AzureImagePlan{Name: "18_04-lts-gen2", Publisher: "Canonical", Product: "UbuntuServer"},
+			},
+			expectedLayout: &apiv1.DiskLayout{OSDisk: &apiv1.OSDiskSpec{Type: "Premium_LRS"}},
+		},
+		{
+			name: "image ID of the operating system",
+			spec: apiv1.NodeSpec{
+				Cloud:           apiv1.NodeCloudSpec{Azure: &apiv1.AzureNodeSpec{Size: "Standard_B2s"}},
+				OperatingSystem: apiv1.OperatingSystemSpec{CentOS: &apiv1.CentOSSpec{}},
+			},
+			expectedAzure: apiv1.AzureNodeSpec{
+				Size:       "Standard_B2s",
+				OSDiskSize: 64,
+				ImageID:    "/subscriptions/sub/resourceGroups/images/providers/Microsoft.Compute/images/centos",
+			},
+			expectedLayout: &apiv1.DiskLayout{OSDisk: &apiv1.OSDiskSpec{Type: "Premium_LRS"}},
+		},
+		{
+			name: "no image for the operating system",
+			spec: apiv1.NodeSpec{
+				Cloud:           apiv1.NodeCloudSpec{Azure: &apiv1.AzureNodeSpec{}},
+				OperatingSystem: apiv1.OperatingSystemSpec{Flatcar: &apiv1.FlatcarSpec{}},
+			},
+			expectedAzure: apiv1.AzureNodeSpec{
+				Size:       "Standard_D2s_v3",
+				OSDiskSize: 64,
+			},
+			expectedLayout: &apiv1.DiskLayout{OSDisk: &apiv1.OSDiskSpec{Type: "Premium_LRS"}},
+		},
+		{
+			name: "settings of the node deployment take precedence",
+			spec: apiv1.NodeSpec{
+				Cloud: apiv1.NodeCloudSpec{Azure: &apiv1.AzureNodeSpec{
+					Size:    "Standard_B2s",
+					ImageID: "/subscriptions/sub/resourceGroups/images/providers/Microsoft.Compute/images/custom",
+				}},
+				OperatingSystem: apiv1.OperatingSystemSpec{Ubuntu: &apiv1.UbuntuSpec{}},
+				DiskLayout:      &apiv1.DiskLayout{OSDisk: &apiv1.OSDiskSpec{Size: 128, Type: "Standard_LRS"}},
+			},
+			expectedAzure: apiv1.AzureNodeSpec{
+				Size:    "Standard_B2s",
+				ImageID: "/subscriptions/sub/resourceGroups/images/providers/Microsoft.Compute/images/custom",
+			},
+			expectedLayout: &apiv1.DiskLayout{OSDisk: &apiv1.OSDiskSpec{Size: 128, Type: "Standard_LRS"}},
+		},
+		{
+			name: "OS disk size of the cloud spec",
+			spec: apiv1.NodeSpec{
+				Cloud:           apiv1.NodeCloudSpec{Azure: &apiv1.AzureNodeSpec{OSDiskSize: 100, ImageReference: &apiv1.AzureImageReference{Publisher: "p", Offer: "o", SKU: "s"}}},
+				OperatingSystem: apiv1.OperatingSystemSpec{Ubuntu: &apiv1.UbuntuSpec{}},
+			},
+			expectedAzure: apiv1.AzureNodeSpec{
+				Size:           "Standard_D2s_v3",
+				OSDiskSize:     100,
+				ImageReference: &apiv1.AzureImageReference{Publisher: "p", Offer: "o", SKU: "s"},
+			},
+			expectedLayout: &apiv1.DiskLayout{OSDisk: &apiv1.OSDiskSpec{Type: "Premium_LRS"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			nd := &apiv1.NodeDeployment{Spec: apiv1.NodeDeploymentSpec{Template: test.spec}}
+			SetAzureNodeDefaults(nd, dc)
+
+			if !equality.Semantic.DeepEqual(*nd.Spec.Template.Cloud.Azure, test.expectedAzure) {
+				t.Errorf("expected Azure spec %+v, got %+v", test.expectedAzure, *nd.Spec.Template.Cloud.Azure)
+			}
+			if !equality.Semantic.DeepEqual(nd.Spec.Template.DiskLayout, test.expectedLayout) {
+				t.Errorf("expected disk layout %+v, got %+v", test.expectedLayout, nd.Spec.Template.DiskLayout)
+			}
+		})
+	}
+}
+
+func TestSetAzureNodeDefaultsWithoutDefaults(t *testing.T) {
+	nd := &apiv1.NodeDeployment{Spec: apiv1.NodeDeploymentSpec{Template: apiv1.NodeSpec{
+		Cloud: apiv1.NodeCloudSpec{Azure: &apiv1.AzureNodeSpec{}},
+	}}}
+	SetAzureNodeDefaults(nd, &kubermaticv1.Datacenter{Spec: kubermaticv1.DatacenterSpec{Azure: &kubermaticv1.DatacenterSpecAzure{}}})
+
+	if !equality.Semantic.DeepEqual(*nd.Spec.Template.Cloud.Azure, apiv1.AzureNodeSpec{}) || nd.Spec.Template.DiskLayout != nil {
+		t.Fatalf("expected the node deployment to be unchanged, got %+v", nd.Spec.Template)
+	}
+}
+
+func TestValidateAzureNodeDefaults(t *testing.T) {
+	tests := []struct {
+		name     string
+		defaults *kubermaticv1.AzureNodeDefaults
+		wantErr  bool
+	}{
+		{
+			name: "no defaults",
+		},
+		{
+			name: "valid defaults",
+			defaults: &kubermaticv1.AzureNodeDefaults{
+				VMSize:     "Standard_D2s_v3",
+				OSDiskType: "StandardSSD_LRS",
+				OSDiskSize: 64,
+				Images: map[providerconfig.OperatingSystem]kubermaticv1.AzureNodeImage{
+					providerconfig.OperatingSystemUbuntu: {Reference: &kubermaticv1.AzureNodeImageReference{Publisher: "Canonical", Offer: "UbuntuServer", SKU: "18_04-lts-gen2"}},
+				},
+			},
+		},
+		{
+			name:     "unknown OS disk type",
+			defaults: &kubermaticv1.AzureNodeDefaults{OSDiskType: "gp3"},
+			wantErr:  true,
+		},
+		{
+			name:     "negative OS disk size",
+			defaults: &kubermaticv1.AzureNodeDefaults{OSDiskSize: -1},
+			wantErr:  true,
+		},
+		{
+			name: "empty image",
+			defaults: &kubermaticv1.AzureNodeDefaults{Images: map[providerconfig.OperatingSystem]kubermaticv1.AzureNodeImage{
+				providerconfig.OperatingSystemUbuntu: {},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "image ID and reference",
+			defaults: &kubermaticv1.AzureNodeDefaults{Images: map[providerconfig.OperatingSystem]kubermaticv1.AzureNodeImage{
+				providerconfig.OperatingSystemUbuntu: {
+					ID:        "/subscriptions/sub/resourceGroups/images/providers/Microsoft.Compute/images/ubuntu",
+					Reference: &kubermaticv1.AzureNodeImageReference{Publisher: "Canonical", Offer: "UbuntuServer", SKU: "18_04-lts-gen2"},
+				},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "incomplete image plan",
+			defaults: &kubermaticv1.AzureNodeDefaults{Images: map[providerconfig.OperatingSystem]kubermaticv1.AzureNodeImage{
+				providerconfig.OperatingSystemUbuntu: {
+					Reference: &kubermaticv1.AzureNodeImageReference{Publisher: "Canonical", Offer: "UbuntuServer", SKU: "18_04-lts-gen2"},
+					Plan:      &kubermaticv1.AzureNodeImagePlan{Name: "18_04-lts-gen2"},
+				},
+			}},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := ValidateAzureNodeDefaults(test.defaults); (err != nil) != test.wantErr {
+				t.Fatalf("expected error: %v, got %v", test.wantErr, err)
+			}
+		})
+	}
+}
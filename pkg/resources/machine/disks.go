@@ -0,0 +1,392 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	machineconversions "k8c.io/kubermatic/v2/pkg/machine"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/yaml"
+)
+
+// diskTypes are the disk types of the cloud providers supporting disk types. OpenStack is not
+// listed, as its volume types are defined by the operator of the cloud.
+var diskTypes = map[providerconfig.CloudProvider]sets.String{
+	providerconfig.CloudProviderAWS:     sets.NewString("gp2", "gp3", "io1", "io2", "st1", "sc1", "standard"),
+	providerconfig.CloudProviderAzure:   sets.NewString("Standard_LRS", "StandardSSD_LRS", "Premium_LRS"),
+	providerconfig.CloudProviderGoogle:  sets.NewString("pd-standard", "pd-balanced", "pd-ssd"),
+	providerconfig.CloudProviderAlibaba: sets.NewString("cloud", "cloud_efficiency", "cloud_ssd", "cloud_essd"),
+}
+
+const (
+	// LocalVolumesLabel is set on the nodes of node deployments with data disks for local persistent
+	// volumes, the local-volume-provisioner addon only runs on these nodes.
+	LocalVolumesLabel = "kubermatic.io/local-volumes"
+	// localVolumesDirectory is the directory the local-volume-provisioner addon discovers the
+	// filesystems of local persistent volumes in.
+	localVolumesDirectory = "/mnt/disks"
+)
+
+// reservedMountPoints are the directories of the operating system data disks must not be mounted at.
+var reservedMountPoints = sets.NewString("/", "/boot", "/dev", "/etc", "/proc", "/run", "/sys", "/usr", "/var")
+
+// nodeCloudProvider returns the cloud provider of the node spec.
+func nodeCloudProvider(cloud apiv1.NodeCloudSpec) providerconfig.CloudProvider {
+	switch {
+	case cloud.AWS != nil:
+		return providerconfig.CloudProviderAWS
+	case cloud.Azure != nil:
+		return providerconfig.CloudProviderAzure
+	case cloud.GCP != nil:
+		return providerconfig.CloudProviderGoogle
+	case cloud.Openstack != nil:
+		return providerconfig.CloudProviderOpenstack
+	case cloud.VSphere != nil:
+		return providerconfig.CloudProviderVsphere
+	case cloud.Alibaba != nil:
+		return providerconfig.CloudProviderAlibaba
+	case cloud.Anexia != nil:
+		return providerconfig.CloudProviderAnexia
+	case cloud.Digitalocean != nil:
+		return providerconfig.CloudProviderDigitalocean
+	case cloud.Hetzner != nil:
+		return providerconfig.CloudProviderHetzner
+	case cloud.Packet != nil:
+		return providerconfig.CloudProviderPacket
+	case cloud.Kubevirt != nil:
+		return providerconfig.CloudProviderKubeVirt
+	}
+	return ""
+}
+
+// validateDiskType checks that the cloud provider supports disks of the given type.
+func validateDiskType(cloudProvider providerconfig.CloudProvider, diskType string) error {
+	if diskType == "" || cloudProvider == providerconfig.CloudProviderOpenstack {
+		return nil
+	}
+
+	types, ok := diskTypes[cloudProvider]
+	if !ok {
+		return fmt.Errorf("disk types are not supported by the cloud provider")
+	}
+	if !types.Has(diskType) {
+		return fmt.Errorf("unsupported disk type '%s'. Allowed: %s", diskType, strings.Join(types.List(), ", "))
+	}
+
+	return nil
+}
+
+// ValidateDiskLayout checks that the disk layout of the node deployment is supported by its
+// cloud provider and that the mount points of the data disks are usable.
+func ValidateDiskLayout(nd *apiv1.NodeDeployment) error {
+	layout := nd.Spec.Template.DiskLayout
+	if layout == nil {
+		return nil
+	}
+	cloudProvider := nodeCloudProvider(nd.Spec.Template.Cloud)
+
+	if osDisk := layout.OSDisk; osDisk != nil {
+		switch cloudProvider {
+		case providerconfig.CloudProviderDigitalocean, providerconfig.CloudProviderHetzner, providerconfig.CloudProviderPacket, providerconfig.CloudProviderKubeVirt:
+			return errors.New("the OS disk cannot be configured for the cloud provider")
+		}
+		if osDisk.Size < 0 {
+			return errors.New("the OS disk size must not be negative")
+		}
+		if osDisk.Type != "" {
+			if cloudProvider == providerconfig.CloudProviderOpenstack && osDisk.Size == 0 {
+				return errors.New("an OS disk type requires the disk size to be set")
+			}
+			if err := validateDiskType(cloudProvider, osDisk.Type); err != nil {
+				return fmt.Errorf("invalid OS disk: %v", err)
+			}
+		}
+	}
+
+	if len(layout.DataDisks) == 0 {
+		return nil
+	}
+	if !machineconversions.SupportsDataDisks(cloudProvider) {
+		return errors.New("data disks are not supported by the cloud provider")
+	}
+	if len(layout.DataDisks) > machineconversions.MaxDataDisks {
+		return fmt.Errorf("at most %d data disks are supported", machineconversions.MaxDataDisks)
+	}
+	if azure := nd.Spec.Template.Cloud.Azure; azure != nil && azure.DataDiskSize > 0 {
+		return errors.New("data disks cannot be combined with the data disk size of Azure")
+	}
+
+	var mountPoints []string
+	for i, disk := range layout.DataDisks {
+		if disk.Size <= 0 {
+			return fmt.Errorf("the size of data disk %d must be positive", i)
+		}
+		if err := validateDiskType(cloudProvider, disk.Type); err != nil {
+			return fmt.Errorf("invalid data disk %d: %v", i, err)
+		}
+		switch disk.Filesystem {
+		case "", apiv1.DataDiskFilesystemExt4, apiv1.DataDiskFilesystemXFS:
+		default:
+			return fmt.Errorf("unsupported filesystem '%s' of data disk %d, must be '%s' or '%s'", disk.Filesystem, i, apiv1.DataDiskFilesystemExt4, apiv1.DataDiskFilesystemXFS)
+		}
+
+		mountPoint := disk.MountPoint
+		if !path.IsAbs(mountPoint) || path.Clean(mountPoint) != mountPoint {
+			return fmt.Errorf("the mount point '%s' of data disk %d must be a clean absolute path", mountPoint, i)
+		}
+		if reservedMountPoints.Has(mountPoint) {
+			return fmt.Errorf("data disk %d must not be mounted at '%s'", i, mountPoint)
+		}
+		// every filesystem below the directory is offered as a local persistent volume
+		if disk.LocalVolumes != (path.Dir(mountPoint) == localVolumesDirectory) {
+			return fmt.Errorf("data disk %d must be mounted directly below '%s' if, and only if, it is dedicated to local volumes", i, localVolumesDirectory)
+		}
+		// mount points within each other would depend on the order the disks are mounted in
+		for _, other := range mountPoints {
+			if mountPoint == other || strings.HasPrefix(mountPoint, other+"/") || strings.HasPrefix(other, mountPoint+"/") {
+				return fmt.Errorf("the mount point '%s' of data disk %d conflicts with '%s'", mountPoint, i, other)
+			}
+		}
+		mountPoints = append(mountPoints, mountPoint)
+	}
+
+	return nil
+}
+
+// hasLocalVolumes returns true if a data disk of the disk layout is dedicated to local volumes.
+func hasLocalVolumes(layout *apiv1.DiskLayout) bool {
+	if layout == nil {
+		return false
+	}
+	for _, disk := range layout.DataDisks {
+		if disk.LocalVolumes {
+			return true
+		}
+	}
+	return false
+}
+
+// withOSDisk returns the node spec with the OS disk of its disk layout applied to the disk settings
+// of the cloud provider. The provider specific settings of the given spec are left untouched.
+func withOSDisk(nodeSpec apiv1.NodeSpec) apiv1.NodeSpec {
+	if nodeSpec.DiskLayout == nil || nodeSpec.DiskLayout.OSDisk == nil {
+		return nodeSpec
+	}
+	osDisk := nodeSpec.DiskLayout.OSDisk
+	size := osDisk.Size
+	cloud := &nodeSpec.Cloud
+
+	switch {
+	case cloud.AWS != nil:
+		spec := *cloud.AWS
+		if size > 0 {
+			spec.VolumeSize = int64(size)
+		}
+		if osDisk.Type != "" {
+			spec.VolumeType = osDisk.Type
+		}
+		cloud.AWS = &spec
+	case cloud.Azure != nil:
+		// the type of the OS disk is set by getAzureProviderSpec
+		spec := *cloud.Azure
+		if size > 0 {
+			spec.OSDiskSize = size
+		}
+		cloud.Azure = &spec
+	case cloud.GCP != nil:
+		spec := *cloud.GCP
+		if size > 0 {
+			spec.DiskSize = int64(size)
+		}
+		if osDisk.Type != "" {
+			spec.DiskType = osDisk.Type
+		}
+		cloud.GCP = &spec
+	case cloud.Openstack != nil:
+		spec := *cloud.Openstack
+		if size > 0 {
+			rootDiskSize := int(size)
+			spec.RootDiskSizeGB = &rootDiskSize
+			spec.RootDiskVolumeType = osDisk.Type
+		}
+		cloud.Openstack = &spec
+	case cloud.VSphere != nil:
+		spec := *cloud.VSphere
+		if size > 0 {
+			diskSize := int64(size)
+			spec.DiskSizeGB = &diskSize
+		}
+		cloud.VSphere = &spec
+	case cloud.Alibaba != nil:
+		spec := *cloud.Alibaba
+		if size > 0 {
+			spec.DiskSize = strconv.Itoa(int(size))
+		}
+		if osDisk.Type != "" {
+			spec.DiskType = osDisk.Type
+		}
+		cloud.Alibaba = &spec
+	case cloud.Anexia != nil:
+		spec := *cloud.Anexia
+		if size > 0 {
+			spec.DiskSize = int64(size)
+		}
+		cloud.Anexia = &spec
+	}
+
+	return nodeSpec
+}
+
+// setDataDisks adds the data disks of the disk layout to the provider config.
+func setDataDisks(config *providerconfig.Config, layout *apiv1.DiskLayout) error {
+	if layout == nil || len(layout.DataDisks) == 0 {
+		return nil
+	}
+
+	disks, err := machineconversions.NewDataDisks(config.CloudProvider, layout.DataDisks)
+	if err != nil {
+		return err
+	}
+
+	return machineconversions.SetDataDisks(config, disks)
+}
+
+// dataDiskLabel returns the filesystem label of the data disk with the given index, which must
+// not exceed the 12 characters XFS allows.
+func dataDiskLabel(index int) string {
+	return fmt.Sprintf("data%d", index)
+}
+
+// addDataDiskMounts adds the formatting and mounting of the data disks to the rendered user data.
+func addDataDiskMounts(userData, format string, disks []machineconversions.DataDisk) (string, error) {
+	if len(disks) == 0 {
+		return userData, nil
+	}
+
+	if format == BootstrapConfigFormatCloudInit {
+		return addCloudInitDataDiskMounts(userData, disks)
+	}
+	return addIgnitionDataDiskMounts(userData, disks)
+}
+
+// addCloudInitDataDiskMounts appends the cloud-init modules creating the filesystems of the data
+// disks and mounting them, which run before the node is set up.
+func addCloudInitDataDiskMounts(userData string, disks []machineconversions.DataDisk) (string, error) {
+	var filesystems []map[string]interface{}
+	var mounts [][]string
+	for i, disk := range disks {
+		filesystems = append(filesystems, map[string]interface{}{
+			"label":      dataDiskLabel(i),
+			"filesystem": disk.Filesystem,
+			"device":     disk.Device,
+			"overwrite":  false,
+		})
+		mounts = append(mounts, []string{disk.Device, disk.MountPoint, disk.Filesystem, "defaults,nofail", "0", "2"})
+	}
+
+	modules, err := yaml.Marshal(map[string]interface{}{
+		"fs_setup": filesystems,
+		"mounts":   mounts,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal data disk mounts: %v", err)
+	}
+
+	if !strings.HasSuffix(userData, "\n") {
+		userData += "\n"
+	}
+	return userData + string(modules), nil
+}
+
+// addIgnitionDataDiskMounts adds the filesystems of the data disks and mount units for them to the
+// Ignition config.
+func addIgnitionDataDiskMounts(userData string, disks []machineconversions.DataDisk) (string, error) {
+	config := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(userData), &config); err != nil {
+		return "", fmt.Errorf("failed to parse Ignition config: %v", err)
+	}
+
+	storage := ignitionSection(config, "storage")
+	systemd := ignitionSection(config, "systemd")
+	filesystems, _ := storage["filesystems"].([]interface{})
+	units, _ := systemd["units"].([]interface{})
+
+	for i, disk := range disks {
+		filesystems = append(filesystems, map[string]interface{}{
+			"name": dataDiskLabel(i),
+			"mount": map[string]interface{}{
+				"device":         disk.Device,
+				"format":         disk.Filesystem,
+				"label":          dataDiskLabel(i),
+				"wipeFilesystem": false,
+			},
+		})
+		units = append(units, map[string]interface{}{
+			"name":    systemdMountUnitName(disk.MountPoint),
+			"enabled": true,
+			"contents": fmt.Sprintf("[Unit]\nBefore=local-fs.target\n[Mount]\nWhat=/dev/disk/by-label/%s\nWhere=%s\nType=%s\nOptions=defaults,nofail\n[Install]\nRequiredBy=local-fs.target\n",
+				dataDiskLabel(i), disk.MountPoint, disk.Filesystem),
+		})
+	}
+	storage["filesystems"] = filesystems
+	systemd["units"] = units
+
+	out, err := json.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal Ignition config: %v", err)
+	}
+
+	return string(out), nil
+}
+
+// ignitionSection returns the section of the Ignition config with the given name, it is added if missing.
+func ignitionSection(config map[string]interface{}, name string) map[string]interface{} {
+	section, ok := config[name].(map[string]interface{})
+	if !ok {
+		section = map[string]interface{}{}
+		config[name] = section
+	}
+	return section
+}
+
+// systemdMountUnitName returns the name of the systemd mount unit for the given path, which systemd
+// derives from the escaped path.
+func systemdMountUnitName(mountPoint string) string {
+	var name strings.Builder
+	for i, c := range []byte(strings.TrimPrefix(mountPoint, "/")) {
+		switch {
+		case c == '/':
+			name.WriteByte('-')
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '_', c == ':', c == '.' && i > 0:
+			name.WriteByte(c)
+		default:
+			fmt.Fprintf(&name, "\\x%02x", c)
+		}
+	}
+
+	return name.String() + ".mount"
+}
@@ -0,0 +1,220 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	machineconversions "k8c.io/kubermatic/v2/pkg/machine"
+)
+
+func TestValidateDiskLayout(t *testing.T) {
+	tests := []struct {
+		name    string
+		cloud   apiv1.NodeCloudSpec
+		layout  *apiv1.DiskLayout
+		wantErr bool
+	}{
+		{
+			name:  "no disk layout",
+			cloud: apiv1.NodeCloudSpec{Hetzner: &apiv1.HetznerNodeSpec{}},
+		},
+		{
+			name:  "OS disk and data disks on AWS",
+			cloud: apiv1.NodeCloudSpec{AWS: &apiv1.AWSNodeSpec{}},
+			layout: &apiv1.DiskLayout{
+				OSDisk: &apiv1.OSDiskSpec{Size: 50, Type: "gp3"},
+				DataDisks: []apiv1.DataDiskSpec{
+					{Size: 100, Type: "io2", MountPoint: "/var/lib/containerd"},
+					{Size: 200, MountPoint: "/mnt/disks/ssd0", Filesystem: apiv1.DataDiskFilesystemXFS, LocalVolumes: true},
+				},
+			},
+		},
+		{
+			name:    "OS disk on Hetzner",
+			cloud:   apiv1.NodeCloudSpec{Hetzner: &apiv1.HetznerNodeSpec{}},
+			layout:  &apiv1.DiskLayout{OSDisk: &apiv1.OSDiskSpec{Size: 50}},
+			wantErr: true,
+		},
+		{
+			name:    "unknown OS disk type",
+			cloud:   apiv1.NodeCloudSpec{Azure: &apiv1.AzureNodeSpec{}},
+			layout:  &apiv1.DiskLayout{OSDisk: &apiv1.OSDiskSpec{Type: "gp3"}},
+			wantErr: true,
+		},
+		{
+			name:    "OpenStack OS disk type without size",
+			cloud:   apiv1.NodeCloudSpec{Openstack: &apiv1.OpenstackNodeSpec{}},
+			layout:  &apiv1.DiskLayout{OSDisk: &apiv1.OSDiskSpec{Type: "ssd"}},
+			wantErr: true,
+		},
+		{
+			name:    "data disks on Alibaba",
+			cloud:   apiv1.NodeCloudSpec{Alibaba: &apiv1.AlibabaNodeSpec{}},
+			layout:  &apiv1.DiskLayout{DataDisks: []apiv1.DataDiskSpec{{Size: 100, MountPoint: "/data"}}},
+			wantErr: true,
+		},
+		{
+			name:    "data disks combined with the Azure data disk size",
+			cloud:   apiv1.NodeCloudSpec{Azure: &apiv1.AzureNodeSpec{DataDiskSize: 100}},
+			layout:  &apiv1.DiskLayout{DataDisks: []apiv1.DataDiskSpec{{Size: 100, MountPoint: "/data"}}},
+			wantErr: true,
+		},
+		{
+			name:    "data disk without size",
+			cloud:   apiv1.NodeCloudSpec{GCP: &apiv1.GCPNodeSpec{}},
+			layout:  &apiv1.DiskLayout{DataDisks: []apiv1.DataDiskSpec{{MountPoint: "/data"}}},
+			wantErr: true,
+		},
+		{
+			name:    "unsupported filesystem",
+			cloud:   apiv1.NodeCloudSpec{GCP: &apiv1.GCPNodeSpec{}},
+			layout:  &apiv1.DiskLayout{DataDisks: []apiv1.DataDiskSpec{{Size: 100, MountPoint: "/data", Filesystem: "btrfs"}}},
+			wantErr: true,
+		},
+		{
+			name:    "relative mount point",
+			cloud:   apiv1.NodeCloudSpec{VSphere: &apiv1.VSphereNodeSpec{}},
+			layout:  &apiv1.DiskLayout{DataDisks: []apiv1.DataDiskSpec{{Size: 100, MountPoint: "data"}}},
+			wantErr: true,
+		},
+		{
+			name:    "local volumes outside of the local volumes directory",
+			cloud:   apiv1.NodeCloudSpec{VSphere: &apiv1.VSphereNodeSpec{}},
+			layout:  &apiv1.DiskLayout{DataDisks: []apiv1.DataDiskSpec{{Size: 100, MountPoint: "/data", LocalVolumes: true}}},
+			wantErr: true,
+		},
+		{
+			name:    "local volumes nested in the local volumes directory",
+			cloud:   apiv1.NodeCloudSpec{VSphere: &apiv1.VSphereNodeSpec{}},
+			layout:  &apiv1.DiskLayout{DataDisks: []apiv1.DataDiskSpec{{Size: 100, MountPoint: "/mnt/disks/ssd/0", LocalVolumes: true}}},
+			wantErr: true,
+		},
+		{
+			name:    "data disk in the local volumes directory",
+			cloud:   apiv1.NodeCloudSpec{VSphere: &apiv1.VSphereNodeSpec{}},
+			layout:  &apiv1.DiskLayout{DataDisks: []apiv1.DataDiskSpec{{Size: 100, MountPoint: "/mnt/disks/ssd0"}}},
+			wantErr: true,
+		},
+		{
+			name:    "reserved mount point",
+			cloud:   apiv1.NodeCloudSpec{VSphere: &apiv1.VSphereNodeSpec{}},
+			layout:  &apiv1.DiskLayout{DataDisks: []apiv1.DataDiskSpec{{Size: 100, MountPoint: "/var"}}},
+			wantErr: true,
+		},
+		{
+			name:  "nested mount points",
+			cloud: apiv1.NodeCloudSpec{VSphere: &apiv1.VSphereNodeSpec{}},
+			layout: &apiv1.DiskLayout{DataDisks: []apiv1.DataDiskSpec{
+				{Size: 100, MountPoint: "/var/lib/containerd"},
+				{Size: 100, MountPoint: "/var/lib"},
+			}},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			nd := &apiv1.NodeDeployment{}
+			nd.Spec.Template.Cloud = test.cloud
+			nd.Spec.Template.DiskLayout = test.layout
+
+			err := ValidateDiskLayout(nd)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("expected error %t, got %v", test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestWithOSDisk(t *testing.T) {
+	aws := &apiv1.AWSNodeSpec{VolumeSize: 25, VolumeType: "gp2"}
+	nodeSpec := apiv1.NodeSpec{
+		Cloud:      apiv1.NodeCloudSpec{AWS: aws},
+		DiskLayout: &apiv1.DiskLayout{OSDisk: &apiv1.OSDiskSpec{Size: 50, Type: "gp3"}},
+	}
+
+	got := withOSDisk(nodeSpec).Cloud.AWS
+	if got.VolumeSize != 50 || got.VolumeType != "gp3" {
+		t.Errorf("expected OS disk of 50 GB gp3, got %d GB %s", got.VolumeSize, got.VolumeType)
+	}
+	if aws.VolumeSize != 25 || aws.VolumeType != "gp2" {
+		t.Error("expected the given node spec to be left untouched")
+	}
+}
+
+func TestAddDataDiskMounts(t *testing.T) {
+	disks := []machineconversions.DataDisk{
+		{Size: 100, Device: "/dev/sdf", MountPoint: "/var/lib/containerd", Filesystem: "ext4"},
+		{Size: 200, Device: "/dev/sdg", MountPoint: "/mnt/disks/local-ssd", Filesystem: "xfs"},
+	}
+
+	t.Run("cloud-init", func(t *testing.T) {
+		userData, err := addDataDiskMounts("#cloud-config\nruncmd:\n- echo\n", BootstrapConfigFormatCloudInit, disks)
+		if err != nil {
+			t.Fatalf("failed to add mounts: %v", err)
+		}
+		for _, want := range []string{"fs_setup:", "device: /dev/sdf", "filesystem: xfs", "mounts:", "- /var/lib/containerd", "runcmd:"} {
+			if !strings.Contains(userData, want) {
+				t.Errorf("expected user data to contain %q, got:\n%s", want, userData)
+			}
+		}
+	})
+
+	t.Run("Ignition", func(t *testing.T) {
+		userData, err := addDataDiskMounts(`{"ignition":{"version":"2.2.0"},"systemd":{"units":[{"name":"kubelet.service"}]}}`, BootstrapConfigFormatIgnition, disks)
+		if err != nil {
+			t.Fatalf("failed to add mounts: %v", err)
+		}
+
+		config := struct {
+			Storage struct {
+				Filesystems []struct {
+					Mount struct {
+						Device string `json:"device"`
+						Format string `json:"format"`
+					} `json:"mount"`
+				} `json:"filesystems"`
+			} `json:"storage"`
+			Systemd struct {
+				Units []struct {
+					Name     string `json:"name"`
+					Contents string `json:"contents"`
+				} `json:"units"`
+			} `json:"systemd"`
+		}{}
+		if err := json.Unmarshal([]byte(userData), &config); err != nil {
+			t.Fatalf("failed to parse Ignition config: %v", err)
+		}
+
+		if n := len(config.Storage.Filesystems); n != 2 {
+			t.Fatalf("expected 2 filesystems, got %d", n)
+		}
+		if fs := config.Storage.Filesystems[1].Mount; fs.Device != "/dev/sdg" || fs.Format != "xfs" {
+			t.Errorf("unexpected filesystem %+v", fs)
+		}
+		if n := len(config.Systemd.Units); n != 3 {
+			t.Fatalf("expected 3 units, got %d", n)
+		}
+		if unit := config.Systemd.Units[2]; unit.Name != `mnt-disks-local\x2dssd.mount` || !strings.Contains(unit.Contents, "Where=/mnt/disks/local-ssd") {
+			t.Errorf("unexpected mount unit %+v", unit)
+		}
+	})
+}
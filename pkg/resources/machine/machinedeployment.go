@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/Masterminds/semver/v3"
@@ -28,6 +29,9 @@ import (
 	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
 	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	machineconversions "k8c.io/kubermatic/v2/pkg/machine"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/provider/cloud/azure"
 	"k8c.io/kubermatic/v2/pkg/resources"
 	"k8c.io/kubermatic/v2/pkg/resources/cloudconfig"
 	"k8c.io/kubermatic/v2/pkg/validation"
@@ -40,6 +44,10 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 )
 
+// NvidiaGPULabel is set on the nodes of Azure node deployments with NVIDIA GPU sizes, the
+// nvidia-gpu-driver addon installs the driver and the device plugin on these nodes.
+const NvidiaGPULabel = "kubermatic.io/nvidia-gpu"
+
 // Deployment returns a Machine Deployment object for the given Node Deployment spec.
 func Deployment(c *kubermaticv1.Cluster, nd *apiv1.NodeDeployment, dc *kubermaticv1.Datacenter, keys []*kubermaticv1.UserSSHKey, data resources.CredentialsData) (*clusterv1alpha1.MachineDeployment, error) {
 	md := &clusterv1alpha1.MachineDeployment{}
@@ -68,6 +76,22 @@ func Deployment(c *kubermaticv1.Cluster, nd *apiv1.NodeDeployment, dc *kubermati
 	if ok {
 		md.Spec.Template.Spec.Labels["system/project"] = projectID
 	}
+	if hasLocalVolumes(nd.Spec.Template.DiskLayout) {
+		md.Spec.Template.Spec.Labels[LocalVolumesLabel] = "true"
+	}
+	if azureSpec := nd.Spec.Template.Cloud.Azure; azureSpec != nil && azure.IsNvidiaGPUSize(azureSpec.Size) {
+		md.Spec.Template.Spec.Labels[NvidiaGPULabel] = "true"
+	}
+	if len(c.Spec.AdditionalClouds) > 0 {
+		providerName, err := provider.ClusterCloudProviderName(c.Spec.Cloud)
+		if err != nil {
+			return nil, err
+		}
+		md.Spec.Template.Spec.Labels[machineconversions.CloudProviderLabel] = providerName
+	}
+	if c.Spec.AdditionalCloud(nd.Spec.Template.Datacenter) != nil {
+		machineconversions.SetDatacenter(md, nd.Spec.Template.Datacenter)
+	}
 
 	var taints []corev1.Taint
 	for _, taint := range nd.Spec.Template.Taints {
@@ -111,11 +135,32 @@ func Deployment(c *kubermaticv1.Cluster, nd *apiv1.NodeDeployment, dc *kubermati
 		md.Spec.Paused = *nd.Spec.Paused
 	}
 
-	config, err := getProviderConfig(c, nd, dc, keys, data)
+	if nd.Spec.ReplicasFrozen != nil && *nd.Spec.ReplicasFrozen {
+		machineconversions.FreezeReplicas(md)
+	}
+
+	machineconversions.SetFallbackInstanceTypes(md, nd.Spec.FallbackInstanceTypes)
+	if err := machineconversions.SetCanaryRollout(md, nd.Spec.CanaryRollout); err != nil {
+		return nil, err
+	}
+
+	if err := machineconversions.SetDiskLayout(md, nd.Spec.Template.DiskLayout); err != nil {
+		return nil, err
+	}
+
+	// the OS disk of the disk layout takes precedence over the disk settings of the cloud provider
+	withDisks := *nd
+	withDisks.Spec.Template = withOSDisk(nd.Spec.Template)
+
+	config, err := getProviderConfig(c, &withDisks, dc, keys, data)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := setDataDisks(config, nd.Spec.Template.DiskLayout); err != nil {
+		return nil, err
+	}
+
 	err = getProviderOS(config, nd)
 	if err != nil {
 		return nil, err
@@ -157,7 +202,7 @@ func getProviderConfig(c *kubermaticv1.Cluster, nd *apiv1.NodeDeployment, dc *ku
 		}
 	case nd.Spec.Template.Cloud.Azure != nil:
 		config.CloudProvider = providerconfig.CloudProviderAzure
-		cloudExt, err = getAzureProviderSpec(c, nd.Spec.Template, dc)
+		cloudExt, err = getAzureProviderSpec(c, nd.Name, nd.Spec.Template, dc)
 		if err != nil {
 			return nil, err
 		}
@@ -233,6 +278,18 @@ func getProviderConfig(c *kubermaticv1.Cluster, nd *apiv1.NodeDeployment, dc *ku
 	}
 	config.CloudProviderSpec = *cloudExt
 
+	// The nodes of additional clouds cannot use the cloud config of the cluster, which is
+	// generated for its own cloud.
+	if config.OverwriteCloudConfig == nil && c.Spec.AdditionalCloud(nd.Spec.Template.Datacenter) != nil {
+		cloudConfig, err := cloudconfig.CloudConfig(c, dc, credentials)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate the cloud config of datacenter %q: %v", nd.Spec.Template.Datacenter, err)
+		}
+		if cloudConfig != "" {
+			config.OverwriteCloudConfig = &cloudConfig
+		}
+	}
+
 	return &config, nil
 }
 
@@ -332,5 +389,179 @@ func Validate(nd *apiv1.NodeDeployment, controlPlaneVersion *semver.Version) (*a
 		}
 	}
 
+	if err := ValidateFallbackInstanceTypes(nd); err != nil {
+		return nil, err
+	}
+
+	if err := ValidateOpenstackRootDisk(nd); err != nil {
+		return nil, err
+	}
+
+	if err := ValidateCanaryRollout(nd); err != nil {
+		return nil, err
+	}
+
+	if err := ValidateDiskLayout(nd); err != nil {
+		return nil, err
+	}
+
+	if err := ValidateAzureSecurityProfile(nd); err != nil {
+		return nil, err
+	}
+
+	if err := ValidateAzureImage(nd); err != nil {
+		return nil, err
+	}
+
 	return nd, nil
 }
+
+// ValidateOpenstackRootDisk checks that a volume type is only set for root disks which are volumes.
+func ValidateOpenstackRootDisk(nd *apiv1.NodeDeployment) error {
+	spec := nd.Spec.Template.Cloud.Openstack
+	if spec == nil || spec.RootDiskVolumeType == "" {
+		return nil
+	}
+	if spec.RootDiskSizeGB == nil || *spec.RootDiskSizeGB <= 0 {
+		return errors.New("a root disk volume type requires the disk size to be set")
+	}
+	return nil
+}
+
+// ValidateAzureSecurityProfile checks that the image generation and the trusted launch settings
+// of Azure node deployments are consistent. Whether the VM size supports them is checked against
+// the Azure API when the node deployment is created.
+func ValidateAzureSecurityProfile(nd *apiv1.NodeDeployment) error {
+	spec := nd.Spec.Template.Cloud.Azure
+	if spec == nil {
+		return nil
+	}
+
+	switch spec.HyperVGeneration {
+	case "", apiv1.AzureHyperVGenerationV1, apiv1.AzureHyperVGenerationV2:
+	default:
+		return fmt.Errorf("unsupported Hyper-V generation %q, must be %q or %q", spec.HyperVGeneration, apiv1.AzureHyperVGenerationV1, apiv1.AzureHyperVGenerationV2)
+	}
+
+	switch spec.SecurityType {
+	case "":
+		if spec.EnableSecureBoot || spec.EnableVTPM {
+			return fmt.Errorf("secure boot and vTPM require the security type %q", apiv1.AzureSecurityTypeTrustedLaunch)
+		}
+	case apiv1.AzureSecurityTypeTrustedLaunch:
+		if spec.HyperVGeneration != apiv1.AzureHyperVGenerationV2 {
+			return fmt.Errorf("trusted launch requires a %s image", apiv1.AzureHyperVGenerationV2)
+		}
+	default:
+		return fmt.Errorf("unsupported security type %q, only %q is supported", spec.SecurityType, apiv1.AzureSecurityTypeTrustedLaunch)
+	}
+
+	return nil
+}
+
+// ValidateAzureNodeSecurityPolicy checks that an Azure node deployment uses the security settings
+// the cluster requires for its nodes.
+func ValidateAzureNodeSecurityPolicy(c *kubermaticv1.Cluster, nd *apiv1.NodeDeployment) error {
+	spec := nd.Spec.Template.Cloud.Azure
+	if spec == nil || c.Spec.Cloud.Azure == nil || c.Spec.Cloud.Azure.NodeSecurity == nil {
+		return nil
+	}
+	policy := c.Spec.Cloud.Azure.NodeSecurity
+
+	if policy.EncryptionAtHost && !spec.EnableEncryptionAtHost {
+		return errors.New("the cluster requires encryption at host for its nodes")
+	}
+	if policy.TrustedLaunch && spec.SecurityType != apiv1.AzureSecurityTypeTrustedLaunch {
+		return fmt.Errorf("the cluster requires its nodes to use the security type %q", apiv1.AzureSecurityTypeTrustedLaunch)
+	}
+	if policy.SecureBoot && !spec.EnableSecureBoot {
+		return errors.New("the cluster requires secure boot for its nodes")
+	}
+	if policy.VTPM && !spec.EnableVTPM {
+		return errors.New("the cluster requires vTPM for its nodes")
+	}
+
+	return nil
+}
+
+// azureImageIDRegexp matches the IDs of managed images and of image definitions and versions of
+// Shared Image Galleries.
+var azureImageIDRegexp = regexp.MustCompile(`(?i)^/subscriptions/[^/]+/resourceGroups/[^/]+/providers/Microsoft\.Compute/(images/[^/]+|galleries/[^/]+/images/[^/]+(/versions/[^/]+)?)$`)
+
+// ValidateAzureImage checks that Azure node deployments reference their image in a single way and
+// that marketplace images and plans are complete. Whether the image exists and the terms of its
+// plan are accepted is checked against the Azure API when the node deployment is created.
+func ValidateAzureImage(nd *apiv1.NodeDeployment) error {
+	spec := nd.Spec.Template.Cloud.Azure
+	if spec == nil {
+		return nil
+	}
+
+	if spec.ImageID != "" {
+		if spec.ImageReference != nil {
+			return errors.New("an image ID and an image reference cannot be combined")
+		}
+		if !azureImageIDRegexp.MatchString(spec.ImageID) {
+			return fmt.Errorf("image ID %q is neither the ID of a managed image nor of a gallery image", spec.ImageID)
+		}
+	}
+
+	if ref := spec.ImageReference; ref != nil && (ref.Publisher == "" || ref.Offer == "" || ref.SKU == "") {
+		return errors.New("the image reference requires a publisher, an offer and a SKU")
+	}
+
+	if plan := spec.ImagePlan; plan != nil {
+		if spec.ImageID == "" && spec.ImageReference == nil {
+			return errors.New("an image plan requires an image ID or an image reference")
+		}
+		if plan.Name == "" || plan.Publisher == "" || plan.Product == "" {
+			return errors.New("the image plan requires a name, a publisher and a product")
+		}
+	}
+
+	return nil
+}
+
+// ValidateCanaryRollout checks that canary rollouts are supported by the cloud provider of the
+// node deployment and that their settings are valid.
+func ValidateCanaryRollout(nd *apiv1.NodeDeployment) error {
+	settings := nd.Spec.CanaryRollout
+	if settings == nil {
+		return nil
+	}
+
+	cloud := nd.Spec.Template.Cloud
+	if cloud.AWS == nil && cloud.Azure == nil && cloud.GCP == nil && cloud.Openstack == nil && cloud.Hetzner == nil && cloud.VSphere == nil {
+		return errors.New("canary rollouts are not supported by the cloud provider")
+	}
+	if settings.Replicas < 0 {
+		return errors.New("the number of canary replicas must not be negative")
+	}
+	if settings.TimeoutMinutes < 0 {
+		return errors.New("the canary rollout timeout must not be negative")
+	}
+	if settings.ValidationImage == "" && len(settings.ValidationCommand) > 0 {
+		return errors.New("a validation command requires a validation image")
+	}
+
+	return nil
+}
+
+// ValidateFallbackInstanceTypes checks that the fallback instance types of the node deployment
+// are supported by its cloud provider.
+func ValidateFallbackInstanceTypes(nd *apiv1.NodeDeployment) error {
+	if len(nd.Spec.FallbackInstanceTypes) == 0 {
+		return nil
+	}
+
+	if nd.Spec.Template.Cloud.VSphere != nil || nd.Spec.Template.Cloud.Kubevirt != nil || nd.Spec.Template.Cloud.Anexia != nil {
+		return errors.New("fallback instance types are not supported by the cloud provider")
+	}
+	for _, instanceType := range nd.Spec.FallbackInstanceTypes {
+		if instanceType == "" || strings.Contains(instanceType, ",") {
+			return fmt.Errorf("invalid fallback instance type '%s'", instanceType)
+		}
+	}
+
+	return nil
+}
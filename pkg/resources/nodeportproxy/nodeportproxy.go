@@ -50,6 +50,14 @@ const (
 	// exposed and the hostname, this is only used when the ExposeType is
 	// SNIType.
 	PortHostMappingAnnotationKey = "nodeport-proxy.k8s.io/port-mapping"
+
+	// annotations understood by the Azure cloud provider of the seed cluster, used to publish
+	// the front LoadBalancer of private clusters via Private Link
+	azureInternalLoadBalancerAnnotation    = "service.beta.kubernetes.io/azure-load-balancer-internal"
+	azurePrivateLinkCreateAnnotation       = "service.beta.kubernetes.io/azure-pls-create"
+	azurePrivateLinkNameAnnotation         = "service.beta.kubernetes.io/azure-pls-name"
+	azurePrivateLinkVisibilityAnnotation   = "service.beta.kubernetes.io/azure-pls-visibility"
+	azurePrivateLinkAutoApprovalAnnotation = "service.beta.kubernetes.io/azure-pls-auto-approval"
 )
 
 // ExposeType defines the strategy used to expose the service.
@@ -416,12 +424,31 @@ func podDisruptionBudget() reconciling.NamedPodDisruptionBudgetCreatorGetter {
 
 // FrontLoadBalancerServiceCreator returns the creator for the LoadBalancer that fronts apiserver
 // and openVPN when using exposeStrategy=LoadBalancer
-func FrontLoadBalancerServiceCreator() reconciling.NamedServiceCreatorGetter {
+func FrontLoadBalancerServiceCreator(data *resources.TemplateData) reconciling.NamedServiceCreatorGetter {
 	return func() (string, reconciling.ServiceCreator) {
 		return resources.FrontLoadBalancerServiceName, func(s *corev1.Service) (*corev1.Service, error) {
 			// We don't actually manage this service, that is done by the nodeport proxy, we just
 			// must make sure that it exists
 			s.Spec.Type = corev1.ServiceTypeLoadBalancer
+
+			// Private Azure clusters are only reachable via an internal LoadBalancer, which
+			// is published to the cluster's subscription via Private Link.
+			if resources.IsAzurePrivateCluster(data.Cluster()) {
+				credentials, err := resources.GetAzureCredentials(data)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get Azure credentials: %v", err)
+				}
+
+				if s.Annotations == nil {
+					s.Annotations = map[string]string{}
+				}
+				s.Annotations[azureInternalLoadBalancerAnnotation] = "true"
+				s.Annotations[azurePrivateLinkCreateAnnotation] = "true"
+				s.Annotations[azurePrivateLinkNameAnnotation] = resources.AzurePrivateLinkServiceName(data.Cluster())
+				s.Annotations[azurePrivateLinkVisibilityAnnotation] = credentials.SubscriptionID
+				s.Annotations[azurePrivateLinkAutoApprovalAnnotation] = credentials.SubscriptionID
+			}
+
 			// Services need at least one port to be valid, so create it initially
 			if len(s.Spec.Ports) == 0 {
 				s.Spec.Ports = []corev1.ServicePort{
@@ -70,6 +70,41 @@ func ImagePullSecretsWrapper(secretNames ...string) ObjectModifier {
 	}
 }
 
+// ImageRewriteWrapper is generating a new ObjectModifier that wraps an ObjectCreator
+// and rewrites the images of all containers using the given function.
+func ImageRewriteWrapper(rewrite func(string) string) ObjectModifier {
+	return func(create ObjectCreator) ObjectCreator {
+		return func(existing ctrlruntimeclient.Object) (ctrlruntimeclient.Object, error) {
+			obj, err := create(existing)
+			if err != nil {
+				return obj, err
+			}
+			switch o := obj.(type) {
+			case *appsv1.Deployment:
+				rewriteImages(&o.Spec.Template.Spec, rewrite)
+			case *appsv1.StatefulSet:
+				rewriteImages(&o.Spec.Template.Spec, rewrite)
+			case *appsv1.DaemonSet:
+				rewriteImages(&o.Spec.Template.Spec, rewrite)
+			case *batchv1beta1.CronJob:
+				rewriteImages(&o.Spec.JobTemplate.Spec.Template.Spec, rewrite)
+			default:
+				return o, fmt.Errorf(`type %q is not supported by ImageRewriteWrapper`, o.GetObjectKind().GroupVersionKind())
+			}
+			return obj, nil
+		}
+	}
+}
+
+func rewriteImages(podSpec *corev1.PodSpec, rewrite func(string) string) {
+	for i := range podSpec.InitContainers {
+		podSpec.InitContainers[i].Image = rewrite(podSpec.InitContainers[i].Image)
+	}
+	for i := range podSpec.Containers {
+		podSpec.Containers[i].Image = rewrite(podSpec.Containers[i].Image)
+	}
+}
+
 func configureImagePullSecrets(podSpec *corev1.PodSpec, secretNames []string) {
 	// Only configure image pull secrets when provided in the configuration.
 	currentSecretNames := sets.NewString()
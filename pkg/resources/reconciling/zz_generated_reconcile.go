@@ -15,6 +15,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	networkingv1beta1 "k8s.io/api/networking/v1beta1"
 	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -208,6 +209,117 @@ func ReconcileServiceAccounts(ctx context.Context, namedGetters []NamedServiceAc
 	return nil
 }
 
+// ResourceQuotaCreator defines an interface to create/update ResourceQuotas
+type ResourceQuotaCreator = func(existing *corev1.ResourceQuota) (*corev1.ResourceQuota, error)
+
+// NamedResourceQuotaCreatorGetter returns the name of the resource and the corresponding creator function
+type NamedResourceQuotaCreatorGetter = func() (name string, create ResourceQuotaCreator)
+
+// ResourceQuotaObjectWrapper adds a wrapper so the ResourceQuotaCreator matches ObjectCreator.
+// This is needed as Go does not support function interface matching.
+func ResourceQuotaObjectWrapper(create ResourceQuotaCreator) ObjectCreator {
+	return func(existing ctrlruntimeclient.Object) (ctrlruntimeclient.Object, error) {
+		if existing != nil {
+			return create(existing.(*corev1.ResourceQuota))
+		}
+		return create(&corev1.ResourceQuota{})
+	}
+}
+
+// ReconcileResourceQuotas will create and update the ResourceQuotas coming from the passed ResourceQuotaCreator slice
+func ReconcileResourceQuotas(ctx context.Context, namedGetters []NamedResourceQuotaCreatorGetter, namespace string, client ctrlruntimeclient.Client, objectModifiers ...ObjectModifier) error {
+	for _, get := range namedGetters {
+		name, create := get()
+		createObject := ResourceQuotaObjectWrapper(create)
+		createObject = createWithNamespace(createObject, namespace)
+		createObject = createWithName(createObject, name)
+
+		for _, objectModifier := range objectModifiers {
+			createObject = objectModifier(createObject)
+		}
+
+		if err := EnsureNamedObject(ctx, types.NamespacedName{Namespace: namespace, Name: name}, createObject, client, &corev1.ResourceQuota{}, false); err != nil {
+			return fmt.Errorf("failed to ensure ResourceQuota %s/%s: %v", namespace, name, err)
+		}
+	}
+
+	return nil
+}
+
+// LimitRangeCreator defines an interface to create/update LimitRanges
+type LimitRangeCreator = func(existing *corev1.LimitRange) (*corev1.LimitRange, error)
+
+// NamedLimitRangeCreatorGetter returns the name of the resource and the corresponding creator function
+type NamedLimitRangeCreatorGetter = func() (name string, create LimitRangeCreator)
+
+// LimitRangeObjectWrapper adds a wrapper so the LimitRangeCreator matches ObjectCreator.
+// This is needed as Go does not support function interface matching.
+func LimitRangeObjectWrapper(create LimitRangeCreator) ObjectCreator {
+	return func(existing ctrlruntimeclient.Object) (ctrlruntimeclient.Object, error) {
+		if existing != nil {
+			return create(existing.(*corev1.LimitRange))
+		}
+		return create(&corev1.LimitRange{})
+	}
+}
+
+// ReconcileLimitRanges will create and update the LimitRanges coming from the passed LimitRangeCreator slice
+func ReconcileLimitRanges(ctx context.Context, namedGetters []NamedLimitRangeCreatorGetter, namespace string, client ctrlruntimeclient.Client, objectModifiers ...ObjectModifier) error {
+	for _, get := range namedGetters {
+		name, create := get()
+		createObject := LimitRangeObjectWrapper(create)
+		createObject = createWithNamespace(createObject, namespace)
+		createObject = createWithName(createObject, name)
+
+		for _, objectModifier := range objectModifiers {
+			createObject = objectModifier(createObject)
+		}
+
+		if err := EnsureNamedObject(ctx, types.NamespacedName{Namespace: namespace, Name: name}, createObject, client, &corev1.LimitRange{}, false); err != nil {
+			return fmt.Errorf("failed to ensure LimitRange %s/%s: %v", namespace, name, err)
+		}
+	}
+
+	return nil
+}
+
+// PersistentVolumeClaimCreator defines an interface to create/update PersistentVolumeClaims
+type PersistentVolumeClaimCreator = func(existing *corev1.PersistentVolumeClaim) (*corev1.PersistentVolumeClaim, error)
+
+// NamedPersistentVolumeClaimCreatorGetter returns the name of the resource and the corresponding creator function
+type NamedPersistentVolumeClaimCreatorGetter = func() (name string, create PersistentVolumeClaimCreator)
+
+// PersistentVolumeClaimObjectWrapper adds a wrapper so the PersistentVolumeClaimCreator matches ObjectCreator.
+// This is needed as Go does not support function interface matching.
+func PersistentVolumeClaimObjectWrapper(create PersistentVolumeClaimCreator) ObjectCreator {
+	return func(existing ctrlruntimeclient.Object) (ctrlruntimeclient.Object, error) {
+		if existing != nil {
+			return create(existing.(*corev1.PersistentVolumeClaim))
+		}
+		return create(&corev1.PersistentVolumeClaim{})
+	}
+}
+
+// ReconcilePersistentVolumeClaims will create and update the PersistentVolumeClaims coming from the passed PersistentVolumeClaimCreator slice
+func ReconcilePersistentVolumeClaims(ctx context.Context, namedGetters []NamedPersistentVolumeClaimCreatorGetter, namespace string, client ctrlruntimeclient.Client, objectModifiers ...ObjectModifier) error {
+	for _, get := range namedGetters {
+		name, create := get()
+		createObject := PersistentVolumeClaimObjectWrapper(create)
+		createObject = createWithNamespace(createObject, namespace)
+		createObject = createWithName(createObject, name)
+
+		for _, objectModifier := range objectModifiers {
+			createObject = objectModifier(createObject)
+		}
+
+		if err := EnsureNamedObject(ctx, types.NamespacedName{Namespace: namespace, Name: name}, createObject, client, &corev1.PersistentVolumeClaim{}, false); err != nil {
+			return fmt.Errorf("failed to ensure PersistentVolumeClaim %s/%s: %v", namespace, name, err)
+		}
+	}
+
+	return nil
+}
+
 // StatefulSetCreator defines an interface to create/update StatefulSets
 type StatefulSetCreator = func(existing *appsv1.StatefulSet) (*appsv1.StatefulSet, error)
 
@@ -767,6 +879,43 @@ func ReconcileIngresses(ctx context.Context, namedGetters []NamedIngressCreatorG
 	return nil
 }
 
+// NetworkPolicyCreator defines an interface to create/update NetworkPolicys
+type NetworkPolicyCreator = func(existing *networkingv1.NetworkPolicy) (*networkingv1.NetworkPolicy, error)
+
+// NamedNetworkPolicyCreatorGetter returns the name of the resource and the corresponding creator function
+type NamedNetworkPolicyCreatorGetter = func() (name string, create NetworkPolicyCreator)
+
+// NetworkPolicyObjectWrapper adds a wrapper so the NetworkPolicyCreator matches ObjectCreator.
+// This is needed as Go does not support function interface matching.
+func NetworkPolicyObjectWrapper(create NetworkPolicyCreator) ObjectCreator {
+	return func(existing ctrlruntimeclient.Object) (ctrlruntimeclient.Object, error) {
+		if existing != nil {
+			return create(existing.(*networkingv1.NetworkPolicy))
+		}
+		return create(&networkingv1.NetworkPolicy{})
+	}
+}
+
+// ReconcileNetworkPolicies will create and update the NetworkPolicies coming from the passed NetworkPolicyCreator slice
+func ReconcileNetworkPolicies(ctx context.Context, namedGetters []NamedNetworkPolicyCreatorGetter, namespace string, client ctrlruntimeclient.Client, objectModifiers ...ObjectModifier) error {
+	for _, get := range namedGetters {
+		name, create := get()
+		createObject := NetworkPolicyObjectWrapper(create)
+		createObject = createWithNamespace(createObject, namespace)
+		createObject = createWithName(createObject, name)
+
+		for _, objectModifier := range objectModifiers {
+			createObject = objectModifier(createObject)
+		}
+
+		if err := EnsureNamedObject(ctx, types.NamespacedName{Namespace: namespace, Name: name}, createObject, client, &networkingv1.NetworkPolicy{}, false); err != nil {
+			return fmt.Errorf("failed to ensure NetworkPolicy %s/%s: %v", namespace, name, err)
+		}
+	}
+
+	return nil
+}
+
 // SeedCreator defines an interface to create/update Seeds
 type SeedCreator = func(existing *kubermaticv1.Seed) (*kubermaticv1.Seed, error)
 
@@ -1136,3 +1285,77 @@ func ReconcileKubermaticV1ClusterTemplates(ctx context.Context, namedGetters []N
 
 	return nil
 }
+
+// KubermaticV1PresetCreator defines an interface to create/update Presets
+type KubermaticV1PresetCreator = func(existing *kubermaticv1.Preset) (*kubermaticv1.Preset, error)
+
+// NamedKubermaticV1PresetCreatorGetter returns the name of the resource and the corresponding creator function
+type NamedKubermaticV1PresetCreatorGetter = func() (name string, create KubermaticV1PresetCreator)
+
+// KubermaticV1PresetObjectWrapper adds a wrapper so the KubermaticV1PresetCreator matches ObjectCreator.
+// This is needed as Go does not support function interface matching.
+func KubermaticV1PresetObjectWrapper(create KubermaticV1PresetCreator) ObjectCreator {
+	return func(existing ctrlruntimeclient.Object) (ctrlruntimeclient.Object, error) {
+		if existing != nil {
+			return create(existing.(*kubermaticv1.Preset))
+		}
+		return create(&kubermaticv1.Preset{})
+	}
+}
+
+// ReconcileKubermaticV1Presets will create and update the KubermaticV1Presets coming from the passed KubermaticV1PresetCreator slice
+func ReconcileKubermaticV1Presets(ctx context.Context, namedGetters []NamedKubermaticV1PresetCreatorGetter, namespace string, client ctrlruntimeclient.Client, objectModifiers ...ObjectModifier) error {
+	for _, get := range namedGetters {
+		name, create := get()
+		createObject := KubermaticV1PresetObjectWrapper(create)
+		createObject = createWithNamespace(createObject, namespace)
+		createObject = createWithName(createObject, name)
+
+		for _, objectModifier := range objectModifiers {
+			createObject = objectModifier(createObject)
+		}
+
+		if err := EnsureNamedObject(ctx, types.NamespacedName{Namespace: namespace, Name: name}, createObject, client, &kubermaticv1.Preset{}, false); err != nil {
+			return fmt.Errorf("failed to ensure Preset %s/%s: %v", namespace, name, err)
+		}
+	}
+
+	return nil
+}
+
+// KubermaticV1AddonConfigCreator defines an interface to create/update AddonConfigs
+type KubermaticV1AddonConfigCreator = func(existing *kubermaticv1.AddonConfig) (*kubermaticv1.AddonConfig, error)
+
+// NamedKubermaticV1AddonConfigCreatorGetter returns the name of the resource and the corresponding creator function
+type NamedKubermaticV1AddonConfigCreatorGetter = func() (name string, create KubermaticV1AddonConfigCreator)
+
+// KubermaticV1AddonConfigObjectWrapper adds a wrapper so the KubermaticV1AddonConfigCreator matches ObjectCreator.
+// This is needed as Go does not support function interface matching.
+func KubermaticV1AddonConfigObjectWrapper(create KubermaticV1AddonConfigCreator) ObjectCreator {
+	return func(existing ctrlruntimeclient.Object) (ctrlruntimeclient.Object, error) {
+		if existing != nil {
+			return create(existing.(*kubermaticv1.AddonConfig))
+		}
+		return create(&kubermaticv1.AddonConfig{})
+	}
+}
+
+// ReconcileKubermaticV1AddonConfigs will create and update the KubermaticV1AddonConfigs coming from the passed KubermaticV1AddonConfigCreator slice
+func ReconcileKubermaticV1AddonConfigs(ctx context.Context, namedGetters []NamedKubermaticV1AddonConfigCreatorGetter, namespace string, client ctrlruntimeclient.Client, objectModifiers ...ObjectModifier) error {
+	for _, get := range namedGetters {
+		name, create := get()
+		createObject := KubermaticV1AddonConfigObjectWrapper(create)
+		createObject = createWithNamespace(createObject, namespace)
+		createObject = createWithName(createObject, name)
+
+		for _, objectModifier := range objectModifiers {
+			createObject = objectModifier(createObject)
+		}
+
+		if err := EnsureNamedObject(ctx, types.NamespacedName{Namespace: namespace, Name: name}, createObject, client, &kubermaticv1.AddonConfig{}, false); err != nil {
+			return fmt.Errorf("failed to ensure AddonConfig %s/%s: %v", namespace, name, err)
+		}
+	}
+
+	return nil
+}
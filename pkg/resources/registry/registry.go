@@ -0,0 +1,102 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"strings"
+
+	"github.com/docker/distribution/reference"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+)
+
+// WithOverwriteFunc is a function that takes a registry and returns the
+// registry that should actually be used to pull images from it.
+type WithOverwriteFunc func(string) string
+
+// GetOverwriteFunc returns a WithOverwriteFunc. If overwriteRegistry is set,
+// it replaces every registry.
+func GetOverwriteFunc(overwriteRegistry string) WithOverwriteFunc {
+	return func(registry string) string {
+		if overwriteRegistry != "" {
+			return overwriteRegistry
+		}
+		return registry
+	}
+}
+
+// ImageRewriteFunc is a function that takes an image and returns the image
+// that should actually be pulled.
+type ImageRewriteFunc func(string) string
+
+// GetCacheRewriteFunc returns an ImageRewriteFunc that rewrites images to be pulled
+// through the given registry cache. Images of registries that are not cached, images
+// that are excluded from the cache and images that cannot be parsed are left untouched.
+// The cache may be nil.
+func GetCacheRewriteFunc(cache *kubermaticv1.RegistryCacheConfig) ImageRewriteFunc {
+	excluded := map[string]bool{}
+	if cache != nil {
+		for _, image := range cache.ExcludedImages {
+			if named, err := reference.ParseNormalizedNamed(image); err == nil {
+				image = named.Name()
+			}
+			excluded[image] = true
+		}
+	}
+
+	return func(image string) string {
+		if cache == nil {
+			return image
+		}
+
+		named, err := reference.ParseNormalizedNamed(image)
+		if err != nil || excluded[named.Name()] {
+			return image
+		}
+
+		domain := reference.Domain(named)
+		mirror := cache.Mirror(domain)
+		if mirror == "" {
+			return image
+		}
+
+		return mirror + strings.TrimPrefix(named.String(), domain)
+	}
+}
+
+// RewriteUnstructuredImages rewrites the images of all containers in the given
+// unstructured object, no matter how deeply the PodSpecs are nested.
+func RewriteUnstructuredImages(obj map[string]interface{}, rewrite ImageRewriteFunc) {
+	for key, value := range obj {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			RewriteUnstructuredImages(v, rewrite)
+		case []interface{}:
+			isContainerList := key == "containers" || key == "initContainers"
+			for _, item := range v {
+				m, ok := item.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				if image, ok := m["image"].(string); ok && isContainerList {
+					m["image"] = rewrite(image)
+				}
+				RewriteUnstructuredImages(m, rewrite)
+			}
+		}
+	}
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+)
+
+func TestGetOverwriteFunc(t *testing.T) {
+	testCases := []struct {
+		name              string
+		overwriteRegistry string
+		registry          string
+		expected          string
+	}{
+		{
+			name:     "no overwrite",
+			registry: "quay.io",
+			expected: "quay.io",
+		},
+		{
+			name:              "overwrite registry replaces every registry",
+			overwriteRegistry: "registry.example.com",
+			registry:          "quay.io",
+			expected:          "registry.example.com",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if result := GetOverwriteFunc(tc.overwriteRegistry)(tc.registry); result != tc.expected {
+				t.Fatalf("expected %q, got %q", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestGetCacheRewriteFunc(t *testing.T) {
+	testCases := []struct {
+		name     string
+		cache    *kubermaticv1.RegistryCacheConfig
+		image    string
+		expected string
+	}{
+		{
+			name:     "no cache",
+			image:    "quay.io/kubermatic/kubermatic:v2.17.0",
+			expected: "quay.io/kubermatic/kubermatic:v2.17.0",
+		},
+		{
+			name:     "default registries are cached",
+			cache:    &kubermaticv1.RegistryCacheConfig{Endpoint: "cache.example.com"},
+			image:    "quay.io/kubermatic/kubermatic:v2.17.0",
+			expected: "cache.example.com:5001/kubermatic/kubermatic:v2.17.0",
+		},
+		{
+			name:     "images without registry are normalized",
+			cache:    &kubermaticv1.RegistryCacheConfig{Endpoint: "cache.example.com"},
+			image:    "nginx:1.19",
+			expected: "cache.example.com:5000/library/nginx:1.19",
+		},
+		{
+			name: "configured registries keep their port",
+			cache: &kubermaticv1.RegistryCacheConfig{
+				Endpoint: "cache.example.com",
+				Registries: []kubermaticv1.RegistryCacheRegistry{
+					{Upstream: "ghcr.io", Port: 6000},
+					{Upstream: "quay.io", Port: 5001},
+				},
+			},
+			image:    "ghcr.io/example/app@sha256:0000000000000000000000000000000000000000000000000000000000000000",
+			expected: "cache.example.com:6000/example/app@sha256:0000000000000000000000000000000000000000000000000000000000000000",
+		},
+		{
+			name:     "registries that are not cached are left untouched",
+			cache:    &kubermaticv1.RegistryCacheConfig{Endpoint: "cache.example.com"},
+			image:    "ghcr.io/example/app:1.0",
+			expected: "ghcr.io/example/app:1.0",
+		},
+		{
+			name: "excluded images are left untouched",
+			cache: &kubermaticv1.RegistryCacheConfig{
+				Endpoint:       "cache.example.com",
+				ExcludedImages: []string{"quay.io/kubermatic/kubermatic", "nginx"},
+			},
+			image:    "docker.io/library/nginx:1.19",
+			expected: "docker.io/library/nginx:1.19",
+		},
+		{
+			name:     "invalid images are left untouched",
+			cache:    &kubermaticv1.RegistryCacheConfig{Endpoint: "cache.example.com"},
+			image:    "",
+			expected: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if result := GetCacheRewriteFunc(tc.cache)(tc.image); result != tc.expected {
+				t.Fatalf("expected %q, got %q", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestRewriteUnstructuredImages(t *testing.T) {
+	obj := map[string]interface{}{
+		"kind": "CronJob",
+		"spec": map[string]interface{}{
+			"jobTemplate": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"template": map[string]interface{}{
+						"spec": map[string]interface{}{
+							"initContainers": []interface{}{
+								map[string]interface{}{"name": "init", "image": "init"},
+							},
+							"containers": []interface{}{
+								map[string]interface{}{"name": "main", "image": "main"},
+							},
+						},
+					},
+				},
+			},
+			"image": "not-a-container",
+		},
+	}
+
+	RewriteUnstructuredImages(obj, func(image string) string {
+		return "rewritten/" + image
+	})
+
+	expected := map[string]interface{}{
+		"kind": "CronJob",
+		"spec": map[string]interface{}{
+			"jobTemplate": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"template": map[string]interface{}{
+						"spec": map[string]interface{}{
+							"initContainers": []interface{}{
+								map[string]interface{}{"name": "init", "image": "rewritten/init"},
+							},
+							"containers": []interface{}{
+								map[string]interface{}{"name": "main", "image": "rewritten/main"},
+							},
+						},
+					},
+				},
+			},
+			"image": "not-a-container",
+		},
+	}
+
+	if diff := deep.Equal(obj, expected); diff != nil {
+		t.Fatalf("unexpected images after rewrite: %v", diff)
+	}
+}
@@ -20,19 +20,16 @@ import (
 	"context"
 	"crypto/ecdsa"
 	"crypto/rsa"
-	"crypto/tls"
 	"crypto/x509"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"net"
-	"net/http"
 	"time"
 
-	minio "github.com/minio/minio-go"
-
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/objectstorage"
 	"k8c.io/kubermatic/v2/pkg/resources/certificates/triple"
 	"k8c.io/kubermatic/v2/pkg/resources/reconciling"
 	"k8c.io/kubermatic/v2/pkg/semver"
@@ -205,6 +202,12 @@ const (
 	ApiserverFrontProxyClientCertificateSecretName = "apiserver-proxy-client-certificate"
 	// GoogleServiceAccountSecretName is the name of the secret that contains the Google Service Account.
 	GoogleServiceAccountSecretName = "google-service-account"
+	// AzureClusterServicePrincipalSecretName is the name of the secret containing the credentials
+	// of the service principal Kubermatic created for an Azure cluster.
+	AzureClusterServicePrincipalSecretName = "azure-cluster-service-principal"
+	// AzureLogAnalyticsSecretName is the name of the secret containing the ID and the shared key of the
+	// Log Analytics workspace the monitoring agent on the nodes of an Azure cluster sends its data to.
+	AzureLogAnalyticsSecretName = "azure-log-analytics"
 	// GoogleServiceAccountVolumeName is the name of the volume containing the Google Service Account secret.
 	GoogleServiceAccountVolumeName = "google-service-account-volume"
 	// AuditLogVolumeName is the name of the volume that hold the audit log of the apiserver.
@@ -237,6 +240,8 @@ const (
 	AuditConfigMapName = "audit-config"
 	// AdmissionControlConfigMapName is the name for the configmap that contains the Admission Controller config file
 	AdmissionControlConfigMapName = "adm-control"
+	// EncryptionConfigurationConfigMapName is the name for the configmap that contains the file passed to the apiserver with the flag "--encryption-provider-config".
+	EncryptionConfigurationConfigMapName = "encryption-configuration"
 
 	// PrometheusServiceAccountName is the name for the Prometheus serviceaccount
 	PrometheusServiceAccountName = "prometheus"
@@ -360,6 +365,8 @@ const (
 	RegistryDocker = "docker.io"
 	// RegistryQuay defines the image registry from coreos/redhat - quay
 	RegistryQuay = "quay.io"
+	// RegistryMCR defines the Microsoft container registry
+	RegistryMCR = "mcr.microsoft.com"
 
 	// TopologyKeyHostname defines the topology key for the node hostname
 	TopologyKeyHostname = "kubernetes.io/hostname"
@@ -402,6 +409,10 @@ const (
 	// IPTablesProxyMode defines the iptables kube-proxy mode.
 	IPTablesProxyMode = "iptables"
 
+	// DefaultNodeCIDRMaskSize is the mask size of the pod CIDR assigned to each node of clusters
+	// which do not configure one, it matches the default of the kube-controller-manager.
+	DefaultNodeCIDRMaskSize = 24
+
 	// PodNodeSelectorAdmissionPlugin defines PodNodeSelector admission plugin
 	PodNodeSelectorAdmissionPlugin = "PodNodeSelector"
 )
@@ -448,17 +459,6 @@ const (
 	// EtcdTLSKeySecretKey etcd-tls.key
 	EtcdTLSKeySecretKey = "etcd-tls.key"
 
-	// EtcdRestoreS3CredentialsSecret names the secret expected in seed kube-system that must contain S3 credentials for etcd backup restores
-	EtcdRestoreS3CredentialsSecret     = "s3-credentials"
-	EtcdRestoreS3AccessKeyIDKey        = "ACCESS_KEY_ID"
-	EtcdRestoreS3SecretKeyAccessKeyKey = "SECRET_ACCESS_KEY"
-
-	// EtcdRestoreS3SettingsConfigMap names the configmap expected in seed kube-system that must contain S3 bucket and endpoint names.
-	EtcdRestoreS3SettingsConfigMap = "s3-settings"
-	EtcdRestoreS3BucketNameKey     = "BUCKET_NAME"
-	EtcdRestoreS3EndpointKey       = "ENDPOINT"
-	EtcdRestoreDefaultS3SEndpoint  = "s3.amazonaws.com"
-
 	// KubeconfigDefaultContextKey is the context key used for all kubeconfigs
 	KubeconfigDefaultContextKey = "default"
 
@@ -504,6 +504,9 @@ const (
 	AzureClientID       = "clientID"
 	AzureClientSecret   = "clientSecret"
 
+	AzureLogAnalyticsWorkspaceID  = "workspaceID"
+	AzureLogAnalyticsWorkspaceKey = "workspaceKey"
+
 	DigitaloceanToken = "token"
 
 	GCPServiceAccount = "serviceAccount"
@@ -624,6 +627,12 @@ const (
 	PromtailClientCertSecretKey    = "client.crt"
 	PromtailClientCertMountPath    = "/etc/ssl/mla"
 
+	LogShippingServiceAccountName     = "log-shipping"
+	LogShippingClusterRoleName        = "system:kubermatic:log-shipping"
+	LogShippingClusterRoleBindingName = "system:kubermatic:log-shipping"
+	LogShippingSecretName             = "log-shipping"
+	LogShippingDaemonSetName          = "log-shipping"
+
 	AlertmanagerName                    = "alertmanager"
 	DefaultAlertmanagerConfigSecretName = "alertmanager"
 	AlertmanagerConfigSecretKey         = "alertmanager.yaml"
@@ -664,6 +673,34 @@ func GetClusterExternalIP(cluster *kubermaticv1.Cluster) (*net.IP, error) {
 	return &ip, nil
 }
 
+// NodeCIDRMaskSize returns the mask size of the pod CIDR assigned to each node.
+func NodeCIDRMaskSize(network kubermaticv1.ClusterNetworkingConfig) int32 {
+	if network.NodeCIDRMaskSize != nil {
+		return *network.NodeCIDRMaskSize
+	}
+	return DefaultNodeCIDRMaskSize
+}
+
+// MaxNodes returns the number of nodes the pod CIDR can be split into, every node gets a pod
+// CIDR with the node CIDR mask size assigned.
+func MaxNodes(network kubermaticv1.ClusterNetworkingConfig) (int, error) {
+	if len(network.Pods.CIDRBlocks) == 0 {
+		return 0, errors.New("no pod CIDR configured")
+	}
+	_, podsNet, err := net.ParseCIDR(network.Pods.CIDRBlocks[0])
+	if err != nil {
+		return 0, err
+	}
+
+	podsMaskSize, _ := podsNet.Mask.Size()
+	maskSize := int(NodeCIDRMaskSize(network))
+	if maskSize < podsMaskSize || maskSize-podsMaskSize > 30 {
+		return 0, fmt.Errorf("node CIDR mask size %d does not fit into pod CIDR %s", maskSize, network.Pods.CIDRBlocks[0])
+	}
+
+	return 1 << (maskSize - podsMaskSize), nil
+}
+
 // GetClusterRef returns a metav1.OwnerReference for the given Cluster
 func GetClusterRef(cluster *kubermaticv1.Cluster) metav1.OwnerReference {
 	gv := kubermaticv1.SchemeGroupVersion
@@ -1174,16 +1211,39 @@ func BackupCABundleConfigMapName(cluster *kubermaticv1.Cluster) string {
 	return fmt.Sprintf("cluster-%s-ca-bundle", cluster.Name)
 }
 
-// GetEtcdRestoreS3Client returns an S3 client for downloading the backup for a given EtcdRestore.
-// If the EtcdRestore doesn't reference a secret containing the credentials and endpoint and bucket name data,
-// one can optionally be created from a well-known secret and configmap in kube-system.
-func GetEtcdRestoreS3Client(ctx context.Context, restore *kubermaticv1.EtcdRestore, createSecretIfMissing bool, client ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster) (*minio.Client, string, error) {
+// IsAzurePrivateCluster returns true if the cluster is a private Azure cluster, whose API server is
+// only reachable via Private Link.
+func IsAzurePrivateCluster(cluster *kubermaticv1.Cluster) bool {
+	return cluster.Spec.Cloud.Azure != nil && cluster.Spec.Cloud.Azure.PrivateCluster
+}
+
+// HasAzureClusterServicePrincipal returns true if the cluster has a service principal of its own,
+// which has been granted access to the resource group of the cluster and should be used by the
+// control plane and the nodes.
+func HasAzureClusterServicePrincipal(cluster *kubermaticv1.Cluster) bool {
+	if cluster.Spec.Cloud.Azure == nil || !cluster.Spec.Cloud.Azure.ClusterServicePrincipal {
+		return false
+	}
+	return cluster.Status.Azure != nil && cluster.Status.Azure.ServicePrincipal != nil &&
+		cluster.Status.Azure.ServicePrincipal.RoleAssignment != ""
+}
+
+// AzurePrivateLinkServiceName returns the name of the Private Link service the seed's cloud provider
+// creates for the front LoadBalancer of a private Azure cluster.
+func AzurePrivateLinkServiceName(cluster *kubermaticv1.Cluster) string {
+	return fmt.Sprintf("cluster-%s-apiserver", cluster.Name)
+}
+
+// GetEtcdRestoreStore returns the object store for downloading the backup for a given EtcdRestore.
+// If the EtcdRestore doesn't reference a secret containing the credentials and bucket settings,
+// one can optionally be created from the well-known backup storage secret and configmap in kube-system.
+func GetEtcdRestoreStore(ctx context.Context, restore *kubermaticv1.EtcdRestore, createSecretIfMissing bool, client ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster) (objectstorage.Store, error) {
 	secretData := make(map[string]string)
 
 	if restore.Spec.BackupDownloadCredentialsSecret != "" {
 		secret := &corev1.Secret{}
 		if err := client.Get(ctx, types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: restore.Spec.BackupDownloadCredentialsSecret}, secret); err != nil {
-			return nil, "", fmt.Errorf("failed to get BackupDownloadCredentialsSecret credentials secret %v: %v", restore.Spec.BackupDownloadCredentialsSecret, err)
+			return nil, fmt.Errorf("failed to get BackupDownloadCredentialsSecret credentials secret %v: %v", restore.Spec.BackupDownloadCredentialsSecret, err)
 		}
 
 		for k, v := range secret.Data {
@@ -1191,18 +1251,20 @@ func GetEtcdRestoreS3Client(ctx context.Context, restore *kubermaticv1.EtcdResto
 		}
 	} else {
 		if !createSecretIfMissing {
-			return nil, "", fmt.Errorf("BackupDownloadCredentialsSecret not set")
+			return nil, fmt.Errorf("BackupDownloadCredentialsSecret not set")
 		}
 
-		// create BackupDownloadCredentialsSecret containing values from kube-system/s3-credentials / kube-system/s3-settings
+		// create BackupDownloadCredentialsSecret containing values from the backup storage credentials and settings in kube-system
 
+		credsSecretName := objectstorage.CredentialsSecretName(objectstorage.PurposeBackups)
 		credsSecret := &corev1.Secret{}
-		if err := client.Get(ctx, types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: EtcdRestoreS3CredentialsSecret}, credsSecret); err != nil {
-			return nil, "", fmt.Errorf("failed to get s3 credentials secret %v/%v: %w", metav1.NamespaceSystem, EtcdRestoreS3CredentialsSecret, err)
+		if err := client.Get(ctx, types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: credsSecretName}, credsSecret); err != nil {
+			return nil, fmt.Errorf("failed to get backup storage credentials secret %v/%v: %w", metav1.NamespaceSystem, credsSecretName, err)
 		}
+		settingsConfigMapName := objectstorage.SettingsConfigMapName(objectstorage.PurposeBackups)
 		settingsConfigMap := &corev1.ConfigMap{}
-		if err := client.Get(ctx, types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: EtcdRestoreS3SettingsConfigMap}, settingsConfigMap); err != nil {
-			return nil, "", fmt.Errorf("failed to get s3 settings configmap %v/%v: %w", metav1.NamespaceSystem, EtcdRestoreS3SettingsConfigMap, err)
+		if err := client.Get(ctx, types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: settingsConfigMapName}, settingsConfigMap); err != nil {
+			return nil, fmt.Errorf("failed to get backup storage settings configmap %v/%v: %w", metav1.NamespaceSystem, settingsConfigMapName, err)
 		}
 
 		for k, v := range credsSecret.Data {
@@ -1231,53 +1293,42 @@ func GetEtcdRestoreS3Client(ctx context.Context, restore *kubermaticv1.EtcdResto
 			ctx,
 			types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: secretName},
 			wrappedCreator, client, &corev1.Secret{}, false); err != nil {
-			return nil, "", fmt.Errorf("failed to ensure Secret %s: %w", secretName, err)
+			return nil, fmt.Errorf("failed to ensure Secret %s: %w", secretName, err)
 		}
 
 		oldRestore := restore.DeepCopy()
 		restore.Spec.BackupDownloadCredentialsSecret = secretName
 		if err := client.Patch(ctx, restore, ctrlruntimeclient.MergeFrom(oldRestore)); err != nil {
-			return nil, "", fmt.Errorf("failed to write etcdrestore.backupDownloadCredentialsSecret: %w", err)
+			return nil, fmt.Errorf("failed to write etcdrestore.backupDownloadCredentialsSecret: %w", err)
 		}
 	}
 
-	accessKeyID := secretData[EtcdRestoreS3AccessKeyIDKey]
-	secretAccessKey := secretData[EtcdRestoreS3SecretKeyAccessKeyKey]
-	bucketName := secretData[EtcdRestoreS3BucketNameKey]
-	endpoint := secretData[EtcdRestoreS3EndpointKey]
-
-	if bucketName == "" {
-		return nil, "", fmt.Errorf("s3 bucket name not set")
-	}
-	if endpoint == "" {
-		endpoint = EtcdRestoreDefaultS3SEndpoint
+	storageConfig, err := objectstorage.ConfigFromData(secretData)
+	if err != nil {
+		return nil, fmt.Errorf("invalid backup storage settings: %w", err)
 	}
 
 	caBundleConfigMap := &corev1.ConfigMap{}
 	caBundleKey := types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: BackupCABundleConfigMapName(cluster)}
 	if err := client.Get(ctx, caBundleKey, caBundleConfigMap); err != nil {
-		return nil, "", fmt.Errorf("failed to get CA bundle ConfigMap: %w", err)
+		return nil, fmt.Errorf("failed to get CA bundle ConfigMap: %w", err)
 	}
 	bundle, ok := caBundleConfigMap.Data[CABundleConfigMapKey]
 	if !ok {
-		return nil, "", fmt.Errorf("ConfigMap does not contain key %q", CABundleConfigMapKey)
+		return nil, fmt.Errorf("ConfigMap does not contain key %q", CABundleConfigMapKey)
 	}
 
 	pool := x509.NewCertPool()
 	if !pool.AppendCertsFromPEM([]byte(bundle)) {
-		return nil, "", errors.New("CA bundle does not contain any valid certificates")
+		return nil, errors.New("CA bundle does not contain any valid certificates")
 	}
 
-	s3Client, err := minio.New(endpoint, accessKeyID, secretAccessKey, true)
+	storageConfig.RootCAs = pool
+
+	store, err := objectstorage.New(ctx, storageConfig)
 	if err != nil {
-		return nil, "", fmt.Errorf("error creating s3 client: %w", err)
+		return nil, fmt.Errorf("error creating object store client: %w", err)
 	}
-	s3Client.SetAppInfo("kubermatic", "v0.1")
-
-	s3Client.SetCustomTransport(&http.Transport{
-		TLSClientConfig:    &tls.Config{RootCAs: pool},
-		DisableCompression: true,
-	})
 
-	return s3Client, bucketName, nil
+	return store, nil
 }
@@ -181,6 +181,19 @@ func DeploymentCreator(data userclusterControllerData) reconciling.NamedDeployme
 				args = append(args, fmt.Sprintf("-cluster-name=%v", data.Cluster().Name))
 			}
 
+			if logShipping := data.Cluster().Spec.LogShipping; logShipping != nil {
+				logShippingLabels, err := getLogShippingLabelsArgValue(data.Cluster())
+				if err != nil {
+					return nil, fmt.Errorf("failed to get log shipping labels: %v", err)
+				}
+				args = append(args, "-log-shipping-type", string(logShipping.Type))
+				args = append(args, "-log-shipping-endpoint", logShipping.Endpoint)
+				args = append(args, "-log-shipping-labels", logShippingLabels)
+				if logShipping.InsecureSkipVerify {
+					args = append(args, "-log-shipping-insecure-skip-verify")
+				}
+			}
+
 			labelArgsValue, err := getLabelsArgValue(data.Cluster())
 			if err != nil {
 				return nil, fmt.Errorf("failed to get label args value: %v", err)
@@ -307,3 +320,23 @@ func getLabelsArgValue(cluster *kubermaticv1.Cluster) (string, error) {
 	}
 	return string(bytes), nil
 }
+
+// getLogShippingLabelsArgValue returns the labels attached to every shipped log line.
+// The cluster and project labels are always set and cannot be overridden by the user,
+// so that tenants can be told apart at the destination.
+func getLogShippingLabelsArgValue(cluster *kubermaticv1.Cluster) (string, error) {
+	labels := map[string]string{}
+	for key, value := range cluster.Spec.LogShipping.Labels {
+		labels[key] = value
+	}
+	labels["cluster"] = cluster.Name
+	if projectID := cluster.Labels[kubermaticv1.ProjectIDLabelKey]; projectID != "" {
+		labels["project"] = projectID
+	}
+
+	bytes, err := json.Marshal(labels)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal labels: %v", err)
+	}
+	return string(bytes), nil
+}
@@ -71,3 +71,64 @@ func TestGetLabelArgsValue(t *testing.T) {
 		})
 	}
 }
+
+func TestGetLogShippingLabelsArgValue(t *testing.T) {
+	testCases := []struct {
+		name           string
+		clusterLabels  map[string]string
+		shippingLabels map[string]string
+		expectedLabels map[string]string
+	}{
+		{
+			name:           "Tenant labels get applied",
+			clusterLabels:  map[string]string{"project-id": "my-project"},
+			expectedLabels: map[string]string{"cluster": "my-cluster", "project": "my-project"},
+		},
+		{
+			name:           "User labels get merged",
+			clusterLabels:  map[string]string{"project-id": "my-project"},
+			shippingLabels: map[string]string{"env": "prod"},
+			expectedLabels: map[string]string{"cluster": "my-cluster", "project": "my-project", "env": "prod"},
+		},
+		{
+			name:           "Tenant labels cannot be overridden",
+			clusterLabels:  map[string]string{"project-id": "my-project"},
+			shippingLabels: map[string]string{"cluster": "other", "project": "other"},
+			expectedLabels: map[string]string{"cluster": "my-cluster", "project": "my-project"},
+		},
+	}
+
+	for idx := range testCases {
+		tc := testCases[idx]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			cluster := &kubermaticv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "my-cluster",
+					Labels: tc.clusterLabels,
+				},
+				Spec: kubermaticv1.ClusterSpec{
+					LogShipping: &kubermaticv1.LogShippingSettings{
+						Type:     kubermaticv1.LogShippingDestinationLoki,
+						Endpoint: "https://loki.example.com",
+						Labels:   tc.shippingLabels,
+					},
+				},
+			}
+			result, err := getLogShippingLabelsArgValue(cluster)
+			if err != nil {
+				t.Fatalf("error when calling getLogShippingLabelsArgValue: %v", err)
+			}
+
+			actualLabels := map[string]string{}
+			if err := json.Unmarshal([]byte(result), &actualLabels); err != nil {
+				t.Fatalf("failed to unmarshal result: %v", err)
+			}
+
+			if diff := deep.Equal(tc.expectedLabels, actualLabels); diff != nil {
+				t.Errorf("actual labels do not match expected labels, diff: %v", diff)
+			}
+		})
+	}
+}
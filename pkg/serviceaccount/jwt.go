@@ -27,6 +27,10 @@ import (
 // Now stubbed out to allow testing
 var Now = time.Now
 
+// MonitoringScope restricts a token to reading the health, metrics and events
+// of the clusters in its project.
+const MonitoringScope = "monitoring"
+
 // TokenGenerator declares the method to generate JWT token
 type TokenGenerator interface {
 	// Generate generates a token which will identify the given
@@ -49,6 +53,9 @@ type CustomTokenClaim struct {
 	Email     string `json:"email,omitempty"`
 	ProjectID string `json:"project_id,omitempty"`
 	TokenID   string `json:"token_id,omitempty"`
+	// Scope restricts the token to a subset of the API, an empty scope grants
+	// the permissions of the service account.
+	Scope string `json:"scope,omitempty"`
 }
 
 func Claims(email, projectID, tokenID string) (*jwt.Claims, *CustomTokenClaim) {
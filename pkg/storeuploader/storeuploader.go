@@ -17,18 +17,17 @@ limitations under the License.
 package storeuploader
 
 import (
-	"crypto/tls"
-	"crypto/x509"
+	"context"
 	"errors"
 	"fmt"
-	"net/http"
 	"os"
 	"path"
 	"sort"
 	"time"
 
-	"github.com/minio/minio-go"
 	"go.uber.org/zap"
+
+	"k8c.io/kubermatic/v2/pkg/objectstorage"
 )
 
 // prefix separator separates the prefix
@@ -40,34 +39,29 @@ const prefixSeparator = "storeuploader"
 // StoreUploader is the configuration
 // for the StoreUploader
 type StoreUploader struct {
-	// client is a pointer to an initialized client
-	client *minio.Client
+	// config describes the object storage, the bucket
+	// is set per operation
+	config objectstorage.Config
 	logger *zap.SugaredLogger
 }
 
 // New returns a new instance of the StoreUploader
-func New(endpoint string, secure bool, accessKeyID, secretAccessKey string, logger *zap.SugaredLogger, rootCAs *x509.CertPool) (*StoreUploader, error) {
-	client, err := minio.New(endpoint, accessKeyID, secretAccessKey, secure)
-	if err != nil {
-		return nil, err
+func New(config objectstorage.Config, logger *zap.SugaredLogger) *StoreUploader {
+	return &StoreUploader{
+		config: config,
+		logger: logger,
 	}
-	client.SetAppInfo("kubermatic-store-uploader", "v0.1")
+}
 
-	if rootCAs != nil {
-		client.SetCustomTransport(&http.Transport{
-			TLSClientConfig:    &tls.Config{RootCAs: rootCAs},
-			DisableCompression: true,
-		})
-	}
+func (u *StoreUploader) store(ctx context.Context, bucket string) (objectstorage.Store, error) {
+	config := u.config
+	config.Bucket = bucket
 
-	return &StoreUploader{
-		client: client,
-		logger: logger,
-	}, nil
+	return objectstorage.New(ctx, config)
 }
 
-// Store uploads the given file to S3
-func (u *StoreUploader) Store(file, bucket, prefix string, createBucket bool) error {
+// Store uploads the given file to the object storage
+func (u *StoreUploader) Store(ctx context.Context, file, bucket, prefix string, createBucket bool) error {
 	if len(prefix) == 0 {
 		return errors.New("prefix cannot be empty")
 	}
@@ -76,55 +70,51 @@ func (u *StoreUploader) Store(file, bucket, prefix string, createBucket bool) er
 		return fmt.Errorf("%s not found", file)
 	}
 
+	store, err := u.store(ctx, bucket)
+	if err != nil {
+		return err
+	}
+
 	logger := u.logger.With("bucket", bucket)
 
 	if createBucket {
-		logger.Debug("Check if bucket exists")
-		exists, err := u.client.BucketExists(bucket)
-		if err != nil {
+		logger.Debug("Ensuring bucket exists")
+		if err := store.EnsureBucket(ctx); err != nil {
 			return err
 		}
-		if !exists {
-			logger.Infow("Creating bucket")
-			if err := u.client.MakeBucket(bucket, ""); err != nil {
-				return err
-			}
-		}
 	}
 
 	objectName := fmt.Sprintf("%s-%s-%s-%s", prefix, prefixSeparator, time.Now().Format("2006-01-02T15:04:05"), path.Base(file))
 	logger.Infow("Uploading file", "src", file, "dst", objectName)
 
-	_, err := u.client.FPutObject(bucket, objectName, file, minio.PutObjectOptions{})
-	return err
+	return objectstorage.UploadFile(ctx, store, objectName, file)
 }
 
 // DeleteOldBackups deletes revisions of all files of the given prefix which are older than max-revisions
-func (u *StoreUploader) DeleteOldBackups(bucket, prefix string, revisionsToKeep int) error {
+func (u *StoreUploader) DeleteOldBackups(ctx context.Context, bucket, prefix string, revisionsToKeep int) error {
 	if len(prefix) == 0 {
 		return errors.New("prefix cannot be empty")
 	}
 
-	doneCh := make(chan struct{})
-	defer close(doneCh)
+	store, err := u.store(ctx, bucket)
+	if err != nil {
+		return err
+	}
 
 	logger := u.logger.With("bucket", bucket, "prefix", prefix, "keep", revisionsToKeep)
 
 	logger.Debugw("Listing existing objects")
 
-	var existingObjects []minio.ObjectInfo
-	for object := range u.client.ListObjects(bucket, fmt.Sprintf("%s-%s", prefix, prefixSeparator), true, doneCh) {
-		if object.Err != nil {
-			return object.Err
-		}
-		existingObjects = append(existingObjects, object)
+	existingObjects, err := store.List(ctx, fmt.Sprintf("%s-%s", prefix, prefixSeparator))
+	if err != nil {
+		return err
 	}
 
 	logger.Debugw("Done listing bucket", "objects", len(existingObjects))
 
 	for _, object := range u.getObjectsToDelete(existingObjects, revisionsToKeep) {
 		logger.Infow("Removing object", "object", object.Key)
-		if err := u.client.RemoveObject(bucket, object.Key); err != nil {
+		if err := store.Delete(ctx, object.Key); err != nil {
 			return err
 		}
 	}
@@ -133,31 +123,30 @@ func (u *StoreUploader) DeleteOldBackups(bucket, prefix string, revisionsToKeep
 }
 
 // DeleteAll deletes all revisions of all files matching the given prefix
-func (u *StoreUploader) DeleteAll(bucket, prefix string) error {
+func (u *StoreUploader) DeleteAll(ctx context.Context, bucket, prefix string) error {
 	if len(prefix) == 0 {
 		return errors.New("prefix cannot be empty")
 	}
 
-	doneCh := make(chan struct{})
-	defer close(doneCh)
+	store, err := u.store(ctx, bucket)
+	if err != nil {
+		return err
+	}
 
 	logger := u.logger.With("bucket", bucket, "prefix", prefix)
 
 	logger.Debugw("Listing existing objects")
 
-	var existingObjects []minio.ObjectInfo
-	for object := range u.client.ListObjects(bucket, fmt.Sprintf("%s-%s", prefix, prefixSeparator), true, doneCh) {
-		if object.Err != nil {
-			return object.Err
-		}
-		existingObjects = append(existingObjects, object)
+	existingObjects, err := store.List(ctx, fmt.Sprintf("%s-%s", prefix, prefixSeparator))
+	if err != nil {
+		return err
 	}
 
 	logger.Debugw("Done listing bucket", "objects", len(existingObjects))
 
 	for _, object := range existingObjects {
 		logger.Infow("Removing object", "object", object.Key)
-		if err := u.client.RemoveObject(bucket, object.Key); err != nil {
+		if err := store.Delete(ctx, object.Key); err != nil {
 			return err
 		}
 	}
@@ -165,7 +154,7 @@ func (u *StoreUploader) DeleteAll(bucket, prefix string) error {
 	return nil
 }
 
-func (u *StoreUploader) getObjectsToDelete(objects []minio.ObjectInfo, revisionsToKeep int) []minio.ObjectInfo {
+func (u *StoreUploader) getObjectsToDelete(objects []objectstorage.Object, revisionsToKeep int) []objectstorage.Object {
 	if len(objects) <= revisionsToKeep {
 		return nil
 	}
@@ -176,7 +165,7 @@ func (u *StoreUploader) getObjectsToDelete(objects []minio.ObjectInfo, revisions
 
 	numRevisionsToDelete := len(objects) - revisionsToKeep
 
-	var objectsToDelete []minio.ObjectInfo
+	var objectsToDelete []objectstorage.Object
 	for idx, object := range objects {
 		if idx >= numRevisionsToDelete {
 			return objectsToDelete
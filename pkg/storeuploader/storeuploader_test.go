@@ -21,20 +21,21 @@ import (
 	"time"
 
 	"github.com/go-test/deep"
-	"github.com/minio/minio-go"
+
+	"k8c.io/kubermatic/v2/pkg/objectstorage"
 )
 
 func TestGetObjectsToDelete(t *testing.T) {
 	tests := []struct {
 		name             string
-		existingObjects  []minio.ObjectInfo
-		expectedToDelete []minio.ObjectInfo
+		existingObjects  []objectstorage.Object
+		expectedToDelete []objectstorage.Object
 		revisions        int
 	}{
 		{
 			name:      "nothing gets deleted as revisions==existing-backups",
 			revisions: 1,
-			existingObjects: []minio.ObjectInfo{
+			existingObjects: []objectstorage.Object{
 				{
 					Key:          "foo",
 					LastModified: time.Unix(1, 0),
@@ -45,7 +46,7 @@ func TestGetObjectsToDelete(t *testing.T) {
 		{
 			name:      "oldest should be deleted as revisions < existing-backups",
 			revisions: 1,
-			existingObjects: []minio.ObjectInfo{
+			existingObjects: []objectstorage.Object{
 				{
 					Key:          "foo",
 					LastModified: time.Unix(1, 0),
@@ -55,7 +56,7 @@ func TestGetObjectsToDelete(t *testing.T) {
 					LastModified: time.Unix(10, 0),
 				},
 			},
-			expectedToDelete: []minio.ObjectInfo{
+			expectedToDelete: []objectstorage.Object{
 				{
 					Key:          "foo",
 					LastModified: time.Unix(1, 0),
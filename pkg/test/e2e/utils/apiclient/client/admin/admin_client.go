@@ -33,6 +33,8 @@ type ClientService interface {
 
 	GetAdmissionPlugin(params *GetAdmissionPluginParams, authInfo runtime.ClientAuthInfoWriter) (*GetAdmissionPluginOK, error)
 
+	GetFleetStatistics(params *GetFleetStatisticsParams, authInfo runtime.ClientAuthInfoWriter) (*GetFleetStatisticsOK, error)
+
 	GetKubermaticCustomLinks(params *GetKubermaticCustomLinksParams, authInfo runtime.ClientAuthInfoWriter) (*GetKubermaticCustomLinksOK, error)
 
 	GetKubermaticSettings(params *GetKubermaticSettingsParams, authInfo runtime.ClientAuthInfoWriter) (*GetKubermaticSettingsOK, error)
@@ -190,6 +192,40 @@ func (a *Client) GetAdmissionPlugin(params *GetAdmissionPluginParams, authInfo r
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+  GetFleetStatistics gets statistics about all clusters of all seeds the statistics are cached for a few minutes
+*/
+func (a *Client) GetFleetStatistics(params *GetFleetStatisticsParams, authInfo runtime.ClientAuthInfoWriter) (*GetFleetStatisticsOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetFleetStatisticsParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "getFleetStatistics",
+		Method:             "GET",
+		PathPattern:        "/api/v1/admin/statistics",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &GetFleetStatisticsReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetFleetStatisticsOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*GetFleetStatisticsDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
   GetKubermaticCustomLinks gets the custom links
 */
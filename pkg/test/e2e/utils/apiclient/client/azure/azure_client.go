@@ -25,12 +25,16 @@ type Client struct {
 
 // ClientService is the interface for Client methods
 type ClientService interface {
+	GetAzureCostEstimate(params *GetAzureCostEstimateParams, authInfo runtime.ClientAuthInfoWriter) (*GetAzureCostEstimateOK, error)
+
 	ListAzureAvailabilityZones(params *ListAzureAvailabilityZonesParams, authInfo runtime.ClientAuthInfoWriter) (*ListAzureAvailabilityZonesOK, error)
 
 	ListAzureAvailabilityZonesNoCredentials(params *ListAzureAvailabilityZonesNoCredentialsParams, authInfo runtime.ClientAuthInfoWriter) (*ListAzureAvailabilityZonesNoCredentialsOK, error)
 
 	ListAzureAvailabilityZonesNoCredentialsV2(params *ListAzureAvailabilityZonesNoCredentialsV2Params, authInfo runtime.ClientAuthInfoWriter) (*ListAzureAvailabilityZonesNoCredentialsV2OK, error)
 
+	ListAzureLocations(params *ListAzureLocationsParams, authInfo runtime.ClientAuthInfoWriter) (*ListAzureLocationsOK, error)
+
 	ListAzureResourceGroups(params *ListAzureResourceGroupsParams, authInfo runtime.ClientAuthInfoWriter) (*ListAzureResourceGroupsOK, error)
 
 	ListAzureRouteTables(params *ListAzureRouteTablesParams, authInfo runtime.ClientAuthInfoWriter) (*ListAzureRouteTablesOK, error)
@@ -50,6 +54,40 @@ type ClientService interface {
 	SetTransport(transport runtime.ClientTransport)
 }
 
+/*
+  GetAzureCostEstimate Estimates the monthly costs of Azure nodes from the retail prices of the VM size and managed disks
+*/
+func (a *Client) GetAzureCostEstimate(params *GetAzureCostEstimateParams, authInfo runtime.ClientAuthInfoWriter) (*GetAzureCostEstimateOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetAzureCostEstimateParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "getAzureCostEstimate",
+		Method:             "GET",
+		PathPattern:        "/api/v1/providers/azure/costestimate",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &GetAzureCostEstimateReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetAzureCostEstimateOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*GetAzureCostEstimateDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
   ListAzureAvailabilityZones Lists VM availability zones in an Azure region
 */
@@ -152,6 +190,40 @@ func (a *Client) ListAzureAvailabilityZonesNoCredentialsV2(params *ListAzureAvai
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+  ListAzureLocations Lists the locations available to the subscription in which clusters can be created
+*/
+func (a *Client) ListAzureLocations(params *ListAzureLocationsParams, authInfo runtime.ClientAuthInfoWriter) (*ListAzureLocationsOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewListAzureLocationsParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "listAzureLocations",
+		Method:             "GET",
+		PathPattern:        "/api/v2/providers/azure/locations",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &ListAzureLocationsReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ListAzureLocationsOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*ListAzureLocationsDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
   ListAzureResourceGroups Lists available VM resource groups
 */
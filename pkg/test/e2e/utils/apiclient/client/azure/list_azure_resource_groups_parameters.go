@@ -66,6 +66,8 @@ type ListAzureResourceGroupsParams struct {
 	ClientSecret *string
 	/*Credential*/
 	Credential *string
+	/*DatacenterName*/
+	DatacenterName *string
 	/*Location*/
 	Location *string
 	/*SubscriptionID*/
@@ -144,6 +146,17 @@ func (o *ListAzureResourceGroupsParams) SetCredential(credential *string) {
 	o.Credential = credential
 }
 
+// WithDatacenterName adds the datacenterName to the list azure resource groups params
+func (o *ListAzureResourceGroupsParams) WithDatacenterName(datacenterName *string) *ListAzureResourceGroupsParams {
+	o.SetDatacenterName(datacenterName)
+	return o
+}
+
+// SetDatacenterName adds the datacenterName to the list azure resource groups params
+func (o *ListAzureResourceGroupsParams) SetDatacenterName(datacenterName *string) {
+	o.DatacenterName = datacenterName
+}
+
 // WithLocation adds the location to the list azure resource groups params
 func (o *ListAzureResourceGroupsParams) WithLocation(location *string) *ListAzureResourceGroupsParams {
 	o.SetLocation(location)
@@ -212,6 +225,15 @@ func (o *ListAzureResourceGroupsParams) WriteToRequest(r runtime.ClientRequest,
 
 	}
 
+	if o.DatacenterName != nil {
+
+		// header param DatacenterName
+		if err := r.SetHeaderParam("DatacenterName", *o.DatacenterName); err != nil {
+			return err
+		}
+
+	}
+
 	if o.Location != nil {
 
 		// header param Location
@@ -66,6 +66,8 @@ type ListAzureRouteTablesParams struct {
 	ClientSecret *string
 	/*Credential*/
 	Credential *string
+	/*DatacenterName*/
+	DatacenterName *string
 	/*Location*/
 	Location *string
 	/*ResourceGroup*/
@@ -146,6 +148,17 @@ func (o *ListAzureRouteTablesParams) SetCredential(credential *string) {
 	o.Credential = credential
 }
 
+// WithDatacenterName adds the datacenterName to the list azure route tables params
+func (o *ListAzureRouteTablesParams) WithDatacenterName(datacenterName *string) *ListAzureRouteTablesParams {
+	o.SetDatacenterName(datacenterName)
+	return o
+}
+
+// SetDatacenterName adds the datacenterName to the list azure route tables params
+func (o *ListAzureRouteTablesParams) SetDatacenterName(datacenterName *string) {
+	o.DatacenterName = datacenterName
+}
+
 // WithLocation adds the location to the list azure route tables params
 func (o *ListAzureRouteTablesParams) WithLocation(location *string) *ListAzureRouteTablesParams {
 	o.SetLocation(location)
@@ -225,6 +238,15 @@ func (o *ListAzureRouteTablesParams) WriteToRequest(r runtime.ClientRequest, reg
 
 	}
 
+	if o.DatacenterName != nil {
+
+		// header param DatacenterName
+		if err := r.SetHeaderParam("DatacenterName", *o.DatacenterName); err != nil {
+			return err
+		}
+
+	}
+
 	if o.Location != nil {
 
 		// header param Location
@@ -66,6 +66,8 @@ type ListAzureSecurityGroupsParams struct {
 	ClientSecret *string
 	/*Credential*/
 	Credential *string
+	/*DatacenterName*/
+	DatacenterName *string
 	/*Location*/
 	Location *string
 	/*ResourceGroup*/
@@ -146,6 +148,17 @@ func (o *ListAzureSecurityGroupsParams) SetCredential(credential *string) {
 	o.Credential = credential
 }
 
+// WithDatacenterName adds the datacenterName to the list azure security groups params
+func (o *ListAzureSecurityGroupsParams) WithDatacenterName(datacenterName *string) *ListAzureSecurityGroupsParams {
+	o.SetDatacenterName(datacenterName)
+	return o
+}
+
+// SetDatacenterName adds the datacenterName to the list azure security groups params
+func (o *ListAzureSecurityGroupsParams) SetDatacenterName(datacenterName *string) {
+	o.DatacenterName = datacenterName
+}
+
 // WithLocation adds the location to the list azure security groups params
 func (o *ListAzureSecurityGroupsParams) WithLocation(location *string) *ListAzureSecurityGroupsParams {
 	o.SetLocation(location)
@@ -225,6 +238,15 @@ func (o *ListAzureSecurityGroupsParams) WriteToRequest(r runtime.ClientRequest,
 
 	}
 
+	if o.DatacenterName != nil {
+
+		// header param DatacenterName
+		if err := r.SetHeaderParam("DatacenterName", *o.DatacenterName); err != nil {
+			return err
+		}
+
+	}
+
 	if o.Location != nil {
 
 		// header param Location
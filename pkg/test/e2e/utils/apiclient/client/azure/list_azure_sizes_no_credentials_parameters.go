@@ -14,6 +14,7 @@ import (
 	"github.com/go-openapi/runtime"
 	cr "github.com/go-openapi/runtime/client"
 	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 )
 
 // NewListAzureSizesNoCredentialsParams creates a new ListAzureSizesNoCredentialsParams object
@@ -60,12 +61,38 @@ for the list azure sizes no credentials operation typically these are written to
 */
 type ListAzureSizesNoCredentialsParams struct {
 
+	/*AcceleratedNetworking
+	  AcceleratedNetworking lists only sizes supporting accelerated networking.
+
+	*/
+	AcceleratedNetworking *bool
 	/*ClusterID*/
 	ClusterID string
 	/*Dc*/
 	DC string
+	/*MinCPU
+	  MinCPU is the minimum number of vCPUs of the sizes.
+
+	*/
+	MinCPU *int64
+	/*MinMemory
+	  MinMemory is the minimum memory of the sizes in GB.
+
+	*/
+	MinMemory *int64
 	/*ProjectID*/
 	ProjectID string
+	/*QuotaHeadroom
+	  QuotaHeadroom lists only sizes of which another VM fits into the remaining vCPU quotas
+	of the subscription in the location.
+
+	*/
+	QuotaHeadroom *bool
+	/*Zone
+	  Zone is an availability zone in which the sizes must be available.
+
+	*/
+	Zone *string
 
 	timeout    time.Duration
 	Context    context.Context
@@ -105,6 +132,17 @@ func (o *ListAzureSizesNoCredentialsParams) SetHTTPClient(client *http.Client) {
 	o.HTTPClient = client
 }
 
+// WithAcceleratedNetworking adds the acceleratedNetworking to the list azure sizes no credentials params
+func (o *ListAzureSizesNoCredentialsParams) WithAcceleratedNetworking(acceleratedNetworking *bool) *ListAzureSizesNoCredentialsParams {
+	o.SetAcceleratedNetworking(acceleratedNetworking)
+	return o
+}
+
+// SetAcceleratedNetworking adds the acceleratedNetworking to the list azure sizes no credentials params
+func (o *ListAzureSizesNoCredentialsParams) SetAcceleratedNetworking(acceleratedNetworking *bool) {
+	o.AcceleratedNetworking = acceleratedNetworking
+}
+
 // WithClusterID adds the clusterID to the list azure sizes no credentials params
 func (o *ListAzureSizesNoCredentialsParams) WithClusterID(clusterID string) *ListAzureSizesNoCredentialsParams {
 	o.SetClusterID(clusterID)
@@ -127,6 +165,28 @@ func (o *ListAzureSizesNoCredentialsParams) SetDC(dc string) {
 	o.DC = dc
 }
 
+// WithMinCPU adds the minCPU to the list azure sizes no credentials params
+func (o *ListAzureSizesNoCredentialsParams) WithMinCPU(minCPU *int64) *ListAzureSizesNoCredentialsParams {
+	o.SetMinCPU(minCPU)
+	return o
+}
+
+// SetMinCPU adds the minCpu to the list azure sizes no credentials params
+func (o *ListAzureSizesNoCredentialsParams) SetMinCPU(minCPU *int64) {
+	o.MinCPU = minCPU
+}
+
+// WithMinMemory adds the minMemory to the list azure sizes no credentials params
+func (o *ListAzureSizesNoCredentialsParams) WithMinMemory(minMemory *int64) *ListAzureSizesNoCredentialsParams {
+	o.SetMinMemory(minMemory)
+	return o
+}
+
+// SetMinMemory adds the minMemory to the list azure sizes no credentials params
+func (o *ListAzureSizesNoCredentialsParams) SetMinMemory(minMemory *int64) {
+	o.MinMemory = minMemory
+}
+
 // WithProjectID adds the projectID to the list azure sizes no credentials params
 func (o *ListAzureSizesNoCredentialsParams) WithProjectID(projectID string) *ListAzureSizesNoCredentialsParams {
 	o.SetProjectID(projectID)
@@ -138,6 +198,28 @@ func (o *ListAzureSizesNoCredentialsParams) SetProjectID(projectID string) {
 	o.ProjectID = projectID
 }
 
+// WithQuotaHeadroom adds the quotaHeadroom to the list azure sizes no credentials params
+func (o *ListAzureSizesNoCredentialsParams) WithQuotaHeadroom(quotaHeadroom *bool) *ListAzureSizesNoCredentialsParams {
+	o.SetQuotaHeadroom(quotaHeadroom)
+	return o
+}
+
+// SetQuotaHeadroom adds the quotaHeadroom to the list azure sizes no credentials params
+func (o *ListAzureSizesNoCredentialsParams) SetQuotaHeadroom(quotaHeadroom *bool) {
+	o.QuotaHeadroom = quotaHeadroom
+}
+
+// WithZone adds the zone to the list azure sizes no credentials params
+func (o *ListAzureSizesNoCredentialsParams) WithZone(zone *string) *ListAzureSizesNoCredentialsParams {
+	o.SetZone(zone)
+	return o
+}
+
+// SetZone adds the zone to the list azure sizes no credentials params
+func (o *ListAzureSizesNoCredentialsParams) SetZone(zone *string) {
+	o.Zone = zone
+}
+
 // WriteToRequest writes these params to a swagger request
 func (o *ListAzureSizesNoCredentialsParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
 
@@ -146,6 +228,22 @@ func (o *ListAzureSizesNoCredentialsParams) WriteToRequest(r runtime.ClientReque
 	}
 	var res []error
 
+	if o.AcceleratedNetworking != nil {
+
+		// query param acceleratedNetworking
+		var qrAcceleratedNetworking bool
+		if o.AcceleratedNetworking != nil {
+			qrAcceleratedNetworking = *o.AcceleratedNetworking
+		}
+		qAcceleratedNetworking := swag.FormatBool(qrAcceleratedNetworking)
+		if qAcceleratedNetworking != "" {
+			if err := r.SetQueryParam("acceleratedNetworking", qAcceleratedNetworking); err != nil {
+				return err
+			}
+		}
+
+	}
+
 	// path param cluster_id
 	if err := r.SetPathParam("cluster_id", o.ClusterID); err != nil {
 		return err
@@ -156,11 +254,75 @@ func (o *ListAzureSizesNoCredentialsParams) WriteToRequest(r runtime.ClientReque
 		return err
 	}
 
+	if o.MinCPU != nil {
+
+		// query param minCPU
+		var qrMinCPU int64
+		if o.MinCPU != nil {
+			qrMinCPU = *o.MinCPU
+		}
+		qMinCPU := swag.FormatInt64(qrMinCPU)
+		if qMinCPU != "" {
+			if err := r.SetQueryParam("minCPU", qMinCPU); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	if o.MinMemory != nil {
+
+		// query param minMemory
+		var qrMinMemory int64
+		if o.MinMemory != nil {
+			qrMinMemory = *o.MinMemory
+		}
+		qMinMemory := swag.FormatInt64(qrMinMemory)
+		if qMinMemory != "" {
+			if err := r.SetQueryParam("minMemory", qMinMemory); err != nil {
+				return err
+			}
+		}
+
+	}
+
 	// path param project_id
 	if err := r.SetPathParam("project_id", o.ProjectID); err != nil {
 		return err
 	}
 
+	if o.QuotaHeadroom != nil {
+
+		// query param quotaHeadroom
+		var qrQuotaHeadroom bool
+		if o.QuotaHeadroom != nil {
+			qrQuotaHeadroom = *o.QuotaHeadroom
+		}
+		qQuotaHeadroom := swag.FormatBool(qrQuotaHeadroom)
+		if qQuotaHeadroom != "" {
+			if err := r.SetQueryParam("quotaHeadroom", qQuotaHeadroom); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	if o.Zone != nil {
+
+		// query param zone
+		var qrZone string
+		if o.Zone != nil {
+			qrZone = *o.Zone
+		}
+		qZone := qrZone
+		if qZone != "" {
+			if err := r.SetQueryParam("zone", qZone); err != nil {
+				return err
+			}
+		}
+
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
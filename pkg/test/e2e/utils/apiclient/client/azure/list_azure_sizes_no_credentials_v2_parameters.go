@@ -14,6 +14,7 @@ import (
 	"github.com/go-openapi/runtime"
 	cr "github.com/go-openapi/runtime/client"
 	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 )
 
 // NewListAzureSizesNoCredentialsV2Params creates a new ListAzureSizesNoCredentialsV2Params object
@@ -60,10 +61,36 @@ for the list azure sizes no credentials v2 operation typically these are written
 */
 type ListAzureSizesNoCredentialsV2Params struct {
 
+	/*AcceleratedNetworking
+	  AcceleratedNetworking lists only sizes supporting accelerated networking.
+
+	*/
+	AcceleratedNetworking *bool
 	/*ClusterID*/
 	ClusterID string
+	/*MinCPU
+	  MinCPU is the minimum number of vCPUs of the sizes.
+
+	*/
+	MinCPU *int64
+	/*MinMemory
+	  MinMemory is the minimum memory of the sizes in GB.
+
+	*/
+	MinMemory *int64
 	/*ProjectID*/
 	ProjectID string
+	/*QuotaHeadroom
+	  QuotaHeadroom lists only sizes of which another VM fits into the remaining vCPU quotas
+	of the subscription in the location.
+
+	*/
+	QuotaHeadroom *bool
+	/*Zone
+	  Zone is an availability zone in which the sizes must be available.
+
+	*/
+	Zone *string
 
 	timeout    time.Duration
 	Context    context.Context
@@ -103,6 +130,17 @@ func (o *ListAzureSizesNoCredentialsV2Params) SetHTTPClient(client *http.Client)
 	o.HTTPClient = client
 }
 
+// WithAcceleratedNetworking adds the acceleratedNetworking to the list azure sizes no credentials v2 params
+func (o *ListAzureSizesNoCredentialsV2Params) WithAcceleratedNetworking(acceleratedNetworking *bool) *ListAzureSizesNoCredentialsV2Params {
+	o.SetAcceleratedNetworking(acceleratedNetworking)
+	return o
+}
+
+// SetAcceleratedNetworking adds the acceleratedNetworking to the list azure sizes no credentials v2 params
+func (o *ListAzureSizesNoCredentialsV2Params) SetAcceleratedNetworking(acceleratedNetworking *bool) {
+	o.AcceleratedNetworking = acceleratedNetworking
+}
+
 // WithClusterID adds the clusterID to the list azure sizes no credentials v2 params
 func (o *ListAzureSizesNoCredentialsV2Params) WithClusterID(clusterID string) *ListAzureSizesNoCredentialsV2Params {
 	o.SetClusterID(clusterID)
@@ -114,6 +152,28 @@ func (o *ListAzureSizesNoCredentialsV2Params) SetClusterID(clusterID string) {
 	o.ClusterID = clusterID
 }
 
+// WithMinCPU adds the minCPU to the list azure sizes no credentials v2 params
+func (o *ListAzureSizesNoCredentialsV2Params) WithMinCPU(minCPU *int64) *ListAzureSizesNoCredentialsV2Params {
+	o.SetMinCPU(minCPU)
+	return o
+}
+
+// SetMinCPU adds the minCpu to the list azure sizes no credentials v2 params
+func (o *ListAzureSizesNoCredentialsV2Params) SetMinCPU(minCPU *int64) {
+	o.MinCPU = minCPU
+}
+
+// WithMinMemory adds the minMemory to the list azure sizes no credentials v2 params
+func (o *ListAzureSizesNoCredentialsV2Params) WithMinMemory(minMemory *int64) *ListAzureSizesNoCredentialsV2Params {
+	o.SetMinMemory(minMemory)
+	return o
+}
+
+// SetMinMemory adds the minMemory to the list azure sizes no credentials v2 params
+func (o *ListAzureSizesNoCredentialsV2Params) SetMinMemory(minMemory *int64) {
+	o.MinMemory = minMemory
+}
+
 // WithProjectID adds the projectID to the list azure sizes no credentials v2 params
 func (o *ListAzureSizesNoCredentialsV2Params) WithProjectID(projectID string) *ListAzureSizesNoCredentialsV2Params {
 	o.SetProjectID(projectID)
@@ -125,6 +185,28 @@ func (o *ListAzureSizesNoCredentialsV2Params) SetProjectID(projectID string) {
 	o.ProjectID = projectID
 }
 
+// WithQuotaHeadroom adds the quotaHeadroom to the list azure sizes no credentials v2 params
+func (o *ListAzureSizesNoCredentialsV2Params) WithQuotaHeadroom(quotaHeadroom *bool) *ListAzureSizesNoCredentialsV2Params {
+	o.SetQuotaHeadroom(quotaHeadroom)
+	return o
+}
+
+// SetQuotaHeadroom adds the quotaHeadroom to the list azure sizes no credentials v2 params
+func (o *ListAzureSizesNoCredentialsV2Params) SetQuotaHeadroom(quotaHeadroom *bool) {
+	o.QuotaHeadroom = quotaHeadroom
+}
+
+// WithZone adds the zone to the list azure sizes no credentials v2 params
+func (o *ListAzureSizesNoCredentialsV2Params) WithZone(zone *string) *ListAzureSizesNoCredentialsV2Params {
+	o.SetZone(zone)
+	return o
+}
+
+// SetZone adds the zone to the list azure sizes no credentials v2 params
+func (o *ListAzureSizesNoCredentialsV2Params) SetZone(zone *string) {
+	o.Zone = zone
+}
+
 // WriteToRequest writes these params to a swagger request
 func (o *ListAzureSizesNoCredentialsV2Params) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
 
@@ -133,16 +215,96 @@ func (o *ListAzureSizesNoCredentialsV2Params) WriteToRequest(r runtime.ClientReq
 	}
 	var res []error
 
+	if o.AcceleratedNetworking != nil {
+
+		// query param acceleratedNetworking
+		var qrAcceleratedNetworking bool
+		if o.AcceleratedNetworking != nil {
+			qrAcceleratedNetworking = *o.AcceleratedNetworking
+		}
+		qAcceleratedNetworking := swag.FormatBool(qrAcceleratedNetworking)
+		if qAcceleratedNetworking != "" {
+			if err := r.SetQueryParam("acceleratedNetworking", qAcceleratedNetworking); err != nil {
+				return err
+			}
+		}
+
+	}
+
 	// path param cluster_id
 	if err := r.SetPathParam("cluster_id", o.ClusterID); err != nil {
 		return err
 	}
 
+	if o.MinCPU != nil {
+
+		// query param minCPU
+		var qrMinCPU int64
+		if o.MinCPU != nil {
+			qrMinCPU = *o.MinCPU
+		}
+		qMinCPU := swag.FormatInt64(qrMinCPU)
+		if qMinCPU != "" {
+			if err := r.SetQueryParam("minCPU", qMinCPU); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	if o.MinMemory != nil {
+
+		// query param minMemory
+		var qrMinMemory int64
+		if o.MinMemory != nil {
+			qrMinMemory = *o.MinMemory
+		}
+		qMinMemory := swag.FormatInt64(qrMinMemory)
+		if qMinMemory != "" {
+			if err := r.SetQueryParam("minMemory", qMinMemory); err != nil {
+				return err
+			}
+		}
+
+	}
+
 	// path param project_id
 	if err := r.SetPathParam("project_id", o.ProjectID); err != nil {
 		return err
 	}
 
+	if o.QuotaHeadroom != nil {
+
+		// query param quotaHeadroom
+		var qrQuotaHeadroom bool
+		if o.QuotaHeadroom != nil {
+			qrQuotaHeadroom = *o.QuotaHeadroom
+		}
+		qQuotaHeadroom := swag.FormatBool(qrQuotaHeadroom)
+		if qQuotaHeadroom != "" {
+			if err := r.SetQueryParam("quotaHeadroom", qQuotaHeadroom); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	if o.Zone != nil {
+
+		// query param zone
+		var qrZone string
+		if o.Zone != nil {
+			qrZone = *o.Zone
+		}
+		qZone := qrZone
+		if qZone != "" {
+			if err := r.SetQueryParam("zone", qZone); err != nil {
+				return err
+			}
+		}
+
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -14,6 +14,7 @@ import (
 	"github.com/go-openapi/runtime"
 	cr "github.com/go-openapi/runtime/client"
 	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 )
 
 // NewListAzureSizesParams creates a new ListAzureSizesParams object
@@ -66,12 +67,40 @@ type ListAzureSizesParams struct {
 	ClientSecret *string
 	/*Credential*/
 	Credential *string
+	/*DatacenterName*/
+	DatacenterName *string
 	/*Location*/
 	Location *string
 	/*SubscriptionID*/
 	SubscriptionID *string
 	/*TenantID*/
 	TenantID *string
+	/*AcceleratedNetworking
+	  AcceleratedNetworking lists only sizes supporting accelerated networking.
+
+	*/
+	AcceleratedNetworking *bool
+	/*MinCPU
+	  MinCPU is the minimum number of vCPUs of the sizes.
+
+	*/
+	MinCPU *int64
+	/*MinMemory
+	  MinMemory is the minimum memory of the sizes in GB.
+
+	*/
+	MinMemory *int64
+	/*QuotaHeadroom
+	  QuotaHeadroom lists only sizes of which another VM fits into the remaining vCPU quotas
+	of the subscription in the location.
+
+	*/
+	QuotaHeadroom *bool
+	/*Zone
+	  Zone is an availability zone in which the sizes must be available.
+
+	*/
+	Zone *string
 
 	timeout    time.Duration
 	Context    context.Context
@@ -144,6 +173,17 @@ func (o *ListAzureSizesParams) SetCredential(credential *string) {
 	o.Credential = credential
 }
 
+// WithDatacenterName adds the datacenterName to the list azure sizes params
+func (o *ListAzureSizesParams) WithDatacenterName(datacenterName *string) *ListAzureSizesParams {
+	o.SetDatacenterName(datacenterName)
+	return o
+}
+
+// SetDatacenterName adds the datacenterName to the list azure sizes params
+func (o *ListAzureSizesParams) SetDatacenterName(datacenterName *string) {
+	o.DatacenterName = datacenterName
+}
+
 // WithLocation adds the location to the list azure sizes params
 func (o *ListAzureSizesParams) WithLocation(location *string) *ListAzureSizesParams {
 	o.SetLocation(location)
@@ -177,6 +217,61 @@ func (o *ListAzureSizesParams) SetTenantID(tenantID *string) {
 	o.TenantID = tenantID
 }
 
+// WithAcceleratedNetworking adds the acceleratedNetworking to the list azure sizes params
+func (o *ListAzureSizesParams) WithAcceleratedNetworking(acceleratedNetworking *bool) *ListAzureSizesParams {
+	o.SetAcceleratedNetworking(acceleratedNetworking)
+	return o
+}
+
+// SetAcceleratedNetworking adds the acceleratedNetworking to the list azure sizes params
+func (o *ListAzureSizesParams) SetAcceleratedNetworking(acceleratedNetworking *bool) {
+	o.AcceleratedNetworking = acceleratedNetworking
+}
+
+// WithMinCPU adds the minCPU to the list azure sizes params
+func (o *ListAzureSizesParams) WithMinCPU(minCPU *int64) *ListAzureSizesParams {
+	o.SetMinCPU(minCPU)
+	return o
+}
+
+// SetMinCPU adds the minCpu to the list azure sizes params
+func (o *ListAzureSizesParams) SetMinCPU(minCPU *int64) {
+	o.MinCPU = minCPU
+}
+
+// WithMinMemory adds the minMemory to the list azure sizes params
+func (o *ListAzureSizesParams) WithMinMemory(minMemory *int64) *ListAzureSizesParams {
+	o.SetMinMemory(minMemory)
+	return o
+}
+
+// SetMinMemory adds the minMemory to the list azure sizes params
+func (o *ListAzureSizesParams) SetMinMemory(minMemory *int64) {
+	o.MinMemory = minMemory
+}
+
+// WithQuotaHeadroom adds the quotaHeadroom to the list azure sizes params
+func (o *ListAzureSizesParams) WithQuotaHeadroom(quotaHeadroom *bool) *ListAzureSizesParams {
+	o.SetQuotaHeadroom(quotaHeadroom)
+	return o
+}
+
+// SetQuotaHeadroom adds the quotaHeadroom to the list azure sizes params
+func (o *ListAzureSizesParams) SetQuotaHeadroom(quotaHeadroom *bool) {
+	o.QuotaHeadroom = quotaHeadroom
+}
+
+// WithZone adds the zone to the list azure sizes params
+func (o *ListAzureSizesParams) WithZone(zone *string) *ListAzureSizesParams {
+	o.SetZone(zone)
+	return o
+}
+
+// SetZone adds the zone to the list azure sizes params
+func (o *ListAzureSizesParams) SetZone(zone *string) {
+	o.Zone = zone
+}
+
 // WriteToRequest writes these params to a swagger request
 func (o *ListAzureSizesParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
 
@@ -212,6 +307,15 @@ func (o *ListAzureSizesParams) WriteToRequest(r runtime.ClientRequest, reg strfm
 
 	}
 
+	if o.DatacenterName != nil {
+
+		// header param DatacenterName
+		if err := r.SetHeaderParam("DatacenterName", *o.DatacenterName); err != nil {
+			return err
+		}
+
+	}
+
 	if o.Location != nil {
 
 		// header param Location
@@ -239,6 +343,86 @@ func (o *ListAzureSizesParams) WriteToRequest(r runtime.ClientRequest, reg strfm
 
 	}
 
+	if o.AcceleratedNetworking != nil {
+
+		// query param acceleratedNetworking
+		var qrAcceleratedNetworking bool
+		if o.AcceleratedNetworking != nil {
+			qrAcceleratedNetworking = *o.AcceleratedNetworking
+		}
+		qAcceleratedNetworking := swag.FormatBool(qrAcceleratedNetworking)
+		if qAcceleratedNetworking != "" {
+			if err := r.SetQueryParam("acceleratedNetworking", qAcceleratedNetworking); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	if o.MinCPU != nil {
+
+		// query param minCPU
+		var qrMinCPU int64
+		if o.MinCPU != nil {
+			qrMinCPU = *o.MinCPU
+		}
+		qMinCPU := swag.FormatInt64(qrMinCPU)
+		if qMinCPU != "" {
+			if err := r.SetQueryParam("minCPU", qMinCPU); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	if o.MinMemory != nil {
+
+		// query param minMemory
+		var qrMinMemory int64
+		if o.MinMemory != nil {
+			qrMinMemory = *o.MinMemory
+		}
+		qMinMemory := swag.FormatInt64(qrMinMemory)
+		if qMinMemory != "" {
+			if err := r.SetQueryParam("minMemory", qMinMemory); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	if o.QuotaHeadroom != nil {
+
+		// query param quotaHeadroom
+		var qrQuotaHeadroom bool
+		if o.QuotaHeadroom != nil {
+			qrQuotaHeadroom = *o.QuotaHeadroom
+		}
+		qQuotaHeadroom := swag.FormatBool(qrQuotaHeadroom)
+		if qQuotaHeadroom != "" {
+			if err := r.SetQueryParam("quotaHeadroom", qQuotaHeadroom); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	if o.Zone != nil {
+
+		// query param zone
+		var qrZone string
+		if o.Zone != nil {
+			qrZone = *o.Zone
+		}
+		qZone := qrZone
+		if qZone != "" {
+			if err := r.SetQueryParam("zone", qZone); err != nil {
+				return err
+			}
+		}
+
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
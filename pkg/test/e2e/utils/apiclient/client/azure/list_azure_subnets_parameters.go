@@ -66,6 +66,8 @@ type ListAzureSubnetsParams struct {
 	ClientSecret *string
 	/*Credential*/
 	Credential *string
+	/*DatacenterName*/
+	DatacenterName *string
 	/*ResourceGroup*/
 	ResourceGroup *string
 	/*SubscriptionID*/
@@ -146,6 +148,17 @@ func (o *ListAzureSubnetsParams) SetCredential(credential *string) {
 	o.Credential = credential
 }
 
+// WithDatacenterName adds the datacenterName to the list azure subnets params
+func (o *ListAzureSubnetsParams) WithDatacenterName(datacenterName *string) *ListAzureSubnetsParams {
+	o.SetDatacenterName(datacenterName)
+	return o
+}
+
+// SetDatacenterName adds the datacenterName to the list azure subnets params
+func (o *ListAzureSubnetsParams) SetDatacenterName(datacenterName *string) {
+	o.DatacenterName = datacenterName
+}
+
 // WithResourceGroup adds the resourceGroup to the list azure subnets params
 func (o *ListAzureSubnetsParams) WithResourceGroup(resourceGroup *string) *ListAzureSubnetsParams {
 	o.SetResourceGroup(resourceGroup)
@@ -225,6 +238,15 @@ func (o *ListAzureSubnetsParams) WriteToRequest(r runtime.ClientRequest, reg str
 
 	}
 
+	if o.DatacenterName != nil {
+
+		// header param DatacenterName
+		if err := r.SetHeaderParam("DatacenterName", *o.DatacenterName); err != nil {
+			return err
+		}
+
+	}
+
 	if o.ResourceGroup != nil {
 
 		// header param ResourceGroup
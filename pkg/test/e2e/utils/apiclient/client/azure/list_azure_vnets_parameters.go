@@ -66,6 +66,8 @@ type ListAzureVnetsParams struct {
 	ClientSecret *string
 	/*Credential*/
 	Credential *string
+	/*DatacenterName*/
+	DatacenterName *string
 	/*Location*/
 	Location *string
 	/*ResourceGroup*/
@@ -146,6 +148,17 @@ func (o *ListAzureVnetsParams) SetCredential(credential *string) {
 	o.Credential = credential
 }
 
+// WithDatacenterName adds the datacenterName to the list azure vnets params
+func (o *ListAzureVnetsParams) WithDatacenterName(datacenterName *string) *ListAzureVnetsParams {
+	o.SetDatacenterName(datacenterName)
+	return o
+}
+
+// SetDatacenterName adds the datacenterName to the list azure vnets params
+func (o *ListAzureVnetsParams) SetDatacenterName(datacenterName *string) {
+	o.DatacenterName = datacenterName
+}
+
 // WithLocation adds the location to the list azure vnets params
 func (o *ListAzureVnetsParams) WithLocation(location *string) *ListAzureVnetsParams {
 	o.SetLocation(location)
@@ -225,6 +238,15 @@ func (o *ListAzureVnetsParams) WriteToRequest(r runtime.ClientRequest, reg strfm
 
 	}
 
+	if o.DatacenterName != nil {
+
+		// header param DatacenterName
+		if err := r.SetHeaderParam("DatacenterName", *o.DatacenterName); err != nil {
+			return err
+		}
+
+	}
+
 	if o.Location != nil {
 
 		// header param Location
@@ -11,6 +11,7 @@ import (
 	"github.com/go-openapi/strfmt"
 
 	"k8c.io/kubermatic/v2/pkg/test/e2e/utils/apiclient/client/addon"
+	"k8c.io/kubermatic/v2/pkg/test/e2e/utils/apiclient/client/addonrollout"
 	"k8c.io/kubermatic/v2/pkg/test/e2e/utils/apiclient/client/admin"
 	"k8c.io/kubermatic/v2/pkg/test/e2e/utils/apiclient/client/alibaba"
 	"k8c.io/kubermatic/v2/pkg/test/e2e/utils/apiclient/client/anexia"
@@ -26,6 +27,7 @@ import (
 	"k8c.io/kubermatic/v2/pkg/test/e2e/utils/apiclient/client/gcp"
 	"k8c.io/kubermatic/v2/pkg/test/e2e/utils/apiclient/client/hetzner"
 	"k8c.io/kubermatic/v2/pkg/test/e2e/utils/apiclient/client/metric"
+	"k8c.io/kubermatic/v2/pkg/test/e2e/utils/apiclient/client/namespacetemplate"
 	"k8c.io/kubermatic/v2/pkg/test/e2e/utils/apiclient/client/openstack"
 	"k8c.io/kubermatic/v2/pkg/test/e2e/utils/apiclient/client/operations"
 	"k8c.io/kubermatic/v2/pkg/test/e2e/utils/apiclient/client/packet"
@@ -86,6 +88,7 @@ func New(transport runtime.ClientTransport, formats strfmt.Registry) *Kubermatic
 	cli := new(KubermaticAPI)
 	cli.Transport = transport
 	cli.Addon = addon.New(transport, formats)
+	cli.Addonrollout = addonrollout.New(transport, formats)
 	cli.Admin = admin.New(transport, formats)
 	cli.Alibaba = alibaba.New(transport, formats)
 	cli.Anexia = anexia.New(transport, formats)
@@ -101,6 +104,7 @@ func New(transport runtime.ClientTransport, formats strfmt.Registry) *Kubermatic
 	cli.Gcp = gcp.New(transport, formats)
 	cli.Hetzner = hetzner.New(transport, formats)
 	cli.Metric = metric.New(transport, formats)
+	cli.Namespacetemplate = namespacetemplate.New(transport, formats)
 	cli.Openstack = openstack.New(transport, formats)
 	cli.Operations = operations.New(transport, formats)
 	cli.Packet = packet.New(transport, formats)
@@ -162,6 +166,8 @@ func (cfg *TransportConfig) WithSchemes(schemes []string) *TransportConfig {
 type KubermaticAPI struct {
 	Addon addon.ClientService
 
+	Addonrollout addonrollout.ClientService
+
 	Admin admin.ClientService
 
 	Alibaba alibaba.ClientService
@@ -192,6 +198,8 @@ type KubermaticAPI struct {
 
 	Metric metric.ClientService
 
+	Namespacetemplate namespacetemplate.ClientService
+
 	Openstack openstack.ClientService
 
 	Operations operations.ClientService
@@ -229,6 +237,7 @@ type KubermaticAPI struct {
 func (c *KubermaticAPI) SetTransport(transport runtime.ClientTransport) {
 	c.Transport = transport
 	c.Addon.SetTransport(transport)
+	c.Addonrollout.SetTransport(transport)
 	c.Admin.SetTransport(transport)
 	c.Alibaba.SetTransport(transport)
 	c.Anexia.SetTransport(transport)
@@ -244,6 +253,7 @@ func (c *KubermaticAPI) SetTransport(transport runtime.ClientTransport) {
 	c.Gcp.SetTransport(transport)
 	c.Hetzner.SetTransport(transport)
 	c.Metric.SetTransport(transport)
+	c.Namespacetemplate.SetTransport(transport)
 	c.Openstack.SetTransport(transport)
 	c.Operations.SetTransport(transport)
 	c.Packet.SetTransport(transport)
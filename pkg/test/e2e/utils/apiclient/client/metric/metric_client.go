@@ -27,6 +27,8 @@ type Client struct {
 type ClientService interface {
 	ListMachineDeploymentMetrics(params *ListMachineDeploymentMetricsParams, authInfo runtime.ClientAuthInfoWriter) (*ListMachineDeploymentMetricsOK, error)
 
+	ListMachineDeploymentsMetrics(params *ListMachineDeploymentsMetricsParams, authInfo runtime.ClientAuthInfoWriter) (*ListMachineDeploymentsMetricsOK, error)
+
 	ListNodeDeploymentMetrics(params *ListNodeDeploymentMetricsParams, authInfo runtime.ClientAuthInfoWriter) (*ListNodeDeploymentMetricsOK, error)
 
 	SetTransport(transport runtime.ClientTransport)
@@ -66,6 +68,40 @@ func (a *Client) ListMachineDeploymentMetrics(params *ListMachineDeploymentMetri
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+  ListMachineDeploymentsMetrics lists the node metrics of all machine deployments of the given cluster aggregated per machine deployment
+*/
+func (a *Client) ListMachineDeploymentsMetrics(params *ListMachineDeploymentsMetricsParams, authInfo runtime.ClientAuthInfoWriter) (*ListMachineDeploymentsMetricsOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewListMachineDeploymentsMetricsParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "listMachineDeploymentsMetrics",
+		Method:             "GET",
+		PathPattern:        "/api/v2/projects/{project_id}/clusters/{cluster_id}/machinedeployments/metrics",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &ListMachineDeploymentsMetricsReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ListMachineDeploymentsMetricsOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*ListMachineDeploymentsMetricsDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
   ListNodeDeploymentMetrics lists metrics that belong to the given node deployment
 */
@@ -39,6 +39,10 @@ type ClientService interface {
 
 	CreateCluster(params *CreateClusterParams, authInfo runtime.ClientAuthInfoWriter) (*CreateClusterCreated, error)
 
+	CreateClusterKubeconfigShareLinkV2(params *CreateClusterKubeconfigShareLinkV2Params, authInfo runtime.ClientAuthInfoWriter) (*CreateClusterKubeconfigShareLinkV2Created, error)
+
+	CreateClusterNamespace(params *CreateClusterNamespaceParams, authInfo runtime.ClientAuthInfoWriter) (*CreateClusterNamespaceCreated, error)
+
 	CreateClusterRole(params *CreateClusterRoleParams, authInfo runtime.ClientAuthInfoWriter) (*CreateClusterRoleCreated, error)
 
 	CreateClusterTemplate(params *CreateClusterTemplateParams, authInfo runtime.ClientAuthInfoWriter) (*CreateClusterTemplateCreated, error)
@@ -65,6 +69,8 @@ type ClientService interface {
 
 	DeleteCluster(params *DeleteClusterParams, authInfo runtime.ClientAuthInfoWriter) (*DeleteClusterOK, error)
 
+	DeleteClusterNamespace(params *DeleteClusterNamespaceParams, authInfo runtime.ClientAuthInfoWriter) (*DeleteClusterNamespaceOK, error)
+
 	DeleteClusterRole(params *DeleteClusterRoleParams, authInfo runtime.ClientAuthInfoWriter) (*DeleteClusterRoleOK, error)
 
 	DeleteClusterTemplate(params *DeleteClusterTemplateParams, authInfo runtime.ClientAuthInfoWriter) (*DeleteClusterTemplateOK, error)
@@ -107,6 +113,8 @@ type ClientService interface {
 
 	GetClusterHealthV2(params *GetClusterHealthV2Params, authInfo runtime.ClientAuthInfoWriter) (*GetClusterHealthV2OK, error)
 
+	GetClusterInfrastructure(params *GetClusterInfrastructureParams, authInfo runtime.ClientAuthInfoWriter) (*GetClusterInfrastructureOK, error)
+
 	GetClusterKubeconfig(params *GetClusterKubeconfigParams, authInfo runtime.ClientAuthInfoWriter) (*GetClusterKubeconfigOK, error)
 
 	GetClusterKubeconfigV2(params *GetClusterKubeconfigV2Params, authInfo runtime.ClientAuthInfoWriter) (*GetClusterKubeconfigV2OK, error)
@@ -121,6 +129,8 @@ type ClientService interface {
 
 	GetClusterTemplateInstance(params *GetClusterTemplateInstanceParams, authInfo runtime.ClientAuthInfoWriter) (*GetClusterTemplateInstanceOK, error)
 
+	GetClusterUpgradePreflight(params *GetClusterUpgradePreflightParams, authInfo runtime.ClientAuthInfoWriter) (*GetClusterUpgradePreflightOK, error)
+
 	GetClusterUpgrades(params *GetClusterUpgradesParams, authInfo runtime.ClientAuthInfoWriter) (*GetClusterUpgradesOK, error)
 
 	GetClusterUpgradesV2(params *GetClusterUpgradesV2Params, authInfo runtime.ClientAuthInfoWriter) (*GetClusterUpgradesV2OK, error)
@@ -139,6 +149,12 @@ type ClientService interface {
 
 	GetMachineDeployment(params *GetMachineDeploymentParams, authInfo runtime.ClientAuthInfoWriter) (*GetMachineDeploymentOK, error)
 
+	GetMonitoringClusterEventsV2(params *GetMonitoringClusterEventsV2Params, authInfo runtime.ClientAuthInfoWriter) (*GetMonitoringClusterEventsV2OK, error)
+
+	GetMonitoringClusterHealthV2(params *GetMonitoringClusterHealthV2Params, authInfo runtime.ClientAuthInfoWriter) (*GetMonitoringClusterHealthV2OK, error)
+
+	GetMonitoringClusterMetricsV2(params *GetMonitoringClusterMetricsV2Params, authInfo runtime.ClientAuthInfoWriter) (*GetMonitoringClusterMetricsV2OK, error)
+
 	GetNodeDeployment(params *GetNodeDeploymentParams, authInfo runtime.ClientAuthInfoWriter) (*GetNodeDeploymentOK, error)
 
 	GetOidcClusterKubeconfig(params *GetOidcClusterKubeconfigParams, authInfo runtime.ClientAuthInfoWriter) (*GetOidcClusterKubeconfigOK, error)
@@ -147,8 +163,16 @@ type ClientService interface {
 
 	GetProject(params *GetProjectParams, authInfo runtime.ClientAuthInfoWriter) (*GetProjectOK, error)
 
+	GetProjectDeletionReport(params *GetProjectDeletionReportParams, authInfo runtime.ClientAuthInfoWriter) (*GetProjectDeletionReportOK, error)
+
 	GetRole(params *GetRoleParams, authInfo runtime.ClientAuthInfoWriter) (*GetRoleOK, error)
 
+	GetSharedClusterKubeconfigV2(params *GetSharedClusterKubeconfigV2Params, authInfo runtime.ClientAuthInfoWriter) (*GetSharedClusterKubeconfigV2OK, error)
+
+	ListClusterCredentialRotations(params *ListClusterCredentialRotationsParams, authInfo runtime.ClientAuthInfoWriter) (*ListClusterCredentialRotationsOK, error)
+
+	ListClusterNamespaces(params *ListClusterNamespacesParams, authInfo runtime.ClientAuthInfoWriter) (*ListClusterNamespacesOK, error)
+
 	ListClusterRole(params *ListClusterRoleParams, authInfo runtime.ClientAuthInfoWriter) (*ListClusterRoleOK, error)
 
 	ListClusterRoleBinding(params *ListClusterRoleBindingParams, authInfo runtime.ClientAuthInfoWriter) (*ListClusterRoleBindingOK, error)
@@ -161,6 +185,8 @@ type ClientService interface {
 
 	ListClusterRoleV2(params *ListClusterRoleV2Params, authInfo runtime.ClientAuthInfoWriter) (*ListClusterRoleV2OK, error)
 
+	ListClusterSpecRevisionsV2(params *ListClusterSpecRevisionsV2Params, authInfo runtime.ClientAuthInfoWriter) (*ListClusterSpecRevisionsV2OK, error)
+
 	ListClusterTemplateInstances(params *ListClusterTemplateInstancesParams, authInfo runtime.ClientAuthInfoWriter) (*ListClusterTemplateInstancesOK, error)
 
 	ListClusterTemplates(params *ListClusterTemplatesParams, authInfo runtime.ClientAuthInfoWriter) (*ListClusterTemplatesOK, error)
@@ -237,6 +263,10 @@ type ClientService interface {
 
 	PatchRole(params *PatchRoleParams, authInfo runtime.ClientAuthInfoWriter) (*PatchRoleOK, error)
 
+	PlanClusterInfrastructure(params *PlanClusterInfrastructureParams, authInfo runtime.ClientAuthInfoWriter) (*PlanClusterInfrastructureOK, error)
+
+	PreviewMachineDeploymentBootstrapConfig(params *PreviewMachineDeploymentBootstrapConfigParams, authInfo runtime.ClientAuthInfoWriter) (*PreviewMachineDeploymentBootstrapConfigOK, error)
+
 	ResetAlertmanager(params *ResetAlertmanagerParams, authInfo runtime.ClientAuthInfoWriter) (*ResetAlertmanagerOK, error)
 
 	RestartMachineDeployment(params *RestartMachineDeploymentParams, authInfo runtime.ClientAuthInfoWriter) (*RestartMachineDeploymentOK, error)
@@ -245,10 +275,14 @@ type ClientService interface {
 
 	RevokeClusterAdminTokenV2(params *RevokeClusterAdminTokenV2Params, authInfo runtime.ClientAuthInfoWriter) (*RevokeClusterAdminTokenV2OK, error)
 
+	RevokeClusterKubeconfigShareLinkV2(params *RevokeClusterKubeconfigShareLinkV2Params, authInfo runtime.ClientAuthInfoWriter) (*RevokeClusterKubeconfigShareLinkV2OK, error)
+
 	RevokeClusterViewerToken(params *RevokeClusterViewerTokenParams, authInfo runtime.ClientAuthInfoWriter) (*RevokeClusterViewerTokenOK, error)
 
 	RevokeClusterViewerTokenV2(params *RevokeClusterViewerTokenV2Params, authInfo runtime.ClientAuthInfoWriter) (*RevokeClusterViewerTokenV2OK, error)
 
+	RollbackClusterSpecV2(params *RollbackClusterSpecV2Params, authInfo runtime.ClientAuthInfoWriter) (*RollbackClusterSpecV2OK, error)
+
 	UnbindUserFromClusterRoleBinding(params *UnbindUserFromClusterRoleBindingParams, authInfo runtime.ClientAuthInfoWriter) (*UnbindUserFromClusterRoleBindingOK, error)
 
 	UnbindUserFromClusterRoleBindingV2(params *UnbindUserFromClusterRoleBindingV2Params, authInfo runtime.ClientAuthInfoWriter) (*UnbindUserFromClusterRoleBindingV2OK, error)
@@ -508,6 +542,74 @@ func (a *Client) CreateCluster(params *CreateClusterParams, authInfo runtime.Cli
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+  CreateClusterKubeconfigShareLinkV2 creates a time limited link to download the viewer kubeconfig for the specified cluster
+*/
+func (a *Client) CreateClusterKubeconfigShareLinkV2(params *CreateClusterKubeconfigShareLinkV2Params, authInfo runtime.ClientAuthInfoWriter) (*CreateClusterKubeconfigShareLinkV2Created, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewCreateClusterKubeconfigShareLinkV2Params()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "createClusterKubeconfigShareLinkV2",
+		Method:             "POST",
+		PathPattern:        "/api/v2/projects/{project_id}/clusters/{cluster_id}/kubeconfig/share",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &CreateClusterKubeconfigShareLinkV2Reader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*CreateClusterKubeconfigShareLinkV2Created)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*CreateClusterKubeconfigShareLinkV2Default)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
+/*
+  CreateClusterNamespace creates a namespace in the cluster the resource quota limit range network policy and role bindings of the given namespace template are created together with the namespace
+*/
+func (a *Client) CreateClusterNamespace(params *CreateClusterNamespaceParams, authInfo runtime.ClientAuthInfoWriter) (*CreateClusterNamespaceCreated, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewCreateClusterNamespaceParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "createClusterNamespace",
+		Method:             "POST",
+		PathPattern:        "/api/v2/projects/{project_id}/clusters/{cluster_id}/clusternamespaces",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &CreateClusterNamespaceReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*CreateClusterNamespaceCreated)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*CreateClusterNamespaceDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
   CreateClusterRole Creates cluster role
 */
@@ -952,6 +1054,40 @@ func (a *Client) DeleteCluster(params *DeleteClusterParams, authInfo runtime.Cli
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+  DeleteClusterNamespace deletes a namespace which was created via the API together with everything in it
+*/
+func (a *Client) DeleteClusterNamespace(params *DeleteClusterNamespaceParams, authInfo runtime.ClientAuthInfoWriter) (*DeleteClusterNamespaceOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewDeleteClusterNamespaceParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "deleteClusterNamespace",
+		Method:             "DELETE",
+		PathPattern:        "/api/v2/projects/{project_id}/clusters/{cluster_id}/clusternamespaces/{namespace}",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &DeleteClusterNamespaceReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*DeleteClusterNamespaceOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*DeleteClusterNamespaceDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
   DeleteClusterRole Delete the cluster role with the given name
 */
@@ -1668,6 +1804,41 @@ func (a *Client) GetClusterHealthV2(params *GetClusterHealthV2Params, authInfo r
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+  GetClusterInfrastructure Queries the cloud provider for the live state of the resources used by the cluster and
+  reports the differences to the cluster spec. Only supported for Azure clusters
+*/
+func (a *Client) GetClusterInfrastructure(params *GetClusterInfrastructureParams, authInfo runtime.ClientAuthInfoWriter) (*GetClusterInfrastructureOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetClusterInfrastructureParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "getClusterInfrastructure",
+		Method:             "GET",
+		PathPattern:        "/api/v2/projects/{project_id}/clusters/{cluster_id}/infrastructure",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &GetClusterInfrastructureReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetClusterInfrastructureOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*GetClusterInfrastructureDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
   GetClusterKubeconfig gets the kubeconfig for the specified cluster
 */
@@ -1906,6 +2077,41 @@ func (a *Client) GetClusterTemplateInstance(params *GetClusterTemplateInstancePa
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+  GetClusterUpgradePreflight Gets the compatibility of the cluster with the next Kubernetes minor version, based on the
+  last scan for APIs which are removed in that version
+*/
+func (a *Client) GetClusterUpgradePreflight(params *GetClusterUpgradePreflightParams, authInfo runtime.ClientAuthInfoWriter) (*GetClusterUpgradePreflightOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetClusterUpgradePreflightParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "getClusterUpgradePreflight",
+		Method:             "GET",
+		PathPattern:        "/api/v2/projects/{project_id}/clusters/{cluster_id}/upgrades/preflight",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &GetClusterUpgradePreflightReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetClusterUpgradePreflightOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*GetClusterUpgradePreflightDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
   GetClusterUpgrades Gets possible cluster upgrades
 */
@@ -2212,6 +2418,108 @@ func (a *Client) GetMachineDeployment(params *GetMachineDeploymentParams, authIn
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+  GetMonitoringClusterEventsV2 Gets the events related to the specified cluster. Only accepts service account tokens with the monitoring scope.
+*/
+func (a *Client) GetMonitoringClusterEventsV2(params *GetMonitoringClusterEventsV2Params, authInfo runtime.ClientAuthInfoWriter) (*GetMonitoringClusterEventsV2OK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetMonitoringClusterEventsV2Params()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "getMonitoringClusterEventsV2",
+		Method:             "GET",
+		PathPattern:        "/api/v2/projects/{project_id}/monitoring/clusters/{cluster_id}/events",
+		ProducesMediaTypes: []string{"application/yaml"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &GetMonitoringClusterEventsV2Reader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetMonitoringClusterEventsV2OK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*GetMonitoringClusterEventsV2Default)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
+/*
+  GetMonitoringClusterHealthV2 Returns the cluster's component health status. Only accepts service account tokens with the monitoring scope.
+*/
+func (a *Client) GetMonitoringClusterHealthV2(params *GetMonitoringClusterHealthV2Params, authInfo runtime.ClientAuthInfoWriter) (*GetMonitoringClusterHealthV2OK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetMonitoringClusterHealthV2Params()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "getMonitoringClusterHealthV2",
+		Method:             "GET",
+		PathPattern:        "/api/v2/projects/{project_id}/monitoring/clusters/{cluster_id}/health",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &GetMonitoringClusterHealthV2Reader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetMonitoringClusterHealthV2OK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*GetMonitoringClusterHealthV2Default)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
+/*
+  GetMonitoringClusterMetricsV2 Gets cluster metrics. Only accepts service account tokens with the monitoring scope.
+*/
+func (a *Client) GetMonitoringClusterMetricsV2(params *GetMonitoringClusterMetricsV2Params, authInfo runtime.ClientAuthInfoWriter) (*GetMonitoringClusterMetricsV2OK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetMonitoringClusterMetricsV2Params()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "getMonitoringClusterMetricsV2",
+		Method:             "GET",
+		PathPattern:        "/api/v2/projects/{project_id}/monitoring/clusters/{cluster_id}/metrics",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &GetMonitoringClusterMetricsV2Reader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetMonitoringClusterMetricsV2OK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*GetMonitoringClusterMetricsV2Default)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
   GetNodeDeployment gets a node deployment that is assigned to the given cluster
 */
@@ -2348,6 +2656,42 @@ func (a *Client) GetProject(params *GetProjectParams, authInfo runtime.ClientAut
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+  GetProjectDeletionReport Lists the resources which are removed together with the project. For a project
+
+which is being deleted, the report shows the progress of each deletion stage.
+*/
+func (a *Client) GetProjectDeletionReport(params *GetProjectDeletionReportParams, authInfo runtime.ClientAuthInfoWriter) (*GetProjectDeletionReportOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetProjectDeletionReportParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "getProjectDeletionReport",
+		Method:             "GET",
+		PathPattern:        "/api/v1/projects/{project_id}/deletionreport",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &GetProjectDeletionReportReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetProjectDeletionReportOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*GetProjectDeletionReportDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
   GetRole Gets the role with the given name
 */
@@ -2382,6 +2726,109 @@ func (a *Client) GetRole(params *GetRoleParams, authInfo runtime.ClientAuthInfoW
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+  GetSharedClusterKubeconfigV2 gets the viewer kubeconfig for the cluster a sharing link was created for
+*/
+func (a *Client) GetSharedClusterKubeconfigV2(params *GetSharedClusterKubeconfigV2Params, authInfo runtime.ClientAuthInfoWriter) (*GetSharedClusterKubeconfigV2OK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetSharedClusterKubeconfigV2Params()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "getSharedClusterKubeconfigV2",
+		Method:             "GET",
+		PathPattern:        "/api/v2/kubeconfig/shared",
+		ProducesMediaTypes: []string{"application/octet-stream"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &GetSharedClusterKubeconfigV2Reader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetSharedClusterKubeconfigV2OK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*GetSharedClusterKubeconfigV2Default)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
+/*
+  ListClusterCredentialRotations Lists the age of the tokens and certificates generated for the control plane of the cluster,
+  which are rotated periodically
+*/
+func (a *Client) ListClusterCredentialRotations(params *ListClusterCredentialRotationsParams, authInfo runtime.ClientAuthInfoWriter) (*ListClusterCredentialRotationsOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewListClusterCredentialRotationsParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "listClusterCredentialRotations",
+		Method:             "GET",
+		PathPattern:        "/api/v2/projects/{project_id}/clusters/{cluster_id}/credentialrotations",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &ListClusterCredentialRotationsReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ListClusterCredentialRotationsOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*ListClusterCredentialRotationsDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
+/*
+  ListClusterNamespaces lists the namespaces which were created in the cluster via the API
+*/
+func (a *Client) ListClusterNamespaces(params *ListClusterNamespacesParams, authInfo runtime.ClientAuthInfoWriter) (*ListClusterNamespacesOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewListClusterNamespacesParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "listClusterNamespaces",
+		Method:             "GET",
+		PathPattern:        "/api/v2/projects/{project_id}/clusters/{cluster_id}/clusternamespaces",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &ListClusterNamespacesReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ListClusterNamespacesOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*ListClusterNamespacesDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
   ListClusterRole Lists all ClusterRoles
 */
@@ -2586,6 +3033,40 @@ func (a *Client) ListClusterRoleV2(params *ListClusterRoleV2Params, authInfo run
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+  ListClusterSpecRevisionsV2 Lists the recorded changes of the cluster spec oldest first
+*/
+func (a *Client) ListClusterSpecRevisionsV2(params *ListClusterSpecRevisionsV2Params, authInfo runtime.ClientAuthInfoWriter) (*ListClusterSpecRevisionsV2OK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewListClusterSpecRevisionsV2Params()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "listClusterSpecRevisionsV2",
+		Method:             "GET",
+		PathPattern:        "/api/v2/projects/{project_id}/clusters/{cluster_id}/history",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &ListClusterSpecRevisionsV2Reader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ListClusterSpecRevisionsV2OK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*ListClusterSpecRevisionsV2Default)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
   ListClusterTemplateInstances lists cluster template instances
 */
@@ -3888,6 +4369,78 @@ func (a *Client) PatchRole(params *PatchRoleParams, authInfo runtime.ClientAuthI
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+  PlanClusterInfrastructure Returns the cloud resources which would be created for a cluster with the given spec,
+without creating the cluster or any cloud resources. The names of the resources contain
+the placeholder "cluster-id" instead of the ID the cluster gets when it is created.
+Only supported for Azure clusters.
+*/
+func (a *Client) PlanClusterInfrastructure(params *PlanClusterInfrastructureParams, authInfo runtime.ClientAuthInfoWriter) (*PlanClusterInfrastructureOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewPlanClusterInfrastructureParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "planClusterInfrastructure",
+		Method:             "POST",
+		PathPattern:        "/api/v2/projects/{project_id}/clusters/infrastructureplan",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &PlanClusterInfrastructureReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*PlanClusterInfrastructureOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*PlanClusterInfrastructureDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
+/*
+  PreviewMachineDeploymentBootstrapConfig Renders the bootstrap configuration the machines of the given machine deployment would be provisioned with,
+  without creating the machine deployment. Secrets like the bootstrap token and the cloud config are redacted.
+*/
+func (a *Client) PreviewMachineDeploymentBootstrapConfig(params *PreviewMachineDeploymentBootstrapConfigParams, authInfo runtime.ClientAuthInfoWriter) (*PreviewMachineDeploymentBootstrapConfigOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewPreviewMachineDeploymentBootstrapConfigParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "previewMachineDeploymentBootstrapConfig",
+		Method:             "POST",
+		PathPattern:        "/api/v2/projects/{project_id}/clusters/{cluster_id}/machinedeployments/bootstrapconfig",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &PreviewMachineDeploymentBootstrapConfigReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*PreviewMachineDeploymentBootstrapConfigOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*PreviewMachineDeploymentBootstrapConfigDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
   ResetAlertmanager resets the alertmanager configuration to default for the specified cluster
 */
@@ -4024,6 +4577,40 @@ func (a *Client) RevokeClusterAdminTokenV2(params *RevokeClusterAdminTokenV2Para
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+  RevokeClusterKubeconfigShareLinkV2 Revokes a kubeconfig sharing link and the tokens of the kubeconfigs downloaded with it
+*/
+func (a *Client) RevokeClusterKubeconfigShareLinkV2(params *RevokeClusterKubeconfigShareLinkV2Params, authInfo runtime.ClientAuthInfoWriter) (*RevokeClusterKubeconfigShareLinkV2OK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewRevokeClusterKubeconfigShareLinkV2Params()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "revokeClusterKubeconfigShareLinkV2",
+		Method:             "DELETE",
+		PathPattern:        "/api/v2/projects/{project_id}/clusters/{cluster_id}/kubeconfig/share/{link_id}",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &RevokeClusterKubeconfigShareLinkV2Reader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*RevokeClusterKubeconfigShareLinkV2OK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*RevokeClusterKubeconfigShareLinkV2Default)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
   RevokeClusterViewerToken Revokes the current viewer token
 */
@@ -4092,6 +4679,42 @@ func (a *Client) RevokeClusterViewerTokenV2(params *RevokeClusterViewerTokenV2Pa
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+  RollbackClusterSpecV2 Rolls the cluster spec back to the given revision only the fields which can safely be
+ changed on a running cluster are restored like the name the admission plugins audit
+ logging o p a integration m l a settings and the update window
+*/
+func (a *Client) RollbackClusterSpecV2(params *RollbackClusterSpecV2Params, authInfo runtime.ClientAuthInfoWriter) (*RollbackClusterSpecV2OK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewRollbackClusterSpecV2Params()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "rollbackClusterSpecV2",
+		Method:             "POST",
+		PathPattern:        "/api/v2/projects/{project_id}/clusters/{cluster_id}/history/{revision}/rollback",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &RollbackClusterSpecV2Reader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*RollbackClusterSpecV2OK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*RollbackClusterSpecV2Default)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
   UnbindUserFromClusterRoleBinding Unbinds user from cluster role binding
 */
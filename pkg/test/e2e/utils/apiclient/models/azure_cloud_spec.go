@@ -6,6 +6,8 @@ package models
 // Editing this file might prove futile when you re-run the swagger generate command
 
 import (
+	"strconv"
+
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
@@ -16,64 +18,375 @@ import (
 // swagger:model AzureCloudSpec
 type AzureCloudSpec struct {
 
+	// Optional: AdditionalSubnets are created in the cluster's VNet next to the cluster's subnet and
+	// can be selected by MachineDeployments, to separate workloads like frontends, backends or GPU
+	// nodes on the network layer. Each subnet is associated with a security group and the route
+	// table of the cluster. Their address ranges are added to the address space of the VNet if it
+	// does not contain them yet. Subnets created by Kubermatic are deleted once they are removed
+	// from the list, which fails as long as nodes are still placed in them.
+	AdditionalSubnets []*AzureSubnet `json:"additionalSubnets"`
+
+	// Optional: AdoptedResources lists the pre-existing resources referenced above which Kubermatic
+	// takes over, any of "resourceGroup", "vnet", "subnet", "routeTable" and "securityGroup".
+	// Adopted resources are tagged as owned by the cluster, reconciled like the resources created
+	// by Kubermatic and deleted together with the cluster. Adoption fails if a resource is already
+	// tagged as owned by another cluster. Referenced resources which are not listed here are left
+	// untouched. Adoption cannot be undone by removing a resource from the list.
+	AdoptedResources []string `json:"adoptedResources"`
+
+	// Optional: AllowedIPRanges are the address ranges SSH access to the nodes is allowed from, if
+	// the security group is created by Kubermatic. Defaults to the ones configured in the
+	// datacenter, if unset SSH is allowed from everywhere. Private clusters only allow SSH from
+	// within their VNet. Cannot be changed after the cluster has been created.
+	AllowedIPRanges []string `json:"allowedIPRanges"`
+
+	// Optional: ApplicationSecurityGroup is the name of an application security group in the
+	// resource group, which is created if it does not exist. The NICs of the nodes are added to it
+	// and the inbound rules of the security group created by Kubermatic target it instead of all
+	// addresses, so that they keep applying when node IPs change. Cannot be changed after the
+	// cluster has been created.
+	ApplicationSecurityGroup string `json:"applicationSecurityGroup,omitempty"`
+
 	// availability set
 	AvailabilitySet string `json:"availabilitySet,omitempty"`
 
+	// Optional: If set to true, the machines of every MachineDeployment created afterwards are
+	// placed in an availability set of their own instead of the one of the cluster, as an
+	// availability set holds at most 200 VMs. The availability sets are listed in the status of
+	// the cluster and deleted together with it.
+	AvailabilitySetPerMachineDeployment bool `json:"availabilitySetPerMachineDeployment,omitempty"`
+
+	// boot diagnostics
+	BootDiagnostics *AzureBootDiagnostics `json:"bootDiagnostics,omitempty"`
+
 	// client ID
 	ClientID string `json:"clientID,omitempty"`
 
+	// Optional: If set to true, Kubermatic creates a service principal for the cluster, which
+	// only has the Contributor role on the resource group of the cluster, and stores it in the
+	// cluster namespace. The control plane and the nodes use it instead of the credentials of the
+	// cluster, which are still used to create the cloud resources and the service principal and
+	// therefore need permissions to register applications in Azure AD. The VNet has to be in the
+	// resource group of the cluster. Cannot be changed after the cluster has been created.
+	ClusterServicePrincipal bool `json:"clusterServicePrincipal,omitempty"`
+
 	// client secret
 	ClientSecret string `json:"clientSecret,omitempty"`
 
+	// Optional: DiskEncryptionSetID is the ID of a disk encryption set in the cluster's subscription
+	// and the datacenter's region, which is used to encrypt the OS disks of the nodes with
+	// customer-managed keys. Only applies to nodes created after it has been set.
+	DiskEncryptionSetID string `json:"diskEncryptionSetID,omitempty"`
+
+	// Optional: DNSServers are the IP addresses of the DNS servers the nodes use, for example to
+	// resolve corporate DNS names. They are only applied to VNets created by Kubermatic; if unset,
+	// the DNS settings of the VNet are left untouched. Running nodes pick up changes once they
+	// renew their DHCP lease or are restarted.
+	DNSServers []string `json:"dnsServers"`
+
+	// Optional: EnableBastion provisions an AzureBastionSubnet and a Bastion host with a public IP
+	// in the cluster's VNet, to reach the nodes for debugging without exposing SSH to the internet.
+	// If the VNet has no AzureBastionSubnet yet, 10.1.0.0/26 is added to its address space for it.
+	EnableBastion bool `json:"enableBastion,omitempty"`
+
+	// Optional: If set to true, a private DNS zone is linked to the cluster's VNet, resolving the
+	// API server name and the hostnames of the nodes, which are registered automatically. This
+	// allows clusters in isolated VNets to resolve internal names without further setup. Private
+	// clusters always get a private DNS zone, but nodes are only registered if this is set. Cannot
+	// be changed after the cluster has been created.
+	EnablePrivateDNSZone bool `json:"enablePrivateDNSZone,omitempty"`
+
+	// Optional: If set to true, a proximity placement group is created for the cluster and the
+	// availability set is assigned to it, so that all worker nodes are placed physically close to
+	// each other. Only takes effect when the availability set is created by Kubermatic.
+	EnableProximityPlacementGroup bool `json:"enableProximityPlacementGroup,omitempty"`
+
+	// Optional: PeerVNets are the resource IDs of hub VNets the cluster's VNet is peered with, in
+	// addition to the ones configured in the datacenter. The hub VNets may live in other resource
+	// groups or subscriptions. If the credentials allow it, the peering back from the hub is
+	// created as well, otherwise it has to be set up by the owner of the hub VNet. Only VNets
+	// created by Kubermatic are peered, VNets provided by the user may be shared with other clusters.
+	PeerVNets []string `json:"peerVNets"`
+
+	// Optional: If set to true, no public endpoints are created for the cluster. The API server
+	// is exposed to the nodes via a private endpoint in the cluster's VNet, which requires the
+	// LoadBalancer expose strategy and a datacenter with Private Link configured. Cannot be
+	// changed after the cluster has been created.
+	PrivateCluster bool `json:"privateCluster,omitempty"`
+
+	// PrivateDNSZone is the name of the private DNS zone resolving the API server name within the cluster's VNet.
+	// It is named after the external name of the cluster.
+	PrivateDNSZone string `json:"privateDNSZone,omitempty"`
+
+	// PrivateEndpoint is the name of the private endpoint connecting the cluster's VNet to the API server.
+	PrivateEndpoint string `json:"privateEndpoint,omitempty"`
+
+	// Optional: PrivateEndpoints are created in the cluster's subnet to reach PaaS resources,
+	// like storage accounts or key vaults, via private IP addresses. Name resolution for them
+	// has to be set up separately, e.g. with private DNS zones linked to the VNet.
+	PrivateEndpoints []*AzurePrivateEndpoint `json:"privateEndpoints"`
+
+	// PrivateEndpointIP is the IP address of the private endpoint within the cluster's subnet.
+	PrivateEndpointIP string `json:"privateEndpointIP,omitempty"`
+
+	// ProximityPlacementGroupID is the Azure resource ID of the proximity placement group the
+	// cluster's availability set is assigned to.
+	ProximityPlacementGroupID string `json:"proximityPlacementGroupID,omitempty"`
+
 	// resource group
 	ResourceGroup string `json:"resourceGroup,omitempty"`
 
+	// Optional: RouteTableID is the resource ID of an existing route table in the subscription of
+	// the VNet, e.g. in the resource group of the VNet, which is used instead of creating one in
+	// the resource group of the cluster. If the subnet already exists, it has to be associated with
+	// the route table, otherwise the subnet is created with it. The route table is neither modified
+	// nor deleted by Kubermatic. Cannot be changed after the cluster has been created.
+	RouteTableID string `json:"routeTableID,omitempty"`
+
 	// route table name
 	RouteTableName string `json:"routeTable,omitempty"`
 
+	// Optional: Routes are added to the route table created by Kubermatic, for example to force
+	// the egress traffic of the nodes through an Azure Firewall or a network virtual appliance.
+	// Other routes in the route table are left untouched.
+	Routes []*AzureRoute `json:"routes"`
+
 	// security group
 	SecurityGroup string `json:"securityGroup,omitempty"`
 
+	// Optional: SecurityGroupID is the resource ID of an existing security group in the
+	// subscription of the VNet, which is used instead of creating one in the resource group of
+	// the cluster. It has to allow the traffic of the nodes and is associated with the subnet
+	// instead of the network interfaces of the nodes, so an existing subnet has to be associated
+	// with it already. The security group is not deleted by Kubermatic. Cannot be changed after the
+	// cluster has been created.
+	SecurityGroupID string `json:"securityGroupID,omitempty"`
+
+	// Optional: SecurityGroupRulePriority is the lowest priority used for the rules Kubermatic adds
+	// to the security group, allowing to reserve the priorities below it for own rules. Must be
+	// between 100 and 3396, defaults to 100. If a priority is already taken by another rule in an
+	// existing security group, the next free one is used.
+	SecurityGroupRulePriority int32 `json:"securityGroupRulePriority,omitempty"`
+
+	// Optional: ServiceEndpoints are the Azure services, for example "Microsoft.Storage",
+	// "Microsoft.KeyVault" or "Microsoft.Sql", whose traffic from the nodes is routed over the
+	// Microsoft backbone. They are only applied to subnets created by Kubermatic; if unset, the
+	// service endpoints of the subnet are left untouched.
+	ServiceEndpoints []string `json:"serviceEndpoints"`
+
 	// subnet name
 	SubnetName string `json:"subnet,omitempty"`
 
+	// Optional: SubnetCIDR is the address range of the subnet if it is created by Kubermatic. It
+	// has to be within the address space of the VNet. Defaults to the one configured in the
+	// datacenter or to the address space of the VNet. Cannot be changed after the cluster has been
+	// created.
+	SubnetCIDR string `json:"subnetCIDR,omitempty"`
+
 	// subscription ID
 	SubscriptionID string `json:"subscriptionID,omitempty"`
 
+	// Tags are applied to all Azure resources created for the cluster. They are merged with the
+	// tags configured in the datacenter, tags defined here take precedence.
+	Tags map[string]string `json:"tags,omitempty"`
+
 	// tenant ID
 	TenantID string `json:"tenantID,omitempty"`
 
 	// v net name
 	VNetName string `json:"vnet,omitempty"`
 
+	// Optional: VNetCIDR is the address space of the VNet if it is created by Kubermatic. Defaults
+	// to the one configured in the datacenter or to 10.0.0.0/16. Cannot be changed after the
+	// cluster has been created.
+	VNetCIDR string `json:"vnetCIDR,omitempty"`
+
 	// v net resource group
 	VNetResourceGroup string `json:"vnetResourceGroup,omitempty"`
 
+	// Optional: VNetSubscriptionID is the subscription the VNet and its resource group live in,
+	// if it differs from the subscription of the cluster, e.g. in a hub-and-spoke layout where
+	// the network is owned by another team. The VNet, the subnet and the security group have to
+	// exist already, as well as the route table if one is needed, and the route table and the
+	// security group have to be referenced by ID. The credentials of the cluster need
+	// permissions on them. Cannot be changed after the cluster has been created.
+	VNetSubscriptionID string `json:"vnetSubscriptionID,omitempty"`
+
+	// Optional: VNetTenantID is the Azure AD tenant of the VNet subscription, if it differs from
+	// the tenant of the cluster. The service principal of the cluster has to be a multi-tenant
+	// application which is registered in that tenant, so that it can authorize against both.
+	// Cannot be changed after the cluster has been created.
+	VNetTenantID string `json:"vnetTenantID,omitempty"`
+
 	// credentials reference
 	CredentialsReference GlobalSecretKeySelector `json:"credentialsReference,omitempty"`
 
+	// egress firewall
+	EgressFirewall *AzureEgressFirewall `json:"egressFirewall,omitempty"`
+
+	// kms
+	KMS *AzureKMSSettings `json:"kms,omitempty"`
+
 	// load balancer s k u
 	LoadBalancerSKU LBSKU `json:"loadBalancerSKU,omitempty"`
+
+	// log analytics
+	LogAnalytics *AzureLogAnalytics `json:"logAnalytics,omitempty"`
+
+	// network plugin
+	NetworkPlugin AzureNetworkPlugin `json:"networkPlugin,omitempty"`
+
+	// node security
+	NodeSecurity *AzureNodeSecurity `json:"nodeSecurity,omitempty"`
 }
 
 // Validate validates this azure cloud spec
 func (m *AzureCloudSpec) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateAdditionalSubnets(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validatePrivateEndpoints(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateRoutes(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateBootDiagnostics(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateCredentialsReference(formats); err != nil {
 		res = append(res, err)
 	}
 
+	if err := m.validateEgressFirewall(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateKMS(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateLoadBalancerSKU(formats); err != nil {
 		res = append(res, err)
 	}
 
+	if err := m.validateLogAnalytics(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateNetworkPlugin(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateNodeSecurity(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
 	return nil
 }
 
+func (m *AzureCloudSpec) validateAdditionalSubnets(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.AdditionalSubnets) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.AdditionalSubnets); i++ {
+		if swag.IsZero(m.AdditionalSubnets[i]) { // not required
+			continue
+		}
+
+		if m.AdditionalSubnets[i] != nil {
+			if err := m.AdditionalSubnets[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("additionalSubnets" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+func (m *AzureCloudSpec) validatePrivateEndpoints(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.PrivateEndpoints) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.PrivateEndpoints); i++ {
+		if swag.IsZero(m.PrivateEndpoints[i]) { // not required
+			continue
+		}
+
+		if m.PrivateEndpoints[i] != nil {
+			if err := m.PrivateEndpoints[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("privateEndpoints" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+func (m *AzureCloudSpec) validateRoutes(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Routes) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.Routes); i++ {
+		if swag.IsZero(m.Routes[i]) { // not required
+			continue
+		}
+
+		if m.Routes[i] != nil {
+			if err := m.Routes[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("routes" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+func (m *AzureCloudSpec) validateBootDiagnostics(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.BootDiagnostics) { // not required
+		return nil
+	}
+
+	if m.BootDiagnostics != nil {
+		if err := m.BootDiagnostics.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("bootDiagnostics")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *AzureCloudSpec) validateCredentialsReference(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.CredentialsReference) { // not required
@@ -90,6 +403,42 @@ func (m *AzureCloudSpec) validateCredentialsReference(formats strfmt.Registry) e
 	return nil
 }
 
+func (m *AzureCloudSpec) validateEgressFirewall(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.EgressFirewall) { // not required
+		return nil
+	}
+
+	if m.EgressFirewall != nil {
+		if err := m.EgressFirewall.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("egressFirewall")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *AzureCloudSpec) validateKMS(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.KMS) { // not required
+		return nil
+	}
+
+	if m.KMS != nil {
+		if err := m.KMS.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("kms")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *AzureCloudSpec) validateLoadBalancerSKU(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.LoadBalancerSKU) { // not required
@@ -106,6 +455,58 @@ func (m *AzureCloudSpec) validateLoadBalancerSKU(formats strfmt.Registry) error
 	return nil
 }
 
+func (m *AzureCloudSpec) validateLogAnalytics(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.LogAnalytics) { // not required
+		return nil
+	}
+
+	if m.LogAnalytics != nil {
+		if err := m.LogAnalytics.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("logAnalytics")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *AzureCloudSpec) validateNetworkPlugin(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.NetworkPlugin) { // not required
+		return nil
+	}
+
+	if err := m.NetworkPlugin.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("networkPlugin")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *AzureCloudSpec) validateNodeSecurity(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.NodeSecurity) { // not required
+		return nil
+	}
+
+	if m.NodeSecurity != nil {
+		if err := m.NodeSecurity.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("nodeSecurity")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 // MarshalBinary interface implementation
 func (m *AzureCloudSpec) MarshalBinary() ([]byte, error) {
 	if m == nil {
@@ -20,24 +20,62 @@ type AzureNodeSpec struct {
 	// should the machine have a publicly accessible IP address
 	AssignPublicIP bool `json:"assignPublicIP,omitempty"`
 
+	// BootDiagnostics writes the serial console output and screenshots of the VMs to the boot
+	// diagnostics storage account of the cluster, which must have boot diagnostics enabled.
+	BootDiagnostics bool `json:"bootDiagnostics,omitempty"`
+
 	// Data disk size in GB
 	DataDiskSize int32 `json:"dataDiskSize,omitempty"`
 
-	// ImageID represents the ID of the image that should be used to run the node
+	// EnableAcceleratedNetworking enables accelerated networking on the network interfaces of the
+	// VMs, which requires a VM size supporting it
+	EnableAcceleratedNetworking bool `json:"enableAcceleratedNetworking,omitempty"`
+
+	// EnableEncryptionAtHost encrypts the temporary disk and the caches of the OS and data disks
+	// on the VM host, which requires a VM size supporting it
+	EnableEncryptionAtHost bool `json:"enableEncryptionAtHost,omitempty"`
+
+	// EnableSecureBoot enables Secure Boot for trusted launch VMs
+	EnableSecureBoot bool `json:"enableSecureBoot,omitempty"`
+
+	// EnableVTPM enables the virtual TPM for trusted launch VMs
+	EnableVTPM bool `json:"enableVTPM,omitempty"`
+
+	// HyperVGeneration is the generation of the VM image, either "V1" or "V2". If no image ID
+	// is set, the Gen2 variant of the default image of the operating system is used for "V2".
+	HyperVGeneration string `json:"hyperVGeneration,omitempty"`
+
+	// ImageID represents the ID of the image that should be used to run the node, either a
+	// managed image or an image definition or version of a Shared Image Gallery. Image
+	// definitions resolve to their latest version.
 	ImageID string `json:"imageID,omitempty"`
 
 	// OS disk size in GB
 	OSDiskSize int32 `json:"osDiskSize,omitempty"`
 
+	// SecurityType is the security type of the VM. Only "TrustedLaunch" is supported, which
+	// requires a Gen2 image and a VM size supporting it.
+	SecurityType string `json:"securityType,omitempty"`
+
 	// VM size
 	// Required: true
 	Size *string `json:"size"`
 
+	// Subnet is the name of the subnet the nodes are placed in, either the subnet of the cluster
+	// or one of its additional subnets. Defaults to the subnet of the cluster.
+	Subnet string `json:"subnet,omitempty"`
+
 	// Additional metadata to set
 	Tags map[string]string `json:"tags,omitempty"`
 
 	// Zones represents the availability zones for azure vms
 	Zones []string `json:"zones"`
+
+	// image plan
+	ImagePlan *AzureImagePlan `json:"imagePlan,omitempty"`
+
+	// image reference
+	ImageReference *AzureImageReference `json:"imageReference,omitempty"`
 }
 
 // Validate validates this azure node spec
@@ -48,6 +86,14 @@ func (m *AzureNodeSpec) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateImagePlan(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateImageReference(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -63,6 +109,42 @@ func (m *AzureNodeSpec) validateSize(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *AzureNodeSpec) validateImagePlan(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.ImagePlan) { // not required
+		return nil
+	}
+
+	if m.ImagePlan != nil {
+		if err := m.ImagePlan.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("imagePlan")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *AzureNodeSpec) validateImageReference(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.ImageReference) { // not required
+		return nil
+	}
+
+	if m.ImageReference != nil {
+		if err := m.ImageReference.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("imageReference")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 // MarshalBinary interface implementation
 func (m *AzureNodeSpec) MarshalBinary() ([]byte, error) {
 	if m == nil {
@@ -15,6 +15,12 @@ import (
 // swagger:model AzureSize
 type AzureSize struct {
 
+	// AcceleratedNetworkingSupported is true if VMs of the size can use accelerated networking
+	AcceleratedNetworkingSupported bool `json:"acceleratedNetworkingSupported,omitempty"`
+
+	// HyperVGenerations are the image generations supported by the size, "V1" and/or "V2"
+	HyperVGenerations []string `json:"hyperVGenerations"`
+
 	// max data disk count
 	MaxDataDiskCount int32 `json:"maxDataDiskCount,omitempty"`
 
@@ -30,11 +36,18 @@ type AzureSize struct {
 	// number of g p us
 	NumberOfGPUs int32 `json:"numberOfGPUs,omitempty"`
 
+	// NvidiaGPU is true if VMs of the size have NVIDIA GPUs, the nvidia-gpu-driver addon installs
+	// the driver on their nodes
+	NvidiaGPU bool `json:"nvidiaGPU,omitempty"`
+
 	// os disk size in m b
 	OsDiskSizeInMB int32 `json:"osDiskSizeInMB,omitempty"`
 
 	// resource disk size in m b
 	ResourceDiskSizeInMB int32 `json:"resourceDiskSizeInMB,omitempty"`
+
+	// TrustedLaunchSupported is true if VMs of the size can use trusted launch
+	TrustedLaunchSupported bool `json:"trustedLaunchSupported,omitempty"`
 }
 
 // Validate validates this azure size
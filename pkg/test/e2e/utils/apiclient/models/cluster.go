@@ -35,6 +35,10 @@ type Cluster struct {
 	// ID unique value that identifies the resource generated by the server. Read-Only.
 	ID string `json:"id,omitempty"`
 
+	// InheritedPreset is the name of the project default preset whose credentials were bound to
+	// the cluster because it was created without explicit credentials.
+	InheritedPreset string `json:"inheritedPreset,omitempty"`
+
 	// inherited labels
 	InheritedLabels map[string]string `json:"inheritedLabels,omitempty"`
 
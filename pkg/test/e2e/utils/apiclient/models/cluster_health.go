@@ -34,9 +34,15 @@ type ClusterHealth struct {
 	// gatekeeper controller
 	GatekeeperController HealthStatus `json:"gatekeeperController,omitempty"`
 
+	// log shipping
+	LogShipping HealthStatus `json:"logShipping,omitempty"`
+
 	// machine controller
 	MachineController HealthStatus `json:"machineController,omitempty"`
 
+	// nodes
+	Nodes HealthStatus `json:"nodes,omitempty"`
+
 	// scheduler
 	Scheduler HealthStatus `json:"scheduler,omitempty"`
 
@@ -72,10 +78,18 @@ func (m *ClusterHealth) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateLogShipping(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateMachineController(formats); err != nil {
 		res = append(res, err)
 	}
 
+	if err := m.validateNodes(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateScheduler(formats); err != nil {
 		res = append(res, err)
 	}
@@ -186,6 +200,22 @@ func (m *ClusterHealth) validateGatekeeperController(formats strfmt.Registry) er
 	return nil
 }
 
+func (m *ClusterHealth) validateLogShipping(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.LogShipping) { // not required
+		return nil
+	}
+
+	if err := m.LogShipping.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("logShipping")
+		}
+		return err
+	}
+
+	return nil
+}
+
 func (m *ClusterHealth) validateMachineController(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.MachineController) { // not required
@@ -202,6 +232,22 @@ func (m *ClusterHealth) validateMachineController(formats strfmt.Registry) error
 	return nil
 }
 
+func (m *ClusterHealth) validateNodes(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Nodes) { // not required
+		return nil
+	}
+
+	if err := m.Nodes.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("nodes")
+		}
+		return err
+	}
+
+	return nil
+}
+
 func (m *ClusterHealth) validateScheduler(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.Scheduler) { // not required
@@ -20,6 +20,11 @@ type ClusterNetworkingConfig struct {
 	// Domain name for services.
 	DNSDomain string `json:"dnsDomain,omitempty"`
 
+	// NodeCIDRMaskSize is the mask size of the pod CIDR assigned to each node, which limits the
+	// number of nodes to 2^(NodeCIDRMaskSize - pod CIDR mask size). Node deployments cannot be
+	// scaled beyond this number of nodes. Defaults to 24.
+	NodeCIDRMaskSize int32 `json:"nodeCIDRMaskSize,omitempty"`
+
 	// NodeLocalDNSCacheEnabled controls whether the NodeLocal DNS Cache feature is enabled.
 	// Defaults to true.
 	NodeLocalDNSCacheEnabled bool `json:"nodeLocalDNSCacheEnabled,omitempty"`
@@ -18,6 +18,10 @@ import (
 // swagger:model ClusterSpec
 type ClusterSpec struct {
 
+	// AdditionalClouds are clouds of other providers node deployments can be created in, in addition
+	// to the cloud of the cluster. Every provider can only be used once.
+	AdditionalClouds []*CloudSpec `json:"additionalClouds"`
+
 	// Additional Admission Controller plugins
 	AdmissionPlugins []string `json:"admissionPlugins"`
 
@@ -59,6 +63,9 @@ type ClusterSpec struct {
 	// cluster network
 	ClusterNetwork *ClusterNetworkingConfig `json:"clusterNetwork,omitempty"`
 
+	// log shipping
+	LogShipping *LogShippingSettings `json:"logShipping,omitempty"`
+
 	// mla
 	Mla *MLASettings `json:"mla,omitempty"`
 
@@ -68,6 +75,9 @@ type ClusterSpec struct {
 	// opa integration
 	OpaIntegration *OPAIntegrationSettings `json:"opaIntegration,omitempty"`
 
+	// patch upgrade policy
+	PatchUpgradePolicy *PatchUpgradePolicy `json:"patchUpgradePolicy,omitempty"`
+
 	// service account
 	ServiceAccount *ServiceAccountSettings `json:"serviceAccount,omitempty"`
 
@@ -82,6 +92,10 @@ type ClusterSpec struct {
 func (m *ClusterSpec) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateAdditionalClouds(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateMachineNetworks(formats); err != nil {
 		res = append(res, err)
 	}
@@ -98,6 +112,10 @@ func (m *ClusterSpec) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateLogShipping(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateMla(formats); err != nil {
 		res = append(res, err)
 	}
@@ -110,6 +128,10 @@ func (m *ClusterSpec) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validatePatchUpgradePolicy(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateServiceAccount(formats); err != nil {
 		res = append(res, err)
 	}
@@ -124,6 +146,31 @@ func (m *ClusterSpec) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *ClusterSpec) validateAdditionalClouds(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.AdditionalClouds) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.AdditionalClouds); i++ {
+		if swag.IsZero(m.AdditionalClouds[i]) { // not required
+			continue
+		}
+
+		if m.AdditionalClouds[i] != nil {
+			if err := m.AdditionalClouds[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("additionalClouds" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 func (m *ClusterSpec) validateMachineNetworks(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.MachineNetworks) { // not required
@@ -203,6 +250,24 @@ func (m *ClusterSpec) validateClusterNetwork(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *ClusterSpec) validateLogShipping(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.LogShipping) { // not required
+		return nil
+	}
+
+	if m.LogShipping != nil {
+		if err := m.LogShipping.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("logShipping")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *ClusterSpec) validateMla(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.Mla) { // not required
@@ -257,6 +322,24 @@ func (m *ClusterSpec) validateOpaIntegration(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *ClusterSpec) validatePatchUpgradePolicy(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.PatchUpgradePolicy) { // not required
+		return nil
+	}
+
+	if m.PatchUpgradePolicy != nil {
+		if err := m.PatchUpgradePolicy.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("patchUpgradePolicy")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *ClusterSpec) validateServiceAccount(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.ServiceAccount) { // not required
@@ -6,6 +6,8 @@ package models
 // Editing this file might prove futile when you re-run the swagger generate command
 
 import (
+	"strconv"
+
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
@@ -19,6 +21,12 @@ type ClusterStatus struct {
 	// URL specifies the address at which the cluster is available
 	URL string `json:"url,omitempty"`
 
+	// CloudProviderResources are the provider-native identifiers of the cloud resources the cluster uses
+	CloudProviderResources []*CloudProviderResource `json:"cloudProviderResources"`
+
+	// NodeProblems are the problems currently reported by the nodes of the cluster
+	NodeProblems []*NodeProblem `json:"nodeProblems"`
+
 	// external c c m migration
 	ExternalCCMMigration ExternalCCMMigrationStatus `json:"externalCCMMigration,omitempty"`
 
@@ -30,6 +38,14 @@ type ClusterStatus struct {
 func (m *ClusterStatus) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateCloudProviderResources(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateNodeProblems(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateExternalCCMMigration(formats); err != nil {
 		res = append(res, err)
 	}
@@ -40,6 +56,56 @@ func (m *ClusterStatus) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *ClusterStatus) validateCloudProviderResources(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.CloudProviderResources) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.CloudProviderResources); i++ {
+		if swag.IsZero(m.CloudProviderResources[i]) { // not required
+			continue
+		}
+
+		if m.CloudProviderResources[i] != nil {
+			if err := m.CloudProviderResources[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("cloudProviderResources" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+func (m *ClusterStatus) validateNodeProblems(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.NodeProblems) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.NodeProblems); i++ {
+		if swag.IsZero(m.NodeProblems[i]) { // not required
+			continue
+		}
+
+		if m.NodeProblems[i] != nil {
+			if err := m.NodeProblems[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("nodeProblems" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 func (m *ClusterStatus) validateExternalCCMMigration(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.ExternalCCMMigration) { // not required
@@ -60,6 +60,9 @@ type DatacenterSpec struct {
 	// bringyourown
 	Bringyourown DatacenterSpecBringYourOwn `json:"bringyourown,omitempty"`
 
+	// capacity limits
+	CapacityLimits *DatacenterCapacityLimits `json:"capacityLimits,omitempty"`
+
 	// digitalocean
 	Digitalocean *DatacenterSpecDigitalocean `json:"digitalocean,omitempty"`
 
@@ -75,6 +78,9 @@ type DatacenterSpec struct {
 	// kubevirt
 	Kubevirt *DatacenterSpecKubevirt `json:"kubevirt,omitempty"`
 
+	// machine flavor filter
+	MachineFlavorFilter *MachineFlavorFilter `json:"machineFlavorFilter,omitempty"`
+
 	// node
 	Node *NodeSettings `json:"node,omitempty"`
 
@@ -108,6 +114,10 @@ func (m *DatacenterSpec) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateCapacityLimits(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateDigitalocean(formats); err != nil {
 		res = append(res, err)
 	}
@@ -128,6 +138,10 @@ func (m *DatacenterSpec) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateMachineFlavorFilter(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateNode(formats); err != nil {
 		res = append(res, err)
 	}
@@ -222,6 +236,24 @@ func (m *DatacenterSpec) validateAzure(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *DatacenterSpec) validateCapacityLimits(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.CapacityLimits) { // not required
+		return nil
+	}
+
+	if m.CapacityLimits != nil {
+		if err := m.CapacityLimits.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("capacityLimits")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *DatacenterSpec) validateDigitalocean(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.Digitalocean) { // not required
@@ -312,6 +344,24 @@ func (m *DatacenterSpec) validateKubevirt(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *DatacenterSpec) validateMachineFlavorFilter(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.MachineFlavorFilter) { // not required
+		return nil
+	}
+
+	if m.MachineFlavorFilter != nil {
+		if err := m.MachineFlavorFilter.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("machineFlavorFilter")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *DatacenterSpec) validateNode(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.Node) { // not required
@@ -6,6 +6,7 @@ package models
 // Editing this file might prove futile when you re-run the swagger generate command
 
 import (
+	"github.com/go-openapi/errors"
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
 )
@@ -15,13 +16,180 @@ import (
 // swagger:model DatacenterSpecAzure
 type DatacenterSpecAzure struct {
 
+	// Optional: If set to true, the terms of the marketplace plans of node images are accepted
+	// with the credentials of the cluster when they have not been accepted in its subscription
+	// yet. Otherwise node deployments using such images are rejected until the terms have been
+	// accepted manually.
+	AcceptMarketplaceTerms bool `json:"acceptMarketplaceTerms,omitempty"`
+
+	// Optional: AllowedIPRanges are the address ranges SSH access to the nodes of new clusters is
+	// allowed from, unless the cluster sets its own.
+	AllowedIPRanges []string `json:"allowedIPRanges"`
+
+	// Optional: DDoSProtectionPlanID is the resource ID of an existing DDoS Protection Plan, which
+	// is associated with every VNet created by Kubermatic in this datacenter. The plan may live in
+	// another resource group or subscription. Removing it does not disassociate the plan from
+	// existing VNets.
+	DDoSProtectionPlanID string `json:"ddosProtectionPlanID,omitempty"`
+
+	// Optional: Environment is the name of the Azure cloud the location belongs to, one of
+	// "AzurePublicCloud", "AzureUSGovernmentCloud" or "AzureChinaCloud". The API uses the
+	// endpoints of this cloud for listing sizes, zones and network resources. Defaults to
+	// "AzurePublicCloud".
+	Environment string `json:"environment,omitempty"`
+
+	// Optional: FaultDomainCount is the number of fault domains of the availability sets created
+	// by Kubermatic, between 1 and 3. It must not exceed the maximum of the region and defaults to
+	// it. Only applies to availability sets created after it has been changed.
+	FaultDomainCount int32 `json:"faultDomainCount,omitempty"`
+
 	// Region to use, for example "westeurope". A list of available regions can be
 	// found at https://azure.microsoft.com/en-us/global-infrastructure/locations/
+	// The locations available to a subscription are listed by the /api/v2/providers/azure/locations endpoint.
 	Location string `json:"location,omitempty"`
+
+	// Optional: If set to true, a CanNotDelete management lock is placed on the resource groups
+	// created by Kubermatic, protecting the cluster infrastructure from accidental deletion. The
+	// lock is removed as soon as the cluster is deleted, before its nodes and load balancers are
+	// torn down.
+	LockResourceGroup bool `json:"lockResourceGroup,omitempty"`
+
+	// Optional: NamingTemplate is a Go template for the names of the resource groups, VNets,
+	// subnets, security groups, route tables and availability sets created for clusters, for
+	// example "{{ .ResourceType }}-{{ .ProjectID }}-{{ .ClusterName }}". The variables are
+	// ClusterName, ProjectID, Datacenter and ResourceType, one of "rg", "vnet", "snet", "nsg",
+	// "rt" and "avail". Only applies to resources created after it has been changed. Defaults to
+	// "kubernetes-{{ .ClusterName }}".
+	NamingTemplate string `json:"namingTemplate,omitempty"`
+
+	// Optional: PeerVNets are the resource IDs of hub VNets the VNet of every cluster in this
+	// datacenter is peered with, for example to reach shared services or on-premises networks.
+	// Only VNets created by Kubermatic are peered.
+	PeerVNets []string `json:"peerVNets"`
+
+	// Optional: SubnetCIDR is the address range of the subnets created for new clusters which do
+	// not set one. Defaults to the address space of the VNet.
+	SubnetCIDR string `json:"subnetCIDR,omitempty"`
+
+	// Optional: Tags are applied to all Azure resources created for clusters in this datacenter,
+	// for example to satisfy cost-allocation policies.
+	Tags map[string]string `json:"tags,omitempty"`
+
+	// Optional: UpdateDomainCount is the number of update domains of the availability sets created
+	// by Kubermatic, between 1 and 20, defaults to 20. Only applies to availability sets created
+	// after it has been changed.
+	UpdateDomainCount int32 `json:"updateDomainCount,omitempty"`
+
+	// Optional: VNetCIDR is the address space of the VNets created for new clusters which do not
+	// set one. Defaults to 10.0.0.0/16.
+	VNetCIDR string `json:"vnetCIDR,omitempty"`
+
+	// cleanup
+	Cleanup *AzureCleanupSettings `json:"cleanup,omitempty"`
+
+	// load balancer s k u
+	LoadBalancerSKU LBSKU `json:"loadBalancerSKU,omitempty"`
+
+	// node defaults
+	NodeDefaults *AzureNodeDefaults `json:"nodeDefaults,omitempty"`
+
+	// private link
+	PrivateLink *AzurePrivateLinkSettings `json:"privateLink,omitempty"`
 }
 
 // Validate validates this datacenter spec azure
 func (m *DatacenterSpecAzure) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateCleanup(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateLoadBalancerSKU(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateNodeDefaults(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validatePrivateLink(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *DatacenterSpecAzure) validateCleanup(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Cleanup) { // not required
+		return nil
+	}
+
+	if m.Cleanup != nil {
+		if err := m.Cleanup.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("cleanup")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *DatacenterSpecAzure) validateLoadBalancerSKU(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.LoadBalancerSKU) { // not required
+		return nil
+	}
+
+	if err := m.LoadBalancerSKU.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("loadBalancerSKU")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *DatacenterSpecAzure) validateNodeDefaults(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.NodeDefaults) { // not required
+		return nil
+	}
+
+	if m.NodeDefaults != nil {
+		if err := m.NodeDefaults.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("nodeDefaults")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *DatacenterSpecAzure) validatePrivateLink(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.PrivateLink) { // not required
+		return nil
+	}
+
+	if m.PrivateLink != nil {
+		if err := m.PrivateLink.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("privateLink")
+			}
+			return err
+		}
+	}
+
 	return nil
 }
 
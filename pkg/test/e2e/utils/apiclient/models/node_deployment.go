@@ -17,6 +17,9 @@ import (
 // swagger:model NodeDeployment
 type NodeDeployment struct {
 
+	// canary rollout
+	CanaryRollout *CanaryRolloutStatus `json:"canaryRollout,omitempty"`
+
 	// CreationTimestamp is a timestamp representing the server time when this object was created.
 	// Format: date-time
 	CreationTimestamp strfmt.DateTime `json:"creationTimestamp,omitempty"`
@@ -28,6 +31,10 @@ type NodeDeployment struct {
 	// ID unique value that identifies the resource generated by the server. Read-Only.
 	ID string `json:"id,omitempty"`
 
+	// InstanceTypesInUse is the number of machines per instance type. It is only reported
+	// for node deployments with fallback instance types.
+	InstanceTypesInUse map[string]int32 `json:"instanceTypesInUse,omitempty"`
+
 	// Name represents human readable name for the resource
 	Name string `json:"name,omitempty"`
 
@@ -42,6 +49,10 @@ type NodeDeployment struct {
 func (m *NodeDeployment) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateCanaryRollout(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateCreationTimestamp(formats); err != nil {
 		res = append(res, err)
 	}
@@ -64,6 +75,24 @@ func (m *NodeDeployment) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *NodeDeployment) validateCanaryRollout(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.CanaryRollout) { // not required
+		return nil
+	}
+
+	if m.CanaryRollout != nil {
+		if err := m.CanaryRollout.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("canaryRollout")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *NodeDeployment) validateCreationTimestamp(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.CreationTimestamp) { // not required
@@ -17,9 +17,18 @@ import (
 // swagger:model NodeDeploymentSpec
 type NodeDeploymentSpec struct {
 
+	// canary rollout
+	CanaryRollout *CanaryRolloutSettings `json:"canaryRollout,omitempty"`
+
 	// dynamic config
 	DynamicConfig bool `json:"dynamicConfig,omitempty"`
 
+	// FallbackInstanceTypes are the instance types, in order of priority, new nodes fall back to
+	// when the cloud provider runs out of capacity for the instance type of the template. They are
+	// only used for the nodes out of capacity and removed again once the instance type of the
+	// template has capacity. Not supported on vSphere, KubeVirt and Anexia.
+	FallbackInstanceTypes []string `json:"fallbackInstanceTypes"`
+
 	// paused
 	Paused bool `json:"paused,omitempty"`
 
@@ -27,6 +36,10 @@ type NodeDeploymentSpec struct {
 	// Required: true
 	Replicas *int32 `json:"replicas"`
 
+	// ReplicasFrozen freezes the number of nodes, e.g. during an investigation. Neither the
+	// cluster autoscaler nor users can scale a frozen node deployment until it is unfrozen.
+	ReplicasFrozen bool `json:"replicasFrozen,omitempty"`
+
 	// template
 	// Required: true
 	Template *NodeSpec `json:"template"`
@@ -36,6 +49,10 @@ type NodeDeploymentSpec struct {
 func (m *NodeDeploymentSpec) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateCanaryRollout(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateReplicas(formats); err != nil {
 		res = append(res, err)
 	}
@@ -50,6 +67,24 @@ func (m *NodeDeploymentSpec) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *NodeDeploymentSpec) validateCanaryRollout(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.CanaryRollout) { // not required
+		return nil
+	}
+
+	if m.CanaryRollout != nil {
+		if err := m.CanaryRollout.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("canaryRollout")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *NodeDeploymentSpec) validateReplicas(formats strfmt.Registry) error {
 
 	if err := validate.Required("replicas", "body", m.Replicas); err != nil {
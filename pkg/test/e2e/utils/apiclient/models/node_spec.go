@@ -19,6 +19,10 @@ import (
 // swagger:model NodeSpec
 type NodeSpec struct {
 
+	// Datacenter is the name of one of the additional clouds of the cluster the nodes are created
+	// in. Defaults to the datacenter of the cluster.
+	Datacenter string `json:"datacenter,omitempty"`
+
 	// Map of string keys and values that can be used to organize and categorize (scope and select) objects.
 	// It will be applied to Nodes allowing users run their apps on specific Node using labelSelector.
 	Labels map[string]string `json:"labels,omitempty"`
@@ -33,6 +37,9 @@ type NodeSpec struct {
 	// Required: true
 	Cloud *NodeCloudSpec `json:"cloud"`
 
+	// disk layout
+	DiskLayout *DiskLayout `json:"diskLayout,omitempty"`
+
 	// operating system
 	// Required: true
 	OperatingSystem *OperatingSystemSpec `json:"operatingSystem"`
@@ -54,6 +61,10 @@ func (m *NodeSpec) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateDiskLayout(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateOperatingSystem(formats); err != nil {
 		res = append(res, err)
 	}
@@ -111,6 +122,24 @@ func (m *NodeSpec) validateCloud(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *NodeSpec) validateDiskLayout(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.DiskLayout) { // not required
+		return nil
+	}
+
+	if m.DiskLayout != nil {
+		if err := m.DiskLayout.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("diskLayout")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *NodeSpec) validateOperatingSystem(formats strfmt.Registry) error {
 
 	if err := validate.Required("operatingSystem", "body", m.OperatingSystem); err != nil {
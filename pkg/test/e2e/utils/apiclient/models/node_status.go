@@ -36,6 +36,9 @@ type NodeStatus struct {
 	// capacity
 	Capacity *NodeResources `json:"capacity,omitempty"`
 
+	// local volumes
+	LocalVolumes *NodeLocalVolumes `json:"localVolumes,omitempty"`
+
 	// node info
 	NodeInfo *NodeSystemInfo `json:"nodeInfo,omitempty"`
 }
@@ -56,6 +59,10 @@ func (m *NodeStatus) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateLocalVolumes(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateNodeInfo(formats); err != nil {
 		res = append(res, err)
 	}
@@ -127,6 +134,24 @@ func (m *NodeStatus) validateCapacity(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *NodeStatus) validateLocalVolumes(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.LocalVolumes) { // not required
+		return nil
+	}
+
+	if m.LocalVolumes != nil {
+		if err := m.LocalVolumes.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("localVolumes")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *NodeStatus) validateNodeInfo(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.NodeInfo) { // not required
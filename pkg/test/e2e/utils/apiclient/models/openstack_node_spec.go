@@ -17,7 +17,7 @@ import (
 // swagger:model OpenstackNodeSpec
 type OpenstackNodeSpec struct {
 
-	// if not set, the default AZ from the Datacenter spec will be used
+	// if not set, the default AZ from the Datacenter spec will be used. The root disk volume is created in the same AZ.
 	AvailabilityZone string `json:"availabilityZone,omitempty"`
 
 	// instance flavor
@@ -37,6 +37,9 @@ type OpenstackNodeSpec struct {
 	// if set, the rootDisk will be a volume. If not, the rootDisk will be on ephemeral storage and its size will be derived from the flavor
 	RootDiskSizeGB int64 `json:"diskSize,omitempty"`
 
+	// if set, the root disk volume is created with this Cinder volume type, requires diskSize to be set
+	RootDiskVolumeType string `json:"rootDiskVolumeType,omitempty"`
+
 	// Additional metadata to set
 	Tags map[string]string `json:"tags,omitempty"`
 
@@ -26,6 +26,10 @@ type Project struct {
 	// Format: date-time
 	CreationTimestamp strfmt.DateTime `json:"creationTimestamp,omitempty"`
 
+	// DefaultPresets maps cloud provider names to the preset used for clusters of the project
+	// which are created without explicit credentials
+	DefaultPresets map[string]string `json:"defaultPresets,omitempty"`
+
 	// DeletionTimestamp is a timestamp representing the server time when this object was deleted.
 	// Format: date-time
 	DeletionTimestamp strfmt.DateTime `json:"deletionTimestamp,omitempty"`
@@ -34,6 +34,11 @@ type PublicServiceAccountToken struct {
 
 	// Name represents human readable name for the resource
 	Name string `json:"name,omitempty"`
+
+	// Scope restricts the token to a part of the API. Tokens with the "monitoring" scope
+	// can only read the health, metrics and events of the clusters in the project.
+	// An empty scope grants the permissions of the service account.
+	Scope string `json:"scope,omitempty"`
 }
 
 // Validate validates this public service account token
@@ -9,6 +9,7 @@ import (
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
 )
 
 // SettingSpec setting spec
@@ -37,6 +38,15 @@ type SettingSpec struct {
 	// enable o ID c kubeconfig
 	EnableOIDCKubeconfig bool `json:"enableOIDCKubeconfig,omitempty"`
 
+	// ForbidPresetInheritance disables the default presets of projects, so that clusters created
+	// without explicit credentials no longer inherit the credentials of a preset.
+	ForbidPresetInheritance bool `json:"forbidPresetInheritance,omitempty"`
+
+	// MachineFlavorFilters curate the machine flavors, e.g. instance types or VM sizes, which are
+	// listed and can be used for nodes, by cloud provider name, e.g. "aws" or "azure".
+	// Datacenters can restrict them further.
+	MachineFlavorFilters map[string]MachineFlavorFilter `json:"machineFlavorFilters,omitempty"`
+
 	// mla alertmanager domain
 	MlaAlertmanagerDomain string `json:"mlaAlertmanagerDomain,omitempty"`
 
@@ -49,6 +59,9 @@ type SettingSpec struct {
 	// cleanup options
 	CleanupOptions *CleanupOptions `json:"cleanupOptions,omitempty"`
 
+	// cluster admission webhook
+	ClusterAdmissionWebhook *ClusterAdmissionWebhook `json:"clusterAdmissionWebhook,omitempty"`
+
 	// cluster type options
 	ClusterTypeOptions ClusterType `json:"clusterTypeOptions,omitempty"`
 
@@ -69,10 +82,18 @@ type SettingSpec struct {
 func (m *SettingSpec) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateMachineFlavorFilters(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateCleanupOptions(formats); err != nil {
 		res = append(res, err)
 	}
 
+	if err := m.validateClusterAdmissionWebhook(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateClusterTypeOptions(formats); err != nil {
 		res = append(res, err)
 	}
@@ -99,6 +120,28 @@ func (m *SettingSpec) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *SettingSpec) validateMachineFlavorFilters(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.MachineFlavorFilters) { // not required
+		return nil
+	}
+
+	for k := range m.MachineFlavorFilters {
+
+		if err := validate.Required("machineFlavorFilters"+"."+k, "body", m.MachineFlavorFilters[k]); err != nil {
+			return err
+		}
+		if val, ok := m.MachineFlavorFilters[k]; ok {
+			if err := val.Validate(formats); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 func (m *SettingSpec) validateCleanupOptions(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.CleanupOptions) { // not required
@@ -117,6 +160,24 @@ func (m *SettingSpec) validateCleanupOptions(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *SettingSpec) validateClusterAdmissionWebhook(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.ClusterAdmissionWebhook) { // not required
+		return nil
+	}
+
+	if m.ClusterAdmissionWebhook != nil {
+		if err := m.ClusterAdmissionWebhook.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("clusterAdmissionWebhook")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *SettingSpec) validateClusterTypeOptions(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.ClusterTypeOptions) { // not required
@@ -628,6 +628,7 @@ func (r *TestClient) GetClusterHealthStatus(projectID, dc, clusterID string) (*a
 	apiClusterHealth.UserClusterControllerManager = convertHealthStatus(response.Payload.UserClusterControllerManager)
 	apiClusterHealth.GatekeeperController = convertHealthStatus(response.Payload.GatekeeperController)
 	apiClusterHealth.GatekeeperAudit = convertHealthStatus(response.Payload.GatekeeperAudit)
+	apiClusterHealth.LogShipping = convertHealthStatus(response.Payload.LogShipping)
 
 	return apiClusterHealth, nil
 }
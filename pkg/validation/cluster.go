@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"net/url"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/coreos/locksmith/pkg/timeutil"
@@ -44,6 +45,7 @@ var (
 	// ErrCloudChangeNotAllowed describes that it is not allowed to change the cloud provider
 	ErrCloudChangeNotAllowed  = errors.New("not allowed to change the cloud provider")
 	azureLoadBalancerSKUTypes = sets.NewString("", string(kubermaticv1.AzureStandardLBSKU), string(kubermaticv1.AzureBasicLBSKU))
+	azureNetworkPlugins       = sets.NewString("", string(kubermaticv1.AzureNetworkPluginKubenet), string(kubermaticv1.AzureNetworkPluginAzure))
 )
 
 // ValidateCreateClusterSpec validates the given cluster spec
@@ -65,6 +67,10 @@ func ValidateCreateClusterSpec(spec *kubermaticv1.ClusterSpec, dc *kubermaticv1.
 		return fmt.Errorf("invalid cloud spec: %v", err)
 	}
 
+	if err := validateCloudNetworkOverlap(cloudProvider, spec); err != nil {
+		return fmt.Errorf("invalid cluster network: %v", err)
+	}
+
 	if err := validateMachineNetworksFromClusterSpec(spec); err != nil {
 		return fmt.Errorf("machine network validation failed, see: %v", err)
 	}
@@ -80,6 +86,14 @@ func ValidateCreateClusterSpec(spec *kubermaticv1.ClusterSpec, dc *kubermaticv1.
 		return fmt.Errorf("apiserver NodePortRange validation failed: %v", errs)
 	}
 
+	if errs := ValidateLogShippingSettings(spec.LogShipping, specFieldPath.Child("logShipping")); len(errs) > 0 {
+		return fmt.Errorf("log shipping validation failed: %v", errs)
+	}
+
+	if errs := ValidatePatchUpgradePolicy(spec.PatchUpgradePolicy, specFieldPath.Child("patchUpgradePolicy")); len(errs) > 0 {
+		return fmt.Errorf("patch upgrade policy validation failed: %v", errs)
+	}
+
 	return nil
 }
 
@@ -121,6 +135,47 @@ func ValidateClusterNetworkConfig(n *kubermaticv1.ClusterNetworkingConfig, fldPa
 		allErrs = append(allErrs, field.NotSupported(fldPath.Child("proxyMode"), n.ProxyMode,
 			[]string{resources.IPVSProxyMode, resources.IPTablesProxyMode}))
 	}
+	// the default mask size has to fit into the pod CIDR as well
+	allErrs = append(allErrs, validateNodeCIDRMaskSize(resources.NodeCIDRMaskSize(*n), n.Pods.CIDRBlocks, fldPath.Child("nodeCIDRMaskSize"))...)
+
+	return allErrs
+}
+
+const (
+	// maxNodeCIDRMaskSizeDifference is the largest difference between the pod CIDR mask size and
+	// the node CIDR mask size accepted by the kube-controller-manager.
+	maxNodeCIDRMaskSizeDifference = 16
+	// kubeletDefaultMaxPods is the number of pods a node runs at most by default, each of them
+	// needs an IP from the node's pod CIDR.
+	kubeletDefaultMaxPods = 110
+)
+
+// validateNodeCIDRMaskSize checks that the pod CIDR can be split into node CIDRs of the given size,
+// each large enough for the pods of a node.
+func validateNodeCIDRMaskSize(maskSize int32, podsCIDR []string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(podsCIDR) != 1 {
+		return allErrs
+	}
+	_, podsNet, err := net.ParseCIDR(podsCIDR[0])
+	if err != nil {
+		// reported by the pod CIDR validation
+		return allErrs
+	}
+
+	podsMaskSize, bits := podsNet.Mask.Size()
+	switch {
+	case int(maskSize) < podsMaskSize:
+		allErrs = append(allErrs, field.Invalid(fldPath, maskSize,
+			fmt.Sprintf("must not be smaller than the mask size %d of the pod CIDR", podsMaskSize)))
+	case int(maskSize)-podsMaskSize > maxNodeCIDRMaskSizeDifference:
+		allErrs = append(allErrs, field.Invalid(fldPath, maskSize,
+			fmt.Sprintf("must not exceed the mask size %d of the pod CIDR by more than %d", podsMaskSize, maxNodeCIDRMaskSizeDifference)))
+	case int(maskSize) > bits || (bits-int(maskSize) < 31 && 1<<(bits-int(maskSize)) < kubeletDefaultMaxPods):
+		allErrs = append(allErrs, field.Invalid(fldPath, maskSize,
+			fmt.Sprintf("leaves fewer than %d pod IPs per node", kubeletDefaultMaxPods)))
+	}
 
 	return allErrs
 }
@@ -162,6 +217,66 @@ func validateMachineNetworksFromClusterSpec(spec *kubermaticv1.ClusterSpec) erro
 	return nil
 }
 
+// ValidateAdditionalClouds validates the additional clouds of the cluster against their datacenters,
+// which are keyed by name. Besides the cloud specs themselves, it checks that the nodes of the
+// additional clouds are able to reach the API server and the pods of the other nodes.
+func ValidateAdditionalClouds(spec *kubermaticv1.ClusterSpec, datacenters map[string]*kubermaticv1.Datacenter) error {
+	if len(spec.AdditionalClouds) == 0 {
+		return nil
+	}
+
+	clusterProvider, err := provider.ClusterCloudProviderName(spec.Cloud)
+	if err != nil {
+		return err
+	}
+	if clusterProvider == provider.BringYourOwnCloudProvider || clusterProvider == provider.FakeCloudProvider {
+		return fmt.Errorf("additional clouds are not supported for %s clusters", clusterProvider)
+	}
+	if spec.Cloud.Azure != nil && spec.Cloud.Azure.PrivateCluster {
+		return errors.New("additional clouds are not supported for private clusters, as their API server is not reachable from other clouds")
+	}
+	if spec.Cloud.Azure != nil && spec.Cloud.Azure.NetworkPlugin == kubermaticv1.AzureNetworkPluginAzure {
+		return errors.New("additional clouds are not supported with the azure network plugin, as pods of other clouds are not routable in the VNet")
+	}
+
+	providers := sets.NewString(clusterProvider)
+	for _, cloud := range spec.AdditionalClouds {
+		if cloud.DatacenterName == spec.Cloud.DatacenterName {
+			return fmt.Errorf("datacenter %q of an additional cloud is the datacenter of the cluster", cloud.DatacenterName)
+		}
+		dc, ok := datacenters[cloud.DatacenterName]
+		if !ok {
+			return fmt.Errorf("datacenter %q of an additional cloud does not exist", cloud.DatacenterName)
+		}
+		if err := ValidateCloudSpec(cloud, dc); err != nil {
+			return fmt.Errorf("invalid additional cloud %q: %v", cloud.DatacenterName, err)
+		}
+
+		providerName, err := provider.ClusterCloudProviderName(cloud)
+		if err != nil {
+			return err
+		}
+		if providerName == provider.BringYourOwnCloudProvider || providerName == provider.FakeCloudProvider {
+			return fmt.Errorf("%s cannot be used for additional clouds", providerName)
+		}
+		if providers.Has(providerName) {
+			return fmt.Errorf("provider %s of additional cloud %q is already used by the cluster", providerName, cloud.DatacenterName)
+		}
+		providers.Insert(providerName)
+
+		if azure := cloud.Azure; azure != nil {
+			if azure.ResourceGroup == "" || azure.VNetName == "" || azure.SubnetName == "" || azure.RouteTableName == "" || azure.SecurityGroup == "" {
+				return fmt.Errorf("additional cloud %q must reference an existing resource group, VNet, subnet, route table and security group", cloud.DatacenterName)
+			}
+			if azure.PrivateCluster || azure.NetworkPlugin == kubermaticv1.AzureNetworkPluginAzure {
+				return fmt.Errorf("additional cloud %q can neither be private nor use the azure network plugin", cloud.DatacenterName)
+			}
+		}
+	}
+
+	return nil
+}
+
 // ValidateCloudChange validates if the cloud provider has been changed
 func ValidateCloudChange(newSpec, oldSpec kubermaticv1.CloudSpec) error {
 	if newSpec.Openstack == nil && oldSpec.Openstack != nil {
@@ -278,9 +393,23 @@ func ValidateUpdateCluster(ctx context.Context, newCluster, oldCluster *kubermat
 		return fmt.Errorf("invalid cloud spec modification: %v", err)
 	}
 
+	if err := validateCloudNetworkOverlap(cloudProvider, &newCluster.Spec); err != nil {
+		return fmt.Errorf("invalid cluster network: %v", err)
+	}
+
 	return nil
 }
 
+// validateCloudNetworkOverlap lets cloud providers which know the address space of the nodes'
+// network reject pod and service CIDRs overlapping it, as these clusters have broken routing.
+func validateCloudNetworkOverlap(cloudProvider provider.CloudProvider, spec *kubermaticv1.ClusterSpec) error {
+	validator, ok := cloudProvider.(provider.ClusterNetworkValidator)
+	if !ok {
+		return nil
+	}
+	return validator.ValidateClusterNetwork(spec.Cloud, spec.ClusterNetwork)
+}
+
 // ValidateCloudSpec validates if the cloud spec is valid
 func ValidateCloudSpec(spec kubermaticv1.CloudSpec, dc *kubermaticv1.Datacenter) error {
 	if spec.DatacenterName == "" {
@@ -312,7 +441,7 @@ func ValidateCloudSpec(spec kubermaticv1.CloudSpec, dc *kubermaticv1.Datacenter)
 		if dc.Spec.Azure == nil {
 			return fmt.Errorf("datacenter %q is not an Azure datacenter", spec.DatacenterName)
 		}
-		return validateAzureCloudSpec(spec.Azure)
+		return validateAzureCloudSpec(spec.Azure, dc)
 	case spec.VSphere != nil:
 		if dc.Spec.VSphere == nil {
 			return fmt.Errorf("datacenter %q is not a vSphere datacenter", spec.DatacenterName)
@@ -461,7 +590,7 @@ func validateVSphereCloudSpec(spec *kubermaticv1.VSphereCloudSpec) error {
 	return nil
 }
 
-func validateAzureCloudSpec(spec *kubermaticv1.AzureCloudSpec) error {
+func validateAzureCloudSpec(spec *kubermaticv1.AzureCloudSpec, dc *kubermaticv1.Datacenter) error {
 	if spec.TenantID == "" {
 		if err := kuberneteshelper.ValidateSecretKeySelector(spec.CredentialsReference, resources.AzureTenantID); err != nil {
 			return err
@@ -485,6 +614,24 @@ func validateAzureCloudSpec(spec *kubermaticv1.AzureCloudSpec) error {
 	if !azureLoadBalancerSKUTypes.Has(string(spec.LoadBalancerSKU)) {
 		return fmt.Errorf("azure LB SKU cannot be %q, allowed values are %v", spec.LoadBalancerSKU, azureLoadBalancerSKUTypes.List())
 	}
+	if !azureNetworkPlugins.Has(string(spec.NetworkPlugin)) {
+		return fmt.Errorf("azure network plugin cannot be %q, allowed values are %v", spec.NetworkPlugin, azureNetworkPlugins.List())
+	}
+	if spec.PrivateCluster && dc.Spec.Azure.PrivateLink == nil {
+		return errors.New("private clusters are not supported in this datacenter, no Private Link settings configured")
+	}
+	if spec.ClusterServicePrincipal && spec.VNetResourceGroup != "" && spec.VNetResourceGroup != spec.ResourceGroup {
+		return errors.New("a cluster service principal requires the VNet to be in the resource group of the cluster")
+	}
+	if spec.ClusterServicePrincipal && spec.VNetSubscriptionID != "" {
+		return errors.New("a cluster service principal requires the VNet to be in the subscription of the cluster")
+	}
+	if spec.VNetTenantID != "" && spec.VNetSubscriptionID == "" {
+		return errors.New("the tenant of the VNet can only be set together with its subscription")
+	}
+	if ns := spec.NodeSecurity; ns != nil && (ns.SecureBoot || ns.VTPM) && !ns.TrustedLaunch {
+		return errors.New("requiring secure boot or vTPM for the nodes requires trusted launch")
+	}
 
 	return nil
 }
@@ -597,6 +744,58 @@ func ValidateLeaderElectionSettings(l *kubermaticv1.LeaderElectionSettings, fldP
 	return allErrs
 }
 
+// ValidateLogShippingSettings validates the destination of the log shipping settings, the
+// scheme of the endpoint must be supported by the type of the destination.
+func ValidateLogShippingSettings(l *kubermaticv1.LogShippingSettings, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if l == nil {
+		return allErrs
+	}
+
+	var schemes []string
+	switch l.Type {
+	case kubermaticv1.LogShippingDestinationLoki, kubermaticv1.LogShippingDestinationElasticsearch:
+		schemes = []string{"http", "https"}
+	case kubermaticv1.LogShippingDestinationSyslog:
+		schemes = []string{"tcp", "tls", "udp"}
+	default:
+		supported := []string{
+			string(kubermaticv1.LogShippingDestinationLoki),
+			string(kubermaticv1.LogShippingDestinationElasticsearch),
+			string(kubermaticv1.LogShippingDestinationSyslog),
+		}
+		return append(allErrs, field.NotSupported(fldPath.Child("type"), l.Type, supported))
+	}
+
+	endpoint, err := url.Parse(l.Endpoint)
+	if err != nil {
+		return append(allErrs, field.Invalid(fldPath.Child("endpoint"), l.Endpoint, err.Error()))
+	}
+	if !sets.NewString(schemes...).Has(endpoint.Scheme) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("endpoint"), l.Endpoint, fmt.Sprintf("scheme must be one of %v", schemes)))
+	}
+	if endpoint.Hostname() == "" {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("endpoint"), l.Endpoint, "host must be specified"))
+	}
+	if l.Type == kubermaticv1.LogShippingDestinationSyslog && endpoint.Port() == "" {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("endpoint"), l.Endpoint, "port must be specified"))
+	}
+
+	return allErrs
+}
+
+// ValidatePatchUpgradePolicy validates the health check timeout of the patch upgrade policy.
+func ValidatePatchUpgradePolicy(p *kubermaticv1.PatchUpgradePolicy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if p != nil && p.HealthCheckTimeout != nil && p.HealthCheckTimeout.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("healthCheckTimeout"), p.HealthCheckTimeout.Duration.String(), "must be positive"))
+	}
+
+	return allErrs
+}
+
 func ValidateNodePortRange(nodePortRange string, fldPath *field.Path, required bool) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -21,9 +21,11 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/pointer"
 )
@@ -234,6 +236,203 @@ func TestValidateLeaderElectionSettings(t *testing.T) {
 	}
 }
 
+func TestValidateLogShippingSettings(t *testing.T) {
+	tests := []struct {
+		name                string
+		logShippingSettings *kubermaticv1.LogShippingSettings
+		wantErr             bool
+	}{
+		{
+			name:                "no log shipping settings",
+			logShippingSettings: nil,
+			wantErr:             false,
+		},
+		{
+			name: "valid loki destination",
+			logShippingSettings: &kubermaticv1.LogShippingSettings{
+				Type:     kubermaticv1.LogShippingDestinationLoki,
+				Endpoint: "https://loki.example.com:3100",
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid syslog destination",
+			logShippingSettings: &kubermaticv1.LogShippingSettings{
+				Type:     kubermaticv1.LogShippingDestinationSyslog,
+				Endpoint: "tls://syslog.example.com:6514",
+			},
+			wantErr: false,
+		},
+		{
+			name: "unsupported destination type",
+			logShippingSettings: &kubermaticv1.LogShippingSettings{
+				Type:     "splunk",
+				Endpoint: "https://splunk.example.com",
+			},
+			wantErr: true,
+		},
+		{
+			name: "unsupported scheme",
+			logShippingSettings: &kubermaticv1.LogShippingSettings{
+				Type:     kubermaticv1.LogShippingDestinationElasticsearch,
+				Endpoint: "tcp://elasticsearch.example.com:9200",
+			},
+			wantErr: true,
+		},
+		{
+			name: "syslog destination without port",
+			logShippingSettings: &kubermaticv1.LogShippingSettings{
+				Type:     kubermaticv1.LogShippingDestinationSyslog,
+				Endpoint: "udp://syslog.example.com",
+			},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errs := ValidateLogShippingSettings(test.logShippingSettings, field.NewPath("spec", "logShipping"))
+
+			if test.wantErr == (len(errs) == 0) {
+				t.Errorf("Want error: %t, but got: \"%v\"", test.wantErr, errs)
+			}
+		})
+	}
+}
+
+func TestValidatePatchUpgradePolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  *kubermaticv1.PatchUpgradePolicy
+		wantErr bool
+	}{
+		{
+			name:    "no policy",
+			wantErr: false,
+		},
+		{
+			name:    "policy with default timeout",
+			policy:  &kubermaticv1.PatchUpgradePolicy{Enabled: true},
+			wantErr: false,
+		},
+		{
+			name:    "policy with timeout",
+			policy:  &kubermaticv1.PatchUpgradePolicy{Enabled: true, HealthCheckTimeout: &metav1.Duration{Duration: 10 * time.Minute}},
+			wantErr: false,
+		},
+		{
+			name:    "policy with negative timeout",
+			policy:  &kubermaticv1.PatchUpgradePolicy{Enabled: true, HealthCheckTimeout: &metav1.Duration{Duration: -time.Minute}},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errs := ValidatePatchUpgradePolicy(test.policy, field.NewPath("spec", "patchUpgradePolicy"))
+
+			if test.wantErr == (len(errs) == 0) {
+				t.Errorf("Want error: %t, but got: \"%v\"", test.wantErr, errs)
+			}
+		})
+	}
+}
+
+func TestValidateAdditionalClouds(t *testing.T) {
+	datacenters := map[string]*kubermaticv1.Datacenter{
+		"vsphere-dc": {Spec: kubermaticv1.DatacenterSpec{VSphere: &kubermaticv1.DatacenterSpecVSphere{}}},
+		"azure-dc":   {Spec: kubermaticv1.DatacenterSpec{Azure: &kubermaticv1.DatacenterSpecAzure{}}},
+		"hetzner-dc": {Spec: kubermaticv1.DatacenterSpec{Hetzner: &kubermaticv1.DatacenterSpecHetzner{}}},
+	}
+	vsphereCloud := kubermaticv1.CloudSpec{
+		DatacenterName: "vsphere-dc",
+		VSphere:        &kubermaticv1.VSphereCloudSpec{Username: "user", Password: "password"},
+	}
+	azureCloud := func(modify func(*kubermaticv1.AzureCloudSpec)) kubermaticv1.CloudSpec {
+		spec := &kubermaticv1.AzureCloudSpec{
+			TenantID:       "tenant",
+			SubscriptionID: "subscription",
+			ClientID:       "client",
+			ClientSecret:   "secret",
+			ResourceGroup:  "rg",
+			VNetName:       "vnet",
+			SubnetName:     "subnet",
+			RouteTableName: "rt",
+			SecurityGroup:  "nsg",
+		}
+		if modify != nil {
+			modify(spec)
+		}
+		return kubermaticv1.CloudSpec{DatacenterName: "azure-dc", Azure: spec}
+	}
+
+	tests := []struct {
+		name    string
+		spec    kubermaticv1.ClusterSpec
+		wantErr bool
+	}{
+		{
+			name:    "no additional clouds",
+			spec:    kubermaticv1.ClusterSpec{Cloud: vsphereCloud},
+			wantErr: false,
+		},
+		{
+			name:    "azure cloud in addition to vsphere",
+			spec:    kubermaticv1.ClusterSpec{Cloud: vsphereCloud, AdditionalClouds: []kubermaticv1.CloudSpec{azureCloud(nil)}},
+			wantErr: false,
+		},
+		{
+			name:    "unknown datacenter",
+			spec:    kubermaticv1.ClusterSpec{Cloud: vsphereCloud, AdditionalClouds: []kubermaticv1.CloudSpec{{DatacenterName: "missing", Hetzner: &kubermaticv1.HetznerCloudSpec{Token: "token"}}}},
+			wantErr: true,
+		},
+		{
+			name:    "datacenter of the cluster",
+			spec:    kubermaticv1.ClusterSpec{Cloud: vsphereCloud, AdditionalClouds: []kubermaticv1.CloudSpec{vsphereCloud}},
+			wantErr: true,
+		},
+		{
+			name: "provider used twice",
+			spec: kubermaticv1.ClusterSpec{Cloud: vsphereCloud, AdditionalClouds: []kubermaticv1.CloudSpec{
+				{DatacenterName: "hetzner-dc", Hetzner: &kubermaticv1.HetznerCloudSpec{Token: "token"}},
+				{DatacenterName: "hetzner-dc", Hetzner: &kubermaticv1.HetznerCloudSpec{Token: "token"}},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "azure cloud without existing network",
+			spec: kubermaticv1.ClusterSpec{Cloud: vsphereCloud, AdditionalClouds: []kubermaticv1.CloudSpec{azureCloud(func(spec *kubermaticv1.AzureCloudSpec) {
+				spec.SubnetName = ""
+			})}},
+			wantErr: true,
+		},
+		{
+			name: "private cluster",
+			spec: kubermaticv1.ClusterSpec{
+				Cloud: azureCloud(func(spec *kubermaticv1.AzureCloudSpec) {
+					spec.PrivateCluster = true
+				}),
+				AdditionalClouds: []kubermaticv1.CloudSpec{vsphereCloud},
+			},
+			wantErr: true,
+		},
+		{
+			name: "azure network plugin",
+			spec: kubermaticv1.ClusterSpec{Cloud: vsphereCloud, AdditionalClouds: []kubermaticv1.CloudSpec{azureCloud(func(spec *kubermaticv1.AzureCloudSpec) {
+				spec.NetworkPlugin = kubermaticv1.AzureNetworkPluginAzure
+			})}},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateAdditionalClouds(&test.spec, datacenters)
+
+			if test.wantErr == (err == nil) {
+				t.Errorf("Want error: %t, but got: \"%v\"", test.wantErr, err)
+			}
+		})
+	}
+}
+
 func TestValidateClusterNetworkingConfig(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -335,6 +534,50 @@ func TestValidateClusterNetworkingConfig(t *testing.T) {
 			wantErr:    true,
 			allowEmpty: true,
 		},
+		{
+			name: "valid node CIDR mask size",
+			networkConfig: kubermaticv1.ClusterNetworkingConfig{
+				Pods:             kubermaticv1.NetworkRanges{CIDRBlocks: []string{"10.241.0.0/16"}},
+				NodeCIDRMaskSize: pointer.Int32Ptr(25),
+			},
+			wantErr:    false,
+			allowEmpty: true,
+		},
+		{
+			name: "node CIDR larger than pod CIDR",
+			networkConfig: kubermaticv1.ClusterNetworkingConfig{
+				Pods:             kubermaticv1.NetworkRanges{CIDRBlocks: []string{"10.241.0.0/16"}},
+				NodeCIDRMaskSize: pointer.Int32Ptr(15),
+			},
+			wantErr:    true,
+			allowEmpty: true,
+		},
+		{
+			name: "too many node CIDRs",
+			networkConfig: kubermaticv1.ClusterNetworkingConfig{
+				Pods:             kubermaticv1.NetworkRanges{CIDRBlocks: []string{"10.0.0.0/8"}},
+				NodeCIDRMaskSize: pointer.Int32Ptr(25),
+			},
+			wantErr:    true,
+			allowEmpty: true,
+		},
+		{
+			name: "default node CIDR mask size larger than pod CIDR",
+			networkConfig: kubermaticv1.ClusterNetworkingConfig{
+				Pods: kubermaticv1.NetworkRanges{CIDRBlocks: []string{"10.241.0.0/25"}},
+			},
+			wantErr:    true,
+			allowEmpty: true,
+		},
+		{
+			name: "node CIDR too small for the pods of a node",
+			networkConfig: kubermaticv1.ClusterNetworkingConfig{
+				Pods:             kubermaticv1.NetworkRanges{CIDRBlocks: []string{"10.241.0.0/16"}},
+				NodeCIDRMaskSize: pointer.Int32Ptr(26),
+			},
+			wantErr:    true,
+			allowEmpty: true,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -0,0 +1,58 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"k8c.io/kubermatic/v2/pkg/provider"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// presetCloudProviders are the cloud providers for which presets can hold credentials.
+var presetCloudProviders = sets.NewString(
+	provider.AlibabaCloudProvider,
+	provider.AnexiaCloudProvider,
+	provider.AWSCloudProvider,
+	provider.AzureCloudProvider,
+	provider.DigitaloceanCloudProvider,
+	provider.FakeCloudProvider,
+	provider.GCPCloudProvider,
+	provider.HetznerCloudProvider,
+	provider.KubevirtCloudProvider,
+	provider.OpenstackCloudProvider,
+	provider.PacketCloudProvider,
+	provider.VSphereCloudProvider,
+)
+
+// ValidateProjectDefaultPresets validates the default presets of a project, which map cloud
+// provider names to preset names.
+func ValidateProjectDefaultPresets(presets map[string]string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for providerName, presetName := range presets {
+		if !presetCloudProviders.Has(providerName) {
+			allErrs = append(allErrs, field.NotSupported(fldPath, providerName, presetCloudProviders.List()))
+			continue
+		}
+		if presetName == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Key(providerName), "preset name must not be empty"))
+		}
+	}
+
+	return allErrs
+}
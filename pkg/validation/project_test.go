@@ -0,0 +1,62 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestValidateProjectDefaultPresets(t *testing.T) {
+	tests := []struct {
+		name    string
+		presets map[string]string
+		valid   bool
+	}{
+		{
+			name:  "no default presets",
+			valid: true,
+		},
+		{
+			name:    "default presets for known providers",
+			presets: map[string]string{"azure": "azure-prod", "aws": "aws-prod"},
+			valid:   true,
+		},
+		{
+			name:    "unknown provider",
+			presets: map[string]string{"foo": "foo-prod"},
+		},
+		{
+			name:    "provider without credentials",
+			presets: map[string]string{"bringyourown": "byo"},
+		},
+		{
+			name:    "empty preset name",
+			presets: map[string]string{"azure": ""},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errs := ValidateProjectDefaultPresets(test.presets, field.NewPath("defaultPresets"))
+			if test.valid != (len(errs) == 0) {
+				t.Errorf("expected valid=%v, got errors: %v", test.valid, errs)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"crypto/x509"
+	"net/url"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidateClusterAdmissionWebhook validates the external webhook called before clusters are created.
+func ValidateClusterAdmissionWebhook(w *kubermaticv1.ClusterAdmissionWebhook, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if w == nil {
+		return allErrs
+	}
+
+	webhookURL, err := url.Parse(w.URL)
+	if err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("url"), w.URL, err.Error()))
+	} else {
+		if webhookURL.Scheme != "https" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("url"), w.URL, "scheme must be https"))
+		}
+		if webhookURL.Hostname() == "" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("url"), w.URL, "host must be specified"))
+		}
+	}
+
+	if w.CABundle != "" && !x509.NewCertPool().AppendCertsFromPEM([]byte(w.CABundle)) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("caBundle"), w.CABundle, "must contain at least one PEM encoded certificate"))
+	}
+
+	if w.TimeoutSeconds < 0 || w.TimeoutSeconds > 30 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("timeoutSeconds"), w.TimeoutSeconds, "must be between 1 and 30"))
+	}
+
+	switch w.FailurePolicy {
+	case "", kubermaticv1.ClusterAdmissionFailurePolicyFail, kubermaticv1.ClusterAdmissionFailurePolicyIgnore:
+	default:
+		supported := []string{string(kubermaticv1.ClusterAdmissionFailurePolicyFail), string(kubermaticv1.ClusterAdmissionFailurePolicyIgnore)}
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("failurePolicy"), w.FailurePolicy, supported))
+	}
+
+	return allErrs
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2021 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestValidateClusterAdmissionWebhook(t *testing.T) {
+	tests := []struct {
+		name    string
+		webhook *kubermaticv1.ClusterAdmissionWebhook
+		valid   bool
+	}{
+		{
+			name:  "no webhook",
+			valid: true,
+		},
+		{
+			name: "valid webhook",
+			webhook: &kubermaticv1.ClusterAdmissionWebhook{
+				URL:            "https://cmdb.example.com/admit",
+				TimeoutSeconds: 5,
+				FailurePolicy:  kubermaticv1.ClusterAdmissionFailurePolicyIgnore,
+			},
+			valid: true,
+		},
+		{
+			name:    "plain HTTP",
+			webhook: &kubermaticv1.ClusterAdmissionWebhook{URL: "http://cmdb.example.com/admit"},
+		},
+		{
+			name:    "missing host",
+			webhook: &kubermaticv1.ClusterAdmissionWebhook{URL: "https:///admit"},
+		},
+		{
+			name:    "invalid CA bundle",
+			webhook: &kubermaticv1.ClusterAdmissionWebhook{URL: "https://cmdb.example.com", CABundle: "not a certificate"},
+		},
+		{
+			name:    "timeout too long",
+			webhook: &kubermaticv1.ClusterAdmissionWebhook{URL: "https://cmdb.example.com", TimeoutSeconds: 60},
+		},
+		{
+			name:    "unknown failure policy",
+			webhook: &kubermaticv1.ClusterAdmissionWebhook{URL: "https://cmdb.example.com", FailurePolicy: "Retry"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errs := ValidateClusterAdmissionWebhook(test.webhook, field.NewPath("clusterAdmissionWebhook"))
+			if test.valid != (len(errs) == 0) {
+				t.Errorf("expected valid to be %v, got errors %v", test.valid, errs)
+			}
+		})
+	}
+}
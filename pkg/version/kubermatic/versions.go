@@ -41,6 +41,7 @@ type Versions struct {
 	UI                string
 	VPA               string
 	Envoy             string
+	RegistryCache     string
 	KubermaticEdition edition.Type
 }
 
@@ -51,6 +52,7 @@ func NewDefaultVersions() Versions {
 		UI:                uiDockerTag,
 		VPA:               "0.5.0",
 		Envoy:             "v1.17.1",
+		RegistryCache:     "2.7.1",
 		KubermaticEdition: edition.KubermaticEdition,
 	}
 }
@@ -62,6 +64,7 @@ func NewFakeVersions() Versions {
 		UI:                "v1.1.1-test",
 		VPA:               "0.5.0",
 		Envoy:             "v1.16.0",
+		RegistryCache:     "2.7.1",
 		KubermaticEdition: edition.KubermaticEdition,
 	}
 }
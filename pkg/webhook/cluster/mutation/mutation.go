@@ -140,6 +140,10 @@ func (h *AdmissionHandler) applyDefaults(c *kubermaticv1.Cluster) {
 		c.Spec.ClusterNetwork.NodeLocalDNSCacheEnabled = pointer.BoolPtr(true)
 	}
 
+	if c.Spec.ClusterNetwork.NodeCIDRMaskSize == nil {
+		c.Spec.ClusterNetwork.NodeCIDRMaskSize = pointer.Int32Ptr(resources.DefaultNodeCIDRMaskSize)
+	}
+
 	// Default component settings
 	if c.Spec.ComponentsOverride.Apiserver.Replicas == nil {
 		c.Spec.ComponentsOverride.Apiserver.Replicas = h.defaultComponentSettings.Apiserver.Replicas
@@ -175,9 +179,9 @@ func (h *AdmissionHandler) applyDefaults(c *kubermaticv1.Cluster) {
 
 func (h *AdmissionHandler) mutateUpdate(ctx context.Context, oldCluster, newCluster *kubermaticv1.Cluster) error {
 	// This part of the code handles the CCM/CSI migration. It currently works
-	// only for OpenStack clusters, in the following way:
+	// only for OpenStack and Azure clusters, in the following way:
 	//   * Add the CCM/CSI migration annotations
-	//   * Enable the UseOctaiva flag
+	//   * Enable the UseOctaiva flag (OpenStack only)
 	switch {
 	case newCluster.Spec.Cloud.Openstack != nil:
 		if v, oldV := newCluster.Spec.Features[kubermaticv1.ClusterFeatureExternalCloudProvider],
@@ -190,6 +194,17 @@ func (h *AdmissionHandler) mutateUpdate(ctx context.Context, oldCluster, newClus
 			newCluster.ObjectMeta.Annotations[kubermaticv1.CSIMigrationNeededAnnotation] = ""
 			newCluster.Spec.Cloud.Openstack.UseOctavia = pointer.BoolPtr(true)
 		}
+
+	case newCluster.Spec.Cloud.Azure != nil:
+		if v, oldV := newCluster.Spec.Features[kubermaticv1.ClusterFeatureExternalCloudProvider],
+			oldCluster.Spec.Features[kubermaticv1.ClusterFeatureExternalCloudProvider]; v && !oldV {
+			if newCluster.ObjectMeta.Annotations == nil {
+				newCluster.ObjectMeta.Annotations = map[string]string{}
+			}
+
+			newCluster.ObjectMeta.Annotations[kubermaticv1.CCMMigrationNeededAnnotation] = ""
+			newCluster.ObjectMeta.Annotations[kubermaticv1.CSIMigrationNeededAnnotation] = ""
+		}
 	}
 
 	return nil
@@ -151,6 +151,7 @@ func TestHandle(t *testing.T) {
 				jsonpatch.NewOperation("replace", "/spec/clusterNetwork/proxyMode", "ipvs"),
 				jsonpatch.NewOperation("replace", "/spec/clusterNetwork/dnsDomain", "cluster.local"),
 				jsonpatch.NewOperation("add", "/spec/clusterNetwork/nodeLocalDNSCacheEnabled", true),
+				jsonpatch.NewOperation("add", "/spec/clusterNetwork/nodeCIDRMaskSize", float64(24)),
 			},
 		},
 		{
@@ -183,6 +184,7 @@ func TestHandle(t *testing.T) {
 				jsonpatch.NewOperation("replace", "/spec/clusterNetwork/proxyMode", "ipvs"),
 				jsonpatch.NewOperation("replace", "/spec/clusterNetwork/dnsDomain", "cluster.local"),
 				jsonpatch.NewOperation("add", "/spec/clusterNetwork/nodeLocalDNSCacheEnabled", true),
+				jsonpatch.NewOperation("add", "/spec/clusterNetwork/nodeCIDRMaskSize", float64(24)),
 			},
 		},
 		{
@@ -250,6 +252,30 @@ func TestHandle(t *testing.T) {
 			wantAllowed: true,
 			wantPatches: []jsonpatch.JsonPatchOperation{},
 		},
+		{
+			name: "Update Azure cluster to enable the CCM/CSI migration",
+			req: webhook.AdmissionRequest{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Update,
+					RequestKind: &metav1.GroupVersionKind{
+						Group:   kubermaticv1.GroupName,
+						Version: kubermaticv1.GroupVersion,
+						Kind:    "Cluster",
+					},
+					Name: "foo",
+					Object: runtime.RawExtension{
+						Raw: rawClusterGen{Name: "foo", CloudSpec: kubermaticv1.CloudSpec{Azure: &kubermaticv1.AzureCloudSpec{}}, ExternalCloudProvider: true}.Do(),
+					},
+					OldObject: runtime.RawExtension{
+						Raw: rawClusterGen{Name: "foo", CloudSpec: kubermaticv1.CloudSpec{Azure: &kubermaticv1.AzureCloudSpec{}}, ExternalCloudProvider: false}.Do(),
+					},
+				},
+			},
+			wantAllowed: true,
+			wantPatches: []jsonpatch.JsonPatchOperation{
+				jsonpatch.NewOperation("add", "/metadata/annotations", map[string]interface{}{"ccm-migration.k8c.io/migration-needed": "", "csi-migration.k8c.io/migration-needed": ""}),
+			},
+		},
 		{
 			name: "Update non-OpenStack cluster to enable CCM/CSI migration",
 			req: webhook.AdmissionRequest{
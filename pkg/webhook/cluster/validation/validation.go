@@ -25,6 +25,7 @@ import (
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/features"
+	"k8c.io/kubermatic/v2/pkg/provider/cloud/azure"
 	"k8c.io/kubermatic/v2/pkg/validation"
 
 	admissionv1 "k8s.io/api/admission/v1"
@@ -107,6 +108,9 @@ func (h *AdmissionHandler) validateCreate(c *kubermaticv1.Cluster) field.ErrorLi
 		!h.features.Enabled(features.TunnelingExposeStrategy) {
 		allErrs = append(allErrs, field.Forbidden(specFldPath.Child("exposeStrategy"), "cannot create cluster with Tunneling expose strategy because the TunnelingExposeStrategy feature gate is not enabled"))
 	}
+	if c.Spec.Cloud.Azure != nil && c.Spec.Cloud.Azure.PrivateCluster && c.Spec.ExposeStrategy != kubermaticv1.ExposeStrategyLoadBalancer {
+		allErrs = append(allErrs, field.Forbidden(specFldPath.Child("cloud", "azure", "privateCluster"), "private clusters require the LoadBalancer expose strategy"))
+	}
 	if c.Spec.CNIPlugin != nil {
 		if !supportedCNIPlugins.Has(c.Spec.CNIPlugin.Type.String()) {
 			allErrs = append(allErrs, field.NotSupported(specFldPath.Child("cniPlugin", "type"), c.Spec.CNIPlugin.Type.String(), supportedCNIPlugins.List()))
@@ -117,6 +121,12 @@ func (h *AdmissionHandler) validateCreate(c *kubermaticv1.Cluster) field.ErrorLi
 	allErrs = append(allErrs, validation.ValidateLeaderElectionSettings(&c.Spec.ComponentsOverride.ControllerManager.LeaderElectionSettings, specFldPath.Child("componentsOverride", "controllerManager", "leaderElection"))...)
 	allErrs = append(allErrs, validation.ValidateLeaderElectionSettings(&c.Spec.ComponentsOverride.Scheduler.LeaderElectionSettings, specFldPath.Child("componentsOverride", "scheduler", "leaderElection"))...)
 	allErrs = append(allErrs, validation.ValidateClusterNetworkConfig(&c.Spec.ClusterNetwork, specFldPath.Child("clusterNetwork"), false)...)
+	// the network of clusters in existing Azure VNets is checked by the API, which has the credentials
+	if err := azure.ValidateNetworkOverlap(c.Spec.Cloud, c.Spec.ClusterNetwork); err != nil {
+		allErrs = append(allErrs, field.Forbidden(specFldPath.Child("clusterNetwork"), err.Error()))
+	}
+	allErrs = append(allErrs, validation.ValidateLogShippingSettings(c.Spec.LogShipping, specFldPath.Child("logShipping"))...)
+	allErrs = append(allErrs, validation.ValidatePatchUpgradePolicy(c.Spec.PatchUpgradePolicy, specFldPath.Child("patchUpgradePolicy"))...)
 
 	allErrs = append(allErrs, validation.ValidateNodePortRange(
 		c.Spec.ComponentsOverride.Apiserver.NodePortRange,
@@ -139,6 +149,8 @@ func (h *AdmissionHandler) validateUpdate(c, oldC *kubermaticv1.Cluster) field.E
 	allErrs = append(allErrs, validation.ValidateLeaderElectionSettings(&c.Spec.ComponentsOverride.ControllerManager.LeaderElectionSettings, specFldPath.Child("componentsOverride", "controllerManager", "leaderElection"))...)
 	allErrs = append(allErrs, validation.ValidateLeaderElectionSettings(&c.Spec.ComponentsOverride.Scheduler.LeaderElectionSettings, specFldPath.Child("componentsOverride", "scheduler", "leaderElection"))...)
 	allErrs = append(allErrs, validation.ValidateClusterNetworkConfig(&c.Spec.ClusterNetwork, specFldPath.Child("clusterNetwork"), false)...)
+	allErrs = append(allErrs, validation.ValidateLogShippingSettings(c.Spec.LogShipping, specFldPath.Child("logShipping"))...)
+	allErrs = append(allErrs, validation.ValidatePatchUpgradePolicy(c.Spec.PatchUpgradePolicy, specFldPath.Child("patchUpgradePolicy"))...)
 
 	allErrs = append(allErrs, validation.ValidateNodePortRange(
 		c.Spec.ComponentsOverride.Apiserver.NodePortRange,
@@ -182,6 +194,24 @@ func validateUpdateImmutability(c, oldC *kubermaticv1.Cluster) field.ErrorList {
 		specFldPath.Child("exposeStrategy"),
 	)...)
 
+	if c.Spec.Cloud.Azure != nil && oldC.Spec.Cloud.Azure != nil {
+		allErrs = append(allErrs, apimachineryvalidation.ValidateImmutableField(
+			c.Spec.Cloud.Azure.PrivateCluster,
+			oldC.Spec.Cloud.Azure.PrivateCluster,
+			specFldPath.Child("cloud", "azure", "privateCluster"),
+		)...)
+		allErrs = append(allErrs, apimachineryvalidation.ValidateImmutableField(
+			c.Spec.Cloud.Azure.EnablePrivateDNSZone,
+			oldC.Spec.Cloud.Azure.EnablePrivateDNSZone,
+			specFldPath.Child("cloud", "azure", "enablePrivateDNSZone"),
+		)...)
+		allErrs = append(allErrs, apimachineryvalidation.ValidateImmutableField(
+			c.Spec.Cloud.Azure.ClusterServicePrincipal,
+			oldC.Spec.Cloud.Azure.ClusterServicePrincipal,
+			specFldPath.Child("cloud", "azure", "clusterServicePrincipal"),
+		)...)
+	}
+
 	if oldC.Spec.EnableUserSSHKeyAgent != nil {
 		allErrs = append(allErrs, apimachineryvalidation.ValidateImmutableField(
 			c.Spec.EnableUserSSHKeyAgent,
@@ -241,6 +271,11 @@ func validateClusterNetworkingConfigUpdateImmutability(c, oldC *kubermaticv1.Clu
 		oldC.NodeLocalDNSCacheEnabled,
 		fldPath.Child("nodeLocalDNSCacheEnabled"),
 	)...)
+	allErrs = append(allErrs, apimachineryvalidation.ValidateImmutableField(
+		c.NodeCIDRMaskSize,
+		oldC.NodeCIDRMaskSize,
+		fldPath.Child("nodeCIDRMaskSize"),
+	)...)
 
 	return allErrs
 }
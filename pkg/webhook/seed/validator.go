@@ -20,11 +20,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
 	"sync"
 
+	autorestazure "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/docker/distribution/reference"
+
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/features"
 	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/provider/cloud/azure"
+	"k8c.io/kubermatic/v2/pkg/resources/machine"
 	"k8c.io/kubermatic/v2/pkg/util/workerlabel"
 
 	admissionv1 "k8s.io/api/admission/v1"
@@ -111,6 +117,22 @@ func (v *validator) validate(ctx context.Context, subject *kubermaticv1.Seed, se
 		return errors.New("cannot create seed using Tunneling as a default expose strategy, the TunnelingExposeStrategy feature gate is not enabled")
 	}
 
+	if err := validateRegistryCache(subject.Spec.RegistryCache); err != nil {
+		return err
+	}
+
+	if density := subject.Spec.ControlPlaneDensity; density != nil && density.MaxControlPlanesPerNode < 1 {
+		return errors.New("the maximum number of control planes per node must be at least 1")
+	}
+
+	if limits := subject.Spec.AzureRateLimits; limits != nil && (limits.QPS <= 0 || limits.Burst < 1) {
+		return errors.New("the Azure rate limits must allow at least one request per second and a burst of at least 1")
+	}
+
+	if err := validateAzureProxy(subject.Spec.AzureProxy); err != nil {
+		return err
+	}
+
 	// this can be nil on new seed clusters
 	existingSeed := existingSeeds[subject.Name]
 
@@ -148,6 +170,9 @@ func (v *validator) validate(ctx context.Context, subject *kubermaticv1.Seed, se
 		if providerName == "" {
 			return fmt.Errorf("datacenter %q has no provider defined", dcName)
 		}
+		if err := validateAzureDatacenter(dcName, dc.Spec.Azure); err != nil {
+			return fmt.Errorf("datacenter %q is invalid: %v", dcName, err)
+		}
 
 		if existingSeed == nil {
 			continue
@@ -204,3 +229,110 @@ func (e ensureSingleSeedValidatorWrapper) Validate(ctx context.Context, seed *ku
 		return nil
 	}
 }
+
+func validateRegistryCache(cache *kubermaticv1.RegistryCacheConfig) error {
+	if cache == nil {
+		return nil
+	}
+
+	if cache.Endpoint == "" {
+		return errors.New("the registry cache requires an endpoint")
+	}
+
+	if cache.TLSSecretName == "" {
+		return errors.New("the registry cache requires a TLS secret")
+	}
+
+	upstreams := sets.NewString()
+	ports := map[int32]string{}
+	for _, registry := range cache.Registries {
+		if registry.Upstream == "" {
+			return errors.New("every cached registry requires an upstream")
+		}
+		if upstreams.Has(registry.Upstream) {
+			return fmt.Errorf("registry %q is cached more than once", registry.Upstream)
+		}
+		upstreams.Insert(registry.Upstream)
+
+		if registry.Port < 1 || registry.Port > 65535-kubermaticv1.RegistryCacheMetricsPortOffset {
+			return fmt.Errorf("registry %q must use a port between 1 and %d", registry.Upstream, 65535-kubermaticv1.RegistryCacheMetricsPortOffset)
+		}
+		for _, port := range []int32{registry.Port, registry.Port + kubermaticv1.RegistryCacheMetricsPortOffset} {
+			if other, ok := ports[port]; ok {
+				return fmt.Errorf("registries %q and %q both use port %d", other, registry.Upstream, port)
+			}
+			ports[port] = registry.Upstream
+		}
+	}
+
+	for _, image := range cache.ExcludedImages {
+		named, err := reference.ParseNormalizedNamed(image)
+		if err != nil {
+			return fmt.Errorf("invalid excluded image %q: %v", image, err)
+		}
+		if !reference.IsNameOnly(named) {
+			return fmt.Errorf("excluded image %q must not contain a tag or digest", image)
+		}
+	}
+
+	return nil
+}
+
+// validateAzureDatacenter checks the Azure cloud, the static limits of the availability set
+// settings, the node defaults, the cleanup settings and the naming template, the maximum number
+// of fault domains of the region is only known to the Azure API.
+func validateAzureDatacenter(name string, spec *kubermaticv1.DatacenterSpecAzure) error {
+	if spec == nil {
+		return nil
+	}
+	if spec.Environment != "" {
+		if _, err := autorestazure.EnvironmentFromName(spec.Environment); err != nil {
+			return fmt.Errorf("unknown Azure environment %q", spec.Environment)
+		}
+	}
+	if spec.FaultDomainCount != nil && (*spec.FaultDomainCount < 1 || *spec.FaultDomainCount > 3) {
+		return fmt.Errorf("the fault domain count must be between 1 and 3, got %d", *spec.FaultDomainCount)
+	}
+	if spec.UpdateDomainCount != nil && (*spec.UpdateDomainCount < 1 || *spec.UpdateDomainCount > 20) {
+		return fmt.Errorf("the update domain count must be between 1 and 20, got %d", *spec.UpdateDomainCount)
+	}
+	if err := machine.ValidateAzureNodeDefaults(spec.NodeDefaults); err != nil {
+		return fmt.Errorf("invalid node defaults: %v", err)
+	}
+	if cleanup := spec.Cleanup; cleanup != nil {
+		if cleanup.StepTimeout != nil && cleanup.StepTimeout.Duration < 0 {
+			return fmt.Errorf("the cleanup step timeout must not be negative, got %v", cleanup.StepTimeout.Duration)
+		}
+		if cleanup.MaxAttempts < 0 {
+			return fmt.Errorf("the maximum number of cleanup attempts must not be negative, got %d", cleanup.MaxAttempts)
+		}
+	}
+	if err := azure.ValidateNamingTemplate(spec.NamingTemplate, name); err != nil {
+		return fmt.Errorf("invalid naming template: %v", err)
+	}
+	return nil
+}
+
+func validateAzureProxy(proxy *kubermaticv1.AzureProxySettings) error {
+	if proxy == nil {
+		return nil
+	}
+
+	proxyURL, err := url.Parse(proxy.URL)
+	if err != nil {
+		return fmt.Errorf("invalid Azure proxy URL: %v", err)
+	}
+	if (proxyURL.Scheme != "http" && proxyURL.Scheme != "https") || proxyURL.Host == "" {
+		return fmt.Errorf("the Azure proxy URL %q must be an absolute http or https URL", proxy.URL)
+	}
+	// credentials belong into a Secret, not into the Seed
+	if proxyURL.User != nil {
+		return errors.New("the Azure proxy URL must not contain credentials, use the credentials reference instead")
+	}
+
+	if ref := proxy.CredentialsReference; ref != nil && (ref.Name == "" || ref.Namespace == "") {
+		return errors.New("the Azure proxy credentials reference requires a name and a namespace")
+	}
+
+	return nil
+}